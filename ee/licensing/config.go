@@ -14,9 +14,9 @@ var (
 )
 
 // initializes the licensing configuration
-func Config(pollInterval time.Duration, failureThreshold int) licensing.Config {
+func Config(pollInterval time.Duration, failureThreshold int, featuresRefreshInterval time.Duration, circuitBreaker licensing.CircuitBreakerConfig) licensing.Config {
 	once.Do(func() {
-		config = licensing.Config{PollInterval: pollInterval, FailureThreshold: failureThreshold}
+		config = licensing.Config{PollInterval: pollInterval, FailureThreshold: failureThreshold, FeaturesRefreshInterval: featuresRefreshInterval, CircuitBreaker: circuitBreaker}
 		if err := config.Validate(); err != nil {
 			panic(fmt.Errorf("invalid licensing config: %w", err))
 		}
@@ -0,0 +1,208 @@
+package httplicensing
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/licensing"
+	"github.com/SigNoz/signoz/pkg/zeus"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+var (
+	ErrCodeCircuitOpen = errors.MustNewCode("zeus_circuit_open")
+)
+
+type breakerState string
+
+const (
+	breakerStateClosed   breakerState = "closed"
+	breakerStateOpen     breakerState = "open"
+	breakerStateHalfOpen breakerState = "half_open"
+)
+
+// circuitBreaker stops calling zeus once it keeps failing so that its incidents do not slow down the requests
+// which depend on the license. The breaker opens after a number of consecutive failures, short-circuits every call
+// while open and lets a single call through once the open timeout is over to probe the recovery of zeus.
+type circuitBreaker struct {
+	config      licensing.CircuitBreakerConfig
+	transitions metric.Int64Counter
+	now         func() time.Time
+
+	mu       sync.Mutex
+	state    breakerState
+	failures int
+	openedAt time.Time
+	probing  bool
+}
+
+func newCircuitBreaker(config licensing.CircuitBreakerConfig, meter metric.Meter) (*circuitBreaker, error) {
+	transitions, err := meter.Int64Counter("signoz.licensing.zeus.circuit_breaker.transitions", metric.WithDescription("Number of transitions of the circuit breaker around the calls to zeus, by the state transitioned to."))
+	if err != nil {
+		return nil, err
+	}
+
+	return &circuitBreaker{
+		config:      config,
+		transitions: transitions,
+		now:         time.Now,
+		state:       breakerStateClosed,
+	}, nil
+}
+
+// do calls fn unless the breaker is open.
+func (breaker *circuitBreaker) do(ctx context.Context, fn func() error) error {
+	if breaker.config.FailureThreshold <= 0 {
+		return fn()
+	}
+
+	probe, err := breaker.allow(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = fn()
+	breaker.record(ctx, probe, err)
+	return err
+}
+
+func (breaker *circuitBreaker) allow(ctx context.Context) (bool, error) {
+	breaker.mu.Lock()
+	defer breaker.mu.Unlock()
+
+	if breaker.state == breakerStateOpen && breaker.now().Sub(breaker.openedAt) >= breaker.config.OpenTimeout {
+		breaker.transition(ctx, breakerStateHalfOpen)
+	}
+
+	switch breaker.state {
+	case breakerStateOpen:
+		return false, errors.Newf(errors.TypeInternal, ErrCodeCircuitOpen, "calls to zeus are short-circuited since %s after %d consecutive failures", breaker.openedAt.Format(time.RFC3339), breaker.config.FailureThreshold)
+	case breakerStateHalfOpen:
+		// Only one call probes zeus, the others are short-circuited until it is done.
+		if breaker.probing {
+			return false, errors.New(errors.TypeInternal, ErrCodeCircuitOpen, "calls to zeus are short-circuited while its recovery is probed")
+		}
+
+		breaker.probing = true
+		return true, nil
+	default:
+		return false, nil
+	}
+}
+
+func (breaker *circuitBreaker) record(ctx context.Context, probe bool, err error) {
+	breaker.mu.Lock()
+	defer breaker.mu.Unlock()
+
+	if probe {
+		breaker.probing = false
+	}
+
+	// A call canceled by its caller says nothing about zeus. A probe which is canceled is attempted again by the
+	// next call.
+	if errors.Is(err, context.Canceled) {
+		return
+	}
+
+	if !isZeusFailure(err) {
+		breaker.failures = 0
+		if breaker.state != breakerStateClosed {
+			breaker.transition(ctx, breakerStateClosed)
+		}
+		return
+	}
+
+	breaker.failures++
+	if probe || (breaker.state == breakerStateClosed && breaker.failures >= breaker.config.FailureThreshold) {
+		breaker.openedAt = breaker.now()
+		breaker.transition(ctx, breakerStateOpen)
+	}
+}
+
+// transition must be called with the lock held.
+func (breaker *circuitBreaker) transition(ctx context.Context, state breakerState) {
+	breaker.state = state
+	breaker.transitions.Add(ctx, 1, metric.WithAttributes(attribute.String("state", string(state))))
+}
+
+// isZeusFailure returns whether the error means that zeus is unavailable. The errors about the request itself,
+// such as an unknown license key, are answers of a healthy zeus.
+func isZeusFailure(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	return !errors.Ast(err, errors.TypeInvalidInput) &&
+		!errors.Ast(err, errors.TypeUnauthenticated) &&
+		!errors.Ast(err, errors.TypeForbidden) &&
+		!errors.Ast(err, errors.TypeNotFound)
+}
+
+// breakerZeus calls zeus through a circuit breaker.
+type breakerZeus struct {
+	zeus    zeus.Zeus
+	breaker *circuitBreaker
+}
+
+var _ zeus.Zeus = (*breakerZeus)(nil)
+
+func (z *breakerZeus) GetLicense(ctx context.Context, key string) ([]byte, error) {
+	var data []byte
+	err := z.breaker.do(ctx, func() (err error) {
+		data, err = z.zeus.GetLicense(ctx, key)
+		return err
+	})
+
+	return data, err
+}
+
+func (z *breakerZeus) GetCheckoutURL(ctx context.Context, key string, body []byte) ([]byte, error) {
+	var data []byte
+	err := z.breaker.do(ctx, func() (err error) {
+		data, err = z.zeus.GetCheckoutURL(ctx, key, body)
+		return err
+	})
+
+	return data, err
+}
+
+func (z *breakerZeus) GetPortalURL(ctx context.Context, key string, body []byte) ([]byte, error) {
+	var data []byte
+	err := z.breaker.do(ctx, func() (err error) {
+		data, err = z.zeus.GetPortalURL(ctx, key, body)
+		return err
+	})
+
+	return data, err
+}
+
+func (z *breakerZeus) GetDeployment(ctx context.Context, key string) ([]byte, error) {
+	var data []byte
+	err := z.breaker.do(ctx, func() (err error) {
+		data, err = z.zeus.GetDeployment(ctx, key)
+		return err
+	})
+
+	return data, err
+}
+
+func (z *breakerZeus) PutMeters(ctx context.Context, key string, body []byte) error {
+	return z.breaker.do(ctx, func() error {
+		return z.zeus.PutMeters(ctx, key, body)
+	})
+}
+
+func (z *breakerZeus) PutProfile(ctx context.Context, key string, body []byte) error {
+	return z.breaker.do(ctx, func() error {
+		return z.zeus.PutProfile(ctx, key, body)
+	})
+}
+
+func (z *breakerZeus) PutHost(ctx context.Context, key string, body []byte) error {
+	return z.breaker.do(ctx, func() error {
+		return z.zeus.PutHost(ctx, key, body)
+	})
+}
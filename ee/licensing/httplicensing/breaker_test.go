@@ -0,0 +1,91 @@
+package httplicensing
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/licensing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/metric/noop"
+)
+
+func newTestCircuitBreaker(t *testing.T, failureThreshold int) (*circuitBreaker, *time.Time) {
+	breaker, err := newCircuitBreaker(licensing.CircuitBreakerConfig{FailureThreshold: failureThreshold, OpenTimeout: time.Minute}, noop.NewMeterProvider().Meter(""))
+	require.NoError(t, err)
+
+	now := time.Now()
+	breaker.now = func() time.Time { return now }
+	return breaker, &now
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	ctx := context.Background()
+	breaker, now := newTestCircuitBreaker(t, 2)
+	unavailable := errors.New(errors.TypeInternal, errors.CodeInternal, "internal")
+
+	calls := 0
+	fail := func() error { calls++; return unavailable }
+	succeed := func() error { calls++; return nil }
+
+	// The breaker opens after the consecutive failures.
+	assert.Equal(t, unavailable, breaker.do(ctx, fail))
+	assert.Equal(t, breakerStateClosed, breaker.state)
+	assert.Equal(t, unavailable, breaker.do(ctx, fail))
+	assert.Equal(t, breakerStateOpen, breaker.state)
+
+	// The calls are short-circuited while the breaker is open.
+	err := breaker.do(ctx, succeed)
+	assert.True(t, errors.Asc(err, ErrCodeCircuitOpen))
+	assert.Equal(t, 2, calls)
+
+	// A failed probe opens the breaker again.
+	*now = now.Add(time.Minute)
+	assert.Equal(t, unavailable, breaker.do(ctx, fail))
+	assert.Equal(t, breakerStateOpen, breaker.state)
+	assert.True(t, errors.Asc(breaker.do(ctx, succeed), ErrCodeCircuitOpen))
+
+	// A successful probe closes it.
+	*now = now.Add(time.Minute)
+	assert.NoError(t, breaker.do(ctx, succeed))
+	assert.Equal(t, breakerStateClosed, breaker.state)
+	assert.Equal(t, 0, breaker.failures)
+	assert.Equal(t, 4, calls)
+}
+
+func TestCircuitBreakerHalfOpenSingleProbe(t *testing.T) {
+	ctx := context.Background()
+	breaker, now := newTestCircuitBreaker(t, 1)
+	require.Error(t, breaker.do(ctx, func() error { return errors.New(errors.TypeInternal, errors.CodeInternal, "internal") }))
+
+	*now = now.Add(time.Minute)
+	err := breaker.do(ctx, func() error {
+		// The other calls are short-circuited while the probe is in flight.
+		assert.True(t, errors.Asc(breaker.do(ctx, func() error { return nil }), ErrCodeCircuitOpen))
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, breakerStateClosed, breaker.state)
+}
+
+func TestCircuitBreakerIgnoresRequestErrors(t *testing.T) {
+	ctx := context.Background()
+	breaker, _ := newTestCircuitBreaker(t, 1)
+
+	// Unknown license keys and canceled calls do not mean that zeus is unavailable.
+	assert.Error(t, breaker.do(ctx, func() error { return errors.New(errors.TypeNotFound, errors.CodeNotFound, "not found") }))
+	assert.Error(t, breaker.do(ctx, func() error { return context.Canceled }))
+	assert.Equal(t, breakerStateClosed, breaker.state)
+}
+
+func TestCircuitBreakerDisabled(t *testing.T) {
+	ctx := context.Background()
+	breaker, _ := newTestCircuitBreaker(t, 0)
+
+	for i := 0; i < 10; i++ {
+		assert.Error(t, breaker.do(ctx, func() error { return errors.New(errors.TypeInternal, errors.CodeInternal, "internal") }))
+	}
+	assert.Equal(t, breakerStateClosed, breaker.state)
+}
@@ -0,0 +1,87 @@
+package httplicensing
+
+import (
+	"maps"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/types/licensetypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+)
+
+type featuresSnapshot struct {
+	features    []*licensetypes.Feature
+	refreshedAt time.Time
+}
+
+// featuresCache holds the last known good snapshot of the feature flags for every org. The snapshots are read on
+// the path of every request checking a feature, hence the reads are lock free: the snapshots are held in an immutable
+// map which is copied and swapped on every write.
+type featuresCache struct {
+	snapshots atomic.Pointer[map[valuer.UUID]featuresSnapshot]
+	// mu serializes the writes of the snapshots.
+	mu sync.Mutex
+	// refreshing holds the orgs whose snapshot is being refreshed in the background, so that a single refresh runs
+	// per org at a time.
+	refreshing sync.Map
+}
+
+func newFeaturesCache() *featuresCache {
+	cache := &featuresCache{}
+	cache.snapshots.Store(&map[valuer.UUID]featuresSnapshot{})
+	return cache
+}
+
+// get returns the snapshot of the org. Its features are shared with the other readers and must not be mutated.
+func (cache *featuresCache) get(orgID valuer.UUID) (featuresSnapshot, bool) {
+	snapshot, ok := (*cache.snapshots.Load())[orgID]
+	return snapshot, ok
+}
+
+// set stores a copy of the features as the snapshot of the org, refreshed at the given time.
+func (cache *featuresCache) set(orgID valuer.UUID, features []*licensetypes.Feature, refreshedAt time.Time) {
+	features = licensetypes.CloneFeatures(features)
+	cache.update(func(snapshots map[valuer.UUID]featuresSnapshot) {
+		snapshots[orgID] = featuresSnapshot{features: features, refreshedAt: refreshedAt}
+	})
+}
+
+// startRefresh returns false if a refresh of the snapshot of the org is already running. Otherwise the caller must
+// call finishRefresh once done.
+func (cache *featuresCache) startRefresh(orgID valuer.UUID) bool {
+	_, running := cache.refreshing.LoadOrStore(orgID, struct{}{})
+	return !running
+}
+
+func (cache *featuresCache) finishRefresh(orgID valuer.UUID) {
+	cache.refreshing.Delete(orgID)
+}
+
+// invalidate marks the snapshot of the org as stale. The snapshot is kept so that it can be served if the next
+// refresh fails.
+func (cache *featuresCache) invalidate(orgID valuer.UUID) {
+	cache.update(func(snapshots map[valuer.UUID]featuresSnapshot) {
+		snapshot, ok := snapshots[orgID]
+		if !ok {
+			return
+		}
+
+		snapshot.refreshedAt = time.Time{}
+		snapshots[orgID] = snapshot
+	})
+}
+
+// update applies fn to a copy of the snapshots and swaps it in.
+func (cache *featuresCache) update(fn func(map[valuer.UUID]featuresSnapshot)) {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	snapshots := maps.Clone(*cache.snapshots.Load())
+	fn(snapshots)
+	cache.snapshots.Store(&snapshots)
+}
+
+func (snapshot featuresSnapshot) isFresh(interval time.Duration) bool {
+	return !snapshot.refreshedAt.IsZero() && time.Since(snapshot.refreshedAt) < interval
+}
@@ -0,0 +1,85 @@
+package httplicensing
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/types/licensetypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFeaturesCache(t *testing.T) {
+	cache := newFeaturesCache()
+	orgID := valuer.GenerateUUID()
+
+	_, ok := cache.get(orgID)
+	assert.False(t, ok)
+
+	cache.set(orgID, licensetypes.BasicPlan, time.Now())
+	snapshot, ok := cache.get(orgID)
+	assert.True(t, ok)
+	assert.True(t, snapshot.isFresh(time.Minute))
+	assert.Equal(t, licensetypes.BasicPlan, snapshot.features)
+
+	cache.invalidate(orgID)
+	snapshot, ok = cache.get(orgID)
+	assert.True(t, ok)
+	assert.False(t, snapshot.isFresh(time.Minute))
+	assert.Equal(t, licensetypes.BasicPlan, snapshot.features)
+}
+
+func TestFeaturesCacheConcurrentReads(t *testing.T) {
+	cache := newFeaturesCache()
+	orgIDs := []valuer.UUID{valuer.GenerateUUID(), valuer.GenerateUUID()}
+
+	var wg sync.WaitGroup
+	for _, orgID := range orgIDs {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for range 100 {
+				cache.set(orgID, licensetypes.BasicPlan, time.Now())
+				cache.invalidate(orgID)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for range 100 {
+				if snapshot, ok := cache.get(orgID); ok {
+					assert.Equal(t, licensetypes.BasicPlan, snapshot.features)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	// The writes of an org do not drop the snapshots of the other orgs.
+	for _, orgID := range orgIDs {
+		_, ok := cache.get(orgID)
+		assert.True(t, ok)
+	}
+}
+
+func TestFeaturesCacheStale(t *testing.T) {
+	cache := newFeaturesCache()
+	orgID := valuer.GenerateUUID()
+
+	cache.set(orgID, licensetypes.BasicPlan, time.Time{})
+	snapshot, ok := cache.get(orgID)
+	assert.True(t, ok)
+	assert.False(t, snapshot.isFresh(time.Minute))
+}
+
+func TestFeaturesCacheRefresh(t *testing.T) {
+	cache := newFeaturesCache()
+	orgID := valuer.GenerateUUID()
+
+	assert.True(t, cache.startRefresh(orgID))
+	assert.False(t, cache.startRefresh(orgID))
+	assert.True(t, cache.startRefresh(valuer.GenerateUUID()))
+
+	cache.finishRefresh(orgID)
+	assert.True(t, cache.startRefresh(orgID))
+}
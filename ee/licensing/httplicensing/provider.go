@@ -11,10 +11,18 @@ import (
 	"github.com/SigNoz/signoz/pkg/licensing"
 	"github.com/SigNoz/signoz/pkg/modules/organization"
 	"github.com/SigNoz/signoz/pkg/sqlstore"
+	"github.com/SigNoz/signoz/pkg/types/authtypes"
 	"github.com/SigNoz/signoz/pkg/types/licensetypes"
 	"github.com/SigNoz/signoz/pkg/valuer"
 	"github.com/SigNoz/signoz/pkg/zeus"
 	"github.com/tidwall/gjson"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+const (
+	// featuresRefreshTimeout bounds the background refreshes of the feature flags.
+	featuresRefreshTimeout = 30 * time.Second
 )
 
 type provider struct {
@@ -23,7 +31,12 @@ type provider struct {
 	config    licensing.Config
 	settings  factory.ScopedProviderSettings
 	orgGetter organization.Getter
-	stopChan  chan struct{}
+	features  *featuresCache
+	// featuresRefreshFailures counts the number of times the feature flags could not be refreshed.
+	featuresRefreshFailures metric.Int64Counter
+	// featuresDuration measures the latency of the feature flag checks, by whether they were served from the cache.
+	featuresDuration metric.Float64Histogram
+	stopChan         chan struct{}
 }
 
 func NewProviderFactory(store sqlstore.SQLStore, zeus zeus.Zeus, orgGetter organization.Getter) factory.ProviderFactory[licensing.Licensing, licensing.Config] {
@@ -35,13 +48,32 @@ func NewProviderFactory(store sqlstore.SQLStore, zeus zeus.Zeus, orgGetter organ
 func New(ctx context.Context, ps factory.ProviderSettings, config licensing.Config, sqlstore sqlstore.SQLStore, zeus zeus.Zeus, orgGetter organization.Getter) (licensing.Licensing, error) {
 	settings := factory.NewScopedProviderSettings(ps, "github.com/SigNoz/signoz/ee/licensing/httplicensing")
 	licensestore := sqllicensingstore.New(sqlstore)
+
+	featuresRefreshFailures, err := settings.Meter().Int64Counter("signoz.licensing.features.refresh.failures", metric.WithDescription("Number of failures while refreshing the feature flags of the license."))
+	if err != nil {
+		return nil, err
+	}
+
+	featuresDuration, err := settings.Meter().Float64Histogram("signoz.licensing.features.duration", metric.WithDescription("Duration of the checks of the feature flags of the license."), metric.WithUnit("s"))
+	if err != nil {
+		return nil, err
+	}
+
+	breaker, err := newCircuitBreaker(config.CircuitBreaker, settings.Meter())
+	if err != nil {
+		return nil, err
+	}
+
 	return &provider{
-		store:     licensestore,
-		zeus:      zeus,
-		config:    config,
-		settings:  settings,
-		orgGetter: orgGetter,
-		stopChan:  make(chan struct{}),
+		store:                   licensestore,
+		zeus:                    &breakerZeus{zeus: zeus, breaker: breaker},
+		config:                  config,
+		settings:                settings,
+		orgGetter:               orgGetter,
+		features:                newFeaturesCache(),
+		featuresRefreshFailures: featuresRefreshFailures,
+		featuresDuration:        featuresDuration,
+		stopChan:                make(chan struct{}),
 	}, nil
 }
 
@@ -100,12 +132,19 @@ func (provider *provider) Activate(ctx context.Context, organizationID valuer.UU
 		return errors.Wrapf(err, errors.TypeInternal, errors.CodeInternal, "failed to create license entity")
 	}
 
+	previousLicense, err := provider.GetActive(ctx, organizationID)
+	if err != nil && !errors.Ast(err, errors.TypeNotFound) {
+		return err
+	}
+
 	storableLicense := licensetypes.NewStorableLicenseFromLicense(license)
 	err = provider.store.Create(ctx, storableLicense)
 	if err != nil {
 		return err
 	}
 
+	provider.features.invalidate(organizationID)
+	provider.audit(ctx, licensetypes.AuditEventTypeApplied, organizationID, previousLicense, license)
 	return nil
 }
 
@@ -133,6 +172,9 @@ func (provider *provider) Refresh(ctx context.Context, organizationID valuer.UUI
 		return err
 	}
 
+	// Update mutates the license in place, keep a copy of the previous state for the audit event.
+	previousLicense := *activeLicense
+
 	data, err := provider.zeus.GetLicense(ctx, activeLicense.Key)
 	if err != nil {
 		if time.Since(activeLicense.LastValidatedAt) > time.Duration(provider.config.FailureThreshold)*provider.config.PollInterval {
@@ -143,8 +185,19 @@ func (provider *provider) Refresh(ctx context.Context, organizationID valuer.UUI
 				return err
 			}
 
+			provider.features.invalidate(organizationID)
+			if licensetypes.Changed(&previousLicense, activeLicense) {
+				provider.audit(ctx, licensetypes.AuditEventTypeExpired, organizationID, &previousLicense, activeLicense)
+			}
+			return nil
+		}
+
+		// The license stored from the last successful refresh is used until zeus recovers.
+		if errors.Asc(err, ErrCodeCircuitOpen) {
+			provider.settings.Logger().DebugContext(ctx, "zeus is short-circuited, keeping the stored license", "org_id", organizationID.StringValue(), "last_validated_at", activeLicense.LastValidatedAt)
 			return nil
 		}
+
 		return err
 	}
 
@@ -159,6 +212,18 @@ func (provider *provider) Refresh(ctx context.Context, organizationID valuer.UUI
 		return err
 	}
 
+	provider.features.invalidate(organizationID)
+
+	// Most refreshes return the license as it was, only the changes are audited.
+	if !licensetypes.Changed(&previousLicense, activeLicense) {
+		return nil
+	}
+
+	eventType := licensetypes.AuditEventTypeRefreshed
+	if activeLicense.ValidUntil != -1 && activeLicense.ValidUntil <= time.Now().Unix() {
+		eventType = licensetypes.AuditEventTypeExpired
+	}
+	provider.audit(ctx, eventType, organizationID, &previousLicense, activeLicense)
 	return nil
 }
 
@@ -204,12 +269,113 @@ func (provider *provider) GetFeatureFlags(ctx context.Context, organizationID va
 	license, err := provider.GetActive(ctx, organizationID)
 	if err != nil {
 		if errors.Ast(err, errors.TypeNotFound) {
-			return licensetypes.BasicPlan, nil
+			return licensetypes.CloneFeatures(licensetypes.BasicPlan), nil
+		}
+		return nil, err
+	}
+
+	return licensetypes.CloneFeatures(license.Features), nil
+}
+
+// Features returns a copy of the snapshot of the feature flags of the org. A stale snapshot is served while it is
+// refreshed in the background, so that the checks of the features never wait on zeus. An org without a snapshot is
+// served the features of its stored license.
+func (provider *provider) Features(ctx context.Context, organizationID valuer.UUID) ([]*licensetypes.Feature, error) {
+	start := time.Now()
+	if snapshot, ok := provider.features.get(organizationID); ok {
+		if !snapshot.isFresh(provider.config.FeaturesRefreshInterval) {
+			provider.refreshFeaturesInBackground(ctx, organizationID)
+		}
+
+		provider.featuresDuration.Record(ctx, time.Since(start).Seconds(), metric.WithAttributes(attribute.Bool("cached", true)))
+		return licensetypes.CloneFeatures(snapshot.features), nil
+	}
+	defer func() {
+		provider.featuresDuration.Record(ctx, time.Since(start).Seconds(), metric.WithAttributes(attribute.Bool("cached", false)))
+	}()
+
+	features, err := provider.GetFeatureFlags(ctx, organizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	// The stored license has not been refreshed from zeus by this replica yet, the snapshot is stored as stale.
+	provider.features.set(organizationID, features, time.Time{})
+	provider.refreshFeaturesInBackground(ctx, organizationID)
+
+	return licensetypes.CloneFeatures(features), nil
+}
+
+// refreshFeaturesInBackground refreshes the license of the org from zeus and then its snapshot, unless a refresh of
+// the org is already running. The last known good snapshot is kept if the refresh fails.
+func (provider *provider) refreshFeaturesInBackground(ctx context.Context, organizationID valuer.UUID) {
+	if !provider.features.startRefresh(organizationID) {
+		return
+	}
+
+	go func() {
+		defer provider.features.finishRefresh(organizationID)
+
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), featuresRefreshTimeout)
+		defer cancel()
+
+		features, err := provider.refreshFeatures(ctx, organizationID)
+		if err != nil {
+			provider.featuresRefreshFailures.Add(ctx, 1)
+			provider.settings.Logger().WarnContext(ctx, "failed to refresh feature flags, using last known good snapshot", "org_id", organizationID.StringValue(), "error", err)
+			return
 		}
+
+		provider.features.set(organizationID, features, time.Now())
+	}()
+}
+
+func (provider *provider) refreshFeatures(ctx context.Context, organizationID valuer.UUID) ([]*licensetypes.Feature, error) {
+	if err := provider.Refresh(ctx, organizationID); err != nil {
 		return nil, err
 	}
 
-	return license.Features, nil
+	return provider.GetFeatureFlags(ctx, organizationID)
+}
+
+func (provider *provider) ListAuditEvents(ctx context.Context, organizationID valuer.UUID, limit int, offset int) ([]*licensetypes.GettableAuditEvent, error) {
+	if limit <= 0 || limit > licensetypes.MaxAuditEventsLimit {
+		return nil, errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "limit must be between 1 and %d", licensetypes.MaxAuditEventsLimit)
+	}
+
+	if offset < 0 {
+		return nil, errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "offset must not be negative")
+	}
+
+	return provider.store.ListAuditEvents(ctx, organizationID, limit, offset)
+}
+
+// audit emits and persists an audit event for a change of the license in org. Failing to persist the event does not
+// fail the change, the event is still emitted through the logger.
+func (provider *provider) audit(ctx context.Context, eventType licensetypes.AuditEventType, organizationID valuer.UUID, previous *licensetypes.License, current *licensetypes.License) {
+	actor := licensetypes.AuditActorSystem
+	if claims, err := authtypes.ClaimsFromContext(ctx); err == nil {
+		actor = claims.Email
+	}
+
+	event := licensetypes.NewAuditEvent(eventType, actor, organizationID, previous, current)
+
+	provider.settings.Logger().InfoContext(
+		ctx,
+		"license audit event",
+		"audit.type", event.Type.StringValue(),
+		"audit.actor", event.Actor,
+		"audit.old_license_id", event.OldLicenseID,
+		"audit.new_license_id", event.NewLicenseID,
+		"audit.added_features", event.AddedFeatures,
+		"audit.removed_features", event.RemovedFeatures,
+		"audit.timestamp", event.CreatedAt,
+		"org_id", organizationID.StringValue(),
+	)
+
+	if err := provider.store.CreateAuditEvent(ctx, event); err != nil {
+		provider.settings.Logger().ErrorContext(ctx, "failed to persist license audit event", "org_id", organizationID.StringValue(), "error", err)
+	}
 }
 
 func (provider *provider) Collect(ctx context.Context, orgID valuer.UUID) (map[string]any, error) {
@@ -79,3 +79,36 @@ func (store *store) Update(ctx context.Context, organizationID valuer.UUID, stor
 
 	return nil
 }
+
+func (store *store) CreateAuditEvent(ctx context.Context, storableAuditEvent *licensetypes.StorableAuditEvent) error {
+	_, err := store.
+		sqlstore.
+		BunDB().
+		NewInsert().
+		Model(storableAuditEvent).
+		Exec(ctx)
+	if err != nil {
+		return errors.Wrapf(err, errors.TypeInternal, errors.CodeInternal, "unable to create license audit event with ID: %s", storableAuditEvent.ID)
+	}
+
+	return nil
+}
+
+func (store *store) ListAuditEvents(ctx context.Context, organizationID valuer.UUID, limit int, offset int) ([]*licensetypes.StorableAuditEvent, error) {
+	storableAuditEvents := make([]*licensetypes.StorableAuditEvent, 0)
+	err := store.
+		sqlstore.
+		BunDB().
+		NewSelect().
+		Model(&storableAuditEvents).
+		Where("org_id = ?", organizationID).
+		Order("created_at DESC").
+		Limit(limit).
+		Offset(offset).
+		Scan(ctx)
+	if err != nil {
+		return nil, errors.Wrapf(err, errors.TypeInternal, errors.CodeInternal, "unable to list license audit events for organizationID: %s", organizationID)
+	}
+
+	return storableAuditEvents, nil
+}
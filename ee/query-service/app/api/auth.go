@@ -6,11 +6,14 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"time"
 
 	"go.uber.org/zap"
 
 	"github.com/SigNoz/signoz/pkg/query-service/constants"
+	"github.com/SigNoz/signoz/pkg/types/ssotypes"
 	"github.com/SigNoz/signoz/pkg/valuer"
+	saml2types "github.com/russellhaering/gosaml2/types"
 )
 
 func handleSsoError(w http.ResponseWriter, r *http.Request, redirectURL string) {
@@ -83,8 +86,9 @@ func (ah *APIHandler) receiveSAML(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if assertionInfo.WarningInfo.InvalidTime {
-		zap.L().Error("[receiveSAML] expired saml response", zap.String("domain", domain.String()), zap.Error(err))
+	// the validity window is checked by consumeSamlAssertion, which tolerates the clock skew with the IdP
+	if err := ah.consumeSamlAssertion(ctx, orgID, &assertionInfo.Assertions[0]); err != nil {
+		zap.L().Error("[receiveSAML] rejected saml assertion", zap.String("domain", domain.String()), zap.Error(err))
 		handleSsoError(w, r, redirectUri)
 		return
 	}
@@ -96,7 +100,7 @@ func (ah *APIHandler) receiveSAML(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	nextPage, err := ah.Signoz.Modules.User.PrepareSsoRedirect(ctx, redirectUri, email, ah.opts.JWT)
+	nextPage, err := ah.Signoz.Modules.User.PrepareSsoRedirect(ctx, redirectUri, email, "", domain.GetSAMLRole(assertionInfo.Values), ah.opts.JWT)
 	if err != nil {
 		zap.L().Error("[receiveSAML] failed to generate redirect URI after successful login ", zap.String("domain", domain.String()), zap.Error(err))
 		handleSsoError(w, r, redirectUri)
@@ -105,3 +109,21 @@ func (ah *APIHandler) receiveSAML(w http.ResponseWriter, r *http.Request) {
 
 	http.Redirect(w, r, nextPage, http.StatusSeeOther)
 }
+
+// consumeSamlAssertion validates the assertion and rejects it when it was already consumed. The consumed assertions
+// are stored in the sqlstore until they expire, so that a captured response cannot be posted again to log in on any
+// replica.
+func (ah *APIHandler) consumeSamlAssertion(ctx context.Context, orgID valuer.UUID, assertion *saml2types.Assertion) error {
+	now := time.Now()
+	expiresAt, err := ssotypes.ValidateAssertion(assertion, now)
+	if err != nil {
+		return err
+	}
+
+	return ah.samlAssertionStore.Consume(ctx, &ssotypes.StorableSamlAssertion{
+		ID:        assertion.ID,
+		OrgID:     orgID.StringValue(),
+		ExpiresAt: expiresAt,
+		CreatedAt: now,
+	})
+}
@@ -22,6 +22,9 @@ import (
 	"go.uber.org/zap"
 )
 
+// cloudIntegrationPATExpiryDays is the number of days after which the PAT of a cloud integration expires.
+const cloudIntegrationPATExpiryDays int64 = 90
+
 type CloudIntegrationConnectionParamsResponse struct {
 	IngestionUrl string `json:"ingestion_url,omitempty"`
 	IngestionKey string `json:"ingestion_key,omitempty"`
@@ -50,7 +53,7 @@ func (ah *APIHandler) CloudIntegrationsGenerateConnectionParams(w http.ResponseW
 		return
 	}
 
-	apiKey, apiErr := ah.getOrCreateCloudIntegrationPAT(r.Context(), claims.OrgID, cloudProvider)
+	apiKey, apiErr := ah.rotateCloudIntegrationPAT(r.Context(), claims.OrgID, claims.UserID, cloudProvider)
 	if apiErr != nil {
 		RespondError(w, basemodel.WrapApiError(
 			apiErr, "couldn't provision PAT for cloud integration:",
@@ -110,7 +113,10 @@ func (ah *APIHandler) CloudIntegrationsGenerateConnectionParams(w http.ResponseW
 	ah.Respond(w, result)
 }
 
-func (ah *APIHandler) getOrCreateCloudIntegrationPAT(ctx context.Context, orgId string, cloudProvider string) (
+// rotateCloudIntegrationPAT creates a new expiring PAT for the cloud integration and revokes the previous ones, so
+// that each integration holds a single PAT. The PATs are stored hashed, so the token of an existing PAT cannot be
+// handed out again and a new one is created instead.
+func (ah *APIHandler) rotateCloudIntegrationPAT(ctx context.Context, orgId string, rotatedBy string, cloudProvider string) (
 	string, *basemodel.ApiError,
 ) {
 	integrationPATName := fmt.Sprintf("%s integration", cloudProvider)
@@ -120,35 +126,29 @@ func (ah *APIHandler) getOrCreateCloudIntegrationPAT(ctx context.Context, orgId
 		return "", apiErr
 	}
 
-	orgIdUUID, err := valuer.NewUUID(orgId)
+	orgID, err := valuer.NewUUID(orgId)
 	if err != nil {
-		return "", basemodel.InternalError(fmt.Errorf(
-			"couldn't parse orgId: %w", err,
-		))
+		return "", basemodel.BadRequest(fmt.Errorf("invalid org id: %w", err))
+	}
+
+	rotatedByID, err := valuer.NewUUID(rotatedBy)
+	if err != nil {
+		return "", basemodel.BadRequest(fmt.Errorf("invalid user id: %w", err))
 	}
 
-	allPats, err := ah.Signoz.Modules.User.ListAPIKeys(ctx, orgIdUUID)
+	apiKeys, err := ah.Signoz.Modules.User.ListAPIKeys(ctx, orgID)
 	if err != nil {
 		return "", basemodel.InternalError(fmt.Errorf(
-			"couldn't list PATs: %w", err,
+			"couldn't list cloud integration PATs: %w", err,
 		))
 	}
-	for _, p := range allPats {
-		if p.UserID == integrationUser.ID && p.Name == integrationPATName {
-			return p.Token, nil
-		}
-	}
-
-	zap.L().Info(
-		"no PAT found for cloud integration, creating a new one",
-		zap.String("cloudProvider", cloudProvider),
-	)
 
-	newPAT, err := types.NewStorableAPIKey(
+	newPAT, token, err := types.NewStorableAPIKey(
 		integrationPATName,
 		integrationUser.ID,
 		types.RoleViewer,
-		0,
+		cloudIntegrationPATExpiryDays,
+		nil,
 	)
 	if err != nil {
 		return "", basemodel.InternalError(fmt.Errorf(
@@ -162,7 +162,22 @@ func (ah *APIHandler) getOrCreateCloudIntegrationPAT(ctx context.Context, orgId
 			"couldn't create cloud integration PAT: %w", err,
 		))
 	}
-	return newPAT.Token, nil
+
+	// the previous PATs are revoked once the new one is stored, the accounts connected with them are expected to be
+	// connected again with the new one
+	for _, apiKey := range apiKeys {
+		if apiKey.UserID != integrationUser.ID || apiKey.Name != integrationPATName {
+			continue
+		}
+
+		if err := ah.Signoz.Modules.User.RevokeAPIKey(ctx, apiKey.ID, rotatedByID); err != nil {
+			return "", basemodel.InternalError(fmt.Errorf(
+				"couldn't revoke previous cloud integration PAT: %w", err,
+			))
+		}
+	}
+
+	return token, nil
 }
 
 func (ah *APIHandler) getOrCreateCloudIntegrationUser(
@@ -11,6 +11,7 @@ import (
 	"github.com/SigNoz/signoz/ee/query-service/constants"
 	pkgError "github.com/SigNoz/signoz/pkg/errors"
 	"github.com/SigNoz/signoz/pkg/http/render"
+	"github.com/SigNoz/signoz/pkg/licensing/overridelicensing"
 	"github.com/SigNoz/signoz/pkg/types/authtypes"
 	"github.com/SigNoz/signoz/pkg/types/licensetypes"
 	"github.com/SigNoz/signoz/pkg/valuer"
@@ -31,7 +32,7 @@ func (ah *APIHandler) getFeatureFlags(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	featureSet, err := ah.Signoz.Licensing.GetFeatureFlags(r.Context(), orgID)
+	featureSet, err := ah.Signoz.Licensing.Features(r.Context(), orgID)
 	if err != nil {
 		ah.HandleError(w, err, http.StatusInternalServerError)
 		return
@@ -75,6 +76,15 @@ func (ah *APIHandler) getFeatureFlags(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	// The overrides take precedence over the features of zeus and of the flags above.
+	if overrider, ok := ah.Signoz.Licensing.(overridelicensing.Overrider); ok {
+		featureSet, err = overrider.ApplyOverrides(ctx, orgID, featureSet)
+		if err != nil {
+			render.Error(w, err)
+			return
+		}
+	}
+
 	ah.Respond(w, featureSet)
 }
 
@@ -19,9 +19,12 @@ import (
 	"github.com/SigNoz/signoz/ee/query-service/usage"
 	"github.com/SigNoz/signoz/pkg/alertmanager"
 	"github.com/SigNoz/signoz/pkg/cache"
+	"github.com/SigNoz/signoz/pkg/grpcserver"
 	"github.com/SigNoz/signoz/pkg/http/middleware"
+	httpserver "github.com/SigNoz/signoz/pkg/http/server"
 	"github.com/SigNoz/signoz/pkg/modules/organization"
 	"github.com/SigNoz/signoz/pkg/prometheus"
+	"github.com/SigNoz/signoz/pkg/ruler"
 	"github.com/SigNoz/signoz/pkg/signoz"
 	"github.com/SigNoz/signoz/pkg/sqlstore"
 	"github.com/SigNoz/signoz/pkg/telemetrystore"
@@ -30,6 +33,7 @@ import (
 	"github.com/rs/cors"
 	"github.com/soheilhy/cmux"
 
+	querierAPI "github.com/SigNoz/signoz/pkg/querier"
 	"github.com/SigNoz/signoz/pkg/query-service/agentConf"
 	baseapp "github.com/SigNoz/signoz/pkg/query-service/app"
 	"github.com/SigNoz/signoz/pkg/query-service/app/cloudintegrations"
@@ -43,6 +47,8 @@ import (
 	baserules "github.com/SigNoz/signoz/pkg/query-service/rules"
 	"github.com/SigNoz/signoz/pkg/query-service/telemetry"
 	"github.com/SigNoz/signoz/pkg/query-service/utils"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 )
 
@@ -72,11 +78,16 @@ type Server struct {
 	privateConn net.Listener
 	privateHTTP *http.Server
 
+	// drainer drains the in-flight requests of the http servers on shutdown
+	drainer *httpserver.Drainer
+
 	// Usage manager
 	usageManager *usage.Manager
 
 	opampServer *opamp.Server
 
+	grpcServer *grpcserver.Server
+
 	unavailableChannel chan healthcheck.Status
 }
 
@@ -115,6 +126,9 @@ func NewServer(serverOptions *ServerOptions) (*Server, error) {
 		serverOptions.SigNoz.TelemetryStore,
 		serverOptions.SigNoz.Prometheus,
 		serverOptions.SigNoz.Modules.OrgGetter,
+		serverOptions.SigNoz.Instrumentation.TracerProvider(),
+		serverOptions.SigNoz.Instrumentation.MeterProvider(),
+		serverOptions.Config.Ruler,
 	)
 
 	if err != nil {
@@ -206,6 +220,7 @@ func NewServer(serverOptions *ServerOptions) (*Server, error) {
 		serverOptions:      serverOptions,
 		unavailableChannel: make(chan healthcheck.Status),
 		usageManager:       usageManager,
+		drainer:            httpserver.NewDrainer(),
 	}
 
 	httpServer, err := s.createPublicServer(apiHandler, serverOptions.SigNoz.Web)
@@ -223,6 +238,22 @@ func NewServer(serverOptions *ServerOptions) (*Server, error) {
 
 	s.privateHTTP = privateServer
 
+	if serverOptions.Config.GRPCServer.Enabled {
+		grpcServer, err := grpcserver.New(
+			serverOptions.SigNoz.Instrumentation.ToProviderSettings(),
+			serverOptions.Config.GRPCServer,
+			serverOptions.Jwt,
+			serverOptions.SigNoz.Sharder,
+			serverOptions.SigNoz.Querier,
+			serverOptions.SigNoz.Modules.Dashboard,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		s.grpcServer = grpcServer
+	}
+
 	s.opampServer = opamp.InitializeServer(
 		&opAmpModel.AllAgents, agentConfMgr,
 	)
@@ -252,9 +283,11 @@ func (s *Server) createPrivateServer(apiHandler *api.APIHandler) (*http.Server,
 		s.serverOptions.Config.APIServer.Timeout.Max,
 	).Wrap)
 	r.Use(middleware.NewAnalytics().Wrap)
-	r.Use(middleware.NewLogging(s.serverOptions.SigNoz.Instrumentation.Logger(), s.serverOptions.Config.APIServer.Logging.ExcludedRoutes).Wrap)
+	r.Use(middleware.NewLogging(s.serverOptions.SigNoz.Instrumentation.Logger(), s.serverOptions.Config.APIServer.Logging).Wrap)
 
 	apiHandler.RegisterPrivateRoutes(r)
+	// the metrics are served on the private server only, the scrapes reach it from within the deployment
+	r.Handle("/metrics", s.serverOptions.SigNoz.Instrumentation.MetricsHandler()).Methods(http.MethodGet)
 
 	c := cors.New(cors.Options{
 		//todo(amol): find out a way to add exact domain or
@@ -268,23 +301,26 @@ func (s *Server) createPrivateServer(apiHandler *api.APIHandler) (*http.Server,
 	handler = handlers.CompressHandler(handler)
 
 	return &http.Server{
-		Handler: handler,
+		Handler: s.drainer.Wrap(handler),
 	}, nil
 }
 
 func (s *Server) createPublicServer(apiHandler *api.APIHandler, web web.Web) (*http.Server, error) {
 	r := baseapp.NewRouter()
-	am := middleware.NewAuthZ(s.serverOptions.SigNoz.Instrumentation.Logger())
+	am := middleware.NewAuthZ(s.serverOptions.SigNoz.Instrumentation.Logger(), s.serverOptions.SigNoz.Modules.RBAC)
 
 	r.Use(middleware.NewAuth(s.serverOptions.Jwt, []string{"Authorization", "Sec-WebSocket-Protocol"}, s.serverOptions.SigNoz.Sharder, s.serverOptions.SigNoz.Instrumentation.Logger()).Wrap)
 	r.Use(middleware.NewAPIKey(s.serverOptions.SigNoz.SQLStore, []string{"SIGNOZ-API-KEY"}, s.serverOptions.SigNoz.Instrumentation.Logger(), s.serverOptions.SigNoz.Sharder).Wrap)
+	if s.serverOptions.Config.APIServer.RateLimit.Enabled {
+		r.Use(middleware.NewRateLimit(s.serverOptions.SigNoz.Instrumentation.Logger(), s.serverOptions.SigNoz.Cache, s.serverOptions.Config.APIServer.RateLimit).Wrap)
+	}
 	r.Use(middleware.NewTimeout(s.serverOptions.SigNoz.Instrumentation.Logger(),
 		s.serverOptions.Config.APIServer.Timeout.ExcludedRoutes,
 		s.serverOptions.Config.APIServer.Timeout.Default,
 		s.serverOptions.Config.APIServer.Timeout.Max,
 	).Wrap)
 	r.Use(middleware.NewAnalytics().Wrap)
-	r.Use(middleware.NewLogging(s.serverOptions.SigNoz.Instrumentation.Logger(), s.serverOptions.Config.APIServer.Logging.ExcludedRoutes).Wrap)
+	r.Use(middleware.NewLogging(s.serverOptions.SigNoz.Instrumentation.Logger(), s.serverOptions.Config.APIServer.Logging).Wrap)
 
 	apiHandler.RegisterRoutes(r, am)
 	apiHandler.RegisterLogsRoutes(r, am)
@@ -305,6 +341,7 @@ func (s *Server) createPublicServer(apiHandler *api.APIHandler, web web.Web) (*h
 		AllowedOrigins: []string{"*"},
 		AllowedMethods: []string{"GET", "DELETE", "POST", "PUT", "PATCH", "OPTIONS"},
 		AllowedHeaders: []string{"Accept", "Authorization", "Content-Type", "cache-control", "X-SIGNOZ-QUERY-ID", "Sec-WebSocket-Protocol"},
+		ExposedHeaders: []string{querierAPI.EstimatedRowsHeader, querierAPI.EstimatedBytesHeader},
 	})
 
 	handler := c.Handler(r)
@@ -317,7 +354,7 @@ func (s *Server) createPublicServer(apiHandler *api.APIHandler, web web.Web) (*h
 	}
 
 	return &http.Server{
-		Handler: handler,
+		Handler: s.drainer.Wrap(handler),
 	}, nil
 }
 
@@ -408,6 +445,14 @@ func (s *Server) Start(ctx context.Context) error {
 
 	}()
 
+	if s.grpcServer != nil {
+		go func() {
+			if err := s.grpcServer.Start(ctx); err != nil {
+				s.unavailableChannel <- healthcheck.Unavailable
+			}
+		}()
+	}
+
 	go func() {
 		zap.L().Info("Starting OpAmp Websocket server", zap.String("addr", baseconst.OpAmpWsEndpoint))
 		err := s.opampServer.Start(baseconst.OpAmpWsEndpoint)
@@ -421,14 +466,21 @@ func (s *Server) Start(ctx context.Context) error {
 }
 
 func (s *Server) Stop(ctx context.Context) error {
-	if s.httpServer != nil {
-		if err := s.httpServer.Shutdown(ctx); err != nil {
-			return err
+	// The servers are drained before the providers of signoz are stopped, so that the in-flight requests can still
+	// use them.
+	servers := []*http.Server{}
+	for _, server := range []*http.Server{s.httpServer, s.privateHTTP} {
+		if server != nil {
+			servers = append(servers, server)
 		}
 	}
 
-	if s.privateHTTP != nil {
-		if err := s.privateHTTP.Shutdown(ctx); err != nil {
+	if err := s.drainer.Drain(ctx, s.serverOptions.SigNoz.Instrumentation.Logger(), s.serverOptions.Config.APIServer.Shutdown.GracePeriod, servers...); err != nil {
+		return err
+	}
+
+	if s.grpcServer != nil {
+		if err := s.grpcServer.Stop(ctx); err != nil {
 			return err
 		}
 	}
@@ -454,6 +506,9 @@ func makeRulesManager(
 	telemetryStore telemetrystore.TelemetryStore,
 	prometheus prometheus.Prometheus,
 	orgGetter organization.Getter,
+	tracerProvider trace.TracerProvider,
+	meterProvider metric.MeterProvider,
+	rulerConfig ruler.Config,
 ) (*baserules.Manager, error) {
 	// create manager opts
 	managerOpts := &baserules.ManagerOptions{
@@ -470,6 +525,10 @@ func makeRulesManager(
 		Alertmanager:        alertmanager,
 		SQLStore:            sqlstore,
 		OrgGetter:           orgGetter,
+		TracerProvider:      tracerProvider,
+		MeterProvider:       meterProvider,
+		Evaluation:          rulerConfig.Evaluation,
+		Idempotency:         rulerConfig.Idempotency,
 	}
 
 	// create Manager
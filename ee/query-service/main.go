@@ -22,7 +22,6 @@ import (
 	"github.com/SigNoz/signoz/pkg/signoz"
 	"github.com/SigNoz/signoz/pkg/sqlstore"
 	"github.com/SigNoz/signoz/pkg/sqlstore/sqlstorehook"
-	"github.com/SigNoz/signoz/pkg/types/authtypes"
 	"github.com/SigNoz/signoz/pkg/version"
 	pkgzeus "github.com/SigNoz/signoz/pkg/zeus"
 
@@ -59,6 +58,8 @@ func main() {
 	var dialTimeout time.Duration
 	var gatewayUrl string
 	var useLicensesV3 bool
+	var licensingFeaturesRefreshInterval time.Duration
+	var licensingCircuitBreaker pkglicensing.CircuitBreakerConfig
 
 	// Deprecated
 	flag.BoolVar(&useLogsNewSchema, "use-logs-new-schema", false, "use logs_v2 schema for logs")
@@ -87,6 +88,9 @@ func main() {
 	flag.StringVar(&gatewayUrl, "gateway-url", "", "(url to the gateway)")
 	// Deprecated
 	flag.BoolVar(&useLicensesV3, "use-licenses-v3", false, "use licenses_v3 schema for licenses")
+	flag.DurationVar(&licensingFeaturesRefreshInterval, "licensing.features-refresh-interval", 1*time.Minute, "(the interval after which the cached feature flags are refreshed from the license)")
+	flag.IntVar(&licensingCircuitBreaker.FailureThreshold, "licensing.circuit-breaker-failure-threshold", 5, "(the number of consecutive failures of the license server after which its calls are short-circuited, 0 disables the circuit breaker)")
+	flag.DurationVar(&licensingCircuitBreaker.OpenTimeout, "licensing.circuit-breaker-open-timeout", 1*time.Minute, "(the time after which a short-circuited license server is probed again)")
 	flag.Parse()
 
 	loggerMgr := initZapLog()
@@ -125,23 +129,23 @@ func main() {
 		zap.L().Info("JWT secret key set successfully.")
 	}
 
-	jwt := authtypes.NewJWT(jwtSecret, 30*time.Minute, 30*24*time.Hour)
-
 	signoz, err := signoz.New(
 		context.Background(),
 		config,
-		jwt,
+		jwtSecret,
 		zeus.Config(),
 		httpzeus.NewProviderFactory(),
-		licensing.Config(24*time.Hour, 3),
+		licensing.Config(24*time.Hour, 3, licensingFeaturesRefreshInterval, licensingCircuitBreaker),
 		func(sqlstore sqlstore.SQLStore, zeus pkgzeus.Zeus, orgGetter organization.Getter) factory.ProviderFactory[pkglicensing.Licensing, pkglicensing.Config] {
 			return httplicensing.NewProviderFactory(sqlstore, zeus, orgGetter)
 		},
-		signoz.NewEmailingProviderFactories(),
+		signoz.NewSecretStoreProviderFactories(),
+		signoz.NewEmailingProviderFactories,
 		signoz.NewCacheProviderFactories(),
 		signoz.NewWebProviderFactories(),
 		sqlStoreFactories,
-		signoz.NewTelemetryStoreProviderFactories(),
+		signoz.NewTelemetryStoreProviderFactories,
+		signoz.NewReceiverPluginProviderFactories(),
 	)
 	if err != nil {
 		zap.L().Fatal("Failed to create signoz", zap.Error(err))
@@ -157,7 +161,7 @@ func main() {
 		FluxIntervalForTraceDetail: fluxIntervalForTraceDetail,
 		Cluster:                    cluster,
 		GatewayUrl:                 gatewayUrl,
-		Jwt:                        jwt,
+		Jwt:                        signoz.JWT,
 	}
 
 	server, err := app.NewServer(serverOptions)
@@ -193,7 +193,7 @@ func (r *AnomalyRule) buildAndRunQuery(ctx context.Context, orgID valuer.UUID, t
 	zap.L().Info("anomaly scores", zap.String("scores", string(scoresJSON)))
 
 	for _, series := range queryResult.AnomalyScores {
-		smpl, shouldAlert := r.ShouldAlert(*series)
+		smpl, shouldAlert := r.ShouldAlert(*series, time.UnixMilli(params.End))
 		if shouldAlert {
 			resultVector = append(resultVector, smpl)
 		}
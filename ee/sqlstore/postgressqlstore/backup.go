@@ -0,0 +1,229 @@
+package postgressqlstore
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/sqlstore"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/stdlib"
+	"github.com/uptrace/bun"
+)
+
+var (
+	// copyEnd ends the rows of a COPY statement.
+	copyEnd = []byte("\\.\n")
+)
+
+func (dialect *dialect) BackupTables(ctx context.Context, bun bun.IDB) ([]string, error) {
+	var tables []string
+	if err := bun.NewRaw("SELECT table_name FROM information_schema.tables WHERE table_schema = current_schema() AND table_type = 'BASE TABLE'").Scan(ctx, &tables); err != nil {
+		return nil, err
+	}
+
+	var references []struct {
+		Table      string `bun:"table_name"`
+		Referenced string `bun:"referenced_table_name"`
+	}
+	if err := bun.NewRaw(`
+		SELECT DISTINCT c.relname AS table_name, r.relname AS referenced_table_name
+		FROM pg_constraint AS con
+		JOIN pg_class AS c ON c.oid = con.conrelid
+		JOIN pg_class AS r ON r.oid = con.confrelid
+		JOIN pg_namespace AS n ON n.oid = c.relnamespace
+		WHERE con.contype = 'f' AND n.nspname = current_schema()`).Scan(ctx, &references); err != nil {
+		return nil, err
+	}
+
+	referenced := make(map[string][]string, len(tables))
+	for _, reference := range references {
+		referenced[reference.Table] = append(referenced[reference.Table], reference.Referenced)
+	}
+
+	return sqlstore.OrderByReferences(tables, referenced), nil
+}
+
+// Backup writes a COPY statement followed by the rows of every table, in the text format of COPY, as pg_dump does. The
+// tables are read in a single repeatable read transaction so that the backup is consistent.
+func (dialect *dialect) Backup(ctx context.Context, bun *bun.DB, tables []string, w io.Writer) error {
+	statements, err := copyStatements(ctx, bun, tables)
+	if err != nil {
+		return err
+	}
+
+	return withPgConn(ctx, bun, func(conn *pgconn.PgConn) error {
+		if err := conn.Exec(ctx, "BEGIN ISOLATION LEVEL REPEATABLE READ READ ONLY").Close(); err != nil {
+			return err
+		}
+		defer conn.Exec(context.WithoutCancel(ctx), "ROLLBACK").Close() //nolint:errcheck
+
+		for _, statement := range statements {
+			if _, err := fmt.Fprintf(w, "%s FROM stdin;\n", statement); err != nil {
+				return err
+			}
+
+			if _, err := conn.CopyTo(ctx, w, statement+" TO STDOUT"); err != nil {
+				return err
+			}
+
+			if _, err := w.Write(copyEnd); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// Restore runs the COPY statements of the backup in a single transaction. The statements are not run as read, every
+// one of them must be the one of the table expected at its position.
+func (dialect *dialect) Restore(ctx context.Context, bun *bun.DB, tables []string, r io.Reader, dryRun bool) error {
+	statements, err := copyStatements(ctx, bun, tables)
+	if err != nil {
+		return err
+	}
+
+	reader := bufio.NewReader(r)
+	return withPgConn(ctx, bun, func(conn *pgconn.PgConn) error {
+		if err := conn.Exec(ctx, "BEGIN").Close(); err != nil {
+			return err
+		}
+		defer conn.Exec(context.WithoutCancel(ctx), "ROLLBACK").Close() //nolint:errcheck
+
+		if err := conn.Exec(ctx, "SET CONSTRAINTS ALL DEFERRED").Close(); err != nil {
+			return err
+		}
+
+		for i := len(tables) - 1; i >= 0; i-- {
+			if err := conn.Exec(ctx, "DELETE FROM "+pgx.Identifier{tables[i]}.Sanitize()).Close(); err != nil {
+				return err
+			}
+		}
+
+		for i, statement := range statements {
+			line, err := reader.ReadString('\n')
+			if err != nil || line != statement+" FROM stdin;\n" {
+				return errors.Newf(errors.TypeInvalidInput, sqlstore.ErrCodeBackupInvalid, "the backup is missing the rows of the table %q", tables[i])
+			}
+
+			if _, err := conn.CopyFrom(ctx, &copyReader{reader: reader}, statement+" FROM STDIN"); err != nil {
+				return errors.Wrapf(err, errors.TypeInvalidInput, sqlstore.ErrCodeBackupInvalid, "cannot restore the table %q", tables[i])
+			}
+		}
+
+		if err := resetSequences(ctx, conn, tables); err != nil {
+			return err
+		}
+
+		// The deferred constraints are checked now rather than by the commit so that a dry run verifies them too.
+		if err := conn.Exec(ctx, "SET CONSTRAINTS ALL IMMEDIATE").Close(); err != nil {
+			return errors.Wrapf(err, errors.TypeInvalidInput, sqlstore.ErrCodeBackupInvalid, "the backup violates the constraints of the database")
+		}
+
+		if dryRun {
+			return conn.Exec(ctx, "ROLLBACK").Close()
+		}
+
+		return conn.Exec(ctx, "COMMIT").Close()
+	})
+}
+
+// copyStatements returns the COPY statements, without their direction, of the columns of the tables.
+func copyStatements(ctx context.Context, bun *bun.DB, tables []string) ([]string, error) {
+	statements := make([]string, len(tables))
+	for i, table := range tables {
+		var columns []string
+		if err := bun.NewRaw("SELECT column_name FROM information_schema.columns WHERE table_schema = current_schema() AND table_name = ? ORDER BY ordinal_position", table).Scan(ctx, &columns); err != nil {
+			return nil, err
+		}
+
+		for j, column := range columns {
+			columns[j] = pgx.Identifier{column}.Sanitize()
+		}
+
+		statements[i] = fmt.Sprintf("COPY %s (%s)", pgx.Identifier{table}.Sanitize(), strings.Join(columns, ", "))
+	}
+
+	return statements, nil
+}
+
+// resetSequences moves the sequences of the serial columns of the tables past the restored values.
+func resetSequences(ctx context.Context, conn *pgconn.PgConn, tables []string) error {
+	for _, table := range tables {
+		result := conn.ExecParams(ctx, "SELECT column_name FROM information_schema.columns WHERE table_schema = current_schema() AND table_name = $1 AND column_default LIKE 'nextval(%'", [][]byte{[]byte(table)}, nil, nil, nil).Read()
+		if result.Err != nil {
+			return result.Err
+		}
+
+		for _, row := range result.Rows {
+			column := string(row[0])
+			query := fmt.Sprintf(
+				"SELECT setval(pg_get_serial_sequence('%s', '%s'), COALESCE(MAX(%s), 0) + 1, false) FROM %s",
+				strings.ReplaceAll(pgx.Identifier{table}.Sanitize(), "'", "''"),
+				strings.ReplaceAll(column, "'", "''"),
+				pgx.Identifier{column}.Sanitize(),
+				pgx.Identifier{table}.Sanitize(),
+			)
+			if err := conn.Exec(ctx, query).Close(); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// withPgConn runs the callback with a connection of the pool of its own.
+func withPgConn(ctx context.Context, bun *bun.DB, cb func(*pgconn.PgConn) error) error {
+	conn, err := bun.DB.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close() //nolint:errcheck
+
+	return conn.Raw(func(driverConn any) error {
+		stdlibConn, ok := driverConn.(*stdlib.Conn)
+		if !ok {
+			return errors.Newf(errors.TypeInternal, errors.CodeInternal, "unexpected driver connection %T", driverConn)
+		}
+
+		return cb(stdlibConn.Conn().PgConn())
+	})
+}
+
+// copyReader reads the rows of a COPY statement until their end, which is consumed but not returned.
+type copyReader struct {
+	reader *bufio.Reader
+	line   []byte
+	done   bool
+}
+
+func (reader *copyReader) Read(p []byte) (int, error) {
+	for len(reader.line) == 0 {
+		if reader.done {
+			return 0, io.EOF
+		}
+
+		line, err := reader.reader.ReadBytes('\n')
+		if err != nil {
+			return 0, errors.Wrapf(err, errors.TypeInvalidInput, sqlstore.ErrCodeBackupInvalid, "the rows of the table end unexpectedly")
+		}
+
+		if bytes.Equal(line, copyEnd) {
+			reader.done = true
+			continue
+		}
+
+		reader.line = line
+	}
+
+	n := copy(p, reader.line)
+	reader.line = reader.line[n:]
+	return n, nil
+}
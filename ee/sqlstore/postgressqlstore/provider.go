@@ -19,8 +19,10 @@ type provider struct {
 	settings factory.ScopedProviderSettings
 	sqldb    *sql.DB
 	bundb    *sqlstore.BunDB
+	replicas *sqlstore.ReplicaSet
 	sqlxdb   *sqlx.DB
 	dialect  *dialect
+	stopC    chan struct{}
 }
 
 func NewFactory(hookFactories ...factory.ProviderFactory[sqlstore.SQLStoreHook, sqlstore.Config]) factory.ProviderFactory[sqlstore.SQLStore, sqlstore.Config] {
@@ -55,17 +57,73 @@ func New(ctx context.Context, providerSettings factory.ProviderSettings, config
 		return nil, err
 	}
 
+	// The pool connects on demand, the database is pinged so that a database which is still starting is waited for.
+	if err := factory.RetryConnect(ctx, settings.Logger(), config.Connection.ConnectRetry, "postgres", pool.Ping); err != nil {
+		pool.Close()
+		return nil, err
+	}
+
 	sqldb := stdlib.OpenDBFromPool(pool)
+	bundb := sqlstore.NewBunDB(settings, sqldb, pgdialect.New(), hooks)
+
+	replicas := make([]*bun.DB, 0, len(config.Postgres.ReplicaDSNs))
+	for _, dsn := range config.Postgres.ReplicaDSNs {
+		replica, err := newReplica(ctx, settings, config, dsn, hooks)
+		if err != nil {
+			// The replicas opened so far and the primary are closed, nothing else would close them.
+			for _, replica := range replicas {
+				_ = replica.Close()
+			}
+			_ = sqldb.Close()
+			return nil, err
+		}
+
+		replicas = append(replicas, replica)
+	}
 
 	return &provider{
 		settings: settings,
 		sqldb:    sqldb,
-		bundb:    sqlstore.NewBunDB(settings, sqldb, pgdialect.New(), hooks),
+		bundb:    bundb,
+		replicas: sqlstore.NewReplicaSet(ctx, settings, bundb, replicas...),
 		sqlxdb:   sqlx.NewDb(sqldb, "postgres"),
 		dialect:  new(dialect),
+		stopC:    make(chan struct{}),
 	}, nil
 }
 
+func newReplica(ctx context.Context, settings factory.ScopedProviderSettings, config sqlstore.Config, dsn string, hooks []sqlstore.SQLStoreHook) (*bun.DB, error) {
+	replicaConfig, err := pgxpool.ParseConfig(dsn)
+	if err != nil {
+		return nil, err
+	}
+	replicaConfig.MaxConns = int32(config.Connection.MaxOpenConns)
+
+	replicaPool, err := pgxpool.NewWithConfig(ctx, replicaConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return sqlstore.NewBunDB(settings, stdlib.OpenDBFromPool(replicaPool), pgdialect.New(), hooks).DB, nil
+}
+
+// Start blocks until the provider is stopped, the provider is a service only so that its replicas are closed on
+// shutdown.
+func (provider *provider) Start(ctx context.Context) error {
+	<-provider.stopC
+	return nil
+}
+
+// Stop closes the connections to the read replicas. The primary is left open for the services stopped after it.
+func (provider *provider) Stop(ctx context.Context) error {
+	close(provider.stopC)
+	return provider.replicas.Close()
+}
+
+func (provider *provider) Healthy(ctx context.Context) error {
+	return provider.sqldb.PingContext(ctx)
+}
+
 func (provider *provider) BunDB() *bun.DB {
 	return provider.bundb.DB
 }
@@ -86,22 +144,49 @@ func (provider *provider) BunDBCtx(ctx context.Context) bun.IDB {
 	return provider.bundb.BunDBCtx(ctx)
 }
 
+func (provider *provider) ReadDB(ctx context.Context) bun.IDB {
+	return provider.replicas.ReadDBCtx(ctx)
+}
+
 func (provider *provider) RunInTxCtx(ctx context.Context, opts *sql.TxOptions, cb func(ctx context.Context) error) error {
 	return provider.bundb.RunInTxCtx(ctx, opts, cb)
 }
 
 func (provider *provider) WrapNotFoundErrf(err error, code errors.Code, format string, args ...any) error {
-	if err == sql.ErrNoRows {
-		return errors.Wrapf(err, errors.TypeNotFound, code, format, args...)
+	if normalizedErr := normalizeErr(err); sqlstore.IsNotFoundError(normalizedErr) {
+		return errors.Wrapf(normalizedErr, errors.TypeNotFound, code, format, args...)
 	}
 
 	return err
 }
 
 func (provider *provider) WrapAlreadyExistsErrf(err error, code errors.Code, format string, args ...any) error {
+	if normalizedErr := normalizeErr(err); sqlstore.IsConstraintUniqueError(normalizedErr) {
+		return errors.Wrapf(normalizedErr, errors.TypeAlreadyExists, code, format, args...)
+	}
+
+	return err
+}
+
+func (provider *provider) WrapErrf(err error, code errors.Code, format string, args ...any) error {
+	return sqlstore.WrapErrf(normalizeErr(err), code, format, args...)
+}
+
+// normalizeErr maps postgres specific errors to the errors of sqlstore.
+// See https://www.postgresql.org/docs/current/errcodes-appendix.html for the codes.
+func normalizeErr(err error) error {
+	if errors.Is(err, sql.ErrNoRows) {
+		return sqlstore.NewNotFoundErr(err)
+	}
+
 	var pgErr *pgconn.PgError
-	if errors.As(err, &pgErr) && pgErr.Code == "23505" {
-		return errors.Wrapf(err, errors.TypeAlreadyExists, code, format, args...)
+	if errors.As(err, &pgErr) {
+		switch pgErr.Code {
+		case "23505":
+			return sqlstore.NewConstraintUniqueErr(err)
+		case "23503":
+			return sqlstore.NewConstraintForeignKeyErr(err)
+		}
 	}
 
 	return err
@@ -110,3 +195,29 @@ func (provider *provider) WrapAlreadyExistsErrf(err error, code errors.Code, for
 func (dialect *dialect) ToggleForeignKeyConstraint(ctx context.Context, bun *bun.DB, enable bool) error {
 	return nil
 }
+
+// TryAdvisoryLock holds the session level advisory lock on a connection of its own, which is returned to the pool once
+// the lock is released.
+func (dialect *dialect) TryAdvisoryLock(ctx context.Context, bun *bun.DB, key string) (func(context.Context) error, bool, error) {
+	conn, err := bun.Conn(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+
+	var acquired bool
+	if err := conn.QueryRowContext(ctx, "SELECT pg_try_advisory_lock(hashtext(?))", key).Scan(&acquired); err != nil {
+		_ = conn.Close()
+		return nil, false, err
+	}
+
+	if !acquired {
+		return nil, false, conn.Close()
+	}
+
+	return func(ctx context.Context) error {
+		defer conn.Close() //nolint:errcheck
+
+		_, err := conn.ExecContext(ctx, "SELECT pg_advisory_unlock(hashtext(?))", key)
+		return err
+	}, true, nil
+}
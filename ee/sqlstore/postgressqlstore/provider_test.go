@@ -0,0 +1,52 @@
+package postgressqlstore
+
+import (
+	"database/sql"
+	"fmt"
+	"testing"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/sqlstore"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWrapErrf(t *testing.T) {
+	provider := &provider{}
+	code := errors.MustNewCode("test")
+
+	testCases := []struct {
+		name       string
+		err        error
+		sentinel   error
+		unique     bool
+		foreignKey bool
+		notFound   bool
+	}{
+		{name: "Unique", err: &pgconn.PgError{Code: "23505"}, sentinel: errors.New(errors.TypeAlreadyExists, code, "unique"), unique: true},
+		{name: "ForeignKey", err: &pgconn.PgError{Code: "23503"}, sentinel: errors.New(errors.TypeInvalidInput, code, "foreign key"), foreignKey: true},
+		{name: "WrappedUnique", err: fmt.Errorf("insert: %w", &pgconn.PgError{Code: "23505"}), sentinel: errors.New(errors.TypeAlreadyExists, code, "unique"), unique: true},
+		{name: "NotFound", err: sql.ErrNoRows, sentinel: errors.New(errors.TypeNotFound, code, "not found"), notFound: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			wrapped := provider.WrapErrf(tc.err, code, "failed")
+
+			typ, _, _, _, _, _ := errors.Unwrapb(tc.sentinel)
+			assert.True(t, errors.Ast(wrapped, typ))
+			assert.True(t, errors.Asc(wrapped, code))
+			assert.Equal(t, tc.unique, sqlstore.IsConstraintUniqueError(wrapped))
+			assert.Equal(t, tc.foreignKey, sqlstore.IsConstraintForeignKeyError(wrapped))
+			assert.Equal(t, tc.unique || tc.foreignKey, sqlstore.IsConstraintError(wrapped))
+			assert.Equal(t, tc.notFound, sqlstore.IsNotFoundError(wrapped))
+		})
+	}
+
+	t.Run("Other", func(t *testing.T) {
+		err := &pgconn.PgError{Code: "42P01"}
+
+		assert.Equal(t, err, provider.WrapErrf(err, code, "failed"))
+		assert.False(t, sqlstore.IsConstraintError(provider.WrapErrf(err, code, "failed")))
+	})
+}
@@ -35,6 +35,7 @@ func New(ctx context.Context, providerSettings factory.ProviderSettings, config
 		providerSettings.MeterProvider,
 		client.WithRequestResponseLog(true),
 		client.WithRetryCount(3),
+		client.WithTransport(providerSettings.HTTPTransport),
 	)
 	if err != nil {
 		return nil, err
@@ -40,6 +40,12 @@ type Alertmanager interface {
 	// UpdateChannel updates a channel for the organization.
 	UpdateChannelByReceiverAndID(context.Context, string, alertmanagertypes.Receiver, valuer.UUID) error
 
+	// SetChannelSigningSecretByID sets the secret signing the webhook notifications of a channel for the organization.
+	SetChannelSigningSecretByID(context.Context, string, valuer.UUID, string) error
+
+	// SetChannelPluginByID sets the receiver plugin notifying a channel for the organization.
+	SetChannelPluginByID(context.Context, string, valuer.UUID, string) error
+
 	// CreateChannel creates a channel for the organization.
 	CreateChannel(context.Context, string, alertmanagertypes.Receiver) error
 
@@ -49,12 +55,33 @@ type Alertmanager interface {
 	// SetConfig sets the config for the organization.
 	SetConfig(context.Context, *alertmanagertypes.Config) error
 
+	// Reload sets the config for the organization and applies it right away. The routing tree and the receivers are
+	// swapped without losing the alerts, the silences and the notifications in flight. An invalid config is rejected
+	// and the current config is retained.
+	Reload(context.Context, *alertmanagertypes.Config) error
+
 	// GetConfig gets the config for the organization.
 	GetConfig(context.Context, string) (*alertmanagertypes.Config, error)
 
 	// SetDefaultConfig sets the default config for the organization.
 	SetDefaultConfig(context.Context, string) error
 
+	// ListDeadLetters lists the notifications which could not be delivered for the organization.
+	ListDeadLetters(context.Context, string) (alertmanagertypes.GettableDeadLetters, error)
+
+	// RedispatchDeadLetter sends a notification which could not be delivered again through the notification pipeline.
+	RedispatchDeadLetter(context.Context, string, valuer.UUID) error
+
+	// CreateSilence creates a silence for the organization and returns its id. The silence mutes the alerts matched
+	// by all of its matchers between its start and end.
+	CreateSilence(context.Context, string, *alertmanagertypes.PostableSilence) (string, error)
+
+	// ListSilences lists the silences of the organization, including the recently expired ones.
+	ListSilences(context.Context, string) (alertmanagertypes.GettableSilences, error)
+
+	// ExpireSilence expires a silence of the organization immediately.
+	ExpireSilence(context.Context, string, string) error
+
 	// Collects stats for the organization.
 	statsreporter.StatsCollector
 }
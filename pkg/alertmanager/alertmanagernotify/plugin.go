@@ -0,0 +1,118 @@
+package alertmanagernotify
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/types/alertmanagertypes"
+	"github.com/prometheus/alertmanager/notify"
+)
+
+// Receiver is a notification receiver implemented outside of the alertmanager, for example to notify an incident
+// tool which has no upstream integration. Receivers are registered as provider factories and notify the channels of
+// the organizations they are selected for by name.
+type Receiver interface {
+	// Notify notifies the firing and resolved alerts of a group. It must return once the context is done.
+	Notify(ctx context.Context, alerts []*alertmanagertypes.Alert) error
+}
+
+// ReceiverConfig is the config with which a receiver plugin is created.
+type ReceiverConfig struct {
+	// Settings are the settings of the plugin, for example the url and the credentials of the tool it notifies.
+	Settings map[string]string
+}
+
+func (c ReceiverConfig) Validate() error {
+	return nil
+}
+
+// pluginNotifier notifies the receiver plugin selected by the channel of a receiver, bounding every call by a timeout
+// and retrying the failed calls in the background.
+type pluginNotifier struct {
+	lookup      func(context.Context) (string, error)
+	plugins     map[string]Receiver
+	timeout     time.Duration
+	maxAttempts int
+	backoff     time.Duration
+	logger      *slog.Logger
+}
+
+// NewPluginNotifier returns the notifier of the plugin whose name is returned by lookup when notifying, nothing is
+// notified when the name is empty. Every call of the plugin is bounded by timeout and the failed calls are attempted
+// up to maxAttempts times, waiting backoff between them.
+func NewPluginNotifier(lookup func(context.Context) (string, error), plugins map[string]Receiver, timeout time.Duration, maxAttempts int, backoff time.Duration, logger *slog.Logger) notify.Notifier {
+	return &pluginNotifier{
+		lookup:      lookup,
+		plugins:     plugins,
+		timeout:     timeout,
+		maxAttempts: maxAttempts,
+		backoff:     backoff,
+		logger:      logger,
+	}
+}
+
+// Notify makes the first call of the plugin and returns, the failed call is retried in the background so that a
+// plugin which is down does not hold up the other notifications of the pipeline. It never asks the pipeline to
+// retry, the plugin retries on its own.
+func (n *pluginNotifier) Notify(ctx context.Context, alerts ...*alertmanagertypes.Alert) (bool, error) {
+	name, err := n.lookup(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	if name == "" {
+		return false, nil
+	}
+
+	receiver, ok := n.plugins[name]
+	if !ok {
+		return false, errors.Newf(errors.TypeNotFound, errors.CodeNotFound, "receiver plugin %q is not registered", name)
+	}
+
+	if err = n.notify(ctx, name, receiver, alerts); err == nil {
+		return false, nil
+	}
+
+	if n.maxAttempts == 1 {
+		return false, errors.Newf(errors.TypeInternal, errors.CodeInternal, "receiver plugin %q failed: %v", name, err)
+	}
+
+	n.logger.WarnContext(ctx, "failed to notify the receiver plugin, retrying in the background", "plugin", name, "attempt", 1, "backoff", n.backoff, "error", err)
+
+	// The context of the pipeline is canceled once Notify returns.
+	go n.retry(context.WithoutCancel(ctx), name, receiver, alerts)
+	return false, nil
+}
+
+func (n *pluginNotifier) retry(ctx context.Context, name string, receiver Receiver, alerts []*alertmanagertypes.Alert) {
+	var err error
+	for attempt := 2; attempt <= n.maxAttempts; attempt++ {
+		time.Sleep(n.backoff)
+
+		if err = n.notify(ctx, name, receiver, alerts); err == nil {
+			return
+		}
+
+		if attempt < n.maxAttempts {
+			n.logger.WarnContext(ctx, "failed to notify the receiver plugin, retrying in the background", "plugin", name, "attempt", attempt, "backoff", n.backoff, "error", err)
+		}
+	}
+
+	n.logger.ErrorContext(ctx, "failed to notify the receiver plugin, giving up", "plugin", name, "attempts", n.maxAttempts, "error", err)
+}
+
+func (n *pluginNotifier) notify(ctx context.Context, name string, receiver Receiver, alerts []*alertmanagertypes.Alert) (err error) {
+	ctx, cancel := context.WithTimeout(ctx, n.timeout)
+	defer cancel()
+
+	// A plugin must not take down the alertmanager.
+	defer func() {
+		if r := recover(); r != nil {
+			err = errors.Newf(errors.TypeInternal, errors.CodeInternal, "receiver plugin %q panicked: %v", name, r)
+		}
+	}()
+
+	return receiver.Notify(ctx, alerts)
+}
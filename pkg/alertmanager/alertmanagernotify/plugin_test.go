@@ -0,0 +1,108 @@
+package alertmanagernotify
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/types/alertmanagertypes"
+	"github.com/prometheus/alertmanager/notify"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type receiverFunc func(context.Context, []*alertmanagertypes.Alert) error
+
+func (f receiverFunc) Notify(ctx context.Context, alerts []*alertmanagertypes.Alert) error {
+	return f(ctx, alerts)
+}
+
+func newTestPluginNotifier(plugin string, receiver Receiver, timeout time.Duration, maxAttempts int, backoff time.Duration) notify.Notifier {
+	lookup := func(context.Context) (string, error) {
+		return plugin, nil
+	}
+
+	return NewPluginNotifier(lookup, map[string]Receiver{"incidenttool": receiver}, timeout, maxAttempts, backoff, slog.New(slog.NewTextHandler(io.Discard, nil)))
+}
+
+func TestPluginNotifierRetries(t *testing.T) {
+	var calls atomic.Int32
+	receiver := receiverFunc(func(context.Context, []*alertmanagertypes.Alert) error {
+		if calls.Add(1) < 3 {
+			return errors.New(errors.TypeInternal, errors.CodeInternal, "incident tool unavailable")
+		}
+
+		return nil
+	})
+
+	// The failed first call is retried in the background, Notify does not wait for the retries.
+	notifier := newTestPluginNotifier("incidenttool", receiver, time.Second, 3, 50*time.Millisecond)
+	retry, err := notifier.Notify(context.Background(), &alertmanagertypes.Alert{})
+	require.NoError(t, err)
+	assert.False(t, retry)
+	assert.Equal(t, int32(1), calls.Load())
+	assert.Eventually(t, func() bool { return calls.Load() == 3 }, time.Second, 10*time.Millisecond)
+
+	// The retries stop after the maximum number of attempts.
+	calls.Store(-10)
+	_, err = notifier.Notify(context.Background(), &alertmanagertypes.Alert{})
+	require.NoError(t, err)
+	assert.Eventually(t, func() bool { return calls.Load() == -7 }, time.Second, 10*time.Millisecond)
+	time.Sleep(100 * time.Millisecond)
+	assert.Equal(t, int32(-7), calls.Load())
+}
+
+func TestPluginNotifierSingleAttempt(t *testing.T) {
+	receiver := receiverFunc(func(context.Context, []*alertmanagertypes.Alert) error {
+		return errors.New(errors.TypeInternal, errors.CodeInternal, "incident tool unavailable")
+	})
+
+	notifier := newTestPluginNotifier("incidenttool", receiver, time.Second, 1, 0)
+	retry, err := notifier.Notify(context.Background(), &alertmanagertypes.Alert{})
+	assert.Error(t, err)
+	assert.False(t, retry)
+}
+
+func TestPluginNotifierTimeout(t *testing.T) {
+	receiver := receiverFunc(func(ctx context.Context, _ []*alertmanagertypes.Alert) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+
+	notifier := newTestPluginNotifier("incidenttool", receiver, 10*time.Millisecond, 1, 0)
+	start := time.Now()
+	_, err := notifier.Notify(context.Background(), &alertmanagertypes.Alert{})
+	assert.Error(t, err)
+	assert.Less(t, time.Since(start), time.Second)
+}
+
+func TestPluginNotifierPanic(t *testing.T) {
+	receiver := receiverFunc(func(context.Context, []*alertmanagertypes.Alert) error {
+		panic("boom")
+	})
+
+	notifier := newTestPluginNotifier("incidenttool", receiver, time.Second, 1, 0)
+	_, err := notifier.Notify(context.Background(), &alertmanagertypes.Alert{})
+	assert.ErrorContains(t, err, "boom")
+}
+
+func TestPluginNotifierSelection(t *testing.T) {
+	var calls atomic.Int32
+	receiver := receiverFunc(func(context.Context, []*alertmanagertypes.Alert) error {
+		calls.Add(1)
+		return nil
+	})
+
+	// Nothing is notified when the channel has no plugin.
+	_, err := newTestPluginNotifier("", receiver, time.Second, 1, 0).Notify(context.Background(), &alertmanagertypes.Alert{})
+	require.NoError(t, err)
+	assert.Equal(t, int32(0), calls.Load())
+
+	_, err = newTestPluginNotifier("unknown", receiver, time.Second, 1, 0).Notify(context.Background(), &alertmanagertypes.Alert{})
+	assert.True(t, errors.Ast(err, errors.TypeNotFound))
+	assert.Equal(t, int32(0), calls.Load())
+}
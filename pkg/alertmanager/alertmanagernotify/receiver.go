@@ -0,0 +1,116 @@
+package alertmanagernotify
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+
+	"github.com/SigNoz/signoz/pkg/types/alertmanagertypes"
+	"github.com/prometheus/alertmanager/config/receiver"
+	"github.com/prometheus/alertmanager/notify"
+	"github.com/prometheus/alertmanager/template"
+	"github.com/prometheus/alertmanager/types"
+	commoncfg "github.com/prometheus/common/config"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+// HTTPClientConfig is the config of the http clients of the integrations.
+type HTTPClientConfig struct {
+	// Options are the options the clients are built with.
+	Options []commoncfg.HTTPClientOption
+
+	// RequestContext returns the context the requests of a notification are sent with, for the timeouts which
+	// cannot be set through the options. The context of the notification is used as it is when nil.
+	RequestContext func(context.Context) (context.Context, context.CancelFunc)
+}
+
+// NewReceiverIntegrations builds the integrations of the receiver. When traceContexts is not nil, the webhook
+// integrations send the W3C trace context of the span which evaluated the notified alerts. When signer is not nil,
+// the payloads of the webhook integrations are signed with it. The other integrations are built upstream. The http
+// clients of the integrations are built with httpClient.
+func NewReceiverIntegrations(nc alertmanagertypes.Receiver, tmpl *template.Template, logger *slog.Logger, traceContexts *alertmanagertypes.TraceContexts, signer *WebhookSigner, httpClient HTTPClientConfig) ([]notify.Integration, error) {
+	integrations, err := newReceiverIntegrations(nc, tmpl, logger, traceContexts, signer, httpClient.Options...)
+	if err != nil {
+		return nil, err
+	}
+
+	if httpClient.RequestContext == nil {
+		return integrations, nil
+	}
+
+	for i := range integrations {
+		integration := integrations[i]
+		integrations[i] = notify.NewIntegration(&requestContextNotifier{next: &integration, requestContext: httpClient.RequestContext}, &integration, integration.Name(), integration.Index(), nc.Name)
+	}
+
+	return integrations, nil
+}
+
+func newReceiverIntegrations(nc alertmanagertypes.Receiver, tmpl *template.Template, logger *slog.Logger, traceContexts *alertmanagertypes.TraceContexts, signer *WebhookSigner, httpOpts ...commoncfg.HTTPClientOption) ([]notify.Integration, error) {
+	if (traceContexts == nil && signer == nil) || len(nc.WebhookConfigs) == 0 {
+		return receiver.BuildReceiverIntegrations(nc, tmpl, logger, httpOpts...)
+	}
+
+	var (
+		errs         types.MultiError
+		integrations []notify.Integration
+	)
+
+	for i, c := range nc.WebhookConfigs {
+		n, err := newWebhookNotifier(c, tmpl, logger.With("integration", "webhook"), traceContexts, signer, httpOpts...)
+		if err != nil {
+			errs.Add(err)
+			continue
+		}
+		integrations = append(integrations, notify.NewIntegration(n, c, "webhook", i, nc.Name))
+	}
+
+	others := nc
+	others.WebhookConfigs = nil
+	otherIntegrations, err := receiver.BuildReceiverIntegrations(others, tmpl, logger, httpOpts...)
+	if err != nil {
+		errs.Add(err)
+	}
+
+	if errs.Len() > 0 {
+		return nil, &errs
+	}
+
+	return append(integrations, otherIntegrations...), nil
+}
+
+// requestContextNotifier notifies with the context returned by requestContext.
+type requestContextNotifier struct {
+	next           notify.Notifier
+	requestContext func(context.Context) (context.Context, context.CancelFunc)
+}
+
+func (n *requestContextNotifier) Notify(ctx context.Context, alerts ...*types.Alert) (bool, error) {
+	ctx, cancel := n.requestContext(ctx)
+	defer cancel()
+
+	return n.next.Notify(ctx, alerts...)
+}
+
+// traceContextRoundTripper injects the trace context of the context of every request in its headers.
+type traceContextRoundTripper struct {
+	next http.RoundTripper
+}
+
+func newTraceContextClient(client *http.Client) *http.Client {
+	next := client.Transport
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	client.Transport = &traceContextRoundTripper{next: next}
+	return client
+}
+
+func (rt *traceContextRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	// A round tripper must not modify the request it is given.
+	req = req.Clone(req.Context())
+	propagation.TraceContext{}.Inject(req.Context(), propagation.HeaderCarrier(req.Header))
+
+	return rt.next.RoundTrip(req)
+}
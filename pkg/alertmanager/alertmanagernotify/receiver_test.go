@@ -0,0 +1,104 @@
+package alertmanagernotify
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/instrumentation/instrumentationtest"
+	"github.com/SigNoz/signoz/pkg/types/alertmanagertypes"
+	"github.com/prometheus/alertmanager/notify"
+	commoncfg "github.com/prometheus/common/config"
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	traceParent = "00-01000000000000000000000000000000-0200000000000000-01"
+)
+
+func TestNewReceiverIntegrationsTraceContext(t *testing.T) {
+	spanContext := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{0x01},
+		SpanID:     trace.SpanID{0x02},
+		TraceFlags: trace.FlagsSampled,
+	})
+
+	testCases := []struct {
+		name          string
+		traceContexts *alertmanagertypes.TraceContexts
+		expected      string
+	}{
+		{name: "Enabled", traceContexts: alertmanagertypes.NewTraceContexts(), expected: traceParent},
+		{name: "Disabled", traceContexts: nil, expected: ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			headers := make(chan http.Header, 1)
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				headers <- r.Header
+			}))
+			defer server.Close()
+
+			receiver, err := alertmanagertypes.NewReceiver(`{"name":"webhook","webhook_configs":[{"url":"` + server.URL + `"}]}`)
+			require.NoError(t, err)
+			// The http config is set from the global config when the receiver is part of a config.
+			receiver.WebhookConfigs[0].HTTPConfig = &commoncfg.DefaultHTTPClientConfig
+
+			tmpl, err := alertmanagertypes.FromGlobs([]string{})
+			require.NoError(t, err)
+			tmpl.ExternalURL = &url.URL{Scheme: "http", Host: "localhost:8080"}
+
+			integrations, err := NewReceiverIntegrations(receiver, tmpl, instrumentationtest.New().Logger(), tc.traceContexts, nil, HTTPClientConfig{})
+			require.NoError(t, err)
+			require.Len(t, integrations, 1)
+
+			alert := &alertmanagertypes.Alert{Alert: model.Alert{Labels: model.LabelSet{"alertname": "test"}}}
+			if tc.traceContexts != nil {
+				tc.traceContexts.Put(trace.ContextWithSpanContext(context.Background(), spanContext), alert)
+			}
+
+			_, err = integrations[0].Notify(notify.WithGroupKey(context.Background(), "group"), alert)
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.expected, (<-headers).Get("traceparent"))
+		})
+	}
+}
+
+func TestNewReceiverIntegrationsRequestContext(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer server.Close()
+	defer close(release)
+
+	receiver, err := alertmanagertypes.NewReceiver(`{"name":"webhook","webhook_configs":[{"url":"` + server.URL + `"}]}`)
+	require.NoError(t, err)
+	receiver.WebhookConfigs[0].HTTPConfig = &commoncfg.DefaultHTTPClientConfig
+
+	tmpl, err := alertmanagertypes.FromGlobs([]string{})
+	require.NoError(t, err)
+	tmpl.ExternalURL = &url.URL{Scheme: "http", Host: "localhost:8080"}
+
+	// The request of the hanging webhook is canceled through the request context.
+	integrations, err := NewReceiverIntegrations(receiver, tmpl, instrumentationtest.New().Logger(), nil, nil, HTTPClientConfig{
+		RequestContext: func(ctx context.Context) (context.Context, context.CancelFunc) {
+			return context.WithTimeout(ctx, 50*time.Millisecond)
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, integrations, 1)
+	assert.Equal(t, "webhook", integrations[0].Name())
+
+	alert := &alertmanagertypes.Alert{Alert: model.Alert{Labels: model.LabelSet{"alertname": "test"}}}
+	_, err = integrations[0].Notify(notify.WithGroupKey(context.Background(), "group"), alert)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
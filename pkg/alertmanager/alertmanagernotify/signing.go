@@ -0,0 +1,88 @@
+package alertmanagernotify
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"hash"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+)
+
+var webhookSigningAlgorithms = map[string]func() hash.Hash{
+	"sha256": sha256.New,
+	"sha512": sha512.New,
+}
+
+// WebhookSigner signs the payloads of the webhook notifications with the secret of their receiver so that the
+// receiving endpoint can verify that they were sent by SigNoz. The secret is stored with the channel of the receiver
+// in its organization and read at each notification, hence a rotated secret applies without a reload. The
+// notifications of the receivers without a secret are not signed.
+//
+// The timestamp header is set to the unix time in seconds at which the notification is sent and the signature
+// header to "<algorithm>=<hex encoded HMAC of '<timestamp>.<body>'>". To verify a notification, the endpoint computes
+// the HMAC of the timestamp header, a dot and the raw body with the shared secret, compares it in constant time
+// with the signature header and rejects the notifications whose timestamp is too far from its clock to prevent
+// replays.
+type WebhookSigner struct {
+	algorithm       string
+	hash            func() hash.Hash
+	secret          func(context.Context) (string, error)
+	signatureHeader string
+	timestampHeader string
+	now             func() time.Time
+}
+
+// NewWebhookSigner returns a signer computing the HMAC of the payloads with the given algorithm and the secret
+// returned by secret.
+func NewWebhookSigner(algorithm string, signatureHeader string, timestampHeader string, secret func(context.Context) (string, error)) (*WebhookSigner, error) {
+	hash, ok := webhookSigningAlgorithms[algorithm]
+	if !ok {
+		return nil, errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "unsupported webhook signing algorithm %q, must be one of sha256 or sha512", algorithm)
+	}
+
+	if signatureHeader == "" || timestampHeader == "" {
+		return nil, errors.New(errors.TypeInvalidInput, errors.CodeInvalidInput, "webhook signature and timestamp headers must not be empty")
+	}
+
+	return &WebhookSigner{
+		algorithm:       algorithm,
+		hash:            hash,
+		secret:          secret,
+		signatureHeader: signatureHeader,
+		timestampHeader: timestampHeader,
+		now:             time.Now,
+	}, nil
+}
+
+// Sign sets the timestamp and signature headers of the body. The headers are not set when the receiver has no secret.
+func (s *WebhookSigner) Sign(ctx context.Context, header http.Header, body []byte) error {
+	secret, err := s.secret(ctx)
+	if err != nil {
+		return err
+	}
+
+	if secret == "" {
+		return nil
+	}
+
+	timestamp := strconv.FormatInt(s.now().Unix(), 10)
+
+	header.Set(s.timestampHeader, timestamp)
+	header.Set(s.signatureHeader, s.algorithm+"="+s.signature(secret, timestamp, body))
+	return nil
+}
+
+func (s *WebhookSigner) signature(secret string, timestamp string, body []byte) string {
+	mac := hmac.New(s.hash, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,117 @@
+package alertmanagernotify
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/instrumentation/instrumentationtest"
+	"github.com/SigNoz/signoz/pkg/types/alertmanagertypes"
+	"github.com/prometheus/alertmanager/notify"
+	commoncfg "github.com/prometheus/common/config"
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func secretOf(secret string) func(context.Context) (string, error) {
+	return func(context.Context) (string, error) {
+		return secret, nil
+	}
+}
+
+func TestNewWebhookSigner(t *testing.T) {
+	testCases := []struct {
+		name      string
+		algorithm string
+		pass      bool
+	}{
+		{name: "Sha256", algorithm: "sha256", pass: true},
+		{name: "Sha512", algorithm: "sha512", pass: true},
+		{name: "Sha1", algorithm: "sha1", pass: false},
+		{name: "UnknownAlgorithm", algorithm: "md5", pass: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := NewWebhookSigner(tc.algorithm, "X-Signature", "X-Timestamp", secretOf("secret"))
+			if tc.pass {
+				assert.NoError(t, err)
+				return
+			}
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestWebhookSignerSign(t *testing.T) {
+	signer, err := NewWebhookSigner("sha256", "X-Signature", "X-Timestamp", secretOf("secret"))
+	require.NoError(t, err)
+	signer.now = func() time.Time { return time.Unix(1700000000, 0) }
+
+	header := http.Header{}
+	require.NoError(t, signer.Sign(context.Background(), header, []byte(`{"status":"firing"}`)))
+
+	mac := hmac.New(sha256.New, []byte("secret"))
+	mac.Write([]byte(`1700000000.{"status":"firing"}`))
+
+	assert.Equal(t, "1700000000", header.Get("X-Timestamp"))
+	assert.Equal(t, "sha256="+hex.EncodeToString(mac.Sum(nil)), header.Get("X-Signature"))
+}
+
+func TestWebhookSignerSignWithoutSecret(t *testing.T) {
+	signer, err := NewWebhookSigner("sha256", "X-Signature", "X-Timestamp", secretOf(""))
+	require.NoError(t, err)
+
+	header := http.Header{}
+	require.NoError(t, signer.Sign(context.Background(), header, []byte(`{"status":"firing"}`)))
+	assert.Empty(t, header.Get("X-Timestamp"))
+	assert.Empty(t, header.Get("X-Signature"))
+}
+
+func TestNewReceiverIntegrationsWebhookSigning(t *testing.T) {
+	type request struct {
+		header http.Header
+		body   []byte
+	}
+
+	requests := make(chan request, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		requests <- request{header: r.Header, body: body}
+	}))
+	defer server.Close()
+
+	receiver, err := alertmanagertypes.NewReceiver(`{"name":"webhook","webhook_configs":[{"url":"` + server.URL + `"}]}`)
+	require.NoError(t, err)
+	receiver.WebhookConfigs[0].HTTPConfig = &commoncfg.DefaultHTTPClientConfig
+
+	tmpl, err := alertmanagertypes.FromGlobs([]string{})
+	require.NoError(t, err)
+	tmpl.ExternalURL = &url.URL{Scheme: "http", Host: "localhost:8080"}
+
+	signer, err := NewWebhookSigner("sha256", "X-Signature", "X-Timestamp", secretOf("secret"))
+	require.NoError(t, err)
+
+	integrations, err := NewReceiverIntegrations(receiver, tmpl, instrumentationtest.New().Logger(), nil, signer, HTTPClientConfig{})
+	require.NoError(t, err)
+	require.Len(t, integrations, 1)
+
+	alert := &alertmanagertypes.Alert{Alert: model.Alert{Labels: model.LabelSet{"alertname": "test"}}}
+	_, err = integrations[0].Notify(notify.WithGroupKey(context.Background(), "group"), alert)
+	require.NoError(t, err)
+
+	actual := <-requests
+	timestamp := actual.header.Get("X-Timestamp")
+	require.NotEmpty(t, timestamp)
+	assert.Equal(t, "sha256="+signer.signature("secret", timestamp, actual.body), actual.header.Get("X-Signature"))
+	assert.Equal(t, "application/json", actual.header.Get("Content-Type"))
+	assert.Empty(t, actual.header.Get("traceparent"))
+}
@@ -0,0 +1,173 @@
+// Copyright 2019 Prometheus Team
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// This file is adapted from https://github.com/prometheus/alertmanager/blob/v0.28.0/notify/webhook/webhook.go to
+// sign the payloads and propagate the trace context of the notified alerts, neither of which can be done through
+// the http client options of the upstream notifier.
+
+package alertmanagernotify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/SigNoz/signoz/pkg/types/alertmanagertypes"
+	commoncfg "github.com/prometheus/common/config"
+
+	"github.com/prometheus/alertmanager/config"
+	"github.com/prometheus/alertmanager/notify"
+	"github.com/prometheus/alertmanager/template"
+	"github.com/prometheus/alertmanager/types"
+)
+
+// webhookNotifier implements a Notifier for generic webhooks.
+type webhookNotifier struct {
+	conf    *config.WebhookConfig
+	tmpl    *template.Template
+	logger  *slog.Logger
+	client  *http.Client
+	retrier *notify.Retrier
+	// traceContexts are the spans which evaluated the alerts, nil when the trace context is not sent.
+	traceContexts *alertmanagertypes.TraceContexts
+	signer        *WebhookSigner
+}
+
+// newWebhookNotifier returns a new Webhook. The trace context of the alerts is sent when traceContexts is not nil
+// and the payloads are signed when signer is not nil.
+func newWebhookNotifier(conf *config.WebhookConfig, t *template.Template, l *slog.Logger, traceContexts *alertmanagertypes.TraceContexts, signer *WebhookSigner, httpOpts ...commoncfg.HTTPClientOption) (*webhookNotifier, error) {
+	client, err := commoncfg.NewClientFromConfig(*conf.HTTPConfig, "webhook", httpOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	if traceContexts != nil {
+		client = newTraceContextClient(client)
+	}
+
+	return &webhookNotifier{
+		conf:          conf,
+		tmpl:          t,
+		logger:        l,
+		client:        client,
+		traceContexts: traceContexts,
+		signer:        signer,
+		// Webhooks are assumed to respond with 2xx response codes on a successful
+		// request and 5xx response codes are assumed to be recoverable.
+		retrier: &notify.Retrier{},
+	}, nil
+}
+
+// webhookMessage defines the JSON object send to webhook endpoints.
+type webhookMessage struct {
+	*template.Data
+
+	// The protocol version.
+	Version         string `json:"version"`
+	GroupKey        string `json:"groupKey"`
+	TruncatedAlerts uint64 `json:"truncatedAlerts"`
+}
+
+func truncateAlerts(maxAlerts uint64, alerts []*types.Alert) ([]*types.Alert, uint64) {
+	if maxAlerts != 0 && uint64(len(alerts)) > maxAlerts {
+		return alerts[:maxAlerts], uint64(len(alerts)) - maxAlerts
+	}
+
+	return alerts, 0
+}
+
+// Notify implements the Notifier interface.
+func (n *webhookNotifier) Notify(ctx context.Context, alerts ...*types.Alert) (bool, error) {
+	if n.traceContexts != nil {
+		ctx = n.traceContexts.NewContext(ctx, alerts...)
+	}
+	alerts, numTruncated := truncateAlerts(n.conf.MaxAlerts, alerts)
+	data := notify.GetTemplateData(ctx, n.tmpl, alerts, n.logger)
+
+	groupKey, err := notify.ExtractGroupKey(ctx)
+	if err != nil {
+		// @tjhop: should we `return false, err` here as we do in most
+		// other Notify() implementations?
+		n.logger.Error("error extracting group key", "err", err)
+	}
+
+	// @tjhop: should we debug log the key here like most other Notify() implementations?
+
+	msg := &webhookMessage{
+		Version:         "4",
+		Data:            data,
+		GroupKey:        groupKey.String(),
+		TruncatedAlerts: numTruncated,
+	}
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(msg); err != nil {
+		return false, err
+	}
+
+	var url string
+	if n.conf.URL != nil {
+		url = n.conf.URL.String()
+	} else {
+		content, err := os.ReadFile(n.conf.URLFile)
+		if err != nil {
+			return false, fmt.Errorf("read url_file: %w", err)
+		}
+		url = strings.TrimSpace(string(content))
+	}
+
+	if n.conf.Timeout > 0 {
+		postCtx, cancel := context.WithTimeoutCause(ctx, n.conf.Timeout, fmt.Errorf("configured webhook timeout reached (%s)", n.conf.Timeout))
+		defer cancel()
+		ctx = postCtx
+	}
+
+	resp, err := n.post(ctx, url, &buf)
+	if err != nil {
+		if ctx.Err() != nil {
+			err = fmt.Errorf("%w: %w", err, context.Cause(ctx))
+		}
+		return true, notify.RedactURL(err)
+	}
+	defer notify.Drain(resp)
+
+	shouldRetry, err := n.retrier.Check(resp.StatusCode, resp.Body)
+	if err != nil {
+		return shouldRetry, notify.NewErrorWithReason(notify.GetFailureReasonFromStatusCode(resp.StatusCode), err)
+	}
+	return shouldRetry, err
+}
+
+// post sends the payload to the url, signing it when the notifier has a signer.
+func (n *webhookNotifier) post(ctx context.Context, url string, buf *bytes.Buffer) (*http.Response, error) {
+	if n.signer == nil {
+		return notify.PostJSON(ctx, n.client, url, buf)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", notify.UserAgentHeader)
+	req.Header.Set("Content-Type", "application/json")
+	if err := n.signer.Sign(ctx, req.Header, buf.Bytes()); err != nil {
+		return nil, err
+	}
+
+	return n.client.Do(req)
+}
@@ -1,9 +1,13 @@
 package alertmanagerserver
 
 import (
+	"context"
 	"net/url"
+	"slices"
 	"time"
 
+	"github.com/SigNoz/signoz/pkg/alertmanager/alertmanagernotify"
+	"github.com/SigNoz/signoz/pkg/errors"
 	"github.com/SigNoz/signoz/pkg/types/alertmanagertypes"
 	"github.com/prometheus/alertmanager/config"
 	"github.com/prometheus/common/model"
@@ -28,6 +32,18 @@ type Config struct {
 
 	// Configuration for the notification log.
 	NFLog NFLogConfig `mapstructure:"nflog"`
+
+	// Configuration for the trace context sent with the notifications.
+	TraceContext TraceContextConfig `mapstructure:"trace_context"`
+
+	// Configuration for the signing of the webhook notifications.
+	WebhookSigning WebhookSigningConfig `mapstructure:"webhook_signing"`
+
+	// Configuration for the receivers notified by plugins.
+	Plugins PluginsConfig `mapstructure:"plugins"`
+
+	// Configuration for the scheduling of the notifications by priority.
+	Priority PriorityConfig `mapstructure:"priority"`
 }
 
 type AlertsConfig struct {
@@ -64,6 +80,126 @@ type NFLogConfig struct {
 	Retention time.Duration `mapstructure:"retention"`
 }
 
+type TraceContextConfig struct {
+	// Enabled sends the W3C trace context of the span which evaluated the alerts with the webhook notifications
+	// so that their delivery can be traced downstream.
+	Enabled bool `mapstructure:"enabled"`
+
+	// ExcludedReceivers are the names of the receivers notified without trace context, for example because they
+	// reject unknown headers.
+	ExcludedReceivers []string `mapstructure:"excluded_receivers"`
+}
+
+// Propagates returns whether the notifications of the receiver are sent with trace context.
+func (c TraceContextConfig) Propagates(receiver string) bool {
+	return c.Enabled && !slices.Contains(c.ExcludedReceivers, receiver)
+}
+
+type WebhookSigningConfig struct {
+	// Algorithm is the hash function of the HMAC signing the payloads. One of sha256 or sha512.
+	Algorithm string `mapstructure:"algorithm"`
+
+	// SignatureHeader is the header in which the signature of the payload is sent.
+	SignatureHeader string `mapstructure:"signature_header"`
+
+	// TimestampHeader is the header in which the signed timestamp of the notification is sent.
+	TimestampHeader string `mapstructure:"timestamp_header"`
+}
+
+// Signer returns the signer of the webhook notifications signing them with the secret returned by secret.
+func (c WebhookSigningConfig) Signer(secret func(context.Context) (string, error)) (*alertmanagernotify.WebhookSigner, error) {
+	return alertmanagernotify.NewWebhookSigner(c.Algorithm, c.SignatureHeader, c.TimestampHeader, secret)
+}
+
+func (c WebhookSigningConfig) Validate() error {
+	if _, err := c.Signer(nil); err != nil {
+		return errors.Wrapf(err, errors.TypeInvalidInput, errors.CodeInvalidInput, "invalid webhook_signing")
+	}
+
+	return nil
+}
+
+type PluginsConfig struct {
+	// Settings are the settings of the plugins keyed by the names of the plugins. The plugin notifying a channel is
+	// selected on the channel of its organization.
+	Settings map[string]map[string]string `mapstructure:"settings"`
+
+	// Timeout is the maximum duration of a call of a plugin.
+	Timeout time.Duration `mapstructure:"timeout"`
+
+	// MaxAttempts is the maximum number of calls of a plugin for the same notification.
+	MaxAttempts int `mapstructure:"max_attempts"`
+
+	// Backoff is the time waited between two calls of a plugin for the same notification.
+	Backoff time.Duration `mapstructure:"backoff"`
+}
+
+func (c PluginsConfig) Validate() error {
+	if c.Timeout <= 0 {
+		return errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "plugins::timeout must be positive, got %v", c.Timeout)
+	}
+
+	if c.MaxAttempts < 1 {
+		return errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "plugins::max_attempts must be at least 1, got %d", c.MaxAttempts)
+	}
+
+	if c.Backoff < 0 {
+		return errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "plugins::backoff must not be negative, got %v", c.Backoff)
+	}
+
+	return nil
+}
+
+type PriorityConfig struct {
+	// Enabled schedules the notifications by the priority of their alerts, the notifications of higher priority are
+	// sent first when the notifications outnumber the workers.
+	Enabled bool `mapstructure:"enabled"`
+
+	// Label is the label of the alerts whose value is their priority level.
+	Label string `mapstructure:"label"`
+
+	// Levels are the priority levels, from the highest to the lowest. The alerts with any other value of the label
+	// have a priority lower than all the levels. A notification has the highest priority of its alerts.
+	Levels []string `mapstructure:"levels"`
+
+	// Workers is the maximum number of notifications sent at the same time.
+	Workers int `mapstructure:"workers"`
+
+	// Capacity is the maximum number of notifications waiting for a worker. When the queue is full, the oldest
+	// notification of the lowest priority is dropped, unless its priority is higher than the one of the new notification.
+	Capacity int `mapstructure:"capacity"`
+}
+
+func (c PriorityConfig) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+
+	if c.Label == "" {
+		return errors.New(errors.TypeInvalidInput, errors.CodeInvalidInput, "priority::label must not be empty")
+	}
+
+	if len(c.Levels) == 0 {
+		return errors.New(errors.TypeInvalidInput, errors.CodeInvalidInput, "priority::levels must not be empty")
+	}
+
+	for i, level := range c.Levels {
+		if slices.Contains(c.Levels[:i], level) {
+			return errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "priority::levels must be unique, got %q twice", level)
+		}
+	}
+
+	if c.Workers < 1 {
+		return errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "priority::workers must be at least 1, got %d", c.Workers)
+	}
+
+	if c.Capacity < 1 {
+		return errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "priority::capacity must be at least 1, got %d", c.Capacity)
+	}
+
+	return nil
+}
+
 func NewConfig() Config {
 	return Config{
 		ExternalURL: &url.URL{
@@ -100,5 +236,27 @@ func NewConfig() Config {
 			MaintenanceInterval: 15 * time.Minute,
 			Retention:           120 * time.Hour,
 		},
+		TraceContext: TraceContextConfig{
+			Enabled:           false,
+			ExcludedReceivers: []string{},
+		},
+		WebhookSigning: WebhookSigningConfig{
+			Algorithm:       "sha256",
+			SignatureHeader: "X-Signoz-Signature",
+			TimestampHeader: "X-Signoz-Timestamp",
+		},
+		Plugins: PluginsConfig{
+			Settings:    map[string]map[string]string{},
+			Timeout:     10 * time.Second,
+			MaxAttempts: 3,
+			Backoff:     time.Second,
+		},
+		Priority: PriorityConfig{
+			Enabled:  false,
+			Label:    "severity",
+			Levels:   []string{"critical", "high", "error", "warning", "info"},
+			Workers:  32,
+			Capacity: 1024,
+		},
 	}
 }
@@ -0,0 +1,124 @@
+package alertmanagerserver
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/types/alertmanagertypes"
+	"github.com/prometheus/alertmanager/notify"
+	"github.com/prometheus/alertmanager/types"
+)
+
+const (
+	// deadLetterTimeout is the maximum time spent persisting a dead letter.
+	deadLetterTimeout = 10 * time.Second
+)
+
+// deadLetterStage wraps the notification stage of a receiver and persists the notification
+// to the dead letter store when it could not be delivered.
+type deadLetterStage struct {
+	orgID           string
+	stage           notify.Stage
+	deadLetterStore alertmanagertypes.DeadLetterStore
+}
+
+func newDeadLetterStage(orgID string, stage notify.Stage, deadLetterStore alertmanagertypes.DeadLetterStore) *deadLetterStage {
+	return &deadLetterStage{
+		orgID:           orgID,
+		stage:           stage,
+		deadLetterStore: deadLetterStore,
+	}
+}
+
+func (stage *deadLetterStage) Exec(ctx context.Context, logger *slog.Logger, alerts ...*types.Alert) (context.Context, []*types.Alert, error) {
+	newCtx, newAlerts, err := stage.stage.Exec(ctx, logger, alerts...)
+	if err == nil {
+		return newCtx, newAlerts, nil
+	}
+
+	// The dispatcher is shutting down or the group is being flushed again, the notification will be retried by the dispatcher.
+	if errors.Is(ctx.Err(), context.Canceled) {
+		return newCtx, newAlerts, err
+	}
+
+	if storeErr := stage.store(ctx, alerts, err); storeErr != nil {
+		logger.ErrorContext(ctx, "failed to store dead letter", "error", storeErr)
+	}
+
+	return newCtx, newAlerts, err
+}
+
+func (stage *deadLetterStage) store(ctx context.Context, alerts []*types.Alert, cause error) error {
+	receiver, ok := notify.ReceiverName(ctx)
+	if !ok {
+		return errors.New(errors.TypeInternal, errors.CodeInternal, "receiver name missing")
+	}
+
+	groupKey, ok := notify.GroupKey(ctx)
+	if !ok {
+		return errors.New(errors.TypeInternal, errors.CodeInternal, "group key missing")
+	}
+
+	// The notification context is bound to the group interval, it may already be close to its deadline.
+	storeCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), deadLetterTimeout)
+	defer cancel()
+
+	deadLetter, err := stage.deadLetterStore.GetByGroupKey(storeCtx, stage.orgID, receiver, groupKey)
+	if err != nil && !errors.Ast(err, errors.TypeNotFound) {
+		return err
+	}
+
+	if deadLetter != nil {
+		deadLetter.Failed(alerts, cause)
+		return stage.deadLetterStore.Update(storeCtx, deadLetter)
+	}
+
+	deadLetter = alertmanagertypes.NewDeadLetter(stage.orgID, receiver, groupKey, alerts, cause)
+	deadLetter.GroupLabels, _ = notify.GroupLabels(ctx)
+	deadLetter.RouteID, _ = notify.RouteID(ctx)
+	deadLetter.RepeatInterval, _ = notify.RepeatInterval(ctx)
+	deadLetter.MuteTimeIntervals, _ = notify.MuteTimeIntervalNames(ctx)
+	deadLetter.ActiveTimeIntervals, _ = notify.ActiveTimeIntervalNames(ctx)
+
+	return stage.deadLetterStore.Create(storeCtx, deadLetter)
+}
+
+// RedispatchDeadLetter sends the notification of the dead letter again through the notification pipeline.
+// The dead letter is deleted when the notification is delivered and updated with the new failure otherwise.
+func (server *Server) RedispatchDeadLetter(ctx context.Context, deadLetter *alertmanagertypes.DeadLetter) error {
+	// The stages are replaced by every change of the config, the stage of the receiver is taken from the current ones.
+	server.stagesMtx.RLock()
+	stage, ok := server.stages[deadLetter.Receiver]
+	configured := server.stages != nil
+	server.stagesMtx.RUnlock()
+
+	if !configured {
+		return errors.New(errors.TypeInternal, errors.CodeInternal, "notification pipeline is not configured")
+	}
+
+	if !ok {
+		return errors.Newf(errors.TypeNotFound, alertmanagertypes.ErrCodeAlertmanagerChannelNotFound, "cannot find receiver %s", deadLetter.Receiver)
+	}
+
+	ctx = notify.WithReceiverName(ctx, deadLetter.Receiver)
+	ctx = notify.WithGroupKey(ctx, deadLetter.GroupKey)
+	ctx = notify.WithGroupLabels(ctx, deadLetter.GroupLabels)
+	ctx = notify.WithRepeatInterval(ctx, deadLetter.RepeatInterval)
+	ctx = notify.WithMuteTimeIntervals(ctx, deadLetter.MuteTimeIntervals)
+	ctx = notify.WithActiveTimeIntervals(ctx, deadLetter.ActiveTimeIntervals)
+	ctx = notify.WithRouteID(ctx, deadLetter.RouteID)
+	ctx = notify.WithNow(ctx, time.Now())
+
+	if _, _, err := stage.Exec(ctx, server.logger, deadLetter.Alerts...); err != nil {
+		deadLetter.Failed(deadLetter.Alerts, err)
+		if updateErr := server.deadLetterStore.Update(ctx, deadLetter); updateErr != nil {
+			return errors.Join(err, updateErr)
+		}
+
+		return err
+	}
+
+	return server.deadLetterStore.Delete(ctx, deadLetter.OrgID, deadLetter.ID)
+}
@@ -0,0 +1,124 @@
+package alertmanagerserver
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/alertmanager/alertmanagernotify"
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/types/alertmanagertypes"
+	"github.com/SigNoz/signoz/pkg/types/alertmanagertypes/alertmanagertypestest"
+	"github.com/prometheus/alertmanager/notify"
+	"github.com/prometheus/alertmanager/types"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestNotificationContext(ctx context.Context) context.Context {
+	ctx = notify.WithReceiverName(ctx, "test-receiver")
+	ctx = notify.WithGroupKey(ctx, "{}:{alertname=\"test-alert\"}")
+	ctx = notify.WithGroupLabels(ctx, model.LabelSet{"alertname": "test-alert"})
+	ctx = notify.WithRepeatInterval(ctx, time.Hour)
+	ctx = notify.WithRouteID(ctx, "{}")
+	return ctx
+}
+
+func newTestAlert() *types.Alert {
+	return &types.Alert{
+		Alert: model.Alert{
+			Labels:   model.LabelSet{"alertname": "test-alert"},
+			StartsAt: time.Now().Add(-time.Hour),
+			EndsAt:   time.Now().Add(time.Hour),
+		},
+	}
+}
+
+func TestDeadLetterStageExec(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	deadLetterStore := alertmanagertypestest.NewDeadLetterStore()
+	cause := errors.New(errors.TypeInternal, errors.CodeInternal, "connection refused")
+
+	stage := newDeadLetterStage("1", notify.StageFunc(func(ctx context.Context, l *slog.Logger, alerts ...*types.Alert) (context.Context, []*types.Alert, error) {
+		return ctx, nil, cause
+	}), deadLetterStore)
+
+	ctx := newTestNotificationContext(context.Background())
+	_, _, err := stage.Exec(ctx, logger, newTestAlert())
+	assert.Equal(t, cause, err)
+
+	deadLetters, err := deadLetterStore.List(context.Background(), "1")
+	require.NoError(t, err)
+	require.Len(t, deadLetters, 1)
+	assert.Equal(t, "test-receiver", deadLetters[0].Receiver)
+	assert.Equal(t, model.LabelSet{"alertname": "test-alert"}, deadLetters[0].GroupLabels)
+	assert.Equal(t, time.Hour, deadLetters[0].RepeatInterval)
+	assert.Equal(t, cause.Error(), deadLetters[0].LastError)
+	assert.Equal(t, 1, deadLetters[0].Attempts)
+	assert.Len(t, deadLetters[0].Alerts, 1)
+
+	// A repeated failure for the same group updates the existing dead letter.
+	_, _, err = stage.Exec(ctx, logger, newTestAlert())
+	assert.Error(t, err)
+
+	deadLetters, err = deadLetterStore.List(context.Background(), "1")
+	require.NoError(t, err)
+	require.Len(t, deadLetters, 1)
+	assert.Equal(t, 2, deadLetters[0].Attempts)
+}
+
+func TestDeadLetterStageExecCanceled(t *testing.T) {
+	deadLetterStore := alertmanagertypestest.NewDeadLetterStore()
+
+	stage := newDeadLetterStage("1", notify.StageFunc(func(ctx context.Context, l *slog.Logger, alerts ...*types.Alert) (context.Context, []*types.Alert, error) {
+		return ctx, nil, ctx.Err()
+	}), deadLetterStore)
+
+	ctx, cancel := context.WithCancel(newTestNotificationContext(context.Background()))
+	cancel()
+
+	_, _, err := stage.Exec(ctx, slog.New(slog.NewTextHandler(io.Discard, nil)), newTestAlert())
+	assert.ErrorIs(t, err, context.Canceled)
+
+	deadLetters, err := deadLetterStore.List(context.Background(), "1")
+	require.NoError(t, err)
+	assert.Empty(t, deadLetters)
+}
+
+func TestServerRedispatchDeadLetter(t *testing.T) {
+	deadLetterStore := alertmanagertypestest.NewDeadLetterStore()
+	server, err := New(context.Background(), slog.New(slog.NewTextHandler(io.Discard, nil)), prometheus.NewRegistry(), NewConfig(), "1", alertmanagertypestest.NewStateStore(), deadLetterStore, nil, nil, alertmanagernotify.HTTPClientConfig{})
+	require.NoError(t, err)
+	defer func() { assert.NoError(t, server.Stop(context.Background())) }()
+
+	var delivered error
+	var receivedGroupKey string
+	server.stages = notify.RoutingStage{
+		"test-receiver": notify.StageFunc(func(ctx context.Context, l *slog.Logger, alerts ...*types.Alert) (context.Context, []*types.Alert, error) {
+			receivedGroupKey, _ = notify.GroupKey(ctx)
+			return ctx, alerts, delivered
+		}),
+	}
+
+	deadLetter := alertmanagertypes.NewDeadLetter("1", "test-receiver", "{}:{alertname=\"test-alert\"}", []*types.Alert{newTestAlert()}, errors.New(errors.TypeInternal, errors.CodeInternal, "connection refused"))
+	require.NoError(t, deadLetterStore.Create(context.Background(), deadLetter))
+
+	delivered = errors.New(errors.TypeInternal, errors.CodeInternal, "still refused")
+	assert.Error(t, server.RedispatchDeadLetter(context.Background(), deadLetter))
+
+	updated, err := deadLetterStore.Get(context.Background(), "1", deadLetter.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 2, updated.Attempts)
+	assert.Equal(t, delivered.Error(), updated.LastError)
+
+	delivered = nil
+	assert.NoError(t, server.RedispatchDeadLetter(context.Background(), deadLetter))
+	assert.Equal(t, deadLetter.GroupKey, receivedGroupKey)
+
+	_, err = deadLetterStore.Get(context.Background(), "1", deadLetter.ID)
+	assert.True(t, errors.Ast(err, errors.TypeNotFound))
+}
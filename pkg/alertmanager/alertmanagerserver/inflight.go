@@ -0,0 +1,71 @@
+package alertmanagerserver
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/prometheus/alertmanager/notify"
+	"github.com/prometheus/alertmanager/types"
+)
+
+const (
+	// inflightDrainTimeout is the maximum time spent waiting for the notifications in flight when the config changes.
+	inflightDrainTimeout = 5 * time.Second
+)
+
+// inflightStage runs the notifications of a receiver outside of the lifetime of the dispatcher which started them.
+// Stopping the dispatcher on a config change does not cancel the notifications in flight, they keep their deadline
+// and are only canceled when the server stops.
+type inflightStage struct {
+	ctx      context.Context
+	inflight *sync.WaitGroup
+	stage    notify.Stage
+}
+
+func newInflightStage(ctx context.Context, inflight *sync.WaitGroup, stage notify.Stage) *inflightStage {
+	return &inflightStage{
+		ctx:      ctx,
+		inflight: inflight,
+		stage:    stage,
+	}
+}
+
+func (stage *inflightStage) Exec(ctx context.Context, logger *slog.Logger, alerts ...*types.Alert) (context.Context, []*types.Alert, error) {
+	stage.inflight.Add(1)
+	defer stage.inflight.Done()
+
+	execCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	defer cancel()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		var cancelDeadline context.CancelFunc
+		execCtx, cancelDeadline = context.WithDeadline(execCtx, deadline)
+		defer cancelDeadline()
+	}
+
+	stop := context.AfterFunc(stage.ctx, cancel)
+	defer stop()
+
+	return stage.stage.Exec(execCtx, logger, alerts...)
+}
+
+// waitInflight waits for the notifications in flight, for at most inflightDrainTimeout.
+func (server *Server) waitInflight(ctx context.Context, inflight *sync.WaitGroup) {
+	doneC := make(chan struct{})
+	go func() {
+		inflight.Wait()
+		close(doneC)
+	}()
+
+	timer := time.NewTimer(inflightDrainTimeout)
+	defer timer.Stop()
+
+	select {
+	case <-doneC:
+	case <-timer.C:
+		server.logger.WarnContext(ctx, "notifications still in flight after the config change, they may be sent again", "timeout", inflightDrainTimeout)
+	case <-ctx.Done():
+	}
+}
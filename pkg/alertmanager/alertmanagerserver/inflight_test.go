@@ -0,0 +1,72 @@
+package alertmanagerserver
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/prometheus/alertmanager/notify"
+	"github.com/prometheus/alertmanager/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInflightStageExec(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	serverCtx, serverCancel := context.WithCancel(context.Background())
+	defer serverCancel()
+
+	startedC := make(chan struct{})
+	releaseC := make(chan struct{})
+	errC := make(chan error, 1)
+	inflight := &sync.WaitGroup{}
+	stage := newInflightStage(serverCtx, inflight, notify.StageFunc(func(ctx context.Context, l *slog.Logger, alerts ...*types.Alert) (context.Context, []*types.Alert, error) {
+		close(startedC)
+		<-releaseC
+		_, hasDeadline := ctx.Deadline()
+		assert.True(t, hasDeadline)
+		return ctx, alerts, ctx.Err()
+	}))
+
+	// The notification outlives the context of the dispatcher which started it.
+	dispatcherCtx, dispatcherCancel := context.WithTimeout(newTestNotificationContext(context.Background()), time.Minute)
+	go func() {
+		_, _, err := stage.Exec(dispatcherCtx, logger, newTestAlert())
+		errC <- err
+	}()
+
+	<-startedC
+	dispatcherCancel()
+	close(releaseC)
+	assert.NoError(t, <-errC)
+
+	doneC := make(chan struct{})
+	go func() {
+		inflight.Wait()
+		close(doneC)
+	}()
+
+	select {
+	case <-doneC:
+	case <-time.After(time.Second):
+		require.Fail(t, "the notification is still tracked as in flight")
+	}
+}
+
+func TestInflightStageExecServerStopped(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	serverCtx, serverCancel := context.WithCancel(context.Background())
+	stage := newInflightStage(serverCtx, &sync.WaitGroup{}, notify.StageFunc(func(ctx context.Context, l *slog.Logger, alerts ...*types.Alert) (context.Context, []*types.Alert, error) {
+		serverCancel()
+		<-ctx.Done()
+		return ctx, alerts, ctx.Err()
+	}))
+
+	_, _, err := stage.Exec(newTestNotificationContext(context.Background()), logger, newTestAlert())
+	assert.ErrorIs(t, err, context.Canceled)
+}
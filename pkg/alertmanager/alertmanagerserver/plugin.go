@@ -0,0 +1,33 @@
+package alertmanagerserver
+
+import (
+	"context"
+
+	"github.com/SigNoz/signoz/pkg/alertmanager/alertmanagernotify"
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/factory"
+)
+
+// NewPlugins creates the plugins of the given factories with their settings, keyed by their names. Every plugin is
+// created once however many channels select it.
+func NewPlugins(ctx context.Context, settings factory.ProviderSettings, config PluginsConfig, factories factory.NamedMap[factory.ProviderFactory[alertmanagernotify.Receiver, alertmanagernotify.ReceiverConfig]]) (map[string]alertmanagernotify.Receiver, error) {
+	for name := range config.Settings {
+		if _, err := factories.Get(name); err != nil {
+			return nil, errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "plugins::settings has the settings of the plugin %q which is not registered", name)
+		}
+	}
+
+	plugins := make(map[string]alertmanagernotify.Receiver)
+	for _, f := range factories.GetInOrder() {
+		name := f.Name().String()
+
+		plugin, err := f.New(ctx, settings, alertmanagernotify.ReceiverConfig{Settings: config.Settings[name]})
+		if err != nil {
+			return nil, errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "cannot create the plugin %q: %v", name, err)
+		}
+
+		plugins[name] = plugin
+	}
+
+	return plugins, nil
+}
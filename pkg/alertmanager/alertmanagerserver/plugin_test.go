@@ -0,0 +1,127 @@
+package alertmanagerserver
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/alertmanager/alertmanagernotify"
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/factory"
+	"github.com/SigNoz/signoz/pkg/factory/factorytest"
+	"github.com/SigNoz/signoz/pkg/types/alertmanagertypes"
+	"github.com/SigNoz/signoz/pkg/types/alertmanagertypes/alertmanagertypestest"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recordingReceiver struct {
+	mu       sync.Mutex
+	settings map[string]string
+	alerts   []*alertmanagertypes.Alert
+}
+
+func (receiver *recordingReceiver) Notify(_ context.Context, alerts []*alertmanagertypes.Alert) error {
+	receiver.mu.Lock()
+	defer receiver.mu.Unlock()
+
+	receiver.alerts = append(receiver.alerts, alerts...)
+	return nil
+}
+
+func newRecordingReceiverFactory(created *int) factory.ProviderFactory[alertmanagernotify.Receiver, alertmanagernotify.ReceiverConfig] {
+	return factory.NewProviderFactory(factory.MustNewName("incidenttool"), func(_ context.Context, _ factory.ProviderSettings, config alertmanagernotify.ReceiverConfig) (alertmanagernotify.Receiver, error) {
+		*created++
+		return &recordingReceiver{settings: config.Settings}, nil
+	})
+}
+
+type channelStore struct {
+	plugins map[string]string
+}
+
+func (store *channelStore) GetSigningSecret(context.Context, string, string) (string, error) {
+	return "", nil
+}
+
+func (store *channelStore) GetPlugin(_ context.Context, _ string, name string) (string, error) {
+	return store.plugins[name], nil
+}
+
+func TestNewPlugins(t *testing.T) {
+	var created int
+	factories := factory.MustNewNamedMap(newRecordingReceiverFactory(&created))
+
+	config := NewConfig().Plugins
+	config.Settings = map[string]map[string]string{"incidenttool": {"url": "https://incidents.example.com"}}
+
+	plugins, err := NewPlugins(context.Background(), factorytest.NewSettings(), config, factories)
+	require.NoError(t, err)
+	require.Len(t, plugins, 1)
+	assert.Equal(t, 1, created)
+	assert.Equal(t, map[string]string{"url": "https://incidents.example.com"}, plugins["incidenttool"].(*recordingReceiver).settings)
+
+	config.Settings["unknown"] = map[string]string{}
+	_, err = NewPlugins(context.Background(), factorytest.NewSettings(), config, factories)
+	assert.True(t, errors.Ast(err, errors.TypeInvalidInput))
+}
+
+func TestServerTestReceiverPlugin(t *testing.T) {
+	srvCfg := NewConfig()
+	plugin := &recordingReceiver{}
+	store := &channelStore{plugins: map[string]string{"oncall": "incidenttool"}}
+
+	server, err := New(context.Background(), slog.New(slog.NewTextHandler(io.Discard, nil)), prometheus.NewRegistry(), srvCfg, "1", alertmanagertypestest.NewStateStore(), alertmanagertypestest.NewDeadLetterStore(), store, map[string]alertmanagernotify.Receiver{"incidenttool": plugin}, alertmanagernotify.HTTPClientConfig{})
+	require.NoError(t, err)
+
+	amConfig, err := alertmanagertypes.NewDefaultConfig(srvCfg.Global, srvCfg.Route, "1")
+	require.NoError(t, err)
+	require.NoError(t, server.SetConfig(context.Background(), amConfig))
+	defer require.NoError(t, server.Stop(context.Background()))
+
+	// The receiver is only notified by the plugin selected by its channel.
+	require.NoError(t, server.TestReceiver(context.Background(), alertmanagertypes.Receiver{Name: "oncall"}))
+	require.Len(t, plugin.alerts, 1)
+	assert.Equal(t, "Test Alert (oncall)", string(plugin.alerts[0].Labels["alertname"]))
+
+	// The channel of another receiver selects no plugin.
+	require.NoError(t, server.TestReceiver(context.Background(), alertmanagertypes.Receiver{Name: "pager"}))
+	assert.Len(t, plugin.alerts, 1)
+
+	// The plugin is read when notifying, the integrations are not built again.
+	store.plugins["pager"] = "incidenttool"
+	require.NoError(t, server.TestReceiver(context.Background(), alertmanagertypes.Receiver{Name: "pager"}))
+	assert.Len(t, plugin.alerts, 2)
+}
+
+func TestServerTestReceiverWithoutPlugins(t *testing.T) {
+	srvCfg := NewConfig()
+	server, err := New(context.Background(), slog.New(slog.NewTextHandler(io.Discard, nil)), prometheus.NewRegistry(), srvCfg, "1", alertmanagertypestest.NewStateStore(), alertmanagertypestest.NewDeadLetterStore(), &channelStore{}, nil, alertmanagernotify.HTTPClientConfig{})
+	require.NoError(t, err)
+
+	amConfig, err := alertmanagertypes.NewDefaultConfig(srvCfg.Global, srvCfg.Route, "1")
+	require.NoError(t, err)
+	require.NoError(t, server.SetConfig(context.Background(), amConfig))
+	defer require.NoError(t, server.Stop(context.Background()))
+
+	err = server.TestReceiver(context.Background(), alertmanagertypes.Receiver{Name: "pager"})
+	assert.True(t, errors.Ast(err, errors.TypeNotFound))
+}
+
+func TestPluginsConfigValidate(t *testing.T) {
+	config := NewConfig().Plugins
+	assert.NoError(t, config.Validate())
+
+	config.MaxAttempts = 0
+	assert.Error(t, config.Validate())
+
+	config.MaxAttempts, config.Timeout = 1, 0
+	assert.Error(t, config.Validate())
+
+	config.Timeout, config.Backoff = time.Second, -time.Second
+	assert.Error(t, config.Validate())
+}
@@ -0,0 +1,251 @@
+package alertmanagerserver
+
+import (
+	"container/list"
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/prometheus/alertmanager/notify"
+	"github.com/prometheus/alertmanager/types"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/model"
+)
+
+const (
+	// priorityOther is the name of the priority of the alerts without any of the priority levels.
+	priorityOther = "other"
+)
+
+var (
+	errPriorityDropped = errors.New(errors.TypeUnavailable, errors.MustNewCode("notification_dropped"), "notification dropped, the priority queue is full")
+)
+
+type priorityMetrics struct {
+	queued    *prometheus.GaugeVec
+	dropped   *prometheus.CounterVec
+	delivered *prometheus.CounterVec
+	wait      *prometheus.HistogramVec
+}
+
+// newPriorityMetrics returns the metrics of the priority queues. The servers of the organizations share the registry,
+// hence the metrics already registered are reused.
+func newPriorityMetrics(registry prometheus.Registerer) *priorityMetrics {
+	return &priorityMetrics{
+		queued: registerOrExisting(registry, prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: "alertmanager",
+			Name:      "notification_priority_queued",
+			Help:      "The number of notifications waiting for a worker.",
+		}, []string{"priority"})),
+		dropped: registerOrExisting(registry, prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "alertmanager",
+			Name:      "notification_priority_dropped_total",
+			Help:      "The total number of notifications dropped because the priority queue was full.",
+		}, []string{"priority"})),
+		delivered: registerOrExisting(registry, prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "alertmanager",
+			Name:      "notification_priority_delivered_total",
+			Help:      "The total number of notifications handed to a worker.",
+		}, []string{"priority"})),
+		wait: registerOrExisting(registry, prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: "alertmanager",
+			Name:      "notification_priority_wait_seconds",
+			Help:      "The time spent by the notifications waiting for a worker.",
+			Buckets:   []float64{.001, .01, .1, .5, 1, 5, 10, 30, 60},
+		}, []string{"priority"})),
+	}
+}
+
+func registerOrExisting[C prometheus.Collector](registry prometheus.Registerer, collector C) C {
+	if err := registry.Register(collector); err != nil {
+		var alreadyRegistered prometheus.AlreadyRegisteredError
+		if errors.As(err, &alreadyRegistered) {
+			if existing, ok := alreadyRegistered.ExistingCollector.(C); ok {
+				return existing
+			}
+		}
+	}
+
+	return collector
+}
+
+type priorityJob struct {
+	level      int
+	enqueuedAt time.Time
+	element    *list.Element
+	// readyC is closed once the job is either granted a worker or dropped.
+	readyC  chan struct{}
+	dropped bool
+}
+
+// priorityQueue bounds the number of notifications sent at the same time and grants the workers to the waiting
+// notifications by priority, then by order of arrival. A full queue drops the oldest notification of the lowest
+// priority, or rejects the new notification when every waiting notification has a higher priority.
+type priorityQueue struct {
+	config  PriorityConfig
+	metrics *priorityMetrics
+
+	mtx     sync.Mutex
+	running int
+	queued  int
+	// levels are the waiting jobs by priority, the last one is the priority of the alerts without any of the levels.
+	levels []*list.List
+}
+
+func newPriorityQueue(config PriorityConfig, registry prometheus.Registerer) *priorityQueue {
+	levels := make([]*list.List, len(config.Levels)+1)
+	for i := range levels {
+		levels[i] = list.New()
+	}
+
+	return &priorityQueue{
+		config:  config,
+		metrics: newPriorityMetrics(registry),
+		levels:  levels,
+	}
+}
+
+// level returns the priority of the alerts, the highest of the priorities of the alerts. Lower is higher.
+func (queue *priorityQueue) level(alerts []*types.Alert) int {
+	level := len(queue.config.Levels)
+	for _, alert := range alerts {
+		value := string(alert.Labels[model.LabelName(queue.config.Label)])
+		for i := range queue.config.Levels[:level] {
+			if queue.config.Levels[i] == value {
+				level = i
+				break
+			}
+		}
+	}
+
+	return level
+}
+
+func (queue *priorityQueue) name(level int) string {
+	if level < len(queue.config.Levels) {
+		return queue.config.Levels[level]
+	}
+
+	return priorityOther
+}
+
+// acquire waits for a worker. It returns errPriorityDropped when the job is dropped from the queue, release must be
+// called once the job is done otherwise.
+func (queue *priorityQueue) acquire(ctx context.Context, level int) error {
+	queue.mtx.Lock()
+	if queue.running < queue.config.Workers && queue.queued == 0 {
+		queue.running++
+		queue.mtx.Unlock()
+		queue.metrics.delivered.WithLabelValues(queue.name(level)).Inc()
+		queue.metrics.wait.WithLabelValues(queue.name(level)).Observe(0)
+		return nil
+	}
+
+	if queue.queued >= queue.config.Capacity {
+		lowest := len(queue.levels) - 1
+		for queue.levels[lowest].Len() == 0 {
+			lowest--
+		}
+
+		if lowest < level {
+			queue.mtx.Unlock()
+			queue.metrics.dropped.WithLabelValues(queue.name(level)).Inc()
+			return errPriorityDropped
+		}
+
+		victim := queue.levels[lowest].Front().Value.(*priorityJob)
+		queue.remove(victim)
+		victim.dropped = true
+		close(victim.readyC)
+		queue.metrics.dropped.WithLabelValues(queue.name(lowest)).Inc()
+	}
+
+	job := &priorityJob{level: level, enqueuedAt: time.Now(), readyC: make(chan struct{})}
+	job.element = queue.levels[level].PushBack(job)
+	queue.queued++
+	queue.metrics.queued.WithLabelValues(queue.name(level)).Inc()
+	queue.mtx.Unlock()
+
+	select {
+	case <-job.readyC:
+	case <-ctx.Done():
+		queue.mtx.Lock()
+		select {
+		case <-job.readyC:
+			// The job was granted a worker or dropped while the context was done.
+			queue.mtx.Unlock()
+			if !job.dropped {
+				queue.release()
+			}
+		default:
+			queue.remove(job)
+			queue.mtx.Unlock()
+		}
+
+		return ctx.Err()
+	}
+
+	if job.dropped {
+		return errPriorityDropped
+	}
+
+	return nil
+}
+
+// release hands the worker of a job done to the oldest waiting job of the highest priority.
+func (queue *priorityQueue) release() {
+	queue.mtx.Lock()
+	defer queue.mtx.Unlock()
+
+	queue.running--
+	for _, jobs := range queue.levels {
+		if jobs.Len() == 0 {
+			continue
+		}
+
+		job := jobs.Front().Value.(*priorityJob)
+		queue.remove(job)
+		queue.running++
+		queue.metrics.delivered.WithLabelValues(queue.name(job.level)).Inc()
+		queue.metrics.wait.WithLabelValues(queue.name(job.level)).Observe(time.Since(job.enqueuedAt).Seconds())
+		close(job.readyC)
+		return
+	}
+}
+
+// remove removes the job from the queue, the lock must be held.
+func (queue *priorityQueue) remove(job *priorityJob) {
+	queue.levels[job.level].Remove(job.element)
+	queue.queued--
+	queue.metrics.queued.WithLabelValues(queue.name(job.level)).Dec()
+}
+
+// priorityStage runs the notifications of a receiver once the priority queue grants them a worker. The notifications
+// dropped from the queue fail, hence they are persisted by the dead letter stage.
+type priorityStage struct {
+	queue *priorityQueue
+	stage notify.Stage
+}
+
+func newPriorityStage(queue *priorityQueue, stage notify.Stage) *priorityStage {
+	return &priorityStage{
+		queue: queue,
+		stage: stage,
+	}
+}
+
+func (stage *priorityStage) Exec(ctx context.Context, logger *slog.Logger, alerts ...*types.Alert) (context.Context, []*types.Alert, error) {
+	level := stage.queue.level(alerts)
+	if err := stage.queue.acquire(ctx, level); err != nil {
+		if errors.Is(err, errPriorityDropped) {
+			logger.WarnContext(ctx, "dropped notification from the priority queue", "priority", stage.queue.name(level), "alerts", len(alerts))
+		}
+
+		return ctx, nil, err
+	}
+	defer stage.queue.release()
+
+	return stage.stage.Exec(ctx, logger, alerts...)
+}
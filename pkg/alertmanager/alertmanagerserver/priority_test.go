@@ -0,0 +1,170 @@
+package alertmanagerserver
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/prometheus/alertmanager/notify"
+	"github.com/prometheus/alertmanager/types"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestPriorityAlert(severity string) *types.Alert {
+	alert := newTestAlert()
+	alert.Labels = alert.Labels.Merge(model.LabelSet{"severity": model.LabelValue(severity)})
+	return alert
+}
+
+func newTestPriorityQueue(t *testing.T, workers int, capacity int) *priorityQueue {
+	config := NewConfig().Priority
+	config.Enabled = true
+	config.Workers = workers
+	config.Capacity = capacity
+	require.NoError(t, config.Validate())
+
+	return newPriorityQueue(config, prometheus.NewRegistry())
+}
+
+// waitQueued waits for the queue to hold n waiting notifications.
+func waitQueued(t *testing.T, queue *priorityQueue, n int) {
+	require.Eventually(t, func() bool {
+		queue.mtx.Lock()
+		defer queue.mtx.Unlock()
+		return queue.queued == n
+	}, time.Second, time.Millisecond)
+}
+
+// blockWorker holds the only worker of the queue until the returned func is called.
+func blockWorker(t *testing.T, queue *priorityQueue) func() {
+	require.NoError(t, queue.acquire(context.Background(), 0))
+	return queue.release
+}
+
+func TestPriorityQueueLevel(t *testing.T) {
+	queue := newTestPriorityQueue(t, 1, 1)
+
+	assert.Equal(t, 0, queue.level([]*types.Alert{newTestPriorityAlert("warning"), newTestPriorityAlert("critical")}))
+	assert.Equal(t, 3, queue.level([]*types.Alert{newTestPriorityAlert("unknown"), newTestPriorityAlert("warning")}))
+	assert.Equal(t, 5, queue.level([]*types.Alert{newTestAlert()}))
+	assert.Equal(t, priorityOther, queue.name(5))
+}
+
+func TestPriorityStageExecOrder(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	queue := newTestPriorityQueue(t, 1, 10)
+
+	var mtx sync.Mutex
+	var order []string
+	stage := newPriorityStage(queue, notify.StageFunc(func(ctx context.Context, l *slog.Logger, alerts ...*types.Alert) (context.Context, []*types.Alert, error) {
+		mtx.Lock()
+		defer mtx.Unlock()
+		order = append(order, string(alerts[0].Labels["severity"]))
+		return ctx, alerts, nil
+	}))
+
+	release := blockWorker(t, queue)
+
+	var wg sync.WaitGroup
+	for i, severity := range []string{"info", "warning", "unknown", "critical", "warning"} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, _, err := stage.Exec(context.Background(), logger, newTestPriorityAlert(severity))
+			assert.NoError(t, err)
+		}()
+		waitQueued(t, queue, i+1)
+	}
+
+	release()
+	wg.Wait()
+
+	assert.Equal(t, []string{"critical", "warning", "warning", "info", "unknown"}, order)
+	assert.Equal(t, float64(2), testutil.ToFloat64(queue.metrics.delivered.WithLabelValues("warning")))
+	assert.Equal(t, float64(0), testutil.ToFloat64(queue.metrics.queued.WithLabelValues("warning")))
+}
+
+func TestPriorityStageExecSaturated(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	queue := newTestPriorityQueue(t, 1, 2)
+	stage := newPriorityStage(queue, notify.StageFunc(func(ctx context.Context, l *slog.Logger, alerts ...*types.Alert) (context.Context, []*types.Alert, error) {
+		return ctx, alerts, nil
+	}))
+
+	release := blockWorker(t, queue)
+
+	exec := func(severity string) chan error {
+		errC := make(chan error, 1)
+		go func() {
+			_, _, err := stage.Exec(context.Background(), logger, newTestPriorityAlert(severity))
+			errC <- err
+		}()
+		return errC
+	}
+
+	oldestInfoC := exec("info")
+	waitQueued(t, queue, 1)
+	newestInfoC := exec("info")
+	waitQueued(t, queue, 2)
+
+	// The oldest notification of the lowest priority makes room for the critical one.
+	criticalC := exec("critical")
+	assert.True(t, errors.Is(<-oldestInfoC, errPriorityDropped))
+	waitQueued(t, queue, 2)
+
+	// The new notification is rejected when every waiting notification has a higher priority.
+	_, _, err := stage.Exec(context.Background(), logger, newTestAlert())
+	assert.True(t, errors.Is(err, errPriorityDropped))
+
+	release()
+	assert.NoError(t, <-criticalC)
+	assert.NoError(t, <-newestInfoC)
+
+	assert.Equal(t, float64(1), testutil.ToFloat64(queue.metrics.dropped.WithLabelValues("info")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(queue.metrics.dropped.WithLabelValues(priorityOther)))
+	assert.Equal(t, float64(0), testutil.ToFloat64(queue.metrics.dropped.WithLabelValues("critical")))
+}
+
+func TestPriorityStageExecCanceled(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	queue := newTestPriorityQueue(t, 1, 1)
+	stage := newPriorityStage(queue, notify.StageFunc(func(ctx context.Context, l *slog.Logger, alerts ...*types.Alert) (context.Context, []*types.Alert, error) {
+		return ctx, alerts, nil
+	}))
+
+	release := blockWorker(t, queue)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errC := make(chan error, 1)
+	go func() {
+		_, _, err := stage.Exec(ctx, logger, newTestPriorityAlert("critical"))
+		errC <- err
+	}()
+	waitQueued(t, queue, 1)
+
+	cancel()
+	assert.ErrorIs(t, <-errC, context.Canceled)
+	waitQueued(t, queue, 0)
+
+	// The worker is handed to the next notification.
+	release()
+	_, _, err := stage.Exec(context.Background(), logger, newTestPriorityAlert("info"))
+	assert.NoError(t, err)
+}
+
+func TestPriorityMetricsShared(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	first := newPriorityMetrics(registry)
+	second := newPriorityMetrics(registry)
+
+	second.dropped.WithLabelValues("critical").Inc()
+	assert.Equal(t, float64(1), testutil.ToFloat64(first.dropped.WithLabelValues("critical")))
+}
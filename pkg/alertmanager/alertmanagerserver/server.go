@@ -7,6 +7,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/SigNoz/signoz/pkg/alertmanager/alertmanagernotify"
 	"github.com/SigNoz/signoz/pkg/errors"
 	"github.com/SigNoz/signoz/pkg/types/alertmanagertypes"
 	"github.com/prometheus/alertmanager/dispatch"
@@ -48,6 +49,22 @@ type Server struct {
 	// store is the backing store for the alertmanager
 	stateStore alertmanagertypes.StateStore
 
+	// deadLetterStore is the store for the notifications which could not be delivered
+	deadLetterStore alertmanagertypes.DeadLetterStore
+
+	// channelStore returns the secrets signing the webhook notifications and the plugins of the channels, nil when
+	// they are neither signed nor notify plugins
+	channelStore alertmanagertypes.ChannelStore
+
+	// plugins are the receiver plugins keyed by their names
+	plugins map[string]alertmanagernotify.Receiver
+
+	// httpClient is the config of the http clients of the integrations
+	httpClient alertmanagernotify.HTTPClientConfig
+
+	// traceContexts are the spans which evaluated the alerts, continued by their notifications
+	traceContexts *alertmanagertypes.TraceContexts
+
 	// alertmanager primitives from upstream alertmanager
 	alerts            *mem.Alerts
 	nflog             *nflog.Log
@@ -58,21 +75,48 @@ type Server struct {
 	silences          *silence.Silences
 	timeIntervals     map[string][]timeinterval.TimeInterval
 	pipelineBuilder   *notify.PipelineBuilder
+	stages            notify.RoutingStage
+	stagesMtx         sync.RWMutex
 	marker            *alertmanagertypes.MemMarker
 	tmpl              *template.Template
 	wg                sync.WaitGroup
 	stopc             chan struct{}
+
+	// configMtx serializes the updates of the config.
+	configMtx sync.Mutex
+
+	// priorityQueue schedules the notifications of all the configs by priority, it is nil when disabled.
+	priorityQueue *priorityQueue
+
+	// inflight tracks the notifications in flight of the current config.
+	inflight *sync.WaitGroup
+
+	// notifyCtx bounds the notifications in flight, it is only canceled when the server stops.
+	notifyCtx    context.Context
+	notifyCancel context.CancelFunc
+
+	// stateMtx serializes the updates of the state of the organization in the state store.
+	stateMtx sync.Mutex
 }
 
-func New(ctx context.Context, logger *slog.Logger, registry prometheus.Registerer, srvConfig Config, orgID string, stateStore alertmanagertypes.StateStore) (*Server, error) {
+func New(ctx context.Context, logger *slog.Logger, registry prometheus.Registerer, srvConfig Config, orgID string, stateStore alertmanagertypes.StateStore, deadLetterStore alertmanagertypes.DeadLetterStore, channelStore alertmanagertypes.ChannelStore, plugins map[string]alertmanagernotify.Receiver, httpClient alertmanagernotify.HTTPClientConfig) (*Server, error) {
 	server := &Server{
-		logger:     logger.With("pkg", "go.signoz.io/pkg/alertmanager/alertmanagerserver"),
-		registry:   registry,
-		srvConfig:  srvConfig,
-		orgID:      orgID,
-		stateStore: stateStore,
-		stopc:      make(chan struct{}),
+		logger:          logger.With("pkg", "go.signoz.io/pkg/alertmanager/alertmanagerserver"),
+		registry:        registry,
+		srvConfig:       srvConfig,
+		orgID:           orgID,
+		stateStore:      stateStore,
+		deadLetterStore: deadLetterStore,
+		channelStore:    channelStore,
+		plugins:         plugins,
+		httpClient:      httpClient,
+		stopc:           make(chan struct{}),
 	}
+	server.notifyCtx, server.notifyCancel = context.WithCancel(context.Background())
+	if srvConfig.Priority.Enabled {
+		server.priorityQueue = newPriorityQueue(srvConfig.Priority, server.registry)
+	}
+
 	// initialize marker
 	server.marker = alertmanagertypes.NewMarker(server.registry)
 
@@ -134,21 +178,7 @@ func New(ctx context.Context, logger *slog.Logger, registry prometheus.Registere
 				// Don't return here - we need to snapshot our state first.
 			}
 
-			storableSilences, err := server.stateStore.Get(ctx, server.orgID)
-			if err != nil && !errors.Ast(err, errors.TypeNotFound) {
-				return 0, err
-			}
-
-			if storableSilences == nil {
-				storableSilences = alertmanagertypes.NewStoreableState(server.orgID)
-			}
-
-			c, err := storableSilences.Set(alertmanagertypes.SilenceStateName, server.silences)
-			if err != nil {
-				return 0, err
-			}
-
-			return c, server.stateStore.Set(ctx, server.orgID, storableSilences)
+			return server.snapshotSilences(ctx)
 		})
 
 	}()
@@ -163,6 +193,9 @@ func New(ctx context.Context, logger *slog.Logger, registry prometheus.Registere
 				// Don't return without saving the current state.
 			}
 
+			server.stateMtx.Lock()
+			defer server.stateMtx.Unlock()
+
 			storableNFLog, err := server.stateStore.Get(ctx, server.orgID)
 			if err != nil && !errors.Ast(err, errors.TypeNotFound) {
 				return 0, err
@@ -181,7 +214,9 @@ func New(ctx context.Context, logger *slog.Logger, registry prometheus.Registere
 		})
 	}()
 
-	server.alerts, err = mem.NewAlerts(ctx, server.marker, server.srvConfig.Alerts.GCInterval, nil, server.logger, server.registry)
+	// The spans of the alerts are forgotten once the alerts are garbage collected.
+	server.traceContexts = alertmanagertypes.NewTraceContexts()
+	server.alerts, err = mem.NewAlerts(ctx, server.marker, server.srvConfig.Alerts.GCInterval, server.traceContexts, server.logger, server.registry)
 	if err != nil {
 		return nil, err
 	}
@@ -200,30 +235,109 @@ func (server *Server) GetAlerts(ctx context.Context, params alertmanagertypes.Ge
 }
 
 func (server *Server) PutAlerts(ctx context.Context, postableAlerts alertmanagertypes.PostableAlerts) error {
-	alerts, err := alertmanagertypes.NewAlertsFromPostableAlerts(postableAlerts, time.Duration(server.srvConfig.Global.ResolveTimeout), time.Now())
+	alerts, errs := alertmanagertypes.NewAlertsFromPostableAlerts(postableAlerts, time.Duration(server.srvConfig.Global.ResolveTimeout), time.Now())
+
+	if server.srvConfig.TraceContext.Enabled {
+		server.traceContexts.Put(ctx, alerts...)
+	}
 
 	// Notification sending alert takes precedence over validation errors.
 	if err := server.alerts.Put(alerts...); err != nil {
-		return err
+		return errors.Wrapf(err, errors.TypeInternal, errors.CodeInternal, "failed to put alerts")
 	}
 
+	if len(errs) > 0 {
+		return errors.Wrapf(errors.Join(errs...), errors.TypeInvalidInput, errors.CodeInvalidInput, "%d alerts are invalid", len(errs))
+	}
+
+	return nil
+}
+
+// CreateSilence creates the silence and returns its id. The silence is persisted to the state store right away so
+// that it survives a restart before the next maintenance.
+func (server *Server) CreateSilence(ctx context.Context, sil *alertmanagertypes.Silence) (string, error) {
+	if err := server.silences.Set(sil); err != nil {
+		if errors.Is(err, silence.ErrNotFound) {
+			return "", errors.Wrapf(err, errors.TypeNotFound, alertmanagertypes.ErrCodeAlertmanagerSilenceNotFound, "silence %s not found", sil.Id)
+		}
+
+		return "", errors.Wrapf(err, errors.TypeInvalidInput, alertmanagertypes.ErrCodeAlertmanagerSilenceInvalid, "cannot create silence")
+	}
+
+	if _, err := server.snapshotSilences(ctx); err != nil {
+		server.logger.ErrorContext(ctx, "failed to persist silences", "silence_id", sil.Id, "error", err)
+	}
+
+	return sil.Id, nil
+}
+
+// ListSilences lists the silences, including the expired silences which have not been garbage collected yet.
+func (server *Server) ListSilences(ctx context.Context) (alertmanagertypes.GettableSilences, error) {
+	silences, _, err := server.silences.Query()
 	if err != nil {
-		return errors.Join(err...)
+		return nil, err
+	}
+
+	return alertmanagertypes.NewGettableSilencesFromSilences(silences)
+}
+
+// ExpireSilence expires the silence immediately.
+func (server *Server) ExpireSilence(ctx context.Context, id string) error {
+	if err := server.silences.Expire(id); err != nil {
+		if errors.Is(err, silence.ErrNotFound) {
+			return errors.Wrapf(err, errors.TypeNotFound, alertmanagertypes.ErrCodeAlertmanagerSilenceNotFound, "silence %s not found", id)
+		}
+
+		return err
+	}
+
+	if _, err := server.snapshotSilences(ctx); err != nil {
+		server.logger.ErrorContext(ctx, "failed to persist silences", "silence_id", id, "error", err)
 	}
 
 	return nil
 }
 
-func (server *Server) SetConfig(ctx context.Context, alertmanagerConfig *alertmanagertypes.Config) error {
+// snapshotSilences writes the silences to the state store.
+func (server *Server) snapshotSilences(ctx context.Context) (int64, error) {
+	server.stateMtx.Lock()
+	defer server.stateMtx.Unlock()
+
+	storableSilences, err := server.stateStore.Get(ctx, server.orgID)
+	if err != nil && !errors.Ast(err, errors.TypeNotFound) {
+		return 0, err
+	}
+
+	if storableSilences == nil {
+		storableSilences = alertmanagertypes.NewStoreableState(server.orgID)
+	}
+
+	c, err := storableSilences.Set(alertmanagertypes.SilenceStateName, server.silences)
+	if err != nil {
+		return 0, err
+	}
+
+	return c, server.stateStore.Set(ctx, server.orgID, storableSilences)
+}
+
+// builtConfig is the routing tree, the receivers and the time intervals built from a config.
+type builtConfig struct {
+	tmpl          *template.Template
+	routes        *dispatch.Route
+	receivers     map[string][]notify.Integration
+	timeIntervals map[string][]timeinterval.TimeInterval
+}
+
+// buildConfig builds the routing tree, the receivers and the time intervals of the config without applying them.
+func (server *Server) buildConfig(ctx context.Context, alertmanagerConfig *alertmanagertypes.Config) (*builtConfig, error) {
 	config := alertmanagerConfig.AlertmanagerConfig()
 
-	var err error
-	server.tmpl, err = alertmanagertypes.FromGlobs(config.Templates)
+	tmpl, err := alertmanagertypes.FromGlobs(config.Templates)
 	if err != nil {
-		return err
+		return nil, errors.Wrapf(err, errors.TypeInvalidInput, alertmanagertypes.ErrCodeAlertmanagerConfigInvalid, "cannot load templates")
 	}
 
-	server.tmpl.ExternalURL = server.srvConfig.ExternalURL
+	tmpl.ExternalURL = server.srvConfig.ExternalURL
 
 	// Build the routing tree and record which receivers are used.
 	routes := dispatch.NewRoute(config.Route, nil)
@@ -234,20 +348,18 @@ func (server *Server) SetConfig(ctx context.Context, alertmanagerConfig *alertma
 
 	// Build the map of receiver to integrations.
 	receivers := make(map[string][]notify.Integration, len(activeReceivers))
-	var integrationsNum int
 	for _, rcv := range config.Receivers {
 		if _, found := activeReceivers[rcv.Name]; !found {
 			// No need to build a receiver if no route is using it.
 			server.logger.InfoContext(ctx, "skipping creation of receiver not referenced by any route", "receiver", rcv.Name)
 			continue
 		}
-		integrations, err := alertmanagertypes.NewReceiverIntegrations(rcv, server.tmpl, server.logger)
+		integrations, err := server.newReceiverIntegrations(rcv, tmpl, server.logger)
 		if err != nil {
-			return err
+			return nil, errors.Wrapf(err, errors.TypeInvalidInput, alertmanagertypes.ErrCodeAlertmanagerConfigInvalid, "cannot build receiver %s", rcv.Name)
 		}
 		// rcv.Name is guaranteed to be unique across all receivers.
 		receivers[rcv.Name] = integrations
-		integrationsNum += len(integrations)
 	}
 
 	// Build the map of time interval names to time interval definitions.
@@ -260,8 +372,32 @@ func (server *Server) SetConfig(ctx context.Context, alertmanagerConfig *alertma
 		timeIntervals[ti.Name] = ti.TimeIntervals
 	}
 
+	return &builtConfig{tmpl: tmpl, routes: routes, receivers: receivers, timeIntervals: timeIntervals}, nil
+}
+
+// ValidateConfig returns an error if the config cannot be applied by SetConfig. The current config is not changed.
+func (server *Server) ValidateConfig(ctx context.Context, alertmanagerConfig *alertmanagertypes.Config) error {
+	_, err := server.buildConfig(ctx, alertmanagerConfig)
+	return err
+}
+
+// SetConfig builds the routing tree, the receivers and the notification pipeline of the config before swapping them
+// with the current ones. An invalid config is rejected and the current config is retained. The alerts and the
+// silences are kept across configs and the notifications in flight are given some time to complete.
+func (server *Server) SetConfig(ctx context.Context, alertmanagerConfig *alertmanagertypes.Config) error {
+	built, err := server.buildConfig(ctx, alertmanagerConfig)
+	if err != nil {
+		return err
+	}
+
+	config := alertmanagerConfig.AlertmanagerConfig()
+	tmpl, routes, receivers, timeIntervals := built.tmpl, built.routes, built.receivers, built.timeIntervals
 	intervener := timeinterval.NewIntervener(timeIntervals)
 
+	// Nothing below can fail, the current config is only replaced from here on.
+	server.configMtx.Lock()
+	defer server.configMtx.Unlock()
+
 	if server.inhibitor != nil {
 		server.inhibitor.Stop()
 	}
@@ -269,12 +405,19 @@ func (server *Server) SetConfig(ctx context.Context, alertmanagerConfig *alertma
 		server.dispatcher.Stop()
 	}
 
+	// The notifications started by the previous dispatcher keep running, wait for them so that the new dispatcher
+	// finds them in the notification log instead of sending them again.
+	if server.inflight != nil {
+		server.waitInflight(ctx, server.inflight)
+	}
+
+	server.tmpl = tmpl
 	server.inhibitor = inhibit.NewInhibitor(server.alerts, config.InhibitRules, server.marker, server.logger)
 	server.timeIntervals = timeIntervals
 	server.silencer = silence.NewSilencer(server.silences, server.marker, server.logger)
 
 	var pipelinePeer notify.Peer
-	pipeline := server.pipelineBuilder.New(
+	stages := server.pipelineBuilder.New(
 		receivers,
 		func() time.Duration { return 0 },
 		server.inhibitor,
@@ -285,6 +428,19 @@ func (server *Server) SetConfig(ctx context.Context, alertmanagerConfig *alertma
 		pipelinePeer,
 	)
 
+	// Undelivered notifications, including the ones dropped by the priority queue, are sent to the dead letter store.
+	// The unwrapped stages are kept to re-dispatch them without creating new dead letters.
+	inflight := &sync.WaitGroup{}
+	pipeline := make(notify.RoutingStage, len(stages))
+	for receiver, stage := range stages {
+		var scheduled notify.Stage = stage
+		if server.priorityQueue != nil {
+			scheduled = newPriorityStage(server.priorityQueue, stage)
+		}
+
+		pipeline[receiver] = newInflightStage(server.notifyCtx, inflight, newDeadLetterStage(server.orgID, scheduled, server.deadLetterStore))
+	}
+
 	timeoutFunc := func(d time.Duration) time.Duration {
 		if d < notify.MinTimeout {
 			d = notify.MinTimeout
@@ -309,12 +465,60 @@ func (server *Server) SetConfig(ctx context.Context, alertmanagerConfig *alertma
 	go server.dispatcher.Run()
 	go server.inhibitor.Run()
 
+	server.inflight = inflight
+	server.stagesMtx.Lock()
+	server.stages = stages
+	server.stagesMtx.Unlock()
 	server.alertmanagerConfig = alertmanagerConfig
 	return nil
 }
 
+// newReceiverIntegrations builds the integrations of the receiver, propagating the trace context of the alerts
+// unless it is disabled for the receiver and signing its webhook notifications if its channel has a secret. The plugin
+// selected by its channel is appended to its integrations, it is read when notifying so that it can be changed without
+// building the integrations again.
+func (server *Server) newReceiverIntegrations(receiver alertmanagertypes.Receiver, tmpl *template.Template, logger *slog.Logger) ([]notify.Integration, error) {
+	var signer *alertmanagernotify.WebhookSigner
+	if server.channelStore != nil {
+		var err error
+		signer, err = server.srvConfig.WebhookSigning.Signer(func(ctx context.Context) (string, error) {
+			return server.channelStore.GetSigningSecret(ctx, server.orgID, receiver.Name)
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var traceContexts *alertmanagertypes.TraceContexts
+	if server.srvConfig.TraceContext.Propagates(receiver.Name) {
+		traceContexts = server.traceContexts
+	}
+
+	integrations, err := alertmanagernotify.NewReceiverIntegrations(receiver, tmpl, logger, traceContexts, signer, server.httpClient)
+	if err != nil {
+		return nil, err
+	}
+
+	if server.channelStore == nil || len(server.plugins) == 0 {
+		return integrations, nil
+	}
+
+	config := server.srvConfig.Plugins
+	notifier := alertmanagernotify.NewPluginNotifier(func(ctx context.Context) (string, error) {
+		return server.channelStore.GetPlugin(ctx, server.orgID, receiver.Name)
+	}, server.plugins, config.Timeout, config.MaxAttempts, config.Backoff, logger.With("integration", "plugin"))
+	return append(integrations, notify.NewIntegration(notifier, sendResolved(true), "plugin", 0, receiver.Name)), nil
+}
+
+// sendResolved is the resolved sender of the integrations always notifying the resolved alerts.
+type sendResolved bool
+
+func (s sendResolved) SendResolved() bool {
+	return bool(s)
+}
+
 func (server *Server) TestReceiver(ctx context.Context, receiver alertmanagertypes.Receiver) error {
-	return alertmanagertypes.TestReceiver(ctx, receiver, server.alertmanagerConfig, server.tmpl, server.logger, alertmanagertypes.NewTestAlert(receiver, time.Now(), time.Now()))
+	return alertmanagertypes.TestReceiver(ctx, receiver, server.newReceiverIntegrations, server.alertmanagerConfig, server.tmpl, server.logger, alertmanagertypes.NewTestAlert(receiver, time.Now(), time.Now()))
 }
 
 func (server *Server) TestAlert(ctx context.Context, postableAlert *alertmanagertypes.PostableAlert, receivers []string) error {
@@ -335,7 +539,7 @@ func (server *Server) TestAlert(ctx context.Context, postableAlert *alertmanager
 				ch <- err
 				return
 			}
-			ch <- alertmanagertypes.TestReceiver(ctx, receiver, server.alertmanagerConfig, server.tmpl, server.logger, alerts[0])
+			ch <- alertmanagertypes.TestReceiver(ctx, receiver, server.newReceiverIntegrations, server.alertmanagerConfig, server.tmpl, server.logger, alerts[0])
 		}(receiverName)
 	}
 
@@ -370,6 +574,9 @@ func (server *Server) Stop(ctx context.Context) error {
 		server.inhibitor.Stop()
 	}
 
+	// Cancel the notifications in flight.
+	server.notifyCancel()
+
 	// Close the alert provider.
 	server.alerts.Close()
 
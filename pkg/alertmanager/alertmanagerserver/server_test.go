@@ -8,14 +8,19 @@ import (
 	"net"
 	"net/http"
 	"net/url"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
+	"github.com/SigNoz/signoz/pkg/alertmanager/alertmanagernotify"
+	"github.com/SigNoz/signoz/pkg/errors"
 	"github.com/SigNoz/signoz/pkg/types/alertmanagertypes"
 	"github.com/SigNoz/signoz/pkg/types/alertmanagertypes/alertmanagertypestest"
 	"github.com/go-openapi/strfmt"
 	"github.com/prometheus/alertmanager/api/v2/models"
 	"github.com/prometheus/alertmanager/config"
+	"github.com/prometheus/alertmanager/pkg/labels"
 	"github.com/prometheus/client_golang/prometheus"
 	commoncfg "github.com/prometheus/common/config"
 	"github.com/stretchr/testify/assert"
@@ -23,7 +28,7 @@ import (
 )
 
 func TestServerSetConfigAndStop(t *testing.T) {
-	server, err := New(context.Background(), slog.New(slog.NewTextHandler(io.Discard, nil)), prometheus.NewRegistry(), NewConfig(), "1", alertmanagertypestest.NewStateStore())
+	server, err := New(context.Background(), slog.New(slog.NewTextHandler(io.Discard, nil)), prometheus.NewRegistry(), NewConfig(), "1", alertmanagertypestest.NewStateStore(), alertmanagertypestest.NewDeadLetterStore(), nil, nil, alertmanagernotify.HTTPClientConfig{})
 	require.NoError(t, err)
 
 	amConfig, err := alertmanagertypes.NewDefaultConfig(alertmanagertypes.GlobalConfig{}, alertmanagertypes.RouteConfig{GroupInterval: 1 * time.Minute, RepeatInterval: 1 * time.Minute, GroupWait: 1 * time.Minute}, "1")
@@ -34,7 +39,7 @@ func TestServerSetConfigAndStop(t *testing.T) {
 }
 
 func TestServerTestReceiverTypeWebhook(t *testing.T) {
-	server, err := New(context.Background(), slog.New(slog.NewTextHandler(io.Discard, nil)), prometheus.NewRegistry(), NewConfig(), "1", alertmanagertypestest.NewStateStore())
+	server, err := New(context.Background(), slog.New(slog.NewTextHandler(io.Discard, nil)), prometheus.NewRegistry(), NewConfig(), "1", alertmanagertypestest.NewStateStore(), alertmanagertypestest.NewDeadLetterStore(), nil, nil, alertmanagernotify.HTTPClientConfig{})
 	require.NoError(t, err)
 
 	amConfig, err := alertmanagertypes.NewDefaultConfig(alertmanagertypes.GlobalConfig{}, alertmanagertypes.RouteConfig{GroupInterval: 1 * time.Minute, RepeatInterval: 1 * time.Minute, GroupWait: 1 * time.Minute}, "1")
@@ -81,7 +86,7 @@ func TestServerPutAlerts(t *testing.T) {
 	stateStore := alertmanagertypestest.NewStateStore()
 	srvCfg := NewConfig()
 	srvCfg.Route.GroupInterval = 1 * time.Second
-	server, err := New(context.Background(), slog.New(slog.NewTextHandler(io.Discard, nil)), prometheus.NewRegistry(), srvCfg, "1", stateStore)
+	server, err := New(context.Background(), slog.New(slog.NewTextHandler(io.Discard, nil)), prometheus.NewRegistry(), srvCfg, "1", stateStore, alertmanagertypestest.NewDeadLetterStore(), nil, nil, alertmanagernotify.HTTPClientConfig{})
 	require.NoError(t, err)
 
 	amConfig, err := alertmanagertypes.NewDefaultConfig(srvCfg.Global, srvCfg.Route, "1")
@@ -123,3 +128,175 @@ func TestServerPutAlerts(t *testing.T) {
 	assert.Equal(t, gettableAlerts[0].Alert.Labels["alertname"], "test-alert")
 	assert.NoError(t, server.Stop(context.Background()))
 }
+
+func TestServerSilences(t *testing.T) {
+	stateStore := alertmanagertypestest.NewStateStore()
+	srvCfg := NewConfig()
+	server, err := New(context.Background(), slog.New(slog.NewTextHandler(io.Discard, nil)), prometheus.NewRegistry(), srvCfg, "1", stateStore, alertmanagertypestest.NewDeadLetterStore(), nil, nil, alertmanagernotify.HTTPClientConfig{})
+	require.NoError(t, err)
+
+	amConfig, err := alertmanagertypes.NewDefaultConfig(srvCfg.Global, srvCfg.Route, "1")
+	require.NoError(t, err)
+	require.NoError(t, server.SetConfig(context.Background(), amConfig))
+
+	require.NoError(t, server.PutAlerts(context.Background(), alertmanagertypes.PostableAlerts{
+		{
+			StartsAt: strfmt.DateTime(time.Now().Add(-time.Hour)),
+			EndsAt:   strfmt.DateTime(time.Now().Add(time.Hour)),
+			Alert:    models.Alert{Labels: models.LabelSet{"alertname": "api-down", "service": "api"}},
+		},
+		{
+			StartsAt: strfmt.DateTime(time.Now().Add(-time.Hour)),
+			EndsAt:   strfmt.DateTime(time.Now().Add(time.Hour)),
+			Alert:    models.Alert{Labels: models.LabelSet{"alertname": "web-down", "service": "web"}},
+		},
+	}))
+
+	// Silence every service but web.
+	matchers := labels.Matchers{&labels.Matcher{Type: labels.MatchRegexp, Name: "alertname", Value: ".+-down"}, &labels.Matcher{Type: labels.MatchNotEqual, Name: "service", Value: "web"}}
+	silence, err := alertmanagertypes.NewSilenceFromPostableSilence(alertmanagertypes.NewPostableSilence(matchers, time.Now(), time.Now().Add(time.Hour), "deploy-bot", "deploy"), time.Now())
+	require.NoError(t, err)
+
+	id, err := server.CreateSilence(context.Background(), silence)
+	require.NoError(t, err)
+	assert.NotEmpty(t, id)
+
+	dummyRequest, err := http.NewRequest(http.MethodGet, "/alerts", nil)
+	require.NoError(t, err)
+	params, err := alertmanagertypes.NewGettableAlertsParams(dummyRequest)
+	require.NoError(t, err)
+
+	gettableAlerts, err := server.GetAlerts(context.Background(), params)
+	require.NoError(t, err)
+	require.Len(t, gettableAlerts, 2)
+	for _, gettableAlert := range gettableAlerts {
+		if gettableAlert.Labels["service"] == "api" {
+			assert.Equal(t, []string{id}, gettableAlert.Status.SilencedBy)
+			continue
+		}
+
+		assert.Empty(t, gettableAlert.Status.SilencedBy)
+	}
+
+	silences, err := server.ListSilences(context.Background())
+	require.NoError(t, err)
+	require.Len(t, silences, 1)
+	assert.Equal(t, id, *silences[0].ID)
+	assert.Equal(t, "deploy-bot", *silences[0].CreatedBy)
+	assert.Equal(t, models.SilenceStatusStateActive, *silences[0].Status.State)
+
+	require.NoError(t, server.ExpireSilence(context.Background(), id))
+	require.NoError(t, server.Stop(context.Background()))
+
+	// The silence is restored from the state store.
+	server, err = New(context.Background(), slog.New(slog.NewTextHandler(io.Discard, nil)), prometheus.NewRegistry(), srvCfg, "1", stateStore, alertmanagertypestest.NewDeadLetterStore(), nil, nil, alertmanagernotify.HTTPClientConfig{})
+	require.NoError(t, err)
+
+	silences, err = server.ListSilences(context.Background())
+	require.NoError(t, err)
+	require.Len(t, silences, 1)
+	assert.Equal(t, models.SilenceStatusStateExpired, *silences[0].Status.State)
+
+	err = server.ExpireSilence(context.Background(), "unknown")
+	assert.True(t, errors.Ast(err, errors.TypeNotFound))
+	assert.NoError(t, server.Stop(context.Background()))
+}
+
+func TestServerSetConfigRejectsInvalidConfig(t *testing.T) {
+	srvCfg := NewConfig()
+	server, err := New(context.Background(), slog.New(slog.NewTextHandler(io.Discard, nil)), prometheus.NewRegistry(), srvCfg, "1", alertmanagertypestest.NewStateStore(), alertmanagertypestest.NewDeadLetterStore(), nil, nil, alertmanagernotify.HTTPClientConfig{})
+	require.NoError(t, err)
+
+	amConfig, err := alertmanagertypes.NewDefaultConfig(srvCfg.Global, srvCfg.Route, "1")
+	require.NoError(t, err)
+	require.NoError(t, server.SetConfig(context.Background(), amConfig))
+	hash := server.Hash()
+
+	templatePath := filepath.Join(t.TempDir(), "invalid.tmpl")
+	require.NoError(t, os.WriteFile(templatePath, []byte(`{{ define "invalid" }}{{ .Unclosed `), 0o600))
+
+	invalidConfig, err := alertmanagertypes.NewDefaultConfig(srvCfg.Global, srvCfg.Route, "1")
+	require.NoError(t, err)
+	require.NoError(t, invalidConfig.CreateReceiver(alertmanagertypes.Receiver{
+		Name: "test-receiver",
+		WebhookConfigs: []*config.WebhookConfig{
+			{
+				HTTPConfig: &commoncfg.HTTPClientConfig{},
+				URL:        &config.SecretURL{URL: &url.URL{Host: "localhost", Path: "/test-receiver"}},
+			},
+		},
+	}))
+	invalidConfig.AlertmanagerConfig().Templates = []string{templatePath}
+
+	err = server.ValidateConfig(context.Background(), invalidConfig)
+	assert.True(t, errors.Asc(err, alertmanagertypes.ErrCodeAlertmanagerConfigInvalid))
+
+	err = server.SetConfig(context.Background(), invalidConfig)
+	require.Error(t, err)
+	assert.True(t, errors.Asc(err, alertmanagertypes.ErrCodeAlertmanagerConfigInvalid))
+
+	// The current config keeps running.
+	assert.Equal(t, hash, server.Hash())
+	require.NoError(t, server.PutAlerts(context.Background(), alertmanagertypes.PostableAlerts{
+		{
+			StartsAt: strfmt.DateTime(time.Now().Add(-time.Hour)),
+			EndsAt:   strfmt.DateTime(time.Now().Add(time.Hour)),
+			Alert:    models.Alert{Labels: models.LabelSet{"alertname": "test-alert"}},
+		},
+	}))
+	assert.NoError(t, server.Stop(context.Background()))
+}
+
+func TestServerSetConfigKeepsAlertsAndSilences(t *testing.T) {
+	srvCfg := NewConfig()
+	server, err := New(context.Background(), slog.New(slog.NewTextHandler(io.Discard, nil)), prometheus.NewRegistry(), srvCfg, "1", alertmanagertypestest.NewStateStore(), alertmanagertypestest.NewDeadLetterStore(), nil, nil, alertmanagernotify.HTTPClientConfig{})
+	require.NoError(t, err)
+
+	amConfig, err := alertmanagertypes.NewDefaultConfig(srvCfg.Global, srvCfg.Route, "1")
+	require.NoError(t, err)
+	require.NoError(t, server.SetConfig(context.Background(), amConfig))
+
+	require.NoError(t, server.PutAlerts(context.Background(), alertmanagertypes.PostableAlerts{
+		{
+			StartsAt: strfmt.DateTime(time.Now().Add(-time.Hour)),
+			EndsAt:   strfmt.DateTime(time.Now().Add(time.Hour)),
+			Alert:    models.Alert{Labels: models.LabelSet{"alertname": "api-down"}},
+		},
+	}))
+
+	matchers := labels.Matchers{&labels.Matcher{Type: labels.MatchEqual, Name: "alertname", Value: "api-down"}}
+	silence, err := alertmanagertypes.NewSilenceFromPostableSilence(alertmanagertypes.NewPostableSilence(matchers, time.Now(), time.Now().Add(time.Hour), "deploy-bot", "deploy"), time.Now())
+	require.NoError(t, err)
+	id, err := server.CreateSilence(context.Background(), silence)
+	require.NoError(t, err)
+
+	newConfig, err := alertmanagertypes.NewDefaultConfig(srvCfg.Global, srvCfg.Route, "1")
+	require.NoError(t, err)
+	require.NoError(t, newConfig.CreateReceiver(alertmanagertypes.Receiver{
+		Name: "test-receiver",
+		WebhookConfigs: []*config.WebhookConfig{
+			{
+				HTTPConfig: &commoncfg.HTTPClientConfig{},
+				URL:        &config.SecretURL{URL: &url.URL{Host: "localhost", Path: "/test-receiver"}},
+			},
+		},
+	}))
+	require.NoError(t, server.SetConfig(context.Background(), newConfig))
+	assert.Equal(t, newConfig.StoreableConfig().Hash, server.Hash())
+
+	dummyRequest, err := http.NewRequest(http.MethodGet, "/alerts", nil)
+	require.NoError(t, err)
+	params, err := alertmanagertypes.NewGettableAlertsParams(dummyRequest)
+	require.NoError(t, err)
+
+	gettableAlerts, err := server.GetAlerts(context.Background(), params)
+	require.NoError(t, err)
+	require.Len(t, gettableAlerts, 1)
+	assert.Equal(t, []string{id}, gettableAlerts[0].Status.SilencedBy)
+
+	silences, err := server.ListSilences(context.Background())
+	require.NoError(t, err)
+	require.Len(t, silences, 1)
+	assert.Equal(t, id, *silences[0].ID)
+	assert.NoError(t, server.Stop(context.Background()))
+}
@@ -3,6 +3,7 @@ package sqlalertmanagerstore
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 
 	"github.com/SigNoz/signoz/pkg/errors"
 	"github.com/SigNoz/signoz/pkg/sqlstore"
@@ -13,11 +14,14 @@ import (
 )
 
 type config struct {
-	sqlstore sqlstore.SQLStore
+	sqlstore  sqlstore.SQLStore
+	encryptor *sqlstore.Encryptor
 }
 
-func NewConfigStore(sqlstore sqlstore.SQLStore) alertmanagertypes.ConfigStore {
-	return &config{sqlstore: sqlstore}
+// NewConfigStore returns the config store encrypting the signing secrets of the channels with the given encryptor when
+// the encryption is enabled.
+func NewConfigStore(sqlstore sqlstore.SQLStore, encryptor *sqlstore.Encryptor) alertmanagertypes.ConfigStore {
+	return &config{sqlstore: sqlstore, encryptor: encryptor}
 }
 
 // Get implements alertmanagertypes.ConfigStore.
@@ -68,12 +72,17 @@ func (store *config) Set(ctx context.Context, config *alertmanagertypes.Config,
 }
 
 func (store *config) CreateChannel(ctx context.Context, channel *alertmanagertypes.Channel, opts ...alertmanagertypes.StoreOption) error {
+	storable, err := store.encrypt(ctx, channel)
+	if err != nil {
+		return err
+	}
+
 	return store.wrap(ctx, func(ctx context.Context) error {
 		if _, err := store.
 			sqlstore.
 			BunDBCtx(ctx).
 			NewInsert().
-			Model(channel).
+			Model(storable).
 			Exec(ctx); err != nil {
 			return err
 		}
@@ -104,12 +113,17 @@ func (store *config) GetChannelByID(ctx context.Context, orgID string, id valuer
 }
 
 func (store *config) UpdateChannel(ctx context.Context, orgID string, channel *alertmanagertypes.Channel, opts ...alertmanagertypes.StoreOption) error {
+	storable, err := store.encrypt(ctx, channel)
+	if err != nil {
+		return err
+	}
+
 	return store.wrap(ctx, func(ctx context.Context) error {
 		if _, err := store.
 			sqlstore.
 			BunDBCtx(ctx).
 			NewUpdate().
-			Model(channel).
+			Model(storable).
 			WherePK().
 			Exec(ctx); err != nil {
 			return err
@@ -171,6 +185,67 @@ func (store *config) ListAllChannels(ctx context.Context) ([]*alertmanagertypes.
 	return channels, nil
 }
 
+func (store *config) GetSigningSecret(ctx context.Context, orgID string, name string) (string, error) {
+	channel := new(alertmanagertypes.Channel)
+
+	err := store.
+		sqlstore.
+		BunDB().
+		NewSelect().
+		Model(channel).
+		Column("signing_secret").
+		Where("org_id = ?", orgID).
+		Where("name = ?", name).
+		Scan(ctx)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return "", nil
+		}
+		return "", err
+	}
+
+	return store.encryptor.DecryptValue(ctx, orgID, channel.SigningSecret)
+}
+
+// encrypt returns a copy of the channel whose signing secret is encrypted when the encryption is enabled. The secrets
+// read from the store are kept encrypted in the channels, they are only decrypted when signing.
+func (store *config) encrypt(ctx context.Context, channel *alertmanagertypes.Channel) (*alertmanagertypes.Channel, error) {
+	secret, err := store.encryptor.EncryptValue(ctx, channel.OrgID, channel.SigningSecret)
+	if err != nil {
+		return nil, err
+	}
+
+	if secret == channel.SigningSecret {
+		return channel, nil
+	}
+
+	storable := *channel
+	storable.SigningSecret = secret
+	return &storable, nil
+}
+
+func (store *config) GetPlugin(ctx context.Context, orgID string, name string) (string, error) {
+	channel := new(alertmanagertypes.Channel)
+
+	err := store.
+		sqlstore.
+		BunDB().
+		NewSelect().
+		Model(channel).
+		Column("plugin").
+		Where("org_id = ?", orgID).
+		Where("name = ?", name).
+		Scan(ctx)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return "", nil
+		}
+		return "", err
+	}
+
+	return channel.Plugin, nil
+}
+
 func (store *config) GetMatchers(ctx context.Context, orgID string) (map[string][]string, error) {
 	type matcher struct {
 		bun.BaseModel `bun:"table:rule"`
@@ -202,6 +277,45 @@ func (store *config) GetMatchers(ctx context.Context, orgID string) (map[string]
 	return matchersMap, nil
 }
 
+func (store *config) GetNotificationSettings(ctx context.Context, orgID string) (map[string]*alertmanagertypes.NotificationSettings, error) {
+	type rule struct {
+		bun.BaseModel `bun:"table:rule"`
+		ID            valuer.UUID `bun:"id,pk"`
+		Data          string      `bun:"data"`
+	}
+
+	rules := []rule{}
+
+	err := store.
+		sqlstore.
+		BunDB().
+		NewSelect().
+		Column("id", "data").
+		Model(&rules).
+		Where("org_id = ?", orgID).
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	settings := make(map[string]*alertmanagertypes.NotificationSettings)
+	for _, rule := range rules {
+		result := gjson.Get(rule.Data, "notificationSettings")
+		if !result.Exists() {
+			continue
+		}
+
+		ruleSettings := new(alertmanagertypes.NotificationSettings)
+		if err := json.Unmarshal([]byte(result.Raw), ruleSettings); err != nil {
+			return nil, errors.Wrapf(err, errors.TypeInternal, errors.CodeInternal, "invalid notification settings of rule %s", rule.ID)
+		}
+
+		settings[rule.ID.StringValue()] = ruleSettings
+	}
+
+	return settings, nil
+}
+
 func (store *config) wrap(ctx context.Context, fn func(ctx context.Context) error, opts ...alertmanagertypes.StoreOption) error {
 	storeOpts := alertmanagertypes.NewStoreOptions(opts...)
 
@@ -0,0 +1,159 @@
+package sqlalertmanagerstore
+
+import (
+	"context"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/sqlstore"
+	"github.com/SigNoz/signoz/pkg/types/alertmanagertypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+)
+
+type deadLetter struct {
+	sqlstore sqlstore.SQLStore
+}
+
+func NewDeadLetterStore(sqlstore sqlstore.SQLStore) alertmanagertypes.DeadLetterStore {
+	return &deadLetter{sqlstore: sqlstore}
+}
+
+// Create implements alertmanagertypes.DeadLetterStore.
+func (store *deadLetter) Create(ctx context.Context, deadLetter *alertmanagertypes.DeadLetter) error {
+	_, err := store.
+		sqlstore.
+		BunDBCtx(ctx).
+		NewInsert().
+		Model(deadLetter).
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Get implements alertmanagertypes.DeadLetterStore.
+func (store *deadLetter) Get(ctx context.Context, orgID string, id valuer.UUID) (*alertmanagertypes.DeadLetter, error) {
+	deadLetter := new(alertmanagertypes.DeadLetter)
+
+	err := store.
+		sqlstore.
+		BunDBCtx(ctx).
+		NewSelect().
+		Model(deadLetter).
+		Where("org_id = ?", orgID).
+		Where("id = ?", id).
+		Scan(ctx)
+	if err != nil {
+		return nil, store.sqlstore.WrapNotFoundErrf(err, alertmanagertypes.ErrCodeAlertmanagerDeadLetterNotFound, "cannot find dead letter with id %s", id.StringValue())
+	}
+
+	return deadLetter, nil
+}
+
+// GetByGroupKey implements alertmanagertypes.DeadLetterStore.
+func (store *deadLetter) GetByGroupKey(ctx context.Context, orgID string, receiver string, groupKey string) (*alertmanagertypes.DeadLetter, error) {
+	deadLetter := new(alertmanagertypes.DeadLetter)
+
+	err := store.
+		sqlstore.
+		BunDBCtx(ctx).
+		NewSelect().
+		Model(deadLetter).
+		Where("org_id = ?", orgID).
+		Where("receiver = ?", receiver).
+		Where("group_key = ?", groupKey).
+		Scan(ctx)
+	if err != nil {
+		return nil, store.sqlstore.WrapNotFoundErrf(err, alertmanagertypes.ErrCodeAlertmanagerDeadLetterNotFound, "cannot find dead letter for receiver %s and group key %s", receiver, groupKey)
+	}
+
+	return deadLetter, nil
+}
+
+// List implements alertmanagertypes.DeadLetterStore.
+func (store *deadLetter) List(ctx context.Context, orgID string) ([]*alertmanagertypes.DeadLetter, error) {
+	deadLetters := make([]*alertmanagertypes.DeadLetter, 0)
+
+	err := store.
+		sqlstore.
+		BunDBCtx(ctx).
+		NewSelect().
+		Model(&deadLetters).
+		Where("org_id = ?", orgID).
+		Order("created_at DESC").
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return deadLetters, nil
+}
+
+// Update implements alertmanagertypes.DeadLetterStore.
+func (store *deadLetter) Update(ctx context.Context, deadLetter *alertmanagertypes.DeadLetter) error {
+	_, err := store.
+		sqlstore.
+		BunDBCtx(ctx).
+		NewUpdate().
+		Model(deadLetter).
+		WherePK().
+		Where("org_id = ?", deadLetter.OrgID).
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Delete implements alertmanagertypes.DeadLetterStore.
+func (store *deadLetter) Delete(ctx context.Context, orgID string, id valuer.UUID) error {
+	_, err := store.
+		sqlstore.
+		BunDBCtx(ctx).
+		NewDelete().
+		Model(new(alertmanagertypes.DeadLetter)).
+		Where("org_id = ?", orgID).
+		Where("id = ?", id).
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// DeleteBefore implements alertmanagertypes.DeadLetterStore.
+func (store *deadLetter) ListAttemptedBefore(ctx context.Context, before time.Time) ([]*alertmanagertypes.DeadLetter, error) {
+	deadLetters := make([]*alertmanagertypes.DeadLetter, 0)
+
+	err := store.
+		sqlstore.
+		BunDBCtx(ctx).
+		NewSelect().
+		Model(&deadLetters).
+		Where("updated_at < ?", before).
+		Order("updated_at ASC").
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return deadLetters, nil
+}
+
+func (store *deadLetter) DeleteBefore(ctx context.Context, before time.Time) error {
+	_, err := store.
+		sqlstore.
+		BunDBCtx(ctx).
+		NewDelete().
+		Model(new(alertmanagertypes.DeadLetter)).
+		Where("updated_at < ?", before).
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -2,6 +2,7 @@ package alertmanager
 
 import (
 	"context"
+	"encoding/json"
 	"io"
 	"net/http"
 	"time"
@@ -14,6 +15,11 @@ import (
 	"github.com/gorilla/mux"
 )
 
+const (
+	// maxInboundAlertsSize is the maximum size in bytes of the body of the inbound alerts.
+	maxInboundAlertsSize int64 = 4 << 20
+)
+
 type API struct {
 	alertmanager Alertmanager
 }
@@ -49,6 +55,114 @@ func (api *API) GetAlerts(rw http.ResponseWriter, req *http.Request) {
 	render.Success(rw, http.StatusOK, alerts)
 }
 
+// PutInboundAlerts ingests the alerts forwarded by external systems, such as the webhook notifications of other
+// alertmanagers, and routes them through the notification pipeline of the organization to the inbound channels.
+func (api *API) PutInboundAlerts(rw http.ResponseWriter, req *http.Request) {
+	ctx, cancel := context.WithTimeout(req.Context(), 30*time.Second)
+	defer cancel()
+
+	claims, err := authtypes.ClaimsFromContext(ctx)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(req.Body, maxInboundAlertsSize+1))
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+	defer req.Body.Close() //nolint:errcheck
+
+	if int64(len(body)) > maxInboundAlertsSize {
+		render.Error(rw, errors.Newf(errors.TypeInvalidInput, alertmanagertypes.ErrCodeAlertmanagerInboundAlertsInvalid, "body is larger than %d bytes", maxInboundAlertsSize))
+		return
+	}
+
+	alerts, err := alertmanagertypes.NewPostableAlertsFromInbound(body)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	if err := api.alertmanager.PutAlerts(ctx, claims.OrgID, alerts); err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	render.Success(rw, http.StatusNoContent, nil)
+}
+
+// GetInboundChannels returns the channels notified of the inbound alerts of the organization.
+func (api *API) GetInboundChannels(rw http.ResponseWriter, req *http.Request) {
+	ctx, cancel := context.WithTimeout(req.Context(), 30*time.Second)
+	defer cancel()
+
+	claims, err := authtypes.ClaimsFromContext(ctx)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	config, err := api.alertmanager.GetConfig(ctx, claims.OrgID)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	render.Success(rw, http.StatusOK, alertmanagertypes.InboundChannels{Channels: config.ReceiverNamesFromRuleID(alertmanagertypes.InboundRuleID)})
+}
+
+// PutInboundChannels sets the channels notified of the inbound alerts of the organization. The inbound alerts are
+// not notified until their channels are set.
+func (api *API) PutInboundChannels(rw http.ResponseWriter, req *http.Request) {
+	ctx, cancel := context.WithTimeout(req.Context(), 30*time.Second)
+	defer cancel()
+
+	claims, err := authtypes.ClaimsFromContext(ctx)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+	defer req.Body.Close() //nolint:errcheck
+
+	channels, err := api.alertmanager.ListChannels(ctx, claims.OrgID)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	inboundChannels, err := alertmanagertypes.NewInboundChannelsFromJSON(body, channels)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	config, err := api.alertmanager.GetConfig(ctx, claims.OrgID)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	if err := config.UpdateRuleIDMatcher(alertmanagertypes.InboundRuleID, inboundChannels.Channels); err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	if err := api.alertmanager.SetConfig(ctx, config); err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	render.Success(rw, http.StatusNoContent, nil)
+}
+
 func (api *API) TestReceiver(rw http.ResponseWriter, req *http.Request) {
 	ctx, cancel := context.WithTimeout(req.Context(), 30*time.Second)
 	defer cancel()
@@ -205,6 +319,92 @@ func (api *API) UpdateChannelByID(rw http.ResponseWriter, req *http.Request) {
 	render.Success(rw, http.StatusNoContent, nil)
 }
 
+func (api *API) SetChannelSigningSecretByID(rw http.ResponseWriter, req *http.Request) {
+	ctx, cancel := context.WithTimeout(req.Context(), 30*time.Second)
+	defer cancel()
+
+	claims, err := authtypes.ClaimsFromContext(ctx)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	vars := mux.Vars(req)
+	if vars == nil {
+		render.Error(rw, errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "id is required in path"))
+		return
+	}
+
+	idString, ok := vars["id"]
+	if !ok {
+		render.Error(rw, errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "id is required in path"))
+		return
+	}
+
+	id, err := valuer.NewUUID(idString)
+	if err != nil {
+		render.Error(rw, errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "id is not a valid uuid-v7"))
+		return
+	}
+
+	postable := new(alertmanagertypes.PostableChannelSigningSecret)
+	if err := json.NewDecoder(req.Body).Decode(postable); err != nil {
+		render.Error(rw, errors.Wrapf(err, errors.TypeInvalidInput, errors.CodeInvalidInput, "invalid signing secret"))
+		return
+	}
+
+	err = api.alertmanager.SetChannelSigningSecretByID(ctx, claims.OrgID, id, postable.Secret)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	render.Success(rw, http.StatusNoContent, nil)
+}
+
+func (api *API) SetChannelPluginByID(rw http.ResponseWriter, req *http.Request) {
+	ctx, cancel := context.WithTimeout(req.Context(), 30*time.Second)
+	defer cancel()
+
+	claims, err := authtypes.ClaimsFromContext(ctx)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	vars := mux.Vars(req)
+	if vars == nil {
+		render.Error(rw, errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "id is required in path"))
+		return
+	}
+
+	idString, ok := vars["id"]
+	if !ok {
+		render.Error(rw, errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "id is required in path"))
+		return
+	}
+
+	id, err := valuer.NewUUID(idString)
+	if err != nil {
+		render.Error(rw, errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "id is not a valid uuid-v7"))
+		return
+	}
+
+	postable := new(alertmanagertypes.PostableChannelPlugin)
+	if err := json.NewDecoder(req.Body).Decode(postable); err != nil {
+		render.Error(rw, errors.Wrapf(err, errors.TypeInvalidInput, errors.CodeInvalidInput, "invalid plugin"))
+		return
+	}
+
+	err = api.alertmanager.SetChannelPluginByID(ctx, claims.OrgID, id, postable.Plugin)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	render.Success(rw, http.StatusNoContent, nil)
+}
+
 func (api *API) DeleteChannelByID(rw http.ResponseWriter, req *http.Request) {
 	ctx, cancel := context.WithTimeout(req.Context(), 30*time.Second)
 	defer cancel()
@@ -273,3 +473,167 @@ func (api *API) CreateChannel(rw http.ResponseWriter, req *http.Request) {
 
 	render.Success(rw, http.StatusNoContent, nil)
 }
+
+func (api *API) ListDeadLetters(rw http.ResponseWriter, req *http.Request) {
+	ctx, cancel := context.WithTimeout(req.Context(), 30*time.Second)
+	defer cancel()
+
+	claims, err := authtypes.ClaimsFromContext(ctx)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	deadLetters, err := api.alertmanager.ListDeadLetters(ctx, claims.OrgID)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	render.Success(rw, http.StatusOK, deadLetters)
+}
+
+func (api *API) RedispatchDeadLetter(rw http.ResponseWriter, req *http.Request) {
+	ctx, cancel := context.WithTimeout(req.Context(), 30*time.Second)
+	defer cancel()
+
+	claims, err := authtypes.ClaimsFromContext(ctx)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	vars := mux.Vars(req)
+	if vars == nil {
+		render.Error(rw, errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "id is required in path"))
+		return
+	}
+
+	idString, ok := vars["id"]
+	if !ok {
+		render.Error(rw, errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "id is required in path"))
+		return
+	}
+
+	id, err := valuer.NewUUID(idString)
+	if err != nil {
+		render.Error(rw, errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "id is not a valid uuid-v7"))
+		return
+	}
+
+	err = api.alertmanager.RedispatchDeadLetter(ctx, claims.OrgID, id)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	render.Success(rw, http.StatusNoContent, nil)
+}
+
+// ReloadConfig applies the stored config of the organization to its alertmanager right away instead of waiting for
+// the next sync.
+func (api *API) ReloadConfig(rw http.ResponseWriter, req *http.Request) {
+	ctx, cancel := context.WithTimeout(req.Context(), 30*time.Second)
+	defer cancel()
+
+	claims, err := authtypes.ClaimsFromContext(ctx)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	config, err := api.alertmanager.GetConfig(ctx, claims.OrgID)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	err = api.alertmanager.Reload(ctx, config)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	render.Success(rw, http.StatusNoContent, nil)
+}
+
+func (api *API) CreateSilence(rw http.ResponseWriter, req *http.Request) {
+	ctx, cancel := context.WithTimeout(req.Context(), 30*time.Second)
+	defer cancel()
+
+	claims, err := authtypes.ClaimsFromContext(ctx)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+	defer req.Body.Close() //nolint:errcheck
+
+	silence, err := alertmanagertypes.NewPostableSilenceFromJSON(body)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	// The creator defaults to the user creating the silence.
+	if silence.CreatedBy == nil || *silence.CreatedBy == "" {
+		silence.CreatedBy = &claims.Email
+	}
+
+	id, err := api.alertmanager.CreateSilence(ctx, claims.OrgID, silence)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	render.Success(rw, http.StatusCreated, map[string]string{"id": id})
+}
+
+func (api *API) ListSilences(rw http.ResponseWriter, req *http.Request) {
+	ctx, cancel := context.WithTimeout(req.Context(), 30*time.Second)
+	defer cancel()
+
+	claims, err := authtypes.ClaimsFromContext(ctx)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	silences, err := api.alertmanager.ListSilences(ctx, claims.OrgID)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	render.Success(rw, http.StatusOK, silences)
+}
+
+func (api *API) ExpireSilence(rw http.ResponseWriter, req *http.Request) {
+	ctx, cancel := context.WithTimeout(req.Context(), 30*time.Second)
+	defer cancel()
+
+	claims, err := authtypes.ClaimsFromContext(ctx)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	id, ok := mux.Vars(req)["id"]
+	if !ok || id == "" {
+		render.Error(rw, errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "id is required in path"))
+		return
+	}
+
+	err = api.alertmanager.ExpireSilence(ctx, claims.OrgID, id)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	render.Success(rw, http.StatusNoContent, nil)
+}
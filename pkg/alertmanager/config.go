@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/SigNoz/signoz/pkg/alertmanager/alertmanagerserver"
+	"github.com/SigNoz/signoz/pkg/errors"
 	"github.com/SigNoz/signoz/pkg/factory"
 )
 
@@ -23,10 +24,25 @@ type Signoz struct {
 	// PollInterval is the interval at which the alertmanager is synced.
 	PollInterval time.Duration `mapstructure:"poll_interval"`
 
+	// DeadLetter is the config of the notifications which could not be delivered.
+	DeadLetter DeadLetter `mapstructure:"dead_letter"`
+
 	// Config is the config for the alertmanager server.
 	alertmanagerserver.Config `mapstructure:",squash" yaml:",squash"`
 }
 
+type DeadLetter struct {
+	// Retention is the time for which a notification which could not be delivered is kept since its last attempt.
+	Retention time.Duration `mapstructure:"retention"`
+
+	// PurgeInterval is the interval at which the notifications past their retention are deleted.
+	PurgeInterval time.Duration `mapstructure:"purge_interval"`
+
+	// RedispatchInterval is the interval at which the notifications not attempted within the interval are sent
+	// again. 0 disables the re-dispatches, the notifications are then only sent again on demand.
+	RedispatchInterval time.Duration `mapstructure:"redispatch_interval"`
+}
+
 type Legacy struct {
 	// ApiURL is the URL of the legacy signoz alertmanager.
 	ApiURL *url.URL `mapstructure:"api_url"`
@@ -48,11 +64,40 @@ func newConfig() factory.Config {
 		},
 		Signoz: Signoz{
 			PollInterval: 1 * time.Minute,
-			Config:       alertmanagerserver.NewConfig(),
+			DeadLetter: DeadLetter{
+				Retention:          7 * 24 * time.Hour,
+				PurgeInterval:      time.Hour,
+				RedispatchInterval: 0,
+			},
+			Config: alertmanagerserver.NewConfig(),
 		},
 	}
 }
 
 func (c Config) Validate() error {
+	if err := c.Signoz.WebhookSigning.Validate(); err != nil {
+		return err
+	}
+
+	if err := c.Signoz.Plugins.Validate(); err != nil {
+		return err
+	}
+
+	if err := c.Signoz.Priority.Validate(); err != nil {
+		return err
+	}
+
+	if c.Signoz.DeadLetter.Retention <= 0 {
+		return errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "signoz::dead_letter::retention must be greater than 0, got %v", c.Signoz.DeadLetter.Retention)
+	}
+
+	if c.Signoz.DeadLetter.PurgeInterval <= 0 {
+		return errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "signoz::dead_letter::purge_interval must be greater than 0, got %v", c.Signoz.DeadLetter.PurgeInterval)
+	}
+
+	if c.Signoz.DeadLetter.RedispatchInterval < 0 {
+		return errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "signoz::dead_letter::redispatch_interval must not be negative, got %v", c.Signoz.DeadLetter.RedispatchInterval)
+	}
+
 	return nil
 }
@@ -13,6 +13,7 @@ import (
 	"github.com/SigNoz/signoz/pkg/alertmanager"
 	"github.com/SigNoz/signoz/pkg/alertmanager/alertmanagerbatcher"
 	"github.com/SigNoz/signoz/pkg/alertmanager/alertmanagerstore/sqlalertmanagerstore"
+	"github.com/SigNoz/signoz/pkg/errors"
 	"github.com/SigNoz/signoz/pkg/factory"
 	"github.com/SigNoz/signoz/pkg/modules/organization"
 	"github.com/SigNoz/signoz/pkg/sqlstore"
@@ -62,21 +63,22 @@ type provider struct {
 	orgID       string
 }
 
-func NewFactory(sqlstore sqlstore.SQLStore, orgGetter organization.Getter) factory.ProviderFactory[alertmanager.Alertmanager, alertmanager.Config] {
+func NewFactory(sqlstore sqlstore.SQLStore, orgGetter organization.Getter, encryptor *sqlstore.Encryptor) factory.ProviderFactory[alertmanager.Alertmanager, alertmanager.Config] {
 	return factory.NewProviderFactory(factory.MustNewName("legacy"), func(ctx context.Context, settings factory.ProviderSettings, config alertmanager.Config) (alertmanager.Alertmanager, error) {
-		return New(ctx, settings, config, sqlstore, orgGetter)
+		return New(ctx, settings, config, sqlstore, orgGetter, encryptor)
 	})
 }
 
-func New(ctx context.Context, providerSettings factory.ProviderSettings, config alertmanager.Config, sqlstore sqlstore.SQLStore, orgGetter organization.Getter) (*provider, error) {
+func New(ctx context.Context, providerSettings factory.ProviderSettings, config alertmanager.Config, sqlstore sqlstore.SQLStore, orgGetter organization.Getter, encryptor *sqlstore.Encryptor) (*provider, error) {
 	settings := factory.NewScopedProviderSettings(providerSettings, "github.com/SigNoz/signoz/pkg/alertmanager/legacyalertmanager")
-	configStore := sqlalertmanagerstore.NewConfigStore(sqlstore)
+	configStore := sqlalertmanagerstore.NewConfigStore(sqlstore, encryptor)
 
 	return &provider{
 		config:   config,
 		settings: settings,
 		client: &http.Client{
-			Timeout: 30 * time.Second,
+			Timeout:   30 * time.Second,
+			Transport: providerSettings.HTTPTransport,
 		},
 		configStore: configStore,
 		batcher:     alertmanagerbatcher.New(settings.Logger(), alertmanagerbatcher.NewConfig()),
@@ -355,6 +357,14 @@ func (provider *provider) UpdateChannelByReceiverAndID(ctx context.Context, orgI
 	return nil
 }
 
+func (provider *provider) SetChannelSigningSecretByID(ctx context.Context, orgID string, id valuer.UUID, secret string) error {
+	return errors.Newf(errors.TypeUnsupported, errors.CodeUnsupported, "not supported by provider legacy")
+}
+
+func (provider *provider) SetChannelPluginByID(ctx context.Context, orgID string, id valuer.UUID, plugin string) error {
+	return errors.Newf(errors.TypeUnsupported, errors.CodeUnsupported, "not supported by provider legacy")
+}
+
 func (provider *provider) CreateChannel(ctx context.Context, orgID string, receiver alertmanagertypes.Receiver) error {
 	channel := alertmanagertypes.NewChannelFromReceiver(receiver, orgID)
 
@@ -454,6 +464,10 @@ func (provider *provider) SetConfig(ctx context.Context, config *alertmanagertyp
 	return provider.configStore.Set(ctx, config)
 }
 
+func (provider *provider) Reload(ctx context.Context, config *alertmanagertypes.Config) error {
+	return errors.Newf(errors.TypeUnsupported, errors.CodeUnsupported, "not supported by provider legacy")
+}
+
 func (provider *provider) Stop(ctx context.Context) error {
 	provider.batcher.Stop(ctx)
 	return nil
@@ -472,6 +486,26 @@ func (provider *provider) SetDefaultConfig(ctx context.Context, orgID string) er
 	return provider.configStore.Set(ctx, config)
 }
 
+func (provider *provider) ListDeadLetters(ctx context.Context, orgID string) (alertmanagertypes.GettableDeadLetters, error) {
+	return nil, errors.Newf(errors.TypeUnsupported, errors.CodeUnsupported, "not supported by provider legacy")
+}
+
+func (provider *provider) RedispatchDeadLetter(ctx context.Context, orgID string, id valuer.UUID) error {
+	return errors.Newf(errors.TypeUnsupported, errors.CodeUnsupported, "not supported by provider legacy")
+}
+
+func (provider *provider) CreateSilence(ctx context.Context, orgID string, silence *alertmanagertypes.PostableSilence) (string, error) {
+	return "", errors.Newf(errors.TypeUnsupported, errors.CodeUnsupported, "not supported by provider legacy")
+}
+
+func (provider *provider) ListSilences(ctx context.Context, orgID string) (alertmanagertypes.GettableSilences, error) {
+	return nil, errors.Newf(errors.TypeUnsupported, errors.CodeUnsupported, "not supported by provider legacy")
+}
+
+func (provider *provider) ExpireSilence(ctx context.Context, orgID string, id string) error {
+	return errors.Newf(errors.TypeUnsupported, errors.CodeUnsupported, "not supported by provider legacy")
+}
+
 func (provider *provider) Collect(ctx context.Context, orgID valuer.UUID) (map[string]any, error) {
 	channels, err := provider.configStore.ListChannels(ctx, orgID.String())
 	if err != nil {
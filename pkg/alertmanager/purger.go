@@ -0,0 +1,51 @@
+package alertmanager
+
+import (
+	"context"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/factory"
+	"github.com/SigNoz/signoz/pkg/types/alertmanagertypes"
+)
+
+// NewDeadLetterPurgeJob returns the job deleting the dead letters which were not attempted within their retention. It
+// runs on a single replica at a time.
+func NewDeadLetterPurgeJob(deadLetterStore alertmanagertypes.DeadLetterStore, config Config) factory.Job {
+	return factory.NewJob(
+		factory.MustNewName("deadletterpurger"),
+		config.Signoz.DeadLetter.PurgeInterval,
+		func(ctx context.Context) error {
+			return deadLetterStore.DeleteBefore(ctx, time.Now().Add(-config.Signoz.DeadLetter.Retention))
+		},
+		factory.WithJitter(config.Signoz.DeadLetter.PurgeInterval/10),
+		factory.WithSingleton(),
+	)
+}
+
+// NewDeadLetterRedispatchJob returns the job sending again the dead letters which were not attempted within the
+// redispatch interval, through the notification pipeline of their organization. It runs on a single replica at a
+// time. The dead letters failing again are kept until their retention.
+func NewDeadLetterRedispatchJob(alertmanager Alertmanager, deadLetterStore alertmanagertypes.DeadLetterStore, config Config) factory.Job {
+	return factory.NewJob(
+		factory.MustNewName("deadletterredispatcher"),
+		config.Signoz.DeadLetter.RedispatchInterval,
+		func(ctx context.Context) error {
+			deadLetters, err := deadLetterStore.ListAttemptedBefore(ctx, time.Now().Add(-config.Signoz.DeadLetter.RedispatchInterval))
+			if err != nil {
+				return err
+			}
+
+			errs := make([]error, 0)
+			for _, deadLetter := range deadLetters {
+				if err := alertmanager.RedispatchDeadLetter(ctx, deadLetter.OrgID, deadLetter.ID); err != nil {
+					errs = append(errs, err)
+				}
+			}
+
+			return errors.Join(errs...)
+		},
+		factory.WithJitter(config.Signoz.DeadLetter.RedispatchInterval/10),
+		factory.WithSingleton(),
+	)
+}
@@ -3,12 +3,16 @@ package alertmanager
 import (
 	"context"
 	"sync"
+	"time"
 
+	"github.com/SigNoz/signoz/pkg/alertmanager/alertmanagernotify"
 	"github.com/SigNoz/signoz/pkg/alertmanager/alertmanagerserver"
 	"github.com/SigNoz/signoz/pkg/errors"
 	"github.com/SigNoz/signoz/pkg/factory"
 	"github.com/SigNoz/signoz/pkg/modules/organization"
 	"github.com/SigNoz/signoz/pkg/types/alertmanagertypes"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
 )
 
 type Service struct {
@@ -21,6 +25,15 @@ type Service struct {
 	// configStore is the config store for the alertmanager service
 	configStore alertmanagertypes.ConfigStore
 
+	// deadLetterStore is the dead letter store for the alertmanager service
+	deadLetterStore alertmanagertypes.DeadLetterStore
+
+	// plugins are the receiver plugins of the alertmanager servers keyed by their names
+	plugins map[string]alertmanagernotify.Receiver
+
+	// httpClient is the config of the http clients of the integrations of the alertmanager servers
+	httpClient alertmanagernotify.HTTPClientConfig
+
 	// organization is the organization module for the alertmanager service
 	orgGetter organization.Getter
 
@@ -32,6 +45,9 @@ type Service struct {
 
 	// Mutex to protect the servers map
 	serversMtx sync.RWMutex
+
+	// reloads counts the configs applied to the servers
+	reloads metric.Int64Counter
 }
 
 func New(
@@ -40,19 +56,31 @@ func New(
 	config alertmanagerserver.Config,
 	stateStore alertmanagertypes.StateStore,
 	configStore alertmanagertypes.ConfigStore,
+	deadLetterStore alertmanagertypes.DeadLetterStore,
 	orgGetter organization.Getter,
-) *Service {
+	plugins map[string]alertmanagernotify.Receiver,
+	httpClient alertmanagernotify.HTTPClientConfig,
+) (*Service, error) {
+	reloads, err := settings.Meter().Int64Counter("signoz.alertmanager.config.reloads", metric.WithDescription("Number of configs applied to the alertmanager of an organization, by result."))
+	if err != nil {
+		return nil, err
+	}
+
 	service := &Service{
-		config:      config,
-		stateStore:  stateStore,
-		configStore: configStore,
-		orgGetter:   orgGetter,
-		settings:    settings,
-		servers:     make(map[string]*alertmanagerserver.Server),
-		serversMtx:  sync.RWMutex{},
+		config:          config,
+		stateStore:      stateStore,
+		configStore:     configStore,
+		deadLetterStore: deadLetterStore,
+		plugins:         plugins,
+		httpClient:      httpClient,
+		orgGetter:       orgGetter,
+		settings:        settings,
+		servers:         make(map[string]*alertmanagerserver.Server),
+		serversMtx:      sync.RWMutex{},
+		reloads:         reloads,
 	}
 
-	return service
+	return service, nil
 }
 
 func (service *Service) SyncServers(ctx context.Context) error {
@@ -61,6 +89,12 @@ func (service *Service) SyncServers(ctx context.Context) error {
 		return err
 	}
 
+	type pendingConfig struct {
+		server *alertmanagerserver.Server
+		config *alertmanagertypes.Config
+	}
+
+	pending := make([]pendingConfig, 0)
 	service.serversMtx.Lock()
 	for _, org := range orgs {
 		config, err := service.getConfig(ctx, org.ID.StringValue())
@@ -85,17 +119,53 @@ func (service *Service) SyncServers(ctx context.Context) error {
 			continue
 		}
 
-		err = service.servers[org.ID.StringValue()].SetConfig(ctx, config)
-		if err != nil {
-			service.settings.Logger().ErrorContext(ctx, "failed to set config for alertmanager server", "org_id", org.ID.StringValue(), "error", err)
+		pending = append(pending, pendingConfig{server: service.servers[org.ID.StringValue()], config: config})
+	}
+	service.serversMtx.Unlock()
+
+	// The configs are applied without the lock, the notifications in flight are drained on every change and must not
+	// hold back the other organizations.
+	for _, p := range pending {
+		if err := service.setConfig(ctx, p.server, p.config); err != nil {
+			service.settings.Logger().ErrorContext(ctx, "failed to set config for alertmanager server", "org_id", p.config.StoreableConfig().OrgID, "error", err)
 			continue
 		}
 	}
-	service.serversMtx.Unlock()
 
 	return nil
 }
 
+// Reload stores the config of its organization and applies it to its server right away instead of waiting for the
+// next sync. An invalid config is rejected before it is stored and the server keeps running with its current config.
+// The config is stored first so that a sync running meanwhile applies it rather than reverting it.
+func (service *Service) Reload(ctx context.Context, config *alertmanagertypes.Config) error {
+	if err := config.SetGlobalConfig(service.config.Global); err != nil {
+		return err
+	}
+	if err := config.SetRouteConfig(service.config.Route); err != nil {
+		return err
+	}
+
+	service.serversMtx.RLock()
+	server, err := service.getServer(config.StoreableConfig().OrgID)
+	service.serversMtx.RUnlock()
+	if err != nil {
+		return err
+	}
+
+	if err := server.ValidateConfig(ctx, config); err != nil {
+		service.reloads.Add(ctx, 1, metric.WithAttributes(attribute.String("result", "failure")))
+		return err
+	}
+
+	if err := service.configStore.Set(ctx, config); err != nil {
+		return err
+	}
+
+	// The notifications in flight are drained without the lock.
+	return service.setConfig(ctx, server, config)
+}
+
 func (service *Service) GetAlerts(ctx context.Context, orgID string, params alertmanagertypes.GettableAlertsParams) (alertmanagertypes.DeprecatedGettableAlerts, error) {
 	service.serversMtx.RLock()
 	defer service.serversMtx.RUnlock()
@@ -149,6 +219,59 @@ func (service *Service) TestAlert(ctx context.Context, orgID string, alert *aler
 	return server.TestAlert(ctx, alert, receivers)
 }
 
+func (service *Service) RedispatchDeadLetter(ctx context.Context, orgID string, deadLetter *alertmanagertypes.DeadLetter) error {
+	service.serversMtx.RLock()
+	server, err := service.getServer(orgID)
+	service.serversMtx.RUnlock()
+	if err != nil {
+		return err
+	}
+
+	// The notification is delivered without the lock, a slow receiver must not hold back the syncs of the servers.
+	return server.RedispatchDeadLetter(ctx, deadLetter)
+}
+
+func (service *Service) CreateSilence(ctx context.Context, orgID string, postableSilence *alertmanagertypes.PostableSilence) (string, error) {
+	silence, err := alertmanagertypes.NewSilenceFromPostableSilence(postableSilence, time.Now())
+	if err != nil {
+		return "", err
+	}
+
+	service.serversMtx.RLock()
+	defer service.serversMtx.RUnlock()
+
+	server, err := service.getServer(orgID)
+	if err != nil {
+		return "", err
+	}
+
+	return server.CreateSilence(ctx, silence)
+}
+
+func (service *Service) ListSilences(ctx context.Context, orgID string) (alertmanagertypes.GettableSilences, error) {
+	service.serversMtx.RLock()
+	defer service.serversMtx.RUnlock()
+
+	server, err := service.getServer(orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	return server.ListSilences(ctx)
+}
+
+func (service *Service) ExpireSilence(ctx context.Context, orgID string, id string) error {
+	service.serversMtx.RLock()
+	defer service.serversMtx.RUnlock()
+
+	server, err := service.getServer(orgID)
+	if err != nil {
+		return err
+	}
+
+	return server.ExpireSilence(ctx, id)
+}
+
 func (service *Service) Stop(ctx context.Context) error {
 	var errs []error
 	for _, server := range service.servers {
@@ -167,7 +290,7 @@ func (service *Service) newServer(ctx context.Context, orgID string) (*alertmana
 		return nil, err
 	}
 
-	server, err := alertmanagerserver.New(ctx, service.settings.Logger(), service.settings.PrometheusRegisterer(), service.config, orgID, service.stateStore)
+	server, err := alertmanagerserver.New(ctx, service.settings.Logger(), service.settings.PrometheusRegisterer(), service.config, orgID, service.stateStore, service.deadLetterStore, service.configStore, service.plugins, service.httpClient)
 	if err != nil {
 		return nil, err
 	}
@@ -225,6 +348,11 @@ func (service *Service) compareAndSelectConfig(ctx context.Context, incomingConf
 		return nil, err
 	}
 
+	settings, err := service.configStore.GetNotificationSettings(ctx, incomingConfig.StoreableConfig().OrgID)
+	if err != nil {
+		return nil, err
+	}
+
 	config, err := alertmanagertypes.NewConfigFromChannels(service.config.Global, service.config.Route, channels, incomingConfig.StoreableConfig().OrgID)
 	if err != nil {
 		return nil, err
@@ -235,6 +363,11 @@ func (service *Service) compareAndSelectConfig(ctx context.Context, incomingConf
 		if err != nil {
 			return nil, err
 		}
+
+		err = config.CreateRuleIDRoutes(ruleID, receivers, settings[ruleID])
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	if incomingConfig.StoreableConfig().Hash != config.StoreableConfig().Hash {
@@ -246,6 +379,21 @@ func (service *Service) compareAndSelectConfig(ctx context.Context, incomingConf
 
 }
 
+// setConfig sets the config of the server and records the result. It should be called with the lock held.
+func (service *Service) setConfig(ctx context.Context, server *alertmanagerserver.Server, config *alertmanagertypes.Config) error {
+	orgID := config.StoreableConfig().OrgID
+	previousHash := server.Hash()
+
+	if err := server.SetConfig(ctx, config); err != nil {
+		service.reloads.Add(ctx, 1, metric.WithAttributes(attribute.String("result", "failure")))
+		return err
+	}
+
+	service.reloads.Add(ctx, 1, metric.WithAttributes(attribute.String("result", "success")))
+	service.settings.Logger().InfoContext(ctx, "alertmanager config reloaded", "org_id", orgID, "previous_hash", previousHash, "hash", server.Hash())
+	return nil
+}
+
 // getServer returns the server for the given orgID. It should be called with the lock held.
 func (service *Service) getServer(orgID string) (*alertmanagerserver.Server, error) {
 	server, ok := service.servers[orgID]
@@ -0,0 +1,30 @@
+package signozalertmanager
+
+import (
+	"context"
+
+	"github.com/SigNoz/signoz/pkg/maintenance"
+	"github.com/SigNoz/signoz/pkg/types/alertmanagertypes"
+)
+
+// maintenanceStateStore skips the snapshots of the silences and the notification log in maintenance mode. The
+// snapshots hold the whole state, hence the first snapshot after maintenance mode catches up with the skipped ones.
+type maintenanceStateStore struct {
+	alertmanagertypes.StateStore
+	maintenance *maintenance.Maintenance
+}
+
+func newMaintenanceStateStore(stateStore alertmanagertypes.StateStore, maintenance *maintenance.Maintenance) *maintenanceStateStore {
+	return &maintenanceStateStore{
+		StateStore:  stateStore,
+		maintenance: maintenance,
+	}
+}
+
+func (store *maintenanceStateStore) Set(ctx context.Context, orgID string, state *alertmanagertypes.StoreableState) error {
+	if store.maintenance.Enabled() {
+		return nil
+	}
+
+	return store.StateStore.Set(ctx, orgID, state)
+}
@@ -5,49 +5,87 @@ import (
 	"time"
 
 	"github.com/SigNoz/signoz/pkg/alertmanager"
+	"github.com/SigNoz/signoz/pkg/alertmanager/alertmanagernotify"
+	"github.com/SigNoz/signoz/pkg/alertmanager/alertmanagerserver"
 	"github.com/SigNoz/signoz/pkg/alertmanager/alertmanagerstore/sqlalertmanagerstore"
 	"github.com/SigNoz/signoz/pkg/errors"
 	"github.com/SigNoz/signoz/pkg/factory"
+	"github.com/SigNoz/signoz/pkg/http/client"
+	"github.com/SigNoz/signoz/pkg/maintenance"
 	"github.com/SigNoz/signoz/pkg/modules/organization"
 	"github.com/SigNoz/signoz/pkg/sqlstore"
 	"github.com/SigNoz/signoz/pkg/types/alertmanagertypes"
 	"github.com/SigNoz/signoz/pkg/valuer"
+	commoncfg "github.com/prometheus/common/config"
 )
 
 type provider struct {
-	service     *alertmanager.Service
-	config      alertmanager.Config
-	settings    factory.ScopedProviderSettings
-	configStore alertmanagertypes.ConfigStore
-	stateStore  alertmanagertypes.StateStore
-	stopC       chan struct{}
+	service         *alertmanager.Service
+	config          alertmanager.Config
+	settings        factory.ScopedProviderSettings
+	configStore     alertmanagertypes.ConfigStore
+	stateStore      alertmanagertypes.StateStore
+	deadLetterStore alertmanagertypes.DeadLetterStore
+	maintenance     *maintenance.Maintenance
+	plugins         map[string]alertmanagernotify.Receiver
+	stopC           chan struct{}
+	// syncedC is closed once the servers of all the organizations have been synced for the first time.
+	syncedC chan struct{}
 }
 
-func NewFactory(sqlstore sqlstore.SQLStore, orgGetter organization.Getter) factory.ProviderFactory[alertmanager.Alertmanager, alertmanager.Config] {
+func NewFactory(sqlstore sqlstore.SQLStore, orgGetter organization.Getter, maintenance *maintenance.Maintenance, encryptor *sqlstore.Encryptor, pluginFactories factory.NamedMap[factory.ProviderFactory[alertmanagernotify.Receiver, alertmanagernotify.ReceiverConfig]]) factory.ProviderFactory[alertmanager.Alertmanager, alertmanager.Config] {
 	return factory.NewProviderFactory(factory.MustNewName("signoz"), func(ctx context.Context, settings factory.ProviderSettings, config alertmanager.Config) (alertmanager.Alertmanager, error) {
-		return New(ctx, settings, config, sqlstore, orgGetter)
+		return New(ctx, settings, config, sqlstore, orgGetter, maintenance, encryptor, pluginFactories)
 	})
 }
 
-func New(ctx context.Context, providerSettings factory.ProviderSettings, config alertmanager.Config, sqlstore sqlstore.SQLStore, orgGetter organization.Getter) (*provider, error) {
+func New(ctx context.Context, providerSettings factory.ProviderSettings, config alertmanager.Config, sqlstore sqlstore.SQLStore, orgGetter organization.Getter, maintenance *maintenance.Maintenance, encryptor *sqlstore.Encryptor, pluginFactories factory.NamedMap[factory.ProviderFactory[alertmanagernotify.Receiver, alertmanagernotify.ReceiverConfig]]) (*provider, error) {
 	settings := factory.NewScopedProviderSettings(providerSettings, "github.com/SigNoz/signoz/pkg/alertmanager/signozalertmanager")
-	configStore := sqlalertmanagerstore.NewConfigStore(sqlstore)
-	stateStore := sqlalertmanagerstore.NewStateStore(sqlstore)
+	configStore := sqlalertmanagerstore.NewConfigStore(sqlstore, encryptor)
+	stateStore := newMaintenanceStateStore(sqlalertmanagerstore.NewStateStore(sqlstore), maintenance)
+	deadLetterStore := sqlalertmanagerstore.NewDeadLetterStore(sqlstore)
+
+	plugins, err := alertmanagerserver.NewPlugins(ctx, providerSettings, config.Signoz.Plugins, pluginFactories)
+	if err != nil {
+		return nil, err
+	}
+
+	// The clients of the notifiers are built by the alertmanager with their own pool, the limits and the timeouts of
+	// the shared transport are applied to their connections when dialing and to their requests through their context.
+	var httpClient alertmanagernotify.HTTPClientConfig
+	if transport, ok := providerSettings.HTTPTransport.(*client.Transport); ok {
+		httpClient = alertmanagernotify.HTTPClientConfig{
+			Options:        []commoncfg.HTTPClientOption{commoncfg.WithDialContextFunc(transport.LimitedDialContext), commoncfg.WithIdleConnTimeout(transport.IdleConnTimeout())},
+			RequestContext: transport.WithTimeouts,
+		}
+	}
+
+	service, err := alertmanager.New(
+		ctx,
+		settings,
+		config.Signoz.Config,
+		stateStore,
+		configStore,
+		deadLetterStore,
+		orgGetter,
+		plugins,
+		httpClient,
+	)
+	if err != nil {
+		return nil, err
+	}
 
 	p := &provider{
-		service: alertmanager.New(
-			ctx,
-			settings,
-			config.Signoz.Config,
-			stateStore,
-			configStore,
-			orgGetter,
-		),
-		settings:    settings,
-		config:      config,
-		configStore: configStore,
-		stateStore:  stateStore,
-		stopC:       make(chan struct{}),
+		service:         service,
+		settings:        settings,
+		config:          config,
+		configStore:     configStore,
+		stateStore:      stateStore,
+		deadLetterStore: deadLetterStore,
+		maintenance:     maintenance,
+		plugins:         plugins,
+		stopC:           make(chan struct{}),
+		syncedC:         make(chan struct{}),
 	}
 
 	return p, nil
@@ -58,6 +96,7 @@ func (provider *provider) Start(ctx context.Context) error {
 		provider.settings.Logger().ErrorContext(ctx, "failed to sync alertmanager servers", "error", err)
 		return err
 	}
+	close(provider.syncedC)
 
 	ticker := time.NewTicker(provider.config.Signoz.PollInterval)
 	defer ticker.Stop()
@@ -66,6 +105,11 @@ func (provider *provider) Start(ctx context.Context) error {
 		case <-provider.stopC:
 			return nil
 		case <-ticker.C:
+			if provider.maintenance.Enabled() {
+				provider.settings.Logger().DebugContext(ctx, "skipping alertmanager sync in maintenance mode")
+				continue
+			}
+
 			if err := provider.service.SyncServers(ctx); err != nil {
 				provider.settings.Logger().ErrorContext(ctx, "failed to sync alertmanager servers", "error", err)
 			}
@@ -78,6 +122,24 @@ func (provider *provider) Stop(ctx context.Context) error {
 	return provider.service.Stop(ctx)
 }
 
+func (provider *provider) Healthy(ctx context.Context) error {
+	select {
+	case <-provider.stopC:
+		return errors.Newf(errors.TypeInternal, errors.CodeInternal, "alertmanager has been stopped")
+	default:
+		return nil
+	}
+}
+
+func (provider *provider) Ready(ctx context.Context) error {
+	select {
+	case <-provider.syncedC:
+		return provider.Healthy(ctx)
+	default:
+		return errors.Newf(errors.TypeInternal, errors.CodeInternal, "alertmanager servers have not been synced yet")
+	}
+}
+
 func (provider *provider) GetAlerts(ctx context.Context, orgID string, params alertmanagertypes.GettableAlertsParams) (alertmanagertypes.DeprecatedGettableAlerts, error) {
 	return provider.service.GetAlerts(ctx, orgID, params)
 }
@@ -130,6 +192,33 @@ func (provider *provider) UpdateChannelByReceiverAndID(ctx context.Context, orgI
 	}))
 }
 
+func (provider *provider) SetChannelSigningSecretByID(ctx context.Context, orgID string, id valuer.UUID, secret string) error {
+	channel, err := provider.configStore.GetChannelByID(ctx, orgID, id)
+	if err != nil {
+		return err
+	}
+
+	if err := channel.SetSigningSecret(secret); err != nil {
+		return err
+	}
+
+	return provider.configStore.UpdateChannel(ctx, orgID, channel)
+}
+
+func (provider *provider) SetChannelPluginByID(ctx context.Context, orgID string, id valuer.UUID, plugin string) error {
+	if _, ok := provider.plugins[plugin]; plugin != "" && !ok {
+		return errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "plugin %q is not registered", plugin)
+	}
+
+	channel, err := provider.configStore.GetChannelByID(ctx, orgID, id)
+	if err != nil {
+		return err
+	}
+
+	channel.SetPlugin(plugin)
+	return provider.configStore.UpdateChannel(ctx, orgID, channel)
+}
+
 func (provider *provider) DeleteChannelByID(ctx context.Context, orgID string, channelID valuer.UUID) error {
 	channel, err := provider.configStore.GetChannelByID(ctx, orgID, channelID)
 	if err != nil {
@@ -170,6 +259,10 @@ func (provider *provider) SetConfig(ctx context.Context, config *alertmanagertyp
 	return provider.configStore.Set(ctx, config)
 }
 
+func (provider *provider) Reload(ctx context.Context, config *alertmanagertypes.Config) error {
+	return provider.service.Reload(ctx, config)
+}
+
 func (provider *provider) GetConfig(ctx context.Context, orgID string) (*alertmanagertypes.Config, error) {
 	return provider.configStore.Get(ctx, orgID)
 }
@@ -183,6 +276,31 @@ func (provider *provider) SetDefaultConfig(ctx context.Context, orgID string) er
 	return provider.configStore.Set(ctx, config)
 }
 
+func (provider *provider) ListDeadLetters(ctx context.Context, orgID string) (alertmanagertypes.GettableDeadLetters, error) {
+	return provider.deadLetterStore.List(ctx, orgID)
+}
+
+func (provider *provider) RedispatchDeadLetter(ctx context.Context, orgID string, id valuer.UUID) error {
+	deadLetter, err := provider.deadLetterStore.Get(ctx, orgID, id)
+	if err != nil {
+		return err
+	}
+
+	return provider.service.RedispatchDeadLetter(ctx, orgID, deadLetter)
+}
+
+func (provider *provider) CreateSilence(ctx context.Context, orgID string, silence *alertmanagertypes.PostableSilence) (string, error) {
+	return provider.service.CreateSilence(ctx, orgID, silence)
+}
+
+func (provider *provider) ListSilences(ctx context.Context, orgID string) (alertmanagertypes.GettableSilences, error) {
+	return provider.service.ListSilences(ctx, orgID)
+}
+
+func (provider *provider) ExpireSilence(ctx context.Context, orgID string, id string) error {
+	return provider.service.ExpireSilence(ctx, orgID, id)
+}
+
 func (provider *provider) Collect(ctx context.Context, orgID valuer.UUID) (map[string]any, error) {
 	channels, err := provider.configStore.ListChannels(ctx, orgID.String())
 	if err != nil {
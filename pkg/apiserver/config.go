@@ -1,15 +1,23 @@
 package apiserver
 
 import (
+	"net/url"
 	"time"
 
+	"github.com/SigNoz/signoz/pkg/errors"
 	"github.com/SigNoz/signoz/pkg/factory"
+	"github.com/SigNoz/signoz/pkg/types"
+	"github.com/SigNoz/signoz/pkg/types/authtypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
 )
 
 // Config holds the configuration for config.
 type Config struct {
-	Timeout Timeout `mapstructure:"timeout"`
-	Logging Logging `mapstructure:"logging"`
+	Timeout   Timeout   `mapstructure:"timeout"`
+	Logging   Logging   `mapstructure:"logging"`
+	RateLimit RateLimit `mapstructure:"rate_limit"`
+	Auth      Auth      `mapstructure:"auth"`
+	Shutdown  Shutdown  `mapstructure:"shutdown"`
 }
 
 type Timeout struct {
@@ -24,6 +32,97 @@ type Timeout struct {
 type Logging struct {
 	// The list of routes that are excluded from the logging
 	ExcludedRoutes []string `mapstructure:"excluded_routes"`
+	// The fraction of the successful requests which are logged, between 0 and 1. The failed requests are always logged
+	SampleRate float64 `mapstructure:"sample_rate"`
+	// The logging of the bodies of the requests and the responses
+	Body LoggingBody `mapstructure:"body"`
+}
+
+type LoggingBody struct {
+	// Whether to log the bodies of the requests and the responses. The bodies of the failed responses are always logged
+	Enabled bool `mapstructure:"enabled"`
+	// The maximum number of bytes of a body which are logged, the bodies are truncated beyond
+	MaxSize int `mapstructure:"max_size"`
+	// The fields of the json and form bodies whose values are redacted, matched case insensitively as substrings of
+	// the names of the fields at any depth
+	RedactedFields []string `mapstructure:"redacted_fields"`
+}
+
+type RateLimit struct {
+	// Whether to rate limit the requests of every tenant
+	Enabled bool `mapstructure:"enabled"`
+	// The limit applied to the routes which are not in routes
+	Default Limit `mapstructure:"default"`
+	// The limits of the routes keyed by the route path template, for example /api/v3/query_range
+	Routes map[string]Limit `mapstructure:"routes"`
+	// The limits of the requests authenticated by an api key, which are limited per api key in addition to the limits
+	// of the tenant
+	APIKeys APIKeysRateLimit `mapstructure:"api_keys"`
+}
+
+type APIKeysRateLimit struct {
+	// The limit of every api key applied to the routes which are not covered by scopes
+	Default Limit `mapstructure:"default"`
+	// The limits of every api key keyed by scope, for example read:telemetry. The requests to the routes requiring a
+	// scope are limited by the limit of the scope
+	Scopes map[string]Limit `mapstructure:"scopes"`
+}
+
+type Auth struct {
+	// The issuers whose tokens are accepted in addition to the tokens issued by signoz
+	TrustedIssuers []TrustedIssuer `mapstructure:"trusted_issuers"`
+}
+
+type TrustedIssuer struct {
+	// The iss claim of the tokens of the issuer
+	Issuer string `mapstructure:"issuer"`
+	// The url of the json web key set of the issuer
+	JWKSURL string `mapstructure:"jwks_url"`
+	// The aud claim the tokens of the issuer must hold
+	Audience string `mapstructure:"audience"`
+	// The id of the organization the tokens of the issuer are accepted for
+	OrgID string `mapstructure:"org_id"`
+	// The claim holding the roles of the users at the issuer, roles by default
+	RoleClaim string `mapstructure:"role_claim"`
+	// The roles of the organization keyed by the roles of the users at the issuer
+	Roles map[string]string `mapstructure:"roles"`
+	// The role of the users without any mapped role, their tokens are rejected when empty
+	DefaultRole string `mapstructure:"default_role"`
+}
+
+// Issuers returns the trusted issuers whose tokens are accepted. It must be called on a validated config.
+func (a Auth) Issuers() []authtypes.TrustedIssuer {
+	issuers := make([]authtypes.TrustedIssuer, 0, len(a.TrustedIssuers))
+	for _, issuer := range a.TrustedIssuers {
+		roles := make(map[string]types.Role, len(issuer.Roles))
+		for from, to := range issuer.Roles {
+			roles[from] = types.Role(to)
+		}
+
+		issuers = append(issuers, authtypes.TrustedIssuer{
+			Issuer:      issuer.Issuer,
+			JWKSURL:     issuer.JWKSURL,
+			Audience:    issuer.Audience,
+			OrgID:       valuer.MustNewUUID(issuer.OrgID),
+			RoleClaim:   issuer.RoleClaim,
+			Roles:       roles,
+			DefaultRole: types.Role(issuer.DefaultRole),
+		})
+	}
+
+	return issuers
+}
+
+type Shutdown struct {
+	// The duration for which the in-flight requests are awaited on shutdown before their connections are closed
+	GracePeriod time.Duration `mapstructure:"grace_period"`
+}
+
+type Limit struct {
+	// The number of requests per second that are refilled in the bucket
+	Rate float64 `mapstructure:"rate"`
+	// The maximum number of requests that can be made at once
+	Burst int `mapstructure:"burst"`
 }
 
 func NewConfigFactory() factory.ConfigFactory {
@@ -38,18 +137,149 @@ func newConfig() factory.Config {
 			ExcludedRoutes: []string{
 				"/api/v1/logs/tail",
 				"/api/v3/logs/livetail",
+				"/api/v5/query_range/export",
 			},
 		},
 		Logging: Logging{
 			ExcludedRoutes: []string{
 				"/api/v1/health",
 				"/api/v1/version",
+				"/ready",
+				"/live",
 				"/",
 			},
+			SampleRate: 1,
+			Body: LoggingBody{
+				Enabled: false,
+				MaxSize: 4096,
+				RedactedFields: []string{
+					"password",
+					"secret",
+					"token",
+					"key",
+					"jwt",
+				},
+			},
+		},
+		RateLimit: RateLimit{
+			Enabled: false,
+			Default: Limit{
+				Rate:  50,
+				Burst: 100,
+			},
+			Routes: map[string]Limit{},
+			APIKeys: APIKeysRateLimit{
+				Default: Limit{
+					Rate:  10,
+					Burst: 50,
+				},
+				Scopes: map[string]Limit{},
+			},
+		},
+		Auth: Auth{
+			TrustedIssuers: []TrustedIssuer{},
+		},
+		Shutdown: Shutdown{
+			GracePeriod: 30 * time.Second,
 		},
 	}
 }
 
 func (c Config) Validate() error {
+	if c.RateLimit.Enabled {
+		if err := c.RateLimit.Default.validate(); err != nil {
+			return errors.Wrapf(err, errors.TypeInvalidInput, errors.CodeInvalidInput, "invalid rate_limit::default")
+		}
+
+		for route, limit := range c.RateLimit.Routes {
+			if err := limit.validate(); err != nil {
+				return errors.Wrapf(err, errors.TypeInvalidInput, errors.CodeInvalidInput, "invalid rate_limit::routes for %q", route)
+			}
+		}
+
+		if err := c.RateLimit.APIKeys.Default.validate(); err != nil {
+			return errors.Wrapf(err, errors.TypeInvalidInput, errors.CodeInvalidInput, "invalid rate_limit::api_keys::default")
+		}
+
+		for scope, limit := range c.RateLimit.APIKeys.Scopes {
+			if _, err := types.NewAPIKeyScopes([]string{scope}); err != nil {
+				return errors.Wrapf(err, errors.TypeInvalidInput, errors.CodeInvalidInput, "invalid rate_limit::api_keys::scopes")
+			}
+
+			if err := limit.validate(); err != nil {
+				return errors.Wrapf(err, errors.TypeInvalidInput, errors.CodeInvalidInput, "invalid rate_limit::api_keys::scopes for %q", scope)
+			}
+		}
+	}
+
+	if c.Logging.SampleRate < 0 || c.Logging.SampleRate > 1 {
+		return errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "logging::sample_rate must be between 0 and 1, got %v", c.Logging.SampleRate)
+	}
+
+	if c.Logging.Body.MaxSize <= 0 {
+		return errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "logging::body::max_size must be positive, got %v", c.Logging.Body.MaxSize)
+	}
+
+	if c.Shutdown.GracePeriod <= 0 {
+		return errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "shutdown::grace_period must be positive, got %v", c.Shutdown.GracePeriod)
+	}
+
+	issuers := make(map[string]struct{}, len(c.Auth.TrustedIssuers))
+	for i, issuer := range c.Auth.TrustedIssuers {
+		if err := issuer.validate(); err != nil {
+			return errors.Wrapf(err, errors.TypeInvalidInput, errors.CodeInvalidInput, "invalid auth::trusted_issuers[%d]", i)
+		}
+
+		if _, ok := issuers[issuer.Issuer]; ok {
+			return errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "auth::trusted_issuers must not contain %q more than once", issuer.Issuer)
+		}
+		issuers[issuer.Issuer] = struct{}{}
+	}
+
+	return nil
+}
+
+func (issuer TrustedIssuer) validate() error {
+	if issuer.Issuer == "" {
+		return errors.New(errors.TypeInvalidInput, errors.CodeInvalidInput, "issuer must not be empty")
+	}
+
+	jwksURL, err := url.Parse(issuer.JWKSURL)
+	if err != nil || (jwksURL.Scheme != "http" && jwksURL.Scheme != "https") || jwksURL.Host == "" {
+		return errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "jwks_url must be an http or https url, got %q", issuer.JWKSURL)
+	}
+
+	if issuer.Audience == "" {
+		return errors.New(errors.TypeInvalidInput, errors.CodeInvalidInput, "audience must not be empty")
+	}
+
+	if _, err := valuer.NewUUID(issuer.OrgID); err != nil {
+		return errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "org_id must be a uuid, got %q", issuer.OrgID)
+	}
+
+	for from, to := range issuer.Roles {
+		if _, err := types.NewRole(to); err != nil {
+			return errors.Wrapf(err, errors.TypeInvalidInput, errors.CodeInvalidInput, "invalid roles for %q", from)
+		}
+	}
+
+	if issuer.DefaultRole != "" {
+		if _, err := types.NewRole(issuer.DefaultRole); err != nil {
+			return errors.Wrapf(err, errors.TypeInvalidInput, errors.CodeInvalidInput, "invalid default_role")
+		}
+	}
+
+	return nil
+}
+
+func (l Limit) validate() error {
+	if l.Rate <= 0 {
+		return errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "rate must be positive, got %v", l.Rate)
+	}
+
+	if l.Burst < 1 {
+		return errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "burst must be at least 1, got %v", l.Burst)
+	}
+
 	return nil
 }
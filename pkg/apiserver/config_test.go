@@ -50,8 +50,101 @@ func TestNewWithEnvProvider(t *testing.T) {
 			ExcludedRoutes: []string{
 				"/api/v1/health1",
 			},
+			SampleRate: 1,
+			Body: LoggingBody{
+				Enabled: false,
+				MaxSize: 4096,
+				RedactedFields: []string{
+					"password",
+					"secret",
+					"token",
+					"key",
+					"jwt",
+				},
+			},
+		},
+		RateLimit: RateLimit{
+			Enabled: false,
+			Default: Limit{
+				Rate:  50,
+				Burst: 100,
+			},
+			Routes: map[string]Limit{},
+			APIKeys: APIKeysRateLimit{
+				Default: Limit{
+					Rate:  10,
+					Burst: 50,
+				},
+				Scopes: map[string]Limit{},
+			},
+		},
+		Auth: Auth{
+			TrustedIssuers: []TrustedIssuer{},
+		},
+		Shutdown: Shutdown{
+			GracePeriod: 30 * time.Second,
 		},
 	}
 
 	assert.Equal(t, expected, actual)
 }
+
+func TestValidateTrustedIssuers(t *testing.T) {
+	issuer := TrustedIssuer{Issuer: "https://idp.example.com", JWKSURL: "https://idp.example.com/.well-known/jwks.json", Audience: "signoz", OrgID: "0196f794-ff30-7bee-a5f4-ef5ad315715e", Roles: map[string]string{"admins": "ADMIN"}}
+
+	testCases := []struct {
+		name    string
+		issuers []TrustedIssuer
+		pass    bool
+	}{
+		{name: "Valid", issuers: []TrustedIssuer{issuer}, pass: true},
+		{name: "EmptyIssuer", issuers: []TrustedIssuer{{JWKSURL: issuer.JWKSURL, OrgID: issuer.OrgID}}, pass: false},
+		{name: "InvalidJWKSURL", issuers: []TrustedIssuer{{Issuer: issuer.Issuer, JWKSURL: "idp.example.com", Audience: issuer.Audience, OrgID: issuer.OrgID}}, pass: false},
+		{name: "EmptyAudience", issuers: []TrustedIssuer{{Issuer: issuer.Issuer, JWKSURL: issuer.JWKSURL, OrgID: issuer.OrgID}}, pass: false},
+		{name: "InvalidOrgID", issuers: []TrustedIssuer{{Issuer: issuer.Issuer, JWKSURL: issuer.JWKSURL, Audience: issuer.Audience, OrgID: "org"}}, pass: false},
+		{name: "InvalidRole", issuers: []TrustedIssuer{{Issuer: issuer.Issuer, JWKSURL: issuer.JWKSURL, Audience: issuer.Audience, OrgID: issuer.OrgID, Roles: map[string]string{"admins": "OWNER"}}}, pass: false},
+		{name: "InvalidDefaultRole", issuers: []TrustedIssuer{{Issuer: issuer.Issuer, JWKSURL: issuer.JWKSURL, Audience: issuer.Audience, OrgID: issuer.OrgID, DefaultRole: "admin"}}, pass: false},
+		{name: "DuplicateIssuer", issuers: []TrustedIssuer{issuer, issuer}, pass: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			config := newConfig().(*Config)
+			config.Auth.TrustedIssuers = tc.issuers
+
+			err := config.Validate()
+			if tc.pass {
+				assert.NoError(t, err)
+				return
+			}
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestValidateAPIKeysRateLimit(t *testing.T) {
+	testCases := []struct {
+		name   string
+		scopes map[string]Limit
+		pass   bool
+	}{
+		{name: "Valid", scopes: map[string]Limit{"read:telemetry": {Rate: 1, Burst: 10}}, pass: true},
+		{name: "InvalidScope", scopes: map[string]Limit{"read:everything": {Rate: 1, Burst: 10}}, pass: false},
+		{name: "InvalidLimit", scopes: map[string]Limit{"write:dashboards": {Rate: 0, Burst: 10}}, pass: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			config := newConfig().(*Config)
+			config.RateLimit.Enabled = true
+			config.RateLimit.APIKeys.Scopes = tc.scopes
+
+			err := config.Validate()
+			if tc.pass {
+				assert.NoError(t, err)
+				return
+			}
+			assert.Error(t, err)
+		})
+	}
+}
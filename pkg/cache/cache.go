@@ -18,6 +18,17 @@ type Cache interface {
 	Delete(ctx context.Context, orgID valuer.UUID, cacheKey string)
 	// DeleteMany deletes multiple cacheble entities from cache
 	DeleteMany(ctx context.Context, orgID valuer.UUID, cacheKeys []string)
+	// GetMany gets the binary representation of multiple cacheable entities in a single round trip.
+	// Keys which are not found are absent from the returned map.
+	GetMany(ctx context.Context, orgID valuer.UUID, cacheKeys []string) (map[string][]byte, error)
+	// SetMany sets multiple cacheable entities in cache in a single round trip.
+	SetMany(ctx context.Context, orgID valuer.UUID, items map[string]cachetypes.Item) error
+	// DeleteByPrefix deletes all the cacheable entities whose keys start with the given prefix. An empty prefix
+	// deletes all the cacheable entities of the org.
+	DeleteByPrefix(ctx context.Context, orgID valuer.UUID, prefix string) error
+	// WithNamespace returns a view of the cache which transparently prefixes all the keys with the namespace.
+	// Views with different namespaces never see each other's keys.
+	WithNamespace(namespace string) Cache
 }
 
 type KeyGenerator interface {
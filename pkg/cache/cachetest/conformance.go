@@ -0,0 +1,191 @@
+package cachetest
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/cache"
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/types/cachetypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Entity is a cacheable entity used by the conformance tests.
+type Entity struct {
+	Value string `json:"value"`
+}
+
+func (entity *Entity) MarshalBinary() ([]byte, error) {
+	return json.Marshal(entity)
+}
+
+func (entity *Entity) UnmarshalBinary(data []byte) error {
+	return json.Unmarshal(data, entity)
+}
+
+// RunConformance runs the tests every implementation of cache.Cache must pass against the caches returned by
+// newCache, which must return an empty cache on every call.
+func RunConformance(t *testing.T, newCache func(t *testing.T) cache.Cache) {
+	ctx := context.Background()
+
+	t.Run("SetGet", func(t *testing.T) {
+		c := newCache(t)
+		orgID := valuer.GenerateUUID()
+
+		require.NoError(t, c.Set(ctx, orgID, "key", &Entity{Value: "value"}, time.Minute))
+
+		dest := new(Entity)
+		require.NoError(t, c.Get(ctx, orgID, "key", dest, false))
+		assert.Equal(t, "value", dest.Value)
+	})
+
+	t.Run("GetMissing", func(t *testing.T) {
+		c := newCache(t)
+
+		err := c.Get(ctx, valuer.GenerateUUID(), "key", new(Entity), false)
+		assert.True(t, errors.Ast(err, errors.TypeNotFound), "expected a not found error, got %v", err)
+	})
+
+	t.Run("Overwrite", func(t *testing.T) {
+		c := newCache(t)
+		orgID := valuer.GenerateUUID()
+
+		require.NoError(t, c.Set(ctx, orgID, "key", &Entity{Value: "first"}, time.Minute))
+		require.NoError(t, c.Set(ctx, orgID, "key", &Entity{Value: "second"}, time.Minute))
+
+		dest := new(Entity)
+		require.NoError(t, c.Get(ctx, orgID, "key", dest, false))
+		assert.Equal(t, "second", dest.Value)
+	})
+
+	t.Run("OrgIsolation", func(t *testing.T) {
+		c := newCache(t)
+		orgID := valuer.GenerateUUID()
+
+		require.NoError(t, c.Set(ctx, orgID, "key", &Entity{Value: "value"}, time.Minute))
+
+		err := c.Get(ctx, valuer.GenerateUUID(), "key", new(Entity), false)
+		assert.True(t, errors.Ast(err, errors.TypeNotFound), "expected a not found error, got %v", err)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		c := newCache(t)
+		orgID := valuer.GenerateUUID()
+
+		require.NoError(t, c.Set(ctx, orgID, "a", &Entity{Value: "a"}, time.Minute))
+		require.NoError(t, c.Set(ctx, orgID, "b", &Entity{Value: "b"}, time.Minute))
+		require.NoError(t, c.Set(ctx, orgID, "c", &Entity{Value: "c"}, time.Minute))
+
+		c.Delete(ctx, orgID, "a")
+		c.DeleteMany(ctx, orgID, []string{"b"})
+
+		values, err := c.GetMany(ctx, orgID, []string{"a", "b", "c"})
+		require.NoError(t, err)
+		assert.Len(t, values, 1)
+		assert.Contains(t, values, "c")
+	})
+
+	t.Run("SetManyGetMany", func(t *testing.T) {
+		c := newCache(t)
+		orgID := valuer.GenerateUUID()
+
+		require.NoError(t, c.SetMany(ctx, orgID, map[string]cachetypes.Item{
+			"a": {Data: &Entity{Value: "a"}, TTL: time.Minute},
+			"b": {Data: &Entity{Value: "b"}, TTL: time.Minute},
+		}))
+
+		values, err := c.GetMany(ctx, orgID, []string{"a", "b", "missing"})
+		require.NoError(t, err)
+		require.Len(t, values, 2)
+
+		for _, key := range []string{"a", "b"} {
+			dest := new(Entity)
+			require.NoError(t, dest.UnmarshalBinary(values[key]))
+			assert.Equal(t, key, dest.Value)
+		}
+	})
+
+	t.Run("DeleteByPrefix", func(t *testing.T) {
+		c := newCache(t)
+		orgID := valuer.GenerateUUID()
+
+		require.NoError(t, c.Set(ctx, orgID, "prefix-a", &Entity{Value: "a"}, time.Minute))
+		require.NoError(t, c.Set(ctx, orgID, "prefix-b", &Entity{Value: "b"}, time.Minute))
+		require.NoError(t, c.Set(ctx, orgID, "other", &Entity{Value: "other"}, time.Minute))
+
+		require.NoError(t, c.DeleteByPrefix(ctx, orgID, "prefix-"))
+
+		values, err := c.GetMany(ctx, orgID, []string{"prefix-a", "prefix-b", "other"})
+		require.NoError(t, err)
+		assert.Len(t, values, 1)
+		assert.Contains(t, values, "other")
+	})
+
+	t.Run("NamespaceIsolation", func(t *testing.T) {
+		c := newCache(t)
+		orgID := valuer.GenerateUUID()
+		a, b := c.WithNamespace("a"), c.WithNamespace("b")
+
+		require.NoError(t, a.Set(ctx, orgID, "key", &Entity{Value: "a"}, time.Minute))
+		require.NoError(t, b.Set(ctx, orgID, "key", &Entity{Value: "b"}, time.Minute))
+		require.NoError(t, b.DeleteByPrefix(ctx, orgID, ""))
+
+		dest := new(Entity)
+		require.NoError(t, a.Get(ctx, orgID, "key", dest, false))
+		assert.Equal(t, "a", dest.Value)
+
+		err := b.Get(ctx, orgID, "key", new(Entity), false)
+		assert.True(t, errors.Ast(err, errors.TypeNotFound), "expected a not found error, got %v", err)
+	})
+
+	t.Run("NamespaceCollision", func(t *testing.T) {
+		c := newCache(t)
+		orgID := valuer.GenerateUUID()
+
+		// The keys are the same once joined with the separator, the entries are not.
+		require.NoError(t, c.WithNamespace("a").Set(ctx, orgID, "b::key", &Entity{Value: "key"}, time.Minute))
+		require.NoError(t, c.WithNamespace("a::b").Set(ctx, orgID, "key", &Entity{Value: "namespace"}, time.Minute))
+		require.NoError(t, c.WithNamespace("a").WithNamespace("b").Set(ctx, orgID, "key", &Entity{Value: "nested"}, time.Minute))
+
+		dest := new(Entity)
+		require.NoError(t, c.WithNamespace("a").Get(ctx, orgID, "b::key", dest, false))
+		assert.Equal(t, "key", dest.Value)
+		require.NoError(t, c.WithNamespace("a::b").Get(ctx, orgID, "key", dest, false))
+		assert.Equal(t, "namespace", dest.Value)
+		require.NoError(t, c.WithNamespace("a").WithNamespace("b").Get(ctx, orgID, "key", dest, false))
+		assert.Equal(t, "nested", dest.Value)
+	})
+
+	t.Run("NestedNamespaceIsolation", func(t *testing.T) {
+		c := newCache(t)
+		orgID := valuer.GenerateUUID()
+		parent := c.WithNamespace("parent")
+		nested := parent.WithNamespace("nested")
+
+		require.NoError(t, parent.Set(ctx, orgID, "key", &Entity{Value: "parent"}, time.Minute))
+		require.NoError(t, nested.Set(ctx, orgID, "key", &Entity{Value: "nested"}, time.Minute))
+		require.NoError(t, parent.DeleteByPrefix(ctx, orgID, ""))
+
+		err := parent.Get(ctx, orgID, "key", new(Entity), false)
+		assert.True(t, errors.Ast(err, errors.TypeNotFound), "expected a not found error, got %v", err)
+
+		dest := new(Entity)
+		require.NoError(t, nested.Get(ctx, orgID, "key", dest, false))
+		assert.Equal(t, "nested", dest.Value)
+	})
+
+	t.Run("Expiry", func(t *testing.T) {
+		c := newCache(t)
+		orgID := valuer.GenerateUUID()
+
+		require.NoError(t, c.Set(ctx, orgID, "key", &Entity{Value: "value"}, 10*time.Millisecond))
+		time.Sleep(50 * time.Millisecond)
+
+		err := c.Get(ctx, orgID, "key", new(Entity), false)
+		assert.True(t, errors.Ast(err, errors.TypeNotFound), "expected a not found error, got %v", err)
+	})
+}
@@ -0,0 +1,149 @@
+package cachetest
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/cache"
+	"github.com/SigNoz/signoz/pkg/types/cachetypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+	"github.com/stretchr/testify/assert"
+)
+
+var _ cache.Cache = (*Recorder)(nil)
+
+// Call is a call made to a Recorder.
+type Call struct {
+	// Method is the name of the method of cache.Cache which was called.
+	Method string
+	OrgID  valuer.UUID
+	// Keys are the cache keys of the call, or the prefix for DeleteByPrefix. The keys of the calls made through a
+	// namespace are prefixed with it.
+	Keys []string
+}
+
+// Recorder is a cache which records the calls made to it before forwarding them to the cache it wraps, so that tests
+// can seed the cache and assert the calls of the code under test.
+type Recorder struct {
+	cache cache.Cache
+	mtx   sync.Mutex
+	calls []Call
+}
+
+// NewRecorder wraps the cache, usually the one returned by New.
+func NewRecorder(cache cache.Cache) *Recorder {
+	return &Recorder{cache: cache}
+}
+
+// Seed sets the entities in the wrapped cache without recording the calls.
+func (recorder *Recorder) Seed(ctx context.Context, orgID valuer.UUID, entities map[string]cachetypes.Cacheable, ttl time.Duration) error {
+	for cacheKey, data := range entities {
+		if err := recorder.cache.Set(ctx, orgID, cacheKey, data, ttl); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Calls returns the calls recorded so far, in order.
+func (recorder *Recorder) Calls() []Call {
+	recorder.mtx.Lock()
+	defer recorder.mtx.Unlock()
+
+	return slices.Clone(recorder.calls)
+}
+
+// CallsTo returns the calls recorded so far to the method, in order.
+func (recorder *Recorder) CallsTo(method string) []Call {
+	var calls []Call
+	for _, call := range recorder.Calls() {
+		if call.Method == method {
+			calls = append(calls, call)
+		}
+	}
+
+	return calls
+}
+
+// Reset forgets the calls recorded so far.
+func (recorder *Recorder) Reset() {
+	recorder.mtx.Lock()
+	defer recorder.mtx.Unlock()
+
+	recorder.calls = nil
+}
+
+// AssertCalled asserts that the method was called for the key of the org.
+func (recorder *Recorder) AssertCalled(t assert.TestingT, method string, orgID valuer.UUID, cacheKey string) bool {
+	for _, call := range recorder.CallsTo(method) {
+		if call.OrgID == orgID && slices.Contains(call.Keys, cacheKey) {
+			return true
+		}
+	}
+
+	return assert.Fail(t, "cache was not called", "expected a call to %s for the key %q of the org %s, got %v", method, cacheKey, orgID.StringValue(), recorder.Calls())
+}
+
+// AssertNotCalled asserts that the method was never called.
+func (recorder *Recorder) AssertNotCalled(t assert.TestingT, method string) bool {
+	if calls := recorder.CallsTo(method); len(calls) > 0 {
+		return assert.Fail(t, "cache was called", "expected no call to %s, got %v", method, calls)
+	}
+
+	return true
+}
+
+func (recorder *Recorder) Set(ctx context.Context, orgID valuer.UUID, cacheKey string, data cachetypes.Cacheable, ttl time.Duration) error {
+	recorder.record("Set", orgID, cacheKey)
+	return recorder.cache.Set(ctx, orgID, cacheKey, data, ttl)
+}
+
+func (recorder *Recorder) Get(ctx context.Context, orgID valuer.UUID, cacheKey string, dest cachetypes.Cacheable, allowExpired bool) error {
+	recorder.record("Get", orgID, cacheKey)
+	return recorder.cache.Get(ctx, orgID, cacheKey, dest, allowExpired)
+}
+
+func (recorder *Recorder) Delete(ctx context.Context, orgID valuer.UUID, cacheKey string) {
+	recorder.record("Delete", orgID, cacheKey)
+	recorder.cache.Delete(ctx, orgID, cacheKey)
+}
+
+func (recorder *Recorder) DeleteMany(ctx context.Context, orgID valuer.UUID, cacheKeys []string) {
+	recorder.record("DeleteMany", orgID, cacheKeys...)
+	recorder.cache.DeleteMany(ctx, orgID, cacheKeys)
+}
+
+func (recorder *Recorder) GetMany(ctx context.Context, orgID valuer.UUID, cacheKeys []string) (map[string][]byte, error) {
+	recorder.record("GetMany", orgID, cacheKeys...)
+	return recorder.cache.GetMany(ctx, orgID, cacheKeys)
+}
+
+func (recorder *Recorder) SetMany(ctx context.Context, orgID valuer.UUID, items map[string]cachetypes.Item) error {
+	cacheKeys := make([]string, 0, len(items))
+	for cacheKey := range items {
+		cacheKeys = append(cacheKeys, cacheKey)
+	}
+	slices.Sort(cacheKeys)
+
+	recorder.record("SetMany", orgID, cacheKeys...)
+	return recorder.cache.SetMany(ctx, orgID, items)
+}
+
+func (recorder *Recorder) DeleteByPrefix(ctx context.Context, orgID valuer.UUID, prefix string) error {
+	recorder.record("DeleteByPrefix", orgID, prefix)
+	return recorder.cache.DeleteByPrefix(ctx, orgID, prefix)
+}
+
+func (recorder *Recorder) WithNamespace(namespace string) cache.Cache {
+	return cache.NewNamespaced(recorder, namespace)
+}
+
+func (recorder *Recorder) record(method string, orgID valuer.UUID, cacheKeys ...string) {
+	recorder.mtx.Lock()
+	defer recorder.mtx.Unlock()
+
+	recorder.calls = append(recorder.calls, Call{Method: method, OrgID: orgID, Keys: slices.Clone(cacheKeys)})
+}
@@ -0,0 +1,52 @@
+package cachetest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/cache"
+	"github.com/SigNoz/signoz/pkg/types/cachetypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newRecorder(t *testing.T) *Recorder {
+	c, err := New(cache.Config{Provider: "memory", Memory: cache.Memory{TTL: time.Minute, CleanupInterval: time.Minute}})
+	require.NoError(t, err)
+
+	return NewRecorder(c)
+}
+
+func TestRecorderConformance(t *testing.T) {
+	RunConformance(t, func(t *testing.T) cache.Cache {
+		return newRecorder(t)
+	})
+}
+
+func TestRecorder(t *testing.T) {
+	ctx := context.Background()
+	orgID := valuer.GenerateUUID()
+	recorder := newRecorder(t)
+
+	require.NoError(t, recorder.Seed(ctx, orgID, map[string]cachetypes.Cacheable{"seeded": &Entity{Value: "seeded"}}, time.Minute))
+	assert.Empty(t, recorder.Calls())
+
+	dest := new(Entity)
+	require.NoError(t, recorder.Get(ctx, orgID, "seeded", dest, false))
+	assert.Equal(t, "seeded", dest.Value)
+
+	require.NoError(t, recorder.WithNamespace("namespace").Set(ctx, orgID, "key", &Entity{Value: "value"}, time.Minute))
+
+	recorder.AssertCalled(t, "Get", orgID, "seeded")
+	recorder.AssertCalled(t, "Set", orgID, "namespace::key")
+	recorder.AssertNotCalled(t, "Delete")
+	assert.Len(t, recorder.Calls(), 2)
+
+	mockT := new(testing.T)
+	assert.False(t, recorder.AssertCalled(mockT, "Get", valuer.GenerateUUID(), "seeded"))
+
+	recorder.Reset()
+	assert.Empty(t, recorder.Calls())
+}
@@ -0,0 +1,173 @@
+package cache
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io"
+	"sync"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/factory"
+	"github.com/klauspost/compress/zstd"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+const (
+	// CompressionAlgorithmNone stores the values as they are.
+	CompressionAlgorithmNone string = "none"
+	// CompressionAlgorithmGzip compresses the values with gzip.
+	CompressionAlgorithmGzip string = "gzip"
+	// CompressionAlgorithmZstd compresses the values with zstd.
+	CompressionAlgorithmZstd string = "zstd"
+)
+
+const (
+	compressionFlagGzip byte = 1
+	compressionFlagZstd byte = 2
+)
+
+var (
+	// compressionMagic starts the header of the compressed values, followed by the flag of their algorithm. The
+	// binary representations of the cacheable entities are json, which never starts with a null byte, hence the
+	// values stored before the compression was enabled are told apart from the compressed ones.
+	compressionMagic = []byte{0x00, 's', 'z'}
+)
+
+var (
+	zstdEncoder = sync.OnceValues(func() (*zstd.Encoder, error) { return zstd.NewWriter(nil) })
+	zstdDecoder = sync.OnceValues(func() (*zstd.Decoder, error) { return zstd.NewReader(nil) })
+)
+
+// Compressor compresses the binary representations of the values above the threshold of the config before they are
+// stored. A nil compressor does not compress the values.
+type Compressor struct {
+	algorithm string
+	flag      byte
+	threshold int
+	input     metric.Int64Counter
+	output    metric.Int64Counter
+	ratio     metric.Float64Histogram
+}
+
+func NewCompressor(settings factory.ScopedProviderSettings, config Compression) (*Compressor, error) {
+	if config.Algorithm == CompressionAlgorithmNone || config.Algorithm == "" {
+		return nil, nil
+	}
+
+	compressor := &Compressor{algorithm: config.Algorithm, threshold: config.Threshold}
+	switch config.Algorithm {
+	case CompressionAlgorithmGzip:
+		compressor.flag = compressionFlagGzip
+	case CompressionAlgorithmZstd:
+		compressor.flag = compressionFlagZstd
+	default:
+		return nil, errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "unknown compression algorithm %q", config.Algorithm)
+	}
+
+	var err error
+	compressor.input, err = settings.Meter().Int64Counter("signoz.cache.compression.input", metric.WithDescription("Number of bytes of the cache values which were compressed, before their compression."), metric.WithUnit("By"))
+	if err != nil {
+		return nil, err
+	}
+
+	compressor.output, err = settings.Meter().Int64Counter("signoz.cache.compression.output", metric.WithDescription("Number of bytes of the cache values which were compressed, after their compression."), metric.WithUnit("By"))
+	if err != nil {
+		return nil, err
+	}
+
+	compressor.ratio, err = settings.Meter().Float64Histogram("signoz.cache.compression.ratio", metric.WithDescription("Ratio of the size of the cache values before their compression to their size after it."))
+	if err != nil {
+		return nil, err
+	}
+
+	return compressor, nil
+}
+
+// Enabled returns whether the compressor compresses the values.
+func (compressor *Compressor) Enabled() bool {
+	return compressor != nil
+}
+
+// Compress returns the value to store for the binary representation of a value. The representations smaller than
+// the threshold, or which would not be smaller once compressed, are returned as they are.
+func (compressor *Compressor) Compress(ctx context.Context, data []byte) ([]byte, error) {
+	if compressor == nil || len(data) < compressor.threshold {
+		return data, nil
+	}
+
+	compressed := bytes.NewBuffer(make([]byte, 0, len(data)/2))
+	compressed.Write(compressionMagic)
+	compressed.WriteByte(compressor.flag)
+
+	switch compressor.flag {
+	case compressionFlagGzip:
+		writer := gzip.NewWriter(compressed)
+		if _, err := writer.Write(data); err != nil {
+			return nil, errors.WrapInternalf(err, errors.CodeInternal, "failed to compress cache value")
+		}
+
+		if err := writer.Close(); err != nil {
+			return nil, errors.WrapInternalf(err, errors.CodeInternal, "failed to compress cache value")
+		}
+	case compressionFlagZstd:
+		encoder, err := zstdEncoder()
+		if err != nil {
+			return nil, errors.WrapInternalf(err, errors.CodeInternal, "failed to create zstd encoder")
+		}
+
+		compressed.Write(encoder.EncodeAll(data, nil))
+	}
+
+	if compressed.Len() >= len(data) {
+		return data, nil
+	}
+
+	attributes := metric.WithAttributes(attribute.String("algorithm", compressor.algorithm))
+	compressor.input.Add(ctx, int64(len(data)), attributes)
+	compressor.output.Add(ctx, int64(compressed.Len()), attributes)
+	compressor.ratio.Record(ctx, float64(len(data))/float64(compressed.Len()), attributes)
+
+	return compressed.Bytes(), nil
+}
+
+// Decompress returns the binary representation of a stored value. The values without the header of the compressed
+// values are returned as they are, whatever the algorithm of the config, so that the values stored before the
+// compression was enabled or changed stay readable.
+func Decompress(data []byte) ([]byte, error) {
+	if len(data) <= len(compressionMagic) || !bytes.HasPrefix(data, compressionMagic) {
+		return data, nil
+	}
+
+	payload := data[len(compressionMagic)+1:]
+	switch data[len(compressionMagic)] {
+	case compressionFlagGzip:
+		reader, err := gzip.NewReader(bytes.NewReader(payload))
+		if err != nil {
+			return nil, errors.WrapInternalf(err, errors.CodeInternal, "failed to decompress cache value")
+		}
+		defer reader.Close()
+
+		decompressed, err := io.ReadAll(reader)
+		if err != nil {
+			return nil, errors.WrapInternalf(err, errors.CodeInternal, "failed to decompress cache value")
+		}
+
+		return decompressed, nil
+	case compressionFlagZstd:
+		decoder, err := zstdDecoder()
+		if err != nil {
+			return nil, errors.WrapInternalf(err, errors.CodeInternal, "failed to create zstd decoder")
+		}
+
+		decompressed, err := decoder.DecodeAll(payload, nil)
+		if err != nil {
+			return nil, errors.WrapInternalf(err, errors.CodeInternal, "failed to decompress cache value")
+		}
+
+		return decompressed, nil
+	default:
+		return nil, errors.Newf(errors.TypeInternal, errors.CodeInternal, "unknown compression flag %d of cache value", data[len(compressionMagic)])
+	}
+}
@@ -0,0 +1,72 @@
+package cache
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"testing"
+
+	"github.com/SigNoz/signoz/pkg/factory"
+	"github.com/SigNoz/signoz/pkg/factory/factorytest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompressor(t *testing.T) {
+	ctx := context.Background()
+	settings := factory.NewScopedProviderSettings(factorytest.NewSettings(), "github.com/SigNoz/signoz/pkg/cache")
+	compressible := bytes.Repeat([]byte(`{"key":"value"}`), 100)
+
+	for _, algorithm := range []string{CompressionAlgorithmGzip, CompressionAlgorithmZstd} {
+		t.Run(algorithm, func(t *testing.T) {
+			compressor, err := NewCompressor(settings, Compression{Algorithm: algorithm, Threshold: 64})
+			require.NoError(t, err)
+			assert.True(t, compressor.Enabled())
+
+			// The values above the threshold are compressed.
+			compressed, err := compressor.Compress(ctx, compressible)
+			require.NoError(t, err)
+			assert.Less(t, len(compressed), len(compressible))
+
+			decompressed, err := Decompress(compressed)
+			require.NoError(t, err)
+			assert.Equal(t, compressible, decompressed)
+
+			// The values below the threshold are stored as they are.
+			small := []byte(`{"key":"value"}`)
+			compressed, err = compressor.Compress(ctx, small)
+			require.NoError(t, err)
+			assert.Equal(t, small, compressed)
+
+			// The values which would not be smaller once compressed are stored as they are.
+			random := make([]byte, 256)
+			_, err = rand.Read(random)
+			require.NoError(t, err)
+			random[0] = '{'
+			compressed, err = compressor.Compress(ctx, random)
+			require.NoError(t, err)
+			assert.Equal(t, random, compressed)
+		})
+	}
+}
+
+func TestCompressorNone(t *testing.T) {
+	compressor, err := NewCompressor(factory.NewScopedProviderSettings(factorytest.NewSettings(), "github.com/SigNoz/signoz/pkg/cache"), Compression{Algorithm: CompressionAlgorithmNone})
+	require.NoError(t, err)
+	assert.False(t, compressor.Enabled())
+
+	data := bytes.Repeat([]byte("a"), 2048)
+	compressed, err := compressor.Compress(context.Background(), data)
+	require.NoError(t, err)
+	assert.Equal(t, data, compressed)
+}
+
+func TestDecompress(t *testing.T) {
+	// The values stored uncompressed are returned as they are.
+	data, err := Decompress([]byte(`{"key":"value"}`))
+	require.NoError(t, err)
+	assert.Equal(t, []byte(`{"key":"value"}`), data)
+
+	_, err = Decompress(append(append([]byte{}, compressionMagic...), 9, 'a'))
+	assert.Error(t, err)
+}
@@ -3,12 +3,30 @@ package cache
 import (
 	"time"
 
+	"github.com/SigNoz/signoz/pkg/errors"
 	"github.com/SigNoz/signoz/pkg/factory"
 )
 
+const (
+	// EvictionPolicyLRU evicts the least recently used entry.
+	EvictionPolicyLRU string = "lru"
+	// EvictionPolicyLFU evicts the least frequently used entry.
+	EvictionPolicyLFU string = "lfu"
+	// EvictionPolicyTTL evicts the entry expiring first.
+	EvictionPolicyTTL string = "ttl"
+)
+
 type Memory struct {
 	TTL             time.Duration `mapstructure:"ttl"`
 	CleanupInterval time.Duration `mapstructure:"cleanup_interval"`
+	// EvictionPolicy is the policy picking the entry evicted when the cache is full, one of lru, lfu or ttl. An
+	// empty policy is lru.
+	EvictionPolicy string `mapstructure:"eviction_policy"`
+	// MaxEntries is the maximum number of entries. 0 means unlimited.
+	MaxEntries int `mapstructure:"max_entries"`
+	// MaxBytes is the maximum size in bytes of the entries, as measured by their binary representation. 0 means
+	// unlimited.
+	MaxBytes int64 `mapstructure:"max_bytes"`
 }
 
 type Redis struct {
@@ -18,10 +36,30 @@ type Redis struct {
 	DB       int    `mapstructure:"db"`
 }
 
+// Compression is the compression of the values of the cache, applied by every provider.
+type Compression struct {
+	// Algorithm is the algorithm compressing the values, one of none, gzip or zstd.
+	Algorithm string `mapstructure:"algorithm"`
+	// Threshold is the minimum size in bytes of the binary representation of a value for it to be compressed.
+	Threshold int `mapstructure:"threshold"`
+}
+
+// Negative is the caching of the entities known to be empty with SetEmpty, applied to the query results by the
+// bucket cache of the querier.
+type Negative struct {
+	// Enabled is whether the entities computed as empty are cached. They are computed again on every lookup otherwise.
+	Enabled bool `mapstructure:"enabled"`
+	// TTL is the time to live of the entities cached as empty, usually shorter than the one of the other entities as
+	// their data may not have been ingested yet.
+	TTL time.Duration `mapstructure:"ttl"`
+}
+
 type Config struct {
-	Provider string `mapstructure:"provider"`
-	Memory   Memory `mapstructure:"memory"`
-	Redis    Redis  `mapstructure:"redis"`
+	Provider    string      `mapstructure:"provider"`
+	Memory      Memory      `mapstructure:"memory"`
+	Redis       Redis       `mapstructure:"redis"`
+	Compression Compression `mapstructure:"compression"`
+	Negative    Negative    `mapstructure:"negative"`
 }
 
 func NewConfigFactory() factory.ConfigFactory {
@@ -34,6 +72,9 @@ func newConfig() factory.Config {
 		Memory: Memory{
 			TTL:             time.Hour * 168,
 			CleanupInterval: 10 * time.Minute,
+			EvictionPolicy:  EvictionPolicyLRU,
+			MaxEntries:      0,
+			MaxBytes:        0,
 		},
 		Redis: Redis{
 			Host:     "localhost",
@@ -41,10 +82,46 @@ func newConfig() factory.Config {
 			Password: "",
 			DB:       0,
 		},
+		Compression: Compression{
+			Algorithm: CompressionAlgorithmNone,
+			Threshold: 1024,
+		},
+		Negative: Negative{
+			Enabled: false,
+			TTL:     time.Minute,
+		},
 	}
 
 }
 
 func (c Config) Validate() error {
+	switch c.Memory.EvictionPolicy {
+	case EvictionPolicyLRU, EvictionPolicyLFU, EvictionPolicyTTL:
+	default:
+		return errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "cache::memory::eviction_policy must be one of %s, %s or %s, got %q", EvictionPolicyLRU, EvictionPolicyLFU, EvictionPolicyTTL, c.Memory.EvictionPolicy)
+	}
+
+	if c.Memory.MaxEntries < 0 {
+		return errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "cache::memory::max_entries must not be negative, got %d", c.Memory.MaxEntries)
+	}
+
+	if c.Memory.MaxBytes < 0 {
+		return errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "cache::memory::max_bytes must not be negative, got %d", c.Memory.MaxBytes)
+	}
+
+	switch c.Compression.Algorithm {
+	case "", CompressionAlgorithmNone, CompressionAlgorithmGzip, CompressionAlgorithmZstd:
+	default:
+		return errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "cache::compression::algorithm must be one of %s, %s or %s, got %q", CompressionAlgorithmNone, CompressionAlgorithmGzip, CompressionAlgorithmZstd, c.Compression.Algorithm)
+	}
+
+	if c.Compression.Threshold < 0 {
+		return errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "cache::compression::threshold must not be negative, got %d", c.Compression.Threshold)
+	}
+
+	if c.Negative.Enabled && c.Negative.TTL <= 0 {
+		return errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "cache::negative::ttl must be greater than 0, got %s", c.Negative.TTL)
+	}
+
 	return nil
 }
@@ -0,0 +1,75 @@
+package cache
+
+import (
+	"context"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/factory"
+	"github.com/SigNoz/signoz/pkg/types/cachetypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+)
+
+type instrumented struct {
+	cache Cache
+	calls *factory.Calls
+}
+
+// Instrument returns a view of the cache recording the calls of its methods. It is the factory.InstrumentFunc of
+// the cache providers.
+func Instrument(cache Cache, calls *factory.Calls) Cache {
+	return &instrumented{cache: cache, calls: calls}
+}
+
+func (instrumented *instrumented) Set(ctx context.Context, orgID valuer.UUID, cacheKey string, data cachetypes.Cacheable, ttl time.Duration) (err error) {
+	defer instrumented.calls.Record(ctx, "Set", time.Now(), &err)
+	return instrumented.cache.Set(ctx, orgID, cacheKey, data, ttl)
+}
+
+func (instrumented *instrumented) Get(ctx context.Context, orgID valuer.UUID, cacheKey string, dest cachetypes.Cacheable, allowExpired bool) error {
+	start := time.Now()
+	err := instrumented.cache.Get(ctx, orgID, cacheKey, dest, allowExpired)
+
+	// A miss is not a failure of the cache.
+	failure := err
+	if err != nil && errors.Ast(err, errors.TypeNotFound) {
+		failure = nil
+	}
+	instrumented.calls.Record(ctx, "Get", start, &failure)
+
+	return err
+}
+
+func (instrumented *instrumented) Delete(ctx context.Context, orgID valuer.UUID, cacheKey string) {
+	defer instrumented.calls.Record(ctx, "Delete", time.Now(), nil)
+	instrumented.cache.Delete(ctx, orgID, cacheKey)
+}
+
+func (instrumented *instrumented) DeleteMany(ctx context.Context, orgID valuer.UUID, cacheKeys []string) {
+	defer instrumented.calls.Record(ctx, "DeleteMany", time.Now(), nil)
+	instrumented.cache.DeleteMany(ctx, orgID, cacheKeys)
+}
+
+func (instrumented *instrumented) GetMany(ctx context.Context, orgID valuer.UUID, cacheKeys []string) (values map[string][]byte, err error) {
+	defer instrumented.calls.Record(ctx, "GetMany", time.Now(), &err)
+	return instrumented.cache.GetMany(ctx, orgID, cacheKeys)
+}
+
+func (instrumented *instrumented) SetMany(ctx context.Context, orgID valuer.UUID, items map[string]cachetypes.Item) (err error) {
+	defer instrumented.calls.Record(ctx, "SetMany", time.Now(), &err)
+	return instrumented.cache.SetMany(ctx, orgID, items)
+}
+
+func (instrumented *instrumented) DeleteByPrefix(ctx context.Context, orgID valuer.UUID, prefix string) (err error) {
+	defer instrumented.calls.Record(ctx, "DeleteByPrefix", time.Now(), &err)
+	return instrumented.cache.DeleteByPrefix(ctx, orgID, prefix)
+}
+
+func (instrumented *instrumented) WithNamespace(namespace string) Cache {
+	return NewNamespaced(instrumented, namespace)
+}
+
+// Healthy reports the health of the instrumented cache, which is unknown if it does not report its health.
+func (instrumented *instrumented) Healthy(ctx context.Context) error {
+	return factory.HealthOf(ctx, instrumented.cache)
+}
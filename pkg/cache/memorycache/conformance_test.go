@@ -0,0 +1,35 @@
+package memorycache_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/cache"
+	"github.com/SigNoz/signoz/pkg/cache/cachetest"
+	"github.com/SigNoz/signoz/pkg/cache/memorycache"
+	"github.com/SigNoz/signoz/pkg/factory/factorytest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConformance(t *testing.T) {
+	testCases := []struct {
+		name   string
+		config cache.Config
+	}{
+		{name: "Default", config: cache.Config{Provider: "memory", Memory: cache.Memory{TTL: time.Minute, CleanupInterval: time.Minute}}},
+		{name: "Bounded", config: cache.Config{Provider: "memory", Memory: cache.Memory{TTL: time.Minute, CleanupInterval: time.Minute, MaxEntries: 100, MaxBytes: 1 << 20}}},
+		{name: "Compressed", config: cache.Config{Provider: "memory", Memory: cache.Memory{TTL: time.Minute, CleanupInterval: time.Minute}, Compression: cache.Compression{Algorithm: cache.CompressionAlgorithmGzip, Threshold: 1}}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cachetest.RunConformance(t, func(t *testing.T) cache.Cache {
+				c, err := memorycache.New(context.Background(), factorytest.NewSettings(), tc.config)
+				require.NoError(t, err)
+
+				return c
+			})
+		})
+	}
+}
@@ -0,0 +1,169 @@
+package memorycache
+
+import (
+	"container/heap"
+	"container/list"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/cache"
+)
+
+// policy picks the entry evicted when the cache is full.
+type policy interface {
+	// add starts tracking the entry.
+	add(entry *entry)
+	// touch records a hit of the entry.
+	touch(entry *entry)
+	// remove stops tracking the entry.
+	remove(entry *entry)
+	// victim returns the entry to evict, or nil when no entry is tracked.
+	victim() *entry
+}
+
+// newPolicy returns the policy of the name, lru for an empty name.
+func newPolicy(name string) policy {
+	switch name {
+	case cache.EvictionPolicyLFU:
+		return &lfuPolicy{}
+	case cache.EvictionPolicyTTL:
+		return &ttlPolicy{entries: entryHeap{less: expiresBefore}}
+	default:
+		return &lruPolicy{entries: list.New()}
+	}
+}
+
+// lruPolicy evicts the least recently used entry.
+type lruPolicy struct {
+	entries *list.List
+}
+
+func (policy *lruPolicy) add(entry *entry) {
+	entry.element = policy.entries.PushFront(entry)
+}
+
+func (policy *lruPolicy) touch(entry *entry) {
+	policy.entries.MoveToFront(entry.element)
+}
+
+func (policy *lruPolicy) remove(entry *entry) {
+	policy.entries.Remove(entry.element)
+}
+
+func (policy *lruPolicy) victim() *entry {
+	if back := policy.entries.Back(); back != nil {
+		return back.Value.(*entry)
+	}
+
+	return nil
+}
+
+// lfuPolicy evicts the least frequently used entry, the least recently used one among the entries with as many hits.
+type lfuPolicy struct {
+	entries entryHeap
+	seq     uint64
+}
+
+func (policy *lfuPolicy) add(entry *entry) {
+	policy.seq++
+	entry.seq = policy.seq
+	heap.Push(&policy.entries, entry)
+}
+
+func (policy *lfuPolicy) touch(entry *entry) {
+	policy.seq++
+	entry.hits++
+	entry.seq = policy.seq
+	heap.Fix(&policy.entries, entry.index)
+}
+
+func (policy *lfuPolicy) remove(entry *entry) {
+	heap.Remove(&policy.entries, entry.index)
+}
+
+func (policy *lfuPolicy) victim() *entry {
+	if len(policy.entries.entries) == 0 {
+		return nil
+	}
+
+	return policy.entries.entries[0]
+}
+
+// ttlPolicy only evicts by expiry, the entry expiring first is evicted when the cache is full. The entries which
+// never expire are evicted last.
+type ttlPolicy struct {
+	entries entryHeap
+}
+
+func (policy *ttlPolicy) add(entry *entry) {
+	heap.Push(&policy.entries, entry)
+}
+
+func (policy *ttlPolicy) touch(*entry) {}
+
+func (policy *ttlPolicy) remove(entry *entry) {
+	heap.Remove(&policy.entries, entry.index)
+}
+
+func (policy *ttlPolicy) victim() *entry {
+	if len(policy.entries.entries) == 0 {
+		return nil
+	}
+
+	return policy.entries.entries[0]
+}
+
+// entryHeap is a heap of entries ordered by less, by hits and recency when less is nil.
+type entryHeap struct {
+	entries []*entry
+	less    func(a, b *entry) bool
+}
+
+func (h entryHeap) Len() int { return len(h.entries) }
+
+func (h entryHeap) Less(i, j int) bool {
+	if h.less != nil {
+		return h.less(h.entries[i], h.entries[j])
+	}
+
+	if h.entries[i].hits != h.entries[j].hits {
+		return h.entries[i].hits < h.entries[j].hits
+	}
+
+	return h.entries[i].seq < h.entries[j].seq
+}
+
+func (h entryHeap) Swap(i, j int) {
+	h.entries[i], h.entries[j] = h.entries[j], h.entries[i]
+	h.entries[i].index = i
+	h.entries[j].index = j
+}
+
+func (h *entryHeap) Push(x any) {
+	entry := x.(*entry)
+	entry.index = len(h.entries)
+	h.entries = append(h.entries, entry)
+}
+
+func (h *entryHeap) Pop() any {
+	last := h.entries[len(h.entries)-1]
+	h.entries[len(h.entries)-1] = nil
+	h.entries = h.entries[:len(h.entries)-1]
+	return last
+}
+
+func expiresBefore(a, b *entry) bool {
+	if a.expiresAt.IsZero() {
+		return false
+	}
+
+	if b.expiresAt.IsZero() {
+		return true
+	}
+
+	return a.expiresAt.Before(b.expiresAt)
+}
+
+// expired returns whether the entry is expired at now.
+func (entry *entry) expired(now time.Time) bool {
+	return !entry.expiresAt.IsZero() && now.After(entry.expiresAt)
+}
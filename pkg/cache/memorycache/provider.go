@@ -1,9 +1,11 @@
 package memorycache
 
 import (
+	"container/list"
 	"context"
 	"reflect"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/SigNoz/signoz/pkg/cache"
@@ -11,13 +13,47 @@ import (
 	"github.com/SigNoz/signoz/pkg/factory"
 	"github.com/SigNoz/signoz/pkg/types/cachetypes"
 	"github.com/SigNoz/signoz/pkg/valuer"
-	go_cache "github.com/patrickmn/go-cache"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+const (
+	evictionReasonExpired  string = "expired"
+	evictionReasonCapacity string = "capacity"
+	// evictionReasonTooLarge is the reason of the entries larger than max_bytes, which are never stored.
+	evictionReasonTooLarge string = "too_large"
 )
 
 type provider struct {
-	cc       *go_cache.Cache
-	config   cache.Config
-	settings factory.ScopedProviderSettings
+	mtx     sync.Mutex
+	entries map[string]*entry
+	policy  policy
+	// bytes is the size of the entries, only measured when max_bytes is set.
+	bytes       int64
+	lastCleanup time.Time
+	now         func() time.Time
+	config      cache.Config
+	settings    factory.ScopedProviderSettings
+	hits        metric.Int64Counter
+	misses      metric.Int64Counter
+	evictions   metric.Int64Counter
+	// compressor compresses the values, which are then stored as their compressed binary representation.
+	compressor *cache.Compressor
+}
+
+type entry struct {
+	key  string
+	data cachetypes.Cacheable
+	// value is the binary representation of the data, possibly compressed, stored in place of the data when the
+	// values are compressed.
+	value     []byte
+	size      int64
+	expiresAt time.Time
+	// element, index, hits and seq are maintained by the policy.
+	element *list.Element
+	index   int
+	hits    uint64
+	seq     uint64
 }
 
 func NewFactory() factory.ProviderFactory[cache.Cache, cache.Config] {
@@ -26,7 +62,39 @@ func NewFactory() factory.ProviderFactory[cache.Cache, cache.Config] {
 
 func New(ctx context.Context, settings factory.ProviderSettings, config cache.Config) (cache.Cache, error) {
 	scopedProviderSettings := factory.NewScopedProviderSettings(settings, "github.com/SigNoz/signoz/pkg/cache/memorycache")
-	return &provider{cc: go_cache.New(config.Memory.TTL, config.Memory.CleanupInterval), settings: scopedProviderSettings, config: config}, nil
+
+	hits, err := scopedProviderSettings.Meter().Int64Counter("signoz.cache.memory.hits", metric.WithDescription("Number of lookups of the in-memory cache which found the key."))
+	if err != nil {
+		return nil, err
+	}
+
+	misses, err := scopedProviderSettings.Meter().Int64Counter("signoz.cache.memory.misses", metric.WithDescription("Number of lookups of the in-memory cache which did not find the key."))
+	if err != nil {
+		return nil, err
+	}
+
+	evictions, err := scopedProviderSettings.Meter().Int64Counter("signoz.cache.memory.evictions", metric.WithDescription("Number of entries evicted from the in-memory cache, by reason."))
+	if err != nil {
+		return nil, err
+	}
+
+	compressor, err := cache.NewCompressor(scopedProviderSettings, config.Compression)
+	if err != nil {
+		return nil, err
+	}
+
+	return &provider{
+		entries:     make(map[string]*entry),
+		policy:      newPolicy(config.Memory.EvictionPolicy),
+		lastCleanup: time.Now(),
+		now:         time.Now,
+		config:      config,
+		settings:    scopedProviderSettings,
+		hits:        hits,
+		misses:      misses,
+		evictions:   evictions,
+		compressor:  compressor,
+	}, nil
 }
 
 func (provider *provider) Set(ctx context.Context, orgID valuer.UUID, cacheKey string, data cachetypes.Cacheable, ttl time.Duration) error {
@@ -39,11 +107,60 @@ func (provider *provider) Set(ctx context.Context, orgID valuer.UUID, cacheKey s
 	if ttl == 0 {
 		provider.settings.Logger().WarnContext(ctx, "zero value for TTL found. defaulting to the base TTL", "cache_key", cacheKey, "default_ttl", provider.config.Memory.TTL)
 	}
-	provider.cc.Set(strings.Join([]string{orgID.StringValue(), cacheKey}, "::"), data, ttl)
+
+	key := strings.Join([]string{orgID.StringValue(), cacheKey}, "::")
+
+	var size int64
+	var value []byte
+	if provider.config.Memory.MaxBytes > 0 || provider.compressor.Enabled() {
+		bytes, err := data.MarshalBinary()
+		if err != nil {
+			return errors.WrapInternalf(err, errors.CodeInternal, "failed to marshal value for key %q", cacheKey)
+		}
+
+		if provider.compressor.Enabled() {
+			value, err = provider.compressor.Compress(ctx, bytes)
+			if err != nil {
+				return err
+			}
+
+			bytes = value
+		}
+
+		size = int64(len(key) + len(bytes))
+	}
+
+	if value != nil {
+		data = nil
+	}
+
+	provider.mtx.Lock()
+	defer provider.mtx.Unlock()
+
+	now := provider.now()
+	provider.cleanup(ctx, now)
+
+	if existing, ok := provider.entries[key]; ok {
+		provider.remove(existing)
+	}
+
+	if size > provider.config.Memory.MaxBytes && provider.config.Memory.MaxBytes > 0 {
+		provider.settings.Logger().DebugContext(ctx, "value larger than the cache, not caching it", "cache_key", cacheKey, "size", size, "max_bytes", provider.config.Memory.MaxBytes)
+		provider.evictions.Add(ctx, 1, metric.WithAttributes(attribute.String("reason", evictionReasonTooLarge)))
+		return nil
+	}
+
+	// Room is made before adding the entry so that the policy never picks the entry being set.
+	provider.evict(ctx, now, size)
+
+	entry := &entry{key: key, data: data, value: value, size: size, expiresAt: provider.expiresAt(now, ttl)}
+	provider.entries[key] = entry
+	provider.policy.add(entry)
+	provider.bytes += size
 	return nil
 }
 
-func (provider *provider) Get(_ context.Context, orgID valuer.UUID, cacheKey string, dest cachetypes.Cacheable, allowExpired bool) error {
+func (provider *provider) Get(ctx context.Context, orgID valuer.UUID, cacheKey string, dest cachetypes.Cacheable, allowExpired bool) error {
 	// check if the destination being passed is a pointer and is not nil
 	err := cachetypes.ValidatePointer(dest, "inmemory")
 	if err != nil {
@@ -56,11 +173,20 @@ func (provider *provider) Get(_ context.Context, orgID valuer.UUID, cacheKey str
 		return errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "destination value is not settable, %s", dstv.Elem())
 	}
 
-	data, found := provider.cc.Get(strings.Join([]string{orgID.StringValue(), cacheKey}, "::"))
+	data, value, found := provider.get(ctx, strings.Join([]string{orgID.StringValue(), cacheKey}, "::"))
 	if !found {
 		return errors.Newf(errors.TypeNotFound, errors.CodeNotFound, "key miss")
 	}
 
+	if value != nil {
+		bytes, err := cache.Decompress(value)
+		if err != nil {
+			return err
+		}
+
+		return dest.UnmarshalBinary(bytes)
+	}
+
 	// check the type compatbility between the src and dest
 	srcv := reflect.ValueOf(data)
 	if !srcv.Type().AssignableTo(dstv.Type()) {
@@ -73,11 +199,165 @@ func (provider *provider) Get(_ context.Context, orgID valuer.UUID, cacheKey str
 }
 
 func (provider *provider) Delete(_ context.Context, orgID valuer.UUID, cacheKey string) {
-	provider.cc.Delete(strings.Join([]string{orgID.StringValue(), cacheKey}, "::"))
+	provider.mtx.Lock()
+	defer provider.mtx.Unlock()
+
+	if entry, ok := provider.entries[strings.Join([]string{orgID.StringValue(), cacheKey}, "::")]; ok {
+		provider.remove(entry)
+	}
 }
 
-func (provider *provider) DeleteMany(_ context.Context, orgID valuer.UUID, cacheKeys []string) {
+func (provider *provider) DeleteMany(ctx context.Context, orgID valuer.UUID, cacheKeys []string) {
 	for _, cacheKey := range cacheKeys {
-		provider.cc.Delete(strings.Join([]string{orgID.StringValue(), cacheKey}, "::"))
+		provider.Delete(ctx, orgID, cacheKey)
+	}
+}
+
+func (provider *provider) GetMany(ctx context.Context, orgID valuer.UUID, cacheKeys []string) (map[string][]byte, error) {
+	result := make(map[string][]byte, len(cacheKeys))
+	for _, cacheKey := range cacheKeys {
+		data, value, found := provider.get(ctx, strings.Join([]string{orgID.StringValue(), cacheKey}, "::"))
+		if !found {
+			continue
+		}
+
+		if value != nil {
+			bytes, err := cache.Decompress(value)
+			if err != nil {
+				return nil, err
+			}
+
+			result[cacheKey] = bytes
+			continue
+		}
+
+		bytes, err := data.MarshalBinary()
+		if err != nil {
+			return nil, errors.WrapInternalf(err, errors.CodeInternal, "failed to marshal cached value for key %q", cacheKey)
+		}
+
+		result[cacheKey] = bytes
 	}
+
+	return result, nil
+}
+
+func (provider *provider) SetMany(ctx context.Context, orgID valuer.UUID, items map[string]cachetypes.Item) error {
+	for cacheKey, item := range items {
+		if err := provider.Set(ctx, orgID, cacheKey, item.Data, item.TTL); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (provider *provider) DeleteByPrefix(_ context.Context, orgID valuer.UUID, prefix string) error {
+	provider.mtx.Lock()
+	defer provider.mtx.Unlock()
+
+	keyPrefix := strings.Join([]string{orgID.StringValue(), prefix}, "::")
+	for key, entry := range provider.entries {
+		if strings.HasPrefix(key, keyPrefix) {
+			provider.remove(entry)
+		}
+	}
+
+	return nil
+}
+
+func (provider *provider) WithNamespace(namespace string) cache.Cache {
+	return cache.NewNamespaced(provider, namespace)
+}
+
+// get returns the data of the key, or its stored value when the values are compressed, counting the hit or the miss.
+// The expired entries are misses.
+func (provider *provider) get(ctx context.Context, key string) (cachetypes.Cacheable, []byte, bool) {
+	provider.mtx.Lock()
+	defer provider.mtx.Unlock()
+
+	entry, ok := provider.entries[key]
+	if ok && entry.expired(provider.now()) {
+		provider.remove(entry)
+		provider.evictions.Add(ctx, 1, metric.WithAttributes(attribute.String("reason", evictionReasonExpired)))
+		ok = false
+	}
+
+	if !ok {
+		provider.misses.Add(ctx, 1)
+		return nil, nil, false
+	}
+
+	provider.policy.touch(entry)
+	provider.hits.Add(ctx, 1)
+	return entry.data, entry.value, true
+}
+
+// expiresAt returns the expiry of an entry set at now with the ttl. A zero ttl is the ttl of the config and a
+// negative ttl never expires, as does the zero ttl of the config.
+func (provider *provider) expiresAt(now time.Time, ttl time.Duration) time.Time {
+	if ttl == 0 {
+		ttl = provider.config.Memory.TTL
+	}
+
+	if ttl <= 0 {
+		return time.Time{}
+	}
+
+	return now.Add(ttl)
+}
+
+// evict evicts the entries picked by the policy until an entry of the size fits within the limits of the cache. It
+// must be called with the lock held.
+func (provider *provider) evict(ctx context.Context, now time.Time, size int64) {
+	for provider.full(size) {
+		entry := provider.policy.victim()
+		if entry == nil {
+			return
+		}
+
+		reason := evictionReasonCapacity
+		if entry.expired(now) {
+			reason = evictionReasonExpired
+		}
+
+		provider.remove(entry)
+		provider.evictions.Add(ctx, 1, metric.WithAttributes(attribute.String("reason", reason)))
+	}
+}
+
+// full returns whether an entry of the size does not fit within the limits of the cache.
+func (provider *provider) full(size int64) bool {
+	if provider.config.Memory.MaxEntries > 0 && len(provider.entries) >= provider.config.Memory.MaxEntries {
+		return true
+	}
+
+	return provider.config.Memory.MaxBytes > 0 && provider.bytes+size > provider.config.Memory.MaxBytes
+}
+
+// cleanup removes the expired entries, at most once every cleanup interval. It must be called with the lock held.
+func (provider *provider) cleanup(ctx context.Context, now time.Time) {
+	if provider.config.Memory.CleanupInterval <= 0 || now.Sub(provider.lastCleanup) < provider.config.Memory.CleanupInterval {
+		return
+	}
+
+	provider.lastCleanup = now
+	var expired int64
+	for _, entry := range provider.entries {
+		if entry.expired(now) {
+			provider.remove(entry)
+			expired++
+		}
+	}
+
+	if expired > 0 {
+		provider.evictions.Add(ctx, expired, metric.WithAttributes(attribute.String("reason", evictionReasonExpired)))
+	}
+}
+
+// remove removes the entry. It must be called with the lock held.
+func (provider *provider) remove(entry *entry) {
+	delete(provider.entries, entry.key)
+	provider.policy.remove(entry)
+	provider.bytes -= entry.size
 }
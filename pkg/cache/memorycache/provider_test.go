@@ -3,11 +3,13 @@ package memorycache
 import (
 	"context"
 	"encoding/json"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/SigNoz/signoz/pkg/cache"
 	"github.com/SigNoz/signoz/pkg/factory/factorytest"
+	"github.com/SigNoz/signoz/pkg/types/cachetypes"
 	"github.com/SigNoz/signoz/pkg/valuer"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -22,7 +24,7 @@ func TestNew(t *testing.T) {
 	c, err := New(context.Background(), factorytest.NewSettings(), cache.Config{Provider: "memory", Memory: opts})
 	require.NoError(t, err)
 	assert.NotNil(t, c)
-	assert.NotNil(t, c.(*provider).cc)
+	assert.NotNil(t, c.(*provider).entries)
 }
 
 type CacheableEntity struct {
@@ -247,3 +249,224 @@ func TestCache(t *testing.T) {
 	assert.Equal(t, storeCacheableEntity, retrieveCacheableEntity)
 	c.Delete(context.Background(), orgID, "key")
 }
+
+func TestGetManyAndSetMany(t *testing.T) {
+	opts := cache.Memory{
+		TTL:             10 * time.Second,
+		CleanupInterval: 10 * time.Second,
+	}
+	c, err := New(context.Background(), factorytest.NewSettings(), cache.Config{Provider: "memory", Memory: opts})
+	require.NoError(t, err)
+	orgID := valuer.GenerateUUID()
+
+	storeCacheableEntity1 := &CacheableEntity{Key: "some-random-key-1", Value: 1}
+	storeCacheableEntity2 := &CacheableEntity{Key: "some-random-key-2", Value: 2}
+	assert.NoError(t, c.SetMany(context.Background(), orgID, map[string]cachetypes.Item{
+		"key1": {Data: storeCacheableEntity1, TTL: 10 * time.Second},
+		"key2": {Data: storeCacheableEntity2, TTL: 10 * time.Second},
+	}))
+
+	data, err := c.GetMany(context.Background(), orgID, []string{"key1", "key2", "key3"})
+	require.NoError(t, err)
+
+	expected1, err := storeCacheableEntity1.MarshalBinary()
+	require.NoError(t, err)
+	expected2, err := storeCacheableEntity2.MarshalBinary()
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string][]byte{"key1": expected1, "key2": expected2}, data)
+}
+
+func TestWithNamespace(t *testing.T) {
+	opts := cache.Memory{
+		TTL:             10 * time.Second,
+		CleanupInterval: 10 * time.Second,
+	}
+	c, err := New(context.Background(), factorytest.NewSettings(), cache.Config{Provider: "memory", Memory: opts})
+	require.NoError(t, err)
+	orgID := valuer.GenerateUUID()
+
+	alertmanager := c.WithNamespace("alertmanager")
+	dashboard := c.WithNamespace("dashboard")
+
+	storeCacheableEntity := &CacheableEntity{Key: "some-random-key", Value: 1}
+	assert.NoError(t, alertmanager.Set(context.Background(), orgID, "key", storeCacheableEntity, 10*time.Second))
+	assert.NoError(t, alertmanager.Set(context.Background(), orgID, "other", storeCacheableEntity, 10*time.Second))
+	assert.NoError(t, dashboard.Set(context.Background(), orgID, "key", storeCacheableEntity, 10*time.Second))
+
+	// the namespaces are isolated from each other and from the root cache
+	retrieveCacheableEntity := new(CacheableEntity)
+	assert.Error(t, c.Get(context.Background(), orgID, "key", retrieveCacheableEntity, false))
+	assert.NoError(t, alertmanager.Get(context.Background(), orgID, "key", retrieveCacheableEntity, false))
+	assert.Equal(t, storeCacheableEntity, retrieveCacheableEntity)
+
+	data, err := alertmanager.GetMany(context.Background(), orgID, []string{"key", "missing"})
+	require.NoError(t, err)
+	assert.Len(t, data, 1)
+	assert.Contains(t, data, "key")
+
+	// flushing a namespace does not touch the other namespaces
+	assert.NoError(t, alertmanager.DeleteByPrefix(context.Background(), orgID, ""))
+	assert.Error(t, alertmanager.Get(context.Background(), orgID, "key", retrieveCacheableEntity, false))
+	assert.Error(t, alertmanager.Get(context.Background(), orgID, "other", retrieveCacheableEntity, false))
+	assert.NoError(t, dashboard.Get(context.Background(), orgID, "key", retrieveCacheableEntity, false))
+}
+
+func TestDeleteByPrefix(t *testing.T) {
+	opts := cache.Memory{
+		TTL:             10 * time.Second,
+		CleanupInterval: 10 * time.Second,
+	}
+	c, err := New(context.Background(), factorytest.NewSettings(), cache.Config{Provider: "memory", Memory: opts})
+	require.NoError(t, err)
+	orgID := valuer.GenerateUUID()
+	otherOrgID := valuer.GenerateUUID()
+
+	storeCacheableEntity := &CacheableEntity{Key: "some-random-key", Value: 1}
+	assert.NoError(t, c.Set(context.Background(), orgID, "query::1", storeCacheableEntity, 10*time.Second))
+	assert.NoError(t, c.Set(context.Background(), orgID, "dashboard::1", storeCacheableEntity, 10*time.Second))
+	assert.NoError(t, c.Set(context.Background(), otherOrgID, "query::1", storeCacheableEntity, 10*time.Second))
+
+	assert.NoError(t, c.DeleteByPrefix(context.Background(), orgID, "query::"))
+
+	retrieveCacheableEntity := new(CacheableEntity)
+	assert.Error(t, c.Get(context.Background(), orgID, "query::1", retrieveCacheableEntity, false))
+	assert.NoError(t, c.Get(context.Background(), orgID, "dashboard::1", retrieveCacheableEntity, false))
+	assert.NoError(t, c.Get(context.Background(), otherOrgID, "query::1", retrieveCacheableEntity, false))
+}
+
+func TestEvictionPolicy(t *testing.T) {
+	ctx := context.Background()
+	orgID := valuer.GenerateUUID()
+
+	// keys sets a, b and c, gets the hit keys, sets d and returns the keys left in the cache.
+	keys := func(t *testing.T, policy string, ttls map[string]time.Duration, hit []string) []string {
+		c, err := New(ctx, factorytest.NewSettings(), cache.Config{Provider: "memory", Memory: cache.Memory{TTL: time.Hour, EvictionPolicy: policy, MaxEntries: 3}})
+		require.NoError(t, err)
+
+		for _, key := range []string{"a", "b", "c"} {
+			require.NoError(t, c.Set(ctx, orgID, key, &CacheableEntity{Key: key}, ttls[key]))
+		}
+
+		for _, key := range hit {
+			require.NoError(t, c.Get(ctx, orgID, key, new(CacheableEntity), false))
+		}
+
+		require.NoError(t, c.Set(ctx, orgID, "d", &CacheableEntity{Key: "d"}, ttls["d"]))
+
+		var found []string
+		for _, key := range []string{"a", "b", "c", "d"} {
+			if err := c.Get(ctx, orgID, key, new(CacheableEntity), false); err == nil {
+				found = append(found, key)
+			}
+		}
+
+		return found
+	}
+
+	t.Run("LRU", func(t *testing.T) {
+		assert.Equal(t, []string{"a", "c", "d"}, keys(t, cache.EvictionPolicyLRU, nil, []string{"a"}))
+	})
+
+	t.Run("LFU", func(t *testing.T) {
+		assert.Equal(t, []string{"a", "b", "d"}, keys(t, cache.EvictionPolicyLFU, nil, []string{"a", "a", "b", "b", "c", "a", "b"}))
+		// Among the entries with as many hits, the least recently used one is evicted.
+		assert.Equal(t, []string{"b", "c", "d"}, keys(t, cache.EvictionPolicyLFU, nil, []string{"a", "b", "c"}))
+	})
+
+	t.Run("TTL", func(t *testing.T) {
+		ttls := map[string]time.Duration{"a": 2 * time.Hour, "b": time.Minute, "c": -1, "d": time.Hour}
+		assert.Equal(t, []string{"a", "c", "d"}, keys(t, cache.EvictionPolicyTTL, ttls, []string{"b"}))
+	})
+}
+
+func TestMaxBytes(t *testing.T) {
+	ctx := context.Background()
+	orgID := valuer.GenerateUUID()
+
+	entity := &CacheableEntity{Key: "key", Value: 1}
+	bytes, err := entity.MarshalBinary()
+	require.NoError(t, err)
+	size := int64(len(orgID.StringValue()+"::a") + len(bytes))
+
+	c, err := New(ctx, factorytest.NewSettings(), cache.Config{Provider: "memory", Memory: cache.Memory{TTL: time.Hour, MaxBytes: 2 * size}})
+	require.NoError(t, err)
+
+	require.NoError(t, c.Set(ctx, orgID, "a", entity, 0))
+	require.NoError(t, c.Set(ctx, orgID, "b", entity, 0))
+	require.NoError(t, c.Set(ctx, orgID, "c", entity, 0))
+
+	assert.Error(t, c.Get(ctx, orgID, "a", new(CacheableEntity), false))
+	assert.NoError(t, c.Get(ctx, orgID, "b", new(CacheableEntity), false))
+	assert.NoError(t, c.Get(ctx, orgID, "c", new(CacheableEntity), false))
+	assert.Equal(t, 2*size, c.(*provider).bytes)
+
+	// A value larger than the cache is not cached and does not evict the others.
+	require.NoError(t, c.Set(ctx, orgID, "large", &CacheableEntity{Key: strings.Repeat("x", int(2*size))}, 0))
+	assert.Error(t, c.Get(ctx, orgID, "large", new(CacheableEntity), false))
+	assert.NoError(t, c.Get(ctx, orgID, "b", new(CacheableEntity), false))
+}
+
+func TestExpiry(t *testing.T) {
+	ctx := context.Background()
+	orgID := valuer.GenerateUUID()
+
+	c, err := New(ctx, factorytest.NewSettings(), cache.Config{Provider: "memory", Memory: cache.Memory{TTL: time.Minute, CleanupInterval: time.Minute}})
+	require.NoError(t, err)
+
+	now := time.Now()
+	c.(*provider).now = func() time.Time { return now }
+
+	require.NoError(t, c.Set(ctx, orgID, "default", &CacheableEntity{}, 0))
+	require.NoError(t, c.Set(ctx, orgID, "long", &CacheableEntity{}, time.Hour))
+	require.NoError(t, c.Set(ctx, orgID, "never", &CacheableEntity{}, -1))
+
+	now = now.Add(2 * time.Minute)
+	assert.Error(t, c.Get(ctx, orgID, "default", new(CacheableEntity), false))
+	assert.NoError(t, c.Get(ctx, orgID, "long", new(CacheableEntity), false))
+
+	now = now.Add(2 * time.Hour)
+	require.NoError(t, c.Set(ctx, orgID, "new", &CacheableEntity{}, 0))
+	assert.Len(t, c.(*provider).entries, 2)
+	assert.NoError(t, c.Get(ctx, orgID, "never", new(CacheableEntity), false))
+}
+
+type CompressibleEntity struct {
+	Values []string `json:"values"`
+}
+
+func (ce *CompressibleEntity) MarshalBinary() ([]byte, error) {
+	return json.Marshal(ce)
+}
+
+func (ce *CompressibleEntity) UnmarshalBinary(data []byte) error {
+	return json.Unmarshal(data, ce)
+}
+
+func TestCompression(t *testing.T) {
+	ctx := context.Background()
+	orgID := valuer.GenerateUUID()
+	c, err := New(ctx, factorytest.NewSettings(), cache.Config{Provider: "memory", Memory: cache.Memory{TTL: time.Hour}, Compression: cache.Compression{Algorithm: cache.CompressionAlgorithmZstd, Threshold: 64}})
+	require.NoError(t, err)
+
+	large := &CompressibleEntity{Values: strings.Split(strings.Repeat("value,", 100), ",")}
+	small := &CompressibleEntity{Values: []string{"value"}}
+	require.NoError(t, c.SetMany(ctx, orgID, map[string]cachetypes.Item{"large": {Data: large, TTL: time.Hour}, "small": {Data: small, TTL: time.Hour}}))
+
+	// The large value is stored compressed and the small one as it is.
+	largeBinary, err := large.MarshalBinary()
+	require.NoError(t, err)
+	assert.Less(t, len(c.(*provider).entries[orgID.StringValue()+"::large"].value), len(largeBinary))
+
+	smallBinary, err := small.MarshalBinary()
+	require.NoError(t, err)
+	assert.Equal(t, smallBinary, c.(*provider).entries[orgID.StringValue()+"::small"].value)
+
+	retrieved := new(CompressibleEntity)
+	require.NoError(t, c.Get(ctx, orgID, "large", retrieved, false))
+	assert.Equal(t, large, retrieved)
+
+	data, err := c.GetMany(ctx, orgID, []string{"large", "small"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]byte{"large": largeBinary, "small": smallBinary}, data)
+}
@@ -0,0 +1,98 @@
+package cache
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/types/cachetypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+)
+
+// NamespaceSeparator separates the namespace from the cache key.
+const NamespaceSeparator = "::"
+
+// nestedNamespaceSeparator separates the namespaces nested in one another.
+const nestedNamespaceSeparator = ":"
+
+// namespaceEscaper escapes the colons of the namespaces and of the cache keys, so that the separators are only ever
+// found between them. The keys of a namespace can then neither be the keys of another namespace nor the keys of the
+// namespaces nested in it.
+var namespaceEscaper = strings.NewReplacer("%", "%25", ":", "%3A")
+
+type namespaced struct {
+	cache     Cache
+	namespace string
+}
+
+// NewNamespaced returns a view of the given cache which transparently prefixes all the keys with the namespace.
+// Providers use it to implement WithNamespace.
+func NewNamespaced(cache Cache, namespace string) Cache {
+	return &namespaced{cache: cache, namespace: namespaceEscaper.Replace(namespace)}
+}
+
+func (namespaced *namespaced) key(cacheKey string) string {
+	return namespaced.namespace + NamespaceSeparator + namespaceEscaper.Replace(cacheKey)
+}
+
+func (namespaced *namespaced) Set(ctx context.Context, orgID valuer.UUID, cacheKey string, data cachetypes.Cacheable, ttl time.Duration) error {
+	return namespaced.cache.Set(ctx, orgID, namespaced.key(cacheKey), data, ttl)
+}
+
+func (namespaced *namespaced) Get(ctx context.Context, orgID valuer.UUID, cacheKey string, dest cachetypes.Cacheable, allowExpired bool) error {
+	return namespaced.cache.Get(ctx, orgID, namespaced.key(cacheKey), dest, allowExpired)
+}
+
+func (namespaced *namespaced) Delete(ctx context.Context, orgID valuer.UUID, cacheKey string) {
+	namespaced.cache.Delete(ctx, orgID, namespaced.key(cacheKey))
+}
+
+func (namespaced *namespaced) DeleteMany(ctx context.Context, orgID valuer.UUID, cacheKeys []string) {
+	keys := make([]string, len(cacheKeys))
+	for i, cacheKey := range cacheKeys {
+		keys[i] = namespaced.key(cacheKey)
+	}
+
+	namespaced.cache.DeleteMany(ctx, orgID, keys)
+}
+
+func (namespaced *namespaced) DeleteByPrefix(ctx context.Context, orgID valuer.UUID, prefix string) error {
+	return namespaced.cache.DeleteByPrefix(ctx, orgID, namespaced.key(prefix))
+}
+
+func (namespaced *namespaced) GetMany(ctx context.Context, orgID valuer.UUID, cacheKeys []string) (map[string][]byte, error) {
+	keys := make([]string, len(cacheKeys))
+	for i, cacheKey := range cacheKeys {
+		keys[i] = namespaced.key(cacheKey)
+	}
+
+	values, err := namespaced.cache.GetMany(ctx, orgID, keys)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string][]byte, len(values))
+	for i, key := range keys {
+		if value, ok := values[key]; ok {
+			result[cacheKeys[i]] = value
+		}
+	}
+
+	return result, nil
+}
+
+func (namespaced *namespaced) SetMany(ctx context.Context, orgID valuer.UUID, items map[string]cachetypes.Item) error {
+	namespacedItems := make(map[string]cachetypes.Item, len(items))
+	for cacheKey, item := range items {
+		namespacedItems[namespaced.key(cacheKey)] = item
+	}
+
+	return namespaced.cache.SetMany(ctx, orgID, namespacedItems)
+}
+
+// WithNamespace nests the namespace in the namespace of the view rather than prefixing the keys of the view with it.
+func (namespaced *namespaced) WithNamespace(namespace string) Cache {
+	nested := *namespaced
+	nested.namespace += nestedNamespaceSeparator + namespaceEscaper.Replace(namespace)
+	return &nested
+}
@@ -0,0 +1,64 @@
+package cache
+
+import (
+	"bytes"
+	"context"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/types/cachetypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+)
+
+var (
+	// ErrCodeCacheEmpty is the code of the errors returned for the keys cached as empty, which are of type not found
+	// like the ones of the keys absent from the cache.
+	ErrCodeCacheEmpty = errors.MustNewCode("cache_empty")
+)
+
+var (
+	// emptyValue is the binary representation of the entities cached as empty. Like the header of the compressed
+	// values, it starts with a null byte which the binary representations of the cacheable entities never start
+	// with, and differs from that header right after it.
+	emptyValue = []byte{0x00, 'e', 'm', 'p', 't', 'y'}
+)
+
+// empty is the cacheable entity stored for the keys known to be empty.
+type empty struct{}
+
+func (*empty) MarshalBinary() ([]byte, error) {
+	return emptyValue, nil
+}
+
+func (*empty) UnmarshalBinary(data []byte) error {
+	if !bytes.Equal(data, emptyValue) {
+		return errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "value is not empty")
+	}
+
+	return nil
+}
+
+// SetEmpty caches the key as empty with the ttl, so that GetOrEmpty tells it apart from a key absent from the cache.
+func SetEmpty(ctx context.Context, cache Cache, orgID valuer.UUID, cacheKey string, ttl time.Duration) error {
+	return cache.Set(ctx, orgID, cacheKey, &empty{}, ttl)
+}
+
+// GetOrEmpty gets the cacheable entity in dest like Cache.Get. If the key is cached as empty, it returns an error of
+// type not found and code ErrCodeCacheEmpty, while the errors of the keys absent from the cache keep their code.
+func GetOrEmpty(ctx context.Context, cache Cache, orgID valuer.UUID, cacheKey string, dest cachetypes.Cacheable, allowExpired bool) error {
+	if err := cachetypes.ValidatePointer(dest, "negative"); err != nil {
+		return err
+	}
+
+	err := cache.Get(ctx, orgID, cacheKey, dest, allowExpired)
+	if err == nil || errors.Ast(err, errors.TypeNotFound) {
+		return err
+	}
+
+	// The entities cached as empty cannot be read in dest, they are looked up again only when dest could not be read.
+	if cache.Get(ctx, orgID, cacheKey, &empty{}, allowExpired) == nil {
+		return errors.Newf(errors.TypeNotFound, ErrCodeCacheEmpty, "key %q is cached as empty", cacheKey)
+	}
+
+	return err
+}
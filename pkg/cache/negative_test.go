@@ -0,0 +1,57 @@
+package cache_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/cache"
+	"github.com/SigNoz/signoz/pkg/cache/cachetest"
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/valuer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type entity struct {
+	Value int `json:"value"`
+}
+
+func (e *entity) MarshalBinary() ([]byte, error) {
+	return json.Marshal(e)
+}
+
+func (e *entity) UnmarshalBinary(data []byte) error {
+	return json.Unmarshal(data, e)
+}
+
+func TestGetOrEmpty(t *testing.T) {
+	compressions := map[string]cache.Compression{
+		"Uncompressed": {Algorithm: cache.CompressionAlgorithmNone},
+		"Compressed":   {Algorithm: cache.CompressionAlgorithmGzip, Threshold: 0},
+	}
+
+	for name, compression := range compressions {
+		t.Run(name, func(t *testing.T) {
+			c, err := cachetest.New(cache.Config{Provider: "memory", Memory: cache.Memory{TTL: time.Minute, CleanupInterval: time.Minute}, Compression: compression})
+			require.NoError(t, err)
+			orgID := valuer.GenerateUUID()
+
+			require.NoError(t, c.Set(context.Background(), orgID, "present", &entity{Value: 1}, time.Minute))
+			require.NoError(t, cache.SetEmpty(context.Background(), c, orgID, "empty", time.Minute))
+
+			dest := &entity{}
+			require.NoError(t, cache.GetOrEmpty(context.Background(), c, orgID, "present", dest, false))
+			assert.Equal(t, 1, dest.Value)
+
+			err = cache.GetOrEmpty(context.Background(), c, orgID, "empty", &entity{}, false)
+			assert.True(t, errors.Ast(err, errors.TypeNotFound))
+			assert.True(t, errors.Asc(err, cache.ErrCodeCacheEmpty))
+
+			err = cache.GetOrEmpty(context.Background(), c, orgID, "absent", &entity{}, false)
+			assert.True(t, errors.Ast(err, errors.TypeNotFound))
+			assert.False(t, errors.Asc(err, cache.ErrCodeCacheEmpty))
+		})
+	}
+}
@@ -17,8 +17,9 @@ import (
 )
 
 type provider struct {
-	client   *redis.Client
-	settings factory.ScopedProviderSettings
+	client     *redis.Client
+	settings   factory.ScopedProviderSettings
+	compressor *cache.Compressor
 }
 
 func NewFactory() factory.ProviderFactory[cache.Cache, cache.Config] {
@@ -37,22 +38,42 @@ func New(ctx context.Context, providerSettings factory.ProviderSettings, config
 		return nil, err
 	}
 
-	return &provider{client: client, settings: settings}, nil
+	compressor, err := cache.NewCompressor(settings, config.Compression)
+	if err != nil {
+		return nil, err
+	}
+
+	return &provider{client: client, settings: settings, compressor: compressor}, nil
+}
+
+func (c *provider) Healthy(ctx context.Context) error {
+	return c.client.Ping(ctx).Err()
 }
 
 func (c *provider) Set(ctx context.Context, orgID valuer.UUID, cacheKey string, data cachetypes.Cacheable, ttl time.Duration) error {
-	return c.client.Set(ctx, strings.Join([]string{orgID.StringValue(), cacheKey}, "::"), data, ttl).Err()
+	value, err := c.value(ctx, data)
+	if err != nil {
+		return err
+	}
+
+	return c.client.Set(ctx, strings.Join([]string{orgID.StringValue(), cacheKey}, "::"), value, ttl).Err()
 }
 
 func (c *provider) Get(ctx context.Context, orgID valuer.UUID, cacheKey string, dest cachetypes.Cacheable, allowExpired bool) error {
-	err := c.client.Get(ctx, strings.Join([]string{orgID.StringValue(), cacheKey}, "::")).Scan(dest)
+	value, err := c.client.Get(ctx, strings.Join([]string{orgID.StringValue(), cacheKey}, "::")).Bytes()
 	if err != nil {
 		if errors.Is(err, redis.Nil) {
 			return errorsV2.Newf(errorsV2.TypeNotFound, errorsV2.CodeNotFound, "key miss")
 		}
 		return err
 	}
-	return nil
+
+	data, err := cache.Decompress(value)
+	if err != nil {
+		return err
+	}
+
+	return dest.UnmarshalBinary(data)
 }
 
 func (c *provider) Delete(ctx context.Context, orgID valuer.UUID, cacheKey string) {
@@ -69,3 +90,116 @@ func (c *provider) DeleteMany(ctx context.Context, orgID valuer.UUID, cacheKeys
 		c.settings.Logger().ErrorContext(ctx, "error deleting cache keys", "cache_keys", cacheKeys, "error", err)
 	}
 }
+
+func (c *provider) GetMany(ctx context.Context, orgID valuer.UUID, cacheKeys []string) (map[string][]byte, error) {
+	result := make(map[string][]byte, len(cacheKeys))
+	if len(cacheKeys) == 0 {
+		return result, nil
+	}
+
+	updatedCacheKeys := make([]string, len(cacheKeys))
+	for i, cacheKey := range cacheKeys {
+		updatedCacheKeys[i] = strings.Join([]string{orgID.StringValue(), cacheKey}, "::")
+	}
+
+	values, err := c.client.MGet(ctx, updatedCacheKeys...).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	for i, value := range values {
+		// missing keys are returned as nil by MGET
+		str, ok := value.(string)
+		if !ok {
+			continue
+		}
+
+		data, err := cache.Decompress([]byte(str))
+		if err != nil {
+			return nil, err
+		}
+
+		result[cacheKeys[i]] = data
+	}
+
+	return result, nil
+}
+
+func (c *provider) SetMany(ctx context.Context, orgID valuer.UUID, items map[string]cachetypes.Item) error {
+	if len(items) == 0 {
+		return nil
+	}
+
+	_, err := c.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for cacheKey, item := range items {
+			value, err := c.value(ctx, item.Data)
+			if err != nil {
+				return err
+			}
+
+			pipe.Set(ctx, strings.Join([]string{orgID.StringValue(), cacheKey}, "::"), value, item.TTL)
+		}
+		return nil
+	})
+
+	return err
+}
+
+func (c *provider) DeleteByPrefix(ctx context.Context, orgID valuer.UUID, prefix string) error {
+	pattern := escapePattern(strings.Join([]string{orgID.StringValue(), prefix}, "::")) + "*"
+
+	iter := c.client.Scan(ctx, 0, pattern, 1000).Iterator()
+	keys := []string{}
+	for iter.Next(ctx) {
+		keys = append(keys, iter.Val())
+		if len(keys) == 1000 {
+			if err := c.client.Unlink(ctx, keys...).Err(); err != nil {
+				return err
+			}
+			keys = keys[:0]
+		}
+	}
+
+	if err := iter.Err(); err != nil {
+		return err
+	}
+
+	if len(keys) > 0 {
+		return c.client.Unlink(ctx, keys...).Err()
+	}
+
+	return nil
+}
+
+func (c *provider) WithNamespace(namespace string) cache.Cache {
+	return cache.NewNamespaced(c, namespace)
+}
+
+// value returns the value stored for the entity. The entity is handed as it is to the client, which marshals it, when
+// the values are not compressed.
+func (c *provider) value(ctx context.Context, data cachetypes.Cacheable) (any, error) {
+	if !c.compressor.Enabled() {
+		return data, nil
+	}
+
+	binary, err := data.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+
+	return c.compressor.Compress(ctx, binary)
+}
+
+// escapePattern escapes the characters which have a special meaning in redis glob-style patterns.
+func escapePattern(s string) string {
+	var builder strings.Builder
+	for _, r := range s {
+		switch r {
+		case '*', '?', '[', ']', '\\':
+			builder.WriteRune('\\')
+		}
+		builder.WriteRune(r)
+	}
+
+	return builder.String()
+}
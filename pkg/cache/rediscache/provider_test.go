@@ -7,8 +7,10 @@ import (
 	"testing"
 	"time"
 
+	cachepkg "github.com/SigNoz/signoz/pkg/cache"
 	"github.com/SigNoz/signoz/pkg/factory"
 	"github.com/SigNoz/signoz/pkg/factory/factorytest"
+	"github.com/SigNoz/signoz/pkg/types/cachetypes"
 	"github.com/SigNoz/signoz/pkg/valuer"
 	"github.com/go-redis/redismock/v8"
 	"github.com/stretchr/testify/assert"
@@ -119,3 +121,112 @@ func TestDeleteMany(t *testing.T) {
 		t.Errorf("there were unfulfilled expectations: %s", err)
 	}
 }
+
+func TestGetMany(t *testing.T) {
+	db, mock := redismock.NewClientMock()
+	cache := &provider{client: db, settings: factory.NewScopedProviderSettings(factorytest.NewSettings(), "github.com/SigNoz/signoz/pkg/cache/rediscache")}
+	storeCacheableEntity := &CacheableEntity{
+		Key:    "some-random-key",
+		Value:  1,
+		Expiry: time.Microsecond,
+	}
+	orgID := valuer.GenerateUUID()
+
+	data, err := storeCacheableEntity.MarshalBinary()
+	assert.NoError(t, err)
+
+	mock.ExpectMGet(strings.Join([]string{orgID.StringValue(), "key"}, "::"), strings.Join([]string{orgID.StringValue(), "key2"}, "::")).SetVal([]interface{}{string(data), nil})
+	values, err := cache.GetMany(context.Background(), orgID, []string{"key", "key2"})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]byte{"key": data}, values)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestSetMany(t *testing.T) {
+	db, mock := redismock.NewClientMock()
+	cache := &provider{client: db, settings: factory.NewScopedProviderSettings(factorytest.NewSettings(), "github.com/SigNoz/signoz/pkg/cache/rediscache")}
+	storeCacheableEntity := &CacheableEntity{
+		Key:    "some-random-key",
+		Value:  1,
+		Expiry: time.Microsecond,
+	}
+	orgID := valuer.GenerateUUID()
+
+	mock.ExpectSet(strings.Join([]string{orgID.StringValue(), "key"}, "::"), storeCacheableEntity, 10*time.Second).SetVal("OK")
+	err := cache.SetMany(context.Background(), orgID, map[string]cachetypes.Item{
+		"key": {Data: storeCacheableEntity, TTL: 10 * time.Second},
+	})
+	assert.NoError(t, err)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestDeleteByPrefix(t *testing.T) {
+	db, mock := redismock.NewClientMock()
+	cache := &provider{client: db, settings: factory.NewScopedProviderSettings(factorytest.NewSettings(), "github.com/SigNoz/signoz/pkg/cache/rediscache")}
+	orgID := valuer.GenerateUUID()
+
+	keys := []string{strings.Join([]string{orgID.StringValue(), "dashboard", "key"}, "::")}
+	mock.ExpectScan(0, strings.Join([]string{orgID.StringValue(), "dashboard", "*"}, "::"), 1000).SetVal(keys, 0)
+	mock.ExpectUnlink(keys...).SetVal(1)
+
+	assert.NoError(t, cache.WithNamespace("dashboard").DeleteByPrefix(context.Background(), orgID, ""))
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestEscapePattern(t *testing.T) {
+	assert.Equal(t, `a\*b\?c\[d\]e\\f`, escapePattern(`a*b?c[d]e\f`))
+}
+
+func TestCompression(t *testing.T) {
+	db, mock := redismock.NewClientMock()
+	settings := factory.NewScopedProviderSettings(factorytest.NewSettings(), "github.com/SigNoz/signoz/pkg/cache/rediscache")
+	compressor, err := cachepkg.NewCompressor(settings, cachepkg.Compression{Algorithm: cachepkg.CompressionAlgorithmGzip, Threshold: 64})
+	assert.NoError(t, err)
+
+	cache := &provider{client: db, settings: settings, compressor: compressor}
+	storeCacheableEntity := &CacheableEntity{
+		Key:    strings.Repeat("some-random-key", 10),
+		Value:  1,
+		Expiry: time.Microsecond,
+	}
+	orgID := valuer.GenerateUUID()
+
+	data, err := storeCacheableEntity.MarshalBinary()
+	assert.NoError(t, err)
+
+	compressed, err := compressor.Compress(context.Background(), data)
+	assert.NoError(t, err)
+	assert.Less(t, len(compressed), len(data))
+
+	mock.ExpectSet(strings.Join([]string{orgID.StringValue(), "key"}, "::"), compressed, 10*time.Second).RedisNil()
+	_ = cache.Set(context.Background(), orgID, "key", storeCacheableEntity, 10*time.Second)
+
+	retrieveCacheableEntity := new(CacheableEntity)
+	mock.ExpectGet(strings.Join([]string{orgID.StringValue(), "key"}, "::")).SetVal(string(compressed))
+	assert.NoError(t, cache.Get(context.Background(), orgID, "key", retrieveCacheableEntity, false))
+	assert.Equal(t, storeCacheableEntity, retrieveCacheableEntity)
+
+	// The values stored before the compression was enabled stay readable.
+	retrieveCacheableEntity = new(CacheableEntity)
+	mock.ExpectGet(strings.Join([]string{orgID.StringValue(), "legacy"}, "::")).SetVal(string(data))
+	assert.NoError(t, cache.Get(context.Background(), orgID, "legacy", retrieveCacheableEntity, false))
+	assert.Equal(t, storeCacheableEntity, retrieveCacheableEntity)
+
+	mock.ExpectMGet(strings.Join([]string{orgID.StringValue(), "key"}, "::"), strings.Join([]string{orgID.StringValue(), "legacy"}, "::")).SetVal([]interface{}{string(compressed), string(data)})
+	values, err := cache.GetMany(context.Background(), orgID, []string{"key", "legacy"})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]byte{"key": data, "legacy": data}, values)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
@@ -0,0 +1,150 @@
+package retrycache
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/cache"
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/factory"
+	"github.com/SigNoz/signoz/pkg/types/cachetypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+)
+
+const (
+	// retryInterval is the interval at which the creation of the cache is retried.
+	retryInterval = 15 * time.Second
+)
+
+// provider is a cache which keeps retrying to create the configured cache in the background, through the job
+// returned by NewJob. Until the configured cache is available, it behaves like a cache which always misses.
+type provider struct {
+	settings         factory.ScopedProviderSettings
+	providerSettings factory.ProviderSettings
+	config           cache.Config
+	factories        factory.NamedMap[factory.ProviderFactory[cache.Cache, cache.Config]]
+	key              string
+	cache            atomic.Pointer[cache.Cache]
+	err              atomic.Pointer[error]
+}
+
+// New returns a cache which retries the creation of the cache provider identified by key in the background through
+// the job returned by NewJob. It is used to boot in a degraded mode when the cache is not available.
+func New(ctx context.Context, providerSettings factory.ProviderSettings, config cache.Config, factories factory.NamedMap[factory.ProviderFactory[cache.Cache, cache.Config]], key string, cause error) cache.Cache {
+	settings := factory.NewScopedProviderSettings(providerSettings, "github.com/SigNoz/signoz/pkg/cache/retrycache")
+
+	provider := &provider{
+		settings:         settings,
+		providerSettings: providerSettings,
+		config:           config,
+		factories:        factories,
+		key:              key,
+	}
+	provider.err.Store(&cause)
+
+	return provider
+}
+
+// NewJob returns the job retrying the creation of the cache until it succeeds. ok is false when the cache is not a
+// cache returned by New, which needs no retries.
+func NewJob(c cache.Cache) (job factory.Job, ok bool) {
+	provider, ok := c.(*provider)
+	if !ok {
+		return nil, false
+	}
+
+	return factory.NewJob(factory.MustNewName("cacheretry"), retryInterval, provider.retry, factory.WithJitter(retryInterval/10)), true
+}
+
+// retry creates the cache unless it was created already.
+func (provider *provider) retry(ctx context.Context) error {
+	if provider.cache.Load() != nil {
+		return nil
+	}
+
+	c, err := factory.NewProviderFromNamedMap(ctx, provider.providerSettings, provider.config, provider.factories, provider.key)
+	if err != nil {
+		provider.err.Store(&err)
+		return errors.Wrapf(err, errors.TypeInternal, errors.CodeInternal, "cache %s is still unavailable", provider.key)
+	}
+
+	provider.cache.Store(&c)
+	provider.settings.Logger().InfoContext(ctx, "cache is available, exiting degraded mode", "provider", provider.key)
+	return nil
+}
+
+func (provider *provider) Healthy(ctx context.Context) error {
+	c := provider.cache.Load()
+	if c == nil {
+		return errors.Wrapf(*provider.err.Load(), errors.TypeInternal, errors.CodeInternal, "cache is unavailable")
+	}
+
+	return factory.HealthOf(ctx, *c)
+}
+
+// EffectiveConfig returns the error with which the cache failed to be created until it is created, the cache always
+// missing until then.
+func (provider *provider) EffectiveConfig(context.Context) (map[string]any, error) {
+	if provider.cache.Load() == nil {
+		return nil, errors.Wrapf(*provider.err.Load(), errors.TypeInternal, errors.CodeInternal, "cache %s failed to be created, every read misses until it is", provider.key)
+	}
+
+	return nil, nil
+}
+
+func (provider *provider) Set(ctx context.Context, orgID valuer.UUID, cacheKey string, data cachetypes.Cacheable, ttl time.Duration) error {
+	if c := provider.cache.Load(); c != nil {
+		return (*c).Set(ctx, orgID, cacheKey, data, ttl)
+	}
+
+	return nil
+}
+
+func (provider *provider) Get(ctx context.Context, orgID valuer.UUID, cacheKey string, dest cachetypes.Cacheable, allowExpired bool) error {
+	if c := provider.cache.Load(); c != nil {
+		return (*c).Get(ctx, orgID, cacheKey, dest, allowExpired)
+	}
+
+	return errors.Newf(errors.TypeNotFound, errors.CodeNotFound, "key miss")
+}
+
+func (provider *provider) Delete(ctx context.Context, orgID valuer.UUID, cacheKey string) {
+	if c := provider.cache.Load(); c != nil {
+		(*c).Delete(ctx, orgID, cacheKey)
+	}
+}
+
+func (provider *provider) DeleteMany(ctx context.Context, orgID valuer.UUID, cacheKeys []string) {
+	if c := provider.cache.Load(); c != nil {
+		(*c).DeleteMany(ctx, orgID, cacheKeys)
+	}
+}
+
+func (provider *provider) GetMany(ctx context.Context, orgID valuer.UUID, cacheKeys []string) (map[string][]byte, error) {
+	if c := provider.cache.Load(); c != nil {
+		return (*c).GetMany(ctx, orgID, cacheKeys)
+	}
+
+	return map[string][]byte{}, nil
+}
+
+func (provider *provider) SetMany(ctx context.Context, orgID valuer.UUID, items map[string]cachetypes.Item) error {
+	if c := provider.cache.Load(); c != nil {
+		return (*c).SetMany(ctx, orgID, items)
+	}
+
+	return nil
+}
+
+func (provider *provider) DeleteByPrefix(ctx context.Context, orgID valuer.UUID, prefix string) error {
+	if c := provider.cache.Load(); c != nil {
+		return (*c).DeleteByPrefix(ctx, orgID, prefix)
+	}
+
+	return nil
+}
+
+func (provider *provider) WithNamespace(namespace string) cache.Cache {
+	return cache.NewNamespaced(provider, namespace)
+}
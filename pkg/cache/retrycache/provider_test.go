@@ -0,0 +1,50 @@
+package retrycache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/cache"
+	"github.com/SigNoz/signoz/pkg/cache/cachetest"
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/factory"
+	"github.com/SigNoz/signoz/pkg/factory/factorytest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJob(t *testing.T) {
+	ctx := context.Background()
+
+	fail := true
+	flaky := factory.NewProviderFactory(factory.MustNewName("flaky"), func(context.Context, factory.ProviderSettings, cache.Config) (cache.Cache, error) {
+		if fail {
+			return nil, errors.New(errors.TypeInternal, errors.CodeInternal, "connection refused")
+		}
+
+		return cachetest.New(cache.Config{Provider: "memory", Memory: cache.Memory{TTL: time.Minute, CleanupInterval: time.Minute}})
+	})
+	factories := factory.MustNewNamedMap(flaky)
+
+	c := New(ctx, factorytest.NewSettings(), cache.Config{}, factories, "flaky", errors.New(errors.TypeInternal, errors.CodeInternal, "connection refused"))
+	job, ok := NewJob(c)
+	require.True(t, ok)
+
+	assert.Error(t, job.Run(ctx))
+	assert.Error(t, c.(factory.Healthy).Healthy(ctx))
+	_, err := c.(factory.EffectiveConfig).EffectiveConfig(ctx)
+	assert.ErrorContains(t, err, "connection refused")
+
+	fail = false
+	require.NoError(t, job.Run(ctx))
+	_, err = c.(factory.EffectiveConfig).EffectiveConfig(ctx)
+	assert.NoError(t, err)
+	// The health of the created cache is reported from then on.
+	assert.ErrorIs(t, c.(factory.Healthy).Healthy(ctx), factory.ErrHealthUnknown)
+
+	created, err := cachetest.New(cache.Config{Provider: "memory", Memory: cache.Memory{TTL: time.Minute, CleanupInterval: time.Minute}})
+	require.NoError(t, err)
+	_, ok = NewJob(created)
+	assert.False(t, ok)
+}
@@ -1,11 +1,27 @@
 package emailing
 
-import "github.com/SigNoz/signoz/pkg/factory"
+import (
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/factory"
+)
 
 type Config struct {
 	Enabled   bool      `mapstructure:"enabled"`
 	Templates Templates `mapstructure:"templates"`
 	SMTP      SMTP      `mapstructure:"smtp"`
+	Retry     Retry     `mapstructure:"retry"`
+}
+
+// Retry is the retry policy for sends which fail with a retryable error.
+type Retry struct {
+	// MaxAttempts is the maximum number of attempts, including the first one.
+	MaxAttempts int `mapstructure:"max_attempts"`
+	// Backoff is the base backoff between attempts. It is doubled after every attempt.
+	Backoff time.Duration `mapstructure:"backoff"`
+	// Jitter is the fraction of the backoff which is randomly added or subtracted.
+	Jitter float64 `mapstructure:"jitter"`
 }
 
 type Templates struct {
@@ -19,6 +35,17 @@ type SMTP struct {
 	Headers map[string]string `mapstructure:"headers"`
 	Auth    SMTPAuth          `mapstructure:"auth"`
 	TLS     SMTPTLS           `mapstructure:"tls"`
+	// Timeout is the maximum time spent connecting to the server and sending an email.
+	Timeout time.Duration `mapstructure:"timeout"`
+	Pool    SMTPPool      `mapstructure:"pool"`
+}
+
+// SMTPPool is the pool of the connections to the server kept open between sends.
+type SMTPPool struct {
+	// MaxIdleConns is the maximum number of idle connections. 0 disables the reuse of connections.
+	MaxIdleConns int `mapstructure:"max_idle_conns"`
+	// IdleTimeout is the time after which an idle connection is closed.
+	IdleTimeout time.Duration `mapstructure:"idle_timeout"`
 }
 
 type SMTPAuth struct {
@@ -29,7 +56,11 @@ type SMTPAuth struct {
 }
 
 type SMTPTLS struct {
-	Enabled            bool   `mapstructure:"enabled"`
+	Enabled bool `mapstructure:"enabled"`
+	// Mode is one of auto, none, starttls or implicit.
+	Mode string `mapstructure:"mode"`
+	// ServerName is the name verified against the certificate of the server. It defaults to the host of the address.
+	ServerName         string `mapstructure:"server_name"`
 	InsecureSkipVerify bool   `mapstructure:"insecure_skip_verify"`
 	CAFilePath         string `mapstructure:"ca_file_path"`
 	KeyFilePath        string `mapstructure:"key_file_path"`
@@ -59,16 +90,58 @@ func newConfig() factory.Config {
 			},
 			TLS: SMTPTLS{
 				Enabled:            false,
+				Mode:               "auto",
+				ServerName:         "",
 				InsecureSkipVerify: false,
 				CAFilePath:         "",
 				KeyFilePath:        "",
 				CertFilePath:       "",
 			},
+			Timeout: 30 * time.Second,
+			Pool: SMTPPool{
+				MaxIdleConns: 2,
+				IdleTimeout:  30 * time.Second,
+			},
+		},
+		Retry: Retry{
+			MaxAttempts: 3,
+			Backoff:     time.Second,
+			Jitter:      0.2,
 		},
 	}
 }
 
 func (c Config) Validate() error {
+	switch c.SMTP.TLS.Mode {
+	case "", "auto", "none", "starttls", "implicit":
+	default:
+		return errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "smtp::tls::mode must be one of auto, none, starttls or implicit, got %q", c.SMTP.TLS.Mode)
+	}
+
+	if c.SMTP.Timeout <= 0 {
+		return errors.New(errors.TypeInvalidInput, errors.CodeInvalidInput, "smtp::timeout must be greater than 0")
+	}
+
+	if c.SMTP.Pool.MaxIdleConns < 0 {
+		return errors.New(errors.TypeInvalidInput, errors.CodeInvalidInput, "smtp::pool::max_idle_conns cannot be negative")
+	}
+
+	if c.SMTP.Pool.IdleTimeout < 0 {
+		return errors.New(errors.TypeInvalidInput, errors.CodeInvalidInput, "smtp::pool::idle_timeout cannot be negative")
+	}
+
+	if c.Retry.MaxAttempts < 1 {
+		return errors.New(errors.TypeInvalidInput, errors.CodeInvalidInput, "retry::max_attempts must be at least 1")
+	}
+
+	if c.Retry.Backoff < 0 {
+		return errors.New(errors.TypeInvalidInput, errors.CodeInvalidInput, "retry::backoff cannot be negative")
+	}
+
+	if c.Retry.Jitter < 0 || c.Retry.Jitter > 1 {
+		return errors.New(errors.TypeInvalidInput, errors.CodeInvalidInput, "retry::jitter must be between 0 and 1")
+	}
+
 	return nil
 }
 
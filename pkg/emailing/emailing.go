@@ -9,4 +9,8 @@ import (
 type Emailing interface {
 	// Sends an HTML email to the given address with the given subject and template name and data.
 	SendHTML(context.Context, string, string, emailtypes.TemplateName, map[string]any) error
+
+	// Sends an HTML email to the given address with the given template name and data. The subject is rendered
+	// from the subject block defined by the template.
+	SendTemplate(context.Context, string, emailtypes.TemplateName, any) error
 }
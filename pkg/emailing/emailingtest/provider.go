@@ -26,3 +26,9 @@ func (provider *Provider) SendHTML(ctx context.Context, to string, subject strin
 	provider.SentEmailCountByTemplateName[templateName]++
 	return nil
 }
+
+func (provider *Provider) SendTemplate(ctx context.Context, to string, templateName emailtypes.TemplateName, data any) error {
+	provider.SentEmailCountByTo[to]++
+	provider.SentEmailCountByTemplateName[templateName]++
+	return nil
+}
@@ -27,3 +27,8 @@ func (provider *provider) SendHTML(ctx context.Context, to string, subject strin
 	provider.settings.Logger().WarnContext(ctx, "using noop provider, no email will be sent", "to", to, "subject", subject)
 	return nil
 }
+
+func (provider *provider) SendTemplate(ctx context.Context, to string, templateName emailtypes.TemplateName, data any) error {
+	provider.settings.Logger().WarnContext(ctx, "using noop provider, no email will be sent", "to", to, "template", templateName.StringValue())
+	return nil
+}
@@ -7,23 +7,44 @@ import (
 	"github.com/SigNoz/signoz/pkg/emailing"
 	"github.com/SigNoz/signoz/pkg/emailing/templatestore/filetemplatestore"
 	"github.com/SigNoz/signoz/pkg/factory"
+	"github.com/SigNoz/signoz/pkg/secretstore"
 	"github.com/SigNoz/signoz/pkg/smtp/client"
 	"github.com/SigNoz/signoz/pkg/types/emailtypes"
+	"go.opentelemetry.io/otel/metric"
 )
 
 type provider struct {
 	settings factory.ScopedProviderSettings
+	config   emailing.Config
 	store    emailtypes.TemplateStore
 	client   *client.Client
+	// retried counts the attempts made after a send failed with a retryable error.
+	retried metric.Int64Counter
+	// failed counts the sends which failed after all the attempts or with an error which is not retryable.
+	failed metric.Int64Counter
+	stopC  chan struct{}
 }
 
-func NewFactory() factory.ProviderFactory[emailing.Emailing, emailing.Config] {
-	return factory.NewProviderFactory(factory.MustNewName("smtp"), New)
+func NewFactory(secretResolver *secretstore.Resolver) factory.ProviderFactory[emailing.Emailing, emailing.Config] {
+	return factory.NewProviderFactory(factory.MustNewName("smtp"), func(ctx context.Context, providerSettings factory.ProviderSettings, config emailing.Config) (emailing.Emailing, error) {
+		return New(ctx, providerSettings, config, secretResolver)
+	})
 }
 
-func New(ctx context.Context, providerSettings factory.ProviderSettings, config emailing.Config) (emailing.Emailing, error) {
+func New(ctx context.Context, providerSettings factory.ProviderSettings, config emailing.Config, secretResolver *secretstore.Resolver) (emailing.Emailing, error) {
 	settings := factory.NewScopedProviderSettings(providerSettings, "github.com/SigNoz/signoz/pkg/emailing/smtpemailing")
 
+	// The password and the secret may reference secrets of the secret store.
+	password, err := secretResolver.Resolve(ctx, config.SMTP.Auth.Password)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := secretResolver.Resolve(ctx, config.SMTP.Auth.Secret)
+	if err != nil {
+		return nil, err
+	}
+
 	// Try to create a template store. If it fails, use an empty store.
 	store, err := filetemplatestore.NewStore(ctx, config.Templates.Directory, emailtypes.Templates, settings.Logger())
 	if err != nil {
@@ -39,23 +60,52 @@ func New(ctx context.Context, providerSettings factory.ProviderSettings, config
 		client.WithHeaders(config.SMTP.Headers),
 		client.WithTLS(client.TLS{
 			Enabled:            config.SMTP.TLS.Enabled,
+			Mode:               client.TLSMode(config.SMTP.TLS.Mode),
+			ServerName:         config.SMTP.TLS.ServerName,
 			InsecureSkipVerify: config.SMTP.TLS.InsecureSkipVerify,
 			CAFilePath:         config.SMTP.TLS.CAFilePath,
 			KeyFilePath:        config.SMTP.TLS.KeyFilePath,
 			CertFilePath:       config.SMTP.TLS.CertFilePath,
 		}),
 		client.WithAuth(client.Auth{
-			Username: config.SMTP.Auth.Username,
-			Password: config.SMTP.Auth.Password,
-			Secret:   config.SMTP.Auth.Secret,
-			Identity: config.SMTP.Auth.Identity,
+			Username:     config.SMTP.Auth.Username,
+			PasswordFunc: password.Get,
+			SecretFunc:   secret.Get,
+			Identity:     config.SMTP.Auth.Identity,
+		}),
+		client.WithTimeout(config.SMTP.Timeout),
+		client.WithPool(client.Pool{
+			MaxIdleConns: config.SMTP.Pool.MaxIdleConns,
+			IdleTimeout:  config.SMTP.Pool.IdleTimeout,
 		}),
 	)
 	if err != nil {
 		return nil, err
 	}
 
-	return &provider{settings: settings, store: store, client: client}, nil
+	retried, err := settings.Meter().Int64Counter("signoz.emailing.sends.retried", metric.WithDescription("Number of attempts made after a send failed with a retryable error."))
+	if err != nil {
+		return nil, err
+	}
+
+	failed, err := settings.Meter().Int64Counter("signoz.emailing.sends.failed", metric.WithDescription("Number of sends which failed permanently."))
+	if err != nil {
+		return nil, err
+	}
+
+	return &provider{settings: settings, config: config, store: store, client: client, retried: retried, failed: failed, stopC: make(chan struct{})}, nil
+}
+
+func (provider *provider) Start(ctx context.Context) error {
+	<-provider.stopC
+	return nil
+}
+
+// Stop closes the idle connections kept by the client for reuse.
+func (provider *provider) Stop(ctx context.Context) error {
+	close(provider.stopC)
+	provider.client.Close()
+	return nil
 }
 
 func (provider *provider) SendHTML(ctx context.Context, to string, subject string, templateName emailtypes.TemplateName, data map[string]any) error {
@@ -74,5 +124,33 @@ func (provider *provider) SendHTML(ctx context.Context, to string, subject strin
 		return err
 	}
 
-	return provider.client.Do(ctx, toAddress, subject, client.ContentTypeHTML, content)
+	return provider.retry(ctx, func(ctx context.Context) error {
+		return provider.client.Do(ctx, toAddress, subject, client.ContentTypeHTML, content)
+	})
+}
+
+func (provider *provider) SendTemplate(ctx context.Context, to string, templateName emailtypes.TemplateName, data any) error {
+	toAddress, err := mail.ParseAddressList(to)
+	if err != nil {
+		return err
+	}
+
+	template, err := provider.store.Get(ctx, templateName)
+	if err != nil {
+		return err
+	}
+
+	subject, err := emailtypes.NewSubject(template, data)
+	if err != nil {
+		return err
+	}
+
+	content, err := emailtypes.NewContent(template, data)
+	if err != nil {
+		return err
+	}
+
+	return provider.retry(ctx, func(ctx context.Context) error {
+		return provider.client.Do(ctx, toAddress, subject, client.ContentTypeHTML, content)
+	})
 }
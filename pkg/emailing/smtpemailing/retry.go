@@ -0,0 +1,73 @@
+package smtpemailing
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"net/textproto"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/emailing"
+)
+
+// isRetryable reports whether the send failed with a temporary (4xx) SMTP response, for example because of
+// greylisting. Every other failure, including permanent (5xx) responses, is not retried.
+func isRetryable(err error) bool {
+	var protoErr *textproto.Error
+	if !errors.As(err, &protoErr) {
+		return false
+	}
+
+	return protoErr.Code >= 400 && protoErr.Code < 500
+}
+
+// backoff returns the time to wait before the given attempt. The first attempt is numbered 1.
+func backoff(policy emailing.Retry, attempt int) time.Duration {
+	if attempt <= 1 || policy.Backoff <= 0 {
+		return 0
+	}
+
+	wait := policy.Backoff << (attempt - 2)
+	if policy.Jitter > 0 {
+		wait += time.Duration((rand.Float64()*2 - 1) * policy.Jitter * float64(wait)) //nolint:gosec
+	}
+
+	return wait
+}
+
+// retry calls do until it succeeds, fails with an error which is not retryable or the attempts are exhausted.
+// It returns early if the context is done or its deadline does not leave enough time for the next attempt.
+func (provider *provider) retry(ctx context.Context, do func(context.Context) error) error {
+	var err error
+	for attempt := 1; attempt <= provider.config.Retry.MaxAttempts; attempt++ {
+		if wait := backoff(provider.config.Retry, attempt); wait > 0 {
+			if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < wait {
+				break
+			}
+
+			timer := time.NewTimer(wait)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return errors.Join(err, ctx.Err())
+			case <-timer.C:
+			}
+
+			provider.retried.Add(ctx, 1)
+		}
+
+		err = do(ctx)
+		if err == nil {
+			return nil
+		}
+
+		if !isRetryable(err) {
+			break
+		}
+
+		provider.settings.Logger().WarnContext(ctx, "failed to send email with a retryable error", "attempt", attempt, "max_attempts", provider.config.Retry.MaxAttempts, "error", err)
+	}
+
+	provider.failed.Add(ctx, 1)
+	return err
+}
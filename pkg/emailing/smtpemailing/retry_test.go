@@ -0,0 +1,97 @@
+package smtpemailing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/textproto"
+	"testing"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/emailing"
+	"github.com/SigNoz/signoz/pkg/factory"
+	"github.com/SigNoz/signoz/pkg/factory/factorytest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/metric/noop"
+)
+
+func newTestProvider(t *testing.T, retry emailing.Retry) *provider {
+	settings := factory.NewScopedProviderSettings(factorytest.NewSettings(), "github.com/SigNoz/signoz/pkg/emailing/smtpemailing")
+	retried, err := noop.NewMeterProvider().Meter("test").Int64Counter("retried")
+	require.NoError(t, err)
+	failed, err := noop.NewMeterProvider().Meter("test").Int64Counter("failed")
+	require.NoError(t, err)
+
+	return &provider{settings: settings, config: emailing.Config{Retry: retry}, retried: retried, failed: failed}
+}
+
+func TestRetry(t *testing.T) {
+	temporary := fmt.Errorf("failed to send RCPT command: %w", &textproto.Error{Code: 451, Msg: "greylisted"})
+	permanent := fmt.Errorf("failed to send RCPT command: %w", &textproto.Error{Code: 550, Msg: "no such user"})
+
+	testCases := []struct {
+		name     string
+		errs     []error
+		attempts int
+		err      error
+	}{
+		{name: "Success", errs: []error{nil}, attempts: 1},
+		{name: "TemporaryThenSuccess", errs: []error{temporary, temporary, nil}, attempts: 3},
+		{name: "TemporaryExhausted", errs: []error{temporary, temporary, temporary}, attempts: 3, err: temporary},
+		{name: "Permanent", errs: []error{permanent}, attempts: 1, err: permanent},
+		{name: "Other", errs: []error{errors.New("dial tcp: connection refused")}, attempts: 1, err: errors.New("dial tcp: connection refused")},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			provider := newTestProvider(t, emailing.Retry{MaxAttempts: 3, Backoff: time.Millisecond, Jitter: 0.2})
+
+			attempts := 0
+			err := provider.retry(context.Background(), func(context.Context) error {
+				err := tc.errs[attempts]
+				attempts++
+				return err
+			})
+
+			assert.Equal(t, tc.attempts, attempts)
+			if tc.err == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tc.err.Error())
+		})
+	}
+}
+
+func TestRetryRespectsDeadline(t *testing.T) {
+	provider := newTestProvider(t, emailing.Retry{MaxAttempts: 3, Backoff: time.Minute})
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	attempts := 0
+	err := provider.retry(ctx, func(context.Context) error {
+		attempts++
+		return &textproto.Error{Code: 421, Msg: "try again later"}
+	})
+
+	require.Error(t, err)
+	assert.Equal(t, 1, attempts)
+}
+
+func TestBackoff(t *testing.T) {
+	policy := emailing.Retry{MaxAttempts: 4, Backoff: time.Second}
+
+	assert.Equal(t, time.Duration(0), backoff(policy, 1))
+	assert.Equal(t, time.Second, backoff(policy, 2))
+	assert.Equal(t, 2*time.Second, backoff(policy, 3))
+	assert.Equal(t, 4*time.Second, backoff(policy, 4))
+
+	policy.Jitter = 0.5
+	for i := 0; i < 100; i++ {
+		wait := backoff(policy, 3)
+		assert.GreaterOrEqual(t, wait, time.Second)
+		assert.LessOrEqual(t, wait, 3*time.Second)
+	}
+}
@@ -28,6 +28,13 @@ func NewStore(ctx context.Context, baseDir string, templates []emailtypes.Templa
 		return nil, err
 	}
 
+	// The layout is optional. If it is present, every template is parsed into a clone of it.
+	layout, err := parseLayoutFile(filepath.Join(baseDir, emailtypes.LayoutTemplateName+emailTemplateExt))
+	if err != nil {
+		logger.ErrorContext(ctx, "failed to parse layout file, templates will be parsed without it", "error", err)
+		layout = nil
+	}
+
 	foundTemplates := make(map[emailtypes.TemplateName]bool)
 	for _, fi := range fis {
 		if fi.IsDir() || filepath.Ext(fi.Name()) != emailTemplateExt {
@@ -43,7 +50,7 @@ func NewStore(ctx context.Context, baseDir string, templates []emailtypes.Templa
 			continue
 		}
 
-		t, err := parseTemplateFile(filepath.Join(baseDir, fi.Name()), templateName)
+		t, err := parseTemplateFile(filepath.Join(baseDir, fi.Name()), templateName, layout)
 		if err != nil {
 			logger.ErrorContext(ctx, "failed to parse template file", "template", templateName, "path", filepath.Join(baseDir, fi.Name()), "error", err)
 			continue
@@ -67,7 +74,7 @@ func NewEmptyStore() emailtypes.TemplateStore {
 func (repository *store) Get(ctx context.Context, name emailtypes.TemplateName) (*template.Template, error) {
 	template, ok := repository.fs[name]
 	if !ok {
-		return nil, errors.Newf(errors.TypeNotFound, errors.CodeNotFound, "cannot find template with name %q", name.StringValue())
+		return nil, errors.Newf(errors.TypeNotFound, emailtypes.ErrCodeEmailTemplateNotFound, "cannot find template with name %q", name.StringValue())
 	}
 
 	return template.Clone()
@@ -78,13 +85,35 @@ func parseTemplateName(fileName string) (emailtypes.TemplateName, error) {
 	return emailtypes.NewTemplateName(name)
 }
 
-func parseTemplateFile(filePath string, templateName emailtypes.TemplateName) (*template.Template, error) {
+func parseLayoutFile(filePath string) (*template.Template, error) {
+	contents, err := os.ReadFile(filepath.Clean(filePath))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return template.New(emailtypes.LayoutTemplateName).Parse(string(contents))
+}
+
+func parseTemplateFile(filePath string, templateName emailtypes.TemplateName, layout *template.Template) (*template.Template, error) {
 	contents, err := os.ReadFile(filepath.Clean(filePath))
 	if err != nil {
 		return nil, err
 	}
 
-	return template.New(templateName.StringValue()).Parse(string(contents))
+	if layout == nil {
+		return template.New(templateName.StringValue()).Parse(string(contents))
+	}
+
+	base, err := layout.Clone()
+	if err != nil {
+		return nil, err
+	}
+
+	return base.New(templateName.StringValue()).Parse(string(contents))
 }
 
 func checkMissingTemplates(supportedTemplates []emailtypes.TemplateName, foundTemplates map[emailtypes.TemplateName]bool) error {
@@ -0,0 +1,85 @@
+package filetemplatestore
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/types/emailtypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStoreWithLayout(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "layout.gotmpl"), []byte(`<div style="color: {{.Branding.PrimaryColor}}">{{template "content" .}}</div>`), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "invitation_email.gotmpl"), []byte(`{{define "subject"}}Hello {{.Name}} & welcome{{end}}{{define "content"}}<p>{{.Name}}</p>{{end}}{{template "layout" .}}`), 0600))
+
+	store, err := NewStore(context.Background(), dir, emailtypes.Templates, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	require.NoError(t, err)
+
+	template, err := store.Get(context.Background(), emailtypes.TemplateNameInvitationEmail)
+	require.NoError(t, err)
+
+	data := struct {
+		Name     string
+		Branding emailtypes.Branding
+	}{Name: "signoz", Branding: emailtypes.Branding{PrimaryColor: "#ff0000"}}
+
+	subject, err := emailtypes.NewSubject(template, data)
+	require.NoError(t, err)
+	assert.Equal(t, "Hello signoz & welcome", subject)
+
+	content, err := emailtypes.NewContent(template, data)
+	require.NoError(t, err)
+	assert.Equal(t, `<div style="color: #ff0000"><p>signoz</p></div>`, string(content))
+}
+
+func TestStoreWithoutTemplate(t *testing.T) {
+	store := NewEmptyStore()
+
+	_, err := store.Get(context.Background(), emailtypes.TemplateNameInvitationEmail)
+	require.Error(t, err)
+	assert.True(t, errors.Asc(err, emailtypes.ErrCodeEmailTemplateNotFound))
+}
+
+func TestStoreWithRepositoryTemplates(t *testing.T) {
+	store, err := NewStore(context.Background(), filepath.Join("..", "..", "..", "..", "templates", "email"), emailtypes.Templates, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	require.NoError(t, err)
+
+	for _, templateName := range emailtypes.Templates {
+		template, err := store.Get(context.Background(), templateName)
+		require.NoError(t, err)
+
+		_, err = emailtypes.NewSubject(template, map[string]any{})
+		require.NoError(t, err)
+
+		content, err := emailtypes.NewContent(template, map[string]any{"Branding": emailtypes.Branding{LogoURL: "https://example.com/logo.png", PrimaryColor: "#ff0000"}})
+		require.NoError(t, err)
+		assert.Contains(t, string(content), "https://example.com/logo.png")
+		assert.Contains(t, string(content), "background-color: #ff0000")
+	}
+}
+
+func TestContentWithMissingField(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "invitation_email.gotmpl"), []byte(`<p>{{.Name}}</p>`), 0600))
+
+	store, err := NewStore(context.Background(), dir, emailtypes.Templates, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	require.NoError(t, err)
+
+	template, err := store.Get(context.Background(), emailtypes.TemplateNameInvitationEmail)
+	require.NoError(t, err)
+
+	_, err = emailtypes.NewContent(template, struct{}{})
+	require.Error(t, err)
+	assert.True(t, errors.Asc(err, emailtypes.ErrCodeEmailTemplateRenderFailed))
+
+	_, err = emailtypes.NewSubject(template, struct{}{})
+	require.Error(t, err)
+	assert.True(t, errors.Asc(err, emailtypes.ErrCodeEmailTemplateNotFound))
+}
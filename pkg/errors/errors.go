@@ -46,6 +46,11 @@ func (b *base) Error() string {
 	return fmt.Sprintf("%s(%s): %s", b.t.s, b.c, b.m)
 }
 
+// Unwrap returns the error wrapped by base, if any.
+func (b *base) Unwrap() error {
+	return b.e
+}
+
 // New returns a base error. It requires type, code and message as input.
 func New(t typ, code Code, message string) *base {
 	return &base{
@@ -146,6 +151,11 @@ func Is(err error, target error) bool {
 	return errors.Is(err, target)
 }
 
+// Unwrap is a wrapper around errors.Unwrap.
+func Unwrap(err error) error {
+	return errors.Unwrap(err)
+}
+
 func WrapNotFoundf(cause error, code Code, format string, args ...interface{}) *base {
 	return Wrapf(cause, TypeNotFound, code, format, args...)
 }
@@ -51,3 +51,13 @@ func TestUnwrapb(t *testing.T) {
 	atyp, _, _, _, _, _ = Unwrapb(oerr)
 	assert.Equal(t, TypeInternal, atyp)
 }
+
+func TestUnwrap(t *testing.T) {
+	typ := typ{"test-error"}
+	oerr := errors.New("original error")
+	berr := Wrapf(oerr, typ, MustNewCode("test_code"), "this is a base err")
+
+	assert.Equal(t, oerr, Unwrap(berr))
+	assert.True(t, Is(Wrapf(berr, typ, MustNewCode("test_code"), "this is another base err"), oerr))
+	assert.Nil(t, Unwrap(New(typ, MustNewCode("test_code"), "this is a base err")))
+}
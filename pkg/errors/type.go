@@ -11,6 +11,8 @@ var (
 	TypeForbidden            = typ{"forbidden"}
 	TypeCanceled             = typ{"canceled"}
 	TypeTimeout              = typ{"timeout"}
+	TypeTooManyRequests      = typ{"too-many-requests"}
+	TypeUnavailable          = typ{"unavailable"}
 )
 
 // Defines custom error types
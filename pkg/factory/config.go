@@ -1,5 +1,7 @@
 package factory
 
+import "context"
+
 // Config is an interface that defines methods for creating and validating configurations.
 type Config interface {
 	// Validate the configuration and returns an error if invalid.
@@ -35,3 +37,12 @@ func (factory *configFactory) New() Config {
 func NewConfigFactory(name Name, f NewConfigFunc) ConfigFactory {
 	return &configFactory{name: name, newConfigFunc: f}
 }
+
+// EffectiveConfig is an optional interface which can be implemented by providers whose config can differ from the
+// config they were created with, such as the ones whose config is changed at runtime or could not be applied.
+type EffectiveConfig interface {
+	// EffectiveConfig returns the values of the config the provider runs with keyed by their path relative to the
+	// config of the provider, for example sqlite::path. The values which are not returned are the ones of the config
+	// the provider was created with. It returns an error when the config could not be applied.
+	EffectiveConfig(context.Context) (map[string]any, error)
+}
@@ -0,0 +1,36 @@
+package factory
+
+import (
+	"context"
+	"errors"
+)
+
+var (
+	// ErrHealthUnknown is returned by the providers which cannot tell their health, such as the ones wrapping a
+	// provider which does not report it. Their health is reported as unknown rather than as healthy.
+	ErrHealthUnknown = errors.New("health is unknown")
+)
+
+// Healthy is an optional interface which can be implemented by providers to report their health.
+type Healthy interface {
+	// Healthy returns an error if the provider is not healthy.
+	Healthy(context.Context) error
+}
+
+// HealthOf returns the health of the provider, ErrHealthUnknown if it does not report its health.
+func HealthOf(ctx context.Context, provider any) error {
+	healthy, ok := provider.(Healthy)
+	if !ok {
+		return ErrHealthUnknown
+	}
+
+	return healthy.Healthy(ctx)
+}
+
+// Ready is an optional interface which can be implemented by providers and services to report whether they are
+// ready to serve requests. A service which does work before serving, such as syncing its state, is not ready
+// until that work is done.
+type Ready interface {
+	// Ready returns an error if the provider is not ready.
+	Ready(context.Context) error
+}
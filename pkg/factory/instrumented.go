@@ -0,0 +1,69 @@
+package factory
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// Calls records the duration and the outcome of the calls of the methods of a provider.
+type Calls struct {
+	duration metric.Float64Histogram
+	provider attribute.KeyValue
+}
+
+// Record records the call of the method which started at start and failed if err is not nil. It is meant to be
+// deferred at the start of the method:
+//
+//	defer calls.Record(ctx, "Get", time.Now(), &err)
+func (calls *Calls) Record(ctx context.Context, method string, start time.Time, err *error) {
+	failed := err != nil && *err != nil
+	calls.duration.Record(ctx, time.Since(start).Seconds(), metric.WithAttributes(
+		calls.provider,
+		attribute.String("provider.method", method),
+		attribute.Bool("error", failed),
+	))
+}
+
+// InstrumentFunc wraps the provider so that its methods record their calls. Go cannot implement an interface at
+// runtime, hence every provider interface opting in comes with its own wrapper. Only the providers whose calls go
+// through their interface opt in, such as the cache. The sqlstore and the telemetrystore hand out their underlying
+// clients, whose queries are recorded by their hooks instead.
+type InstrumentFunc[P Provider] func(P, *Calls) P
+
+type instrumentedProviderFactory[P Provider, C Config] struct {
+	factory    ProviderFactory[P, C]
+	instrument InstrumentFunc[P]
+}
+
+// NewInstrumentedProviderFactory returns a factory wrapping the providers of the given factory with instrument so
+// that the duration and the errors of the calls of their methods are recorded in the
+// signoz.provider.call.duration histogram.
+func NewInstrumentedProviderFactory[P Provider, C Config](factory ProviderFactory[P, C], instrument InstrumentFunc[P]) ProviderFactory[P, C] {
+	return &instrumentedProviderFactory[P, C]{factory: factory, instrument: instrument}
+}
+
+func (factory *instrumentedProviderFactory[P, C]) Name() Name {
+	return factory.factory.Name()
+}
+
+func (factory *instrumentedProviderFactory[P, C]) New(ctx context.Context, settings ProviderSettings, config C) (p P, err error) {
+	provider, err := factory.factory.New(ctx, settings, config)
+	if err != nil {
+		return
+	}
+
+	duration, err := settings.MeterProvider.Meter("github.com/SigNoz/signoz/pkg/factory").Float64Histogram(
+		"signoz.provider.call.duration",
+		metric.WithDescription("Duration of the calls of the methods of the providers, by provider, method and outcome."),
+		metric.WithUnit("s"),
+	)
+	if err != nil {
+		return
+	}
+
+	p = factory.instrument(provider, &Calls{duration: duration, provider: attribute.String("provider.name", factory.Name().String())})
+	return
+}
@@ -0,0 +1,75 @@
+package factory
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	metricsdk "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+type greeter interface {
+	Greet(ctx context.Context, name string) (string, error)
+}
+
+type p2 struct{}
+
+func (p2) Greet(_ context.Context, name string) (string, error) {
+	if name == "" {
+		return "", errors.New("name must not be empty")
+	}
+
+	return "hello " + name, nil
+}
+
+type instrumentedGreeter struct {
+	greeter greeter
+	calls   *Calls
+}
+
+func (g *instrumentedGreeter) Greet(ctx context.Context, name string) (greeting string, err error) {
+	defer g.calls.Record(ctx, "Greet", time.Now(), &err)
+	return g.greeter.Greet(ctx, name)
+}
+
+func TestNewInstrumentedProviderFactory(t *testing.T) {
+	reader := metricsdk.NewManualReader()
+	settings := ProviderSettings{MeterProvider: metricsdk.NewMeterProvider(metricsdk.WithReader(reader))}
+
+	pf := NewInstrumentedProviderFactory(
+		NewProviderFactory(MustNewName("p2"), func(context.Context, ProviderSettings, pc1) (greeter, error) { return p2{}, nil }),
+		func(g greeter, calls *Calls) greeter { return &instrumentedGreeter{greeter: g, calls: calls} },
+	)
+	assert.Equal(t, MustNewName("p2"), pf.Name())
+
+	g, err := pf.New(context.Background(), settings, pc1{})
+	require.NoError(t, err)
+
+	_, err = g.Greet(context.Background(), "world")
+	require.NoError(t, err)
+	_, err = g.Greet(context.Background(), "")
+	require.Error(t, err)
+
+	resourceMetrics := metricdata.ResourceMetrics{}
+	require.NoError(t, reader.Collect(context.Background(), &resourceMetrics))
+	require.Len(t, resourceMetrics.ScopeMetrics, 1)
+	require.Len(t, resourceMetrics.ScopeMetrics[0].Metrics, 1)
+	assert.Equal(t, "signoz.provider.call.duration", resourceMetrics.ScopeMetrics[0].Metrics[0].Name)
+
+	histogram := resourceMetrics.ScopeMetrics[0].Metrics[0].Data.(metricdata.Histogram[float64])
+	require.Len(t, histogram.DataPoints, 2)
+	for _, dataPoint := range histogram.DataPoints {
+		assert.Equal(t, uint64(1), dataPoint.Count)
+
+		name, _ := dataPoint.Attributes.Value("provider.name")
+		method, _ := dataPoint.Attributes.Value("provider.method")
+		assert.Equal(t, "p2", name.AsString())
+		assert.Equal(t, "Greet", method.AsString())
+		assert.True(t, dataPoint.Attributes.HasValue(attribute.Key("error")))
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"log/slog"
 	"os"
 	"os/signal"
+	"sync"
 	"syscall"
 )
 
@@ -14,7 +15,12 @@ type Registry struct {
 	services NamedMap[NamedService]
 	logger   *slog.Logger
 	startCh  chan error
-	stopCh   chan error
+	// started holds the services in the order in which they were started.
+	started   []NamedService
+	startedMu sync.Mutex
+	// failed holds the errors of the services which returned from Start with an error.
+	failed   map[string]error
+	failedMu sync.Mutex
 }
 
 // New creates a new registry of services. It needs at least one service in the input.
@@ -36,19 +42,28 @@ func NewRegistry(logger *slog.Logger, services ...NamedService) (*Registry, erro
 		logger:   logger.With("pkg", "go.signoz.io/pkg/factory"),
 		services: m,
 		startCh:  make(chan error, 1),
-		stopCh:   make(chan error, len(services)),
+		started:  make([]NamedService, 0, len(services)),
+		failed:   make(map[string]error),
 	}, nil
 }
 
 func (r *Registry) Start(ctx context.Context) {
+	r.startedMu.Lock()
+	defer r.startedMu.Unlock()
+
 	for _, s := range r.services.GetInOrder() {
+		r.started = append(r.started, s)
 		go func(s NamedService) {
 			r.logger.InfoContext(ctx, "starting service", "service", s.Name())
 			err := s.Start(ctx)
+			if err != nil {
+				r.failedMu.Lock()
+				r.failed[s.Name().String()] = err
+				r.failedMu.Unlock()
+			}
 			r.startCh <- err
 		}(s)
 	}
-
 }
 
 func (r *Registry) Wait(ctx context.Context) error {
@@ -68,22 +83,86 @@ func (r *Registry) Wait(ctx context.Context) error {
 	return nil
 }
 
-func (r *Registry) Stop(ctx context.Context) error {
-	for _, s := range r.services.GetInOrder() {
-		go func(s NamedService) {
-			r.logger.InfoContext(ctx, "stopping service", "service", s.Name())
-			err := s.Stop(ctx)
-			r.stopCh <- err
-		}(s)
+// Ready returns an error until the services have been started and while any of them is not ready. The registry is
+// not ready anymore once a service has failed or once the services have been stopped.
+func (r *Registry) Ready(ctx context.Context) error {
+	r.startedMu.Lock()
+	started := make([]NamedService, len(r.started))
+	copy(started, r.started)
+	r.startedMu.Unlock()
+
+	if len(started) == 0 {
+		return fmt.Errorf("services have not been started")
 	}
 
-	errs := make([]error, len(r.services.GetInOrder()))
-	for i := 0; i < len(r.services.GetInOrder()); i++ {
-		err := <-r.stopCh
-		if err != nil {
+	for _, s := range started {
+		r.failedMu.Lock()
+		err, failed := r.failed[s.Name().String()]
+		r.failedMu.Unlock()
+		if failed {
+			return fmt.Errorf("service %q has failed: %w", s.Name(), err)
+		}
+
+		if ready, ok := s.(Ready); ok {
+			if err := ready.Ready(ctx); err != nil {
+				return fmt.Errorf("service %q is not ready: %w", s.Name(), err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Stop stops the started services in the reverse order in which they were started (LIFO).
+// Every service is given a chance to stop, even if a previous one fails. The errors of all the
+// failed services are joined and returned.
+func (r *Registry) Stop(ctx context.Context) error {
+	// The services are stopped without holding the lock, so that Ready reports the registry as not ready rather than
+	// blocking until all of them have stopped.
+	r.startedMu.Lock()
+	started := r.started
+	r.started = make([]NamedService, 0, len(started))
+	r.startedMu.Unlock()
+
+	var errs []error
+	for i := len(started) - 1; i >= 0; i-- {
+		if err := r.stop(ctx, started[i]); err != nil {
 			errs = append(errs, err)
 		}
 	}
 
 	return errors.Join(errs...)
 }
+
+func (r *Registry) stop(ctx context.Context, s NamedService) error {
+	r.logger.InfoContext(ctx, "stopping service", "service", s.Name())
+
+	timeout := s.StopTimeout()
+	if timeout <= 0 {
+		if err := s.Stop(ctx); err != nil {
+			r.logger.ErrorContext(ctx, "failed to stop service", "service", s.Name(), "error", err)
+			return fmt.Errorf("failed to stop service %q: %w", s.Name(), err)
+		}
+		return nil
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	stopCh := make(chan error, 1)
+	go func() {
+		stopCh <- s.Stop(timeoutCtx)
+	}()
+
+	select {
+	case err := <-stopCh:
+		if err != nil {
+			r.logger.ErrorContext(ctx, "failed to stop service", "service", s.Name(), "error", err)
+			return fmt.Errorf("failed to stop service %q: %w", s.Name(), err)
+		}
+		return nil
+	case <-timeoutCtx.Done():
+		r.logger.ErrorContext(ctx, "timed out while stopping service", "service", s.Name(), "timeout", timeout)
+		return fmt.Errorf("failed to stop service %q within %s: %w", s.Name(), timeout, timeoutCtx.Err())
+	}
+}
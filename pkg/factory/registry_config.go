@@ -0,0 +1,50 @@
+package factory
+
+import (
+	"fmt"
+	"time"
+)
+
+// RegistryConfig is the configuration of the registry of the services.
+type RegistryConfig struct {
+	// StopTimeout is the maximum time the registry waits for every service to stop.
+	StopTimeout time.Duration `mapstructure:"stop_timeout"`
+
+	// StopTimeouts are the maximum times the registry waits for the services to stop by service name, in place of
+	// StopTimeout.
+	StopTimeouts map[string]time.Duration `mapstructure:"stop_timeouts"`
+}
+
+func NewRegistryConfigFactory() ConfigFactory {
+	return NewConfigFactory(MustNewName("registry"), newRegistryConfig)
+}
+
+func newRegistryConfig() Config {
+	return RegistryConfig{
+		StopTimeout:  30 * time.Second,
+		StopTimeouts: map[string]time.Duration{},
+	}
+}
+
+func (c RegistryConfig) Validate() error {
+	if c.StopTimeout <= 0 {
+		return fmt.Errorf("stop_timeout must be positive, got %v", c.StopTimeout)
+	}
+
+	for name, timeout := range c.StopTimeouts {
+		if timeout <= 0 {
+			return fmt.Errorf("stop_timeouts::%s must be positive, got %v", name, timeout)
+		}
+	}
+
+	return nil
+}
+
+// StopTimeoutOf returns the maximum time the registry waits for the service of the name to stop.
+func (c RegistryConfig) StopTimeoutOf(name Name) time.Duration {
+	if timeout, ok := c.StopTimeouts[name.String()]; ok {
+		return timeout
+	}
+
+	return c.StopTimeout
+}
@@ -2,11 +2,14 @@ package factory
 
 import (
 	"context"
+	"errors"
 	"io"
 	"log/slog"
 	"sync"
 	"testing"
+	"time"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -68,3 +71,175 @@ func TestRegistryWith2ServicesWithoutWait(t *testing.T) {
 
 	wg.Wait()
 }
+
+type torderedservice struct {
+	name    string
+	c       chan struct{}
+	stopped *[]string
+	mu      *sync.Mutex
+	err     error
+	block   bool
+}
+
+func (s *torderedservice) Start(_ context.Context) error {
+	<-s.c
+	return nil
+}
+
+func (s *torderedservice) Stop(ctx context.Context) error {
+	if s.block {
+		<-ctx.Done()
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	*s.stopped = append(*s.stopped, s.name)
+	close(s.c)
+	return s.err
+}
+
+func TestRegistryStopInReverseOrder(t *testing.T) {
+	stopped := []string{}
+	mu := &sync.Mutex{}
+
+	services := []NamedService{}
+	for _, name := range []string{"s1", "s2", "s3"} {
+		services = append(services, NewNamedService(MustNewName(name), &torderedservice{name: name, c: make(chan struct{}), stopped: &stopped, mu: mu}))
+	}
+
+	registry, err := NewRegistry(slog.New(slog.NewTextHandler(io.Discard, nil)), services...)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	registry.Start(ctx)
+	require.NoError(t, registry.Stop(ctx))
+	assert.Equal(t, []string{"s3", "s2", "s1"}, stopped)
+}
+
+func TestRegistryStopJoinsErrors(t *testing.T) {
+	stopped := []string{}
+	mu := &sync.Mutex{}
+
+	err1 := errors.New("s1 failed")
+	err3 := errors.New("s3 failed")
+
+	registry, err := NewRegistry(
+		slog.New(slog.NewTextHandler(io.Discard, nil)),
+		NewNamedService(MustNewName("s1"), &torderedservice{name: "s1", c: make(chan struct{}), stopped: &stopped, mu: mu, err: err1}),
+		NewNamedService(MustNewName("s2"), &torderedservice{name: "s2", c: make(chan struct{}), stopped: &stopped, mu: mu}),
+		NewNamedService(MustNewName("s3"), &torderedservice{name: "s3", c: make(chan struct{}), stopped: &stopped, mu: mu, err: err3}),
+	)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	registry.Start(ctx)
+	err = registry.Stop(ctx)
+	assert.ErrorIs(t, err, err1)
+	assert.ErrorIs(t, err, err3)
+	assert.Equal(t, []string{"s3", "s2", "s1"}, stopped)
+}
+
+func TestRegistryStopWithTimeout(t *testing.T) {
+	stopped := []string{}
+	mu := &sync.Mutex{}
+
+	registry, err := NewRegistry(
+		slog.New(slog.NewTextHandler(io.Discard, nil)),
+		NewNamedService(MustNewName("s1"), &torderedservice{name: "s1", c: make(chan struct{}), stopped: &stopped, mu: mu}),
+		NewNamedService(MustNewName("s2"), &torderedservice{name: "s2", c: make(chan struct{}), stopped: &stopped, mu: mu, block: true}, WithStopTimeout(10*time.Millisecond)),
+	)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	registry.Start(ctx)
+	err = registry.Stop(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, []string{"s1"}, stopped)
+}
+
+type treadyservice struct {
+	*tservice
+	err error
+}
+
+func (s *treadyservice) Ready(_ context.Context) error {
+	return s.err
+}
+
+func TestRegistryReady(t *testing.T) {
+	s1 := newTestService(t)
+	s2 := &treadyservice{tservice: newTestService(t), err: errors.New("syncing")}
+
+	registry, err := NewRegistry(slog.New(slog.NewTextHandler(io.Discard, nil)), NewNamedService(MustNewName("s1"), s1), NewNamedService(MustNewName("s2"), s2))
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	assert.Error(t, registry.Ready(ctx))
+
+	registry.Start(ctx)
+	assert.ErrorContains(t, registry.Ready(ctx), "service \"s2\" is not ready")
+
+	s2.err = nil
+	assert.NoError(t, registry.Ready(ctx))
+
+	require.NoError(t, registry.Stop(ctx))
+	assert.Error(t, registry.Ready(ctx))
+}
+
+type tblockingservice struct {
+	*tservice
+	stopping chan struct{}
+	release  chan struct{}
+}
+
+func (s *tblockingservice) Stop(ctx context.Context) error {
+	close(s.stopping)
+	<-s.release
+	return s.tservice.Stop(ctx)
+}
+
+func TestRegistryReadyWhileStopping(t *testing.T) {
+	s1 := &tblockingservice{tservice: newTestService(t), stopping: make(chan struct{}), release: make(chan struct{})}
+
+	registry, err := NewRegistry(slog.New(slog.NewTextHandler(io.Discard, nil)), NewNamedService(MustNewName("s1"), s1))
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	registry.Start(ctx)
+	require.NoError(t, registry.Ready(ctx))
+
+	stopped := make(chan error, 1)
+	go func() {
+		stopped <- registry.Stop(ctx)
+	}()
+	<-s1.stopping
+
+	// The registry is not ready while its services are stopping, and says so without waiting for them.
+	ready := make(chan error, 1)
+	go func() {
+		ready <- registry.Ready(ctx)
+	}()
+
+	select {
+	case err := <-ready:
+		assert.Error(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("ready blocked while the services were stopping")
+	}
+
+	close(s1.release)
+	require.NoError(t, <-stopped)
+}
+
+func TestRegistryConfigStopTimeoutOf(t *testing.T) {
+	config := newRegistryConfig().(RegistryConfig)
+	config.StopTimeouts["alertmanager"] = time.Minute
+	require.NoError(t, config.Validate())
+
+	assert.Equal(t, time.Minute, config.StopTimeoutOf(MustNewName("alertmanager")))
+	assert.Equal(t, 30*time.Second, config.StopTimeoutOf(MustNewName("sqlstore")))
+
+	config.StopTimeout = 0
+	assert.Error(t, config.Validate())
+}
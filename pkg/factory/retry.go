@@ -0,0 +1,78 @@
+package factory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+// ConnectRetryConfig is the retry policy of the initial connection of a provider to its backend, which may still be
+// starting when the provider is created.
+type ConnectRetryConfig struct {
+	// MaxDuration is the maximum time spent retrying the initial connection before giving up. 0 disables the retries,
+	// the connections are then only opened on demand.
+	MaxDuration time.Duration `mapstructure:"max_duration"`
+
+	// InitialBackoff is the time waited before the first retry. It is doubled after every attempt.
+	InitialBackoff time.Duration `mapstructure:"initial_backoff"`
+
+	// MaxBackoff is the maximum time waited between two attempts.
+	MaxBackoff time.Duration `mapstructure:"max_backoff"`
+}
+
+func (c ConnectRetryConfig) Validate() error {
+	if c.MaxDuration < 0 {
+		return fmt.Errorf("max_duration must not be negative, got %v", c.MaxDuration)
+	}
+
+	if c.MaxDuration == 0 {
+		return nil
+	}
+
+	if c.InitialBackoff <= 0 {
+		return fmt.Errorf("initial_backoff must be positive, got %v", c.InitialBackoff)
+	}
+
+	if c.MaxBackoff < c.InitialBackoff {
+		return fmt.Errorf("max_backoff must not be less than initial_backoff (%v), got %v", c.InitialBackoff, c.MaxBackoff)
+	}
+
+	return nil
+}
+
+// RetryConnect calls connect until it succeeds, backing off exponentially between the attempts, and gives up once
+// the max duration of the policy is over. Every failed attempt is logged along with the name of the backend.
+func RetryConnect(ctx context.Context, logger *slog.Logger, config ConnectRetryConfig, name string, connect func(context.Context) error) error {
+	if config.MaxDuration <= 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, config.MaxDuration)
+	defer cancel()
+
+	backoff := config.InitialBackoff
+	for attempt := 1; ; attempt++ {
+		err := connect(ctx)
+		if err == nil {
+			if attempt > 1 {
+				logger.InfoContext(ctx, "connected after retrying", "backend", name, "attempts", attempt)
+			}
+
+			return nil
+		}
+
+		logger.WarnContext(ctx, "failed to connect, retrying", "backend", name, "attempt", attempt, "backoff", backoff, "error", err)
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return fmt.Errorf("cannot connect to %s after %d attempts in %v: %w", name, attempt, config.MaxDuration, errors.Join(err, ctx.Err()))
+		case <-timer.C:
+		}
+
+		backoff = min(backoff*2, config.MaxBackoff)
+	}
+}
@@ -0,0 +1,49 @@
+package factory
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetryConnect(t *testing.T) {
+	config := ConnectRetryConfig{MaxDuration: time.Second, InitialBackoff: time.Millisecond, MaxBackoff: 4 * time.Millisecond}
+
+	attempts := 0
+	err := RetryConnect(context.Background(), slog.New(slog.NewTextHandler(io.Discard, nil)), config, "test", func(context.Context) error {
+		attempts++
+		if attempts < 4 {
+			return errors.New("connection refused")
+		}
+
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 4, attempts)
+
+	config.MaxDuration = 20 * time.Millisecond
+	err = RetryConnect(context.Background(), slog.New(slog.NewTextHandler(io.Discard, nil)), config, "test", func(context.Context) error {
+		return errors.New("connection refused")
+	})
+	assert.ErrorContains(t, err, "connection refused")
+
+	// The retries are disabled by default, the connection is not even attempted.
+	err = RetryConnect(context.Background(), slog.New(slog.NewTextHandler(io.Discard, nil)), ConnectRetryConfig{}, "test", func(context.Context) error {
+		return errors.New("connection refused")
+	})
+	assert.NoError(t, err)
+}
+
+func TestConnectRetryConfigValidate(t *testing.T) {
+	assert.NoError(t, ConnectRetryConfig{}.Validate())
+	assert.NoError(t, ConnectRetryConfig{MaxDuration: time.Minute, InitialBackoff: time.Second, MaxBackoff: time.Second}.Validate())
+	assert.Error(t, ConnectRetryConfig{MaxDuration: -time.Minute}.Validate())
+	assert.Error(t, ConnectRetryConfig{MaxDuration: time.Minute}.Validate())
+	assert.Error(t, ConnectRetryConfig{MaxDuration: time.Minute, InitialBackoff: time.Second, MaxBackoff: time.Millisecond}.Validate())
+}
@@ -0,0 +1,280 @@
+package factory
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// Locker holds the locks and records the last runs making the singleton jobs run once per interval across the
+// replicas.
+type Locker interface {
+	// TryLock tries to acquire the lock of the given key without waiting. The lock is held until unlock is called.
+	TryLock(ctx context.Context, key string) (unlock func(context.Context) error, acquired bool, err error)
+	// LastRun returns the time of the last successful run of the given key, the zero time when it never ran.
+	LastRun(ctx context.Context, key string) (time.Time, error)
+	// RecordRun records a successful run of the given key at the given time.
+	RecordRun(ctx context.Context, key string, at time.Time) error
+}
+
+// Job is a named periodic background job.
+type Job interface {
+	Named
+	// Interval returns the time between two runs of the job.
+	Interval() time.Duration
+	// Jitter returns the maximum random delay added to the interval, which spreads the runs of the replicas.
+	Jitter() time.Duration
+	// Singleton returns whether the job runs once per interval across the replicas, on a single replica at a time.
+	Singleton() bool
+	// RunAtStart returns whether the job also runs at the start of the scheduler.
+	RunAtStart() bool
+	// Run runs the job once.
+	Run(context.Context) error
+}
+
+type JobOption func(*job)
+
+// WithJitter adds a random delay of at most jitter to the interval of the job.
+func WithJitter(jitter time.Duration) JobOption {
+	return func(j *job) {
+		j.jitter = jitter
+	}
+}
+
+// WithSingleton makes the job run once per interval across the replicas, on a single replica at a time. The replicas
+// failing to acquire the lock of the job, or finding that the job ran less than an interval ago, skip their run.
+func WithSingleton() JobOption {
+	return func(j *job) {
+		j.singleton = true
+	}
+}
+
+// WithRunAtStart makes the job also run at the start of the scheduler, such as to apply a config which may have
+// changed since the last run. The run at the start of a singleton job still takes its lock, whatever its last run.
+func WithRunAtStart() JobOption {
+	return func(j *job) {
+		j.runAtStart = true
+	}
+}
+
+type job struct {
+	name       Name
+	interval   time.Duration
+	jitter     time.Duration
+	singleton  bool
+	runAtStart bool
+	run        func(context.Context) error
+}
+
+func NewJob(name Name, interval time.Duration, run func(context.Context) error, opts ...JobOption) Job {
+	j := &job{
+		name:     name,
+		interval: interval,
+		run:      run,
+	}
+
+	for _, opt := range opts {
+		opt(j)
+	}
+
+	return j
+}
+
+func (j *job) Name() Name {
+	return j.name
+}
+
+func (j *job) Interval() time.Duration {
+	return j.interval
+}
+
+func (j *job) Jitter() time.Duration {
+	return j.jitter
+}
+
+func (j *job) Singleton() bool {
+	return j.singleton
+}
+
+func (j *job) RunAtStart() bool {
+	return j.runAtStart
+}
+
+func (j *job) Run(ctx context.Context) error {
+	return j.run(ctx)
+}
+
+// Scheduler is a service running the registered jobs periodically, from its start until its stop. The first run of
+// a job happens one interval after the start, unless it runs at the start as well.
+type Scheduler struct {
+	settings ScopedProviderSettings
+	locker   Locker
+	jobs     NamedMap[Job]
+	duration metric.Float64Histogram
+	failures metric.Int64Counter
+	stopC    chan struct{}
+	wg       sync.WaitGroup
+}
+
+func NewScheduler(providerSettings ProviderSettings, locker Locker, jobs ...Job) (*Scheduler, error) {
+	m, err := NewNamedMap(jobs...)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, j := range jobs {
+		if j.Interval() <= 0 {
+			return nil, fmt.Errorf("cannot build scheduler, interval of job %q must be positive", j.Name())
+		}
+
+		if j.Jitter() < 0 {
+			return nil, fmt.Errorf("cannot build scheduler, jitter of job %q must not be negative", j.Name())
+		}
+
+		if j.Singleton() && locker == nil {
+			return nil, fmt.Errorf("cannot build scheduler, job %q is a singleton and no locker is given", j.Name())
+		}
+	}
+
+	settings := NewScopedProviderSettings(providerSettings, "github.com/SigNoz/signoz/pkg/factory")
+
+	duration, err := settings.Meter().Float64Histogram(
+		"signoz.scheduler.job.duration",
+		metric.WithDescription("Duration of the runs of the jobs of the scheduler, by job and outcome."),
+		metric.WithUnit("s"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	failures, err := settings.Meter().Int64Counter(
+		"signoz.scheduler.job.failures",
+		metric.WithDescription("Number of the failed runs of the jobs of the scheduler, by job."),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Scheduler{
+		settings: settings,
+		locker:   locker,
+		jobs:     m,
+		duration: duration,
+		failures: failures,
+		stopC:    make(chan struct{}),
+	}, nil
+}
+
+func (scheduler *Scheduler) Start(ctx context.Context) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	for _, j := range scheduler.jobs.GetInOrder() {
+		scheduler.wg.Add(1)
+		go func(j Job) {
+			defer scheduler.wg.Done()
+			scheduler.loop(ctx, j)
+		}(j)
+	}
+
+	<-scheduler.stopC
+	cancel()
+	scheduler.wg.Wait()
+	return nil
+}
+
+// Stop stops the scheduler. The running jobs are canceled and waited for until the given context is done.
+func (scheduler *Scheduler) Stop(ctx context.Context) error {
+	close(scheduler.stopC)
+
+	doneC := make(chan struct{})
+	go func() {
+		scheduler.wg.Wait()
+		close(doneC)
+	}()
+
+	select {
+	case <-doneC:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (scheduler *Scheduler) loop(ctx context.Context, j Job) {
+	if j.RunAtStart() {
+		scheduler.run(ctx, j, true)
+	}
+
+	for {
+		delay := j.Interval()
+		if j.Jitter() > 0 {
+			delay += rand.N(j.Jitter())
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+			scheduler.run(ctx, j, false)
+		}
+	}
+}
+
+func (scheduler *Scheduler) run(ctx context.Context, j Job, atStart bool) {
+	key := "job:" + j.Name().String()
+	start := time.Now()
+
+	if j.Singleton() {
+		unlock, acquired, err := scheduler.locker.TryLock(ctx, key)
+		if err != nil {
+			scheduler.settings.Logger().ErrorContext(ctx, "failed to acquire the lock of the job", "job", j.Name(), "error", err)
+			return
+		}
+
+		// Another replica runs the job.
+		if !acquired {
+			return
+		}
+
+		defer func() {
+			if err := unlock(context.WithoutCancel(ctx)); err != nil {
+				scheduler.settings.Logger().ErrorContext(ctx, "failed to release the lock of the job", "job", j.Name(), "error", err)
+			}
+		}()
+
+		lastRun, err := scheduler.locker.LastRun(ctx, key)
+		if err != nil {
+			scheduler.settings.Logger().ErrorContext(ctx, "failed to read the last run of the job", "job", j.Name(), "error", err)
+			return
+		}
+
+		// Another replica ran the job less than an interval ago.
+		if !atStart && start.Sub(lastRun) < j.Interval() {
+			return
+		}
+	}
+
+	err := j.Run(ctx)
+
+	attributes := []attribute.KeyValue{attribute.String("job.name", j.Name().String()), attribute.Bool("error", err != nil)}
+	scheduler.duration.Record(ctx, time.Since(start).Seconds(), metric.WithAttributes(attributes...))
+	if err != nil {
+		scheduler.failures.Add(ctx, 1, metric.WithAttributes(attributes[0]))
+		scheduler.settings.Logger().ErrorContext(ctx, "failed to run the job", "job", j.Name(), "error", err)
+		return
+	}
+
+	if j.Singleton() {
+		if err := scheduler.locker.RecordRun(context.WithoutCancel(ctx), key, start); err != nil {
+			scheduler.settings.Logger().ErrorContext(ctx, "failed to record the run of the job", "job", j.Name(), "error", err)
+		}
+	}
+}
@@ -0,0 +1,186 @@
+package factory
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metricsdk "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+type tlocker struct {
+	acquired bool
+	unlocks  atomic.Int64
+	mtx      sync.Mutex
+	lastRuns map[string]time.Time
+}
+
+func (locker *tlocker) LastRun(_ context.Context, key string) (time.Time, error) {
+	locker.mtx.Lock()
+	defer locker.mtx.Unlock()
+
+	return locker.lastRuns[key], nil
+}
+
+func (locker *tlocker) RecordRun(_ context.Context, key string, at time.Time) error {
+	locker.mtx.Lock()
+	defer locker.mtx.Unlock()
+
+	if locker.lastRuns == nil {
+		locker.lastRuns = map[string]time.Time{}
+	}
+	locker.lastRuns[key] = at
+	return nil
+}
+
+func (locker *tlocker) TryLock(context.Context, string) (func(context.Context) error, bool, error) {
+	if !locker.acquired {
+		return nil, false, nil
+	}
+
+	return func(context.Context) error {
+		locker.unlocks.Add(1)
+		return nil
+	}, true, nil
+}
+
+func newTestSchedulerSettings(reader metricsdk.Reader) ProviderSettings {
+	return ProviderSettings{
+		Logger:         slog.New(slog.NewTextHandler(io.Discard, nil)),
+		MeterProvider:  metricsdk.NewMeterProvider(metricsdk.WithReader(reader)),
+		TracerProvider: noop.NewTracerProvider(),
+	}
+}
+
+func TestSchedulerRunsJobs(t *testing.T) {
+	reader := metricsdk.NewManualReader()
+
+	var runs, failures, singletonRuns atomic.Int64
+	locker := &tlocker{acquired: false}
+	scheduler, err := NewScheduler(
+		newTestSchedulerSettings(reader),
+		locker,
+		NewJob(MustNewName("ok"), 5*time.Millisecond, func(context.Context) error { runs.Add(1); return nil }, WithJitter(time.Millisecond)),
+		NewJob(MustNewName("failing"), 5*time.Millisecond, func(context.Context) error { failures.Add(1); return errors.New("failed") }),
+		NewJob(MustNewName("singleton"), 5*time.Millisecond, func(context.Context) error { singletonRuns.Add(1); return nil }, WithSingleton()),
+	)
+	require.NoError(t, err)
+
+	done := make(chan error, 1)
+	go func() { done <- scheduler.Start(context.Background()) }()
+
+	assert.Eventually(t, func() bool { return runs.Load() >= 2 && failures.Load() >= 2 }, time.Second, time.Millisecond)
+	require.NoError(t, scheduler.Stop(context.Background()))
+	require.NoError(t, <-done)
+
+	// The lock of the singleton job is held by another replica.
+	assert.Zero(t, singletonRuns.Load())
+	assert.Zero(t, locker.unlocks.Load())
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+
+	names := map[string]bool{}
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			names[m.Name] = true
+		}
+	}
+	assert.True(t, names["signoz.scheduler.job.duration"])
+	assert.True(t, names["signoz.scheduler.job.failures"])
+}
+
+func TestSchedulerRunsSingletonJobWithLock(t *testing.T) {
+	var runs atomic.Int64
+	locker := &tlocker{acquired: true}
+	scheduler, err := NewScheduler(
+		newTestSchedulerSettings(metricsdk.NewManualReader()),
+		locker,
+		NewJob(MustNewName("singleton"), 5*time.Millisecond, func(context.Context) error { runs.Add(1); return nil }, WithSingleton()),
+	)
+	require.NoError(t, err)
+
+	done := make(chan error, 1)
+	go func() { done <- scheduler.Start(context.Background()) }()
+
+	assert.Eventually(t, func() bool { return runs.Load() >= 1 && locker.unlocks.Load() >= 1 }, time.Second, time.Millisecond)
+	require.NoError(t, scheduler.Stop(context.Background()))
+	require.NoError(t, <-done)
+}
+
+func TestSchedulerRunsSingletonJobOncePerInterval(t *testing.T) {
+	ctx := context.Background()
+
+	var runs atomic.Int64
+	j := NewJob(MustNewName("singleton"), time.Hour, func(context.Context) error { runs.Add(1); return nil }, WithSingleton())
+
+	// The replicas share the runs recorded by the locker.
+	locker := &tlocker{acquired: true}
+	first, err := NewScheduler(newTestSchedulerSettings(metricsdk.NewManualReader()), locker, j)
+	require.NoError(t, err)
+	second, err := NewScheduler(newTestSchedulerSettings(metricsdk.NewManualReader()), locker, j)
+	require.NoError(t, err)
+
+	first.run(ctx, j, false)
+	second.run(ctx, j, false)
+	assert.Equal(t, int64(1), runs.Load())
+
+	// The run at the start applies whatever the last run.
+	second.run(ctx, j, true)
+	assert.Equal(t, int64(2), runs.Load())
+
+	require.NoError(t, locker.RecordRun(ctx, "job:singleton", time.Now().Add(-2*time.Hour)))
+	first.run(ctx, j, false)
+	assert.Equal(t, int64(3), runs.Load())
+}
+
+func TestSchedulerStopWaitsForRunningJobs(t *testing.T) {
+	startedC := make(chan struct{})
+	var finished atomic.Bool
+	scheduler, err := NewScheduler(
+		newTestSchedulerSettings(metricsdk.NewManualReader()),
+		nil,
+		NewJob(MustNewName("slow"), time.Hour, func(ctx context.Context) error {
+			close(startedC)
+			<-ctx.Done()
+			time.Sleep(10 * time.Millisecond)
+			finished.Store(true)
+			return nil
+		}, WithRunAtStart()),
+	)
+	require.NoError(t, err)
+
+	done := make(chan error, 1)
+	go func() { done <- scheduler.Start(context.Background()) }()
+
+	<-startedC
+	require.NoError(t, scheduler.Stop(context.Background()))
+	assert.True(t, finished.Load())
+	require.NoError(t, <-done)
+}
+
+func TestNewSchedulerValidatesJobs(t *testing.T) {
+	settings := newTestSchedulerSettings(metricsdk.NewManualReader())
+	run := func(context.Context) error { return nil }
+
+	_, err := NewScheduler(settings, nil, NewJob(MustNewName("job"), 0, run))
+	assert.Error(t, err)
+
+	_, err = NewScheduler(settings, nil, NewJob(MustNewName("job"), time.Second, run, WithJitter(-time.Second)))
+	assert.Error(t, err)
+
+	_, err = NewScheduler(settings, nil, NewJob(MustNewName("job"), time.Second, run, WithSingleton()))
+	assert.Error(t, err)
+
+	_, err = NewScheduler(settings, nil, NewJob(MustNewName("job"), time.Second, run), NewJob(MustNewName("job"), time.Second, run))
+	assert.Error(t, err)
+}
@@ -1,6 +1,9 @@
 package factory
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 type Service interface {
 	// Starts a service. It should block and should not return until the service is stopped or it fails.
@@ -12,10 +15,23 @@ type Service interface {
 type NamedService interface {
 	Named
 	Service
+	// StopTimeout returns the maximum time the registry waits for the service to stop.
+	// A zero value means that the registry waits until the context passed to Stop is done.
+	StopTimeout() time.Duration
+}
+
+type NamedServiceOption func(*namedService)
+
+// WithStopTimeout sets the maximum time the registry waits for the service to stop.
+func WithStopTimeout(timeout time.Duration) NamedServiceOption {
+	return func(s *namedService) {
+		s.stopTimeout = timeout
+	}
 }
 
 type namedService struct {
-	name Name
+	name        Name
+	stopTimeout time.Duration
 	Service
 }
 
@@ -23,9 +39,29 @@ func (s *namedService) Name() Name {
 	return s.name
 }
 
-func NewNamedService(name Name, service Service) NamedService {
-	return &namedService{
+func (s *namedService) StopTimeout() time.Duration {
+	return s.stopTimeout
+}
+
+// Ready reports the readiness of the underlying service if it implements Ready. Other services are ready as soon as
+// they have been started.
+func (s *namedService) Ready(ctx context.Context) error {
+	if ready, ok := s.Service.(Ready); ok {
+		return ready.Ready(ctx)
+	}
+
+	return nil
+}
+
+func NewNamedService(name Name, service Service, opts ...NamedServiceOption) NamedService {
+	s := &namedService{
 		name:    name,
 		Service: service,
 	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
 }
@@ -2,6 +2,7 @@ package factory
 
 import (
 	"log/slog"
+	"net/http"
 
 	"github.com/prometheus/client_golang/prometheus"
 	sdkmetric "go.opentelemetry.io/otel/metric"
@@ -17,6 +18,10 @@ type ProviderSettings struct {
 	TracerProvider sdktrace.TracerProvider
 	// PrometheusRegistry is the prometheus registry.
 	PrometheusRegisterer prometheus.Registerer
+	// Labels are the static labels attached to every metric and span.
+	Labels map[string]string
+	// HTTPTransport is the transport shared by the outbound http clients, nil when the default transport is used.
+	HTTPTransport http.RoundTripper
 }
 
 type ScopedProviderSettings interface {
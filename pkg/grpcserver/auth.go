@@ -0,0 +1,73 @@
+package grpcserver
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/sharder"
+	"github.com/SigNoz/signoz/pkg/types"
+	"github.com/SigNoz/signoz/pkg/types/authtypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	authCrossOrgMessage string = "::AUTH-CROSS-ORG::"
+	authzDeniedMessage  string = "::AUTHZ-DENIED::"
+	// authorizationKey is the metadata key carrying the jwt, as the Authorization header of the http API.
+	authorizationKey string = "authorization"
+)
+
+// auth authenticates the callers with the jwt of the authorization metadata, as middleware.Auth does for the http
+// API, and lets the viewers, editors and admins of the organizations owned by this instance through.
+type auth struct {
+	jwt     *authtypes.JWT
+	sharder sharder.Sharder
+	logger  *slog.Logger
+}
+
+func newAuth(jwt *authtypes.JWT, sharder sharder.Sharder, logger *slog.Logger) *auth {
+	return &auth{jwt: jwt, sharder: sharder, logger: logger}
+}
+
+func (a *auth) UnaryServerInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	ctx, err := a.authenticate(ctx)
+	if err != nil {
+		return nil, newStatusError(err)
+	}
+
+	return handler(ctx, req)
+}
+
+func (a *auth) authenticate(ctx context.Context) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	claimsCtx, err := a.jwt.ContextFromRequest(ctx, md.Get(authorizationKey)...)
+	if err != nil {
+		return ctx, err
+	}
+
+	claims, err := authtypes.ClaimsFromContext(claimsCtx)
+	if err != nil {
+		return ctx, err
+	}
+
+	orgID, err := valuer.NewUUID(claims.OrgID)
+	if err != nil {
+		return ctx, errors.Wrapf(err, errors.TypeUnauthenticated, errors.CodeUnauthenticated, "invalid org id in the claims")
+	}
+
+	if err := a.sharder.IsMyOwnedKey(ctx, types.NewOrganizationKey(orgID)); err != nil {
+		a.logger.ErrorContext(ctx, authCrossOrgMessage, "claims", claims, "error", err)
+		return ctx, errors.New(errors.TypeUnauthenticated, errors.CodeUnauthenticated, "organization is not owned by this instance")
+	}
+
+	if err := claims.IsViewer(); err != nil {
+		a.logger.WarnContext(ctx, authzDeniedMessage, "claims", claims)
+		return ctx, err
+	}
+
+	return claimsCtx, nil
+}
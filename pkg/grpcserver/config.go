@@ -0,0 +1,33 @@
+package grpcserver
+
+import (
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/factory"
+)
+
+// Config holds the configuration for the grpc server.
+type Config struct {
+	// Whether to serve the grpc API.
+	Enabled bool `mapstructure:"enabled"`
+	// The TCP address to listen on, in the form "host:port".
+	Address string `mapstructure:"address"`
+}
+
+func NewConfigFactory() factory.ConfigFactory {
+	return factory.NewConfigFactory(factory.MustNewName("grpcserver"), newConfig)
+}
+
+func newConfig() factory.Config {
+	return &Config{
+		Enabled: false,
+		Address: "0.0.0.0:8086",
+	}
+}
+
+func (c Config) Validate() error {
+	if c.Enabled && c.Address == "" {
+		return errors.New(errors.TypeInvalidInput, errors.CodeInvalidInput, "grpcserver::address must be set when grpcserver::enabled is true")
+	}
+
+	return nil
+}
@@ -0,0 +1,123 @@
+package grpcserver
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/modules/dashboard"
+	signozv1 "github.com/SigNoz/signoz/pkg/proto/signoz/v1"
+	"github.com/SigNoz/signoz/pkg/types"
+	"github.com/SigNoz/signoz/pkg/types/authtypes"
+	"github.com/SigNoz/signoz/pkg/types/dashboardtypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var _ signozv1.DashboardServiceServer = (*dashboardService)(nil)
+
+type dashboardService struct {
+	signozv1.UnimplementedDashboardServiceServer
+	module dashboard.Module
+}
+
+func newDashboardService(module dashboard.Module) *dashboardService {
+	return &dashboardService{module: module}
+}
+
+func (service *dashboardService) ListDashboards(ctx context.Context, req *signozv1.ListDashboardsRequest) (*signozv1.ListDashboardsResponse, error) {
+	claims, err := authtypes.ClaimsFromContext(ctx)
+	if err != nil {
+		return nil, newStatusError(err)
+	}
+
+	orgID, err := valuer.NewUUID(claims.OrgID)
+	if err != nil {
+		return nil, newStatusError(err)
+	}
+
+	pagination, err := newPagination(req)
+	if err != nil {
+		return nil, newStatusError(err)
+	}
+
+	var dashboards []*dashboardtypes.Dashboard
+	var nextCursor string
+	if pagination == nil {
+		dashboards, err = service.module.List(ctx, orgID)
+	} else {
+		dashboards, nextCursor, err = service.module.ListPage(ctx, orgID, pagination)
+	}
+	if err != nil {
+		return nil, newStatusError(err)
+	}
+
+	resp := &signozv1.ListDashboardsResponse{Dashboards: make([]*signozv1.Dashboard, 0, len(dashboards)), NextCursor: nextCursor}
+	for _, dashboard := range dashboards {
+		protoDashboard, err := newProtoDashboard(dashboard)
+		if err != nil {
+			return nil, newStatusError(err)
+		}
+
+		resp.Dashboards = append(resp.Dashboards, protoDashboard)
+	}
+
+	return resp, nil
+}
+
+// newPagination returns the pagination of the request with the semantics of types.NewPaginationFromRequest.
+func newPagination(req *signozv1.ListDashboardsRequest) (*types.Pagination, error) {
+	if req.GetLimit() == 0 && req.GetCursor() == "" {
+		return nil, nil
+	}
+
+	pagination := &types.Pagination{Limit: types.DefaultPaginationLimit}
+	if req.GetLimit() != 0 {
+		if req.GetLimit() > types.MaxPaginationLimit {
+			return nil, errors.Newf(errors.TypeInvalidInput, types.ErrCodePaginationInvalid, "limit must be an integer between 1 and %d", types.MaxPaginationLimit)
+		}
+
+		pagination.Limit = int(req.GetLimit())
+	}
+
+	if req.GetCursor() != "" {
+		cursor, err := types.NewCursorFromString(req.GetCursor())
+		if err != nil {
+			return nil, err
+		}
+
+		pagination.Cursor = cursor
+	}
+
+	return pagination, nil
+}
+
+func newProtoDashboard(dashboard *dashboardtypes.Dashboard) (*signozv1.Dashboard, error) {
+	protoDashboard := &signozv1.Dashboard{
+		Id:        dashboard.ID,
+		Locked:    dashboard.Locked,
+		CreatedAt: timestamppb.New(dashboard.CreatedAt),
+		CreatedBy: dashboard.CreatedBy,
+		UpdatedAt: timestamppb.New(dashboard.UpdatedAt),
+		UpdatedBy: dashboard.UpdatedBy,
+	}
+
+	if dashboard.Data == nil {
+		return protoDashboard, nil
+	}
+
+	data, err := json.Marshal(dashboard.Data)
+	if err != nil {
+		return nil, errors.Wrapf(err, errors.TypeInternal, errors.CodeInternal, "failed to encode the data of dashboard %s", dashboard.ID)
+	}
+
+	protoData := new(structpb.Struct)
+	if err := protojson.Unmarshal(data, protoData); err != nil {
+		return nil, errors.Wrapf(err, errors.TypeInternal, errors.CodeInternal, "failed to encode the data of dashboard %s", dashboard.ID)
+	}
+
+	protoDashboard.Data = protoData
+	return protoDashboard, nil
+}
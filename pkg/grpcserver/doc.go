@@ -0,0 +1,3 @@
+// package grpcserver serves the grpc API defined in proto/signoz/v1. The services call the same modules as the http
+// handlers they mirror and authenticate the callers with the same jwt.
+package grpcserver
@@ -0,0 +1,114 @@
+package grpcserver
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	signozv1 "github.com/SigNoz/signoz/pkg/proto/signoz/v1"
+	"github.com/SigNoz/signoz/pkg/querier"
+	"github.com/SigNoz/signoz/pkg/types/authtypes"
+	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
+	"github.com/SigNoz/signoz/pkg/valuer"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+var _ signozv1.QueryServiceServer = (*queryService)(nil)
+
+type queryService struct {
+	signozv1.UnimplementedQueryServiceServer
+	querier querier.Querier
+}
+
+func newQueryService(querier querier.Querier) *queryService {
+	return &queryService{querier: querier}
+}
+
+func (service *queryService) QueryRange(ctx context.Context, req *signozv1.QueryRangeRequest) (*signozv1.QueryRangeResponse, error) {
+	claims, err := authtypes.ClaimsFromContext(ctx)
+	if err != nil {
+		return nil, newStatusError(err)
+	}
+
+	orgID, err := valuer.NewUUID(claims.OrgID)
+	if err != nil {
+		return nil, newStatusError(err)
+	}
+
+	queryRangeRequest, err := newQueryRangeRequest(req)
+	if err != nil {
+		return nil, newStatusError(err)
+	}
+
+	queryRangeResponse, err := service.querier.QueryRange(ctx, orgID, queryRangeRequest)
+	if err != nil {
+		return nil, newStatusError(err)
+	}
+
+	resp, err := newQueryRangeResponse(queryRangeResponse)
+	if err != nil {
+		return nil, newStatusError(err)
+	}
+
+	return resp, nil
+}
+
+// newQueryRangeRequest converts the request to the request of the http API through its json form, so that the
+// composite query is decoded and validated as the http API does.
+func newQueryRangeRequest(req *signozv1.QueryRangeRequest) (*qbtypes.QueryRangeRequest, error) {
+	input := map[string]any{
+		"schemaVersion":  req.GetSchemaVersion(),
+		"start":          req.GetStart(),
+		"end":            req.GetEnd(),
+		"requestType":    req.GetRequestType(),
+		"compositeQuery": req.GetCompositeQuery().AsMap(),
+		"variables":      req.GetVariables().AsMap(),
+		"noCache":        req.GetNoCache(),
+	}
+
+	if req.GetFormatOptions() != nil {
+		input["formatOptions"] = map[string]any{
+			"fillGaps":               req.GetFormatOptions().GetFillGaps(),
+			"formatTableResultForUI": req.GetFormatOptions().GetFormatTableResultForUi(),
+		}
+	}
+
+	data, err := json.Marshal(input)
+	if err != nil {
+		return nil, errors.Wrapf(err, errors.TypeInvalidInput, errors.CodeInvalidInput, "invalid query range request")
+	}
+
+	queryRangeRequest := new(qbtypes.QueryRangeRequest)
+	if err := json.Unmarshal(data, queryRangeRequest); err != nil {
+		if errors.Ast(err, errors.TypeInvalidInput) {
+			return nil, err
+		}
+
+		return nil, errors.Wrapf(err, errors.TypeInvalidInput, errors.CodeInvalidInput, "invalid query range request")
+	}
+
+	return queryRangeRequest, nil
+}
+
+func newQueryRangeResponse(queryRangeResponse *qbtypes.QueryRangeResponse) (*signozv1.QueryRangeResponse, error) {
+	data, err := json.Marshal(queryRangeResponse.Data)
+	if err != nil {
+		return nil, errors.Wrapf(err, errors.TypeInternal, errors.CodeInternal, "failed to encode the query range response")
+	}
+
+	value := new(structpb.Value)
+	if err := protojson.Unmarshal(data, value); err != nil {
+		return nil, errors.Wrapf(err, errors.TypeInternal, errors.CodeInternal, "failed to encode the query range response")
+	}
+
+	return &signozv1.QueryRangeResponse{
+		Type: queryRangeResponse.Type.StringValue(),
+		Data: value,
+		Meta: &signozv1.ExecStats{
+			RowsScanned:  queryRangeResponse.Meta.RowsScanned,
+			BytesScanned: queryRangeResponse.Meta.BytesScanned,
+			DurationMs:   queryRangeResponse.Meta.DurationMS,
+		},
+	}, nil
+}
@@ -0,0 +1,99 @@
+package grpcserver
+
+import (
+	"context"
+	"log/slog"
+	"net"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/factory"
+	"github.com/SigNoz/signoz/pkg/modules/dashboard"
+	signozv1 "github.com/SigNoz/signoz/pkg/proto/signoz/v1"
+	"github.com/SigNoz/signoz/pkg/querier"
+	"github.com/SigNoz/signoz/pkg/sharder"
+	"github.com/SigNoz/signoz/pkg/types/authtypes"
+	"google.golang.org/grpc"
+)
+
+const (
+	// stopTimeout is the maximum time spent waiting for the calls in flight when the server stops.
+	stopTimeout = 5 * time.Second
+)
+
+var _ factory.Service = (*Server)(nil)
+
+type Server struct {
+	srv      *grpc.Server
+	logger   *slog.Logger
+	config   Config
+	listener net.Listener
+}
+
+func New(providerSettings factory.ProviderSettings, config Config, jwt *authtypes.JWT, sharder sharder.Sharder, querier querier.Querier, dashboard dashboard.Module) (*Server, error) {
+	if jwt == nil {
+		return nil, errors.New(errors.TypeInvalidInput, errors.CodeInvalidInput, "cannot build grpc server, jwt is required")
+	}
+
+	settings := factory.NewScopedProviderSettings(providerSettings, "github.com/SigNoz/signoz/pkg/grpcserver")
+
+	srv := grpc.NewServer(grpc.ChainUnaryInterceptor(newAuth(jwt, sharder, settings.Logger()).UnaryServerInterceptor))
+	signozv1.RegisterQueryServiceServer(srv, newQueryService(querier))
+	signozv1.RegisterDashboardServiceServer(srv, newDashboardService(dashboard))
+
+	return &Server{
+		srv:    srv,
+		logger: settings.Logger(),
+		config: config,
+	}, nil
+}
+
+// listen opens the listener of the server, Start opens it when it is not open yet.
+func (server *Server) listen() error {
+	listener, err := net.Listen("tcp", server.config.Address)
+	if err != nil {
+		return errors.Wrapf(err, errors.TypeInternal, errors.CodeInternal, "failed to listen on %s", server.config.Address)
+	}
+
+	server.listener = listener
+	return nil
+}
+
+func (server *Server) Start(ctx context.Context) error {
+	if server.listener == nil {
+		if err := server.listen(); err != nil {
+			return err
+		}
+	}
+
+	server.logger.InfoContext(ctx, "starting grpc server", "address", server.listener.Addr().String())
+	if err := server.srv.Serve(server.listener); err != nil && err != grpc.ErrServerStopped {
+		server.logger.ErrorContext(ctx, "failed to start grpc server", "error", err)
+		return err
+	}
+
+	return nil
+}
+
+func (server *Server) Stop(ctx context.Context) error {
+	doneC := make(chan struct{})
+	go func() {
+		server.srv.GracefulStop()
+		close(doneC)
+	}()
+
+	timer := time.NewTimer(stopTimeout)
+	defer timer.Stop()
+
+	select {
+	case <-doneC:
+	case <-timer.C:
+		server.logger.WarnContext(ctx, "calls still in flight after the grpc server stopped, canceling them", "timeout", stopTimeout)
+		server.srv.Stop()
+	case <-ctx.Done():
+		server.srv.Stop()
+	}
+
+	server.logger.InfoContext(ctx, "grpc server stopped gracefully")
+	return nil
+}
@@ -0,0 +1,137 @@
+package grpcserver
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/analytics/analyticstest"
+	"github.com/SigNoz/signoz/pkg/factory/factorytest"
+	"github.com/SigNoz/signoz/pkg/instrumentation/instrumentationtest"
+	"github.com/SigNoz/signoz/pkg/modules/dashboard/impldashboard"
+	signozv1 "github.com/SigNoz/signoz/pkg/proto/signoz/v1"
+	"github.com/SigNoz/signoz/pkg/querier"
+	"github.com/SigNoz/signoz/pkg/query-service/utils"
+	"github.com/SigNoz/signoz/pkg/sharder"
+	"github.com/SigNoz/signoz/pkg/sharder/noopsharder"
+	"github.com/SigNoz/signoz/pkg/types"
+	"github.com/SigNoz/signoz/pkg/types/authtypes"
+	"github.com/SigNoz/signoz/pkg/types/dashboardtypes"
+	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
+	"github.com/SigNoz/signoz/pkg/valuer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+type fakeQuerier struct {
+	querier.Querier
+	orgID valuer.UUID
+	req   *qbtypes.QueryRangeRequest
+}
+
+func (q *fakeQuerier) QueryRange(ctx context.Context, orgID valuer.UUID, req *qbtypes.QueryRangeRequest) (*qbtypes.QueryRangeResponse, error) {
+	q.orgID = orgID
+	q.req = req
+
+	return &qbtypes.QueryRangeResponse{
+		Type: req.RequestType,
+		Data: map[string]any{"results": []any{map[string]any{"queryName": "A"}}},
+		Meta: qbtypes.ExecStats{RowsScanned: 10, BytesScanned: 100, DurationMS: 1},
+	}, nil
+}
+
+func TestServer(t *testing.T) {
+	ctx := context.Background()
+	sqlstore := utils.NewQueryServiceDBForTests(t)
+
+	organization := types.NewOrganization("test")
+	_, err := sqlstore.BunDB().NewInsert().Model(organization).Exec(ctx)
+	require.NoError(t, err)
+
+	dashboardModule := impldashboard.NewModule(sqlstore, instrumentationtest.New().ToProviderSettings(), analyticstest.New())
+	_, err = dashboardModule.Create(ctx, organization.ID, "creator@signoz.io", organization.ID, dashboardtypes.PostableDashboard{"title": "test"})
+	require.NoError(t, err)
+
+	sharder, err := noopsharder.New(ctx, factorytest.NewSettings(), sharder.Config{})
+	require.NoError(t, err)
+
+	jwt := authtypes.NewJWT("secret", time.Hour, time.Hour)
+	querier := &fakeQuerier{}
+
+	server, err := New(factorytest.NewSettings(), Config{Enabled: true, Address: "127.0.0.1:0"}, jwt, sharder, querier, dashboardModule)
+	require.NoError(t, err)
+	require.NoError(t, server.listen())
+	go func() { _ = server.Start(ctx) }()
+	t.Cleanup(func() { _ = server.Stop(ctx) })
+
+	conn, err := grpc.NewClient(server.listener.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	token, _, err := jwt.AccessToken(organization.ID.StringValue(), valuer.GenerateUUID().StringValue(), "viewer@signoz.io", types.RoleViewer)
+	require.NoError(t, err)
+	authCtx := metadata.AppendToOutgoingContext(ctx, authorizationKey, "Bearer "+token)
+
+	t.Run("Unauthenticated", func(t *testing.T) {
+		_, err := signozv1.NewDashboardServiceClient(conn).ListDashboards(ctx, &signozv1.ListDashboardsRequest{})
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+		_, err = signozv1.NewDashboardServiceClient(conn).ListDashboards(metadata.AppendToOutgoingContext(ctx, authorizationKey, "Bearer invalid"), &signozv1.ListDashboardsRequest{})
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	})
+
+	t.Run("QueryRange", func(t *testing.T) {
+		compositeQuery, err := structpb.NewStruct(map[string]any{
+			"queries": []any{
+				map[string]any{"type": "promql", "spec": map[string]any{"name": "A", "query": "up"}},
+			},
+		})
+		require.NoError(t, err)
+
+		resp, err := signozv1.NewQueryServiceClient(conn).QueryRange(authCtx, &signozv1.QueryRangeRequest{
+			SchemaVersion:  "v1",
+			Start:          1000,
+			End:            2000,
+			RequestType:    "time_series",
+			CompositeQuery: compositeQuery,
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, organization.ID, querier.orgID)
+		assert.Equal(t, uint64(1000), querier.req.Start)
+		assert.Equal(t, qbtypes.RequestTypeTimeSeries, querier.req.RequestType)
+		require.Len(t, querier.req.CompositeQuery.Queries, 1)
+		assert.Equal(t, qbtypes.QueryTypePromQL, querier.req.CompositeQuery.Queries[0].Type)
+
+		assert.Equal(t, "time_series", resp.GetType())
+		assert.Equal(t, uint64(10), resp.GetMeta().GetRowsScanned())
+		assert.Equal(t, "A", resp.GetData().GetStructValue().GetFields()["results"].GetListValue().GetValues()[0].GetStructValue().GetFields()["queryName"].GetStringValue())
+	})
+
+	t.Run("QueryRangeInvalid", func(t *testing.T) {
+		compositeQuery, err := structpb.NewStruct(map[string]any{
+			"queries": []any{map[string]any{"type": "builder_query", "spec": map[string]any{"signal": "unknown"}}},
+		})
+		require.NoError(t, err)
+
+		_, err = signozv1.NewQueryServiceClient(conn).QueryRange(authCtx, &signozv1.QueryRangeRequest{RequestType: "time_series", CompositeQuery: compositeQuery})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("ListDashboards", func(t *testing.T) {
+		resp, err := signozv1.NewDashboardServiceClient(conn).ListDashboards(authCtx, &signozv1.ListDashboardsRequest{})
+		require.NoError(t, err)
+		require.Len(t, resp.GetDashboards(), 1)
+		assert.Equal(t, "test", resp.GetDashboards()[0].GetData().GetFields()["title"].GetStringValue())
+		assert.Empty(t, resp.GetNextCursor())
+
+		_, err = signozv1.NewDashboardServiceClient(conn).ListDashboards(authCtx, &signozv1.ListDashboardsRequest{Limit: types.MaxPaginationLimit + 1})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
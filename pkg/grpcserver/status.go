@@ -0,0 +1,39 @@
+package grpcserver
+
+import (
+	"github.com/SigNoz/signoz/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// newStatusError converts the error to a grpc status error, deriving the status code from the type of the error as
+// render.Error derives the http status code.
+func newStatusError(cause error) error {
+	t, _, m, _, _, _ := errors.Unwrapb(cause)
+
+	code := codes.Internal
+	switch t {
+	case errors.TypeInvalidInput:
+		code = codes.InvalidArgument
+	case errors.TypeNotFound:
+		code = codes.NotFound
+	case errors.TypeAlreadyExists:
+		code = codes.AlreadyExists
+	case errors.TypeUnauthenticated:
+		code = codes.Unauthenticated
+	case errors.TypeUnsupported:
+		code = codes.Unimplemented
+	case errors.TypeForbidden:
+		code = codes.PermissionDenied
+	case errors.TypeCanceled:
+		code = codes.Canceled
+	case errors.TypeTimeout:
+		code = codes.DeadlineExceeded
+	case errors.TypeTooManyRequests:
+		code = codes.ResourceExhausted
+	case errors.TypeUnavailable:
+		code = codes.Unavailable
+	}
+
+	return status.Error(code, m)
+}
@@ -0,0 +1,67 @@
+package client
+
+import (
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/factory"
+)
+
+// Config is the config of the transport shared by the outbound http clients, such as the ones calling zeus, the
+// jwks of the trusted issuers and the webhooks of the alertmanager.
+type Config struct {
+	// DialTimeout is the maximum time to establish a connection.
+	DialTimeout time.Duration `mapstructure:"dial_timeout"`
+	// TLSHandshakeTimeout is the maximum time to perform the tls handshake.
+	TLSHandshakeTimeout time.Duration `mapstructure:"tls_handshake_timeout"`
+	// ResponseHeaderTimeout is the maximum time to wait for the headers of the response once the request is sent.
+	ResponseHeaderTimeout time.Duration `mapstructure:"response_header_timeout"`
+	// IdleConnTimeout is the maximum time an idle connection is kept in the pool.
+	IdleConnTimeout time.Duration `mapstructure:"idle_conn_timeout"`
+	// MaxIdleConns is the maximum number of idle connections across all the hosts.
+	MaxIdleConns int `mapstructure:"max_idle_conns"`
+	// MaxIdleConnsPerHost is the maximum number of idle connections per host.
+	MaxIdleConnsPerHost int `mapstructure:"max_idle_conns_per_host"`
+	// MaxConnsPerHost is the maximum number of connections per host, 0 is unlimited.
+	MaxConnsPerHost int `mapstructure:"max_conns_per_host"`
+}
+
+func NewConfigFactory() factory.ConfigFactory {
+	return factory.NewConfigFactory(factory.MustNewName("http_client"), newConfig)
+}
+
+func newConfig() factory.Config {
+	return Config{
+		DialTimeout:           10 * time.Second,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ResponseHeaderTimeout: 30 * time.Second,
+		IdleConnTimeout:       90 * time.Second,
+		MaxIdleConns:          100,
+		MaxIdleConnsPerHost:   10,
+		MaxConnsPerHost:       0,
+	}
+}
+
+func (c Config) Validate() error {
+	if c.DialTimeout <= 0 {
+		return errors.NewInvalidInputf(errors.CodeInvalidInput, "dial_timeout must be positive, got %v", c.DialTimeout)
+	}
+
+	if c.TLSHandshakeTimeout <= 0 {
+		return errors.NewInvalidInputf(errors.CodeInvalidInput, "tls_handshake_timeout must be positive, got %v", c.TLSHandshakeTimeout)
+	}
+
+	if c.ResponseHeaderTimeout <= 0 {
+		return errors.NewInvalidInputf(errors.CodeInvalidInput, "response_header_timeout must be positive, got %v", c.ResponseHeaderTimeout)
+	}
+
+	if c.IdleConnTimeout <= 0 {
+		return errors.NewInvalidInputf(errors.CodeInvalidInput, "idle_conn_timeout must be positive, got %v", c.IdleConnTimeout)
+	}
+
+	if c.MaxIdleConns < 0 || c.MaxIdleConnsPerHost < 0 || c.MaxConnsPerHost < 0 {
+		return errors.NewInvalidInputf(errors.CodeInvalidInput, "max_idle_conns, max_idle_conns_per_host and max_conns_per_host must not be negative")
+	}
+
+	return nil
+}
@@ -29,9 +29,13 @@ func New(logger *slog.Logger, tracerProvider trace.TracerProvider, meterProvider
 		opt(&clientOpts)
 	}
 
+	if clientOpts.transport == nil {
+		clientOpts.transport = http.DefaultTransport
+	}
+
 	netc := &http.Client{
 		Timeout:   clientOpts.timeout,
-		Transport: otelhttp.NewTransport(http.DefaultTransport, otelhttp.WithTracerProvider(tracerProvider), otelhttp.WithMeterProvider(meterProvider)),
+		Transport: otelhttp.NewTransport(clientOpts.transport, otelhttp.WithTracerProvider(tracerProvider), otelhttp.WithMeterProvider(meterProvider)),
 	}
 
 	if clientOpts.retriable == nil {
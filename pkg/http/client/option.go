@@ -1,6 +1,7 @@
 package client
 
 import (
+	"net/http"
 	"time"
 
 	"github.com/gojek/heimdall/v7"
@@ -13,6 +14,7 @@ type options struct {
 	requestResponseLog bool
 	timeout            time.Duration
 	retriable          Retriable
+	transport          http.RoundTripper
 }
 
 type Option func(*options)
@@ -40,3 +42,11 @@ func WithRetriable(retriable Retriable) Option {
 		o.retriable = retriable
 	}
 }
+
+// WithTransport sets the transport of the client, usually the shared transport of the provider settings. The default
+// transport is used when it is nil.
+func WithTransport(transport http.RoundTripper) Option {
+	return func(o *options) {
+		o.transport = transport
+	}
+}
@@ -0,0 +1,237 @@
+package client
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"net/http"
+	"net/http/httptrace"
+	"sync"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+var _ http.RoundTripper = (*Transport)(nil)
+
+// Transport is the transport shared by the outbound http clients so that they share the same pool of connections,
+// bounded by the limits of the config. The connections of the pool are measured.
+type Transport struct {
+	config      Config
+	transport   *http.Transport
+	dialer      *net.Dialer
+	connections metric.Int64UpDownCounter
+	dials       metric.Int64Counter
+	inflight    metric.Int64UpDownCounter
+	hostsMtx    sync.Mutex
+	hosts       map[string]*hostConns
+}
+
+// hostConns are the connections open to a host by the clients which cannot use the transport itself.
+type hostConns struct {
+	slots chan struct{}
+	// users is the number of connections open or waiting to be dialed to the host.
+	users int
+}
+
+func NewTransport(meterProvider metric.MeterProvider, config Config) (*Transport, error) {
+	meter := meterProvider.Meter("github.com/SigNoz/signoz/pkg/http/client")
+
+	connections, err := meter.Int64UpDownCounter("signoz.http.client.connections", metric.WithDescription("Number of open connections of the outbound http clients, both in use and idle."))
+	if err != nil {
+		return nil, err
+	}
+
+	dials, err := meter.Int64Counter("signoz.http.client.dials", metric.WithDescription("Number of connections dialed by the outbound http clients, by result."))
+	if err != nil {
+		return nil, err
+	}
+
+	inflight, err := meter.Int64UpDownCounter("signoz.http.client.requests.inflight", metric.WithDescription("Number of requests of the outbound http clients waiting for their response."))
+	if err != nil {
+		return nil, err
+	}
+
+	transport := &Transport{
+		config:      config,
+		hosts:       make(map[string]*hostConns),
+		dialer:      &net.Dialer{Timeout: config.DialTimeout, KeepAlive: 30 * time.Second},
+		connections: connections,
+		dials:       dials,
+		inflight:    inflight,
+	}
+
+	transport.transport = &http.Transport{
+		Proxy:                 http.ProxyFromEnvironment,
+		DialContext:           transport.DialContext,
+		ForceAttemptHTTP2:     true,
+		TLSHandshakeTimeout:   config.TLSHandshakeTimeout,
+		ResponseHeaderTimeout: config.ResponseHeaderTimeout,
+		IdleConnTimeout:       config.IdleConnTimeout,
+		MaxIdleConns:          config.MaxIdleConns,
+		MaxIdleConnsPerHost:   config.MaxIdleConnsPerHost,
+		MaxConnsPerHost:       config.MaxConnsPerHost,
+		ExpectContinueTimeout: time.Second,
+	}
+
+	return transport, nil
+}
+
+func (transport *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	transport.inflight.Add(req.Context(), 1)
+	defer transport.inflight.Add(req.Context(), -1)
+
+	return transport.transport.RoundTrip(req)
+}
+
+// DialContext dials a connection with the dial timeout of the config, the connection is measured until it is closed.
+func (transport *Transport) DialContext(ctx context.Context, network string, address string) (net.Conn, error) {
+	conn, err := transport.dialer.DialContext(ctx, network, address)
+	if err != nil {
+		transport.dials.Add(ctx, 1, metric.WithAttributes(attribute.String("result", "error")))
+		return nil, err
+	}
+
+	transport.dials.Add(ctx, 1, metric.WithAttributes(attribute.String("result", "success")))
+	transport.connections.Add(ctx, 1)
+	return &measuredConn{Conn: conn, connections: transport.connections}, nil
+}
+
+// LimitedDialContext dials a connection as DialContext does, waiting while the host has max_conns_per_host connections
+// open. It is used by the clients which cannot use the transport itself, such as the ones of the alertmanager
+// notifiers, as their pool does not apply the limit.
+func (transport *Transport) LimitedDialContext(ctx context.Context, network string, address string) (net.Conn, error) {
+	if transport.config.MaxConnsPerHost <= 0 {
+		return transport.DialContext(ctx, network, address)
+	}
+
+	transport.hostsMtx.Lock()
+	host, ok := transport.hosts[address]
+	if !ok {
+		host = &hostConns{slots: make(chan struct{}, transport.config.MaxConnsPerHost)}
+		transport.hosts[address] = host
+	}
+	host.users++
+	transport.hostsMtx.Unlock()
+
+	select {
+	case host.slots <- struct{}{}:
+	case <-ctx.Done():
+		transport.releaseHost(address, host, false)
+		return nil, ctx.Err()
+	}
+
+	conn, err := transport.DialContext(ctx, network, address)
+	if err != nil {
+		transport.releaseHost(address, host, true)
+		return nil, err
+	}
+
+	return &limitedConn{Conn: conn, release: func() { transport.releaseHost(address, host, true) }}, nil
+}
+
+func (transport *Transport) releaseHost(address string, host *hostConns, acquired bool) {
+	if acquired {
+		<-host.slots
+	}
+
+	transport.hostsMtx.Lock()
+	defer transport.hostsMtx.Unlock()
+
+	host.users--
+	if host.users == 0 {
+		delete(transport.hosts, address)
+	}
+}
+
+// WithTimeouts returns a context applying the tls handshake and response header timeouts of the config to the requests
+// sent with it, the request being canceled once one of them is reached. It is used by the clients which cannot use
+// the transport itself, the requests of the context must be sent one after the other.
+func (transport *Transport) WithTimeouts(ctx context.Context) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancelCause(ctx)
+
+	var (
+		mtx   sync.Mutex
+		timer *time.Timer
+	)
+
+	arm := func(timeout time.Duration, cause error) {
+		mtx.Lock()
+		defer mtx.Unlock()
+
+		if timer != nil {
+			timer.Stop()
+		}
+		timer = time.AfterFunc(timeout, func() { cancel(cause) })
+	}
+
+	disarm := func() {
+		mtx.Lock()
+		defer mtx.Unlock()
+
+		if timer != nil {
+			timer.Stop()
+			timer = nil
+		}
+	}
+
+	ctx = httptrace.WithClientTrace(ctx, &httptrace.ClientTrace{
+		TLSHandshakeStart: func() {
+			arm(transport.config.TLSHandshakeTimeout, errors.Newf(errors.TypeTimeout, errors.CodeTimeout, "tls handshake timeout of %s reached", transport.config.TLSHandshakeTimeout))
+		},
+		TLSHandshakeDone: func(tls.ConnectionState, error) {
+			disarm()
+		},
+		WroteRequest: func(httptrace.WroteRequestInfo) {
+			arm(transport.config.ResponseHeaderTimeout, errors.Newf(errors.TypeTimeout, errors.CodeTimeout, "timeout of %s awaiting response headers reached", transport.config.ResponseHeaderTimeout))
+		},
+		GotFirstResponseByte: func() {
+			disarm()
+		},
+	})
+
+	return ctx, func() {
+		disarm()
+		cancel(context.Canceled)
+	}
+}
+
+// IdleConnTimeout returns the maximum time an idle connection is kept in the pool.
+func (transport *Transport) IdleConnTimeout() time.Duration {
+	return transport.transport.IdleConnTimeout
+}
+
+// CloseIdleConnections closes the idle connections of the pool.
+func (transport *Transport) CloseIdleConnections() {
+	transport.transport.CloseIdleConnections()
+}
+
+// measuredConn is a connection decrementing the number of open connections when it is closed.
+type measuredConn struct {
+	net.Conn
+	connections metric.Int64UpDownCounter
+	once        sync.Once
+}
+
+func (conn *measuredConn) Close() error {
+	conn.once.Do(func() {
+		conn.connections.Add(context.Background(), -1)
+	})
+
+	return conn.Conn.Close()
+}
+
+// limitedConn is a connection releasing its slot of the host once it is closed.
+type limitedConn struct {
+	net.Conn
+	release func()
+	once    sync.Once
+}
+
+func (conn *limitedConn) Close() error {
+	err := conn.Conn.Close()
+	conn.once.Do(conn.release)
+	return err
+}
@@ -0,0 +1,132 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func TestTransportResponseHeaderTimeout(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		<-release
+	}))
+	defer server.Close()
+	defer close(release)
+
+	config := newConfig().(Config)
+	config.ResponseHeaderTimeout = 50 * time.Millisecond
+
+	transport, err := NewTransport(sdkmetric.NewMeterProvider(), config)
+	require.NoError(t, err)
+
+	req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+	require.NoError(t, err)
+
+	_, err = (&http.Client{Transport: transport}).Do(req)
+	assert.ErrorContains(t, err, "timeout awaiting response headers")
+}
+
+func TestTransportConnections(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {}))
+	defer server.Close()
+
+	reader := sdkmetric.NewManualReader()
+	transport, err := NewTransport(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)), newConfig().(Config))
+	require.NoError(t, err)
+
+	httpClient := &http.Client{Transport: transport}
+	for range 3 {
+		res, err := httpClient.Get(server.URL)
+		require.NoError(t, err)
+		require.NoError(t, res.Body.Close())
+	}
+
+	// The connection is reused from the pool.
+	assert.Equal(t, int64(1), sum(t, reader, "signoz.http.client.dials"))
+	assert.Equal(t, int64(1), sum(t, reader, "signoz.http.client.connections"))
+
+	transport.CloseIdleConnections()
+	assert.Equal(t, int64(0), sum(t, reader, "signoz.http.client.connections"))
+}
+
+func TestTransportWithTimeouts(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		<-release
+	}))
+	defer server.Close()
+	defer close(release)
+
+	config := newConfig().(Config)
+	config.ResponseHeaderTimeout = 50 * time.Millisecond
+
+	transport, err := NewTransport(sdkmetric.NewMeterProvider(), config)
+	require.NoError(t, err)
+
+	// The client has its own pool, the timeouts of the transport are applied through the context of the request.
+	ctx, cancel := transport.WithTimeouts(context.Background())
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	require.NoError(t, err)
+
+	_, err = (&http.Client{Transport: &http.Transport{DialContext: transport.LimitedDialContext}}).Do(req)
+	assert.ErrorContains(t, err, "awaiting response headers")
+}
+
+func TestTransportLimitedDialContext(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {}))
+	defer server.Close()
+
+	config := newConfig().(Config)
+	config.MaxConnsPerHost = 1
+
+	transport, err := NewTransport(sdkmetric.NewMeterProvider(), config)
+	require.NoError(t, err)
+
+	address := server.Listener.Addr().String()
+	conn, err := transport.LimitedDialContext(context.Background(), "tcp", address)
+	require.NoError(t, err)
+
+	// The host has max_conns_per_host connections open, the dial waits until the context is done.
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err = transport.LimitedDialContext(ctx, "tcp", address)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	require.NoError(t, conn.Close())
+	conn, err = transport.LimitedDialContext(context.Background(), "tcp", address)
+	require.NoError(t, err)
+	require.NoError(t, conn.Close())
+	assert.Empty(t, transport.hosts)
+}
+
+func sum(t *testing.T, reader *sdkmetric.ManualReader, name string) int64 {
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if m.Name != name {
+				continue
+			}
+
+			var total int64
+			for _, point := range m.Data.(metricdata.Sum[int64]).DataPoints {
+				total += point.Value
+			}
+
+			return total
+		}
+	}
+
+	return 0
+}
@@ -3,19 +3,48 @@ package middleware
 import (
 	"log/slog"
 	"net/http"
+	"strings"
 	"time"
 
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/http/render"
 	"github.com/SigNoz/signoz/pkg/sharder"
 	"github.com/SigNoz/signoz/pkg/sqlstore"
 	"github.com/SigNoz/signoz/pkg/types"
 	"github.com/SigNoz/signoz/pkg/types/authtypes"
 	"github.com/SigNoz/signoz/pkg/valuer"
+	"github.com/gorilla/mux"
 )
 
 const (
 	apiKeyCrossOrgMessage string = "::API-KEY-CROSS-ORG::"
 )
 
+// apiKeyScopeRoute maps the routes under a prefix to the resource of the scopes granting access to them.
+type apiKeyScopeRoute struct {
+	prefix   string
+	resource string
+	// readOnly is true if every request to the routes is a read whatever its method, such as the POST of a query.
+	readOnly bool
+}
+
+var apiKeyScopeRoutes = []apiKeyScopeRoute{
+	{prefix: "/api/v1/dashboards", resource: types.APIKeyScopeResourceDashboards},
+	{prefix: "/api/v1/rules", resource: types.APIKeyScopeResourceAlerts},
+	{prefix: "/api/v1/testRule", resource: types.APIKeyScopeResourceAlerts},
+	{prefix: "/api/v1/alerts", resource: types.APIKeyScopeResourceAlerts},
+	{prefix: "/api/v1/downtime_schedules", resource: types.APIKeyScopeResourceAlerts},
+	{prefix: "/api/v1/channels", resource: types.APIKeyScopeResourceChannels},
+	{prefix: "/api/v1/testChannel", resource: types.APIKeyScopeResourceChannels},
+	{prefix: "/api/v1/explorer/views", resource: types.APIKeyScopeResourceViews},
+	{prefix: "/api/v1/logs/pipelines", resource: types.APIKeyScopeResourcePipelines},
+	{prefix: "/api/v1/query_range", resource: types.APIKeyScopeResourceTelemetry, readOnly: true},
+	{prefix: "/api/v3/query_range", resource: types.APIKeyScopeResourceTelemetry, readOnly: true},
+	{prefix: "/api/v4/query_range", resource: types.APIKeyScopeResourceTelemetry, readOnly: true},
+	{prefix: "/api/v5/query_range", resource: types.APIKeyScopeResourceTelemetry, readOnly: true},
+	{prefix: "/api/v1/telemetry/schema", resource: types.APIKeyScopeResourceTelemetry, readOnly: true},
+}
+
 type APIKey struct {
 	store   sqlstore.SQLStore
 	uuid    *authtypes.UUID
@@ -55,16 +84,25 @@ func (a *APIKey) Wrap(next http.Handler) http.Handler {
 			BunDB().
 			NewSelect().
 			Model(&apiKey).
-			Where("token = ?", apiKeyToken).
+			Where("token = ?", types.HashAPIKeyToken(apiKeyToken)).
 			Scan(r.Context())
 		if err != nil {
 			next.ServeHTTP(w, r)
 			return
 		}
 
-		// allow the APIKey if expires_at is not set
-		if apiKey.ExpiresAt.Before(time.Now()) && !apiKey.ExpiresAt.Equal(types.NEVER_EXPIRES) {
-			next.ServeHTTP(w, r)
+		if apiKey.Revoked {
+			render.Error(w, errors.Newf(errors.TypeUnauthenticated, types.ErrCodeAPIKeyRevoked, "api key %s... has been revoked", apiKey.TokenPrefix))
+			return
+		}
+
+		if apiKey.Expired(time.Now()) {
+			render.Error(w, errors.Newf(errors.TypeUnauthenticated, types.ErrCodeAPIKeyExpired, "api key %s... expired at %s", apiKey.TokenPrefix, apiKey.ExpiresAt.UTC().Format(time.RFC3339)))
+			return
+		}
+
+		if err := checkAPIKeyScopes(r, apiKey.Scopes); err != nil {
+			render.Error(w, err)
 			return
 		}
 
@@ -102,7 +140,7 @@ func (a *APIKey) Wrap(next http.Handler) http.Handler {
 		next.ServeHTTP(w, r)
 
 		apiKey.LastUsed = time.Now()
-		_, err = a.store.BunDB().NewUpdate().Model(&apiKey).Column("last_used").Where("token = ?", apiKeyToken).Where("revoked = false").Exec(r.Context())
+		_, err = a.store.BunDB().NewUpdate().Model(&apiKey).Column("last_used").Where("id = ?", apiKey.ID).Where("revoked = false").Exec(r.Context())
 		if err != nil {
 			a.logger.ErrorContext(r.Context(), "failed to update last used of api key", "error", err)
 		}
@@ -110,3 +148,57 @@ func (a *APIKey) Wrap(next http.Handler) http.Handler {
 	})
 
 }
+
+// checkAPIKeyScopes returns an error if the scopes do not grant access to the route of the request. The requests
+// to the routes which are not covered by any scope are rejected unless the api key has no scopes.
+func checkAPIKeyScopes(r *http.Request, scopes types.APIKeyScopes) error {
+	if len(scopes) == 0 {
+		return nil
+	}
+
+	route := routeOf(r)
+	action, resource, ok := apiKeyScopeOf(r.Method, route)
+	if !ok {
+		return errors.Newf(errors.TypeForbidden, types.ErrCodeAPIKeyScopeForbidden, "api key scopes do not grant access to %s %s", r.Method, route)
+	}
+
+	if scopes.Allows(action, resource) {
+		return nil
+	}
+
+	return errors.Newf(errors.TypeForbidden, types.ErrCodeAPIKeyScopeForbidden, "api key requires the %s:%s scope to %s %s", action, resource, r.Method, route)
+}
+
+// apiKeyScopeOf returns the action and the resource of the scope required by the method on the route, ok being
+// false when the route is not covered by any scope.
+func apiKeyScopeOf(method string, route string) (string, string, bool) {
+	for _, scopeRoute := range apiKeyScopeRoutes {
+		if route != scopeRoute.prefix && !strings.HasPrefix(route, scopeRoute.prefix+"/") {
+			continue
+		}
+
+		action := types.APIKeyScopeActionWrite
+		switch method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			action = types.APIKeyScopeActionRead
+		}
+		if scopeRoute.readOnly {
+			action = types.APIKeyScopeActionRead
+		}
+
+		return action, scopeRoute.resource, true
+	}
+
+	return "", "", false
+}
+
+// routeOf returns the path template of the route of the request, its path when it has no route.
+func routeOf(r *http.Request) string {
+	if current := mux.CurrentRoute(r); current != nil {
+		if template, err := current.GetPathTemplate(); err == nil {
+			return template
+		}
+	}
+
+	return r.URL.Path
+}
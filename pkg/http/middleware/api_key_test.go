@@ -0,0 +1,51 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/types"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckAPIKeyScopes(t *testing.T) {
+	testCases := []struct {
+		name    string
+		scopes  types.APIKeyScopes
+		method  string
+		path    string
+		allowed bool
+	}{
+		{name: "NoScopes", scopes: nil, method: http.MethodDelete, path: "/api/v1/user/{id}", allowed: true},
+		{name: "Read", scopes: types.APIKeyScopes{"read:dashboards"}, method: http.MethodGet, path: "/api/v1/dashboards/{id}", allowed: true},
+		{name: "ReadWithoutWrite", scopes: types.APIKeyScopes{"read:dashboards"}, method: http.MethodPut, path: "/api/v1/dashboards/{id}", allowed: false},
+		{name: "WriteGrantsRead", scopes: types.APIKeyScopes{"write:alerts"}, method: http.MethodGet, path: "/api/v1/rules", allowed: true},
+		{name: "OtherResource", scopes: types.APIKeyScopes{"write:alerts"}, method: http.MethodGet, path: "/api/v1/dashboards", allowed: false},
+		{name: "ReadOnlyRoute", scopes: types.APIKeyScopes{"read:telemetry"}, method: http.MethodPost, path: "/api/v5/query_range", allowed: true},
+		{name: "UncoveredRoute", scopes: types.APIKeyScopes{"write:dashboards"}, method: http.MethodGet, path: "/api/v1/user", allowed: false},
+		{name: "PrefixBoundary", scopes: types.APIKeyScopes{"read:alerts"}, method: http.MethodGet, path: "/api/v1/alertsfoo", allowed: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var err error
+			router := mux.NewRouter()
+			router.HandleFunc(tc.path, func(rw http.ResponseWriter, req *http.Request) {
+				err = checkAPIKeyScopes(req, tc.scopes)
+			})
+
+			router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(tc.method, tc.path, nil))
+			if tc.allowed {
+				assert.NoError(t, err)
+				return
+			}
+
+			if assert.Error(t, err) {
+				assert.True(t, errors.Ast(err, errors.TypeForbidden))
+			}
+		})
+	}
+}
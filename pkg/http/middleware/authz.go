@@ -1,11 +1,13 @@
 package middleware
 
 import (
+	"context"
 	"log/slog"
 	"net/http"
 
 	"github.com/SigNoz/signoz/pkg/http/render"
 	"github.com/SigNoz/signoz/pkg/types/authtypes"
+	"github.com/SigNoz/signoz/pkg/types/rbactypes"
 	"github.com/gorilla/mux"
 )
 
@@ -13,16 +15,26 @@ const (
 	authzDeniedMessage string = "::AUTHZ-DENIED::"
 )
 
+// Authorizer authorizes the actions of the subjects on the resources.
+type Authorizer interface {
+	Authorize(ctx context.Context, subject rbactypes.Subject, resource string, action string) error
+}
+
 type AuthZ struct {
-	logger *slog.Logger
+	logger     *slog.Logger
+	authorizer Authorizer
 }
 
-func NewAuthZ(logger *slog.Logger) *AuthZ {
+func NewAuthZ(logger *slog.Logger, authorizer Authorizer) *AuthZ {
 	if logger == nil {
 		panic("cannot build authz middleware, logger is empty")
 	}
 
-	return &AuthZ{logger: logger}
+	if authorizer == nil {
+		panic("cannot build authz middleware, authorizer is empty")
+	}
+
+	return &AuthZ{logger: logger, authorizer: authorizer}
 }
 
 func (middleware *AuthZ) ViewAccess(next http.HandlerFunc) http.HandlerFunc {
@@ -79,6 +91,31 @@ func (middleware *AuthZ) AdminAccess(next http.HandlerFunc) http.HandlerFunc {
 	})
 }
 
+// Permission allows the request if the permissions of the user allow the action on the resource.
+func (middleware *AuthZ) Permission(resource string, action string, next http.HandlerFunc) http.HandlerFunc {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		claims, err := authtypes.ClaimsFromContext(req.Context())
+		if err != nil {
+			render.Error(rw, err)
+			return
+		}
+
+		subject, err := rbactypes.NewSubjectFromClaims(claims)
+		if err != nil {
+			render.Error(rw, err)
+			return
+		}
+
+		if err := middleware.authorizer.Authorize(req.Context(), subject, resource, action); err != nil {
+			middleware.logger.WarnContext(req.Context(), authzDeniedMessage, "claims", claims, "resource", resource, "action", action)
+			render.Error(rw, err)
+			return
+		}
+
+		next(rw, req)
+	})
+}
+
 func (middleware *AuthZ) SelfAccess(next http.HandlerFunc) http.HandlerFunc {
 	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
 		claims, err := authtypes.ClaimsFromContext(req.Context())
@@ -3,14 +3,18 @@ package middleware
 import (
 	"bytes"
 	"context"
+	"io"
 	"log/slog"
+	"math/rand/v2"
 	"net"
 	"net/http"
 	"net/url"
 	"strings"
 	"time"
 
+	"github.com/SigNoz/signoz/pkg/apiserver"
 	"github.com/SigNoz/signoz/pkg/query-service/common"
+	"github.com/SigNoz/signoz/pkg/telemetrystore"
 	"github.com/SigNoz/signoz/pkg/types/authtypes"
 	"github.com/gorilla/mux"
 	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
@@ -23,17 +27,23 @@ const (
 type Logging struct {
 	logger         *slog.Logger
 	excludedRoutes map[string]struct{}
+	sampleRate     float64
+	body           apiserver.LoggingBody
+	redactor       *redactor
 }
 
-func NewLogging(logger *slog.Logger, excludedRoutes []string) *Logging {
+func NewLogging(logger *slog.Logger, config apiserver.Logging) *Logging {
 	excludedRoutesMap := make(map[string]struct{})
-	for _, route := range excludedRoutes {
+	for _, route := range config.ExcludedRoutes {
 		excludedRoutesMap[route] = struct{}{}
 	}
 
 	return &Logging{
 		logger:         logger.With("pkg", pkgname),
 		excludedRoutes: excludedRoutesMap,
+		sampleRate:     config.SampleRate,
+		body:           config.Body,
+		redactor:       newRedactor(config.Body.RedactedFields),
 	}
 }
 
@@ -47,6 +57,7 @@ func (middleware *Logging) Wrap(next http.Handler) http.Handler {
 		}
 
 		fields := []any{
+			string(semconv.HTTPRequestMethodKey), req.Method,
 			string(semconv.ClientAddressKey), req.RemoteAddr,
 			string(semconv.UserAgentOriginalKey), req.UserAgent(),
 			string(semconv.ServerAddressKey), host,
@@ -56,10 +67,27 @@ func (middleware *Logging) Wrap(next http.Handler) http.Handler {
 		}
 
 		logCommentKVs := middleware.getLogCommentKVs(req)
-		req = req.WithContext(context.WithValue(req.Context(), common.LogCommentKey, logCommentKVs))
+		ctx := context.WithValue(req.Context(), common.LogCommentKey, logCommentKVs)
+		if claims, err := authtypes.ClaimsFromContext(ctx); err == nil {
+			ctx = telemetrystore.NewContextWithQueryTag(ctx, telemetrystore.NewQueryTagFromClaims(claims, queryKindFromSource(logCommentKVs["source"])))
+			fields = append(fields, "org_id", claims.OrgID)
+		}
+		req = req.WithContext(ctx)
+
+		// The body of the request is captured as it is read by the handler, it is neither buffered nor read twice.
+		var requestBody *cappedBuffer
+		if middleware.body.Enabled && req.Body != nil && req.Body != http.NoBody {
+			requestBody = &cappedBuffer{max: middleware.body.MaxSize}
+			req.Body = &teeReadCloser{Reader: io.TeeReader(req.Body, requestBody), Closer: req.Body}
+		}
 
-		badResponseBuffer := new(bytes.Buffer)
-		writer := newBadResponseLoggingWriter(rw, badResponseBuffer)
+		responseBody := new(bytes.Buffer)
+		var writer badResponseLoggingWriter
+		if middleware.body.Enabled {
+			writer = newResponseLoggingWriter(rw, responseBody, true, middleware.body.MaxSize)
+		} else {
+			writer = newBadResponseLoggingWriter(rw, responseBody)
+		}
 		next.ServeHTTP(writer, req)
 
 		// if the path is in the excludedRoutes map, don't log
@@ -68,17 +96,28 @@ func (middleware *Logging) Wrap(next http.Handler) http.Handler {
 		}
 
 		statusCode, err := writer.StatusCode(), writer.WriteError()
+
+		// The failed requests are always logged, the successful ones are sampled.
+		if err == nil && statusCode < 400 && middleware.sampleRate < 1 && rand.Float64() >= middleware.sampleRate {
+			return
+		}
+
 		fields = append(fields,
 			string(semconv.HTTPResponseStatusCodeKey), statusCode,
 			string(semconv.HTTPServerRequestDurationName), time.Since(start),
 		)
+
+		if requestBody != nil && requestBody.Len() != 0 {
+			fields = append(fields, "request.body", middleware.redactor.redact(requestBody.String()))
+		}
+
 		if err != nil {
 			fields = append(fields, "error", err)
 			middleware.logger.ErrorContext(req.Context(), logMessage, fields...)
 		} else {
-			// when the status code is 400 or >=500, and the response body is not empty.
-			if badResponseBuffer.Len() != 0 {
-				fields = append(fields, "response.body", badResponseBuffer.String())
+			// when the status code is 400 or >=500, and the response body is not empty, or when the bodies are logged.
+			if responseBody.Len() != 0 {
+				fields = append(fields, "response.body", middleware.redactor.redact(responseBody.String()))
 			}
 
 			middleware.logger.InfoContext(req.Context(), logMessage, fields...)
@@ -149,3 +188,17 @@ func (middleware *Logging) getLogCommentKVs(r *http.Request) map[string]string {
 	}
 	return kvs
 }
+
+// queryKindFromSource returns the kind of the queries issued from the page of the frontend the request comes from.
+func queryKindFromSource(source string) string {
+	switch source {
+	case "dashboards":
+		return telemetrystore.QueryKindDashboard
+	case "alerts":
+		return telemetrystore.QueryKindAlert
+	case "logs-explorer", "traces-explorer", "metrics-explorer":
+		return telemetrystore.QueryKindExplorer
+	default:
+		return telemetrystore.QueryKindAPI
+	}
+}
@@ -0,0 +1,91 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/SigNoz/signoz/pkg/apiserver"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedact(t *testing.T) {
+	redactor := newRedactor([]string{"password", "secret", "token", "key", "jwt"})
+
+	testCases := []struct {
+		name     string
+		body     string
+		expected string
+	}{
+		{name: "String", body: `{"email":"a@b.c","password":"p\"ss"}`, expected: `{"email":"a@b.c","password":"[REDACTED]"}`},
+		{name: "CaseInsensitive", body: `{"Token": "abc", "nested": {"password" : 123}}`, expected: `{"Token": "[REDACTED]", "nested": {"password" : "[REDACTED]"}}`},
+		{name: "Truncated", body: `{"token":"abcdef...`, expected: `{"token":"[REDACTED]"`},
+		{name: "Form", body: `email=a&password=secret&x=1`, expected: `email=a&password=[REDACTED]&x=1`},
+		{name: "OldAndNewPassword", body: `{"oldPassword":"a","newPassword":"b"}`, expected: `{"oldPassword":"[REDACTED]","newPassword":"[REDACTED]"}`},
+		{name: "RefreshToken", body: `{"refreshToken":"abc","userId":"1"}`, expected: `{"refreshToken":"[REDACTED]","userId":"1"}`},
+		{name: "ClientSecret", body: `{"config":{"clientId":"id","clientSecret":"s"}}`, expected: `{"config":{"clientId":"id","clientSecret":"[REDACTED]"}}`},
+		{name: "Array", body: `{"passwords":["a","b"],"x":1}`, expected: `{"passwords":"[REDACTED]","x":1}`},
+		{name: "Object", body: `{"secrets":{"name":"n","apiKey":"k"}}`, expected: `{"secrets":{"name":"n","apiKey":"[REDACTED]"}}`},
+		{name: "FormSubstring", body: `grant_type=refresh&refresh_token=abc`, expected: `grant_type=refresh&refresh_token=[REDACTED]`},
+		{name: "Untouched", body: `{"email":"a@b.c","name":"n"}`, expected: `{"email":"a@b.c","name":"n"}`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, redactor.redact(tc.body))
+		})
+	}
+
+	assert.Equal(t, `{"password":"p"}`, newRedactor(nil).redact(`{"password":"p"}`))
+}
+
+func TestLoggingBody(t *testing.T) {
+	buffer := new(bytes.Buffer)
+	logger := slog.New(slog.NewJSONHandler(buffer, nil))
+
+	router := mux.NewRouter()
+	router.Use(NewLogging(logger, apiserver.Logging{
+		SampleRate: 1,
+		Body:       apiserver.LoggingBody{Enabled: true, MaxSize: 32, RedactedFields: []string{"password"}},
+	}).Wrap)
+	router.HandleFunc("/api/v1/login", func(rw http.ResponseWriter, req *http.Request) {
+		body, err := io.ReadAll(req.Body)
+		require.NoError(t, err)
+		// The handler reads the whole body regardless of the capture.
+		assert.Len(t, body, 64)
+		_, _ = rw.Write([]byte(`{"token":"abc"}`))
+	}).Methods(http.MethodPost)
+
+	body := `{"password":"secret","padding":"` + strings.Repeat("x", 30) + `"}`
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/api/v1/login", strings.NewReader(body)))
+
+	record := map[string]any{}
+	require.NoError(t, json.Unmarshal(buffer.Bytes(), &record))
+	assert.Equal(t, http.MethodPost, record["http.request.method"])
+	assert.Equal(t, `{"password":"[REDACTED]","padding":"...`, record["request.body"])
+	assert.Equal(t, `{"token":"abc"}`, record["response.body"])
+}
+
+func TestLoggingSampling(t *testing.T) {
+	buffer := new(bytes.Buffer)
+	logger := slog.New(slog.NewJSONHandler(buffer, nil))
+
+	router := mux.NewRouter()
+	router.Use(NewLogging(logger, apiserver.Logging{SampleRate: 0, Body: apiserver.LoggingBody{MaxSize: 32}}).Wrap)
+	router.HandleFunc("/ok", func(rw http.ResponseWriter, req *http.Request) { rw.WriteHeader(http.StatusNoContent) })
+	router.HandleFunc("/fail", func(rw http.ResponseWriter, req *http.Request) { rw.WriteHeader(http.StatusInternalServerError) })
+
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/ok", nil))
+	assert.Zero(t, buffer.Len())
+
+	// The failed requests are always logged.
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/fail", nil))
+	assert.NotZero(t, buffer.Len())
+}
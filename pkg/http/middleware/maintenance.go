@@ -0,0 +1,38 @@
+package middleware
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/SigNoz/signoz/pkg/http/render"
+	"github.com/SigNoz/signoz/pkg/maintenance"
+)
+
+// Maintenance rejects the writes in maintenance mode with a 503. The routes are marked as writes explicitly with
+// Write, the routes which only read keep being served whatever their method, such as the query range routes which
+// read with a POST.
+type Maintenance struct {
+	logger      *slog.Logger
+	maintenance *maintenance.Maintenance
+}
+
+func NewMaintenance(logger *slog.Logger, maintenance *maintenance.Maintenance) *Maintenance {
+	return &Maintenance{
+		logger:      logger.With("pkg", pkgname),
+		maintenance: maintenance,
+	}
+}
+
+// Write marks the handler as a write, which is rejected in maintenance mode. The route setting maintenance mode must
+// not be marked so that maintenance mode can be disabled.
+func (middleware *Maintenance) Write(next http.HandlerFunc) http.HandlerFunc {
+	return func(rw http.ResponseWriter, req *http.Request) {
+		if err := middleware.maintenance.Check(); err != nil {
+			middleware.logger.DebugContext(req.Context(), "rejected write in maintenance mode", "path", req.URL.Path, "method", req.Method)
+			render.Error(rw, err)
+			return
+		}
+
+		next(rw, req)
+	}
+}
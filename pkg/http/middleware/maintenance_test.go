@@ -0,0 +1,61 @@
+package middleware
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/SigNoz/signoz/pkg/factory/factorytest"
+	"github.com/SigNoz/signoz/pkg/maintenance"
+	"github.com/SigNoz/signoz/pkg/sqlstore/sqlstoretest"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMaintenance(t *testing.T) {
+	ctx := context.Background()
+	store := sqlstoretest.NewSQLite(t)
+	_, err := store.BunDB().NewCreateTable().Model(new(maintenance.StorableMaintenance)).Exec(ctx)
+	require.NoError(t, err)
+
+	mode := maintenance.New(factorytest.NewSettings(), maintenance.Config{}, store)
+	require.NoError(t, mode.Set(ctx, true))
+
+	middleware := NewMaintenance(slog.New(slog.NewTextHandler(io.Discard, nil)), mode)
+	handler := func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusNoContent)
+	}
+
+	router := mux.NewRouter()
+	router.HandleFunc("/api/v1/dashboards/{id}", handler).Methods(http.MethodGet)
+	router.HandleFunc("/api/v1/dashboards/{id}", middleware.Write(handler)).Methods(http.MethodPut)
+	router.HandleFunc("/api/v5/query_range", handler).Methods(http.MethodPost)
+
+	testCases := []struct {
+		name     string
+		method   string
+		path     string
+		expected int
+	}{
+		{name: "Read", method: http.MethodGet, path: "/api/v1/dashboards/1", expected: http.StatusNoContent},
+		{name: "Write", method: http.MethodPut, path: "/api/v1/dashboards/1", expected: http.StatusServiceUnavailable},
+		{name: "ReadWithPost", method: http.MethodPost, path: "/api/v5/query_range", expected: http.StatusNoContent},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			rw := httptest.NewRecorder()
+			router.ServeHTTP(rw, httptest.NewRequest(tc.method, tc.path, nil))
+			assert.Equal(t, tc.expected, rw.Code)
+		})
+	}
+
+	require.NoError(t, mode.Set(ctx, false))
+	rw := httptest.NewRecorder()
+	router.ServeHTTP(rw, httptest.NewRequest(http.MethodPut, "/api/v1/dashboards/1", nil))
+	assert.Equal(t, http.StatusNoContent, rw.Code)
+}
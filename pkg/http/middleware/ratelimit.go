@@ -0,0 +1,225 @@
+package middleware
+
+import (
+	"encoding/json"
+	"hash/fnv"
+	"log/slog"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/apiserver"
+	"github.com/SigNoz/signoz/pkg/cache"
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/http/render"
+	"github.com/SigNoz/signoz/pkg/types"
+	"github.com/SigNoz/signoz/pkg/types/authtypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+)
+
+const (
+	rateLimitCacheNamespace string = "ratelimit"
+
+	// rateLimitLocks is the number of locks serializing the updates of the buckets, shared by the buckets whose keys
+	// hash to the same lock so that the locks do not grow with the number of buckets.
+	rateLimitLocks int = 256
+)
+
+var (
+	ErrCodeRateLimited = errors.MustNewCode("rate_limited")
+)
+
+// RateLimit applies a token bucket rate limit to the requests of every tenant per route, the requests authenticated
+// by an api key being limited per api key as well. The buckets are stored in the cache so that the limits are shared
+// across replicas, and expire once they are full again. The read and the write of a bucket are not atomic across
+// replicas, hence concurrent requests to different replicas can briefly exceed the limit.
+type RateLimit struct {
+	logger       *slog.Logger
+	cache        cache.Cache
+	defaultLimit apiserver.Limit
+	routes       map[string]apiserver.Limit
+	apiKeys      apiserver.APIKeysRateLimit
+	// locks serializes the updates of a bucket within this replica.
+	locks [rateLimitLocks]sync.Mutex
+}
+
+func NewRateLimit(logger *slog.Logger, cache cache.Cache, config apiserver.RateLimit) *RateLimit {
+	return &RateLimit{
+		logger:       logger.With("pkg", pkgname),
+		cache:        cache.WithNamespace(rateLimitCacheNamespace),
+		defaultLimit: config.Default,
+		routes:       config.Routes,
+		apiKeys:      config.APIKeys,
+	}
+}
+
+func (middleware *RateLimit) Wrap(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		claims, err := authtypes.ClaimsFromContext(req.Context())
+		if err != nil {
+			next.ServeHTTP(rw, req)
+			return
+		}
+
+		orgID, err := valuer.NewUUID(claims.OrgID)
+		if err != nil {
+			next.ServeHTTP(rw, req)
+			return
+		}
+
+		route := routeOf(req)
+
+		var result rateLimitResult
+		taken := false
+		for _, bucket := range middleware.bucketsOf(req, route) {
+			bucketResult, ok := middleware.take(req, orgID, bucket.key, bucket.limit)
+			if !ok {
+				continue
+			}
+
+			// The headers are the ones of the most restrictive bucket, the first bucket denying the request.
+			if !taken || !bucketResult.allowed || bucketResult.remaining < result.remaining {
+				result = bucketResult
+				taken = true
+			}
+
+			if !bucketResult.allowed {
+				break
+			}
+		}
+
+		if !taken {
+			next.ServeHTTP(rw, req)
+			return
+		}
+
+		rw.Header().Set("X-RateLimit-Limit", strconv.Itoa(result.limit.Burst))
+		rw.Header().Set("X-RateLimit-Remaining", strconv.Itoa(int(math.Floor(result.remaining))))
+		rw.Header().Set("X-RateLimit-Reset", strconv.Itoa(int(math.Ceil(result.reset.Seconds()))))
+
+		if !result.allowed {
+			rw.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(result.retryAfter.Seconds()))))
+			render.Error(rw, errors.Newf(errors.TypeTooManyRequests, ErrCodeRateLimited, "rate limit exceeded for route %q, retry after %s", route, result.retryAfter.String()))
+			return
+		}
+
+		next.ServeHTTP(rw, req)
+	})
+}
+
+type rateLimitBucketKey struct {
+	key   string
+	limit apiserver.Limit
+}
+
+// bucketsOf returns the keys of the buckets of the request along with their limits. The requests of a tenant share the
+// bucket of the tenant for the route, whether they are authenticated by an api key or not, so that a tenant does not
+// multiply its limits by creating api keys. The requests authenticated by an api key take a token from the bucket of
+// the api key for the scope required by the route first.
+func (middleware *RateLimit) bucketsOf(req *http.Request, route string) []rateLimitBucketKey {
+	routeLimit, ok := middleware.routes[route]
+	if !ok {
+		routeLimit = middleware.defaultLimit
+	}
+	routeBucket := rateLimitBucketKey{key: route, limit: routeLimit}
+
+	token, ok := authtypes.UUIDFromContext(req.Context())
+	if !ok || token == "" {
+		return []rateLimitBucketKey{routeBucket}
+	}
+
+	// The token is hashed so that it is not stored in the cache in plaintext.
+	key := "apikey::" + types.HashAPIKeyToken(token)
+	if action, resource, ok := apiKeyScopeOf(req.Method, route); ok {
+		scope := action + ":" + resource
+		if limit, ok := middleware.apiKeys.Scopes[scope]; ok {
+			return []rateLimitBucketKey{{key: key + "::" + scope, limit: limit}, routeBucket}
+		}
+	}
+
+	return []rateLimitBucketKey{{key: key, limit: middleware.apiKeys.Default}, routeBucket}
+}
+
+type rateLimitResult struct {
+	allowed bool
+	// limit is the limit of the bucket.
+	limit apiserver.Limit
+	// remaining is the number of tokens left in the bucket.
+	remaining float64
+	// reset is the time after which the bucket is full again.
+	reset time.Duration
+	// retryAfter is the time after which a token is available when the request is not allowed.
+	retryAfter time.Duration
+}
+
+// take takes a token from the bucket of the key for the tenant. It returns false when the bucket could not be read,
+// in which case the request is allowed.
+func (middleware *RateLimit) take(req *http.Request, orgID valuer.UUID, key string, limit apiserver.Limit) (rateLimitResult, bool) {
+	ctx := req.Context()
+	hash := fnv.New32a()
+	_, _ = hash.Write([]byte(orgID.StringValue() + "::" + key))
+
+	lock := &middleware.locks[hash.Sum32()%uint32(rateLimitLocks)]
+	lock.Lock()
+	defer lock.Unlock()
+
+	now := time.Now()
+	bucket := new(rateLimitBucket)
+	if err := middleware.cache.Get(ctx, orgID, key, bucket, false); err != nil {
+		if !errors.Ast(err, errors.TypeNotFound) {
+			// Fail open so that the cache being unavailable does not take the api down.
+			middleware.logger.WarnContext(ctx, "failed to get rate limit bucket, allowing request", "bucket", key, "error", err)
+			return rateLimitResult{}, false
+		}
+
+		bucket = &rateLimitBucket{Tokens: float64(limit.Burst), UpdatedAt: now}
+	}
+
+	bucket.refill(now, limit)
+
+	allowed := bucket.Tokens >= 1
+	if allowed {
+		bucket.Tokens--
+	}
+
+	// The bucket expires once it would have been refilled completely.
+	reset := time.Duration((float64(limit.Burst) - bucket.Tokens) / limit.Rate * float64(time.Second))
+	ttl := reset
+	if ttl < time.Second {
+		ttl = time.Second
+	}
+
+	if err := middleware.cache.Set(ctx, orgID, key, bucket, ttl); err != nil {
+		middleware.logger.WarnContext(ctx, "failed to set rate limit bucket", "bucket", key, "error", err)
+	}
+
+	result := rateLimitResult{allowed: allowed, limit: limit, remaining: bucket.Tokens, reset: reset}
+	if !allowed {
+		result.retryAfter = time.Duration((1 - bucket.Tokens) / limit.Rate * float64(time.Second))
+	}
+
+	return result, true
+}
+
+type rateLimitBucket struct {
+	Tokens    float64   `json:"tokens"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+func (bucket *rateLimitBucket) refill(now time.Time, limit apiserver.Limit) {
+	elapsed := now.Sub(bucket.UpdatedAt).Seconds()
+	if elapsed > 0 {
+		bucket.Tokens = math.Min(float64(limit.Burst), bucket.Tokens+elapsed*limit.Rate)
+	}
+	bucket.UpdatedAt = now
+}
+
+func (bucket *rateLimitBucket) MarshalBinary() ([]byte, error) {
+	return json.Marshal(bucket)
+}
+
+func (bucket *rateLimitBucket) UnmarshalBinary(data []byte) error {
+	return json.Unmarshal(data, bucket)
+}
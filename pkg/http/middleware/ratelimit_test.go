@@ -0,0 +1,182 @@
+package middleware
+
+import (
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/apiserver"
+	"github.com/SigNoz/signoz/pkg/cache"
+	"github.com/SigNoz/signoz/pkg/cache/cachetest"
+	"github.com/SigNoz/signoz/pkg/types/authtypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimit(t *testing.T) {
+	c, err := cachetest.New(cache.Config{Provider: "memory", Memory: cache.Memory{TTL: time.Minute, CleanupInterval: time.Minute}})
+	require.NoError(t, err)
+
+	m := NewRateLimit(slog.New(slog.NewTextHandler(io.Discard, nil)), c, apiserver.RateLimit{
+		Enabled: true,
+		Default: apiserver.Limit{Rate: 1, Burst: 2},
+		Routes: map[string]apiserver.Limit{
+			"/api/v1/dashboards/{id}": {Rate: 0.1, Burst: 1},
+		},
+	})
+
+	router := mux.NewRouter()
+	router.Use(m.Wrap)
+	router.HandleFunc("/api/v1/dashboards/{id}", func(rw http.ResponseWriter, _ *http.Request) { rw.WriteHeader(http.StatusNoContent) })
+	router.HandleFunc("/api/v1/dashboards", func(rw http.ResponseWriter, _ *http.Request) { rw.WriteHeader(http.StatusNoContent) })
+
+	do := func(orgID valuer.UUID, path string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		if !orgID.IsZero() {
+			req = req.WithContext(authtypes.NewContextWithClaims(req.Context(), authtypes.Claims{OrgID: orgID.StringValue()}))
+		}
+
+		rw := httptest.NewRecorder()
+		router.ServeHTTP(rw, req)
+		return rw
+	}
+
+	orgID := valuer.GenerateUUID()
+	otherOrgID := valuer.GenerateUUID()
+
+	// the default limit allows a burst of 2
+	assert.Equal(t, http.StatusNoContent, do(orgID, "/api/v1/dashboards").Code)
+	assert.Equal(t, http.StatusNoContent, do(orgID, "/api/v1/dashboards").Code)
+	rw := do(orgID, "/api/v1/dashboards")
+	assert.Equal(t, http.StatusTooManyRequests, rw.Code)
+	assert.Equal(t, "1", rw.Header().Get("Retry-After"))
+
+	// the route limit is keyed by the path template and allows a burst of 1
+	assert.Equal(t, http.StatusNoContent, do(orgID, "/api/v1/dashboards/1").Code)
+	rw = do(orgID, "/api/v1/dashboards/2")
+	assert.Equal(t, http.StatusTooManyRequests, rw.Code)
+	assert.Equal(t, "10", rw.Header().Get("Retry-After"))
+
+	// other tenants are not affected
+	assert.Equal(t, http.StatusNoContent, do(otherOrgID, "/api/v1/dashboards").Code)
+	assert.Equal(t, http.StatusNoContent, do(otherOrgID, "/api/v1/dashboards/1").Code)
+
+	// unauthenticated requests are not rate limited
+	for i := 0; i < 5; i++ {
+		assert.Equal(t, http.StatusNoContent, do(valuer.UUID{}, "/api/v1/dashboards").Code)
+	}
+}
+
+func TestRateLimitAPIKeys(t *testing.T) {
+	c, err := cachetest.New(cache.Config{Provider: "memory", Memory: cache.Memory{TTL: time.Minute, CleanupInterval: time.Minute}})
+	require.NoError(t, err)
+
+	m := NewRateLimit(slog.New(slog.NewTextHandler(io.Discard, nil)), c, apiserver.RateLimit{
+		Enabled: true,
+		Default: apiserver.Limit{Rate: 0.01, Burst: 5},
+		APIKeys: apiserver.APIKeysRateLimit{
+			Default: apiserver.Limit{Rate: 1, Burst: 2},
+			Scopes: map[string]apiserver.Limit{
+				"read:telemetry": {Rate: 0.5, Burst: 3},
+			},
+		},
+	})
+
+	router := mux.NewRouter()
+	router.Use(m.Wrap)
+	router.HandleFunc("/api/v1/dashboards", func(rw http.ResponseWriter, _ *http.Request) { rw.WriteHeader(http.StatusNoContent) })
+	router.HandleFunc("/api/v5/query_range", func(rw http.ResponseWriter, _ *http.Request) { rw.WriteHeader(http.StatusNoContent) })
+
+	orgID := valuer.GenerateUUID()
+	do := func(token string, method string, path string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, nil)
+		ctx := authtypes.NewContextWithClaims(req.Context(), authtypes.Claims{OrgID: orgID.StringValue()})
+		if token != "" {
+			ctx = authtypes.NewContextWithUUID(ctx, token)
+		}
+
+		rw := httptest.NewRecorder()
+		router.ServeHTTP(rw, req.WithContext(ctx))
+		return rw
+	}
+
+	// the api key limit allows a burst of 2 on the routes which are not covered by a scope limit
+	rw := do("key", http.MethodGet, "/api/v1/dashboards")
+	assert.Equal(t, http.StatusNoContent, rw.Code)
+	assert.Equal(t, "2", rw.Header().Get("X-RateLimit-Limit"))
+	assert.Equal(t, "1", rw.Header().Get("X-RateLimit-Remaining"))
+	assert.Equal(t, "1", rw.Header().Get("X-RateLimit-Reset"))
+	assert.Equal(t, http.StatusNoContent, do("key", http.MethodGet, "/api/v1/dashboards").Code)
+	rw = do("key", http.MethodGet, "/api/v1/dashboards")
+	assert.Equal(t, http.StatusTooManyRequests, rw.Code)
+	assert.Equal(t, "0", rw.Header().Get("X-RateLimit-Remaining"))
+	assert.Equal(t, "2", rw.Header().Get("X-RateLimit-Reset"))
+	assert.Equal(t, "1", rw.Header().Get("Retry-After"))
+
+	// the routes requiring a scope with a limit have their own bucket
+	for i := 0; i < 3; i++ {
+		rw = do("key", http.MethodPost, "/api/v5/query_range")
+		assert.Equal(t, http.StatusNoContent, rw.Code)
+		assert.Equal(t, "3", rw.Header().Get("X-RateLimit-Limit"))
+		assert.Equal(t, strconv.Itoa(2-i), rw.Header().Get("X-RateLimit-Remaining"))
+	}
+	rw = do("key", http.MethodPost, "/api/v5/query_range")
+	assert.Equal(t, http.StatusTooManyRequests, rw.Code)
+	assert.Equal(t, "2", rw.Header().Get("Retry-After"))
+
+	// other api keys and the requests of the tenant without an api key are not affected by the bucket of the api key
+	assert.Equal(t, http.StatusNoContent, do("other", http.MethodGet, "/api/v1/dashboards").Code)
+	rw = do("", http.MethodGet, "/api/v1/dashboards")
+	assert.Equal(t, http.StatusNoContent, rw.Code)
+	assert.Equal(t, "5", rw.Header().Get("X-RateLimit-Limit"))
+	assert.Equal(t, "1", rw.Header().Get("X-RateLimit-Remaining"))
+
+	// but they all share the bucket of the tenant, new api keys do not add to the limit of the tenant
+	assert.Equal(t, http.StatusNoContent, do("third", http.MethodGet, "/api/v1/dashboards").Code)
+	rw = do("fourth", http.MethodGet, "/api/v1/dashboards")
+	assert.Equal(t, http.StatusTooManyRequests, rw.Code)
+	assert.Equal(t, "5", rw.Header().Get("X-RateLimit-Limit"))
+}
+
+func TestRateLimitAPIKeysRoutes(t *testing.T) {
+	c, err := cachetest.New(cache.Config{Provider: "memory", Memory: cache.Memory{TTL: time.Minute, CleanupInterval: time.Minute}})
+	require.NoError(t, err)
+
+	m := NewRateLimit(slog.New(slog.NewTextHandler(io.Discard, nil)), c, apiserver.RateLimit{
+		Enabled: true,
+		Default: apiserver.Limit{Rate: 1, Burst: 10},
+		Routes: map[string]apiserver.Limit{
+			"/api/v1/dashboards": {Rate: 0.1, Burst: 1},
+		},
+		APIKeys: apiserver.APIKeysRateLimit{
+			Default: apiserver.Limit{Rate: 1, Burst: 10},
+		},
+	})
+
+	router := mux.NewRouter()
+	router.Use(m.Wrap)
+	router.HandleFunc("/api/v1/dashboards", func(rw http.ResponseWriter, _ *http.Request) { rw.WriteHeader(http.StatusNoContent) })
+
+	orgID := valuer.GenerateUUID()
+	do := func(token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/dashboards", nil)
+		ctx := authtypes.NewContextWithUUID(authtypes.NewContextWithClaims(req.Context(), authtypes.Claims{OrgID: orgID.StringValue()}), token)
+
+		rw := httptest.NewRecorder()
+		router.ServeHTTP(rw, req.WithContext(ctx))
+		return rw
+	}
+
+	// the limit of the route applies to the requests authenticated by an api key
+	assert.Equal(t, http.StatusNoContent, do("key").Code)
+	rw := do("key")
+	assert.Equal(t, http.StatusTooManyRequests, rw.Code)
+	assert.Equal(t, "1", rw.Header().Get("X-RateLimit-Limit"))
+	assert.Equal(t, "10", rw.Header().Get("Retry-After"))
+}
@@ -0,0 +1,74 @@
+package middleware
+
+import (
+	"bytes"
+	"io"
+	"regexp"
+	"strings"
+)
+
+const (
+	redactedValue string = "[REDACTED]"
+)
+
+// redactor redacts the values of the sensitive fields of the bodies written in the logs. A field is sensitive when its
+// name contains one of the fields, such as newPassword or clientSecret. The bodies may be truncated, hence they are
+// matched with expressions rather than parsed.
+type redactor struct {
+	json *regexp.Regexp
+	form *regexp.Regexp
+}
+
+func newRedactor(fields []string) *redactor {
+	if len(fields) == 0 {
+		return &redactor{}
+	}
+
+	quoted := make([]string, len(fields))
+	for i, field := range fields {
+		quoted[i] = regexp.QuoteMeta(field)
+	}
+	alternation := strings.Join(quoted, "|")
+
+	return &redactor{
+		// A string value, possibly cut by the truncation, an array or any other scalar value of the field. The objects
+		// are left to the redaction of their own fields.
+		json: regexp.MustCompile(`(?i)("[^"]*(?:` + alternation + `)[^"]*"\s*:\s*)(?:"(?:[^"\\]|\\.)*"?|\[[^\]]*\]?|[^,{}\[\]\s]+)`),
+		form: regexp.MustCompile(`(?i)(^|[?&])([^=&\s]*(?:` + alternation + `)[^=&\s]*)=[^&\s]*`),
+	}
+}
+
+func (redactor *redactor) redact(body string) string {
+	if redactor.json == nil {
+		return body
+	}
+
+	body = redactor.json.ReplaceAllString(body, `${1}"`+redactedValue+`"`)
+	return redactor.form.ReplaceAllString(body, `${1}${2}=`+redactedValue)
+}
+
+// cappedBuffer keeps the first max bytes written to it and drops the rest. Its writes never fail.
+type cappedBuffer struct {
+	bytes.Buffer
+	max       int
+	truncated bool
+}
+
+func (buffer *cappedBuffer) Write(data []byte) (int, error) {
+	left := buffer.max - buffer.Buffer.Len()
+	if len(data) > left {
+		if !buffer.truncated {
+			_, _ = buffer.Buffer.Write(data[:max(left, 0)])
+			_, _ = buffer.Buffer.WriteString("...")
+			buffer.truncated = true
+		}
+		return len(data), nil
+	}
+
+	return buffer.Buffer.Write(data)
+}
+
+type teeReadCloser struct {
+	io.Reader
+	io.Closer
+}
@@ -21,11 +21,17 @@ type badResponseLoggingWriter interface {
 }
 
 func newBadResponseLoggingWriter(rw http.ResponseWriter, buffer io.Writer) badResponseLoggingWriter {
+	return newResponseLoggingWriter(rw, buffer, false, maxResponseBodyInLogs)
+}
+
+// newResponseLoggingWriter captures at most maxBody bytes of the body of the response in buffer. The body of every
+// response is captured if all is set, the body of the bad responses only otherwise.
+func newResponseLoggingWriter(rw http.ResponseWriter, buffer io.Writer, all bool, maxBody int) badResponseLoggingWriter {
 	b := nonFlushingBadResponseLoggingWriter{
 		rw:            rw,
 		buffer:        buffer,
-		logBody:       false,
-		bodyBytesLeft: maxResponseBodyInLogs,
+		logBody:       all,
+		bodyBytesLeft: maxBody,
 		statusCode:    http.StatusOK,
 	}
 
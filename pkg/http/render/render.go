@@ -70,6 +70,10 @@ func Error(rw http.ResponseWriter, cause error) {
 		httpCode = statusClientClosedConnection
 	case errors.TypeTimeout:
 		httpCode = http.StatusGatewayTimeout
+	case errors.TypeTooManyRequests:
+		httpCode = http.StatusTooManyRequests
+	case errors.TypeUnavailable:
+		httpCode = http.StatusServiceUnavailable
 	}
 
 	rea := make([]responseerroradditional, len(a))
@@ -0,0 +1,83 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Drainer counts the in-flight requests of http servers so that they are drained when the servers shut down. The
+// upgraded requests, such as websockets, are not counted as their connections are not drained by the servers.
+type Drainer struct {
+	inFlight atomic.Int64
+}
+
+func NewDrainer() *Drainer {
+	return &Drainer{}
+}
+
+func (drainer *Drainer) Wrap(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if strings.EqualFold(req.Header.Get("Connection"), "upgrade") || req.Header.Get("Upgrade") != "" {
+			next.ServeHTTP(rw, req)
+			return
+		}
+
+		drainer.inFlight.Add(1)
+		defer drainer.inFlight.Add(-1)
+
+		next.ServeHTTP(rw, req)
+	})
+}
+
+// InFlight returns the number of requests being served.
+func (drainer *Drainer) InFlight() int64 {
+	return drainer.inFlight.Load()
+}
+
+// Drain shuts the servers down. The servers stop accepting new connections right away and the in-flight requests are
+// awaited for up to the grace period, the connections left are closed once it is over. The error of a server failing
+// to shut down is returned, the servers closing connections once the grace period is over is not an error.
+func (drainer *Drainer) Drain(ctx context.Context, logger *slog.Logger, gracePeriod time.Duration, servers ...*http.Server) error {
+	inFlight := drainer.InFlight()
+	logger.InfoContext(ctx, "draining in-flight requests", "in_flight", inFlight, "grace_period", gracePeriod)
+
+	shutdownCtx, cancel := context.WithTimeout(ctx, gracePeriod)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	errs := make([]error, len(servers))
+	for i, server := range servers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			err := server.Shutdown(shutdownCtx)
+			if err == nil {
+				return
+			}
+
+			if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) {
+				err = server.Close()
+			}
+
+			errs[i] = err
+		}()
+	}
+	wg.Wait()
+
+	remaining := drainer.InFlight()
+	drained := max(inFlight-remaining, 0)
+	if remaining > 0 {
+		logger.WarnContext(ctx, "grace period is over, closed the connections of the requests left", "drained", drained, "closed", remaining, "grace_period", gracePeriod)
+	} else {
+		logger.InfoContext(ctx, "drained in-flight requests", "drained", drained)
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,71 @@
+package server
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDrainerDrain(t *testing.T) {
+	testCases := []struct {
+		name        string
+		gracePeriod time.Duration
+		release     time.Duration
+		completed   bool
+	}{
+		{name: "Drained", gracePeriod: 5 * time.Second, release: 100 * time.Millisecond, completed: true},
+		{name: "Closed", gracePeriod: 100 * time.Millisecond, release: time.Second, completed: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			started := make(chan struct{})
+			release := make(chan struct{})
+			drainer := NewDrainer()
+			server := &http.Server{Handler: drainer.Wrap(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				close(started)
+				<-release
+				rw.WriteHeader(http.StatusOK)
+			}))}
+
+			listener, err := net.Listen("tcp", "127.0.0.1:0")
+			require.NoError(t, err)
+			go func() { _ = server.Serve(listener) }()
+
+			responses := make(chan error, 1)
+			go func() {
+				res, err := http.Get("http://" + listener.Addr().String())
+				if err == nil {
+					_, _ = io.Copy(io.Discard, res.Body)
+					_ = res.Body.Close()
+				}
+				responses <- err
+			}()
+
+			<-started
+			assert.Equal(t, int64(1), drainer.InFlight())
+
+			time.AfterFunc(tc.release, func() { close(release) })
+			require.NoError(t, drainer.Drain(context.Background(), slog.New(slog.NewTextHandler(io.Discard, nil)), tc.gracePeriod, server))
+
+			// The server does not accept new connections anymore.
+			_, err = net.Dial("tcp", listener.Addr().String())
+			assert.Error(t, err)
+
+			if tc.completed {
+				assert.NoError(t, <-responses)
+				assert.Equal(t, int64(0), drainer.InFlight())
+				return
+			}
+
+			assert.Error(t, <-responses)
+		})
+	}
+}
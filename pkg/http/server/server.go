@@ -17,6 +17,7 @@ type Server struct {
 	logger  *slog.Logger
 	handler http.Handler
 	cfg     Config
+	drainer *Drainer
 }
 
 func New(logger *slog.Logger, cfg Config, handler http.Handler) (*Server, error) {
@@ -28,9 +29,14 @@ func New(logger *slog.Logger, cfg Config, handler http.Handler) (*Server, error)
 		return nil, fmt.Errorf("cannot build http server, logger is required")
 	}
 
+	if cfg.GracePeriod <= 0 {
+		return nil, fmt.Errorf("cannot build http server, grace period must be positive, got %v", cfg.GracePeriod)
+	}
+
+	drainer := NewDrainer()
 	srv := &http.Server{
 		Addr:           cfg.Address,
-		Handler:        handler,
+		Handler:        drainer.Wrap(handler),
 		ReadTimeout:    10 * time.Second,
 		WriteTimeout:   10 * time.Second,
 		MaxHeaderBytes: 1 << 20,
@@ -41,6 +47,7 @@ func New(logger *slog.Logger, cfg Config, handler http.Handler) (*Server, error)
 		logger:  logger.With("pkg", "go.signoz.io/pkg/http/server"),
 		handler: handler,
 		cfg:     cfg,
+		drainer: drainer,
 	}, nil
 }
 
@@ -56,10 +63,7 @@ func (server *Server) Start(ctx context.Context) error {
 }
 
 func (server *Server) Stop(ctx context.Context) error {
-	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
-	defer cancel()
-
-	if err := server.srv.Shutdown(ctx); err != nil {
+	if err := server.drainer.Drain(ctx, server.logger, server.cfg.GracePeriod, server.srv); err != nil {
 		server.logger.ErrorContext(ctx, "failed to stop server", "error", err)
 		return err
 	}
@@ -0,0 +1,24 @@
+package server
+
+import (
+	"io"
+	"log/slog"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	handler := http.NotFoundHandler()
+
+	server, err := New(logger, Config{Address: "127.0.0.1:0", GracePeriod: 30 * time.Second}, handler)
+	require.NoError(t, err)
+	assert.Equal(t, 30*time.Second, server.cfg.GracePeriod)
+
+	_, err = New(logger, Config{Address: "127.0.0.1:0"}, handler)
+	assert.Error(t, err)
+}
@@ -2,8 +2,13 @@ package instrumentation
 
 import (
 	"log/slog"
+	"reflect"
+	"strings"
+	"time"
 
+	"github.com/SigNoz/signoz/pkg/errors"
 	"github.com/SigNoz/signoz/pkg/factory"
+	"github.com/prometheus/common/model"
 	contribsdkconfig "go.opentelemetry.io/contrib/config"
 )
 
@@ -13,6 +18,9 @@ type Config struct {
 	Traces   TracesConfig  `mapstructure:"traces"`
 	Metrics  MetricsConfig `mapstructure:"metrics"`
 	Resource Resource      `mapstructure:"resource"`
+	// Labels are static labels attached to the resource of the spans and to every metric of the prometheus
+	// registry, which the metrics endpoint serves. A metric with a label of the same key fails to be collected.
+	Labels map[string]string `mapstructure:"labels"`
 }
 
 // Resource defines the configuration for OpenTelemetry resource attributes.
@@ -23,13 +31,43 @@ type Resource struct {
 // LogsConfig holds the configuration for the logging component.
 type LogsConfig struct {
 	Level slog.Level `mapstructure:"level"`
+	// Modules are the levels of specific modules keyed by their package path, which is relative to
+	// github.com/SigNoz/signoz/pkg for the modules of signoz. A level applies to the sub modules as well.
+	Modules map[string]slog.Level `mapstructure:"modules"`
+	// OverrideTTL is the time after which the levels overridden at runtime revert, unless they are persistent.
+	OverrideTTL time.Duration `mapstructure:"override_ttl"`
 }
 
 // TracesConfig holds the configuration for the tracing component.
 type TracesConfig struct {
-	Enabled    bool                     `mapstructure:"enabled"`
-	Processors TracesProcessors         `mapstructure:"processors"`
-	Sampler    contribsdkconfig.Sampler `mapstructure:"sampler"`
+	Enabled    bool             `mapstructure:"enabled"`
+	Processors TracesProcessors `mapstructure:"processors"`
+	// Sampler is the head sampler of the traces, deciding whether a trace is sampled when it starts. It defaults to
+	// sampling the root spans and following the decision of the parent of the other spans.
+	Sampler contribsdkconfig.Sampler `mapstructure:"sampler"`
+	// TailSampling decides whether a trace is sampled once it has ended, in place of the sampler.
+	TailSampling TailSamplingConfig `mapstructure:"tail_sampling"`
+	// Loopback writes the traces into the telemetry store of signoz itself, in place of the exporter of the batch
+	// processor. Only the traces are looped back, the metrics keep going through their readers.
+	Loopback LoopbackConfig `mapstructure:"loopback"`
+}
+
+type LoopbackConfig struct {
+	// Enabled enables the loopback of the traces, which cannot be combined with an exporter. There is no loopback of
+	// the metrics.
+	Enabled bool `mapstructure:"enabled"`
+}
+
+type TailSamplingConfig struct {
+	// Enabled enables the tail sampling of the traces, which cannot be combined with a sampler.
+	Enabled bool `mapstructure:"enabled"`
+	// Ratio is the ratio of the traces without an error and faster than the latency which are kept.
+	Ratio float64 `mapstructure:"ratio"`
+	// Latency is the duration from which a trace is always kept.
+	Latency time.Duration `mapstructure:"latency"`
+	// MaxTraces is the maximum number of traces buffered while awaiting their decision, the oldest traces are
+	// decided early beyond it.
+	MaxTraces int `mapstructure:"max_traces"`
 }
 
 type TracesProcessors struct {
@@ -40,6 +78,15 @@ type TracesProcessors struct {
 type MetricsConfig struct {
 	Enabled bool           `mapstructure:"enabled"`
 	Readers MetricsReaders `mapstructure:"readers"`
+	// Endpoint exposes the metrics on the /metrics route of the private api server, in place of the pull reader.
+	Endpoint MetricsEndpoint `mapstructure:"endpoint"`
+}
+
+type MetricsEndpoint struct {
+	// Enabled enables the /metrics route of the private api server.
+	Enabled bool `mapstructure:"enabled"`
+	// Token is the bearer token the scrapes must present. The route is open when it is empty.
+	Token string `mapstructure:"token"`
 }
 
 type MetricsReaders struct {
@@ -56,10 +103,21 @@ func newConfig() factory.Config {
 
 	return Config{
 		Logs: LogsConfig{
-			Level: slog.LevelInfo,
+			Level:       slog.LevelInfo,
+			Modules:     map[string]slog.Level{},
+			OverrideTTL: time.Hour,
 		},
 		Traces: TracesConfig{
 			Enabled: false,
+			TailSampling: TailSamplingConfig{
+				Enabled:   false,
+				Ratio:     0.1,
+				Latency:   time.Second,
+				MaxTraces: 10000,
+			},
+			Loopback: LoopbackConfig{
+				Enabled: false,
+			},
 		},
 		Metrics: MetricsConfig{
 			Enabled: true,
@@ -73,11 +131,72 @@ func newConfig() factory.Config {
 					},
 				},
 			},
+			Endpoint: MetricsEndpoint{
+				Enabled: false,
+				Token:   "",
+			},
 		},
 	}
 
 }
 
 func (c Config) Validate() error {
+	if c.Logs.OverrideTTL <= 0 {
+		return errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "logs::override_ttl must be positive, got %v", c.Logs.OverrideTTL)
+	}
+
+	for module := range c.Logs.Modules {
+		if strings.TrimSpace(module) == "" {
+			return errors.New(errors.TypeInvalidInput, errors.CodeInvalidInput, "logs::modules must not have an empty module")
+		}
+	}
+
+	if c.Metrics.Endpoint.Enabled && !c.Metrics.Enabled {
+		return errors.New(errors.TypeInvalidInput, errors.CodeInvalidInput, "metrics::endpoint::enabled requires metrics::enabled")
+	}
+
+	if _, _, err := newSampler(c.Traces.Sampler); err != nil {
+		return err
+	}
+
+	if c.Traces.TailSampling.Enabled {
+		if !reflect.ValueOf(c.Traces.Sampler).IsZero() {
+			return errors.New(errors.TypeInvalidInput, errors.CodeInvalidInput, "traces::tail_sampling::enabled cannot be combined with traces::sampler")
+		}
+
+		if c.Traces.TailSampling.Ratio < 0 || c.Traces.TailSampling.Ratio > 1 {
+			return errors.New(errors.TypeInvalidInput, errors.CodeInvalidInput, "traces::tail_sampling::ratio must be between 0 and 1")
+		}
+
+		if c.Traces.TailSampling.Latency <= 0 {
+			return errors.New(errors.TypeInvalidInput, errors.CodeInvalidInput, "traces::tail_sampling::latency must be greater than 0")
+		}
+
+		if c.Traces.TailSampling.MaxTraces <= 0 {
+			return errors.New(errors.TypeInvalidInput, errors.CodeInvalidInput, "traces::tail_sampling::max_traces must be greater than 0")
+		}
+	}
+
+	if c.Traces.Loopback.Enabled {
+		if !c.Traces.Enabled {
+			return errors.New(errors.TypeInvalidInput, errors.CodeInvalidInput, "traces::loopback::enabled requires traces::enabled")
+		}
+
+		if !reflect.ValueOf(c.Traces.Processors.Batch.Exporter).IsZero() {
+			return errors.New(errors.TypeInvalidInput, errors.CodeInvalidInput, "traces::loopback::enabled cannot be combined with traces::processors::batch::exporter")
+		}
+	}
+
+	for key := range c.Labels {
+		if !model.LabelName(key).IsValidLegacy() {
+			return errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "labels::%s must match %s", key, model.LabelNameRE.String())
+		}
+
+		// Prometheus reserves the labels starting with __ for its internal use.
+		if strings.HasPrefix(key, "__") {
+			return errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "labels::%s must not start with __", key)
+		}
+	}
+
 	return nil
 }
@@ -14,6 +14,7 @@ import (
 
 func TestNewWithEnvProvider(t *testing.T) {
 	t.Setenv("SIGNOZ_INSTRUMENTATION_LOGS_LEVEL", "debug")
+	t.Setenv("SIGNOZ_INSTRUMENTATION_LOGS_MODULES_ALERTMANAGER", "warn")
 	t.Setenv("SIGNOZ_INSTRUMENTATION_METRICS_READERS_PULL_EXPORTER_PROMETHEUS_PORT", "1111")
 	t.Setenv("SIGNOZ_INSTRUMENTATION_TRACES_ENABLED", "true")
 
@@ -38,6 +39,7 @@ func TestNewWithEnvProvider(t *testing.T) {
 	port := 1111
 	expected := NewConfigFactory().New().(Config)
 	expected.Logs.Level = slog.LevelDebug
+	expected.Logs.Modules = map[string]slog.Level{"alertmanager": slog.LevelWarn}
 	expected.Traces.Enabled = true
 	expected.Metrics.Readers.Pull.Exporter.Prometheus.Port = &port
 
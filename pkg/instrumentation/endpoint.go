@@ -0,0 +1,49 @@
+package instrumentation
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	otelprom "go.opentelemetry.io/otel/exporters/prometheus"
+	otelsdkmetric "go.opentelemetry.io/otel/sdk/metric"
+)
+
+// newEndpointMeterProvider returns a meter provider exporting the metrics to the registry served by the metrics
+// endpoint. The names of the metrics are derived from the names of the instruments only, the instrumentation scope
+// is left out so that the series do not change with the versions of the instrumented packages.
+func newEndpointMeterProvider(registerer prometheus.Registerer, attributes map[string]any) (*otelsdkmetric.MeterProvider, error) {
+	reader, err := otelprom.New(otelprom.WithRegisterer(registerer), otelprom.WithoutScopeInfo())
+	if err != nil {
+		return nil, err
+	}
+
+	resource, err := newResource(attributes)
+	if err != nil {
+		return nil, err
+	}
+
+	return otelsdkmetric.NewMeterProvider(otelsdkmetric.WithResource(resource), otelsdkmetric.WithReader(reader)), nil
+}
+
+// newMetricsHandler serves the registry in the prometheus text or the openmetrics format, depending on the
+// negotiated content type. The scrapes must present the token as a bearer token when it is set.
+func newMetricsHandler(registry *prometheus.Registry, token string) http.Handler {
+	handler := promhttp.HandlerFor(registry, promhttp.HandlerOpts{Registry: registry, EnableOpenMetrics: true})
+	if token == "" {
+		return handler
+	}
+
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		presented, ok := strings.CutPrefix(req.Header.Get("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(presented), []byte(token)) != 1 {
+			rw.Header().Set("WWW-Authenticate", `Bearer realm="metrics"`)
+			http.Error(rw, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+			return
+		}
+
+		handler.ServeHTTP(rw, req)
+	})
+}
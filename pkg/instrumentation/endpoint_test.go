@@ -0,0 +1,46 @@
+package instrumentation
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/metric"
+)
+
+func TestMetricsEndpoint(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	meterProvider, err := newEndpointMeterProvider(registry, map[string]any{"service.name": "signoz"})
+	require.NoError(t, err)
+
+	counter, err := meterProvider.Meter("github.com/SigNoz/signoz/pkg/cache").Int64Counter("signoz.cache.hits", metric.WithDescription("Number of cache hits."))
+	require.NoError(t, err)
+	counter.Add(context.Background(), 3)
+
+	handler := newMetricsHandler(registry, "secret")
+
+	rw := httptest.NewRecorder()
+	handler.ServeHTTP(rw, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	assert.Equal(t, http.StatusUnauthorized, rw.Code)
+
+	req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+	req.Header.Set("Authorization", "Bearer secret")
+	rw = httptest.NewRecorder()
+	handler.ServeHTTP(rw, req)
+	require.Equal(t, http.StatusOK, rw.Code)
+	assert.Contains(t, rw.Body.String(), "# HELP signoz_cache_hits_total Number of cache hits.\n")
+	assert.Contains(t, rw.Body.String(), "signoz_cache_hits_total 3\n")
+	assert.NotContains(t, rw.Body.String(), "otel_scope_name")
+
+	req = httptest.NewRequest(http.MethodGet, "/metrics", nil)
+	req.Header.Set("Authorization", "Bearer secret")
+	req.Header.Set("Accept", "application/openmetrics-text; version=1.0.0")
+	rw = httptest.NewRecorder()
+	handler.ServeHTTP(rw, req)
+	assert.Contains(t, rw.Header().Get("Content-Type"), "application/openmetrics-text")
+	assert.Contains(t, rw.Body.String(), "# EOF\n")
+}
@@ -1,12 +1,17 @@
 package instrumentation
 
 import (
+	"fmt"
 	"log/slog"
+	"net/http"
 
 	"github.com/SigNoz/signoz/pkg/factory"
+	"github.com/SigNoz/signoz/pkg/instrumentation/loghandler"
 	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel/attribute"
 	sdkmetric "go.opentelemetry.io/otel/metric"
 	sdkresource "go.opentelemetry.io/otel/sdk/resource"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
 	sdktrace "go.opentelemetry.io/otel/trace"
 )
 
@@ -20,6 +25,16 @@ type Instrumentation interface {
 	TracerProvider() sdktrace.TracerProvider
 	// PrometheusRegisterer returns the Prometheus registerer.
 	PrometheusRegisterer() prometheus.Registerer
+	// MetricsHandler returns the handler of the metrics endpoint, which responds with a 404 when it is disabled.
+	MetricsHandler() http.Handler
+	// Sampling returns the status of the sampling of the traces.
+	Sampling() SamplingStatus
+	// LogLevels returns the levels of the logs by module.
+	LogLevels() loghandler.LevelsStatus
+	// SetLogLevel overrides the level of the logs of a module at runtime.
+	SetLogLevel(PostableLogLevel) (loghandler.LevelOverride, error)
+	// ResetLogLevel reverts the logs of a module to the default level.
+	ResetLogLevel(module string)
 	// ToProviderSettings converts instrumentation to provider settings.
 	ToProviderSettings() factory.ProviderSettings
 }
@@ -39,3 +54,20 @@ func mergeAttributes(input map[string]any, resource *sdkresource.Resource) map[s
 
 	return output
 }
+
+// newResource returns the default resource merged with the attributes.
+func newResource(attributes map[string]any) (*sdkresource.Resource, error) {
+	keyValues := make([]attribute.KeyValue, 0, len(attributes))
+	for key, value := range attributes {
+		switch value := value.(type) {
+		case string:
+			keyValues = append(keyValues, attribute.String(key, value))
+		case attribute.Value:
+			keyValues = append(keyValues, attribute.KeyValue{Key: attribute.Key(key), Value: value})
+		default:
+			keyValues = append(keyValues, attribute.String(key, fmt.Sprint(value)))
+		}
+	}
+
+	return sdkresource.Merge(sdkresource.Default(), sdkresource.NewWithAttributes(semconv.SchemaURL, keyValues...))
+}
@@ -3,9 +3,11 @@ package instrumentationtest
 import (
 	"io"
 	"log/slog"
+	"net/http"
 
 	"github.com/SigNoz/signoz/pkg/factory"
 	"github.com/SigNoz/signoz/pkg/instrumentation"
+	"github.com/SigNoz/signoz/pkg/instrumentation/loghandler"
 	"github.com/prometheus/client_golang/prometheus"
 	sdkmetric "go.opentelemetry.io/otel/metric"
 	noopmetric "go.opentelemetry.io/otel/metric/noop"
@@ -43,6 +45,24 @@ func (i *noopInstrumentation) PrometheusRegisterer() prometheus.Registerer {
 	return prometheus.NewRegistry()
 }
 
+func (i *noopInstrumentation) MetricsHandler() http.Handler {
+	return http.NotFoundHandler()
+}
+
+func (i *noopInstrumentation) Sampling() instrumentation.SamplingStatus {
+	return instrumentation.SamplingStatus{Enabled: false}
+}
+
+func (i *noopInstrumentation) LogLevels() loghandler.LevelsStatus {
+	return loghandler.LevelsStatus{Default: slog.LevelInfo, Overrides: []loghandler.LevelOverride{}}
+}
+
+func (i *noopInstrumentation) SetLogLevel(postable instrumentation.PostableLogLevel) (loghandler.LevelOverride, error) {
+	return loghandler.LevelOverride{Module: loghandler.NormalizeModule(postable.Module), Level: postable.Level}, nil
+}
+
+func (i *noopInstrumentation) ResetLogLevel(string) {}
+
 func (i *noopInstrumentation) ToProviderSettings() factory.ProviderSettings {
 	return factory.ProviderSettings{
 		Logger:               i.Logger(),
@@ -0,0 +1,69 @@
+package instrumentation
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/SigNoz/signoz/pkg/version"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+func TestLabels(t *testing.T) {
+	config := NewConfigFactory().New().(Config)
+	config.Metrics.Enabled = true
+	config.Metrics.Endpoint.Enabled = true
+	config.Labels = map[string]string{"region": "eu"}
+
+	sdk, err := New(context.Background(), config, version.Build{}, "signoz")
+	require.NoError(t, err)
+
+	counter, err := sdk.MeterProvider().Meter("test").Int64Counter("otel.counter")
+	require.NoError(t, err)
+	counter.Add(context.Background(), 1, metric.WithAttributes(attribute.String("status", "ok")))
+
+	gauge := prometheus.NewGauge(prometheus.GaugeOpts{Name: "prometheus_gauge"})
+	require.NoError(t, sdk.ToProviderSettings().PrometheusRegisterer.Register(gauge))
+	gauge.Set(2)
+
+	rw := httptest.NewRecorder()
+	sdk.MetricsHandler().ServeHTTP(rw, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	require.Equal(t, http.StatusOK, rw.Code, rw.Body.String())
+
+	// The labels merge with the labels of the metrics.
+	assert.Contains(t, rw.Body.String(), `otel_counter_total{region="eu",status="ok"} 1`)
+	assert.Contains(t, rw.Body.String(), `prometheus_gauge{region="eu"} 2`)
+}
+
+func TestConfigValidateLabels(t *testing.T) {
+	testCases := []struct {
+		name   string
+		labels map[string]string
+		pass   bool
+	}{
+		{name: "Valid", labels: map[string]string{"region": "eu", "_tier": "paid", "zone1": "a"}, pass: true},
+		{name: "StartsWithDigit", labels: map[string]string{"1region": "eu"}, pass: false},
+		{name: "Dot", labels: map[string]string{"deployment.region": "eu"}, pass: false},
+		{name: "Reserved", labels: map[string]string{"__region": "eu"}, pass: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			config := NewConfigFactory().New().(Config)
+			config.Labels = tc.labels
+
+			err := config.Validate()
+			if tc.pass {
+				assert.NoError(t, err)
+				return
+			}
+
+			assert.Error(t, err)
+		})
+	}
+}
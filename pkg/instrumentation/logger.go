@@ -7,9 +7,10 @@ import (
 	"github.com/SigNoz/signoz/pkg/instrumentation/loghandler"
 )
 
-func NewLogger(config Config, wrappers ...loghandler.Wrapper) *slog.Logger {
+// NewLogger returns the logger of signoz, logging the records at or above the level of the module of their logger.
+func NewLogger(config Config, levels *loghandler.Levels, wrappers ...loghandler.Wrapper) *slog.Logger {
 	logger := slog.New(
-		loghandler.New(
+		loghandler.NewLeveled(
 			slog.NewJSONHandler(os.Stderr, &slog.HandlerOptions{Level: config.Logs.Level, AddSource: true, ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
 				// This is more in line with OpenTelemetry semantic conventions
 				if a.Key == slog.SourceKey {
@@ -24,6 +25,7 @@ func NewLogger(config Config, wrappers ...loghandler.Wrapper) *slog.Logger {
 
 				return a
 			}}),
+			levels,
 			wrappers...,
 		),
 	)
@@ -8,34 +8,63 @@ import (
 	"go.opentelemetry.io/otel/trace"
 )
 
+const (
+	// TraceIDKey is the key of the trace id of the log records, as in the trace_id column of the logs.
+	TraceIDKey string = "trace_id"
+	// SpanIDKey is the key of the span id of the log records, as in the span_id column of the logs.
+	SpanIDKey string = "span_id"
+)
+
+// TraceIDFromContext returns the hex encoded id of the trace of the span context of ctx.
+func TraceIDFromContext(ctx context.Context) (string, bool) {
+	spanContext := trace.SpanContextFromContext(ctx)
+	if !spanContext.HasTraceID() {
+		return "", false
+	}
+
+	return spanContext.TraceID().String(), true
+}
+
+// SpanIDFromContext returns the hex encoded id of the span of the span context of ctx.
+func SpanIDFromContext(ctx context.Context) (string, bool) {
+	spanContext := trace.SpanContextFromContext(ctx)
+	if !spanContext.HasSpanID() {
+		return "", false
+	}
+
+	return spanContext.SpanID().String(), true
+}
+
+// CorrelationAttrs returns the attributes correlating a log record to the span context of ctx, none when ctx has no
+// span context.
+func CorrelationAttrs(ctx context.Context) []slog.Attr {
+	attrs := make([]slog.Attr, 0, 2)
+	if traceID, ok := TraceIDFromContext(ctx); ok {
+		attrs = append(attrs, slog.String(TraceIDKey, traceID))
+	}
+
+	if spanID, ok := SpanIDFromContext(ctx); ok {
+		attrs = append(attrs, slog.String(SpanIDKey, spanID))
+	}
+
+	return attrs
+}
+
 type correlation struct{}
 
 func NewCorrelation() *correlation {
 	return &correlation{}
 }
 
+// Wrap adds the trace and span ids to the records logged within a span context. The ids are added even when the
+// span is not recorded, for example because it is sampled out, so that the logs of a request can still be grouped.
 func (h *correlation) Wrap(next LogHandler) LogHandler {
 	return LogHandlerFunc(func(ctx context.Context, record slog.Record) error {
-		span := trace.SpanFromContext(ctx)
-		if span == nil || !span.IsRecording() {
-			return next.Handle(ctx, record)
-		}
-
-		// Adding span info to log record.
-		spanContext := span.SpanContext()
-		if spanContext.HasTraceID() {
-			traceID := spanContext.TraceID().String()
-			record.AddAttrs(slog.String("trace_id", traceID))
-		}
-
-		if spanContext.HasSpanID() {
-			spanID := spanContext.SpanID().String()
-			record.AddAttrs(slog.String("span_id", spanID))
-		}
+		record.AddAttrs(CorrelationAttrs(ctx)...)
 
 		// Setting span status if the log is an error.
 		// Purposely leaving as codes.Unset (default) otherwise.
-		if record.Level >= slog.LevelError {
+		if span := trace.SpanFromContext(ctx); span.IsRecording() && record.Level >= slog.LevelError {
 			span.SetStatus(codes.Error, record.Message)
 		}
 
@@ -31,3 +31,30 @@ func TestCorrelation(t *testing.T) {
 	assert.Equal(t, span.SpanContext().TraceID().String(), m["trace_id"])
 	assert.Equal(t, span.SpanContext().SpanID().String(), m["span_id"])
 }
+
+func TestCorrelationWithoutRecordingSpan(t *testing.T) {
+	buf := bytes.NewBuffer(nil)
+	logger := slog.New(&handler{base: slog.NewJSONHandler(buf, &slog.HandlerOptions{Level: slog.LevelDebug}), wrappers: []Wrapper{NewCorrelation()}})
+
+	// A sampled out span is not recorded but its ids are still propagated.
+	tracer := trace.NewTracerProvider(trace.WithSampler(trace.NeverSample())).Tracer("test")
+	ctx, span := tracer.Start(context.Background(), "test")
+	defer span.End()
+	require.False(t, span.IsRecording())
+
+	logger.ErrorContext(ctx, "test")
+
+	m := make(map[string]any)
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &m))
+	assert.Equal(t, span.SpanContext().TraceID().String(), m[TraceIDKey])
+	assert.Equal(t, span.SpanContext().SpanID().String(), m[SpanIDKey])
+
+	// Nothing is added outside of a span context.
+	buf.Reset()
+	logger.InfoContext(context.Background(), "test")
+
+	m = make(map[string]any)
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &m))
+	assert.NotContains(t, m, TraceIDKey)
+	assert.NotContains(t, m, SpanIDKey)
+}
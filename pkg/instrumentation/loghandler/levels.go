@@ -0,0 +1,152 @@
+package loghandler
+
+import (
+	"log/slog"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// ModuleKey is the key of the attribute naming the module of a logger, which is the package path of the
+	// provider it was scoped to.
+	ModuleKey string = "logger"
+
+	// modulePrefix is the prefix of the modules given without it, so that the modules of signoz can be named
+	// after their package alone, for example alertmanager.
+	modulePrefix string = "github.com/SigNoz/signoz/pkg/"
+)
+
+// LevelOverride is the level of the records logged by the loggers of a module and of its sub modules.
+type LevelOverride struct {
+	Module string     `json:"module"`
+	Level  slog.Level `json:"level"`
+	// ExpiresAt is the time at which the override reverts to the default level, it is nil for the persistent
+	// overrides.
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+}
+
+type LevelsStatus struct {
+	// Default is the level of the modules without an override.
+	Default   slog.Level      `json:"default"`
+	Overrides []LevelOverride `json:"overrides"`
+}
+
+// Levels are the minimum levels of the records logged by module. The levels can be overridden at runtime, the
+// overrides then apply to the loggers already created.
+//
+// The overrides are held in memory, hence they only apply to the replica they were set on and are reset to the
+// config on restart.
+type Levels struct {
+	defaultLevel slog.Level
+	mtx          sync.Mutex
+	// overrides is replaced on every change so that the levels are read without locking.
+	overrides atomic.Pointer[map[string]LevelOverride]
+}
+
+func NewLevels(defaultLevel slog.Level, modules map[string]slog.Level) *Levels {
+	overrides := make(map[string]LevelOverride, len(modules))
+	for module, level := range modules {
+		module = NormalizeModule(module)
+		overrides[module] = LevelOverride{Module: module, Level: level}
+	}
+
+	levels := &Levels{defaultLevel: defaultLevel}
+	levels.overrides.Store(&overrides)
+
+	return levels
+}
+
+// NormalizeModule returns the package path of the module, the modules of signoz being given with or without the
+// prefix of their package path.
+func NormalizeModule(module string) string {
+	module = strings.TrimSuffix(strings.TrimSpace(module), "/")
+	if module == "" || strings.Contains(strings.SplitN(module, "/", 2)[0], ".") {
+		return module
+	}
+
+	return modulePrefix + module
+}
+
+// Level returns the level of the module, which is the level of the override of the longest module it is part of,
+// or the default level if none applies.
+func (levels *Levels) Level(module string) slog.Level {
+	level := levels.defaultLevel
+	if module == "" {
+		return level
+	}
+
+	now := time.Now()
+	longest := -1
+	for prefix, override := range *levels.overrides.Load() {
+		if override.ExpiresAt != nil && !now.Before(*override.ExpiresAt) {
+			continue
+		}
+
+		if len(prefix) <= longest || (module != prefix && !strings.HasPrefix(module, prefix+"/")) {
+			continue
+		}
+
+		longest = len(prefix)
+		level = override.Level
+	}
+
+	return level
+}
+
+// Set overrides the level of the module and of its sub modules until the ttl is over, or until it is reset when
+// the ttl is 0.
+func (levels *Levels) Set(module string, level slog.Level, ttl time.Duration) LevelOverride {
+	override := LevelOverride{Module: NormalizeModule(module), Level: level}
+	if ttl > 0 {
+		expiresAt := time.Now().Add(ttl)
+		override.ExpiresAt = &expiresAt
+	}
+
+	levels.update(func(overrides map[string]LevelOverride) {
+		overrides[override.Module] = override
+	})
+
+	return override
+}
+
+// Reset reverts the module to the default level.
+func (levels *Levels) Reset(module string) {
+	levels.update(func(overrides map[string]LevelOverride) {
+		delete(overrides, NormalizeModule(module))
+	})
+}
+
+func (levels *Levels) Status() LevelsStatus {
+	now := time.Now()
+	status := LevelsStatus{Default: levels.defaultLevel, Overrides: []LevelOverride{}}
+	for _, override := range *levels.overrides.Load() {
+		if override.ExpiresAt == nil || now.Before(*override.ExpiresAt) {
+			status.Overrides = append(status.Overrides, override)
+		}
+	}
+
+	slices.SortFunc(status.Overrides, func(a, b LevelOverride) int {
+		return strings.Compare(a.Module, b.Module)
+	})
+
+	return status
+}
+
+// update replaces the overrides by a copy changed by fn, from which the expired overrides are dropped.
+func (levels *Levels) update(fn func(map[string]LevelOverride)) {
+	levels.mtx.Lock()
+	defer levels.mtx.Unlock()
+
+	overrides := maps.Clone(*levels.overrides.Load())
+	now := time.Now()
+	maps.DeleteFunc(overrides, func(_ string, override LevelOverride) bool {
+		return override.ExpiresAt != nil && !now.Before(*override.ExpiresAt)
+	})
+
+	fn(overrides)
+	levels.overrides.Store(&overrides)
+}
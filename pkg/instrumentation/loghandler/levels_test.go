@@ -0,0 +1,70 @@
+package loghandler
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLevels(t *testing.T) {
+	levels := NewLevels(slog.LevelInfo, map[string]slog.Level{"alertmanager": slog.LevelWarn})
+
+	assert.Equal(t, slog.LevelInfo, levels.Level(""))
+	assert.Equal(t, slog.LevelInfo, levels.Level("github.com/SigNoz/signoz/pkg/sqlstore"))
+	assert.Equal(t, slog.LevelWarn, levels.Level("github.com/SigNoz/signoz/pkg/alertmanager"))
+	assert.Equal(t, slog.LevelWarn, levels.Level("github.com/SigNoz/signoz/pkg/alertmanager/alertmanagerserver"))
+	// A module is not part of another module sharing a prefix of its name.
+	assert.Equal(t, slog.LevelInfo, levels.Level("github.com/SigNoz/signoz/pkg/alertmanagerstore"))
+
+	// The override of the longest module applies.
+	levels.Set("alertmanager/alertmanagerserver", slog.LevelDebug, time.Hour)
+	assert.Equal(t, slog.LevelDebug, levels.Level("github.com/SigNoz/signoz/pkg/alertmanager/alertmanagerserver"))
+	assert.Equal(t, slog.LevelWarn, levels.Level("github.com/SigNoz/signoz/pkg/alertmanager/alertmanagerbatcher"))
+
+	levels.Reset("github.com/SigNoz/signoz/pkg/alertmanager/alertmanagerserver")
+	assert.Equal(t, slog.LevelWarn, levels.Level("github.com/SigNoz/signoz/pkg/alertmanager/alertmanagerserver"))
+
+	// The overrides revert once their ttl is over.
+	override := levels.Set("sqlstore", slog.LevelDebug, time.Millisecond)
+	require.NotNil(t, override.ExpiresAt)
+	assert.Equal(t, "github.com/SigNoz/signoz/pkg/sqlstore", override.Module)
+	time.Sleep(5 * time.Millisecond)
+	assert.Equal(t, slog.LevelInfo, levels.Level("github.com/SigNoz/signoz/pkg/sqlstore"))
+
+	assert.Equal(t, LevelsStatus{
+		Default:   slog.LevelInfo,
+		Overrides: []LevelOverride{{Module: "github.com/SigNoz/signoz/pkg/alertmanager", Level: slog.LevelWarn}},
+	}, levels.Status())
+}
+
+func TestLeveledHandler(t *testing.T) {
+	buf := bytes.NewBuffer(nil)
+	levels := NewLevels(slog.LevelInfo, nil)
+	logger := slog.New(NewLeveled(slog.NewJSONHandler(buf, &slog.HandlerOptions{Level: slog.LevelError}), levels))
+
+	alertmanager := logger.With(ModuleKey, "github.com/SigNoz/signoz/pkg/alertmanager/alertmanagerserver")
+	sqlstore := logger.With(ModuleKey, "github.com/SigNoz/signoz/pkg/sqlstore")
+
+	alertmanager.DebugContext(context.Background(), "alertmanager")
+	sqlstore.InfoContext(context.Background(), "sqlstore")
+	assert.NotContains(t, buf.String(), `"msg":"alertmanager"`)
+	// The level of the base handler is ignored.
+	assert.Contains(t, buf.String(), `"msg":"sqlstore"`)
+
+	// The overrides apply to the loggers already created.
+	levels.Set("alertmanager", slog.LevelDebug, 0)
+	alertmanager.DebugContext(context.Background(), "alertmanager")
+	sqlstore.DebugContext(context.Background(), "sqlstore debug")
+	assert.Contains(t, buf.String(), `"msg":"alertmanager"`)
+	assert.NotContains(t, buf.String(), `"msg":"sqlstore debug"`)
+
+	// The attributes of a group do not set the module.
+	grouped := sqlstore.WithGroup("request").With(ModuleKey, "github.com/SigNoz/signoz/pkg/alertmanager")
+	grouped.DebugContext(context.Background(), "grouped")
+	assert.NotContains(t, buf.String(), `"msg":"grouped"`)
+}
@@ -41,13 +41,29 @@ func MergeLogHandlerWrappers(handlers ...Wrapper) Wrapper {
 type handler struct {
 	base     slog.Handler
 	wrappers []Wrapper
+	// levels are the levels of the records by module, the level of the base handler applies when it is nil.
+	levels *Levels
+	// module is the module of the logger, set by its attribute of key ModuleKey.
+	module string
+	// grouped is true once the attributes are added to a group, in which they no longer set the module.
+	grouped bool
 }
 
 func New(base slog.Handler, wrappers ...Wrapper) *handler {
 	return &handler{base: base, wrappers: wrappers}
 }
 
+// NewLeveled returns a handler logging the records at or above the level of the module of their logger. The level
+// of the base handler is ignored.
+func NewLeveled(base slog.Handler, levels *Levels, wrappers ...Wrapper) *handler {
+	return &handler{base: base, wrappers: wrappers, levels: levels}
+}
+
 func (h *handler) Enabled(ctx context.Context, level slog.Level) bool {
+	if h.levels != nil {
+		return level >= h.levels.Level(h.module)
+	}
+
 	return h.base.Enabled(ctx, level)
 }
 
@@ -56,9 +72,18 @@ func (h *handler) Handle(ctx context.Context, r slog.Record) error {
 }
 
 func (h *handler) WithAttrs(attrs []slog.Attr) slog.Handler {
-	return &handler{base: h.base.WithAttrs(attrs), wrappers: h.wrappers}
+	module := h.module
+	if !h.grouped {
+		for _, attr := range attrs {
+			if attr.Key == ModuleKey && attr.Value.Kind() == slog.KindString {
+				module = attr.Value.String()
+			}
+		}
+	}
+
+	return &handler{base: h.base.WithAttrs(attrs), wrappers: h.wrappers, levels: h.levels, module: module, grouped: h.grouped}
 }
 
 func (h *handler) WithGroup(name string) slog.Handler {
-	return &handler{base: h.base.WithGroup(name), wrappers: h.wrappers}
+	return &handler{base: h.base.WithGroup(name), wrappers: h.wrappers, levels: h.levels, module: h.module, grouped: h.grouped || name != ""}
 }
@@ -0,0 +1,45 @@
+package instrumentation
+
+import (
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+)
+
+type PostableLogLevel struct {
+	// Module is the package path of the module, relative to github.com/SigNoz/signoz/pkg for the modules of signoz.
+	Module string     `json:"module"`
+	Level  slog.Level `json:"level"`
+	// TTL is the time after which the level reverts, logs::override_ttl when it is empty.
+	TTL string `json:"ttl"`
+	// Persistent keeps the level until it is reset or signoz restarts, in place of reverting it after the ttl.
+	Persistent bool `json:"persistent"`
+}
+
+// ttl returns the time after which the level reverts, 0 when it is persistent.
+func (postable PostableLogLevel) ttl(defaultTTL time.Duration) (time.Duration, error) {
+	if strings.TrimSpace(postable.Module) == "" {
+		return 0, errors.New(errors.TypeInvalidInput, errors.CodeInvalidInput, "module must not be empty")
+	}
+
+	if postable.Persistent {
+		if postable.TTL != "" {
+			return 0, errors.New(errors.TypeInvalidInput, errors.CodeInvalidInput, "ttl cannot be combined with persistent")
+		}
+
+		return 0, nil
+	}
+
+	if postable.TTL == "" {
+		return defaultTTL, nil
+	}
+
+	ttl, err := time.ParseDuration(postable.TTL)
+	if err != nil || ttl <= 0 {
+		return 0, errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "ttl must be a positive duration, got %q", postable.TTL)
+	}
+
+	return ttl, nil
+}
@@ -0,0 +1,33 @@
+package instrumentation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostableLogLevelTTL(t *testing.T) {
+	ttl, err := PostableLogLevel{Module: "alertmanager"}.ttl(time.Hour)
+	require.NoError(t, err)
+	assert.Equal(t, time.Hour, ttl)
+
+	ttl, err = PostableLogLevel{Module: "alertmanager", TTL: "15m"}.ttl(time.Hour)
+	require.NoError(t, err)
+	assert.Equal(t, 15*time.Minute, ttl)
+
+	ttl, err = PostableLogLevel{Module: "alertmanager", Persistent: true}.ttl(time.Hour)
+	require.NoError(t, err)
+	assert.Zero(t, ttl)
+
+	for _, postable := range []PostableLogLevel{
+		{Module: ""},
+		{Module: "alertmanager", TTL: "soon"},
+		{Module: "alertmanager", TTL: "-1m"},
+		{Module: "alertmanager", TTL: "1m", Persistent: true},
+	} {
+		_, err := postable.ttl(time.Hour)
+		assert.Error(t, err, postable)
+	}
+}
@@ -0,0 +1,91 @@
+package instrumentation
+
+import (
+	"context"
+	"sync/atomic"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
+	otelsdktrace "go.opentelemetry.io/otel/sdk/trace"
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+var _ otlptrace.Client = (*loopback)(nil)
+var _ otelsdktrace.Sampler = (*loopbackSampler)(nil)
+
+// LoopbackSink writes the traces exported through the loopback, usually into the telemetry store of signoz itself.
+// The loopback only carries the traces, the metrics are exported through the readers of the meter provider.
+type LoopbackSink interface {
+	ConsumeTraces(ctx context.Context, traces ptrace.Traces) error
+}
+
+type loopbackKey struct{}
+
+// loopback is the client of the span exporter of the loopback, handing the spans to its sink in place of sending
+// them over the network. The spans exported before the sink is set are dropped.
+type loopback struct {
+	sink atomic.Pointer[LoopbackSink]
+}
+
+func newLoopback() *loopback {
+	return &loopback{}
+}
+
+func (loopback *loopback) Start(ctx context.Context) error {
+	return nil
+}
+
+func (loopback *loopback) Stop(ctx context.Context) error {
+	return nil
+}
+
+func (loopback *loopback) UploadTraces(ctx context.Context, resourceSpans []*tracepb.ResourceSpans) error {
+	sink := loopback.sink.Load()
+	if sink == nil || len(resourceSpans) == 0 {
+		return nil
+	}
+
+	// The spans are handed over in the representation of the collector, which is only reachable through the wire
+	// format from the one of the exporter.
+	buf, err := proto.Marshal(&coltracepb.ExportTraceServiceRequest{ResourceSpans: resourceSpans})
+	if err != nil {
+		return errors.WrapInternalf(err, errors.CodeInternal, "failed to marshal loopback spans")
+	}
+
+	request := ptraceotlp.NewExportRequest()
+	if err := request.UnmarshalProto(buf); err != nil {
+		return errors.WrapInternalf(err, errors.CodeInternal, "failed to unmarshal loopback spans")
+	}
+
+	// The spans started while writing the spans are not sampled, otherwise every write would be followed by the
+	// write of its own spans.
+	return (*sink).ConsumeTraces(context.WithValue(ctx, loopbackKey{}, true), request.Traces())
+}
+
+// loopbackSampler drops the spans started while the loopback writes the spans, and defers to the next sampler for
+// the other ones.
+type loopbackSampler struct {
+	next otelsdktrace.Sampler
+}
+
+func newLoopbackSampler(next otelsdktrace.Sampler) *loopbackSampler {
+	return &loopbackSampler{next: next}
+}
+
+func (sampler *loopbackSampler) ShouldSample(parameters otelsdktrace.SamplingParameters) otelsdktrace.SamplingResult {
+	if parameters.ParentContext != nil {
+		if ok, _ := parameters.ParentContext.Value(loopbackKey{}).(bool); ok {
+			return otelsdktrace.SamplingResult{Decision: otelsdktrace.Drop}
+		}
+	}
+
+	return sampler.next.ShouldSample(parameters)
+}
+
+func (sampler *loopbackSampler) Description() string {
+	return sampler.next.Description()
+}
@@ -0,0 +1,76 @@
+package instrumentation
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	contribsdkconfig "go.opentelemetry.io/contrib/config"
+	sdkresource "go.opentelemetry.io/otel/sdk/resource"
+	otelsdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// recordingSink records the names of the spans it writes, and starts a span of its own for every write as the
+// instrumentation of the telemetry store would.
+type recordingSink struct {
+	mtx            sync.Mutex
+	tracerProvider *otelsdktrace.TracerProvider
+	names          []string
+}
+
+func (sink *recordingSink) ConsumeTraces(ctx context.Context, traces ptrace.Traces) error {
+	_, span := sink.tracerProvider.Tracer("sink").Start(ctx, "write")
+	defer span.End()
+
+	sink.mtx.Lock()
+	defer sink.mtx.Unlock()
+	for i := 0; i < traces.ResourceSpans().Len(); i++ {
+		scopeSpans := traces.ResourceSpans().At(i).ScopeSpans()
+		for j := 0; j < scopeSpans.Len(); j++ {
+			for k := 0; k < scopeSpans.At(j).Spans().Len(); k++ {
+				sink.names = append(sink.names, scopeSpans.At(j).Spans().At(k).Name())
+			}
+		}
+	}
+
+	return nil
+}
+
+func TestLoopback(t *testing.T) {
+	loopback := newLoopback()
+	tracerProvider, _, err := newTracerProvider(context.Background(), TracesConfig{Enabled: true, Loopback: LoopbackConfig{Enabled: true}}, sdkresource.Default(), loopback)
+	require.NoError(t, err)
+
+	// The spans ended before the sink is set are dropped.
+	_, span := tracerProvider.Tracer("test").Start(context.Background(), "dropped")
+	span.End()
+	require.NoError(t, tracerProvider.ForceFlush(context.Background()))
+
+	sink := &recordingSink{tracerProvider: tracerProvider}
+	var loopbackSink LoopbackSink = sink
+	loopback.sink.Store(&loopbackSink)
+
+	_, span = tracerProvider.Tracer("test").Start(context.Background(), "looped")
+	span.End()
+	require.NoError(t, tracerProvider.ForceFlush(context.Background()))
+	require.NoError(t, tracerProvider.ForceFlush(context.Background()))
+
+	// The spans of the sink are not looped back.
+	assert.Equal(t, []string{"looped"}, sink.names)
+}
+
+func TestConfigValidateLoopback(t *testing.T) {
+	config := newConfig().(Config)
+	config.Traces.Loopback.Enabled = true
+	assert.Error(t, config.Validate())
+
+	config.Traces.Enabled = true
+	assert.NoError(t, config.Validate())
+
+	endpoint := "localhost:4317"
+	config.Traces.Processors.Batch.Exporter.OTLP = &contribsdkconfig.OTLP{Endpoint: endpoint}
+	assert.Error(t, config.Validate())
+}
@@ -0,0 +1,315 @@
+package instrumentation
+
+import (
+	"container/list"
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	contribsdkconfig "go.opentelemetry.io/contrib/config"
+	"go.opentelemetry.io/otel/codes"
+	otelsdktrace "go.opentelemetry.io/otel/sdk/trace"
+	sdktrace "go.opentelemetry.io/otel/trace"
+)
+
+// SamplingStatus is the status of the sampling of the traces exported by the instrumentation.
+type SamplingStatus struct {
+	// Enabled is false when the traces are not exported.
+	Enabled bool `json:"enabled"`
+	// Sampler is the description of the sampler of the traces.
+	Sampler string `json:"sampler,omitempty"`
+	// Ratio is the configured ratio of the traces which are sampled.
+	Ratio float64 `json:"ratio"`
+	// EffectiveRatio is the observed ratio of the traces which were sampled. It is the configured ratio until a
+	// trace is sampled or dropped.
+	EffectiveRatio float64 `json:"effectiveRatio"`
+	// Traces is the number of traces on which a sampling decision was taken.
+	Traces uint64 `json:"traces"`
+	// Sampled is the number of traces which were sampled.
+	Sampled uint64 `json:"sampled"`
+}
+
+// sampling counts the sampling decisions taken on the traces started by the instrumentation.
+type sampling struct {
+	description string
+	ratio       float64
+	traces      atomic.Uint64
+	sampled     atomic.Uint64
+}
+
+func (sampling *sampling) record(sampled bool) {
+	sampling.traces.Add(1)
+	if sampled {
+		sampling.sampled.Add(1)
+	}
+}
+
+func (sampling *sampling) status() SamplingStatus {
+	// The sampled traces are loaded first so that they never exceed the traces.
+	sampled := sampling.sampled.Load()
+	traces := sampling.traces.Load()
+
+	effectiveRatio := sampling.ratio
+	if traces > 0 {
+		effectiveRatio = float64(sampled) / float64(traces)
+	}
+
+	return SamplingStatus{
+		Enabled:        true,
+		Sampler:        sampling.description,
+		Ratio:          sampling.ratio,
+		EffectiveRatio: effectiveRatio,
+		Traces:         traces,
+		Sampled:        sampled,
+	}
+}
+
+// newSampler returns the sampler of the config along with the ratio of the root spans it samples. The sampler
+// defaults to sampling the root spans and following the decision of the parent of the other spans.
+func newSampler(config contribsdkconfig.Sampler) (otelsdktrace.Sampler, float64, error) {
+	set := 0
+	for _, ok := range []bool{config.AlwaysOff != nil, config.AlwaysOn != nil, config.JaegerRemote != nil, config.ParentBased != nil, config.TraceIDRatioBased != nil} {
+		if ok {
+			set++
+		}
+	}
+
+	if set > 1 {
+		return nil, 0, errors.New(errors.TypeInvalidInput, errors.CodeInvalidInput, "traces::sampler must not specify multiple samplers")
+	}
+
+	switch {
+	case config.AlwaysOff != nil:
+		return otelsdktrace.NeverSample(), 0, nil
+	case config.AlwaysOn != nil:
+		return otelsdktrace.AlwaysSample(), 1, nil
+	case config.JaegerRemote != nil:
+		return nil, 0, errors.New(errors.TypeUnsupported, errors.CodeUnsupported, "traces::sampler::jaeger_remote is not supported")
+	case config.TraceIDRatioBased != nil:
+		ratio := 1.0
+		if config.TraceIDRatioBased.Ratio != nil {
+			ratio = *config.TraceIDRatioBased.Ratio
+		}
+
+		if ratio < 0 || ratio > 1 {
+			return nil, 0, errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "traces::sampler::trace_id_ratio_based::ratio must be between 0 and 1, got %v", ratio)
+		}
+
+		return otelsdktrace.TraceIDRatioBased(ratio), ratio, nil
+	case config.ParentBased != nil:
+		root, ratio := otelsdktrace.AlwaysSample(), 1.0
+		if config.ParentBased.Root != nil {
+			var err error
+			root, ratio, err = newSampler(*config.ParentBased.Root)
+			if err != nil {
+				return nil, 0, err
+			}
+		}
+
+		var opts []otelsdktrace.ParentBasedSamplerOption
+		for _, parent := range []struct {
+			config *contribsdkconfig.Sampler
+			option func(otelsdktrace.Sampler) otelsdktrace.ParentBasedSamplerOption
+		}{
+			{config.ParentBased.RemoteParentSampled, otelsdktrace.WithRemoteParentSampled},
+			{config.ParentBased.RemoteParentNotSampled, otelsdktrace.WithRemoteParentNotSampled},
+			{config.ParentBased.LocalParentSampled, otelsdktrace.WithLocalParentSampled},
+			{config.ParentBased.LocalParentNotSampled, otelsdktrace.WithLocalParentNotSampled},
+		} {
+			if parent.config == nil {
+				continue
+			}
+
+			sampler, _, err := newSampler(*parent.config)
+			if err != nil {
+				return nil, 0, err
+			}
+
+			opts = append(opts, parent.option(sampler))
+		}
+
+		return otelsdktrace.ParentBased(root, opts...), ratio, nil
+	default:
+		return otelsdktrace.ParentBased(otelsdktrace.AlwaysSample()), 1, nil
+	}
+}
+
+// countingSampler counts the decisions of the sampler it wraps on the root spans of the traces, a span being a root
+// span when it has no parent in this process.
+type countingSampler struct {
+	sampler  otelsdktrace.Sampler
+	sampling *sampling
+}
+
+func newCountingSampler(sampler otelsdktrace.Sampler, ratio float64) *countingSampler {
+	return &countingSampler{
+		sampler:  sampler,
+		sampling: &sampling{description: sampler.Description(), ratio: ratio},
+	}
+}
+
+func (sampler *countingSampler) ShouldSample(parameters otelsdktrace.SamplingParameters) otelsdktrace.SamplingResult {
+	result := sampler.sampler.ShouldSample(parameters)
+
+	parent := sdktrace.SpanContextFromContext(parameters.ParentContext)
+	if !parent.IsValid() || parent.IsRemote() {
+		sampler.sampling.record(result.Decision == otelsdktrace.RecordAndSample)
+	}
+
+	return result
+}
+
+func (sampler *countingSampler) Description() string {
+	return sampler.sampler.Description()
+}
+
+// pendingTrace holds the spans of a trace until the sampling decision is taken.
+type pendingTrace struct {
+	id      sdktrace.TraceID
+	spans   []otelsdktrace.ReadOnlySpan
+	errored bool
+}
+
+// tailSampler buffers the spans of the traces and takes the sampling decision once the root span of a trace has
+// ended. The traces with an error or lasting at least the latency are always kept, the others are kept at the ratio.
+// The spans of a kept trace are handed to the next processor. The decisions are remembered for the last MaxTraces
+// traces, so that the spans ending after their root span follow the decision of their trace.
+type tailSampler struct {
+	config   TailSamplingConfig
+	next     otelsdktrace.SpanProcessor
+	ratio    otelsdktrace.Sampler
+	sampling *sampling
+
+	mtx sync.Mutex
+	// pending are the traces awaiting a decision, from the oldest to the newest.
+	pending       *list.List
+	pendingByID   map[sdktrace.TraceID]*list.Element
+	decided       map[sdktrace.TraceID]bool
+	decidedInFIFO []sdktrace.TraceID
+}
+
+func newTailSampler(config TailSamplingConfig, next otelsdktrace.SpanProcessor) *tailSampler {
+	return &tailSampler{
+		config: config,
+		next:   next,
+		ratio:  otelsdktrace.TraceIDRatioBased(config.Ratio),
+		sampling: &sampling{
+			description: fmt.Sprintf("TailSampling{ratio:%v,latency:%s}", config.Ratio, config.Latency),
+			ratio:       config.Ratio,
+		},
+		pending:     list.New(),
+		pendingByID: make(map[sdktrace.TraceID]*list.Element),
+		decided:     make(map[sdktrace.TraceID]bool),
+	}
+}
+
+// OnStart does not hand the span to the next processor, which only sees the spans of the kept traces once they end.
+func (sampler *tailSampler) OnStart(ctx context.Context, span otelsdktrace.ReadWriteSpan) {}
+
+func (sampler *tailSampler) OnEnd(span otelsdktrace.ReadOnlySpan) {
+	if !span.SpanContext().IsSampled() {
+		return
+	}
+
+	id := span.SpanContext().TraceID()
+
+	sampler.mtx.Lock()
+	if keep, ok := sampler.decided[id]; ok {
+		sampler.mtx.Unlock()
+		if keep {
+			sampler.next.OnEnd(span)
+		}
+		return
+	}
+
+	element, ok := sampler.pendingByID[id]
+	if !ok {
+		element = sampler.pending.PushBack(&pendingTrace{id: id})
+		sampler.pendingByID[id] = element
+	}
+
+	trace := element.Value.(*pendingTrace)
+	trace.spans = append(trace.spans, span)
+	if span.Status().Code == codes.Error {
+		trace.errored = true
+	}
+
+	var kept []otelsdktrace.ReadOnlySpan
+	if !span.Parent().IsValid() || span.Parent().IsRemote() {
+		kept = append(kept, sampler.decide(element)...)
+	}
+
+	// The oldest traces are decided early once too many traces are pending, so that the memory stays bounded.
+	for sampler.pending.Len() > sampler.config.MaxTraces {
+		kept = append(kept, sampler.decide(sampler.pending.Front())...)
+	}
+	sampler.mtx.Unlock()
+
+	for _, span := range kept {
+		sampler.next.OnEnd(span)
+	}
+}
+
+// Shutdown decides all the pending traces before shutting down the next processor.
+func (sampler *tailSampler) Shutdown(ctx context.Context) error {
+	sampler.flush()
+	return sampler.next.Shutdown(ctx)
+}
+
+// ForceFlush decides all the pending traces before flushing the next processor.
+func (sampler *tailSampler) ForceFlush(ctx context.Context) error {
+	sampler.flush()
+	return sampler.next.ForceFlush(ctx)
+}
+
+func (sampler *tailSampler) flush() {
+	sampler.mtx.Lock()
+	var kept []otelsdktrace.ReadOnlySpan
+	for sampler.pending.Len() > 0 {
+		kept = append(kept, sampler.decide(sampler.pending.Front())...)
+	}
+	sampler.mtx.Unlock()
+
+	for _, span := range kept {
+		sampler.next.OnEnd(span)
+	}
+}
+
+// decide takes the sampling decision of the pending trace and returns its spans when it is kept. It must be called
+// with the lock held.
+func (sampler *tailSampler) decide(element *list.Element) []otelsdktrace.ReadOnlySpan {
+	trace := sampler.pending.Remove(element).(*pendingTrace)
+	delete(sampler.pendingByID, trace.id)
+
+	var start, end time.Time
+	for _, span := range trace.spans {
+		if start.IsZero() || span.StartTime().Before(start) {
+			start = span.StartTime()
+		}
+
+		if span.EndTime().After(end) {
+			end = span.EndTime()
+		}
+	}
+
+	keep := trace.errored ||
+		end.Sub(start) >= sampler.config.Latency ||
+		sampler.ratio.ShouldSample(otelsdktrace.SamplingParameters{TraceID: trace.id}).Decision == otelsdktrace.RecordAndSample
+
+	sampler.decided[trace.id] = keep
+	sampler.decidedInFIFO = append(sampler.decidedInFIFO, trace.id)
+	if len(sampler.decidedInFIFO) > sampler.config.MaxTraces {
+		delete(sampler.decided, sampler.decidedInFIFO[0])
+		sampler.decidedInFIFO = sampler.decidedInFIFO[1:]
+	}
+
+	sampler.sampling.record(keep)
+	if !keep {
+		return nil
+	}
+
+	return trace.spans
+}
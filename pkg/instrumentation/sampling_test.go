@@ -0,0 +1,151 @@
+package instrumentation
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	contribsdkconfig "go.opentelemetry.io/contrib/config"
+	"go.opentelemetry.io/otel/codes"
+	otelsdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	sdktrace "go.opentelemetry.io/otel/trace"
+)
+
+func TestNewSampler(t *testing.T) {
+	ratio := 0.25
+	invalid := 2.0
+
+	testCases := []struct {
+		name        string
+		config      contribsdkconfig.Sampler
+		description string
+		ratio       float64
+		pass        bool
+	}{
+		{name: "Default", config: contribsdkconfig.Sampler{}, description: "ParentBased{root:AlwaysOnSampler,remoteParentSampled:AlwaysOnSampler,remoteParentNotSampled:AlwaysOffSampler,localParentSampled:AlwaysOnSampler,localParentNotSampled:AlwaysOffSampler}", ratio: 1, pass: true},
+		{name: "AlwaysOff", config: contribsdkconfig.Sampler{AlwaysOff: contribsdkconfig.SamplerAlwaysOff{}}, description: "AlwaysOffSampler", ratio: 0, pass: true},
+		{name: "TraceIDRatioBased", config: contribsdkconfig.Sampler{TraceIDRatioBased: &contribsdkconfig.SamplerTraceIDRatioBased{Ratio: &ratio}}, description: "TraceIDRatioBased{0.25}", ratio: 0.25, pass: true},
+		{name: "ParentBased", config: contribsdkconfig.Sampler{ParentBased: &contribsdkconfig.SamplerParentBased{Root: &contribsdkconfig.Sampler{TraceIDRatioBased: &contribsdkconfig.SamplerTraceIDRatioBased{Ratio: &ratio}}, RemoteParentNotSampled: &contribsdkconfig.Sampler{AlwaysOn: contribsdkconfig.SamplerAlwaysOn{}}}}, description: "ParentBased{root:TraceIDRatioBased{0.25},remoteParentSampled:AlwaysOnSampler,remoteParentNotSampled:AlwaysOnSampler,localParentSampled:AlwaysOnSampler,localParentNotSampled:AlwaysOffSampler}", ratio: 0.25, pass: true},
+		{name: "InvalidRatio", config: contribsdkconfig.Sampler{ParentBased: &contribsdkconfig.SamplerParentBased{Root: &contribsdkconfig.Sampler{TraceIDRatioBased: &contribsdkconfig.SamplerTraceIDRatioBased{Ratio: &invalid}}}}, pass: false},
+		{name: "Multiple", config: contribsdkconfig.Sampler{AlwaysOff: contribsdkconfig.SamplerAlwaysOff{}, AlwaysOn: contribsdkconfig.SamplerAlwaysOn{}}, pass: false},
+		{name: "JaegerRemote", config: contribsdkconfig.Sampler{JaegerRemote: &contribsdkconfig.SamplerJaegerRemote{}}, pass: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sampler, ratio, err := newSampler(tc.config)
+			if !tc.pass {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.description, sampler.Description())
+			assert.Equal(t, tc.ratio, ratio)
+		})
+	}
+}
+
+func TestCountingSampler(t *testing.T) {
+	ratio := 0.5
+	sampler, configured, err := newSampler(contribsdkconfig.Sampler{ParentBased: &contribsdkconfig.SamplerParentBased{Root: &contribsdkconfig.Sampler{TraceIDRatioBased: &contribsdkconfig.SamplerTraceIDRatioBased{Ratio: &ratio}}}})
+	require.NoError(t, err)
+
+	countingSampler := newCountingSampler(sampler, configured)
+	assert.Equal(t, SamplingStatus{Enabled: true, Sampler: sampler.Description(), Ratio: 0.5, EffectiveRatio: 0.5}, countingSampler.sampling.status())
+
+	recorder := tracetest.NewSpanRecorder()
+	tracerProvider := otelsdktrace.NewTracerProvider(otelsdktrace.WithSampler(countingSampler), otelsdktrace.WithSpanProcessor(recorder))
+	tracer := tracerProvider.Tracer("test")
+
+	for range 1000 {
+		ctx, root := tracer.Start(context.Background(), "root")
+		_, child := tracer.Start(ctx, "child")
+		// The children follow the decision of their root and are not counted.
+		assert.Equal(t, root.SpanContext().IsSampled(), child.SpanContext().IsSampled())
+		child.End()
+		root.End()
+	}
+
+	status := countingSampler.sampling.status()
+	assert.Equal(t, uint64(1000), status.Traces)
+	assert.Equal(t, uint64(len(recorder.Ended())/2), status.Sampled)
+	assert.InDelta(t, 0.5, status.EffectiveRatio, 0.1)
+}
+
+func TestTailSampler(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tailSampler := newTailSampler(TailSamplingConfig{Ratio: 0, Latency: time.Second, MaxTraces: 2}, recorder)
+	tracerProvider := otelsdktrace.NewTracerProvider(otelsdktrace.WithSampler(otelsdktrace.ParentBased(otelsdktrace.AlwaysSample())), otelsdktrace.WithSpanProcessor(tailSampler))
+	tracer := tracerProvider.Tracer("test")
+	now := time.Now()
+
+	// A fast trace without an error is dropped.
+	ctx, root := tracer.Start(context.Background(), "fast", sdktrace.WithTimestamp(now))
+	_, child := tracer.Start(ctx, "fast-child", sdktrace.WithTimestamp(now))
+	child.End(sdktrace.WithTimestamp(now.Add(time.Millisecond)))
+	root.End(sdktrace.WithTimestamp(now.Add(time.Millisecond)))
+	assert.Empty(t, recorder.Ended())
+
+	// A trace with an error is kept, along with the spans ending after its root.
+	ctx, root = tracer.Start(context.Background(), "errored", sdktrace.WithTimestamp(now))
+	_, child = tracer.Start(ctx, "errored-child", sdktrace.WithTimestamp(now))
+	_, late := tracer.Start(ctx, "errored-late", sdktrace.WithTimestamp(now))
+	child.SetStatus(codes.Error, "failed")
+	child.End(sdktrace.WithTimestamp(now.Add(time.Millisecond)))
+	root.End(sdktrace.WithTimestamp(now.Add(time.Millisecond)))
+	late.End(sdktrace.WithTimestamp(now.Add(2 * time.Millisecond)))
+	assert.Equal(t, []string{"errored-child", "errored", "errored-late"}, names(recorder.Ended()))
+
+	// A slow trace is kept.
+	_, root = tracer.Start(context.Background(), "slow", sdktrace.WithTimestamp(now))
+	root.End(sdktrace.WithTimestamp(now.Add(2 * time.Second)))
+	assert.Equal(t, []string{"errored-child", "errored", "errored-late", "slow"}, names(recorder.Ended()))
+
+	// The oldest pending trace is decided early once more than max traces are pending.
+	for _, name := range []string{"first", "second", "third"} {
+		ctx, root := tracer.Start(context.Background(), name, sdktrace.WithTimestamp(now))
+		_, child := tracer.Start(ctx, name+"-child", sdktrace.WithTimestamp(now))
+		if name == "first" {
+			child.SetStatus(codes.Error, "failed")
+		}
+		child.End(sdktrace.WithTimestamp(now.Add(time.Millisecond)))
+		defer root.End()
+	}
+	assert.Equal(t, []string{"errored-child", "errored", "errored-late", "slow", "first-child"}, names(recorder.Ended()))
+
+	status := tailSampler.sampling.status()
+	assert.Equal(t, uint64(4), status.Traces)
+	assert.Equal(t, uint64(3), status.Sampled)
+	assert.Equal(t, 0.75, status.EffectiveRatio)
+}
+
+func TestTailSamplerShutdown(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tailSampler := newTailSampler(TailSamplingConfig{Ratio: 0, Latency: time.Second, MaxTraces: 10}, recorder)
+	tracerProvider := otelsdktrace.NewTracerProvider(otelsdktrace.WithSpanProcessor(tailSampler))
+	tracer := tracerProvider.Tracer("test")
+
+	ctx, root := tracer.Start(context.Background(), "root")
+	_, child := tracer.Start(ctx, "child")
+	child.SetStatus(codes.Error, "failed")
+	child.End()
+	assert.Empty(t, recorder.Ended())
+
+	// The pending traces are decided on shutdown.
+	require.NoError(t, tracerProvider.Shutdown(context.Background()))
+	assert.Equal(t, []string{"child"}, names(recorder.Ended()))
+	root.End()
+}
+
+func names(spans []otelsdktrace.ReadOnlySpan) []string {
+	names := make([]string, 0, len(spans))
+	for _, span := range spans {
+		names = append(names, span.Name())
+	}
+
+	return names
+}
@@ -3,16 +3,23 @@ package instrumentation
 import (
 	"context"
 	"log/slog"
+	"maps"
+	"net/http"
+	"time"
 
 	"github.com/SigNoz/signoz/pkg/factory"
+	"github.com/SigNoz/signoz/pkg/instrumentation/loghandler"
 	"github.com/SigNoz/signoz/pkg/version"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/collectors"
 	contribsdkconfig "go.opentelemetry.io/contrib/config"
 	sdkmetric "go.opentelemetry.io/otel/metric"
+	otelsdkmetric "go.opentelemetry.io/otel/sdk/metric"
 	sdkresource "go.opentelemetry.io/otel/sdk/resource"
+	otelsdktrace "go.opentelemetry.io/otel/sdk/trace"
 	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
 	sdktrace "go.opentelemetry.io/otel/trace"
+	nooptrace "go.opentelemetry.io/otel/trace/noop"
 )
 
 var _ factory.Service = (*SDK)(nil)
@@ -23,7 +30,20 @@ type SDK struct {
 	logger             *slog.Logger
 	sdk                contribsdkconfig.SDK
 	prometheusRegistry *prometheus.Registry
-	startCh            chan struct{}
+	// prometheusRegisterer registers the metrics in the registry with the labels.
+	prometheusRegisterer prometheus.Registerer
+	// meterProvider is the meter provider of the metrics endpoint, it is nil when the endpoint is disabled.
+	meterProvider *otelsdkmetric.MeterProvider
+	// tracerProvider is the tracer provider of the traces, it is nil when the traces are disabled.
+	tracerProvider *otelsdktrace.TracerProvider
+	// loopback is the loopback of the traces, it is nil when the traces are not looped back.
+	loopback       *loopback
+	sampling       *sampling
+	logLevels      *loghandler.Levels
+	logOverrideTTL time.Duration
+	metricsHandler http.Handler
+	labels         map[string]string
+	startCh        chan struct{}
 }
 
 // New creates a new Instrumentation instance with configured providers.
@@ -52,25 +72,47 @@ func New(ctx context.Context, cfg Config, build version.Build, serviceName strin
 
 	// Prepare the resource configuration by merging
 	// resource and attributes.
+	attributes := mergeAttributes(cfg.Resource.Attributes, resource)
+	// The labels are attached to the metrics of the endpoint by the registry, they are left out of the resource of
+	// the endpoint which would attach them twice to the target_info metric.
+	endpointAttributes := maps.Clone(attributes)
+
+	// The labels are added to the resource so that they are attached to the spans as well.
+	for key, value := range cfg.Labels {
+		if _, ok := attributes[key]; !ok {
+			attributes[key] = value
+		}
+	}
+
 	sch := semconv.SchemaURL
 	configResource := contribsdkconfig.Resource{
-		Attributes: mergeAttributes(cfg.Resource.Attributes, resource),
+		Attributes: attributes,
 		Detectors:  nil,
 		SchemaUrl:  &sch,
 	}
 
-	var tracerProvider *contribsdkconfig.TracerProvider
+	// The tracer provider is not created by the contrib sdk, which does not apply the sampler.
+	var tracerProvider *otelsdktrace.TracerProvider
+	var tracesSampling *sampling
+	var tracesLoopback *loopback
 	if cfg.Traces.Enabled {
-		tracerProvider = &contribsdkconfig.TracerProvider{
-			Processors: []contribsdkconfig.SpanProcessor{
-				{Batch: &cfg.Traces.Processors.Batch},
-			},
-			Sampler: &cfg.Traces.Sampler,
+		tracesResource, err := newResource(attributes)
+		if err != nil {
+			return nil, err
+		}
+
+		if cfg.Traces.Loopback.Enabled {
+			tracesLoopback = newLoopback()
+		}
+
+		tracerProvider, tracesSampling, err = newTracerProvider(ctx, cfg.Traces, tracesResource, tracesLoopback)
+		if err != nil {
+			return nil, err
 		}
 	}
 
 	var meterProvider *contribsdkconfig.MeterProvider
-	if cfg.Metrics.Enabled {
+	if cfg.Metrics.Enabled && !cfg.Metrics.Endpoint.Enabled {
 		meterProvider = &contribsdkconfig.MeterProvider{
 			Readers: []contribsdkconfig.MetricReader{
 				{Pull: &cfg.Metrics.Readers.Pull},
@@ -81,23 +123,47 @@ func New(ctx context.Context, cfg Config, build version.Build, serviceName strin
 	sdk, err := contribsdkconfig.NewSDK(
 		contribsdkconfig.WithContext(ctx),
 		contribsdkconfig.WithOpenTelemetryConfiguration(contribsdkconfig.OpenTelemetryConfiguration{
-			TracerProvider: tracerProvider,
-			MeterProvider:  meterProvider,
-			Resource:       &configResource,
+			MeterProvider: meterProvider,
+			Resource:      &configResource,
 		}),
 	)
 	if err != nil {
 		return nil, err
 	}
 
+	// The labels are attached to every metric registered in the registry, including the metrics of the meter
+	// provider of the endpoint.
 	prometheusRegistry := prometheus.NewRegistry()
-	prometheusRegistry.MustRegister(collectors.NewBuildInfoCollector())
+	prometheusRegisterer := prometheus.WrapRegistererWith(prometheus.Labels(cfg.Labels), prometheusRegistry)
+	prometheusRegisterer.MustRegister(collectors.NewBuildInfoCollector())
+
+	var endpointMeterProvider *otelsdkmetric.MeterProvider
+	metricsHandler := http.NotFoundHandler()
+	if cfg.Metrics.Enabled && cfg.Metrics.Endpoint.Enabled {
+		endpointMeterProvider, err = newEndpointMeterProvider(prometheusRegisterer, endpointAttributes)
+		if err != nil {
+			return nil, err
+		}
+
+		metricsHandler = newMetricsHandler(prometheusRegistry, cfg.Metrics.Endpoint.Token)
+	}
+
+	logLevels := loghandler.NewLevels(cfg.Logs.Level, cfg.Logs.Modules)
 
 	return &SDK{
-		sdk:                sdk,
-		prometheusRegistry: prometheusRegistry,
-		logger:             NewLogger(cfg),
-		startCh:            make(chan struct{}),
+		sdk:                  sdk,
+		prometheusRegistry:   prometheusRegistry,
+		prometheusRegisterer: prometheusRegisterer,
+		meterProvider:        endpointMeterProvider,
+		tracerProvider:       tracerProvider,
+		loopback:             tracesLoopback,
+		sampling:             tracesSampling,
+		logLevels:            logLevels,
+		logOverrideTTL:       cfg.Logs.OverrideTTL,
+		metricsHandler:       metricsHandler,
+		labels:               cfg.Labels,
+		logger:               NewLogger(cfg, logLevels, loghandler.NewCorrelation()),
+		startCh:              make(chan struct{}),
 	}, nil
 }
 
@@ -108,6 +174,18 @@ func (i *SDK) Start(ctx context.Context) error {
 
 func (i *SDK) Stop(ctx context.Context) error {
 	close(i.startCh)
+	if i.tracerProvider != nil {
+		if err := i.tracerProvider.Shutdown(ctx); err != nil {
+			return err
+		}
+	}
+
+	if i.meterProvider != nil {
+		if err := i.meterProvider.Shutdown(ctx); err != nil {
+			return err
+		}
+	}
+
 	return i.sdk.Shutdown(ctx)
 }
 
@@ -116,15 +194,62 @@ func (i *SDK) Logger() *slog.Logger {
 }
 
 func (i *SDK) MeterProvider() sdkmetric.MeterProvider {
+	if i.meterProvider != nil {
+		return i.meterProvider
+	}
+
 	return i.sdk.MeterProvider()
 }
 
+func (i *SDK) MetricsHandler() http.Handler {
+	return i.metricsHandler
+}
+
 func (i *SDK) TracerProvider() sdktrace.TracerProvider {
-	return i.sdk.TracerProvider()
+	if i.tracerProvider != nil {
+		return i.tracerProvider
+	}
+
+	return nooptrace.NewTracerProvider()
+}
+
+// SetLoopbackSink sets the sink of the traces when they are looped back, and is a no-op otherwise. The telemetry
+// store is created after the instrumentation, hence the sink is set once it is available.
+func (i *SDK) SetLoopbackSink(sink LoopbackSink) {
+	if i.loopback == nil {
+		return
+	}
+
+	i.loopback.sink.Store(&sink)
+}
+
+func (i *SDK) Sampling() SamplingStatus {
+	if i.sampling == nil {
+		return SamplingStatus{Enabled: false}
+	}
+
+	return i.sampling.status()
+}
+
+func (i *SDK) LogLevels() loghandler.LevelsStatus {
+	return i.logLevels.Status()
+}
+
+func (i *SDK) SetLogLevel(postable PostableLogLevel) (loghandler.LevelOverride, error) {
+	ttl, err := postable.ttl(i.logOverrideTTL)
+	if err != nil {
+		return loghandler.LevelOverride{}, err
+	}
+
+	return i.logLevels.Set(postable.Module, postable.Level, ttl), nil
+}
+
+func (i *SDK) ResetLogLevel(module string) {
+	i.logLevels.Reset(module)
 }
 
 func (i *SDK) PrometheusRegisterer() prometheus.Registerer {
-	return i.prometheusRegistry
+	return i.prometheusRegisterer
 }
 
 func (i *SDK) ToProviderSettings() factory.ProviderSettings {
@@ -133,5 +258,6 @@ func (i *SDK) ToProviderSettings() factory.ProviderSettings {
 		MeterProvider:        i.MeterProvider(),
 		TracerProvider:       i.TracerProvider(),
 		PrometheusRegisterer: i.PrometheusRegisterer(),
+		Labels:               maps.Clone(i.labels),
 	}
 }
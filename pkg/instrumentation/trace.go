@@ -0,0 +1,213 @@
+package instrumentation
+
+import (
+	"context"
+	"net/url"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	contribsdkconfig "go.opentelemetry.io/contrib/config"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	sdkresource "go.opentelemetry.io/otel/sdk/resource"
+	otelsdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// newTracerProvider returns the tracer provider of the config along with its sampling. The tracer provider is built
+// here rather than by the contrib sdk, which does not apply the sampler of the config. The spans are exported through
+// the loopback when it is not nil.
+func newTracerProvider(ctx context.Context, config TracesConfig, resource *sdkresource.Resource, loopback *loopback) (*otelsdktrace.TracerProvider, *sampling, error) {
+	var exporter otelsdktrace.SpanExporter
+	var err error
+	if loopback != nil {
+		exporter, err = otlptrace.New(ctx, loopback)
+	} else {
+		exporter, err = newSpanExporter(ctx, config.Processors.Batch.Exporter)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	guard := func(sampler otelsdktrace.Sampler) otelsdktrace.Sampler {
+		if loopback == nil {
+			return sampler
+		}
+
+		return newLoopbackSampler(sampler)
+	}
+
+	processor, err := newBatchSpanProcessor(config.Processors.Batch, exporter)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if config.TailSampling.Enabled {
+		tailSampler := newTailSampler(config.TailSampling, processor)
+
+		return otelsdktrace.NewTracerProvider(
+			otelsdktrace.WithResource(resource),
+			// Every trace is recorded so that the decision can be taken once it has ended.
+			otelsdktrace.WithSampler(guard(otelsdktrace.ParentBased(otelsdktrace.AlwaysSample()))),
+			otelsdktrace.WithSpanProcessor(tailSampler),
+		), tailSampler.sampling, nil
+	}
+
+	sampler, ratio, err := newSampler(config.Sampler)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	countingSampler := newCountingSampler(sampler, ratio)
+
+	return otelsdktrace.NewTracerProvider(
+		otelsdktrace.WithResource(resource),
+		otelsdktrace.WithSampler(guard(countingSampler)),
+		otelsdktrace.WithSpanProcessor(processor),
+	), countingSampler.sampling, nil
+}
+
+func newSpanExporter(ctx context.Context, config contribsdkconfig.SpanExporter) (otelsdktrace.SpanExporter, error) {
+	if config.Console != nil && config.OTLP != nil {
+		return nil, errors.New(errors.TypeInvalidInput, errors.CodeInvalidInput, "traces::processors::batch::exporter must not specify multiple exporters")
+	}
+
+	if config.Console != nil {
+		return stdouttrace.New(stdouttrace.WithPrettyPrint())
+	}
+
+	if config.OTLP == nil {
+		return nil, errors.New(errors.TypeInvalidInput, errors.CodeInvalidInput, "traces::processors::batch::exporter must specify an exporter")
+	}
+
+	switch config.OTLP.Protocol {
+	case "http/protobuf":
+		return newOTLPHTTPSpanExporter(ctx, config.OTLP)
+	// The protocol defaults to grpc, the endpoint of the example config being a grpc endpoint.
+	case "grpc", "":
+		return newOTLPGRPCSpanExporter(ctx, config.OTLP)
+	default:
+		return nil, errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "traces::processors::batch::exporter::otlp::protocol %q is not supported", config.OTLP.Protocol)
+	}
+}
+
+func newOTLPGRPCSpanExporter(ctx context.Context, config *contribsdkconfig.OTLP) (otelsdktrace.SpanExporter, error) {
+	var opts []otlptracegrpc.Option
+
+	if config.Endpoint != "" {
+		u, err := url.ParseRequestURI(config.Endpoint)
+		// An endpoint without a scheme, such as localhost:4317, is used as is.
+		if err == nil && u.Host != "" {
+			opts = append(opts, otlptracegrpc.WithEndpoint(u.Host))
+			if u.Scheme == "http" {
+				opts = append(opts, otlptracegrpc.WithInsecure())
+			}
+		} else {
+			opts = append(opts, otlptracegrpc.WithEndpoint(config.Endpoint))
+		}
+	}
+
+	if config.Insecure != nil && *config.Insecure {
+		opts = append(opts, otlptracegrpc.WithInsecure())
+	}
+
+	if config.Compression != nil {
+		switch *config.Compression {
+		case "gzip":
+			opts = append(opts, otlptracegrpc.WithCompressor(*config.Compression))
+		case "none":
+		default:
+			return nil, errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "traces::processors::batch::exporter::otlp::compression %q is not supported", *config.Compression)
+		}
+	}
+
+	if config.Timeout != nil && *config.Timeout > 0 {
+		opts = append(opts, otlptracegrpc.WithTimeout(time.Duration(*config.Timeout)*time.Millisecond))
+	}
+
+	if len(config.Headers) > 0 {
+		opts = append(opts, otlptracegrpc.WithHeaders(config.Headers))
+	}
+
+	return otlptracegrpc.New(ctx, opts...)
+}
+
+func newOTLPHTTPSpanExporter(ctx context.Context, config *contribsdkconfig.OTLP) (otelsdktrace.SpanExporter, error) {
+	var opts []otlptracehttp.Option
+
+	if config.Endpoint != "" {
+		u, err := url.ParseRequestURI(config.Endpoint)
+		if err != nil {
+			return nil, errors.Wrapf(err, errors.TypeInvalidInput, errors.CodeInvalidInput, "invalid traces::processors::batch::exporter::otlp::endpoint")
+		}
+
+		opts = append(opts, otlptracehttp.WithEndpoint(u.Host))
+		if u.Scheme == "http" {
+			opts = append(opts, otlptracehttp.WithInsecure())
+		}
+
+		if u.Path != "" {
+			opts = append(opts, otlptracehttp.WithURLPath(u.Path))
+		}
+	}
+
+	if config.Insecure != nil && *config.Insecure {
+		opts = append(opts, otlptracehttp.WithInsecure())
+	}
+
+	if config.Compression != nil {
+		switch *config.Compression {
+		case "gzip":
+			opts = append(opts, otlptracehttp.WithCompression(otlptracehttp.GzipCompression))
+		case "none":
+			opts = append(opts, otlptracehttp.WithCompression(otlptracehttp.NoCompression))
+		default:
+			return nil, errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "traces::processors::batch::exporter::otlp::compression %q is not supported", *config.Compression)
+		}
+	}
+
+	if config.Timeout != nil && *config.Timeout > 0 {
+		opts = append(opts, otlptracehttp.WithTimeout(time.Duration(*config.Timeout)*time.Millisecond))
+	}
+
+	if len(config.Headers) > 0 {
+		opts = append(opts, otlptracehttp.WithHeaders(config.Headers))
+	}
+
+	return otlptracehttp.New(ctx, opts...)
+}
+
+func newBatchSpanProcessor(config contribsdkconfig.BatchSpanProcessor, exporter otelsdktrace.SpanExporter) (otelsdktrace.SpanProcessor, error) {
+	var opts []otelsdktrace.BatchSpanProcessorOption
+
+	if config.ExportTimeout != nil {
+		if *config.ExportTimeout < 0 {
+			return nil, errors.New(errors.TypeInvalidInput, errors.CodeInvalidInput, "traces::processors::batch::export_timeout cannot be negative")
+		}
+		opts = append(opts, otelsdktrace.WithExportTimeout(time.Duration(*config.ExportTimeout)*time.Millisecond))
+	}
+
+	if config.MaxExportBatchSize != nil {
+		if *config.MaxExportBatchSize < 0 {
+			return nil, errors.New(errors.TypeInvalidInput, errors.CodeInvalidInput, "traces::processors::batch::max_export_batch_size cannot be negative")
+		}
+		opts = append(opts, otelsdktrace.WithMaxExportBatchSize(*config.MaxExportBatchSize))
+	}
+
+	if config.MaxQueueSize != nil {
+		if *config.MaxQueueSize < 0 {
+			return nil, errors.New(errors.TypeInvalidInput, errors.CodeInvalidInput, "traces::processors::batch::max_queue_size cannot be negative")
+		}
+		opts = append(opts, otelsdktrace.WithMaxQueueSize(*config.MaxQueueSize))
+	}
+
+	if config.ScheduleDelay != nil {
+		if *config.ScheduleDelay < 0 {
+			return nil, errors.New(errors.TypeInvalidInput, errors.CodeInvalidInput, "traces::processors::batch::schedule_delay cannot be negative")
+		}
+		opts = append(opts, otelsdktrace.WithBatchTimeout(time.Duration(*config.ScheduleDelay)*time.Millisecond))
+	}
+
+	return otelsdktrace.NewBatchSpanProcessor(exporter, opts...), nil
+}
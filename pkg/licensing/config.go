@@ -3,16 +3,39 @@ package licensing
 import (
 	"time"
 
+	"github.com/SigNoz/signoz/pkg/errors"
 	"github.com/SigNoz/signoz/pkg/factory"
 )
 
 var _ factory.Config = (*Config)(nil)
 
 type Config struct {
-	PollInterval     time.Duration `mapstructure:"poll_interval"`
-	FailureThreshold int           `mapstructure:"failure_threshold"`
+	PollInterval            time.Duration        `mapstructure:"poll_interval"`
+	FailureThreshold        int                  `mapstructure:"failure_threshold"`
+	FeaturesRefreshInterval time.Duration        `mapstructure:"features_refresh_interval"`
+	CircuitBreaker          CircuitBreakerConfig `mapstructure:"circuit_breaker"`
+}
+
+// CircuitBreakerConfig is the configuration of the circuit breaker around the calls to zeus.
+type CircuitBreakerConfig struct {
+	// FailureThreshold is the number of consecutive failures after which the breaker opens. Zero disables the breaker.
+	FailureThreshold int `mapstructure:"failure_threshold"`
+	// OpenTimeout is the time the breaker stays open before a call is let through to probe the recovery of zeus.
+	OpenTimeout time.Duration `mapstructure:"open_timeout"`
 }
 
 func (c Config) Validate() error {
+	if c.FeaturesRefreshInterval <= 0 {
+		return errors.New(errors.TypeInvalidInput, errors.CodeInvalidInput, "features_refresh_interval must be greater than 0")
+	}
+
+	if c.CircuitBreaker.FailureThreshold < 0 {
+		return errors.New(errors.TypeInvalidInput, errors.CodeInvalidInput, "circuit_breaker::failure_threshold cannot be negative")
+	}
+
+	if c.CircuitBreaker.FailureThreshold > 0 && c.CircuitBreaker.OpenTimeout <= 0 {
+		return errors.New(errors.TypeInvalidInput, errors.CodeInvalidInput, "circuit_breaker::open_timeout must be greater than 0")
+	}
+
 	return nil
 }
@@ -33,6 +33,12 @@ type Licensing interface {
 	Portal(ctx context.Context, organizationID valuer.UUID, postableSubscription *licensetypes.PostableSubscription) (*licensetypes.GettableSubscription, error)
 	// GetFeatureFlags fetches all the defined feature flags
 	GetFeatureFlags(ctx context.Context, organizationID valuer.UUID) ([]*licensetypes.Feature, error)
+	// Features returns a copy of the cached snapshot of the feature flags in org, which the caller may mutate. The
+	// snapshot is refreshed in the background after the configured interval and falls back to the last known good
+	// snapshot if the refresh fails.
+	Features(ctx context.Context, organizationID valuer.UUID) ([]*licensetypes.Feature, error)
+	// ListAuditEvents pages through the license audit events in org, most recent first
+	ListAuditEvents(ctx context.Context, organizationID valuer.UUID, limit int, offset int) ([]*licensetypes.GettableAuditEvent, error)
 
 	statsreporter.StatsCollector
 }
@@ -41,6 +47,7 @@ type API interface {
 	Activate(http.ResponseWriter, *http.Request)
 	Refresh(http.ResponseWriter, *http.Request)
 	GetActive(http.ResponseWriter, *http.Request)
+	ListAuditEvents(http.ResponseWriter, *http.Request)
 
 	Checkout(http.ResponseWriter, *http.Request)
 	Portal(http.ResponseWriter, *http.Request)
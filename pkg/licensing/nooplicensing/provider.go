@@ -60,7 +60,15 @@ func (provider *noopLicensing) GetActive(ctx context.Context, organizationID val
 }
 
 func (provider *noopLicensing) GetFeatureFlags(_ context.Context, _ valuer.UUID) ([]*licensetypes.Feature, error) {
-	return licensetypes.DefaultFeatureSet, nil
+	return licensetypes.CloneFeatures(licensetypes.DefaultFeatureSet), nil
+}
+
+func (provider *noopLicensing) Features(_ context.Context, _ valuer.UUID) ([]*licensetypes.Feature, error) {
+	return licensetypes.CloneFeatures(licensetypes.DefaultFeatureSet), nil
+}
+
+func (provider *noopLicensing) ListAuditEvents(_ context.Context, _ valuer.UUID, _ int, _ int) ([]*licensetypes.GettableAuditEvent, error) {
+	return nil, errors.New(errors.TypeUnsupported, licensing.ErrCodeUnsupported, "listing license audit events is not supported")
 }
 
 func (provider *noopLicensing) Collect(ctx context.Context, orgID valuer.UUID) (map[string]any, error) {
@@ -0,0 +1,104 @@
+package overridelicensing
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/http/render"
+	"github.com/SigNoz/signoz/pkg/licensing"
+	"github.com/SigNoz/signoz/pkg/types/authtypes"
+	"github.com/SigNoz/signoz/pkg/types/licensetypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+	"github.com/gorilla/mux"
+)
+
+type API struct {
+	licensing licensing.Licensing
+}
+
+func NewAPI(licensing licensing.Licensing) *API {
+	return &API{licensing: licensing}
+}
+
+func (api *API) List(rw http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	overrider, orgID, err := api.overrider(ctx)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	overrides, err := overrider.ListOverrides(ctx, orgID)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	render.Success(rw, http.StatusOK, overrides)
+}
+
+func (api *API) Set(rw http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	overrider, orgID, err := api.overrider(ctx)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	override := new(licensetypes.PostableFeatureOverride)
+	if err := json.NewDecoder(r.Body).Decode(override); err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	if err := overrider.SetOverride(ctx, orgID, override); err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	render.Success(rw, http.StatusNoContent, nil)
+}
+
+func (api *API) Delete(rw http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	overrider, orgID, err := api.overrider(ctx)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	if err := overrider.DeleteOverride(ctx, orgID, mux.Vars(r)["name"]); err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	render.Success(rw, http.StatusNoContent, nil)
+}
+
+func (api *API) overrider(ctx context.Context) (Overrider, valuer.UUID, error) {
+	overrider, ok := api.licensing.(Overrider)
+	if !ok {
+		return nil, valuer.UUID{}, errors.New(errors.TypeUnsupported, ErrCodeOverridesDisabled, "feature overrides are not enabled")
+	}
+
+	claims, err := authtypes.ClaimsFromContext(ctx)
+	if err != nil {
+		return nil, valuer.UUID{}, err
+	}
+
+	orgID, err := valuer.NewUUID(claims.OrgID)
+	if err != nil {
+		return nil, valuer.UUID{}, errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "orgId is invalid")
+	}
+
+	return overrider, orgID, nil
+}
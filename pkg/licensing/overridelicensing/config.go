@@ -0,0 +1,41 @@
+package overridelicensing
+
+import (
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/factory"
+)
+
+type Config struct {
+	// Enabled enables the overrides of the feature flags. It is only supported by the builds with the
+	// licensingoverrides build tag, which the release builds do not have.
+	Enabled bool `mapstructure:"enabled"`
+
+	// Features are the overrides of the feature flags of every organization keyed by the feature name. The overrides
+	// set through the API take precedence over them.
+	Features map[string]bool `mapstructure:"features"`
+}
+
+func NewConfigFactory() factory.ConfigFactory {
+	return factory.NewConfigFactory(factory.MustNewName("feature_overrides"), newConfig)
+}
+
+func newConfig() factory.Config {
+	return Config{
+		Enabled:  false,
+		Features: map[string]bool{},
+	}
+}
+
+func (c Config) Validate() error {
+	if c.Enabled && !Allowed {
+		return errors.New(errors.TypeInvalidInput, errors.CodeInvalidInput, "feature_overrides cannot be enabled in this build, it must be built with the licensingoverrides tag")
+	}
+
+	for name := range c.Features {
+		if name == "" {
+			return errors.New(errors.TypeInvalidInput, errors.CodeInvalidInput, "feature_overrides::features must not have an empty feature name")
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,6 @@
+//go:build !licensingoverrides
+
+package overridelicensing
+
+// Allowed is whether the overrides of the feature flags can be enabled in this build.
+const Allowed bool = false
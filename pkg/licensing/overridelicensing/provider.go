@@ -0,0 +1,235 @@
+package overridelicensing
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/factory"
+	"github.com/SigNoz/signoz/pkg/licensing"
+	"github.com/SigNoz/signoz/pkg/sqlstore"
+	"github.com/SigNoz/signoz/pkg/types/authtypes"
+	"github.com/SigNoz/signoz/pkg/types/licensetypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+)
+
+var (
+	ErrCodeOverridesDisabled = errors.MustNewCode("feature_overrides_disabled")
+)
+
+// Overrider sets the overrides of the feature flags of the organizations.
+type Overrider interface {
+	// ListOverrides returns the overrides of the feature flags in org, including the ones of the config.
+	ListOverrides(ctx context.Context, organizationID valuer.UUID) ([]*licensetypes.GettableFeatureOverride, error)
+	// SetOverride overrides the feature flag in org.
+	SetOverride(ctx context.Context, organizationID valuer.UUID, override *licensetypes.PostableFeatureOverride) error
+	// DeleteOverride deletes the override of the feature flag in org set by SetOverride.
+	DeleteOverride(ctx context.Context, organizationID valuer.UUID, name string) error
+	// ApplyOverrides returns a copy of the features with the overrides of org applied, for the features which are
+	// not derived from the licensing only.
+	ApplyOverrides(ctx context.Context, organizationID valuer.UUID, features []*licensetypes.Feature) ([]*licensetypes.Feature, error)
+}
+
+// provider overrides the feature flags derived from the license of the licensing it wraps. The overrides set through
+// the API are stored in the sqlstore, hence they are shared between the replicas and audited in the license audit.
+type provider struct {
+	licensing.Licensing
+	settings factory.ScopedProviderSettings
+	config   Config
+	sqlstore sqlstore.SQLStore
+}
+
+// New returns the given licensing with the overrides of the config applied on top of it, or the given licensing
+// as is when the overrides are not enabled.
+func New(ctx context.Context, providerSettings factory.ProviderSettings, config Config, sqlstore sqlstore.SQLStore, next licensing.Licensing) (licensing.Licensing, error) {
+	if !config.Enabled {
+		return next, nil
+	}
+
+	if !Allowed {
+		return nil, errors.New(errors.TypeUnsupported, ErrCodeOverridesDisabled, "feature overrides are not supported by this build")
+	}
+
+	return newProvider(ctx, providerSettings, config, sqlstore, next), nil
+}
+
+func newProvider(ctx context.Context, providerSettings factory.ProviderSettings, config Config, sqlstore sqlstore.SQLStore, next licensing.Licensing) *provider {
+	provider := &provider{
+		Licensing: next,
+		settings:  factory.NewScopedProviderSettings(providerSettings, "github.com/SigNoz/signoz/pkg/licensing/overridelicensing"),
+		config:    config,
+		sqlstore:  sqlstore,
+	}
+
+	// The overrides of the config apply to every organization and are part of the config, they are logged rather
+	// than stored in the license audit of each organization.
+	for _, name := range slices.Sorted(maps.Keys(config.Features)) {
+		provider.settings.Logger().WarnContext(
+			ctx,
+			"license audit event",
+			"audit.type", licensetypes.AuditEventTypeOverridden.StringValue(),
+			"audit.actor", licensetypes.AuditActorSystem,
+			"audit.feature", name,
+			"audit.active", config.Features[name],
+		)
+	}
+
+	return provider
+}
+
+func (provider *provider) GetFeatureFlags(ctx context.Context, organizationID valuer.UUID) ([]*licensetypes.Feature, error) {
+	features, err := provider.Licensing.GetFeatureFlags(ctx, organizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	return provider.ApplyOverrides(ctx, organizationID, features)
+}
+
+func (provider *provider) Features(ctx context.Context, organizationID valuer.UUID) ([]*licensetypes.Feature, error) {
+	features, err := provider.Licensing.Features(ctx, organizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	return provider.ApplyOverrides(ctx, organizationID, features)
+}
+
+func (provider *provider) ApplyOverrides(ctx context.Context, organizationID valuer.UUID, features []*licensetypes.Feature) ([]*licensetypes.Feature, error) {
+	overrides, err := provider.overridesOf(ctx, organizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	return licensetypes.ApplyFeatureOverrides(features, overrides), nil
+}
+
+func (provider *provider) ListOverrides(ctx context.Context, organizationID valuer.UUID) ([]*licensetypes.GettableFeatureOverride, error) {
+	overrides, err := provider.overridesOf(ctx, organizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	gettable := make([]*licensetypes.GettableFeatureOverride, 0, len(overrides))
+	for _, name := range slices.Sorted(maps.Keys(overrides)) {
+		gettable = append(gettable, &licensetypes.GettableFeatureOverride{Name: name, Active: overrides[name]})
+	}
+
+	return gettable, nil
+}
+
+func (provider *provider) SetOverride(ctx context.Context, organizationID valuer.UUID, override *licensetypes.PostableFeatureOverride) error {
+	return provider.update(ctx, organizationID, func(ctx context.Context) error {
+		storable := &licensetypes.StorableFeatureOverride{
+			OrgID:     organizationID,
+			Name:      override.Name,
+			Active:    override.Active,
+			UpdatedAt: time.Now(),
+		}
+
+		_, err := provider.
+			sqlstore.
+			BunDBCtx(ctx).
+			NewInsert().
+			Model(storable).
+			On("CONFLICT (org_id, name) DO UPDATE").
+			Set("active = EXCLUDED.active").
+			Set("updated_at = EXCLUDED.updated_at").
+			Exec(ctx)
+		if err != nil {
+			return errors.Wrapf(err, errors.TypeInternal, errors.CodeInternal, "failed to store the override of feature %q", override.Name)
+		}
+
+		return nil
+	})
+}
+
+func (provider *provider) DeleteOverride(ctx context.Context, organizationID valuer.UUID, name string) error {
+	return provider.update(ctx, organizationID, func(ctx context.Context) error {
+		result, err := provider.
+			sqlstore.
+			BunDBCtx(ctx).
+			NewDelete().
+			Model(new(licensetypes.StorableFeatureOverride)).
+			Where("org_id = ?", organizationID).
+			Where("name = ?", name).
+			Exec(ctx)
+		if err != nil {
+			return errors.Wrapf(err, errors.TypeInternal, errors.CodeInternal, "failed to delete the override of feature %q", name)
+		}
+
+		if rows, err := result.RowsAffected(); err == nil && rows == 0 {
+			return errors.Newf(errors.TypeNotFound, errors.CodeNotFound, "feature %q is not overridden", name)
+		}
+
+		return nil
+	})
+}
+
+// update runs cb in a transaction along with the audit event of the change of the feature flags of org it makes.
+func (provider *provider) update(ctx context.Context, organizationID valuer.UUID, cb func(context.Context) error) error {
+	features, err := provider.Licensing.Features(ctx, organizationID)
+	if err != nil {
+		return err
+	}
+
+	actor := licensetypes.AuditActorSystem
+	if claims, err := authtypes.ClaimsFromContext(ctx); err == nil {
+		actor = claims.Email
+	}
+
+	return provider.sqlstore.RunInTxCtx(ctx, nil, func(ctx context.Context) error {
+		previous, err := provider.ApplyOverrides(ctx, organizationID, features)
+		if err != nil {
+			return err
+		}
+
+		if err := cb(ctx); err != nil {
+			return err
+		}
+
+		current, err := provider.ApplyOverrides(ctx, organizationID, features)
+		if err != nil {
+			return err
+		}
+
+		_, err = provider.
+			sqlstore.
+			BunDBCtx(ctx).
+			NewInsert().
+			Model(licensetypes.NewOverrideAuditEvent(actor, organizationID, previous, current)).
+			Exec(ctx)
+		if err != nil {
+			return errors.Wrapf(err, errors.TypeInternal, errors.CodeInternal, "failed to create the audit event of the feature overrides")
+		}
+
+		return nil
+	})
+}
+
+// overridesOf returns the overrides of the config merged with the overrides of the org.
+func (provider *provider) overridesOf(ctx context.Context, organizationID valuer.UUID) (map[string]bool, error) {
+	storables := make([]*licensetypes.StorableFeatureOverride, 0)
+	err := provider.
+		sqlstore.
+		BunDBCtx(ctx).
+		NewSelect().
+		Model(&storables).
+		Where("org_id = ?", organizationID).
+		Scan(ctx)
+	if err != nil {
+		return nil, errors.Wrapf(err, errors.TypeInternal, errors.CodeInternal, "failed to get the feature overrides")
+	}
+
+	overrides := maps.Clone(provider.config.Features)
+	if overrides == nil {
+		overrides = make(map[string]bool)
+	}
+	for _, storable := range storables {
+		overrides[storable.Name] = storable.Active
+	}
+
+	return overrides, nil
+}
@@ -0,0 +1,128 @@
+package overridelicensing
+
+import (
+	"context"
+	"testing"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/factory/factorytest"
+	"github.com/SigNoz/signoz/pkg/licensing"
+	"github.com/SigNoz/signoz/pkg/licensing/nooplicensing"
+	"github.com/SigNoz/signoz/pkg/sqlstore"
+	"github.com/SigNoz/signoz/pkg/sqlstore/sqlstoretest"
+	"github.com/SigNoz/signoz/pkg/types/licensetypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func activeOf(features []*licensetypes.Feature) map[string]bool {
+	active := make(map[string]bool, len(features))
+	for _, feature := range features {
+		active[feature.Name.StringValue()] = feature.Active
+	}
+
+	return active
+}
+
+func newTestStore(t *testing.T) sqlstore.SQLStore {
+	store := sqlstoretest.NewSQLite(t)
+	for _, model := range []any{new(licensetypes.StorableFeatureOverride), new(licensetypes.StorableAuditEvent)} {
+		_, err := store.BunDB().NewCreateTable().Model(model).Exec(context.Background())
+		require.NoError(t, err)
+	}
+
+	return store
+}
+
+func TestProviderOverrides(t *testing.T) {
+	ctx := context.Background()
+	next, err := nooplicensing.New(ctx, factorytest.NewSettings(), licensing.Config{})
+	require.NoError(t, err)
+
+	store := newTestStore(t)
+	config := Config{Enabled: true, Features: map[string]bool{licensetypes.UseSpanMetrics.StringValue(): true, "beta": true}}
+	provider := newProvider(ctx, factorytest.NewSettings(), config, store, next)
+
+	orgID := valuer.GenerateUUID()
+	features, err := provider.Features(ctx, orgID)
+	require.NoError(t, err)
+	active := activeOf(features)
+	assert.True(t, active[licensetypes.UseSpanMetrics.StringValue()])
+	assert.False(t, active[licensetypes.DotMetricsEnabled.StringValue()])
+	assert.True(t, active["beta"])
+
+	// The overrides of an organization take precedence over the config and do not leak to the other organizations.
+	require.NoError(t, provider.SetOverride(ctx, orgID, &licensetypes.PostableFeatureOverride{Name: licensetypes.UseSpanMetrics.StringValue(), Active: false}))
+	require.NoError(t, provider.SetOverride(ctx, orgID, &licensetypes.PostableFeatureOverride{Name: licensetypes.DotMetricsEnabled.StringValue(), Active: true}))
+
+	features, err = provider.GetFeatureFlags(ctx, orgID)
+	require.NoError(t, err)
+	active = activeOf(features)
+	assert.False(t, active[licensetypes.UseSpanMetrics.StringValue()])
+	assert.True(t, active[licensetypes.DotMetricsEnabled.StringValue()])
+
+	features, err = provider.Features(ctx, valuer.GenerateUUID())
+	require.NoError(t, err)
+	assert.True(t, activeOf(features)[licensetypes.UseSpanMetrics.StringValue()])
+
+	// The features of the wrapped licensing are left untouched.
+	assert.False(t, activeOf(licensetypes.DefaultFeatureSet)[licensetypes.DotMetricsEnabled.StringValue()])
+
+	overrides, err := provider.ListOverrides(ctx, orgID)
+	require.NoError(t, err)
+	assert.Equal(t, []*licensetypes.GettableFeatureOverride{
+		{Name: "beta", Active: true},
+		{Name: licensetypes.DotMetricsEnabled.StringValue(), Active: true},
+		{Name: licensetypes.UseSpanMetrics.StringValue(), Active: false},
+	}, overrides)
+
+	// The overrides are stored, hence they are seen by the other replicas.
+	replica := newProvider(ctx, factorytest.NewSettings(), config, store, next)
+	features, err = replica.Features(ctx, orgID)
+	require.NoError(t, err)
+	assert.True(t, activeOf(features)[licensetypes.DotMetricsEnabled.StringValue()])
+
+	// The overrides of zeus are overridden again.
+	features, err = replica.ApplyOverrides(ctx, orgID, []*licensetypes.Feature{{Name: licensetypes.DotMetricsEnabled, Active: false}})
+	require.NoError(t, err)
+	assert.True(t, activeOf(features)[licensetypes.DotMetricsEnabled.StringValue()])
+
+	require.NoError(t, provider.DeleteOverride(ctx, orgID, licensetypes.UseSpanMetrics.StringValue()))
+	features, err = provider.Features(ctx, orgID)
+	require.NoError(t, err)
+	assert.True(t, activeOf(features)[licensetypes.UseSpanMetrics.StringValue()])
+
+	err = provider.DeleteOverride(ctx, orgID, licensetypes.UseSpanMetrics.StringValue())
+	assert.True(t, errors.Ast(err, errors.TypeNotFound))
+
+	events := make([]*licensetypes.StorableAuditEvent, 0)
+	require.NoError(t, store.BunDB().NewSelect().Model(&events).Where("org_id = ?", orgID).Order("created_at ASC").Scan(ctx))
+	require.Len(t, events, 3)
+	for _, event := range events {
+		assert.Equal(t, licensetypes.AuditEventTypeOverridden, event.Type)
+	}
+	assert.Equal(t, []string{licensetypes.UseSpanMetrics.StringValue()}, events[0].RemovedFeatures)
+	assert.Equal(t, []string{licensetypes.DotMetricsEnabled.StringValue()}, events[1].AddedFeatures)
+	assert.Equal(t, []string{licensetypes.UseSpanMetrics.StringValue()}, events[2].AddedFeatures)
+}
+
+func TestNew(t *testing.T) {
+	ctx := context.Background()
+	next, err := nooplicensing.New(ctx, factorytest.NewSettings(), licensing.Config{})
+	require.NoError(t, err)
+
+	// The licensing is returned as is when the overrides are disabled.
+	actual, err := New(ctx, factorytest.NewSettings(), Config{}, sqlstoretest.NewSQLite(t), next)
+	require.NoError(t, err)
+	assert.Equal(t, next, actual)
+
+	_, err = New(ctx, factorytest.NewSettings(), Config{Enabled: true}, sqlstoretest.NewSQLite(t), next)
+	if Allowed {
+		assert.NoError(t, err)
+		assert.NoError(t, Config{Enabled: true}.Validate())
+	} else {
+		assert.Error(t, err)
+		assert.Error(t, Config{Enabled: true}.Validate())
+	}
+}
@@ -0,0 +1,35 @@
+package maintenance
+
+import (
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/factory"
+)
+
+type Config struct {
+	// Enabled starts signoz in maintenance mode.
+	Enabled bool `mapstructure:"enabled"`
+
+	// RefreshInterval is the interval at which each replica reads maintenance mode again from the sqlstore.
+	RefreshInterval time.Duration `mapstructure:"refresh_interval"`
+}
+
+func NewConfigFactory() factory.ConfigFactory {
+	return factory.NewConfigFactory(factory.MustNewName("maintenance"), newConfig)
+}
+
+func newConfig() factory.Config {
+	return Config{
+		Enabled:         false,
+		RefreshInterval: 10 * time.Second,
+	}
+}
+
+func (c Config) Validate() error {
+	if c.RefreshInterval <= 0 {
+		return errors.NewInvalidInputf(errors.CodeInvalidInput, "refresh_interval must be positive, got %v", c.RefreshInterval)
+	}
+
+	return nil
+}
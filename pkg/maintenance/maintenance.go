@@ -0,0 +1,248 @@
+package maintenance
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/factory"
+	"github.com/SigNoz/signoz/pkg/sqlstore"
+	"github.com/uptrace/bun"
+)
+
+var (
+	ErrCodeMaintenance = errors.MustNewCode("maintenance")
+)
+
+const (
+	// storableID is the id of the single row holding maintenance mode.
+	storableID string = "signoz"
+)
+
+// Maintenance is the maintenance mode of signoz, for example while a backup is taken. In maintenance mode the
+// reads keep being served while the writes are rejected and the background work writing to the sqlstore is paused.
+//
+// The mode is stored in the sqlstore so that it applies to all the replicas. Each replica reads it again at the
+// refresh interval of the config, hence the other replicas follow a change within that interval.
+type Maintenance struct {
+	settings factory.ScopedProviderSettings
+	config   Config
+	sqlstore sqlstore.SQLStore
+	mtx      sync.RWMutex
+	enabled  bool
+	since    time.Time
+	stopC    chan struct{}
+}
+
+type Status struct {
+	// Enabled is true in maintenance mode.
+	Enabled bool `json:"enabled"`
+	// Since is the time maintenance mode was enabled at.
+	Since *time.Time `json:"since,omitempty"`
+}
+
+type PostableStatus struct {
+	Enabled bool `json:"enabled"`
+}
+
+type StorableMaintenance struct {
+	bun.BaseModel `bun:"table:maintenance"`
+
+	ID        string     `bun:"id,pk,type:text"`
+	Enabled   bool       `bun:"enabled,notnull"`
+	Since     *time.Time `bun:"since,nullzero"`
+	UpdatedAt time.Time  `bun:"updated_at,notnull"`
+}
+
+func New(providerSettings factory.ProviderSettings, config Config, sqlstore sqlstore.SQLStore) *Maintenance {
+	maintenance := &Maintenance{
+		settings: factory.NewScopedProviderSettings(providerSettings, "github.com/SigNoz/signoz/pkg/maintenance"),
+		config:   config,
+		sqlstore: sqlstore,
+		stopC:    make(chan struct{}),
+	}
+
+	if config.Enabled {
+		maintenance.set(true, time.Now())
+	}
+
+	return maintenance
+}
+
+// Load loads maintenance mode from the sqlstore. When maintenance mode is enabled by the config, it is stored instead
+// so that the other replicas enter it as well. The replica stays in maintenance mode when the config enables it and
+// it cannot be stored, for example while the migration adding its table is pending.
+func (maintenance *Maintenance) Load(ctx context.Context) error {
+	if maintenance.config.Enabled {
+		return maintenance.Set(ctx, true)
+	}
+
+	return maintenance.Refresh(ctx)
+}
+
+// Refresh reads maintenance mode from the sqlstore. The mode is kept as it is when it cannot be read.
+func (maintenance *Maintenance) Refresh(ctx context.Context) error {
+	storable := new(StorableMaintenance)
+	err := maintenance.
+		sqlstore.
+		BunDB().
+		NewSelect().
+		Model(storable).
+		Where("id = ?", storableID).
+		Scan(ctx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			maintenance.set(false, time.Time{})
+			return nil
+		}
+
+		return errors.Wrapf(err, errors.TypeInternal, errors.CodeInternal, "failed to read maintenance mode")
+	}
+
+	since := time.Time{}
+	if storable.Since != nil {
+		since = *storable.Since
+	}
+
+	maintenance.set(storable.Enabled, since)
+	return nil
+}
+
+// Enabled returns true in maintenance mode.
+func (maintenance *Maintenance) Enabled() bool {
+	maintenance.mtx.RLock()
+	defer maintenance.mtx.RUnlock()
+
+	return maintenance.enabled
+}
+
+// Set enables or disables maintenance mode on all the replicas. Enabling it again keeps the time it was first
+// enabled at.
+func (maintenance *Maintenance) Set(ctx context.Context, enabled bool) error {
+	// The time is truncated to the precision of the sqlstore so that the replicas reading it see the same time.
+	storable := &StorableMaintenance{ID: storableID, Enabled: enabled, UpdatedAt: time.Now().Truncate(time.Microsecond)}
+
+	err := maintenance.sqlstore.RunInTxCtx(ctx, nil, func(ctx context.Context) error {
+		existing := new(StorableMaintenance)
+		err := maintenance.
+			sqlstore.
+			BunDBCtx(ctx).
+			NewSelect().
+			Model(existing).
+			Where("id = ?", storableID).
+			Scan(ctx)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return err
+		}
+
+		if enabled {
+			storable.Since = &storable.UpdatedAt
+			if err == nil && existing.Enabled && existing.Since != nil {
+				storable.Since = existing.Since
+			}
+		}
+
+		_, err = maintenance.
+			sqlstore.
+			BunDBCtx(ctx).
+			NewInsert().
+			Model(storable).
+			On("CONFLICT (id) DO UPDATE").
+			Set("enabled = EXCLUDED.enabled").
+			Set("since = EXCLUDED.since").
+			Set("updated_at = EXCLUDED.updated_at").
+			Exec(ctx)
+		return err
+	})
+	if err != nil {
+		return errors.Wrapf(err, errors.TypeInternal, errors.CodeInternal, "failed to store maintenance mode")
+	}
+
+	since := time.Time{}
+	if storable.Since != nil {
+		since = *storable.Since
+	}
+
+	maintenance.set(enabled, since)
+	return nil
+}
+
+// EffectiveConfig returns whether maintenance mode is enabled, as it is changed at runtime.
+func (maintenance *Maintenance) EffectiveConfig(context.Context) (map[string]any, error) {
+	return map[string]any{"enabled": maintenance.Enabled()}, nil
+}
+
+func (maintenance *Maintenance) Status() Status {
+	maintenance.mtx.RLock()
+	defer maintenance.mtx.RUnlock()
+
+	if !maintenance.enabled {
+		return Status{Enabled: false}
+	}
+
+	since := maintenance.since
+	return Status{Enabled: true, Since: &since}
+}
+
+// Check returns an error of type TypeUnavailable in maintenance mode.
+func (maintenance *Maintenance) Check() error {
+	if !maintenance.Enabled() {
+		return nil
+	}
+
+	return errors.New(errors.TypeUnavailable, ErrCodeMaintenance, "signoz is in maintenance mode, writes are rejected until it ends, reads are still served")
+}
+
+// Pause returns the job skipping its runs in maintenance mode.
+func (maintenance *Maintenance) Pause(job factory.Job) factory.Job {
+	return &pausedJob{Job: job, maintenance: maintenance}
+}
+
+// Start refreshes maintenance mode from the sqlstore at the refresh interval until stopped.
+func (maintenance *Maintenance) Start(ctx context.Context) error {
+	ticker := time.NewTicker(maintenance.config.RefreshInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-maintenance.stopC:
+			return nil
+		case <-ticker.C:
+			if err := maintenance.Refresh(ctx); err != nil {
+				maintenance.settings.Logger().WarnContext(ctx, "failed to refresh maintenance mode, keeping the current mode", "enabled", maintenance.Enabled(), "error", err)
+			}
+		}
+	}
+}
+
+func (maintenance *Maintenance) Stop(ctx context.Context) error {
+	close(maintenance.stopC)
+	return nil
+}
+
+func (maintenance *Maintenance) set(enabled bool, since time.Time) {
+	maintenance.mtx.Lock()
+	defer maintenance.mtx.Unlock()
+
+	if !enabled {
+		since = time.Time{}
+	}
+
+	maintenance.enabled = enabled
+	maintenance.since = since
+}
+
+type pausedJob struct {
+	factory.Job
+	maintenance *Maintenance
+}
+
+func (job *pausedJob) Run(ctx context.Context) error {
+	if job.maintenance.Enabled() {
+		return nil
+	}
+
+	return job.Job.Run(ctx)
+}
@@ -0,0 +1,91 @@
+package maintenance
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/factory"
+	"github.com/SigNoz/signoz/pkg/factory/factorytest"
+	"github.com/SigNoz/signoz/pkg/sqlstore"
+	"github.com/SigNoz/signoz/pkg/sqlstore/sqlstoretest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestStore(t *testing.T) sqlstore.SQLStore {
+	store := sqlstoretest.NewSQLite(t)
+	_, err := store.BunDB().NewCreateTable().Model(new(StorableMaintenance)).Exec(context.Background())
+	require.NoError(t, err)
+
+	return store
+}
+
+func TestMaintenance(t *testing.T) {
+	ctx := context.Background()
+	maintenance := New(factorytest.NewSettings(), Config{}, newTestStore(t))
+	require.NoError(t, maintenance.Load(ctx))
+	assert.False(t, maintenance.Enabled())
+	assert.NoError(t, maintenance.Check())
+	assert.Equal(t, Status{Enabled: false}, maintenance.Status())
+
+	require.NoError(t, maintenance.Set(ctx, true))
+	err := maintenance.Check()
+	assert.True(t, errors.Ast(err, errors.TypeUnavailable))
+	assert.True(t, errors.Asc(err, ErrCodeMaintenance))
+
+	status := maintenance.Status()
+	require.NotNil(t, status.Since)
+	assert.True(t, status.Enabled)
+
+	// Enabling again keeps the time maintenance mode was enabled at.
+	require.NoError(t, maintenance.Set(ctx, true))
+	assert.True(t, status.Since.Equal(*maintenance.Status().Since))
+
+	require.NoError(t, maintenance.Set(ctx, false))
+	assert.NoError(t, maintenance.Check())
+	assert.Equal(t, Status{Enabled: false}, maintenance.Status())
+}
+
+func TestMaintenanceShared(t *testing.T) {
+	ctx := context.Background()
+	store := newTestStore(t)
+
+	// The replica started in maintenance mode by its config stores it for the other replicas.
+	first := New(factorytest.NewSettings(), Config{Enabled: true}, store)
+	require.NoError(t, first.Load(ctx))
+
+	second := New(factorytest.NewSettings(), Config{}, store)
+	require.NoError(t, second.Load(ctx))
+	assert.True(t, second.Enabled())
+
+	require.NoError(t, second.Set(ctx, false))
+	assert.True(t, first.Enabled())
+
+	require.NoError(t, first.Refresh(ctx))
+	assert.False(t, first.Enabled())
+}
+
+func TestMaintenanceLoadWithoutTable(t *testing.T) {
+	// The table is missing while its migration is pending, the replica keeps the mode of its config.
+	maintenance := New(factorytest.NewSettings(), Config{Enabled: true}, sqlstoretest.NewSQLite(t))
+	assert.Error(t, maintenance.Load(context.Background()))
+	assert.True(t, maintenance.Enabled())
+}
+
+func TestPause(t *testing.T) {
+	ctx := context.Background()
+	maintenance := New(factorytest.NewSettings(), Config{}, newTestStore(t))
+
+	runs := 0
+	job := maintenance.Pause(factory.NewJob(factory.MustNewName("job"), time.Minute, func(context.Context) error {
+		runs++
+		return nil
+	}))
+
+	require.NoError(t, job.Run(ctx))
+	require.NoError(t, maintenance.Set(ctx, true))
+	require.NoError(t, job.Run(ctx))
+	assert.Equal(t, 1, runs)
+}
@@ -0,0 +1,54 @@
+package dashboard
+
+import (
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/factory"
+)
+
+type Config struct {
+	// Purge is the configuration of the purge of the deleted dashboards.
+	Purge PurgeConfig `mapstructure:"purge"`
+}
+
+type PurgeConfig struct {
+	// Enabled enables the purge of the deleted dashboards. The deleted dashboards are kept forever otherwise.
+	Enabled bool `mapstructure:"enabled"`
+
+	// Interval is the interval at which the deleted dashboards are purged.
+	Interval time.Duration `mapstructure:"interval"`
+
+	// Retention is the time for which a deleted dashboard can be restored before it is purged.
+	Retention time.Duration `mapstructure:"retention"`
+}
+
+func NewConfigFactory() factory.ConfigFactory {
+	return factory.NewConfigFactory(factory.MustNewName("dashboard"), newConfig)
+}
+
+func newConfig() factory.Config {
+	return Config{
+		Purge: PurgeConfig{
+			Enabled:   true,
+			Interval:  time.Hour,
+			Retention: 30 * 24 * time.Hour,
+		},
+	}
+}
+
+func (c Config) Validate() error {
+	if !c.Purge.Enabled {
+		return nil
+	}
+
+	if c.Purge.Interval <= 0 {
+		return errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "purge::interval must be greater than 0, got %v", c.Purge.Interval)
+	}
+
+	if c.Purge.Retention <= 0 {
+		return errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "purge::retention must be greater than 0, got %v", c.Purge.Retention)
+	}
+
+	return nil
+}
@@ -3,9 +3,12 @@ package dashboard
 import (
 	"context"
 	"net/http"
+	"time"
 
 	"github.com/SigNoz/signoz/pkg/statsreporter"
+	"github.com/SigNoz/signoz/pkg/types"
 	"github.com/SigNoz/signoz/pkg/types/dashboardtypes"
+	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
 	"github.com/SigNoz/signoz/pkg/valuer"
 )
 
@@ -16,14 +19,53 @@ type Module interface {
 
 	List(ctx context.Context, orgID valuer.UUID) ([]*dashboardtypes.Dashboard, error)
 
+	// ListPage lists a page of the dashboards of the organization and returns the cursor of the next page.
+	ListPage(ctx context.Context, orgID valuer.UUID, pagination *types.Pagination) ([]*dashboardtypes.Dashboard, string, error)
+
 	Update(ctx context.Context, orgID valuer.UUID, id valuer.UUID, updatedBy string, data dashboardtypes.UpdatableDashboard) (*dashboardtypes.Dashboard, error)
 
 	LockUnlock(ctx context.Context, orgID valuer.UUID, id valuer.UUID, updatedBy string, lock bool) error
 
-	Delete(ctx context.Context, orgID valuer.UUID, id valuer.UUID) error
+	// Delete soft deletes the dashboard, it can be restored until it is purged.
+	Delete(ctx context.Context, orgID valuer.UUID, id valuer.UUID, deletedBy string) error
+
+	ListDeleted(ctx context.Context, orgID valuer.UUID) ([]*dashboardtypes.Dashboard, error)
+
+	Restore(ctx context.Context, orgID valuer.UUID, id valuer.UUID, restoredBy string) (*dashboardtypes.Dashboard, error)
+
+	// Purge hard deletes the dashboards of all the orgs deleted before the given time.
+	Purge(ctx context.Context, before time.Time) error
+
+	// Export serializes the given dashboards, or all the dashboards of the organization when none is given, into a
+	// versioned bundle.
+	Export(ctx context.Context, orgID valuer.UUID, ids []valuer.UUID) (*dashboardtypes.Bundle, error)
+
+	// Import upserts the dashboards of the bundle, each in its own transaction, and reports the outcome of each of
+	// them. It only fails when the bundle itself is invalid.
+	Import(ctx context.Context, orgID valuer.UUID, importedBy string, postableImport dashboardtypes.PostableImport) ([]*dashboardtypes.ImportResult, error)
 
 	GetByMetricNames(ctx context.Context, orgID valuer.UUID, metricNames []string) (map[string][]map[string]string, error)
 
+	// CreatePublicLink creates a link granting a read only access to the dashboard without an account. The token of
+	// the link is only returned by it.
+	CreatePublicLink(ctx context.Context, orgID valuer.UUID, dashboardID valuer.UUID, createdBy string, postablePublicLink dashboardtypes.PostablePublicLink) (*dashboardtypes.GettablePublicLink, error)
+
+	ListPublicLinks(ctx context.Context, orgID valuer.UUID, dashboardID valuer.UUID) ([]*dashboardtypes.GettablePublicLink, error)
+
+	// RevokePublicLink revokes the link, the dashboard cannot be accessed through it from then on.
+	RevokePublicLink(ctx context.Context, orgID valuer.UUID, dashboardID valuer.UUID, id valuer.UUID, revokedBy string) error
+
+	ListPublicLinkAuditEvents(ctx context.Context, orgID valuer.UUID, dashboardID valuer.UUID) ([]*dashboardtypes.GettablePublicLinkAuditEvent, error)
+
+	// GetPublic gets the dashboard of the link of the token, if the link grants the access to it with the password.
+	// Every granted access is audited with the client as actor, as is the lockout of the client after too many wrong
+	// passwords.
+	GetPublic(ctx context.Context, token string, password string, client string) (*dashboardtypes.GettablePublicDashboard, error)
+
+	// GetPublicQueryRange returns the organization of the dashboard of the link of the token and the request running
+	// the stored queries of the widget over the time range, if the link grants the access to it with the password.
+	GetPublicQueryRange(ctx context.Context, token string, password string, client string, widgetID string, postable dashboardtypes.PostablePublicQuery) (valuer.UUID, *qbtypes.QueryRangeRequest, error)
+
 	statsreporter.StatsCollector
 }
 
@@ -35,4 +77,24 @@ type Handler interface {
 	LockUnlock(http.ResponseWriter, *http.Request)
 
 	Delete(http.ResponseWriter, *http.Request)
+
+	ListDeleted(http.ResponseWriter, *http.Request)
+
+	Restore(http.ResponseWriter, *http.Request)
+
+	Export(http.ResponseWriter, *http.Request)
+
+	Import(http.ResponseWriter, *http.Request)
+
+	CreatePublicLink(http.ResponseWriter, *http.Request)
+
+	ListPublicLinks(http.ResponseWriter, *http.Request)
+
+	RevokePublicLink(http.ResponseWriter, *http.Request)
+
+	ListPublicLinkAuditEvents(http.ResponseWriter, *http.Request)
+
+	GetPublic(http.ResponseWriter, *http.Request)
+
+	QueryRangePublic(http.ResponseWriter, *http.Request)
 }
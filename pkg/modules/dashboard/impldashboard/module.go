@@ -3,29 +3,36 @@ package impldashboard
 import (
 	"context"
 	"strings"
+	"time"
 
 	"github.com/SigNoz/signoz/pkg/analytics"
 	"github.com/SigNoz/signoz/pkg/errors"
 	"github.com/SigNoz/signoz/pkg/factory"
 	"github.com/SigNoz/signoz/pkg/modules/dashboard"
 	"github.com/SigNoz/signoz/pkg/sqlstore"
+	"github.com/SigNoz/signoz/pkg/types"
 	"github.com/SigNoz/signoz/pkg/types/analyticstypes"
 	"github.com/SigNoz/signoz/pkg/types/dashboardtypes"
+	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
 	"github.com/SigNoz/signoz/pkg/valuer"
 )
 
 type module struct {
-	store     dashboardtypes.Store
-	settings  factory.ScopedProviderSettings
-	analytics analytics.Analytics
+	sqlstore         sqlstore.SQLStore
+	store            dashboardtypes.Store
+	settings         factory.ScopedProviderSettings
+	analytics        analytics.Analytics
+	passwordAttempts *passwordAttempts
 }
 
 func NewModule(sqlstore sqlstore.SQLStore, settings factory.ProviderSettings, analytics analytics.Analytics) dashboard.Module {
 	scopedProviderSettings := factory.NewScopedProviderSettings(settings, "github.com/SigNoz/signoz/pkg/modules/impldashboard")
 	return &module{
-		store:     NewStore(sqlstore),
-		settings:  scopedProviderSettings,
-		analytics: analytics,
+		sqlstore:         sqlstore,
+		store:            NewStore(sqlstore),
+		settings:         scopedProviderSettings,
+		analytics:        analytics,
+		passwordAttempts: newPasswordAttempts(maxPasswordFailures, passwordLockout),
 	}
 }
 
@@ -88,6 +95,24 @@ func (module *module) List(ctx context.Context, orgID valuer.UUID) ([]*dashboard
 	return dashboards, nil
 }
 
+func (module *module) ListPage(ctx context.Context, orgID valuer.UUID, pagination *types.Pagination) ([]*dashboardtypes.Dashboard, string, error) {
+	storableDashboards, err := module.store.ListPage(ctx, orgID, pagination)
+	if err != nil {
+		return nil, "", err
+	}
+
+	storableDashboards, nextCursor := types.Paginate(pagination, storableDashboards, func(storableDashboard *dashboardtypes.StorableDashboard) types.Cursor {
+		return types.Cursor{CreatedAt: storableDashboard.CreatedAt, ID: storableDashboard.ID}
+	})
+
+	dashboards, err := dashboardtypes.NewDashboardsFromStorableDashboards(storableDashboards)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return dashboards, nextCursor, nil
+}
+
 func (module *module) Update(ctx context.Context, orgID valuer.UUID, id valuer.UUID, updatedBy string, updatableDashboard dashboardtypes.UpdatableDashboard) (*dashboardtypes.Dashboard, error) {
 	dashboard, err := module.Get(ctx, orgID, id)
 	if err != nil {
@@ -135,7 +160,7 @@ func (module *module) LockUnlock(ctx context.Context, orgID valuer.UUID, id valu
 	return nil
 }
 
-func (module *module) Delete(ctx context.Context, orgID valuer.UUID, id valuer.UUID) error {
+func (module *module) Delete(ctx context.Context, orgID valuer.UUID, id valuer.UUID, deletedBy string) error {
 	dashboard, err := module.Get(ctx, orgID, id)
 	if err != nil {
 		return err
@@ -145,7 +170,201 @@ func (module *module) Delete(ctx context.Context, orgID valuer.UUID, id valuer.U
 		return errors.New(errors.TypeInvalidInput, errors.CodeInvalidInput, "dashboard is locked, please unlock the dashboard to be delete it")
 	}
 
-	return module.store.Delete(ctx, orgID, id)
+	if err := module.store.Delete(ctx, orgID, id, deletedBy); err != nil {
+		return err
+	}
+
+	module.settings.Logger().InfoContext(ctx, "dashboard deleted", "org_id", orgID, "dashboard_id", id, "deleted_by", deletedBy)
+	return nil
+}
+
+func (module *module) ListDeleted(ctx context.Context, orgID valuer.UUID) ([]*dashboardtypes.Dashboard, error) {
+	storableDashboards, err := module.store.ListDeleted(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	return dashboardtypes.NewDashboardsFromStorableDashboards(storableDashboards)
+}
+
+func (module *module) Restore(ctx context.Context, orgID valuer.UUID, id valuer.UUID, restoredBy string) (*dashboardtypes.Dashboard, error) {
+	if err := module.store.Restore(ctx, orgID, id, restoredBy); err != nil {
+		return nil, err
+	}
+
+	module.settings.Logger().InfoContext(ctx, "dashboard restored", "org_id", orgID, "dashboard_id", id, "restored_by", restoredBy)
+	return module.Get(ctx, orgID, id)
+}
+
+func (module *module) Purge(ctx context.Context, before time.Time) error {
+	purged, err := module.store.Purge(ctx, before)
+	if err != nil {
+		return err
+	}
+
+	if purged > 0 {
+		module.settings.Logger().InfoContext(ctx, "purged deleted dashboards", "count", purged, "deleted_before", before)
+	}
+
+	return nil
+}
+
+func (module *module) Export(ctx context.Context, orgID valuer.UUID, ids []valuer.UUID) (*dashboardtypes.Bundle, error) {
+	if len(ids) == 0 {
+		dashboards, err := module.List(ctx, orgID)
+		if err != nil {
+			return nil, err
+		}
+
+		return dashboardtypes.NewBundle(dashboards), nil
+	}
+
+	dashboards := make([]*dashboardtypes.Dashboard, 0, len(ids))
+	for _, id := range ids {
+		dashboard, err := module.Get(ctx, orgID, id)
+		if err != nil {
+			return nil, err
+		}
+
+		dashboards = append(dashboards, dashboard)
+	}
+
+	return dashboardtypes.NewBundle(dashboards), nil
+}
+
+func (module *module) Import(ctx context.Context, orgID valuer.UUID, importedBy string, postableImport dashboardtypes.PostableImport) ([]*dashboardtypes.ImportResult, error) {
+	if err := postableImport.Bundle.Validate(); err != nil {
+		return nil, err
+	}
+
+	results := make([]*dashboardtypes.ImportResult, len(postableImport.Bundle.Dashboards))
+	for idx, bundleDashboard := range postableImport.Bundle.Dashboards {
+		bundleDashboard.Data.RemapDatasources(postableImport.Datasources)
+
+		result := &dashboardtypes.ImportResult{SourceID: bundleDashboard.ID, Title: bundleDashboard.Data.Title()}
+		err := module.sqlstore.RunInTxCtx(ctx, nil, func(ctx context.Context) error {
+			return module.importDashboard(ctx, orgID, importedBy, postableImport.Strategy, bundleDashboard, result)
+		})
+		if err != nil {
+			result.ID = ""
+			result.Status = dashboardtypes.ImportStatusFailed
+			result.Error = err.Error()
+		}
+
+		results[idx] = result
+	}
+
+	module.settings.Logger().InfoContext(ctx, "dashboards imported", "org_id", orgID, "count", len(results), "strategy", postableImport.Strategy, "imported_by", importedBy)
+	return results, nil
+}
+
+func (module *module) importDashboard(ctx context.Context, orgID valuer.UUID, importedBy string, strategy dashboardtypes.ConflictStrategy, bundleDashboard dashboardtypes.BundleDashboard, result *dashboardtypes.ImportResult) error {
+	existing, err := module.getConflicting(ctx, orgID, bundleDashboard)
+	if err != nil {
+		return err
+	}
+
+	// The dashboards are created with new ids, the ids of the bundle may be taken by the dashboards of another org.
+	if existing == nil {
+		dashboard, err := module.createImported(ctx, orgID, importedBy, bundleDashboard.Data)
+		if err != nil {
+			return err
+		}
+
+		result.ID = dashboard.ID
+		result.Status = dashboardtypes.ImportStatusCreated
+		return nil
+	}
+
+	switch strategy {
+	case dashboardtypes.ConflictStrategyOverwrite:
+		if existing.Locked {
+			return errors.New(errors.TypeInvalidInput, errors.CodeInvalidInput, "dashboard is locked, please unlock the dashboard to overwrite it")
+		}
+
+		existing.Data = bundleDashboard.Data
+		existing.UpdatedBy = importedBy
+		existing.UpdatedAt = time.Now()
+
+		storableDashboard, err := dashboardtypes.NewStorableDashboardFromDashboard(existing)
+		if err != nil {
+			return err
+		}
+
+		if err := module.store.Update(ctx, orgID, storableDashboard); err != nil {
+			return err
+		}
+
+		result.ID = existing.ID
+		result.Status = dashboardtypes.ImportStatusOverwritten
+	case dashboardtypes.ConflictStrategyRename:
+		data := make(dashboardtypes.StorableDashboardData, len(bundleDashboard.Data))
+		for key, value := range bundleDashboard.Data {
+			data[key] = value
+		}
+		data["title"] = strings.TrimSpace(bundleDashboard.Data.Title() + " (imported)")
+
+		dashboard, err := module.createImported(ctx, orgID, importedBy, data)
+		if err != nil {
+			return err
+		}
+
+		result.ID = dashboard.ID
+		result.Title = dashboard.Data.Title()
+		result.Status = dashboardtypes.ImportStatusRenamed
+	default:
+		result.ID = existing.ID
+		result.Status = dashboardtypes.ImportStatusSkipped
+	}
+
+	return nil
+}
+
+// getConflicting returns the dashboard of the org the bundled dashboard conflicts with, the dashboard with its id,
+// such as when a bundle is imported again in the org it was exported from, or else the dashboard with its title.
+func (module *module) getConflicting(ctx context.Context, orgID valuer.UUID, bundleDashboard dashboardtypes.BundleDashboard) (*dashboardtypes.Dashboard, error) {
+	existing, err := module.Get(ctx, orgID, valuer.MustNewUUID(bundleDashboard.ID))
+	if err == nil {
+		return existing, nil
+	}
+
+	if !errors.Ast(err, errors.TypeNotFound) {
+		return nil, err
+	}
+
+	title := bundleDashboard.Data.Title()
+	if title == "" {
+		return nil, nil
+	}
+
+	storableDashboard, err := module.store.GetByTitle(ctx, orgID, title)
+	if err != nil {
+		if errors.Ast(err, errors.TypeNotFound) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return dashboardtypes.NewDashboardFromStorableDashboard(storableDashboard)
+}
+
+func (module *module) createImported(ctx context.Context, orgID valuer.UUID, createdBy string, data dashboardtypes.StorableDashboardData) (*dashboardtypes.Dashboard, error) {
+	dashboard, err := dashboardtypes.NewDashboard(orgID, createdBy, data)
+	if err != nil {
+		return nil, err
+	}
+
+	storableDashboard, err := dashboardtypes.NewStorableDashboardFromDashboard(dashboard)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := module.store.Create(ctx, storableDashboard); err != nil {
+		return nil, err
+	}
+
+	return dashboard, nil
 }
 
 func (module *module) GetByMetricNames(ctx context.Context, orgID valuer.UUID, metricNames []string) (map[string][]map[string]string, error) {
@@ -226,6 +445,133 @@ func (module *module) GetByMetricNames(ctx context.Context, orgID valuer.UUID, m
 	return result, nil
 }
 
+func (module *module) CreatePublicLink(ctx context.Context, orgID valuer.UUID, dashboardID valuer.UUID, createdBy string, postablePublicLink dashboardtypes.PostablePublicLink) (*dashboardtypes.GettablePublicLink, error) {
+	if _, err := module.Get(ctx, orgID, dashboardID); err != nil {
+		return nil, err
+	}
+
+	storablePublicLink, token, err := dashboardtypes.NewStorablePublicLink(orgID, dashboardID, createdBy, postablePublicLink)
+	if err != nil {
+		return nil, err
+	}
+
+	err = module.sqlstore.RunInTxCtx(ctx, nil, func(ctx context.Context) error {
+		if err := module.store.CreatePublicLink(ctx, storablePublicLink); err != nil {
+			return err
+		}
+
+		return module.store.CreatePublicLinkAuditEvent(ctx, dashboardtypes.NewPublicLinkAuditEvent(dashboardtypes.PublicLinkAuditEventTypeCreated, createdBy, storablePublicLink))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	module.settings.Logger().InfoContext(ctx, "dashboard public link created", "org_id", orgID, "dashboard_id", dashboardID, "link_id", storablePublicLink.ID, "created_by", createdBy)
+	return dashboardtypes.NewGettablePublicLinkFromStorablePublicLink(storablePublicLink, token), nil
+}
+
+func (module *module) ListPublicLinks(ctx context.Context, orgID valuer.UUID, dashboardID valuer.UUID) ([]*dashboardtypes.GettablePublicLink, error) {
+	storablePublicLinks, err := module.store.ListPublicLinks(ctx, orgID, dashboardID)
+	if err != nil {
+		return nil, err
+	}
+
+	gettablePublicLinks := make([]*dashboardtypes.GettablePublicLink, len(storablePublicLinks))
+	for idx, storablePublicLink := range storablePublicLinks {
+		gettablePublicLinks[idx] = dashboardtypes.NewGettablePublicLinkFromStorablePublicLink(storablePublicLink, "")
+	}
+
+	return gettablePublicLinks, nil
+}
+
+func (module *module) RevokePublicLink(ctx context.Context, orgID valuer.UUID, dashboardID valuer.UUID, id valuer.UUID, revokedBy string) error {
+	err := module.sqlstore.RunInTxCtx(ctx, nil, func(ctx context.Context) error {
+		storablePublicLink, err := module.store.RevokePublicLink(ctx, orgID, dashboardID, id, revokedBy)
+		if err != nil {
+			return err
+		}
+
+		return module.store.CreatePublicLinkAuditEvent(ctx, dashboardtypes.NewPublicLinkAuditEvent(dashboardtypes.PublicLinkAuditEventTypeRevoked, revokedBy, storablePublicLink))
+	})
+	if err != nil {
+		return err
+	}
+
+	module.settings.Logger().InfoContext(ctx, "dashboard public link revoked", "org_id", orgID, "dashboard_id", dashboardID, "link_id", id, "revoked_by", revokedBy)
+	return nil
+}
+
+func (module *module) ListPublicLinkAuditEvents(ctx context.Context, orgID valuer.UUID, dashboardID valuer.UUID) ([]*dashboardtypes.GettablePublicLinkAuditEvent, error) {
+	return module.store.ListPublicLinkAuditEvents(ctx, orgID, dashboardID)
+}
+
+func (module *module) GetPublic(ctx context.Context, token string, password string, client string) (*dashboardtypes.GettablePublicDashboard, error) {
+	storablePublicLink, err := module.authorizePublic(ctx, token, password, client)
+	if err != nil {
+		return nil, err
+	}
+
+	dashboard, err := module.Get(ctx, storablePublicLink.OrgID, storablePublicLink.DashboardID)
+	if err != nil {
+		return nil, err
+	}
+
+	module.audit(ctx, dashboardtypes.PublicLinkAuditEventTypeGranted, client, storablePublicLink)
+	return dashboardtypes.NewGettablePublicDashboardFromDashboard(dashboard), nil
+}
+
+func (module *module) GetPublicQueryRange(ctx context.Context, token string, password string, client string, widgetID string, postable dashboardtypes.PostablePublicQuery) (valuer.UUID, *qbtypes.QueryRangeRequest, error) {
+	storablePublicLink, err := module.authorizePublic(ctx, token, password, client)
+	if err != nil {
+		return valuer.UUID{}, nil, err
+	}
+
+	dashboard, err := module.Get(ctx, storablePublicLink.OrgID, storablePublicLink.DashboardID)
+	if err != nil {
+		return valuer.UUID{}, nil, err
+	}
+
+	queryRangeRequest, err := dashboard.NewPublicQueryRangeRequest(widgetID, postable)
+	if err != nil {
+		return valuer.UUID{}, nil, err
+	}
+
+	return storablePublicLink.OrgID, queryRangeRequest, nil
+}
+
+// authorizePublic returns the link of the token if it grants the access to its dashboard with the password. The client
+// is locked out of the link once it tried too many wrong passwords, and the lockout is audited.
+func (module *module) authorizePublic(ctx context.Context, token string, password string, client string) (*dashboardtypes.StorablePublicLink, error) {
+	storablePublicLink, err := module.store.GetPublicLinkByTokenHash(ctx, dashboardtypes.HashPublicLinkToken(token))
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if err := module.passwordAttempts.check(storablePublicLink.ID, client, now); err != nil {
+		return nil, err
+	}
+
+	if err := storablePublicLink.Authorize(password, now); err != nil {
+		if password != "" && errors.Ast(err, errors.TypeUnauthenticated) && module.passwordAttempts.fail(storablePublicLink.ID, client, now) {
+			module.settings.Logger().WarnContext(ctx, "client locked out of a dashboard public link after too many wrong passwords", "org_id", storablePublicLink.OrgID, "link_id", storablePublicLink.ID, "client", client)
+			module.audit(ctx, dashboardtypes.PublicLinkAuditEventTypeDenied, client, storablePublicLink)
+		}
+
+		return nil, err
+	}
+
+	module.passwordAttempts.reset(storablePublicLink.ID, client)
+	return storablePublicLink, nil
+}
+
+// audit records the access through the public link. The access is not failed when it cannot be recorded.
+func (module *module) audit(ctx context.Context, typ dashboardtypes.PublicLinkAuditEventType, actor string, storablePublicLink *dashboardtypes.StorablePublicLink) {
+	if err := module.store.CreatePublicLinkAuditEvent(ctx, dashboardtypes.NewPublicLinkAuditEvent(typ, actor, storablePublicLink)); err != nil {
+		module.settings.Logger().ErrorContext(ctx, "failed to audit the access through a dashboard public link", "org_id", storablePublicLink.OrgID, "link_id", storablePublicLink.ID, "type", typ, "error", err)
+	}
+}
+
 func (module *module) Collect(ctx context.Context, orgID valuer.UUID) (map[string]any, error) {
 	dashboards, err := module.store.List(ctx, orgID)
 	if err != nil {
@@ -0,0 +1,484 @@
+package impldashboard
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/analytics/analyticstest"
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/instrumentation/instrumentationtest"
+	"github.com/SigNoz/signoz/pkg/query-service/utils"
+	"github.com/SigNoz/signoz/pkg/types"
+	"github.com/SigNoz/signoz/pkg/types/dashboardtypes"
+	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
+	"github.com/SigNoz/signoz/pkg/valuer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSoftDelete(t *testing.T) {
+	ctx := context.Background()
+	sqlstore := utils.NewQueryServiceDBForTests(t)
+
+	organization := types.NewOrganization("test")
+	_, err := sqlstore.BunDB().NewInsert().Model(organization).Exec(ctx)
+	require.NoError(t, err)
+
+	module := NewModule(sqlstore, instrumentationtest.New().ToProviderSettings(), analyticstest.New())
+
+	dashboard, err := module.Create(ctx, organization.ID, "creator@signoz.io", organization.ID, dashboardtypes.PostableDashboard{"title": "test"})
+	require.NoError(t, err)
+	id := valuer.MustNewUUID(dashboard.ID)
+
+	require.NoError(t, module.Delete(ctx, organization.ID, id, "deleter@signoz.io"))
+
+	t.Run("ExcludedFromListings", func(t *testing.T) {
+		_, err := module.Get(ctx, organization.ID, id)
+		assert.True(t, errors.Ast(err, errors.TypeNotFound))
+
+		dashboards, err := module.List(ctx, organization.ID)
+		require.NoError(t, err)
+		assert.Empty(t, dashboards)
+
+		deleted, err := module.ListDeleted(ctx, organization.ID)
+		require.NoError(t, err)
+		require.Len(t, deleted, 1)
+		assert.Equal(t, "deleter@signoz.io", deleted[0].DeletedBy)
+		assert.NotNil(t, deleted[0].DeletedAt)
+	})
+
+	t.Run("DeleteTwice", func(t *testing.T) {
+		err := module.Delete(ctx, organization.ID, id, "deleter@signoz.io")
+		assert.True(t, errors.Ast(err, errors.TypeNotFound))
+	})
+
+	t.Run("Restore", func(t *testing.T) {
+		restored, err := module.Restore(ctx, organization.ID, id, "restorer@signoz.io")
+		require.NoError(t, err)
+		assert.Nil(t, restored.DeletedAt)
+		assert.Empty(t, restored.DeletedBy)
+		assert.Equal(t, "restorer@signoz.io", restored.RestoredBy)
+		assert.NotNil(t, restored.RestoredAt)
+
+		_, err = module.Restore(ctx, organization.ID, id, "restorer@signoz.io")
+		assert.True(t, errors.Ast(err, errors.TypeNotFound))
+	})
+
+	t.Run("Purge", func(t *testing.T) {
+		require.NoError(t, module.Delete(ctx, organization.ID, id, "deleter@signoz.io"))
+
+		// The dashboards deleted after the given time are kept.
+		require.NoError(t, module.Purge(ctx, time.Now().Add(-time.Hour)))
+		deleted, err := module.ListDeleted(ctx, organization.ID)
+		require.NoError(t, err)
+		assert.Len(t, deleted, 1)
+
+		require.NoError(t, module.Purge(ctx, time.Now().Add(time.Hour)))
+		deleted, err = module.ListDeleted(ctx, organization.ID)
+		require.NoError(t, err)
+		assert.Empty(t, deleted)
+	})
+}
+
+func TestListPage(t *testing.T) {
+	ctx := context.Background()
+	sqlstore := utils.NewQueryServiceDBForTests(t)
+
+	organization := types.NewOrganization("test")
+	_, err := sqlstore.BunDB().NewInsert().Model(organization).Exec(ctx)
+	require.NoError(t, err)
+
+	module := NewModule(sqlstore, instrumentationtest.New().ToProviderSettings(), analyticstest.New())
+
+	ids := []string{}
+	for i := 0; i < 5; i++ {
+		dashboard, err := module.Create(ctx, organization.ID, "creator@signoz.io", organization.ID, dashboardtypes.PostableDashboard{"title": "test"})
+		require.NoError(t, err)
+		ids = append(ids, dashboard.ID)
+	}
+
+	var cursor *types.Cursor
+	listed := []string{}
+	for {
+		dashboards, nextCursor, err := module.ListPage(ctx, organization.ID, &types.Pagination{Limit: 2, Cursor: cursor})
+		require.NoError(t, err)
+		assert.LessOrEqual(t, len(dashboards), 2)
+		for _, dashboard := range dashboards {
+			listed = append(listed, dashboard.ID)
+		}
+
+		// The dashboards created while paginating are listed at the end.
+		if len(listed) == 2 {
+			dashboard, err := module.Create(ctx, organization.ID, "creator@signoz.io", organization.ID, dashboardtypes.PostableDashboard{"title": "test"})
+			require.NoError(t, err)
+			ids = append(ids, dashboard.ID)
+		}
+
+		if nextCursor == "" {
+			break
+		}
+
+		cursor, err = types.NewCursorFromString(nextCursor)
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, ids, listed)
+
+	_, err = types.NewCursorFromString("invalid")
+	assert.True(t, errors.Ast(err, errors.TypeInvalidInput))
+}
+
+func TestExportImport(t *testing.T) {
+	ctx := context.Background()
+	sqlstore := utils.NewQueryServiceDBForTests(t)
+
+	source := types.NewOrganization("source")
+	_, err := sqlstore.BunDB().NewInsert().Model(source).Exec(ctx)
+	require.NoError(t, err)
+
+	target := types.NewOrganization("target")
+	_, err = sqlstore.BunDB().NewInsert().Model(target).Exec(ctx)
+	require.NoError(t, err)
+
+	module := NewModule(sqlstore, instrumentationtest.New().ToProviderSettings(), analyticstest.New())
+
+	dashboard, err := module.Create(ctx, source.ID, "creator@signoz.io", source.ID, dashboardtypes.PostableDashboard{
+		"title":     "test",
+		"variables": map[string]interface{}{"service": map[string]interface{}{"datasource": "old"}},
+		"widgets":   []interface{}{map[string]interface{}{"id": "a", "query": map[string]interface{}{"builder": map[string]interface{}{"queryData": []interface{}{map[string]interface{}{"dataSource": "old"}}}}}},
+	})
+	require.NoError(t, err)
+
+	bundle, err := module.Export(ctx, source.ID, nil)
+	require.NoError(t, err)
+	assert.Equal(t, dashboardtypes.BundleVersion, bundle.Version)
+	require.Len(t, bundle.Dashboards, 1)
+
+	t.Run("Skip", func(t *testing.T) {
+		results, err := module.Import(ctx, source.ID, "importer@signoz.io", dashboardtypes.PostableImport{Bundle: *bundle, Strategy: dashboardtypes.ConflictStrategySkip})
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.Equal(t, dashboardtypes.ImportStatusSkipped, results[0].Status)
+		assert.Equal(t, dashboard.ID, results[0].ID)
+	})
+
+	t.Run("Rename", func(t *testing.T) {
+		results, err := module.Import(ctx, source.ID, "importer@signoz.io", dashboardtypes.PostableImport{Bundle: *bundle, Strategy: dashboardtypes.ConflictStrategyRename})
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.Equal(t, dashboardtypes.ImportStatusRenamed, results[0].Status)
+		assert.NotEqual(t, dashboard.ID, results[0].ID)
+		assert.Equal(t, "test (imported)", results[0].Title)
+	})
+
+	t.Run("LockedOverwrite", func(t *testing.T) {
+		locked, err := module.Create(ctx, source.ID, "creator@signoz.io", source.ID, dashboardtypes.PostableDashboard{"title": "locked"})
+		require.NoError(t, err)
+		storableDashboard, err := dashboardtypes.NewStorableDashboardFromDashboard(locked)
+		require.NoError(t, err)
+		storableDashboard.Locked = true
+		require.NoError(t, NewStore(sqlstore).Update(ctx, source.ID, storableDashboard))
+
+		other := dashboardtypes.Bundle{Version: dashboardtypes.BundleVersion, Dashboards: []dashboardtypes.BundleDashboard{
+			{ID: locked.ID, Data: dashboardtypes.StorableDashboardData{"title": "overwritten"}},
+			{ID: valuer.GenerateUUID().StringValue(), Data: dashboardtypes.StorableDashboardData{"title": "new"}},
+		}}
+
+		results, err := module.Import(ctx, source.ID, "importer@signoz.io", dashboardtypes.PostableImport{Bundle: other, Strategy: dashboardtypes.ConflictStrategyOverwrite})
+		require.NoError(t, err)
+		require.Len(t, results, 2)
+		assert.Equal(t, dashboardtypes.ImportStatusFailed, results[0].Status)
+		assert.NotEmpty(t, results[0].Error)
+		assert.Equal(t, dashboardtypes.ImportStatusCreated, results[1].Status)
+	})
+
+	t.Run("RemapDatasources", func(t *testing.T) {
+		bundle, err := module.Export(ctx, source.ID, []valuer.UUID{valuer.MustNewUUID(dashboard.ID)})
+		require.NoError(t, err)
+
+		results, err := module.Import(ctx, target.ID, "importer@signoz.io", dashboardtypes.PostableImport{Bundle: *bundle, Strategy: dashboardtypes.ConflictStrategySkip, Datasources: map[string]string{"old": "new"}})
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		require.Equal(t, dashboardtypes.ImportStatusCreated, results[0].Status)
+		// The id of the bundle is taken by the dashboard of the source org.
+		assert.NotEqual(t, dashboard.ID, results[0].ID)
+
+		imported, err := module.Get(ctx, target.ID, valuer.MustNewUUID(results[0].ID))
+		require.NoError(t, err)
+		assert.Equal(t, "new", imported.Data["variables"].(map[string]interface{})["service"].(map[string]interface{})["datasource"])
+		queryData := imported.Data["widgets"].([]interface{})[0].(map[string]interface{})["query"].(map[string]interface{})["builder"].(map[string]interface{})["queryData"].([]interface{})
+		assert.Equal(t, "new", queryData[0].(map[string]interface{})["dataSource"])
+	})
+
+	t.Run("ConflictByTitle", func(t *testing.T) {
+		// The dashboard imported in the target org above is found by its title, its id differs from the bundle.
+		results, err := module.Import(ctx, target.ID, "importer@signoz.io", dashboardtypes.PostableImport{Bundle: *bundle, Strategy: dashboardtypes.ConflictStrategySkip})
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.Equal(t, dashboardtypes.ImportStatusSkipped, results[0].Status)
+		assert.NotEqual(t, dashboard.ID, results[0].ID)
+	})
+
+	t.Run("UnsupportedVersion", func(t *testing.T) {
+		_, err := module.Import(ctx, source.ID, "importer@signoz.io", dashboardtypes.PostableImport{Bundle: dashboardtypes.Bundle{Version: dashboardtypes.BundleVersion + 1, Dashboards: bundle.Dashboards}, Strategy: dashboardtypes.ConflictStrategySkip})
+		assert.True(t, errors.Asc(err, dashboardtypes.ErrCodeBundleVersionUnsupported))
+	})
+}
+
+func TestPublicLinks(t *testing.T) {
+	ctx := context.Background()
+	sqlstore := utils.NewQueryServiceDBForTests(t)
+
+	organization := types.NewOrganization("test")
+	_, err := sqlstore.BunDB().NewInsert().Model(organization).Exec(ctx)
+	require.NoError(t, err)
+
+	module := NewModule(sqlstore, instrumentationtest.New().ToProviderSettings(), analyticstest.New())
+
+	dashboard, err := module.Create(ctx, organization.ID, "creator@signoz.io", organization.ID, dashboardtypes.PostableDashboard{"title": "test"})
+	require.NoError(t, err)
+	id := valuer.MustNewUUID(dashboard.ID)
+
+	link, err := module.CreatePublicLink(ctx, organization.ID, id, "creator@signoz.io", dashboardtypes.PostablePublicLink{})
+	require.NoError(t, err)
+	require.NotEmpty(t, link.Token)
+
+	protected, err := module.CreatePublicLink(ctx, organization.ID, id, "creator@signoz.io", dashboardtypes.PostablePublicLink{Password: "secret"})
+	require.NoError(t, err)
+
+	t.Run("TokenIsHashed", func(t *testing.T) {
+		links, err := module.ListPublicLinks(ctx, organization.ID, id)
+		require.NoError(t, err)
+		require.Len(t, links, 2)
+		for _, link := range links {
+			assert.Empty(t, link.Token)
+		}
+
+		storablePublicLink, err := NewStore(sqlstore).GetPublicLinkByTokenHash(ctx, dashboardtypes.HashPublicLinkToken(link.Token))
+		require.NoError(t, err)
+		assert.NotEqual(t, link.Token, storablePublicLink.TokenHash)
+	})
+
+	t.Run("Get", func(t *testing.T) {
+		public, err := module.GetPublic(ctx, link.Token, "", "127.0.0.1")
+		require.NoError(t, err)
+		assert.Equal(t, dashboard.ID, public.ID)
+		assert.Equal(t, "test", public.Data.Title())
+
+		_, err = module.GetPublic(ctx, "unknown", "", "127.0.0.1")
+		assert.True(t, errors.Asc(err, dashboardtypes.ErrCodePublicLinkInvalid))
+	})
+
+	t.Run("Password", func(t *testing.T) {
+		_, err := module.GetPublic(ctx, protected.Token, "", "127.0.0.1")
+		assert.True(t, errors.Ast(err, errors.TypeUnauthenticated))
+
+		_, err = module.GetPublic(ctx, protected.Token, "wrong", "127.0.0.1")
+		assert.True(t, errors.Ast(err, errors.TypeUnauthenticated))
+
+		_, err = module.GetPublic(ctx, protected.Token, "secret", "127.0.0.1")
+		assert.NoError(t, err)
+	})
+
+	t.Run("Revoke", func(t *testing.T) {
+		require.NoError(t, module.RevokePublicLink(ctx, organization.ID, id, link.ID, "revoker@signoz.io"))
+
+		_, err := module.GetPublic(ctx, link.Token, "", "127.0.0.1")
+		assert.True(t, errors.Asc(err, dashboardtypes.ErrCodePublicLinkInvalid))
+
+		err = module.RevokePublicLink(ctx, organization.ID, id, link.ID, "revoker@signoz.io")
+		assert.True(t, errors.Ast(err, errors.TypeNotFound))
+	})
+
+	t.Run("Expired", func(t *testing.T) {
+		_, err := module.CreatePublicLink(ctx, organization.ID, id, "creator@signoz.io", dashboardtypes.PostablePublicLink{ExpiresAt: &time.Time{}})
+		assert.True(t, errors.Ast(err, errors.TypeInvalidInput))
+
+		expiresAt := time.Now().Add(time.Hour)
+		expiring, err := module.CreatePublicLink(ctx, organization.ID, id, "creator@signoz.io", dashboardtypes.PostablePublicLink{ExpiresAt: &expiresAt})
+		require.NoError(t, err)
+
+		storablePublicLink, err := NewStore(sqlstore).GetPublicLinkByTokenHash(ctx, dashboardtypes.HashPublicLinkToken(expiring.Token))
+		require.NoError(t, err)
+		assert.NoError(t, storablePublicLink.Authorize("", time.Now()))
+		assert.True(t, errors.Asc(storablePublicLink.Authorize("", expiresAt), dashboardtypes.ErrCodePublicLinkInvalid))
+	})
+
+	t.Run("Lockout", func(t *testing.T) {
+		for i := 0; i < maxPasswordFailures; i++ {
+			_, err := module.GetPublic(ctx, protected.Token, "wrong", "10.0.0.1")
+			assert.True(t, errors.Ast(err, errors.TypeUnauthenticated))
+		}
+
+		// The client is locked out even with the right password, the other clients are not.
+		_, err := module.GetPublic(ctx, protected.Token, "secret", "10.0.0.1")
+		assert.True(t, errors.Ast(err, errors.TypeTooManyRequests))
+
+		_, err = module.GetPublic(ctx, protected.Token, "secret", "10.0.0.2")
+		assert.NoError(t, err)
+	})
+
+	t.Run("Audit", func(t *testing.T) {
+		events, err := module.ListPublicLinkAuditEvents(ctx, organization.ID, id)
+		require.NoError(t, err)
+
+		counts := map[string]int{}
+		for _, event := range events {
+			counts[event.Type.StringValue()]++
+		}
+
+		// Only the lockout is audited among the denied accesses.
+		assert.Equal(t, map[string]int{"created": 3, "granted": 3, "denied": 1, "revoked": 1}, counts)
+	})
+}
+
+func TestGetPublicQueryRange(t *testing.T) {
+	ctx := context.Background()
+	sqlstore := utils.NewQueryServiceDBForTests(t)
+
+	organization := types.NewOrganization("test")
+	_, err := sqlstore.BunDB().NewInsert().Model(organization).Exec(ctx)
+	require.NoError(t, err)
+
+	module := NewModule(sqlstore, instrumentationtest.New().ToProviderSettings(), analyticstest.New())
+
+	var data dashboardtypes.PostableDashboard
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"title": "test",
+		"widgets": [
+			{
+				"id": "metrics",
+				"panelTypes": "graph",
+				"query": {
+					"queryType": "builder",
+					"builder": {
+						"queryData": [
+							{"queryName": "A", "dataSource": "metrics", "aggregateAttribute": {"key": "http_requests_total"}, "timeAggregation": "rate", "spaceAggregation": "sum", "filters": {"items": [{"key": {"key": "host_name"}, "op": "in", "value": ["a"]}], "op": "AND"}, "groupBy": [{"key": "service_name"}], "stepInterval": 60}
+						],
+						"queryFormulas": [{"queryName": "F1", "expression": "A * 2"}]
+					}
+				}
+			},
+			{
+				"id": "logs",
+				"panelTypes": "value",
+				"query": {
+					"queryType": "builder",
+					"builder": {
+						"queryData": [
+							{"queryName": "A", "dataSource": "logs", "aggregateOperator": "count", "filter": {"expression": "service.name = 'api'"}}
+						]
+					}
+				}
+			},
+			{
+				"id": "list",
+				"panelTypes": "list",
+				"query": {
+					"queryType": "builder",
+					"builder": {
+						"queryData": [
+							{"queryName": "A", "dataSource": "logs", "aggregateOperator": "noop", "filter": {"expression": "service.name = 'api'"}, "orderBy": [{"columnName": "timestamp", "order": "desc"}], "limit": 10}
+						]
+					}
+				}
+			},
+			{
+				"id": "sql",
+				"panelTypes": "table",
+				"query": {"queryType": "clickhouse_sql", "clickhouse_sql": [{"name": "A", "query": "SELECT 1"}, {"name": "B", "query": ""}]}
+			}
+		]
+	}`), &data))
+
+	dashboard, err := module.Create(ctx, organization.ID, "creator@signoz.io", organization.ID, data)
+	require.NoError(t, err)
+
+	link, err := module.CreatePublicLink(ctx, organization.ID, valuer.MustNewUUID(dashboard.ID), "creator@signoz.io", dashboardtypes.PostablePublicLink{})
+	require.NoError(t, err)
+
+	t.Run("Reshaped", func(t *testing.T) {
+		// The queries sent along with the time range are not run in place of the queries of the widget.
+		bodies := []string{
+			`{"start": 1000, "end": 2000, "requestType": "raw"}`,
+			`{"start": 1000, "end": 2000, "compositeQuery": {"queries": [{"type": "builder_query", "spec": {"name": "A", "signal": "logs", "selectFields": [{"name": "body"}]}}]}}`,
+			`{"start": 2000, "end": 1000}`,
+		}
+
+		for _, body := range bodies {
+			var postable dashboardtypes.PostablePublicQuery
+			err := json.Unmarshal([]byte(body), &postable)
+			assert.True(t, errors.Asc(err, dashboardtypes.ErrCodePublicQueryInvalid), body)
+		}
+	})
+
+	postable := dashboardtypes.PostablePublicQuery{}
+	require.NoError(t, json.Unmarshal([]byte(`{"start": 1000, "end": 2000}`), &postable))
+
+	t.Run("Metrics", func(t *testing.T) {
+		orgID, req, err := module.GetPublicQueryRange(ctx, link.Token, "", "127.0.0.1", "metrics", postable)
+		require.NoError(t, err)
+		assert.Equal(t, organization.ID, orgID)
+		assert.Equal(t, qbtypes.RequestTypeTimeSeries, req.RequestType)
+		assert.Equal(t, uint64(1000), req.Start)
+		assert.Equal(t, uint64(2000), req.End)
+		require.Len(t, req.CompositeQuery.Queries, 2)
+
+		spec, ok := req.CompositeQuery.Queries[0].Spec.(qbtypes.QueryBuilderQuery[qbtypes.MetricAggregation])
+		require.True(t, ok)
+		assert.Equal(t, "http_requests_total", spec.Aggregations[0].MetricName)
+		assert.Equal(t, "host_name IN ('a')", spec.Filter.Expression)
+		assert.Equal(t, "service_name", spec.GroupBy[0].Name)
+		assert.Equal(t, time.Minute, spec.StepInterval.Duration)
+
+		formula, ok := req.CompositeQuery.Queries[1].Spec.(qbtypes.QueryBuilderFormula)
+		require.True(t, ok)
+		assert.Equal(t, "A * 2", formula.Expression)
+	})
+
+	t.Run("Logs", func(t *testing.T) {
+		postable := postable
+		postable.Step = qbtypes.Step{Duration: 30 * time.Second}
+
+		_, req, err := module.GetPublicQueryRange(ctx, link.Token, "", "127.0.0.1", "logs", postable)
+		require.NoError(t, err)
+		assert.Equal(t, qbtypes.RequestTypeScalar, req.RequestType)
+
+		spec, ok := req.CompositeQuery.Queries[0].Spec.(qbtypes.QueryBuilderQuery[qbtypes.LogAggregation])
+		require.True(t, ok)
+		assert.Equal(t, "count()", spec.Aggregations[0].Expression)
+		assert.Equal(t, "service.name = 'api'", spec.Filter.Expression)
+		assert.Equal(t, 30*time.Second, spec.StepInterval.Duration)
+		assert.Empty(t, spec.SelectFields)
+	})
+
+	t.Run("List", func(t *testing.T) {
+		_, req, err := module.GetPublicQueryRange(ctx, link.Token, "", "127.0.0.1", "list", postable)
+		require.NoError(t, err)
+		assert.Equal(t, qbtypes.RequestTypeRaw, req.RequestType)
+
+		spec, ok := req.CompositeQuery.Queries[0].Spec.(qbtypes.QueryBuilderQuery[qbtypes.LogAggregation])
+		require.True(t, ok)
+		assert.Empty(t, spec.Aggregations)
+		assert.Equal(t, 10, spec.Limit)
+		assert.Equal(t, "timestamp", spec.Order[0].Key.Name)
+		assert.Equal(t, qbtypes.OrderDirectionDesc, spec.Order[0].Direction)
+	})
+
+	t.Run("ClickHouse", func(t *testing.T) {
+		_, req, err := module.GetPublicQueryRange(ctx, link.Token, "", "127.0.0.1", "sql", postable)
+		require.NoError(t, err)
+		require.Len(t, req.CompositeQuery.Queries, 1)
+
+		spec, ok := req.CompositeQuery.Queries[0].Spec.(qbtypes.ClickHouseQuery)
+		require.True(t, ok)
+		assert.Equal(t, "SELECT 1", spec.Query)
+	})
+
+	t.Run("UnknownWidget", func(t *testing.T) {
+		_, _, err := module.GetPublicQueryRange(ctx, link.Token, "", "127.0.0.1", "unknown", postable)
+		assert.True(t, errors.Ast(err, errors.TypeNotFound), err)
+	})
+}
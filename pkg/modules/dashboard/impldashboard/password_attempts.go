@@ -0,0 +1,91 @@
+package impldashboard
+
+import (
+	"sync"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/types/dashboardtypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+)
+
+const (
+	// maxPasswordFailures is the number of wrong passwords a client can try on a public link before it is locked out
+	// of the link for the passwordLockout.
+	maxPasswordFailures int           = 5
+	passwordLockout     time.Duration = 15 * time.Minute
+)
+
+// passwordAttempts counts the wrong passwords tried by every client on every public link, and locks a client out of
+// a link once it tried too many of them. The counts are kept by every replica and are lost on restart.
+type passwordAttempts struct {
+	max     int
+	lockout time.Duration
+	mtx     sync.Mutex
+	clients map[string]*passwordFailures
+}
+
+type passwordFailures struct {
+	count int
+	// since is the time of the first wrong password, or the time the client was locked out at.
+	since time.Time
+}
+
+func newPasswordAttempts(maxFailures int, lockout time.Duration) *passwordAttempts {
+	return &passwordAttempts{
+		max:     maxFailures,
+		lockout: lockout,
+		clients: map[string]*passwordFailures{},
+	}
+}
+
+// check returns an error of type TypeTooManyRequests while the client is locked out of the link.
+func (attempts *passwordAttempts) check(linkID valuer.UUID, client string, now time.Time) error {
+	attempts.mtx.Lock()
+	defer attempts.mtx.Unlock()
+
+	failures, ok := attempts.clients[passwordAttemptsKey(linkID, client)]
+	if !ok || failures.count < attempts.max || now.Sub(failures.since) >= attempts.lockout {
+		return nil
+	}
+
+	return errors.Newf(errors.TypeTooManyRequests, dashboardtypes.ErrCodePublicLinkPasswordAttempts, "too many wrong passwords for the public link, retry after %s", failures.since.Add(attempts.lockout).Sub(now).Round(time.Second))
+}
+
+// fail counts a wrong password of the client and returns true when the client is locked out of the link by it.
+func (attempts *passwordAttempts) fail(linkID valuer.UUID, client string, now time.Time) bool {
+	attempts.mtx.Lock()
+	defer attempts.mtx.Unlock()
+
+	// The expired failures are dropped so that the clients which stopped trying do not accumulate.
+	for k, failures := range attempts.clients {
+		if now.Sub(failures.since) >= attempts.lockout {
+			delete(attempts.clients, k)
+		}
+	}
+
+	failures, ok := attempts.clients[passwordAttemptsKey(linkID, client)]
+	if !ok {
+		failures = &passwordFailures{since: now}
+		attempts.clients[passwordAttemptsKey(linkID, client)] = failures
+	}
+
+	failures.count++
+	if failures.count < attempts.max {
+		return false
+	}
+
+	failures.since = now
+	return true
+}
+
+func (attempts *passwordAttempts) reset(linkID valuer.UUID, client string) {
+	attempts.mtx.Lock()
+	defer attempts.mtx.Unlock()
+
+	delete(attempts.clients, passwordAttemptsKey(linkID, client))
+}
+
+func passwordAttemptsKey(linkID valuer.UUID, client string) string {
+	return linkID.StringValue() + "::" + client
+}
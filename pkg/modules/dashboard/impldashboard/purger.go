@@ -0,0 +1,23 @@
+package impldashboard
+
+import (
+	"context"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/factory"
+	"github.com/SigNoz/signoz/pkg/modules/dashboard"
+)
+
+// NewPurgeJob returns the job hard deleting the dashboards deleted for longer than the retention. It runs on a single
+// replica at a time.
+func NewPurgeJob(module dashboard.Module, config dashboard.Config) factory.Job {
+	return factory.NewJob(
+		factory.MustNewName("dashboardpurger"),
+		config.Purge.Interval,
+		func(ctx context.Context) error {
+			return module.Purge(ctx, time.Now().Add(-config.Purge.Retention))
+		},
+		factory.WithJitter(config.Purge.Interval/10),
+		factory.WithSingleton(),
+	)
+}
@@ -2,9 +2,12 @@ package impldashboard
 
 import (
 	"context"
+	"database/sql"
+	"time"
 
 	"github.com/SigNoz/signoz/pkg/errors"
 	"github.com/SigNoz/signoz/pkg/sqlstore"
+	"github.com/SigNoz/signoz/pkg/types"
 	"github.com/SigNoz/signoz/pkg/types/dashboardtypes"
 	"github.com/SigNoz/signoz/pkg/valuer"
 )
@@ -20,7 +23,7 @@ func NewStore(sqlstore sqlstore.SQLStore) dashboardtypes.Store {
 func (store *store) Create(ctx context.Context, storabledashboard *dashboardtypes.StorableDashboard) error {
 	_, err := store.
 		sqlstore.
-		BunDB().
+		BunDBCtx(ctx).
 		NewInsert().
 		Model(storabledashboard).
 		Exec(ctx)
@@ -36,11 +39,12 @@ func (store *store) Get(ctx context.Context, orgID valuer.UUID, id valuer.UUID)
 
 	err := store.
 		sqlstore.
-		BunDB().
+		BunDBCtx(ctx).
 		NewSelect().
 		Model(storableDashboard).
 		Where("id = ?", id).
 		Where("org_id = ?", orgID).
+		Where("deleted_at IS NULL").
 		Scan(ctx)
 	if err != nil {
 		return nil, store.sqlstore.WrapNotFoundErrf(err, errors.CodeNotFound, "dashboard with id %s doesn't exist", id)
@@ -49,15 +53,55 @@ func (store *store) Get(ctx context.Context, orgID valuer.UUID, id valuer.UUID)
 	return storableDashboard, nil
 }
 
+func (store *store) GetByTitle(ctx context.Context, orgID valuer.UUID, title string) (*dashboardtypes.StorableDashboard, error) {
+	storableDashboard := new(dashboardtypes.StorableDashboard)
+
+	err := store.
+		sqlstore.
+		BunDBCtx(ctx).
+		NewSelect().
+		Model(storableDashboard).
+		Where("org_id = ?", orgID).
+		Where("title = ?", title).
+		Where("deleted_at IS NULL").
+		Order("created_at ASC").
+		Limit(1).
+		Scan(ctx)
+	if err != nil {
+		return nil, store.sqlstore.WrapNotFoundErrf(err, errors.CodeNotFound, "dashboard with title %s doesn't exist", title)
+	}
+
+	return storableDashboard, nil
+}
+
 func (store *store) List(ctx context.Context, orgID valuer.UUID) ([]*dashboardtypes.StorableDashboard, error) {
 	storableDashboards := make([]*dashboardtypes.StorableDashboard, 0)
 
 	err := store.
 		sqlstore.
-		BunDB().
+		ReadDB(ctx).
+		NewSelect().
+		Model(&storableDashboards).
+		Where("org_id = ?", orgID).
+		Where("deleted_at IS NULL").
+		Scan(ctx)
+	if err != nil {
+		return nil, store.sqlstore.WrapNotFoundErrf(err, errors.CodeNotFound, "no dashboards found in orgID %s", orgID)
+	}
+
+	return storableDashboards, nil
+}
+
+func (store *store) ListPage(ctx context.Context, orgID valuer.UUID, pagination *types.Pagination) ([]*dashboardtypes.StorableDashboard, error) {
+	storableDashboards := make([]*dashboardtypes.StorableDashboard, 0)
+
+	err := pagination.Apply(store.
+		sqlstore.
+		ReadDB(ctx).
 		NewSelect().
 		Model(&storableDashboards).
 		Where("org_id = ?", orgID).
+		Where("deleted_at IS NULL")).
 		Scan(ctx)
 	if err != nil {
 		return nil, store.sqlstore.WrapNotFoundErrf(err, errors.CodeNotFound, "no dashboards found in orgID %s", orgID)
@@ -66,14 +110,34 @@ func (store *store) List(ctx context.Context, orgID valuer.UUID) ([]*dashboardty
 	return storableDashboards, nil
 }
 
+func (store *store) ListDeleted(ctx context.Context, orgID valuer.UUID) ([]*dashboardtypes.StorableDashboard, error) {
+	storableDashboards := make([]*dashboardtypes.StorableDashboard, 0)
+
+	err := store.
+		sqlstore.
+		ReadDB(ctx).
+		NewSelect().
+		Model(&storableDashboards).
+		Where("org_id = ?", orgID).
+		Where("deleted_at IS NOT NULL").
+		Order("deleted_at DESC").
+		Scan(ctx)
+	if err != nil {
+		return nil, store.sqlstore.WrapNotFoundErrf(err, errors.CodeNotFound, "no deleted dashboards found in orgID %s", orgID)
+	}
+
+	return storableDashboards, nil
+}
+
 func (store *store) Update(ctx context.Context, orgID valuer.UUID, storableDashboard *dashboardtypes.StorableDashboard) error {
 	_, err := store.
 		sqlstore.
-		BunDB().
+		BunDBCtx(ctx).
 		NewUpdate().
 		Model(storableDashboard).
 		WherePK().
 		Where("org_id = ?", orgID).
+		Where("deleted_at IS NULL").
 		Exec(ctx)
 	if err != nil {
 		return store.sqlstore.WrapNotFoundErrf(err, errors.CodeAlreadyExists, "dashboard with id %s doesn't exist", storableDashboard.ID)
@@ -82,18 +146,200 @@ func (store *store) Update(ctx context.Context, orgID valuer.UUID, storableDashb
 	return nil
 }
 
-func (store *store) Delete(ctx context.Context, orgID valuer.UUID, id valuer.UUID) error {
-	_, err := store.
+func (store *store) Delete(ctx context.Context, orgID valuer.UUID, id valuer.UUID, deletedBy string) error {
+	result, err := store.
 		sqlstore.
 		BunDB().
-		NewDelete().
+		NewUpdate().
 		Model(new(dashboardtypes.StorableDashboard)).
+		Set("deleted_at = ?", time.Now()).
+		Set("deleted_by = ?", deletedBy).
 		Where("id = ?", id).
 		Where("org_id = ?", orgID).
+		Where("deleted_at IS NULL").
 		Exec(ctx)
 	if err != nil {
 		return store.sqlstore.WrapNotFoundErrf(err, errors.CodeNotFound, "dashboard with id %s doesn't exist", id)
 	}
 
+	return checkAffected(result, id)
+}
+
+func (store *store) Restore(ctx context.Context, orgID valuer.UUID, id valuer.UUID, restoredBy string) error {
+	result, err := store.
+		sqlstore.
+		BunDB().
+		NewUpdate().
+		Model(new(dashboardtypes.StorableDashboard)).
+		Set("deleted_at = NULL").
+		Set("deleted_by = NULL").
+		Set("restored_at = ?", time.Now()).
+		Set("restored_by = ?", restoredBy).
+		Where("id = ?", id).
+		Where("org_id = ?", orgID).
+		Where("deleted_at IS NOT NULL").
+		Exec(ctx)
+	if err != nil {
+		return store.sqlstore.WrapNotFoundErrf(err, errors.CodeNotFound, "deleted dashboard with id %s doesn't exist", id)
+	}
+
+	return checkAffected(result, id)
+}
+
+func (store *store) Purge(ctx context.Context, before time.Time) (int64, error) {
+	result, err := store.
+		sqlstore.
+		BunDB().
+		NewDelete().
+		Model(new(dashboardtypes.StorableDashboard)).
+		Where("deleted_at IS NOT NULL").
+		Where("deleted_at < ?", before).
+		Exec(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
+func checkAffected(result sql.Result, id valuer.UUID) error {
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return errors.Newf(errors.TypeNotFound, errors.CodeNotFound, "dashboard with id %s doesn't exist", id)
+	}
+
+	return nil
+}
+
+func (store *store) CreatePublicLink(ctx context.Context, storablePublicLink *dashboardtypes.StorablePublicLink) error {
+	_, err := store.
+		sqlstore.
+		BunDBCtx(ctx).
+		NewInsert().
+		Model(storablePublicLink).
+		Exec(ctx)
+	if err != nil {
+		// The public link either already exists or its dashboard was deleted in the meantime.
+		return store.sqlstore.WrapErrf(err, dashboardtypes.ErrCodePublicLinkInvalid, "public link with id %s cannot be created", storablePublicLink.ID)
+	}
+
+	return nil
+}
+
+func (store *store) ListPublicLinks(ctx context.Context, orgID valuer.UUID, dashboardID valuer.UUID) ([]*dashboardtypes.StorablePublicLink, error) {
+	storablePublicLinks := make([]*dashboardtypes.StorablePublicLink, 0)
+
+	err := store.
+		sqlstore.
+		ReadDB(ctx).
+		NewSelect().
+		Model(&storablePublicLinks).
+		Where("org_id = ?", orgID).
+		Where("dashboard_id = ?", dashboardID).
+		Order("created_at DESC").
+		Scan(ctx)
+	if err != nil {
+		return nil, store.sqlstore.WrapNotFoundErrf(err, errors.CodeNotFound, "no public links found for dashboard with id %s", dashboardID)
+	}
+
+	return storablePublicLinks, nil
+}
+
+func (store *store) GetPublicLinkByTokenHash(ctx context.Context, tokenHash string) (*dashboardtypes.StorablePublicLink, error) {
+	storablePublicLink := new(dashboardtypes.StorablePublicLink)
+
+	// The link is read from the primary so that a revocation applies immediately.
+	err := store.
+		sqlstore.
+		BunDBCtx(ctx).
+		NewSelect().
+		Model(storablePublicLink).
+		Where("token_hash = ?", tokenHash).
+		Scan(ctx)
+	if err != nil {
+		return nil, store.sqlstore.WrapNotFoundErrf(err, dashboardtypes.ErrCodePublicLinkInvalid, "public link doesn't exist")
+	}
+
+	return storablePublicLink, nil
+}
+
+func (store *store) RevokePublicLink(ctx context.Context, orgID valuer.UUID, dashboardID valuer.UUID, id valuer.UUID, revokedBy string) (*dashboardtypes.StorablePublicLink, error) {
+	now := time.Now()
+	result, err := store.
+		sqlstore.
+		BunDBCtx(ctx).
+		NewUpdate().
+		Model(new(dashboardtypes.StorablePublicLink)).
+		Set("revoked_at = ?", now).
+		Set("revoked_by = ?", revokedBy).
+		Set("updated_at = ?", now).
+		Set("updated_by = ?", revokedBy).
+		Where("id = ?", id).
+		Where("org_id = ?", orgID).
+		Where("dashboard_id = ?", dashboardID).
+		Where("revoked_at IS NULL").
+		Exec(ctx)
+	if err != nil {
+		return nil, store.sqlstore.WrapNotFoundErrf(err, errors.CodeNotFound, "public link with id %s doesn't exist", id)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+
+	if affected == 0 {
+		return nil, errors.Newf(errors.TypeNotFound, errors.CodeNotFound, "public link with id %s doesn't exist or is already revoked", id)
+	}
+
+	storablePublicLink := new(dashboardtypes.StorablePublicLink)
+	err = store.
+		sqlstore.
+		BunDBCtx(ctx).
+		NewSelect().
+		Model(storablePublicLink).
+		Where("id = ?", id).
+		Scan(ctx)
+	if err != nil {
+		return nil, store.sqlstore.WrapNotFoundErrf(err, errors.CodeNotFound, "public link with id %s doesn't exist", id)
+	}
+
+	return storablePublicLink, nil
+}
+
+func (store *store) CreatePublicLinkAuditEvent(ctx context.Context, event *dashboardtypes.StorablePublicLinkAuditEvent) error {
+	_, err := store.
+		sqlstore.
+		BunDBCtx(ctx).
+		NewInsert().
+		Model(event).
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
+
+func (store *store) ListPublicLinkAuditEvents(ctx context.Context, orgID valuer.UUID, dashboardID valuer.UUID) ([]*dashboardtypes.StorablePublicLinkAuditEvent, error) {
+	events := make([]*dashboardtypes.StorablePublicLinkAuditEvent, 0)
+
+	err := store.
+		sqlstore.
+		ReadDB(ctx).
+		NewSelect().
+		Model(&events).
+		Where("org_id = ?", orgID).
+		Where("dashboard_id = ?", dashboardID).
+		Order("created_at DESC").
+		Scan(ctx)
+	if err != nil {
+		return nil, store.sqlstore.WrapNotFoundErrf(err, errors.CodeNotFound, "no public link audit events found for dashboard with id %s", dashboardID)
+	}
+
+	return events, nil
+}
@@ -0,0 +1,83 @@
+package implrbac
+
+import (
+	"context"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/modules/rbac"
+	"github.com/SigNoz/signoz/pkg/types/rbactypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+)
+
+type module struct {
+	store rbactypes.Store
+}
+
+func NewModule(store rbactypes.Store) rbac.Module {
+	return &module{store: store}
+}
+
+func (module *module) Authorize(ctx context.Context, subject rbactypes.Subject, resource string, action string) error {
+	roles, err := module.store.ListRolesBySubject(ctx, subject.OrgID, subject.ID)
+	if err != nil {
+		return err
+	}
+
+	permissions := append([]rbactypes.Permission{}, rbactypes.BuiltinPermissions[subject.Role]...)
+	for _, role := range roles {
+		permissions = append(permissions, role.Permissions...)
+	}
+
+	if !rbactypes.Evaluate(permissions, resource, action) {
+		return errors.Newf(errors.TypeForbidden, rbactypes.ErrCodePermissionDenied, "%s on %s is not allowed", action, resource)
+	}
+
+	return nil
+}
+
+func (module *module) CreateRole(ctx context.Context, orgID valuer.UUID, postable *rbactypes.PostableRole) (*rbactypes.Role, error) {
+	role, err := rbactypes.NewRole(orgID, postable.Name, postable.Description, postable.Permissions)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := module.store.CreateRole(ctx, role); err != nil {
+		return nil, err
+	}
+
+	return role, nil
+}
+
+func (module *module) ListRoles(ctx context.Context, orgID valuer.UUID) ([]*rbactypes.Role, error) {
+	return module.store.ListRoles(ctx, orgID)
+}
+
+func (module *module) DeleteRole(ctx context.Context, orgID valuer.UUID, id valuer.UUID) error {
+	return module.store.DeleteRole(ctx, orgID, id)
+}
+
+func (module *module) CreateBinding(ctx context.Context, orgID valuer.UUID, postable *rbactypes.PostableBinding) (*rbactypes.Binding, error) {
+	// the role must belong to the organization of the binding
+	if _, err := module.store.GetRole(ctx, orgID, postable.RoleID); err != nil {
+		return nil, err
+	}
+
+	binding, err := rbactypes.NewBinding(orgID, postable.RoleID, postable.Subject)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := module.store.CreateBinding(ctx, binding); err != nil {
+		return nil, err
+	}
+
+	return binding, nil
+}
+
+func (module *module) ListBindings(ctx context.Context, orgID valuer.UUID) ([]*rbactypes.Binding, error) {
+	return module.store.ListBindings(ctx, orgID)
+}
+
+func (module *module) DeleteBinding(ctx context.Context, orgID valuer.UUID, id valuer.UUID) error {
+	return module.store.DeleteBinding(ctx, orgID, id)
+}
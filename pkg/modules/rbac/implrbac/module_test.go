@@ -0,0 +1,57 @@
+package implrbac
+
+import (
+	"context"
+	"testing"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/types"
+	"github.com/SigNoz/signoz/pkg/types/rbactypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+	"github.com/stretchr/testify/assert"
+)
+
+// subjectStore is a store holding the roles bound to every subject.
+type subjectStore struct {
+	rbactypes.Store
+	roles map[string][]*rbactypes.Role
+}
+
+func (store *subjectStore) ListRolesBySubject(_ context.Context, _ valuer.UUID, subject string) ([]*rbactypes.Role, error) {
+	return store.roles[subject], nil
+}
+
+func TestModuleAuthorize(t *testing.T) {
+	orgID := valuer.GenerateUUID()
+	oncall, err := rbactypes.NewRole(orgID, "oncall", "", []rbactypes.Permission{{Resource: "alerts", Action: rbactypes.ActionWrite, Effect: rbactypes.EffectAllow}})
+	assert.NoError(t, err)
+	nobilling, err := rbactypes.NewRole(orgID, "nobilling", "", []rbactypes.Permission{{Resource: "billing", Action: rbactypes.Wildcard, Effect: rbactypes.EffectDeny}})
+	assert.NoError(t, err)
+
+	module := NewModule(&subjectStore{roles: map[string][]*rbactypes.Role{"viewer": {oncall}, "admin": {nobilling}}})
+
+	testCases := []struct {
+		name     string
+		subject  rbactypes.Subject
+		resource string
+		action   string
+		allowed  bool
+	}{
+		{name: "BuiltinRole", subject: rbactypes.Subject{OrgID: orgID, ID: "other", Role: types.RoleViewer}, resource: "dashboards", action: rbactypes.ActionRead, allowed: true},
+		{name: "BuiltinRoleDenied", subject: rbactypes.Subject{OrgID: orgID, ID: "other", Role: types.RoleViewer}, resource: "dashboards", action: rbactypes.ActionWrite, allowed: false},
+		{name: "BoundRoleExtends", subject: rbactypes.Subject{OrgID: orgID, ID: "viewer", Role: types.RoleViewer}, resource: "alerts", action: rbactypes.ActionWrite, allowed: true},
+		{name: "BoundRoleDenies", subject: rbactypes.Subject{OrgID: orgID, ID: "admin", Role: types.RoleAdmin}, resource: "billing", action: rbactypes.ActionRead, allowed: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := module.Authorize(context.Background(), tc.subject, tc.resource, tc.action)
+			if tc.allowed {
+				assert.NoError(t, err)
+				return
+			}
+
+			assert.True(t, errors.Asc(err, rbactypes.ErrCodePermissionDenied))
+		})
+	}
+}
@@ -0,0 +1,170 @@
+package implrbac
+
+import (
+	"context"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/sqlstore"
+	"github.com/SigNoz/signoz/pkg/types/rbactypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+)
+
+type store struct {
+	sqlstore sqlstore.SQLStore
+}
+
+func NewStore(sqlstore sqlstore.SQLStore) rbactypes.Store {
+	return &store{sqlstore: sqlstore}
+}
+
+func (store *store) CreateRole(ctx context.Context, role *rbactypes.Role) error {
+	_, err := store.
+		sqlstore.
+		BunDBCtx(ctx).
+		NewInsert().
+		Model(role).
+		Exec(ctx)
+	if err != nil {
+		return store.sqlstore.WrapAlreadyExistsErrf(err, rbactypes.ErrCodeRoleAlreadyExists, "role with name %s already exists", role.Name)
+	}
+
+	return nil
+}
+
+func (store *store) GetRole(ctx context.Context, orgID valuer.UUID, id valuer.UUID) (*rbactypes.Role, error) {
+	role := new(rbactypes.Role)
+	err := store.
+		sqlstore.
+		BunDBCtx(ctx).
+		NewSelect().
+		Model(role).
+		Where("org_id = ?", orgID).
+		Where("id = ?", id).
+		Scan(ctx)
+	if err != nil {
+		return nil, store.sqlstore.WrapNotFoundErrf(err, rbactypes.ErrCodeRoleNotFound, "role with id %s does not exist", id)
+	}
+
+	return role, nil
+}
+
+func (store *store) ListRoles(ctx context.Context, orgID valuer.UUID) ([]*rbactypes.Role, error) {
+	roles := make([]*rbactypes.Role, 0)
+	err := store.
+		sqlstore.
+		BunDBCtx(ctx).
+		NewSelect().
+		Model(&roles).
+		Where("org_id = ?", orgID).
+		Order("name").
+		Scan(ctx)
+	if err != nil {
+		return nil, errors.Wrapf(err, errors.TypeInternal, errors.CodeInternal, "failed to list roles")
+	}
+
+	return roles, nil
+}
+
+func (store *store) DeleteRole(ctx context.Context, orgID valuer.UUID, id valuer.UUID) error {
+	return store.sqlstore.RunInTxCtx(ctx, nil, func(ctx context.Context) error {
+		result, err := store.
+			sqlstore.
+			BunDBCtx(ctx).
+			NewDelete().
+			Model(new(rbactypes.Role)).
+			Where("org_id = ?", orgID).
+			Where("id = ?", id).
+			Exec(ctx)
+		if err != nil {
+			return errors.Wrapf(err, errors.TypeInternal, errors.CodeInternal, "failed to delete role")
+		}
+
+		if rows, err := result.RowsAffected(); err == nil && rows == 0 {
+			return errors.Newf(errors.TypeNotFound, rbactypes.ErrCodeRoleNotFound, "role with id %s does not exist", id)
+		}
+
+		_, err = store.
+			sqlstore.
+			BunDBCtx(ctx).
+			NewDelete().
+			Model(new(rbactypes.Binding)).
+			Where("org_id = ?", orgID).
+			Where("role_id = ?", id).
+			Exec(ctx)
+		if err != nil {
+			return errors.Wrapf(err, errors.TypeInternal, errors.CodeInternal, "failed to delete the bindings of the role")
+		}
+
+		return nil
+	})
+}
+
+func (store *store) CreateBinding(ctx context.Context, binding *rbactypes.Binding) error {
+	_, err := store.
+		sqlstore.
+		BunDBCtx(ctx).
+		NewInsert().
+		Model(binding).
+		Exec(ctx)
+	if err != nil {
+		// The role is either already bound to the subject or does not exist.
+		return store.sqlstore.WrapErrf(err, rbactypes.ErrCodeBindingInvalid, "role %s cannot be bound to %s", binding.RoleID, binding.Subject)
+	}
+
+	return nil
+}
+
+func (store *store) ListBindings(ctx context.Context, orgID valuer.UUID) ([]*rbactypes.Binding, error) {
+	bindings := make([]*rbactypes.Binding, 0)
+	err := store.
+		sqlstore.
+		BunDBCtx(ctx).
+		NewSelect().
+		Model(&bindings).
+		Where("org_id = ?", orgID).
+		Scan(ctx)
+	if err != nil {
+		return nil, errors.Wrapf(err, errors.TypeInternal, errors.CodeInternal, "failed to list bindings")
+	}
+
+	return bindings, nil
+}
+
+func (store *store) DeleteBinding(ctx context.Context, orgID valuer.UUID, id valuer.UUID) error {
+	result, err := store.
+		sqlstore.
+		BunDBCtx(ctx).
+		NewDelete().
+		Model(new(rbactypes.Binding)).
+		Where("org_id = ?", orgID).
+		Where("id = ?", id).
+		Exec(ctx)
+	if err != nil {
+		return errors.Wrapf(err, errors.TypeInternal, errors.CodeInternal, "failed to delete binding")
+	}
+
+	if rows, err := result.RowsAffected(); err == nil && rows == 0 {
+		return errors.Newf(errors.TypeNotFound, rbactypes.ErrCodeBindingNotFound, "binding with id %s does not exist", id)
+	}
+
+	return nil
+}
+
+func (store *store) ListRolesBySubject(ctx context.Context, orgID valuer.UUID, subject string) ([]*rbactypes.Role, error) {
+	roles := make([]*rbactypes.Role, 0)
+	err := store.
+		sqlstore.
+		BunDBCtx(ctx).
+		NewSelect().
+		Model(&roles).
+		Join("JOIN rbac_binding AS binding ON binding.role_id = role.id").
+		Where("role.org_id = ?", orgID).
+		Where("binding.org_id = ?", orgID).
+		Where("binding.subject = ?", subject).
+		Scan(ctx)
+	if err != nil {
+		return nil, errors.Wrapf(err, errors.TypeInternal, errors.CodeInternal, "failed to list the roles of %s", subject)
+	}
+
+	return roles, nil
+}
@@ -0,0 +1,41 @@
+package rbac
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/SigNoz/signoz/pkg/types/rbactypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+)
+
+type Module interface {
+	// Authorize returns a forbidden error unless the permissions of the built-in role of the subject and of the
+	// roles bound to them allow the action on the resource.
+	Authorize(ctx context.Context, subject rbactypes.Subject, resource string, action string) error
+
+	CreateRole(context.Context, valuer.UUID, *rbactypes.PostableRole) (*rbactypes.Role, error)
+
+	ListRoles(context.Context, valuer.UUID) ([]*rbactypes.Role, error)
+
+	DeleteRole(context.Context, valuer.UUID, valuer.UUID) error
+
+	CreateBinding(context.Context, valuer.UUID, *rbactypes.PostableBinding) (*rbactypes.Binding, error)
+
+	ListBindings(context.Context, valuer.UUID) ([]*rbactypes.Binding, error)
+
+	DeleteBinding(context.Context, valuer.UUID, valuer.UUID) error
+}
+
+type Handler interface {
+	CreateRole(http.ResponseWriter, *http.Request)
+
+	ListRoles(http.ResponseWriter, *http.Request)
+
+	DeleteRole(http.ResponseWriter, *http.Request)
+
+	CreateBinding(http.ResponseWriter, *http.Request)
+
+	ListBindings(http.ResponseWriter, *http.Request)
+
+	DeleteBinding(http.ResponseWriter, *http.Request)
+}
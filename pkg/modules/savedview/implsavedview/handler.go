@@ -10,6 +10,7 @@ import (
 	"github.com/SigNoz/signoz/pkg/http/render"
 	"github.com/SigNoz/signoz/pkg/modules/savedview"
 	v3 "github.com/SigNoz/signoz/pkg/query-service/model/v3"
+	"github.com/SigNoz/signoz/pkg/sqlstore"
 	"github.com/SigNoz/signoz/pkg/types/authtypes"
 	"github.com/SigNoz/signoz/pkg/valuer"
 	"github.com/gorilla/mux"
@@ -151,15 +152,31 @@ func (handler *handler) List(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	sourcePage := r.URL.Query().Get("sourcePage")
-	name := r.URL.Query().Get("name")
-	category := r.URL.Query().Get("category")
+	list, err := sqlstore.NewListQueryFromRequest(r)
+	if err != nil {
+		render.Error(w, err)
+		return
+	}
+
+	// The sourcePage, name and category parameters predate the filters and are kept as filters of their own.
+	list.Filters = append(list.Filters, sqlstore.Filter{Field: "sourcePage", Operator: sqlstore.OperatorEqual, Value: r.URL.Query().Get("sourcePage")})
+	if name := r.URL.Query().Get("name"); name != "" {
+		list.Filters = append(list.Filters, sqlstore.Filter{Field: "name", Operator: sqlstore.OperatorContains, Value: name})
+	}
+	if category := r.URL.Query().Get("category"); category != "" {
+		list.Filters = append(list.Filters, sqlstore.Filter{Field: "category", Operator: sqlstore.OperatorContains, Value: category})
+	}
 
-	queries, err := handler.module.GetViewsForFilters(r.Context(), claims.OrgID, sourcePage, name, category)
+	views, nextCursor, err := handler.module.ListViews(ctx, claims.OrgID, list)
 	if err != nil {
 		render.Error(w, err)
 		return
 	}
 
-	render.Success(w, http.StatusOK, queries)
+	if list.Limit > 0 {
+		render.Success(w, http.StatusOK, &v3.SavedViewsPage{Views: views, NextCursor: nextCursor})
+		return
+	}
+
+	render.Success(w, http.StatusOK, views)
 }
@@ -15,6 +15,20 @@ import (
 	"github.com/SigNoz/signoz/pkg/valuer"
 )
 
+var (
+	// listQueryBuilder is the builder of the list queries of the saved views, which can be filtered and sorted on
+	// these fields only.
+	listQueryBuilder = sqlstore.MustNewListQueryBuilder(sqlstore.ListFields{
+		"id":         {Column: "id", Type: sqlstore.FieldTypeString},
+		"name":       {Column: "name", Type: sqlstore.FieldTypeString},
+		"category":   {Column: "category", Type: sqlstore.FieldTypeString},
+		"sourcePage": {Column: "source_page", Type: sqlstore.FieldTypeString},
+		"createdBy":  {Column: "created_by", Type: sqlstore.FieldTypeString},
+		"createdAt":  {Column: "created_at", Type: sqlstore.FieldTypeTime},
+		"updatedAt":  {Column: "updated_at", Type: sqlstore.FieldTypeTime},
+	}, "id")
+)
+
 type module struct {
 	sqlstore sqlstore.SQLStore
 }
@@ -23,16 +37,37 @@ func NewModule(sqlstore sqlstore.SQLStore) savedview.Module {
 	return &module{sqlstore: sqlstore}
 }
 
-func (module *module) GetViewsForFilters(ctx context.Context, orgID string, sourcePage string, name string, category string) ([]*v3.SavedView, error) {
+func (module *module) ListViews(ctx context.Context, orgID string, list *sqlstore.ListQuery) ([]*v3.SavedView, string, error) {
 	var views []types.SavedView
-	var err error
-	if len(category) == 0 {
-		err = module.sqlstore.BunDB().NewSelect().Model(&views).Where("org_id = ? AND source_page = ? AND name LIKE ?", orgID, sourcePage, "%"+name+"%").Scan(ctx)
-	} else {
-		err = module.sqlstore.BunDB().NewSelect().Model(&views).Where("org_id = ? AND source_page = ? AND category LIKE ? AND name LIKE ?", orgID, sourcePage, "%"+category+"%", "%"+name+"%").Scan(ctx)
+	query, err := listQueryBuilder.Apply(module.sqlstore.BunDB().NewSelect().Model(&views).Where("org_id = ?", orgID), list)
+	if err != nil {
+		return nil, "", err
 	}
+
+	if err := query.Scan(ctx); err != nil {
+		return nil, "", fmt.Errorf("error in getting saved views: %s", err.Error())
+	}
+
+	views, nextCursor, err := sqlstore.Paginate(listQueryBuilder, list, views, func(view types.SavedView, field string) any {
+		switch field {
+		case "name":
+			return view.Name
+		case "category":
+			return view.Category
+		case "sourcePage":
+			return view.SourcePage
+		case "createdBy":
+			return view.CreatedBy
+		case "createdAt":
+			return view.CreatedAt
+		case "updatedAt":
+			return view.UpdatedAt
+		default:
+			return view.ID.StringValue()
+		}
+	})
 	if err != nil {
-		return nil, fmt.Errorf("error in getting saved views: %s", err.Error())
+		return nil, "", err
 	}
 
 	var savedViews []*v3.SavedView
@@ -40,7 +75,7 @@ func (module *module) GetViewsForFilters(ctx context.Context, orgID string, sour
 		var compositeQuery v3.CompositeQuery
 		err = json.Unmarshal([]byte(view.Data), &compositeQuery)
 		if err != nil {
-			return nil, fmt.Errorf("error in unmarshalling explorer query data: %s", err.Error())
+			return nil, "", fmt.Errorf("error in unmarshalling explorer query data: %s", err.Error())
 		}
 		savedViews = append(savedViews, &v3.SavedView{
 			ID:             view.ID,
@@ -55,7 +90,7 @@ func (module *module) GetViewsForFilters(ctx context.Context, orgID string, sour
 			ExtraData:      view.ExtraData,
 		})
 	}
-	return savedViews, nil
+	return savedViews, nextCursor, nil
 }
 
 func (module *module) CreateView(ctx context.Context, orgID string, view v3.SavedView) (valuer.UUID, error) {
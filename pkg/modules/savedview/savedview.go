@@ -5,12 +5,14 @@ import (
 	"net/http"
 
 	v3 "github.com/SigNoz/signoz/pkg/query-service/model/v3"
+	"github.com/SigNoz/signoz/pkg/sqlstore"
 	"github.com/SigNoz/signoz/pkg/statsreporter"
 	"github.com/SigNoz/signoz/pkg/valuer"
 )
 
 type Module interface {
-	GetViewsForFilters(ctx context.Context, orgID string, sourcePage string, name string, category string) ([]*v3.SavedView, error)
+	// ListViews returns the page of the saved views of the list query and the cursor of the next page.
+	ListViews(ctx context.Context, orgID string, list *sqlstore.ListQuery) ([]*v3.SavedView, string, error)
 
 	CreateView(ctx context.Context, orgID string, view v3.SavedView) (valuer.UUID, error)
 
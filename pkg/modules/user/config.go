@@ -0,0 +1,38 @@
+package user
+
+import (
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/factory"
+)
+
+type Config struct {
+	// RefreshToken is the configuration of the refresh tokens of the users.
+	RefreshToken RefreshTokenConfig `mapstructure:"refresh_token"`
+}
+
+type RefreshTokenConfig struct {
+	// PurgeInterval is the interval at which the expired and the revoked refresh tokens are deleted.
+	PurgeInterval time.Duration `mapstructure:"purge_interval"`
+}
+
+func NewConfigFactory() factory.ConfigFactory {
+	return factory.NewConfigFactory(factory.MustNewName("user"), newConfig)
+}
+
+func newConfig() factory.Config {
+	return Config{
+		RefreshToken: RefreshTokenConfig{
+			PurgeInterval: time.Hour,
+		},
+	}
+}
+
+func (c Config) Validate() error {
+	if c.RefreshToken.PurgeInterval <= 0 {
+		return errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "refresh_token::purge_interval must be greater than 0, got %v", c.RefreshToken.PurgeInterval)
+	}
+
+	return nil
+}
@@ -152,13 +152,13 @@ func (h *handler) CreateBulkInvite(rw http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	_, err = h.module.CreateBulkInvite(ctx, claims.OrgID, claims.UserID, &req)
+	statuses, err := h.module.CreateBulkInviteWithStatus(ctx, claims.OrgID, claims.UserID, &req)
 	if err != nil {
 		render.Error(rw, err)
 		return
 	}
 
-	render.Success(rw, http.StatusCreated, nil)
+	render.Success(rw, http.StatusCreated, statuses)
 }
 
 func (h *handler) GetInvite(w http.ResponseWriter, r *http.Request) {
@@ -262,6 +262,23 @@ func (h *handler) ListUsers(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	pagination, err := types.NewPaginationFromRequest(r)
+	if err != nil {
+		render.Error(w, err)
+		return
+	}
+
+	if pagination != nil {
+		users, nextCursor, err := h.module.ListUsersPage(ctx, claims.OrgID, pagination)
+		if err != nil {
+			render.Error(w, err)
+			return
+		}
+
+		render.Success(w, http.StatusOK, &types.GettableUsersPage{Users: users, NextCursor: nextCursor})
+		return
+	}
+
 	users, err := h.module.ListUsers(ctx, claims.OrgID)
 	if err != nil {
 		render.Error(w, err)
@@ -466,15 +483,27 @@ func (h *handler) Login(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if req.RefreshToken == "" {
-		_, err := h.module.CanUsePassword(ctx, req.Email)
+	if req.RefreshToken != "" {
+		user, jwt, err := h.module.RefreshJWT(ctx, req.RefreshToken)
 		if err != nil {
 			render.Error(w, err)
 			return
 		}
+
+		render.Success(w, http.StatusOK, &types.GettableLoginResponse{
+			GettableUserJwt: jwt,
+			UserID:          user.ID.String(),
+		})
+		return
 	}
 
-	user, err := h.module.GetAuthenticatedUser(ctx, req.OrgID, req.Email, req.Password, req.RefreshToken)
+	_, err := h.module.CanUsePassword(ctx, req.Email)
+	if err != nil {
+		render.Error(w, err)
+		return
+	}
+
+	user, err := h.module.GetAuthenticatedUser(ctx, req.OrgID, req.Email, req.Password)
 	if err != nil {
 		render.Error(w, err)
 		return
@@ -542,11 +571,12 @@ func (h *handler) CreateAPIKey(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	apiKey, err := types.NewStorableAPIKey(
+	apiKey, token, err := types.NewStorableAPIKey(
 		req.Name,
 		userID,
 		req.Role,
 		req.ExpiresInDays,
+		req.Scopes,
 	)
 	if err != nil {
 		render.Error(w, err)
@@ -565,8 +595,11 @@ func (h *handler) CreateAPIKey(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// just corrected the status code, response is same,
-	render.Success(w, http.StatusCreated, createdApiKey)
+	// the plaintext token is only ever returned here, only its hash is stored
+	gettableAPIKey := types.NewGettableAPIKeyFromStorableAPIKey(createdApiKey)
+	gettableAPIKey.Token = token
+
+	render.Success(w, http.StatusCreated, gettableAPIKey)
 }
 
 func (h *handler) ListAPIKeys(w http.ResponseWriter, r *http.Request) {
@@ -814,6 +847,7 @@ func (h *handler) UpdateDomain(rw http.ResponseWriter, r *http.Request) {
 	req.ID = domainId
 	if err := req.Valid(nil); err != nil {
 		render.Error(rw, errors.Wrapf(err, errors.TypeInvalidInput, errors.CodeInvalidInput, "invalid request"))
+		return
 	}
 
 	err = h.module.UpdateDomain(ctx, &req)
@@ -0,0 +1,110 @@
+package impluser
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/SigNoz/signoz/pkg/modules/user"
+	"github.com/SigNoz/signoz/pkg/types"
+	"github.com/SigNoz/signoz/pkg/types/authtypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// bulkInviteModule invites the valid emails of a bulk invite. The other methods of the module are not implemented.
+type bulkInviteModule struct {
+	user.Module
+	requests []*types.PostableBulkInviteRequest
+}
+
+func (m *bulkInviteModule) CreateBulkInviteWithStatus(_ context.Context, _, _ string, bulkInvites *types.PostableBulkInviteRequest) ([]*types.GettableBulkInviteStatus, error) {
+	m.requests = append(m.requests, bulkInvites)
+
+	statuses := make([]*types.GettableBulkInviteStatus, 0, len(bulkInvites.Invites))
+	for _, invite := range bulkInvites.Invites {
+		if invite.Email == "" {
+			statuses = append(statuses, &types.GettableBulkInviteStatus{Email: invite.Email, Status: types.InviteStatusInvalid, Error: "invalid email"})
+			continue
+		}
+
+		newInvite, err := types.NewInvite("org", invite.Role.String(), invite.Name, invite.Email)
+		if err != nil {
+			return nil, err
+		}
+
+		statuses = append(statuses, &types.GettableBulkInviteStatus{Email: invite.Email, Status: types.InviteStatusInvited, ID: newInvite.ID.String()})
+	}
+
+	return statuses, nil
+}
+
+func TestHandlerCreateBulkInvite(t *testing.T) {
+	testCases := []struct {
+		name       string
+		body       string
+		statusCode int
+		statuses   []string
+	}{
+		{
+			name:       "Statuses",
+			body:       `{"invites":[{"email":"first@signoz.io","role":"VIEWER"},{"email":"","role":"VIEWER"}]}`,
+			statusCode: http.StatusCreated,
+			statuses:   []string{"invited", "invalid"},
+		},
+		{
+			name:       "NoInvites",
+			body:       `{"invites":[]}`,
+			statusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			module := &bulkInviteModule{}
+			handler := NewHandler(module)
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/invite/bulk", bytes.NewBufferString(tc.body))
+			req = req.WithContext(authtypes.NewContextWithClaims(req.Context(), authtypes.Claims{OrgID: valuer.GenerateUUID().String(), UserID: valuer.GenerateUUID().String()}))
+			rr := httptest.NewRecorder()
+
+			handler.CreateBulkInvite(rr, req)
+			require.Equal(t, tc.statusCode, rr.Code, rr.Body.String())
+			if tc.statusCode != http.StatusCreated {
+				assert.Empty(t, module.requests)
+				return
+			}
+
+			var response struct {
+				Data []map[string]any `json:"data"`
+			}
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+			require.Len(t, response.Data, len(tc.statuses))
+
+			for i, status := range response.Data {
+				assert.Equal(t, tc.statuses[i], status["status"])
+				// The token of an invite is only sent by email.
+				assert.NotContains(t, status, "token")
+				assert.NotContains(t, status, "invite")
+			}
+			assert.NotEmpty(t, response.Data[0]["id"])
+			assert.NotContains(t, response.Data[1], "id")
+		})
+	}
+}
+
+func TestHandlerCreateBulkInviteUnauthenticated(t *testing.T) {
+	module := &bulkInviteModule{}
+	handler := NewHandler(module)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/invite/bulk", bytes.NewBufferString(`{"invites":[{"email":"first@signoz.io","role":"VIEWER"}]}`))
+	rr := httptest.NewRecorder()
+
+	handler.CreateBulkInvite(rr, req)
+	assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	assert.Empty(t, module.requests)
+}
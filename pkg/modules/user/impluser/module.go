@@ -3,6 +3,7 @@ package impluser
 import (
 	"context"
 	"fmt"
+	"net/mail"
 	"net/url"
 	"slices"
 	"strings"
@@ -26,24 +27,26 @@ import (
 )
 
 type Module struct {
-	store     types.UserStore
-	jwt       *authtypes.JWT
-	emailing  emailing.Emailing
-	settings  factory.ScopedProviderSettings
-	orgSetter organization.Setter
-	analytics analytics.Analytics
+	store       types.UserStore
+	jwt         *authtypes.JWT
+	tokenIssuer *authtypes.TokenIssuer
+	emailing    emailing.Emailing
+	settings    factory.ScopedProviderSettings
+	orgSetter   organization.Setter
+	analytics   analytics.Analytics
 }
 
 // This module is a WIP, don't take inspiration from this.
-func NewModule(store types.UserStore, jwt *authtypes.JWT, emailing emailing.Emailing, providerSettings factory.ProviderSettings, orgSetter organization.Setter, analytics analytics.Analytics) user.Module {
+func NewModule(store types.UserStore, refreshTokenStore authtypes.RefreshTokenStore, jwt *authtypes.JWT, emailing emailing.Emailing, providerSettings factory.ProviderSettings, orgSetter organization.Setter, analytics analytics.Analytics) user.Module {
 	settings := factory.NewScopedProviderSettings(providerSettings, "github.com/SigNoz/signoz/pkg/modules/user/impluser")
 	return &Module{
-		store:     store,
-		jwt:       jwt,
-		emailing:  emailing,
-		settings:  settings,
-		orgSetter: orgSetter,
-		analytics: analytics,
+		store:       store,
+		jwt:         jwt,
+		tokenIssuer: authtypes.NewTokenIssuer(jwt, refreshTokenStore),
+		emailing:    emailing,
+		settings:    settings,
+		orgSetter:   orgSetter,
+		analytics:   analytics,
 	}
 }
 
@@ -93,24 +96,100 @@ func (m *Module) CreateBulkInvite(ctx context.Context, orgID, userID string, bul
 		return nil, err
 	}
 
-	// send telemetry event
-	for i := 0; i < len(invites); i++ {
+	m.sendInviteEmails(ctx, creator, invites)
+
+	return invites, nil
+}
+
+func (m *Module) CreateBulkInviteWithStatus(ctx context.Context, orgID, userID string, bulkInvites *types.PostableBulkInviteRequest) ([]*types.GettableBulkInviteStatus, error) {
+	creator, err := m.GetUserByID(ctx, orgID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// The existing members and invites are listed once rather than checked email by email.
+	members, err := m.ListUsers(ctx, orgID)
+	if err != nil && !errors.Ast(err, errors.TypeNotFound) {
+		return nil, err
+	}
+
+	existingInvites, err := m.ListInvite(ctx, orgID)
+	if err != nil && !errors.Ast(err, errors.TypeNotFound) {
+		return nil, err
+	}
+
+	taken := make(map[string]types.InviteStatus, len(members)+len(existingInvites))
+	for _, member := range members {
+		taken[strings.ToLower(member.Email)] = types.InviteStatusAlreadyMember
+	}
+	for _, existingInvite := range existingInvites {
+		taken[strings.ToLower(existingInvite.Email)] = types.InviteStatusAlreadyInvited
+	}
+
+	statuses := make([]*types.GettableBulkInviteStatus, 0, len(bulkInvites.Invites))
+	invites := make([]*types.Invite, 0, len(bulkInvites.Invites))
+	for _, invite := range bulkInvites.Invites {
+		status := &types.GettableBulkInviteStatus{Email: strings.TrimSpace(invite.Email)}
+		statuses = append(statuses, status)
+
+		if _, err := mail.ParseAddress(status.Email); err != nil {
+			status.Status, status.Error = types.InviteStatusInvalid, "invalid email"
+			continue
+		}
+
+		key := strings.ToLower(status.Email)
+		if takenStatus, ok := taken[key]; ok {
+			status.Status = takenStatus
+			continue
+		}
+
+		if _, err := types.NewRole(invite.Role.String()); err != nil {
+			status.Status, status.Error = types.InviteStatusInvalid, fmt.Sprintf("invalid role %q", invite.Role.String())
+			continue
+		}
+
+		newInvite, err := types.NewInvite(orgID, invite.Role.String(), invite.Name, status.Email)
+		if err != nil {
+			return nil, err
+		}
+		newInvite.InviteLink = fmt.Sprintf("%s/signup?token=%s", invite.FrontendBaseUrl, newInvite.Token)
+
+		// Later occurrences of an email in the same request are duplicates of the first one.
+		taken[key] = types.InviteStatusDuplicate
+		status.Status, status.ID = types.InviteStatusInvited, newInvite.ID.String()
+		invites = append(invites, newInvite)
+	}
+
+	if len(invites) == 0 {
+		return statuses, nil
+	}
+
+	// The invites are inserted by a single statement so that either all of them or none are created.
+	if err := m.store.CreateBulkInvite(ctx, invites); err != nil {
+		return nil, err
+	}
+
+	m.sendInviteEmails(ctx, creator, invites)
+
+	return statuses, nil
+}
+
+// sendInviteEmails sends the email of every invite. Failures are only logged.
+func (m *Module) sendInviteEmails(ctx context.Context, creator *types.GettableUser, invites []*types.Invite) {
+	for _, invite := range invites {
 		telemetry.GetInstance().SendEvent(telemetry.TELEMETRY_EVENT_USER_INVITATION_SENT, map[string]interface{}{
-			"invited user email": invites[i].Email,
+			"invited user email": invite.Email,
 		}, creator.Email, true, false)
 
-		if err := m.emailing.SendHTML(ctx, invites[i].Email, "You are invited to join a team in SigNoz", emailtypes.TemplateNameInvitationEmail, map[string]any{
-			"CustomerName": invites[i].Name,
+		if err := m.emailing.SendTemplate(ctx, invite.Email, emailtypes.TemplateNameInvitationEmail, map[string]any{
+			"CustomerName": invite.Name,
 			"InviterName":  creator.DisplayName,
 			"InviterEmail": creator.Email,
-			"Link":         fmt.Sprintf("%s/signup?token=%s", bulkInvites.Invites[i].FrontendBaseUrl, invites[i].Token),
+			"Link":         invite.InviteLink,
 		}); err != nil {
 			m.settings.Logger().ErrorContext(ctx, "failed to send email", "error", err)
 		}
-
 	}
-
-	return invites, nil
 }
 
 func (m *Module) ListInvite(ctx context.Context, orgID string) ([]*types.Invite, error) {
@@ -226,6 +305,19 @@ func (m *Module) ListUsers(ctx context.Context, orgID string) ([]*types.Gettable
 	return m.store.ListUsers(ctx, orgID)
 }
 
+func (m *Module) ListUsersPage(ctx context.Context, orgID string, pagination *types.Pagination) ([]*types.GettableUser, string, error) {
+	users, err := m.store.ListUsersPage(ctx, orgID, pagination)
+	if err != nil {
+		return nil, "", err
+	}
+
+	users, nextCursor := types.Paginate(pagination, users, func(user *types.GettableUser) types.Cursor {
+		return types.Cursor{CreatedAt: user.CreatedAt, ID: user.ID}
+	})
+
+	return users, nextCursor, nil
+}
+
 func (m *Module) UpdateUser(ctx context.Context, orgID string, id string, user *types.User) (*types.User, error) {
 	return m.store.UpdateUser(ctx, orgID, id, user)
 }
@@ -330,21 +422,7 @@ func (m *Module) UpdatePassword(ctx context.Context, userID string, password str
 	return m.store.UpdatePassword(ctx, userID, hashedPassword)
 }
 
-func (m *Module) GetAuthenticatedUser(ctx context.Context, orgID, email, password, refreshToken string) (*types.User, error) {
-	if refreshToken != "" {
-		// parse the refresh token
-		claims, err := m.jwt.Claims(refreshToken)
-		if err != nil {
-			return nil, err
-		}
-
-		user, err := m.store.GetUserByID(ctx, claims.OrgID, claims.UserID)
-		if err != nil {
-			return nil, err
-		}
-		return &user.User, nil
-	}
-
+func (m *Module) GetAuthenticatedUser(ctx context.Context, orgID, email, password string) (*types.User, error) {
 	var dbUser *types.User
 	// when the orgID is not provided we login if the user exists in just one org
 	users, err := m.store.GetUsersByEmail(ctx, email)
@@ -438,44 +516,73 @@ func (m *Module) GetJWTForUser(ctx context.Context, user *types.User) (types.Get
 		return types.GettableUserJwt{}, err
 	}
 
-	accessJwt, accessClaims, err := m.jwt.AccessToken(user.OrgID, user.ID.String(), user.Email, role)
+	tokenPair, err := m.tokenIssuer.NewTokenPair(ctx, user.OrgID, user.ID.String(), user.Email, role)
 	if err != nil {
 		return types.GettableUserJwt{}, err
 	}
 
-	refreshJwt, refreshClaims, err := m.jwt.RefreshToken(user.OrgID, user.ID.String(), user.Email, role)
+	return newGettableUserJwt(tokenPair), nil
+}
+
+func (m *Module) RefreshJWT(ctx context.Context, refreshToken string) (*types.User, types.GettableUserJwt, error) {
+	claims, err := m.jwt.Claims(refreshToken)
+	if err != nil {
+		return nil, types.GettableUserJwt{}, err
+	}
+
+	user, err := m.store.GetUserByID(ctx, claims.OrgID, claims.UserID)
 	if err != nil {
-		return types.GettableUserJwt{}, err
+		return nil, types.GettableUserJwt{}, err
 	}
 
-	return types.GettableUserJwt{
-		AccessJwt:        accessJwt,
-		RefreshJwt:       refreshJwt,
-		AccessJwtExpiry:  accessClaims.ExpiresAt.Unix(),
-		RefreshJwtExpiry: refreshClaims.ExpiresAt.Unix(),
-	}, nil
+	// The refreshed tokens carry the claims of the refresh token, a change of role requires a new login.
+	if user.Role != claims.Role.String() {
+		if err := m.tokenIssuer.RevokeFamily(ctx, refreshToken); err != nil {
+			return nil, types.GettableUserJwt{}, err
+		}
+
+		return nil, types.GettableUserJwt{}, errors.New(errors.TypeUnauthenticated, errors.CodeUnauthenticated, "role of the user has changed, please log in again")
+	}
+
+	tokenPair, err := m.tokenIssuer.Refresh(ctx, refreshToken)
+	if err != nil {
+		return nil, types.GettableUserJwt{}, err
+	}
+
+	return &user.User, newGettableUserJwt(tokenPair), nil
 }
 
-func (m *Module) CreateUserForSAMLRequest(ctx context.Context, email string) (*types.User, error) {
+func (m *Module) CreateUserForSAMLRequest(ctx context.Context, email, name string, role types.Role) (*types.User, error) {
 	// get auth domain from email domain
-	_, err := m.GetAuthDomainByEmail(ctx, email)
+	domain, err := m.GetAuthDomainByEmail(ctx, email)
 	if err != nil && !errors.Ast(err, errors.TypeNotFound) {
 		return nil, err
 	}
 
-	// get name from email
+	// get name from email when the auth provider does not send it
 	parts := strings.Split(email, "@")
 	if len(parts) < 2 {
 		return nil, errors.New(errors.TypeInvalidInput, errors.CodeInvalidInput, "invalid email format")
 	}
-	name := parts[0]
+	if name == "" {
+		name = parts[0]
+	}
 
-	defaultOrgID, err := m.store.GetDefaultOrgID(ctx)
-	if err != nil {
-		return nil, err
+	var orgID string
+	if domain != nil {
+		orgID = domain.OrgID
+	} else {
+		orgID, err = m.store.GetDefaultOrgID(ctx)
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	user, err := types.NewUser(name, email, types.RoleViewer.String(), defaultOrgID)
+	if role == "" {
+		role = types.RoleViewer
+	}
+
+	user, err := types.NewUser(name, email, role.String(), orgID)
 	if err != nil {
 		return nil, err
 	}
@@ -489,7 +596,7 @@ func (m *Module) CreateUserForSAMLRequest(ctx context.Context, email string) (*t
 
 }
 
-func (m *Module) PrepareSsoRedirect(ctx context.Context, redirectUri, email string, jwt *authtypes.JWT) (string, error) {
+func (m *Module) PrepareSsoRedirect(ctx context.Context, redirectUri, email, name string, role types.Role, jwt *authtypes.JWT) (string, error) {
 	users, err := m.GetUsersByEmail(ctx, email)
 	if err != nil {
 		m.settings.Logger().ErrorContext(ctx, "failed to get user with email received from auth provider", "error", err)
@@ -498,7 +605,7 @@ func (m *Module) PrepareSsoRedirect(ctx context.Context, redirectUri, email stri
 	user := &types.User{}
 
 	if len(users) == 0 {
-		newUser, err := m.CreateUserForSAMLRequest(ctx, email)
+		newUser, err := m.CreateUserForSAMLRequest(ctx, email, name, role)
 		user = newUser
 		if err != nil {
 			m.settings.Logger().ErrorContext(ctx, "failed to create user with email received from auth provider", "error", err)
@@ -506,6 +613,10 @@ func (m *Module) PrepareSsoRedirect(ctx context.Context, redirectUri, email stri
 		}
 	} else {
 		user = &users[0].User
+		if err := m.syncSsoRole(ctx, user, role); err != nil {
+			m.settings.Logger().ErrorContext(ctx, "failed to update the role of the user with the role received from auth provider", "error", err)
+			return "", err
+		}
 	}
 
 	tokenStore, err := m.GetJWTForUser(ctx, user)
@@ -521,6 +632,30 @@ func (m *Module) PrepareSsoRedirect(ctx context.Context, redirectUri, email stri
 		tokenStore.RefreshJwt), nil
 }
 
+// syncSsoRole updates the role of the user to the role received from the auth provider. The last admin of the
+// organization is never demoted so that the organization cannot be locked out by a mapping error.
+func (m *Module) syncSsoRole(ctx context.Context, user *types.User, role types.Role) error {
+	if role == "" || user.Role == role.String() {
+		return nil
+	}
+
+	if user.Role == types.RoleAdmin.String() {
+		admins, err := m.GetUsersByRoleInOrg(ctx, user.OrgID, types.RoleAdmin)
+		if err != nil {
+			return err
+		}
+
+		if len(admins) == 1 {
+			m.settings.Logger().WarnContext(ctx, "not demoting the last admin of the organization to the role received from auth provider", "user_id", user.ID.StringValue(), "role", role.String())
+			return nil
+		}
+	}
+
+	user.Role = role.String()
+	_, err := m.UpdateUser(ctx, user.OrgID, user.ID.StringValue(), user)
+	return err
+}
+
 func (m *Module) CanUsePassword(ctx context.Context, email string) (bool, error) {
 	domain, err := m.GetAuthDomainByEmail(ctx, email)
 	if err != nil && !errors.Ast(err, errors.TypeNotFound) {
@@ -651,3 +786,12 @@ func (m *Module) Collect(ctx context.Context, orgID valuer.UUID) (map[string]any
 
 	return map[string]any{"user.count": count}, nil
 }
+
+func newGettableUserJwt(tokenPair authtypes.TokenPair) types.GettableUserJwt {
+	return types.GettableUserJwt{
+		AccessJwt:        tokenPair.AccessToken,
+		AccessJwtExpiry:  tokenPair.AccessClaims.ExpiresAt.Unix(),
+		RefreshJwt:       tokenPair.RefreshToken,
+		RefreshJwtExpiry: tokenPair.RefreshClaims.ExpiresAt.Unix(),
+	}
+}
@@ -0,0 +1,24 @@
+package impluser
+
+import (
+	"context"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/factory"
+	"github.com/SigNoz/signoz/pkg/modules/user"
+	"github.com/SigNoz/signoz/pkg/types/authtypes"
+)
+
+// NewRefreshTokenPurgeJob returns the job deleting the expired and the revoked refresh tokens. The rotated refresh
+// tokens are kept until they expire to detect their reuse. It runs on a single replica at a time.
+func NewRefreshTokenPurgeJob(refreshTokenStore authtypes.RefreshTokenStore, config user.Config) factory.Job {
+	return factory.NewJob(
+		factory.MustNewName("refreshtokenpurger"),
+		config.RefreshToken.PurgeInterval,
+		func(ctx context.Context) error {
+			return refreshTokenStore.DeleteExpired(ctx, time.Now())
+		},
+		factory.WithJitter(config.RefreshToken.PurgeInterval/10),
+		factory.WithSingleton(),
+	)
+}
@@ -0,0 +1,103 @@
+package impluser
+
+import (
+	"context"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/sqlstore"
+	"github.com/SigNoz/signoz/pkg/types/authtypes"
+)
+
+type refreshTokenStore struct {
+	sqlstore sqlstore.SQLStore
+}
+
+func NewRefreshTokenStore(sqlstore sqlstore.SQLStore) authtypes.RefreshTokenStore {
+	return &refreshTokenStore{sqlstore: sqlstore}
+}
+
+// Create implements authtypes.RefreshTokenStore.
+func (store *refreshTokenStore) Create(ctx context.Context, refreshToken *authtypes.StorableRefreshToken) error {
+	_, err := store.sqlstore.BunDBCtx(ctx).NewInsert().
+		Model(refreshToken).
+		Exec(ctx)
+	if err != nil {
+		return store.sqlstore.WrapAlreadyExistsErrf(err, errors.CodeAlreadyExists, "refresh token with id: %s already exists", refreshToken.ID)
+	}
+
+	return nil
+}
+
+// Get implements authtypes.RefreshTokenStore.
+func (store *refreshTokenStore) Get(ctx context.Context, id string) (*authtypes.StorableRefreshToken, error) {
+	refreshToken := new(authtypes.StorableRefreshToken)
+	err := store.sqlstore.BunDBCtx(ctx).NewSelect().
+		Model(refreshToken).
+		Where("id = ?", id).
+		Scan(ctx)
+	if err != nil {
+		return nil, store.sqlstore.WrapNotFoundErrf(err, authtypes.ErrCodeRefreshTokenNotFound, "refresh token with id: %s does not exist", id)
+	}
+
+	return refreshToken, nil
+}
+
+// Rotate implements authtypes.RefreshTokenStore.
+func (store *refreshTokenStore) Rotate(ctx context.Context, id string) error {
+	now := time.Now()
+	// The conditions make the rotation atomic, only one of two concurrent rotations of a token succeeds.
+	res, err := store.sqlstore.BunDBCtx(ctx).NewUpdate().
+		Model(new(authtypes.StorableRefreshToken)).
+		Set("rotated_at = ?", now).
+		Set("updated_at = ?", now).
+		Where("id = ?", id).
+		Where("rotated_at IS NULL").
+		Where("revoked_at IS NULL").
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return errors.Newf(errors.TypeAlreadyExists, authtypes.ErrCodeRefreshTokenReused, "refresh token with id: %s has already been used", id)
+	}
+
+	return nil
+}
+
+// RevokeFamily implements authtypes.RefreshTokenStore.
+func (store *refreshTokenStore) RevokeFamily(ctx context.Context, familyID string) error {
+	now := time.Now()
+	_, err := store.sqlstore.BunDBCtx(ctx).NewUpdate().
+		Model(new(authtypes.StorableRefreshToken)).
+		Set("revoked_at = ?", now).
+		Set("updated_at = ?", now).
+		Where("family_id = ?", familyID).
+		Where("revoked_at IS NULL").
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// DeleteExpired implements authtypes.RefreshTokenStore.
+func (store *refreshTokenStore) DeleteExpired(ctx context.Context, before time.Time) error {
+	_, err := store.sqlstore.BunDBCtx(ctx).NewDelete().
+		Model(new(authtypes.StorableRefreshToken)).
+		WhereOr("expires_at < ?", before).
+		WhereOr("revoked_at IS NOT NULL").
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
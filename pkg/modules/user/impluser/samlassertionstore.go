@@ -0,0 +1,51 @@
+package impluser
+
+import (
+	"context"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/sqlstore"
+	"github.com/SigNoz/signoz/pkg/types/ssotypes"
+)
+
+type samlAssertionStore struct {
+	sqlstore sqlstore.SQLStore
+}
+
+func NewSamlAssertionStore(sqlstore sqlstore.SQLStore) ssotypes.SamlAssertionStore {
+	return &samlAssertionStore{sqlstore: sqlstore}
+}
+
+// Consume implements ssotypes.SamlAssertionStore.
+func (store *samlAssertionStore) Consume(ctx context.Context, assertion *ssotypes.StorableSamlAssertion) error {
+	return store.sqlstore.RunInTxCtx(ctx, nil, func(ctx context.Context) error {
+		// The expired assertions cannot be replayed anymore, they are deleted along the way.
+		_, err := store.sqlstore.BunDBCtx(ctx).NewDelete().
+			Model(new(ssotypes.StorableSamlAssertion)).
+			Where("expires_at < ?", assertion.CreatedAt).
+			Exec(ctx)
+		if err != nil {
+			return err
+		}
+
+		// The insert is the check, only one of two concurrent consumptions of an assertion inserts it.
+		res, err := store.sqlstore.BunDBCtx(ctx).NewInsert().
+			Model(assertion).
+			On("CONFLICT (id) DO NOTHING").
+			Exec(ctx)
+		if err != nil {
+			return err
+		}
+
+		rows, err := res.RowsAffected()
+		if err != nil {
+			return err
+		}
+
+		if rows == 0 {
+			return errors.Newf(errors.TypeAlreadyExists, ssotypes.ErrCodeSamlAssertionReplayed, "saml assertion %q was already consumed", assertion.ID)
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,35 @@
+package impluser
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/sqlstore/sqlstoretest"
+	"github.com/SigNoz/signoz/pkg/types/ssotypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSamlAssertionStoreConsume(t *testing.T) {
+	ctx := context.Background()
+	sqlstore := sqlstoretest.NewSQLite(t)
+	_, err := sqlstore.BunDB().NewCreateTable().Model(new(ssotypes.StorableSamlAssertion)).Exec(ctx)
+	require.NoError(t, err)
+
+	store := NewSamlAssertionStore(sqlstore)
+	now := time.Now()
+
+	require.NoError(t, store.Consume(ctx, &ssotypes.StorableSamlAssertion{ID: "assertion", OrgID: "org", ExpiresAt: now.Add(time.Minute), CreatedAt: now}))
+
+	// The replay is rejected until the assertion expires.
+	err = store.Consume(ctx, &ssotypes.StorableSamlAssertion{ID: "assertion", OrgID: "org", ExpiresAt: now.Add(time.Minute), CreatedAt: now.Add(time.Second)})
+	assert.True(t, errors.Asc(err, ssotypes.ErrCodeSamlAssertionReplayed))
+
+	// The expired assertions are deleted by the next consumption.
+	require.NoError(t, store.Consume(ctx, &ssotypes.StorableSamlAssertion{ID: "other", OrgID: "org", ExpiresAt: now.Add(3 * time.Minute), CreatedAt: now.Add(2 * time.Minute)}))
+	count, err := sqlstore.BunDB().NewSelect().Model(new(ssotypes.StorableSamlAssertion)).Count(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+}
@@ -293,6 +293,23 @@ func (store *store) ListUsers(ctx context.Context, orgID string) ([]*types.Getta
 		return nil, store.sqlstore.WrapNotFoundErrf(err, types.ErrUserNotFound, "users with org id: %s does not exist", orgID)
 	}
 
+	return store.newGettableUsers(ctx, orgID, users)
+}
+
+func (store *store) ListUsersPage(ctx context.Context, orgID string, pagination *types.Pagination) ([]*types.GettableUser, error) {
+	users := []*types.User{}
+	err := pagination.Apply(store.sqlstore.BunDB().NewSelect().
+		Model(&users).
+		Where("org_id = ?", orgID)).
+		Scan(ctx)
+	if err != nil {
+		return nil, store.sqlstore.WrapNotFoundErrf(err, types.ErrUserNotFound, "users with org id: %s does not exist", orgID)
+	}
+
+	return store.newGettableUsers(ctx, orgID, users)
+}
+
+func (store *store) newGettableUsers(ctx context.Context, orgID string, users []*types.User) ([]*types.GettableUser, error) {
 	// remove this in next PR
 	orgName, err := store.getOrgNameByID(ctx, orgID)
 	if err != nil {
@@ -514,7 +531,7 @@ func (store *store) CreateAPIKey(ctx context.Context, apiKey *types.StorableAPIK
 		Model(apiKey).
 		Exec(ctx)
 	if err != nil {
-		return store.sqlstore.WrapAlreadyExistsErrf(err, types.ErrAPIKeyAlreadyExists, "API key with token: %s already exists", apiKey.Token)
+		return store.sqlstore.WrapAlreadyExistsErrf(err, types.ErrAPIKeyAlreadyExists, "API key with prefix: %s already exists", apiKey.TokenPrefix)
 	}
 
 	return nil
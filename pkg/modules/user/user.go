@@ -15,6 +15,9 @@ import (
 type Module interface {
 	// invite
 	CreateBulkInvite(ctx context.Context, orgID, userID string, bulkInvites *types.PostableBulkInviteRequest) ([]*types.Invite, error)
+	// CreateBulkInviteWithStatus invites every valid email which is neither a member nor invited yet and returns
+	// the status of every email in the order of the request. Only errors affecting the whole batch are returned.
+	CreateBulkInviteWithStatus(ctx context.Context, orgID, userID string, bulkInvites *types.PostableBulkInviteRequest) ([]*types.GettableBulkInviteStatus, error)
 	ListInvite(ctx context.Context, orgID string) ([]*types.Invite, error)
 	DeleteInvite(ctx context.Context, orgID string, id valuer.UUID) error
 	GetInviteByToken(ctx context.Context, token string) (*types.GettableInvite, error)
@@ -28,17 +31,24 @@ type Module interface {
 	GetUserByEmailInOrg(ctx context.Context, orgID string, email string) (*types.GettableUser, error)
 	GetUsersByRoleInOrg(ctx context.Context, orgID string, role types.Role) ([]*types.GettableUser, error)
 	ListUsers(ctx context.Context, orgID string) ([]*types.GettableUser, error)
+	// ListUsersPage lists a page of the users of the organization and returns the cursor of the next page.
+	ListUsersPage(ctx context.Context, orgID string, pagination *types.Pagination) ([]*types.GettableUser, string, error)
 	UpdateUser(ctx context.Context, orgID string, id string, user *types.User) (*types.User, error)
 	DeleteUser(ctx context.Context, orgID string, id string) error
 
 	// login
-	GetAuthenticatedUser(ctx context.Context, orgID, email, password, refreshToken string) (*types.User, error)
+	GetAuthenticatedUser(ctx context.Context, orgID, email, password string) (*types.User, error)
 	GetJWTForUser(ctx context.Context, user *types.User) (types.GettableUserJwt, error)
-	CreateUserForSAMLRequest(ctx context.Context, email string) (*types.User, error)
+	// RefreshJWT rotates the refresh token and returns a new pair of tokens for the user.
+	RefreshJWT(ctx context.Context, refreshToken string) (*types.User, types.GettableUserJwt, error)
+	CreateUserForSAMLRequest(ctx context.Context, email, name string, role types.Role) (*types.User, error)
 	LoginPrecheck(ctx context.Context, orgID, email, sourceUrl string) (*types.GettableLoginPrecheck, error)
 
 	// sso
-	PrepareSsoRedirect(ctx context.Context, redirectUri, email string, jwt *authtypes.JWT) (string, error)
+	// PrepareSsoRedirect logs in the user received from the auth provider, creating it when needed, and returns the
+	// url redirecting it with its tokens. A non empty name is the name of the user created, a non empty role is the role
+	// of the user managed by the auth provider.
+	PrepareSsoRedirect(ctx context.Context, redirectUri, email, name string, role types.Role, jwt *authtypes.JWT) (string, error)
 	CanUsePassword(ctx context.Context, email string) (bool, error)
 
 	// password
@@ -0,0 +1,39 @@
+package otlpreceiver
+
+import (
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/factory"
+)
+
+type Config struct {
+	// Traces configures the receiver of the traces.
+	Traces TracesConfig `mapstructure:"traces"`
+}
+
+type TracesConfig struct {
+	// Enabled turns on the receiver of the traces.
+	Enabled bool `mapstructure:"enabled"`
+	// MaxRequestSize is the maximum size in bytes of an export request, once decompressed.
+	MaxRequestSize int64 `mapstructure:"max_request_size"`
+}
+
+func NewConfigFactory() factory.ConfigFactory {
+	return factory.NewConfigFactory(factory.MustNewName("otlpreceiver"), newConfig)
+}
+
+func newConfig() factory.Config {
+	return Config{
+		Traces: TracesConfig{
+			Enabled:        false,
+			MaxRequestSize: 16 << 20,
+		},
+	}
+}
+
+func (c Config) Validate() error {
+	if c.Traces.MaxRequestSize <= 0 {
+		return errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "traces::max_request_size must be greater than 0")
+	}
+
+	return nil
+}
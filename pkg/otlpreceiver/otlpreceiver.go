@@ -0,0 +1,146 @@
+package otlpreceiver
+
+import (
+	"compress/gzip"
+	"context"
+	"io"
+	"mime"
+	"net/http"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/factory"
+	"github.com/SigNoz/signoz/pkg/http/render"
+	"github.com/SigNoz/signoz/pkg/telemetrystore"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
+)
+
+const (
+	contentTypeProtobuf string = "application/x-protobuf"
+	contentTypeJSON     string = "application/json"
+)
+
+var (
+	ErrCodeOTLPReceiverDisabled = errors.MustNewCode("otlp_receiver_disabled")
+	ErrCodeInvalidExportRequest = errors.MustNewCode("invalid_export_request")
+)
+
+// PartialSuccess is the outcome of an export request of which some of the items were rejected, as defined by the OTLP
+// specification. The rejected items must not be retried by the clients.
+type PartialSuccess struct {
+	// Rejected is the number of items which were rejected.
+	Rejected int64
+	// Message explains why the items were rejected.
+	Message string
+}
+
+// Receiver receives the telemetry exported over OTLP/HTTP and writes it to the telemetry store.
+type Receiver struct {
+	config Config
+	traces *tracesWriter
+}
+
+func New(providerSettings factory.ProviderSettings, config Config, telemetryStore telemetrystore.TelemetryStore) (*Receiver, error) {
+	settings := factory.NewScopedProviderSettings(providerSettings, "github.com/SigNoz/signoz/pkg/otlpreceiver")
+
+	traces, err := newTracesWriter(settings, telemetryStore)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Receiver{config: config, traces: traces}, nil
+}
+
+// ExportTraces handles a protobuf or JSON encoded ExportTraceServiceRequest. It responds with an
+// ExportTraceServiceResponse of the same encoding, whose partial success holds the spans which were rejected.
+func (receiver *Receiver) ExportTraces(rw http.ResponseWriter, req *http.Request) {
+	if !receiver.config.Traces.Enabled {
+		render.Error(rw, errors.New(errors.TypeUnsupported, ErrCodeOTLPReceiverDisabled, "the otlp receiver of the traces is disabled"))
+		return
+	}
+
+	contentType, body, err := readRequest(req, receiver.config.Traces.MaxRequestSize)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	request := ptraceotlp.NewExportRequest()
+	if contentType == contentTypeProtobuf {
+		err = request.UnmarshalProto(body)
+	} else {
+		err = request.UnmarshalJSON(body)
+	}
+	if err != nil {
+		render.Error(rw, errors.Wrapf(err, errors.TypeInvalidInput, ErrCodeInvalidExportRequest, "failed to unmarshal export request"))
+		return
+	}
+
+	partialSuccess, err := receiver.traces.Write(req.Context(), request.Traces(), len(body))
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	response := ptraceotlp.NewExportResponse()
+	if partialSuccess.Rejected > 0 {
+		response.PartialSuccess().SetRejectedSpans(partialSuccess.Rejected)
+		response.PartialSuccess().SetErrorMessage(partialSuccess.Message)
+	}
+
+	var encoded []byte
+	if contentType == contentTypeProtobuf {
+		encoded, err = response.MarshalProto()
+	} else {
+		encoded, err = response.MarshalJSON()
+	}
+	if err != nil {
+		render.Error(rw, errors.Wrapf(err, errors.TypeInternal, errors.CodeInternal, "failed to marshal export response"))
+		return
+	}
+
+	rw.Header().Set("Content-Type", contentType)
+	rw.WriteHeader(http.StatusOK)
+	_, _ = rw.Write(encoded)
+}
+
+// ConsumeTraces writes the traces generated by signoz itself, looped back by the instrumentation whether the receiver
+// is enabled or not. The rejected spans are accounted by the metrics of the receiver.
+func (receiver *Receiver) ConsumeTraces(ctx context.Context, traces ptrace.Traces) error {
+	_, err := receiver.traces.Write(ctx, traces, (&ptrace.ProtoMarshaler{}).TracesSize(traces))
+	return err
+}
+
+// readRequest reads the body of an export request, decompressing it if needed, and returns it along with its
+// content type.
+func readRequest(req *http.Request, maxRequestSize int64) (string, []byte, error) {
+	contentType, _, err := mime.ParseMediaType(req.Header.Get("Content-Type"))
+	if err != nil || (contentType != contentTypeProtobuf && contentType != contentTypeJSON) {
+		return "", nil, errors.Newf(errors.TypeInvalidInput, ErrCodeInvalidExportRequest, "content type must be %s or %s, got %q", contentTypeProtobuf, contentTypeJSON, req.Header.Get("Content-Type"))
+	}
+
+	var body io.Reader = req.Body
+	switch req.Header.Get("Content-Encoding") {
+	case "", "identity":
+	case "gzip":
+		reader, err := gzip.NewReader(req.Body)
+		if err != nil {
+			return "", nil, errors.Wrapf(err, errors.TypeInvalidInput, ErrCodeInvalidExportRequest, "failed to decompress export request")
+		}
+		defer reader.Close() //nolint:errcheck
+		body = reader
+	default:
+		return "", nil, errors.Newf(errors.TypeInvalidInput, ErrCodeInvalidExportRequest, "content encoding %q is not supported", req.Header.Get("Content-Encoding"))
+	}
+
+	buf, err := io.ReadAll(io.LimitReader(body, maxRequestSize+1))
+	if err != nil {
+		return "", nil, errors.Wrapf(err, errors.TypeInvalidInput, ErrCodeInvalidExportRequest, "failed to read export request")
+	}
+
+	if int64(len(buf)) > maxRequestSize {
+		return "", nil, errors.Newf(errors.TypeInvalidInput, ErrCodeInvalidExportRequest, "export request is larger than %d bytes", maxRequestSize)
+	}
+
+	return contentType, buf, nil
+}
@@ -0,0 +1,248 @@
+package otlpreceiver
+
+import (
+	"bytes"
+	"compress/gzip"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/SigNoz/signoz/pkg/factory/factorytest"
+	"github.com/SigNoz/signoz/pkg/telemetrystore"
+	"github.com/SigNoz/signoz/pkg/telemetrystore/telemetrystoretest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
+)
+
+func newTestReceiver(t *testing.T, config Config, telemetryStoreConfig telemetrystore.Config) (*Receiver, *telemetrystoretest.Provider) {
+	telemetryStore := telemetrystoretest.New(telemetryStoreConfig, sqlmock.QueryMatcherEqual)
+
+	receiver, err := New(factorytest.NewSettings(), config, telemetryStore)
+	require.NoError(t, err)
+
+	return receiver, telemetryStore
+}
+
+// newTestTraces returns traces with a valid span, and an invalid one without a trace id if invalid is set.
+func newTestTraces(invalid bool) ptrace.Traces {
+	start := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	traces := ptrace.NewTraces()
+	resourceSpans := traces.ResourceSpans().AppendEmpty()
+	resourceSpans.Resource().Attributes().PutStr("service.name", "checkout")
+	spans := resourceSpans.ScopeSpans().AppendEmpty().Spans()
+
+	span := spans.AppendEmpty()
+	span.SetName("GET /cart")
+	span.SetKind(ptrace.SpanKindClient)
+	span.SetTraceID(pcommon.TraceID{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16})
+	span.SetSpanID(pcommon.SpanID{1, 2, 3, 4, 5, 6, 7, 8})
+	span.SetParentSpanID(pcommon.SpanID{8, 7, 6, 5, 4, 3, 2, 1})
+	span.SetStartTimestamp(pcommon.NewTimestampFromTime(start))
+	span.SetEndTimestamp(pcommon.NewTimestampFromTime(start.Add(time.Second)))
+	span.Attributes().PutStr("http.request.method", "GET")
+	span.Attributes().PutStr("url.full", "https://cart.acme.com/cart")
+	span.Attributes().PutInt("http.response.status_code", 500)
+	span.Status().SetCode(ptrace.StatusCodeError)
+	exception := span.Events().AppendEmpty()
+	exception.SetName("exception")
+	exception.Attributes().PutStr("exception.type", "ConnectionError")
+	exception.Attributes().PutStr("exception.message", "connection refused")
+
+	if invalid {
+		span := spans.AppendEmpty()
+		span.SetName("GET /orders")
+		span.SetSpanID(pcommon.SpanID{2, 2, 3, 4, 5, 6, 7, 8})
+		span.SetStartTimestamp(pcommon.NewTimestampFromTime(start))
+		span.SetEndTimestamp(pcommon.NewTimestampFromTime(start.Add(time.Second)))
+	}
+
+	return traces
+}
+
+func expectWrite(telemetryStore *telemetrystoretest.Provider) {
+	resources := telemetryStore.Mock().ExpectPrepareBatch(insertResourcesQuery)
+	resources.ExpectAppend()
+	resources.ExpectSend()
+
+	spans := telemetryStore.Mock().ExpectPrepareBatch(insertSpansQuery)
+	spans.ExpectAppend()
+	spans.ExpectSend()
+
+	errors := telemetryStore.Mock().ExpectPrepareBatch(insertErrorsQuery)
+	errors.ExpectAppend()
+	errors.ExpectSend()
+
+	attributes := telemetryStore.Mock().ExpectPrepareBatch(insertAttributesQuery)
+	attributes.ExpectAppend()
+	attributes.ExpectSend()
+
+	attributeKeys := telemetryStore.Mock().ExpectPrepareBatch(insertAttributeKeysQuery)
+	attributeKeys.ExpectAppend()
+	attributeKeys.ExpectSend()
+}
+
+func TestReceiverExportTraces(t *testing.T) {
+	config := Config{Traces: TracesConfig{Enabled: true, MaxRequestSize: 1 << 20}}
+
+	t.Run("Disabled", func(t *testing.T) {
+		receiver, _ := newTestReceiver(t, Config{Traces: TracesConfig{Enabled: false, MaxRequestSize: 1 << 20}}, telemetrystore.Config{Provider: "clickhouse"})
+
+		rw := httptest.NewRecorder()
+		receiver.ExportTraces(rw, httptest.NewRequest(http.MethodPost, "/", nil))
+		assert.Equal(t, http.StatusNotImplemented, rw.Code)
+	})
+
+	t.Run("Protobuf", func(t *testing.T) {
+		receiver, telemetryStore := newTestReceiver(t, config, telemetrystore.Config{Provider: "clickhouse"})
+		expectWrite(telemetryStore)
+
+		body, err := ptraceotlp.NewExportRequestFromTraces(newTestTraces(false)).MarshalProto()
+		require.NoError(t, err)
+
+		req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/x-protobuf")
+		rw := httptest.NewRecorder()
+		receiver.ExportTraces(rw, req)
+
+		require.Equal(t, http.StatusOK, rw.Code)
+		assert.Equal(t, "application/x-protobuf", rw.Header().Get("Content-Type"))
+
+		response := ptraceotlp.NewExportResponse()
+		require.NoError(t, response.UnmarshalProto(rw.Body.Bytes()))
+		assert.Equal(t, int64(0), response.PartialSuccess().RejectedSpans())
+		assert.NoError(t, telemetryStore.Mock().ExpectationsWereMet())
+	})
+
+	t.Run("JSONPartialSuccess", func(t *testing.T) {
+		receiver, telemetryStore := newTestReceiver(t, config, telemetrystore.Config{Provider: "clickhouse"})
+		expectWrite(telemetryStore)
+
+		body, err := ptraceotlp.NewExportRequestFromTraces(newTestTraces(true)).MarshalJSON()
+		require.NoError(t, err)
+
+		var compressed bytes.Buffer
+		gz := gzip.NewWriter(&compressed)
+		_, err = gz.Write(body)
+		require.NoError(t, err)
+		require.NoError(t, gz.Close())
+
+		req := httptest.NewRequest(http.MethodPost, "/", &compressed)
+		req.Header.Set("Content-Type", "application/json; charset=utf-8")
+		req.Header.Set("Content-Encoding", "gzip")
+		rw := httptest.NewRecorder()
+		receiver.ExportTraces(rw, req)
+
+		require.Equal(t, http.StatusOK, rw.Code)
+		assert.Equal(t, "application/json", rw.Header().Get("Content-Type"))
+
+		response := ptraceotlp.NewExportResponse()
+		require.NoError(t, response.UnmarshalJSON(rw.Body.Bytes()))
+		assert.Equal(t, int64(1), response.PartialSuccess().RejectedSpans())
+		assert.Contains(t, response.PartialSuccess().ErrorMessage(), "empty trace id")
+		assert.NoError(t, telemetryStore.Mock().ExpectationsWereMet())
+	})
+
+	t.Run("Limited", func(t *testing.T) {
+		receiver, telemetryStore := newTestReceiver(t, config, telemetrystore.Config{
+			Provider:  "clickhouse",
+			Ingestion: telemetrystore.IngestionConfig{Enabled: true, Window: time.Second, Default: telemetrystore.IngestionLimit{BytesPerSecond: 1}},
+		})
+
+		body, err := ptraceotlp.NewExportRequestFromTraces(newTestTraces(false)).MarshalProto()
+		require.NoError(t, err)
+
+		req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/x-protobuf")
+		rw := httptest.NewRecorder()
+		receiver.ExportTraces(rw, req)
+
+		assert.Equal(t, http.StatusTooManyRequests, rw.Code)
+		assert.NoError(t, telemetryStore.Mock().ExpectationsWereMet())
+	})
+
+	t.Run("InvalidRequest", func(t *testing.T) {
+		receiver, _ := newTestReceiver(t, config, telemetrystore.Config{Provider: "clickhouse"})
+
+		testCases := []struct {
+			name        string
+			contentType string
+			body        []byte
+		}{
+			{name: "ContentType", contentType: "text/plain", body: []byte("{}")},
+			{name: "Body", contentType: "application/x-protobuf", body: []byte("not a request")},
+			{name: "TooLarge", contentType: "application/json", body: bytes.Repeat([]byte(" "), 1<<20+1)},
+		}
+
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(tc.body))
+				req.Header.Set("Content-Type", tc.contentType)
+				rw := httptest.NewRecorder()
+				receiver.ExportTraces(rw, req)
+
+				assert.Equal(t, http.StatusBadRequest, rw.Code)
+			})
+		}
+	})
+}
+
+func TestNewSpanRow(t *testing.T) {
+	traces := newTestTraces(false)
+	span := traces.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0)
+
+	row, err := newSpanRow(1735725600, "fingerprint", span, map[string]string{"service.name": "checkout"})
+	require.NoError(t, err)
+	require.Len(t, row, 31)
+
+	assert.Equal(t, "0102030405060708090a0b0c0d0e0f10", row[3])
+	assert.Equal(t, "0102030405060708", row[4])
+	assert.Equal(t, "0807060504030201", row[6])
+	assert.Equal(t, uint64(time.Second), row[11])
+	assert.Equal(t, `[{"traceId":"0102030405060708090a0b0c0d0e0f10","spanId":"0807060504030201","refType":"CHILD_OF"}]`, row[20])
+	assert.Equal(t, []any{"500", "cart.acme.com", "https://cart.acme.com/cart", "GET", "GET", "", "", "", true, "unknown"}, row[21:])
+}
+
+func TestNewErrorRows(t *testing.T) {
+	traces := newTestTraces(false)
+	span := traces.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0)
+	span.Events().AppendEmpty().SetName("retry")
+
+	rows := newErrorRows(span, "checkout", map[string]string{"service.name": "checkout"})
+	require.Len(t, rows, 1)
+	require.Len(t, rows[0], 11)
+
+	assert.Len(t, rows[0][1], 32)
+	assert.Equal(t, "f6d19d8d6977bba1d18b055d43b81c11", rows[0][2])
+	assert.Equal(t, []any{"0102030405060708090a0b0c0d0e0f10", "0102030405060708", "checkout", "ConnectionError", "connection refused", "", false}, rows[0][3:10])
+}
+
+func TestAttributesAddSpan(t *testing.T) {
+	traces := newTestTraces(false)
+	resourceSpans := traces.ResourceSpans().At(0)
+	span := resourceSpans.ScopeSpans().At(0).Spans().At(0)
+	span.Attributes().PutStr("http.request.body", strings.Repeat("a", maxAttributeValueLength+1))
+
+	attrs := attributes{keys: map[attributeKey]struct{}{}, values: map[attributeValue]struct{}{}}
+	attrs.addSpan(span, resourceSpans.Resource().Attributes())
+
+	assert.Equal(t, map[attributeKey]struct{}{
+		{key: "http.request.method", tagType: "tag", dataType: "string"}:        {},
+		{key: "url.full", tagType: "tag", dataType: "string"}:                   {},
+		{key: "http.response.status_code", tagType: "tag", dataType: "float64"}: {},
+		{key: "http.request.body", tagType: "tag", dataType: "string"}:          {},
+		{key: "service.name", tagType: "resource", dataType: "string"}:          {},
+	}, attrs.keys)
+
+	bucket := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC).UnixMilli()
+	assert.Contains(t, attrs.values, attributeValue{attributeKey: attributeKey{key: "http.response.status_code", tagType: "tag", dataType: "float64"}, bucket: bucket, numberValue: 500})
+	assert.Contains(t, attrs.values, attributeValue{attributeKey: attributeKey{key: "name", tagType: "spanfield", dataType: "string"}, bucket: bucket, stringValue: "GET /cart"})
+	// The values too long to be suggested are not written, only their key.
+	assert.Len(t, attrs.values, 4+5)
+}
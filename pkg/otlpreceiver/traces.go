@@ -0,0 +1,612 @@
+package otlpreceiver
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/SigNoz/signoz-otel-collector/utils/fingerprint"
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/factory"
+	"github.com/SigNoz/signoz/pkg/querybuilder/resourcefilter"
+	"github.com/SigNoz/signoz/pkg/telemetrystore"
+	"github.com/SigNoz/signoz/pkg/telemetrytraces"
+	"github.com/SigNoz/signoz/pkg/types/authtypes"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+const (
+	// resourceBucketSeconds is the size of the time buckets of the spans and of their resources.
+	resourceBucketSeconds int64 = 1800
+
+	// hasIsRemoteMask and isRemoteMask are the bits of the flags of a span telling whether its parent is remote.
+	hasIsRemoteMask uint32 = 0x00000100
+	isRemoteMask    uint32 = 0x00000200
+
+	rejectReasonInvalid string = "invalid"
+	rejectReasonLimited string = "limited"
+	rejectReasonStore   string = "store"
+
+	// attributeBucketMillis is the size of the time buckets of the values of the attributes.
+	attributeBucketMillis int64 = 3600000
+
+	// maxAttributeValueLength is the length above which the values of the attributes are not written, only their key.
+	maxAttributeValueLength int = 256
+
+	// unknownServiceName is the service name of the errors of the resources without one.
+	unknownServiceName string = "<nil-service-name>"
+)
+
+var (
+	insertSpansQuery = fmt.Sprintf(
+		"INSERT INTO %s.%s (ts_bucket_start, resource_fingerprint, timestamp, trace_id, span_id, trace_state, parent_span_id, flags, name, kind, kind_string, duration_nano, status_code, status_message, status_code_string, attributes_string, attributes_number, attributes_bool, resources_string, events, links, response_status_code, external_http_url, http_url, external_http_method, http_method, http_host, db_name, db_operation, has_error, is_remote)",
+		telemetrytraces.DBName, telemetrytraces.SpanIndexV3TableName,
+	)
+	insertResourcesQuery = fmt.Sprintf(
+		"INSERT INTO %s.%s (labels, fingerprint, seen_at_ts_bucket_start)",
+		resourcefilter.TracesDBName, resourcefilter.TraceResourceV3TableName,
+	)
+	insertErrorsQuery = fmt.Sprintf(
+		"INSERT INTO %s.%s (timestamp, errorID, groupID, traceID, spanID, serviceName, exceptionType, exceptionMessage, exceptionStacktrace, exceptionEscaped, resourceTagsMap)",
+		telemetrytraces.DBName, telemetrytraces.ErrorIndexV2TableName,
+	)
+	insertAttributesQuery = fmt.Sprintf(
+		"INSERT INTO %s.%s (unix_milli, tag_key, tag_type, tag_data_type, string_value, number_value)",
+		telemetrytraces.DBName, telemetrytraces.TagAttributesV2TableName,
+	)
+	insertAttributeKeysQuery = fmt.Sprintf(
+		"INSERT INTO %s.%s (tagKey, tagType, dataType, isColumn)",
+		telemetrytraces.DBName, telemetrytraces.SpanAttributesKeysTableName,
+	)
+)
+
+type tracesWriter struct {
+	settings       factory.ScopedProviderSettings
+	telemetryStore telemetrystore.TelemetryStore
+	// received counts the spans received by the receiver.
+	received metric.Int64Counter
+	// written counts the spans persisted in the telemetrystore.
+	written metric.Int64Counter
+	// rejected counts the spans which were dropped, by reason.
+	rejected metric.Int64Counter
+}
+
+// resource is a resource of the spans seen in a time bucket.
+type resource struct {
+	labels      string
+	fingerprint string
+	bucket      int64
+}
+
+// attributeKey is a key of the attributes of the spans, looked up by the autocomplete of the keys.
+type attributeKey struct {
+	key      string
+	tagType  string
+	dataType string
+}
+
+// attributeValue is a value of the attributes of the spans seen in a time bucket, looked up by the autocomplete of
+// the values. Only the values of the string and number attributes are kept.
+type attributeValue struct {
+	attributeKey
+	bucket      int64
+	stringValue string
+	numberValue float64
+}
+
+// attributes are the distinct keys and values of the attributes of the spans of a request.
+type attributes struct {
+	keys   map[attributeKey]struct{}
+	values map[attributeValue]struct{}
+}
+
+func (attrs *attributes) add(bucket int64, key string, tagType string, v pcommon.Value) {
+	value := attributeValue{attributeKey: attributeKey{key: key, tagType: tagType}, bucket: bucket}
+	switch v.Type() {
+	case pcommon.ValueTypeDouble:
+		if math.IsNaN(v.Double()) || math.IsInf(v.Double(), 0) {
+			return
+		}
+		value.dataType, value.numberValue = "float64", v.Double()
+	case pcommon.ValueTypeInt:
+		value.dataType, value.numberValue = "float64", float64(v.Int())
+	case pcommon.ValueTypeBool:
+		value.dataType = "bool"
+	default:
+		value.dataType, value.stringValue = "string", v.AsString()
+	}
+
+	attrs.keys[value.attributeKey] = struct{}{}
+	if len(value.stringValue) <= maxAttributeValueLength {
+		attrs.values[value] = struct{}{}
+	}
+}
+
+// addSpan adds the attributes of the span and its fields which are suggested along with them.
+func (attrs *attributes) addSpan(span ptrace.Span, resourceAttrs pcommon.Map) {
+	bucket := span.StartTimestamp().AsTime().UnixMilli() / attributeBucketMillis * attributeBucketMillis
+
+	span.Attributes().Range(func(k string, v pcommon.Value) bool {
+		attrs.add(bucket, k, "tag", v)
+		return true
+	})
+	resourceAttrs.Range(func(k string, v pcommon.Value) bool {
+		attrs.add(bucket, k, "resource", pcommon.NewValueStr(v.AsString()))
+		return true
+	})
+
+	// The fields are only suggested with their values, they are columns rather than attribute keys.
+	for _, field := range []attributeValue{
+		{attributeKey: attributeKey{key: "name", dataType: "string"}, stringValue: span.Name()},
+		{attributeKey: attributeKey{key: "kind_string", dataType: "string"}, stringValue: span.Kind().String()},
+		{attributeKey: attributeKey{key: "kind", dataType: "float64"}, numberValue: float64(span.Kind())},
+		{attributeKey: attributeKey{key: "status_code_string", dataType: "string"}, stringValue: span.Status().Code().String()},
+		{attributeKey: attributeKey{key: "status_code", dataType: "float64"}, numberValue: float64(span.Status().Code())},
+	} {
+		field.tagType, field.bucket = "spanfield", bucket
+		attrs.values[field] = struct{}{}
+	}
+}
+
+// event is an event of a span, as encoded in the events column.
+type event struct {
+	Name         string            `json:"name,omitempty"`
+	TimeUnixNano uint64            `json:"timeUnixNano,omitempty"`
+	AttributeMap map[string]string `json:"attributeMap,omitempty"`
+	IsError      bool              `json:"isError,omitempty"`
+}
+
+// reference is a parent or a link of a span, as encoded in the links column.
+type reference struct {
+	TraceID string `json:"traceId,omitempty"`
+	SpanID  string `json:"spanId,omitempty"`
+	RefType string `json:"refType,omitempty"`
+}
+
+func newTracesWriter(settings factory.ScopedProviderSettings, telemetryStore telemetrystore.TelemetryStore) (*tracesWriter, error) {
+	received, err := settings.Meter().Int64Counter("signoz.otlpreceiver.spans.received", metric.WithDescription("Number of spans received by the otlp receiver."))
+	if err != nil {
+		return nil, err
+	}
+
+	written, err := settings.Meter().Int64Counter("signoz.otlpreceiver.spans.written", metric.WithDescription("Number of spans written by the otlp receiver."))
+	if err != nil {
+		return nil, err
+	}
+
+	rejected, err := settings.Meter().Int64Counter("signoz.otlpreceiver.spans.rejected", metric.WithDescription("Number of spans rejected by the otlp receiver."))
+	if err != nil {
+		return nil, err
+	}
+
+	return &tracesWriter{
+		settings:       settings,
+		telemetryStore: telemetryStore,
+		received:       received,
+		written:        written,
+		rejected:       rejected,
+	}, nil
+}
+
+// Write writes the valid spans of the traces and returns the invalid ones, and the ones rejected by the telemetry
+// store, as a partial success. The request fails as a whole when it is over the ingestion limits.
+func (writer *tracesWriter) Write(ctx context.Context, traces ptrace.Traces, size int) (PartialSuccess, error) {
+	// Requests without claims are accounted against the default limits.
+	var tenantID string
+	if claims, err := authtypes.ClaimsFromContext(ctx); err == nil {
+		tenantID = claims.OrgID
+		// Every table of the spans is written to the shard of the tenant.
+		ctx = telemetrystore.NewContextWithTenantID(ctx, tenantID)
+	}
+
+	var received, invalid int64
+	var invalidErr error
+	resources := make(map[resource]struct{})
+	attrs := attributes{keys: map[attributeKey]struct{}{}, values: map[attributeValue]struct{}{}}
+	var rows, errorRows telemetrystore.AnyRows
+	for i := 0; i < traces.ResourceSpans().Len(); i++ {
+		resourceSpans := traces.ResourceSpans().At(i)
+		resourceAttrs := resourceAttributes(resourceSpans.Resource().Attributes())
+
+		labels, err := json.Marshal(resourceAttrs)
+		if err != nil {
+			return PartialSuccess{}, err
+		}
+
+		resourceFingerprint := fingerprint.CalculateFingerprint(resourceSpans.Resource().Attributes().AsRaw(), fingerprint.ResourceHierarchy())
+
+		serviceName, ok := resourceAttrs["service.name"]
+		if !ok {
+			serviceName = unknownServiceName
+		}
+
+		for j := 0; j < resourceSpans.ScopeSpans().Len(); j++ {
+			spans := resourceSpans.ScopeSpans().At(j).Spans()
+			received += int64(spans.Len())
+
+			for k := 0; k < spans.Len(); k++ {
+				span := spans.At(k)
+				if err := validateSpan(span); err != nil {
+					invalid++
+					if invalidErr == nil {
+						invalidErr = err
+					}
+					continue
+				}
+
+				bucket := span.StartTimestamp().AsTime().Unix() / resourceBucketSeconds * resourceBucketSeconds
+				resources[resource{labels: string(labels), fingerprint: resourceFingerprint, bucket: bucket}] = struct{}{}
+
+				row, err := newSpanRow(uint64(bucket), resourceFingerprint, span, resourceAttrs)
+				if err != nil {
+					return PartialSuccess{}, err
+				}
+				rows = append(rows, row)
+				errorRows = append(errorRows, newErrorRows(span, serviceName, resourceAttrs)...)
+				attrs.addSpan(span, resourceSpans.Resource().Attributes())
+			}
+		}
+	}
+
+	writer.received.Add(ctx, received)
+	partialSuccess := PartialSuccess{}
+	if invalid > 0 {
+		writer.rejected.Add(ctx, invalid, metric.WithAttributes(attribute.String("reason", rejectReasonInvalid)))
+		partialSuccess = PartialSuccess{Rejected: invalid, Message: fmt.Sprintf("%d invalid spans were rejected: %s", invalid, invalidErr.Error())}
+	}
+
+	if len(rows) == 0 {
+		return partialSuccess, nil
+	}
+
+	if err := writer.telemetryStore.IngestionLimiter().Allow(ctx, tenantID, int64(len(rows)), int64(size)); err != nil {
+		writer.rejected.Add(ctx, int64(len(rows)), metric.WithAttributes(attribute.String("reason", rejectReasonLimited)))
+		return PartialSuccess{}, err
+	}
+
+	if err := writer.writeResources(ctx, resources); err != nil {
+		return PartialSuccess{}, err
+	}
+
+	result, err := writer.telemetryStore.BatchInserter().Insert(ctx, insertSpansQuery, rows)
+	if err != nil {
+		return PartialSuccess{}, err
+	}
+
+	writer.written.Add(ctx, int64(result.Accepted))
+	if len(result.Rejections) > 0 {
+		writer.rejected.Add(ctx, int64(len(result.Rejections)), metric.WithAttributes(attribute.String("reason", rejectReasonStore)))
+		writer.settings.Logger().WarnContext(ctx, "spans rejected by the telemetry store", "count", len(result.Rejections), "rejection", result.Rejections[0])
+
+		// The spans rejected by the telemetry store are not retried either, they would be rejected again.
+		if partialSuccess.Rejected == 0 {
+			partialSuccess.Message = fmt.Sprintf("%d spans were rejected by the telemetry store: %s", len(result.Rejections), result.Rejections[0].Message)
+		}
+		partialSuccess.Rejected += int64(len(result.Rejections))
+	}
+
+	// The errors and the attributes are best effort, failing to write them does not fail the request as retrying it
+	// would write the spans twice.
+	if err := writer.writeRows(ctx, insertErrorsQuery, errorRows); err != nil {
+		writer.settings.Logger().WarnContext(ctx, "failed to write the errors of the spans", "count", len(errorRows), "error", err)
+	}
+
+	if err := writer.writeAttributes(ctx, attrs); err != nil {
+		writer.settings.Logger().WarnContext(ctx, "failed to write the attributes of the spans", "error", err)
+	}
+
+	return partialSuccess, nil
+}
+
+// writeResources writes the resources of the spans, which are looked up by the resource filters of the queries.
+func (writer *tracesWriter) writeResources(ctx context.Context, resources map[resource]struct{}) error {
+	rows := make(telemetrystore.AnyRows, 0, len(resources))
+	for r := range resources {
+		rows = append(rows, []any{r.labels, r.fingerprint, r.bucket})
+	}
+
+	return writer.writeRows(ctx, insertResourcesQuery, rows)
+}
+
+// writeAttributes writes the keys and the values of the attributes of the spans, which are looked up by the
+// autocomplete of the queries.
+func (writer *tracesWriter) writeAttributes(ctx context.Context, attrs attributes) error {
+	values := make(telemetrystore.AnyRows, 0, len(attrs.values))
+	for v := range attrs.values {
+		switch v.dataType {
+		case "float64":
+			values = append(values, []any{v.bucket, v.key, v.tagType, v.dataType, "", v.numberValue})
+		default:
+			values = append(values, []any{v.bucket, v.key, v.tagType, v.dataType, v.stringValue, nil})
+		}
+	}
+
+	if err := writer.writeRows(ctx, insertAttributesQuery, values); err != nil {
+		return err
+	}
+
+	keys := make(telemetrystore.AnyRows, 0, len(attrs.keys))
+	for k := range attrs.keys {
+		keys = append(keys, []any{k.key, k.tagType, k.dataType, false})
+	}
+
+	return writer.writeRows(ctx, insertAttributeKeysQuery, keys)
+}
+
+// writeRows writes the rows in a single batch to the shard of the tenant of the context.
+func (writer *tracesWriter) writeRows(ctx context.Context, query string, rows telemetrystore.AnyRows) error {
+	if len(rows) == 0 {
+		return nil
+	}
+
+	statement, err := writer.telemetryStore.ClickhouseDB().PrepareBatch(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer statement.Abort() //nolint:errcheck
+
+	for i := range rows {
+		if err := rows.AppendTo(statement, i); err != nil {
+			return err
+		}
+	}
+
+	return statement.Send()
+}
+
+func validateSpan(span ptrace.Span) error {
+	if span.TraceID().IsEmpty() {
+		return errors.Newf(errors.TypeInvalidInput, ErrCodeInvalidExportRequest, "span %q has an empty trace id", span.Name())
+	}
+
+	if span.SpanID().IsEmpty() {
+		return errors.Newf(errors.TypeInvalidInput, ErrCodeInvalidExportRequest, "span %q has an empty span id", span.Name())
+	}
+
+	if span.StartTimestamp() == 0 {
+		return errors.Newf(errors.TypeInvalidInput, ErrCodeInvalidExportRequest, "span %q has no start time", span.Name())
+	}
+
+	if span.EndTimestamp() < span.StartTimestamp() {
+		return errors.Newf(errors.TypeInvalidInput, ErrCodeInvalidExportRequest, "span %q ends before it starts", span.Name())
+	}
+
+	return nil
+}
+
+// newSpanRow returns the row of the span in the order of the columns of insertSpansQuery.
+func newSpanRow(bucket uint64, resourceFingerprint string, span ptrace.Span, resourceAttrs map[string]string) ([]any, error) {
+	attrsString, attrsNumber, attrsBool := map[string]string{}, map[string]float64{}, map[string]bool{}
+	span.Attributes().Range(func(k string, v pcommon.Value) bool {
+		switch v.Type() {
+		case pcommon.ValueTypeDouble:
+			if !math.IsNaN(v.Double()) && !math.IsInf(v.Double(), 0) {
+				attrsNumber[k] = v.Double()
+			}
+		case pcommon.ValueTypeInt:
+			attrsNumber[k] = float64(v.Int())
+		case pcommon.ValueTypeBool:
+			attrsBool[k] = v.Bool()
+		default:
+			attrsString[k] = v.AsString()
+		}
+		return true
+	})
+
+	events := make([]string, 0, span.Events().Len())
+	for i := 0; i < span.Events().Len(); i++ {
+		e := event{
+			Name:         span.Events().At(i).Name(),
+			TimeUnixNano: uint64(span.Events().At(i).Timestamp()),
+			AttributeMap: map[string]string{},
+			IsError:      span.Events().At(i).Name() == "exception",
+		}
+		span.Events().At(i).Attributes().Range(func(k string, v pcommon.Value) bool {
+			e.AttributeMap[k] = v.AsString()
+			return true
+		})
+
+		encoded, err := json.Marshal(e)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, string(encoded))
+	}
+
+	// The parent is the first reference, as expected by the trace views.
+	references := make([]reference, 0, span.Links().Len()+1)
+	if !span.ParentSpanID().IsEmpty() {
+		references = append(references, reference{TraceID: traceID(span.TraceID()), SpanID: spanID(span.ParentSpanID()), RefType: "CHILD_OF"})
+	}
+	for i := 0; i < span.Links().Len(); i++ {
+		link := span.Links().At(i)
+		references = append(references, reference{TraceID: traceID(link.TraceID()), SpanID: spanID(link.SpanID()), RefType: "FOLLOWS_FROM"})
+	}
+
+	links, err := json.Marshal(references)
+	if err != nil {
+		return nil, err
+	}
+
+	isRemote := "unknown"
+	if span.Flags()&hasIsRemoteMask != 0 {
+		isRemote = "no"
+		if span.Flags()&isRemoteMask != 0 {
+			isRemote = "yes"
+		}
+	}
+
+	composite := newCompositeAttributes(span)
+
+	return []any{
+		bucket,
+		resourceFingerprint,
+		time.Unix(0, int64(span.StartTimestamp())),
+		traceID(span.TraceID()),
+		spanID(span.SpanID()),
+		span.TraceState().AsRaw(),
+		spanID(span.ParentSpanID()),
+		span.Flags(),
+		span.Name(),
+		int8(span.Kind()),
+		span.Kind().String(),
+		uint64(span.EndTimestamp() - span.StartTimestamp()),
+		int16(span.Status().Code()),
+		span.Status().Message(),
+		span.Status().Code().String(),
+		attrsString,
+		attrsNumber,
+		attrsBool,
+		resourceAttrs,
+		events,
+		string(links),
+		composite.responseStatusCode,
+		composite.externalHTTPURL,
+		composite.httpURL,
+		composite.externalHTTPMethod,
+		composite.httpMethod,
+		composite.httpHost,
+		composite.dbName,
+		composite.dbOperation,
+		span.Status().Code() == ptrace.StatusCodeError,
+		isRemote,
+	}, nil
+}
+
+// newErrorRows returns the rows of the exceptions recorded as events of the span, in the order of the columns of
+// insertErrorsQuery. The exceptions are grouped by their service, type and message.
+func newErrorRows(span ptrace.Span, serviceName string, resourceAttrs map[string]string) telemetrystore.AnyRows {
+	var rows telemetrystore.AnyRows
+	for i := 0; i < span.Events().Len(); i++ {
+		e := span.Events().At(i)
+		if e.Name() != "exception" {
+			continue
+		}
+
+		attr := func(key string) string {
+			if v, ok := e.Attributes().Get(key); ok {
+				return v.AsString()
+			}
+			return ""
+		}
+
+		exceptionType, exceptionMessage := attr("exception.type"), attr("exception.message")
+		groupID := md5.Sum([]byte(serviceName + exceptionType + exceptionMessage))
+
+		rows = append(rows, []any{
+			time.Unix(0, int64(e.Timestamp())),
+			strings.ReplaceAll(uuid.NewString(), "-", ""),
+			hex.EncodeToString(groupID[:]),
+			traceID(span.TraceID()),
+			spanID(span.SpanID()),
+			serviceName,
+			exceptionType,
+			exceptionMessage,
+			attr("exception.stacktrace"),
+			attr("exception.escaped") == "true",
+			resourceAttrs,
+		})
+	}
+
+	return rows
+}
+
+// compositeAttributes are the attributes of a span which are stored in columns of their own, under both their
+// current and deprecated semantic convention names.
+type compositeAttributes struct {
+	responseStatusCode string
+	externalHTTPURL    string
+	httpURL            string
+	externalHTTPMethod string
+	httpMethod         string
+	httpHost           string
+	dbName             string
+	dbOperation        string
+}
+
+func newCompositeAttributes(span ptrace.Span) compositeAttributes {
+	client := span.Kind() == ptrace.SpanKindClient
+
+	var composite compositeAttributes
+	span.Attributes().Range(func(k string, v pcommon.Value) bool {
+		switch k {
+		case "http.status_code", "http.response.status_code", "rpc.grpc.status_code":
+			composite.responseStatusCode = statusCode(v)
+		case "rpc.jsonrpc.error_code":
+			composite.responseStatusCode = v.AsString()
+		case "http.url", "url.full":
+			composite.httpURL = v.AsString()
+			if client {
+				composite.externalHTTPURL = v.AsString()
+				if u, err := url.Parse(v.AsString()); err == nil {
+					composite.externalHTTPURL = u.Hostname()
+				}
+			}
+		case "http.method", "http.request.method":
+			composite.httpMethod = v.AsString()
+			if client {
+				composite.externalHTTPMethod = v.AsString()
+			}
+		case "http.host", "server.address", "client.address", "http.request.header.host":
+			composite.httpHost = v.AsString()
+		case "db.name", "db.namespace":
+			composite.dbName = v.AsString()
+		case "db.operation", "db.operation.name":
+			composite.dbOperation = v.AsString()
+		}
+		return true
+	})
+
+	return composite
+}
+
+// statusCode returns a status code sent either as an int or as a string.
+func statusCode(v pcommon.Value) string {
+	if v.Type() == pcommon.ValueTypeInt {
+		return strconv.FormatInt(v.Int(), 10)
+	}
+
+	if code, err := strconv.ParseInt(v.Str(), 10, 64); err == nil {
+		return strconv.FormatInt(code, 10)
+	}
+
+	return "0"
+}
+
+// resourceAttributes returns the attributes of a resource as strings.
+func resourceAttributes(attrs pcommon.Map) map[string]string {
+	m := make(map[string]string, attrs.Len())
+	attrs.Range(func(k string, v pcommon.Value) bool {
+		m[k] = v.AsString()
+		return true
+	})
+
+	return m
+}
+
+func traceID(id pcommon.TraceID) string {
+	if id.IsEmpty() {
+		return ""
+	}
+
+	return hex.EncodeToString(id[:])
+}
+
+func spanID(id pcommon.SpanID) string {
+	if id.IsEmpty() {
+		return ""
+	}
+
+	return hex.EncodeToString(id[:])
+}
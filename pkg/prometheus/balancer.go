@@ -0,0 +1,254 @@
+package prometheus
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"sync/atomic"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/factory"
+	commoncfg "github.com/prometheus/common/config"
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/prompb"
+	"github.com/prometheus/prometheus/storage"
+	"github.com/prometheus/prometheus/storage/remote"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+var (
+	ErrCodeNoHealthyBackend = errors.MustNewCode("no_healthy_backend")
+)
+
+// backend is a Prometheus compatible backend read through the remote read api.
+type backend struct {
+	endpoint string
+	client   remote.ReadClient
+	// probe checks the health of the backend.
+	probe func(ctx context.Context) error
+	// healthy is false while the backend is ejected.
+	healthy atomic.Bool
+	// outstanding is the number of queries of the backend in progress.
+	outstanding atomic.Int64
+	// failures is the number of consecutive failed queries of the backend.
+	failures   atomic.Int64
+	attributes metric.MeasurementOption
+}
+
+func newBackend(endpoint string, client remote.ReadClient, probe func(ctx context.Context) error) *backend {
+	backend := &backend{
+		endpoint:   endpoint,
+		client:     client,
+		probe:      probe,
+		attributes: metric.WithAttributes(attribute.String("prometheus.backend", endpoint)),
+	}
+	backend.healthy.Store(true)
+
+	return backend
+}
+
+// Balancer routes the remote reads to the healthy backend with the least outstanding queries. A backend is ejected
+// once MaxFailures consecutive queries or a probe of its health check fail, and restored once a probe succeeds.
+type Balancer struct {
+	settings factory.ScopedProviderSettings
+	config   BackendsConfig
+	backends []*backend
+	// next rotates the backend from which the least loaded backend is searched, so that the ties are broken
+	// round-robin.
+	next    atomic.Uint64
+	queries metric.Int64Counter
+	errors  metric.Int64Counter
+	stopC   chan struct{}
+}
+
+// NewBalancer returns the balancer of the backends of the config. The backends are probed once the balancer is
+// started.
+func NewBalancer(settings factory.ScopedProviderSettings, config BackendsConfig) (*Balancer, error) {
+	httpClient := &http.Client{Timeout: config.HealthCheck.Timeout}
+
+	backends := make([]*backend, 0, len(config.Endpoints))
+	for _, endpoint := range config.Endpoints {
+		u, err := url.Parse(endpoint)
+		if err != nil {
+			return nil, errors.Wrapf(err, errors.TypeInvalidInput, errors.CodeInvalidInput, "invalid prometheus backend %q", endpoint)
+		}
+
+		client, err := remote.NewReadClient(u.Host, &remote.ClientConfig{
+			URL:              &commoncfg.URL{URL: u},
+			Timeout:          model.Duration(config.Timeout),
+			HTTPClientConfig: commoncfg.DefaultHTTPClientConfig,
+		})
+		if err != nil {
+			return nil, errors.Wrapf(err, errors.TypeInvalidInput, errors.CodeInvalidInput, "invalid prometheus backend %q", endpoint)
+		}
+
+		healthURL := u.ResolveReference(&url.URL{Path: config.HealthCheck.Path}).String()
+		backends = append(backends, newBackend(endpoint, client, func(ctx context.Context) error {
+			return probe(ctx, httpClient, healthURL)
+		}))
+	}
+
+	return newBalancer(settings, config, backends)
+}
+
+func newBalancer(settings factory.ScopedProviderSettings, config BackendsConfig, backends []*backend) (*Balancer, error) {
+	queries, err := settings.Meter().Int64Counter("signoz.prometheus.backend.queries", metric.WithDescription("Number of queries routed to the prometheus backends."))
+	if err != nil {
+		return nil, err
+	}
+
+	queryErrors, err := settings.Meter().Int64Counter("signoz.prometheus.backend.errors", metric.WithDescription("Number of queries of the prometheus backends which failed."))
+	if err != nil {
+		return nil, err
+	}
+
+	healthy, err := settings.Meter().Int64ObservableGauge("signoz.prometheus.backend.healthy", metric.WithDescription("Whether the prometheus backend is healthy (1) or ejected (0)."))
+	if err != nil {
+		return nil, err
+	}
+
+	outstanding, err := settings.Meter().Int64ObservableGauge("signoz.prometheus.backend.outstanding", metric.WithDescription("Number of queries of the prometheus backend in progress."))
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = settings.Meter().RegisterCallback(func(_ context.Context, observer metric.Observer) error {
+		for _, backend := range backends {
+			var up int64
+			if backend.healthy.Load() {
+				up = 1
+			}
+			observer.ObserveInt64(healthy, up, backend.attributes)
+			observer.ObserveInt64(outstanding, backend.outstanding.Load(), backend.attributes)
+		}
+		return nil
+	}, healthy, outstanding)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Balancer{
+		settings: settings,
+		config:   config,
+		backends: backends,
+		queries:  queries,
+		errors:   queryErrors,
+		stopC:    make(chan struct{}),
+	}, nil
+}
+
+// Read reads the query from the healthy backend with the least outstanding queries. The queries canceled by their
+// callers do not count against the backends.
+func (balancer *Balancer) Read(ctx context.Context, query *prompb.Query, sortSeries bool) (storage.SeriesSet, error) {
+	backend := balancer.pick()
+	if backend == nil {
+		return nil, errors.New(errors.TypeUnavailable, ErrCodeNoHealthyBackend, "no prometheus backend is healthy")
+	}
+
+	backend.outstanding.Add(1)
+	defer backend.outstanding.Add(-1)
+
+	balancer.queries.Add(ctx, 1, backend.attributes)
+	seriesSet, err := backend.client.Read(ctx, query, sortSeries)
+	if err != nil {
+		balancer.errors.Add(ctx, 1, backend.attributes)
+		if ctx.Err() == nil && backend.failures.Add(1) >= int64(balancer.config.MaxFailures) {
+			balancer.eject(ctx, backend, err)
+		}
+
+		return nil, errors.Newf(errors.TypeInternal, errors.CodeInternal, "failed to query the prometheus backend %q: %v", backend.endpoint, err)
+	}
+
+	backend.failures.Store(0)
+	return seriesSet, nil
+}
+
+// Start probes the backends every interval until the balancer is stopped.
+func (balancer *Balancer) Start(ctx context.Context) error {
+	if len(balancer.backends) == 0 {
+		<-balancer.stopC
+		return nil
+	}
+
+	ticker := time.NewTicker(balancer.config.HealthCheck.Interval)
+	defer ticker.Stop()
+
+	for {
+		balancer.probe(ctx)
+
+		select {
+		case <-balancer.stopC:
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func (balancer *Balancer) Stop(ctx context.Context) error {
+	close(balancer.stopC)
+	return nil
+}
+
+// pick returns the healthy backend with the least outstanding queries, nil when no backend is healthy.
+func (balancer *Balancer) pick() *backend {
+	if len(balancer.backends) == 0 {
+		return nil
+	}
+
+	start := balancer.next.Add(1)
+	var picked *backend
+	for i := range balancer.backends {
+		backend := balancer.backends[(start+uint64(i))%uint64(len(balancer.backends))]
+		if !backend.healthy.Load() {
+			continue
+		}
+
+		if picked == nil || backend.outstanding.Load() < picked.outstanding.Load() {
+			picked = backend
+		}
+	}
+
+	return picked
+}
+
+// probe runs the health check of every backend, ejecting the failing ones and restoring the passing ones.
+func (balancer *Balancer) probe(ctx context.Context) {
+	for _, backend := range balancer.backends {
+		if err := backend.probe(ctx); err != nil {
+			balancer.eject(ctx, backend, err)
+			continue
+		}
+
+		backend.failures.Store(0)
+		if backend.healthy.CompareAndSwap(false, true) {
+			balancer.settings.Logger().InfoContext(ctx, "prometheus backend is healthy again, restoring it", "backend", backend.endpoint)
+		}
+	}
+}
+
+func (balancer *Balancer) eject(ctx context.Context, backend *backend, err error) {
+	if backend.healthy.CompareAndSwap(true, false) {
+		balancer.settings.Logger().WarnContext(ctx, "prometheus backend is unhealthy, ejecting it", "backend", backend.endpoint, "error", err)
+	}
+}
+
+func probe(ctx context.Context, client *http.Client, healthURL string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, healthURL, nil)
+	if err != nil {
+		return err
+	}
+
+	res, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode/100 != 2 {
+		return errors.Newf(errors.TypeUnavailable, errors.CodeInternal, "health check %s returned %s", healthURL, res.Status)
+	}
+
+	return nil
+}
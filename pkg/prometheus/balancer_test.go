@@ -0,0 +1,164 @@
+package prometheus
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/factory"
+	"github.com/SigNoz/signoz/pkg/factory/factorytest"
+	"github.com/prometheus/prometheus/prompb"
+	"github.com/prometheus/prometheus/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type readClient struct {
+	reads    atomic.Int64
+	err      atomic.Pointer[error]
+	blockC   chan struct{}
+	startedC chan struct{}
+}
+
+func (client *readClient) Read(ctx context.Context, query *prompb.Query, sortSeries bool) (storage.SeriesSet, error) {
+	client.reads.Add(1)
+	if client.blockC != nil {
+		client.startedC <- struct{}{}
+		<-client.blockC
+	}
+
+	if err := client.err.Load(); err != nil {
+		return nil, *err
+	}
+
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	return storage.EmptySeriesSet(), nil
+}
+
+func (client *readClient) fail(err error) {
+	client.err.Store(&err)
+}
+
+func newTestBalancer(t *testing.T, clients ...*readClient) *Balancer {
+	backends := make([]*backend, 0, len(clients))
+	for _, client := range clients {
+		backends = append(backends, newBackend("backend", client, func(ctx context.Context) error {
+			if err := client.err.Load(); err != nil {
+				return *err
+			}
+			return nil
+		}))
+	}
+
+	balancer, err := newBalancer(factory.NewScopedProviderSettings(factorytest.NewSettings(), "balancer"), BackendsConfig{MaxFailures: 2}, backends)
+	require.NoError(t, err)
+
+	return balancer
+}
+
+func TestBalancerLeastOutstanding(t *testing.T) {
+	ctx := context.Background()
+	busy := &readClient{blockC: make(chan struct{}), startedC: make(chan struct{})}
+	idle := &readClient{}
+	balancer := newTestBalancer(t, busy, idle)
+
+	// The ties are broken round-robin until the busy backend has a query in progress.
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for busy.reads.Load() == 0 {
+			_, err := balancer.Read(ctx, &prompb.Query{}, false)
+			assert.NoError(t, err)
+		}
+	}()
+	<-busy.startedC
+
+	for range 10 {
+		_, err := balancer.Read(ctx, &prompb.Query{}, false)
+		require.NoError(t, err)
+	}
+	assert.Equal(t, int64(1), busy.reads.Load())
+
+	close(busy.blockC)
+	<-done
+}
+
+func TestBalancerEjection(t *testing.T) {
+	ctx := context.Background()
+	first := &readClient{}
+	second := &readClient{}
+	balancer := newTestBalancer(t, first, second)
+
+	first.fail(errors.New(errors.TypeInternal, errors.CodeInternal, "down"))
+
+	// The failing backend is ejected after max_failures consecutive failures, and the queries go to the other one.
+	failures := 0
+	for range 10 {
+		if _, err := balancer.Read(ctx, &prompb.Query{}, false); err != nil {
+			failures++
+		}
+	}
+	assert.Equal(t, 2, failures)
+	assert.False(t, balancer.backends[0].healthy.Load())
+	assert.Equal(t, int64(8), second.reads.Load())
+
+	// The queries canceled by their callers do not eject the backend.
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	for range 3 {
+		_, err := balancer.Read(canceled, &prompb.Query{}, false)
+		assert.Error(t, err)
+	}
+	assert.True(t, balancer.backends[1].healthy.Load())
+
+	second.fail(errors.New(errors.TypeInternal, errors.CodeInternal, "down"))
+	balancer.probe(ctx)
+	_, err := balancer.Read(ctx, &prompb.Query{}, false)
+	assert.True(t, errors.Asc(err, ErrCodeNoHealthyBackend))
+
+	// The backends are restored once their health check passes.
+	first.err.Store(nil)
+	balancer.probe(ctx)
+	_, err = balancer.Read(ctx, &prompb.Query{}, false)
+	assert.NoError(t, err)
+	assert.True(t, balancer.backends[0].healthy.Load())
+	assert.False(t, balancer.backends[1].healthy.Load())
+}
+
+func TestNewBalancerHealthCheck(t *testing.T) {
+	var healthy atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/-/healthy" || !healthy.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	config := newConfig().(Config).Backends
+	config.Endpoints = []string{server.URL + "/api/v1/read"}
+	config.HealthCheck.Interval = 10 * time.Millisecond
+
+	balancer, err := NewBalancer(factory.NewScopedProviderSettings(factorytest.NewSettings(), "balancer"), config)
+	require.NoError(t, err)
+
+	go func() {
+		assert.NoError(t, balancer.Start(context.Background()))
+	}()
+	defer func() {
+		assert.NoError(t, balancer.Stop(context.Background()))
+	}()
+
+	assert.Eventually(t, func() bool { return !balancer.backends[0].healthy.Load() }, time.Second, 10*time.Millisecond)
+
+	healthy.Store(true)
+	assert.Eventually(t, func() bool { return balancer.backends[0].healthy.Load() }, time.Second, 10*time.Millisecond)
+}
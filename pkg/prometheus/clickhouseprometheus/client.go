@@ -5,11 +5,15 @@ import (
 	"fmt"
 	"github.com/SigNoz/signoz/pkg/query-service/constants"
 	"math"
+	"slices"
 	"strconv"
 	"strings"
 
 	"github.com/SigNoz/signoz/pkg/factory"
+	"github.com/SigNoz/signoz/pkg/prometheus"
 	"github.com/SigNoz/signoz/pkg/telemetrystore"
+	"github.com/prometheus/prometheus/model/exemplar"
+	"github.com/prometheus/prometheus/model/labels"
 	promValue "github.com/prometheus/prometheus/model/value"
 	"github.com/prometheus/prometheus/prompb"
 	"github.com/prometheus/prometheus/storage"
@@ -22,6 +26,10 @@ type client struct {
 }
 
 func NewReadClient(settings factory.ScopedProviderSettings, telemetryStore telemetrystore.TelemetryStore) remote.ReadClient {
+	return newClient(settings, telemetryStore)
+}
+
+func newClient(settings factory.ScopedProviderSettings, telemetryStore telemetrystore.TelemetryStore) *client {
 	return &client{
 		settings:       settings,
 		telemetryStore: telemetryStore,
@@ -234,6 +242,64 @@ func (client *client) querySamples(ctx context.Context, start int64, end int64,
 	return res, nil
 }
 
+// queryExemplars returns the most recent exemplars of the given fingerprints between start and end, up to limit,
+// keyed by fingerprint and sorted by time.
+func (client *client) queryExemplars(ctx context.Context, start int64, end int64, metricName string, fingerprints []uint64, limit int) (map[uint64][]exemplar.Exemplar, error) {
+	query := fmt.Sprintf(`
+		SELECT fingerprint, unix_milli, value, trace_id, span_id, attrs
+			FROM %s.%s
+			WHERE metric_name = $1 AND has($2, fingerprint) AND unix_milli >= $3 AND unix_milli <= $4 ORDER BY unix_milli DESC LIMIT $5;`,
+		databaseName, distributedExemplars)
+	query = strings.TrimSpace(query)
+
+	rows, err := client.telemetryStore.ClickhouseDB().Query(ctx, query, metricName, fingerprints, start, end, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := make(map[uint64][]exemplar.Exemplar)
+	for rows.Next() {
+		var fingerprint uint64
+		var timestampMs int64
+		var value float64
+		var traceID, spanID string
+		var attrs map[string]string
+		if err := rows.Scan(&fingerprint, &timestampMs, &value, &traceID, &spanID, &attrs); err != nil {
+			return nil, err
+		}
+
+		builder := labels.NewScratchBuilder(len(attrs) + 2)
+		for name, value := range attrs {
+			builder.Add(name, value)
+		}
+		if traceID != "" {
+			builder.Add(prometheus.ExemplarTraceIDLabel, traceID)
+		}
+		if spanID != "" {
+			builder.Add(prometheus.ExemplarSpanIDLabel, spanID)
+		}
+		builder.Sort()
+
+		res[fingerprint] = append(res[fingerprint], exemplar.Exemplar{
+			Labels: builder.Labels(),
+			Value:  value,
+			Ts:     timestampMs,
+			HasTs:  true,
+		})
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for _, exemplars := range res {
+		slices.Reverse(exemplars)
+	}
+
+	return res, nil
+}
+
 func (client *client) queryRaw(ctx context.Context, query string, ts int64) (*prompb.QueryResult, error) {
 	rows, err := client.telemetryStore.ClickhouseDB().Query(ctx, query)
 	if err != nil {
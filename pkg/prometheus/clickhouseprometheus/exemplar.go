@@ -0,0 +1,98 @@
+package clickhouseprometheus
+
+import (
+	"context"
+	"sort"
+
+	"github.com/SigNoz/signoz/pkg/prometheus"
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/model/exemplar"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/storage"
+	"github.com/prometheus/prometheus/storage/remote"
+)
+
+var _ storage.ExemplarQuerier = (*exemplarQuerier)(nil)
+
+// exemplarQuerier reads the exemplars written by the remote write receiver.
+type exemplarQuerier struct {
+	ctx    context.Context
+	client *client
+	config prometheus.ExemplarsConfig
+}
+
+func (querier *exemplarQuerier) Select(start, end int64, matchers ...[]*labels.Matcher) ([]exemplar.QueryResult, error) {
+	if !querier.config.Enabled {
+		return nil, nil
+	}
+
+	// The series selected by several matchers are looked up once.
+	seen := make(map[uint64]struct{})
+	var results []exemplar.QueryResult
+
+	for _, ms := range matchers {
+		remaining := querier.config.MaxPerQuery
+		for _, result := range results {
+			remaining -= len(result.Exemplars)
+		}
+
+		if remaining <= 0 {
+			break
+		}
+
+		query, err := remote.ToQuery(start, end, ms, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var metricName string
+		for _, matcher := range ms {
+			if matcher.Name == model.MetricNameLabel {
+				metricName = matcher.Value
+			}
+		}
+
+		clickhouseQuery, args, err := querier.client.queryToClickhouseQuery(querier.ctx, query, metricName, false)
+		if err != nil {
+			return nil, err
+		}
+
+		fingerprints, err := querier.client.getFingerprintsFromClickhouseQuery(querier.ctx, clickhouseQuery, args)
+		if err != nil {
+			return nil, err
+		}
+
+		unseen := make([]uint64, 0, len(fingerprints))
+		for fingerprint := range fingerprints {
+			if _, ok := seen[fingerprint]; !ok {
+				seen[fingerprint] = struct{}{}
+				unseen = append(unseen, fingerprint)
+			}
+		}
+
+		if len(unseen) == 0 {
+			continue
+		}
+
+		exemplars, err := querier.client.queryExemplars(querier.ctx, start, end, metricName, unseen, remaining)
+		if err != nil {
+			return nil, err
+		}
+
+		for fingerprint, seriesExemplars := range exemplars {
+			builder := labels.NewScratchBuilder(len(fingerprints[fingerprint]))
+			for _, label := range fingerprints[fingerprint] {
+				builder.Add(label.Name, label.Value)
+			}
+			builder.Sort()
+
+			results = append(results, exemplar.QueryResult{SeriesLabels: builder.Labels(), Exemplars: seriesExemplars})
+		}
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		return labels.Compare(results[i].SeriesLabels, results[j].SeriesLabels) < 0
+	})
+
+	return results, nil
+}
@@ -0,0 +1,72 @@
+package clickhouseprometheus
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/SigNoz/signoz/pkg/factory"
+	"github.com/SigNoz/signoz/pkg/factory/factorytest"
+	"github.com/SigNoz/signoz/pkg/prometheus"
+	"github.com/SigNoz/signoz/pkg/telemetrystore"
+	"github.com/SigNoz/signoz/pkg/telemetrystore/telemetrystoretest"
+	"github.com/prometheus/prometheus/model/labels"
+	cmock "github.com/srikanthccv/ClickHouse-go-mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestExemplarQuerier(config prometheus.ExemplarsConfig) (*exemplarQuerier, *telemetrystoretest.Provider) {
+	telemetryStore := telemetrystoretest.New(telemetrystore.Config{Provider: "clickhouse"}, sqlmock.QueryMatcherRegexp)
+	settings := factory.NewScopedProviderSettings(factorytest.NewSettings(), "github.com/SigNoz/signoz/pkg/prometheus/clickhouseprometheus")
+
+	return &exemplarQuerier{ctx: context.Background(), client: newClient(settings, telemetryStore), config: config}, telemetryStore
+}
+
+func TestExemplarQuerierSelect(t *testing.T) {
+	matchers := []*labels.Matcher{labels.MustNewMatcher(labels.MatchEqual, "__name__", "latency_bucket")}
+
+	t.Run("Disabled", func(t *testing.T) {
+		querier, telemetryStore := newTestExemplarQuerier(prometheus.ExemplarsConfig{Enabled: false})
+
+		results, err := querier.Select(0, 1000, matchers)
+		require.NoError(t, err)
+		assert.Empty(t, results)
+		assert.NoError(t, telemetryStore.Mock().ExpectationsWereMet())
+	})
+
+	t.Run("LooksUpTheSeriesOnce", func(t *testing.T) {
+		querier, telemetryStore := newTestExemplarQuerier(prometheus.ExemplarsConfig{Enabled: true, MaxPerQuery: 10})
+
+		fingerprintCols := []cmock.ColumnType{{Name: "fingerprint", Type: "UInt64"}, {Name: "labels", Type: "String"}}
+		fingerprintValues := [][]any{{uint64(1), `{"__name__":"latency_bucket","le":"0.5"}`}}
+
+		telemetryStore.Mock().ExpectQuery("SELECT fingerprint, any\\(labels\\)").WithArgs("latency_bucket", "__name__", "latency_bucket").WillReturnRows(cmock.NewRows(fingerprintCols, fingerprintValues))
+		telemetryStore.Mock().ExpectQuery("FROM signoz_metrics.distributed_exemplars .* ORDER BY unix_milli DESC LIMIT").
+			WithArgs("latency_bucket", []uint64{1}, int64(0), int64(1000), 10).
+			WillReturnRows(cmock.NewRows(
+				[]cmock.ColumnType{
+					{Name: "fingerprint", Type: "UInt64"},
+					{Name: "unix_milli", Type: "Int64"},
+					{Name: "value", Type: "Float64"},
+					{Name: "trace_id", Type: "String"},
+					{Name: "span_id", Type: "String"},
+					{Name: "attrs", Type: "Map(String, String)"},
+				},
+				[][]any{
+					{uint64(1), int64(900), 0.4, "0af7651916cd43dd8448eb211c80319c", "", map[string]string{}},
+					{uint64(1), int64(100), 0.3, "", "", map[string]string{}},
+				},
+			))
+		// The series selected again by the second matcher is not looked up again.
+		telemetryStore.Mock().ExpectQuery("SELECT fingerprint, any\\(labels\\)").WithArgs("latency_bucket", "__name__", "latency_bucket").WillReturnRows(cmock.NewRows(fingerprintCols, fingerprintValues))
+
+		results, err := querier.Select(0, 1000, matchers, matchers)
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		require.Len(t, results[0].Exemplars, 2)
+		assert.Equal(t, int64(100), results[0].Exemplars[0].Ts)
+		assert.Equal(t, int64(900), results[0].Exemplars[1].Ts)
+		assert.NoError(t, telemetryStore.Mock().ExpectationsWereMet())
+	})
+}
@@ -2,14 +2,22 @@ package clickhouseprometheus
 
 import (
 	"context"
+	"io"
+	"time"
 
+	"github.com/SigNoz/signoz/pkg/cache"
 	"github.com/SigNoz/signoz/pkg/factory"
 	"github.com/SigNoz/signoz/pkg/prometheus"
 	"github.com/SigNoz/signoz/pkg/telemetrystore"
+	"github.com/SigNoz/signoz/pkg/valuer"
 	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/model/exemplar"
 	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/prompb"
+	"github.com/prometheus/prometheus/promql"
 	"github.com/prometheus/prometheus/storage"
 	"github.com/prometheus/prometheus/storage/remote"
+	"github.com/prometheus/prometheus/util/annotations"
 )
 
 var stCallback = func() (int64, error) {
@@ -21,27 +29,66 @@ type provider struct {
 	telemetryStore telemetrystore.TelemetryStore
 	engine         *prometheus.Engine
 	queryable      storage.SampleAndChunkQueryable
+	client         *client
+	writer         *writer
+	queryCache     *prometheus.QueryCache
+	downsampling   prometheus.DownsamplingConfig
+	exemplars      prometheus.ExemplarsConfig
+	balancer       *prometheus.Balancer
+	// backends is the queryable of the prometheus backends, nil when none is configured.
+	backends storage.SampleAndChunkQueryable
 }
 
-func NewFactory(telemetryStore telemetrystore.TelemetryStore) factory.ProviderFactory[prometheus.Prometheus, prometheus.Config] {
+func NewFactory(telemetryStore telemetrystore.TelemetryStore, cache cache.Cache) factory.ProviderFactory[prometheus.Prometheus, prometheus.Config] {
 	return factory.NewProviderFactory(factory.MustNewName("clickhouse"), func(ctx context.Context, providerSettings factory.ProviderSettings, config prometheus.Config) (prometheus.Prometheus, error) {
-		return New(ctx, providerSettings, config, telemetryStore)
+		return New(ctx, providerSettings, config, telemetryStore, cache)
 	})
 }
 
-func New(ctx context.Context, providerSettings factory.ProviderSettings, config prometheus.Config, telemetryStore telemetrystore.TelemetryStore) (prometheus.Prometheus, error) {
+func New(ctx context.Context, providerSettings factory.ProviderSettings, config prometheus.Config, telemetryStore telemetrystore.TelemetryStore, cache cache.Cache) (prometheus.Prometheus, error) {
 	settings := factory.NewScopedProviderSettings(providerSettings, "github.com/SigNoz/signoz/pkg/prometheus/clickhouseprometheus")
 
-	readClient := NewReadClient(settings, telemetryStore)
+	readClient := newClient(settings, telemetryStore)
+
+	writer, err := newWriter(settings, telemetryStore, config.RemoteWrite)
+	if err != nil {
+		return nil, err
+	}
+
+	balancer, err := prometheus.NewBalancer(settings, config.Backends)
+	if err != nil {
+		return nil, err
+	}
+
+	var backends storage.SampleAndChunkQueryable
+	if len(config.Backends.Endpoints) > 0 {
+		backends = remote.NewSampleAndChunkQueryableClient(balancer, labels.EmptyLabels(), []*labels.Matcher{}, false, stCallback)
+	}
 
 	return &provider{
 		settings:       settings,
 		telemetryStore: telemetryStore,
 		engine:         prometheus.NewEngine(settings.Logger(), config),
 		queryable:      remote.NewSampleAndChunkQueryableClient(readClient, labels.EmptyLabels(), []*labels.Matcher{}, false, stCallback),
+		client:         readClient,
+		writer:         writer,
+		queryCache:     prometheus.NewQueryCache(settings.Logger(), cache, config.Cache),
+		downsampling:   config.Downsampling,
+		exemplars:      config.RemoteWrite.Exemplars,
+		balancer:       balancer,
+		backends:       backends,
 	}, nil
 }
 
+// Start probes the health of the prometheus backends until the provider is stopped.
+func (provider *provider) Start(ctx context.Context) error {
+	return provider.balancer.Start(ctx)
+}
+
+func (provider *provider) Stop(ctx context.Context) error {
+	return provider.balancer.Stop(ctx)
+}
+
 func (provider *provider) Engine() *prometheus.Engine {
 	return provider.engine
 }
@@ -50,11 +97,50 @@ func (provider *provider) Storage() storage.Queryable {
 	return provider
 }
 
+func (provider *provider) DecodeWriteRequest(r io.Reader) (*prompb.WriteRequest, error) {
+	return prometheus.DecodeWriteRequest(r, provider.writer.config)
+}
+
+func (provider *provider) Write(ctx context.Context, req *prompb.WriteRequest) error {
+	return provider.writer.Write(ctx, req)
+}
+
+func (provider *provider) RangeQuery(ctx context.Context, orgID valuer.UUID, params *prometheus.RangeQueryParams) (promql.Matrix, annotations.Annotations, error) {
+	return provider.queryCache.RangeQuery(ctx, provider.engine, provider, orgID, params)
+}
+
+func (provider *provider) HistogramExemplars(ctx context.Context, query string, start int64, end int64) ([]exemplar.QueryResult, error) {
+	if !provider.exemplars.Enabled {
+		return nil, nil
+	}
+
+	return prometheus.HistogramExemplars(ctx, provider, query, start, end)
+}
+
+func (provider *provider) Downsample(query string, start time.Time, end time.Time, step time.Duration) prometheus.Downsampling {
+	return prometheus.Downsample(provider.downsampling, query, start, end, step)
+}
+
+func (provider *provider) ExemplarQuerier(ctx context.Context) (storage.ExemplarQuerier, error) {
+	return &exemplarQuerier{ctx: ctx, client: provider.client, config: provider.exemplars}, nil
+}
+
 func (provider *provider) Querier(mint, maxt int64) (storage.Querier, error) {
 	querier, err := provider.queryable.Querier(mint, maxt)
 	if err != nil {
 		return nil, err
 	}
 
-	return storage.NewMergeQuerier(nil, []storage.Querier{querier}, storage.ChainedSeriesMerge), nil
+	queriers := []storage.Querier{querier}
+	if provider.backends != nil {
+		backendsQuerier, err := provider.backends.Querier(mint, maxt)
+		if err != nil {
+			return nil, err
+		}
+
+		// The failures of the backends are returned as warnings alongside the series read from clickhouse.
+		queriers = append(queriers, backendsQuerier)
+	}
+
+	return storage.NewMergeQuerier(nil, queriers, storage.ChainedSeriesMerge), nil
 }
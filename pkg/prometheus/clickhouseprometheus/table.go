@@ -8,6 +8,7 @@ const (
 	distributedTimeSeriesV46hrs string = "distributed_time_series_v4_6hrs"
 	distributedTimeSeriesV41day string = "distributed_time_series_v4_1day"
 	distributedSamplesV4        string = "distributed_samples_v4"
+	distributedExemplars        string = "distributed_exemplars"
 )
 
 var (
@@ -0,0 +1,438 @@
+package clickhouseprometheus
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/ClickHouse/clickhouse-go/v2/lib/driver"
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/factory"
+	"github.com/SigNoz/signoz/pkg/prometheus"
+	"github.com/SigNoz/signoz/pkg/query-service/constants"
+	"github.com/SigNoz/signoz/pkg/telemetrystore"
+	"github.com/SigNoz/signoz/pkg/types/authtypes"
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/model/labels"
+	promValue "github.com/prometheus/prometheus/model/value"
+	"github.com/prometheus/prometheus/prompb"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+const (
+	// env is the deployment environment written for remote write series.
+	env string = "default"
+
+	rejectReasonStale         string = "stale"
+	rejectReasonInvalidLabels string = "invalid_labels"
+	rejectReasonLimited       string = "limited"
+	rejectReasonCardinality   string = "cardinality"
+	rejectReasonStore         string = "store"
+)
+
+var (
+	insertTimeSeriesQuery = fmt.Sprintf(
+		"INSERT INTO %s.%s (env, temporality, metric_name, description, unit, type, is_monotonic, fingerprint, unix_milli, labels, attrs, scope_attrs, resource_attrs, __normalized)",
+		databaseName, distributedTimeSeriesV4,
+	)
+	insertSamplesQuery = fmt.Sprintf(
+		"INSERT INTO %s.%s (env, temporality, metric_name, fingerprint, unix_milli, value, flags)",
+		databaseName, distributedSamplesV4,
+	)
+	insertExemplarsQuery = fmt.Sprintf(
+		"INSERT INTO %s.%s (env, temporality, metric_name, fingerprint, unix_milli, value, trace_id, span_id, attrs)",
+		databaseName, distributedExemplars,
+	)
+)
+
+type writer struct {
+	settings       factory.ScopedProviderSettings
+	telemetryStore telemetrystore.TelemetryStore
+	config         prometheus.RemoteWriteConfig
+	now            func() time.Time
+	// received counts the samples received by the remote write receiver.
+	received metric.Int64Counter
+	// written counts the samples persisted in the telemetrystore.
+	written metric.Int64Counter
+	// rejected counts the samples which were dropped, by reason.
+	rejected metric.Int64Counter
+}
+
+// series is a validated time series of a write request.
+type series struct {
+	metricName  string
+	fingerprint uint64
+	labels      string
+	attrs       map[string]string
+	metadata    prompb.MetricMetadata
+	samples     []prompb.Sample
+	exemplars   []prompb.Exemplar
+}
+
+func newWriter(settings factory.ScopedProviderSettings, telemetryStore telemetrystore.TelemetryStore, config prometheus.RemoteWriteConfig) (*writer, error) {
+	received, err := settings.Meter().Int64Counter("signoz.prometheus.remote_write.samples.received", metric.WithDescription("Number of samples received by the remote write receiver."))
+	if err != nil {
+		return nil, err
+	}
+
+	written, err := settings.Meter().Int64Counter("signoz.prometheus.remote_write.samples.written", metric.WithDescription("Number of samples written by the remote write receiver."))
+	if err != nil {
+		return nil, err
+	}
+
+	rejected, err := settings.Meter().Int64Counter("signoz.prometheus.remote_write.samples.rejected", metric.WithDescription("Number of samples rejected by the remote write receiver."))
+	if err != nil {
+		return nil, err
+	}
+
+	return &writer{
+		settings:       settings,
+		telemetryStore: telemetryStore,
+		config:         config,
+		now:            time.Now,
+		received:       received,
+		written:        written,
+		rejected:       rejected,
+	}, nil
+}
+
+func (writer *writer) Write(ctx context.Context, req *prompb.WriteRequest) error {
+	if !writer.config.Enabled {
+		return errors.New(errors.TypeUnsupported, prometheus.ErrCodeRemoteWriteDisabled, "remote write is disabled")
+	}
+
+	metadata := make(map[string]prompb.MetricMetadata, len(req.Metadata))
+	for _, m := range req.Metadata {
+		metadata[m.MetricFamilyName] = m
+	}
+
+	minTimestamp := writer.now().Add(-writer.config.StalenessWindow).UnixMilli()
+
+	// Requests without claims are accounted against the default limits.
+	var tenantID string
+	if claims, err := authtypes.ClaimsFromContext(ctx); err == nil {
+		tenantID = claims.OrgID
+	}
+
+	var received, accepted, stale, invalid, limited int64
+	var invalidErr, limitedErr error
+	allSeries := make([]series, 0, len(req.Timeseries))
+	admission := writer.telemetryStore.CardinalityGuard().NewAdmission(tenantID)
+	for _, ts := range req.Timeseries {
+		received += int64(len(ts.Samples))
+
+		if err := prometheus.ValidateLabels(ts.Labels); err != nil {
+			invalid += int64(len(ts.Samples))
+			if invalidErr == nil {
+				invalidErr = err
+			}
+			continue
+		}
+
+		samples := make([]prompb.Sample, 0, len(ts.Samples))
+		for _, sample := range ts.Samples {
+			if sample.Timestamp < minTimestamp {
+				stale++
+				continue
+			}
+			samples = append(samples, sample)
+		}
+
+		if len(samples) == 0 {
+			continue
+		}
+
+		s, err := newSeries(ts.Labels, samples)
+		if err != nil {
+			return err
+		}
+
+		admitted, err := admission.Admit(ctx, s.metricName, s.fingerprint)
+		if !admitted {
+			limited += int64(len(samples))
+			if limitedErr == nil {
+				limitedErr = err
+			}
+			continue
+		}
+
+		accepted += int64(len(samples))
+		s.metadata = metadata[s.metricName]
+		s.exemplars = writer.retainedExemplars(ts.Exemplars, minTimestamp)
+		allSeries = append(allSeries, s)
+	}
+
+	writer.received.Add(ctx, received)
+	if stale > 0 {
+		writer.rejected.Add(ctx, stale, metric.WithAttributes(attribute.String("reason", rejectReasonStale)))
+		writer.settings.Logger().DebugContext(ctx, "rejected samples older than the staleness window", "count", stale, "staleness_window", writer.config.StalenessWindow)
+	}
+	if invalid > 0 {
+		writer.rejected.Add(ctx, invalid, metric.WithAttributes(attribute.String("reason", rejectReasonInvalidLabels)))
+	}
+	if limited > 0 {
+		writer.rejected.Add(ctx, limited, metric.WithAttributes(attribute.String("reason", rejectReasonCardinality)))
+	}
+
+	if len(allSeries) > 0 {
+		if err := writer.telemetryStore.IngestionLimiter().Allow(ctx, tenantID, accepted, int64(req.Size())); err != nil {
+			writer.rejected.Add(ctx, accepted, metric.WithAttributes(attribute.String("reason", rejectReasonLimited)))
+			return err
+		}
+
+		if err := writer.writeTimeSeries(ctx, allSeries); err != nil {
+			return err
+		}
+
+		written, err := writer.writeSamples(ctx, allSeries)
+		writer.written.Add(ctx, written)
+		if err != nil {
+			return err
+		}
+
+		admission.Commit()
+
+		// The exemplars are best effort, failing to write them does not fail the request as retrying it would
+		// write the samples twice.
+		if err := writer.writeExemplars(ctx, allSeries); err != nil {
+			writer.settings.Logger().WarnContext(ctx, "failed to write exemplars", "error", err)
+		}
+	}
+
+	// The valid series are written even if some of them are invalid, in line with the prometheus receiver.
+	if invalidErr != nil {
+		return invalidErr
+	}
+
+	if limitedErr != nil {
+		return limitedErr
+	}
+
+	return nil
+}
+
+func (writer *writer) writeTimeSeries(ctx context.Context, allSeries []series) error {
+	statement, err := writer.telemetryStore.ClickhouseDB().PrepareBatch(ctx, insertTimeSeriesQuery)
+	if err != nil {
+		return err
+	}
+	defer statement.Abort() //nolint:errcheck
+
+	normalized := !constants.IsDotMetricsEnabled
+	for _, s := range allSeries {
+		typ, temporality, isMonotonic := metricType(s.metadata)
+
+		// The time series tables are bucketed by the hour, write one row for every bucket the samples fall in.
+		buckets := make(map[int64]struct{})
+		for _, sample := range s.samples {
+			bucket := sample.Timestamp - (sample.Timestamp % time.Hour.Milliseconds())
+			if _, ok := buckets[bucket]; ok {
+				continue
+			}
+			buckets[bucket] = struct{}{}
+
+			if err := statement.Append(
+				env,
+				temporality,
+				s.metricName,
+				s.metadata.Help,
+				s.metadata.Unit,
+				typ,
+				isMonotonic,
+				s.fingerprint,
+				bucket,
+				s.labels,
+				s.attrs,
+				map[string]string{},
+				map[string]string{},
+				normalized,
+			); err != nil {
+				return err
+			}
+		}
+	}
+
+	return statement.Send()
+}
+
+// sampleRow is a sample of a series written by writeSamples.
+type sampleRow struct {
+	series      *series
+	temporality string
+	sample      prompb.Sample
+}
+
+// sampleRows are the rows of the samples, their values are only built when they are appended to a batch.
+type sampleRows []sampleRow
+
+func (rows sampleRows) Len() int {
+	return len(rows)
+}
+
+func (rows sampleRows) AppendTo(batch driver.Batch, index int) error {
+	row := rows[index]
+	value, flags := row.sample.Value, uint32(0)
+	if promValue.IsStaleNaN(value) {
+		value, flags = 0, 1
+	}
+
+	return batch.Append(env, row.temporality, row.series.metricName, row.series.fingerprint, row.sample.Timestamp, value, flags)
+}
+
+// exemplarRow is an exemplar of a series written by writeExemplars.
+type exemplarRow struct {
+	series      *series
+	temporality string
+	exemplar    prompb.Exemplar
+}
+
+// exemplarRows are the rows of the exemplars, their values are only built when they are appended to a batch.
+type exemplarRows []exemplarRow
+
+func (rows exemplarRows) Len() int {
+	return len(rows)
+}
+
+func (rows exemplarRows) AppendTo(batch driver.Batch, index int) error {
+	row := rows[index]
+
+	// The trace and span ids are stored in columns of their own, the other labels as attributes.
+	var traceID, spanID string
+	attrs := make(map[string]string, len(row.exemplar.Labels))
+	for _, label := range row.exemplar.Labels {
+		switch label.Name {
+		case prometheus.ExemplarTraceIDLabel:
+			traceID = label.Value
+		case prometheus.ExemplarSpanIDLabel:
+			spanID = label.Value
+		default:
+			attrs[label.Name] = label.Value
+		}
+	}
+
+	return batch.Append(env, row.temporality, row.series.metricName, row.series.fingerprint, row.exemplar.Timestamp, row.exemplar.Value, traceID, spanID, attrs)
+}
+
+// writeSamples writes the samples of the series through the batch inserter of the telemetry store. With partial
+// writes, the samples rejected by the telemetry store are counted and the others are written.
+func (writer *writer) writeSamples(ctx context.Context, allSeries []series) (int64, error) {
+	count := 0
+	for i := range allSeries {
+		count += len(allSeries[i].samples)
+	}
+
+	rows := make(sampleRows, 0, count)
+	for i := range allSeries {
+		_, temporality, _ := metricType(allSeries[i].metadata)
+		for _, sample := range allSeries[i].samples {
+			rows = append(rows, sampleRow{series: &allSeries[i], temporality: temporality, sample: sample})
+		}
+	}
+
+	result, err := writer.telemetryStore.BatchInserter().Insert(ctx, insertSamplesQuery, rows)
+	if err != nil {
+		return 0, err
+	}
+
+	if len(result.Rejections) > 0 {
+		writer.rejected.Add(ctx, int64(len(result.Rejections)), metric.WithAttributes(attribute.String("reason", rejectReasonStore)))
+		writer.settings.Logger().WarnContext(ctx, "samples rejected by the telemetry store", "count", len(result.Rejections), "rejection", result.Rejections[0])
+		return int64(result.Accepted), errors.Newf(errors.TypeInvalidInput, prometheus.ErrCodeInvalidWriteRequest, "%d samples were rejected by the telemetry store: %s", len(result.Rejections), result.Rejections[0].Message)
+	}
+
+	return int64(result.Accepted), nil
+}
+
+// writeExemplars writes the exemplars of the series through the batch inserter of the telemetry store.
+func (writer *writer) writeExemplars(ctx context.Context, allSeries []series) error {
+	var rows exemplarRows
+	for i := range allSeries {
+		_, temporality, _ := metricType(allSeries[i].metadata)
+		for _, e := range allSeries[i].exemplars {
+			rows = append(rows, exemplarRow{series: &allSeries[i], temporality: temporality, exemplar: e})
+		}
+	}
+
+	if len(rows) == 0 {
+		return nil
+	}
+
+	result, err := writer.telemetryStore.BatchInserter().Insert(ctx, insertExemplarsQuery, rows)
+	if err != nil {
+		return err
+	}
+
+	if len(result.Rejections) > 0 {
+		return errors.Newf(errors.TypeInvalidInput, prometheus.ErrCodeInvalidWriteRequest, "%d exemplars were rejected by the telemetry store: %s", len(result.Rejections), result.Rejections[0].Message)
+	}
+
+	return nil
+}
+
+// retainedExemplars returns the exemplars of a series to write, the most recent ones within the staleness window
+// and up to the maximum number of exemplars per series. It returns nothing when the exemplars are not ingested.
+func (writer *writer) retainedExemplars(exemplars []prompb.Exemplar, minTimestamp int64) []prompb.Exemplar {
+	if !writer.config.Exemplars.Enabled || len(exemplars) == 0 {
+		return nil
+	}
+
+	retained := make([]prompb.Exemplar, 0, len(exemplars))
+	for _, e := range exemplars {
+		if e.Timestamp < minTimestamp {
+			continue
+		}
+		retained = append(retained, e)
+	}
+
+	sort.Slice(retained, func(i, j int) bool { return retained[i].Timestamp < retained[j].Timestamp })
+	if len(retained) > writer.config.Exemplars.MaxPerSeries {
+		retained = retained[len(retained)-writer.config.Exemplars.MaxPerSeries:]
+	}
+
+	return retained
+}
+
+func newSeries(promLabels []prompb.Label, samples []prompb.Sample) (series, error) {
+	builder := labels.NewScratchBuilder(len(promLabels))
+	m := make(map[string]string, len(promLabels))
+	attrs := make(map[string]string, len(promLabels))
+	var metricName string
+	for _, label := range promLabels {
+		builder.Add(label.Name, label.Value)
+		m[label.Name] = label.Value
+		if label.Name == model.MetricNameLabel {
+			metricName = label.Value
+			continue
+		}
+		attrs[label.Name] = label.Value
+	}
+	builder.Sort()
+
+	encoded, err := json.Marshal(m)
+	if err != nil {
+		return series{}, err
+	}
+
+	sort.Slice(samples, func(i, j int) bool { return samples[i].Timestamp < samples[j].Timestamp })
+
+	return series{
+		metricName:  metricName,
+		fingerprint: builder.Labels().Hash(),
+		labels:      string(encoded),
+		attrs:       attrs,
+		samples:     samples,
+	}, nil
+}
+
+// metricType maps the prometheus metadata of a series to its type, temporality and monotonicity.
+func metricType(metadata prompb.MetricMetadata) (string, string, bool) {
+	switch metadata.Type {
+	case prompb.MetricMetadata_COUNTER:
+		return "Sum", "Cumulative", true
+	default:
+		return "Gauge", "Unspecified", false
+	}
+}
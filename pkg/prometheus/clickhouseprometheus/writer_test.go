@@ -0,0 +1,229 @@
+package clickhouseprometheus
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/factory"
+	"github.com/SigNoz/signoz/pkg/factory/factorytest"
+	"github.com/SigNoz/signoz/pkg/prometheus"
+	"github.com/SigNoz/signoz/pkg/telemetrystore"
+	"github.com/SigNoz/signoz/pkg/telemetrystore/telemetrystoretest"
+	"github.com/prometheus/prometheus/prompb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestWriter(t *testing.T, config prometheus.RemoteWriteConfig, now time.Time) (*writer, *telemetrystoretest.Provider) {
+	telemetryStore := telemetrystoretest.New(telemetrystore.Config{Provider: "clickhouse"}, sqlmock.QueryMatcherEqual)
+	settings := factory.NewScopedProviderSettings(factorytest.NewSettings(), "github.com/SigNoz/signoz/pkg/prometheus/clickhouseprometheus")
+
+	writer, err := newWriter(settings, telemetryStore, config)
+	require.NoError(t, err)
+	writer.now = func() time.Time { return now }
+
+	return writer, telemetryStore
+}
+
+func TestWriterWrite(t *testing.T) {
+	now := time.Date(2025, 1, 1, 10, 30, 0, 0, time.UTC)
+	config := prometheus.RemoteWriteConfig{Enabled: true, StalenessWindow: time.Hour}
+
+	t.Run("Disabled", func(t *testing.T) {
+		writer, _ := newTestWriter(t, prometheus.RemoteWriteConfig{Enabled: false, StalenessWindow: time.Hour}, now)
+
+		err := writer.Write(context.Background(), &prompb.WriteRequest{})
+		assert.True(t, errors.Ast(err, errors.TypeUnsupported))
+	})
+
+	t.Run("RejectsStaleSamples", func(t *testing.T) {
+		writer, telemetryStore := newTestWriter(t, config, now)
+
+		timeSeries := telemetryStore.Mock().ExpectPrepareBatch(insertTimeSeriesQuery)
+		timeSeries.ExpectAppend()
+		timeSeries.ExpectSend()
+
+		samples := telemetryStore.Mock().ExpectPrepareBatch(insertSamplesQuery)
+		samples.ExpectAppend()
+		samples.ExpectSend()
+
+		err := writer.Write(context.Background(), &prompb.WriteRequest{
+			Timeseries: []prompb.TimeSeries{
+				{
+					Labels: []prompb.Label{{Name: "__name__", Value: "up"}, {Name: "job", Value: "node"}},
+					Samples: []prompb.Sample{
+						{Timestamp: now.Add(-2 * time.Hour).UnixMilli(), Value: 1},
+						{Timestamp: now.Add(-time.Minute).UnixMilli(), Value: 1},
+					},
+				},
+			},
+		})
+		require.NoError(t, err)
+		assert.NoError(t, telemetryStore.Mock().ExpectationsWereMet())
+	})
+
+	t.Run("RejectsInvalidLabels", func(t *testing.T) {
+		writer, telemetryStore := newTestWriter(t, config, now)
+
+		timeSeries := telemetryStore.Mock().ExpectPrepareBatch(insertTimeSeriesQuery)
+		timeSeries.ExpectAppend()
+		timeSeries.ExpectSend()
+
+		samples := telemetryStore.Mock().ExpectPrepareBatch(insertSamplesQuery)
+		samples.ExpectAppend()
+		samples.ExpectSend()
+
+		err := writer.Write(context.Background(), &prompb.WriteRequest{
+			Timeseries: []prompb.TimeSeries{
+				{
+					Labels:  []prompb.Label{{Name: "__name__", Value: "up"}},
+					Samples: []prompb.Sample{{Timestamp: now.UnixMilli(), Value: 1}},
+				},
+				{
+					Labels:  []prompb.Label{{Name: "job", Value: "node"}},
+					Samples: []prompb.Sample{{Timestamp: now.UnixMilli(), Value: 1}},
+				},
+			},
+		})
+		assert.True(t, errors.Ast(err, errors.TypeInvalidInput))
+		assert.NoError(t, telemetryStore.Mock().ExpectationsWereMet())
+	})
+
+	t.Run("BoundsExemplars", func(t *testing.T) {
+		writer, telemetryStore := newTestWriter(t, prometheus.RemoteWriteConfig{
+			Enabled:         true,
+			StalenessWindow: time.Hour,
+			Exemplars:       prometheus.ExemplarsConfig{Enabled: true, MaxPerSeries: 2},
+		}, now)
+
+		timeSeries := telemetryStore.Mock().ExpectPrepareBatch(insertTimeSeriesQuery)
+		timeSeries.ExpectAppend()
+		timeSeries.ExpectSend()
+
+		samples := telemetryStore.Mock().ExpectPrepareBatch(insertSamplesQuery)
+		samples.ExpectAppend()
+		samples.ExpectSend()
+
+		// The stale exemplar and the oldest of the other ones are dropped.
+		exemplars := telemetryStore.Mock().ExpectPrepareBatch(insertExemplarsQuery)
+		exemplars.ExpectAppend()
+		exemplars.ExpectAppend()
+		exemplars.ExpectSend()
+
+		exemplar := func(ts time.Time) prompb.Exemplar {
+			return prompb.Exemplar{Labels: []prompb.Label{{Name: prometheus.ExemplarTraceIDLabel, Value: "0af7651916cd43dd8448eb211c80319c"}}, Value: 0.42, Timestamp: ts.UnixMilli()}
+		}
+
+		err := writer.Write(context.Background(), &prompb.WriteRequest{
+			Timeseries: []prompb.TimeSeries{
+				{
+					Labels:    []prompb.Label{{Name: "__name__", Value: "http_duration_bucket"}, {Name: "le", Value: "0.5"}},
+					Samples:   []prompb.Sample{{Timestamp: now.UnixMilli(), Value: 3}},
+					Exemplars: []prompb.Exemplar{exemplar(now), exemplar(now.Add(-2 * time.Hour)), exemplar(now.Add(-2 * time.Minute)), exemplar(now.Add(-time.Minute))},
+				},
+			},
+		})
+		require.NoError(t, err)
+		assert.NoError(t, telemetryStore.Mock().ExpectationsWereMet())
+	})
+
+	t.Run("LimitsCardinality", func(t *testing.T) {
+		telemetryStore := telemetrystoretest.New(telemetrystore.Config{
+			Provider:    "clickhouse",
+			Cardinality: telemetrystore.CardinalityConfig{Enabled: true, Window: time.Hour, MaxSeriesPerMetric: 1, Action: telemetrystore.CardinalityActionReject},
+		}, sqlmock.QueryMatcherEqual)
+		writer, err := newWriter(factory.NewScopedProviderSettings(factorytest.NewSettings(), "github.com/SigNoz/signoz/pkg/prometheus/clickhouseprometheus"), telemetryStore, config)
+		require.NoError(t, err)
+		writer.now = func() time.Time { return now }
+
+		// Only the first series of the metric is written.
+		timeSeries := telemetryStore.Mock().ExpectPrepareBatch(insertTimeSeriesQuery)
+		timeSeries.ExpectAppend()
+		timeSeries.ExpectSend()
+
+		samples := telemetryStore.Mock().ExpectPrepareBatch(insertSamplesQuery)
+		samples.ExpectAppend()
+		samples.ExpectSend()
+
+		err = writer.Write(context.Background(), &prompb.WriteRequest{
+			Timeseries: []prompb.TimeSeries{
+				{
+					Labels:  []prompb.Label{{Name: "__name__", Value: "http_requests_total"}, {Name: "request_id", Value: "1"}},
+					Samples: []prompb.Sample{{Timestamp: now.UnixMilli(), Value: 1}},
+				},
+				{
+					Labels:  []prompb.Label{{Name: "__name__", Value: "http_requests_total"}, {Name: "request_id", Value: "2"}},
+					Samples: []prompb.Sample{{Timestamp: now.UnixMilli(), Value: 1}},
+				},
+			},
+		})
+		assert.True(t, errors.Asc(err, telemetrystore.ErrCodeCardinalityLimited))
+		assert.NoError(t, telemetryStore.Mock().ExpectationsWereMet())
+	})
+
+	t.Run("LimitsIngestion", func(t *testing.T) {
+		telemetryStore := telemetrystoretest.New(telemetrystore.Config{
+			Provider:  "clickhouse",
+			Ingestion: telemetrystore.IngestionConfig{Enabled: true, Window: time.Second, Default: telemetrystore.IngestionLimit{SamplesPerSecond: 1}},
+		}, sqlmock.QueryMatcherEqual)
+		writer, err := newWriter(factory.NewScopedProviderSettings(factorytest.NewSettings(), "github.com/SigNoz/signoz/pkg/prometheus/clickhouseprometheus"), telemetryStore, config)
+		require.NoError(t, err)
+		writer.now = func() time.Time { return now }
+
+		// Nothing is written when the request is over the limit.
+		err = writer.Write(context.Background(), &prompb.WriteRequest{
+			Timeseries: []prompb.TimeSeries{
+				{
+					Labels:  []prompb.Label{{Name: "__name__", Value: "up"}},
+					Samples: []prompb.Sample{{Timestamp: now.Add(-time.Second).UnixMilli(), Value: 1}, {Timestamp: now.UnixMilli(), Value: 1}},
+				},
+			},
+		})
+		assert.True(t, errors.Asc(err, telemetrystore.ErrCodeIngestionLimited))
+		assert.True(t, errors.Ast(err, errors.TypeTooManyRequests))
+		assert.NoError(t, telemetryStore.Mock().ExpectationsWereMet())
+	})
+
+	t.Run("AllStale", func(t *testing.T) {
+		writer, telemetryStore := newTestWriter(t, config, now)
+
+		err := writer.Write(context.Background(), &prompb.WriteRequest{
+			Timeseries: []prompb.TimeSeries{
+				{
+					Labels:  []prompb.Label{{Name: "__name__", Value: "up"}},
+					Samples: []prompb.Sample{{Timestamp: now.Add(-2 * time.Hour).UnixMilli(), Value: 1}},
+				},
+			},
+		})
+		require.NoError(t, err)
+		assert.NoError(t, telemetryStore.Mock().ExpectationsWereMet())
+	})
+}
+
+func TestNewSeries(t *testing.T) {
+	a, err := newSeries([]prompb.Label{{Name: "__name__", Value: "up"}, {Name: "job", Value: "node"}}, nil)
+	require.NoError(t, err)
+
+	b, err := newSeries([]prompb.Label{{Name: "job", Value: "node"}, {Name: "__name__", Value: "up"}}, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, "up", a.metricName)
+	assert.Equal(t, a.fingerprint, b.fingerprint)
+	assert.Equal(t, map[string]string{"job": "node"}, a.attrs)
+	assert.JSONEq(t, `{"__name__":"up","job":"node"}`, a.labels)
+}
+
+func TestWriterRetainedExemplars(t *testing.T) {
+	now := time.Date(2025, 1, 1, 10, 30, 0, 0, time.UTC)
+	exemplars := []prompb.Exemplar{{Timestamp: 3}, {Timestamp: 1}, {Timestamp: 2}}
+
+	writer, _ := newTestWriter(t, prometheus.RemoteWriteConfig{Exemplars: prometheus.ExemplarsConfig{Enabled: true, MaxPerSeries: 2}}, now)
+	assert.Equal(t, []prompb.Exemplar{{Timestamp: 2}, {Timestamp: 3}}, writer.retainedExemplars(exemplars, 0))
+	assert.Equal(t, []prompb.Exemplar{{Timestamp: 3}}, writer.retainedExemplars(exemplars, 3))
+
+	writer, _ = newTestWriter(t, prometheus.RemoteWriteConfig{}, now)
+	assert.Nil(t, writer.retainedExemplars(exemplars, 0))
+}
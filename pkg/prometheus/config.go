@@ -1,6 +1,12 @@
 package prometheus
 
-import "github.com/SigNoz/signoz/pkg/factory"
+import (
+	"net/url"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/factory"
+)
 
 type ActiveQueryTrackerConfig struct {
 	Enabled       bool   `mapstructure:"enabled"`
@@ -8,8 +14,75 @@ type ActiveQueryTrackerConfig struct {
 	MaxConcurrent int    `mapstructure:"max_concurrent"`
 }
 
+type RemoteWriteConfig struct {
+	// Enabled turns on the remote write receiver.
+	Enabled bool `mapstructure:"enabled"`
+	// StalenessWindow is the maximum age of a sample accepted by the receiver. Older samples are rejected.
+	StalenessWindow time.Duration `mapstructure:"staleness_window"`
+	// MaxRequestSize is the maximum size in bytes of a compressed write request.
+	MaxRequestSize int64 `mapstructure:"max_request_size"`
+	// MaxDecodedSize is the maximum size in bytes of a write request once decompressed.
+	MaxDecodedSize int64 `mapstructure:"max_decoded_size"`
+	// Exemplars configures the ingestion of the exemplars of the series.
+	Exemplars ExemplarsConfig `mapstructure:"exemplars"`
+}
+
+type ExemplarsConfig struct {
+	// Enabled turns on the ingestion and the reads of exemplars, they are dropped otherwise. The exemplars are
+	// stored in the distributed_exemplars table of the metrics database, which must be created by the schema
+	// migrations of the collector before enabling them.
+	Enabled bool `mapstructure:"enabled"`
+	// MaxPerSeries is the maximum number of exemplars retained for a series in a write request, the most recent
+	// ones are kept. Every bucket of a histogram is a series of its own.
+	MaxPerSeries int `mapstructure:"max_per_series"`
+	// MaxPerQuery is the maximum number of exemplars returned for a query, the most recent ones are kept.
+	MaxPerQuery int `mapstructure:"max_per_query"`
+}
+
+type CacheConfig struct {
+	// Enabled turns on the caching of the results of range queries.
+	Enabled bool `mapstructure:"enabled"`
+	// TTL is the time to live of the cached results.
+	TTL time.Duration `mapstructure:"ttl"`
+	// FluxInterval is the duration before now for which results are not cached as samples may still be arriving.
+	FluxInterval time.Duration `mapstructure:"flux_interval"`
+}
+
+type DownsamplingConfig struct {
+	// Enabled turns on the downsampling of the range queries returning too many points per series. The queries
+	// asking for their raw resolution are never downsampled.
+	Enabled bool `mapstructure:"enabled"`
+	// MaxPoints is the maximum number of points per series returned by a downsampled range query.
+	MaxPoints int `mapstructure:"max_points"`
+}
+
+type BackendsConfig struct {
+	// Endpoints are the remote read urls of the Prometheus compatible backends queried alongside ClickHouse. The
+	// backends are replicas of the same data, every query is routed to one of them.
+	Endpoints []string `mapstructure:"endpoints"`
+	// Timeout is the timeout of a query of a backend.
+	Timeout time.Duration `mapstructure:"timeout"`
+	// MaxFailures is the number of consecutive failed queries after which a backend is ejected.
+	MaxFailures int `mapstructure:"max_failures"`
+	// HealthCheck is the health check of the backends.
+	HealthCheck BackendsHealthCheckConfig `mapstructure:"health_check"`
+}
+
+type BackendsHealthCheckConfig struct {
+	// Path is the path of the health endpoint of the backends, relative to the host of their endpoints.
+	Path string `mapstructure:"path"`
+	// Interval is the interval at which the backends are probed. The ejected backends are restored once healthy.
+	Interval time.Duration `mapstructure:"interval"`
+	// Timeout is the timeout of a probe.
+	Timeout time.Duration `mapstructure:"timeout"`
+}
+
 type Config struct {
 	ActiveQueryTrackerConfig ActiveQueryTrackerConfig `mapstructure:"active_query_tracker"`
+	RemoteWrite              RemoteWriteConfig        `mapstructure:"remote_write"`
+	Cache                    CacheConfig              `mapstructure:"cache"`
+	Downsampling             DownsamplingConfig       `mapstructure:"downsampling"`
+	Backends                 BackendsConfig           `mapstructure:"backends"`
 }
 
 func NewConfigFactory() factory.ConfigFactory {
@@ -23,10 +96,101 @@ func newConfig() factory.Config {
 			Path:          "",
 			MaxConcurrent: 20,
 		},
+		RemoteWrite: RemoteWriteConfig{
+			Enabled:         false,
+			StalenessWindow: time.Hour,
+			MaxRequestSize:  32 << 20,
+			MaxDecodedSize:  128 << 20,
+			Exemplars: ExemplarsConfig{
+				Enabled:      false,
+				MaxPerSeries: 10,
+				MaxPerQuery:  1000,
+			},
+		},
+		Cache: CacheConfig{
+			Enabled:      false,
+			TTL:          time.Hour,
+			FluxInterval: 5 * time.Minute,
+		},
+		Downsampling: DownsamplingConfig{
+			Enabled:   true,
+			MaxPoints: 2000,
+		},
+		Backends: BackendsConfig{
+			Endpoints:   []string{},
+			Timeout:     time.Minute,
+			MaxFailures: 3,
+			HealthCheck: BackendsHealthCheckConfig{
+				Path:     "/-/healthy",
+				Interval: 10 * time.Second,
+				Timeout:  5 * time.Second,
+			},
+		},
 	}
 }
 
 func (c Config) Validate() error {
+	if c.RemoteWrite.StalenessWindow <= 0 {
+		return errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "remote_write::staleness_window must be greater than 0")
+	}
+
+	if c.RemoteWrite.MaxRequestSize <= 0 {
+		return errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "remote_write::max_request_size must be greater than 0")
+	}
+
+	if c.RemoteWrite.MaxDecodedSize <= 0 {
+		return errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "remote_write::max_decoded_size must be greater than 0")
+	}
+
+	if c.RemoteWrite.Exemplars.Enabled {
+		if c.RemoteWrite.Exemplars.MaxPerSeries <= 0 {
+			return errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "remote_write::exemplars::max_per_series must be greater than 0")
+		}
+
+		if c.RemoteWrite.Exemplars.MaxPerQuery <= 0 {
+			return errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "remote_write::exemplars::max_per_query must be greater than 0")
+		}
+	}
+
+	if c.Cache.Enabled {
+		if c.Cache.TTL <= 0 {
+			return errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "cache::ttl must be greater than 0")
+		}
+
+		if c.Cache.FluxInterval < 0 {
+			return errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "cache::flux_interval must not be negative")
+		}
+	}
+
+	if c.Downsampling.Enabled && c.Downsampling.MaxPoints < 2 {
+		return errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "downsampling::max_points must be at least 2")
+	}
+
+	if len(c.Backends.Endpoints) > 0 {
+		for _, endpoint := range c.Backends.Endpoints {
+			u, err := url.Parse(endpoint)
+			if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+				return errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "backends::endpoints must be http or https urls, got %q", endpoint)
+			}
+		}
+
+		if c.Backends.Timeout <= 0 {
+			return errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "backends::timeout must be greater than 0")
+		}
+
+		if c.Backends.MaxFailures <= 0 {
+			return errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "backends::max_failures must be greater than 0")
+		}
+
+		if c.Backends.HealthCheck.Interval <= 0 {
+			return errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "backends::health_check::interval must be greater than 0")
+		}
+
+		if c.Backends.HealthCheck.Timeout <= 0 {
+			return errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "backends::health_check::timeout must be greater than 0")
+		}
+	}
+
 	return nil
 }
 
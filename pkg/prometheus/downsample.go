@@ -0,0 +1,90 @@
+package prometheus
+
+import (
+	"strings"
+	"time"
+
+	"github.com/prometheus/prometheus/promql/parser"
+)
+
+const (
+	DownsamplingAggregationAvg string = "avg"
+	DownsamplingAggregationMax string = "max"
+	DownsamplingAggregationMin string = "min"
+)
+
+// Downsampling is a range query downsampled so that it returns at most the maximum number of points per series.
+type Downsampling struct {
+	// Query is the query to evaluate. It aggregates the steps of the original query over every step of the
+	// downsampled query, or is the original query when it is not downsampled.
+	Query string
+	// Step is the step at which the query is evaluated.
+	Step time.Duration
+	// Aggregation is the aggregation of the steps of the original query, empty when the query is not downsampled.
+	Aggregation string
+}
+
+// Downsample downsamples the range query when it returns more points per series than the maximum of the config.
+// The step of the downsampled query is the smallest multiple of the original step keeping the points under the
+// maximum, and every downsampled point aggregates the points of the original query over its step with the
+// aggregation matching the query: max for the maxima and the quantiles, whose peaks matter, min for the minima and
+// avg otherwise. Queries which cannot be aggregated, such as scalar ones, are only evaluated at the larger step.
+func Downsample(config DownsamplingConfig, query string, start time.Time, end time.Time, step time.Duration) Downsampling {
+	downsampling := Downsampling{Query: query, Step: step}
+	if !config.Enabled || step <= 0 || !end.After(start) {
+		return downsampling
+	}
+
+	// The steps are counted from start so the range holds range/step+1 points.
+	factor := int64(end.Sub(start)-1)/(int64(config.MaxPoints-1)*int64(step)) + 1
+	if factor <= 1 {
+		return downsampling
+	}
+
+	downsampling.Step = time.Duration(factor) * step
+
+	expr, err := parser.ParseExpr(query)
+	if err != nil || expr.Type() != parser.ValueTypeVector {
+		return downsampling
+	}
+
+	aggregation := downsamplingAggregation(expr)
+	downsampling.Query = (&parser.Call{
+		Func: parser.Functions[aggregation+"_over_time"],
+		Args: parser.Expressions{&parser.SubqueryExpr{Expr: &parser.ParenExpr{Expr: expr}, Range: downsampling.Step, Step: step}},
+	}).String()
+	downsampling.Aggregation = aggregation
+
+	return downsampling
+}
+
+// downsamplingAggregation returns the aggregation of the points of the query preserving its meaning.
+func downsamplingAggregation(expr parser.Expr) string {
+	switch expr := expr.(type) {
+	case *parser.ParenExpr:
+		return downsamplingAggregation(expr.Expr)
+	case *parser.AggregateExpr:
+		switch expr.Op {
+		case parser.MAX, parser.TOPK:
+			return DownsamplingAggregationMax
+		case parser.MIN, parser.BOTTOMK:
+			return DownsamplingAggregationMin
+		}
+	case *parser.Call:
+		switch expr.Func.Name {
+		case "max_over_time", "histogram_quantile", "quantile_over_time":
+			return DownsamplingAggregationMax
+		case "min_over_time":
+			return DownsamplingAggregationMin
+		}
+	case *parser.VectorSelector:
+		switch {
+		case strings.HasSuffix(expr.Name, "_max"):
+			return DownsamplingAggregationMax
+		case strings.HasSuffix(expr.Name, "_min"):
+			return DownsamplingAggregationMin
+		}
+	}
+
+	return DownsamplingAggregationAvg
+}
@@ -0,0 +1,110 @@
+package prometheus_test
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/prometheus"
+	"github.com/SigNoz/signoz/pkg/prometheus/prometheustest"
+	"github.com/SigNoz/signoz/pkg/valuer"
+	"github.com/prometheus/prometheus/prompb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDownsample(t *testing.T) {
+	config := prometheus.DownsamplingConfig{Enabled: true, MaxPoints: 11}
+	start := time.Unix(0, 0)
+
+	testCases := []struct {
+		name     string
+		query    string
+		end      time.Time
+		expected prometheus.Downsampling
+	}{
+		{
+			name:     "UnderMaxPoints",
+			query:    "rate(http_requests_total[5m])",
+			end:      start.Add(150 * time.Second),
+			expected: prometheus.Downsampling{Query: "rate(http_requests_total[5m])", Step: 15 * time.Second},
+		},
+		{
+			name:     "Avg",
+			query:    "rate(http_requests_total[5m])",
+			end:      start.Add(300 * time.Second),
+			expected: prometheus.Downsampling{Query: "avg_over_time((rate(http_requests_total[5m]))[30s:15s])", Step: 30 * time.Second, Aggregation: prometheus.DownsamplingAggregationAvg},
+		},
+		{
+			name:     "Max",
+			query:    "max by (service) (container_memory_usage_bytes)",
+			end:      start.Add(time.Hour),
+			expected: prometheus.Downsampling{Query: "max_over_time((max by (service) (container_memory_usage_bytes))[6m:15s])", Step: 6 * time.Minute, Aggregation: prometheus.DownsamplingAggregationMax},
+		},
+		{
+			name:     "Quantile",
+			query:    "histogram_quantile(0.99, sum by (le) (rate(latency_bucket[5m])))",
+			end:      start.Add(time.Hour),
+			expected: prometheus.Downsampling{Query: "max_over_time((histogram_quantile(0.99, sum by (le) (rate(latency_bucket[5m]))))[6m:15s])", Step: 6 * time.Minute, Aggregation: prometheus.DownsamplingAggregationMax},
+		},
+		{
+			name:     "Min",
+			query:    "(min(node_filesystem_free_bytes))",
+			end:      start.Add(time.Hour),
+			expected: prometheus.Downsampling{Query: "min_over_time(((min(node_filesystem_free_bytes)))[6m:15s])", Step: 6 * time.Minute, Aggregation: prometheus.DownsamplingAggregationMin},
+		},
+		{
+			name:     "Scalar",
+			query:    "scalar(up)",
+			end:      start.Add(time.Hour),
+			expected: prometheus.Downsampling{Query: "scalar(up)", Step: 6 * time.Minute},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, prometheus.Downsample(config, tc.query, start, tc.end, 15*time.Second))
+		})
+	}
+
+	config.Enabled = false
+	assert.Equal(t, prometheus.Downsampling{Query: "up", Step: 15 * time.Second}, prometheus.Downsample(config, "up", start, start.Add(time.Hour), 15*time.Second))
+}
+
+func TestDownsampleRangeQuery(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	provider := prometheustest.New(logger, prometheus.Config{Downsampling: prometheus.DownsamplingConfig{Enabled: true, MaxPoints: 5}})
+	t.Cleanup(func() { assert.NoError(t, provider.Close()) })
+
+	base := time.Now().Add(-time.Hour).Truncate(time.Hour)
+	samples := []prompb.Sample{}
+	for i := 0; i <= 8; i++ {
+		samples = append(samples, prompb.Sample{Timestamp: base.Add(time.Duration(i) * time.Minute).UnixMilli(), Value: float64(i)})
+	}
+
+	require.NoError(t, provider.Write(context.Background(), &prompb.WriteRequest{
+		Timeseries: []prompb.TimeSeries{{Labels: []prompb.Label{{Name: "__name__", Value: "queue_size_max"}}, Samples: samples}},
+	}))
+
+	downsampling := provider.Downsample("queue_size_max", base, base.Add(8*time.Minute), time.Minute)
+	assert.Equal(t, 2*time.Minute, downsampling.Step)
+	assert.Equal(t, prometheus.DownsamplingAggregationMax, downsampling.Aggregation)
+
+	matrix, _, err := provider.RangeQuery(context.Background(), valuer.GenerateUUID(), &prometheus.RangeQueryParams{
+		Query: downsampling.Query,
+		Start: base,
+		End:   base.Add(8 * time.Minute),
+		Step:  downsampling.Step,
+	})
+	require.NoError(t, err)
+	require.Len(t, matrix, 1)
+
+	// Every point is the maximum of the raw points of its step.
+	values := make([]float64, 0, len(matrix[0].Floats))
+	for _, point := range matrix[0].Floats {
+		values = append(values, point.F)
+	}
+	assert.Equal(t, []float64{0, 2, 4, 6, 8}, values)
+}
@@ -0,0 +1,59 @@
+package prometheus
+
+import (
+	"context"
+	"strings"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/model/exemplar"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/promql/parser"
+	"github.com/prometheus/prometheus/storage"
+)
+
+const (
+	// ExemplarTraceIDLabel and ExemplarSpanIDLabel are the labels of an exemplar linking it to the span it was
+	// recorded in.
+	ExemplarTraceIDLabel string = "trace_id"
+	ExemplarSpanIDLabel  string = "span_id"
+)
+
+// bucketSuffix is the suffix of the names of the bucket series of the classic histograms.
+const bucketSuffix = "_bucket"
+
+// HistogramExemplars returns the exemplars, between start and end in milliseconds, of the buckets of the classic
+// histograms selected by the query. It returns nothing for the queries which do not select histogram buckets.
+func HistogramExemplars(ctx context.Context, queryable storage.ExemplarQueryable, query string, start int64, end int64) ([]exemplar.QueryResult, error) {
+	expr, err := parser.ParseExpr(query)
+	if err != nil {
+		return nil, errors.Wrapf(err, errors.TypeInvalidInput, errors.CodeInvalidInput, "invalid promql query %q", query)
+	}
+
+	selectors := histogramSelectors(expr)
+	if len(selectors) == 0 {
+		return nil, nil
+	}
+
+	querier, err := queryable.ExemplarQuerier(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return querier.Select(start, end, selectors...)
+}
+
+// histogramSelectors returns the selectors of the expression on the buckets of classic histograms.
+func histogramSelectors(expr parser.Expr) [][]*labels.Matcher {
+	var selectors [][]*labels.Matcher
+	for _, matchers := range parser.ExtractSelectors(expr) {
+		for _, matcher := range matchers {
+			if matcher.Name == model.MetricNameLabel && matcher.Type == labels.MatchEqual && strings.HasSuffix(matcher.Value, bucketSuffix) {
+				selectors = append(selectors, matchers)
+				break
+			}
+		}
+	}
+
+	return selectors
+}
@@ -0,0 +1,58 @@
+package prometheus_test
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/prometheus"
+	"github.com/SigNoz/signoz/pkg/prometheus/prometheustest"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/prompb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHistogramExemplars(t *testing.T) {
+	provider := prometheustest.New(slog.New(slog.NewTextHandler(io.Discard, nil)), prometheus.Config{})
+	t.Cleanup(func() { assert.NoError(t, provider.Close()) })
+
+	now := time.Now().UnixMilli()
+	err := provider.Write(context.Background(), &prompb.WriteRequest{
+		Timeseries: []prompb.TimeSeries{
+			{
+				Labels:  []prompb.Label{{Name: "__name__", Value: "http_duration_bucket"}, {Name: "le", Value: "0.5"}},
+				Samples: []prompb.Sample{{Timestamp: now, Value: 3}},
+				Exemplars: []prompb.Exemplar{{
+					Labels:    []prompb.Label{{Name: prometheus.ExemplarTraceIDLabel, Value: "0af7651916cd43dd8448eb211c80319c"}},
+					Value:     0.42,
+					Timestamp: now,
+				}},
+			},
+			{
+				Labels:    []prompb.Label{{Name: "__name__", Value: "http_requests_total"}},
+				Samples:   []prompb.Sample{{Timestamp: now, Value: 3}},
+				Exemplars: []prompb.Exemplar{{Labels: []prompb.Label{{Name: prometheus.ExemplarTraceIDLabel, Value: "b7ad6b7169203331"}}, Value: 1, Timestamp: now}},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	results, err := provider.HistogramExemplars(context.Background(), "histogram_quantile(0.9, sum by (le) (rate(http_duration_bucket[5m])))", now-time.Minute.Milliseconds(), now)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, labels.FromStrings("__name__", "http_duration_bucket", "le", "0.5"), results[0].SeriesLabels)
+	require.Len(t, results[0].Exemplars, 1)
+	assert.Equal(t, "0af7651916cd43dd8448eb211c80319c", results[0].Exemplars[0].Labels.Get(prometheus.ExemplarTraceIDLabel))
+	assert.Equal(t, 0.42, results[0].Exemplars[0].Value)
+
+	// The exemplars of the series other than histogram buckets are not returned.
+	results, err = provider.HistogramExemplars(context.Background(), "rate(http_requests_total[5m])", now-time.Minute.Milliseconds(), now)
+	require.NoError(t, err)
+	assert.Empty(t, results)
+
+	_, err = provider.HistogramExemplars(context.Background(), "rate(", now-time.Minute.Milliseconds(), now)
+	assert.Error(t, err)
+}
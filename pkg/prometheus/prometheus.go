@@ -1,8 +1,22 @@
 package prometheus
 
 import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/valuer"
+	"github.com/prometheus/prometheus/model/exemplar"
+	"github.com/prometheus/prometheus/prompb"
 	"github.com/prometheus/prometheus/promql"
 	"github.com/prometheus/prometheus/storage"
+	"github.com/prometheus/prometheus/util/annotations"
+)
+
+var (
+	ErrCodeRemoteWriteDisabled = errors.MustNewCode("remote_write_disabled")
+	ErrCodeInvalidWriteRequest = errors.MustNewCode("invalid_write_request")
 )
 
 type Engine = promql.Engine
@@ -10,4 +24,15 @@ type Engine = promql.Engine
 type Prometheus interface {
 	Engine() *Engine
 	Storage() storage.Queryable
+	// DecodeWriteRequest reads a remote write request within the configured size limits.
+	DecodeWriteRequest(io.Reader) (*prompb.WriteRequest, error)
+	// Write persists the samples of a remote write request.
+	Write(context.Context, *prompb.WriteRequest) error
+	// RangeQuery evaluates the range query of the org, reusing the steps cached by previous queries.
+	RangeQuery(context.Context, valuer.UUID, *RangeQueryParams) (promql.Matrix, annotations.Annotations, error)
+	// HistogramExemplars returns the exemplars, between start and end in milliseconds, of the histogram buckets
+	// selected by the query.
+	HistogramExemplars(ctx context.Context, query string, start int64, end int64) ([]exemplar.QueryResult, error)
+	// Downsample downsamples the range query when it returns more points per series than configured.
+	Downsample(query string, start time.Time, end time.Time, step time.Duration) Downsampling
 }
@@ -1,21 +1,32 @@
 package prometheustest
 
 import (
+	"context"
+	"io"
 	"log/slog"
 	"os"
 	"time"
 
 	"github.com/SigNoz/signoz/pkg/prometheus"
+	"github.com/SigNoz/signoz/pkg/valuer"
+	"github.com/prometheus/prometheus/model/exemplar"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/prompb"
+	"github.com/prometheus/prometheus/promql"
 	"github.com/prometheus/prometheus/storage"
 	"github.com/prometheus/prometheus/tsdb"
+	"github.com/prometheus/prometheus/util/annotations"
 )
 
 var _ prometheus.Prometheus = (*Provider)(nil)
 
 type Provider struct {
-	db     *tsdb.DB
-	dir    string
-	engine *prometheus.Engine
+	db           *tsdb.DB
+	dir          string
+	engine       *prometheus.Engine
+	queryCache   *prometheus.QueryCache
+	downsampling prometheus.DownsamplingConfig
+	remoteWrite  prometheus.RemoteWriteConfig
 }
 
 func New(logger *slog.Logger, cfg prometheus.Config, outOfOrderTimeWindow ...int64) *Provider {
@@ -31,6 +42,8 @@ func New(logger *slog.Logger, cfg prometheus.Config, outOfOrderTimeWindow ...int
 	opts.MaxBlockDuration = int64(24 * time.Hour / time.Millisecond)
 	opts.RetentionDuration = 0
 	opts.EnableNativeHistograms = true
+	opts.EnableExemplarStorage = true
+	opts.MaxExemplars = 100000
 
 	// Set OutOfOrderTimeWindow if provided, otherwise use default (0)
 	if len(outOfOrderTimeWindow) > 0 {
@@ -47,9 +60,12 @@ func New(logger *slog.Logger, cfg prometheus.Config, outOfOrderTimeWindow ...int
 	engine := prometheus.NewEngine(logger, cfg)
 
 	return &Provider{
-		db:     db,
-		dir:    dir,
-		engine: engine,
+		db:           db,
+		dir:          dir,
+		engine:       engine,
+		queryCache:   prometheus.NewQueryCache(logger, nil, cfg.Cache),
+		downsampling: cfg.Downsampling,
+		remoteWrite:  cfg.RemoteWrite,
 	}
 }
 
@@ -61,6 +77,58 @@ func (provider *Provider) Storage() storage.Queryable {
 	return provider.db
 }
 
+func (provider *Provider) RangeQuery(ctx context.Context, orgID valuer.UUID, params *prometheus.RangeQueryParams) (promql.Matrix, annotations.Annotations, error) {
+	return provider.queryCache.RangeQuery(ctx, provider.engine, provider.db, orgID, params)
+}
+
+func (provider *Provider) Downsample(query string, start time.Time, end time.Time, step time.Duration) prometheus.Downsampling {
+	return prometheus.Downsample(provider.downsampling, query, start, end, step)
+}
+
+func (provider *Provider) HistogramExemplars(ctx context.Context, query string, start int64, end int64) ([]exemplar.QueryResult, error) {
+	return prometheus.HistogramExemplars(ctx, provider.db, query, start, end)
+}
+
+func (provider *Provider) DecodeWriteRequest(r io.Reader) (*prompb.WriteRequest, error) {
+	return prometheus.DecodeWriteRequest(r, provider.remoteWrite)
+}
+
+func (provider *Provider) Write(ctx context.Context, req *prompb.WriteRequest) error {
+	appender := provider.db.Appender(ctx)
+	for _, ts := range req.Timeseries {
+		builder := labels.NewScratchBuilder(len(ts.Labels))
+		for _, label := range ts.Labels {
+			builder.Add(label.Name, label.Value)
+		}
+		builder.Sort()
+
+		lbls := builder.Labels()
+		var ref storage.SeriesRef
+		for _, sample := range ts.Samples {
+			var err error
+			if ref, err = appender.Append(ref, lbls, sample.Timestamp, sample.Value); err != nil {
+				_ = appender.Rollback()
+				return err
+			}
+		}
+
+		for _, e := range ts.Exemplars {
+			exemplarBuilder := labels.NewScratchBuilder(len(e.Labels))
+			for _, label := range e.Labels {
+				exemplarBuilder.Add(label.Name, label.Value)
+			}
+			exemplarBuilder.Sort()
+
+			if _, err := appender.AppendExemplar(ref, lbls, exemplar.Exemplar{Labels: exemplarBuilder.Labels(), Value: e.Value, Ts: e.Timestamp, HasTs: true}); err != nil {
+				_ = appender.Rollback()
+				return err
+			}
+		}
+	}
+
+	return appender.Commit()
+}
+
 func (provider *Provider) Close() error {
 	if err := provider.db.Close(); err != nil {
 		return err
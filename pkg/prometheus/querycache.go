@@ -0,0 +1,323 @@
+package prometheus
+
+import (
+	"cmp"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"log/slog"
+	"math"
+	"slices"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/cache"
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/valuer"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/promql"
+	"github.com/prometheus/prometheus/promql/parser"
+	"github.com/prometheus/prometheus/storage"
+	"github.com/prometheus/prometheus/util/annotations"
+)
+
+const (
+	queryCacheNamespace string = "prometheus"
+)
+
+// RangeQueryParams are the parameters of a range query.
+type RangeQueryParams struct {
+	Query string
+	Start time.Time
+	End   time.Time
+	Step  time.Duration
+	// NoCache evaluates the whole range of the query without reading or writing the cache.
+	NoCache bool
+}
+
+// QueryCache evaluates range queries, reusing the steps evaluated by previous queries with the same expression
+// and step. The range of a query is rounded to its step so that the evaluation timestamps of overlapping queries
+// line up, which lets a cached range be extended by evaluating only the steps missing from it.
+type QueryCache struct {
+	logger *slog.Logger
+	cache  cache.Cache
+	config CacheConfig
+	now    func() time.Time
+}
+
+// NewQueryCache returns a query cache storing its results in the given cache. Queries are evaluated without the
+// cache when it is nil or not enabled.
+func NewQueryCache(logger *slog.Logger, c cache.Cache, config CacheConfig) *QueryCache {
+	if c != nil {
+		c = c.WithNamespace(queryCacheNamespace)
+	}
+
+	return &QueryCache{
+		logger: logger,
+		cache:  c,
+		config: config,
+		now:    time.Now,
+	}
+}
+
+// cachedSeries is a series of float samples. Values are stored as strings since NaN and infinities cannot be
+// represented in JSON.
+type cachedSeries struct {
+	Metric labels.Labels `json:"metric"`
+	T      []int64       `json:"t"`
+	F      []string      `json:"f"`
+}
+
+// cachedExtent is the contiguous range of steps of a query which have been evaluated.
+type cachedExtent struct {
+	StartMs int64          `json:"startMs"`
+	EndMs   int64          `json:"endMs"`
+	Series  []cachedSeries `json:"series"`
+}
+
+func (extent *cachedExtent) MarshalBinary() ([]byte, error) {
+	return json.Marshal(extent)
+}
+
+func (extent *cachedExtent) UnmarshalBinary(data []byte) error {
+	return json.Unmarshal(data, extent)
+}
+
+func (extent *cachedExtent) matrix() (promql.Matrix, error) {
+	matrix := make(promql.Matrix, 0, len(extent.Series))
+	for _, series := range extent.Series {
+		if len(series.T) != len(series.F) {
+			return nil, errors.Newf(errors.TypeInternal, errors.CodeInternal, "cached series %s is corrupted", series.Metric.String())
+		}
+
+		floats := make([]promql.FPoint, len(series.T))
+		for i := range series.T {
+			f, err := strconv.ParseFloat(series.F[i], 64)
+			if err != nil {
+				return nil, errors.Wrapf(err, errors.TypeInternal, errors.CodeInternal, "cached series %s is corrupted", series.Metric.String())
+			}
+
+			floats[i] = promql.FPoint{T: series.T[i], F: f}
+		}
+
+		matrix = append(matrix, promql.Series{Metric: series.Metric, Floats: floats})
+	}
+
+	return matrix, nil
+}
+
+func newCachedExtent(startMs int64, endMs int64, matrix promql.Matrix) *cachedExtent {
+	extent := &cachedExtent{StartMs: startMs, EndMs: endMs, Series: make([]cachedSeries, 0, len(matrix))}
+	for _, series := range matrix {
+		cached := cachedSeries{Metric: series.Metric}
+		for _, point := range series.Floats {
+			if point.T < startMs || point.T > endMs {
+				continue
+			}
+
+			cached.T = append(cached.T, point.T)
+			cached.F = append(cached.F, strconv.FormatFloat(point.F, 'g', -1, 64))
+		}
+
+		if len(cached.T) > 0 {
+			extent.Series = append(extent.Series, cached)
+		}
+	}
+
+	return extent
+}
+
+// RangeQuery evaluates the query over the range of the params, rounded to its step. Errors returned by the
+// engine are returned as is.
+func (queryCache *QueryCache) RangeQuery(ctx context.Context, engine *Engine, queryable storage.Queryable, orgID valuer.UUID, params *RangeQueryParams) (promql.Matrix, annotations.Annotations, error) {
+	if params.NoCache || !queryCache.config.Enabled || queryCache.cache == nil || params.Step <= 0 || !isCacheable(params.Query) {
+		return rangeQuery(ctx, engine, queryable, params.Query, params.Start.UnixMilli(), params.End.UnixMilli(), params.Step)
+	}
+
+	stepMs := params.Step.Milliseconds()
+	startMs := floor(params.Start.UnixMilli(), stepMs)
+	endMs := floor(params.End.UnixMilli(), stepMs)
+	// Steps which are still in flux are evaluated but never cached.
+	cacheableEndMs := min(endMs, floor(queryCache.now().Add(-queryCache.config.FluxInterval).UnixMilli(), stepMs))
+
+	key := cacheKey(params.Query, params.Step)
+
+	var extent cachedExtent
+	var cached promql.Matrix
+	extentStartMs, extentEndMs := startMs, cacheableEndMs
+	missing := [][2]int64{{startMs, endMs}}
+
+	if err := queryCache.cache.Get(ctx, orgID, key, &extent, false); err == nil {
+		// The cached extent can only be extended if it is contiguous with the range of the query.
+		if extent.StartMs <= endMs+stepMs && extent.EndMs >= startMs-stepMs {
+			cached, err = extent.matrix()
+			if err != nil {
+				queryCache.logger.ErrorContext(ctx, "failed to decode cached query result", "query", params.Query, "error", err)
+				cached = nil
+			} else {
+				missing = missing[:0]
+				if startMs < extent.StartMs {
+					missing = append(missing, [2]int64{startMs, min(endMs, extent.StartMs-stepMs)})
+				}
+
+				if endMs > extent.EndMs {
+					missing = append(missing, [2]int64{max(startMs, extent.EndMs+stepMs), endMs})
+				}
+
+				extentStartMs, extentEndMs = min(startMs, extent.StartMs), max(cacheableEndMs, extent.EndMs)
+			}
+		}
+	} else if !errors.Ast(err, errors.TypeNotFound) {
+		queryCache.logger.ErrorContext(ctx, "failed to get cached query result", "query", params.Query, "error", err)
+	}
+
+	matrices := []promql.Matrix{cached}
+	var warnings annotations.Annotations
+	cacheable := true
+	for _, r := range missing {
+		matrix, ws, err := rangeQuery(ctx, engine, queryable, params.Query, r[0], r[1], params.Step)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		for _, series := range matrix {
+			if len(series.Histograms) > 0 {
+				cacheable = false
+			}
+		}
+
+		matrices = append(matrices, matrix)
+		warnings = warnings.Merge(ws)
+	}
+
+	merged := mergeMatrices(matrices...)
+
+	// Results with warnings may be partial and results with histograms cannot be stored.
+	if len(missing) > 0 && cacheable && !hasWarnings(warnings) && extentEndMs >= extentStartMs {
+		if err := queryCache.cache.Set(ctx, orgID, key, newCachedExtent(extentStartMs, extentEndMs, merged), queryCache.config.TTL); err != nil {
+			queryCache.logger.ErrorContext(ctx, "failed to cache query result", "query", params.Query, "error", err)
+		}
+	}
+
+	return truncateMatrix(merged, startMs, endMs), warnings, nil
+}
+
+func rangeQuery(ctx context.Context, engine *Engine, queryable storage.Queryable, query string, startMs int64, endMs int64, step time.Duration) (promql.Matrix, annotations.Annotations, error) {
+	qry, err := engine.NewRangeQuery(ctx, queryable, nil, query, time.UnixMilli(startMs), time.UnixMilli(endMs), step)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer qry.Close()
+
+	res := qry.Exec(ctx)
+	if res.Err != nil {
+		return nil, nil, res.Err
+	}
+
+	matrix, err := res.Matrix()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// The points of the matrix are returned to the pools of the engine when the query is closed.
+	cloned := make(promql.Matrix, len(matrix))
+	for i, series := range matrix {
+		cloned[i] = promql.Series{Metric: series.Metric, Floats: slices.Clone(series.Floats), Histograms: slices.Clone(series.Histograms)}
+	}
+
+	return cloned, res.Warnings, nil
+}
+
+// isCacheable returns whether the result of the query at a step only depends on the timestamp of the step. This
+// is not the case of queries using the start() or end() @ modifiers.
+func isCacheable(query string) bool {
+	expr, err := parser.ParseExpr(query)
+	if err != nil {
+		return false
+	}
+
+	cacheable := true
+	parser.Inspect(expr, func(node parser.Node, _ []parser.Node) error {
+		switch n := node.(type) {
+		case *parser.VectorSelector:
+			if n.StartOrEnd != 0 {
+				cacheable = false
+			}
+		case *parser.SubqueryExpr:
+			if n.StartOrEnd != 0 {
+				cacheable = false
+			}
+		}
+		return nil
+	})
+
+	return cacheable
+}
+
+// hasWarnings returns whether any of the annotations is more than an info.
+func hasWarnings(annots annotations.Annotations) bool {
+	for _, err := range annots {
+		if !errors.Is(err, annotations.PromQLInfo) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func cacheKey(query string, step time.Duration) string {
+	sum := sha256.Sum256([]byte(query + "&" + step.String()))
+	return "range_query:" + hex.EncodeToString(sum[:])
+}
+
+// floor rounds the timestamp down to a multiple of the step.
+func floor(ts int64, step int64) int64 {
+	return int64(math.Floor(float64(ts)/float64(step))) * step
+}
+
+// mergeMatrices merges the series with the same labels of the matrices. The matrices are expected to cover
+// disjoint ranges.
+func mergeMatrices(matrices ...promql.Matrix) promql.Matrix {
+	bySignature := make(map[string]int)
+	var merged promql.Matrix
+	for _, matrix := range matrices {
+		for _, series := range matrix {
+			signature := string(series.Metric.Bytes(nil))
+			idx, ok := bySignature[signature]
+			if !ok {
+				bySignature[signature] = len(merged)
+				merged = append(merged, promql.Series{Metric: series.Metric, Floats: series.Floats, Histograms: series.Histograms})
+				continue
+			}
+
+			merged[idx].Floats = append(merged[idx].Floats, series.Floats...)
+			merged[idx].Histograms = append(merged[idx].Histograms, series.Histograms...)
+		}
+	}
+
+	for i := range merged {
+		slices.SortFunc(merged[i].Floats, func(a, b promql.FPoint) int { return cmp.Compare(a.T, b.T) })
+		slices.SortFunc(merged[i].Histograms, func(a, b promql.HPoint) int { return cmp.Compare(a.T, b.T) })
+	}
+
+	sort.Sort(merged)
+	return merged
+}
+
+// truncateMatrix returns the points of the matrix within the range, dropping the series left without points.
+func truncateMatrix(matrix promql.Matrix, startMs int64, endMs int64) promql.Matrix {
+	truncated := make(promql.Matrix, 0, len(matrix))
+	for _, series := range matrix {
+		floats := slices.DeleteFunc(slices.Clone(series.Floats), func(p promql.FPoint) bool { return p.T < startMs || p.T > endMs })
+		histograms := slices.DeleteFunc(slices.Clone(series.Histograms), func(p promql.HPoint) bool { return p.T < startMs || p.T > endMs })
+		if len(floats) == 0 && len(histograms) == 0 {
+			continue
+		}
+
+		truncated = append(truncated, promql.Series{Metric: series.Metric, Floats: floats, Histograms: histograms})
+	}
+
+	return truncated
+}
@@ -0,0 +1,123 @@
+package prometheus_test
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/cache"
+	"github.com/SigNoz/signoz/pkg/cache/cachetest"
+	"github.com/SigNoz/signoz/pkg/prometheus"
+	"github.com/SigNoz/signoz/pkg/prometheus/prometheustest"
+	"github.com/SigNoz/signoz/pkg/valuer"
+	"github.com/prometheus/prometheus/prompb"
+	"github.com/prometheus/prometheus/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingQueryable records the minimum time of the queriers it opens.
+type recordingQueryable struct {
+	storage.Queryable
+	mints []int64
+}
+
+func (queryable *recordingQueryable) Querier(mint, maxt int64) (storage.Querier, error) {
+	queryable.mints = append(queryable.mints, mint)
+	return queryable.Queryable.Querier(mint, maxt)
+}
+
+func newTestQueryCache(t *testing.T) (*prometheustest.Provider, *prometheus.QueryCache, time.Time) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	provider := prometheustest.New(logger, prometheus.Config{})
+	t.Cleanup(func() { assert.NoError(t, provider.Close()) })
+
+	c, err := cachetest.New(cache.Config{Provider: "memory", Memory: cache.Memory{TTL: time.Hour, CleanupInterval: 10 * time.Minute}})
+	require.NoError(t, err)
+
+	// Samples are old enough to never be in flux.
+	base := time.Now().Add(-3 * time.Hour).Truncate(time.Hour)
+	samples := []prompb.Sample{}
+	for ts := base; ts.Before(base.Add(2 * time.Hour)); ts = ts.Add(15 * time.Second) {
+		samples = append(samples, prompb.Sample{Timestamp: ts.UnixMilli(), Value: float64(ts.Unix())})
+	}
+
+	require.NoError(t, provider.Write(context.Background(), &prompb.WriteRequest{
+		Timeseries: []prompb.TimeSeries{
+			{Labels: []prompb.Label{{Name: "__name__", Value: "test_metric"}, {Name: "service", Value: "a"}}, Samples: samples},
+			{Labels: []prompb.Label{{Name: "__name__", Value: "test_metric"}, {Name: "service", Value: "b"}}, Samples: samples},
+		},
+	}))
+
+	return provider, prometheus.NewQueryCache(logger, c, prometheus.CacheConfig{Enabled: true, TTL: time.Hour, FluxInterval: 5 * time.Minute}), base
+}
+
+func TestQueryCacheRangeQuery(t *testing.T) {
+	provider, queryCache, base := newTestQueryCache(t)
+	orgID := valuer.GenerateUUID()
+	query := "sum by (service) (rate(test_metric[5m]))"
+
+	params := &prometheus.RangeQueryParams{Query: query, Start: base.Add(30 * time.Minute), End: base.Add(85 * time.Minute), Step: time.Minute}
+	_, _, err := queryCache.RangeQuery(context.Background(), provider.Engine(), provider.Storage(), orgID, params)
+	require.NoError(t, err)
+
+	// The cached 55m are reused and only the tail of the 1h query is evaluated.
+	queryable := &recordingQueryable{Queryable: provider.Storage()}
+	params = &prometheus.RangeQueryParams{Query: query, Start: base.Add(30 * time.Minute), End: base.Add(90 * time.Minute), Step: time.Minute}
+	cached, _, err := queryCache.RangeQuery(context.Background(), provider.Engine(), queryable, orgID, params)
+	require.NoError(t, err)
+	require.NotEmpty(t, queryable.mints)
+	for _, mint := range queryable.mints {
+		// The first missing step minus the lookback delta and the range of the selector.
+		assert.GreaterOrEqual(t, mint, base.Add(76*time.Minute).UnixMilli())
+	}
+
+	params.NoCache = true
+	expected, _, err := queryCache.RangeQuery(context.Background(), provider.Engine(), provider.Storage(), orgID, params)
+	require.NoError(t, err)
+	require.Len(t, cached, 2)
+	assert.Equal(t, expected, cached)
+	assert.Len(t, cached[0].Floats, 61)
+}
+
+func TestQueryCacheRangeQueryHead(t *testing.T) {
+	provider, queryCache, base := newTestQueryCache(t)
+	orgID := valuer.GenerateUUID()
+	query := "test_metric"
+
+	params := &prometheus.RangeQueryParams{Query: query, Start: base.Add(30 * time.Minute), End: base.Add(60 * time.Minute), Step: time.Minute}
+	_, _, err := queryCache.RangeQuery(context.Background(), provider.Engine(), provider.Storage(), orgID, params)
+	require.NoError(t, err)
+
+	// Unaligned ranges are rounded to the step and only the head of the query is evaluated.
+	queryable := &recordingQueryable{Queryable: provider.Storage()}
+	params = &prometheus.RangeQueryParams{Query: query, Start: base.Add(10*time.Minute + 20*time.Second), End: base.Add(45*time.Minute + 10*time.Second), Step: time.Minute}
+	cached, _, err := queryCache.RangeQuery(context.Background(), provider.Engine(), queryable, orgID, params)
+	require.NoError(t, err)
+	require.NotEmpty(t, queryable.mints)
+
+	expected, _, err := queryCache.RangeQuery(context.Background(), provider.Engine(), provider.Storage(), orgID, &prometheus.RangeQueryParams{Query: query, Start: base.Add(10 * time.Minute), End: base.Add(45 * time.Minute), Step: time.Minute, NoCache: true})
+	require.NoError(t, err)
+	assert.Equal(t, expected, cached)
+}
+
+func TestQueryCacheRangeQueryNoCache(t *testing.T) {
+	provider, queryCache, base := newTestQueryCache(t)
+	orgID := valuer.GenerateUUID()
+
+	params := &prometheus.RangeQueryParams{Query: "test_metric", Start: base.Add(30 * time.Minute), End: base.Add(60 * time.Minute), Step: time.Minute, NoCache: true}
+	_, _, err := queryCache.RangeQuery(context.Background(), provider.Engine(), provider.Storage(), orgID, params)
+	require.NoError(t, err)
+
+	// Nothing has been cached by the previous query, the whole range is evaluated.
+	queryable := &recordingQueryable{Queryable: provider.Storage()}
+	params.NoCache = false
+	matrix, _, err := queryCache.RangeQuery(context.Background(), provider.Engine(), queryable, orgID, params)
+	require.NoError(t, err)
+	require.Len(t, queryable.mints, 1)
+	assert.Less(t, queryable.mints[0], base.Add(30*time.Minute).UnixMilli())
+	require.Len(t, matrix, 2)
+	assert.Len(t, matrix[0].Floats, 31)
+}
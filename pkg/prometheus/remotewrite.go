@@ -0,0 +1,78 @@
+package prometheus
+
+import (
+	"io"
+	"unicode/utf8"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/golang/snappy"
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/prompb"
+)
+
+// DecodeWriteRequest reads a snappy compressed protobuf remote write request. The request is rejected if it is larger
+// than the configured size, or if it would decompress to more than the configured decoded size, which is checked
+// from the header of the snappy block before decompressing it.
+func DecodeWriteRequest(r io.Reader, config RemoteWriteConfig) (*prompb.WriteRequest, error) {
+	compressed, err := io.ReadAll(io.LimitReader(r, config.MaxRequestSize+1))
+	if err != nil {
+		return nil, errors.Wrapf(err, errors.TypeInvalidInput, ErrCodeInvalidWriteRequest, "failed to read write request")
+	}
+
+	if int64(len(compressed)) > config.MaxRequestSize {
+		return nil, errors.Newf(errors.TypeInvalidInput, ErrCodeInvalidWriteRequest, "write request is larger than %d bytes", config.MaxRequestSize)
+	}
+
+	decodedLen, err := snappy.DecodedLen(compressed)
+	if err != nil {
+		return nil, errors.Wrapf(err, errors.TypeInvalidInput, ErrCodeInvalidWriteRequest, "failed to decompress write request")
+	}
+
+	if int64(decodedLen) > config.MaxDecodedSize {
+		return nil, errors.Newf(errors.TypeInvalidInput, ErrCodeInvalidWriteRequest, "write request decompresses to more than %d bytes", config.MaxDecodedSize)
+	}
+
+	buf, err := snappy.Decode(nil, compressed)
+	if err != nil {
+		return nil, errors.Wrapf(err, errors.TypeInvalidInput, ErrCodeInvalidWriteRequest, "failed to decompress write request")
+	}
+
+	var req prompb.WriteRequest
+	if err := req.Unmarshal(buf); err != nil {
+		return nil, errors.Wrapf(err, errors.TypeInvalidInput, ErrCodeInvalidWriteRequest, "failed to unmarshal write request")
+	}
+
+	return &req, nil
+}
+
+// ValidateLabels checks that the labels of a time series have a metric name, valid and unique label names
+// and valid utf-8 label values.
+func ValidateLabels(labels []prompb.Label) error {
+	var hasMetricName bool
+	seen := make(map[string]struct{}, len(labels))
+
+	for _, label := range labels {
+		if !model.LabelName(label.Name).IsValid() {
+			return errors.Newf(errors.TypeInvalidInput, ErrCodeInvalidWriteRequest, "invalid label name %q", label.Name)
+		}
+
+		if !utf8.ValidString(label.Value) {
+			return errors.Newf(errors.TypeInvalidInput, ErrCodeInvalidWriteRequest, "invalid value for label %q", label.Name)
+		}
+
+		if _, ok := seen[label.Name]; ok {
+			return errors.Newf(errors.TypeInvalidInput, ErrCodeInvalidWriteRequest, "duplicate label name %q", label.Name)
+		}
+		seen[label.Name] = struct{}{}
+
+		if label.Name == model.MetricNameLabel && label.Value != "" {
+			hasMetricName = true
+		}
+	}
+
+	if !hasMetricName {
+		return errors.Newf(errors.TypeInvalidInput, ErrCodeInvalidWriteRequest, "missing metric name label %q", model.MetricNameLabel)
+	}
+
+	return nil
+}
@@ -0,0 +1,53 @@
+package prometheus
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/golang/snappy"
+	"github.com/prometheus/prometheus/prompb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeWriteRequest(t *testing.T) {
+	req := &prompb.WriteRequest{
+		Timeseries: []prompb.TimeSeries{
+			{
+				Labels:  []prompb.Label{{Name: "__name__", Value: "up"}},
+				Samples: []prompb.Sample{{Value: 1, Timestamp: 1}},
+			},
+		},
+	}
+	data, err := req.Marshal()
+	require.NoError(t, err)
+
+	// A megabyte of zeros compresses to a few kilobytes.
+	bomb := snappy.Encode(nil, make([]byte, 1<<20))
+
+	testCases := []struct {
+		name   string
+		body   []byte
+		config RemoteWriteConfig
+		err    bool
+	}{
+		{name: "Valid", body: snappy.Encode(nil, data), config: RemoteWriteConfig{MaxRequestSize: 1 << 20, MaxDecodedSize: 1 << 20}},
+		{name: "TooLarge", body: bomb, config: RemoteWriteConfig{MaxRequestSize: int64(len(bomb)) - 1, MaxDecodedSize: 2 << 20}, err: true},
+		{name: "DecodesTooLarge", body: bomb, config: RemoteWriteConfig{MaxRequestSize: 1 << 20, MaxDecodedSize: 1<<20 - 1}, err: true},
+		{name: "NotSnappy", body: []byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff}, config: RemoteWriteConfig{MaxRequestSize: 1 << 20, MaxDecodedSize: 1 << 20}, err: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			decoded, err := DecodeWriteRequest(bytes.NewReader(tc.body), tc.config)
+			if tc.err {
+				assert.True(t, errors.Asc(err, ErrCodeInvalidWriteRequest))
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, req.Timeseries, decoded.Timeseries)
+		})
+	}
+}
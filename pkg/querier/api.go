@@ -1,15 +1,29 @@
 package querier
 
 import (
+	"compress/gzip"
+	"context"
 	"encoding/json"
+	"fmt"
+	"io"
 	"net/http"
+	"strconv"
+	"time"
 
+	"github.com/SigNoz/signoz/pkg/errors"
 	"github.com/SigNoz/signoz/pkg/http/render"
 	"github.com/SigNoz/signoz/pkg/types/authtypes"
 	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
 	"github.com/SigNoz/signoz/pkg/valuer"
 )
 
+const (
+	// EstimatedRowsHeader is the header holding the estimated number of rows read by the queries of a request.
+	EstimatedRowsHeader string = "X-Signoz-Estimated-Rows"
+	// EstimatedBytesHeader is the header holding the estimated number of bytes read by the queries of a request.
+	EstimatedBytesHeader string = "X-Signoz-Estimated-Bytes"
+)
+
 type API struct {
 	querier Querier
 }
@@ -45,5 +59,137 @@ func (a *API) QueryRange(rw http.ResponseWriter, req *http.Request) {
 		return
 	}
 
+	if queryRangeResponse.Estimate != nil {
+		rw.Header().Set(EstimatedRowsHeader, strconv.FormatUint(queryRangeResponse.Estimate.Rows, 10))
+		rw.Header().Set(EstimatedBytesHeader, strconv.FormatUint(queryRangeResponse.Estimate.Bytes, 10))
+	}
+
 	render.Success(rw, http.StatusOK, queryRangeResponse)
 }
+
+// FederatedQuery runs the queries of several signals and returns their results correlated by the shared dimensions.
+func (a *API) FederatedQuery(rw http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+
+	claims, err := authtypes.ClaimsFromContext(ctx)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	var federatedQueryRequest qbtypes.FederatedQueryRequest
+	if err := json.NewDecoder(req.Body).Decode(&federatedQueryRequest); err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	orgID, err := valuer.NewUUID(claims.OrgID)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	federatedQueryResponse, err := a.querier.FederatedQuery(ctx, orgID, &federatedQueryRequest)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	render.Success(rw, http.StatusOK, federatedQueryResponse)
+}
+
+// QueryRangeExport streams the rows of the single query of the request as a CSV attachment. The export is
+// compressed as a .csv.gz file when the compression query parameter is gzip. The query is canceled as soon as the
+// client disconnects.
+func (a *API) QueryRangeExport(rw http.ResponseWriter, req *http.Request) {
+	ctx, cancel := context.WithCancel(req.Context())
+	defer cancel()
+
+	claims, err := authtypes.ClaimsFromContext(ctx)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	var queryRangeRequest qbtypes.QueryRangeRequest
+	if err := json.NewDecoder(req.Body).Decode(&queryRangeRequest); err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	orgID, err := valuer.NewUUID(claims.OrgID)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	compression := req.URL.Query().Get("compression")
+	if compression != "" && compression != "gzip" {
+		render.Error(rw, errors.NewInvalidInputf(errors.CodeInvalidInput, "compression must be gzip, got %q", compression))
+		return
+	}
+
+	filename := fmt.Sprintf(
+		"signoz-export-%s-%s.csv",
+		time.UnixMilli(int64(queryRangeRequest.Start)).UTC().Format("20060102T150405Z"),
+		time.UnixMilli(int64(queryRangeRequest.End)).UTC().Format("20060102T150405Z"),
+	)
+
+	// The headers are only sent with the first row so that the errors of the query are still rendered as such.
+	export := &exportResponseWriter{rw: rw, filename: filename, contentType: "text/csv; charset=utf-8"}
+	var w io.Writer = export
+	var gzipWriter *gzip.Writer
+	if compression == "gzip" {
+		export.filename += ".gz"
+		export.contentType = "application/gzip"
+		gzipWriter = gzip.NewWriter(export)
+		w = gzipWriter
+	}
+
+	csvWriter := newCSVRowWriter(w)
+	err = a.querier.Export(ctx, orgID, &queryRangeRequest, csvWriter)
+	if err == nil {
+		err = csvWriter.Flush()
+	}
+	if err == nil && gzipWriter != nil {
+		err = gzipWriter.Close()
+	}
+
+	if err != nil {
+		if !export.started {
+			render.Error(rw, err)
+			return
+		}
+
+		// The export is already partially sent, the connection is aborted so that the client does not take it for
+		// a complete one.
+		panic(http.ErrAbortHandler)
+	}
+
+	// An export without any row is sent once it is done.
+	export.start()
+}
+
+// exportResponseWriter sends the headers of the export along with its first bytes.
+type exportResponseWriter struct {
+	rw          http.ResponseWriter
+	filename    string
+	contentType string
+	started     bool
+}
+
+func (w *exportResponseWriter) start() {
+	if w.started {
+		return
+	}
+
+	w.started = true
+	w.rw.Header().Set("Content-Type", w.contentType)
+	w.rw.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", w.filename))
+	w.rw.WriteHeader(http.StatusOK)
+}
+
+func (w *exportResponseWriter) Write(p []byte) (int, error) {
+	w.start()
+	return w.rw.Write(p)
+}
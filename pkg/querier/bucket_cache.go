@@ -21,18 +21,21 @@ type bucketCache struct {
 	logger       *slog.Logger
 	cacheTTL     time.Duration
 	fluxInterval time.Duration
+	negative     cache.Negative
 }
 
 var _ BucketCache = (*bucketCache)(nil)
 
-// NewBucketCache creates a new BucketCache implementation
-func NewBucketCache(settings factory.ProviderSettings, cache cache.Cache, cacheTTL time.Duration, fluxInterval time.Duration) BucketCache {
+// NewBucketCache creates a new BucketCache implementation. The empty results are cached as empty for the window of
+// their query for the ttl of negative when it is enabled, and computed again on every lookup otherwise.
+func NewBucketCache(settings factory.ProviderSettings, cache cache.Cache, cacheTTL time.Duration, fluxInterval time.Duration, negative cache.Negative) BucketCache {
 	cacheSettings := factory.NewScopedProviderSettings(settings, "github.com/SigNoz/signoz/pkg/querier/bucket_cache")
 	return &bucketCache{
 		cache:        cache,
 		logger:       cacheSettings.Logger(),
 		cacheTTL:     cacheTTL,
 		fluxInterval: fluxInterval,
+		negative:     negative,
 	}
 }
 
@@ -72,6 +75,15 @@ func (bc *bucketCache) GetMissRanges(
 
 	bc.logger.DebugContext(ctx, "getting miss ranges", "fingerprint", q.Fingerprint(), "start", startMs, "end", endMs)
 
+	// The window of the query is known to be empty, there is nothing to fetch
+	if bc.negative.Enabled {
+		err := cache.GetOrEmpty(ctx, bc.cache, orgID, bc.generateEmptyCacheKey(q), &cachedData{}, false)
+		if errors.Asc(err, cache.ErrCodeCacheEmpty) {
+			bc.logger.DebugContext(ctx, "window cached as empty", "fingerprint", q.Fingerprint())
+			return &qbtypes.Result{Type: qbtypes.RequestTypeTimeSeries, Value: &qbtypes.TimeSeriesData{Aggregations: []*qbtypes.AggregationBucket{}}}, nil
+		}
+	}
+
 	// Generate cache key
 	cacheKey := bc.generateCacheKey(q)
 
@@ -121,6 +133,15 @@ func (bc *bucketCache) Put(ctx context.Context, orgID valuer.UUID, q qbtypes.Que
 	// Get query window
 	startMs, endMs := q.Window()
 
+	// Cache the empty result as empty for the window of the query, as its data may not have been ingested yet
+	if bc.negative.Enabled && fresh.Type == qbtypes.RequestTypeTimeSeries {
+		if isEmpty, isFiltered := bc.isEmptyResult(fresh); isEmpty && !isFiltered {
+			if err := cache.SetEmpty(ctx, bc.cache, orgID, bc.generateEmptyCacheKey(q), bc.negative.TTL); err != nil {
+				bc.logger.ErrorContext(ctx, "error setting empty cached data", "error", err)
+			}
+		}
+	}
+
 	// Calculate the flux boundary - data after this point should not be cached
 	currentMs := uint64(time.Now().UnixMilli())
 	fluxBoundary := currentMs - uint64(bc.fluxInterval.Milliseconds())
@@ -193,6 +214,13 @@ func (bc *bucketCache) generateCacheKey(q qbtypes.Query) string {
 	return fmt.Sprintf("v5:query:%s", fingerprint)
 }
 
+// generateEmptyCacheKey creates the cache key of the window of the query when its result is cached as empty
+func (bc *bucketCache) generateEmptyCacheKey(q qbtypes.Query) string {
+	startMs, endMs := q.Window()
+
+	return fmt.Sprintf("%s:empty:%d-%d", bc.generateCacheKey(q), startMs, endMs)
+}
+
 // findMissingRangesWithStep identifies time ranges not covered by cached buckets with step alignment
 func (bc *bucketCache) findMissingRangesWithStep(buckets []*cachedBucket, startMs, endMs uint64, stepMs uint64) []*qbtypes.TimeRange {
 	// When step is 0 or window is too small to be cached, use simple algorithm
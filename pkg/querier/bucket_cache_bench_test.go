@@ -345,7 +345,7 @@ func createBenchmarkBucketCache(tb testing.TB) BucketCache {
 	}
 	memCache, err := cachetest.New(config)
 	require.NoError(tb, err)
-	return NewBucketCache(instrumentationtest.New().ToProviderSettings(), memCache, time.Hour, 5*time.Minute)
+	return NewBucketCache(instrumentationtest.New().ToProviderSettings(), memCache, time.Hour, 5*time.Minute, cache.Negative{})
 }
 
 // Helper function to create benchmark result
@@ -8,6 +8,7 @@ import (
 
 	"github.com/SigNoz/signoz/pkg/cache"
 	"github.com/SigNoz/signoz/pkg/cache/cachetest"
+	"github.com/SigNoz/signoz/pkg/errors"
 	"github.com/SigNoz/signoz/pkg/instrumentation/instrumentationtest"
 	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
 	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
@@ -75,7 +76,7 @@ func ptr[T any](v T) *T {
 // createTestBucketCache creates a test bucket cache
 func createTestBucketCache(t *testing.T) *bucketCache {
 	memCache := createTestCache(t)
-	return NewBucketCache(instrumentationtest.New().ToProviderSettings(), memCache, cacheTTL, defaultFluxInterval).(*bucketCache)
+	return NewBucketCache(instrumentationtest.New().ToProviderSettings(), memCache, cacheTTL, defaultFluxInterval, cache.Negative{}).(*bucketCache)
 }
 
 func createTestTimeSeries(queryName string, startMs, endMs uint64, step uint64) *qbtypes.TimeSeriesData {
@@ -120,7 +121,7 @@ func createTestTimeSeries(queryName string, startMs, endMs uint64, step uint64)
 
 func TestBucketCache_GetMissRanges_EmptyCache(t *testing.T) {
 	memCache := createTestCache(t)
-	bc := NewBucketCache(instrumentationtest.New().ToProviderSettings(), memCache, cacheTTL, defaultFluxInterval)
+	bc := NewBucketCache(instrumentationtest.New().ToProviderSettings(), memCache, cacheTTL, defaultFluxInterval, cache.Negative{})
 
 	query := &mockQuery{
 		fingerprint: "test-query",
@@ -138,7 +139,7 @@ func TestBucketCache_GetMissRanges_EmptyCache(t *testing.T) {
 
 func TestBucketCache_Put_And_Get(t *testing.T) {
 	memCache := createTestCache(t)
-	bc := NewBucketCache(instrumentationtest.New().ToProviderSettings(), memCache, cacheTTL, defaultFluxInterval)
+	bc := NewBucketCache(instrumentationtest.New().ToProviderSettings(), memCache, cacheTTL, defaultFluxInterval, cache.Negative{})
 
 	// Create a query and result
 	query := &mockQuery{
@@ -181,7 +182,7 @@ func TestBucketCache_Put_And_Get(t *testing.T) {
 
 func TestBucketCache_PartialHit(t *testing.T) {
 	memCache := createTestCache(t)
-	bc := NewBucketCache(instrumentationtest.New().ToProviderSettings(), memCache, cacheTTL, defaultFluxInterval)
+	bc := NewBucketCache(instrumentationtest.New().ToProviderSettings(), memCache, cacheTTL, defaultFluxInterval, cache.Negative{})
 
 	// First query: cache data for 1000-3000ms
 	query1 := &mockQuery{
@@ -218,7 +219,7 @@ func TestBucketCache_PartialHit(t *testing.T) {
 
 func TestBucketCache_MultipleBuckets(t *testing.T) {
 	memCache := createTestCache(t)
-	bc := NewBucketCache(instrumentationtest.New().ToProviderSettings(), memCache, cacheTTL, defaultFluxInterval)
+	bc := NewBucketCache(instrumentationtest.New().ToProviderSettings(), memCache, cacheTTL, defaultFluxInterval, cache.Negative{})
 
 	// Cache multiple non-contiguous ranges
 	query1 := &mockQuery{
@@ -268,7 +269,7 @@ func TestBucketCache_MultipleBuckets(t *testing.T) {
 
 func TestBucketCache_FluxInterval(t *testing.T) {
 	memCache := createTestCache(t)
-	bc := NewBucketCache(instrumentationtest.New().ToProviderSettings(), memCache, cacheTTL, defaultFluxInterval)
+	bc := NewBucketCache(instrumentationtest.New().ToProviderSettings(), memCache, cacheTTL, defaultFluxInterval, cache.Negative{})
 
 	// Try to cache data too close to current time
 	currentMs := uint64(time.Now().UnixMilli())
@@ -299,7 +300,7 @@ func TestBucketCache_FluxInterval(t *testing.T) {
 
 func TestBucketCache_MergeTimeSeriesResults(t *testing.T) {
 	memCache := createTestCache(t)
-	bc := NewBucketCache(instrumentationtest.New().ToProviderSettings(), memCache, cacheTTL, defaultFluxInterval)
+	bc := NewBucketCache(instrumentationtest.New().ToProviderSettings(), memCache, cacheTTL, defaultFluxInterval, cache.Negative{})
 
 	// Create time series with same labels but different time ranges
 	series1 := &qbtypes.TimeSeries{
@@ -411,7 +412,7 @@ func TestBucketCache_MergeTimeSeriesResults(t *testing.T) {
 
 func TestBucketCache_RawData(t *testing.T) {
 	memCache := createTestCache(t)
-	bc := NewBucketCache(instrumentationtest.New().ToProviderSettings(), memCache, cacheTTL, defaultFluxInterval)
+	bc := NewBucketCache(instrumentationtest.New().ToProviderSettings(), memCache, cacheTTL, defaultFluxInterval, cache.Negative{})
 
 	// Test with raw data type
 	query := &mockQuery{
@@ -459,7 +460,7 @@ func TestBucketCache_RawData(t *testing.T) {
 
 func TestBucketCache_ScalarData(t *testing.T) {
 	memCache := createTestCache(t)
-	bc := NewBucketCache(instrumentationtest.New().ToProviderSettings(), memCache, cacheTTL, defaultFluxInterval)
+	bc := NewBucketCache(instrumentationtest.New().ToProviderSettings(), memCache, cacheTTL, defaultFluxInterval, cache.Negative{})
 
 	query := &mockQuery{
 		fingerprint: "test-query",
@@ -499,7 +500,7 @@ func TestBucketCache_ScalarData(t *testing.T) {
 
 func TestBucketCache_EmptyFingerprint(t *testing.T) {
 	memCache := createTestCache(t)
-	bc := NewBucketCache(instrumentationtest.New().ToProviderSettings(), memCache, cacheTTL, defaultFluxInterval)
+	bc := NewBucketCache(instrumentationtest.New().ToProviderSettings(), memCache, cacheTTL, defaultFluxInterval, cache.Negative{})
 
 	// Query with empty fingerprint should generate a fallback key
 	query := &mockQuery{
@@ -524,7 +525,7 @@ func TestBucketCache_EmptyFingerprint(t *testing.T) {
 
 func TestBucketCache_FindMissingRanges_EdgeCases(t *testing.T) {
 	memCache := createTestCache(t)
-	bc := NewBucketCache(instrumentationtest.New().ToProviderSettings(), memCache, cacheTTL, defaultFluxInterval).(*bucketCache)
+	bc := NewBucketCache(instrumentationtest.New().ToProviderSettings(), memCache, cacheTTL, defaultFluxInterval, cache.Negative{}).(*bucketCache)
 
 	// Test with buckets that have gaps and overlaps
 	buckets := []*cachedBucket{
@@ -551,7 +552,7 @@ func TestBucketCache_FindMissingRanges_EdgeCases(t *testing.T) {
 
 func TestBucketCache_ConcurrentAccess(t *testing.T) {
 	memCache := createTestCache(t)
-	bc := NewBucketCache(instrumentationtest.New().ToProviderSettings(), memCache, cacheTTL, defaultFluxInterval)
+	bc := NewBucketCache(instrumentationtest.New().ToProviderSettings(), memCache, cacheTTL, defaultFluxInterval, cache.Negative{})
 
 	// Test concurrent puts and gets
 	done := make(chan bool)
@@ -901,6 +902,67 @@ func TestBucketCache_EmptyDataHandling(t *testing.T) {
 	}
 }
 
+func TestBucketCache_NegativeCaching(t *testing.T) {
+	memCache := createTestCache(t)
+	bc := NewBucketCache(instrumentationtest.New().ToProviderSettings(), memCache, cacheTTL, defaultFluxInterval, cache.Negative{Enabled: true, TTL: time.Minute}).(*bucketCache)
+	ctx := context.Background()
+	orgID := valuer.UUID{}
+
+	end := uint64(time.Now().Add(-time.Hour).Truncate(time.Minute).UnixMilli())
+	query := &mockQuery{
+		fingerprint: "test-negative",
+		startMs:     end - uint64(time.Hour.Milliseconds()),
+		endMs:       end,
+	}
+
+	// The empty result is cached as empty, its window is no longer missing.
+	bc.Put(ctx, orgID, query, &qbtypes.Result{
+		Type:  qbtypes.RequestTypeTimeSeries,
+		Value: &qbtypes.TimeSeriesData{QueryName: "A", Aggregations: []*qbtypes.AggregationBucket{}},
+	})
+
+	cached, missing := bc.GetMissRanges(ctx, orgID, query, qbtypes.Step{Duration: time.Minute})
+	require.NotNil(t, cached)
+	assert.Empty(t, cached.Value.(*qbtypes.TimeSeriesData).Aggregations)
+	assert.Empty(t, missing)
+
+	// The window is cached as empty rather than missing from the cache.
+	err := cache.GetOrEmpty(ctx, memCache, orgID, bc.generateEmptyCacheKey(query), &cachedData{}, false)
+	assert.True(t, errors.Asc(err, cache.ErrCodeCacheEmpty))
+
+	// The other windows of the query are still missing.
+	other := &mockQuery{fingerprint: "test-negative", startMs: query.startMs - uint64(time.Hour.Milliseconds()), endMs: query.endMs}
+	cached, missing = bc.GetMissRanges(ctx, orgID, other, qbtypes.Step{Duration: time.Minute})
+	assert.Nil(t, cached)
+	assert.Len(t, missing, 1)
+}
+
+func TestBucketCache_NegativeCachingDisabled(t *testing.T) {
+	bc := createTestBucketCache(t)
+	ctx := context.Background()
+	orgID := valuer.UUID{}
+
+	end := uint64(time.Now().Add(-time.Hour).Truncate(time.Minute).UnixMilli())
+	query := &mockQuery{
+		fingerprint: "test-negative-disabled",
+		startMs:     end - uint64(time.Hour.Milliseconds()),
+		endMs:       end,
+	}
+
+	bc.Put(ctx, orgID, query, &qbtypes.Result{
+		Type:  qbtypes.RequestTypeTimeSeries,
+		Value: &qbtypes.TimeSeriesData{QueryName: "A", Aggregations: []*qbtypes.AggregationBucket{}},
+	})
+
+	err := cache.GetOrEmpty(ctx, bc.cache, orgID, bc.generateEmptyCacheKey(query), &cachedData{}, false)
+	assert.True(t, errors.Ast(err, errors.TypeNotFound))
+	assert.False(t, errors.Asc(err, cache.ErrCodeCacheEmpty))
+
+	cached, missing := bc.GetMissRanges(ctx, orgID, query, qbtypes.Step{Duration: time.Minute})
+	assert.Nil(t, cached)
+	assert.Len(t, missing, 1)
+}
+
 func TestBucketCache_PartialValues(t *testing.T) {
 	bc := createTestBucketCache(t)
 	ctx := context.Background()
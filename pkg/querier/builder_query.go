@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/base64"
 	"fmt"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -184,17 +185,28 @@ func (q *builderQuery[T]) Execute(ctx context.Context) (*qbtypes.Result, error)
 	return result, nil
 }
 
+// Estimate estimates the data read by the query over its whole window, which bounds the data read by the pages of a
+// window list.
+func (q *builderQuery[T]) Estimate(ctx context.Context) (telemetrystore.Estimate, error) {
+	stmt, err := q.stmtBuilder.Build(ctx, q.fromMS, q.toMS, q.kind, q.spec)
+	if err != nil {
+		return telemetrystore.Estimate{}, err
+	}
+
+	return q.telemetryStore.Estimator().Estimate(ctx, stmt.Query, stmt.Args...)
+}
+
 // executeWithContext executes the query with query window and step context for partial value detection
 func (q *builderQuery[T]) executeWithContext(ctx context.Context, query string, args []any) (*qbtypes.Result, error) {
 	totalRows := uint64(0)
 	totalBytes := uint64(0)
 	elapsed := time.Duration(0)
 
-	ctx = clickhouse.Context(ctx, clickhouse.WithProgress(func(p *clickhouse.Progress) {
+	ctx = telemetrystore.NewContextWithProgress(ctx, func(p *clickhouse.Progress) {
 		totalRows += p.Rows
 		totalBytes += p.Bytes
 		elapsed += p.Elapsed
-	}))
+	})
 
 	rows, err := q.telemetryStore.ClickhouseDB().Query(ctx, query, args...)
 	if err != nil {
@@ -224,8 +236,31 @@ func (q *builderQuery[T]) executeWindowList(ctx context.Context) (*qbtypes.Resul
 	isAsc := len(q.spec.Order) > 0 &&
 		strings.ToLower(string(q.spec.Order[0].Direction.StringValue())) == "asc"
 
+	// The logs ordered by timestamp and id are paged through with a cursor on their timestamp and id, see
+	// qbtypes.Cursor. The other lists are paged through with the millisecond of their last row.
+	isCursorOrder := q.spec.Signal == telemetrytypes.SignalLogs && qbtypes.IsCursorOrder(q.spec.Order)
+	cur := strings.TrimSpace(q.spec.Cursor)
+
+	var cursor *qbtypes.Cursor
+	if isCursorOrder {
+		var err error
+		cursor, err = qbtypes.NewLogsCursor(cur, q.spec.Order)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// Adjust [fromMS,toMS] window if a cursor was supplied
-	if cur := strings.TrimSpace(q.spec.Cursor); cur != "" {
+	if cursor != nil {
+		// The logs of a previous page are selected in the reverse order of the list. The statement builder selects
+		// the logs strictly after the cursor, the window only narrows to its millisecond.
+		isAsc = isAsc != cursor.Previous
+		if isAsc {
+			q.fromMS = max(q.fromMS, cursor.Timestamp/1e6)
+		} else {
+			q.toMS = min(q.toMS, cursor.Timestamp/1e6+1)
+		}
+	} else if cur != "" {
 		if ts, err := decodeCursor(cur); err == nil {
 			if isAsc {
 				if uint64(ts) >= q.fromMS {
@@ -252,7 +287,16 @@ func (q *builderQuery[T]) executeWindowList(ctx context.Context) (*qbtypes.Resul
 	totalBytes := uint64(0)
 	start := time.Now()
 
-	for _, r := range makeBuckets(q.fromMS, q.toMS) {
+	// The buckets are ordered from the newest, they are walked from the oldest for an ascending order.
+	var buckets []tsRange
+	if q.fromMS < q.toMS {
+		buckets = makeBuckets(q.fromMS, q.toMS)
+	}
+	if isAsc {
+		slices.Reverse(buckets)
+	}
+
+	for _, r := range buckets {
 		q.spec.Offset = 0
 		q.spec.Limit = need
 
@@ -287,8 +331,13 @@ func (q *builderQuery[T]) executeWindowList(ctx context.Context) (*qbtypes.Resul
 		}
 	}
 
-	nextCursor := ""
-	if len(rows) == reqLimit {
+	nextCursor, prevCursor := "", ""
+	if isCursorOrder {
+		if cursor != nil && cursor.Previous {
+			slices.Reverse(rows)
+		}
+		nextCursor, prevCursor = logsCursors(rows, reqLimit, cursor)
+	} else if len(rows) == reqLimit {
 		lastTS := rows[len(rows)-1].Timestamp.UnixMilli()
 		nextCursor = encodeCursor(lastTS)
 	}
@@ -299,6 +348,7 @@ func (q *builderQuery[T]) executeWindowList(ctx context.Context) (*qbtypes.Resul
 			QueryName:  q.spec.Name,
 			Rows:       rows,
 			NextCursor: nextCursor,
+			PrevCursor: prevCursor,
 		},
 		Stats: qbtypes.ExecStats{
 			RowsScanned:  totalRows,
@@ -308,6 +358,35 @@ func (q *builderQuery[T]) executeWindowList(ctx context.Context) (*qbtypes.Resul
 	}, nil
 }
 
+// logsCursors returns the cursors of the pages after and before the rows of a page of logs, in the order of the list.
+// The page after the rows only exists when the page is full or when it was reached from it. The page before the rows
+// may be empty, its cursor is returned anyway so that the logs ingested later can be paged through.
+func logsCursors(rows []*qbtypes.RawRow, limit int, cursor *qbtypes.Cursor) (string, string) {
+	if len(rows) == 0 {
+		if cursor == nil {
+			return "", ""
+		}
+
+		return "", qbtypes.Cursor{Timestamp: cursor.Timestamp, ID: cursor.ID, Previous: true}.String()
+	}
+
+	nextCursor := ""
+	if len(rows) == limit || (cursor != nil && cursor.Previous) {
+		nextCursor = rowCursor(rows[len(rows)-1], false).String()
+	}
+
+	return nextCursor, rowCursor(rows[0], true).String()
+}
+
+func rowCursor(row *qbtypes.RawRow, previous bool) qbtypes.Cursor {
+	cursor := qbtypes.Cursor{Timestamp: uint64(row.Timestamp.UnixNano()), Previous: previous}
+	if id, ok := row.Data["id"]; ok && id != nil {
+		cursor.ID, _ = (*id).(string)
+	}
+
+	return cursor
+}
+
 func encodeCursor(tsMilli int64) string {
 	return base64.StdEncoding.EncodeToString([]byte(strconv.FormatInt(tsMilli, 10)))
 }
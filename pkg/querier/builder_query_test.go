@@ -0,0 +1,79 @@
+package querier
+
+import (
+	"testing"
+	"time"
+
+	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
+	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestLogRow(ts int64, id string) *qbtypes.RawRow {
+	value := any(id)
+	return &qbtypes.RawRow{Timestamp: time.Unix(0, ts), Data: map[string]*any{"id": &value}}
+}
+
+func TestLogsCursors(t *testing.T) {
+	rows := []*qbtypes.RawRow{newTestLogRow(3, "c"), newTestLogRow(2, "b"), newTestLogRow(2, "a")}
+
+	decode := func(cursor string) *qbtypes.Cursor {
+		if cursor == "" {
+			return nil
+		}
+
+		decoded, err := qbtypes.NewCursorFromString(cursor)
+		require.NoError(t, err)
+		return decoded
+	}
+
+	t.Run("FullPage", func(t *testing.T) {
+		next, prev := logsCursors(rows, 3, nil)
+		assert.Equal(t, &qbtypes.Cursor{Timestamp: 2, ID: "a"}, decode(next))
+		assert.Equal(t, &qbtypes.Cursor{Timestamp: 3, ID: "c", Previous: true}, decode(prev))
+	})
+
+	t.Run("LastPage", func(t *testing.T) {
+		next, prev := logsCursors(rows, 10, &qbtypes.Cursor{Timestamp: 4, ID: "d"})
+		assert.Nil(t, decode(next))
+		assert.Equal(t, &qbtypes.Cursor{Timestamp: 3, ID: "c", Previous: true}, decode(prev))
+	})
+
+	t.Run("PreviousPage", func(t *testing.T) {
+		// The page reached from a previous cursor is followed by the page it was reached from.
+		next, _ := logsCursors(rows, 10, &qbtypes.Cursor{Timestamp: 1, ID: "z", Previous: true})
+		assert.Equal(t, &qbtypes.Cursor{Timestamp: 2, ID: "a"}, decode(next))
+	})
+
+	t.Run("NoNewerLogs", func(t *testing.T) {
+		// The previous cursor stays valid so that the logs ingested later are paged through.
+		next, prev := logsCursors(nil, 10, &qbtypes.Cursor{Timestamp: 3, ID: "c", Previous: true})
+		assert.Nil(t, decode(next))
+		assert.Equal(t, &qbtypes.Cursor{Timestamp: 3, ID: "c", Previous: true}, decode(prev))
+	})
+}
+
+func TestNewLogsCursor(t *testing.T) {
+	order := []qbtypes.OrderBy{
+		{Key: qbtypes.OrderByKey{TelemetryFieldKey: telemetrytypes.TelemetryFieldKey{Name: "timestamp"}}, Direction: qbtypes.OrderDirectionDesc},
+		{Key: qbtypes.OrderByKey{TelemetryFieldKey: telemetrytypes.TelemetryFieldKey{Name: "id"}}, Direction: qbtypes.OrderDirectionDesc},
+	}
+	cursor := qbtypes.Cursor{Timestamp: 2, ID: "a"}
+
+	decoded, err := qbtypes.NewLogsCursor(cursor.String(), order)
+	require.NoError(t, err)
+	assert.Equal(t, &cursor, decoded)
+
+	// The other orders keep the millisecond cursor, as do the cursors returned before the cursors on timestamp and id.
+	decoded, err = qbtypes.NewLogsCursor(encodeCursor(1747950000000), order[:1])
+	require.NoError(t, err)
+	assert.Nil(t, decoded)
+
+	decoded, err = qbtypes.NewLogsCursor(encodeCursor(1747950000000), order)
+	require.NoError(t, err)
+	assert.Nil(t, decoded)
+
+	_, err = qbtypes.NewLogsCursor("invalid", order)
+	assert.Error(t, err)
+}
@@ -45,17 +45,21 @@ func (q *chSQLQuery) Fingerprint() string {
 
 func (q *chSQLQuery) Window() (uint64, uint64) { return q.fromMS, q.toMS }
 
+func (q *chSQLQuery) Estimate(ctx context.Context) (telemetrystore.Estimate, error) {
+	return q.telemetryStore.Estimator().Estimate(ctx, q.query.Query, q.args...)
+}
+
 func (q *chSQLQuery) Execute(ctx context.Context) (*qbtypes.Result, error) {
 
 	totalRows := uint64(0)
 	totalBytes := uint64(0)
 	elapsed := time.Duration(0)
 
-	ctx = clickhouse.Context(ctx, clickhouse.WithProgress(func(p *clickhouse.Progress) {
+	ctx = telemetrystore.NewContextWithProgress(ctx, func(p *clickhouse.Progress) {
 		totalRows += p.Rows
 		totalBytes += p.Bytes
 		elapsed += p.Elapsed
-	}))
+	})
 
 	rows, err := q.telemetryStore.ClickhouseDB().Query(ctx, q.query.Query, q.args...)
 	if err != nil {
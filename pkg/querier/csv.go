@@ -0,0 +1,108 @@
+package querier
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+// csvRowWriter writes the rows of an export as CSV. The rows are buffered by the csv writer and reach the
+// underlying writer as the buffer fills up.
+type csvRowWriter struct {
+	w       *csv.Writer
+	record  []string
+	written bool
+}
+
+func newCSVRowWriter(w io.Writer) *csvRowWriter {
+	return &csvRowWriter{w: csv.NewWriter(w)}
+}
+
+func (w *csvRowWriter) WriteHeader(columns []string) error {
+	w.written = true
+	w.record = make([]string, len(columns))
+	for i, column := range columns {
+		w.record[i] = escapeCSVFormula(column)
+	}
+
+	return w.w.Write(w.record)
+}
+
+func (w *csvRowWriter) WriteRow(values []any) error {
+	for i, value := range values {
+		w.record[i] = formatCSVValue(value)
+	}
+
+	return w.w.Write(w.record)
+}
+
+// Flush writes the buffered rows to the underlying writer.
+func (w *csvRowWriter) Flush() error {
+	w.w.Flush()
+	return w.w.Error()
+}
+
+// formatCSVValue formats a value read from the telemetry store as a CSV field. Nested values such as maps and
+// arrays are formatted as JSON. The text values are escaped so that spreadsheets do not evaluate them as formulas,
+// the numbers are left as they are.
+func formatCSVValue(value any) string {
+	rv := reflect.ValueOf(value)
+	for rv.Kind() == reflect.Pointer || rv.Kind() == reflect.Interface {
+		if rv.IsNil() {
+			return ""
+		}
+		rv = rv.Elem()
+	}
+
+	if !rv.IsValid() {
+		return ""
+	}
+
+	switch v := rv.Interface().(type) {
+	case string:
+		return escapeCSVFormula(v)
+	case []byte:
+		return escapeCSVFormula(string(v))
+	case time.Time:
+		return v.UTC().Format(time.RFC3339Nano)
+	case float32:
+		return strconv.FormatFloat(float64(v), 'f', -1, 32)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case fmt.Stringer:
+		return escapeCSVFormula(v.String())
+	}
+
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Bool:
+		return fmt.Sprint(rv.Interface())
+	case reflect.Map, reflect.Slice, reflect.Array, reflect.Struct:
+		bytes, err := json.Marshal(rv.Interface())
+		if err != nil {
+			return escapeCSVFormula(fmt.Sprint(rv.Interface()))
+		}
+		return escapeCSVFormula(string(bytes))
+	default:
+		return escapeCSVFormula(fmt.Sprint(rv.Interface()))
+	}
+}
+
+// escapeCSVFormula prefixes the fields which spreadsheets would evaluate as formulas with a single quote, which
+// makes them read the field as text.
+func escapeCSVFormula(field string) string {
+	if field == "" {
+		return field
+	}
+
+	switch field[0] {
+	case '=', '+', '-', '@', '\t', '\r':
+		return "'" + field
+	default:
+		return field
+	}
+}
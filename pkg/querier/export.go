@@ -0,0 +1,191 @@
+package querier
+
+import (
+	"context"
+	"reflect"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/ClickHouse/clickhouse-go/v2/lib/driver"
+	"github.com/SigNoz/signoz/pkg/errors"
+	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
+	"github.com/SigNoz/signoz/pkg/valuer"
+)
+
+// exportable is a query whose rows can be exported.
+type exportable interface {
+	export(ctx context.Context, w RowWriter) error
+}
+
+var (
+	_ exportable = (*builderQuery[any])(nil)
+	_ exportable = (*chSQLQuery)(nil)
+	_ exportable = (*promqlQuery)(nil)
+)
+
+func (q *querier) Export(ctx context.Context, orgID valuer.UUID, req *qbtypes.QueryRangeRequest, w RowWriter) error {
+	queries, _, err := q.newQueries(req)
+	if err != nil {
+		return err
+	}
+
+	if len(queries) != 1 {
+		return errors.NewInvalidInputf(errors.CodeInvalidInput, "exactly one query can be exported, got %d", len(queries))
+	}
+
+	for _, query := range queries {
+		exportable, ok := query.(exportable)
+		if !ok {
+			return errors.NewInvalidInputf(errors.CodeInvalidInput, "query of type %T cannot be exported", query)
+		}
+
+		return exportable.export(ctx, w)
+	}
+
+	return nil
+}
+
+func (q *builderQuery[T]) export(ctx context.Context, w RowWriter) error {
+	stmt, err := q.stmtBuilder.Build(ctx, q.fromMS, q.toMS, q.kind, q.spec)
+	if err != nil {
+		return err
+	}
+
+	rows, err := q.telemetryStore.ClickhouseDB().Query(ctx, stmt.Query, stmt.Args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	return exportRows(rows, q.aggregationNames(), w)
+}
+
+// aggregationNames returns the names of the aggregations of the query, which replace the __result_N column names
+// of the statements.
+func (q *builderQuery[T]) aggregationNames() []string {
+	names := make([]string, len(q.spec.Aggregations))
+	for i, agg := range q.spec.Aggregations {
+		switch a := any(agg).(type) {
+		case qbtypes.TraceAggregation:
+			names[i] = a.Expression
+			if a.Alias != "" {
+				names[i] = a.Alias
+			}
+		case qbtypes.LogAggregation:
+			names[i] = a.Expression
+			if a.Alias != "" {
+				names[i] = a.Alias
+			}
+		case qbtypes.MetricAggregation:
+			names[i] = a.MetricName
+		}
+	}
+
+	return names
+}
+
+func (q *chSQLQuery) export(ctx context.Context, w RowWriter) error {
+	rows, err := q.telemetryStore.ClickhouseDB().Query(ctx, q.query.Query, q.args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	return exportRows(rows, nil, w)
+}
+
+// export writes a row per value of every series. The promql engine evaluates the whole query before returning,
+// so the rows are written once the query is done.
+func (q *promqlQuery) export(ctx context.Context, w RowWriter) error {
+	result, err := q.Execute(ctx)
+	if err != nil {
+		return err
+	}
+
+	data, ok := result.Value.(*qbtypes.TimeSeriesData)
+	if !ok {
+		return errors.NewInternalf(errors.CodeInternal, "unexpected result of type %T for promql query %q", result.Value, q.query.Query)
+	}
+
+	// The series do not share their labels, every label found in any series gets a column.
+	labels := make([]string, 0)
+	for _, bucket := range data.Aggregations {
+		for _, series := range bucket.Series {
+			for _, label := range series.Labels {
+				if !slices.Contains(labels, label.Key.Name) {
+					labels = append(labels, label.Key.Name)
+				}
+			}
+		}
+	}
+	slices.Sort(labels)
+
+	if err := w.WriteHeader(append(append([]string{"timestamp"}, labels...), "value")); err != nil {
+		return err
+	}
+
+	values := make([]any, len(labels)+2)
+	for _, bucket := range data.Aggregations {
+		for _, series := range bucket.Series {
+			clear(values)
+			for _, label := range series.Labels {
+				values[slices.Index(labels, label.Key.Name)+1] = label.Value
+			}
+
+			for _, value := range series.Values {
+				values[0] = time.UnixMilli(value.Timestamp)
+				values[len(values)-1] = value.Value
+				if err := w.WriteRow(values); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// exportRows writes the rows to the writer as they are read. The __result_N columns are renamed to the
+// aggregation names, when given.
+func exportRows(rows driver.Rows, aggregationNames []string, w RowWriter) error {
+	columns := slices.Clone(rows.Columns())
+	for i, column := range columns {
+		if m := aggRe.FindStringSubmatch(column); m != nil {
+			if index, err := strconv.Atoi(m[1]); err == nil && index < len(aggregationNames) && aggregationNames[index] != "" {
+				columns[i] = aggregationNames[index]
+			}
+		}
+	}
+
+	if err := w.WriteHeader(columns); err != nil {
+		return err
+	}
+
+	columnTypes := rows.ColumnTypes()
+	slots := make([]any, len(columnTypes))
+	for i, columnType := range columnTypes {
+		slots[i] = reflect.New(columnType.ScanType()).Interface()
+	}
+
+	values := make([]any, len(slots))
+	for rows.Next() {
+		if err := rows.Scan(slots...); err != nil {
+			return err
+		}
+
+		for i, slot := range slots {
+			values[i] = reflect.ValueOf(slot).Elem().Interface()
+		}
+
+		if err := w.WriteRow(values); err != nil {
+			return err
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return errors.WrapInternalf(err, errors.CodeInternal, "failed to read the rows of the export")
+	}
+
+	return nil
+}
@@ -0,0 +1,135 @@
+package querier
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/SigNoz/signoz/pkg/factory/factorytest"
+	"github.com/SigNoz/signoz/pkg/telemetrystore"
+	"github.com/SigNoz/signoz/pkg/telemetrystore/telemetrystoretest"
+	"github.com/SigNoz/signoz/pkg/types/authtypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+	cmock "github.com/srikanthccv/ClickHouse-go-mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	exportRequest = `{
+		"schemaVersion": "v1",
+		"start": 1640995200000,
+		"end": 1640998800000,
+		"requestType": "raw",
+		"compositeQuery": {"queries": [{"type": "clickhouse_sql", "spec": {"name": "A", "query": "SELECT timestamp, body, attributes FROM logs"}}]}
+	}`
+)
+
+func newTestExportAPI(t *testing.T) (*API, *telemetrystoretest.Provider) {
+	telemetryStore := telemetrystoretest.New(telemetrystore.Config{Provider: "clickhouse"}, sqlmock.QueryMatcherEqual)
+	return NewAPI(New(factorytest.NewSettings(), telemetryStore, nil, nil, nil, nil, nil, nil)), telemetryStore
+}
+
+func newTestExportRequest(t *testing.T, target string, body string) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, target, strings.NewReader(body))
+	return req.WithContext(authtypes.NewContextWithClaims(req.Context(), authtypes.Claims{OrgID: valuer.GenerateUUID().StringValue()}))
+}
+
+func TestQueryRangeExport(t *testing.T) {
+	api, telemetryStore := newTestExportAPI(t)
+
+	cols := []cmock.ColumnType{{Name: "timestamp", Type: "UInt64"}, {Name: "body", Type: "String"}, {Name: "attributes", Type: "String"}}
+	ts := uint64(1640995200000000000)
+	body := "GET /api, 200"
+	attributes := `{"service":"frontend"}`
+	telemetryStore.Mock().ExpectQuery("SELECT timestamp, body, attributes FROM logs").WillReturnRows(cmock.NewRows(cols, [][]any{{&ts, &body, &attributes}}))
+
+	rw := httptest.NewRecorder()
+	api.QueryRangeExport(rw, newTestExportRequest(t, "/api/v5/query_range/export", exportRequest))
+
+	assert.Equal(t, http.StatusOK, rw.Code)
+	assert.Equal(t, "text/csv; charset=utf-8", rw.Header().Get("Content-Type"))
+	assert.Equal(t, `attachment; filename="signoz-export-20220101T000000Z-20220101T010000Z.csv"`, rw.Header().Get("Content-Disposition"))
+	assert.Equal(t, "timestamp,body,attributes\n1640995200000000000,\"GET /api, 200\",\"{\"\"service\"\":\"\"frontend\"\"}\"\n", rw.Body.String())
+}
+
+func TestQueryRangeExportGzip(t *testing.T) {
+	api, telemetryStore := newTestExportAPI(t)
+
+	cols := []cmock.ColumnType{{Name: "body", Type: "String"}}
+	body := "hello"
+	telemetryStore.Mock().ExpectQuery("SELECT timestamp, body, attributes FROM logs").WillReturnRows(cmock.NewRows(cols, [][]any{{&body}}))
+
+	rw := httptest.NewRecorder()
+	api.QueryRangeExport(rw, newTestExportRequest(t, "/api/v5/query_range/export?compression=gzip", exportRequest))
+
+	assert.Equal(t, http.StatusOK, rw.Code)
+	assert.Equal(t, "application/gzip", rw.Header().Get("Content-Type"))
+	assert.Contains(t, rw.Header().Get("Content-Disposition"), `.csv.gz"`)
+
+	reader, err := gzip.NewReader(rw.Body)
+	require.NoError(t, err)
+	csv, err := io.ReadAll(reader)
+	require.NoError(t, err)
+	assert.Equal(t, "body\nhello\n", string(csv))
+}
+
+func TestQueryRangeExportError(t *testing.T) {
+	api, telemetryStore := newTestExportAPI(t)
+	telemetryStore.Mock().ExpectQuery("SELECT timestamp, body, attributes FROM logs").WillReturnError(io.ErrUnexpectedEOF)
+
+	rw := httptest.NewRecorder()
+	api.QueryRangeExport(rw, newTestExportRequest(t, "/api/v5/query_range/export", exportRequest))
+
+	// Nothing was sent yet, the error is rendered as such.
+	assert.Equal(t, http.StatusInternalServerError, rw.Code)
+	assert.Empty(t, rw.Header().Get("Content-Disposition"))
+}
+
+func TestQueryRangeExportSingleQuery(t *testing.T) {
+	api, _ := newTestExportAPI(t)
+
+	rw := httptest.NewRecorder()
+	api.QueryRangeExport(rw, newTestExportRequest(t, "/api/v5/query_range/export", `{"start": 1, "end": 2, "requestType": "raw", "compositeQuery": {"queries": []}}`))
+
+	assert.Equal(t, http.StatusBadRequest, rw.Code)
+}
+
+func TestFormatCSVValue(t *testing.T) {
+	value := 1.5
+	var null *string
+
+	assert.Equal(t, "1.5", formatCSVValue(&value))
+	assert.Equal(t, "", formatCSVValue(null))
+	assert.Equal(t, "", formatCSVValue(nil))
+	assert.Equal(t, "42", formatCSVValue(uint64(42)))
+	assert.Equal(t, `["a","b"]`, formatCSVValue([]string{"a", "b"}))
+	assert.Equal(t, `{"service":"frontend"}`, formatCSVValue(map[string]string{"service": "frontend"}))
+	assert.Equal(t, "2022-01-01T00:00:00.5Z", formatCSVValue(time.Date(2022, 1, 1, 0, 0, 0, 5e8, time.UTC)))
+
+	// The text values which spreadsheets would evaluate as formulas are escaped, the numbers are not.
+	assert.Equal(t, "'=HYPERLINK(\"http://attacker\")", formatCSVValue("=HYPERLINK(\"http://attacker\")"))
+	assert.Equal(t, "'+1", formatCSVValue("+1"))
+	assert.Equal(t, "'-cmd", formatCSVValue([]byte("-cmd")))
+	assert.Equal(t, "'@SUM(A1)", formatCSVValue("@SUM(A1)"))
+	assert.Equal(t, "user=admin", formatCSVValue("user=admin"))
+	assert.Equal(t, "-1.5", formatCSVValue(-1.5))
+	assert.Equal(t, "-42", formatCSVValue(int64(-42)))
+}
+
+func TestCSVRowWriterEscapesHeader(t *testing.T) {
+	var buf bytes.Buffer
+	w := newCSVRowWriter(&buf)
+
+	require.NoError(t, w.WriteHeader([]string{"body", "=cmd"}))
+	require.NoError(t, w.WriteRow([]any{"-hello", 1}))
+	require.NoError(t, w.Flush())
+
+	assert.Equal(t, "body,'=cmd\n'-hello,1\n", buf.String())
+}
@@ -0,0 +1,222 @@
+package querier
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
+	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+)
+
+// FederatedQuery runs the queries of every signal concurrently, as scalar queries constrained by the shared filter
+// and grouped by the shared dimensions, and correlates their results by the values of the dimensions. A signal
+// failing to be queried is reported in its status rather than failing the request.
+func (q *querier) FederatedQuery(ctx context.Context, orgID valuer.UUID, req *qbtypes.FederatedQueryRequest) (*qbtypes.FederatedQueryResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	var signals []telemetrytypes.Signal
+	envelopes := make(map[telemetrytypes.Signal][]qbtypes.QueryEnvelope)
+	names := make(map[telemetrytypes.Signal][]string)
+	for _, envelope := range req.Queries {
+		signal, name, spec, err := federateQuery(envelope, req.Filter, req.GroupBy)
+		if err != nil {
+			return nil, err
+		}
+
+		if _, ok := envelopes[signal]; !ok {
+			signals = append(signals, signal)
+		}
+
+		envelopes[signal] = append(envelopes[signal], qbtypes.QueryEnvelope{Type: qbtypes.QueryTypeBuilder, Spec: spec})
+		names[signal] = append(names[signal], name)
+	}
+
+	statuses := make([]*qbtypes.FederatedSignalStatus, len(signals))
+	results := make([]map[string]*qbtypes.ScalarData, len(signals))
+
+	var wg sync.WaitGroup
+	for i, signal := range signals {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			status := &qbtypes.FederatedSignalStatus{Signal: signal, Queries: names[signal]}
+			results[i] = q.federatedSignal(ctx, orgID, req, envelopes[signal], status)
+			statuses[i] = status
+		}()
+	}
+	wg.Wait()
+
+	dimensions := make([]string, len(req.GroupBy))
+	for i, groupBy := range req.GroupBy {
+		dimensions[i] = groupBy.Name
+	}
+
+	resp := &qbtypes.FederatedQueryResponse{
+		Dimensions: dimensions,
+		Rows:       correlate(dimensions, results),
+		Signals:    statuses,
+	}
+
+	for _, status := range statuses {
+		resp.Meta.RowsScanned += status.Meta.RowsScanned
+		resp.Meta.BytesScanned += status.Meta.BytesScanned
+		resp.Meta.DurationMS = max(resp.Meta.DurationMS, status.Meta.DurationMS)
+	}
+
+	return resp, nil
+}
+
+// federatedSignal runs the queries of the signal and returns their results by query name. The failure of any query
+// of the signal is recorded in its status and none of its results are returned.
+func (q *querier) federatedSignal(ctx context.Context, orgID valuer.UUID, req *qbtypes.FederatedQueryRequest, envelopes []qbtypes.QueryEnvelope, status *qbtypes.FederatedSignalStatus) map[string]*qbtypes.ScalarData {
+	fail := func(err error) map[string]*qbtypes.ScalarData {
+		q.logger.WarnContext(ctx, "failed to run the federated queries of the signal", "signal", status.Signal.StringValue(), "error", err)
+		status.Error = err.Error()
+		return nil
+	}
+
+	rangeReq := &qbtypes.QueryRangeRequest{
+		Start:          req.Start,
+		End:            req.End,
+		RequestType:    qbtypes.RequestTypeScalar,
+		CompositeQuery: qbtypes.CompositeQuery{Queries: envelopes},
+		NoCache:        req.NoCache,
+	}
+
+	queries, steps, err := q.newQueries(rangeReq)
+	if err != nil {
+		return fail(err)
+	}
+
+	_, warning, err := q.estimate(ctx, orgID, queries)
+	if err != nil {
+		return fail(err)
+	}
+
+	if warning != "" {
+		status.Warnings = append(status.Warnings, warning)
+	}
+
+	results := make(map[string]*qbtypes.ScalarData, len(queries))
+	for name, query := range queries {
+		result, err := q.execute(ctx, orgID, name, query, steps[name], req.NoCache)
+		if err != nil {
+			return fail(err)
+		}
+
+		data, ok := result.Value.(*qbtypes.ScalarData)
+		if !ok {
+			return fail(errors.NewInternalf(errors.CodeInternal, "unexpected result %T of the query %q", result.Value, name))
+		}
+
+		results[name] = data
+		status.Warnings = append(status.Warnings, result.Warnings...)
+		status.Meta.RowsScanned += result.Stats.RowsScanned
+		status.Meta.BytesScanned += result.Stats.BytesScanned
+		status.Meta.DurationMS += result.Stats.DurationMS
+	}
+
+	return results
+}
+
+// federateQuery returns the signal, the name and the spec of the builder query constrained by the shared filter and
+// grouped by the shared dimensions.
+func federateQuery(envelope qbtypes.QueryEnvelope, filter *qbtypes.Filter, groupBy []qbtypes.GroupByKey) (telemetrytypes.Signal, string, any, error) {
+	switch spec := envelope.Spec.(type) {
+	case qbtypes.QueryBuilderQuery[qbtypes.TraceAggregation]:
+		return telemetrytypes.SignalTraces, spec.Name, federate(spec, filter, groupBy), nil
+	case qbtypes.QueryBuilderQuery[qbtypes.LogAggregation]:
+		return telemetrytypes.SignalLogs, spec.Name, federate(spec, filter, groupBy), nil
+	case qbtypes.QueryBuilderQuery[qbtypes.MetricAggregation]:
+		return telemetrytypes.SignalMetrics, spec.Name, federate(spec, filter, groupBy), nil
+	}
+
+	return telemetrytypes.SignalUnspecified, "", nil, errors.NewInvalidInputf(errors.CodeInvalidInput, "unsupported builder spec type %T", envelope.Spec)
+}
+
+func federate[T any](spec qbtypes.QueryBuilderQuery[T], filter *qbtypes.Filter, groupBy []qbtypes.GroupByKey) qbtypes.QueryBuilderQuery[T] {
+	spec.GroupBy = groupBy
+
+	switch {
+	case filter == nil || strings.TrimSpace(filter.Expression) == "":
+	case spec.Filter == nil || strings.TrimSpace(spec.Filter.Expression) == "":
+		spec.Filter = &qbtypes.Filter{Expression: filter.Expression}
+	default:
+		spec.Filter = &qbtypes.Filter{Expression: fmt.Sprintf("(%s) AND (%s)", filter.Expression, spec.Filter.Expression)}
+	}
+
+	return spec
+}
+
+// correlate keys the aggregations of the results of every query by the values of the dimensions. The rows are
+// sorted by their keys.
+func correlate(dimensions []string, results []map[string]*qbtypes.ScalarData) []*qbtypes.FederatedRow {
+	rows := make(map[string]*qbtypes.FederatedRow)
+	for _, signalResults := range results {
+		for name, data := range signalResults {
+			dimensionIndexes := make([]int, len(dimensions))
+			var aggregationIndexes []int
+			for i := range dimensionIndexes {
+				dimensionIndexes[i] = -1
+			}
+
+			for i, column := range data.Columns {
+				if column.Type == qbtypes.ColumnTypeAggregation {
+					aggregationIndexes = append(aggregationIndexes, i)
+					continue
+				}
+
+				if j := slices.Index(dimensions, column.Name); j >= 0 {
+					dimensionIndexes[j] = i
+				}
+			}
+
+			for _, values := range data.Data {
+				key := make(map[string]any, len(dimensions))
+				var rowKey strings.Builder
+				for i, dimension := range dimensions {
+					var value any
+					if dimensionIndexes[i] >= 0 {
+						value = values[dimensionIndexes[i]]
+					}
+
+					key[dimension] = value
+					fmt.Fprintf(&rowKey, "%s=%v,", dimension, value)
+				}
+
+				row, ok := rows[rowKey.String()]
+				if !ok {
+					row = &qbtypes.FederatedRow{Key: key, Values: make(map[string][]any)}
+					rows[rowKey.String()] = row
+				}
+
+				aggregations := make([]any, len(aggregationIndexes))
+				for i, index := range aggregationIndexes {
+					aggregations[i] = values[index]
+				}
+				row.Values[name] = aggregations
+			}
+		}
+	}
+
+	keys := make([]string, 0, len(rows))
+	for key := range rows {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	correlated := make([]*qbtypes.FederatedRow, len(keys))
+	for i, key := range keys {
+		correlated[i] = rows[key]
+	}
+
+	return correlated
+}
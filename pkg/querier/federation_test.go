@@ -0,0 +1,104 @@
+package querier
+
+import (
+	"testing"
+
+	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
+	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFederateQuery(t *testing.T) {
+	groupBy := []qbtypes.GroupByKey{{TelemetryFieldKey: telemetrytypes.TelemetryFieldKey{Name: "service.name"}}}
+
+	testCases := []struct {
+		name     string
+		filter   *qbtypes.Filter
+		own      *qbtypes.Filter
+		expected *qbtypes.Filter
+	}{
+		{name: "NoFilter", filter: nil, own: nil, expected: nil},
+		{name: "SharedFilter", filter: &qbtypes.Filter{Expression: "env = 'prod'"}, own: nil, expected: &qbtypes.Filter{Expression: "env = 'prod'"}},
+		{name: "OwnFilter", filter: nil, own: &qbtypes.Filter{Expression: "severity_text = 'ERROR'"}, expected: &qbtypes.Filter{Expression: "severity_text = 'ERROR'"}},
+		{name: "BothFilters", filter: &qbtypes.Filter{Expression: "env = 'prod'"}, own: &qbtypes.Filter{Expression: "severity_text = 'ERROR'"}, expected: &qbtypes.Filter{Expression: "(env = 'prod') AND (severity_text = 'ERROR')"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			envelope := qbtypes.QueryEnvelope{
+				Type: qbtypes.QueryTypeBuilder,
+				Spec: qbtypes.QueryBuilderQuery[qbtypes.LogAggregation]{Name: "errors", Signal: telemetrytypes.SignalLogs, Filter: tc.own},
+			}
+
+			signal, name, spec, err := federateQuery(envelope, tc.filter, groupBy)
+			require.NoError(t, err)
+			assert.Equal(t, telemetrytypes.SignalLogs, signal)
+			assert.Equal(t, "errors", name)
+
+			query := spec.(qbtypes.QueryBuilderQuery[qbtypes.LogAggregation])
+			assert.Equal(t, tc.expected, query.Filter)
+			assert.Equal(t, groupBy, query.GroupBy)
+		})
+	}
+}
+
+func TestCorrelate(t *testing.T) {
+	scalar := func(queryName string, rows ...[]any) *qbtypes.ScalarData {
+		return &qbtypes.ScalarData{
+			Columns: []*qbtypes.ColumnDescriptor{
+				{TelemetryFieldKey: telemetrytypes.TelemetryFieldKey{Name: "service.name"}, QueryName: queryName, Type: qbtypes.ColumnTypeGroup},
+				{TelemetryFieldKey: telemetrytypes.TelemetryFieldKey{Name: "__result_0"}, QueryName: queryName, Type: qbtypes.ColumnTypeAggregation},
+			},
+			Data: rows,
+		}
+	}
+
+	rows := correlate([]string{"service.name"}, []map[string]*qbtypes.ScalarData{
+		{"latency": scalar("latency", []any{"api", 120.0}, []any{"web", 80.0})},
+		// The logs failed to be queried.
+		nil,
+		{"errors": scalar("errors", []any{"api", uint64(3)}, []any{"worker", uint64(1)})},
+	})
+
+	require.Len(t, rows, 3)
+
+	assert.Equal(t, map[string]any{"service.name": "api"}, rows[0].Key)
+	assert.Equal(t, map[string][]any{"latency": {120.0}, "errors": {uint64(3)}}, rows[0].Values)
+
+	assert.Equal(t, map[string]any{"service.name": "web"}, rows[1].Key)
+	assert.Equal(t, map[string][]any{"latency": {80.0}}, rows[1].Values)
+
+	assert.Equal(t, map[string]any{"service.name": "worker"}, rows[2].Key)
+	assert.Equal(t, map[string][]any{"errors": {uint64(1)}}, rows[2].Values)
+}
+
+func TestFederatedQueryRequestValidate(t *testing.T) {
+	groupBy := []qbtypes.GroupByKey{{TelemetryFieldKey: telemetrytypes.TelemetryFieldKey{Name: "service.name"}}}
+	logs := qbtypes.QueryEnvelope{Type: qbtypes.QueryTypeBuilder, Spec: qbtypes.QueryBuilderQuery[qbtypes.LogAggregation]{Name: "A", Signal: telemetrytypes.SignalLogs}}
+
+	testCases := []struct {
+		name string
+		req  qbtypes.FederatedQueryRequest
+		pass bool
+	}{
+		{name: "Valid", req: qbtypes.FederatedQueryRequest{Start: 1, End: 2, GroupBy: groupBy, Queries: []qbtypes.QueryEnvelope{logs}}, pass: true},
+		{name: "NoDimension", req: qbtypes.FederatedQueryRequest{Start: 1, End: 2, Queries: []qbtypes.QueryEnvelope{logs}}, pass: false},
+		{name: "NoQuery", req: qbtypes.FederatedQueryRequest{Start: 1, End: 2, GroupBy: groupBy}, pass: false},
+		{name: "DuplicateName", req: qbtypes.FederatedQueryRequest{Start: 1, End: 2, GroupBy: groupBy, Queries: []qbtypes.QueryEnvelope{logs, logs}}, pass: false},
+		{name: "OwnGroupBy", req: qbtypes.FederatedQueryRequest{Start: 1, End: 2, GroupBy: groupBy, Queries: []qbtypes.QueryEnvelope{{Type: qbtypes.QueryTypeBuilder, Spec: qbtypes.QueryBuilderQuery[qbtypes.LogAggregation]{Name: "A", GroupBy: groupBy}}}}, pass: false},
+		{name: "PromQL", req: qbtypes.FederatedQueryRequest{Start: 1, End: 2, GroupBy: groupBy, Queries: []qbtypes.QueryEnvelope{{Type: qbtypes.QueryTypePromQL, Spec: qbtypes.PromQuery{Name: "A"}}}}, pass: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.req.Validate()
+			if tc.pass {
+				assert.NoError(t, err)
+				return
+			}
+
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package querier
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"sync"
+
+	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
+	"github.com/SigNoz/signoz/pkg/valuer"
+)
+
+// flights de-duplicates the concurrent executions of the same query over the same window, so that the panels of a
+// popular dashboard missing from the bucket cache are executed once rather than once per viewer.
+type flights struct {
+	mtx   sync.Mutex
+	calls map[string]*flight
+}
+
+// flight is an execution shared by the calls waiting for it.
+type flight struct {
+	done    chan struct{}
+	result  *qbtypes.Result
+	err     error
+	waiters int
+	cancel  context.CancelFunc
+}
+
+func newFlights() *flights {
+	return &flights{calls: make(map[string]*flight)}
+}
+
+// do executes the query once for all the concurrent calls with the same key and returns its result to each of them.
+// A call returns as soon as its ctx is done and the execution is canceled once no call waits for it anymore, the
+// calls made after that execute the query again. The errors of the execution are returned to all the calls.
+func (f *flights) do(ctx context.Context, key string, execute func(context.Context) (*qbtypes.Result, error)) (*qbtypes.Result, error) {
+	f.mtx.Lock()
+	call, ok := f.calls[key]
+	if !ok {
+		// The execution outlives the call starting it as long as other calls wait for it.
+		executeCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+		call = &flight{done: make(chan struct{}), cancel: cancel}
+		f.calls[key] = call
+
+		go func() {
+			defer cancel()
+			result, err := execute(executeCtx)
+
+			f.mtx.Lock()
+			f.forget(key, call)
+			call.result, call.err = result, err
+			f.mtx.Unlock()
+
+			close(call.done)
+		}()
+	}
+	call.waiters++
+	f.mtx.Unlock()
+
+	select {
+	case <-call.done:
+		return call.result, call.err
+	case <-ctx.Done():
+		f.mtx.Lock()
+		call.waiters--
+		if call.waiters == 0 {
+			call.cancel()
+			f.forget(key, call)
+		}
+		f.mtx.Unlock()
+
+		return nil, ctx.Err()
+	}
+}
+
+// forget removes the call from the calls joined by the next ones. It should be called with the lock held.
+func (f *flights) forget(key string, call *flight) {
+	if f.calls[key] == call {
+		delete(f.calls, key)
+	}
+}
+
+// flightKey returns the key of the executions of the query over its window for the organization.
+func flightKey(orgID valuer.UUID, query qbtypes.Query) string {
+	startMs, endMs := query.Window()
+	return strings.Join([]string{orgID.StringValue(), query.Fingerprint(), strconv.FormatUint(startMs, 10), strconv.FormatUint(endMs, 10)}, ":")
+}
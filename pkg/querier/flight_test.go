@@ -0,0 +1,99 @@
+package querier
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFlightsDeduplicates(t *testing.T) {
+	f := newFlights()
+
+	var executions atomic.Int64
+	release := make(chan struct{})
+	execute := func(context.Context) (*qbtypes.Result, error) {
+		executions.Add(1)
+		<-release
+		return &qbtypes.Result{Type: qbtypes.RequestTypeTimeSeries}, nil
+	}
+
+	var wg sync.WaitGroup
+	results := make([]*qbtypes.Result, 10)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			result, err := f.do(context.Background(), "key", execute)
+			assert.NoError(t, err)
+			results[i] = result
+		}(i)
+	}
+
+	// The calls join the execution started by the first one.
+	assert.Eventually(t, func() bool {
+		f.mtx.Lock()
+		defer f.mtx.Unlock()
+		return f.calls["key"] != nil && f.calls["key"].waiters == len(results)
+	}, time.Second, time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, int64(1), executions.Load())
+	for _, result := range results {
+		assert.Same(t, results[0], result)
+	}
+	assert.Empty(t, f.calls)
+}
+
+func TestFlightsCancelsWithoutWaiters(t *testing.T) {
+	f := newFlights()
+
+	canceled := make(chan struct{})
+	execute := func(ctx context.Context) (*qbtypes.Result, error) {
+		<-ctx.Done()
+		close(canceled)
+		return nil, ctx.Err()
+	}
+
+	first, cancelFirst := context.WithCancel(context.Background())
+	second, cancelSecond := context.WithCancel(context.Background())
+
+	errs := make(chan error, 2)
+	go func() {
+		_, err := f.do(first, "key", execute)
+		errs <- err
+	}()
+	go func() {
+		_, err := f.do(second, "key", execute)
+		errs <- err
+	}()
+
+	require.Eventually(t, func() bool {
+		f.mtx.Lock()
+		defer f.mtx.Unlock()
+		return f.calls["key"] != nil && f.calls["key"].waiters == 2
+	}, time.Second, time.Millisecond)
+
+	// The execution keeps running for the call still waiting for it.
+	cancelFirst()
+	assert.ErrorIs(t, <-errs, context.Canceled)
+	select {
+	case <-canceled:
+		t.Fatal("execution canceled while a call waits for it")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	cancelSecond()
+	assert.ErrorIs(t, <-errs, context.Canceled)
+	<-canceled
+
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	assert.Empty(t, f.calls)
+}
@@ -3,6 +3,7 @@ package querier
 import (
 	"context"
 
+	"github.com/SigNoz/signoz/pkg/telemetrystore"
 	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
 	"github.com/SigNoz/signoz/pkg/valuer"
 )
@@ -10,6 +11,22 @@ import (
 // Querier interface defines the contract for querying data
 type Querier interface {
 	QueryRange(ctx context.Context, orgID valuer.UUID, req *qbtypes.QueryRangeRequest) (*qbtypes.QueryRangeResponse, error)
+
+	// Export writes the rows of the single query of the request to the writer. The rows of the builder and
+	// clickhouse queries are written as they are read from the telemetry store, without holding the result in memory.
+	Export(ctx context.Context, orgID valuer.UUID, req *qbtypes.QueryRangeRequest, w RowWriter) error
+
+	// FederatedQuery runs the queries of several signals constrained by a shared filter and correlates their results
+	// by the values of the shared dimensions. The failures of the signals are reported in the response.
+	FederatedQuery(ctx context.Context, orgID valuer.UUID, req *qbtypes.FederatedQueryRequest) (*qbtypes.FederatedQueryResponse, error)
+}
+
+// RowWriter writes the rows of an exported query.
+type RowWriter interface {
+	// WriteHeader writes the names of the columns. It is called once, before the rows.
+	WriteHeader(columns []string) error
+	// WriteRow writes the values of a row in the order of the columns.
+	WriteRow(values []any) error
 }
 
 // BucketCache is the interface for bucket-based caching
@@ -18,4 +35,14 @@ type BucketCache interface {
 	GetMissRanges(ctx context.Context, orgID valuer.UUID, q qbtypes.Query, step qbtypes.Step) (cached *qbtypes.Result, missing []*qbtypes.TimeRange)
 	// store fresh buckets for future hits
 	Put(ctx context.Context, orgID valuer.UUID, q qbtypes.Query, fresh *qbtypes.Result)
-}
\ No newline at end of file
+}
+
+// estimatedQuery is a query whose data read can be estimated before it is run.
+type estimatedQuery interface {
+	Estimate(ctx context.Context) (telemetrystore.Estimate, error)
+}
+
+// exemplarQuery is a query returning the exemplars of the histograms it selects along with its result.
+type exemplarQuery interface {
+	Exemplars(ctx context.Context) ([]*qbtypes.ExemplarSeries, error)
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/SigNoz/signoz/pkg/querybuilder"
 	qbv5 "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
 	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
+	"github.com/prometheus/prometheus/model/labels"
 	"github.com/prometheus/prometheus/promql"
 )
 
@@ -131,3 +132,55 @@ func (q *promqlQuery) Execute(ctx context.Context) (*qbv5.Result, error) {
 		// TODO: map promql stats?
 	}, nil
 }
+
+// Exemplars returns the exemplars of the histogram buckets selected by the query, nil when it selects none.
+func (q *promqlQuery) Exemplars(ctx context.Context) ([]*qbv5.ExemplarSeries, error) {
+	start := int64(querybuilder.ToNanoSecs(q.tr.From)) / int64(time.Millisecond)
+	end := int64(querybuilder.ToNanoSecs(q.tr.To)) / int64(time.Millisecond)
+
+	results, err := q.promEngine.HistogramExemplars(ctx, q.query.Query, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	series := make([]*qbv5.ExemplarSeries, 0, len(results))
+	for _, result := range results {
+		s := &qbv5.ExemplarSeries{
+			Labels:    make([]*qbv5.Label, 0, result.SeriesLabels.Len()),
+			Exemplars: make([]*qbv5.Exemplar, 0, len(result.Exemplars)),
+		}
+
+		result.SeriesLabels.Range(func(label labels.Label) {
+			s.Labels = append(s.Labels, &qbv5.Label{
+				Key:   telemetrytypes.TelemetryFieldKey{Name: label.Name},
+				Value: label.Value,
+			})
+		})
+
+		for _, e := range result.Exemplars {
+			exemplar := &qbv5.Exemplar{Timestamp: e.Ts, Value: e.Value}
+			e.Labels.Range(func(label labels.Label) {
+				switch label.Name {
+				case prometheus.ExemplarTraceIDLabel:
+					exemplar.TraceID = label.Value
+				case prometheus.ExemplarSpanIDLabel:
+					exemplar.SpanID = label.Value
+				default:
+					if exemplar.Labels == nil {
+						exemplar.Labels = make(map[string]string)
+					}
+					exemplar.Labels[label.Name] = label.Value
+				}
+			})
+			s.Exemplars = append(s.Exemplars, exemplar)
+		}
+
+		series = append(series, s)
+	}
+
+	if len(series) == 0 {
+		return nil, nil
+	}
+
+	return series, nil
+}
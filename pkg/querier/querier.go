@@ -27,6 +27,7 @@ type querier struct {
 	logStmtBuilder    qbtypes.StatementBuilder[qbtypes.LogAggregation]
 	metricStmtBuilder qbtypes.StatementBuilder[qbtypes.MetricAggregation]
 	bucketCache       BucketCache
+	flights           *flights
 }
 
 var _ Querier = (*querier)(nil)
@@ -51,11 +52,70 @@ func New(
 		logStmtBuilder:    logStmtBuilder,
 		metricStmtBuilder: metricStmtBuilder,
 		bucketCache:       bucketCache,
+		flights:           newFlights(),
 	}
 }
 
 func (q *querier) QueryRange(ctx context.Context, orgID valuer.UUID, req *qbtypes.QueryRangeRequest) (*qbtypes.QueryRangeResponse, error) {
+	queries, steps, err := q.newQueries(req)
+	if err != nil {
+		return nil, err
+	}
+
+	estimate, warning, err := q.estimate(ctx, orgID, queries)
+	if err != nil {
+		return nil, err
+	}
+
+	warnings := make([]string, 0)
+	if warning != "" {
+		warnings = append(warnings, warning)
+	}
+
+	resp, err := q.run(ctx, orgID, queries, req, steps, warnings)
+	if err != nil {
+		return nil, err
+	}
+
+	resp.Estimate = estimate
+	return resp, nil
+}
+
+// estimate estimates the data read by the queries and checks it against the query budget of the organization. The
+// returned estimate is nil when the queries are not estimated.
+func (q *querier) estimate(ctx context.Context, orgID valuer.UUID, queries map[string]qbtypes.Query) (*qbtypes.QueryEstimate, string, error) {
+	estimator := q.telemetryStore.Estimator()
+	if !estimator.Enabled() {
+		return nil, "", nil
+	}
+
+	var total telemetrystore.Estimate
+	for _, query := range queries {
+		estimated, ok := query.(estimatedQuery)
+		if !ok {
+			continue
+		}
 
+		estimate, err := estimated.Estimate(ctx)
+		if err != nil {
+			// The estimate is advisory, the queries which cannot be estimated are run anyway.
+			q.logger.WarnContext(ctx, "failed to estimate the query", "error", err)
+			continue
+		}
+
+		total = total.Add(estimate)
+	}
+
+	warning, err := estimator.Check(orgID.StringValue(), total)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return &qbtypes.QueryEstimate{Rows: total.Rows, Bytes: total.Bytes}, warning, nil
+}
+
+// newQueries returns the queries of the request by name along with their steps.
+func (q *querier) newQueries(req *qbtypes.QueryRangeRequest) (map[string]qbtypes.Query, map[string]qbtypes.Step, error) {
 	queries := make(map[string]qbtypes.Query)
 	steps := make(map[string]qbtypes.Step)
 
@@ -64,7 +124,7 @@ func (q *querier) QueryRange(ctx context.Context, orgID valuer.UUID, req *qbtype
 		case qbtypes.QueryTypePromQL:
 			promQuery, ok := query.Spec.(qbtypes.PromQuery)
 			if !ok {
-				return nil, errors.NewInvalidInputf(errors.CodeInvalidInput, "invalid promql query spec %T", query.Spec)
+				return nil, nil, errors.NewInvalidInputf(errors.CodeInvalidInput, "invalid promql query spec %T", query.Spec)
 			}
 			promqlQuery := newPromqlQuery(q.promEngine, promQuery, qbtypes.TimeRange{From: req.Start, To: req.End}, req.RequestType)
 			queries[promQuery.Name] = promqlQuery
@@ -72,7 +132,7 @@ func (q *querier) QueryRange(ctx context.Context, orgID valuer.UUID, req *qbtype
 		case qbtypes.QueryTypeClickHouseSQL:
 			chQuery, ok := query.Spec.(qbtypes.ClickHouseQuery)
 			if !ok {
-				return nil, errors.NewInvalidInputf(errors.CodeInvalidInput, "invalid clickhouse query spec %T", query.Spec)
+				return nil, nil, errors.NewInvalidInputf(errors.CodeInvalidInput, "invalid clickhouse query spec %T", query.Spec)
 			}
 			chSQLQuery := newchSQLQuery(q.telemetryStore, chQuery, nil, qbtypes.TimeRange{From: req.Start, To: req.End}, req.RequestType)
 			queries[chQuery.Name] = chSQLQuery
@@ -91,46 +151,27 @@ func (q *querier) QueryRange(ctx context.Context, orgID valuer.UUID, req *qbtype
 				queries[spec.Name] = bq
 				steps[spec.Name] = spec.StepInterval
 			default:
-				return nil, errors.NewInvalidInputf(errors.CodeInvalidInput, "unsupported builder spec type %T", query.Spec)
+				return nil, nil, errors.NewInvalidInputf(errors.CodeInvalidInput, "unsupported builder spec type %T", query.Spec)
 			}
 		}
 	}
-	return q.run(ctx, orgID, queries, req, steps)
+	return queries, steps, nil
 }
 
-func (q *querier) run(ctx context.Context, orgID valuer.UUID, qs map[string]qbtypes.Query, req *qbtypes.QueryRangeRequest, steps map[string]qbtypes.Step) (*qbtypes.QueryRangeResponse, error) {
+func (q *querier) run(ctx context.Context, orgID valuer.UUID, qs map[string]qbtypes.Query, req *qbtypes.QueryRangeRequest, steps map[string]qbtypes.Step, warnings []string) (*qbtypes.QueryRangeResponse, error) {
 	results := make(map[string]any)
-	warnings := make([]string, 0)
 	stats := qbtypes.ExecStats{}
 
 	for name, query := range qs {
-		// Skip cache if NoCache is set, or if cache is not available
-		if req.NoCache || q.bucketCache == nil || query.Fingerprint() == "" {
-			if req.NoCache {
-				q.logger.DebugContext(ctx, "NoCache flag set, bypassing cache", "query", name)
-			} else {
-				q.logger.InfoContext(ctx, "no bucket cache or fingerprint, executing query", "fingerprint", query.Fingerprint())
-			}
-			result, err := query.Execute(ctx)
-			if err != nil {
-				return nil, err
-			}
-			results[name] = result.Value
-			warnings = append(warnings, result.Warnings...)
-			stats.RowsScanned += result.Stats.RowsScanned
-			stats.BytesScanned += result.Stats.BytesScanned
-			stats.DurationMS += result.Stats.DurationMS
-		} else {
-			result, err := q.executeWithCache(ctx, orgID, query, steps[name], req.NoCache)
-			if err != nil {
-				return nil, err
-			}
-			results[name] = result.Value
-			warnings = append(warnings, result.Warnings...)
-			stats.RowsScanned += result.Stats.RowsScanned
-			stats.BytesScanned += result.Stats.BytesScanned
-			stats.DurationMS += result.Stats.DurationMS
+		result, err := q.execute(ctx, orgID, name, query, steps[name], req.NoCache)
+		if err != nil {
+			return nil, err
 		}
+		results[name] = q.withExemplars(ctx, query, result.Value)
+		warnings = append(warnings, result.Warnings...)
+		stats.RowsScanned += result.Stats.RowsScanned
+		stats.BytesScanned += result.Stats.BytesScanned
+		stats.DurationMS += result.Stats.DurationMS
 	}
 
 	return &qbtypes.QueryRangeResponse{
@@ -154,27 +195,79 @@ func (q *querier) run(ctx context.Context, orgID valuer.UUID, qs map[string]qbty
 	}, nil
 }
 
+// execute executes the query, through the bucket cache unless the cache is disabled for the request or the query
+// cannot be cached.
+func (q *querier) execute(ctx context.Context, orgID valuer.UUID, name string, query qbtypes.Query, step qbtypes.Step, noCache bool) (*qbtypes.Result, error) {
+	// Skip cache if NoCache is set, or if cache is not available
+	if noCache || q.bucketCache == nil || query.Fingerprint() == "" {
+		if noCache {
+			q.logger.DebugContext(ctx, "NoCache flag set, bypassing cache", "query", name)
+		} else {
+			q.logger.InfoContext(ctx, "no bucket cache or fingerprint, executing query", "fingerprint", query.Fingerprint())
+		}
+		return query.Execute(ctx)
+	}
+
+	return q.executeWithCache(ctx, orgID, name, query, step, noCache)
+}
+
+// withExemplars returns the time series of the query along with the exemplars of the histograms it selects. The
+// exemplars are not cached, they are fetched for the whole window of the query on every request.
+func (q *querier) withExemplars(ctx context.Context, query qbtypes.Query, value any) any {
+	exemplarQuery, ok := query.(exemplarQuery)
+	if !ok {
+		return value
+	}
+
+	data, ok := value.(*qbtypes.TimeSeriesData)
+	if !ok {
+		return value
+	}
+
+	exemplars, err := exemplarQuery.Exemplars(ctx)
+	if err != nil {
+		// The exemplars are an addition to the series, the series are returned without them.
+		q.logger.WarnContext(ctx, "failed to fetch the exemplars of the query", "error", err)
+		return value
+	}
+
+	if len(exemplars) == 0 {
+		return value
+	}
+
+	// The time series may be held by the bucket cache, they are copied rather than modified.
+	withExemplars := *data
+	withExemplars.Exemplars = exemplars
+	return &withExemplars
+}
+
 // executeWithCache executes a query using the bucket cache
-func (q *querier) executeWithCache(ctx context.Context, orgID valuer.UUID, query qbtypes.Query, step qbtypes.Step, noCache bool) (*qbtypes.Result, error) {
+func (q *querier) executeWithCache(ctx context.Context, orgID valuer.UUID, name string, query qbtypes.Query, step qbtypes.Step, noCache bool) (*qbtypes.Result, error) {
 	// Get cached data and missing ranges
 	cachedResult, missingRanges := q.bucketCache.GetMissRanges(ctx, orgID, query, step)
 
 	// If no missing ranges, return cached result
 	if len(missingRanges) == 0 && cachedResult != nil {
+		// The results cached as empty do not carry the name of their query
+		if data, ok := cachedResult.Value.(*qbtypes.TimeSeriesData); ok && data.QueryName == "" {
+			data.QueryName = name
+		}
 		return cachedResult, nil
 	}
 
-	// If entire range is missing, execute normally
+	// If entire range is missing, execute normally, once for the concurrent requests of the same query
 	if cachedResult == nil && len(missingRanges) == 1 {
 		startMs, endMs := query.Window()
 		if missingRanges[0].From == startMs && missingRanges[0].To == endMs {
-			result, err := query.Execute(ctx)
-			if err != nil {
-				return nil, err
-			}
-			// Store in cache for future use
-			q.bucketCache.Put(ctx, orgID, query, result)
-			return result, nil
+			return q.flights.do(ctx, flightKey(orgID, query), func(ctx context.Context) (*qbtypes.Result, error) {
+				result, err := query.Execute(ctx)
+				if err != nil {
+					return nil, err
+				}
+				// Store in cache for future use
+				q.bucketCache.Put(ctx, orgID, query, result)
+				return result, nil
+			})
 		}
 	}
 
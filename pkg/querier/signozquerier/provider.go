@@ -21,6 +21,7 @@ func NewFactory(
 	telemetryStore telemetrystore.TelemetryStore,
 	prometheus prometheus.Prometheus,
 	cache cache.Cache,
+	negative cache.Negative,
 ) factory.ProviderFactory[querier.Querier, querier.Config] {
 	return factory.NewProviderFactory(
 		factory.MustNewName("signoz"),
@@ -29,7 +30,7 @@ func NewFactory(
 			settings factory.ProviderSettings,
 			cfg querier.Config,
 		) (querier.Querier, error) {
-			return newProvider(ctx, settings, cfg, telemetryStore, prometheus, cache)
+			return newProvider(ctx, settings, cfg, telemetryStore, prometheus, cache, negative)
 		},
 	)
 }
@@ -41,6 +42,7 @@ func newProvider(
 	telemetryStore telemetrystore.TelemetryStore,
 	prometheus prometheus.Prometheus,
 	cache cache.Cache,
+	negative cache.Negative,
 ) (querier.Querier, error) {
 
 	// Create telemetry metadata store
@@ -124,6 +126,7 @@ func newProvider(
 		cache,
 		cfg.CacheTTL,
 		cfg.FluxInterval,
+		negative,
 	)
 
 	// Create and return the querier
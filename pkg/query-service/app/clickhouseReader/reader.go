@@ -28,6 +28,7 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/prometheus/prometheus/promql"
+	"github.com/prometheus/prometheus/promql/parser"
 	"github.com/prometheus/prometheus/util/stats"
 
 	"github.com/ClickHouse/clickhouse-go/v2"
@@ -116,6 +117,7 @@ var (
 // SpanWriter for reading spans from ClickHouse
 type ClickHouseReader struct {
 	db                      clickhouse.Conn
+	telemetryStore          telemetrystore.TelemetryStore
 	prometheus              prometheus.Prometheus
 	sqlDB                   sqlstore.SQLStore
 	TraceDB                 string
@@ -188,6 +190,7 @@ func NewReaderFromClickhouseConnection(
 
 	return &ClickHouseReader{
 		db:                         telemetryStore.ClickhouseDB(),
+		telemetryStore:             telemetryStore,
 		prometheus:                 prometheus,
 		sqlDB:                      sqlDB,
 		TraceDB:                    options.primary.TraceDB,
@@ -247,6 +250,13 @@ func (r *ClickHouseReader) GetInstantQueryMetricsResult(ctx context.Context, que
 }
 
 func (r *ClickHouseReader) GetQueryRangeResult(ctx context.Context, query *model.QueryRangeParams) (*promql.Result, *stats.QueryStats, *model.ApiError) {
+	// Results served from the cache have no stats, queries asking for them are always evaluated.
+	if claims, err := authtypes.ClaimsFromContext(ctx); err == nil && query.Stats == "" {
+		if orgID, err := valuer.NewUUID(claims.OrgID); err == nil {
+			return r.getCachedQueryRangeResult(ctx, orgID, query)
+		}
+	}
+
 	qry, err := r.prometheus.Engine().NewRangeQuery(ctx, r.prometheus.Storage(), nil, query.Query, query.Start, query.End, query.Step)
 
 	if err != nil {
@@ -265,6 +275,27 @@ func (r *ClickHouseReader) GetQueryRangeResult(ctx context.Context, query *model
 	return res, &qs, nil
 }
 
+func (r *ClickHouseReader) getCachedQueryRangeResult(ctx context.Context, orgID valuer.UUID, query *model.QueryRangeParams) (*promql.Result, *stats.QueryStats, *model.ApiError) {
+	matrix, warnings, err := r.prometheus.RangeQuery(ctx, orgID, &prometheus.RangeQueryParams{
+		Query:   query.Query,
+		Start:   query.Start,
+		End:     query.End,
+		Step:    query.Step,
+		NoCache: query.NoCache,
+	})
+	var qs stats.QueryStats
+	if err != nil {
+		var parseErrs parser.ParseErrors
+		if errorsV2.As(err, &parseErrs) {
+			return nil, nil, &model.ApiError{Typ: model.ErrorBadData, Err: err}
+		}
+
+		return &promql.Result{Err: err}, &qs, nil
+	}
+
+	return &promql.Result{Value: matrix, Warnings: warnings}, &qs, nil
+}
+
 func (r *ClickHouseReader) GetServicesList(ctx context.Context) (*[]string, error) {
 	services := []string{}
 	rows, err := r.db.Query(ctx, fmt.Sprintf(`SELECT DISTINCT serviceName FROM %s.%s WHERE ts_bucket_start > (toUnixTimestamp(now() - INTERVAL 1 DAY) - 1800) AND toDate(timestamp) > now() - INTERVAL 1 DAY`, r.TraceDB, r.traceTableName))
@@ -2286,14 +2317,34 @@ func (r *ClickHouseReader) getPrevErrorID(ctx context.Context, queryParams *mode
 	}
 }
 
-func (r *ClickHouseReader) GetTotalSpans(ctx context.Context) (uint64, error) {
+// countAcrossShards sums the count returned by the query on every shard. The shards which fail are left out of
+// the sum, which is then reported as partial.
+func (r *ClickHouseReader) countAcrossShards(ctx context.Context, query string) (uint64, bool, error) {
+	result, err := telemetrystore.FanOut(ctx, r.telemetryStore.Shards(), func(ctx context.Context, conn clickhouse.Conn) (uint64, error) {
+		var count uint64
+		err := conn.QueryRow(ctx, query).Scan(&count)
+		return count, err
+	}, func(counts []uint64) uint64 {
+		var total uint64
+		for _, count := range counts {
+			total += count
+		}
+		return total
+	})
+	if err != nil {
+		return 0, false, err
+	}
+
+	if result.Partial {
+		zap.L().Warn("count is partial as some shards failed", zap.String("query", query), zap.Any("errors", result.Errors))
+	}
 
-	var totalSpans uint64
+	return result.Value, result.Partial, nil
+}
 
+func (r *ClickHouseReader) GetTotalSpans(ctx context.Context) (uint64, bool, error) {
 	queryStr := fmt.Sprintf("SELECT count() from %s.%s;", signozTraceDBName, r.traceTableName)
-	r.db.QueryRow(ctx, queryStr).Scan(&totalSpans)
-
-	return totalSpans, nil
+	return r.countAcrossShards(ctx, queryStr)
 }
 
 func (r *ClickHouseReader) GetSpansInLastHeartBeatInterval(ctx context.Context, interval time.Duration) (uint64, error) {
@@ -2303,14 +2354,9 @@ func (r *ClickHouseReader) GetSpansInLastHeartBeatInterval(ctx context.Context,
 	return spansInLastHeartBeatInterval, nil
 }
 
-func (r *ClickHouseReader) GetTotalLogs(ctx context.Context) (uint64, error) {
-
-	var totalLogs uint64
-
+func (r *ClickHouseReader) GetTotalLogs(ctx context.Context) (uint64, bool, error) {
 	queryStr := fmt.Sprintf("SELECT count() from %s.%s;", r.logsDB, r.logsTableName)
-	r.db.QueryRow(ctx, queryStr).Scan(&totalLogs)
-
-	return totalLogs, nil
+	return r.countAcrossShards(ctx, queryStr)
 }
 
 func (r *ClickHouseReader) FetchTemporality(ctx context.Context, orgID valuer.UUID, metricNames []string) (map[string]map[v3.Temporality]bool, error) {
@@ -2395,14 +2441,9 @@ func (r *ClickHouseReader) GetSamplesInfoInLastHeartBeatInterval(ctx context.Con
 	return totalSamples, nil
 }
 
-func (r *ClickHouseReader) GetTotalSamples(ctx context.Context) (uint64, error) {
-	var totalSamples uint64
-
+func (r *ClickHouseReader) GetTotalSamples(ctx context.Context) (uint64, bool, error) {
 	queryStr := fmt.Sprintf("select count() from %s.%s where metric_name not like 'signoz_%%';", signozMetricDBName, signozSampleTableName)
-
-	r.db.QueryRow(ctx, queryStr).Scan(&totalSamples)
-
-	return totalSamples, nil
+	return r.countAcrossShards(ctx, queryStr)
 }
 
 func (r *ClickHouseReader) GetDistributedInfoInLastHeartBeatInterval(ctx context.Context) (map[string]interface{}, error) {
@@ -3919,7 +3960,7 @@ func (r *ClickHouseReader) GetTimeSeriesResultV3(ctx context.Context, query stri
 			zap.L().Error("GetTimeSeriesResultV3: queryId in ctx not a string as expected", zap.Any("queryId", queryId))
 
 		} else {
-			ctx = clickhouse.Context(ctx, clickhouse.WithProgress(
+			ctx = telemetrystore.NewContextWithProgress(ctx,
 				func(p *clickhouse.Progress) {
 					go func() {
 						err := r.queryProgressTracker.ReportQueryProgress(qid, p)
@@ -3931,7 +3972,7 @@ func (r *ClickHouseReader) GetTimeSeriesResultV3(ctx context.Context, query stri
 						}
 					}()
 				},
-			))
+			)
 		}
 	}
 
@@ -3939,7 +3980,8 @@ func (r *ClickHouseReader) GetTimeSeriesResultV3(ctx context.Context, query stri
 
 	if err != nil {
 		zap.L().Error("error while reading time series result", zap.Error(err))
-		return nil, errors.New(err.Error())
+		// The error is returned as is so that the timeouts of the telemetry store keep their type.
+		return nil, err
 	}
 	defer rows.Close()
 
@@ -3986,7 +4028,8 @@ func (r *ClickHouseReader) GetListResultV3(ctx context.Context, query string) ([
 
 	if err != nil {
 		zap.L().Error("error while reading time series result", zap.Error(err))
-		return nil, errors.New(err.Error())
+		// The error is returned as is so that the timeouts of the telemetry store keep their type.
+		return nil, err
 	}
 	defer rows.Close()
 
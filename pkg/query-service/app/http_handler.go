@@ -27,11 +27,18 @@ import (
 	errorsV2 "github.com/SigNoz/signoz/pkg/errors"
 	"github.com/SigNoz/signoz/pkg/http/middleware"
 	"github.com/SigNoz/signoz/pkg/http/render"
+	"github.com/SigNoz/signoz/pkg/instrumentation"
 	"github.com/SigNoz/signoz/pkg/licensing"
+	"github.com/SigNoz/signoz/pkg/licensing/overridelicensing"
+	"github.com/SigNoz/signoz/pkg/maintenance"
 	"github.com/SigNoz/signoz/pkg/query-service/app/cloudintegrations/services"
 	"github.com/SigNoz/signoz/pkg/query-service/app/integrations"
 	"github.com/SigNoz/signoz/pkg/query-service/app/metricsexplorer"
 	"github.com/SigNoz/signoz/pkg/signoz"
+	"github.com/SigNoz/signoz/pkg/telemetrylogs"
+	"github.com/SigNoz/signoz/pkg/telemetrymetrics"
+	"github.com/SigNoz/signoz/pkg/telemetrystore"
+	"github.com/SigNoz/signoz/pkg/telemetrytraces"
 	"github.com/SigNoz/signoz/pkg/valuer"
 	"github.com/prometheus/prometheus/promql"
 
@@ -64,7 +71,10 @@ import (
 	"github.com/SigNoz/signoz/pkg/types/dashboardtypes"
 	"github.com/SigNoz/signoz/pkg/types/licensetypes"
 	"github.com/SigNoz/signoz/pkg/types/pipelinetypes"
+	"github.com/SigNoz/signoz/pkg/types/rbactypes"
 	ruletypes "github.com/SigNoz/signoz/pkg/types/ruletypes"
+	"github.com/SigNoz/signoz/pkg/types/ssotypes"
+	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
 
 	"go.uber.org/zap"
 
@@ -149,6 +159,8 @@ type APIHandler struct {
 	QuerierAPI *querierAPI.API
 
 	Signoz *signoz.SigNoz
+
+	maintenance *middleware.Maintenance
 }
 
 type APIHandlerOpts struct {
@@ -250,6 +262,7 @@ func NewAPIHandler(opts APIHandlerOpts) (*APIHandler, error) {
 		Signoz:                        opts.Signoz,
 		FieldsAPI:                     opts.FieldsAPI,
 		QuerierAPI:                    opts.QuerierAPI,
+		maintenance:                   middleware.NewMaintenance(opts.Signoz.Instrumentation.Logger(), opts.Signoz.Maintenance),
 	}
 
 	logsQueryBuilder := logsv4.PrepareLogsQuery
@@ -331,8 +344,10 @@ func RespondError(w http.ResponseWriter, apiErr model.BaseApiError, data interfa
 		code = http.StatusBadRequest
 	case model.ErrorExec:
 		code = 422
-	case model.ErrorCanceled, model.ErrorTimeout:
+	case model.ErrorCanceled:
 		code = http.StatusServiceUnavailable
+	case model.ErrorTimeout:
+		code = http.StatusGatewayTimeout
 	case model.ErrorInternal:
 		code = http.StatusInternalServerError
 	case model.ErrorNotFound:
@@ -483,6 +498,8 @@ func (aH *APIHandler) RegisterQueryRangeV4Routes(router *mux.Router, am *middlew
 func (aH *APIHandler) RegisterQueryRangeV5Routes(router *mux.Router, am *middleware.AuthZ) {
 	subRouter := router.PathPrefix("/api/v5").Subrouter()
 	subRouter.HandleFunc("/query_range", am.ViewAccess(aH.QuerierAPI.QueryRange)).Methods(http.MethodPost)
+	subRouter.HandleFunc("/query_range/export", am.ViewAccess(aH.QuerierAPI.QueryRangeExport)).Methods(http.MethodPost)
+	subRouter.HandleFunc("/query_range/federated", am.ViewAccess(aH.QuerierAPI.FederatedQuery)).Methods(http.MethodPost)
 }
 
 // todo(remove): Implemented at render package (github.com/SigNoz/signoz/pkg/http/render) with the new error structure
@@ -495,26 +512,44 @@ func (aH *APIHandler) RegisterPrivateRoutes(router *mux.Router) {
 	router.HandleFunc("/api/v1/channels", aH.AlertmanagerAPI.ListAllChannels).Methods(http.MethodGet)
 }
 
+// Writes marks the handler as a write, which is rejected in maintenance mode. The handlers which only read are not
+// marked, even when they are requested with a POST.
+func (aH *APIHandler) Writes(next http.HandlerFunc) http.HandlerFunc {
+	return aH.maintenance.Write(next)
+}
+
 // RegisterRoutes registers routes for this handler on the given router
 func (aH *APIHandler) RegisterRoutes(router *mux.Router, am *middleware.AuthZ) {
 	router.HandleFunc("/api/v1/query_range", am.ViewAccess(aH.queryRangeMetrics)).Methods(http.MethodGet)
 	router.HandleFunc("/api/v1/query", am.ViewAccess(aH.queryMetrics)).Methods(http.MethodGet)
 	router.HandleFunc("/api/v1/channels", am.ViewAccess(aH.AlertmanagerAPI.ListChannels)).Methods(http.MethodGet)
 	router.HandleFunc("/api/v1/channels/{id}", am.ViewAccess(aH.AlertmanagerAPI.GetChannelByID)).Methods(http.MethodGet)
-	router.HandleFunc("/api/v1/channels/{id}", am.AdminAccess(aH.AlertmanagerAPI.UpdateChannelByID)).Methods(http.MethodPut)
-	router.HandleFunc("/api/v1/channels/{id}", am.AdminAccess(aH.AlertmanagerAPI.DeleteChannelByID)).Methods(http.MethodDelete)
-	router.HandleFunc("/api/v1/channels", am.EditAccess(aH.AlertmanagerAPI.CreateChannel)).Methods(http.MethodPost)
+	router.HandleFunc("/api/v1/channels/{id}", am.AdminAccess(aH.Writes(aH.AlertmanagerAPI.UpdateChannelByID))).Methods(http.MethodPut)
+	router.HandleFunc("/api/v1/channels/{id}", am.AdminAccess(aH.Writes(aH.AlertmanagerAPI.DeleteChannelByID))).Methods(http.MethodDelete)
+	router.HandleFunc("/api/v1/channels/{id}/signing_secret", am.AdminAccess(aH.Writes(aH.AlertmanagerAPI.SetChannelSigningSecretByID))).Methods(http.MethodPut)
+	router.HandleFunc("/api/v1/channels/{id}/plugin", am.AdminAccess(aH.Writes(aH.AlertmanagerAPI.SetChannelPluginByID))).Methods(http.MethodPut)
+	router.HandleFunc("/api/v1/channels", am.EditAccess(aH.Writes(aH.AlertmanagerAPI.CreateChannel))).Methods(http.MethodPost)
 	router.HandleFunc("/api/v1/testChannel", am.EditAccess(aH.AlertmanagerAPI.TestReceiver)).Methods(http.MethodPost)
 
 	router.HandleFunc("/api/v1/alerts", am.ViewAccess(aH.AlertmanagerAPI.GetAlerts)).Methods(http.MethodGet)
+	router.HandleFunc("/api/v1/alerts/inbound", am.EditAccess(aH.Writes(aH.AlertmanagerAPI.PutInboundAlerts))).Methods(http.MethodPost)
+	router.HandleFunc("/api/v1/alerts/inbound/channels", am.ViewAccess(aH.AlertmanagerAPI.GetInboundChannels)).Methods(http.MethodGet)
+	router.HandleFunc("/api/v1/alerts/inbound/channels", am.AdminAccess(aH.Writes(aH.AlertmanagerAPI.PutInboundChannels))).Methods(http.MethodPut)
+	router.HandleFunc("/api/v1/alerts/dead_letters", am.ViewAccess(aH.AlertmanagerAPI.ListDeadLetters)).Methods(http.MethodGet)
+	router.HandleFunc("/api/v1/alerts/dead_letters/{id}/redispatch", am.EditAccess(aH.Writes(aH.AlertmanagerAPI.RedispatchDeadLetter))).Methods(http.MethodPost)
+	router.HandleFunc("/api/v1/alerts/config/reload", am.AdminAccess(aH.Writes(aH.AlertmanagerAPI.ReloadConfig))).Methods(http.MethodPost)
+	router.HandleFunc("/api/v1/alerts/silences", am.ViewAccess(aH.AlertmanagerAPI.ListSilences)).Methods(http.MethodGet)
+	router.HandleFunc("/api/v1/alerts/silences", am.EditAccess(aH.Writes(aH.AlertmanagerAPI.CreateSilence))).Methods(http.MethodPost)
+	router.HandleFunc("/api/v1/alerts/silences/{id}", am.EditAccess(aH.Writes(aH.AlertmanagerAPI.ExpireSilence))).Methods(http.MethodDelete)
 
 	router.HandleFunc("/api/v1/rules", am.ViewAccess(aH.listRules)).Methods(http.MethodGet)
 	router.HandleFunc("/api/v1/rules/{id}", am.ViewAccess(aH.getRule)).Methods(http.MethodGet)
-	router.HandleFunc("/api/v1/rules", am.EditAccess(aH.createRule)).Methods(http.MethodPost)
-	router.HandleFunc("/api/v1/rules/{id}", am.EditAccess(aH.editRule)).Methods(http.MethodPut)
-	router.HandleFunc("/api/v1/rules/{id}", am.EditAccess(aH.deleteRule)).Methods(http.MethodDelete)
-	router.HandleFunc("/api/v1/rules/{id}", am.EditAccess(aH.patchRule)).Methods(http.MethodPatch)
+	router.HandleFunc("/api/v1/rules", am.EditAccess(aH.Writes(aH.createRule))).Methods(http.MethodPost)
+	router.HandleFunc("/api/v1/rules/{id}", am.EditAccess(aH.Writes(aH.editRule))).Methods(http.MethodPut)
+	router.HandleFunc("/api/v1/rules/{id}", am.EditAccess(aH.Writes(aH.deleteRule))).Methods(http.MethodDelete)
+	router.HandleFunc("/api/v1/rules/{id}", am.EditAccess(aH.Writes(aH.patchRule))).Methods(http.MethodPatch)
 	router.HandleFunc("/api/v1/testRule", am.EditAccess(aH.testRule)).Methods(http.MethodPost)
+	router.HandleFunc("/api/v1/rules/validate", am.EditAccess(aH.validateRule)).Methods(http.MethodPost)
 	router.HandleFunc("/api/v1/rules/{id}/history/stats", am.ViewAccess(aH.getRuleStats)).Methods(http.MethodPost)
 	router.HandleFunc("/api/v1/rules/{id}/history/timeline", am.ViewAccess(aH.getRuleStateHistory)).Methods(http.MethodPost)
 	router.HandleFunc("/api/v1/rules/{id}/history/top_contributors", am.ViewAccess(aH.getRuleStateHistoryTopContributors)).Methods(http.MethodPost)
@@ -522,23 +557,43 @@ func (aH *APIHandler) RegisterRoutes(router *mux.Router, am *middleware.AuthZ) {
 
 	router.HandleFunc("/api/v1/downtime_schedules", am.ViewAccess(aH.listDowntimeSchedules)).Methods(http.MethodGet)
 	router.HandleFunc("/api/v1/downtime_schedules/{id}", am.ViewAccess(aH.getDowntimeSchedule)).Methods(http.MethodGet)
-	router.HandleFunc("/api/v1/downtime_schedules", am.EditAccess(aH.createDowntimeSchedule)).Methods(http.MethodPost)
-	router.HandleFunc("/api/v1/downtime_schedules/{id}", am.EditAccess(aH.editDowntimeSchedule)).Methods(http.MethodPut)
-	router.HandleFunc("/api/v1/downtime_schedules/{id}", am.EditAccess(aH.deleteDowntimeSchedule)).Methods(http.MethodDelete)
-
-	router.HandleFunc("/api/v1/dashboards", am.ViewAccess(aH.List)).Methods(http.MethodGet)
-	router.HandleFunc("/api/v1/dashboards", am.EditAccess(aH.Signoz.Handlers.Dashboard.Create)).Methods(http.MethodPost)
-	router.HandleFunc("/api/v1/dashboards/{id}", am.ViewAccess(aH.Get)).Methods(http.MethodGet)
-	router.HandleFunc("/api/v1/dashboards/{id}", am.EditAccess(aH.Signoz.Handlers.Dashboard.Update)).Methods(http.MethodPut)
-	router.HandleFunc("/api/v1/dashboards/{id}", am.EditAccess(aH.Signoz.Handlers.Dashboard.Delete)).Methods(http.MethodDelete)
-	router.HandleFunc("/api/v1/dashboards/{id}/lock", am.EditAccess(aH.Signoz.Handlers.Dashboard.LockUnlock)).Methods(http.MethodPut)
+	router.HandleFunc("/api/v1/downtime_schedules", am.EditAccess(aH.Writes(aH.createDowntimeSchedule))).Methods(http.MethodPost)
+	router.HandleFunc("/api/v1/downtime_schedules/{id}", am.EditAccess(aH.Writes(aH.editDowntimeSchedule))).Methods(http.MethodPut)
+	router.HandleFunc("/api/v1/downtime_schedules/{id}", am.EditAccess(aH.Writes(aH.deleteDowntimeSchedule))).Methods(http.MethodDelete)
+
+	router.HandleFunc("/api/v1/dashboards", am.Permission("dashboards", rbactypes.ActionRead, aH.List)).Methods(http.MethodGet)
+	router.HandleFunc("/api/v1/dashboards", am.Permission("dashboards", rbactypes.ActionWrite, aH.Writes(aH.Signoz.Handlers.Dashboard.Create))).Methods(http.MethodPost)
+	router.HandleFunc("/api/v1/dashboards/export", am.Permission("dashboards", rbactypes.ActionRead, aH.Signoz.Handlers.Dashboard.Export)).Methods(http.MethodPost)
+	router.HandleFunc("/api/v1/dashboards/import", am.Permission("dashboards", rbactypes.ActionWrite, aH.Writes(aH.Signoz.Handlers.Dashboard.Import))).Methods(http.MethodPost)
+	router.HandleFunc("/api/v1/dashboards/deleted", am.Permission("dashboards", rbactypes.ActionRead, aH.Signoz.Handlers.Dashboard.ListDeleted)).Methods(http.MethodGet)
+	router.HandleFunc("/api/v1/dashboards/{id}", am.Permission("dashboards", rbactypes.ActionRead, aH.Get)).Methods(http.MethodGet)
+	router.HandleFunc("/api/v1/dashboards/{id}", am.Permission("dashboards", rbactypes.ActionWrite, aH.Writes(aH.Signoz.Handlers.Dashboard.Update))).Methods(http.MethodPut)
+	router.HandleFunc("/api/v1/dashboards/{id}", am.Permission("dashboards", rbactypes.ActionWrite, aH.Writes(aH.Signoz.Handlers.Dashboard.Delete))).Methods(http.MethodDelete)
+	router.HandleFunc("/api/v1/dashboards/{id}/lock", am.Permission("dashboards", rbactypes.ActionWrite, aH.Writes(aH.Signoz.Handlers.Dashboard.LockUnlock))).Methods(http.MethodPut)
+	router.HandleFunc("/api/v1/dashboards/{id}/restore", am.Permission("dashboards", rbactypes.ActionWrite, aH.Writes(aH.Signoz.Handlers.Dashboard.Restore))).Methods(http.MethodPost)
+	// The public links are managed under their own resource so that a role can be allowed or denied the sharing of the
+	// dashboards independently of their edition.
+	router.HandleFunc("/api/v1/dashboards/{id}/public_links", am.Permission("dashboard_public_links", rbactypes.ActionWrite, aH.Writes(aH.Signoz.Handlers.Dashboard.CreatePublicLink))).Methods(http.MethodPost)
+	router.HandleFunc("/api/v1/dashboards/{id}/public_links", am.Permission("dashboard_public_links", rbactypes.ActionRead, aH.Signoz.Handlers.Dashboard.ListPublicLinks)).Methods(http.MethodGet)
+	router.HandleFunc("/api/v1/dashboards/{id}/public_links/audit", am.Permission("dashboard_public_links", rbactypes.ActionRead, aH.Signoz.Handlers.Dashboard.ListPublicLinkAuditEvents)).Methods(http.MethodGet)
+	router.HandleFunc("/api/v1/dashboards/{id}/public_links/{linkId}", am.Permission("dashboard_public_links", rbactypes.ActionWrite, aH.Writes(aH.Signoz.Handlers.Dashboard.RevokePublicLink))).Methods(http.MethodDelete)
+	// The public links grant a read only access to their dashboard without an account.
+	router.HandleFunc("/api/v1/public/dashboards/{token}", am.OpenAccess(aH.Signoz.Handlers.Dashboard.GetPublic)).Methods(http.MethodGet)
+	router.HandleFunc("/api/v1/public/dashboards/{token}/widgets/{widgetId}/query_range", am.OpenAccess(aH.Signoz.Handlers.Dashboard.QueryRangePublic)).Methods(http.MethodPost)
+
+	router.HandleFunc("/api/v1/rbac/roles", am.AdminAccess(aH.Signoz.Handlers.RBAC.ListRoles)).Methods(http.MethodGet)
+	router.HandleFunc("/api/v1/rbac/roles", am.AdminAccess(aH.Writes(aH.Signoz.Handlers.RBAC.CreateRole))).Methods(http.MethodPost)
+	router.HandleFunc("/api/v1/rbac/roles/{id}", am.AdminAccess(aH.Writes(aH.Signoz.Handlers.RBAC.DeleteRole))).Methods(http.MethodDelete)
+	router.HandleFunc("/api/v1/rbac/bindings", am.AdminAccess(aH.Signoz.Handlers.RBAC.ListBindings)).Methods(http.MethodGet)
+	router.HandleFunc("/api/v1/rbac/bindings", am.AdminAccess(aH.Writes(aH.Signoz.Handlers.RBAC.CreateBinding))).Methods(http.MethodPost)
+	router.HandleFunc("/api/v1/rbac/bindings/{id}", am.AdminAccess(aH.Writes(aH.Signoz.Handlers.RBAC.DeleteBinding))).Methods(http.MethodDelete)
 	router.HandleFunc("/api/v2/variables/query", am.ViewAccess(aH.queryDashboardVarsV2)).Methods(http.MethodPost)
 
 	router.HandleFunc("/api/v1/explorer/views", am.ViewAccess(aH.Signoz.Handlers.SavedView.List)).Methods(http.MethodGet)
-	router.HandleFunc("/api/v1/explorer/views", am.EditAccess(aH.Signoz.Handlers.SavedView.Create)).Methods(http.MethodPost)
+	router.HandleFunc("/api/v1/explorer/views", am.EditAccess(aH.Writes(aH.Signoz.Handlers.SavedView.Create))).Methods(http.MethodPost)
 	router.HandleFunc("/api/v1/explorer/views/{viewId}", am.ViewAccess(aH.Signoz.Handlers.SavedView.Get)).Methods(http.MethodGet)
-	router.HandleFunc("/api/v1/explorer/views/{viewId}", am.EditAccess(aH.Signoz.Handlers.SavedView.Update)).Methods(http.MethodPut)
-	router.HandleFunc("/api/v1/explorer/views/{viewId}", am.EditAccess(aH.Signoz.Handlers.SavedView.Delete)).Methods(http.MethodDelete)
+	router.HandleFunc("/api/v1/explorer/views/{viewId}", am.EditAccess(aH.Writes(aH.Signoz.Handlers.SavedView.Update))).Methods(http.MethodPut)
+	router.HandleFunc("/api/v1/explorer/views/{viewId}", am.EditAccess(aH.Writes(aH.Signoz.Handlers.SavedView.Delete))).Methods(http.MethodDelete)
 
 	router.HandleFunc("/api/v1/feedback", am.OpenAccess(aH.submitFeedback)).Methods(http.MethodPost)
 	router.HandleFunc("/api/v1/event", am.ViewAccess(aH.registerEvent)).Methods(http.MethodPost)
@@ -551,19 +606,45 @@ func (aH *APIHandler) RegisterRoutes(router *mux.Router, am *middleware.AuthZ) {
 	router.HandleFunc("/api/v1/traces/{traceId}", am.ViewAccess(aH.SearchTraces)).Methods(http.MethodGet)
 	router.HandleFunc("/api/v1/usage", am.ViewAccess(aH.getUsage)).Methods(http.MethodGet)
 	router.HandleFunc("/api/v1/dependency_graph", am.ViewAccess(aH.dependencyGraph)).Methods(http.MethodPost)
-	router.HandleFunc("/api/v1/settings/ttl", am.AdminAccess(aH.setTTL)).Methods(http.MethodPost)
+	router.HandleFunc("/api/v1/settings/ttl", am.AdminAccess(aH.Writes(aH.setTTL))).Methods(http.MethodPost)
 	router.HandleFunc("/api/v1/settings/ttl", am.ViewAccess(aH.getTTL)).Methods(http.MethodGet)
-	router.HandleFunc("/api/v1/settings/apdex", am.AdminAccess(aH.Signoz.Handlers.Apdex.Set)).Methods(http.MethodPost)
+	router.HandleFunc("/api/v1/settings/retention", am.ViewAccess(aH.getRetention)).Methods(http.MethodGet)
+	router.HandleFunc("/api/v1/telemetry/schema/{signal}", am.ViewAccess(aH.getTelemetrySchema)).Methods(http.MethodGet)
+	router.HandleFunc("/api/v1/settings/apdex", am.AdminAccess(aH.Writes(aH.Signoz.Handlers.Apdex.Set))).Methods(http.MethodPost)
 	router.HandleFunc("/api/v1/settings/apdex", am.ViewAccess(aH.Signoz.Handlers.Apdex.Get)).Methods(http.MethodGet)
 
 	router.HandleFunc("/api/v2/traces/fields", am.ViewAccess(aH.traceFields)).Methods(http.MethodGet)
-	router.HandleFunc("/api/v2/traces/fields", am.EditAccess(aH.updateTraceField)).Methods(http.MethodPost)
+	router.HandleFunc("/api/v2/traces/fields", am.EditAccess(aH.Writes(aH.updateTraceField))).Methods(http.MethodPost)
 	router.HandleFunc("/api/v2/traces/flamegraph/{traceId}", am.ViewAccess(aH.GetFlamegraphSpansForTrace)).Methods(http.MethodPost)
 	router.HandleFunc("/api/v2/traces/waterfall/{traceId}", am.ViewAccess(aH.GetWaterfallSpansForTraceWithMetadata)).Methods(http.MethodPost)
 
 	router.HandleFunc("/api/v1/version", am.OpenAccess(aH.getVersion)).Methods(http.MethodGet)
 	router.HandleFunc("/api/v1/features", am.ViewAccess(aH.getFeatureFlags)).Methods(http.MethodGet)
+	featureOverridesAPI := overridelicensing.NewAPI(aH.Signoz.Licensing)
+	router.HandleFunc("/api/v1/features/overrides", am.AdminAccess(featureOverridesAPI.List)).Methods(http.MethodGet)
+	router.HandleFunc("/api/v1/features/overrides", am.AdminAccess(aH.Writes(featureOverridesAPI.Set))).Methods(http.MethodPut)
+	router.HandleFunc("/api/v1/features/overrides/{name}", am.AdminAccess(aH.Writes(featureOverridesAPI.Delete))).Methods(http.MethodDelete)
+
+	deletionAPI := telemetrystore.NewDeletionAPI(aH.Signoz.Instrumentation.Logger(), aH.Signoz.TelemetryStore, aH.Signoz.SQLStore, map[telemetrytypes.Signal]telemetrystore.DeletionTables{
+		telemetrytypes.SignalMetrics: telemetrymetrics.DeletionTables,
+		telemetrytypes.SignalLogs:    telemetrylogs.DeletionTables,
+		telemetrytypes.SignalTraces:  telemetrytraces.DeletionTables,
+	})
+	router.HandleFunc("/api/v1/telemetry/deletions", am.AdminAccess(aH.Writes(deletionAPI.Delete))).Methods(http.MethodPost)
+	router.HandleFunc("/api/v1/telemetry/deletions/{id}", am.AdminAccess(deletionAPI.Get)).Methods(http.MethodGet)
 	router.HandleFunc("/api/v1/health", am.OpenAccess(aH.getHealth)).Methods(http.MethodGet)
+	router.HandleFunc("/api/v1/status", am.OpenAccess(aH.getStatus)).Methods(http.MethodGet)
+	router.HandleFunc("/api/v1/migrations/progress", am.AdminAccess(aH.streamMigrationsProgress)).Methods(http.MethodGet)
+	router.HandleFunc("/api/v1/config/reconcile", am.AdminAccess(aH.reconcileConfig)).Methods(http.MethodGet)
+	router.HandleFunc("/api/v1/maintenance", am.AdminAccess(aH.getMaintenance)).Methods(http.MethodGet)
+	router.HandleFunc("/api/v1/maintenance", am.AdminAccess(aH.setMaintenance)).Methods(http.MethodPut)
+	router.HandleFunc("/api/v1/log_levels", am.AdminAccess(aH.getLogLevels)).Methods(http.MethodGet)
+	router.HandleFunc("/api/v1/log_levels", am.AdminAccess(aH.setLogLevel)).Methods(http.MethodPut)
+	router.HandleFunc("/api/v1/log_levels", am.AdminAccess(aH.resetLogLevel)).Methods(http.MethodDelete)
+	router.HandleFunc("/ready", am.OpenAccess(aH.getReady)).Methods(http.MethodGet)
+	router.HandleFunc("/live", am.OpenAccess(aH.getLive)).Methods(http.MethodGet)
+	router.HandleFunc("/api/v1/prom/write", am.EditAccess(aH.Writes(aH.prometheusRemoteWrite))).Methods(http.MethodPost)
+	router.HandleFunc("/api/v1/otlp/v1/traces", am.EditAccess(aH.Writes(aH.Signoz.OTLPReceiver.ExportTraces))).Methods(http.MethodPost)
 
 	router.HandleFunc("/api/v1/listErrors", am.ViewAccess(aH.listErrors)).Methods(http.MethodPost)
 	router.HandleFunc("/api/v1/countErrors", am.ViewAccess(aH.countErrors)).Methods(http.MethodPost)
@@ -575,51 +656,52 @@ func (aH *APIHandler) RegisterRoutes(router *mux.Router, am *middleware.AuthZ) {
 
 	router.HandleFunc("/api/v1/user/preferences", am.ViewAccess(aH.Signoz.Handlers.Preference.ListByUser)).Methods(http.MethodGet)
 	router.HandleFunc("/api/v1/user/preferences/{name}", am.ViewAccess(aH.Signoz.Handlers.Preference.GetByUser)).Methods(http.MethodGet)
-	router.HandleFunc("/api/v1/user/preferences/{name}", am.ViewAccess(aH.Signoz.Handlers.Preference.UpdateByUser)).Methods(http.MethodPut)
+	router.HandleFunc("/api/v1/user/preferences/{name}", am.ViewAccess(aH.Writes(aH.Signoz.Handlers.Preference.UpdateByUser))).Methods(http.MethodPut)
 	router.HandleFunc("/api/v1/org/preferences", am.AdminAccess(aH.Signoz.Handlers.Preference.ListByOrg)).Methods(http.MethodGet)
 	router.HandleFunc("/api/v1/org/preferences/{name}", am.AdminAccess(aH.Signoz.Handlers.Preference.GetByOrg)).Methods(http.MethodGet)
-	router.HandleFunc("/api/v1/org/preferences/{name}", am.AdminAccess(aH.Signoz.Handlers.Preference.UpdateByOrg)).Methods(http.MethodPut)
+	router.HandleFunc("/api/v1/org/preferences/{name}", am.AdminAccess(aH.Writes(aH.Signoz.Handlers.Preference.UpdateByOrg))).Methods(http.MethodPut)
 
 	// Quick Filters
 	router.HandleFunc("/api/v1/orgs/me/filters", am.ViewAccess(aH.Signoz.Handlers.QuickFilter.GetQuickFilters)).Methods(http.MethodGet)
 	router.HandleFunc("/api/v1/orgs/me/filters/{signal}", am.ViewAccess(aH.Signoz.Handlers.QuickFilter.GetSignalFilters)).Methods(http.MethodGet)
-	router.HandleFunc("/api/v1/orgs/me/filters", am.AdminAccess(aH.Signoz.Handlers.QuickFilter.UpdateQuickFilters)).Methods(http.MethodPut)
+	router.HandleFunc("/api/v1/orgs/me/filters", am.AdminAccess(aH.Writes(aH.Signoz.Handlers.QuickFilter.UpdateQuickFilters))).Methods(http.MethodPut)
 
 	// === Authentication APIs ===
-	router.HandleFunc("/api/v1/invite", am.AdminAccess(aH.Signoz.Handlers.User.CreateInvite)).Methods(http.MethodPost)
-	router.HandleFunc("/api/v1/invite/bulk", am.AdminAccess(aH.Signoz.Handlers.User.CreateBulkInvite)).Methods(http.MethodPost)
+	router.HandleFunc("/api/v1/invite", am.AdminAccess(aH.Writes(aH.Signoz.Handlers.User.CreateInvite))).Methods(http.MethodPost)
+	router.HandleFunc("/api/v1/invite/bulk", am.AdminAccess(aH.Writes(aH.Signoz.Handlers.User.CreateBulkInvite))).Methods(http.MethodPost)
 	router.HandleFunc("/api/v1/invite/{token}", am.OpenAccess(aH.Signoz.Handlers.User.GetInvite)).Methods(http.MethodGet)
-	router.HandleFunc("/api/v1/invite/{id}", am.AdminAccess(aH.Signoz.Handlers.User.DeleteInvite)).Methods(http.MethodDelete)
+	router.HandleFunc("/api/v1/invite/{id}", am.AdminAccess(aH.Writes(aH.Signoz.Handlers.User.DeleteInvite))).Methods(http.MethodDelete)
 	router.HandleFunc("/api/v1/invite", am.AdminAccess(aH.Signoz.Handlers.User.ListInvite)).Methods(http.MethodGet)
-	router.HandleFunc("/api/v1/invite/accept", am.OpenAccess(aH.Signoz.Handlers.User.AcceptInvite)).Methods(http.MethodPost)
+	router.HandleFunc("/api/v1/invite/accept", am.OpenAccess(aH.Writes(aH.Signoz.Handlers.User.AcceptInvite))).Methods(http.MethodPost)
 
-	router.HandleFunc("/api/v1/register", am.OpenAccess(aH.registerUser)).Methods(http.MethodPost)
+	router.HandleFunc("/api/v1/register", am.OpenAccess(aH.Writes(aH.registerUser))).Methods(http.MethodPost)
 	router.HandleFunc("/api/v1/login", am.OpenAccess(aH.Signoz.Handlers.User.Login)).Methods(http.MethodPost)
 	router.HandleFunc("/api/v1/loginPrecheck", am.OpenAccess(aH.Signoz.Handlers.User.LoginPrecheck)).Methods(http.MethodGet)
 	router.HandleFunc("/api/v1/complete/google", am.OpenAccess(aH.receiveGoogleAuth)).Methods(http.MethodGet)
+	router.HandleFunc("/api/v1/complete/oidc", am.OpenAccess(aH.receiveOIDC)).Methods(http.MethodGet)
 
 	router.HandleFunc("/api/v1/domains", am.AdminAccess(aH.Signoz.Handlers.User.ListDomains)).Methods(http.MethodGet)
-	router.HandleFunc("/api/v1/domains", am.AdminAccess(aH.Signoz.Handlers.User.CreateDomain)).Methods(http.MethodPost)
-	router.HandleFunc("/api/v1/domains/{id}", am.AdminAccess(aH.Signoz.Handlers.User.UpdateDomain)).Methods(http.MethodPut)
-	router.HandleFunc("/api/v1/domains/{id}", am.AdminAccess(aH.Signoz.Handlers.User.DeleteDomain)).Methods(http.MethodDelete)
+	router.HandleFunc("/api/v1/domains", am.AdminAccess(aH.Writes(aH.Signoz.Handlers.User.CreateDomain))).Methods(http.MethodPost)
+	router.HandleFunc("/api/v1/domains/{id}", am.AdminAccess(aH.Writes(aH.Signoz.Handlers.User.UpdateDomain))).Methods(http.MethodPut)
+	router.HandleFunc("/api/v1/domains/{id}", am.AdminAccess(aH.Writes(aH.Signoz.Handlers.User.DeleteDomain))).Methods(http.MethodDelete)
 
-	router.HandleFunc("/api/v1/pats", am.AdminAccess(aH.Signoz.Handlers.User.CreateAPIKey)).Methods(http.MethodPost)
+	router.HandleFunc("/api/v1/pats", am.AdminAccess(aH.Writes(aH.Signoz.Handlers.User.CreateAPIKey))).Methods(http.MethodPost)
 	router.HandleFunc("/api/v1/pats", am.AdminAccess(aH.Signoz.Handlers.User.ListAPIKeys)).Methods(http.MethodGet)
-	router.HandleFunc("/api/v1/pats/{id}", am.AdminAccess(aH.Signoz.Handlers.User.UpdateAPIKey)).Methods(http.MethodPut)
-	router.HandleFunc("/api/v1/pats/{id}", am.AdminAccess(aH.Signoz.Handlers.User.RevokeAPIKey)).Methods(http.MethodDelete)
+	router.HandleFunc("/api/v1/pats/{id}", am.AdminAccess(aH.Writes(aH.Signoz.Handlers.User.UpdateAPIKey))).Methods(http.MethodPut)
+	router.HandleFunc("/api/v1/pats/{id}", am.AdminAccess(aH.Writes(aH.Signoz.Handlers.User.RevokeAPIKey))).Methods(http.MethodDelete)
 
 	router.HandleFunc("/api/v1/user", am.AdminAccess(aH.Signoz.Handlers.User.ListUsers)).Methods(http.MethodGet)
 	router.HandleFunc("/api/v1/user/me", am.OpenAccess(aH.Signoz.Handlers.User.GetCurrentUserFromJWT)).Methods(http.MethodGet)
 	router.HandleFunc("/api/v1/user/{id}", am.SelfAccess(aH.Signoz.Handlers.User.GetUser)).Methods(http.MethodGet)
-	router.HandleFunc("/api/v1/user/{id}", am.SelfAccess(aH.Signoz.Handlers.User.UpdateUser)).Methods(http.MethodPut)
-	router.HandleFunc("/api/v1/user/{id}", am.AdminAccess(aH.Signoz.Handlers.User.DeleteUser)).Methods(http.MethodDelete)
+	router.HandleFunc("/api/v1/user/{id}", am.SelfAccess(aH.Writes(aH.Signoz.Handlers.User.UpdateUser))).Methods(http.MethodPut)
+	router.HandleFunc("/api/v1/user/{id}", am.AdminAccess(aH.Writes(aH.Signoz.Handlers.User.DeleteUser))).Methods(http.MethodDelete)
 
 	router.HandleFunc("/api/v2/orgs/me", am.AdminAccess(aH.Signoz.Handlers.Organization.Get)).Methods(http.MethodGet)
-	router.HandleFunc("/api/v2/orgs/me", am.AdminAccess(aH.Signoz.Handlers.Organization.Update)).Methods(http.MethodPut)
+	router.HandleFunc("/api/v2/orgs/me", am.AdminAccess(aH.Writes(aH.Signoz.Handlers.Organization.Update))).Methods(http.MethodPut)
 
-	router.HandleFunc("/api/v1/getResetPasswordToken/{id}", am.AdminAccess(aH.Signoz.Handlers.User.GetResetPasswordToken)).Methods(http.MethodGet)
-	router.HandleFunc("/api/v1/resetPassword", am.OpenAccess(aH.Signoz.Handlers.User.ResetPassword)).Methods(http.MethodPost)
-	router.HandleFunc("/api/v1/changePassword/{id}", am.SelfAccess(aH.Signoz.Handlers.User.ChangePassword)).Methods(http.MethodPost)
+	router.HandleFunc("/api/v1/getResetPasswordToken/{id}", am.AdminAccess(aH.Writes(aH.Signoz.Handlers.User.GetResetPasswordToken))).Methods(http.MethodGet)
+	router.HandleFunc("/api/v1/resetPassword", am.OpenAccess(aH.Writes(aH.Signoz.Handlers.User.ResetPassword))).Methods(http.MethodPost)
+	router.HandleFunc("/api/v1/changePassword/{id}", am.SelfAccess(aH.Writes(aH.Signoz.Handlers.User.ChangePassword))).Methods(http.MethodPost)
 
 	router.HandleFunc("/api/v3/licenses", am.ViewAccess(func(rw http.ResponseWriter, req *http.Request) {
 		render.Success(rw, http.StatusOK, []any{})
@@ -652,7 +734,7 @@ func (ah *APIHandler) MetricExplorerRoutes(router *mux.Router, am *middleware.Au
 		am.ViewAccess(ah.GetInspectMetricsData)).
 		Methods(http.MethodPost)
 	router.HandleFunc("/api/v1/metrics/{metric_name}/metadata",
-		am.ViewAccess(ah.UpdateMetricsMetadata)).
+		am.ViewAccess(ah.Writes(ah.UpdateMetricsMetadata))).
 		Methods(http.MethodPost)
 }
 
@@ -1104,8 +1186,18 @@ func (aH *APIHandler) getRuleStateHistoryTopContributors(w http.ResponseWriter,
 }
 
 func (aH *APIHandler) listRules(w http.ResponseWriter, r *http.Request) {
+	pagination, err := types.NewPaginationFromRequest(r)
+	if err != nil {
+		render.Error(w, err)
+		return
+	}
 
-	rules, err := aH.ruleManager.ListRuleStates(r.Context())
+	var rules *ruletypes.GettableRules
+	if pagination != nil {
+		rules, err = aH.ruleManager.ListRuleStatesPage(r.Context(), pagination)
+	} else {
+		rules, err = aH.ruleManager.ListRuleStates(r.Context())
+	}
 	if err != nil {
 		RespondError(w, &model.ApiError{Typ: model.ErrorInternal, Err: err}, nil)
 		return
@@ -1261,8 +1353,20 @@ func (aH *APIHandler) List(rw http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	pagination, err := types.NewPaginationFromRequest(r)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
 	dashboards := make([]*dashboardtypes.Dashboard, 0)
-	sqlDashboards, err := aH.Signoz.Modules.Dashboard.List(ctx, orgID)
+	var sqlDashboards []*dashboardtypes.Dashboard
+	var nextCursor string
+	if pagination != nil {
+		sqlDashboards, nextCursor, err = aH.Signoz.Modules.Dashboard.ListPage(ctx, orgID, pagination)
+	} else {
+		sqlDashboards, err = aH.Signoz.Modules.Dashboard.List(ctx, orgID)
+	}
 	if err != nil && !errorsV2.Ast(err, errorsV2.TypeNotFound) {
 		render.Error(rw, err)
 		return
@@ -1271,6 +1375,18 @@ func (aH *APIHandler) List(rw http.ResponseWriter, r *http.Request) {
 		dashboards = append(dashboards, sqlDashboards...)
 	}
 
+	// The dashboards of the integrations are not stored, they are only listed in the first page.
+	if pagination != nil && pagination.Cursor != nil {
+		gettableDashboards, err := dashboardtypes.NewGettableDashboardsFromDashboards(dashboards)
+		if err != nil {
+			render.Error(rw, err)
+			return
+		}
+
+		render.Success(rw, http.StatusOK, &dashboardtypes.GettableDashboardsPage{Dashboards: gettableDashboards, NextCursor: nextCursor})
+		return
+	}
+
 	installedIntegrationDashboards, apiErr := aH.IntegrationsController.GetDashboardsForInstalledIntegrations(ctx, orgID)
 	if apiErr != nil {
 		zap.L().Error("failed to get dashboards for installed integrations", zap.Error(apiErr))
@@ -1290,6 +1406,12 @@ func (aH *APIHandler) List(rw http.ResponseWriter, r *http.Request) {
 		render.Error(rw, err)
 		return
 	}
+
+	if pagination != nil {
+		render.Success(rw, http.StatusOK, &dashboardtypes.GettableDashboardsPage{Dashboards: gettableDashboards, NextCursor: nextCursor})
+		return
+	}
+
 	render.Success(rw, http.StatusOK, gettableDashboards)
 }
 
@@ -1343,6 +1465,33 @@ func (aH *APIHandler) testRule(w http.ResponseWriter, r *http.Request) {
 	aH.Respond(w, response)
 }
 
+// validateRule lints the rule of the body, looks up the metrics and labels its queries reference and evaluates it
+// once, without saving it nor sending any notification. The errors and warnings of the rule are returned with a 200.
+func (aH *APIHandler) validateRule(w http.ResponseWriter, r *http.Request) {
+	claims, err := authtypes.ClaimsFromContext(r.Context())
+	if err != nil {
+		render.Error(w, err)
+		return
+	}
+	orgID, err := valuer.NewUUID(claims.OrgID)
+	if err != nil {
+		render.Error(w, err)
+		return
+	}
+	defer r.Body.Close()
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		zap.L().Error("Error in getting req body in validate rule API", zap.Error(err))
+		RespondError(w, &model.ApiError{Typ: model.ErrorBadData, Err: err}, nil)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 1*time.Minute)
+	defer cancel()
+
+	aH.Respond(w, aH.ruleManager.ValidateRule(ctx, orgID, string(body)))
+}
+
 func (aH *APIHandler) deleteRule(w http.ResponseWriter, r *http.Request) {
 
 	id := mux.Vars(r)["id"]
@@ -1422,7 +1571,7 @@ func (aH *APIHandler) createRule(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	rule, err := aH.ruleManager.CreateRule(r.Context(), string(body))
+	rule, err := aH.ruleManager.CreateRuleIdempotently(r.Context(), string(body), r.Header.Get(rules.IdempotencyKeyHeader))
 	if err != nil {
 		RespondError(w, &model.ApiError{Typ: model.ErrorBadData, Err: err}, nil)
 		return
@@ -1443,6 +1592,20 @@ func (aH *APIHandler) queryRangeMetrics(w http.ResponseWriter, r *http.Request)
 
 	// zap.L().Info(query, apiError)
 
+	var downsampling string
+	if !query.Raw {
+		downsampled := aH.Signoz.Prometheus.Downsample(query.Query, query.Start, query.End, query.Step)
+		query.Query, query.Step, downsampling = downsampled.Query, downsampled.Step, downsampled.Aggregation
+	}
+
+	// For safety, limit the number of returned points per timeseries.
+	// This is sufficient for 60s resolution for a week or 1h resolution for a year.
+	if query.End.Sub(query.Start)/query.Step > 11000 {
+		err := errors.New("exceeded maximum resolution of 11,000 points per timeseries. Try decreasing the query resolution (?step=XX)")
+		RespondError(w, &model.ApiError{Typ: model.ErrorBadData, Err: err}, nil)
+		return
+	}
+
 	ctx := r.Context()
 	if to := r.FormValue("timeout"); to != "" {
 		var cancel context.CancelFunc
@@ -1478,9 +1641,11 @@ func (aH *APIHandler) queryRangeMetrics(w http.ResponseWriter, r *http.Request)
 	}
 
 	response_data := &model.QueryData{
-		ResultType: res.Value.Type(),
-		Result:     res.Value,
-		Stats:      qs,
+		ResultType:   res.Value.Type(),
+		Result:       res.Value,
+		Stats:        qs,
+		Step:         query.Step.Seconds(),
+		Downsampling: downsampling,
 	}
 
 	aH.Respond(w, response_data)
@@ -1915,6 +2080,12 @@ func (aH *APIHandler) setTTL(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// The TTL of a signal managed by telemetrystore::retention would be replaced on the next start.
+	if aH.Signoz.TelemetryStore.Retention().Managed(telemetrytypes.Signal{String: valuer.NewString(ttlParams.Type)}) {
+		aH.HandleError(w, fmt.Errorf("the retention of %s is managed by telemetrystore::retention and cannot be set from the UI", ttlParams.Type), http.StatusConflict)
+		return
+	}
+
 	// Context is not used here as TTL is long duration DB operation
 	result, apiErr := aH.reader.SetTTL(context.Background(), claims.OrgID, ttlParams)
 	if apiErr != nil {
@@ -1951,6 +2122,37 @@ func (aH *APIHandler) getTTL(w http.ResponseWriter, r *http.Request) {
 	aH.WriteJSON(w, r, result)
 }
 
+// getRetention returns the retention in seconds of every signal applied to the data of the organization, which is
+// the retention of its shard. A zero retention means that the retention of the signal is not managed by the config
+// and is the one set from the UI.
+func (aH *APIHandler) getRetention(w http.ResponseWriter, r *http.Request) {
+	claims, err := authtypes.ClaimsFromContext(r.Context())
+	if err != nil {
+		RespondError(w, &model.ApiError{Err: errors.New("failed to get org id from context"), Typ: model.ErrorInternal}, nil)
+		return
+	}
+
+	policy := aH.Signoz.TelemetryStore.Retention().Policy(claims.OrgID)
+	aH.WriteJSON(w, r, map[string]int64{
+		"traces":  int64(policy.Traces / time.Second),
+		"logs":    int64(policy.Logs / time.Second),
+		"metrics": int64(policy.Metrics / time.Second),
+	})
+}
+
+// getTelemetrySchema returns the tables of the signal with their columns and normalized types.
+func (aH *APIHandler) getTelemetrySchema(w http.ResponseWriter, r *http.Request) {
+	signal := telemetrytypes.Signal{String: valuer.NewString(mux.Vars(r)["signal"])}
+
+	tables, err := aH.Signoz.TelemetryStore.Schema().Tables(r.Context(), signal)
+	if err != nil {
+		render.Error(w, err)
+		return
+	}
+
+	render.Success(w, http.StatusOK, tables)
+}
+
 func (aH *APIHandler) getDisks(w http.ResponseWriter, r *http.Request) {
 	result, apiErr := aH.reader.GetDisks(context.Background())
 	if apiErr != nil && aH.HandleError(w, apiErr.Err, http.StatusInternalServerError) {
@@ -1971,7 +2173,19 @@ func (aH *APIHandler) getVersion(w http.ResponseWriter, r *http.Request) {
 }
 
 func (aH *APIHandler) getFeatureFlags(w http.ResponseWriter, r *http.Request) {
-	featureSet, err := aH.Signoz.Licensing.GetFeatureFlags(r.Context(), valuer.GenerateUUID())
+	claims, err := authtypes.ClaimsFromContext(r.Context())
+	if err != nil {
+		render.Error(w, err)
+		return
+	}
+
+	orgID, err := valuer.NewUUID(claims.OrgID)
+	if err != nil {
+		render.Error(w, err)
+		return
+	}
+
+	featureSet, err := aH.Signoz.Licensing.GetFeatureFlags(r.Context(), orgID)
 	if err != nil {
 		aH.HandleError(w, err, http.StatusInternalServerError)
 		return
@@ -2010,6 +2224,121 @@ func (aH *APIHandler) getHealth(w http.ResponseWriter, r *http.Request) {
 	aH.WriteJSON(w, r, map[string]string{"status": "ok"})
 }
 
+// getStatus reports the health of each subsystem independently. It responds with
+// 503 if any of the critical subsystems are unhealthy.
+func (aH *APIHandler) getStatus(w http.ResponseWriter, r *http.Request) {
+	status := aH.Signoz.Status(r.Context())
+	if !status.Healthy {
+		render.Success(w, http.StatusServiceUnavailable, status)
+		return
+	}
+
+	render.Success(w, http.StatusOK, status)
+}
+
+func (aH *APIHandler) getMaintenance(w http.ResponseWriter, r *http.Request) {
+	render.Success(w, http.StatusOK, aH.Signoz.Maintenance.Status())
+}
+
+// streamMigrationsProgress streams the progress of the sqlstore migrations as server-sent events. The migrations
+// have already run once the api server is up, the stream ends with their final status.
+func (aH *APIHandler) streamMigrationsProgress(w http.ResponseWriter, r *http.Request) {
+	aH.Signoz.SQLMigrator.Progress().ServeHTTP(w, r)
+}
+
+// reconcileConfig reports the config the replica serving the request runs with, along with its drifts from the config
+// loaded again from its sources.
+func (aH *APIHandler) reconcileConfig(w http.ResponseWriter, r *http.Request) {
+	render.Success(w, http.StatusOK, aH.Signoz.ReconcileConfig(r.Context()))
+}
+
+// setMaintenance enables or disables maintenance mode on all the replicas.
+func (aH *APIHandler) setMaintenance(w http.ResponseWriter, r *http.Request) {
+	var postable maintenance.PostableStatus
+	if err := json.NewDecoder(r.Body).Decode(&postable); err != nil {
+		render.Error(w, errorsV2.Wrapf(err, errorsV2.TypeInvalidInput, errorsV2.CodeInvalidInput, "failed to decode maintenance status"))
+		return
+	}
+
+	if err := aH.Signoz.Maintenance.Set(r.Context(), postable.Enabled); err != nil {
+		render.Error(w, err)
+		return
+	}
+	zap.L().Info("maintenance mode changed", zap.Bool("enabled", postable.Enabled))
+
+	render.Success(w, http.StatusOK, aH.Signoz.Maintenance.Status())
+}
+
+func (aH *APIHandler) getLogLevels(w http.ResponseWriter, r *http.Request) {
+	render.Success(w, http.StatusOK, aH.Signoz.Instrumentation.LogLevels())
+}
+
+// setLogLevel overrides the level of the logs of a module on the replica serving the request.
+func (aH *APIHandler) setLogLevel(w http.ResponseWriter, r *http.Request) {
+	var postable instrumentation.PostableLogLevel
+	if err := json.NewDecoder(r.Body).Decode(&postable); err != nil {
+		render.Error(w, errorsV2.Wrapf(err, errorsV2.TypeInvalidInput, errorsV2.CodeInvalidInput, "failed to decode log level"))
+		return
+	}
+
+	override, err := aH.Signoz.Instrumentation.SetLogLevel(postable)
+	if err != nil {
+		render.Error(w, err)
+		return
+	}
+	zap.L().Info("log level changed", zap.String("module", override.Module), zap.String("level", override.Level.String()), zap.Any("expiresAt", override.ExpiresAt))
+
+	render.Success(w, http.StatusOK, aH.Signoz.Instrumentation.LogLevels())
+}
+
+// resetLogLevel reverts the logs of the module of the module query parameter to the default level on the replica
+// serving the request.
+func (aH *APIHandler) resetLogLevel(w http.ResponseWriter, r *http.Request) {
+	module := r.URL.Query().Get("module")
+	if module == "" {
+		render.Error(w, errorsV2.New(errorsV2.TypeInvalidInput, errorsV2.CodeInvalidInput, "module must not be empty"))
+		return
+	}
+
+	aH.Signoz.Instrumentation.ResetLogLevel(module)
+	zap.L().Info("log level reset", zap.String("module", module))
+
+	render.Success(w, http.StatusOK, aH.Signoz.Instrumentation.LogLevels())
+}
+
+// getReady responds with 503 until all the services have started and while any of the
+// critical subsystems is not ready. It is meant to be used as a readiness probe.
+func (aH *APIHandler) getReady(w http.ResponseWriter, r *http.Request) {
+	readiness := aH.Signoz.Readiness(r.Context())
+	if !readiness.Ready {
+		render.Success(w, http.StatusServiceUnavailable, readiness)
+		return
+	}
+
+	render.Success(w, http.StatusOK, readiness)
+}
+
+// getLive responds with 200 as long as the process is able to serve requests. It does not
+// check any dependency and is meant to be used as a liveness probe.
+func (aH *APIHandler) getLive(w http.ResponseWriter, r *http.Request) {
+	render.Success(w, http.StatusOK, map[string]string{"status": "ok"})
+}
+
+func (aH *APIHandler) prometheusRemoteWrite(w http.ResponseWriter, r *http.Request) {
+	req, err := aH.Signoz.Prometheus.DecodeWriteRequest(r.Body)
+	if err != nil {
+		render.Error(w, err)
+		return
+	}
+
+	if err := aH.Signoz.Prometheus.Write(r.Context(), req); err != nil {
+		render.Error(w, err)
+		return
+	}
+
+	render.Success(w, http.StatusNoContent, nil)
+}
+
 func (aH *APIHandler) registerUser(w http.ResponseWriter, r *http.Request) {
 	if aH.SetupCompleted {
 		RespondError(w, &model.ApiError{Err: errors.New("self-registration is disabled"), Typ: model.ErrorBadData}, nil)
@@ -2046,22 +2375,34 @@ func handleSsoError(w http.ResponseWriter, r *http.Request, redirectURL string)
 // receiveGoogleAuth completes google OAuth response and forwards a request
 // to front-end to sign user in
 func (aH *APIHandler) receiveGoogleAuth(w http.ResponseWriter, r *http.Request) {
+	aH.receiveOAuth(w, r, "receiveGoogleAuth", (*types.GettableOrgDomain).PrepareGoogleOAuthProvider)
+}
+
+// receiveOIDC completes the response of the generic OIDC provider of a domain and forwards a request
+// to front-end to sign user in
+func (aH *APIHandler) receiveOIDC(w http.ResponseWriter, r *http.Request) {
+	aH.receiveOAuth(w, r, "receiveOIDC", (*types.GettableOrgDomain).PrepareOIDCProvider)
+}
+
+// receiveOAuth completes the OAuth response of the provider prepared from the domain of the relay state. The claims of
+// the id token are mapped to the user by the claims mapping of the domain.
+func (aH *APIHandler) receiveOAuth(w http.ResponseWriter, r *http.Request, handler string, prepareProvider func(*types.GettableOrgDomain, *url.URL) (ssotypes.OAuthCallbackProvider, error)) {
 	redirectUri := constants.GetDefaultSiteURL()
 	ctx := context.Background()
 
 	q := r.URL.Query()
 	if errType := q.Get("error"); errType != "" {
-		zap.L().Error("[receiveGoogleAuth] failed to login with google auth", zap.String("error", errType), zap.String("error_description", q.Get("error_description")))
+		zap.L().Error("["+handler+"] failed to login with the auth provider", zap.String("error", errType), zap.String("error_description", q.Get("error_description")))
 		http.Redirect(w, r, fmt.Sprintf("%s?ssoerror=%s", redirectUri, "failed to login through SSO"), http.StatusMovedPermanently)
 		return
 	}
 
 	relayState := q.Get("state")
-	zap.L().Debug("[receiveGoogleAuth] relay state received", zap.String("state", relayState))
+	zap.L().Debug("["+handler+"] relay state received", zap.String("state", relayState))
 
 	parsedState, err := url.Parse(relayState)
 	if err != nil || relayState == "" {
-		zap.L().Error("[receiveGoogleAuth] failed to process response - invalid response from IDP", zap.Error(err), zap.Any("request", r))
+		zap.L().Error("["+handler+"] failed to process response - invalid response from IDP", zap.Error(err), zap.Any("request", r))
 		handleSsoError(w, r, redirectUri)
 		return
 	}
@@ -2077,25 +2418,32 @@ func (aH *APIHandler) receiveGoogleAuth(w http.ResponseWriter, r *http.Request)
 	}
 
 	// now that we have domain, use domain to fetch sso settings.
-	// prepare google callback handler using parsedState -
+	// prepare the callback handler using parsedState -
 	// which contains redirect URL (front-end endpoint)
-	callbackHandler, err := domain.PrepareGoogleOAuthProvider(parsedState)
+	callbackHandler, err := prepareProvider(domain, parsedState)
 	if err != nil {
-		zap.L().Error("[receiveGoogleAuth] failed to prepare google oauth provider", zap.String("domain", domain.String()), zap.Error(err))
+		zap.L().Error("["+handler+"] failed to prepare the auth provider", zap.String("domain", domain.String()), zap.Error(err))
 		handleSsoError(w, r, redirectUri)
 		return
 	}
 
 	identity, err := callbackHandler.HandleCallback(r)
 	if err != nil {
-		zap.L().Error("[receiveGoogleAuth] failed to process HandleCallback", zap.String("domain", domain.String()), zap.Error(err))
+		zap.L().Error("["+handler+"] failed to process HandleCallback", zap.String("domain", domain.String()), zap.Error(err))
+		handleSsoError(w, r, redirectUri)
+		return
+	}
+
+	user, err := domain.GetClaimsMapping().Map(identity.Claims, domain.Name)
+	if err != nil {
+		zap.L().Error("["+handler+"] failed to map the claims of the id token", zap.String("domain", domain.String()), zap.Error(err))
 		handleSsoError(w, r, redirectUri)
 		return
 	}
 
-	nextPage, err := aH.Signoz.Modules.User.PrepareSsoRedirect(ctx, redirectUri, identity.Email, aH.JWT)
+	nextPage, err := aH.Signoz.Modules.User.PrepareSsoRedirect(ctx, redirectUri, user.Email, user.Name, user.Role, aH.JWT)
 	if err != nil {
-		zap.L().Error("[receiveGoogleAuth] failed to generate redirect URI after successful login ", zap.String("domain", domain.String()), zap.Error(err))
+		zap.L().Error("["+handler+"] failed to generate redirect URI after successful login ", zap.String("domain", domain.String()), zap.Error(err))
 		handleSsoError(w, r, redirectUri)
 		return
 	}
@@ -3174,11 +3522,11 @@ func (aH *APIHandler) RegisterIntegrationRoutes(router *mux.Router, am *middlewa
 	subRouter := router.PathPrefix("/api/v1/integrations").Subrouter()
 
 	subRouter.HandleFunc(
-		"/install", am.ViewAccess(aH.InstallIntegration),
+		"/install", am.ViewAccess(aH.Writes(aH.InstallIntegration)),
 	).Methods(http.MethodPost)
 
 	subRouter.HandleFunc(
-		"/uninstall", am.ViewAccess(aH.UninstallIntegration),
+		"/uninstall", am.ViewAccess(aH.Writes(aH.UninstallIntegration)),
 	).Methods(http.MethodPost)
 
 	// Used for polling for status in v0
@@ -3507,7 +3855,7 @@ func (aH *APIHandler) RegisterCloudIntegrationsRoutes(router *mux.Router, am *mi
 	subRouter := router.PathPrefix("/api/v1/cloud-integrations").Subrouter()
 
 	subRouter.HandleFunc(
-		"/{cloudProvider}/accounts/generate-connection-url", am.EditAccess(aH.CloudIntegrationsGenerateConnectionUrl),
+		"/{cloudProvider}/accounts/generate-connection-url", am.EditAccess(aH.Writes(aH.CloudIntegrationsGenerateConnectionUrl)),
 	).Methods(http.MethodPost)
 
 	subRouter.HandleFunc(
@@ -3519,15 +3867,15 @@ func (aH *APIHandler) RegisterCloudIntegrationsRoutes(router *mux.Router, am *mi
 	).Methods(http.MethodGet)
 
 	subRouter.HandleFunc(
-		"/{cloudProvider}/accounts/{accountId}/config", am.EditAccess(aH.CloudIntegrationsUpdateAccountConfig),
+		"/{cloudProvider}/accounts/{accountId}/config", am.EditAccess(aH.Writes(aH.CloudIntegrationsUpdateAccountConfig)),
 	).Methods(http.MethodPost)
 
 	subRouter.HandleFunc(
-		"/{cloudProvider}/accounts/{accountId}/disconnect", am.EditAccess(aH.CloudIntegrationsDisconnectAccount),
+		"/{cloudProvider}/accounts/{accountId}/disconnect", am.EditAccess(aH.Writes(aH.CloudIntegrationsDisconnectAccount)),
 	).Methods(http.MethodPost)
 
 	subRouter.HandleFunc(
-		"/{cloudProvider}/agent-check-in", am.ViewAccess(aH.CloudIntegrationsAgentCheckIn),
+		"/{cloudProvider}/agent-check-in", am.ViewAccess(aH.Writes(aH.CloudIntegrationsAgentCheckIn)),
 	).Methods(http.MethodPost)
 
 	subRouter.HandleFunc(
@@ -3539,7 +3887,7 @@ func (aH *APIHandler) RegisterCloudIntegrationsRoutes(router *mux.Router, am *mi
 	).Methods(http.MethodGet)
 
 	subRouter.HandleFunc(
-		"/{cloudProvider}/services/{serviceId}/config", am.EditAccess(aH.CloudIntegrationsUpdateServiceConfig),
+		"/{cloudProvider}/services/{serviceId}/config", am.EditAccess(aH.Writes(aH.CloudIntegrationsUpdateServiceConfig)),
 	).Methods(http.MethodPost)
 
 }
@@ -4026,13 +4374,13 @@ func (aH *APIHandler) RegisterLogsRoutes(router *mux.Router, am *middleware.Auth
 	subRouter.HandleFunc("", am.ViewAccess(aH.getLogs)).Methods(http.MethodGet)
 	subRouter.HandleFunc("/tail", am.ViewAccess(aH.tailLogs)).Methods(http.MethodGet)
 	subRouter.HandleFunc("/fields", am.ViewAccess(aH.logFields)).Methods(http.MethodGet)
-	subRouter.HandleFunc("/fields", am.EditAccess(aH.logFieldUpdate)).Methods(http.MethodPost)
+	subRouter.HandleFunc("/fields", am.EditAccess(aH.Writes(aH.logFieldUpdate))).Methods(http.MethodPost)
 	subRouter.HandleFunc("/aggregate", am.ViewAccess(aH.logAggregate)).Methods(http.MethodGet)
 
 	// log pipelines
 	subRouter.HandleFunc("/pipelines/preview", am.ViewAccess(aH.PreviewLogsPipelinesHandler)).Methods(http.MethodPost)
 	subRouter.HandleFunc("/pipelines/{version}", am.ViewAccess(aH.ListLogsPipelinesHandler)).Methods(http.MethodGet)
-	subRouter.HandleFunc("/pipelines", am.EditAccess(aH.CreateLogsPipeline)).Methods(http.MethodPost)
+	subRouter.HandleFunc("/pipelines", am.EditAccess(aH.Writes(aH.CreateLogsPipeline))).Methods(http.MethodPost)
 }
 
 func (aH *APIHandler) logFields(w http.ResponseWriter, r *http.Request) {
@@ -4569,8 +4917,7 @@ func (aH *APIHandler) queryRangeV3(ctx context.Context, queryRangeParams *v3.Que
 		for name, err := range errQuriesByName {
 			queryErrors[fmt.Sprintf("Query-%s", name)] = err.Error()
 		}
-		apiErrObj := &model.ApiError{Typ: model.ErrorInternal, Err: err}
-		RespondError(w, apiErrObj, queryErrors)
+		RespondError(w, queryRangeError(err, errQuriesByName), queryErrors)
 		return
 	}
 
@@ -4613,6 +4960,22 @@ func (aH *APIHandler) queryRangeV3(ctx context.Context, queryRangeParams *v3.Que
 	aH.Respond(w, resp)
 }
 
+// queryRangeError returns the error of a failed query range, a timeout naming the kind of the query when one of the
+// queries exceeded the timeout of the telemetry store so that it is answered with a 504.
+func queryRangeError(err error, errQueriesByName map[string]error) *model.ApiError {
+	for _, queryErr := range errQueriesByName {
+		if errorsV2.Ast(queryErr, errorsV2.TypeTimeout) {
+			return &model.ApiError{Typ: model.ErrorTimeout, Err: queryErr}
+		}
+	}
+
+	if errorsV2.Ast(err, errorsV2.TypeTimeout) {
+		return &model.ApiError{Typ: model.ErrorTimeout, Err: err}
+	}
+
+	return &model.ApiError{Typ: model.ErrorInternal, Err: err}
+}
+
 func sendQueryResultEvents(r *http.Request, result []*v3.Result, queryRangeParams *v3.QueryRangeParamsV3) {
 	referrer := r.Header.Get("Referer")
 
@@ -4977,8 +5340,7 @@ func (aH *APIHandler) queryRangeV4(ctx context.Context, queryRangeParams *v3.Que
 		for name, err := range errQuriesByName {
 			queryErrors[fmt.Sprintf("Query-%s", name)] = err.Error()
 		}
-		apiErrObj := &model.ApiError{Typ: model.ErrorInternal, Err: err}
-		RespondError(w, apiErrObj, queryErrors)
+		RespondError(w, queryRangeError(err, errQuriesByName), queryErrors)
 		return
 	}
 
@@ -5198,22 +5560,22 @@ func (aH *APIHandler) RegisterTraceFunnelsRoutes(router *mux.Router, am *middlew
 
 	// API endpoints
 	traceFunnelsRouter.HandleFunc("/new",
-		am.EditAccess(aH.Signoz.Handlers.TraceFunnel.New)).
+		am.EditAccess(aH.Writes(aH.Signoz.Handlers.TraceFunnel.New))).
 		Methods(http.MethodPost)
 	traceFunnelsRouter.HandleFunc("/list",
 		am.ViewAccess(aH.Signoz.Handlers.TraceFunnel.List)).
 		Methods(http.MethodGet)
 	traceFunnelsRouter.HandleFunc("/steps/update",
-		am.EditAccess(aH.Signoz.Handlers.TraceFunnel.UpdateSteps)).
+		am.EditAccess(aH.Writes(aH.Signoz.Handlers.TraceFunnel.UpdateSteps))).
 		Methods(http.MethodPut)
 
 	traceFunnelsRouter.HandleFunc("/{funnel_id}",
 		am.ViewAccess(aH.Signoz.Handlers.TraceFunnel.Get)).
 		Methods(http.MethodGet)
 	traceFunnelsRouter.HandleFunc("/{funnel_id}",
-		am.EditAccess(aH.Signoz.Handlers.TraceFunnel.Delete)).
+		am.EditAccess(aH.Writes(aH.Signoz.Handlers.TraceFunnel.Delete))).
 		Methods(http.MethodDelete)
 	traceFunnelsRouter.HandleFunc("/{funnel_id}",
-		am.EditAccess(aH.Signoz.Handlers.TraceFunnel.UpdateFunnel)).
+		am.EditAccess(aH.Writes(aH.Signoz.Handlers.TraceFunnel.UpdateFunnel))).
 		Methods(http.MethodPut)
 }
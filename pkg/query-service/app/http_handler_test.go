@@ -3,11 +3,13 @@ package app
 import (
 	"bytes"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
 
+	errorsV2 "github.com/SigNoz/signoz/pkg/errors"
 	"github.com/SigNoz/signoz/pkg/query-service/model"
 )
 
@@ -130,3 +132,19 @@ func TestPrepareQuery(t *testing.T) {
 		})
 	}
 }
+
+func TestQueryRangeErrorTimeout(t *testing.T) {
+	timeout := errorsV2.Newf(errorsV2.TypeTimeout, errorsV2.CodeTimeout, "dashboards query exceeded its timeout of 1s")
+
+	rw := httptest.NewRecorder()
+	RespondError(rw, queryRangeError(fmt.Errorf("error in builder queries"), map[string]error{"A": timeout}), nil)
+	if rw.Code != http.StatusGatewayTimeout {
+		t.Errorf("expected status %d, but got %d", http.StatusGatewayTimeout, rw.Code)
+	}
+
+	rw = httptest.NewRecorder()
+	RespondError(rw, queryRangeError(fmt.Errorf("error in builder queries"), map[string]error{"A": fmt.Errorf("syntax error")}), nil)
+	if rw.Code != http.StatusInternalServerError {
+		t.Errorf("expected status %d, but got %d", http.StatusInternalServerError, rw.Code)
+	}
+}
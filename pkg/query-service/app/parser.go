@@ -151,13 +151,6 @@ func parseQueryRangeRequest(r *http.Request) (*model.QueryRangeParams, *model.Ap
 		return nil, &model.ApiError{Typ: model.ErrorBadData, Err: err}
 	}
 
-	// For safety, limit the number of returned points per timeseries.
-	// This is sufficient for 60s resolution for a week or 1h resolution for a year.
-	if end.Sub(start)/step > 11000 {
-		err := errors.New("exceeded maximum resolution of 11,000 points per timeseries. Try decreasing the query resolution (?step=XX)")
-		return nil, &model.ApiError{Typ: model.ErrorBadData, Err: err}
-	}
-
 	queryRangeParams := model.QueryRangeParams{
 		Start: start,
 		End:   end,
@@ -166,6 +159,20 @@ func parseQueryRangeRequest(r *http.Request) (*model.QueryRangeParams, *model.Ap
 		Stats: r.FormValue("stats"),
 	}
 
+	if noCache := r.FormValue("nocache"); noCache != "" {
+		queryRangeParams.NoCache, err = strconv.ParseBool(noCache)
+		if err != nil {
+			return nil, &model.ApiError{Typ: model.ErrorBadData, Err: err}
+		}
+	}
+
+	if raw := r.FormValue("raw"); raw != "" {
+		queryRangeParams.Raw, err = strconv.ParseBool(raw)
+		if err != nil {
+			return nil, &model.ApiError{Typ: model.ErrorBadData, Err: err}
+		}
+	}
+
 	return &queryRangeParams, nil
 }
 
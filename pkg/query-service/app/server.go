@@ -13,7 +13,9 @@ import (
 
 	"github.com/SigNoz/signoz/pkg/alertmanager"
 	"github.com/SigNoz/signoz/pkg/apis/fields"
+	"github.com/SigNoz/signoz/pkg/grpcserver"
 	"github.com/SigNoz/signoz/pkg/http/middleware"
+	httpserver "github.com/SigNoz/signoz/pkg/http/server"
 	"github.com/SigNoz/signoz/pkg/licensing/nooplicensing"
 	"github.com/SigNoz/signoz/pkg/modules/organization"
 	"github.com/SigNoz/signoz/pkg/prometheus"
@@ -25,6 +27,7 @@ import (
 	"github.com/SigNoz/signoz/pkg/query-service/app/logparsingpipeline"
 	"github.com/SigNoz/signoz/pkg/query-service/app/opamp"
 	opAmpModel "github.com/SigNoz/signoz/pkg/query-service/app/opamp/model"
+	"github.com/SigNoz/signoz/pkg/ruler"
 	"github.com/SigNoz/signoz/pkg/signoz"
 	"github.com/SigNoz/signoz/pkg/sqlstore"
 	"github.com/SigNoz/signoz/pkg/telemetrystore"
@@ -40,6 +43,8 @@ import (
 	"github.com/SigNoz/signoz/pkg/query-service/rules"
 	"github.com/SigNoz/signoz/pkg/query-service/telemetry"
 	"github.com/SigNoz/signoz/pkg/query-service/utils"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 )
 
@@ -69,8 +74,13 @@ type Server struct {
 	privateConn net.Listener
 	privateHTTP *http.Server
 
+	// drainer drains the in-flight requests of the http servers on shutdown
+	drainer *httpserver.Drainer
+
 	opampServer *opamp.Server
 
+	grpcServer *grpcserver.Server
+
 	unavailableChannel chan healthcheck.Status
 }
 
@@ -114,6 +124,9 @@ func NewServer(serverOptions *ServerOptions) (*Server, error) {
 		serverOptions.SigNoz.TelemetryStore,
 		serverOptions.SigNoz.Prometheus,
 		serverOptions.SigNoz.Modules.OrgGetter,
+		serverOptions.SigNoz.Instrumentation.TracerProvider(),
+		serverOptions.SigNoz.Instrumentation.MeterProvider(),
+		serverOptions.Config.Ruler,
 	)
 	if err != nil {
 		return nil, err
@@ -162,6 +175,7 @@ func NewServer(serverOptions *ServerOptions) (*Server, error) {
 		ruleManager:        rm,
 		serverOptions:      serverOptions,
 		unavailableChannel: make(chan healthcheck.Status),
+		drainer:            httpserver.NewDrainer(),
 	}
 
 	httpServer, err := s.createPublicServer(apiHandler, serverOptions.SigNoz.Web)
@@ -179,6 +193,22 @@ func NewServer(serverOptions *ServerOptions) (*Server, error) {
 
 	s.privateHTTP = privateServer
 
+	if serverOptions.Config.GRPCServer.Enabled {
+		grpcServer, err := grpcserver.New(
+			serverOptions.SigNoz.Instrumentation.ToProviderSettings(),
+			serverOptions.Config.GRPCServer,
+			serverOptions.Jwt,
+			serverOptions.SigNoz.Sharder,
+			serverOptions.SigNoz.Querier,
+			serverOptions.SigNoz.Modules.Dashboard,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		s.grpcServer = grpcServer
+	}
+
 	_, err = opAmpModel.InitDB(serverOptions.SigNoz.SQLStore.SQLxDB())
 	if err != nil {
 		return nil, err
@@ -224,9 +254,11 @@ func (s *Server) createPrivateServer(api *APIHandler) (*http.Server, error) {
 	).Wrap)
 	r.Use(middleware.NewAnalytics().Wrap)
 	r.Use(middleware.NewAPIKey(s.serverOptions.SigNoz.SQLStore, []string{"SIGNOZ-API-KEY"}, s.serverOptions.SigNoz.Instrumentation.Logger(), s.serverOptions.SigNoz.Sharder).Wrap)
-	r.Use(middleware.NewLogging(s.serverOptions.SigNoz.Instrumentation.Logger(), s.serverOptions.Config.APIServer.Logging.ExcludedRoutes).Wrap)
+	r.Use(middleware.NewLogging(s.serverOptions.SigNoz.Instrumentation.Logger(), s.serverOptions.Config.APIServer.Logging).Wrap)
 
 	api.RegisterPrivateRoutes(r)
+	// the metrics are served on the private server only, the scrapes reach it from within the deployment
+	r.Handle("/metrics", s.serverOptions.SigNoz.Instrumentation.MetricsHandler()).Methods(http.MethodGet)
 
 	c := cors.New(cors.Options{
 		//todo(amol): find out a way to add exact domain or
@@ -240,7 +272,7 @@ func (s *Server) createPrivateServer(api *APIHandler) (*http.Server, error) {
 	handler = handlers.CompressHandler(handler)
 
 	return &http.Server{
-		Handler: handler,
+		Handler: s.drainer.Wrap(handler),
 	}, nil
 }
 
@@ -255,9 +287,12 @@ func (s *Server) createPublicServer(api *APIHandler, web web.Web) (*http.Server,
 	).Wrap)
 	r.Use(middleware.NewAnalytics().Wrap)
 	r.Use(middleware.NewAPIKey(s.serverOptions.SigNoz.SQLStore, []string{"SIGNOZ-API-KEY"}, s.serverOptions.SigNoz.Instrumentation.Logger(), s.serverOptions.SigNoz.Sharder).Wrap)
-	r.Use(middleware.NewLogging(s.serverOptions.SigNoz.Instrumentation.Logger(), s.serverOptions.Config.APIServer.Logging.ExcludedRoutes).Wrap)
+	if s.serverOptions.Config.APIServer.RateLimit.Enabled {
+		r.Use(middleware.NewRateLimit(s.serverOptions.SigNoz.Instrumentation.Logger(), s.serverOptions.SigNoz.Cache, s.serverOptions.Config.APIServer.RateLimit).Wrap)
+	}
+	r.Use(middleware.NewLogging(s.serverOptions.SigNoz.Instrumentation.Logger(), s.serverOptions.Config.APIServer.Logging).Wrap)
 
-	am := middleware.NewAuthZ(s.serverOptions.SigNoz.Instrumentation.Logger())
+	am := middleware.NewAuthZ(s.serverOptions.SigNoz.Instrumentation.Logger(), s.serverOptions.SigNoz.Modules.RBAC)
 
 	api.RegisterRoutes(r, am)
 	api.RegisterLogsRoutes(r, am)
@@ -278,6 +313,7 @@ func (s *Server) createPublicServer(api *APIHandler, web web.Web) (*http.Server,
 		AllowedOrigins: []string{"*"},
 		AllowedMethods: []string{"GET", "DELETE", "POST", "PUT", "PATCH", "OPTIONS"},
 		AllowedHeaders: []string{"Accept", "Authorization", "Content-Type", "cache-control", "X-SIGNOZ-QUERY-ID", "Sec-WebSocket-Protocol"},
+		ExposedHeaders: []string{querierAPI.EstimatedRowsHeader, querierAPI.EstimatedBytesHeader},
 	})
 
 	handler := c.Handler(r)
@@ -290,7 +326,7 @@ func (s *Server) createPublicServer(api *APIHandler, web web.Web) (*http.Server,
 	}
 
 	return &http.Server{
-		Handler: handler,
+		Handler: s.drainer.Wrap(handler),
 	}, nil
 }
 
@@ -381,6 +417,14 @@ func (s *Server) Start(ctx context.Context) error {
 
 	}()
 
+	if s.grpcServer != nil {
+		go func() {
+			if err := s.grpcServer.Start(ctx); err != nil {
+				s.unavailableChannel <- healthcheck.Unavailable
+			}
+		}()
+	}
+
 	go func() {
 		zap.L().Info("Starting OpAmp Websocket server", zap.String("addr", constants.OpAmpWsEndpoint))
 		err := s.opampServer.Start(constants.OpAmpWsEndpoint)
@@ -394,14 +438,21 @@ func (s *Server) Start(ctx context.Context) error {
 }
 
 func (s *Server) Stop(ctx context.Context) error {
-	if s.httpServer != nil {
-		if err := s.httpServer.Shutdown(context.Background()); err != nil {
-			return err
+	// The servers are drained before the providers of signoz are stopped, so that the in-flight requests can still
+	// use them.
+	servers := []*http.Server{}
+	for _, server := range []*http.Server{s.httpServer, s.privateHTTP} {
+		if server != nil {
+			servers = append(servers, server)
 		}
 	}
 
-	if s.privateHTTP != nil {
-		if err := s.privateHTTP.Shutdown(context.Background()); err != nil {
+	if err := s.drainer.Drain(ctx, s.serverOptions.SigNoz.Instrumentation.Logger(), s.serverOptions.Config.APIServer.Shutdown.GracePeriod, servers...); err != nil {
+		return err
+	}
+
+	if s.grpcServer != nil {
+		if err := s.grpcServer.Stop(ctx); err != nil {
 			return err
 		}
 	}
@@ -423,6 +474,9 @@ func makeRulesManager(
 	telemetryStore telemetrystore.TelemetryStore,
 	prometheus prometheus.Prometheus,
 	orgGetter organization.Getter,
+	tracerProvider trace.TracerProvider,
+	meterProvider metric.MeterProvider,
+	rulerConfig ruler.Config,
 ) (*rules.Manager, error) {
 	// create manager opts
 	managerOpts := &rules.ManagerOptions{
@@ -436,6 +490,10 @@ func makeRulesManager(
 		EvalDelay:      constants.GetEvalDelay(),
 		SQLStore:       sqlstore,
 		OrgGetter:      orgGetter,
+		TracerProvider: tracerProvider,
+		MeterProvider:  meterProvider,
+		Evaluation:     rulerConfig.Evaluation,
+		Idempotency:    rulerConfig.Idempotency,
 	}
 
 	// create Manager
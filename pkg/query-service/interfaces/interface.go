@@ -64,9 +64,11 @@ type Reader interface {
 	LiveTailLogsV3(ctx context.Context, query string, timestampStart uint64, idStart string, client *model.LogsLiveTailClient)
 	LiveTailLogsV4(ctx context.Context, query string, timestampStart uint64, idStart string, client *model.LogsLiveTailClientV2)
 
-	GetTotalSpans(ctx context.Context) (uint64, error)
-	GetTotalLogs(ctx context.Context) (uint64, error)
-	GetTotalSamples(ctx context.Context) (uint64, error)
+	// GetTotalSpans, GetTotalLogs and GetTotalSamples count across all the shards of the telemetry store. The count is
+	// partial when some of the shards failed, in which case it is missing their data.
+	GetTotalSpans(ctx context.Context) (count uint64, partial bool, err error)
+	GetTotalLogs(ctx context.Context) (count uint64, partial bool, err error)
+	GetTotalSamples(ctx context.Context) (count uint64, partial bool, err error)
 	GetSpansInLastHeartBeatInterval(ctx context.Context, interval time.Duration) (uint64, error)
 	GetTimeSeriesInfo(ctx context.Context) (map[string]interface{}, error)
 	GetSamplesInfoInLastHeartBeatInterval(ctx context.Context, interval time.Duration) (uint64, error)
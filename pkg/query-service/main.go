@@ -17,7 +17,6 @@ import (
 	"github.com/SigNoz/signoz/pkg/query-service/constants"
 	"github.com/SigNoz/signoz/pkg/signoz"
 	"github.com/SigNoz/signoz/pkg/sqlstore"
-	"github.com/SigNoz/signoz/pkg/types/authtypes"
 	"github.com/SigNoz/signoz/pkg/version"
 	"github.com/SigNoz/signoz/pkg/zeus"
 	"github.com/SigNoz/signoz/pkg/zeus/noopzeus"
@@ -113,23 +112,23 @@ func main() {
 		zap.L().Info("JWT secret key set successfully.")
 	}
 
-	jwt := authtypes.NewJWT(jwtSecret, 30*time.Minute, 30*24*time.Hour)
-
 	signoz, err := signoz.New(
 		context.Background(),
 		config,
-		jwt,
+		jwtSecret,
 		zeus.Config{},
 		noopzeus.NewProviderFactory(),
 		licensing.Config{},
 		func(_ sqlstore.SQLStore, _ zeus.Zeus, _ organization.Getter) factory.ProviderFactory[licensing.Licensing, licensing.Config] {
 			return nooplicensing.NewFactory()
 		},
-		signoz.NewEmailingProviderFactories(),
+		signoz.NewSecretStoreProviderFactories(),
+		signoz.NewEmailingProviderFactories,
 		signoz.NewCacheProviderFactories(),
 		signoz.NewWebProviderFactories(),
 		signoz.NewSQLStoreProviderFactories(),
-		signoz.NewTelemetryStoreProviderFactories(),
+		signoz.NewTelemetryStoreProviderFactories,
+		signoz.NewReceiverPluginProviderFactories(),
 	)
 	if err != nil {
 		zap.L().Fatal("Failed to create signoz", zap.Error(err))
@@ -145,7 +144,7 @@ func main() {
 		FluxIntervalForTraceDetail: fluxIntervalForTraceDetail,
 		Cluster:                    cluster,
 		SigNoz:                     signoz,
-		Jwt:                        jwt,
+		Jwt:                        signoz.JWT,
 	}
 
 	server, err := app.NewServer(serverOptions)
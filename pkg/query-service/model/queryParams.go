@@ -16,6 +16,10 @@ type QueryRangeParams struct {
 	Step  time.Duration
 	Query string
 	Stats string
+	// NoCache evaluates the query without the cached results of previous queries.
+	NoCache bool
+	// Raw evaluates the query at its step even when it returns more points than the downsampling allows.
+	Raw bool
 }
 
 const (
@@ -141,6 +141,11 @@ type QueryData struct {
 	ResultType parser.ValueType  `json:"resultType"`
 	Result     parser.Value      `json:"result"`
 	Stats      *stats.QueryStats `json:"stats,omitempty"`
+	// Step is the step in seconds at which a range query was evaluated, larger than the requested step when the
+	// query was downsampled.
+	Step float64 `json:"step,omitempty"`
+	// Downsampling is the aggregation of the points of a downsampled range query.
+	Downsampling string `json:"downsampling,omitempty"`
 }
 
 type RuleResponseItem struct {
@@ -1427,6 +1427,12 @@ type SavedView struct {
 	ExtraData string `json:"extraData"`
 }
 
+// SavedViewsPage is a page of the saved views.
+type SavedViewsPage struct {
+	Views      []*SavedView `json:"views"`
+	NextCursor string       `json:"nextCursor,omitempty"`
+}
+
 func (eq *SavedView) Validate() error {
 
 	if eq.CompositeQuery == nil {
@@ -144,6 +144,11 @@ func NewBaseRule(id string, orgID valuer.UUID, p *ruletypes.PostableRule, reader
 		baseRule.evalWindow = 5 * time.Minute
 	}
 
+	// The query of the windows is run once over the longest window.
+	if p.RuleCondition.Windows != nil {
+		baseRule.evalWindow = max(baseRule.evalWindow, p.RuleCondition.Windows.MaxWindow())
+	}
+
 	for _, opt := range opts {
 		opt(baseRule)
 	}
@@ -156,11 +161,26 @@ func (r *BaseRule) targetVal() float64 {
 		return 0
 	}
 
+	return r.convertTarget(*r.ruleCondition.Target)
+}
+
+// thresholdOf returns the threshold the sample was matched against, which is the threshold of the window for the
+// conditions with windows.
+func (r *BaseRule) thresholdOf(sample ruletypes.Sample) float64 {
+	if sample.Threshold != nil {
+		return *sample.Threshold
+	}
+
+	return r.targetVal()
+}
+
+// convertTarget converts the target from the target unit of the rule to the unit of its query.
+func (r *BaseRule) convertTarget(target float64) float64 {
 	// get the converter for the target unit
 	unitConverter := converter.FromUnit(converter.Unit(r.ruleCondition.TargetUnit))
 	// convert the target value to the y-axis unit
 	value := unitConverter.Convert(converter.Value{
-		F: *r.ruleCondition.Target,
+		F: target,
 		U: converter.Unit(r.ruleCondition.TargetUnit),
 	}, converter.Unit(r.Unit()))
 
@@ -362,9 +382,10 @@ func (r *BaseRule) ForEachActiveAlert(f func(*ruletypes.Alert)) {
 	}
 }
 
-func (r *BaseRule) ShouldAlert(series v3.Series) (ruletypes.Sample, bool) {
+// ShouldAlert matches the series against the condition of the rule. The end is the end of the evaluated range, the
+// windows of the condition ending at it.
+func (r *BaseRule) ShouldAlert(series v3.Series, end time.Time) (ruletypes.Sample, bool) {
 	var alertSmpl ruletypes.Sample
-	var shouldAlert bool
 	var lbls qslabels.Labels
 
 	for name, value := range series.Labels {
@@ -385,44 +406,56 @@ func (r *BaseRule) ShouldAlert(series v3.Series) (ruletypes.Sample, bool) {
 		}
 	}
 
-	switch r.matchType() {
+	if r.ruleCondition.Windows != nil {
+		return r.shouldAlertWindows(series.Points, end, lbls, *r.ruleCondition.Windows)
+	}
+
+	return matchPoints(series.Points, lbls, r.matchType(), r.compareOp(), r.targetVal())
+}
+
+// matchPoints matches the points against the target.
+func matchPoints(points []v3.Point, lbls qslabels.Labels, matchType ruletypes.MatchType, compareOp ruletypes.CompareOp, target float64) (ruletypes.Sample, bool) {
+	var alertSmpl ruletypes.Sample
+	var shouldAlert bool
+
+	switch matchType {
 	case ruletypes.AtleastOnce:
 		// If any sample matches the condition, the rule is firing.
-		if r.compareOp() == ruletypes.ValueIsAbove {
-			for _, smpl := range series.Points {
-				if smpl.Value > r.targetVal() {
+		if compareOp == ruletypes.ValueIsAbove {
+			for _, smpl := range points {
+				if smpl.Value > target {
 					alertSmpl = ruletypes.Sample{Point: ruletypes.Point{V: smpl.Value}, Metric: lbls}
 					shouldAlert = true
 					break
 				}
 			}
-		} else if r.compareOp() == ruletypes.ValueIsBelow {
-			for _, smpl := range series.Points {
-				if smpl.Value < r.targetVal() {
+		} else if compareOp == ruletypes.ValueIsBelow {
+			for _, smpl := range points {
+				if smpl.Value < target {
 					alertSmpl = ruletypes.Sample{Point: ruletypes.Point{V: smpl.Value}, Metric: lbls}
 					shouldAlert = true
 					break
 				}
 			}
-		} else if r.compareOp() == ruletypes.ValueIsEq {
-			for _, smpl := range series.Points {
-				if smpl.Value == r.targetVal() {
+		} else if compareOp == ruletypes.ValueIsEq {
+			for _, smpl := range points {
+				if smpl.Value == target {
 					alertSmpl = ruletypes.Sample{Point: ruletypes.Point{V: smpl.Value}, Metric: lbls}
 					shouldAlert = true
 					break
 				}
 			}
-		} else if r.compareOp() == ruletypes.ValueIsNotEq {
-			for _, smpl := range series.Points {
-				if smpl.Value != r.targetVal() {
+		} else if compareOp == ruletypes.ValueIsNotEq {
+			for _, smpl := range points {
+				if smpl.Value != target {
 					alertSmpl = ruletypes.Sample{Point: ruletypes.Point{V: smpl.Value}, Metric: lbls}
 					shouldAlert = true
 					break
 				}
 			}
-		} else if r.compareOp() == ruletypes.ValueOutsideBounds {
-			for _, smpl := range series.Points {
-				if math.Abs(smpl.Value) >= r.targetVal() {
+		} else if compareOp == ruletypes.ValueOutsideBounds {
+			for _, smpl := range points {
+				if math.Abs(smpl.Value) >= target {
 					alertSmpl = ruletypes.Sample{Point: ruletypes.Point{V: smpl.Value}, Metric: lbls}
 					shouldAlert = true
 					break
@@ -432,10 +465,10 @@ func (r *BaseRule) ShouldAlert(series v3.Series) (ruletypes.Sample, bool) {
 	case ruletypes.AllTheTimes:
 		// If all samples match the condition, the rule is firing.
 		shouldAlert = true
-		alertSmpl = ruletypes.Sample{Point: ruletypes.Point{V: r.targetVal()}, Metric: lbls}
-		if r.compareOp() == ruletypes.ValueIsAbove {
-			for _, smpl := range series.Points {
-				if smpl.Value <= r.targetVal() {
+		alertSmpl = ruletypes.Sample{Point: ruletypes.Point{V: target}, Metric: lbls}
+		if compareOp == ruletypes.ValueIsAbove {
+			for _, smpl := range points {
+				if smpl.Value <= target {
 					shouldAlert = false
 					break
 				}
@@ -443,55 +476,55 @@ func (r *BaseRule) ShouldAlert(series v3.Series) (ruletypes.Sample, bool) {
 			// use min value from the series
 			if shouldAlert {
 				var minValue float64 = math.Inf(1)
-				for _, smpl := range series.Points {
+				for _, smpl := range points {
 					if smpl.Value < minValue {
 						minValue = smpl.Value
 					}
 				}
 				alertSmpl = ruletypes.Sample{Point: ruletypes.Point{V: minValue}, Metric: lbls}
 			}
-		} else if r.compareOp() == ruletypes.ValueIsBelow {
-			for _, smpl := range series.Points {
-				if smpl.Value >= r.targetVal() {
+		} else if compareOp == ruletypes.ValueIsBelow {
+			for _, smpl := range points {
+				if smpl.Value >= target {
 					shouldAlert = false
 					break
 				}
 			}
 			if shouldAlert {
 				var maxValue float64 = math.Inf(-1)
-				for _, smpl := range series.Points {
+				for _, smpl := range points {
 					if smpl.Value > maxValue {
 						maxValue = smpl.Value
 					}
 				}
 				alertSmpl = ruletypes.Sample{Point: ruletypes.Point{V: maxValue}, Metric: lbls}
 			}
-		} else if r.compareOp() == ruletypes.ValueIsEq {
-			for _, smpl := range series.Points {
-				if smpl.Value != r.targetVal() {
+		} else if compareOp == ruletypes.ValueIsEq {
+			for _, smpl := range points {
+				if smpl.Value != target {
 					shouldAlert = false
 					break
 				}
 			}
-		} else if r.compareOp() == ruletypes.ValueIsNotEq {
-			for _, smpl := range series.Points {
-				if smpl.Value == r.targetVal() {
+		} else if compareOp == ruletypes.ValueIsNotEq {
+			for _, smpl := range points {
+				if smpl.Value == target {
 					shouldAlert = false
 					break
 				}
 			}
 			// use any non-inf or nan value from the series
 			if shouldAlert {
-				for _, smpl := range series.Points {
+				for _, smpl := range points {
 					if !math.IsInf(smpl.Value, 0) && !math.IsNaN(smpl.Value) {
 						alertSmpl = ruletypes.Sample{Point: ruletypes.Point{V: smpl.Value}, Metric: lbls}
 						break
 					}
 				}
 			}
-		} else if r.compareOp() == ruletypes.ValueOutsideBounds {
-			for _, smpl := range series.Points {
-				if math.Abs(smpl.Value) < r.targetVal() {
+		} else if compareOp == ruletypes.ValueOutsideBounds {
+			for _, smpl := range points {
+				if math.Abs(smpl.Value) < target {
 					alertSmpl = ruletypes.Sample{Point: ruletypes.Point{V: smpl.Value}, Metric: lbls}
 					shouldAlert = false
 					break
@@ -501,7 +534,7 @@ func (r *BaseRule) ShouldAlert(series v3.Series) (ruletypes.Sample, bool) {
 	case ruletypes.OnAverage:
 		// If the average of all samples matches the condition, the rule is firing.
 		var sum, count float64
-		for _, smpl := range series.Points {
+		for _, smpl := range points {
 			if math.IsNaN(smpl.Value) || math.IsInf(smpl.Value, 0) {
 				continue
 			}
@@ -510,24 +543,24 @@ func (r *BaseRule) ShouldAlert(series v3.Series) (ruletypes.Sample, bool) {
 		}
 		avg := sum / count
 		alertSmpl = ruletypes.Sample{Point: ruletypes.Point{V: avg}, Metric: lbls}
-		if r.compareOp() == ruletypes.ValueIsAbove {
-			if avg > r.targetVal() {
+		if compareOp == ruletypes.ValueIsAbove {
+			if avg > target {
 				shouldAlert = true
 			}
-		} else if r.compareOp() == ruletypes.ValueIsBelow {
-			if avg < r.targetVal() {
+		} else if compareOp == ruletypes.ValueIsBelow {
+			if avg < target {
 				shouldAlert = true
 			}
-		} else if r.compareOp() == ruletypes.ValueIsEq {
-			if avg == r.targetVal() {
+		} else if compareOp == ruletypes.ValueIsEq {
+			if avg == target {
 				shouldAlert = true
 			}
-		} else if r.compareOp() == ruletypes.ValueIsNotEq {
-			if avg != r.targetVal() {
+		} else if compareOp == ruletypes.ValueIsNotEq {
+			if avg != target {
 				shouldAlert = true
 			}
-		} else if r.compareOp() == ruletypes.ValueOutsideBounds {
-			if math.Abs(avg) >= r.targetVal() {
+		} else if compareOp == ruletypes.ValueOutsideBounds {
+			if math.Abs(avg) >= target {
 				shouldAlert = true
 			}
 		}
@@ -535,52 +568,52 @@ func (r *BaseRule) ShouldAlert(series v3.Series) (ruletypes.Sample, bool) {
 		// If the sum of all samples matches the condition, the rule is firing.
 		var sum float64
 
-		for _, smpl := range series.Points {
+		for _, smpl := range points {
 			if math.IsNaN(smpl.Value) || math.IsInf(smpl.Value, 0) {
 				continue
 			}
 			sum += smpl.Value
 		}
 		alertSmpl = ruletypes.Sample{Point: ruletypes.Point{V: sum}, Metric: lbls}
-		if r.compareOp() == ruletypes.ValueIsAbove {
-			if sum > r.targetVal() {
+		if compareOp == ruletypes.ValueIsAbove {
+			if sum > target {
 				shouldAlert = true
 			}
-		} else if r.compareOp() == ruletypes.ValueIsBelow {
-			if sum < r.targetVal() {
+		} else if compareOp == ruletypes.ValueIsBelow {
+			if sum < target {
 				shouldAlert = true
 			}
-		} else if r.compareOp() == ruletypes.ValueIsEq {
-			if sum == r.targetVal() {
+		} else if compareOp == ruletypes.ValueIsEq {
+			if sum == target {
 				shouldAlert = true
 			}
-		} else if r.compareOp() == ruletypes.ValueIsNotEq {
-			if sum != r.targetVal() {
+		} else if compareOp == ruletypes.ValueIsNotEq {
+			if sum != target {
 				shouldAlert = true
 			}
-		} else if r.compareOp() == ruletypes.ValueOutsideBounds {
-			if math.Abs(sum) >= r.targetVal() {
+		} else if compareOp == ruletypes.ValueOutsideBounds {
+			if math.Abs(sum) >= target {
 				shouldAlert = true
 			}
 		}
 	case ruletypes.Last:
 		// If the last sample matches the condition, the rule is firing.
 		shouldAlert = false
-		alertSmpl = ruletypes.Sample{Point: ruletypes.Point{V: series.Points[len(series.Points)-1].Value}, Metric: lbls}
-		if r.compareOp() == ruletypes.ValueIsAbove {
-			if series.Points[len(series.Points)-1].Value > r.targetVal() {
+		alertSmpl = ruletypes.Sample{Point: ruletypes.Point{V: points[len(points)-1].Value}, Metric: lbls}
+		if compareOp == ruletypes.ValueIsAbove {
+			if points[len(points)-1].Value > target {
 				shouldAlert = true
 			}
-		} else if r.compareOp() == ruletypes.ValueIsBelow {
-			if series.Points[len(series.Points)-1].Value < r.targetVal() {
+		} else if compareOp == ruletypes.ValueIsBelow {
+			if points[len(points)-1].Value < target {
 				shouldAlert = true
 			}
-		} else if r.compareOp() == ruletypes.ValueIsEq {
-			if series.Points[len(series.Points)-1].Value == r.targetVal() {
+		} else if compareOp == ruletypes.ValueIsEq {
+			if points[len(points)-1].Value == target {
 				shouldAlert = true
 			}
-		} else if r.compareOp() == ruletypes.ValueIsNotEq {
-			if series.Points[len(series.Points)-1].Value != r.targetVal() {
+		} else if compareOp == ruletypes.ValueIsNotEq {
+			if points[len(points)-1].Value != target {
 				shouldAlert = true
 			}
 		}
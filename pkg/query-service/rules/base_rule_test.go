@@ -2,6 +2,7 @@ package rules
 
 import (
 	"testing"
+	"time"
 
 	v3 "github.com/SigNoz/signoz/pkg/query-service/model/v3"
 	ruletypes "github.com/SigNoz/signoz/pkg/types/ruletypes"
@@ -56,7 +57,7 @@ func TestBaseRule_RequireMinPoints(t *testing.T) {
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			_, shouldAlert := test.rule.ShouldAlert(*test.series)
+			_, shouldAlert := test.rule.ShouldAlert(*test.series, time.Now())
 			if shouldAlert != test.shouldAlert {
 				t.Errorf("expected shouldAlert to be %v, got %v", test.shouldAlert, shouldAlert)
 			}
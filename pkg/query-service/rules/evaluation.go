@@ -0,0 +1,99 @@
+package rules
+
+import (
+	"context"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/ruler"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/noop"
+	"go.uber.org/zap"
+)
+
+const (
+	// skipReasonOverrun is the reason of the evaluations skipped because the previous evaluation of their task
+	// overran its interval.
+	skipReasonOverrun string = "overrun"
+	// skipReasonQueued is the reason of the evaluations skipped because they waited for a free slot for longer
+	// than their interval.
+	skipReasonQueued string = "queued"
+)
+
+// evaluations schedules the evaluations of the rules of a manager. It bounds the number of rules evaluated at the
+// same time, offsets the evaluations of every task within its interval and records the metrics of the evaluations.
+type evaluations struct {
+	config ruler.EvaluationConfig
+	// slots holds a token per running evaluation, it is nil when the concurrency is unlimited.
+	slots    chan struct{}
+	duration metric.Float64Histogram
+	skipped  metric.Int64Counter
+}
+
+func newEvaluations(meterProvider metric.MeterProvider, config ruler.EvaluationConfig) (*evaluations, error) {
+	if meterProvider == nil {
+		meterProvider = noop.NewMeterProvider()
+	}
+	meter := meterProvider.Meter("github.com/SigNoz/signoz/pkg/query-service/rules")
+
+	duration, err := meter.Float64Histogram("signoz.ruler.evaluation.duration", metric.WithDescription("Duration of the evaluations of the rules."), metric.WithUnit("s"))
+	if err != nil {
+		return nil, err
+	}
+
+	skipped, err := meter.Int64Counter("signoz.ruler.evaluation.skipped", metric.WithDescription("Number of evaluations of the rules which were skipped, by reason."))
+	if err != nil {
+		return nil, err
+	}
+
+	var slots chan struct{}
+	if config.Concurrency > 0 {
+		slots = make(chan struct{}, config.Concurrency)
+	}
+
+	return &evaluations{config: config, slots: slots, duration: duration, skipped: skipped}, nil
+}
+
+// offset returns the offset of the evaluations of a task, identified by the hash of its name, from the boundaries of
+// its interval.
+func (e *evaluations) offset(hash uint64, frequency time.Duration) int64 {
+	window := uint64(float64(frequency) * e.config.Jitter)
+	if window == 0 {
+		return 0
+	}
+
+	return int64(hash % window)
+}
+
+// acquire waits for a free slot to evaluate the rule of the name at ts, for at most the interval of the rule.
+// It returns false when the evaluation must be skipped, either because it waited for too long or because done was
+// closed, and otherwise the function releasing the slot.
+func (e *evaluations) acquire(ctx context.Context, done <-chan struct{}, name string, ts time.Time, frequency time.Duration) (func(), bool) {
+	if e.slots == nil {
+		return func() {}, true
+	}
+
+	timer := time.NewTimer(time.Until(ts.Add(frequency)))
+	defer timer.Stop()
+
+	select {
+	case e.slots <- struct{}{}:
+		return func() { <-e.slots }, true
+	case <-timer.C:
+		e.skip(ctx, name, skipReasonQueued, 1)
+		return nil, false
+	case <-done:
+		return nil, false
+	}
+}
+
+// skip records the evaluations of the rule or the task of the name which were skipped.
+func (e *evaluations) skip(ctx context.Context, name string, reason string, count int64) {
+	e.skipped.Add(ctx, count, metric.WithAttributes(attribute.String("reason", reason)))
+	zap.L().Warn("skipped rule evaluations", zap.String("name", name), zap.String("reason", reason), zap.Int64("count", count))
+}
+
+// record records the duration of an evaluation of a rule of the type.
+func (e *evaluations) record(ctx context.Context, typ TaskType, duration time.Duration) {
+	e.duration.Record(ctx, duration.Seconds(), metric.WithAttributes(attribute.String("rule.type", string(typ))))
+}
@@ -0,0 +1,77 @@
+package rules
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/ruler"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEvaluationsOffset(t *testing.T) {
+	testCases := []struct {
+		name   string
+		jitter float64
+		max    time.Duration
+	}{
+		{name: "Aligned", jitter: 0, max: 0},
+		{name: "Half", jitter: 0.5, max: 30 * time.Second},
+		{name: "Full", jitter: 1, max: time.Minute},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			evals, err := newEvaluations(nil, ruler.EvaluationConfig{Jitter: tc.jitter})
+			require.NoError(t, err)
+
+			for _, hash := range []uint64{0, 1, 12345678901234567890, 42424242424242} {
+				offset := evals.offset(hash, time.Minute)
+				assert.GreaterOrEqual(t, offset, int64(0))
+				assert.LessOrEqual(t, offset, int64(tc.max))
+			}
+		})
+	}
+}
+
+func TestEvaluationsAcquire(t *testing.T) {
+	t.Run("Unlimited", func(t *testing.T) {
+		evals, err := newEvaluations(nil, ruler.EvaluationConfig{Concurrency: 0, Jitter: 1})
+		require.NoError(t, err)
+
+		for i := 0; i < 10; i++ {
+			_, ok := evals.acquire(context.Background(), nil, "rule", time.Now(), time.Minute)
+			assert.True(t, ok)
+		}
+	})
+
+	t.Run("SkipsQueued", func(t *testing.T) {
+		evals, err := newEvaluations(nil, ruler.EvaluationConfig{Concurrency: 1, Jitter: 1})
+		require.NoError(t, err)
+
+		release, ok := evals.acquire(context.Background(), nil, "rule", time.Now(), time.Minute)
+		require.True(t, ok)
+
+		// The evaluation waits for the slot for at most its interval.
+		_, ok = evals.acquire(context.Background(), nil, "rule", time.Now(), 10*time.Millisecond)
+		assert.False(t, ok)
+
+		release()
+		_, ok = evals.acquire(context.Background(), nil, "rule", time.Now(), time.Minute)
+		assert.True(t, ok)
+	})
+
+	t.Run("Done", func(t *testing.T) {
+		evals, err := newEvaluations(nil, ruler.EvaluationConfig{Concurrency: 1, Jitter: 1})
+		require.NoError(t, err)
+
+		_, ok := evals.acquire(context.Background(), nil, "rule", time.Now(), time.Minute)
+		require.True(t, ok)
+
+		done := make(chan struct{})
+		close(done)
+		_, ok = evals.acquire(context.Background(), done, "rule", time.Now(), time.Minute)
+		assert.False(t, ok)
+	})
+}
@@ -0,0 +1,114 @@
+package rules
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/types/authtypes"
+	ruletypes "github.com/SigNoz/signoz/pkg/types/ruletypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+	"go.uber.org/zap"
+)
+
+const (
+	// IdempotencyKeyHeader is the header holding the idempotency key of a rule creation.
+	IdempotencyKeyHeader string = "Idempotency-Key"
+	// maxIdempotencyKeyLength is the maximum length of an idempotency key.
+	maxIdempotencyKeyLength int = 255
+)
+
+var (
+	ErrCodeIdempotencyKeyMismatch = errors.MustNewCode("idempotency_key_mismatch")
+)
+
+// CreateRuleIdempotently creates the rule unless a rule was already created with the same idempotency key within
+// the ttl, in which case that rule is returned instead. The key is claimed in the store before the rule is
+// created, so that concurrent retries on any replica do not create duplicates either.
+func (m *Manager) CreateRuleIdempotently(ctx context.Context, ruleStr string, idempotencyKey string) (*ruletypes.GettableRule, error) {
+	if idempotencyKey == "" {
+		return m.CreateRule(ctx, ruleStr)
+	}
+
+	if len(idempotencyKey) > maxIdempotencyKeyLength {
+		return nil, errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "%s must be at most %d characters long", IdempotencyKeyHeader, maxIdempotencyKeyLength)
+	}
+
+	claims, err := authtypes.ClaimsFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	orgID, err := valuer.NewUUID(claims.OrgID)
+	if err != nil {
+		return nil, err
+	}
+
+	sum := sha256.Sum256([]byte(ruleStr))
+	key := &ruletypes.StorableIdempotencyKey{
+		OrgID:     orgID.StringValue(),
+		Key:       idempotencyKey,
+		BodyHash:  hex.EncodeToString(sum[:]),
+		CreatedAt: time.Now(),
+	}
+
+	// A key whose rule was deleted since is claimed again, once.
+	for attempt := 0; ; attempt++ {
+		recorded, claimed, err := m.idempotencyKeyStore.Claim(ctx, key, key.CreatedAt.Add(-m.opts.Idempotency.TTL))
+		if err != nil {
+			return nil, err
+		}
+
+		if claimed {
+			return m.createClaimedRule(ctx, ruleStr, key)
+		}
+
+		if recorded.BodyHash != key.BodyHash {
+			return nil, errors.Newf(errors.TypeInvalidInput, ErrCodeIdempotencyKeyMismatch, "%s %q was already used to create a different rule", IdempotencyKeyHeader, idempotencyKey)
+		}
+
+		if recorded.RuleID == "" {
+			return nil, errors.Newf(errors.TypeAlreadyExists, errors.CodeAlreadyExists, "the rule of %s %q is being created, retry later", IdempotencyKeyHeader, idempotencyKey)
+		}
+
+		ruleID, err := valuer.NewUUID(recorded.RuleID)
+		if err != nil {
+			return nil, err
+		}
+
+		rule, err := m.GetRule(ctx, ruleID)
+		if err == nil {
+			return rule, nil
+		}
+
+		if !errors.Is(err, sql.ErrNoRows) || attempt > 0 {
+			return nil, err
+		}
+
+		if err := m.idempotencyKeyStore.Delete(ctx, key.OrgID, key.Key); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// createClaimedRule creates the rule of a claimed key. The key is released when the creation fails so that the
+// creation can be retried with it.
+func (m *Manager) createClaimedRule(ctx context.Context, ruleStr string, key *ruletypes.StorableIdempotencyKey) (*ruletypes.GettableRule, error) {
+	rule, err := m.CreateRule(ctx, ruleStr)
+	if err != nil {
+		if err := m.idempotencyKeyStore.Delete(context.WithoutCancel(ctx), key.OrgID, key.Key); err != nil {
+			zap.L().Warn("failed to release the idempotency key", zap.String("idempotency_key", key.Key), zap.Error(err))
+		}
+
+		return nil, err
+	}
+
+	if err := m.idempotencyKeyStore.SetRuleID(ctx, key.OrgID, key.Key, rule.Id); err != nil {
+		zap.L().Warn("failed to set the rule created for the idempotency key", zap.String("idempotency_key", key.Key), zap.Error(err))
+	}
+
+	return rule, nil
+}
@@ -0,0 +1,83 @@
+package rules
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/ruler"
+	"github.com/SigNoz/signoz/pkg/ruler/rulestore/sqlrulestore"
+	"github.com/SigNoz/signoz/pkg/sqlstore/sqlstoretest"
+	"github.com/SigNoz/signoz/pkg/types/authtypes"
+	ruletypes "github.com/SigNoz/signoz/pkg/types/ruletypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateRuleIdempotently(t *testing.T) {
+	store := sqlstoretest.NewSQLite(t)
+	orgID := valuer.GenerateUUID()
+	ctx := authtypes.NewContextWithClaims(context.Background(), authtypes.Claims{OrgID: orgID.StringValue()})
+
+	require.NoError(t, sqlstoretest.Seed(ctx, store,
+		&ruletypes.StorableIdempotencyKey{OrgID: orgID.StringValue(), Key: "key", BodyHash: "hash", RuleID: valuer.GenerateUUID().StringValue(), CreatedAt: time.Now()},
+		&ruletypes.StorableIdempotencyKey{OrgID: orgID.StringValue(), Key: "pending", BodyHash: hashOf(`{"alert":"pending"}`), CreatedAt: time.Now()},
+	))
+
+	m := &Manager{
+		opts:                &ManagerOptions{Idempotency: ruler.IdempotencyConfig{TTL: time.Hour}},
+		idempotencyKeyStore: sqlrulestore.NewIdempotencyKeyStore(store),
+	}
+
+	t.Run("Mismatch", func(t *testing.T) {
+		_, err := m.CreateRuleIdempotently(ctx, `{"alert":"other"}`, "key")
+		require.Error(t, err)
+		assert.True(t, errors.Asc(err, ErrCodeIdempotencyKeyMismatch))
+	})
+
+	t.Run("BeingCreated", func(t *testing.T) {
+		_, err := m.CreateRuleIdempotently(ctx, `{"alert":"pending"}`, "pending")
+		require.Error(t, err)
+		assert.True(t, errors.Ast(err, errors.TypeAlreadyExists))
+	})
+
+	t.Run("TooLong", func(t *testing.T) {
+		_, err := m.CreateRuleIdempotently(ctx, `{"alert":"other"}`, strings.Repeat("k", maxIdempotencyKeyLength+1))
+		require.Error(t, err)
+		assert.True(t, errors.Ast(err, errors.TypeInvalidInput))
+	})
+}
+
+func TestIdempotencyKeyStoreClaim(t *testing.T) {
+	ctx := context.Background()
+	store := sqlstoretest.NewSQLite(t)
+	_, err := store.BunDB().NewCreateTable().Model(new(ruletypes.StorableIdempotencyKey)).Exec(ctx)
+	require.NoError(t, err)
+
+	keys := sqlrulestore.NewIdempotencyKeyStore(store)
+	now := time.Now()
+
+	_, claimed, err := keys.Claim(ctx, &ruletypes.StorableIdempotencyKey{OrgID: "org", Key: "key", BodyHash: "first", CreatedAt: now.Add(-2 * time.Hour)}, now.Add(-3*time.Hour))
+	require.NoError(t, err)
+	assert.True(t, claimed)
+
+	recorded, claimed, err := keys.Claim(ctx, &ruletypes.StorableIdempotencyKey{OrgID: "org", Key: "key", BodyHash: "second", CreatedAt: now}, now.Add(-3*time.Hour))
+	require.NoError(t, err)
+	assert.False(t, claimed)
+	assert.Equal(t, "first", recorded.BodyHash)
+
+	// The first claim expired, the key is claimed again.
+	_, claimed, err = keys.Claim(ctx, &ruletypes.StorableIdempotencyKey{OrgID: "org", Key: "key", BodyHash: "second", CreatedAt: now}, now.Add(-time.Hour))
+	require.NoError(t, err)
+	assert.True(t, claimed)
+}
+
+func hashOf(body string) string {
+	sum := sha256.Sum256([]byte(body))
+	return hex.EncodeToString(sum[:])
+}
@@ -15,6 +15,9 @@ import (
 
 	"github.com/go-openapi/strfmt"
 	"github.com/jmoiron/sqlx"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	nooptrace "go.opentelemetry.io/otel/trace/noop"
 
 	"github.com/SigNoz/signoz/pkg/alertmanager"
 	"github.com/SigNoz/signoz/pkg/cache"
@@ -22,6 +25,7 @@ import (
 	"github.com/SigNoz/signoz/pkg/prometheus"
 	"github.com/SigNoz/signoz/pkg/query-service/interfaces"
 	"github.com/SigNoz/signoz/pkg/query-service/model"
+	"github.com/SigNoz/signoz/pkg/ruler"
 	"github.com/SigNoz/signoz/pkg/ruler/rulestore/sqlrulestore"
 	"github.com/SigNoz/signoz/pkg/sqlstore"
 	"github.com/SigNoz/signoz/pkg/telemetrystore"
@@ -96,6 +100,42 @@ type ManagerOptions struct {
 	Alertmanager        alertmanager.Alertmanager
 	SQLStore            sqlstore.SQLStore
 	OrgGetter           organization.Getter
+
+	// TracerProvider provides the tracer of the spans of the rule evaluations. The notifications of the alerts
+	// continue these spans.
+	TracerProvider trace.TracerProvider
+
+	// MeterProvider provides the meter of the metrics of the rule evaluations.
+	MeterProvider metric.MeterProvider
+
+	// Evaluation configures the scheduling of the rule evaluations.
+	Evaluation ruler.EvaluationConfig
+
+	// Idempotency configures the idempotency keys of the rule creations.
+	Idempotency ruler.IdempotencyConfig
+
+	// evals is shared by the tasks of the manager, it is set by NewManager.
+	evals *evaluations
+}
+
+// tracer returns the tracer of the rule evaluations.
+func (o *ManagerOptions) tracer() trace.Tracer {
+	if o.TracerProvider == nil {
+		return nooptrace.NewTracerProvider().Tracer("")
+	}
+
+	return o.TracerProvider.Tracer("github.com/SigNoz/signoz/pkg/query-service/rules")
+}
+
+// evaluations returns the scheduling of the rule evaluations. The tasks created without a manager evaluate their
+// rules without limits.
+func (o *ManagerOptions) evaluations() *evaluations {
+	if o.evals == nil {
+		evals, _ := newEvaluations(nil, ruler.EvaluationConfig{Jitter: 1})
+		return evals
+	}
+
+	return o.evals
 }
 
 // The Manager manages recording and alerting rules.
@@ -106,8 +146,9 @@ type Manager struct {
 	mtx   sync.RWMutex
 	block chan struct{}
 	// datastore to store alert definitions
-	ruleStore        ruletypes.RuleStore
-	maintenanceStore ruletypes.MaintenanceStore
+	ruleStore           ruletypes.RuleStore
+	maintenanceStore    ruletypes.MaintenanceStore
+	idempotencyKeyStore ruletypes.IdempotencyKeyStore
 
 	logger              *zap.Logger
 	reader              interfaces.Reader
@@ -195,6 +236,13 @@ func defaultPrepareTaskFunc(opts PrepareTaskOptions) (Task, error) {
 // by calling the Run method.
 func NewManager(o *ManagerOptions) (*Manager, error) {
 	o = defaultOptions(o)
+
+	evals, err := newEvaluations(o.MeterProvider, o.Evaluation)
+	if err != nil {
+		return nil, err
+	}
+	o.evals = evals
+
 	ruleStore := sqlrulestore.NewRuleStore(o.SQLStore)
 	maintenanceStore := sqlrulestore.NewMaintenanceStore(o.SQLStore)
 
@@ -203,6 +251,7 @@ func NewManager(o *ManagerOptions) (*Manager, error) {
 		rules:               map[string]Rule{},
 		ruleStore:           ruleStore,
 		maintenanceStore:    maintenanceStore,
+		idempotencyKeyStore: sqlrulestore.NewIdempotencyKeyStore(o.SQLStore),
 		opts:                o,
 		block:               make(chan struct{}),
 		logger:              o.Logger,
@@ -368,6 +417,16 @@ func (m *Manager) EditRule(ctx context.Context, ruleStr string, id valuer.UUID)
 			return err
 		}
 
+		if parsedRule.NotificationSettings != nil {
+			if err := parsedRule.NotificationSettings.Validate(preferredChannels); err != nil {
+				return err
+			}
+
+			if err := cfg.CreateRuleIDRoutes(id.StringValue(), preferredChannels, parsedRule.NotificationSettings); err != nil {
+				return err
+			}
+		}
+
 		err = m.alertmanager.SetConfig(ctx, cfg)
 		if err != nil {
 			return err
@@ -552,6 +611,16 @@ func (m *Manager) CreateRule(ctx context.Context, ruleStr string) (*ruletypes.Ge
 			return err
 		}
 
+		if parsedRule.NotificationSettings != nil {
+			if err := parsedRule.NotificationSettings.Validate(preferredChannels); err != nil {
+				return err
+			}
+
+			if err := cfg.CreateRuleIDRoutes(id.StringValue(), preferredChannels, parsedRule.NotificationSettings); err != nil {
+				return err
+			}
+		}
+
 		err = m.alertmanager.SetConfig(ctx, cfg)
 		if err != nil {
 			return err
@@ -781,6 +850,29 @@ func (m *Manager) ListRuleStates(ctx context.Context) (*ruletypes.GettableRules,
 		return nil, err
 	}
 
+	return &ruletypes.GettableRules{Rules: m.newGettableRules(storedRules)}, nil
+}
+
+// ListRuleStatesPage lists a page of the rules of the organization along with the cursor of the next page.
+func (m *Manager) ListRuleStatesPage(ctx context.Context, pagination *types.Pagination) (*ruletypes.GettableRules, error) {
+	claims, err := authtypes.ClaimsFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	storedRules, err := m.ruleStore.GetStoredRulesPage(ctx, claims.OrgID, pagination)
+	if err != nil {
+		return nil, err
+	}
+
+	storedRules, nextCursor := types.Paginate(pagination, storedRules, func(storedRule *ruletypes.Rule) types.Cursor {
+		return types.Cursor{CreatedAt: storedRule.CreatedAt, ID: storedRule.ID}
+	})
+
+	return &ruletypes.GettableRules{Rules: m.newGettableRules(storedRules), NextCursor: nextCursor}, nil
+}
+
+func (m *Manager) newGettableRules(storedRules []*ruletypes.Rule) []*ruletypes.GettableRule {
 	// initiate response object
 	resp := make([]*ruletypes.GettableRule, 0)
 
@@ -808,7 +900,7 @@ func (m *Manager) ListRuleStates(ctx context.Context) (*ruletypes.GettableRules,
 		resp = append(resp, ruleResponse)
 	}
 
-	return &ruletypes.GettableRules{Rules: resp}, nil
+	return resp
 }
 
 func (m *Manager) GetRule(ctx context.Context, id valuer.UUID) (*ruletypes.GettableRule, error) {
@@ -135,13 +135,13 @@ func (r *PromRule) Eval(ctx context.Context, ts time.Time) (interface{}, error)
 			continue
 		}
 
-		alertSmpl, shouldAlert := r.ShouldAlert(toCommonSeries(series))
+		alertSmpl, shouldAlert := r.ShouldAlert(toCommonSeries(series), end)
 		if !shouldAlert {
 			continue
 		}
 		zap.L().Debug("alerting for series", zap.String("name", r.Name()), zap.Any("series", series))
 
-		threshold := valueFormatter.Format(r.targetVal(), r.Unit())
+		threshold := valueFormatter.Format(r.thresholdOf(alertSmpl), r.Unit())
 
 		tmplData := ruletypes.AlertTemplateData(l, valueFormatter.Format(alertSmpl.V, r.Unit()), threshold)
 		// Inject some convenience variables that are easier to remember for users
@@ -8,10 +8,13 @@ import (
 	"time"
 
 	"github.com/SigNoz/signoz/pkg/query-service/common"
+	"github.com/SigNoz/signoz/pkg/telemetrystore"
 	ruletypes "github.com/SigNoz/signoz/pkg/types/ruletypes"
 	"github.com/SigNoz/signoz/pkg/valuer"
 	opentracing "github.com/opentracing/opentracing-go"
 	plabels "github.com/prometheus/prometheus/model/labels"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 )
 
@@ -153,6 +156,9 @@ func (g *PromRuleTask) Run(ctx context.Context) {
 				return
 			case <-tick.C:
 				missed := (time.Since(evalTimestamp) / g.frequency) - 1
+				if missed > 0 {
+					g.opts.evaluations().skip(ctx, g.name, skipReasonOverrun, int64(missed))
+				}
 				evalTimestamp = evalTimestamp.Add((missed + 1) * g.frequency)
 				iter()
 			}
@@ -248,7 +254,7 @@ func (g *PromRuleTask) setLastEvaluation(ts time.Time) {
 // EvalTimestamp returns the immediately preceding consistently slotted evaluation time.
 func (g *PromRuleTask) EvalTimestamp(startTime int64) time.Time {
 	var (
-		offset = int64(g.hash() % uint64(g.frequency))
+		offset = g.opts.evaluations().offset(g.hash(), g.frequency)
 		adjNow = startTime - offset
 		base   = adjNow - (adjNow % int64(g.frequency))
 	)
@@ -357,16 +363,26 @@ func (g *PromRuleTask) Eval(ctx context.Context, ts time.Time) {
 		default:
 		}
 
+		release, ok := g.opts.evaluations().acquire(ctx, g.done, rule.Name(), ts, g.frequency)
+		if !ok {
+			continue
+		}
+
 		func(i int, rule Rule) {
 			sp, ctx := opentracing.StartSpanFromContext(ctx, "rule")
+			ctx, span := g.opts.tracer().Start(ctx, "rule", trace.WithAttributes(attribute.String("rule.id", rule.ID()), attribute.String("rule.name", rule.Name())))
 
 			sp.SetTag("name", rule.Name())
 			defer func(t time.Time) {
 				sp.Finish()
+				span.End()
 
 				since := time.Since(t)
 				rule.SetEvaluationDuration(since)
 				rule.SetEvaluationTimestamp(t)
+
+				release()
+				g.opts.evaluations().record(ctx, g.Type(), since)
 			}(time.Now())
 
 			kvs := map[string]string{
@@ -375,6 +391,7 @@ func (g *PromRuleTask) Eval(ctx context.Context, ts time.Time) {
 				"client":  "query-service",
 			}
 			ctx = context.WithValue(ctx, common.LogCommentKey, kvs)
+			ctx = telemetrystore.NewContextWithQueryTag(ctx, telemetrystore.QueryTag{TenantID: g.orgID.StringValue(), Kind: telemetrystore.QueryKindAlert})
 
 			_, err := rule.Eval(ctx, ts)
 			if err != nil {
@@ -663,7 +663,7 @@ func TestPromRuleShouldAlert(t *testing.T) {
 			assert.NoError(t, err)
 		}
 
-		_, shoulAlert := rule.ShouldAlert(toCommonSeries(c.values))
+		_, shoulAlert := rule.ShouldAlert(toCommonSeries(c.values), time.Now())
 		assert.Equal(t, c.expectAlert, shoulAlert, "Test case %d", idx)
 	}
 }
@@ -811,7 +811,7 @@ func TestThresholdRuleShouldAlert(t *testing.T) {
 			values.Points[i].Timestamp = time.Now().UnixMilli()
 		}
 
-		smpl, shoulAlert := rule.ShouldAlert(c.values)
+		smpl, shoulAlert := rule.ShouldAlert(c.values, time.Now())
 		assert.Equal(t, c.expectAlert, shoulAlert, "Test case %d", idx)
 		if shoulAlert {
 			assert.Equal(t, c.expectedAlertSample.Value, smpl.V, "Test case %d", idx)
@@ -1015,7 +1015,7 @@ func TestThresholdRuleLabelNormalization(t *testing.T) {
 			values.Points[i].Timestamp = time.Now().UnixMilli()
 		}
 
-		sample, shoulAlert := rule.ShouldAlert(c.values)
+		sample, shoulAlert := rule.ShouldAlert(c.values, time.Now())
 		for name, value := range c.values.Labels {
 			assert.Equal(t, value, sample.Metric.Get(name))
 		}
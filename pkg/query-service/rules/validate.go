@@ -0,0 +1,292 @@
+package rules
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/query-service/model"
+	"github.com/SigNoz/signoz/pkg/query-service/model/metrics_explorer"
+	v3 "github.com/SigNoz/signoz/pkg/query-service/model/v3"
+	ruletypes "github.com/SigNoz/signoz/pkg/types/ruletypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/promql/parser"
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+)
+
+// metricIntrospector looks up the metrics and labels referenced by the queries of the rules.
+type metricIntrospector interface {
+	GetTotalTimeSeriesForMetricName(ctx context.Context, metricName string) (uint64, *model.ApiError)
+	GetAttributesForMetricName(ctx context.Context, metricName string, start, end *int64, set *v3.FilterSet) (*[]metrics_explorer.Attribute, *model.ApiError)
+}
+
+// metricReference is a metric referenced by a query along with the labels the query references on it.
+type metricReference struct {
+	name   string
+	labels []string
+}
+
+// ValidateRule validates the rule without saving it. The rule is parsed and linted, the metrics and labels referenced
+// by its queries are looked up and, when the rule has no errors, it is evaluated once over its evaluation window
+// without sending any notification.
+func (m *Manager) ValidateRule(ctx context.Context, orgID valuer.UUID, ruleStr string) *ruletypes.RuleValidation {
+	validation := ruletypes.NewRuleValidation()
+
+	parsedRule, err := ruletypes.ParsePostableRule([]byte(ruleStr))
+	if err != nil {
+		for _, err := range multierr.Errors(err) {
+			validation.AddError("", "%s", err.Error())
+		}
+		return validation
+	}
+
+	lintRule(ctx, m.reader, parsedRule, validation)
+	if !validation.Valid {
+		return validation
+	}
+
+	m.evalRule(ctx, orgID, parsedRule, validation)
+	return validation
+}
+
+// lintRule checks that the selected query of the rule exists and that the metrics and labels its queries reference
+// exist. The missing metrics and labels are warnings as their data may not have been received yet.
+func lintRule(ctx context.Context, introspector metricIntrospector, rule *ruletypes.PostableRule, validation *ruletypes.RuleValidation) {
+	compositeQuery := rule.RuleCondition.CompositeQuery
+
+	var queryNames []string
+	switch compositeQuery.QueryType {
+	case v3.QueryTypeBuilder:
+		for name, query := range compositeQuery.BuilderQueries {
+			if !query.Disabled {
+				queryNames = append(queryNames, name)
+			}
+		}
+	case v3.QueryTypePromQL:
+		for name, query := range compositeQuery.PromQueries {
+			if !query.Disabled {
+				queryNames = append(queryNames, name)
+			}
+		}
+	case v3.QueryTypeClickHouseSQL:
+		for name, query := range compositeQuery.ClickHouseQueries {
+			if !query.Disabled {
+				queryNames = append(queryNames, name)
+			}
+		}
+	default:
+		validation.AddError("", "unknown query type %q", compositeQuery.QueryType)
+		return
+	}
+	slices.Sort(queryNames)
+
+	if len(queryNames) == 0 {
+		validation.AddError("", "the rule has no enabled query")
+		return
+	}
+
+	var selectedQuery string
+	if compositeQuery.QueryType == v3.QueryTypePromQL && rule.RuleCondition.SelectedQuery == "" {
+		// The promql rules default to the query A, see PromRule.GetSelectedQuery.
+		selectedQuery = "A"
+	} else {
+		selectedQuery = rule.RuleCondition.GetSelectedQueryName()
+	}
+
+	if !slices.Contains(queryNames, selectedQuery) {
+		validation.AddError(selectedQuery, "the selected query %q is not an enabled query of the rule", selectedQuery)
+	}
+
+	for _, name := range queryNames {
+		var references []metricReference
+		switch compositeQuery.QueryType {
+		case v3.QueryTypePromQL:
+			expr, err := parser.ParseExpr(compositeQuery.PromQueries[name].Query)
+			if err != nil {
+				validation.AddError(name, "failed to parse the promql query: %s", err.Error())
+				continue
+			}
+
+			references = promQueryReferences(expr)
+		case v3.QueryTypeBuilder:
+			references = builderQueryReferences(name, compositeQuery.BuilderQueries[name])
+		}
+
+		for _, reference := range references {
+			lintMetricReference(ctx, introspector, name, reference, validation)
+		}
+	}
+}
+
+func lintMetricReference(ctx context.Context, introspector metricIntrospector, query string, reference metricReference, validation *ruletypes.RuleValidation) {
+	timeSeries, apiErr := introspector.GetTotalTimeSeriesForMetricName(ctx, reference.name)
+	if apiErr != nil {
+		validation.AddWarning(query, "failed to look up the metric %q: %s", reference.name, apiErr.Error())
+		return
+	}
+
+	if timeSeries == 0 {
+		validation.AddWarning(query, "the metric %q has no series", reference.name)
+		return
+	}
+
+	if len(reference.labels) == 0 {
+		return
+	}
+
+	attributes, apiErr := introspector.GetAttributesForMetricName(ctx, reference.name, nil, nil, nil)
+	if apiErr != nil {
+		validation.AddWarning(query, "failed to look up the labels of the metric %q: %s", reference.name, apiErr.Error())
+		return
+	}
+
+	keys := map[string]struct{}{}
+	if attributes != nil {
+		for _, attribute := range *attributes {
+			keys[attribute.Key] = struct{}{}
+		}
+	}
+
+	for _, label := range reference.labels {
+		if _, ok := keys[label]; !ok {
+			validation.AddWarning(query, "no series of the metric %q has the label %q", reference.name, label)
+		}
+	}
+}
+
+// promQueryReferences returns the metrics selected by name in the promql expression along with the labels of their
+// matchers and of the groupings of the aggregations over them.
+func promQueryReferences(expr parser.Expr) []metricReference {
+	var references []metricReference
+	parser.Inspect(expr, func(node parser.Node, path []parser.Node) error {
+		selector, ok := node.(*parser.VectorSelector)
+		if !ok {
+			return nil
+		}
+
+		reference := metricReference{name: selector.Name}
+		for _, matcher := range selector.LabelMatchers {
+			if matcher.Name == labels.MetricName {
+				if matcher.Type == labels.MatchEqual {
+					reference.name = matcher.Value
+				}
+				continue
+			}
+
+			reference.labels = appendLabel(reference.labels, matcher.Name)
+		}
+
+		// The metrics selected by a regex cannot be looked up.
+		if reference.name == "" {
+			return nil
+		}
+
+		for _, parent := range path {
+			if aggregation, ok := parent.(*parser.AggregateExpr); ok {
+				for _, label := range aggregation.Grouping {
+					reference.labels = appendLabel(reference.labels, label)
+				}
+			}
+		}
+
+		references = append(references, reference)
+		return nil
+	})
+
+	return references
+}
+
+// builderQueryReferences returns the metric of the builder query along with the labels of its filters and group by.
+func builderQueryReferences(name string, query *v3.BuilderQuery) []metricReference {
+	// The formulas reference other queries.
+	if query.DataSource != v3.DataSourceMetrics || query.AggregateAttribute.Key == "" || query.Expression != name {
+		return nil
+	}
+
+	reference := metricReference{name: query.AggregateAttribute.Key}
+	if query.Filters != nil {
+		for _, item := range query.Filters.Items {
+			reference.labels = appendLabel(reference.labels, item.Key.Key)
+		}
+	}
+
+	for _, groupBy := range query.GroupBy {
+		reference.labels = appendLabel(reference.labels, groupBy.Key)
+	}
+
+	return []metricReference{reference}
+}
+
+func appendLabel(names []string, label string) []string {
+	if label == "" || slices.Contains(names, label) {
+		return names
+	}
+
+	return append(names, label)
+}
+
+// evalRule evaluates the rule once at the current time and adds the series which would fire as samples.
+func (m *Manager) evalRule(ctx context.Context, orgID valuer.UUID, parsedRule *ruletypes.PostableRule, validation *ruletypes.RuleValidation) {
+	ts := time.Now().UTC()
+	name := parsedRule.AlertName + ruletypes.TestAlertPostFix
+
+	switch parsedRule.RuleType {
+	case ruletypes.RuleTypeThreshold:
+		rule, err := NewThresholdRule(name, orgID, parsedRule, m.reader, WithSQLStore(m.sqlstore))
+		if err != nil {
+			validation.AddError("", "failed to prepare the rule: %s", err.Error())
+			return
+		}
+
+		vector, err := rule.buildAndRunQuery(ctx, orgID, ts)
+		if err != nil {
+			zap.L().Error("failed to evaluate the rule for its validation", zap.String("rule", name), zap.Error(err))
+			validation.AddError(rule.GetSelectedQuery(), "failed to evaluate the rule: %s", err.Error())
+			return
+		}
+
+		for _, sample := range vector {
+			validation.AddSample(sample)
+		}
+	case ruletypes.RuleTypeProm:
+		rule, err := NewPromRule(name, orgID, parsedRule, m.logger, m.reader, m.opts.Prometheus, WithSQLStore(m.sqlstore))
+		if err != nil {
+			validation.AddError("", "failed to prepare the rule: %s", err.Error())
+			return
+		}
+
+		query, err := rule.getPqlQuery()
+		if err != nil {
+			validation.AddError(rule.GetSelectedQuery(), "%s", err.Error())
+			return
+		}
+
+		matrix, err := rule.RunAlertQuery(ctx, query, ts.Add(-rule.EvalWindow()), ts, 60*time.Second)
+		if err != nil {
+			zap.L().Error("failed to evaluate the rule for its validation", zap.String("rule", name), zap.Error(err))
+			validation.AddError(rule.GetSelectedQuery(), "failed to evaluate the rule: %s", err.Error())
+			return
+		}
+
+		if len(matrix) == 0 {
+			validation.AddWarning(rule.GetSelectedQuery(), "the query returned no series over the last %s", rule.EvalWindow())
+		}
+
+		for _, series := range matrix {
+			if len(series.Floats) == 0 {
+				continue
+			}
+
+			if sample, ok := rule.ShouldAlert(toCommonSeries(series), ts); ok {
+				validation.AddSample(sample)
+			}
+		}
+	default:
+		validation.AddWarning("", "the rules of type %q are not evaluated by the validation", parsedRule.RuleType)
+		return
+	}
+
+	validation.EvaluatedAt = ts
+}
@@ -0,0 +1,95 @@
+package rules
+
+import (
+	"context"
+	"testing"
+
+	"github.com/SigNoz/signoz/pkg/query-service/model"
+	"github.com/SigNoz/signoz/pkg/query-service/model/metrics_explorer"
+	v3 "github.com/SigNoz/signoz/pkg/query-service/model/v3"
+	ruletypes "github.com/SigNoz/signoz/pkg/types/ruletypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeMetricIntrospector map[string][]string
+
+func (introspector fakeMetricIntrospector) GetTotalTimeSeriesForMetricName(_ context.Context, metricName string) (uint64, *model.ApiError) {
+	if _, ok := introspector[metricName]; !ok {
+		return 0, nil
+	}
+
+	return 1, nil
+}
+
+func (introspector fakeMetricIntrospector) GetAttributesForMetricName(_ context.Context, metricName string, _, _ *int64, _ *v3.FilterSet) (*[]metrics_explorer.Attribute, *model.ApiError) {
+	attributes := []metrics_explorer.Attribute{}
+	for _, key := range introspector[metricName] {
+		attributes = append(attributes, metrics_explorer.Attribute{Key: key})
+	}
+
+	return &attributes, nil
+}
+
+func TestLintRule(t *testing.T) {
+	introspector := fakeMetricIntrospector{
+		"http_requests_total": {"service_name", "status_code"},
+	}
+
+	testCases := []struct {
+		name     string
+		rule     string
+		errors   []ruletypes.RuleValidationIssue
+		warnings []ruletypes.RuleValidationIssue
+	}{
+		{
+			name:     "PromQL",
+			rule:     `{"alert":"a","condition":{"compositeQuery":{"queryType":"promql","promQueries":{"A":{"query":"sum by (service_name) (rate(http_requests_total{status_code=\"500\"}[5m]))"}}},"op":"1","target":1,"matchType":"1"}}`,
+			errors:   []ruletypes.RuleValidationIssue{},
+			warnings: []ruletypes.RuleValidationIssue{},
+		},
+		{
+			name:     "PromQLUnknownLabel",
+			rule:     `{"alert":"a","condition":{"compositeQuery":{"queryType":"promql","promQueries":{"A":{"query":"sum by (region) (rate(http_requests_total{status=\"500\"}[5m]))"}}},"op":"1","target":1,"matchType":"1"}}`,
+			errors:   []ruletypes.RuleValidationIssue{},
+			warnings: []ruletypes.RuleValidationIssue{{Query: "A", Message: `no series of the metric "http_requests_total" has the label "status"`}, {Query: "A", Message: `no series of the metric "http_requests_total" has the label "region"`}},
+		},
+		{
+			name:     "PromQLUnknownMetric",
+			rule:     `{"alert":"a","condition":{"compositeQuery":{"queryType":"promql","promQueries":{"A":{"query":"http_requests{service_name=\"api\"}"}}},"op":"1","target":1,"matchType":"1"}}`,
+			errors:   []ruletypes.RuleValidationIssue{},
+			warnings: []ruletypes.RuleValidationIssue{{Query: "A", Message: `the metric "http_requests" has no series`}},
+		},
+		{
+			name:     "PromQLInvalid",
+			rule:     `{"alert":"a","condition":{"compositeQuery":{"queryType":"promql","promQueries":{"A":{"query":"sum(http_requests_total"}}},"op":"1","target":1,"matchType":"1"}}`,
+			errors:   []ruletypes.RuleValidationIssue{{Query: "A", Message: "failed to parse the promql query: 1:24: parse error: unclosed left parenthesis"}},
+			warnings: []ruletypes.RuleValidationIssue{},
+		},
+		{
+			name:     "SelectedQueryMissing",
+			rule:     `{"alert":"a","condition":{"compositeQuery":{"queryType":"promql","promQueries":{"B":{"query":"http_requests_total"}}},"selectedQueryName":"A","op":"1","target":1,"matchType":"1"}}`,
+			errors:   []ruletypes.RuleValidationIssue{{Query: "A", Message: `the selected query "A" is not an enabled query of the rule`}},
+			warnings: []ruletypes.RuleValidationIssue{},
+		},
+		{
+			name:     "Builder",
+			rule:     `{"alert":"a","condition":{"compositeQuery":{"queryType":"builder","builderQueries":{"A":{"queryName":"A","dataSource":"metrics","aggregateAttribute":{"key":"http_requests_total"},"filters":{"op":"AND","items":[{"key":{"key":"status_code"},"op":"=","value":"500"}]},"groupBy":[{"key":"host_name"}],"expression":"A"}}},"op":"1","target":1,"matchType":"1"}}`,
+			errors:   []ruletypes.RuleValidationIssue{},
+			warnings: []ruletypes.RuleValidationIssue{{Query: "A", Message: `no series of the metric "http_requests_total" has the label "host_name"`}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			rule, err := ruletypes.ParsePostableRule([]byte(tc.rule))
+			require.NoError(t, err)
+
+			validation := ruletypes.NewRuleValidation()
+			lintRule(context.Background(), introspector, rule, validation)
+			assert.Equal(t, tc.errors, validation.Errors)
+			assert.Equal(t, tc.warnings, validation.Warnings)
+			assert.Equal(t, len(tc.errors) == 0, validation.Valid)
+		})
+	}
+}
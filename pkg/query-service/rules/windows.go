@@ -0,0 +1,67 @@
+package rules
+
+import (
+	"time"
+
+	v3 "github.com/SigNoz/signoz/pkg/query-service/model/v3"
+	qslabels "github.com/SigNoz/signoz/pkg/query-service/utils/labels"
+	ruletypes "github.com/SigNoz/signoz/pkg/types/ruletypes"
+)
+
+// shouldAlertWindows evaluates the windows of the condition over the points of a series. The windows end at the end
+// of the evaluation, the points being queried once over the longest window, so that a series which stopped reporting
+// is not evaluated over its stale points. The sample is the value of the first window which fires, along with the
+// threshold of that window.
+func (r *BaseRule) shouldAlertWindows(points []v3.Point, end time.Time, lbls qslabels.Labels, condition ruletypes.WindowCondition) (ruletypes.Sample, bool) {
+	if len(points) == 0 {
+		return ruletypes.Sample{}, false
+	}
+
+	return r.evalWindowCondition(points, end.UnixMilli(), lbls, condition)
+}
+
+func (r *BaseRule) evalWindowCondition(points []v3.Point, end int64, lbls qslabels.Labels, condition ruletypes.WindowCondition) (ruletypes.Sample, bool) {
+	if condition.IsWindow() {
+		start := end - time.Duration(condition.Window).Milliseconds()
+
+		windowPoints := make([]v3.Point, 0, len(points))
+		for _, point := range points {
+			if point.Timestamp > start && point.Timestamp <= end {
+				windowPoints = append(windowPoints, point)
+			}
+		}
+
+		if len(windowPoints) == 0 {
+			return ruletypes.Sample{}, false
+		}
+
+		target := r.convertTarget(*condition.Target)
+		sample, ok := matchPoints(windowPoints, lbls, condition.GetMatchType(), condition.CompareOp, target)
+		if ok {
+			sample.Threshold = &target
+		}
+
+		return sample, ok
+	}
+
+	var firing *ruletypes.Sample
+	for _, child := range condition.Conditions {
+		sample, ok := r.evalWindowCondition(points, end, lbls, child)
+		switch {
+		case ok && firing == nil:
+			firing = &sample
+		case !ok && condition.Op == ruletypes.WindowsOpAnd:
+			return ruletypes.Sample{}, false
+		}
+
+		if ok && condition.Op == ruletypes.WindowsOpOr {
+			return sample, true
+		}
+	}
+
+	if firing == nil {
+		return ruletypes.Sample{}, false
+	}
+
+	return *firing, true
+}
@@ -0,0 +1,124 @@
+package rules
+
+import (
+	"testing"
+	"time"
+
+	v3 "github.com/SigNoz/signoz/pkg/query-service/model/v3"
+	ruletypes "github.com/SigNoz/signoz/pkg/types/ruletypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShouldAlertWindows(t *testing.T) {
+	// The multi-window multi-burn-rate condition of an SLO: the fast burn fires on 1h and 5m above 14.4, the slow burn
+	// fires on 6h and 30m above 6.
+	postableRule, err := ruletypes.ParsePostableRule([]byte(`{
+		"alert": "slo",
+		"ruleType": "promql_rule",
+		"evalWindow": "5m",
+		"condition": {
+			"compositeQuery": {"queryType": "promql", "promQueries": {"A": {"query": "burn_rate"}}},
+			"windows": {
+				"op": "or",
+				"conditions": [
+					{"op": "and", "conditions": [{"window": "1h", "compareOp": "1", "target": 14.4}, {"window": "5m", "compareOp": "1", "target": 14.4}]},
+					{"op": "and", "conditions": [{"window": "6h", "compareOp": "1", "target": 6}, {"window": "30m", "compareOp": "1", "target": 6}]}
+				]
+			}
+		}
+	}`))
+	require.NoError(t, err)
+
+	rule, err := NewBaseRule("slo", valuer.GenerateUUID(), postableRule, nil)
+	require.NoError(t, err)
+	// The query is run once over the longest window.
+	assert.Equal(t, 6*time.Hour, rule.EvalWindow())
+
+	end := time.Now().Truncate(time.Minute)
+	series := func(value func(ago time.Duration) float64) v3.Series {
+		points := []v3.Point{}
+		for ago := 6 * time.Hour; ago >= 0; ago -= time.Minute {
+			points = append(points, v3.Point{Timestamp: end.Add(-ago).UnixMilli(), Value: value(ago)})
+		}
+		return v3.Series{Labels: map[string]string{"service_name": "api"}, Points: points}
+	}
+
+	testCases := []struct {
+		name      string
+		value     func(ago time.Duration) float64
+		firing    bool
+		sample    float64
+		threshold float64
+	}{
+		{name: "NoBurn", value: func(time.Duration) float64 { return 1 }, firing: false},
+		{name: "FastBurn", value: func(time.Duration) float64 { return 20 }, firing: true, sample: 20, threshold: 14.4},
+		{name: "FastBurnOver", value: func(ago time.Duration) float64 {
+			// The fast burn is over as the 5m window is not above the target anymore, the slow burn still fires.
+			if ago < 5*time.Minute {
+				return 1
+			}
+			return 20
+		}, firing: true, sample: (356*20 + 5*1) / 361.0, threshold: 6},
+		{name: "FastBurnSpike", value: func(ago time.Duration) float64 {
+			// The 5m window is above the target but the 1h and 6h windows are not.
+			if ago < 5*time.Minute {
+				return 100
+			}
+			return 0
+		}, firing: false},
+		{name: "SlowBurn", value: func(time.Duration) float64 { return 8 }, firing: true, sample: 8, threshold: 6},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sample, firing := rule.ShouldAlert(series(tc.value), end)
+			assert.Equal(t, tc.firing, firing)
+			if tc.firing {
+				assert.InDelta(t, tc.sample, sample.V, 0.01)
+				assert.Equal(t, "api", sample.Metric.Map()["service_name"])
+				require.NotNil(t, sample.Threshold)
+				assert.Equal(t, tc.threshold, *sample.Threshold)
+				assert.Equal(t, tc.threshold, rule.thresholdOf(sample))
+			}
+		})
+	}
+
+	// The windows end at the end of the evaluation, the series which stopped reporting 10m ago does not fire on the
+	// fast burn as its 5m window is empty, it still fires on the slow burn.
+	sample, firing := rule.ShouldAlert(series(func(time.Duration) float64 { return 20 }), end.Add(10*time.Minute))
+	assert.True(t, firing)
+	require.NotNil(t, sample.Threshold)
+	assert.Equal(t, 6.0, *sample.Threshold)
+
+	// The series which stopped reporting an hour ago does not fire at all.
+	_, firing = rule.ShouldAlert(series(func(time.Duration) float64 { return 20 }), end.Add(time.Hour))
+	assert.False(t, firing)
+}
+
+func TestValidateWindows(t *testing.T) {
+	testCases := []struct {
+		name    string
+		windows string
+		pass    bool
+	}{
+		{name: "Window", windows: `{"window": "1h", "compareOp": "1", "target": 1}`, pass: true},
+		{name: "MissingTarget", windows: `{"window": "1h", "compareOp": "1"}`, pass: false},
+		{name: "MissingWindow", windows: `{"compareOp": "1", "target": 1}`, pass: false},
+		{name: "UnknownOp", windows: `{"op": "xor", "conditions": [{"window": "1h", "compareOp": "1", "target": 1}]}`, pass: false},
+		{name: "OpWithTarget", windows: `{"op": "and", "target": 1, "conditions": [{"window": "1h", "compareOp": "1", "target": 1}]}`, pass: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := ruletypes.ParsePostableRule([]byte(`{"alert": "slo", "condition": {"compositeQuery": {"queryType": "builder", "builderQueries": {"A": {"queryName": "A", "dataSource": "metrics", "aggregateAttribute": {"key": "burn_rate"}, "expression": "A"}}}, "windows": ` + tc.windows + `}}`))
+			if tc.pass {
+				assert.NoError(t, err)
+				return
+			}
+
+			assert.Error(t, err)
+		})
+	}
+}
@@ -15,9 +15,9 @@ import (
 // GetDashboardsInfo returns analytics data for dashboards
 func GetDashboardsInfo(ctx context.Context, sqlstore sqlstore.SQLStore) (*model.DashboardsInfo, error) {
 	dashboardsInfo := model.DashboardsInfo{}
-	// fetch dashboards from dashboard db
+	// fetch dashboards from dashboard db, leaving out the deleted ones
 	dashboards := []dashboardtypes.StorableDashboard{}
-	err := sqlstore.BunDB().NewSelect().Model(&dashboards).Scan(ctx)
+	err := sqlstore.BunDB().NewSelect().Model(&dashboards).Where("deleted_at IS NULL").Scan(ctx)
 	if err != nil {
 		zap.L().Error("Error in processing sql query", zap.Error(err))
 		return &dashboardsInfo, err
@@ -304,11 +304,11 @@ func createTelemetry() {
 		if len(services) > 0 {
 			telemetry.SendEvent(TELEMETRY_EVENT_SERVICE, map[string]interface{}{"serviceName": services}, "", true, false)
 		}
-		totalSpans, _ := telemetry.reader.GetTotalSpans(ctx)
-		totalLogs, _ := telemetry.reader.GetTotalLogs(ctx)
+		totalSpans, totalSpansPartial, _ := telemetry.reader.GetTotalSpans(ctx)
+		totalLogs, totalLogsPartial, _ := telemetry.reader.GetTotalLogs(ctx)
 		spansInLastHeartBeatInterval, _ := telemetry.reader.GetSpansInLastHeartBeatInterval(ctx, HEART_BEAT_DURATION)
 		getSamplesInfoInLastHeartBeatInterval, _ := telemetry.reader.GetSamplesInfoInLastHeartBeatInterval(ctx, HEART_BEAT_DURATION)
-		totalSamples, _ := telemetry.reader.GetTotalSamples(ctx)
+		totalSamples, totalSamplesPartial, _ := telemetry.reader.GetTotalSamples(ctx)
 		tsInfo, _ := telemetry.reader.GetTimeSeriesInfo(ctx)
 
 		getLogsInfoInLastHeartBeatInterval, _ := telemetry.reader.GetLogsInfoInLastHeartBeatInterval(ctx, HEART_BEAT_DURATION)
@@ -326,6 +326,7 @@ func createTelemetry() {
 			"totalSamples":                          totalSamples,
 			"getSamplesInfoInLastHeartBeatInterval": getSamplesInfoInLastHeartBeatInterval,
 			"totalLogs":                             totalLogs,
+			"totalCountsPartial":                    totalSpansPartial || totalLogsPartial || totalSamplesPartial,
 			"getLogsInfoInLastHeartBeatInterval":    getLogsInfoInLastHeartBeatInterval,
 			"countUsers":                            userCount,
 			"metricsTTLStatus":                      metricsTTL.Status,
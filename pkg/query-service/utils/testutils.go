@@ -69,13 +69,31 @@ func NewTestSqliteDB(t *testing.T) (sqlStore sqlstore.SQLStore, testDBFilePath s
 			sqlmigration.NewUpdateApiMonitoringFiltersFactory(sqlStore),
 			sqlmigration.NewAddKeyOrganizationFactory(sqlStore),
 			sqlmigration.NewUpdateDashboardFactory(sqlStore),
+			sqlmigration.NewAddRefreshTokenFactory(sqlStore),
+			sqlmigration.NewAddDashboardSoftDeleteFactory(sqlStore),
+			sqlmigration.NewAddAPIKeyScopesFactory(sqlStore),
+			sqlmigration.NewAddRBACFactory(sqlStore),
+			sqlmigration.NewAddDashboardPublicLinkFactory(sqlStore),
+			sqlmigration.NewAddOutboxEventFactory(sqlStore),
+			sqlmigration.NewAddMigrationChecksumFactory(sqlStore),
+			sqlmigration.NewAddRuleIdempotencyKeyFactory(sqlStore),
+			sqlmigration.NewAddMaintenanceFactory(sqlStore),
+			sqlmigration.NewAddSamlAssertionFactory(sqlStore),
+			sqlmigration.NewAddBackfillCheckpointFactory(sqlStore),
+			sqlmigration.NewAddDashboardTitleFactory(sqlStore),
+			sqlmigration.NewAddChannelSigningSecretFactory(sqlStore),
+			sqlmigration.NewAddJobRunFactory(sqlStore),
+			sqlmigration.NewAddFeatureOverrideFactory(sqlStore),
+			sqlmigration.NewAddTelemetryDeletionFactory(sqlStore),
+			sqlmigration.NewAddChannelPluginFactory(sqlStore),
+			sqlmigration.NewEncryptSensitiveColumnsFactory(nil),
 		),
 	)
 	if err != nil {
 		t.Fatalf("could not create test db sql migrations: %v", err)
 	}
 
-	err = sqlmigrator.New(context.Background(), factorytest.NewSettings(), sqlStore, sqlmigrations, sqlmigrator.Config{}).Migrate(context.Background())
+	err = sqlmigrator.New(context.Background(), factorytest.NewSettings(), sqlStore, sqlmigrations, sqlmigration.MustNewChecksums(), sqlmigrator.Config{}).Migrate(context.Background())
 	if err != nil {
 		t.Fatalf("could not migrate test db sql migrations: %v", err)
 	}
@@ -1,10 +1,34 @@
 package ruler
 
 import (
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
 	"github.com/SigNoz/signoz/pkg/factory"
 )
 
 type Config struct {
+	// Evaluation configures the scheduling of the evaluations of the rules.
+	Evaluation EvaluationConfig `mapstructure:"evaluation"`
+
+	// Idempotency configures the idempotency keys of the rule creations.
+	Idempotency IdempotencyConfig `mapstructure:"idempotency"`
+}
+
+type EvaluationConfig struct {
+	// Concurrency is the maximum number of rules evaluated at the same time. 0 means unlimited.
+	Concurrency int `mapstructure:"concurrency"`
+
+	// Jitter is the fraction of its interval over which the evaluations of a rule are offset from the interval
+	// boundaries, so that the evaluations of the rules sharing an interval are spread instead of starting together.
+	// The offset of a rule is derived from its name and stays the same across restarts. 0 aligns all the evaluations
+	// on the interval boundaries and 1 spreads them over the whole interval.
+	Jitter float64 `mapstructure:"jitter"`
+}
+
+type IdempotencyConfig struct {
+	// TTL is the time during which a rule creation with the same idempotency key returns the rule created first.
+	TTL time.Duration `mapstructure:"ttl"`
 }
 
 func NewConfigFactory() factory.ConfigFactory {
@@ -12,9 +36,29 @@ func NewConfigFactory() factory.ConfigFactory {
 }
 
 func newConfig() factory.Config {
-	return Config{}
+	return Config{
+		Evaluation: EvaluationConfig{
+			Concurrency: 0,
+			Jitter:      1,
+		},
+		Idempotency: IdempotencyConfig{
+			TTL: 24 * time.Hour,
+		},
+	}
 }
 
 func (c Config) Validate() error {
+	if c.Evaluation.Concurrency < 0 {
+		return errors.New(errors.TypeInvalidInput, errors.CodeInvalidInput, "evaluation::concurrency must not be negative")
+	}
+
+	if c.Evaluation.Jitter < 0 || c.Evaluation.Jitter > 1 {
+		return errors.New(errors.TypeInvalidInput, errors.CodeInvalidInput, "evaluation::jitter must be between 0 and 1")
+	}
+
+	if c.Idempotency.TTL <= 0 {
+		return errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "idempotency::ttl must be greater than 0, got %v", c.Idempotency.TTL)
+	}
+
 	return nil
 }
@@ -0,0 +1,96 @@
+package sqlrulestore
+
+import (
+	"context"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/sqlstore"
+	ruletypes "github.com/SigNoz/signoz/pkg/types/ruletypes"
+)
+
+type idempotencyKey struct {
+	sqlstore sqlstore.SQLStore
+}
+
+func NewIdempotencyKeyStore(store sqlstore.SQLStore) ruletypes.IdempotencyKeyStore {
+	return &idempotencyKey{sqlstore: store}
+}
+
+func (store *idempotencyKey) Claim(ctx context.Context, key *ruletypes.StorableIdempotencyKey, expiredBefore time.Time) (*ruletypes.StorableIdempotencyKey, bool, error) {
+	recorded := new(ruletypes.StorableIdempotencyKey)
+	claimed := false
+
+	err := store.sqlstore.RunInTxCtx(ctx, nil, func(ctx context.Context) error {
+		_, err := store.sqlstore.
+			BunDBCtx(ctx).
+			NewDelete().
+			Model(new(ruletypes.StorableIdempotencyKey)).
+			Where("org_id = ?", key.OrgID).
+			Where("created_at < ?", expiredBefore).
+			Exec(ctx)
+		if err != nil {
+			return err
+		}
+
+		// The primary key makes the insert the lock of the key across the replicas.
+		result, err := store.sqlstore.
+			BunDBCtx(ctx).
+			NewInsert().
+			Model(key).
+			On("CONFLICT (org_id, idempotency_key) DO NOTHING").
+			Exec(ctx)
+		if err != nil {
+			return err
+		}
+
+		inserted, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+
+		if inserted > 0 {
+			claimed = true
+			return nil
+		}
+
+		return store.sqlstore.
+			BunDBCtx(ctx).
+			NewSelect().
+			Model(recorded).
+			Where("org_id = ?", key.OrgID).
+			Where("idempotency_key = ?", key.Key).
+			Scan(ctx)
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	if claimed {
+		return key, true, nil
+	}
+
+	return recorded, false, nil
+}
+
+func (store *idempotencyKey) SetRuleID(ctx context.Context, orgID string, key string, ruleID string) error {
+	_, err := store.sqlstore.
+		BunDB().
+		NewUpdate().
+		Model(new(ruletypes.StorableIdempotencyKey)).
+		Set("rule_id = ?", ruleID).
+		Where("org_id = ?", orgID).
+		Where("idempotency_key = ?", key).
+		Exec(ctx)
+	return err
+}
+
+func (store *idempotencyKey) Delete(ctx context.Context, orgID string, key string) error {
+	_, err := store.sqlstore.
+		BunDB().
+		NewDelete().
+		Model(new(ruletypes.StorableIdempotencyKey)).
+		Where("org_id = ?", orgID).
+		Where("idempotency_key = ?", key).
+		Exec(ctx)
+	return err
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/SigNoz/signoz/pkg/sqlstore"
+	"github.com/SigNoz/signoz/pkg/types"
 	ruletypes "github.com/SigNoz/signoz/pkg/types/ruletypes"
 	"github.com/SigNoz/signoz/pkg/valuer"
 )
@@ -16,8 +17,14 @@ func NewRuleStore(store sqlstore.SQLStore) ruletypes.RuleStore {
 	return &rule{sqlstore: store}
 }
 
+// CreateRule creates the rule and publishes its creation to the outbox in the same transaction.
 func (r *rule) CreateRule(ctx context.Context, storedRule *ruletypes.Rule, cb func(context.Context, valuer.UUID) error) (valuer.UUID, error) {
-	err := r.sqlstore.RunInTxCtx(ctx, nil, func(ctx context.Context) error {
+	orgID, err := valuer.NewUUID(storedRule.OrgID)
+	if err != nil {
+		return valuer.UUID{}, err
+	}
+
+	err = r.sqlstore.RunInTxCtx(ctx, nil, func(ctx context.Context) error {
 		_, err := r.sqlstore.
 			BunDBCtx(ctx).
 			NewInsert().
@@ -27,6 +34,10 @@ func (r *rule) CreateRule(ctx context.Context, storedRule *ruletypes.Rule, cb fu
 			return err
 		}
 
+		if err := sqlstore.PublishOutboxEvent(ctx, r.sqlstore, orgID, ruletypes.TopicRuleCreated, ruletypes.NewRuleCreatedEvent(storedRule)); err != nil {
+			return err
+		}
+
 		return cb(ctx, storedRule.ID)
 	})
 
@@ -88,6 +99,21 @@ func (r *rule) GetStoredRules(ctx context.Context, orgID string) ([]*ruletypes.R
 	return rules, nil
 }
 
+func (r *rule) GetStoredRulesPage(ctx context.Context, orgID string, pagination *types.Pagination) ([]*ruletypes.Rule, error) {
+	rules := make([]*ruletypes.Rule, 0)
+	err := pagination.Apply(r.sqlstore.
+		BunDB().
+		NewSelect().
+		Model(&rules).
+		Where("org_id = ?", orgID)).
+		Scan(ctx)
+	if err != nil {
+		return rules, err
+	}
+
+	return rules, nil
+}
+
 func (r *rule) GetStoredRule(ctx context.Context, id valuer.UUID) (*ruletypes.Rule, error) {
 	rule := new(ruletypes.Rule)
 	err := r.sqlstore.
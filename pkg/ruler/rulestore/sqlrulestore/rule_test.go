@@ -0,0 +1,60 @@
+package sqlrulestore
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/query-service/utils"
+	"github.com/SigNoz/signoz/pkg/sqlstore"
+	"github.com/SigNoz/signoz/pkg/types"
+	ruletypes "github.com/SigNoz/signoz/pkg/types/ruletypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newRule(orgID valuer.UUID) *ruletypes.Rule {
+	now := time.Now()
+	return &ruletypes.Rule{
+		Identifiable:  types.Identifiable{ID: valuer.GenerateUUID()},
+		TimeAuditable: types.TimeAuditable{CreatedAt: now, UpdatedAt: now},
+		UserAuditable: types.UserAuditable{CreatedBy: "creator@signoz.io", UpdatedBy: "creator@signoz.io"},
+		Data:          `{"alert": "test"}`,
+		OrgID:         orgID.StringValue(),
+	}
+}
+
+func TestCreateRulePublishesOutboxEvent(t *testing.T) {
+	ctx := context.Background()
+	store := utils.NewQueryServiceDBForTests(t)
+
+	organization := types.NewOrganization("test")
+	_, err := store.BunDB().NewInsert().Model(organization).Exec(ctx)
+	require.NoError(t, err)
+
+	ruleStore := NewRuleStore(store)
+	rule := newRule(organization.ID)
+	_, err = ruleStore.CreateRule(ctx, rule, func(context.Context, valuer.UUID) error { return nil })
+	require.NoError(t, err)
+
+	// The event of the rule failing to be created is rolled back along with it.
+	_, err = ruleStore.CreateRule(ctx, newRule(organization.ID), func(context.Context, valuer.UUID) error {
+		return errors.New(errors.TypeInternal, errors.CodeInternal, "failed to set the config")
+	})
+	require.Error(t, err)
+
+	events := make([]*sqlstore.OutboxEvent, 0)
+	require.NoError(t, store.BunDB().NewSelect().Model(&events).Scan(ctx))
+	require.Len(t, events, 1)
+	assert.Equal(t, ruletypes.TopicRuleCreated, events[0].Topic)
+	assert.Equal(t, organization.ID, events[0].OrgID)
+
+	payload := ruletypes.RuleCreatedEvent{}
+	require.NoError(t, json.Unmarshal([]byte(events[0].Payload), &payload))
+	assert.Equal(t, rule.ID.StringValue(), payload.ID)
+	assert.Equal(t, "creator@signoz.io", payload.CreatedBy)
+	assert.JSONEq(t, rule.Data, string(payload.Rule))
+}
@@ -0,0 +1,57 @@
+package secretstore
+
+import (
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/factory"
+)
+
+type Config struct {
+	// Provider is the provider of the secret store.
+	Provider string `mapstructure:"provider"`
+
+	// TTL is the time after which a resolved secret is resolved again. 0 resolves the secrets only once.
+	TTL time.Duration `mapstructure:"ttl"`
+
+	// Env is the config of the env secret store.
+	Env EnvConfig `mapstructure:"env"`
+
+	// File is the config of the file secret store.
+	File FileConfig `mapstructure:"file"`
+}
+
+type EnvConfig struct {
+	// Prefix is the prefix of the environment variables holding the secrets.
+	Prefix string `mapstructure:"prefix"`
+}
+
+type FileConfig struct {
+	// Directory is the directory holding the secrets.
+	Directory string `mapstructure:"directory"`
+}
+
+func NewConfigFactory() factory.ConfigFactory {
+	return factory.NewConfigFactory(factory.MustNewName("secretstore"), newConfig)
+}
+
+func newConfig() factory.Config {
+	return &Config{
+		Provider: "env",
+		TTL:      0,
+		Env: EnvConfig{
+			Prefix: "SIGNOZ_SECRET_",
+		},
+		File: FileConfig{
+			Directory: "/etc/signoz/secrets",
+		},
+	}
+}
+
+func (c Config) Validate() error {
+	if c.TTL < 0 {
+		return errors.New(errors.TypeInvalidInput, errors.CodeInvalidInput, "secretstore::ttl must not be negative")
+	}
+
+	return nil
+}
@@ -0,0 +1,52 @@
+package envsecretstore
+
+import (
+	"context"
+	"os"
+	"strings"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/factory"
+	"github.com/SigNoz/signoz/pkg/secretstore"
+)
+
+type provider struct {
+	settings factory.ScopedProviderSettings
+	prefix   string
+}
+
+func NewFactory() factory.ProviderFactory[secretstore.SecretStore, secretstore.Config] {
+	return factory.NewProviderFactory(factory.MustNewName("env"), New)
+}
+
+func New(ctx context.Context, providerSettings factory.ProviderSettings, config secretstore.Config) (secretstore.SecretStore, error) {
+	settings := factory.NewScopedProviderSettings(providerSettings, "github.com/SigNoz/signoz/pkg/secretstore/envsecretstore")
+
+	return &provider{
+		settings: settings,
+		prefix:   config.Env.Prefix,
+	}, nil
+}
+
+// Get reads the environment variable named after the path and the key, for example SIGNOZ_SECRET_SMTP_PASSWORD for
+// secret://smtp/password.
+func (provider *provider) Get(ctx context.Context, path string, key string) (string, error) {
+	name := provider.name(path, key)
+
+	value, ok := os.LookupEnv(name)
+	if !ok {
+		return "", errors.Newf(errors.TypeNotFound, secretstore.ErrCodeSecretNotFound, "environment variable %s is not set", name)
+	}
+
+	return value, nil
+}
+
+func (provider *provider) name(path string, key string) string {
+	return provider.prefix + strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+			return r
+		}
+
+		return '_'
+	}, strings.ToUpper(path+"_"+key))
+}
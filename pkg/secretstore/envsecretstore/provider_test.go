@@ -0,0 +1,26 @@
+package envsecretstore
+
+import (
+	"context"
+	"testing"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/factory/factorytest"
+	"github.com/SigNoz/signoz/pkg/secretstore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGet(t *testing.T) {
+	t.Setenv("SIGNOZ_SECRET_KV_CLICKHOUSE_EU_PASSWORD", "hunter2")
+
+	store, err := New(context.Background(), factorytest.NewSettings(), secretstore.Config{Env: secretstore.EnvConfig{Prefix: "SIGNOZ_SECRET_"}})
+	require.NoError(t, err)
+
+	value, err := store.Get(context.Background(), "kv/clickhouse-eu", "password")
+	require.NoError(t, err)
+	assert.Equal(t, "hunter2", value)
+
+	_, err = store.Get(context.Background(), "kv/clickhouse-eu", "username")
+	assert.True(t, errors.Ast(err, errors.TypeNotFound))
+}
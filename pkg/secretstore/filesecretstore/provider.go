@@ -0,0 +1,51 @@
+package filesecretstore
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/factory"
+	"github.com/SigNoz/signoz/pkg/secretstore"
+)
+
+type provider struct {
+	settings  factory.ScopedProviderSettings
+	directory string
+}
+
+func NewFactory() factory.ProviderFactory[secretstore.SecretStore, secretstore.Config] {
+	return factory.NewProviderFactory(factory.MustNewName("file"), New)
+}
+
+func New(ctx context.Context, providerSettings factory.ProviderSettings, config secretstore.Config) (secretstore.SecretStore, error) {
+	settings := factory.NewScopedProviderSettings(providerSettings, "github.com/SigNoz/signoz/pkg/secretstore/filesecretstore")
+
+	if config.File.Directory == "" {
+		return nil, errors.New(errors.TypeInvalidInput, errors.CodeInvalidInput, "secretstore::file::directory must be set")
+	}
+
+	return &provider{
+		settings:  settings,
+		directory: config.File.Directory,
+	}, nil
+}
+
+// Get reads the file of the key in the directory of the path, for example <directory>/smtp/password for
+// secret://smtp/password. It is the layout of the secrets mounted as volumes. The trailing newline is trimmed.
+func (provider *provider) Get(ctx context.Context, path string, key string) (string, error) {
+	name := filepath.Join(provider.directory, filepath.FromSlash(path), key)
+
+	data, err := os.ReadFile(name)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", errors.Newf(errors.TypeNotFound, secretstore.ErrCodeSecretNotFound, "secret file %s does not exist", name)
+		}
+
+		return "", errors.Wrapf(err, errors.TypeInternal, errors.CodeInternal, "cannot read secret file %s", name)
+	}
+
+	return strings.TrimRight(string(data), "\r\n"), nil
+}
@@ -0,0 +1,30 @@
+package filesecretstore
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/factory/factorytest"
+	"github.com/SigNoz/signoz/pkg/secretstore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGet(t *testing.T) {
+	directory := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(directory, "kv", "smtp"), 0o700))
+	require.NoError(t, os.WriteFile(filepath.Join(directory, "kv", "smtp", "password"), []byte("hunter2\n"), 0o600))
+
+	store, err := New(context.Background(), factorytest.NewSettings(), secretstore.Config{File: secretstore.FileConfig{Directory: directory}})
+	require.NoError(t, err)
+
+	value, err := store.Get(context.Background(), "kv/smtp", "password")
+	require.NoError(t, err)
+	assert.Equal(t, "hunter2", value)
+
+	_, err = store.Get(context.Background(), "kv/smtp", "username")
+	assert.True(t, errors.Ast(err, errors.TypeNotFound))
+}
@@ -0,0 +1,49 @@
+package secretstore
+
+import (
+	"strings"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+)
+
+const (
+	// ReferenceScheme is the scheme of the config values referencing a secret, such as secret://smtp/password.
+	ReferenceScheme = "secret://"
+)
+
+// Reference is a reference to the key of a secret at a path.
+type Reference struct {
+	Path string
+	Key  string
+}
+
+// IsReference returns whether the config value references a secret.
+func IsReference(input string) bool {
+	return strings.HasPrefix(input, ReferenceScheme)
+}
+
+// ParseReference parses a reference of the form secret://path/key. The path may contain slashes, the key is the
+// last segment.
+func ParseReference(input string) (Reference, error) {
+	if !IsReference(input) {
+		return Reference{}, errors.Newf(errors.TypeInvalidInput, ErrCodeSecretReferenceInvalid, "secret reference must start with %s", ReferenceScheme)
+	}
+
+	idx := strings.LastIndex(input, "/")
+	path, key := strings.Trim(input[len(ReferenceScheme):idx+1], "/"), input[idx+1:]
+	if idx < len(ReferenceScheme) || path == "" || key == "" {
+		return Reference{}, errors.Newf(errors.TypeInvalidInput, ErrCodeSecretReferenceInvalid, "secret reference %s must be of the form %spath/key", input, ReferenceScheme)
+	}
+
+	for _, segment := range append(strings.Split(path, "/"), key) {
+		if segment == "" || segment == "." || segment == ".." {
+			return Reference{}, errors.Newf(errors.TypeInvalidInput, ErrCodeSecretReferenceInvalid, "secret reference %s has an invalid path", input)
+		}
+	}
+
+	return Reference{Path: path, Key: key}, nil
+}
+
+func (reference Reference) String() string {
+	return ReferenceScheme + reference.Path + "/" + reference.Key
+}
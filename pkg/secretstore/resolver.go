@@ -0,0 +1,123 @@
+package secretstore
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/factory"
+)
+
+const (
+	redacted = "[REDACTED]"
+)
+
+// Resolver resolves the config values referencing a secret through the secret store. A nil resolver only
+// resolves the values which do not reference a secret.
+type Resolver struct {
+	settings factory.ScopedProviderSettings
+	store    SecretStore
+	ttl      time.Duration
+}
+
+func NewResolver(providerSettings factory.ProviderSettings, store SecretStore, ttl time.Duration) *Resolver {
+	return &Resolver{
+		settings: factory.NewScopedProviderSettings(providerSettings, "github.com/SigNoz/signoz/pkg/secretstore"),
+		store:    store,
+		ttl:      ttl,
+	}
+}
+
+// Resolve resolves the config value. A value which does not reference a secret is returned as is.
+func (resolver *Resolver) Resolve(ctx context.Context, input string) (*Value, error) {
+	if !IsReference(input) {
+		return &Value{value: input}, nil
+	}
+
+	reference, err := ParseReference(input)
+	if err != nil {
+		return nil, err
+	}
+
+	if resolver == nil || resolver.store == nil {
+		return nil, errors.Newf(errors.TypeInvalidInput, ErrCodeSecretNotFound, "cannot resolve %s without a secret store", reference.String())
+	}
+
+	value := &Value{resolver: resolver, reference: &reference}
+	if err := value.resolve(ctx); err != nil {
+		return nil, err
+	}
+
+	return value, nil
+}
+
+// Value is a config value resolved through the secret store. The secret is resolved again once the ttl of the
+// resolver is over so that the rotated credentials are picked up. The value is redacted when formatted or logged.
+type Value struct {
+	resolver  *Resolver
+	reference *Reference
+
+	mtx        sync.Mutex
+	value      string
+	resolvedAt time.Time
+}
+
+// NewLiteralValue returns a value which does not reference a secret.
+func NewLiteralValue(value string) *Value {
+	return &Value{value: value}
+}
+
+// Get returns the value. When the secret cannot be resolved again, the last resolved value is returned.
+func (value *Value) Get(ctx context.Context) string {
+	if value == nil {
+		return ""
+	}
+
+	if value.reference == nil || value.resolver.ttl <= 0 {
+		return value.value
+	}
+
+	value.mtx.Lock()
+	defer value.mtx.Unlock()
+
+	if time.Since(value.resolvedAt) < value.resolver.ttl {
+		return value.value
+	}
+
+	if err := value.resolve(ctx); err != nil {
+		// Retry on the next call instead of waiting for another ttl.
+		value.resolver.settings.Logger().ErrorContext(ctx, "failed to resolve secret, using the last resolved value", "reference", value.reference.String(), "error", err)
+	}
+
+	return value.value
+}
+
+// resolve must be called with the lock held or before the value is shared.
+func (value *Value) resolve(ctx context.Context) error {
+	resolved, err := value.resolver.store.Get(ctx, value.reference.Path, value.reference.Key)
+	if err != nil {
+		return err
+	}
+
+	if resolved != value.value && !value.resolvedAt.IsZero() {
+		value.resolver.settings.Logger().InfoContext(ctx, "secret changed", "reference", value.reference.String())
+	}
+
+	value.value = resolved
+	value.resolvedAt = time.Now()
+	return nil
+}
+
+func (value *Value) String() string {
+	return redacted
+}
+
+func (value *Value) GoString() string {
+	return redacted
+}
+
+func (value *Value) LogValue() slog.Value {
+	return slog.StringValue(redacted)
+}
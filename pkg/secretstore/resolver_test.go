@@ -0,0 +1,117 @@
+package secretstore
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/factory/factorytest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mapSecretStore map[string]string
+
+func (store mapSecretStore) Get(ctx context.Context, path string, key string) (string, error) {
+	value, ok := store[path+"/"+key]
+	if !ok {
+		return "", errors.Newf(errors.TypeNotFound, ErrCodeSecretNotFound, "secret %s/%s not found", path, key)
+	}
+
+	return value, nil
+}
+
+func TestParseReference(t *testing.T) {
+	testCases := []struct {
+		name      string
+		input     string
+		reference Reference
+		pass      bool
+	}{
+		{name: "PathAndKey", input: "secret://smtp/password", reference: Reference{Path: "smtp", Key: "password"}, pass: true},
+		{name: "NestedPath", input: "secret://kv/clickhouse/eu/password", reference: Reference{Path: "kv/clickhouse/eu", Key: "password"}, pass: true},
+		{name: "NoKey", input: "secret://smtp/", pass: false},
+		{name: "NoPath", input: "secret://password", pass: false},
+		{name: "ParentPath", input: "secret://../smtp/password", pass: false},
+		{name: "ParentKey", input: "secret://smtp/..", pass: false},
+		{name: "NotReference", input: "password", pass: false},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			reference, err := ParseReference(testCase.input)
+			if !testCase.pass {
+				assert.True(t, errors.Ast(err, errors.TypeInvalidInput))
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, testCase.reference, reference)
+			assert.Equal(t, testCase.input, reference.String())
+		})
+	}
+}
+
+func TestResolverResolve(t *testing.T) {
+	ctx := context.Background()
+	store := mapSecretStore{"smtp/password": "hunter2"}
+	resolver := NewResolver(factorytest.NewSettings(), store, 0)
+
+	value, err := resolver.Resolve(ctx, "plain")
+	require.NoError(t, err)
+	assert.Equal(t, "plain", value.Get(ctx))
+
+	value, err = resolver.Resolve(ctx, "secret://smtp/password")
+	require.NoError(t, err)
+	assert.Equal(t, "hunter2", value.Get(ctx))
+
+	_, err = resolver.Resolve(ctx, "secret://smtp/missing")
+	assert.True(t, errors.Ast(err, errors.TypeNotFound))
+
+	// Without a secret store, only the values which are not references are resolved.
+	var nilResolver *Resolver
+	value, err = nilResolver.Resolve(ctx, "plain")
+	require.NoError(t, err)
+	assert.Equal(t, "plain", value.Get(ctx))
+
+	_, err = nilResolver.Resolve(ctx, "secret://smtp/password")
+	assert.Error(t, err)
+}
+
+func TestValueGetRotation(t *testing.T) {
+	ctx := context.Background()
+	store := mapSecretStore{"smtp/password": "hunter2"}
+	resolver := NewResolver(factorytest.NewSettings(), store, time.Hour)
+
+	value, err := resolver.Resolve(ctx, "secret://smtp/password")
+	require.NoError(t, err)
+
+	store["smtp/password"] = "hunter3"
+	assert.Equal(t, "hunter2", value.Get(ctx))
+
+	// The secret is resolved again once the ttl is over.
+	value.resolvedAt = time.Now().Add(-2 * time.Hour)
+	assert.Equal(t, "hunter3", value.Get(ctx))
+
+	// The last resolved value is kept when the secret cannot be resolved again.
+	delete(store, "smtp/password")
+	value.resolvedAt = time.Now().Add(-2 * time.Hour)
+	assert.Equal(t, "hunter3", value.Get(ctx))
+}
+
+func TestValueRedacted(t *testing.T) {
+	ctx := context.Background()
+	resolver := NewResolver(factorytest.NewSettings(), mapSecretStore{"smtp/password": "hunter2"}, 0)
+
+	value, err := resolver.Resolve(ctx, "secret://smtp/password")
+	require.NoError(t, err)
+
+	buf := new(bytes.Buffer)
+	slog.New(slog.NewJSONHandler(buf, nil)).InfoContext(ctx, "resolved", "password", value)
+	assert.NotContains(t, buf.String(), "hunter2")
+	assert.NotContains(t, fmt.Sprintf("%v %+v %#v %s", value, value, value, value), "hunter2")
+}
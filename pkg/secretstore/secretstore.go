@@ -0,0 +1,18 @@
+package secretstore
+
+import (
+	"context"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+)
+
+var (
+	ErrCodeSecretNotFound         = errors.MustNewCode("secret_not_found")
+	ErrCodeSecretReferenceInvalid = errors.MustNewCode("secret_reference_invalid")
+)
+
+// SecretStore is the store from which the credentials referenced in the config are resolved.
+type SecretStore interface {
+	// Get returns the value of the key of the secret at the path.
+	Get(ctx context.Context, path string, key string) (string, error)
+}
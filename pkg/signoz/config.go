@@ -16,10 +16,18 @@ import (
 	"github.com/SigNoz/signoz/pkg/config"
 	"github.com/SigNoz/signoz/pkg/emailing"
 	"github.com/SigNoz/signoz/pkg/factory"
+	"github.com/SigNoz/signoz/pkg/grpcserver"
+	"github.com/SigNoz/signoz/pkg/http/client"
 	"github.com/SigNoz/signoz/pkg/instrumentation"
+	"github.com/SigNoz/signoz/pkg/licensing/overridelicensing"
+	"github.com/SigNoz/signoz/pkg/maintenance"
+	"github.com/SigNoz/signoz/pkg/modules/dashboard"
+	"github.com/SigNoz/signoz/pkg/modules/user"
+	"github.com/SigNoz/signoz/pkg/otlpreceiver"
 	"github.com/SigNoz/signoz/pkg/prometheus"
 	"github.com/SigNoz/signoz/pkg/querier"
 	"github.com/SigNoz/signoz/pkg/ruler"
+	"github.com/SigNoz/signoz/pkg/secretstore"
 	"github.com/SigNoz/signoz/pkg/sharder"
 	"github.com/SigNoz/signoz/pkg/sqlmigration"
 	"github.com/SigNoz/signoz/pkg/sqlmigrator"
@@ -47,6 +55,9 @@ type Config struct {
 	// Cache config
 	Cache cache.Config `mapstructure:"cache"`
 
+	// SecretStore config
+	SecretStore secretstore.Config `mapstructure:"secretstore"`
+
 	// SQLStore config
 	SQLStore sqlstore.Config `mapstructure:"sqlstore"`
 
@@ -59,12 +70,21 @@ type Config struct {
 	// API Server config
 	APIServer apiserver.Config `mapstructure:"apiserver"`
 
+	// HTTP Client config
+	HTTPClient client.Config `mapstructure:"http_client"`
+
+	// GRPC Server config
+	GRPCServer grpcserver.Config `mapstructure:"grpcserver"`
+
 	// TelemetryStore config
 	TelemetryStore telemetrystore.Config `mapstructure:"telemetrystore"`
 
 	// Prometheus config
 	Prometheus prometheus.Config `mapstructure:"prometheus"`
 
+	// OTLPReceiver config
+	OTLPReceiver otlpreceiver.Config `mapstructure:"otlpreceiver"`
+
 	// Alertmanager config
 	Alertmanager alertmanager.Config `mapstructure:"alertmanager" yaml:"alertmanager"`
 
@@ -82,6 +102,24 @@ type Config struct {
 
 	// StatsReporter config
 	StatsReporter statsreporter.Config `mapstructure:"statsreporter"`
+
+	// Dashboard config
+	Dashboard dashboard.Config `mapstructure:"dashboard"`
+
+	// User config
+	User user.Config `mapstructure:"user"`
+
+	// Maintenance config
+	Maintenance maintenance.Config `mapstructure:"maintenance"`
+
+	// FeatureOverrides config
+	FeatureOverrides overridelicensing.Config `mapstructure:"feature_overrides"`
+
+	// Registry config
+	Registry factory.RegistryConfig `mapstructure:"registry"`
+
+	// source is the source the config was loaded from, nil when the config was not created by NewConfig.
+	source *configSource
 }
 
 // DeprecatedFlags are the flags that are deprecated and scheduled for removal.
@@ -100,17 +138,27 @@ func NewConfig(ctx context.Context, resolverConfig config.ResolverConfig, deprec
 		analytics.NewConfigFactory(),
 		web.NewConfigFactory(),
 		cache.NewConfigFactory(),
+		secretstore.NewConfigFactory(),
 		sqlstore.NewConfigFactory(),
+		sqlmigration.NewConfigFactory(),
 		sqlmigrator.NewConfigFactory(),
 		apiserver.NewConfigFactory(),
+		client.NewConfigFactory(),
+		grpcserver.NewConfigFactory(),
 		telemetrystore.NewConfigFactory(),
 		prometheus.NewConfigFactory(),
+		otlpreceiver.NewConfigFactory(),
 		alertmanager.NewConfigFactory(),
 		querier.NewConfigFactory(),
 		ruler.NewConfigFactory(),
 		emailing.NewConfigFactory(),
 		sharder.NewConfigFactory(),
 		statsreporter.NewConfigFactory(),
+		dashboard.NewConfigFactory(),
+		user.NewConfigFactory(),
+		maintenance.NewConfigFactory(),
+		overridelicensing.NewConfigFactory(),
+		factory.NewRegistryConfigFactory(),
 	}
 
 	conf, err := config.New(ctx, resolverConfig, configFactories)
@@ -129,12 +177,17 @@ func NewConfig(ctx context.Context, resolverConfig config.ResolverConfig, deprec
 		return Config{}, err
 	}
 
+	config.source = &configSource{resolverConfig: resolverConfig, deprecatedFlags: deprecatedFlags}
 	return config, nil
 }
 
 func validateConfig(config Config) error {
 	rvConfig := reflect.ValueOf(config)
 	for i := 0; i < rvConfig.NumField(); i++ {
+		if !rvConfig.Type().Field(i).IsExported() {
+			continue
+		}
+
 		factoryConfig, ok := rvConfig.Field(i).Interface().(factory.Config)
 		if !ok {
 			return fmt.Errorf("%q is not of type \"factory.Config\"", rvConfig.Type().Field(i).Name)
@@ -11,12 +11,15 @@ import (
 	"github.com/SigNoz/signoz/pkg/modules/preference/implpreference"
 	"github.com/SigNoz/signoz/pkg/modules/quickfilter"
 	"github.com/SigNoz/signoz/pkg/modules/quickfilter/implquickfilter"
+	"github.com/SigNoz/signoz/pkg/modules/rbac"
+	"github.com/SigNoz/signoz/pkg/modules/rbac/implrbac"
 	"github.com/SigNoz/signoz/pkg/modules/savedview"
 	"github.com/SigNoz/signoz/pkg/modules/savedview/implsavedview"
 	"github.com/SigNoz/signoz/pkg/modules/tracefunnel"
 	"github.com/SigNoz/signoz/pkg/modules/tracefunnel/impltracefunnel"
 	"github.com/SigNoz/signoz/pkg/modules/user"
 	"github.com/SigNoz/signoz/pkg/modules/user/impluser"
+	"github.com/SigNoz/signoz/pkg/querier"
 )
 
 type Handlers struct {
@@ -28,17 +31,19 @@ type Handlers struct {
 	Dashboard    dashboard.Handler
 	QuickFilter  quickfilter.Handler
 	TraceFunnel  tracefunnel.Handler
+	RBAC         rbac.Handler
 }
 
-func NewHandlers(modules Modules) Handlers {
+func NewHandlers(modules Modules, querier querier.Querier) Handlers {
 	return Handlers{
 		Organization: implorganization.NewHandler(modules.OrgGetter, modules.OrgSetter),
 		Preference:   implpreference.NewHandler(modules.Preference),
 		User:         impluser.NewHandler(modules.User),
 		SavedView:    implsavedview.NewHandler(modules.SavedView),
 		Apdex:        implapdex.NewHandler(modules.Apdex),
-		Dashboard:    impldashboard.NewHandler(modules.Dashboard),
+		Dashboard:    impldashboard.NewHandler(modules.Dashboard, querier),
 		QuickFilter:  implquickfilter.NewHandler(modules.QuickFilter),
 		TraceFunnel:  impltracefunnel.NewHandler(modules.TraceFunnel),
+		RBAC:         implrbac.NewHandler(modules.RBAC),
 	}
 }
@@ -15,6 +15,8 @@ import (
 	"github.com/SigNoz/signoz/pkg/modules/preference/implpreference"
 	"github.com/SigNoz/signoz/pkg/modules/quickfilter"
 	"github.com/SigNoz/signoz/pkg/modules/quickfilter/implquickfilter"
+	"github.com/SigNoz/signoz/pkg/modules/rbac"
+	"github.com/SigNoz/signoz/pkg/modules/rbac/implrbac"
 	"github.com/SigNoz/signoz/pkg/modules/savedview"
 	"github.com/SigNoz/signoz/pkg/modules/savedview/implsavedview"
 	"github.com/SigNoz/signoz/pkg/modules/tracefunnel"
@@ -36,6 +38,7 @@ type Modules struct {
 	Dashboard   dashboard.Module
 	QuickFilter quickfilter.Module
 	TraceFunnel tracefunnel.Module
+	RBAC        rbac.Module
 }
 
 func NewModules(
@@ -49,7 +52,7 @@ func NewModules(
 ) Modules {
 	quickfilter := implquickfilter.NewModule(implquickfilter.NewStore(sqlstore))
 	orgSetter := implorganization.NewSetter(implorganization.NewStore(sqlstore), alertmanager, quickfilter)
-	user := impluser.NewModule(impluser.NewStore(sqlstore, providerSettings), jwt, emailing, providerSettings, orgSetter, analytics)
+	user := impluser.NewModule(impluser.NewStore(sqlstore, providerSettings), impluser.NewRefreshTokenStore(sqlstore), jwt, emailing, providerSettings, orgSetter, analytics)
 	return Modules{
 		OrgGetter:   orgGetter,
 		OrgSetter:   orgSetter,
@@ -60,5 +63,6 @@ func NewModules(
 		User:        user,
 		QuickFilter: quickfilter,
 		TraceFunnel: impltracefunnel.NewModule(impltracefunnel.NewStore(sqlstore)),
+		RBAC:        implrbac.NewModule(implrbac.NewStore(sqlstore)),
 	}
 }
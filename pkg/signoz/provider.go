@@ -2,6 +2,7 @@ package signoz
 
 import (
 	"github.com/SigNoz/signoz/pkg/alertmanager"
+	"github.com/SigNoz/signoz/pkg/alertmanager/alertmanagernotify"
 	"github.com/SigNoz/signoz/pkg/alertmanager/legacyalertmanager"
 	"github.com/SigNoz/signoz/pkg/alertmanager/signozalertmanager"
 	"github.com/SigNoz/signoz/pkg/analytics"
@@ -14,6 +15,7 @@ import (
 	"github.com/SigNoz/signoz/pkg/emailing/noopemailing"
 	"github.com/SigNoz/signoz/pkg/emailing/smtpemailing"
 	"github.com/SigNoz/signoz/pkg/factory"
+	"github.com/SigNoz/signoz/pkg/maintenance"
 	"github.com/SigNoz/signoz/pkg/modules/organization"
 	"github.com/SigNoz/signoz/pkg/prometheus"
 	"github.com/SigNoz/signoz/pkg/prometheus/clickhouseprometheus"
@@ -21,6 +23,9 @@ import (
 	"github.com/SigNoz/signoz/pkg/querier/signozquerier"
 	"github.com/SigNoz/signoz/pkg/ruler"
 	"github.com/SigNoz/signoz/pkg/ruler/signozruler"
+	"github.com/SigNoz/signoz/pkg/secretstore"
+	"github.com/SigNoz/signoz/pkg/secretstore/envsecretstore"
+	"github.com/SigNoz/signoz/pkg/secretstore/filesecretstore"
 	"github.com/SigNoz/signoz/pkg/sharder"
 	"github.com/SigNoz/signoz/pkg/sharder/noopsharder"
 	"github.com/SigNoz/signoz/pkg/sharder/singlesharder"
@@ -49,8 +54,8 @@ func NewAnalyticsProviderFactories() factory.NamedMap[factory.ProviderFactory[an
 
 func NewCacheProviderFactories() factory.NamedMap[factory.ProviderFactory[cache.Cache, cache.Config]] {
 	return factory.MustNewNamedMap(
-		memorycache.NewFactory(),
-		rediscache.NewFactory(),
+		factory.NewInstrumentedProviderFactory(memorycache.NewFactory(), cache.Instrument),
+		factory.NewInstrumentedProviderFactory(rediscache.NewFactory(), cache.Instrument),
 	)
 }
 
@@ -68,7 +73,7 @@ func NewSQLStoreProviderFactories() factory.NamedMap[factory.ProviderFactory[sql
 	)
 }
 
-func NewSQLMigrationProviderFactories(sqlstore sqlstore.SQLStore) factory.NamedMap[factory.ProviderFactory[sqlmigration.SQLMigration, sqlmigration.Config]] {
+func NewSQLMigrationProviderFactories(sqlstore sqlstore.SQLStore, encryptor *sqlstore.Encryptor) factory.NamedMap[factory.ProviderFactory[sqlmigration.SQLMigration, sqlmigration.Config]] {
 	return factory.MustNewNamedMap(
 		sqlmigration.NewAddDataMigrationsFactory(),
 		sqlmigration.NewAddOrganizationFactory(),
@@ -110,25 +115,58 @@ func NewSQLMigrationProviderFactories(sqlstore sqlstore.SQLStore) factory.NamedM
 		sqlmigration.NewUpdateDashboardFactory(sqlstore),
 		sqlmigration.NewDropFeatureSetFactory(),
 		sqlmigration.NewDropDeprecatedTablesFactory(),
+		sqlmigration.NewAddLicenseAuditFactory(sqlstore),
+		sqlmigration.NewAddAlertmanagerDeadLetterFactory(sqlstore),
+		sqlmigration.NewAddRefreshTokenFactory(sqlstore),
+		sqlmigration.NewAddDashboardSoftDeleteFactory(sqlstore),
+		sqlmigration.NewAddAPIKeyScopesFactory(sqlstore),
+		sqlmigration.NewAddRBACFactory(sqlstore),
+		sqlmigration.NewAddDashboardPublicLinkFactory(sqlstore),
+		sqlmigration.NewAddOutboxEventFactory(sqlstore),
+		sqlmigration.NewAddMigrationChecksumFactory(sqlstore),
+		sqlmigration.NewAddRuleIdempotencyKeyFactory(sqlstore),
+		sqlmigration.NewAddMaintenanceFactory(sqlstore),
+		sqlmigration.NewAddSamlAssertionFactory(sqlstore),
+		sqlmigration.NewAddBackfillCheckpointFactory(sqlstore),
+		sqlmigration.NewAddDashboardTitleFactory(sqlstore),
+		sqlmigration.NewAddChannelSigningSecretFactory(sqlstore),
+		sqlmigration.NewAddJobRunFactory(sqlstore),
+		sqlmigration.NewAddFeatureOverrideFactory(sqlstore),
+		sqlmigration.NewAddTelemetryDeletionFactory(sqlstore),
+		sqlmigration.NewAddChannelPluginFactory(sqlstore),
+		sqlmigration.NewEncryptSensitiveColumnsFactory(encryptor),
 	)
 }
 
-func NewTelemetryStoreProviderFactories() factory.NamedMap[factory.ProviderFactory[telemetrystore.TelemetryStore, telemetrystore.Config]] {
+func NewSecretStoreProviderFactories() factory.NamedMap[factory.ProviderFactory[secretstore.SecretStore, secretstore.Config]] {
 	return factory.MustNewNamedMap(
-		clickhousetelemetrystore.NewFactory(telemetrystorehook.NewSettingsFactory(), telemetrystorehook.NewLoggingFactory()),
+		envsecretstore.NewFactory(),
+		filesecretstore.NewFactory(),
 	)
 }
 
-func NewPrometheusProviderFactories(telemetryStore telemetrystore.TelemetryStore) factory.NamedMap[factory.ProviderFactory[prometheus.Prometheus, prometheus.Config]] {
+func NewTelemetryStoreProviderFactories(secretResolver *secretstore.Resolver) factory.NamedMap[factory.ProviderFactory[telemetrystore.TelemetryStore, telemetrystore.Config]] {
 	return factory.MustNewNamedMap(
-		clickhouseprometheus.NewFactory(telemetryStore),
+		clickhousetelemetrystore.NewFactory(secretResolver, nil, telemetrystorehook.NewSettingsFactory(), telemetrystorehook.NewLoggingFactory(), telemetrystorehook.NewSlowQueryFactory()),
 	)
 }
 
-func NewAlertmanagerProviderFactories(sqlstore sqlstore.SQLStore, orgGetter organization.Getter) factory.NamedMap[factory.ProviderFactory[alertmanager.Alertmanager, alertmanager.Config]] {
+func NewPrometheusProviderFactories(telemetryStore telemetrystore.TelemetryStore, cache cache.Cache) factory.NamedMap[factory.ProviderFactory[prometheus.Prometheus, prometheus.Config]] {
 	return factory.MustNewNamedMap(
-		legacyalertmanager.NewFactory(sqlstore, orgGetter),
-		signozalertmanager.NewFactory(sqlstore, orgGetter),
+		clickhouseprometheus.NewFactory(telemetryStore, cache),
+	)
+}
+
+// NewReceiverPluginProviderFactories returns the factories of the receiver plugins of the alertmanager. None is
+// built in, the plugins are added to the returned factories before creating signoz.
+func NewReceiverPluginProviderFactories() factory.NamedMap[factory.ProviderFactory[alertmanagernotify.Receiver, alertmanagernotify.ReceiverConfig]] {
+	return factory.MustNewNamedMap[factory.ProviderFactory[alertmanagernotify.Receiver, alertmanagernotify.ReceiverConfig]]()
+}
+
+func NewAlertmanagerProviderFactories(sqlstore sqlstore.SQLStore, orgGetter organization.Getter, maintenance *maintenance.Maintenance, encryptor *sqlstore.Encryptor, receiverPluginFactories factory.NamedMap[factory.ProviderFactory[alertmanagernotify.Receiver, alertmanagernotify.ReceiverConfig]]) factory.NamedMap[factory.ProviderFactory[alertmanager.Alertmanager, alertmanager.Config]] {
+	return factory.MustNewNamedMap(
+		legacyalertmanager.NewFactory(sqlstore, orgGetter, encryptor),
+		signozalertmanager.NewFactory(sqlstore, orgGetter, maintenance, encryptor, receiverPluginFactories),
 	)
 }
 
@@ -138,10 +176,10 @@ func NewRulerProviderFactories(sqlstore sqlstore.SQLStore) factory.NamedMap[fact
 	)
 }
 
-func NewEmailingProviderFactories() factory.NamedMap[factory.ProviderFactory[emailing.Emailing, emailing.Config]] {
+func NewEmailingProviderFactories(secretResolver *secretstore.Resolver) factory.NamedMap[factory.ProviderFactory[emailing.Emailing, emailing.Config]] {
 	return factory.MustNewNamedMap(
 		noopemailing.NewFactory(),
-		smtpemailing.NewFactory(),
+		smtpemailing.NewFactory(secretResolver),
 	)
 }
 
@@ -159,8 +197,8 @@ func NewStatsReporterProviderFactories(telemetryStore telemetrystore.TelemetrySt
 	)
 }
 
-func NewQuerierProviderFactories(telemetryStore telemetrystore.TelemetryStore, prometheus prometheus.Prometheus, cache cache.Cache) factory.NamedMap[factory.ProviderFactory[querier.Querier, querier.Config]] {
+func NewQuerierProviderFactories(telemetryStore telemetrystore.TelemetryStore, prometheus prometheus.Prometheus, cache cache.Cache, negative cache.Negative) factory.NamedMap[factory.ProviderFactory[querier.Querier, querier.Config]] {
 	return factory.MustNewNamedMap(
-		signozquerier.NewFactory(telemetryStore, prometheus, cache),
+		signozquerier.NewFactory(telemetryStore, prometheus, cache, negative),
 	)
 }
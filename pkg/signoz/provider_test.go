@@ -5,6 +5,8 @@ import (
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/SigNoz/signoz/pkg/analytics"
+	"github.com/SigNoz/signoz/pkg/factory/factorytest"
+	"github.com/SigNoz/signoz/pkg/maintenance"
 	"github.com/SigNoz/signoz/pkg/modules/organization/implorganization"
 	"github.com/SigNoz/signoz/pkg/sqlstore"
 	"github.com/SigNoz/signoz/pkg/sqlstore/sqlstoretest"
@@ -27,25 +29,34 @@ func TestNewProviderFactories(t *testing.T) {
 		NewWebProviderFactories()
 	})
 
+	assert.NotPanics(t, func() {
+		NewSecretStoreProviderFactories()
+	})
+
 	assert.NotPanics(t, func() {
 		NewSQLStoreProviderFactories()
 	})
 
 	assert.NotPanics(t, func() {
-		NewTelemetryStoreProviderFactories()
+		NewTelemetryStoreProviderFactories(nil)
 	})
 
 	assert.NotPanics(t, func() {
-		NewSQLMigrationProviderFactories(sqlstoretest.New(sqlstore.Config{Provider: "sqlite"}, sqlmock.QueryMatcherEqual))
+		NewSQLMigrationProviderFactories(sqlstoretest.New(sqlstore.Config{Provider: "sqlite"}, sqlmock.QueryMatcherEqual), nil)
 	})
 
 	assert.NotPanics(t, func() {
-		NewPrometheusProviderFactories(telemetrystoretest.New(telemetrystore.Config{Provider: "clickhouse"}, sqlmock.QueryMatcherEqual))
+		NewPrometheusProviderFactories(telemetrystoretest.New(telemetrystore.Config{Provider: "clickhouse"}, sqlmock.QueryMatcherEqual), nil)
 	})
 
 	assert.NotPanics(t, func() {
-		orgGetter := implorganization.NewGetter(implorganization.NewStore(sqlstoretest.New(sqlstore.Config{Provider: "sqlite"}, sqlmock.QueryMatcherEqual)), nil)
-		NewAlertmanagerProviderFactories(sqlstoretest.New(sqlstore.Config{Provider: "sqlite"}, sqlmock.QueryMatcherEqual), orgGetter)
+		store := sqlstoretest.New(sqlstore.Config{Provider: "sqlite"}, sqlmock.QueryMatcherEqual)
+		orgGetter := implorganization.NewGetter(implorganization.NewStore(store), nil)
+		NewAlertmanagerProviderFactories(store, orgGetter, maintenance.New(factorytest.NewSettings(), maintenance.Config{}, store), nil, NewReceiverPluginProviderFactories())
+	})
+
+	assert.NotPanics(t, func() {
+		NewReceiverPluginProviderFactories()
 	})
 
 	assert.NotPanics(t, func() {
@@ -53,7 +64,7 @@ func TestNewProviderFactories(t *testing.T) {
 	})
 
 	assert.NotPanics(t, func() {
-		NewEmailingProviderFactories()
+		NewEmailingProviderFactories(nil)
 	})
 
 	assert.NotPanics(t, func() {
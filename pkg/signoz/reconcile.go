@@ -0,0 +1,381 @@
+package signoz
+
+import (
+	"context"
+	"encoding"
+	"fmt"
+	"net/url"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/config"
+	"github.com/SigNoz/signoz/pkg/factory"
+	"github.com/SigNoz/signoz/pkg/secretstore"
+	commoncfg "github.com/prometheus/common/config"
+)
+
+const (
+	redactedConfigValue string = "[REDACTED]"
+	// redactedURLValue is the value of the secrets embedded in a string, which is the one of url.URL.Redacted.
+	redactedURLValue string = "xxxxx"
+)
+
+var (
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	durationType      = reflect.TypeOf(time.Duration(0))
+	urlType           = reflect.TypeOf(url.URL{})
+	secretType        = reflect.TypeOf(commoncfg.Secret(""))
+	// configPairRegexp matches the key=value pairs of a string, the value being quoted or ending at a separator.
+	configPairRegexp = regexp.MustCompile(`(^|[\s?&;])([A-Za-z_][A-Za-z0-9_.\-]*)(\s*=\s*)('(?:\\.|[^'\\])*'|[^\s&;]*)`)
+)
+
+// configSource is the source a config was loaded from, so that it can be loaded again.
+type configSource struct {
+	resolverConfig  config.ResolverConfig
+	deprecatedFlags DeprecatedFlags
+}
+
+// ConfigDrift is a value of the config which differs between the runtime and the config loaded from its sources.
+type ConfigDrift struct {
+	// Path is the path of the value, for example sqlstore::sqlite::path.
+	Path string `json:"path"`
+	// Runtime is the value SigNoz runs with.
+	Runtime any `json:"runtime"`
+	// Loaded is the value of the config loaded from its sources.
+	Loaded any `json:"loaded"`
+	// RestartRequired is true when the loaded value differs from the value SigNoz started with, the config being
+	// only applied when SigNoz starts. It is false when the runtime value was changed at runtime, such as the
+	// maintenance mode.
+	RestartRequired bool `json:"restartRequired"`
+}
+
+// ProviderConfig is the runtime config of a provider along with its drifts from the loaded config.
+type ProviderConfig struct {
+	// Name is the name of the config of the provider, for example sqlstore.
+	Name string `json:"name"`
+	// Provider is the provider in use, if the config selects one.
+	Provider string `json:"provider,omitempty"`
+	// Config are the runtime values of the config keyed by their path, the secrets being redacted.
+	Config map[string]any `json:"config"`
+	// Drifts are the values of the config which differ from the loaded config.
+	Drifts []ConfigDrift `json:"drifts"`
+	// Error is the reason why the provider could not apply its config, such as the cache being unavailable.
+	Error string `json:"error,omitempty"`
+}
+
+// ConfigReconciliation compares the config SigNoz runs with against the config loaded from its sources.
+type ConfigReconciliation struct {
+	// InSync is true when the runtime config matches the loaded config.
+	InSync bool `json:"inSync"`
+	// Providers are the configs of the providers, in the order of the config.
+	Providers []ProviderConfig `json:"providers"`
+	// Error is the reason why the config could not be loaded from its sources, such as it being invalid.
+	Error string `json:"error,omitempty"`
+}
+
+// configLeaf is a value of a flattened config.
+type configLeaf struct {
+	value     any
+	sensitive bool
+}
+
+// ReconcileConfig loads the config from its sources again and compares it against the config SigNoz runs with, which
+// is the config SigNoz started with overridden by the effective config of the providers. The secrets are redacted
+// from the values of the reconciliation.
+func (signoz *SigNoz) ReconcileConfig(ctx context.Context) ConfigReconciliation {
+	started := flattenConfig(signoz.config)
+	runtime := flattenConfig(signoz.config)
+
+	errs := map[string]string{}
+	for name, provider := range signoz.effectiveConfigs() {
+		values, err := provider.EffectiveConfig(ctx)
+		if err != nil {
+			errs[name] = err.Error()
+		}
+
+		for path, value := range values {
+			path = joinConfigPath(name, path)
+			sensitive := runtime[path].sensitive || slices.ContainsFunc(strings.Split(path, "::"), isSensitiveConfigKey)
+			flattenConfigValue(path, reflect.ValueOf(value), sensitive, runtime)
+		}
+	}
+
+	reconciliation := ConfigReconciliation{InSync: true, Providers: []ProviderConfig{}}
+
+	var loaded map[string]configLeaf
+	if signoz.config.source == nil {
+		reconciliation.InSync = false
+		reconciliation.Error = "the config was not loaded from any source"
+	} else if loadedConfig, err := NewConfig(ctx, signoz.config.source.resolverConfig, signoz.config.source.deprecatedFlags); err != nil {
+		reconciliation.InSync = false
+		reconciliation.Error = err.Error()
+	} else {
+		loaded = flattenConfig(loadedConfig)
+	}
+
+	rtConfig := reflect.TypeOf(signoz.config)
+	for i := 0; i < rtConfig.NumField(); i++ {
+		field := rtConfig.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name := configFieldName(field)
+		providerConfig := ProviderConfig{Name: name, Config: map[string]any{}, Drifts: []ConfigDrift{}, Error: errs[name]}
+		if providerConfig.Error != "" {
+			reconciliation.InSync = false
+		}
+
+		if provider, ok := runtime[name+"::provider"].value.(string); ok {
+			providerConfig.Provider = provider
+		}
+
+		paths := []string{}
+		for path := range runtime {
+			if strings.HasPrefix(path, name+"::") || path == name {
+				paths = append(paths, path)
+			}
+		}
+
+		for path := range loaded {
+			if _, ok := runtime[path]; !ok && (strings.HasPrefix(path, name+"::") || path == name) {
+				paths = append(paths, path)
+			}
+		}
+		slices.Sort(paths)
+
+		for _, path := range paths {
+			runtimeLeaf, inRuntime := runtime[path]
+			if inRuntime {
+				providerConfig.Config[path] = runtimeLeaf.redacted()
+			}
+
+			if loaded == nil {
+				continue
+			}
+
+			loadedLeaf := loaded[path]
+			if inRuntime && reflect.DeepEqual(runtimeLeaf.value, loadedLeaf.value) {
+				continue
+			}
+
+			providerConfig.Drifts = append(providerConfig.Drifts, ConfigDrift{
+				Path:            path,
+				Runtime:         runtimeLeaf.redacted(),
+				Loaded:          loadedLeaf.redacted(),
+				RestartRequired: !reflect.DeepEqual(started[path].value, loadedLeaf.value),
+			})
+			reconciliation.InSync = false
+		}
+
+		reconciliation.Providers = append(reconciliation.Providers, providerConfig)
+	}
+
+	return reconciliation
+}
+
+// effectiveConfigs returns the providers reporting their effective config keyed by the name of their config.
+func (signoz *SigNoz) effectiveConfigs() map[string]factory.EffectiveConfig {
+	providers := map[string]any{
+		"cache":          signoz.Cache,
+		"web":            signoz.Web,
+		"sqlstore":       signoz.SQLStore,
+		"telemetrystore": signoz.TelemetryStore,
+		"prometheus":     signoz.Prometheus,
+		"alertmanager":   signoz.Alertmanager,
+		"emailing":       signoz.Emailing,
+		"sharder":        signoz.Sharder,
+		"statsreporter":  signoz.StatsReporter,
+	}
+
+	// The nil pointers would implement the interface.
+	if signoz.Maintenance != nil {
+		providers["maintenance"] = signoz.Maintenance
+	}
+
+	effectiveConfigs := map[string]factory.EffectiveConfig{}
+	for name, provider := range providers {
+		if effectiveConfig, ok := provider.(factory.EffectiveConfig); ok {
+			effectiveConfigs[name] = effectiveConfig
+		}
+	}
+
+	return effectiveConfigs
+}
+
+func (leaf configLeaf) redacted() any {
+	value, ok := leaf.value.(string)
+	if !leaf.sensitive || leaf.value == nil {
+		if ok {
+			return redactString(value)
+		}
+
+		return leaf.value
+	}
+
+	if ok && (value == "" || secretstore.IsReference(value)) {
+		return value
+	}
+
+	// The empty maps and slices are kept so that the unset secrets are told apart.
+	if rv := reflect.ValueOf(leaf.value); (rv.Kind() == reflect.Map || rv.Kind() == reflect.Slice) && rv.Len() == 0 {
+		return leaf.value
+	}
+
+	return redactedConfigValue
+}
+
+// flattenConfig returns the values of the config keyed by their path. The values are not redacted so that the
+// changes of the secrets are reported as drifts, they are redacted once reported.
+func flattenConfig(config Config) map[string]configLeaf {
+	leaves := map[string]configLeaf{}
+	flattenConfigValue("", reflect.ValueOf(config), false, leaves)
+	return leaves
+}
+
+func flattenConfigValue(path string, value reflect.Value, sensitive bool, leaves map[string]configLeaf) {
+	for value.IsValid() && (value.Kind() == reflect.Pointer || value.Kind() == reflect.Interface) {
+		if value.IsNil() {
+			leaves[path] = configLeaf{value: nil, sensitive: sensitive}
+			return
+		}
+		value = value.Elem()
+	}
+
+	if !value.IsValid() {
+		leaves[path] = configLeaf{value: nil, sensitive: sensitive}
+		return
+	}
+
+	switch {
+	case value.Type() == secretType:
+		leaves[path] = configLeaf{value: value.String(), sensitive: true}
+		return
+	case value.Type() == durationType:
+		leaves[path] = configLeaf{value: time.Duration(value.Int()).String(), sensitive: sensitive}
+		return
+	case value.Type() == urlType:
+		u := value.Interface().(url.URL)
+		leaves[path] = configLeaf{value: u.String(), sensitive: sensitive}
+		return
+	case value.Type().Implements(textMarshalerType) && value.Kind() != reflect.Struct:
+		text, err := value.Interface().(encoding.TextMarshaler).MarshalText()
+		if err == nil {
+			leaves[path] = configLeaf{value: string(text), sensitive: sensitive}
+			return
+		}
+	}
+
+	switch value.Kind() {
+	case reflect.Struct:
+		for i := 0; i < value.NumField(); i++ {
+			field := value.Type().Field(i)
+			// The fields collecting the unknown keys are not part of the config.
+			if !field.IsExported() || strings.HasSuffix(field.Tag.Get("mapstructure"), ",remain") {
+				continue
+			}
+
+			name := configFieldName(field)
+			if name == "" {
+				// The fields squashed into their parent share its path.
+				flattenConfigValue(path, value.Field(i), sensitive, leaves)
+				continue
+			}
+
+			flattenConfigValue(joinConfigPath(path, name), value.Field(i), sensitive || isSensitiveConfigKey(name), leaves)
+		}
+	case reflect.Map:
+		if value.Len() == 0 || value.Type().Key().Kind() != reflect.String {
+			leaves[path] = configLeaf{value: value.Interface(), sensitive: sensitive}
+			return
+		}
+
+		for _, key := range value.MapKeys() {
+			flattenConfigValue(joinConfigPath(path, key.String()), value.MapIndex(key), sensitive || isSensitiveConfigKey(key.String()), leaves)
+		}
+	case reflect.Slice, reflect.Array:
+		if value.Kind() == reflect.Slice && value.IsNil() {
+			leaves[path] = configLeaf{value: nil, sensitive: sensitive}
+			return
+		}
+
+		if value.Len() == 0 {
+			leaves[path] = configLeaf{value: []any{}, sensitive: sensitive}
+			return
+		}
+
+		for i := 0; i < value.Len(); i++ {
+			flattenConfigValue(fmt.Sprintf("%s[%d]", path, i), value.Index(i), sensitive, leaves)
+		}
+	case reflect.String:
+		leaves[path] = configLeaf{value: value.String(), sensitive: sensitive}
+	case reflect.Func, reflect.Chan, reflect.UnsafePointer:
+	default:
+		leaves[path] = configLeaf{value: value.Interface(), sensitive: sensitive}
+	}
+}
+
+// configFieldName returns the name of the field in the config, empty when the field is squashed into its parent.
+func configFieldName(field reflect.StructField) string {
+	for _, tag := range []string{"mapstructure", "yaml"} {
+		name, options, _ := strings.Cut(field.Tag.Get(tag), ",")
+		if name != "" {
+			return name
+		}
+
+		if strings.Contains(options, "squash") || strings.Contains(options, "inline") {
+			return ""
+		}
+	}
+
+	return strings.ToLower(field.Name)
+}
+
+func joinConfigPath(path string, name string) string {
+	if path == "" {
+		return name
+	}
+
+	return path + "::" + name
+}
+
+// isSensitiveConfigKey returns whether the values under the key are secrets.
+func isSensitiveConfigKey(key string) bool {
+	key = strings.ToLower(key)
+	for _, word := range []string{"password", "secret", "token", "credential"} {
+		if strings.Contains(key, word) {
+			return true
+		}
+	}
+
+	return key == "key" || strings.HasSuffix(key, "_key") || strings.HasSuffix(key, "apikey")
+}
+
+// redactString redacts the secrets embedded in the value, such as the password of a dsn. The password of the userinfo
+// of a url is redacted, along with the values of the sensitive keys of the key=value pairs, such as the ones of a
+// query string or of a postgres key=value dsn.
+func redactString(value string) string {
+	if strings.Contains(value, "://") {
+		if u, err := url.Parse(value); err == nil && u.User != nil {
+			if _, ok := u.User.Password(); ok {
+				value = u.Redacted()
+			}
+		}
+	}
+
+	if !strings.Contains(value, "=") {
+		return value
+	}
+
+	return configPairRegexp.ReplaceAllStringFunc(value, func(pair string) string {
+		match := configPairRegexp.FindStringSubmatch(pair)
+		if !isSensitiveConfigKey(match[2]) {
+			return pair
+		}
+
+		return match[1] + match[2] + match[3] + redactedURLValue
+	})
+}
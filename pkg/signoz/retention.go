@@ -0,0 +1,48 @@
+package signoz
+
+import (
+	"context"
+
+	"github.com/SigNoz/signoz/pkg/factory"
+	"github.com/SigNoz/signoz/pkg/telemetrylogs"
+	"github.com/SigNoz/signoz/pkg/telemetrymetrics"
+	"github.com/SigNoz/signoz/pkg/telemetrystore"
+	"github.com/SigNoz/signoz/pkg/telemetrytraces"
+	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
+)
+
+const (
+	metricsTimeExpression  string = "toDateTime(toUInt32(unix_milli / 1000), 'UTC')"
+	resourceTimeExpression string = "toDateTime(seen_at_ts_bucket_start) + toIntervalSecond(1800)"
+)
+
+// retentionTables are the tables whose TTL is managed by telemetrystore::retention.
+var retentionTables = []telemetrystore.RetentionTable{
+	{Signal: telemetrytypes.SignalTraces, Name: telemetrytraces.DBName + "." + telemetrytraces.SpanIndexV3LocalTableName, TimeExpression: "toDateTime(timestamp)"},
+	{Signal: telemetrytypes.SignalTraces, Name: telemetrytraces.DBName + ".traces_v3_resource", TimeExpression: resourceTimeExpression},
+	{Signal: telemetrytypes.SignalLogs, Name: telemetrylogs.DBName + "." + telemetrylogs.LogsV2LocalTableName, TimeExpression: "toDateTime(timestamp / 1000000000)"},
+	{Signal: telemetrytypes.SignalLogs, Name: telemetrylogs.DBName + ".logs_v2_resource", TimeExpression: resourceTimeExpression},
+	{Signal: telemetrytypes.SignalMetrics, Name: telemetrymetrics.DBName + "." + telemetrymetrics.SamplesV4LocalTableName, TimeExpression: metricsTimeExpression},
+	{Signal: telemetrytypes.SignalMetrics, Name: telemetrymetrics.DBName + "." + telemetrymetrics.SamplesV4Agg5mLocalTableName, TimeExpression: metricsTimeExpression},
+	{Signal: telemetrytypes.SignalMetrics, Name: telemetrymetrics.DBName + "." + telemetrymetrics.SamplesV4Agg30mLocalTableName, TimeExpression: metricsTimeExpression},
+	{Signal: telemetrytypes.SignalMetrics, Name: telemetrymetrics.DBName + "." + telemetrymetrics.ExpHistogramLocalTableName, TimeExpression: metricsTimeExpression},
+	{Signal: telemetrytypes.SignalMetrics, Name: telemetrymetrics.DBName + "." + telemetrymetrics.TimeseriesV4LocalTableName, TimeExpression: metricsTimeExpression},
+	{Signal: telemetrytypes.SignalMetrics, Name: telemetrymetrics.DBName + "." + telemetrymetrics.TimeseriesV46hrsLocalTableName, TimeExpression: metricsTimeExpression},
+	{Signal: telemetrytypes.SignalMetrics, Name: telemetrymetrics.DBName + "." + telemetrymetrics.TimeseriesV41dayLocalTableName, TimeExpression: metricsTimeExpression},
+	{Signal: telemetrytypes.SignalMetrics, Name: telemetrymetrics.DBName + "." + telemetrymetrics.TimeseriesV41weekLocalTableName, TimeExpression: metricsTimeExpression},
+}
+
+// newRetentionJob returns the job applying the retention of the telemetry store at startup and at the interval of
+// the config. A failed run keeps the data for the retention previously set.
+func newRetentionJob(retention telemetrystore.Retention, config telemetrystore.RetentionConfig) factory.Job {
+	return factory.NewJob(
+		factory.MustNewName("telemetryretention"),
+		config.Interval,
+		func(ctx context.Context) error {
+			return retention.Apply(ctx, retentionTables)
+		},
+		factory.WithJitter(config.Interval/10),
+		factory.WithSingleton(),
+		factory.WithRunAtStart(),
+	)
+}
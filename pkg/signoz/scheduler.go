@@ -0,0 +1,89 @@
+package signoz
+
+import (
+	"github.com/SigNoz/signoz/pkg/alertmanager"
+	"github.com/SigNoz/signoz/pkg/alertmanager/alertmanagerstore/sqlalertmanagerstore"
+	"github.com/SigNoz/signoz/pkg/cache"
+	"github.com/SigNoz/signoz/pkg/cache/retrycache"
+	"github.com/SigNoz/signoz/pkg/factory"
+	"github.com/SigNoz/signoz/pkg/http/client"
+	"github.com/SigNoz/signoz/pkg/maintenance"
+	"github.com/SigNoz/signoz/pkg/modules/dashboard/impldashboard"
+	"github.com/SigNoz/signoz/pkg/modules/user/impluser"
+	"github.com/SigNoz/signoz/pkg/sqlstore"
+	"github.com/SigNoz/signoz/pkg/telemetrystore"
+)
+
+// NewJobs returns the recurring background jobs. New recurring work should be added here rather than run in a
+// goroutine or a service of its own. The jobs write to the stores, hence they are paused in maintenance mode, except
+// for the retries of the cache.
+func NewJobs(config Config, store sqlstore.SQLStore, telemetryStore telemetrystore.TelemetryStore, cache cache.Cache, am alertmanager.Alertmanager, modules Modules, outboxRelay *sqlstore.OutboxRelay, encryptor *sqlstore.Encryptor, maintenance *maintenance.Maintenance) []factory.Job {
+	jobs := []factory.Job{}
+
+	// The dead letters are only recorded by the signoz alertmanager.
+	if config.Alertmanager.Provider == "signoz" {
+		deadLetterStore := sqlalertmanagerstore.NewDeadLetterStore(store)
+		jobs = append(jobs, alertmanager.NewDeadLetterPurgeJob(deadLetterStore, config.Alertmanager))
+
+		if config.Alertmanager.Signoz.DeadLetter.RedispatchInterval > 0 {
+			jobs = append(jobs, alertmanager.NewDeadLetterRedispatchJob(am, deadLetterStore, config.Alertmanager))
+		}
+	}
+
+	if config.TelemetryStore.Retention.Enabled {
+		jobs = append(jobs, newRetentionJob(telemetryStore.Retention(), config.TelemetryStore.Retention))
+	}
+
+	if config.Dashboard.Purge.Enabled {
+		jobs = append(jobs, impldashboard.NewPurgeJob(modules.Dashboard, config.Dashboard))
+	}
+
+	jobs = append(jobs, impluser.NewRefreshTokenPurgeJob(impluser.NewRefreshTokenStore(store), config.User))
+
+	if config.SQLStore.Outbox.Enabled {
+		jobs = append(jobs, sqlstore.NewOutboxJob(outboxRelay, config.SQLStore.Outbox))
+	} else {
+		jobs = append(jobs, sqlstore.NewOutboxPurgeJob(store, config.SQLStore.Outbox))
+	}
+
+	if config.SQLStore.Encryption.Enabled {
+		jobs = append(jobs, sqlstore.NewEncryptionRotationJob(store, encryptor, config.SQLStore.Encryption))
+	}
+
+	for i, job := range jobs {
+		jobs[i] = maintenance.Pause(job)
+	}
+
+	// The cache retries its creation in the background when it failed at boot time.
+	if job, ok := retrycache.NewJob(cache); ok {
+		jobs = append(jobs, job)
+	}
+
+	return jobs
+}
+
+func newScheduler(providerSettings factory.ProviderSettings, store sqlstore.SQLStore, jobs []factory.Job) (*factory.Scheduler, error) {
+	return factory.NewScheduler(providerSettings, sqlstore.NewLocker(store), jobs...)
+}
+
+// newOutboxRelay returns the relay publishing the events of the outbox to the webhook of the config, or nil when the
+// outbox is not enabled.
+func newOutboxRelay(providerSettings factory.ProviderSettings, store sqlstore.SQLStore, config sqlstore.OutboxConfig) (*sqlstore.OutboxRelay, error) {
+	if !config.Enabled {
+		return nil, nil
+	}
+
+	webhookClient, err := client.New(
+		providerSettings.Logger,
+		providerSettings.TracerProvider,
+		providerSettings.MeterProvider,
+		client.WithTransport(providerSettings.HTTPTransport),
+		client.WithTimeout(config.Webhook.Timeout),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// The sink posts without the retries of the client, the relay retries the events with a backoff of its own.
+	return sqlstore.NewOutboxRelay(providerSettings, store, sqlstore.NewWebhookOutboxSink(webhookClient.Client(), config.Webhook), config)
+}
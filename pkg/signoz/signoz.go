@@ -2,18 +2,26 @@ package signoz
 
 import (
 	"context"
+	"time"
 
 	"github.com/SigNoz/signoz/pkg/alertmanager"
+	"github.com/SigNoz/signoz/pkg/alertmanager/alertmanagernotify"
 	"github.com/SigNoz/signoz/pkg/cache"
+	"github.com/SigNoz/signoz/pkg/cache/retrycache"
 	"github.com/SigNoz/signoz/pkg/emailing"
 	"github.com/SigNoz/signoz/pkg/factory"
+	"github.com/SigNoz/signoz/pkg/http/client"
 	"github.com/SigNoz/signoz/pkg/instrumentation"
 	"github.com/SigNoz/signoz/pkg/licensing"
+	"github.com/SigNoz/signoz/pkg/licensing/overridelicensing"
+	"github.com/SigNoz/signoz/pkg/maintenance"
 	"github.com/SigNoz/signoz/pkg/modules/organization"
 	"github.com/SigNoz/signoz/pkg/modules/organization/implorganization"
+	"github.com/SigNoz/signoz/pkg/otlpreceiver"
 	"github.com/SigNoz/signoz/pkg/prometheus"
 	"github.com/SigNoz/signoz/pkg/querier"
 	"github.com/SigNoz/signoz/pkg/ruler"
+	"github.com/SigNoz/signoz/pkg/secretstore"
 	"github.com/SigNoz/signoz/pkg/sharder"
 	"github.com/SigNoz/signoz/pkg/sqlmigration"
 	"github.com/SigNoz/signoz/pkg/sqlmigrator"
@@ -33,8 +41,12 @@ type SigNoz struct {
 	Cache           cache.Cache
 	Web             web.Web
 	SQLStore        sqlstore.SQLStore
+	SQLMigrator     sqlmigrator.SQLMigrator
+	Encryptor       *sqlstore.Encryptor
+	JWT             *authtypes.JWT
 	TelemetryStore  telemetrystore.TelemetryStore
 	Prometheus      prometheus.Prometheus
+	OTLPReceiver    *otlpreceiver.Receiver
 	Alertmanager    alertmanager.Alertmanager
 	Querier         querier.Querier
 	Rules           ruler.Ruler
@@ -43,24 +55,32 @@ type SigNoz struct {
 	Emailing        emailing.Emailing
 	Sharder         sharder.Sharder
 	StatsReporter   statsreporter.StatsReporter
+	Maintenance     *maintenance.Maintenance
 	Modules         Modules
 	Handlers        Handlers
+	// config is the config SigNoz was created with.
+	config     Config
+	subsystems []subsystem
 }
 
 func New(
 	ctx context.Context,
 	config Config,
-	jwt *authtypes.JWT,
+	jwtSecret string,
 	zeusConfig zeus.Config,
 	zeusProviderFactory factory.ProviderFactory[zeus.Zeus, zeus.Config],
 	licenseConfig licensing.Config,
 	licenseProviderFactory func(sqlstore.SQLStore, zeus.Zeus, organization.Getter) factory.ProviderFactory[licensing.Licensing, licensing.Config],
-	emailingProviderFactories factory.NamedMap[factory.ProviderFactory[emailing.Emailing, emailing.Config]],
+	secretstoreProviderFactories factory.NamedMap[factory.ProviderFactory[secretstore.SecretStore, secretstore.Config]],
+	emailingProviderFactories func(*secretstore.Resolver) factory.NamedMap[factory.ProviderFactory[emailing.Emailing, emailing.Config]],
 	cacheProviderFactories factory.NamedMap[factory.ProviderFactory[cache.Cache, cache.Config]],
 	webProviderFactories factory.NamedMap[factory.ProviderFactory[web.Web, web.Config]],
 	sqlstoreProviderFactories factory.NamedMap[factory.ProviderFactory[sqlstore.SQLStore, sqlstore.Config]],
-	telemetrystoreProviderFactories factory.NamedMap[factory.ProviderFactory[telemetrystore.TelemetryStore, telemetrystore.Config]],
+	telemetrystoreProviderFactories func(*secretstore.Resolver) factory.NamedMap[factory.ProviderFactory[telemetrystore.TelemetryStore, telemetrystore.Config]],
+	receiverPluginProviderFactories factory.NamedMap[factory.ProviderFactory[alertmanagernotify.Receiver, alertmanagernotify.ReceiverConfig]],
 ) (*SigNoz, error) {
+	start := time.Now()
+
 	// Initialize instrumentation
 	instrumentation, err := instrumentation.New(ctx, config.Instrumentation, version.Info, "signoz")
 	if err != nil {
@@ -70,9 +90,20 @@ func New(
 	instrumentation.Logger().InfoContext(ctx, "starting signoz", "version", version.Info.Version(), "variant", version.Info.Variant(), "commit", version.Info.Hash(), "branch", version.Info.Branch(), "go", version.Info.GoVersion(), "time", version.Info.Time())
 	instrumentation.Logger().DebugContext(ctx, "loaded signoz config", "config", config)
 
+	startup, err := newStartup(instrumentation.Logger(), instrumentation.MeterProvider().Meter("github.com/SigNoz/signoz/pkg/signoz"), start)
+	if err != nil {
+		return nil, err
+	}
+
 	// Get the provider settings from instrumentation
 	providerSettings := instrumentation.ToProviderSettings()
 
+	// Initialize the transport shared by the outbound http clients of the providers
+	providerSettings.HTTPTransport, err = client.NewTransport(providerSettings.MeterProvider, config.HTTPClient)
+	if err != nil {
+		return nil, err
+	}
+
 	// Initialize analytics just after instrumentation, as providers might require it
 	analytics, err := factory.NewProviderFromNamedMap(
 		ctx,
@@ -87,6 +118,7 @@ func New(
 
 	// Initialize zeus from the available zeus provider factory. This is not config controlled
 	// and depends on the variant of the build.
+	done := startup.begin(ctx, "zeus")
 	zeus, err := zeusProviderFactory.New(
 		ctx,
 		providerSettings,
@@ -95,20 +127,45 @@ func New(
 	if err != nil {
 		return nil, err
 	}
+	done()
+
+	// Initialize secretstore from the available secretstore provider factories. The credentials referenced
+	// in the config of the providers below are resolved through it.
+	done = startup.begin(ctx, "secretstore")
+	secretStore, err := factory.NewProviderFromNamedMap(
+		ctx,
+		providerSettings,
+		config.SecretStore,
+		secretstoreProviderFactories,
+		config.SecretStore.Provider,
+	)
+	if err != nil {
+		return nil, err
+	}
+	done()
+
+	secretResolver := secretstore.NewResolver(providerSettings, secretStore, config.SecretStore.TTL)
+
+	// Initialize the encryptor of the sensitive columns, whose keys are resolved from the secretstore
+	encryptor := sqlstore.NewEncryptor(config.SQLStore.Encryption, secretStore)
 
 	// Initialize emailing from the available emailing provider factories
+	done = startup.begin(ctx, "emailing")
 	emailing, err := factory.NewProviderFromNamedMap(
 		ctx,
 		providerSettings,
 		config.Emailing,
-		emailingProviderFactories,
+		emailingProviderFactories(secretResolver),
 		config.Emailing.Provider(),
 	)
 	if err != nil {
 		return nil, err
 	}
+	done()
 
-	// Initialize cache from the available cache provider factories
+	// Initialize cache from the available cache provider factories. The cache is not critical,
+	// so if it fails we boot in a degraded mode and keep retrying in the background.
+	done = startup.begin(ctx, "cache")
 	cache, err := factory.NewProviderFromNamedMap(
 		ctx,
 		providerSettings,
@@ -117,10 +174,13 @@ func New(
 		config.Cache.Provider,
 	)
 	if err != nil {
-		return nil, err
+		instrumentation.Logger().ErrorContext(ctx, "failed to initialize cache, starting in degraded mode", "provider", config.Cache.Provider, "error", err)
+		cache = retrycache.New(ctx, providerSettings, config.Cache, cacheProviderFactories, config.Cache.Provider, err)
 	}
+	done()
 
 	// Initialize web from the available web provider factories
+	done = startup.begin(ctx, "web")
 	web, err := factory.NewProviderFromNamedMap(
 		ctx,
 		providerSettings,
@@ -131,8 +191,10 @@ func New(
 	if err != nil {
 		return nil, err
 	}
+	done()
 
 	// Initialize sqlstore from the available sqlstore provider factories
+	done = startup.begin(ctx, "sqlstore")
 	sqlstore, err := factory.NewProviderFromNamedMap(
 		ctx,
 		providerSettings,
@@ -143,58 +205,89 @@ func New(
 	if err != nil {
 		return nil, err
 	}
+	done()
 
 	// Initialize telemetrystore from the available telemetrystore provider factories
+	done = startup.begin(ctx, "telemetrystore")
 	telemetrystore, err := factory.NewProviderFromNamedMap(
 		ctx,
 		providerSettings,
 		config.TelemetryStore,
-		telemetrystoreProviderFactories,
+		telemetrystoreProviderFactories(secretResolver),
 		config.TelemetryStore.Provider,
 	)
 	if err != nil {
 		return nil, err
 	}
+	done()
 
 	// Initialize prometheus from the available prometheus provider factories
+	done = startup.begin(ctx, "prometheus")
 	prometheus, err := factory.NewProviderFromNamedMap(
 		ctx,
 		providerSettings,
 		config.Prometheus,
-		NewPrometheusProviderFactories(telemetrystore),
+		NewPrometheusProviderFactories(telemetrystore, cache),
 		config.Prometheus.Provider(),
 	)
 	if err != nil {
 		return nil, err
 	}
+	done()
+
+	// Initialize the receiver of the telemetry exported over otlp
+	otlpReceiver, err := otlpreceiver.New(providerSettings, config.OTLPReceiver, telemetrystore)
+	if err != nil {
+		return nil, err
+	}
+
+	// Loop the traces of signoz back into its own telemetry store if configured
+	instrumentation.SetLoopbackSink(otlpReceiver)
 
 	// Initialize querier from the available querier provider factories
+	done = startup.begin(ctx, "querier")
 	querier, err := factory.NewProviderFromNamedMap(
 		ctx,
 		providerSettings,
 		config.Querier,
-		NewQuerierProviderFactories(telemetrystore, prometheus, cache),
+		NewQuerierProviderFactories(telemetrystore, prometheus, cache, config.Cache.Negative),
 		config.Querier.Provider(),
 	)
 	if err != nil {
 		return nil, err
 	}
+	done()
+
+	// Initialize maintenance mode from the sqlstore, or from the config when it enables it. It can be changed at runtime
+	// through the api.
+	maintenance := maintenance.New(providerSettings, config.Maintenance, sqlstore)
+	if err := maintenance.Load(ctx); err != nil {
+		instrumentation.Logger().WarnContext(ctx, "failed to load maintenance mode", "enabled", maintenance.Enabled(), "error", err)
+	}
 
 	// Run migrations on the sqlstore
+	done = startup.begin(ctx, "migrations")
 	sqlmigrations, err := sqlmigration.New(
 		ctx,
 		providerSettings,
 		config.SQLMigration,
-		NewSQLMigrationProviderFactories(sqlstore),
+		NewSQLMigrationProviderFactories(sqlstore, encryptor),
 	)
 	if err != nil {
 		return nil, err
 	}
 
-	err = sqlmigrator.New(ctx, providerSettings, sqlstore, sqlmigrations, config.SQLMigrator).Migrate(ctx)
-	if err != nil {
-		return nil, err
+	sqlmigrator := sqlmigrator.New(ctx, providerSettings, sqlstore, sqlmigrations, sqlmigration.MustNewChecksums(), config.SQLMigrator)
+	if maintenance.Enabled() {
+		// The pending migrations are reported by the readiness of the sqlmigrator until signoz restarts out of maintenance mode.
+		instrumentation.Logger().WarnContext(ctx, "skipping sqlstore migrations in maintenance mode")
+	} else {
+		err = sqlmigrator.Migrate(ctx)
+		if err != nil {
+			return nil, err
+		}
 	}
+	done()
 
 	// Initialize sharder from the available sharder provider factories
 	sharder, err := factory.NewProviderFromNamedMap(
@@ -212,16 +305,18 @@ func New(
 	orgGetter := implorganization.NewGetter(implorganization.NewStore(sqlstore), sharder)
 
 	// Initialize alertmanager from the available alertmanager provider factories
+	done = startup.begin(ctx, "alertmanager")
 	alertmanager, err := factory.NewProviderFromNamedMap(
 		ctx,
 		providerSettings,
 		config.Alertmanager,
-		NewAlertmanagerProviderFactories(sqlstore, orgGetter),
+		NewAlertmanagerProviderFactories(sqlstore, orgGetter, maintenance, encryptor, receiverPluginProviderFactories),
 		config.Alertmanager.Provider,
 	)
 	if err != nil {
 		return nil, err
 	}
+	done()
 
 	// Initialize ruler from the available ruler provider factories
 	ruler, err := factory.NewProviderFromNamedMap(
@@ -235,6 +330,7 @@ func New(
 		return nil, err
 	}
 
+	done = startup.begin(ctx, "licensing")
 	licensingProviderFactory := licenseProviderFactory(sqlstore, zeus, orgGetter)
 	licensing, err := licensingProviderFactory.New(
 		ctx,
@@ -245,11 +341,30 @@ func New(
 		return nil, err
 	}
 
+	licensing, err = overridelicensing.New(ctx, providerSettings, config.FeatureOverrides, sqlstore, licensing)
+	if err != nil {
+		return nil, err
+	}
+	done()
+
+	// The tokens of the issuers of the apiserver config are trusted in addition to the tokens signed with the secret
+	jwksClient, err := client.New(
+		providerSettings.Logger,
+		providerSettings.TracerProvider,
+		providerSettings.MeterProvider,
+		client.WithTransport(providerSettings.HTTPTransport),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	jwt := authtypes.NewJWT(jwtSecret, 30*time.Minute, 30*24*time.Hour, authtypes.WithTrustedIssuers(jwksClient.Client(), config.APIServer.Auth.Issuers()...))
+
 	// Initialize all modules
 	modules := NewModules(sqlstore, jwt, emailing, providerSettings, orgGetter, alertmanager, analytics)
 
 	// Initialize all handlers for the modules
-	handlers := NewHandlers(modules)
+	handlers := NewHandlers(modules, querier)
 
 	// Create a list of all stats collectors
 	statsCollectors := []statsreporter.StatsCollector{
@@ -273,33 +388,94 @@ func New(
 		return nil, err
 	}
 
-	registry, err := factory.NewRegistry(
-		instrumentation.Logger(),
-		factory.NewNamedService(factory.MustNewName("instrumentation"), instrumentation),
-		factory.NewNamedService(factory.MustNewName("analytics"), analytics),
-		factory.NewNamedService(factory.MustNewName("alertmanager"), alertmanager),
-		factory.NewNamedService(factory.MustNewName("licensing"), licensing),
-		factory.NewNamedService(factory.MustNewName("statsreporter"), statsReporter),
+	// Initialize the relay publishing the events of the outbox
+	outboxRelay, err := newOutboxRelay(providerSettings, sqlstore, config.SQLStore.Outbox)
+	if err != nil {
+		return nil, err
+	}
+
+	// Initialize the scheduler running the recurring background work
+	scheduler, err := newScheduler(providerSettings, sqlstore, NewJobs(config, sqlstore, telemetrystore, cache, alertmanager, modules, outboxRelay, encryptor, maintenance))
+	if err != nil {
+		return nil, err
+	}
+
+	// Every service is given the stop timeout of its name, so that a service hanging on shutdown does not prevent the
+	// others from stopping.
+	newNamedService := func(name string, service factory.Service) factory.NamedService {
+		serviceName := factory.MustNewName(name)
+		return factory.NewNamedService(serviceName, service, factory.WithStopTimeout(config.Registry.StopTimeoutOf(serviceName)))
+	}
+
+	// Services are stopped in the reverse order of registration, so services which depend
+	// on others should be registered after their dependencies.
+	services := []factory.NamedService{
+		newNamedService("instrumentation", instrumentation),
+	}
+
+	// Some sqlstore providers, such as sqlite, run background maintenance of the database and others, such as
+	// postgres, close their read replicas on shutdown.
+	if service, ok := sqlstore.(factory.Service); ok {
+		services = append(services, newNamedService("sqlstore", service))
+	}
+
+	// Maintenance mode is read again from the sqlstore to follow the changes made on the other replicas.
+	services = append(services, newNamedService("maintenance", maintenance))
+
+	// Some emailing providers, such as smtp, keep their connections open for reuse and close them on shutdown.
+	if service, ok := emailing.(factory.Service); ok {
+		services = append(services, newNamedService("emailing", service))
+	}
+
+	// Some prometheus providers, such as clickhouse, probe the health of their backends.
+	if service, ok := prometheus.(factory.Service); ok {
+		services = append(services, newNamedService("prometheus", service))
+	}
+
+	services = append(
+		services,
+		newNamedService("analytics", analytics),
+		newNamedService("alertmanager", alertmanager),
+		newNamedService("licensing", licensing),
+		newNamedService("statsreporter", statsReporter),
+		newNamedService("scheduler", scheduler),
 	)
+
+	registry, err := factory.NewRegistry(instrumentation.Logger(), services...)
 	if err != nil {
 		return nil, err
 	}
 
+	startup.finish(ctx)
+
 	return &SigNoz{
 		Registry:        registry,
 		Instrumentation: instrumentation,
 		Cache:           cache,
 		Web:             web,
 		SQLStore:        sqlstore,
+		SQLMigrator:     sqlmigrator,
+		Encryptor:       encryptor,
+		JWT:             jwt,
 		TelemetryStore:  telemetrystore,
 		Prometheus:      prometheus,
+		OTLPReceiver:    otlpReceiver,
 		Alertmanager:    alertmanager,
 		Querier:         querier,
 		Zeus:            zeus,
 		Licensing:       licensing,
 		Emailing:        emailing,
 		Sharder:         sharder,
+		Maintenance:     maintenance,
 		Modules:         modules,
 		Handlers:        handlers,
+		config:          config,
+		subsystems: []subsystem{
+			{name: factory.MustNewName("sqlstore"), critical: true, provider: sqlstore},
+			{name: factory.MustNewName("sqlmigrator"), critical: true, provider: sqlmigrator},
+			{name: factory.MustNewName("telemetrystore"), critical: false, provider: telemetrystore},
+			{name: factory.MustNewName("alertmanager"), critical: true, provider: alertmanager},
+			{name: factory.MustNewName("cache"), critical: false, provider: cache},
+		},
 	}, nil
 }
@@ -0,0 +1,45 @@
+package signoz
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// startup records the durations of the initialization of the providers of signoz, to pinpoint the slow ones on boot.
+type startup struct {
+	logger    *slog.Logger
+	start     time.Time
+	durations []any
+	histogram metric.Float64Histogram
+}
+
+func newStartup(logger *slog.Logger, meter metric.Meter, start time.Time) (*startup, error) {
+	histogram, err := meter.Float64Histogram("signoz.startup.provider.duration", metric.WithDescription("Duration of the initialization of the providers on boot."), metric.WithUnit("s"))
+	if err != nil {
+		return nil, err
+	}
+
+	return &startup{logger: logger, start: start, histogram: histogram}, nil
+}
+
+// begin starts timing the initialization of the provider of the name and returns the function to call once it is
+// initialized.
+func (s *startup) begin(ctx context.Context, name string) func() {
+	start := time.Now()
+
+	return func() {
+		duration := time.Since(start)
+		s.durations = append(s.durations, slog.Duration(name, duration))
+		s.histogram.Record(ctx, duration.Seconds(), metric.WithAttributes(attribute.String("provider", name)))
+		s.logger.DebugContext(ctx, "initialized provider", "provider", name, "duration", duration)
+	}
+}
+
+// finish logs the total duration of the boot along with the duration of every provider.
+func (s *startup) finish(ctx context.Context) {
+	s.logger.InfoContext(ctx, "finished starting signoz", "duration", time.Since(s.start), slog.Group("providers", s.durations...))
+}
@@ -0,0 +1,33 @@
+package signoz
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/metric/noop"
+)
+
+func TestStartup(t *testing.T) {
+	buf := new(bytes.Buffer)
+	startup, err := newStartup(slog.New(slog.NewJSONHandler(buf, &slog.HandlerOptions{Level: slog.LevelInfo})), noop.NewMeterProvider().Meter("test"), time.Now())
+	require.NoError(t, err)
+
+	startup.begin(context.Background(), "sqlstore")()
+	startup.begin(context.Background(), "telemetrystore")()
+	startup.finish(context.Background())
+
+	var record map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &record))
+
+	assert.Equal(t, "finished starting signoz", record["msg"])
+	assert.Contains(t, record, "duration")
+	require.IsType(t, map[string]any{}, record["providers"])
+	assert.Contains(t, record["providers"], "sqlstore")
+	assert.Contains(t, record["providers"], "telemetrystore")
+}
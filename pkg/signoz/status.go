@@ -0,0 +1,170 @@
+package signoz
+
+import (
+	"context"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/factory"
+	"github.com/SigNoz/signoz/pkg/instrumentation"
+	"github.com/SigNoz/signoz/pkg/instrumentation/loghandler"
+	"github.com/SigNoz/signoz/pkg/maintenance"
+)
+
+const (
+	healthCheckTimeout = 5 * time.Second
+)
+
+// Critical subsystems are required for SigNoz to serve requests. SigNoz fails to boot if any of them cannot be created,
+// and is reported as unhealthy if any of them are unhealthy. These are:
+//   - sqlstore
+//   - sqlmigrator
+//   - alertmanager
+//
+// Non critical subsystems are allowed to fail at boot time, in which case they are retried in the background while
+// SigNoz runs in a degraded mode. These are:
+//   - cache
+//   - telemetrystore, whose connections are opened on demand once clickhouse is reachable
+type subsystem struct {
+	name     factory.Name
+	critical bool
+	provider any
+}
+
+type SubsystemStatus struct {
+	Name     string `json:"name"`
+	Critical bool   `json:"critical"`
+	Healthy  bool   `json:"healthy"`
+	// Unknown is true if the subsystem does not report its health, in which case it is neither healthy nor counted
+	// as unhealthy.
+	Unknown bool   `json:"unknown,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+type Status struct {
+	// Healthy is false if any of the critical subsystems are unhealthy.
+	Healthy bool `json:"healthy"`
+	// Degraded is true if any of the non critical subsystems are unhealthy.
+	Degraded   bool              `json:"degraded"`
+	Subsystems []SubsystemStatus `json:"subsystems"`
+	// Maintenance is the maintenance mode, in which the writes are rejected.
+	Maintenance maintenance.Status `json:"maintenance"`
+	// Sampling is the sampling of the traces exported by SigNoz.
+	Sampling instrumentation.SamplingStatus `json:"sampling"`
+	// LogLevels are the levels of the logs of SigNoz by module.
+	LogLevels loghandler.LevelsStatus `json:"logLevels"`
+}
+
+// Status checks the health of each subsystem independently and returns the aggregated status.
+func (signoz *SigNoz) Status(ctx context.Context) Status {
+	status := Status{Healthy: true, Subsystems: make([]SubsystemStatus, len(signoz.subsystems))}
+
+	for i, subsystem := range signoz.subsystems {
+		subsystemStatus := SubsystemStatus{Name: subsystem.name.String(), Critical: subsystem.critical, Healthy: true}
+
+		checkCtx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+		err := factory.HealthOf(checkCtx, subsystem.provider)
+		cancel()
+
+		switch {
+		case errors.Is(err, factory.ErrHealthUnknown):
+			subsystemStatus.Healthy = false
+			subsystemStatus.Unknown = true
+		case err != nil:
+			subsystemStatus.Healthy = false
+			subsystemStatus.Error = signoz.publicError(ctx, subsystem.name, err)
+		}
+
+		if !subsystemStatus.Healthy && !subsystemStatus.Unknown {
+			if subsystem.critical {
+				status.Healthy = false
+			} else {
+				status.Degraded = true
+			}
+		}
+
+		status.Subsystems[i] = subsystemStatus
+	}
+
+	if signoz.Maintenance != nil {
+		status.Maintenance = signoz.Maintenance.Status()
+	}
+
+	if signoz.Instrumentation != nil {
+		status.Sampling = signoz.Instrumentation.Sampling()
+		status.LogLevels = signoz.Instrumentation.LogLevels()
+	}
+
+	return status
+}
+
+type SubsystemReadiness struct {
+	Name     string `json:"name"`
+	Critical bool   `json:"critical"`
+	Ready    bool   `json:"ready"`
+	Error    string `json:"error,omitempty"`
+}
+
+type Readiness struct {
+	// Ready is false until all the services have started and while any of the critical subsystems is not ready.
+	Ready bool `json:"ready"`
+	// Error is the reason why the services are not ready.
+	Error      string               `json:"error,omitempty"`
+	Subsystems []SubsystemReadiness `json:"subsystems"`
+}
+
+// Readiness checks whether SigNoz is ready to serve requests. A subsystem is ready if it implements factory.Ready
+// and reports itself as ready, or if it is healthy or its health is unknown otherwise. Only the critical subsystems gate the readiness.
+func (signoz *SigNoz) Readiness(ctx context.Context) Readiness {
+	readiness := Readiness{Ready: true, Subsystems: make([]SubsystemReadiness, len(signoz.subsystems))}
+
+	if signoz.Registry != nil {
+		checkCtx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+		if err := signoz.Registry.Ready(checkCtx); err != nil {
+			readiness.Ready = false
+			readiness.Error = signoz.publicError(ctx, factory.MustNewName("registry"), err)
+		}
+		cancel()
+	}
+
+	for i, subsystem := range signoz.subsystems {
+		subsystemReadiness := SubsystemReadiness{Name: subsystem.name.String(), Critical: subsystem.critical, Ready: true}
+
+		checkCtx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+		var err error
+		switch provider := subsystem.provider.(type) {
+		case factory.Ready:
+			err = provider.Ready(checkCtx)
+		case factory.Healthy:
+			err = provider.Healthy(checkCtx)
+		}
+		cancel()
+
+		// The subsystems which cannot tell their health do not gate the readiness.
+		if err != nil && !errors.Is(err, factory.ErrHealthUnknown) {
+			subsystemReadiness.Ready = false
+			subsystemReadiness.Error = signoz.publicError(ctx, subsystem.name, err)
+			if subsystem.critical {
+				readiness.Ready = false
+			}
+		}
+
+		readiness.Subsystems[i] = subsystemReadiness
+	}
+
+	return readiness
+}
+
+// publicError returns the error reported for a failed check. The status and the readiness are served without
+// authentication, so the errors themselves, which can hold addresses and credentials, are logged instead.
+func (signoz *SigNoz) publicError(ctx context.Context, name factory.Name, err error) string {
+	if signoz.Instrumentation != nil {
+		signoz.Instrumentation.Logger().WarnContext(ctx, "check failed", "subsystem", name.String(), "error", err)
+	}
+
+	if errors.Is(err, context.DeadlineExceeded) {
+		return "check timed out"
+	}
+
+	return "check failed"
+}
@@ -0,0 +1,176 @@
+package signoz
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/SigNoz/signoz/pkg/factory"
+	"github.com/stretchr/testify/assert"
+)
+
+type healthyProvider struct {
+	err error
+}
+
+func (provider *healthyProvider) Healthy(context.Context) error {
+	return provider.err
+}
+
+func TestStatus(t *testing.T) {
+	testCases := []struct {
+		name       string
+		subsystems []subsystem
+		expected   Status
+	}{
+		{
+			name: "AllHealthy",
+			subsystems: []subsystem{
+				{name: factory.MustNewName("critical"), critical: true, provider: &healthyProvider{}},
+				{name: factory.MustNewName("noncritical"), critical: false, provider: &healthyProvider{}},
+				{name: factory.MustNewName("nohealthcheck"), critical: true, provider: struct{}{}},
+			},
+			expected: Status{
+				Healthy:  true,
+				Degraded: false,
+				Subsystems: []SubsystemStatus{
+					{Name: "critical", Critical: true, Healthy: true},
+					{Name: "noncritical", Critical: false, Healthy: true},
+					{Name: "nohealthcheck", Critical: true, Healthy: false, Unknown: true},
+				},
+			},
+		},
+		{
+			name: "UnknownHealth",
+			subsystems: []subsystem{
+				{name: factory.MustNewName("critical"), critical: true, provider: &healthyProvider{err: factory.ErrHealthUnknown}},
+			},
+			expected: Status{
+				Healthy:  true,
+				Degraded: false,
+				Subsystems: []SubsystemStatus{
+					{Name: "critical", Critical: true, Healthy: false, Unknown: true},
+				},
+			},
+		},
+		{
+			name: "NonCriticalUnhealthy",
+			subsystems: []subsystem{
+				{name: factory.MustNewName("critical"), critical: true, provider: &healthyProvider{}},
+				{name: factory.MustNewName("noncritical"), critical: false, provider: &healthyProvider{err: errors.New("connection refused")}},
+			},
+			expected: Status{
+				Healthy:  true,
+				Degraded: true,
+				Subsystems: []SubsystemStatus{
+					{Name: "critical", Critical: true, Healthy: true},
+					{Name: "noncritical", Critical: false, Healthy: false, Error: "check failed"},
+				},
+			},
+		},
+		{
+			name: "CriticalUnhealthy",
+			subsystems: []subsystem{
+				{name: factory.MustNewName("critical"), critical: true, provider: &healthyProvider{err: errors.New("dial tcp postgres://signoz:secret@db:5432: connection refused")}},
+				{name: factory.MustNewName("noncritical"), critical: false, provider: &healthyProvider{}},
+			},
+			expected: Status{
+				Healthy:  false,
+				Degraded: false,
+				Subsystems: []SubsystemStatus{
+					{Name: "critical", Critical: true, Healthy: false, Error: "check failed"},
+					{Name: "noncritical", Critical: false, Healthy: true},
+				},
+			},
+		},
+		{
+			name: "TimedOut",
+			subsystems: []subsystem{
+				{name: factory.MustNewName("noncritical"), critical: false, provider: &healthyProvider{err: fmt.Errorf("ping: %w", context.DeadlineExceeded)}},
+			},
+			expected: Status{
+				Healthy:  true,
+				Degraded: true,
+				Subsystems: []SubsystemStatus{
+					{Name: "noncritical", Critical: false, Healthy: false, Error: "check timed out"},
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			signoz := &SigNoz{subsystems: tc.subsystems}
+			assert.Equal(t, tc.expected, signoz.Status(context.Background()))
+		})
+	}
+}
+
+type readyProvider struct {
+	healthyProvider
+	ready error
+}
+
+func (provider *readyProvider) Ready(context.Context) error {
+	return provider.ready
+}
+
+func TestReadiness(t *testing.T) {
+	testCases := []struct {
+		name       string
+		subsystems []subsystem
+		expected   Readiness
+	}{
+		{
+			name: "AllReady",
+			subsystems: []subsystem{
+				{name: factory.MustNewName("critical"), critical: true, provider: &readyProvider{}},
+				{name: factory.MustNewName("healthy"), critical: true, provider: &healthyProvider{}},
+				{name: factory.MustNewName("nocheck"), critical: true, provider: struct{}{}},
+			},
+			expected: Readiness{
+				Ready: true,
+				Subsystems: []SubsystemReadiness{
+					{Name: "critical", Critical: true, Ready: true},
+					{Name: "healthy", Critical: true, Ready: true},
+					{Name: "nocheck", Critical: true, Ready: true},
+				},
+			},
+		},
+		{
+			name: "NonCriticalNotReady",
+			subsystems: []subsystem{
+				{name: factory.MustNewName("critical"), critical: true, provider: &readyProvider{}},
+				{name: factory.MustNewName("noncritical"), critical: false, provider: &healthyProvider{err: errors.New("connection refused")}},
+			},
+			expected: Readiness{
+				Ready: true,
+				Subsystems: []SubsystemReadiness{
+					{Name: "critical", Critical: true, Ready: true},
+					{Name: "noncritical", Critical: false, Ready: false, Error: "check failed"},
+				},
+			},
+		},
+		{
+			name: "CriticalNotReady",
+			subsystems: []subsystem{
+				// A healthy subsystem which is not ready yet is not ready.
+				{name: factory.MustNewName("critical"), critical: true, provider: &readyProvider{ready: errors.New("2 migrations have not been applied")}},
+			},
+			expected: Readiness{
+				Ready: false,
+				Subsystems: []SubsystemReadiness{
+					{Name: "critical", Critical: true, Ready: false, Error: "check failed"},
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			signoz := &SigNoz{subsystems: tc.subsystems}
+			assert.Equal(t, tc.expected, signoz.Readiness(context.Background()))
+		})
+	}
+}
@@ -1,26 +1,79 @@
 package client
 
+import (
+	"context"
+	"time"
+)
+
+// TLSMode is the way TLS is established with the server.
+type TLSMode string
+
+const (
+	// TLSModeAuto upgrades the connection with STARTTLS if the server supports it. Implicit TLS is used if TLS is
+	// enabled or if the port is 465.
+	TLSModeAuto TLSMode = "auto"
+	// TLSModeNone never uses TLS.
+	TLSModeNone TLSMode = "none"
+	// TLSModeStartTLS requires the connection to be upgraded with STARTTLS.
+	TLSModeStartTLS TLSMode = "starttls"
+	// TLSModeImplicit establishes TLS before any SMTP command is exchanged, usually on port 465.
+	TLSModeImplicit TLSMode = "implicit"
+)
+
 type Auth struct {
 	Username string
 	Password string
 	Identity string
 	Secret   string
+	// PasswordFunc and SecretFunc take precedence over Password and Secret. They are called on every
+	// authentication so that rotated credentials are picked up.
+	PasswordFunc func(context.Context) string
+	SecretFunc   func(context.Context) string
+}
+
+func (auth Auth) password(ctx context.Context) string {
+	if auth.PasswordFunc != nil {
+		return auth.PasswordFunc(ctx)
+	}
+
+	return auth.Password
+}
+
+func (auth Auth) secret(ctx context.Context) string {
+	if auth.SecretFunc != nil {
+		return auth.SecretFunc(ctx)
+	}
+
+	return auth.Secret
 }
 
 type TLS struct {
-	Enabled            bool
+	Enabled bool
+	Mode    TLSMode
+	// ServerName is the name verified against the certificate of the server. It defaults to the host of the address.
+	ServerName         string
 	InsecureSkipVerify bool
 	CAFilePath         string
 	KeyFilePath        string
 	CertFilePath       string
 }
 
+// Pool is the pool of the connections kept open between sends.
+type Pool struct {
+	// MaxIdleConns is the maximum number of idle connections. 0 disables the reuse of connections.
+	MaxIdleConns int
+	// IdleTimeout is the time after which an idle connection is closed.
+	IdleTimeout time.Duration
+}
+
 type options struct {
 	from    string
 	headers map[string]string
 	hello   string
 	auth    Auth
 	tls     TLS
+	timeout time.Duration
+	pool    Pool
 }
 
 type Option func(*options)
@@ -54,3 +107,16 @@ func WithTLS(tls TLS) Option {
 		o.tls = tls
 	}
 }
+
+// WithTimeout sets the maximum time spent connecting to the server and sending an email.
+func WithTimeout(timeout time.Duration) Option {
+	return func(o *options) {
+		o.timeout = timeout
+	}
+}
+
+func WithPool(pool Pool) Option {
+	return func(o *options) {
+		o.pool = pool
+	}
+}
@@ -0,0 +1,81 @@
+package client
+
+import (
+	"net"
+	"net/smtp"
+	"sync"
+	"time"
+)
+
+// session is an open connection to the server on which the greeting, TLS and authentication have been done.
+type session struct {
+	client    *smtp.Client
+	conn      net.Conn
+	idleSince time.Time
+}
+
+// close quits the session and closes the connection.
+func (s *session) close() error {
+	defer s.conn.Close() //nolint:errcheck
+	return s.client.Quit()
+}
+
+// pool keeps the idle sessions so that the following sends do not have to connect and handshake again.
+type pool struct {
+	config Pool
+	mtx    sync.Mutex
+	idle   []*session
+	closed bool
+}
+
+func newPool(config Pool) *pool {
+	return &pool{config: config, idle: make([]*session, 0, max(config.MaxIdleConns, 0))}
+}
+
+// get returns the most recently used idle session, closing the sessions which have been idle for too long.
+func (p *pool) get() *session {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	for len(p.idle) > 0 {
+		s := p.idle[len(p.idle)-1]
+		p.idle = p.idle[:len(p.idle)-1]
+
+		if p.config.IdleTimeout > 0 && time.Since(s.idleSince) > p.config.IdleTimeout {
+			go s.close() //nolint:errcheck
+			continue
+		}
+
+		return s
+	}
+
+	return nil
+}
+
+// put returns the session to the pool. It returns false if the pool is full or closed, in which case the caller is
+// expected to close the session.
+func (p *pool) put(s *session) bool {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	if p.closed || len(p.idle) >= p.config.MaxIdleConns {
+		return false
+	}
+
+	s.idleSince = time.Now()
+	p.idle = append(p.idle, s)
+	return true
+}
+
+// close closes all the idle sessions. The sessions of the sends still in flight are closed once they are done.
+func (p *pool) close() {
+	p.mtx.Lock()
+	idle := p.idle
+	p.idle = nil
+	p.closed = true
+	p.mtx.Unlock()
+
+	for _, s := range idle {
+		_ = s.close()
+	}
+}
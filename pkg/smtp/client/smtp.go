@@ -32,7 +32,10 @@ type Client struct {
 	hello     string
 	auth      Auth
 	tls       TLS
+	tlsMode   TLSMode
 	tlsConfig *tls.Config
+	timeout   time.Duration
+	pool      *pool
 }
 
 func New(address string, logger *slog.Logger, opts ...Option) (*Client, error) {
@@ -43,7 +46,9 @@ func New(address string, logger *slog.Logger, opts ...Option) (*Client, error) {
 		tls: TLS{
 			Enabled: false,
 		},
-		hello: "",
+		hello:   "",
+		timeout: 30 * time.Second,
+		pool:    Pool{},
 	}
 
 	for _, opt := range opts {
@@ -65,7 +70,17 @@ func New(address string, logger *slog.Logger, opts ...Option) (*Client, error) {
 	}
 	clientOpts.headers["From"] = from.String()
 
-	tls, err := newTLSConfig(clientOpts.tls, host)
+	tlsMode, err := newTLSMode(clientOpts.tls, port)
+	if err != nil {
+		return nil, err
+	}
+
+	serverName := host
+	if clientOpts.tls.ServerName != "" {
+		serverName = clientOpts.tls.ServerName
+	}
+
+	tls, err := newTLSConfig(clientOpts.tls, serverName)
 	if err != nil {
 		return nil, fmt.Errorf("create TLS config: %w", err)
 	}
@@ -80,48 +95,141 @@ func New(address string, logger *slog.Logger, opts ...Option) (*Client, error) {
 		hello:     clientOpts.hello,
 		auth:      clientOpts.auth,
 		tls:       clientOpts.tls,
+		tlsMode:   tlsMode,
 		tlsConfig: tls,
+		timeout:   clientOpts.timeout,
+		pool:      newPool(clientOpts.pool),
 	}, nil
 }
 
+// newTLSMode resolves the auto mode of the config to implicit TLS when TLS is enabled or the port is 465.
+func newTLSMode(config TLS, port string) (TLSMode, error) {
+	switch config.Mode {
+	case "", TLSModeAuto:
+		if config.Enabled || port == "465" {
+			return TLSModeImplicit, nil
+		}
+
+		return TLSModeAuto, nil
+	case TLSModeNone, TLSModeStartTLS, TLSModeImplicit:
+		return config.Mode, nil
+	default:
+		return "", fmt.Errorf("unsupported TLS mode %q, it must be one of %q, %q, %q or %q", config.Mode, TLSModeAuto, TLSModeNone, TLSModeStartTLS, TLSModeImplicit)
+	}
+}
+
+// Close closes the idle connections of the client.
+func (c *Client) Close() {
+	c.pool.close()
+}
+
 func (c *Client) Do(ctx context.Context, tos []*mail.Address, subject string, contentType ContentType, body []byte) error {
-	var (
-		smtpClient *smtp.Client
-		conn       net.Conn
-		err        error
-		success    = false
-	)
+	if c.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.timeout)
+		defer cancel()
+	}
 
-	// Dial the SMTP server.
-	conn, err = c.dial(ctx)
+	session, err := c.session(ctx)
 	if err != nil {
 		return err
 	}
 
-	// Create a new SMTP client.
-	smtpClient, err = smtp.NewClient(conn, c.host)
+	// Bound every command to the deadline so that an unresponsive server fails the send instead of hanging it.
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = session.conn.SetDeadline(deadline)
+	}
+
+	if err := c.send(session.client, tos, subject, contentType, body); err != nil {
+		// Try to clean up after ourselves but don't log anything since something has already failed.
+		_ = session.close()
+		return err
+	}
+
+	// Reset the session so that it can be reused for the next send.
+	if err := session.client.Reset(); err == nil {
+		_ = session.conn.SetDeadline(time.Time{})
+		if c.pool.put(session) {
+			return nil
+		}
+	}
+
+	if err := session.close(); err != nil {
+		c.logger.WarnContext(ctx, "failed to close SMTP connection", "error", err)
+	}
+
+	return nil
+}
+
+// session returns an idle session of the pool which is still alive or connects a new one.
+func (c *Client) session(ctx context.Context) (*session, error) {
+	for s := c.pool.get(); s != nil; s = c.pool.get() {
+		if deadline, ok := ctx.Deadline(); ok {
+			_ = s.conn.SetDeadline(deadline)
+		}
+
+		if err := s.client.Noop(); err == nil {
+			return s, nil
+		}
+
+		_ = s.close()
+	}
+
+	return c.connect(ctx)
+}
+
+// connect dials the server and runs the greeting, TLS and authentication of a new session.
+func (c *Client) connect(ctx context.Context) (*session, error) {
+	// Dial the SMTP server.
+	conn, err := c.dial(ctx)
 	if err != nil {
-		conn.Close()
-		return fmt.Errorf("failed to create SMTP client: %w", err)
+		return nil, err
 	}
 
-	// Try to clean up after ourselves but don't log anything if something has failed.
-	defer func() {
-		if err := smtpClient.Quit(); success && err != nil {
-			c.logger.WarnContext(ctx, "failed to close SMTP connection", "error", err)
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	// Create a new SMTP client. This waits for the greeting of the server.
+	smtpClient, err := smtp.NewClient(conn, c.host)
+	if err != nil {
+		conn.Close()
+		var netErr net.Error
+		if errors.As(err, &netErr) && netErr.Timeout() && c.tlsMode != TLSModeImplicit {
+			return nil, fmt.Errorf("timed out waiting for the SMTP greeting, the server may only accept implicit TLS: %w", err)
 		}
-	}()
 
+		return nil, fmt.Errorf("failed to create SMTP client: %w", err)
+	}
+
+	session := &session{client: smtpClient, conn: conn}
+	if err := c.handshake(ctx, smtpClient); err != nil {
+		_ = session.close()
+		return nil, err
+	}
+
+	return session, nil
+}
+
+func (c *Client) handshake(ctx context.Context, smtpClient *smtp.Client) error {
 	// Send the EHLO command.
 	if c.hello != "" {
-		err = smtpClient.Hello(c.hello)
-		if err != nil {
+		if err := smtpClient.Hello(c.hello); err != nil {
 			return fmt.Errorf("failed to send EHLO command: %w", err)
 		}
 	}
 
-	// If TLS is not enabled, check if the server supports STARTTLS.
-	if !c.tls.Enabled {
+	switch c.tlsMode {
+	case TLSModeStartTLS:
+		if ok, _ := smtpClient.Extension("STARTTLS"); !ok {
+			return fmt.Errorf("server does not support STARTTLS, use the %q or %q TLS mode instead", TLSModeImplicit, TLSModeNone)
+		}
+
+		if err := smtpClient.StartTLS(c.tlsConfig); err != nil {
+			return fmt.Errorf("failed to send STARTTLS command: %w", err)
+		}
+	case TLSModeAuto:
+		// Upgrade the connection if the server supports STARTTLS.
 		if ok, _ := smtpClient.Extension("STARTTLS"); ok {
 			if err := smtpClient.StartTLS(c.tlsConfig); err != nil {
 				return fmt.Errorf("failed to send STARTTLS command: %w", err)
@@ -145,6 +253,13 @@ func (c *Client) Do(ctx context.Context, tos []*mail.Address, subject string, co
 		}
 	}
 
+	return nil
+}
+
+// send sends the email over an established session.
+func (c *Client) send(smtpClient *smtp.Client, tos []*mail.Address, subject string, contentType ContentType, body []byte) error {
+	var err error
+
 	// Send the MAIL command.
 	if err = smtpClient.Mail(c.from.Address); err != nil {
 		return fmt.Errorf("failed to send MAIL command: %w", err)
@@ -261,19 +376,18 @@ func (c *Client) Do(ctx context.Context, tos []*mail.Address, subject string, co
 		return fmt.Errorf("failed to deliver: %w", err)
 	}
 
-	success = true
 	return nil
 }
 
 // auth resolves a string of authentication mechanisms.
-func (c *Client) smtpAuth(_ context.Context, mechs string) (smtp.Auth, error) {
+func (c *Client) smtpAuth(ctx context.Context, mechs string) (smtp.Auth, error) {
 	username := c.auth.Username
 
 	var errs []error
 	for _, mech := range strings.Split(mechs, " ") {
 		switch mech {
 		case "CRAM-MD5":
-			secret := c.auth.Secret
+			secret := c.auth.secret(ctx)
 			if secret == "" {
 				errs = append(errs, errors.New("missing secret for CRAM-MD5 auth mechanism"))
 				continue
@@ -281,7 +395,7 @@ func (c *Client) smtpAuth(_ context.Context, mechs string) (smtp.Auth, error) {
 			return smtp.CRAMMD5Auth(username, secret), nil
 
 		case "PLAIN":
-			password := c.auth.Password
+			password := c.auth.password(ctx)
 			if password == "" {
 				errs = append(errs, errors.New("missing password for PLAIN auth mechanism"))
 				continue
@@ -290,7 +404,7 @@ func (c *Client) smtpAuth(_ context.Context, mechs string) (smtp.Auth, error) {
 
 			return smtp.PlainAuth(identity, username, password, c.host), nil
 		case "LOGIN":
-			password := c.auth.Password
+			password := c.auth.password(ctx)
 			if password == "" {
 				errs = append(errs, errors.New("missing password for LOGIN auth mechanism"))
 				continue
@@ -308,22 +422,24 @@ func (c *Client) smtpAuth(_ context.Context, mechs string) (smtp.Auth, error) {
 }
 
 func (c *Client) dial(ctx context.Context) (net.Conn, error) {
-	var (
-		conn net.Conn
-		err  error
-	)
+	dialer := &net.Dialer{Timeout: c.timeout}
 
-	if c.tls.Enabled || c.port == "465" {
-		conn, err = tls.Dial("tcp", c.address, c.tlsConfig)
+	if c.tlsMode == TLSModeImplicit {
+		tlsDialer := &tls.Dialer{NetDialer: dialer, Config: c.tlsConfig}
+		conn, err := tlsDialer.DialContext(ctx, "tcp", c.address)
 		if err != nil {
+			var recordErr tls.RecordHeaderError
+			if errors.As(err, &recordErr) {
+				return nil, fmt.Errorf("server did not answer with TLS, it may only accept the %q or %q TLS modes: %w", TLSModeStartTLS, TLSModeNone, err)
+			}
+
 			return nil, fmt.Errorf("failed to establish TLS connection to server: %w", err)
 		}
 
 		return conn, nil
 	}
 
-	var d net.Dialer
-	conn, err = d.DialContext(ctx, "tcp", c.address)
+	conn, err := dialer.DialContext(ctx, "tcp", c.address)
 	if err != nil {
 		return nil, fmt.Errorf("failed to establish connection to server: %w", err)
 	}
@@ -0,0 +1,195 @@
+package client
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"log/slog"
+	"net"
+	"net/mail"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// server is a plain SMTP server accepting every email.
+type server struct {
+	listener net.Listener
+	// conns is the number of accepted connections.
+	conns atomic.Int64
+	// greet is whether the server sends its greeting.
+	greet bool
+}
+
+func newServer(t *testing.T, greet bool) *server {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = listener.Close() })
+
+	server := &server{listener: listener, greet: greet}
+	go server.serve()
+
+	return server
+}
+
+func (server *server) serve() {
+	for {
+		conn, err := server.listener.Accept()
+		if err != nil {
+			return
+		}
+
+		server.conns.Add(1)
+		go server.handle(conn)
+	}
+}
+
+func (server *server) handle(conn net.Conn) {
+	defer conn.Close() //nolint:errcheck
+
+	reader := bufio.NewReader(conn)
+	if !server.greet {
+		_, _ = io.Copy(io.Discard, reader)
+		return
+	}
+
+	_, _ = io.WriteString(conn, "220 localhost ESMTP\r\n")
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return
+		}
+
+		command := strings.ToUpper(strings.TrimSpace(line))
+		switch {
+		case strings.HasPrefix(command, "EHLO"):
+			_, _ = io.WriteString(conn, "250-localhost\r\n250 8BITMIME\r\n")
+		case command == "DATA":
+			_, _ = io.WriteString(conn, "354 go ahead\r\n")
+			for {
+				line, err := reader.ReadString('\n')
+				if err != nil {
+					return
+				}
+
+				if line == ".\r\n" {
+					break
+				}
+			}
+			_, _ = io.WriteString(conn, "250 queued\r\n")
+		case command == "QUIT":
+			_, _ = io.WriteString(conn, "221 bye\r\n")
+			return
+		default:
+			_, _ = io.WriteString(conn, "250 ok\r\n")
+		}
+	}
+}
+
+func newTestClient(t *testing.T, address string, opts ...Option) *Client {
+	client, err := New(address, slog.New(slog.NewTextHandler(io.Discard, nil)), opts...)
+	require.NoError(t, err)
+	t.Cleanup(client.Close)
+
+	return client
+}
+
+func TestNewTLSMode(t *testing.T) {
+	testCases := []struct {
+		name     string
+		tls      TLS
+		port     string
+		expected TLSMode
+		fail     bool
+	}{
+		{name: "Default", tls: TLS{}, port: "25", expected: TLSModeAuto},
+		{name: "DefaultOn465", tls: TLS{}, port: "465", expected: TLSModeImplicit},
+		{name: "Enabled", tls: TLS{Enabled: true}, port: "587", expected: TLSModeImplicit},
+		{name: "StartTLSOn465", tls: TLS{Mode: TLSModeStartTLS}, port: "465", expected: TLSModeStartTLS},
+		{name: "Unknown", tls: TLS{Mode: "ssl"}, port: "25", fail: true},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			mode, err := newTLSMode(testCase.tls, testCase.port)
+			if testCase.fail {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expected, mode)
+		})
+	}
+}
+
+func TestClientDoReusesConnections(t *testing.T) {
+	server := newServer(t, true)
+	client := newTestClient(t, server.listener.Addr().String(), WithTLS(TLS{Mode: TLSModeNone}), WithPool(Pool{MaxIdleConns: 1, IdleTimeout: time.Minute}))
+
+	to := []*mail.Address{{Address: "to@signoz.localhost"}}
+	for i := 0; i < 3; i++ {
+		require.NoError(t, client.Do(context.Background(), to, "subject", ContentTypeText, []byte("body")))
+	}
+
+	assert.Equal(t, int64(1), server.conns.Load())
+}
+
+func TestClientClose(t *testing.T) {
+	server := newServer(t, true)
+	client := newTestClient(t, server.listener.Addr().String(), WithTLS(TLS{Mode: TLSModeNone}), WithPool(Pool{MaxIdleConns: 1, IdleTimeout: time.Minute}))
+
+	to := []*mail.Address{{Address: "to@signoz.localhost"}}
+	require.NoError(t, client.Do(context.Background(), to, "subject", ContentTypeText, []byte("body")))
+	client.Close()
+
+	// The sessions are not kept once the client is closed.
+	require.NoError(t, client.Do(context.Background(), to, "subject", ContentTypeText, []byte("body")))
+	require.NoError(t, client.Do(context.Background(), to, "subject", ContentTypeText, []byte("body")))
+	assert.Equal(t, int64(3), server.conns.Load())
+}
+
+func TestClientDoWithoutPool(t *testing.T) {
+	server := newServer(t, true)
+	client := newTestClient(t, server.listener.Addr().String(), WithTLS(TLS{Mode: TLSModeNone}))
+
+	to := []*mail.Address{{Address: "to@signoz.localhost"}}
+	for i := 0; i < 2; i++ {
+		require.NoError(t, client.Do(context.Background(), to, "subject", ContentTypeText, []byte("body")))
+	}
+
+	assert.Equal(t, int64(2), server.conns.Load())
+}
+
+func TestClientDoStartTLSNotSupported(t *testing.T) {
+	server := newServer(t, true)
+	client := newTestClient(t, server.listener.Addr().String(), WithTLS(TLS{Mode: TLSModeStartTLS}))
+
+	err := client.Do(context.Background(), []*mail.Address{{Address: "to@signoz.localhost"}}, "subject", ContentTypeText, []byte("body"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "server does not support STARTTLS")
+}
+
+func TestClientDoImplicitTLSOnPlainServer(t *testing.T) {
+	server := newServer(t, true)
+	client := newTestClient(t, server.listener.Addr().String(), WithTLS(TLS{Mode: TLSModeImplicit}), WithTimeout(5*time.Second))
+
+	err := client.Do(context.Background(), []*mail.Address{{Address: "to@signoz.localhost"}}, "subject", ContentTypeText, []byte("body"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "server did not answer with TLS")
+}
+
+func TestClientDoTimesOutWithoutGreeting(t *testing.T) {
+	server := newServer(t, false)
+	client := newTestClient(t, server.listener.Addr().String(), WithTLS(TLS{Mode: TLSModeAuto}), WithTimeout(200*time.Millisecond))
+
+	start := time.Now()
+	err := client.Do(context.Background(), []*mail.Address{{Address: "to@signoz.localhost"}}, "subject", ContentTypeText, []byte("body"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "the server may only accept implicit TLS")
+	assert.Less(t, time.Since(start), 5*time.Second)
+}
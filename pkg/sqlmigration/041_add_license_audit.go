@@ -0,0 +1,89 @@
+package sqlmigration
+
+import (
+	"context"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/factory"
+	"github.com/SigNoz/signoz/pkg/sqlstore"
+	"github.com/SigNoz/signoz/pkg/types"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/migrate"
+)
+
+type licenseAudit struct {
+	bun.BaseModel `bun:"table:license_audit"`
+
+	types.Identifiable
+	Type            string    `bun:"type,type:text,notnull"`
+	Actor           string    `bun:"actor,type:text,notnull"`
+	OldLicenseID    string    `bun:"old_license_id,type:text"`
+	NewLicenseID    string    `bun:"new_license_id,type:text"`
+	AddedFeatures   string    `bun:"added_features,type:text"`
+	RemovedFeatures string    `bun:"removed_features,type:text"`
+	CreatedAt       time.Time `bun:"created_at,notnull"`
+	OrgID           string    `bun:"org_id,type:text,notnull"`
+}
+
+type addLicenseAudit struct {
+	sqlstore sqlstore.SQLStore
+}
+
+func NewAddLicenseAuditFactory(sqlstore sqlstore.SQLStore) factory.ProviderFactory[SQLMigration, Config] {
+	return factory.NewProviderFactory(factory.MustNewName("add_license_audit"), func(ctx context.Context, providerSettings factory.ProviderSettings, config Config) (SQLMigration, error) {
+		return newAddLicenseAudit(ctx, providerSettings, config, sqlstore)
+	})
+}
+
+func newAddLicenseAudit(_ context.Context, _ factory.ProviderSettings, _ Config, sqlstore sqlstore.SQLStore) (SQLMigration, error) {
+	return &addLicenseAudit{sqlstore: sqlstore}, nil
+}
+
+func (migration *addLicenseAudit) Register(migrations *migrate.Migrations) error {
+	if err := migrations.Register(migration.Up, migration.Down); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (migration *addLicenseAudit) Up(ctx context.Context, db *bun.DB) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	_, err = tx.NewCreateTable().
+		Model(new(licenseAudit)).
+		ForeignKey(`("org_id") REFERENCES "organizations" ("id") ON DELETE CASCADE`).
+		IfNotExists().
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.NewCreateIndex().
+		Model(new(licenseAudit)).
+		Index("idx_license_audit_org_id_created_at").
+		Column("org_id", "created_at").
+		IfNotExists().
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (migration *addLicenseAudit) Down(ctx context.Context, db *bun.DB) error {
+	return nil
+}
@@ -0,0 +1,82 @@
+package sqlmigration
+
+import (
+	"context"
+
+	"github.com/SigNoz/signoz/pkg/factory"
+	"github.com/SigNoz/signoz/pkg/sqlstore"
+	"github.com/SigNoz/signoz/pkg/types"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/migrate"
+)
+
+type alertmanagerDeadLetter struct {
+	bun.BaseModel `bun:"table:alertmanager_dead_letter"`
+
+	types.Identifiable
+	types.TimeAuditable
+	OrgID               string `bun:"org_id,type:text,notnull"`
+	Receiver            string `bun:"receiver,type:text,notnull"`
+	GroupKey            string `bun:"group_key,type:text,notnull"`
+	GroupLabels         string `bun:"group_labels,type:text"`
+	RouteID             string `bun:"route_id,type:text"`
+	RepeatInterval      int64  `bun:"repeat_interval"`
+	MuteTimeIntervals   string `bun:"mute_time_intervals,type:text"`
+	ActiveTimeIntervals string `bun:"active_time_intervals,type:text"`
+	Alerts              string `bun:"alerts,type:text,notnull"`
+	LastError           string `bun:"last_error,type:text"`
+	Attempts            int    `bun:"attempts"`
+}
+
+type addAlertmanagerDeadLetter struct {
+	sqlstore sqlstore.SQLStore
+}
+
+func NewAddAlertmanagerDeadLetterFactory(sqlstore sqlstore.SQLStore) factory.ProviderFactory[SQLMigration, Config] {
+	return factory.NewProviderFactory(factory.MustNewName("add_alertmanager_dead_letter"), func(ctx context.Context, providerSettings factory.ProviderSettings, config Config) (SQLMigration, error) {
+		return newAddAlertmanagerDeadLetter(ctx, providerSettings, config, sqlstore)
+	})
+}
+
+func newAddAlertmanagerDeadLetter(_ context.Context, _ factory.ProviderSettings, _ Config, sqlstore sqlstore.SQLStore) (SQLMigration, error) {
+	return &addAlertmanagerDeadLetter{sqlstore: sqlstore}, nil
+}
+
+func (migration *addAlertmanagerDeadLetter) Register(migrations *migrate.Migrations) error {
+	if err := migrations.Register(migration.Up, migration.Down); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (migration *addAlertmanagerDeadLetter) Up(ctx context.Context, db *bun.DB) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	_, err = tx.NewCreateTable().
+		Model(new(alertmanagerDeadLetter)).
+		ForeignKey(`("org_id") REFERENCES "organizations" ("id") ON DELETE CASCADE`).
+		IfNotExists().
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (migration *addAlertmanagerDeadLetter) Down(ctx context.Context, db *bun.DB) error {
+	return nil
+}
@@ -0,0 +1,89 @@
+package sqlmigration
+
+import (
+	"context"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/factory"
+	"github.com/SigNoz/signoz/pkg/sqlstore"
+	"github.com/SigNoz/signoz/pkg/types"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/migrate"
+)
+
+type refreshToken struct {
+	bun.BaseModel `bun:"table:refresh_token"`
+
+	types.TimeAuditable
+	ID        string     `bun:"id,pk,type:text"`
+	FamilyID  string     `bun:"family_id,type:text,notnull"`
+	UserID    string     `bun:"user_id,type:text,notnull"`
+	OrgID     string     `bun:"org_id,type:text,notnull"`
+	ExpiresAt time.Time  `bun:"expires_at,notnull"`
+	RotatedAt *time.Time `bun:"rotated_at"`
+	RevokedAt *time.Time `bun:"revoked_at"`
+}
+
+type addRefreshToken struct {
+	sqlstore sqlstore.SQLStore
+}
+
+func NewAddRefreshTokenFactory(sqlstore sqlstore.SQLStore) factory.ProviderFactory[SQLMigration, Config] {
+	return factory.NewProviderFactory(factory.MustNewName("add_refresh_token"), func(ctx context.Context, providerSettings factory.ProviderSettings, config Config) (SQLMigration, error) {
+		return newAddRefreshToken(ctx, providerSettings, config, sqlstore)
+	})
+}
+
+func newAddRefreshToken(_ context.Context, _ factory.ProviderSettings, _ Config, sqlstore sqlstore.SQLStore) (SQLMigration, error) {
+	return &addRefreshToken{sqlstore: sqlstore}, nil
+}
+
+func (migration *addRefreshToken) Register(migrations *migrate.Migrations) error {
+	if err := migrations.Register(migration.Up, migration.Down); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (migration *addRefreshToken) Up(ctx context.Context, db *bun.DB) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	_, err = tx.NewCreateTable().
+		Model(new(refreshToken)).
+		ForeignKey(`("user_id") REFERENCES "users" ("id") ON DELETE CASCADE`).
+		ForeignKey(`("org_id") REFERENCES "organizations" ("id") ON DELETE CASCADE`).
+		IfNotExists().
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.NewCreateIndex().
+		Model(new(refreshToken)).
+		Index("idx_refresh_token_family_id").
+		Column("family_id").
+		IfNotExists().
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (migration *addRefreshToken) Down(ctx context.Context, db *bun.DB) error {
+	return nil
+}
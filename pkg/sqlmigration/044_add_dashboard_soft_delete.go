@@ -0,0 +1,80 @@
+package sqlmigration
+
+import (
+	"context"
+
+	"github.com/SigNoz/signoz/pkg/factory"
+	"github.com/SigNoz/signoz/pkg/sqlstore"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/migrate"
+)
+
+type addDashboardSoftDelete struct {
+	sqlstore sqlstore.SQLStore
+}
+
+func NewAddDashboardSoftDeleteFactory(sqlstore sqlstore.SQLStore) factory.ProviderFactory[SQLMigration, Config] {
+	return factory.NewProviderFactory(factory.MustNewName("add_dashboard_soft_delete"), func(ctx context.Context, providerSettings factory.ProviderSettings, config Config) (SQLMigration, error) {
+		return newAddDashboardSoftDelete(ctx, providerSettings, config, sqlstore)
+	})
+}
+
+func newAddDashboardSoftDelete(_ context.Context, _ factory.ProviderSettings, _ Config, sqlstore sqlstore.SQLStore) (SQLMigration, error) {
+	return &addDashboardSoftDelete{sqlstore: sqlstore}, nil
+}
+
+func (migration *addDashboardSoftDelete) Register(migrations *migrate.Migrations) error {
+	if err := migrations.Register(migration.Up, migration.Down); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (migration *addDashboardSoftDelete) Up(ctx context.Context, db *bun.DB) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	columns := []struct {
+		name string
+		expr string
+	}{
+		{"deleted_at", "TIMESTAMP"},
+		{"deleted_by", "TEXT"},
+		{"restored_at", "TIMESTAMP"},
+		{"restored_by", "TEXT"},
+	}
+
+	for _, column := range columns {
+		if err := migration.sqlstore.Dialect().AddColumn(ctx, tx, "dashboard", column.name, column.expr); err != nil {
+			return err
+		}
+	}
+
+	_, err = tx.NewCreateIndex().
+		Table("dashboard").
+		Index("idx_dashboard_deleted_at").
+		Column("deleted_at").
+		IfNotExists().
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (migration *addDashboardSoftDelete) Down(ctx context.Context, db *bun.DB) error {
+	return nil
+}
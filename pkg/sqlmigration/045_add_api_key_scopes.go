@@ -0,0 +1,132 @@
+package sqlmigration
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+
+	"github.com/SigNoz/signoz/pkg/factory"
+	"github.com/SigNoz/signoz/pkg/sqlstore"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/migrate"
+)
+
+type addAPIKeyScopes struct {
+	sqlstore sqlstore.SQLStore
+}
+
+func NewAddAPIKeyScopesFactory(sqlstore sqlstore.SQLStore) factory.ProviderFactory[SQLMigration, Config] {
+	return factory.NewProviderFactory(factory.MustNewName("add_api_key_scopes"), func(ctx context.Context, providerSettings factory.ProviderSettings, config Config) (SQLMigration, error) {
+		return newAddAPIKeyScopes(ctx, providerSettings, config, sqlstore)
+	})
+}
+
+func newAddAPIKeyScopes(_ context.Context, _ factory.ProviderSettings, _ Config, sqlstore sqlstore.SQLStore) (SQLMigration, error) {
+	return &addAPIKeyScopes{sqlstore: sqlstore}, nil
+}
+
+func (migration *addAPIKeyScopes) Register(migrations *migrate.Migrations) error {
+	if err := migrations.Register(migration.Up, migration.Down); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (migration *addAPIKeyScopes) Up(ctx context.Context, db *bun.DB) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	columns := []struct {
+		name string
+		expr string
+	}{
+		{"token_prefix", "TEXT"},
+		{"scopes", "TEXT"},
+	}
+
+	for _, column := range columns {
+		if err := migration.sqlstore.Dialect().AddColumn(ctx, tx, "factor_api_key", column.name, column.expr); err != nil {
+			return err
+		}
+	}
+
+	// the tokens were stored in plaintext, they are replaced by their hash and their prefix is kept to tell the keys apart
+	var apiKeys []struct {
+		ID    string `bun:"id"`
+		Token string `bun:"token"`
+	}
+	if err := tx.NewSelect().
+		Table("factor_api_key").
+		Column("id", "token").
+		Where("token_prefix IS NULL").
+		Scan(ctx, &apiKeys); err != nil {
+		return err
+	}
+
+	for _, apiKey := range apiKeys {
+		sum := sha256.Sum256([]byte(apiKey.Token))
+
+		prefix := apiKey.Token
+		if len(prefix) > 8 {
+			prefix = prefix[:8]
+		}
+
+		if _, err := tx.
+			NewUpdate().
+			Table("factor_api_key").
+			Set("token = ?", hex.EncodeToString(sum[:])).
+			Set("token_prefix = ?", prefix).
+			Where("id = ?", apiKey.ID).
+			Exec(ctx); err != nil {
+			return err
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (migration *addAPIKeyScopes) Down(ctx context.Context, db *bun.DB) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	// the plaintext tokens cannot be recovered from their hash, the keys are revoked and have to be created again
+	if _, err := tx.
+		NewUpdate().
+		Table("factor_api_key").
+		Set("revoked = ?", true).
+		Where("token_prefix IS NOT NULL").
+		Exec(ctx); err != nil {
+		return err
+	}
+
+	for _, column := range []string{"token_prefix", "scopes"} {
+		if err := migration.sqlstore.Dialect().DropColumn(ctx, tx, "factor_api_key", column); err != nil {
+			return err
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,117 @@
+package sqlmigration
+
+import (
+	"context"
+
+	"github.com/SigNoz/signoz/pkg/factory"
+	"github.com/SigNoz/signoz/pkg/sqlstore"
+	"github.com/SigNoz/signoz/pkg/types"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/migrate"
+)
+
+type rbacRole struct {
+	bun.BaseModel `bun:"table:rbac_role"`
+
+	types.Identifiable
+	types.TimeAuditable
+	OrgID       string `bun:"org_id,type:text,notnull"`
+	Name        string `bun:"name,type:text,notnull"`
+	Description string `bun:"description,type:text"`
+	Permissions string `bun:"permissions,type:text,notnull"`
+}
+
+type rbacBinding struct {
+	bun.BaseModel `bun:"table:rbac_binding"`
+
+	types.Identifiable
+	types.TimeAuditable
+	OrgID   string `bun:"org_id,type:text,notnull"`
+	RoleID  string `bun:"role_id,type:text,notnull"`
+	Subject string `bun:"subject,type:text,notnull"`
+}
+
+type addRBAC struct {
+	sqlstore sqlstore.SQLStore
+}
+
+func NewAddRBACFactory(sqlstore sqlstore.SQLStore) factory.ProviderFactory[SQLMigration, Config] {
+	return factory.NewProviderFactory(factory.MustNewName("add_rbac"), func(ctx context.Context, providerSettings factory.ProviderSettings, config Config) (SQLMigration, error) {
+		return newAddRBAC(ctx, providerSettings, config, sqlstore)
+	})
+}
+
+func newAddRBAC(_ context.Context, _ factory.ProviderSettings, _ Config, sqlstore sqlstore.SQLStore) (SQLMigration, error) {
+	return &addRBAC{sqlstore: sqlstore}, nil
+}
+
+func (migration *addRBAC) Register(migrations *migrate.Migrations) error {
+	if err := migrations.Register(migration.Up, migration.Down); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (migration *addRBAC) Up(ctx context.Context, db *bun.DB) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	_, err = tx.NewCreateTable().
+		Model(new(rbacRole)).
+		ForeignKey(`("org_id") REFERENCES "organizations" ("id") ON DELETE CASCADE`).
+		IfNotExists().
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.NewCreateTable().
+		Model(new(rbacBinding)).
+		ForeignKey(`("org_id") REFERENCES "organizations" ("id") ON DELETE CASCADE`).
+		ForeignKey(`("role_id") REFERENCES "rbac_role" ("id") ON DELETE CASCADE`).
+		IfNotExists().
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.NewCreateIndex().
+		Model(new(rbacRole)).
+		Unique().
+		Index("idx_rbac_role_org_id_name").
+		Column("org_id", "name").
+		IfNotExists().
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.NewCreateIndex().
+		Model(new(rbacBinding)).
+		Unique().
+		Index("idx_rbac_binding_org_id_subject_role_id").
+		Column("org_id", "subject", "role_id").
+		IfNotExists().
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (migration *addRBAC) Down(ctx context.Context, db *bun.DB) error {
+	return nil
+}
@@ -0,0 +1,125 @@
+package sqlmigration
+
+import (
+	"context"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/factory"
+	"github.com/SigNoz/signoz/pkg/sqlstore"
+	"github.com/SigNoz/signoz/pkg/types"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/migrate"
+)
+
+type dashboardPublicLink struct {
+	bun.BaseModel `bun:"table:dashboard_public_link"`
+
+	types.Identifiable
+	types.TimeAuditable
+	types.UserAuditable
+	OrgID        string     `bun:"org_id,type:text,notnull"`
+	DashboardID  string     `bun:"dashboard_id,type:text,notnull"`
+	TokenHash    string     `bun:"token_hash,type:text,notnull"`
+	TokenPrefix  string     `bun:"token_prefix,type:text,notnull"`
+	PasswordHash string     `bun:"password_hash,type:text"`
+	ExpiresAt    *time.Time `bun:"expires_at"`
+	RevokedAt    *time.Time `bun:"revoked_at"`
+	RevokedBy    string     `bun:"revoked_by,type:text"`
+}
+
+type dashboardPublicLinkAudit struct {
+	bun.BaseModel `bun:"table:dashboard_public_link_audit"`
+
+	types.Identifiable
+	OrgID       string    `bun:"org_id,type:text,notnull"`
+	DashboardID string    `bun:"dashboard_id,type:text,notnull"`
+	LinkID      string    `bun:"link_id,type:text,notnull"`
+	Type        string    `bun:"type,type:text,notnull"`
+	Actor       string    `bun:"actor,type:text,notnull"`
+	CreatedAt   time.Time `bun:"created_at,notnull"`
+}
+
+type addDashboardPublicLink struct {
+	sqlstore sqlstore.SQLStore
+}
+
+func NewAddDashboardPublicLinkFactory(sqlstore sqlstore.SQLStore) factory.ProviderFactory[SQLMigration, Config] {
+	return factory.NewProviderFactory(factory.MustNewName("add_dashboard_public_link"), func(ctx context.Context, providerSettings factory.ProviderSettings, config Config) (SQLMigration, error) {
+		return newAddDashboardPublicLink(ctx, providerSettings, config, sqlstore)
+	})
+}
+
+func newAddDashboardPublicLink(_ context.Context, _ factory.ProviderSettings, _ Config, sqlstore sqlstore.SQLStore) (SQLMigration, error) {
+	return &addDashboardPublicLink{sqlstore: sqlstore}, nil
+}
+
+func (migration *addDashboardPublicLink) Register(migrations *migrate.Migrations) error {
+	if err := migrations.Register(migration.Up, migration.Down); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (migration *addDashboardPublicLink) Up(ctx context.Context, db *bun.DB) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	_, err = tx.NewCreateTable().
+		Model(new(dashboardPublicLink)).
+		ForeignKey(`("org_id") REFERENCES "organizations" ("id") ON DELETE CASCADE`).
+		ForeignKey(`("dashboard_id") REFERENCES "dashboard" ("id") ON DELETE CASCADE`).
+		IfNotExists().
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	// the audit events outlive the links and the dashboards
+	_, err = tx.NewCreateTable().
+		Model(new(dashboardPublicLinkAudit)).
+		ForeignKey(`("org_id") REFERENCES "organizations" ("id") ON DELETE CASCADE`).
+		IfNotExists().
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.NewCreateIndex().
+		Model(new(dashboardPublicLink)).
+		Unique().
+		Index("idx_dashboard_public_link_token_hash").
+		Column("token_hash").
+		IfNotExists().
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.NewCreateIndex().
+		Model(new(dashboardPublicLinkAudit)).
+		Index("idx_dashboard_public_link_audit_org_id_dashboard_id_created_at").
+		Column("org_id", "dashboard_id", "created_at").
+		IfNotExists().
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (migration *addDashboardPublicLink) Down(ctx context.Context, db *bun.DB) error {
+	return nil
+}
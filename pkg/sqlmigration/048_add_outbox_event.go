@@ -0,0 +1,90 @@
+package sqlmigration
+
+import (
+	"context"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/factory"
+	"github.com/SigNoz/signoz/pkg/sqlstore"
+	"github.com/SigNoz/signoz/pkg/types"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/migrate"
+)
+
+type outboxEvent struct {
+	bun.BaseModel `bun:"table:outbox_event"`
+
+	types.Identifiable
+	OrgID         string     `bun:"org_id,type:text,notnull"`
+	Topic         string     `bun:"topic,type:text,notnull"`
+	Payload       string     `bun:"payload,type:text,notnull"`
+	CreatedAt     time.Time  `bun:"created_at,notnull"`
+	Attempts      int        `bun:"attempts,notnull"`
+	LastError     string     `bun:"last_error,type:text"`
+	NextAttemptAt time.Time  `bun:"next_attempt_at,notnull"`
+	SentAt        *time.Time `bun:"sent_at"`
+	FailedAt      *time.Time `bun:"failed_at"`
+}
+
+type addOutboxEvent struct {
+	sqlstore sqlstore.SQLStore
+}
+
+func NewAddOutboxEventFactory(sqlstore sqlstore.SQLStore) factory.ProviderFactory[SQLMigration, Config] {
+	return factory.NewProviderFactory(factory.MustNewName("add_outbox_event"), func(ctx context.Context, providerSettings factory.ProviderSettings, config Config) (SQLMigration, error) {
+		return newAddOutboxEvent(ctx, providerSettings, config, sqlstore)
+	})
+}
+
+func newAddOutboxEvent(_ context.Context, _ factory.ProviderSettings, _ Config, sqlstore sqlstore.SQLStore) (SQLMigration, error) {
+	return &addOutboxEvent{sqlstore: sqlstore}, nil
+}
+
+func (migration *addOutboxEvent) Register(migrations *migrate.Migrations) error {
+	if err := migrations.Register(migration.Up, migration.Down); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (migration *addOutboxEvent) Up(ctx context.Context, db *bun.DB) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	_, err = tx.NewCreateTable().
+		Model(new(outboxEvent)).
+		IfNotExists().
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	// the relay lists the pending events by their next attempt
+	_, err = tx.NewCreateIndex().
+		Model(new(outboxEvent)).
+		Index("idx_outbox_event_sent_at_failed_at_next_attempt_at").
+		Column("sent_at", "failed_at", "next_attempt_at").
+		IfNotExists().
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (migration *addOutboxEvent) Down(ctx context.Context, db *bun.DB) error {
+	return nil
+}
@@ -0,0 +1,56 @@
+package sqlmigration
+
+import (
+	"context"
+
+	"github.com/SigNoz/signoz/pkg/factory"
+	"github.com/SigNoz/signoz/pkg/sqlstore"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/migrate"
+)
+
+type migrationChecksum struct {
+	bun.BaseModel `bun:"table:migration_checksum"`
+
+	Name     string `bun:"name,pk,type:text"`
+	Checksum string `bun:"checksum,type:text,notnull"`
+}
+
+type addMigrationChecksum struct {
+	sqlstore sqlstore.SQLStore
+}
+
+func NewAddMigrationChecksumFactory(sqlstore sqlstore.SQLStore) factory.ProviderFactory[SQLMigration, Config] {
+	return factory.NewProviderFactory(factory.MustNewName("add_migration_checksum"), func(ctx context.Context, providerSettings factory.ProviderSettings, config Config) (SQLMigration, error) {
+		return newAddMigrationChecksum(ctx, providerSettings, config, sqlstore)
+	})
+}
+
+func newAddMigrationChecksum(_ context.Context, _ factory.ProviderSettings, _ Config, sqlstore sqlstore.SQLStore) (SQLMigration, error) {
+	return &addMigrationChecksum{sqlstore: sqlstore}, nil
+}
+
+func (migration *addMigrationChecksum) Register(migrations *migrate.Migrations) error {
+	if err := migrations.Register(migration.Up, migration.Down); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (migration *addMigrationChecksum) Up(ctx context.Context, db *bun.DB) error {
+	// the table was created by the migrator before this migration, hence if not exists
+	_, err := db.NewCreateTable().
+		Model(new(migrationChecksum)).
+		IfNotExists().
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (migration *addMigrationChecksum) Down(ctx context.Context, db *bun.DB) error {
+	return nil
+}
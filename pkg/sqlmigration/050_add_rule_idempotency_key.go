@@ -0,0 +1,60 @@
+package sqlmigration
+
+import (
+	"context"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/factory"
+	"github.com/SigNoz/signoz/pkg/sqlstore"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/migrate"
+)
+
+type ruleIdempotencyKey struct {
+	bun.BaseModel `bun:"table:rule_idempotency_key"`
+
+	OrgID     string    `bun:"org_id,pk,type:text"`
+	Key       string    `bun:"idempotency_key,pk,type:text"`
+	BodyHash  string    `bun:"body_hash,type:text,notnull"`
+	RuleID    string    `bun:"rule_id,type:text,notnull"`
+	CreatedAt time.Time `bun:"created_at,notnull"`
+}
+
+type addRuleIdempotencyKey struct {
+	sqlstore sqlstore.SQLStore
+}
+
+func NewAddRuleIdempotencyKeyFactory(sqlstore sqlstore.SQLStore) factory.ProviderFactory[SQLMigration, Config] {
+	return factory.NewProviderFactory(factory.MustNewName("add_rule_idempotency_key"), func(ctx context.Context, providerSettings factory.ProviderSettings, config Config) (SQLMigration, error) {
+		return newAddRuleIdempotencyKey(ctx, providerSettings, config, sqlstore)
+	})
+}
+
+func newAddRuleIdempotencyKey(_ context.Context, _ factory.ProviderSettings, _ Config, sqlstore sqlstore.SQLStore) (SQLMigration, error) {
+	return &addRuleIdempotencyKey{sqlstore: sqlstore}, nil
+}
+
+func (migration *addRuleIdempotencyKey) Register(migrations *migrate.Migrations) error {
+	if err := migrations.Register(migration.Up, migration.Down); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (migration *addRuleIdempotencyKey) Up(ctx context.Context, db *bun.DB) error {
+	_, err := db.NewCreateTable().
+		Model(new(ruleIdempotencyKey)).
+		ForeignKey(`("org_id") REFERENCES "organizations" ("id") ON DELETE CASCADE`).
+		IfNotExists().
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (migration *addRuleIdempotencyKey) Down(ctx context.Context, db *bun.DB) error {
+	return nil
+}
@@ -0,0 +1,58 @@
+package sqlmigration
+
+import (
+	"context"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/factory"
+	"github.com/SigNoz/signoz/pkg/sqlstore"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/migrate"
+)
+
+type maintenance struct {
+	bun.BaseModel `bun:"table:maintenance"`
+
+	ID        string     `bun:"id,pk,type:text"`
+	Enabled   bool       `bun:"enabled,notnull"`
+	Since     *time.Time `bun:"since,nullzero"`
+	UpdatedAt time.Time  `bun:"updated_at,notnull"`
+}
+
+type addMaintenance struct {
+	sqlstore sqlstore.SQLStore
+}
+
+func NewAddMaintenanceFactory(sqlstore sqlstore.SQLStore) factory.ProviderFactory[SQLMigration, Config] {
+	return factory.NewProviderFactory(factory.MustNewName("add_maintenance"), func(ctx context.Context, providerSettings factory.ProviderSettings, config Config) (SQLMigration, error) {
+		return newAddMaintenance(ctx, providerSettings, config, sqlstore)
+	})
+}
+
+func newAddMaintenance(_ context.Context, _ factory.ProviderSettings, _ Config, sqlstore sqlstore.SQLStore) (SQLMigration, error) {
+	return &addMaintenance{sqlstore: sqlstore}, nil
+}
+
+func (migration *addMaintenance) Register(migrations *migrate.Migrations) error {
+	if err := migrations.Register(migration.Up, migration.Down); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (migration *addMaintenance) Up(ctx context.Context, db *bun.DB) error {
+	_, err := db.NewCreateTable().
+		Model(new(maintenance)).
+		IfNotExists().
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (migration *addMaintenance) Down(ctx context.Context, db *bun.DB) error {
+	return nil
+}
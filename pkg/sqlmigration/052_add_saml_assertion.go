@@ -0,0 +1,69 @@
+package sqlmigration
+
+import (
+	"context"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/factory"
+	"github.com/SigNoz/signoz/pkg/sqlstore"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/migrate"
+)
+
+type samlAssertion struct {
+	bun.BaseModel `bun:"table:saml_assertion"`
+
+	ID        string    `bun:"id,pk,type:text"`
+	OrgID     string    `bun:"org_id,type:text,notnull"`
+	ExpiresAt time.Time `bun:"expires_at,notnull"`
+	CreatedAt time.Time `bun:"created_at,notnull"`
+}
+
+type addSamlAssertion struct {
+	sqlstore sqlstore.SQLStore
+}
+
+func NewAddSamlAssertionFactory(sqlstore sqlstore.SQLStore) factory.ProviderFactory[SQLMigration, Config] {
+	return factory.NewProviderFactory(factory.MustNewName("add_saml_assertion"), func(ctx context.Context, providerSettings factory.ProviderSettings, config Config) (SQLMigration, error) {
+		return newAddSamlAssertion(ctx, providerSettings, config, sqlstore)
+	})
+}
+
+func newAddSamlAssertion(_ context.Context, _ factory.ProviderSettings, _ Config, sqlstore sqlstore.SQLStore) (SQLMigration, error) {
+	return &addSamlAssertion{sqlstore: sqlstore}, nil
+}
+
+func (migration *addSamlAssertion) Register(migrations *migrate.Migrations) error {
+	if err := migrations.Register(migration.Up, migration.Down); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (migration *addSamlAssertion) Up(ctx context.Context, db *bun.DB) error {
+	_, err := db.NewCreateTable().
+		Model(new(samlAssertion)).
+		ForeignKey(`("org_id") REFERENCES "organizations" ("id") ON DELETE CASCADE`).
+		IfNotExists().
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = db.NewCreateIndex().
+		Model(new(samlAssertion)).
+		Index("idx_saml_assertion_expires_at").
+		Column("expires_at").
+		IfNotExists().
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (migration *addSamlAssertion) Down(ctx context.Context, db *bun.DB) error {
+	return nil
+}
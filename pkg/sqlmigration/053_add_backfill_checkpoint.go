@@ -0,0 +1,48 @@
+package sqlmigration
+
+import (
+	"context"
+
+	"github.com/SigNoz/signoz/pkg/factory"
+	"github.com/SigNoz/signoz/pkg/sqlstore"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/migrate"
+)
+
+type addBackfillCheckpoint struct {
+	sqlstore sqlstore.SQLStore
+}
+
+func NewAddBackfillCheckpointFactory(sqlstore sqlstore.SQLStore) factory.ProviderFactory[SQLMigration, Config] {
+	return factory.NewProviderFactory(factory.MustNewName("add_backfill_checkpoint"), func(ctx context.Context, providerSettings factory.ProviderSettings, config Config) (SQLMigration, error) {
+		return newAddBackfillCheckpoint(ctx, providerSettings, config, sqlstore)
+	})
+}
+
+func newAddBackfillCheckpoint(_ context.Context, _ factory.ProviderSettings, _ Config, sqlstore sqlstore.SQLStore) (SQLMigration, error) {
+	return &addBackfillCheckpoint{sqlstore: sqlstore}, nil
+}
+
+func (migration *addBackfillCheckpoint) Register(migrations *migrate.Migrations) error {
+	if err := migrations.Register(migration.Up, migration.Down); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (migration *addBackfillCheckpoint) Up(ctx context.Context, db *bun.DB) error {
+	_, err := db.NewCreateTable().
+		Model(new(backfillCheckpoint)).
+		IfNotExists().
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (migration *addBackfillCheckpoint) Down(ctx context.Context, db *bun.DB) error {
+	return nil
+}
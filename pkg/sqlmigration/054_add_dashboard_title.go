@@ -0,0 +1,103 @@
+package sqlmigration
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/SigNoz/signoz/pkg/factory"
+	"github.com/SigNoz/signoz/pkg/sqlstore"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/migrate"
+)
+
+type addDashboardTitle struct {
+	sqlstore sqlstore.SQLStore
+	backfill *Backfill
+}
+
+func NewAddDashboardTitleFactory(sqlstore sqlstore.SQLStore) factory.ProviderFactory[SQLMigration, Config] {
+	return factory.NewProviderFactory(factory.MustNewName("add_dashboard_title"), func(ctx context.Context, providerSettings factory.ProviderSettings, config Config) (SQLMigration, error) {
+		return newAddDashboardTitle(ctx, providerSettings, config, sqlstore)
+	})
+}
+
+func newAddDashboardTitle(_ context.Context, providerSettings factory.ProviderSettings, config Config, sqlstore sqlstore.SQLStore) (SQLMigration, error) {
+	backfill, err := NewBackfill(providerSettings, config.Backfill, "add_dashboard_title", "dashboard", "id", fillDashboardTitles)
+	if err != nil {
+		return nil, err
+	}
+
+	return &addDashboardTitle{sqlstore: sqlstore, backfill: backfill}, nil
+}
+
+func (migration *addDashboardTitle) Register(migrations *migrate.Migrations) error {
+	if err := migrations.Register(migration.Up, migration.Down); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Up adds the title column to the dashboards, filled from the title in their data. The dashboards can be many and
+// their data large, hence the column is filled by a backfill rather than a single update locking the table.
+func (migration *addDashboardTitle) Up(ctx context.Context, db *bun.DB) error {
+	if err := migration.sqlstore.Dialect().AddColumn(ctx, db, "dashboard", "title", "TEXT"); err != nil {
+		return err
+	}
+
+	_, err := db.NewCreateIndex().
+		Table("dashboard").
+		Index("idx_dashboard_org_id_title").
+		Column("org_id", "title").
+		IfNotExists().
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	return migration.backfill.Run(ctx, db)
+}
+
+func (migration *addDashboardTitle) Down(ctx context.Context, db *bun.DB) error {
+	return nil
+}
+
+func fillDashboardTitles(ctx context.Context, tx bun.Tx, ids []string) error {
+	var dashboards []struct {
+		ID   string `bun:"id"`
+		Data string `bun:"data"`
+	}
+
+	err := tx.NewSelect().
+		Table("dashboard").
+		Column("id", "data").
+		Where("id IN (?)", bun.In(ids)).
+		Scan(ctx, &dashboards)
+	if err != nil {
+		return err
+	}
+
+	for _, dashboard := range dashboards {
+		var data map[string]any
+		// The dashboards whose data cannot be read keep an empty title.
+		if err := json.Unmarshal([]byte(dashboard.Data), &data); err != nil {
+			continue
+		}
+
+		title, _ := data["title"].(string)
+		if title == "" {
+			continue
+		}
+
+		_, err := tx.NewUpdate().
+			Table("dashboard").
+			Set("title = ?", title).
+			Where("id = ?", dashboard.ID).
+			Exec(ctx)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,42 @@
+package sqlmigration
+
+import (
+	"context"
+
+	"github.com/SigNoz/signoz/pkg/factory"
+	"github.com/SigNoz/signoz/pkg/sqlstore"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/migrate"
+)
+
+type addChannelSigningSecret struct {
+	sqlstore sqlstore.SQLStore
+}
+
+func NewAddChannelSigningSecretFactory(sqlstore sqlstore.SQLStore) factory.ProviderFactory[SQLMigration, Config] {
+	return factory.NewProviderFactory(factory.MustNewName("add_channel_signing_secret"), func(ctx context.Context, providerSettings factory.ProviderSettings, config Config) (SQLMigration, error) {
+		return newAddChannelSigningSecret(ctx, providerSettings, config, sqlstore)
+	})
+}
+
+func newAddChannelSigningSecret(_ context.Context, _ factory.ProviderSettings, _ Config, sqlstore sqlstore.SQLStore) (SQLMigration, error) {
+	return &addChannelSigningSecret{sqlstore: sqlstore}, nil
+}
+
+func (migration *addChannelSigningSecret) Register(migrations *migrate.Migrations) error {
+	if err := migrations.Register(migration.Up, migration.Down); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Up adds the secret signing the webhook notifications to the notification channels, so that it is scoped to the
+// channel of its organization.
+func (migration *addChannelSigningSecret) Up(ctx context.Context, db *bun.DB) error {
+	return migration.sqlstore.Dialect().AddColumn(ctx, db, "notification_channel", "signing_secret", "TEXT")
+}
+
+func (migration *addChannelSigningSecret) Down(ctx context.Context, db *bun.DB) error {
+	return nil
+}
@@ -0,0 +1,56 @@
+package sqlmigration
+
+import (
+	"context"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/factory"
+	"github.com/SigNoz/signoz/pkg/sqlstore"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/migrate"
+)
+
+type jobRun struct {
+	bun.BaseModel `bun:"table:job_run"`
+
+	Name      string    `bun:"name,pk,type:text"`
+	LastRunAt time.Time `bun:"last_run_at,notnull"`
+}
+
+type addJobRun struct {
+	sqlstore sqlstore.SQLStore
+}
+
+func NewAddJobRunFactory(sqlstore sqlstore.SQLStore) factory.ProviderFactory[SQLMigration, Config] {
+	return factory.NewProviderFactory(factory.MustNewName("add_job_run"), func(ctx context.Context, providerSettings factory.ProviderSettings, config Config) (SQLMigration, error) {
+		return newAddJobRun(ctx, providerSettings, config, sqlstore)
+	})
+}
+
+func newAddJobRun(_ context.Context, _ factory.ProviderSettings, _ Config, sqlstore sqlstore.SQLStore) (SQLMigration, error) {
+	return &addJobRun{sqlstore: sqlstore}, nil
+}
+
+func (migration *addJobRun) Register(migrations *migrate.Migrations) error {
+	if err := migrations.Register(migration.Up, migration.Down); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (migration *addJobRun) Up(ctx context.Context, db *bun.DB) error {
+	_, err := db.NewCreateTable().
+		Model(new(jobRun)).
+		IfNotExists().
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (migration *addJobRun) Down(ctx context.Context, db *bun.DB) error {
+	return nil
+}
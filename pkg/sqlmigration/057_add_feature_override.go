@@ -0,0 +1,58 @@
+package sqlmigration
+
+import (
+	"context"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/factory"
+	"github.com/SigNoz/signoz/pkg/sqlstore"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/migrate"
+)
+
+type featureOverride struct {
+	bun.BaseModel `bun:"table:feature_override"`
+
+	OrgID     string    `bun:"org_id,pk,type:text"`
+	Name      string    `bun:"name,pk,type:text"`
+	Active    bool      `bun:"active,notnull"`
+	UpdatedAt time.Time `bun:"updated_at,notnull"`
+}
+
+type addFeatureOverride struct {
+	sqlstore sqlstore.SQLStore
+}
+
+func NewAddFeatureOverrideFactory(sqlstore sqlstore.SQLStore) factory.ProviderFactory[SQLMigration, Config] {
+	return factory.NewProviderFactory(factory.MustNewName("add_feature_override"), func(ctx context.Context, providerSettings factory.ProviderSettings, config Config) (SQLMigration, error) {
+		return newAddFeatureOverride(ctx, providerSettings, config, sqlstore)
+	})
+}
+
+func newAddFeatureOverride(_ context.Context, _ factory.ProviderSettings, _ Config, sqlstore sqlstore.SQLStore) (SQLMigration, error) {
+	return &addFeatureOverride{sqlstore: sqlstore}, nil
+}
+
+func (migration *addFeatureOverride) Register(migrations *migrate.Migrations) error {
+	if err := migrations.Register(migration.Up, migration.Down); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (migration *addFeatureOverride) Up(ctx context.Context, db *bun.DB) error {
+	_, err := db.NewCreateTable().
+		Model(new(featureOverride)).
+		IfNotExists().
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (migration *addFeatureOverride) Down(ctx context.Context, db *bun.DB) error {
+	return nil
+}
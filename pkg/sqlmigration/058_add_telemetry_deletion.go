@@ -0,0 +1,66 @@
+package sqlmigration
+
+import (
+	"context"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/factory"
+	"github.com/SigNoz/signoz/pkg/sqlstore"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/migrate"
+)
+
+type telemetryDeletion struct {
+	bun.BaseModel `bun:"table:telemetry_deletion"`
+
+	ID           string     `bun:"id,pk,type:text"`
+	OrgID        string     `bun:"org_id,type:text,notnull"`
+	Signal       string     `bun:"signal,type:text,notnull"`
+	Status       string     `bun:"status,type:text,notnull"`
+	Matchers     string     `bun:"matchers,type:text,notnull"`
+	StartTime    time.Time  `bun:"start_time,notnull"`
+	EndTime      time.Time  `bun:"end_time,notnull"`
+	Series       uint64     `bun:"series,notnull"`
+	AffectedRows uint64     `bun:"affected_rows,notnull"`
+	Error        string     `bun:"error,type:text"`
+	CreatedAt    time.Time  `bun:"created_at,notnull"`
+	FinishedAt   *time.Time `bun:"finished_at,nullzero"`
+}
+
+type addTelemetryDeletion struct {
+	sqlstore sqlstore.SQLStore
+}
+
+func NewAddTelemetryDeletionFactory(sqlstore sqlstore.SQLStore) factory.ProviderFactory[SQLMigration, Config] {
+	return factory.NewProviderFactory(factory.MustNewName("add_telemetry_deletion"), func(ctx context.Context, providerSettings factory.ProviderSettings, config Config) (SQLMigration, error) {
+		return newAddTelemetryDeletion(ctx, providerSettings, config, sqlstore)
+	})
+}
+
+func newAddTelemetryDeletion(_ context.Context, _ factory.ProviderSettings, _ Config, sqlstore sqlstore.SQLStore) (SQLMigration, error) {
+	return &addTelemetryDeletion{sqlstore: sqlstore}, nil
+}
+
+func (migration *addTelemetryDeletion) Register(migrations *migrate.Migrations) error {
+	if err := migrations.Register(migration.Up, migration.Down); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (migration *addTelemetryDeletion) Up(ctx context.Context, db *bun.DB) error {
+	_, err := db.NewCreateTable().
+		Model(new(telemetryDeletion)).
+		IfNotExists().
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (migration *addTelemetryDeletion) Down(ctx context.Context, db *bun.DB) error {
+	return nil
+}
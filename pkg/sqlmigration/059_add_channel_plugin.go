@@ -0,0 +1,42 @@
+package sqlmigration
+
+import (
+	"context"
+
+	"github.com/SigNoz/signoz/pkg/factory"
+	"github.com/SigNoz/signoz/pkg/sqlstore"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/migrate"
+)
+
+type addChannelPlugin struct {
+	sqlstore sqlstore.SQLStore
+}
+
+func NewAddChannelPluginFactory(sqlstore sqlstore.SQLStore) factory.ProviderFactory[SQLMigration, Config] {
+	return factory.NewProviderFactory(factory.MustNewName("add_channel_plugin"), func(ctx context.Context, providerSettings factory.ProviderSettings, config Config) (SQLMigration, error) {
+		return newAddChannelPlugin(ctx, providerSettings, config, sqlstore)
+	})
+}
+
+func newAddChannelPlugin(_ context.Context, _ factory.ProviderSettings, _ Config, sqlstore sqlstore.SQLStore) (SQLMigration, error) {
+	return &addChannelPlugin{sqlstore: sqlstore}, nil
+}
+
+func (migration *addChannelPlugin) Register(migrations *migrate.Migrations) error {
+	if err := migrations.Register(migration.Up, migration.Down); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Up adds the receiver plugin to the notification channels, so that it is selected by the channel of its
+// organization.
+func (migration *addChannelPlugin) Up(ctx context.Context, db *bun.DB) error {
+	return migration.sqlstore.Dialect().AddColumn(ctx, db, "notification_channel", "plugin", "TEXT")
+}
+
+func (migration *addChannelPlugin) Down(ctx context.Context, db *bun.DB) error {
+	return nil
+}
@@ -0,0 +1,49 @@
+package sqlmigration
+
+import (
+	"context"
+
+	"github.com/SigNoz/signoz/pkg/factory"
+	"github.com/SigNoz/signoz/pkg/sqlstore"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/migrate"
+)
+
+type encryptSensitiveColumns struct {
+	encryptor *sqlstore.Encryptor
+}
+
+func NewEncryptSensitiveColumnsFactory(encryptor *sqlstore.Encryptor) factory.ProviderFactory[SQLMigration, Config] {
+	return factory.NewProviderFactory(factory.MustNewName("encrypt_sensitive_columns"), func(ctx context.Context, providerSettings factory.ProviderSettings, config Config) (SQLMigration, error) {
+		return newEncryptSensitiveColumns(ctx, providerSettings, config, encryptor)
+	})
+}
+
+func newEncryptSensitiveColumns(_ context.Context, _ factory.ProviderSettings, _ Config, encryptor *sqlstore.Encryptor) (SQLMigration, error) {
+	return &encryptSensitiveColumns{encryptor: encryptor}, nil
+}
+
+func (migration *encryptSensitiveColumns) Register(migrations *migrate.Migrations) error {
+	if err := migrations.Register(migration.Up, migration.Down); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Up encrypts the values of the sensitive columns written before they were encrypted. It does nothing when the
+// encryption is not enabled, the values are then encrypted by the rotation job once it is.
+func (migration *encryptSensitiveColumns) Up(ctx context.Context, db *bun.DB) error {
+	if !migration.encryptor.Enabled() {
+		return nil
+	}
+
+	return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		_, err := migration.encryptor.RotateColumns(ctx, tx)
+		return err
+	})
+}
+
+func (migration *encryptSensitiveColumns) Down(ctx context.Context, db *bun.DB) error {
+	return nil
+}
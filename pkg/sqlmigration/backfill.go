@@ -0,0 +1,202 @@
+package sqlmigration
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/factory"
+	"github.com/uptrace/bun"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+const (
+	// backfillProgressInterval is the minimum time between two logs of the progress of a backfill.
+	backfillProgressInterval = 10 * time.Second
+)
+
+// BackfillFunc updates the rows of the given ids. It is called in the transaction of the batch.
+type BackfillFunc func(ctx context.Context, tx bun.Tx, ids []string) error
+
+// BackfillReporter is notified of the progress of the backfills, with the number of rows processed by the backfill
+// named name out of the total number of rows it has to process.
+type BackfillReporter func(ctx context.Context, name string, processed int, total int)
+
+type backfillReporterKey struct{}
+
+// NewContextWithBackfillReporter returns a context whose backfills report their progress to reporter.
+func NewContextWithBackfillReporter(ctx context.Context, reporter BackfillReporter) context.Context {
+	return context.WithValue(ctx, backfillReporterKey{}, reporter)
+}
+
+func backfillReporterFromContext(ctx context.Context) BackfillReporter {
+	if reporter, ok := ctx.Value(backfillReporterKey{}).(BackfillReporter); ok {
+		return reporter
+	}
+
+	return func(context.Context, string, int, int) {}
+}
+
+// backfillCheckpoint is the id of the last row processed by a backfill, so that an interrupted backfill resumes
+// after it rather than starting over. Its table is added by the add_backfill_checkpoint migration, hence the
+// backfills can only be run by the migrations following it.
+type backfillCheckpoint struct {
+	bun.BaseModel `bun:"table:backfill_checkpoint"`
+
+	Name      string    `bun:"name,pk,type:text"`
+	LastID    string    `bun:"last_id,type:text,notnull"`
+	UpdatedAt time.Time `bun:"updated_at,notnull"`
+}
+
+// Backfill updates the rows of a table in batches, such as to fill a column added to a large table which a single
+// update would lock for minutes. Each batch is committed in its own transaction along with the id of its last row,
+// and the backfill sleeps between two batches to let the other writers acquire the table.
+//
+// The rows are walked in the order of their id, the update of a row must be idempotent since the last batch of an
+// interrupted backfill is processed again when it was not committed.
+type Backfill struct {
+	settings factory.ScopedProviderSettings
+	config   BackfillConfig
+	name     string
+	table    string
+	column   string
+	update   BackfillFunc
+	rows     metric.Int64Counter
+}
+
+// NewBackfill returns the backfill named name of the rows of table, whose ids are in the text column column. The
+// name identifies the progress of the backfill and must be unique across the migrations.
+func NewBackfill(providerSettings factory.ProviderSettings, config BackfillConfig, name string, table string, column string, update BackfillFunc) (*Backfill, error) {
+	settings := factory.NewScopedProviderSettings(providerSettings, "github.com/SigNoz/signoz/pkg/sqlmigration")
+
+	rows, err := settings.Meter().Int64Counter("signoz.sqlmigration.backfill.rows", metric.WithDescription("Number of rows updated by the backfill migrations."))
+	if err != nil {
+		return nil, err
+	}
+
+	return &Backfill{
+		settings: settings,
+		config:   config,
+		name:     name,
+		table:    table,
+		column:   column,
+		update:   update,
+		rows:     rows,
+	}, nil
+}
+
+// Run updates the rows which were not processed yet. It resumes after the last row processed by a previous run.
+func (backfill *Backfill) Run(ctx context.Context, db *bun.DB) error {
+	checkpoint := new(backfillCheckpoint)
+	err := db.NewSelect().Model(checkpoint).Where("name = ?", backfill.name).Scan(ctx)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+
+	lastID := checkpoint.LastID
+	if lastID != "" {
+		backfill.settings.Logger().InfoContext(ctx, "resuming backfill", "migration", backfill.name, "table", backfill.table, "last_id", lastID)
+	}
+
+	total, err := db.NewSelect().Table(backfill.table).Where("? > ?", bun.Ident(backfill.column), lastID).Count(ctx)
+	if err != nil {
+		return err
+	}
+
+	backfill.settings.Logger().InfoContext(ctx, "starting backfill", "migration", backfill.name, "table", backfill.table, "rows", total, "batch_size", backfill.config.BatchSize)
+
+	report := backfillReporterFromContext(ctx)
+	report(ctx, backfill.name, 0, total)
+
+	processed := 0
+	loggedAt := time.Now()
+	for {
+		ids, err := backfill.batch(ctx, db, lastID)
+		if err != nil {
+			return err
+		}
+
+		if len(ids) == 0 {
+			break
+		}
+
+		lastID = ids[len(ids)-1]
+		processed += len(ids)
+		backfill.rows.Add(ctx, int64(len(ids)), metric.WithAttributes(attribute.String("migration", backfill.name)))
+		report(ctx, backfill.name, processed, total)
+
+		if time.Since(loggedAt) >= backfillProgressInterval {
+			backfill.settings.Logger().InfoContext(ctx, "backfill in progress", "migration", backfill.name, "table", backfill.table, "processed", processed, "rows", total, "last_id", lastID)
+			loggedAt = time.Now()
+		}
+
+		if len(ids) < backfill.config.BatchSize {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backfill.config.Interval):
+		}
+	}
+
+	// The checkpoint is not needed once the migration is applied, running the backfill again starts over.
+	if _, err := db.NewDelete().Model(new(backfillCheckpoint)).Where("name = ?", backfill.name).Exec(ctx); err != nil {
+		return err
+	}
+
+	backfill.settings.Logger().InfoContext(ctx, "completed backfill", "migration", backfill.name, "table", backfill.table, "processed", processed)
+	return nil
+}
+
+// batch updates the rows following lastID and records the id of the last of them in the same transaction.
+func (backfill *Backfill) batch(ctx context.Context, db *bun.DB, lastID string) ([]string, error) {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	var ids []string
+	err = tx.NewSelect().
+		Table(backfill.table).
+		Column(backfill.column).
+		Where("? > ?", bun.Ident(backfill.column), lastID).
+		OrderExpr("? ASC", bun.Ident(backfill.column)).
+		Limit(backfill.config.BatchSize).
+		Scan(ctx, &ids)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	if err := backfill.update(ctx, tx, ids); err != nil {
+		return nil, err
+	}
+
+	checkpoint := &backfillCheckpoint{Name: backfill.name, LastID: ids[len(ids)-1], UpdatedAt: time.Now()}
+	_, err = tx.NewInsert().
+		Model(checkpoint).
+		On("CONFLICT (name) DO UPDATE").
+		Set("last_id = EXCLUDED.last_id").
+		Set("updated_at = EXCLUDED.updated_at").
+		Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
@@ -0,0 +1,144 @@
+package sqlmigration
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/instrumentation/instrumentationtest"
+	"github.com/SigNoz/signoz/pkg/sqlstore"
+	"github.com/SigNoz/signoz/pkg/sqlstore/sqlitesqlstore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/uptrace/bun"
+)
+
+func newBackfillStore(t *testing.T, rows int) sqlstore.SQLStore {
+	ctx := context.Background()
+	store, err := sqlitesqlstore.New(ctx, instrumentationtest.New().ToProviderSettings(), sqlstore.Config{
+		Provider: "sqlite",
+		Sqlite:   sqlstore.SqliteConfig{Path: filepath.Join(t.TempDir(), "signoz.db")},
+	})
+	require.NoError(t, err)
+
+	_, err = store.BunDB().ExecContext(ctx, "CREATE TABLE event (id TEXT PRIMARY KEY, name TEXT NOT NULL, normalized_name TEXT)")
+	require.NoError(t, err)
+
+	_, err = store.BunDB().NewCreateTable().Model(new(backfillCheckpoint)).Exec(ctx)
+	require.NoError(t, err)
+
+	for i := 0; i < rows; i++ {
+		_, err = store.BunDB().ExecContext(ctx, "INSERT INTO event (id, name) VALUES (?, ?)", fmt.Sprintf("%03d", i), fmt.Sprintf("Event %d", i))
+		require.NoError(t, err)
+	}
+
+	return store
+}
+
+func normalize(ctx context.Context, tx bun.Tx, ids []string) error {
+	_, err := tx.NewUpdate().Table("event").Set("normalized_name = lower(name)").Where("id IN (?)", bun.In(ids)).Exec(ctx)
+	return err
+}
+
+func countNormalized(t *testing.T, store sqlstore.SQLStore) int {
+	count, err := store.BunDB().NewSelect().Table("event").Where("normalized_name IS NOT NULL").Count(context.Background())
+	require.NoError(t, err)
+	return count
+}
+
+func TestBackfill(t *testing.T) {
+	ctx := context.Background()
+	store := newBackfillStore(t, 25)
+
+	var batches [][]string
+	backfill, err := NewBackfill(instrumentationtest.New().ToProviderSettings(), BackfillConfig{BatchSize: 10}, "normalize_event_name", "event", "id", func(ctx context.Context, tx bun.Tx, ids []string) error {
+		batches = append(batches, ids)
+		return normalize(ctx, tx, ids)
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, backfill.Run(ctx, store.BunDB()))
+	assert.Equal(t, 25, countNormalized(t, store))
+	require.Len(t, batches, 3)
+	assert.Len(t, batches[0], 10)
+	assert.Len(t, batches[2], 5)
+
+	// The checkpoint is deleted once the backfill completes.
+	count, err := store.BunDB().NewSelect().Model(new(backfillCheckpoint)).Count(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, count)
+}
+
+func TestBackfillResume(t *testing.T) {
+	ctx := context.Background()
+	store := newBackfillStore(t, 25)
+
+	calls := 0
+	failing, err := NewBackfill(instrumentationtest.New().ToProviderSettings(), BackfillConfig{BatchSize: 10}, "normalize_event_name", "event", "id", func(ctx context.Context, tx bun.Tx, ids []string) error {
+		calls++
+		if calls == 2 {
+			return errors.New(errors.TypeInternal, errors.CodeInternal, "interrupted")
+		}
+		return normalize(ctx, tx, ids)
+	})
+	require.NoError(t, err)
+
+	require.Error(t, failing.Run(ctx, store.BunDB()))
+	assert.Equal(t, 10, countNormalized(t, store))
+
+	var resumed []string
+	backfill, err := NewBackfill(instrumentationtest.New().ToProviderSettings(), BackfillConfig{BatchSize: 10}, "normalize_event_name", "event", "id", func(ctx context.Context, tx bun.Tx, ids []string) error {
+		resumed = append(resumed, ids...)
+		return normalize(ctx, tx, ids)
+	})
+	require.NoError(t, err)
+
+	// The rows of the committed batch are not processed again.
+	require.NoError(t, backfill.Run(ctx, store.BunDB()))
+	assert.Equal(t, 25, countNormalized(t, store))
+	assert.Len(t, resumed, 15)
+	assert.Equal(t, "010", resumed[0])
+}
+
+func TestBackfillCanceled(t *testing.T) {
+	store := newBackfillStore(t, 25)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	backfill, err := NewBackfill(instrumentationtest.New().ToProviderSettings(), BackfillConfig{BatchSize: 10}, "normalize_event_name", "event", "id", func(ctx context.Context, tx bun.Tx, ids []string) error {
+		cancel()
+		return normalize(ctx, tx, ids)
+	})
+	require.NoError(t, err)
+
+	assert.ErrorIs(t, backfill.Run(ctx, store.BunDB()), context.Canceled)
+}
+
+func TestAddDashboardTitle(t *testing.T) {
+	ctx := context.Background()
+	store := newBackfillStore(t, 0)
+
+	_, err := store.BunDB().ExecContext(ctx, "CREATE TABLE dashboard (id TEXT PRIMARY KEY, org_id TEXT NOT NULL, data TEXT NOT NULL)")
+	require.NoError(t, err)
+
+	for id, data := range map[string]string{"1": `{"title":"first"}`, "2": `{}`, "3": `not json`} {
+		_, err = store.BunDB().ExecContext(ctx, "INSERT INTO dashboard (id, org_id, data) VALUES (?, ?, ?)", id, "org", data)
+		require.NoError(t, err)
+	}
+
+	migration, err := newAddDashboardTitle(ctx, instrumentationtest.New().ToProviderSettings(), Config{Backfill: BackfillConfig{BatchSize: 2}}, store)
+	require.NoError(t, err)
+	require.NoError(t, migration.(*addDashboardTitle).Up(ctx, store.BunDB()))
+
+	var titles []struct {
+		ID    string  `bun:"id"`
+		Title *string `bun:"title"`
+	}
+	require.NoError(t, store.BunDB().NewSelect().Table("dashboard").Column("id", "title").OrderExpr("id ASC").Scan(ctx, &titles))
+	require.Len(t, titles, 3)
+	require.NotNil(t, titles[0].Title)
+	assert.Equal(t, "first", *titles[0].Title)
+	assert.Nil(t, titles[1].Title)
+	assert.Nil(t, titles[2].Title)
+}
@@ -0,0 +1,57 @@
+package sqlmigration
+
+import (
+	"crypto/sha256"
+	"embed"
+	"encoding/hex"
+	"io/fs"
+	"regexp"
+)
+
+var (
+	//go:embed [0-9]*.go
+	sources embed.FS
+
+	// sourceNameRE matches the file names from which the migrations derive their names.
+	sourceNameRE = regexp.MustCompile(`^(\d{1,14})_([0-9a-z_\-]+)\.go$`)
+)
+
+// NewChecksums returns the checksums of the migrations of this package keyed by migration version. The checksum of
+// a migration is computed over its version and its name so that an applied version which is reused by another
+// migration is detected, while refactoring the code of the migrations is not.
+func NewChecksums() (map[string]string, error) {
+	entries, err := fs.ReadDir(sources, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	checksums := make(map[string]string, len(entries))
+	for _, entry := range entries {
+		matches := sourceNameRE.FindStringSubmatch(entry.Name())
+		if matches == nil {
+			continue
+		}
+
+		checksums[matches[1]] = checksum(matches[1], matches[2])
+	}
+
+	return checksums, nil
+}
+
+func MustNewChecksums() map[string]string {
+	checksums, err := NewChecksums()
+	if err != nil {
+		panic(err)
+	}
+
+	return checksums
+}
+
+func checksum(version string, name string) string {
+	hash := sha256.New()
+	hash.Write([]byte(version))
+	hash.Write([]byte{0})
+	hash.Write([]byte(name))
+
+	return hex.EncodeToString(hash.Sum(nil))
+}
@@ -0,0 +1,24 @@
+package sqlmigration
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewChecksums(t *testing.T) {
+	checksums, err := NewChecksums()
+	require.NoError(t, err)
+
+	assert.Equal(t, checksum("001", "add_organization"), checksums["001"])
+	assert.Equal(t, checksum("049", "add_migration_checksum"), checksums["049"])
+}
+
+func TestChecksum(t *testing.T) {
+	// Another migration reusing the version does not match.
+	assert.NotEqual(t, checksum("043", "add_refresh_token"), checksum("043", "add_dashboard_version"))
+
+	// The version and the name are not concatenated ambiguously.
+	assert.NotEqual(t, checksum("04", "3_add"), checksum("043", "_add"))
+}
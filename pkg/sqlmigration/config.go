@@ -1,19 +1,46 @@
 package sqlmigration
 
 import (
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
 	"github.com/SigNoz/signoz/pkg/factory"
 )
 
-type Config struct{}
+type Config struct {
+	// Backfill is the config of the migrations updating the rows of a table in batches.
+	Backfill BackfillConfig `mapstructure:"backfill"`
+}
+
+type BackfillConfig struct {
+	// BatchSize is the number of rows updated by a batch.
+	BatchSize int `mapstructure:"batch_size"`
+
+	// Interval is the time to sleep between two batches, letting the other writers acquire the table.
+	Interval time.Duration `mapstructure:"interval"`
+}
 
 func NewConfigFactory() factory.ConfigFactory {
 	return factory.NewConfigFactory(factory.MustNewName("sqlmigration"), newConfig)
 }
 
 func newConfig() factory.Config {
-	return Config{}
+	return Config{
+		Backfill: BackfillConfig{
+			BatchSize: 1000,
+			Interval:  100 * time.Millisecond,
+		},
+	}
 }
 
 func (c Config) Validate() error {
+	if c.Backfill.BatchSize <= 0 {
+		return errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "backfill::batch_size must be greater than 0, got %d", c.Backfill.BatchSize)
+	}
+
+	if c.Backfill.Interval < 0 {
+		return errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "backfill::interval must not be negative, got %v", c.Backfill.Interval)
+	}
+
 	return nil
 }
@@ -10,6 +10,24 @@ import (
 type Config struct {
 	// Lock is the lock configuration.
 	Lock Lock `mapstructure:"lock"`
+
+	// Checksum is the checksum configuration.
+	Checksum Checksum `mapstructure:"checksum"`
+
+	// Progress is the progress configuration.
+	Progress Progress `mapstructure:"progress"`
+}
+
+type Progress struct {
+	// Address is the address on which the progress of the migrations is streamed while they run, before the api
+	// server is up. The progress is not streamed when it is empty.
+	Address string `mapstructure:"address"`
+}
+
+type Checksum struct {
+	// IgnoreMismatch accepts the changes made to applied migrations instead of refusing to migrate. The
+	// checksums of the changed migrations are updated and have to match again on the next run.
+	IgnoreMismatch bool `mapstructure:"ignore_mismatch"`
 }
 
 type Lock struct {
@@ -29,6 +47,12 @@ func newConfig() factory.Config {
 			Timeout:  2 * time.Minute,
 			Interval: 10 * time.Second,
 		},
+		Checksum: Checksum{
+			IgnoreMismatch: false,
+		},
+		Progress: Progress{
+			Address: "",
+		},
 	}
 }
 
@@ -0,0 +1,314 @@
+package sqlmigrator
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/dialect"
+	"github.com/uptrace/bun/migrate"
+)
+
+func (migrator *migrator) DryRun(ctx context.Context) ([]MigrationPlan, error) {
+	pending, err := migrator.pendingMigrations(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	plans := make([]MigrationPlan, len(pending))
+	for i, migration := range pending {
+		plans[i] = MigrationPlan{Name: migration.Name, Comment: migration.Comment, SQL: []string{}}
+	}
+
+	// The statements of the migrations are only known by running them. They are run against a scratch copy of the
+	// sqlite databases, and inside a transaction which is rolled back for postgres. The other dialects are planned
+	// from the names and the comments of the registered migrations.
+	if len(pending) > 0 {
+		switch migrator.sqlstore.BunDB().Dialect().Name() {
+		case dialect.SQLite:
+			if err := migrator.planOnScratchCopy(ctx, pending, plans); err != nil {
+				return nil, err
+			}
+		case dialect.PG:
+			if err := migrator.planInRolledBackTx(ctx, pending, plans); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	migrator.settings.Logger().InfoContext(ctx, "computed pending sqlstore migrations", "count", len(plans), "dialect", migrator.dialect)
+	return plans, nil
+}
+
+func (migrator *migrator) pendingMigrations(ctx context.Context) (migrate.MigrationSlice, error) {
+	exists, err := migrator.tableExists(ctx, migrationTableName)
+	if err != nil {
+		return nil, err
+	}
+
+	// The migration table is created by the first call to Migrate. Until then, every migration is pending.
+	if !exists {
+		return migrator.migrations.Sorted(), nil
+	}
+
+	migrations, err := migrator.migrator.MigrationsWithStatus(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return migrations.Unapplied(), nil
+}
+
+func (migrator *migrator) tableExists(ctx context.Context, name string) (bool, error) {
+	var query string
+	switch migrator.sqlstore.BunDB().Dialect().Name() {
+	case dialect.SQLite:
+		query = "SELECT count(*) FROM sqlite_master WHERE type = 'table' AND name = ?"
+	case dialect.PG:
+		query = "SELECT count(*) FROM information_schema.tables WHERE table_schema = current_schema() AND table_name = ?"
+	default:
+		return false, fmt.Errorf("listing the tables is not supported for dialect %q", migrator.dialect)
+	}
+
+	var count int
+	if err := migrator.sqlstore.BunDB().QueryRowContext(ctx, query, name).Scan(&count); err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+// planOnScratchCopy copies the sqlite database to a temporary file with VACUUM INTO, which only reads the database,
+// and runs the pending migrations against the copy, recording the statements which are not read only. The copy is
+// removed once planned.
+func (migrator *migrator) planOnScratchCopy(ctx context.Context, pending migrate.MigrationSlice, plans []MigrationPlan) error {
+	dir, err := os.MkdirTemp("", "signoz-dryrun-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir) //nolint:errcheck
+
+	path := filepath.Join(dir, "signoz.db")
+	if _, err := migrator.sqlstore.SQLDB().ExecContext(ctx, "VACUUM INTO ?", path); err != nil {
+		return fmt.Errorf("failed to copy the database: %w", err)
+	}
+
+	sqldb := sql.OpenDB(&scratchConnector{driver: migrator.sqlstore.SQLDB().Driver(), dsn: "file:" + path})
+	defer sqldb.Close() //nolint:errcheck
+	// A single connection so that the pragmas set by the migrations apply to all their statements.
+	sqldb.SetMaxOpenConns(1)
+
+	return migrator.plan(ctx, sqldb, pending, plans)
+}
+
+// planInRolledBackTx runs the pending migrations on a connection of the database inside a transaction which is
+// always rolled back, recording the statements which are not read only. The transactions begun by the migrations are
+// savepoints of it, so that none of them commits.
+func (migrator *migrator) planInRolledBackTx(ctx context.Context, pending migrate.MigrationSlice, plans []MigrationPlan) error {
+	conn, err := migrator.sqlstore.SQLDB().Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close() //nolint:errcheck
+
+	return conn.Raw(func(driverConn any) (err error) {
+		execer, ok := driverConn.(driver.ExecerContext)
+		if !ok {
+			return fmt.Errorf("planning the migrations in a transaction is not supported by the driver %T", driverConn)
+		}
+
+		if _, err := execer.ExecContext(ctx, "BEGIN", nil); err != nil {
+			return fmt.Errorf("failed to begin the dry run transaction: %w", err)
+		}
+		// The rollback runs even when the context is done. The connection is discarded rather than put back in the
+		// pool when the rollback fails.
+		defer func() {
+			if _, rollbackErr := execer.ExecContext(context.WithoutCancel(ctx), "ROLLBACK", nil); rollbackErr != nil && err == nil {
+				err = fmt.Errorf("failed to roll back the dry run transaction: %v: %w", rollbackErr, driver.ErrBadConn)
+			}
+		}()
+
+		sqldb := sql.OpenDB(&txConnector{conn: &txConn{Conn: driverConn.(driver.Conn), execer: execer}})
+		defer sqldb.Close() //nolint:errcheck
+		sqldb.SetMaxOpenConns(1)
+
+		return migrator.plan(ctx, sqldb, pending, plans)
+	})
+}
+
+// plan runs the pending migrations against the database, recording the statements of every migration in its plan.
+func (migrator *migrator) plan(ctx context.Context, sqldb *sql.DB, pending migrate.MigrationSlice, plans []MigrationPlan) error {
+	recorder := &dryRunRecorder{}
+	db := bun.NewDB(sqldb, migrator.sqlstore.BunDB().Dialect())
+	db.AddQueryHook(recorder)
+
+	for i, migration := range pending {
+		if migration.Up == nil {
+			continue
+		}
+
+		recorder.reset()
+		if err := migration.Up(ctx, db); err != nil {
+			return fmt.Errorf("failed to plan migration %q: %w", migration.Name, err)
+		}
+
+		plans[i].SQL = recorder.statements()
+	}
+
+	return nil
+}
+
+// scratchConnector opens the connections to the scratch copy with the driver of the database it was copied from.
+type scratchConnector struct {
+	driver driver.Driver
+	dsn    string
+}
+
+func (connector *scratchConnector) Connect(context.Context) (driver.Conn, error) {
+	return connector.driver.Open(connector.dsn)
+}
+
+func (connector *scratchConnector) Driver() driver.Driver {
+	return connector.driver
+}
+
+// txConnector hands the connection of the dry run transaction out to every caller.
+type txConnector struct {
+	conn *txConn
+}
+
+func (connector *txConnector) Connect(context.Context) (driver.Conn, error) {
+	return connector.conn, nil
+}
+
+func (connector *txConnector) Driver() driver.Driver {
+	return nil
+}
+
+// txConn is a connection inside the dry run transaction. The transactions begun on it are savepoints, and closing it
+// leaves the connection to the pool it was taken from.
+type txConn struct {
+	driver.Conn
+	execer     driver.ExecerContext
+	savepoints int
+}
+
+var (
+	_ driver.ConnBeginTx       = (*txConn)(nil)
+	_ driver.ExecerContext     = (*txConn)(nil)
+	_ driver.QueryerContext    = (*txConn)(nil)
+	_ driver.NamedValueChecker = (*txConn)(nil)
+)
+
+func (conn *txConn) Begin() (driver.Tx, error) {
+	return conn.BeginTx(context.Background(), driver.TxOptions{})
+}
+
+func (conn *txConn) BeginTx(ctx context.Context, _ driver.TxOptions) (driver.Tx, error) {
+	conn.savepoints++
+	savepoint := fmt.Sprintf("dry_run_%d", conn.savepoints)
+	if _, err := conn.execer.ExecContext(ctx, "SAVEPOINT "+savepoint, nil); err != nil {
+		return nil, err
+	}
+
+	return &savepointTx{conn: conn, name: savepoint}, nil
+}
+
+func (conn *txConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	return conn.execer.ExecContext(ctx, query, args)
+}
+
+func (conn *txConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	queryer, ok := conn.Conn.(driver.QueryerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+
+	return queryer.QueryContext(ctx, query, args)
+}
+
+func (conn *txConn) CheckNamedValue(value *driver.NamedValue) error {
+	checker, ok := conn.Conn.(driver.NamedValueChecker)
+	if !ok {
+		return driver.ErrSkip
+	}
+
+	return checker.CheckNamedValue(value)
+}
+
+func (conn *txConn) Close() error {
+	return nil
+}
+
+type savepointTx struct {
+	conn *txConn
+	name string
+}
+
+func (tx *savepointTx) Commit() error {
+	_, err := tx.conn.execer.ExecContext(context.Background(), "RELEASE SAVEPOINT "+tx.name, nil)
+	return err
+}
+
+func (tx *savepointTx) Rollback() error {
+	_, err := tx.conn.execer.ExecContext(context.Background(), "ROLLBACK TO SAVEPOINT "+tx.name, nil)
+	return err
+}
+
+// dryRunRecorder records the statements run by the migrations which are not read only.
+type dryRunRecorder struct {
+	mu   sync.Mutex
+	sqls []string
+}
+
+var _ bun.QueryHook = (*dryRunRecorder)(nil)
+
+func (recorder *dryRunRecorder) BeforeQuery(ctx context.Context, event *bun.QueryEvent) context.Context {
+	return ctx
+}
+
+func (recorder *dryRunRecorder) AfterQuery(ctx context.Context, event *bun.QueryEvent) {
+	if event.Err != nil || isReadOnly(event.Query) || isTxControl(event.Query) {
+		return
+	}
+
+	recorder.mu.Lock()
+	defer recorder.mu.Unlock()
+	recorder.sqls = append(recorder.sqls, event.Query)
+}
+
+func (recorder *dryRunRecorder) reset() {
+	recorder.mu.Lock()
+	defer recorder.mu.Unlock()
+	recorder.sqls = nil
+}
+
+func (recorder *dryRunRecorder) statements() []string {
+	recorder.mu.Lock()
+	defer recorder.mu.Unlock()
+	return append([]string{}, recorder.sqls...)
+}
+
+func isReadOnly(query string) bool {
+	query = strings.ToUpper(strings.TrimSpace(query))
+	switch {
+	case strings.HasPrefix(query, "SELECT"), strings.HasPrefix(query, "SHOW"):
+		return true
+	case strings.HasPrefix(query, "PRAGMA"):
+		// PRAGMA statements with an assignment change the state of the connection.
+		return !strings.Contains(query, "=")
+	default:
+		return false
+	}
+}
+
+func isTxControl(query string) bool {
+	query = strings.ToUpper(strings.TrimSpace(query))
+	return query == "BEGIN" || query == "COMMIT" || query == "ROLLBACK"
+}
@@ -3,44 +3,94 @@ package sqlmigrator
 import (
 	"context"
 	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
 	"time"
 
 	"github.com/SigNoz/signoz/pkg/factory"
 	"github.com/SigNoz/signoz/pkg/sqlstore"
+	"github.com/uptrace/bun"
 	"github.com/uptrace/bun/migrate"
 )
 
 var (
 	migrationTableName     string = "migration"
 	migrationLockTableName string = "migration_lock"
+	checksumTableName      string = "migration_checksum"
 )
 
+const (
+	// progressPath is the path on which the progress of the migrations is streamed.
+	progressPath string = "/api/v1/migrations/progress"
+	// progressShutdownTimeout is the time given to the streams of the progress to end once the migrations ran.
+	progressShutdownTimeout = 5 * time.Second
+)
+
+// migrationChecksum is the checksum of an applied migration. The table is created by a migration.
+type migrationChecksum struct {
+	bun.BaseModel `bun:"table:migration_checksum"`
+
+	Name     string `bun:"name,pk,type:text"`
+	Checksum string `bun:"checksum,type:text,notnull"`
+}
+
 type migrator struct {
-	settings factory.ScopedProviderSettings
-	config   Config
-	migrator *migrate.Migrator
-	dialect  string
+	settings   factory.ScopedProviderSettings
+	config     Config
+	migrator   *migrate.Migrator
+	migrations *migrate.Migrations
+	sqlstore   sqlstore.SQLStore
+	dialect    string
+	// checksums are the checksums of the migrations keyed by migration name.
+	checksums map[string]string
+	progress  *ProgressTracker
 }
 
-func New(ctx context.Context, providerSettings factory.ProviderSettings, sqlstore sqlstore.SQLStore, migrations *migrate.Migrations, config Config) SQLMigrator {
+// New returns a migrator running the migrations. The applied migrations with a checksum are verified not to have
+// changed since they were applied; checksums can be nil to skip this verification.
+func New(ctx context.Context, providerSettings factory.ProviderSettings, sqlstore sqlstore.SQLStore, migrations *migrate.Migrations, checksums map[string]string, config Config) SQLMigrator {
+	progress := newProgressTracker()
+
 	return &migrator{
 		migrator: migrate.NewMigrator(
 			sqlstore.BunDB(),
-			migrations,
+			progress.track(migrations),
 			migrate.WithTableName(migrationTableName),
 			migrate.WithLocksTableName(migrationLockTableName),
 			// This is to ensure that the migration is marked as applied only on success. If the migration fails, no entry is made in the migration table
 			// and the migration will be retried.
 			migrate.WithMarkAppliedOnSuccess(true),
 		),
-		settings: factory.NewScopedProviderSettings(providerSettings, "github.com/SigNoz/signoz/pkg/sqlmigrator"),
-		config:   config,
-		dialect:  sqlstore.BunDB().Dialect().Name().String(),
+		migrations: migrations,
+		sqlstore:   sqlstore,
+		settings:   factory.NewScopedProviderSettings(providerSettings, "github.com/SigNoz/signoz/pkg/sqlmigrator"),
+		config:     config,
+		dialect:    sqlstore.BunDB().Dialect().Name().String(),
+		checksums:  checksums,
+		progress:   progress,
 	}
 }
 
-func (migrator *migrator) Migrate(ctx context.Context) error {
+func (migrator *migrator) Migrate(ctx context.Context) (err error) {
 	migrator.settings.Logger().InfoContext(ctx, "starting sqlstore migrations", "dialect", migrator.dialect)
+	migrator.progress.start()
+
+	if migrator.config.Progress.Address != "" {
+		stop, err := migrator.serveProgress(ctx)
+		if err != nil {
+			migrator.settings.Logger().WarnContext(ctx, "cannot stream the progress of the sqlstore migrations", "error", err, "address", migrator.config.Progress.Address)
+		} else {
+			defer stop()
+		}
+	}
+
+	// The run ends before the progress server stops so that the streams end with its final status.
+	defer func() {
+		migrator.progress.end(err)
+	}()
+
 	if err := migrator.migrator.Init(ctx); err != nil {
 		return err
 	}
@@ -51,11 +101,25 @@ func (migrator *migrator) Migrate(ctx context.Context) error {
 
 	defer migrator.migrator.Unlock(ctx) //nolint:errcheck
 
+	if err := migrator.verifyChecksums(ctx); err != nil {
+		return err
+	}
+
+	migrations, err := migrator.migrator.MigrationsWithStatus(ctx)
+	if err != nil {
+		return err
+	}
+	migrator.progress.plan(migrations.Unapplied())
+
 	group, err := migrator.migrator.Migrate(ctx)
 	if err != nil {
 		return err
 	}
 
+	if err := migrator.recordChecksums(ctx); err != nil {
+		return err
+	}
+
 	if group.IsZero() {
 		migrator.settings.Logger().InfoContext(ctx, "no new migrations to run (database is up to date)", "dialect", migrator.dialect)
 		return nil
@@ -65,6 +129,147 @@ func (migrator *migrator) Migrate(ctx context.Context) error {
 	return nil
 }
 
+func (migrator *migrator) Progress() *ProgressTracker {
+	return migrator.progress
+}
+
+// serveProgress streams the progress of the migrations on the configured address until the returned function is
+// called.
+func (migrator *migrator) serveProgress(ctx context.Context) (func(), error) {
+	listener, err := net.Listen("tcp", migrator.config.Progress.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle(progressPath, migrator.progress)
+	server := &http.Server{Handler: mux, ReadHeaderTimeout: progressShutdownTimeout}
+
+	go func() {
+		if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			migrator.settings.Logger().ErrorContext(ctx, "failed to stream the progress of the sqlstore migrations", "error", err)
+		}
+	}()
+
+	migrator.settings.Logger().InfoContext(ctx, "streaming the progress of the sqlstore migrations", "address", listener.Addr().String(), "path", progressPath)
+
+	return func() {
+		shutdownCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), progressShutdownTimeout)
+		defer cancel()
+
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			migrator.settings.Logger().WarnContext(ctx, "failed to stop streaming the progress of the sqlstore migrations", "error", err)
+		}
+	}, nil
+}
+
+// verifyChecksums returns an error if an applied migration does not match the checksum recorded when
+// it was applied, unless mismatches are ignored.
+func (migrator *migrator) verifyChecksums(ctx context.Context) error {
+	if len(migrator.checksums) == 0 {
+		return nil
+	}
+
+	// The checksum table is created by a migration. Until it ran, no checksum was recorded.
+	exists, err := migrator.tableExists(ctx, checksumTableName)
+	if err != nil {
+		return err
+	}
+
+	if !exists {
+		return nil
+	}
+
+	migrations, err := migrator.migrator.MigrationsWithStatus(ctx)
+	if err != nil {
+		return err
+	}
+
+	var recorded []migrationChecksum
+	if err := migrator.sqlstore.BunDB().NewSelect().Model(&recorded).Scan(ctx); err != nil {
+		return err
+	}
+
+	recordedByName := make(map[string]string, len(recorded))
+	for _, checksum := range recorded {
+		recordedByName[checksum.Name] = checksum.Checksum
+	}
+
+	mismatched := []string{}
+	for _, migration := range migrations.Applied() {
+		expected, ok := recordedByName[migration.Name]
+		if !ok {
+			continue
+		}
+
+		if actual, ok := migrator.checksums[migration.Name]; ok && actual != expected {
+			mismatched = append(mismatched, migration.String())
+		}
+	}
+
+	if len(mismatched) == 0 {
+		return nil
+	}
+
+	if !migrator.config.Checksum.IgnoreMismatch {
+		err := fmt.Errorf("the applied migrations %s have changed since they were applied, revert the changes or set sqlmigrator::checksum::ignore_mismatch to accept them", strings.Join(mismatched, ", "))
+		migrator.settings.Logger().ErrorContext(ctx, "sqlstore migrations have drifted", "error", err, "dialect", migrator.dialect)
+		return err
+	}
+
+	migrator.settings.Logger().WarnContext(ctx, "accepting changes to applied sqlstore migrations", "migrations", mismatched, "dialect", migrator.dialect)
+	return nil
+}
+
+// recordChecksums records the checksums of the applied migrations, including the ones applied before checksums
+// were recorded.
+func (migrator *migrator) recordChecksums(ctx context.Context) error {
+	if len(migrator.checksums) == 0 {
+		return nil
+	}
+
+	exists, err := migrator.tableExists(ctx, checksumTableName)
+	if err != nil {
+		return err
+	}
+
+	if !exists {
+		return nil
+	}
+
+	migrations, err := migrator.migrator.MigrationsWithStatus(ctx)
+	if err != nil {
+		return err
+	}
+
+	checksums := []*migrationChecksum{}
+	for _, migration := range migrations.Applied() {
+		if checksum, ok := migrator.checksums[migration.Name]; ok {
+			checksums = append(checksums, &migrationChecksum{Name: migration.Name, Checksum: checksum})
+		}
+	}
+
+	if len(checksums) == 0 {
+		return nil
+	}
+
+	_, err = migrator.sqlstore.BunDB().NewInsert().Model(&checksums).On("CONFLICT (name) DO UPDATE").Set("checksum = EXCLUDED.checksum").Exec(ctx)
+	return err
+}
+
+func (migrator *migrator) Ready(ctx context.Context) error {
+	migrations, err := migrator.migrator.MigrationsWithStatus(ctx)
+	if err != nil {
+		return err
+	}
+
+	if unapplied := migrations.Unapplied(); len(unapplied) > 0 {
+		return fmt.Errorf("%d migrations have not been applied, the next one is %s", len(unapplied), unapplied[0].Name)
+	}
+
+	return nil
+}
+
 func (migrator *migrator) Rollback(ctx context.Context) error {
 	if err := migrator.Lock(ctx); err != nil {
 		return err
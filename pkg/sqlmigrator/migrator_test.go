@@ -3,6 +3,7 @@ package sqlmigrator
 import (
 	"context"
 	"database/sql/driver"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -12,8 +13,12 @@ import (
 	"github.com/SigNoz/signoz/pkg/sqlmigration"
 	"github.com/SigNoz/signoz/pkg/sqlmigration/sqlmigrationtest"
 	"github.com/SigNoz/signoz/pkg/sqlstore"
+	"github.com/SigNoz/signoz/pkg/sqlstore/sqlitesqlstore"
 	"github.com/SigNoz/signoz/pkg/sqlstore/sqlstoretest"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/migrate"
 )
 
 func TestMigratorWithSqliteAndNoopMigration(t *testing.T) {
@@ -36,6 +41,7 @@ func TestMigratorWithSqliteAndNoopMigration(t *testing.T) {
 		providerSettings,
 		sqlstore,
 		sqlmigration.MustNew(ctx, providerSettings, sqlmigration.Config{}, factory.MustNewNamedMap(sqlmigrationtest.NoopMigrationFactory())),
+		nil,
 		migrationConfig,
 	)
 
@@ -43,8 +49,188 @@ func TestMigratorWithSqliteAndNoopMigration(t *testing.T) {
 	sqlstore.Mock().ExpectExec("CREATE TABLE IF NOT EXISTS migration_lock (.+)").WillReturnResult(driver.ResultNoRows)
 	sqlstore.Mock().ExpectQuery("INSERT INTO migration_lock (.+)").WillReturnRows(sqlstore.Mock().NewRows([]string{"id"}).AddRow(1))
 	sqlstore.Mock().ExpectQuery("(.+) FROM migration").WillReturnRows(sqlstore.Mock().NewRows([]string{"id"}).AddRow(1))
+	sqlstore.Mock().ExpectQuery("(.+) FROM migration").WillReturnRows(sqlstore.Mock().NewRows([]string{"id"}).AddRow(1))
 	sqlstore.Mock().ExpectQuery("INSERT INTO migration (.+)").WillReturnRows(sqlstore.Mock().NewRows([]string{"id", "migrated_at"}).AddRow(1, time.Now()))
 
 	err := migrator.Migrate(ctx)
 	require.NoError(t, err)
 }
+
+func TestMigratorDryRunWithSqlite(t *testing.T) {
+	ctx := context.Background()
+	providerSettings := instrumentationtest.New().ToProviderSettings()
+
+	sqlstore, err := sqlitesqlstore.New(ctx, providerSettings, sqlstore.Config{
+		Provider: "sqlite",
+		Sqlite:   sqlstore.SqliteConfig{Path: filepath.Join(t.TempDir(), "signoz.db")},
+	})
+	require.NoError(t, err)
+
+	migrations := migrate.NewMigrations()
+	migrations.Add(migrate.Migration{
+		Name: "001",
+		Up: func(ctx context.Context, db *bun.DB) error {
+			tx, err := db.BeginTx(ctx, nil)
+			if err != nil {
+				return err
+			}
+			defer tx.Rollback() //nolint:errcheck
+
+			var count int
+			if err := tx.QueryRowContext(ctx, "SELECT count(*) FROM sqlite_master WHERE name = 'dry_run'").Scan(&count); err != nil {
+				return err
+			}
+
+			if count == 0 {
+				if _, err := tx.ExecContext(ctx, "CREATE TABLE dry_run (id INTEGER PRIMARY KEY)"); err != nil {
+					return err
+				}
+			}
+
+			return tx.Commit()
+		},
+	})
+	migrations.Add(migrate.Migration{
+		Name: "002",
+		Up: func(ctx context.Context, db *bun.DB) error {
+			_, err := db.ExecContext(ctx, "INSERT INTO dry_run (id) VALUES (1)")
+			return err
+		},
+	})
+
+	migrator := New(ctx, providerSettings, sqlstore, migrations, nil, Config{Lock: Lock{Timeout: 10 * time.Second, Interval: 1 * time.Second}})
+
+	plans, err := migrator.DryRun(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []MigrationPlan{
+		{Name: "001", SQL: []string{"CREATE TABLE dry_run (id INTEGER PRIMARY KEY)"}},
+		{Name: "002", SQL: []string{"INSERT INTO dry_run (id) VALUES (1)"}},
+	}, plans)
+
+	var count int
+	require.NoError(t, sqlstore.SQLDB().QueryRowContext(ctx, "SELECT count(*) FROM sqlite_master WHERE name IN ('dry_run', 'migration', 'migration_lock')").Scan(&count))
+	assert.Equal(t, 0, count)
+
+	require.NoError(t, migrator.Migrate(ctx))
+
+	plans, err = migrator.DryRun(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, plans)
+}
+
+func TestMigratorDryRunWithPostgres(t *testing.T) {
+	ctx := context.Background()
+	providerSettings := instrumentationtest.New().ToProviderSettings()
+	sqlstore := sqlstoretest.New(sqlstore.Config{Provider: "postgres"}, sqlmock.QueryMatcherRegexp)
+
+	migrations := migrate.NewMigrations()
+	migrations.Add(migrate.Migration{
+		Name: "001",
+		Up: func(ctx context.Context, db *bun.DB) error {
+			tx, err := db.BeginTx(ctx, nil)
+			if err != nil {
+				return err
+			}
+			defer tx.Rollback() //nolint:errcheck
+
+			if _, err := tx.ExecContext(ctx, "CREATE TABLE dry_run (id INTEGER PRIMARY KEY)"); err != nil {
+				return err
+			}
+
+			return tx.Commit()
+		},
+	})
+	migrations.Add(migrate.Migration{
+		Name: "002",
+		Up: func(ctx context.Context, db *bun.DB) error {
+			_, err := db.ExecContext(ctx, "INSERT INTO dry_run (id) VALUES (1)")
+			return err
+		},
+	})
+
+	migrator := New(ctx, providerSettings, sqlstore, migrations, nil, Config{Lock: Lock{Timeout: 10 * time.Second, Interval: 1 * time.Second}})
+
+	sqlstore.Mock().ExpectQuery("FROM information_schema.tables").WillReturnRows(sqlstore.Mock().NewRows([]string{"count"}).AddRow(0))
+	sqlstore.Mock().ExpectExec("^BEGIN$").WillReturnResult(driver.ResultNoRows)
+	sqlstore.Mock().ExpectExec("^SAVEPOINT dry_run_1$").WillReturnResult(driver.ResultNoRows)
+	sqlstore.Mock().ExpectExec("CREATE TABLE dry_run").WillReturnResult(driver.ResultNoRows)
+	sqlstore.Mock().ExpectExec("^RELEASE SAVEPOINT dry_run_1$").WillReturnResult(driver.ResultNoRows)
+	sqlstore.Mock().ExpectExec("INSERT INTO dry_run").WillReturnResult(sqlmock.NewResult(1, 1))
+	// The statements of the migrations are never committed.
+	sqlstore.Mock().ExpectExec("^ROLLBACK$").WillReturnResult(driver.ResultNoRows)
+
+	plans, err := migrator.DryRun(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []MigrationPlan{
+		{Name: "001", SQL: []string{"CREATE TABLE dry_run (id INTEGER PRIMARY KEY)"}},
+		{Name: "002", SQL: []string{"INSERT INTO dry_run (id) VALUES (1)"}},
+	}, plans)
+	assert.NoError(t, sqlstore.Mock().ExpectationsWereMet())
+}
+
+func TestMigratorReadyWithSqlite(t *testing.T) {
+	ctx := context.Background()
+	providerSettings := instrumentationtest.New().ToProviderSettings()
+
+	sqlstore, err := sqlitesqlstore.New(ctx, providerSettings, sqlstore.Config{
+		Provider: "sqlite",
+		Sqlite:   sqlstore.SqliteConfig{Path: filepath.Join(t.TempDir(), "signoz.db")},
+	})
+	require.NoError(t, err)
+
+	migrations := migrate.NewMigrations()
+	migrations.Add(migrate.Migration{
+		Name: "001",
+		Up: func(ctx context.Context, db *bun.DB) error {
+			_, err := db.ExecContext(ctx, "CREATE TABLE ready (id INTEGER PRIMARY KEY)")
+			return err
+		},
+	})
+
+	migrator := New(ctx, providerSettings, sqlstore, migrations, nil, Config{Lock: Lock{Timeout: 10 * time.Second, Interval: 1 * time.Second}})
+
+	// The migration table does not exist yet.
+	assert.Error(t, migrator.Ready(ctx))
+
+	require.NoError(t, migrator.Migrate(ctx))
+	assert.NoError(t, migrator.Ready(ctx))
+}
+
+func TestMigratorChecksumsWithSqlite(t *testing.T) {
+	ctx := context.Background()
+	providerSettings := instrumentationtest.New().ToProviderSettings()
+
+	sqlstore, err := sqlitesqlstore.New(ctx, providerSettings, sqlstore.Config{
+		Provider: "sqlite",
+		Sqlite:   sqlstore.SqliteConfig{Path: filepath.Join(t.TempDir(), "signoz.db")},
+	})
+	require.NoError(t, err)
+
+	migrations := migrate.NewMigrations()
+	migrations.Add(migrate.Migration{
+		Name: "001",
+		Up: func(ctx context.Context, db *bun.DB) error {
+			_, err := db.NewCreateTable().Model(new(migrationChecksum)).Exec(ctx)
+			return err
+		},
+	})
+
+	config := Config{Lock: Lock{Timeout: 10 * time.Second, Interval: 1 * time.Second}}
+	require.NoError(t, New(ctx, providerSettings, sqlstore, migrations, map[string]string{"001": "add_migration_checksum"}, config).Migrate(ctx))
+
+	// The same code migrates again without any error.
+	require.NoError(t, New(ctx, providerSettings, sqlstore, migrations, map[string]string{"001": "add_migration_checksum"}, config).Migrate(ctx))
+
+	// The version of the applied migration is reused by another migration.
+	err = New(ctx, providerSettings, sqlstore, migrations, map[string]string{"001": "add_checksum"}, config).Migrate(ctx)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "001")
+
+	// The change is accepted and recorded when mismatches are ignored.
+	config.Checksum.IgnoreMismatch = true
+	require.NoError(t, New(ctx, providerSettings, sqlstore, migrations, map[string]string{"001": "add_checksum"}, config).Migrate(ctx))
+
+	config.Checksum.IgnoreMismatch = false
+	require.NoError(t, New(ctx, providerSettings, sqlstore, migrations, map[string]string{"001": "add_checksum"}, config).Migrate(ctx))
+	assert.Error(t, New(ctx, providerSettings, sqlstore, migrations, map[string]string{"001": "add_migration_checksum"}, config).Migrate(ctx))
+}
@@ -0,0 +1,284 @@
+package sqlmigrator
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/sqlmigration"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/migrate"
+)
+
+const (
+	// progressRefreshInterval is the interval at which the progress is streamed while it does not change, so that
+	// the elapsed times keep moving.
+	progressRefreshInterval = time.Second
+)
+
+const (
+	StatusIdle      = "idle"
+	StatusPending   = "pending"
+	StatusRunning   = "running"
+	StatusCompleted = "completed"
+	StatusFailed    = "failed"
+)
+
+// BackfillProgress is the progress of a backfill run by a migration.
+type BackfillProgress struct {
+	// Name is the name of the backfill.
+	Name string `json:"name"`
+	// Processed is the number of rows processed so far.
+	Processed int `json:"processed"`
+	// Total is the number of rows the backfill has to process.
+	Total int `json:"total"`
+}
+
+// MigrationProgress is the progress of a migration of a run.
+type MigrationProgress struct {
+	// Name is the name of the migration.
+	Name string `json:"name"`
+	// Comment is the comment of the migration.
+	Comment string `json:"comment"`
+	// Status is the status of the migration, one of pending, running, completed or failed.
+	Status string `json:"status"`
+	// ElapsedMs is the time spent running the migration in milliseconds.
+	ElapsedMs int64 `json:"elapsedMs"`
+	// Backfill is the progress of the backfill run by the migration, if any.
+	Backfill *BackfillProgress `json:"backfill,omitempty"`
+	// Error is the error of the migration when it failed.
+	Error string `json:"error,omitempty"`
+
+	startedAt time.Time
+	endedAt   time.Time
+}
+
+// RunProgress is the progress of the migrations run by the last call of Migrate.
+type RunProgress struct {
+	// Status is the status of the run, one of idle, running, completed or failed. The run is idle until Migrate
+	// is called.
+	Status string `json:"status"`
+	// Running is the name of the migration which is running, if any.
+	Running string `json:"running,omitempty"`
+	// ElapsedMs is the time spent by the run in milliseconds.
+	ElapsedMs int64 `json:"elapsedMs"`
+	// Migrations are the migrations of the run, in the order they are applied.
+	Migrations []MigrationProgress `json:"migrations"`
+	// Error is the error of the run when it failed.
+	Error string `json:"error,omitempty"`
+}
+
+// ProgressTracker tracks the progress of the migrations and streams it as server-sent events.
+type ProgressTracker struct {
+	mtx        sync.Mutex
+	status     string
+	startedAt  time.Time
+	endedAt    time.Time
+	migrations []MigrationProgress
+	err        error
+	// changedC is closed and replaced on every change of the progress.
+	changedC chan struct{}
+	now      func() time.Time
+}
+
+func newProgressTracker() *ProgressTracker {
+	return &ProgressTracker{
+		status:     StatusIdle,
+		migrations: []MigrationProgress{},
+		changedC:   make(chan struct{}),
+		now:        time.Now,
+	}
+}
+
+// Get returns the progress of the last run along with a channel which is closed when it changes.
+func (progress *ProgressTracker) Get() (RunProgress, <-chan struct{}) {
+	progress.mtx.Lock()
+	defer progress.mtx.Unlock()
+
+	now := progress.now()
+	run := RunProgress{
+		Status:     progress.status,
+		ElapsedMs:  elapsed(progress.startedAt, progress.endedAt, now),
+		Migrations: make([]MigrationProgress, len(progress.migrations)),
+	}
+
+	for i, migration := range progress.migrations {
+		migration.ElapsedMs = elapsed(migration.startedAt, migration.endedAt, now)
+		if migration.Backfill != nil {
+			backfill := *migration.Backfill
+			migration.Backfill = &backfill
+		}
+
+		if migration.Status == StatusRunning {
+			run.Running = migration.Name
+		}
+
+		run.Migrations[i] = migration
+	}
+
+	if progress.err != nil {
+		run.Error = progress.err.Error()
+	}
+
+	return run, progress.changedC
+}
+
+// ServeHTTP streams the progress as server-sent events until the run is no longer running, the last event being
+// the final status of the run. The progress is streamed on every change and at least every second.
+func (progress *ProgressTracker) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming is not supported", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	ticker := time.NewTicker(progressRefreshInterval)
+	defer ticker.Stop()
+
+	for {
+		run, changedC := progress.Get()
+		data, err := json.Marshal(run)
+		if err != nil {
+			return
+		}
+
+		if _, err := fmt.Fprintf(w, "data: %s\n\n", data); err != nil {
+			return
+		}
+		flusher.Flush()
+
+		if run.Status != StatusRunning {
+			return
+		}
+
+		select {
+		case <-r.Context().Done():
+			return
+		case <-changedC:
+		case <-ticker.C:
+		}
+	}
+}
+
+// start starts a run, whose migrations are set once the migration lock is acquired.
+func (progress *ProgressTracker) start() {
+	progress.update(func() {
+		progress.status = StatusRunning
+		progress.startedAt = progress.now()
+		progress.endedAt = time.Time{}
+		progress.err = nil
+		progress.migrations = []MigrationProgress{}
+	})
+}
+
+// plan sets the pending migrations of the run.
+func (progress *ProgressTracker) plan(pending migrate.MigrationSlice) {
+	progress.update(func() {
+		progress.migrations = make([]MigrationProgress, 0, len(pending))
+		for _, migration := range pending {
+			progress.migrations = append(progress.migrations, MigrationProgress{Name: migration.Name, Comment: migration.Comment, Status: StatusPending})
+		}
+	})
+}
+
+// end ends the run, failed when err is not nil.
+func (progress *ProgressTracker) end(err error) {
+	progress.update(func() {
+		progress.status = StatusCompleted
+		progress.endedAt = progress.now()
+		progress.err = err
+		if err != nil {
+			progress.status = StatusFailed
+		}
+	})
+}
+
+func (progress *ProgressTracker) startMigration(name string) {
+	progress.updateMigration(name, func(migration *MigrationProgress) {
+		migration.Status = StatusRunning
+		migration.startedAt = progress.now()
+	})
+}
+
+func (progress *ProgressTracker) endMigration(name string, err error) {
+	progress.updateMigration(name, func(migration *MigrationProgress) {
+		migration.Status = StatusCompleted
+		migration.endedAt = progress.now()
+		if err != nil {
+			migration.Status = StatusFailed
+			migration.Error = err.Error()
+		}
+	})
+}
+
+// reportBackfill is the backfill reporter of the migration of the given name.
+func (progress *ProgressTracker) reportBackfill(migration string) sqlmigration.BackfillReporter {
+	return func(ctx context.Context, name string, processed int, total int) {
+		progress.updateMigration(migration, func(migration *MigrationProgress) {
+			migration.Backfill = &BackfillProgress{Name: name, Processed: processed, Total: total}
+		})
+	}
+}
+
+func (progress *ProgressTracker) updateMigration(name string, fn func(*MigrationProgress)) {
+	progress.update(func() {
+		index := slices.IndexFunc(progress.migrations, func(migration MigrationProgress) bool { return migration.Name == name })
+		if index < 0 {
+			return
+		}
+
+		fn(&progress.migrations[index])
+	})
+}
+
+func (progress *ProgressTracker) update(fn func()) {
+	progress.mtx.Lock()
+	defer progress.mtx.Unlock()
+
+	fn()
+	close(progress.changedC)
+	progress.changedC = make(chan struct{})
+}
+
+// track returns the migrations whose runs are tracked by the progress.
+func (progress *ProgressTracker) track(migrations *migrate.Migrations) *migrate.Migrations {
+	tracked := migrate.NewMigrations()
+	for _, migration := range migrations.Sorted() {
+		up := migration.Up
+		if up != nil {
+			name := migration.Name
+			migration.Up = func(ctx context.Context, db *bun.DB) error {
+				progress.startMigration(name)
+				err := up(sqlmigration.NewContextWithBackfillReporter(ctx, progress.reportBackfill(name)), db)
+				progress.endMigration(name, err)
+				return err
+			}
+		}
+
+		tracked.Add(migration)
+	}
+
+	return tracked
+}
+
+func elapsed(startedAt time.Time, endedAt time.Time, now time.Time) int64 {
+	if startedAt.IsZero() {
+		return 0
+	}
+
+	if endedAt.IsZero() {
+		endedAt = now
+	}
+
+	return endedAt.Sub(startedAt).Milliseconds()
+}
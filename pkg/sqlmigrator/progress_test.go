@@ -0,0 +1,183 @@
+package sqlmigrator
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/instrumentation/instrumentationtest"
+	"github.com/SigNoz/signoz/pkg/sqlmigration"
+	"github.com/SigNoz/signoz/pkg/sqlstore"
+	"github.com/SigNoz/signoz/pkg/sqlstore/sqlitesqlstore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/migrate"
+)
+
+// readProgress reads the events of a progress stream until it ends, calling fn with every one of them.
+func readProgress(t *testing.T, res *http.Response, fn func(RunProgress)) []RunProgress {
+	defer res.Body.Close()
+	assert.Equal(t, "text/event-stream", res.Header.Get("Content-Type"))
+
+	runs := []RunProgress{}
+	scanner := bufio.NewScanner(res.Body)
+	for scanner.Scan() {
+		data, ok := strings.CutPrefix(scanner.Text(), "data: ")
+		if !ok {
+			continue
+		}
+
+		var run RunProgress
+		require.NoError(t, json.Unmarshal([]byte(data), &run))
+		runs = append(runs, run)
+		fn(run)
+	}
+	require.NoError(t, scanner.Err())
+
+	return runs
+}
+
+func TestMigratorProgressWithSqlite(t *testing.T) {
+	ctx := context.Background()
+	providerSettings := instrumentationtest.New().ToProviderSettings()
+
+	store, err := sqlitesqlstore.New(ctx, providerSettings, sqlstore.Config{
+		Provider: "sqlite",
+		Sqlite:   sqlstore.SqliteConfig{Path: filepath.Join(t.TempDir(), "signoz.db")},
+	})
+	require.NoError(t, err)
+
+	releaseC := make(chan struct{})
+	backfill, err := sqlmigration.NewBackfill(providerSettings, sqlmigration.BackfillConfig{BatchSize: 5}, "fill_event_name", "event", "id", func(ctx context.Context, tx bun.Tx, ids []string) error {
+		// The backfill waits for the stream to have seen it running.
+		<-releaseC
+		_, err := tx.NewUpdate().Table("event").Set("name = id").Where("id IN (?)", bun.In(ids)).Exec(ctx)
+		return err
+	})
+	require.NoError(t, err)
+
+	migrations := migrate.NewMigrations()
+	migrations.Add(migrate.Migration{
+		Name:    "001",
+		Comment: "add_event",
+		Up: func(ctx context.Context, db *bun.DB) error {
+			if _, err := db.ExecContext(ctx, "CREATE TABLE event (id TEXT PRIMARY KEY, name TEXT)"); err != nil {
+				return err
+			}
+
+			// The table of the checkpoints of the backfills is added by a migration of signoz.
+			if _, err := db.ExecContext(ctx, "CREATE TABLE backfill_checkpoint (name TEXT PRIMARY KEY, last_id TEXT NOT NULL, updated_at TIMESTAMP NOT NULL)"); err != nil {
+				return err
+			}
+
+			for i := range 10 {
+				if _, err := db.ExecContext(ctx, "INSERT INTO event (id) VALUES (?)", fmt.Sprintf("%02d", i)); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		},
+	})
+	migrations.Add(migrate.Migration{
+		Name:    "002",
+		Comment: "fill_event_name",
+		Up: func(ctx context.Context, db *bun.DB) error {
+			return backfill.Run(ctx, db)
+		},
+	})
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	address := listener.Addr().String()
+	require.NoError(t, listener.Close())
+
+	config := Config{Lock: Lock{Timeout: 10 * time.Second, Interval: time.Second}, Progress: Progress{Address: address}}
+	migrator := New(ctx, providerSettings, store, migrations, nil, config)
+	assert.Equal(t, StatusIdle, func() string { run, _ := migrator.Progress().Get(); return run.Status }())
+
+	errC := make(chan error, 1)
+	go func() {
+		errC <- migrator.Migrate(ctx)
+	}()
+
+	var res *http.Response
+	require.Eventually(t, func() bool {
+		res, err = http.Get("http://" + address + "/api/v1/migrations/progress")
+		return err == nil
+	}, 5*time.Second, 10*time.Millisecond)
+
+	released := false
+	runs := readProgress(t, res, func(run RunProgress) {
+		if !released && run.Running == "002" && run.Migrations[1].Backfill != nil {
+			assert.Equal(t, StatusRunning, run.Status)
+			assert.Equal(t, StatusCompleted, run.Migrations[0].Status)
+			assert.Equal(t, BackfillProgress{Name: "fill_event_name", Processed: 0, Total: 10}, *run.Migrations[1].Backfill)
+			released = true
+			close(releaseC)
+		}
+	})
+	require.NoError(t, <-errC)
+	assert.True(t, released)
+
+	// The stream ends with the final status of the run.
+	last := runs[len(runs)-1]
+	assert.Equal(t, StatusCompleted, last.Status)
+	assert.Empty(t, last.Running)
+	require.Len(t, last.Migrations, 2)
+	assert.Equal(t, "fill_event_name", last.Migrations[1].Comment)
+	assert.Equal(t, StatusCompleted, last.Migrations[1].Status)
+	assert.Equal(t, BackfillProgress{Name: "fill_event_name", Processed: 10, Total: 10}, *last.Migrations[1].Backfill)
+
+	// The progress server is stopped once the migrations ran, the progress is still served by the api server.
+	_, err = http.Get("http://" + address + "/api/v1/migrations/progress")
+	assert.Error(t, err)
+
+	server := httptest.NewServer(migrator.Progress())
+	defer server.Close()
+
+	res, err = http.Get(server.URL)
+	require.NoError(t, err)
+	runs = readProgress(t, res, func(RunProgress) {})
+	require.Len(t, runs, 1)
+	assert.Equal(t, StatusCompleted, runs[0].Status)
+}
+
+func TestMigratorProgressFailureWithSqlite(t *testing.T) {
+	ctx := context.Background()
+	providerSettings := instrumentationtest.New().ToProviderSettings()
+
+	store, err := sqlitesqlstore.New(ctx, providerSettings, sqlstore.Config{
+		Provider: "sqlite",
+		Sqlite:   sqlstore.SqliteConfig{Path: filepath.Join(t.TempDir(), "signoz.db")},
+	})
+	require.NoError(t, err)
+
+	migrations := migrate.NewMigrations()
+	migrations.Add(migrate.Migration{
+		Name: "001",
+		Up: func(ctx context.Context, db *bun.DB) error {
+			_, err := db.ExecContext(ctx, "CREATE TABLE")
+			return err
+		},
+	})
+
+	migrator := New(ctx, providerSettings, store, migrations, nil, Config{Lock: Lock{Timeout: 10 * time.Second, Interval: time.Second}})
+	require.Error(t, migrator.Migrate(ctx))
+
+	run, _ := migrator.Progress().Get()
+	assert.Equal(t, StatusFailed, run.Status)
+	assert.NotEmpty(t, run.Error)
+	require.Len(t, run.Migrations, 1)
+	assert.Equal(t, StatusFailed, run.Migrations[0].Status)
+	assert.Equal(t, run.Error, run.Migrations[0].Error)
+}
@@ -10,4 +10,23 @@ type SQLMigrator interface {
 	Migrate(context.Context) error
 	// Rollback rolls back the database. Rollback acquires a lock on the database and rolls back the migrations.
 	Rollback(context.Context) error
+	// DryRun returns the ordered list of pending migrations. The migrations are run against a scratch copy of sqlite
+	// databases, and inside a transaction which is rolled back for postgres, to return the SQL they would execute.
+	// DryRun does not acquire a lock and does not mutate the database or the migration table.
+	DryRun(context.Context) ([]MigrationPlan, error)
+	// Ready returns an error while some of the migrations have not been applied.
+	Ready(context.Context) error
+	// Progress returns the tracker of the progress of the migrations run by Migrate.
+	Progress() *ProgressTracker
+}
+
+// MigrationPlan is a pending migration returned by DryRun.
+type MigrationPlan struct {
+	// Name is the name of the migration.
+	Name string `json:"name"`
+	// Comment is the comment of the migration.
+	Comment string `json:"comment"`
+	// SQL is the ordered list of statements the migration would execute. It is only known for sqlite and is empty
+	// for the other dialects.
+	SQL []string `json:"sql"`
 }
@@ -0,0 +1,162 @@
+package sqlstore
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"slices"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/uptrace/bun"
+)
+
+var (
+	ErrCodeBackupInvalid         = errors.MustNewCode("backup_invalid")
+	ErrCodeBackupVersionMismatch = errors.MustNewCode("backup_version_mismatch")
+)
+
+const (
+	// migrationTableName is the table of the applied migrations, see sqlmigrator.
+	migrationTableName string = "migration"
+)
+
+// backupExcludedTables are the tables of the migrations. They are not backed up, the database a backup is restored
+// into is migrated by itself and must be at the migration version of the backup.
+var backupExcludedTables = []string{migrationTableName, "migration_lock", "migration_checksum"}
+
+// BackupHeader is the first line of a backup, it describes the data which follows.
+type BackupHeader struct {
+	// Dialect is the dialect of the database which was backed up, a backup is only restored into the same dialect.
+	Dialect string `json:"dialect"`
+	// MigrationVersion is the name of the last migration applied to the database which was backed up.
+	MigrationVersion string `json:"migration_version"`
+	// Tables are the tables in the backup, the referenced tables come first.
+	Tables    []string  `json:"tables"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type RestoreOptions struct {
+	// DryRun restores the backup in a transaction which is rolled back, it verifies that the backup can be restored
+	// without changing the database.
+	DryRun bool
+}
+
+// Backup writes a consistent logical backup of the data of the store to the writer. The backup is a json header
+// followed by the data in the format of the dialect. The schema is not backed up, it is recreated by the migrations.
+func Backup(ctx context.Context, store SQLStore, w io.Writer) (*BackupHeader, error) {
+	version, err := MigrationVersion(ctx, store.BunDB())
+	if err != nil {
+		return nil, err
+	}
+
+	tables, err := store.Dialect().BackupTables(ctx, store.BunDB())
+	if err != nil {
+		return nil, err
+	}
+
+	header := &BackupHeader{
+		Dialect:          store.BunDB().Dialect().Name().String(),
+		MigrationVersion: version,
+		Tables:           slices.DeleteFunc(tables, func(table string) bool { return slices.Contains(backupExcludedTables, table) }),
+		CreatedAt:        time.Now(),
+	}
+
+	if err := json.NewEncoder(w).Encode(header); err != nil {
+		return nil, err
+	}
+
+	if err := store.Dialect().Backup(ctx, store.BunDB(), header.Tables, w); err != nil {
+		return nil, err
+	}
+
+	return header, nil
+}
+
+// Restore restores a backup written by Backup into the store, which must have been migrated to the migration version
+// of the backup. The data of the tables in the backup replaces the data of the store in a single transaction, the
+// rows created by the migrations of a fresh database are hence replaced by the ones of the backup.
+func Restore(ctx context.Context, store SQLStore, r io.Reader, opts RestoreOptions) (*BackupHeader, error) {
+	reader := bufio.NewReader(r)
+	line, err := reader.ReadBytes('\n')
+	if err != nil {
+		return nil, errors.Wrapf(err, errors.TypeInvalidInput, ErrCodeBackupInvalid, "cannot read the header of the backup")
+	}
+
+	header := new(BackupHeader)
+	if err := json.Unmarshal(line, header); err != nil {
+		return nil, errors.Wrapf(err, errors.TypeInvalidInput, ErrCodeBackupInvalid, "cannot parse the header of the backup")
+	}
+
+	if dialect := store.BunDB().Dialect().Name().String(); header.Dialect != dialect {
+		return nil, errors.Newf(errors.TypeInvalidInput, ErrCodeBackupInvalid, "cannot restore a backup of %s into %s", header.Dialect, dialect)
+	}
+
+	version, err := MigrationVersion(ctx, store.BunDB())
+	if err != nil {
+		return nil, err
+	}
+
+	if header.MigrationVersion != version {
+		return nil, errors.Newf(errors.TypeInvalidInput, ErrCodeBackupVersionMismatch, "the backup is at migration %q but the database is at migration %q", header.MigrationVersion, version)
+	}
+
+	tables, err := store.Dialect().BackupTables(ctx, store.BunDB())
+	if err != nil {
+		return nil, err
+	}
+
+	for _, table := range header.Tables {
+		if slices.Contains(backupExcludedTables, table) || !slices.Contains(tables, table) {
+			return nil, errors.Newf(errors.TypeInvalidInput, ErrCodeBackupInvalid, "table %q of the backup cannot be restored", table)
+		}
+	}
+
+	if err := store.Dialect().Restore(ctx, store.BunDB(), header.Tables, reader, opts.DryRun); err != nil {
+		return nil, err
+	}
+
+	return header, nil
+}
+
+// MigrationVersion returns the name of the last migration applied to the database.
+func MigrationVersion(ctx context.Context, db bun.IDB) (string, error) {
+	var version string
+	if err := db.NewSelect().Table(migrationTableName).Column("name").OrderExpr("id DESC").Limit(1).Scan(ctx, &version); err != nil {
+		return "", errors.Wrapf(err, errors.TypeInternal, errors.CodeInternal, "cannot read the migration version")
+	}
+
+	return version, nil
+}
+
+// OrderByReferences orders the tables so that the tables referenced by the foreign keys of a table come before it.
+// The references are the tables referenced by every table, self references and cycles are ignored.
+func OrderByReferences(tables []string, references map[string][]string) []string {
+	sorted := slices.Sorted(slices.Values(tables))
+	ordered := make([]string, 0, len(tables))
+	visited := make(map[string]bool, len(tables))
+
+	var visit func(table string)
+	visit = func(table string) {
+		if visited[table] {
+			return
+		}
+		visited[table] = true
+
+		referenced := slices.Sorted(slices.Values(references[table]))
+		for _, reference := range referenced {
+			if slices.Contains(sorted, reference) {
+				visit(reference)
+			}
+		}
+
+		ordered = append(ordered, table)
+	}
+
+	for _, table := range sorted {
+		visit(table)
+	}
+
+	return ordered
+}
@@ -0,0 +1,20 @@
+package sqlstore
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOrderByReferences(t *testing.T) {
+	tables := []string{"users", "dashboards", "organizations", "factor_password", "tags"}
+	references := map[string][]string{
+		"users":           {"organizations"},
+		"dashboards":      {"organizations", "users"},
+		"factor_password": {"users"},
+		// Self references and references to unknown tables are ignored.
+		"tags": {"tags", "unknown"},
+	}
+
+	assert.Equal(t, []string{"organizations", "users", "dashboards", "factor_password", "tags"}, OrderByReferences(tables, references))
+}
@@ -1,7 +1,20 @@
 package sqlstore
 
 import (
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
 	"github.com/SigNoz/signoz/pkg/factory"
+	"github.com/SigNoz/signoz/pkg/valuer"
+)
+
+var (
+	sqliteJournalModes = []string{"delete", "truncate", "persist", "memory", "wal", "off"}
+	sqliteSynchronous  = []string{"off", "normal", "full", "extra"}
+	sqliteVacuumModes  = []string{"full", "incremental"}
 )
 
 type Config struct {
@@ -13,21 +26,95 @@ type Config struct {
 	Sqlite SqliteConfig `mapstructure:"sqlite"`
 	// Postgres is the postgres configuration.
 	Postgres PostgresConfig `mapstructure:"postgres"`
+	// Encryption is the configuration of the encryption of the sensitive columns.
+	Encryption EncryptionConfig `mapstructure:"encryption"`
+	// Outbox is the configuration of the relay publishing the events of the outbox.
+	Outbox OutboxConfig `mapstructure:"outbox"`
+}
+
+type OutboxConfig struct {
+	// Enabled enables the relay publishing the events of the outbox to the sink.
+	Enabled bool `mapstructure:"enabled"`
+	// Interval is the interval between two runs of the relay.
+	Interval time.Duration `mapstructure:"interval"`
+	// BatchSize is the maximum number of events published by a run of the relay.
+	BatchSize int `mapstructure:"batch_size"`
+	// MaxAttempts is the number of failed attempts to publish an event after which the event is given up on.
+	MaxAttempts int `mapstructure:"max_attempts"`
+	// InitialBackoff is the time waited before publishing an event again after its first failed attempt. It is
+	// doubled after every attempt.
+	InitialBackoff time.Duration `mapstructure:"initial_backoff"`
+	// MaxBackoff is the maximum time waited between two attempts to publish an event.
+	MaxBackoff time.Duration `mapstructure:"max_backoff"`
+	// Retention is the time for which the published events are kept before being deleted. The events written while
+	// the relay is disabled are deleted once they are older than it.
+	Retention time.Duration `mapstructure:"retention"`
+	// FailedRetention is the time for which the events given up on are kept before being deleted.
+	FailedRetention time.Duration `mapstructure:"failed_retention"`
+	// Webhook is the webhook to which the events are published.
+	Webhook OutboxWebhookConfig `mapstructure:"webhook"`
+}
+
+type OutboxWebhookConfig struct {
+	// URL is the url to which the events are posted.
+	URL string `mapstructure:"url"`
+	// OrgURLs are the urls to which the events of the organizations are posted instead of the url, by the id of the
+	// organizations.
+	OrgURLs map[string]string `mapstructure:"org_urls"`
+	// Timeout is the timeout of the publication of an event.
+	Timeout time.Duration `mapstructure:"timeout"`
+}
+
+type EncryptionConfig struct {
+	// Enabled enables the encryption of the sensitive columns.
+	Enabled bool `mapstructure:"enabled"`
+	// Path is the path in the secret store under which the keys of the organizations are stored, at <path>/<org id>.
+	Path string `mapstructure:"path"`
+	// KeyID is the id of the current key of the organizations, which is the key of their secrets. The values are
+	// encrypted with the current key and decrypted with the key which encrypted them.
+	KeyID string `mapstructure:"key_id"`
+	// RotationInterval is the interval at which the values which are not encrypted with the current keys are
+	// encrypted again.
+	RotationInterval time.Duration `mapstructure:"rotation_interval"`
 }
 
 type PostgresConfig struct {
 	// DSN is the database source name.
 	DSN string `mapstructure:"dsn"`
+	// ReplicaDSNs are the database source names of the read replicas.
+	ReplicaDSNs []string `mapstructure:"replica_dsns"`
 }
 
 type SqliteConfig struct {
 	// Path is the path to the sqlite database.
 	Path string `mapstructure:"path"`
+	// JournalMode is the journal mode of the sqlite database (delete, truncate, persist, memory, wal or off).
+	JournalMode string `mapstructure:"journal_mode"`
+	// Synchronous is the synchronous setting of the sqlite database (off, normal, full or extra).
+	Synchronous string `mapstructure:"synchronous"`
+	// BusyTimeout is the time to wait for a lock to be released before returning "database is locked".
+	BusyTimeout time.Duration `mapstructure:"busy_timeout"`
+	// Vacuum is the configuration of the periodic vacuum of the sqlite database.
+	Vacuum SqliteVacuumConfig `mapstructure:"vacuum"`
+}
+
+type SqliteVacuumConfig struct {
+	// Enabled enables the periodic vacuum of the sqlite database.
+	Enabled bool `mapstructure:"enabled"`
+	// Mode is the vacuum mode (full or incremental). A full vacuum rebuilds the whole database while an incremental
+	// vacuum only releases its free pages. The first incremental vacuum of a database is a full vacuum.
+	Mode string `mapstructure:"mode"`
+	// Interval is the interval between two vacuums. A vacuum which is due waits until the database is idle.
+	Interval time.Duration `mapstructure:"interval"`
+	// MinReclaimableBytes is the minimum size of the free pages of the database for a vacuum to run.
+	MinReclaimableBytes int64 `mapstructure:"min_reclaimable_bytes"`
 }
 
 type ConnectionConfig struct {
 	// MaxOpenConns is the maximum number of open connections to the database.
 	MaxOpenConns int `mapstructure:"max_open_conns"`
+	// ConnectRetry is the retry policy of the initial connection to the database.
+	ConnectRetry factory.ConnectRetryConfig `mapstructure:"connect_retry"`
 }
 
 func NewConfigFactory() factory.ConfigFactory {
@@ -39,14 +126,141 @@ func newConfig() factory.Config {
 		Provider: "sqlite",
 		Connection: ConnectionConfig{
 			MaxOpenConns: 100,
+			ConnectRetry: factory.ConnectRetryConfig{
+				MaxDuration:    0,
+				InitialBackoff: time.Second,
+				MaxBackoff:     30 * time.Second,
+			},
 		},
 		Sqlite: SqliteConfig{
-			Path: "/var/lib/signoz/signoz.db",
+			Path:        "/var/lib/signoz/signoz.db",
+			JournalMode: "delete",
+			Synchronous: "full",
+			BusyTimeout: 10 * time.Second,
+			Vacuum: SqliteVacuumConfig{
+				Enabled:             false,
+				Mode:                "full",
+				Interval:            24 * time.Hour,
+				MinReclaimableBytes: 16 * 1024 * 1024,
+			},
+		},
+		Encryption: EncryptionConfig{
+			Enabled:          false,
+			Path:             "signoz/sqlstore",
+			KeyID:            "v1",
+			RotationInterval: time.Hour,
+		},
+		Outbox: OutboxConfig{
+			Enabled:         false,
+			Interval:        5 * time.Second,
+			BatchSize:       100,
+			MaxAttempts:     10,
+			InitialBackoff:  5 * time.Second,
+			MaxBackoff:      time.Hour,
+			Retention:       7 * 24 * time.Hour,
+			FailedRetention: 30 * 24 * time.Hour,
+			Webhook: OutboxWebhookConfig{
+				URL:     "",
+				OrgURLs: map[string]string{},
+				Timeout: 10 * time.Second,
+			},
 		},
 	}
 
 }
 
 func (c Config) Validate() error {
+	if err := c.Connection.ConnectRetry.Validate(); err != nil {
+		return errors.Wrapf(err, errors.TypeInvalidInput, errors.CodeInvalidInput, "invalid connect_retry")
+	}
+
+	if c.Sqlite.JournalMode != "" && !slices.Contains(sqliteJournalModes, strings.ToLower(c.Sqlite.JournalMode)) {
+		return errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "sqlite::journal_mode must be one of %s, got %q", strings.Join(sqliteJournalModes, ", "), c.Sqlite.JournalMode)
+	}
+
+	if c.Sqlite.Synchronous != "" && !slices.Contains(sqliteSynchronous, strings.ToLower(c.Sqlite.Synchronous)) {
+		return errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "sqlite::synchronous must be one of %s, got %q", strings.Join(sqliteSynchronous, ", "), c.Sqlite.Synchronous)
+	}
+
+	if c.Sqlite.BusyTimeout < 0 {
+		return errors.New(errors.TypeInvalidInput, errors.CodeInvalidInput, "sqlite::busy_timeout cannot be negative")
+	}
+
+	if c.Sqlite.Vacuum.Enabled {
+		if !slices.Contains(sqliteVacuumModes, strings.ToLower(c.Sqlite.Vacuum.Mode)) {
+			return errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "sqlite::vacuum::mode must be one of %s, got %q", strings.Join(sqliteVacuumModes, ", "), c.Sqlite.Vacuum.Mode)
+		}
+
+		if c.Sqlite.Vacuum.Interval <= 0 {
+			return errors.New(errors.TypeInvalidInput, errors.CodeInvalidInput, "sqlite::vacuum::interval must be positive")
+		}
+
+		if c.Sqlite.Vacuum.MinReclaimableBytes < 0 {
+			return errors.New(errors.TypeInvalidInput, errors.CodeInvalidInput, "sqlite::vacuum::min_reclaimable_bytes cannot be negative")
+		}
+	}
+
+	if c.Encryption.Enabled {
+		if c.Encryption.Path == "" {
+			return errors.New(errors.TypeInvalidInput, errors.CodeInvalidInput, "encryption::path must be set")
+		}
+
+		if c.Encryption.KeyID == "" || strings.Contains(c.Encryption.KeyID, ":") {
+			return errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "encryption::key_id must be set and cannot contain \":\", got %q", c.Encryption.KeyID)
+		}
+
+		if c.Encryption.RotationInterval <= 0 {
+			return errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "encryption::rotation_interval must be positive, got %v", c.Encryption.RotationInterval)
+		}
+	}
+
+	if c.Outbox.Enabled {
+		if c.Outbox.Interval <= 0 {
+			return errors.New(errors.TypeInvalidInput, errors.CodeInvalidInput, "outbox::interval must be positive")
+		}
+
+		if c.Outbox.BatchSize <= 0 {
+			return errors.New(errors.TypeInvalidInput, errors.CodeInvalidInput, "outbox::batch_size must be positive")
+		}
+
+		if c.Outbox.MaxAttempts <= 0 {
+			return errors.New(errors.TypeInvalidInput, errors.CodeInvalidInput, "outbox::max_attempts must be positive")
+		}
+
+		if c.Outbox.InitialBackoff <= 0 {
+			return errors.New(errors.TypeInvalidInput, errors.CodeInvalidInput, "outbox::initial_backoff must be positive")
+		}
+
+		if c.Outbox.MaxBackoff < c.Outbox.InitialBackoff {
+			return errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "outbox::max_backoff must not be less than outbox::initial_backoff (%v), got %v", c.Outbox.InitialBackoff, c.Outbox.MaxBackoff)
+		}
+
+		if c.Outbox.Retention <= 0 {
+			return errors.New(errors.TypeInvalidInput, errors.CodeInvalidInput, "outbox::retention must be positive")
+		}
+
+		if c.Outbox.FailedRetention <= 0 {
+			return errors.New(errors.TypeInvalidInput, errors.CodeInvalidInput, "outbox::failed_retention must be positive")
+		}
+
+		if u, err := url.Parse(c.Outbox.Webhook.URL); err != nil || !u.IsAbs() || u.Host == "" {
+			return errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "outbox::webhook::url must be an absolute url, got %q", c.Outbox.Webhook.URL)
+		}
+
+		for orgID, orgURL := range c.Outbox.Webhook.OrgURLs {
+			if _, err := valuer.NewUUID(orgID); err != nil {
+				return errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "outbox::webhook::org_urls must be keyed by the ids of the organizations, got %q", orgID)
+			}
+
+			if u, err := url.Parse(orgURL); err != nil || !u.IsAbs() || u.Host == "" {
+				return errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "outbox::webhook::org_urls::%s must be an absolute url, got %q", orgID, orgURL)
+			}
+		}
+
+		if c.Outbox.Webhook.Timeout <= 0 {
+			return errors.New(errors.TypeInvalidInput, errors.CodeInvalidInput, "outbox::webhook::timeout must be positive")
+		}
+	}
+
 	return nil
 }
@@ -0,0 +1,364 @@
+package sqlstore
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"strings"
+	"sync"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/factory"
+	"github.com/SigNoz/signoz/pkg/secretstore"
+	"github.com/uptrace/bun"
+)
+
+const (
+	// encryptedPrefix is the prefix of the encrypted values, followed by the id of the key encrypting their data key,
+	// the encrypted data key and the encrypted value.
+	encryptedPrefix string = "enc:v1:"
+
+	keySize int = 32
+)
+
+var (
+	ErrCodeEncryptionFailed = errors.MustNewCode("sqlstore_encryption_failed")
+	ErrCodeDecryptionFailed = errors.MustNewCode("sqlstore_decryption_failed")
+)
+
+// EncryptedColumn is a column of a table holding values encrypted by an Encryptor.
+type EncryptedColumn struct {
+	// Table is the name of the table.
+	Table string
+	// Column is the name of the encrypted column.
+	Column string
+	// IDColumn is the name of the primary key column of the table.
+	IDColumn string
+	// OrgIDColumn is the name of the column holding the id of the organization of the rows.
+	OrgIDColumn string
+}
+
+// EncryptedColumns are the sensitive columns encrypted when the encryption is enabled. Their values are encrypted by
+// their stores on write and rotated by the rotation job.
+var EncryptedColumns = []EncryptedColumn{
+	{Table: "notification_channel", Column: "signing_secret", IDColumn: "id", OrgIDColumn: "org_id"},
+}
+
+// Encryptor encrypts the values of the sensitive columns with the keys of their organizations, which are resolved
+// from the secret store. Every value is encrypted with a random data key, itself encrypted with the key of the
+// organization, so that rotating the key of an organization only requires encrypting the data keys again.
+type Encryptor struct {
+	config EncryptionConfig
+	store  secretstore.SecretStore
+
+	mtx  sync.RWMutex
+	keys map[string]cipher.AEAD
+}
+
+// NewEncryptor returns the encryptor resolving the keys of the organizations from the given secret store.
+func NewEncryptor(config EncryptionConfig, store secretstore.SecretStore) *Encryptor {
+	return &Encryptor{
+		config: config,
+		store:  store,
+		keys:   make(map[string]cipher.AEAD),
+	}
+}
+
+// IsEncrypted returns whether the value has been encrypted by an encryptor.
+func IsEncrypted(value string) bool {
+	return strings.HasPrefix(value, encryptedPrefix)
+}
+
+// Enabled returns whether the values of the sensitive columns are encrypted.
+func (encryptor *Encryptor) Enabled() bool {
+	return encryptor != nil && encryptor.config.Enabled
+}
+
+// EncryptValue encrypts the value of a sensitive column when the encryption is enabled. The empty values and the
+// values which are already encrypted are returned as is.
+func (encryptor *Encryptor) EncryptValue(ctx context.Context, orgID string, value string) (string, error) {
+	if !encryptor.Enabled() || value == "" || IsEncrypted(value) {
+		return value, nil
+	}
+
+	return encryptor.Encrypt(ctx, orgID, value)
+}
+
+// DecryptValue decrypts the value of a sensitive column when it is encrypted. The values written before the
+// encryption was enabled are returned as is, the encrypted values are decrypted even once it is disabled.
+func (encryptor *Encryptor) DecryptValue(ctx context.Context, orgID string, value string) (string, error) {
+	if !IsEncrypted(value) {
+		return value, nil
+	}
+
+	if encryptor == nil {
+		return "", errors.New(errors.TypeInternal, ErrCodeDecryptionFailed, "cannot decrypt the value without an encryptor")
+	}
+
+	return encryptor.Decrypt(ctx, orgID, value)
+}
+
+// Encrypt encrypts the value of the organization with its current key.
+func (encryptor *Encryptor) Encrypt(ctx context.Context, orgID string, value string) (string, error) {
+	if !encryptor.config.Enabled {
+		return "", errors.New(errors.TypeUnsupported, ErrCodeEncryptionFailed, "the encryption of the sqlstore is not enabled")
+	}
+
+	dataKey := make([]byte, keySize)
+	if _, err := rand.Read(dataKey); err != nil {
+		return "", errors.Wrapf(err, errors.TypeInternal, ErrCodeEncryptionFailed, "failed to generate a data key")
+	}
+
+	data, err := newAEAD(dataKey)
+	if err != nil {
+		return "", errors.Wrapf(err, errors.TypeInternal, ErrCodeEncryptionFailed, "failed to create the cipher of the data key")
+	}
+
+	return encryptor.seal(ctx, orgID, dataKey, seal(data, []byte(value), []byte(orgID)))
+}
+
+// Decrypt decrypts the value of the organization. It fails when the value is not encrypted, or is encrypted with a
+// key or for an organization other than the given ones, so that a value is never returned undecrypted.
+func (encryptor *Encryptor) Decrypt(ctx context.Context, orgID string, value string) (string, error) {
+	keyID, dataKey, sealed, err := encryptor.open(ctx, orgID, value)
+	if err != nil {
+		return "", err
+	}
+
+	data, err := newAEAD(dataKey)
+	if err != nil {
+		return "", errors.Newf(errors.TypeInternal, ErrCodeDecryptionFailed, "invalid data key of the value encrypted with key %q: %v", keyID, err)
+	}
+
+	plaintext, err := unseal(data, sealed, []byte(orgID))
+	if err != nil {
+		return "", errors.Newf(errors.TypeInternal, ErrCodeDecryptionFailed, "failed to decrypt the value encrypted with key %q for organization %s: %v", keyID, orgID, err)
+	}
+
+	return string(plaintext), nil
+}
+
+// Rotate encrypts the data key of the value with the current key of the organization. Values which are not
+// encrypted yet are encrypted, the values already encrypted with the current key are returned as is.
+func (encryptor *Encryptor) Rotate(ctx context.Context, orgID string, value string) (string, error) {
+	if !IsEncrypted(value) {
+		return encryptor.Encrypt(ctx, orgID, value)
+	}
+
+	keyID, dataKey, sealed, err := encryptor.open(ctx, orgID, value)
+	if err != nil {
+		return "", err
+	}
+
+	if keyID == encryptor.config.KeyID {
+		return value, nil
+	}
+
+	return encryptor.seal(ctx, orgID, dataKey, sealed)
+}
+
+// RotateColumn rotates the values of the column which are not encrypted with the current keys of their
+// organizations, for example after a key rotation or to encrypt the values of a column which was not encrypted. It
+// returns the number of rows updated. Run it in a transaction to update all the rows or none.
+func (encryptor *Encryptor) RotateColumn(ctx context.Context, db bun.IDB, column EncryptedColumn) (int, error) {
+	rows, err := db.QueryContext(ctx, "SELECT ?, ?, ? FROM ?", bun.Ident(column.IDColumn), bun.Ident(column.OrgIDColumn), bun.Ident(column.Column), bun.Ident(column.Table))
+	if err != nil {
+		return 0, errors.Wrapf(err, errors.TypeInternal, errors.CodeInternal, "failed to select the rows of %s", column.Table)
+	}
+
+	type row struct {
+		id    string
+		value string
+	}
+
+	updates := make([]row, 0)
+	for rows.Next() {
+		var id, orgID string
+		var value *string
+		if err := rows.Scan(&id, &orgID, &value); err != nil {
+			_ = rows.Close()
+			return 0, errors.Wrapf(err, errors.TypeInternal, errors.CodeInternal, "failed to scan the rows of %s", column.Table)
+		}
+
+		if value == nil || *value == "" {
+			continue
+		}
+
+		rotated, err := encryptor.Rotate(ctx, orgID, *value)
+		if err != nil {
+			_ = rows.Close()
+			return 0, errors.Newf(errors.TypeInternal, ErrCodeEncryptionFailed, "failed to rotate %s.%s of row %s: %v", column.Table, column.Column, id, err)
+		}
+
+		if rotated != *value {
+			updates = append(updates, row{id: id, value: rotated})
+		}
+	}
+
+	if err := rows.Close(); err != nil {
+		return 0, errors.Wrapf(err, errors.TypeInternal, errors.CodeInternal, "failed to select the rows of %s", column.Table)
+	}
+
+	if err := rows.Err(); err != nil {
+		return 0, errors.Wrapf(err, errors.TypeInternal, errors.CodeInternal, "failed to select the rows of %s", column.Table)
+	}
+
+	// The rows are updated once they have all been read as some drivers cannot run queries while rows are open.
+	for _, update := range updates {
+		if _, err := db.ExecContext(ctx, "UPDATE ? SET ? = ? WHERE ? = ?", bun.Ident(column.Table), bun.Ident(column.Column), update.value, bun.Ident(column.IDColumn), update.id); err != nil {
+			return 0, errors.Wrapf(err, errors.TypeInternal, errors.CodeInternal, "failed to update %s.%s of row %s", column.Table, column.Column, update.id)
+		}
+	}
+
+	return len(updates), nil
+}
+
+// NewEncryptionRotationJob returns the job rotating the values of the encrypted columns, which encrypts the values
+// written before the encryption was enabled and encrypts the data keys again with the current keys once key_id is
+// changed. It runs on a single replica at a time.
+func NewEncryptionRotationJob(store SQLStore, encryptor *Encryptor, config EncryptionConfig) factory.Job {
+	return factory.NewJob(
+		factory.MustNewName("encryptionrotation"),
+		config.RotationInterval,
+		func(ctx context.Context) error {
+			return store.RunInTxCtx(ctx, nil, func(ctx context.Context) error {
+				_, err := encryptor.RotateColumns(ctx, store.BunDBCtx(ctx))
+				return err
+			})
+		},
+		factory.WithSingleton(),
+	)
+}
+
+// RotateColumns rotates the values of all the encrypted columns, see RotateColumn. It returns the number of rows
+// updated.
+func (encryptor *Encryptor) RotateColumns(ctx context.Context, db bun.IDB) (int, error) {
+	total := 0
+	for _, column := range EncryptedColumns {
+		n, err := encryptor.RotateColumn(ctx, db, column)
+		if err != nil {
+			return total, err
+		}
+
+		total += n
+	}
+
+	return total, nil
+}
+
+// seal encrypts the data key with the current key of the organization and formats the encrypted value.
+func (encryptor *Encryptor) seal(ctx context.Context, orgID string, dataKey []byte, sealed []byte) (string, error) {
+	key, err := encryptor.key(ctx, orgID, encryptor.config.KeyID)
+	if err != nil {
+		return "", errors.Newf(errors.TypeInternal, ErrCodeEncryptionFailed, "failed to resolve the key %q of organization %s: %v", encryptor.config.KeyID, orgID, err)
+	}
+
+	encoding := base64.RawStdEncoding
+	return encryptedPrefix + encryptor.config.KeyID + ":" + encoding.EncodeToString(seal(key, dataKey, []byte(orgID))) + ":" + encoding.EncodeToString(sealed), nil
+}
+
+// open parses the encrypted value and decrypts its data key with the key of the organization which encrypted it.
+func (encryptor *Encryptor) open(ctx context.Context, orgID string, value string) (string, []byte, []byte, error) {
+	if !IsEncrypted(value) {
+		return "", nil, nil, errors.New(errors.TypeInternal, ErrCodeDecryptionFailed, "the value is not encrypted")
+	}
+
+	parts := strings.Split(strings.TrimPrefix(value, encryptedPrefix), ":")
+	if len(parts) != 3 {
+		return "", nil, nil, errors.New(errors.TypeInternal, ErrCodeDecryptionFailed, "the encrypted value is malformed")
+	}
+
+	keyID := parts[0]
+	encoding := base64.RawStdEncoding
+	sealedDataKey, err := encoding.DecodeString(parts[1])
+	if err != nil {
+		return "", nil, nil, errors.Newf(errors.TypeInternal, ErrCodeDecryptionFailed, "the data key of the value encrypted with key %q is malformed: %v", keyID, err)
+	}
+
+	sealed, err := encoding.DecodeString(parts[2])
+	if err != nil {
+		return "", nil, nil, errors.Newf(errors.TypeInternal, ErrCodeDecryptionFailed, "the value encrypted with key %q is malformed: %v", keyID, err)
+	}
+
+	key, err := encryptor.key(ctx, orgID, keyID)
+	if err != nil {
+		return "", nil, nil, errors.Newf(errors.TypeInternal, ErrCodeDecryptionFailed, "failed to resolve the key %q of organization %s: %v", keyID, orgID, err)
+	}
+
+	dataKey, err := unseal(key, sealedDataKey, []byte(orgID))
+	if err != nil {
+		return "", nil, nil, errors.Newf(errors.TypeInternal, ErrCodeDecryptionFailed, "failed to decrypt the data key with key %q of organization %s: %v", keyID, orgID, err)
+	}
+
+	return keyID, dataKey, sealed, nil
+}
+
+// key returns the cipher of the key of the organization, resolved from the secret store at <path>/<org id>. The
+// keys are cached as a key id always refers to the same key.
+func (encryptor *Encryptor) key(ctx context.Context, orgID string, keyID string) (cipher.AEAD, error) {
+	if encryptor.store == nil {
+		return nil, errors.New(errors.TypeInvalidInput, secretstore.ErrCodeSecretNotFound, "cannot resolve the keys without a secret store")
+	}
+
+	cacheKey := orgID + "/" + keyID
+
+	encryptor.mtx.RLock()
+	key, ok := encryptor.keys[cacheKey]
+	encryptor.mtx.RUnlock()
+	if ok {
+		return key, nil
+	}
+
+	encoded, err := encryptor.store.Get(ctx, encryptor.config.Path+"/"+orgID, keyID)
+	if err != nil {
+		return nil, err
+	}
+
+	decoded, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+	if err != nil {
+		return nil, errors.Wrapf(err, errors.TypeInvalidInput, errors.CodeInvalidInput, "the key must be base64 encoded")
+	}
+
+	key, err = newAEAD(decoded)
+	if err != nil {
+		return nil, err
+	}
+
+	encryptor.mtx.Lock()
+	encryptor.keys[cacheKey] = key
+	encryptor.mtx.Unlock()
+
+	return key, nil
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	if len(key) != keySize {
+		return nil, errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "the key must be %d bytes long, got %d", keySize, len(key))
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
+
+// seal encrypts the plaintext with a random nonce prepended to the ciphertext.
+func seal(aead cipher.AEAD, plaintext []byte, additionalData []byte) []byte {
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(plaintext)+aead.Overhead())
+	// The reader of crypto/rand never fails.
+	_, _ = rand.Read(nonce)
+
+	return aead.Seal(nonce, nonce, plaintext, additionalData)
+}
+
+func unseal(aead cipher.AEAD, sealed []byte, additionalData []byte) ([]byte, error) {
+	if len(sealed) < aead.NonceSize() {
+		return nil, errors.New(errors.TypeInvalidInput, errors.CodeInvalidInput, "the ciphertext is too short")
+	}
+
+	return aead.Open(nil, sealed[:aead.NonceSize()], sealed[aead.NonceSize():], additionalData)
+}
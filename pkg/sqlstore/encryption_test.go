@@ -0,0 +1,213 @@
+package sqlstore_test
+
+import (
+	"context"
+	"encoding/base64"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/instrumentation/instrumentationtest"
+	"github.com/SigNoz/signoz/pkg/secretstore"
+	"github.com/SigNoz/signoz/pkg/sqlstore"
+	"github.com/SigNoz/signoz/pkg/sqlstore/sqlitesqlstore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mapSecretStore map[string]string
+
+func (store mapSecretStore) Get(ctx context.Context, path string, key string) (string, error) {
+	value, ok := store[path+"/"+key]
+	if !ok {
+		return "", errors.Newf(errors.TypeNotFound, secretstore.ErrCodeSecretNotFound, "secret %s/%s not found", path, key)
+	}
+
+	return value, nil
+}
+
+func newKey(b byte) string {
+	return base64.StdEncoding.EncodeToString([]byte(strings.Repeat(string(b), 32)))
+}
+
+func TestEncryptorEncryptDecrypt(t *testing.T) {
+	ctx := context.Background()
+	store := mapSecretStore{"sqlstore/org1/v1": newKey('a'), "sqlstore/org2/v1": newKey('b'), "sqlstore/org3/v1": "short"}
+	encryptor := sqlstore.NewEncryptor(sqlstore.EncryptionConfig{Enabled: true, Path: "sqlstore", KeyID: "v1"}, store)
+
+	encrypted, err := encryptor.Encrypt(ctx, "org1", "token")
+	require.NoError(t, err)
+	assert.True(t, sqlstore.IsEncrypted(encrypted))
+	assert.NotContains(t, encrypted, "token")
+
+	decrypted, err := encryptor.Decrypt(ctx, "org1", encrypted)
+	require.NoError(t, err)
+	assert.Equal(t, "token", decrypted)
+
+	// The value of an organization cannot be decrypted as the value of another organization.
+	_, err = encryptor.Decrypt(ctx, "org2", encrypted)
+	assert.True(t, errors.Asc(err, sqlstore.ErrCodeDecryptionFailed))
+
+	// Tampered, malformed and plaintext values fail rather than decrypting to garbage.
+	tampered := encrypted[:len(encrypted)-2] + "AA"
+	if tampered == encrypted {
+		tampered = encrypted[:len(encrypted)-2] + "BB"
+	}
+	for _, value := range []string{tampered, "enc:v1:v1:garbage", "token"} {
+		_, err = encryptor.Decrypt(ctx, "org1", value)
+		assert.True(t, errors.Asc(err, sqlstore.ErrCodeDecryptionFailed), value)
+	}
+
+	// The keys which are missing or invalid fail the encryption.
+	_, err = encryptor.Encrypt(ctx, "org3", "token")
+	assert.True(t, errors.Asc(err, sqlstore.ErrCodeEncryptionFailed))
+	_, err = encryptor.Encrypt(ctx, "org4", "token")
+	assert.True(t, errors.Asc(err, sqlstore.ErrCodeEncryptionFailed))
+
+	_, err = sqlstore.NewEncryptor(sqlstore.EncryptionConfig{Path: "sqlstore", KeyID: "v1"}, store).Encrypt(ctx, "org1", "token")
+	assert.True(t, errors.Ast(err, errors.TypeUnsupported))
+}
+
+func TestEncryptorRotateColumn(t *testing.T) {
+	ctx := context.Background()
+	store, err := sqlitesqlstore.New(ctx, instrumentationtest.New().ToProviderSettings(), sqlstore.Config{
+		Provider: "sqlite",
+		Sqlite:   sqlstore.SqliteConfig{Path: filepath.Join(t.TempDir(), "signoz.db")},
+	})
+	require.NoError(t, err)
+
+	_, err = store.BunDB().ExecContext(ctx, "CREATE TABLE integration (id TEXT PRIMARY KEY, org_id TEXT NOT NULL, token TEXT)")
+	require.NoError(t, err)
+
+	secrets := mapSecretStore{"sqlstore/org1/v1": newKey('a'), "sqlstore/org1/v2": newKey('c'), "sqlstore/org2/v2": newKey('d')}
+	previous := sqlstore.NewEncryptor(sqlstore.EncryptionConfig{Enabled: true, Path: "sqlstore", KeyID: "v1"}, secrets)
+	encrypted, err := previous.Encrypt(ctx, "org1", "first")
+	require.NoError(t, err)
+
+	_, err = store.BunDB().ExecContext(ctx, "INSERT INTO integration (id, org_id, token) VALUES ('1', 'org1', ?), ('2', 'org2', 'second'), ('3', 'org2', NULL), ('4', 'org2', '')", encrypted)
+	require.NoError(t, err)
+
+	column := sqlstore.EncryptedColumn{Table: "integration", Column: "token", IDColumn: "id", OrgIDColumn: "org_id"}
+	current := sqlstore.NewEncryptor(sqlstore.EncryptionConfig{Enabled: true, Path: "sqlstore", KeyID: "v2"}, secrets)
+	count, err := current.RotateColumn(ctx, store.BunDB(), column)
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+
+	// The rows are all encrypted with the current key once rotated, and rotating them again is a no-op.
+	tokens := make(map[string]string)
+	for id, orgID := range map[string]string{"1": "org1", "2": "org2"} {
+		var token string
+		require.NoError(t, store.BunDB().QueryRowContext(ctx, "SELECT token FROM integration WHERE id = ?", id).Scan(&token))
+		assert.True(t, strings.HasPrefix(token, "enc:v1:v2:"))
+
+		tokens[id], err = current.Decrypt(ctx, orgID, token)
+		require.NoError(t, err)
+	}
+	assert.Equal(t, map[string]string{"1": "first", "2": "second"}, tokens)
+
+	count, err = current.RotateColumn(ctx, store.BunDB(), column)
+	require.NoError(t, err)
+	assert.Equal(t, 0, count)
+
+	// A row which cannot be decrypted fails the rotation.
+	_, err = store.BunDB().ExecContext(ctx, "UPDATE integration SET token = 'enc:v1:v2:garbage' WHERE id = '1'")
+	require.NoError(t, err)
+	_, err = current.RotateColumn(ctx, store.BunDB(), column)
+	assert.ErrorContains(t, err, "row 1")
+}
+
+func TestEncryptorValue(t *testing.T) {
+	ctx := context.Background()
+	secrets := mapSecretStore{"sqlstore/org1/v1": newKey('a')}
+	encryptor := sqlstore.NewEncryptor(sqlstore.EncryptionConfig{Enabled: true, Path: "sqlstore", KeyID: "v1"}, secrets)
+
+	encrypted, err := encryptor.EncryptValue(ctx, "org1", "secret")
+	require.NoError(t, err)
+	assert.True(t, sqlstore.IsEncrypted(encrypted))
+
+	// The values which are empty or already encrypted are not encrypted again.
+	for _, value := range []string{"", encrypted} {
+		kept, err := encryptor.EncryptValue(ctx, "org1", value)
+		require.NoError(t, err)
+		assert.Equal(t, value, kept)
+	}
+
+	decrypted, err := encryptor.DecryptValue(ctx, "org1", encrypted)
+	require.NoError(t, err)
+	assert.Equal(t, "secret", decrypted)
+
+	// The values written before the encryption was enabled are returned as is.
+	decrypted, err = encryptor.DecryptValue(ctx, "org1", "secret")
+	require.NoError(t, err)
+	assert.Equal(t, "secret", decrypted)
+
+	// The values are written in plaintext when the encryption is disabled, and still decrypted.
+	disabled := sqlstore.NewEncryptor(sqlstore.EncryptionConfig{Path: "sqlstore", KeyID: "v1"}, secrets)
+	plaintext, err := disabled.EncryptValue(ctx, "org1", "secret")
+	require.NoError(t, err)
+	assert.Equal(t, "secret", plaintext)
+
+	decrypted, err = disabled.DecryptValue(ctx, "org1", encrypted)
+	require.NoError(t, err)
+	assert.Equal(t, "secret", decrypted)
+
+	var missing *sqlstore.Encryptor
+	_, err = missing.DecryptValue(ctx, "org1", encrypted)
+	assert.True(t, errors.Asc(err, sqlstore.ErrCodeDecryptionFailed))
+}
+
+func TestEncryptionRotationJob(t *testing.T) {
+	ctx := context.Background()
+	store, err := sqlitesqlstore.New(ctx, instrumentationtest.New().ToProviderSettings(), sqlstore.Config{
+		Provider: "sqlite",
+		Sqlite:   sqlstore.SqliteConfig{Path: filepath.Join(t.TempDir(), "signoz.db")},
+	})
+	require.NoError(t, err)
+
+	_, err = store.BunDB().ExecContext(ctx, "CREATE TABLE notification_channel (id TEXT PRIMARY KEY, org_id TEXT NOT NULL, signing_secret TEXT)")
+	require.NoError(t, err)
+	_, err = store.BunDB().ExecContext(ctx, "INSERT INTO notification_channel (id, org_id, signing_secret) VALUES ('1', 'org1', 'secret'), ('2', 'org1', NULL)")
+	require.NoError(t, err)
+
+	config := sqlstore.EncryptionConfig{Enabled: true, Path: "sqlstore", KeyID: "v1", RotationInterval: time.Hour}
+	encryptor := sqlstore.NewEncryptor(config, mapSecretStore{"sqlstore/org1/v1": newKey('a')})
+
+	// The job encrypts the secrets written before the encryption was enabled.
+	require.NoError(t, sqlstore.NewEncryptionRotationJob(store, encryptor, config).Run(ctx))
+
+	var secret string
+	require.NoError(t, store.BunDB().QueryRowContext(ctx, "SELECT signing_secret FROM notification_channel WHERE id = '1'").Scan(&secret))
+	assert.True(t, strings.HasPrefix(secret, "enc:v1:v1:"))
+
+	decrypted, err := encryptor.DecryptValue(ctx, "org1", secret)
+	require.NoError(t, err)
+	assert.Equal(t, "secret", decrypted)
+}
+
+func TestValidateEncryption(t *testing.T) {
+	testCases := []struct {
+		name   string
+		config sqlstore.EncryptionConfig
+		pass   bool
+	}{
+		{name: "Disabled", config: sqlstore.EncryptionConfig{}, pass: true},
+		{name: "Enabled", config: sqlstore.EncryptionConfig{Enabled: true, Path: "sqlstore", KeyID: "v1", RotationInterval: time.Hour}, pass: true},
+		{name: "NoRotationInterval", config: sqlstore.EncryptionConfig{Enabled: true, Path: "sqlstore", KeyID: "v1"}, pass: false},
+		{name: "NoPath", config: sqlstore.EncryptionConfig{Enabled: true, KeyID: "v1"}, pass: false},
+		{name: "NoKeyID", config: sqlstore.EncryptionConfig{Enabled: true, Path: "sqlstore"}, pass: false},
+		{name: "ColonInKeyID", config: sqlstore.EncryptionConfig{Enabled: true, Path: "sqlstore", KeyID: "v:1"}, pass: false},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := sqlstore.Config{Encryption: testCase.config}.Validate()
+			if testCase.pass {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
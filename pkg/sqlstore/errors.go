@@ -0,0 +1,74 @@
+package sqlstore
+
+import (
+	"github.com/SigNoz/signoz/pkg/errors"
+)
+
+var (
+	ErrCodeConstraintUnique     = errors.MustNewCode("sqlstore_constraint_unique")
+	ErrCodeConstraintForeignKey = errors.MustNewCode("sqlstore_constraint_foreign_key")
+	ErrCodeNotFound             = errors.MustNewCode("sqlstore_not_found")
+)
+
+// NewConstraintUniqueErr wraps a driver specific unique constraint violation into an already exists error.
+func NewConstraintUniqueErr(cause error) error {
+	return errors.Wrapf(cause, errors.TypeAlreadyExists, ErrCodeConstraintUnique, "unique constraint violated")
+}
+
+// NewConstraintForeignKeyErr wraps a driver specific foreign key constraint violation into an invalid input error.
+func NewConstraintForeignKeyErr(cause error) error {
+	return errors.Wrapf(cause, errors.TypeInvalidInput, ErrCodeConstraintForeignKey, "foreign key constraint violated")
+}
+
+// NewNotFoundErr wraps a driver specific no rows error into a not found error.
+func NewNotFoundErr(cause error) error {
+	return errors.Wrapf(cause, errors.TypeNotFound, ErrCodeNotFound, "no rows found")
+}
+
+// IsConstraintError reports whether err is a unique or foreign key constraint violation normalized by a sqlstore.
+func IsConstraintError(err error) bool {
+	return IsConstraintUniqueError(err) || IsConstraintForeignKeyError(err)
+}
+
+// IsConstraintUniqueError reports whether err is a unique constraint violation normalized by a sqlstore.
+func IsConstraintUniqueError(err error) bool {
+	return hasCode(err, ErrCodeConstraintUnique)
+}
+
+// IsConstraintForeignKeyError reports whether err is a foreign key constraint violation normalized by a sqlstore.
+func IsConstraintForeignKeyError(err error) bool {
+	return hasCode(err, ErrCodeConstraintForeignKey)
+}
+
+// IsNotFoundError reports whether err is a no rows error normalized by a sqlstore.
+func IsNotFoundError(err error) bool {
+	return hasCode(err, ErrCodeNotFound)
+}
+
+// WrapErrf wraps an error normalized by a sqlstore with the given code and message. The type of the returned error is
+// derived from the normalized error. Errors which are not normalized are returned as is.
+func WrapErrf(err error, code errors.Code, format string, args ...any) error {
+	switch {
+	case IsConstraintUniqueError(err):
+		return errors.Wrapf(err, errors.TypeAlreadyExists, code, format, args...)
+	case IsConstraintForeignKeyError(err):
+		return errors.Wrapf(err, errors.TypeInvalidInput, code, format, args...)
+	case IsNotFoundError(err):
+		return errors.Wrapf(err, errors.TypeNotFound, code, format, args...)
+	}
+
+	return err
+}
+
+// hasCode walks the chain of err and reports whether any of the errors in it has the given code.
+func hasCode(err error, code errors.Code) bool {
+	for err != nil {
+		if errors.Asc(err, code) {
+			return true
+		}
+
+		err = errors.Unwrap(err)
+	}
+
+	return false
+}
@@ -0,0 +1,385 @@
+package sqlstore
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/uptrace/bun"
+)
+
+const (
+	// MaxListLimit is the maximum limit of a page of a list query.
+	MaxListLimit = 1000
+)
+
+var (
+	ErrCodeListQueryInvalid = errors.MustNewCode("list_query_invalid")
+)
+
+type Operator string
+
+const (
+	OperatorEqual              Operator = "eq"
+	OperatorNotEqual           Operator = "ne"
+	OperatorLessThan           Operator = "lt"
+	OperatorLessThanOrEqual    Operator = "lte"
+	OperatorGreaterThan        Operator = "gt"
+	OperatorGreaterThanOrEqual Operator = "gte"
+	// OperatorIn matches one of the values separated by commas.
+	OperatorIn Operator = "in"
+	// OperatorContains matches the strings containing the value, ignoring the case.
+	OperatorContains Operator = "contains"
+)
+
+var comparisons = map[Operator]string{
+	OperatorEqual:              "=",
+	OperatorNotEqual:           "<>",
+	OperatorLessThan:           "<",
+	OperatorLessThanOrEqual:    "<=",
+	OperatorGreaterThan:        ">",
+	OperatorGreaterThanOrEqual: ">=",
+}
+
+type FieldType string
+
+const (
+	FieldTypeString FieldType = "string"
+	FieldTypeInt64  FieldType = "int64"
+	// FieldTypeTime are the timestamps, in RFC 3339 in the filters.
+	FieldTypeTime FieldType = "time"
+	FieldTypeBool FieldType = "bool"
+)
+
+// Field is a field of a list which can be filtered and sorted on.
+type Field struct {
+	// Column is the column of the field in the table of the model of the query.
+	Column string
+	// Type is the type of the values of the field, the values of the filters and cursors are parsed as such.
+	Type FieldType
+}
+
+// ListFields are the fields of a list keyed by their name. They are the allowlist of the fields of the list queries,
+// the queries on any other field are rejected so that the input of the clients never reaches the SQL but as values.
+type ListFields map[string]Field
+
+// Filter selects the items whose field compares to the value with the operator.
+type Filter struct {
+	Field    string
+	Operator Operator
+	Value    string
+}
+
+// Sort orders the items by a field.
+type Sort struct {
+	Field      string
+	Descending bool
+}
+
+// ListQuery is the filtering, sorting and pagination of a list as requested by a client. It is validated against the
+// fields of the list by the ListQueryBuilder applying it.
+type ListQuery struct {
+	Filters []Filter
+	Sort    []Sort
+	// Limit is the number of items of a page. The whole list is selected when it is 0.
+	Limit int
+	// Cursor is the position of the last item of the previous page, as returned by Paginate.
+	Cursor string
+}
+
+// NewListQueryFromRequest returns the list query of the query parameters of the request:
+//
+//   - filter is a filter formatted as field:operator:value, it can be repeated
+//   - sort is the fields separated by commas, a field prefixed by - is sorted in descending order
+//   - limit is the number of items of a page
+//   - cursor is the cursor of the page
+func NewListQueryFromRequest(req *http.Request) (*ListQuery, error) {
+	query := req.URL.Query()
+	list := &ListQuery{Cursor: query.Get("cursor")}
+
+	for _, filter := range query["filter"] {
+		parts := strings.SplitN(filter, ":", 3)
+		if len(parts) != 3 {
+			return nil, errors.Newf(errors.TypeInvalidInput, ErrCodeListQueryInvalid, "filter %q must be formatted as field:operator:value", filter)
+		}
+
+		list.Filters = append(list.Filters, Filter{Field: parts[0], Operator: Operator(parts[1]), Value: parts[2]})
+	}
+
+	if query.Get("sort") != "" {
+		for _, field := range strings.Split(query.Get("sort"), ",") {
+			name, descending := strings.CutPrefix(field, "-")
+			list.Sort = append(list.Sort, Sort{Field: name, Descending: descending})
+		}
+	}
+
+	if query.Has("limit") {
+		limit, err := strconv.Atoi(query.Get("limit"))
+		if err != nil || limit <= 0 || limit > MaxListLimit {
+			return nil, errors.Newf(errors.TypeInvalidInput, ErrCodeListQueryInvalid, "limit must be an integer between 1 and %d", MaxListLimit)
+		}
+
+		list.Limit = limit
+	}
+
+	return list, nil
+}
+
+// ListQueryBuilder applies the list queries of the clients to the select queries of a list. The filters are composed
+// with AND, the items are sorted by the requested fields then by the key and paginated by keyset on the values of the
+// sort, which is portable across sqlite and postgres.
+type ListQueryBuilder struct {
+	fields ListFields
+	key    string
+}
+
+// MustNewListQueryBuilder returns a builder for the fields. The key is the name of the field whose values are unique,
+// it orders the items of equal sort values. It panics if the key is not one of the fields.
+func MustNewListQueryBuilder(fields ListFields, key string) *ListQueryBuilder {
+	if _, ok := fields[key]; !ok {
+		panic(errors.Newf(errors.TypeInternal, errors.CodeInternal, "key %q is not one of the fields", key))
+	}
+
+	return &ListQueryBuilder{fields: fields, key: key}
+}
+
+// Apply filters, sorts and paginates the query, whose model must be the one of the columns of the fields. It selects
+// one more item than the limit to find out whether there is a next page, see Paginate.
+func (builder *ListQueryBuilder) Apply(query *bun.SelectQuery, list *ListQuery) (*bun.SelectQuery, error) {
+	for _, filter := range list.Filters {
+		field, err := builder.field(filter.Field)
+		if err != nil {
+			return nil, err
+		}
+
+		query, err = builder.applyFilter(query, field, filter)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	sorts, err := builder.sorts(list)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, sort := range sorts {
+		query = query.OrderExpr("?TableAlias.? "+direction(sort), bun.Ident(builder.fields[sort.Field].Column))
+	}
+
+	if list.Cursor != "" {
+		values, err := builder.decodeCursor(list.Cursor, sorts)
+		if err != nil {
+			return nil, err
+		}
+
+		condition, args := keysetCondition(builder.fields, sorts, values)
+		query = query.Where(condition, args...)
+	}
+
+	if list.Limit < 0 || list.Limit > MaxListLimit {
+		return nil, errors.Newf(errors.TypeInvalidInput, ErrCodeListQueryInvalid, "limit must be an integer between 1 and %d", MaxListLimit)
+	}
+
+	if list.Limit > 0 {
+		query = query.Limit(list.Limit + 1)
+	}
+
+	return query, nil
+}
+
+// Paginate trims the items selected by a query to which the list query was applied and returns the cursor of the next
+// page, which is empty on the last page. value returns the value of the field of the given name of an item.
+func Paginate[T any](builder *ListQueryBuilder, list *ListQuery, items []T, value func(item T, field string) any) ([]T, string, error) {
+	if list.Limit == 0 || len(items) <= list.Limit {
+		return items, "", nil
+	}
+
+	sorts, err := builder.sorts(list)
+	if err != nil {
+		return nil, "", err
+	}
+
+	items = items[:list.Limit]
+	values := make([]any, len(sorts))
+	for i, sort := range sorts {
+		values[i] = value(items[len(items)-1], sort.Field)
+	}
+
+	data, err := json.Marshal(values)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return items, base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+func (builder *ListQueryBuilder) field(name string) (Field, error) {
+	field, ok := builder.fields[name]
+	if !ok {
+		names := make([]string, 0, len(builder.fields))
+		for name := range builder.fields {
+			names = append(names, name)
+		}
+		slices.Sort(names)
+
+		return Field{}, errors.Newf(errors.TypeInvalidInput, ErrCodeListQueryInvalid, "unknown field %q, must be one of %s", name, strings.Join(names, ", "))
+	}
+
+	return field, nil
+}
+
+func (builder *ListQueryBuilder) applyFilter(query *bun.SelectQuery, field Field, filter Filter) (*bun.SelectQuery, error) {
+	switch filter.Operator {
+	case OperatorIn:
+		var values []any
+		for _, input := range strings.Split(filter.Value, ",") {
+			value, err := parseValue(field.Type, input)
+			if err != nil {
+				return nil, errors.Wrapf(err, errors.TypeInvalidInput, ErrCodeListQueryInvalid, "invalid value of the filter on %q", filter.Field)
+			}
+			values = append(values, value)
+		}
+
+		return query.Where("?TableAlias.? IN (?)", bun.Ident(field.Column), bun.In(values)), nil
+
+	case OperatorContains:
+		if field.Type != FieldTypeString {
+			return nil, errors.Newf(errors.TypeInvalidInput, ErrCodeListQueryInvalid, "operator %q is not supported by the field %q of type %s", filter.Operator, filter.Field, field.Type)
+		}
+
+		// The wildcards of the value are escaped so that it is matched literally.
+		pattern := "%" + strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(strings.ToLower(filter.Value)) + "%"
+		return query.Where(`LOWER(?TableAlias.?) LIKE ? ESCAPE '\'`, bun.Ident(field.Column), pattern), nil
+	}
+
+	comparison, ok := comparisons[filter.Operator]
+	if !ok {
+		return nil, errors.Newf(errors.TypeInvalidInput, ErrCodeListQueryInvalid, "unknown operator %q of the filter on %q", filter.Operator, filter.Field)
+	}
+
+	if field.Type == FieldTypeBool && filter.Operator != OperatorEqual && filter.Operator != OperatorNotEqual {
+		return nil, errors.Newf(errors.TypeInvalidInput, ErrCodeListQueryInvalid, "operator %q is not supported by the field %q of type %s", filter.Operator, filter.Field, field.Type)
+	}
+
+	value, err := parseValue(field.Type, filter.Value)
+	if err != nil {
+		return nil, errors.Wrapf(err, errors.TypeInvalidInput, ErrCodeListQueryInvalid, "invalid value of the filter on %q", filter.Field)
+	}
+
+	return query.Where("?TableAlias.? "+comparison+" ?", bun.Ident(field.Column), value), nil
+}
+
+// sorts returns the sort of the list followed by the key, unless the list is already sorted by it.
+func (builder *ListQueryBuilder) sorts(list *ListQuery) ([]Sort, error) {
+	sorts := make([]Sort, 0, len(list.Sort)+1)
+	for _, sort := range list.Sort {
+		if _, err := builder.field(sort.Field); err != nil {
+			return nil, err
+		}
+
+		sorts = append(sorts, sort)
+		if sort.Field == builder.key {
+			return sorts, nil
+		}
+	}
+
+	return append(sorts, Sort{Field: builder.key}), nil
+}
+
+func (builder *ListQueryBuilder) decodeCursor(cursor string, sorts []Sort) ([]any, error) {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, errors.New(errors.TypeInvalidInput, ErrCodeListQueryInvalid, "cursor is invalid")
+	}
+
+	var raws []json.RawMessage
+	if err := json.Unmarshal(data, &raws); err != nil || len(raws) != len(sorts) {
+		return nil, errors.New(errors.TypeInvalidInput, ErrCodeListQueryInvalid, "cursor is invalid or does not match the sort")
+	}
+
+	values := make([]any, len(sorts))
+	for i, sort := range sorts {
+		value, err := decodeValue(builder.fields[sort.Field].Type, raws[i])
+		if err != nil {
+			return nil, errors.New(errors.TypeInvalidInput, ErrCodeListQueryInvalid, "cursor is invalid or does not match the sort")
+		}
+
+		values[i] = value
+	}
+
+	return values, nil
+}
+
+// keysetCondition returns the condition selecting the items after the values of the sort. The condition is expanded
+// as (a > ?) OR (a = ? AND b > ?) rather than compared as a row since the fields can be sorted in different directions.
+func keysetCondition(fields ListFields, sorts []Sort, values []any) (string, []any) {
+	var disjuncts []string
+	var args []any
+	for i, sort := range sorts {
+		conjuncts := make([]string, 0, i+1)
+		for j := 0; j < i; j++ {
+			conjuncts = append(conjuncts, "?TableAlias.? = ?")
+			args = append(args, bun.Ident(fields[sorts[j].Field].Column), values[j])
+		}
+
+		comparison := ">"
+		if sort.Descending {
+			comparison = "<"
+		}
+
+		conjuncts = append(conjuncts, "?TableAlias.? "+comparison+" ?")
+		args = append(args, bun.Ident(fields[sort.Field].Column), values[i])
+		disjuncts = append(disjuncts, "("+strings.Join(conjuncts, " AND ")+")")
+	}
+
+	return "(" + strings.Join(disjuncts, " OR ") + ")", args
+}
+
+func direction(sort Sort) string {
+	if sort.Descending {
+		return "DESC"
+	}
+
+	return "ASC"
+}
+
+func parseValue(typ FieldType, input string) (any, error) {
+	switch typ {
+	case FieldTypeInt64:
+		return strconv.ParseInt(input, 10, 64)
+	case FieldTypeTime:
+		return time.Parse(time.RFC3339Nano, input)
+	case FieldTypeBool:
+		return strconv.ParseBool(input)
+	default:
+		return input, nil
+	}
+}
+
+func decodeValue(typ FieldType, raw json.RawMessage) (any, error) {
+	switch typ {
+	case FieldTypeInt64:
+		return decode[int64](raw)
+	case FieldTypeTime:
+		return decode[time.Time](raw)
+	case FieldTypeBool:
+		return decode[bool](raw)
+	default:
+		return decode[string](raw)
+	}
+}
+
+func decode[T any](raw json.RawMessage) (any, error) {
+	var value T
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return nil, err
+	}
+
+	return value, nil
+}
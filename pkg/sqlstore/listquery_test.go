@@ -0,0 +1,181 @@
+package sqlstore_test
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/sqlstore"
+	"github.com/SigNoz/signoz/pkg/sqlstore/sqlstoretest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/uptrace/bun"
+)
+
+type listItem struct {
+	bun.BaseModel `bun:"table:list_items"`
+
+	ID        string    `bun:"id,pk"`
+	Name      string    `bun:"name"`
+	Priority  int64     `bun:"priority"`
+	Enabled   bool      `bun:"enabled"`
+	CreatedAt time.Time `bun:"created_at"`
+}
+
+var listQueryBuilder = sqlstore.MustNewListQueryBuilder(sqlstore.ListFields{
+	"id":        {Column: "id", Type: sqlstore.FieldTypeString},
+	"name":      {Column: "name", Type: sqlstore.FieldTypeString},
+	"priority":  {Column: "priority", Type: sqlstore.FieldTypeInt64},
+	"enabled":   {Column: "enabled", Type: sqlstore.FieldTypeBool},
+	"createdAt": {Column: "created_at", Type: sqlstore.FieldTypeTime},
+}, "id")
+
+func listItemValue(item listItem, field string) any {
+	switch field {
+	case "name":
+		return item.Name
+	case "priority":
+		return item.Priority
+	case "enabled":
+		return item.Enabled
+	case "createdAt":
+		return item.CreatedAt
+	default:
+		return item.ID
+	}
+}
+
+func newTestListStore(t *testing.T) sqlstore.SQLStore {
+	store := sqlstoretest.NewSQLite(t)
+
+	createdAt := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	items := []listItem{
+		{ID: "a", Name: "Checkout latency", Priority: 1, Enabled: true, CreatedAt: createdAt},
+		{ID: "b", Name: "checkout errors", Priority: 2, Enabled: false, CreatedAt: createdAt.Add(time.Hour)},
+		{ID: "c", Name: "Payments 100%", Priority: 2, Enabled: true, CreatedAt: createdAt.Add(2 * time.Hour)},
+		{ID: "d", Name: "Payments errors", Priority: 2, Enabled: true, CreatedAt: createdAt.Add(3 * time.Hour)},
+		{ID: "e", Name: "Login errors", Priority: 3, Enabled: false, CreatedAt: createdAt.Add(4 * time.Hour)},
+	}
+	require.NoError(t, sqlstoretest.Seed(context.Background(), store, &items))
+
+	return store
+}
+
+func listIDs(t *testing.T, store sqlstore.SQLStore, list *sqlstore.ListQuery) ([]string, string) {
+	var items []listItem
+	query, err := listQueryBuilder.Apply(store.BunDB().NewSelect().Model(&items), list)
+	require.NoError(t, err)
+	require.NoError(t, query.Scan(context.Background()))
+
+	items, nextCursor, err := sqlstore.Paginate(listQueryBuilder, list, items, listItemValue)
+	require.NoError(t, err)
+
+	ids := make([]string, len(items))
+	for i, item := range items {
+		ids[i] = item.ID
+	}
+
+	return ids, nextCursor
+}
+
+func TestListQueryBuilderFilter(t *testing.T) {
+	store := newTestListStore(t)
+
+	testCases := []struct {
+		name     string
+		filters  []sqlstore.Filter
+		expected []string
+	}{
+		{name: "Equal", filters: []sqlstore.Filter{{Field: "priority", Operator: sqlstore.OperatorEqual, Value: "2"}}, expected: []string{"b", "c", "d"}},
+		{name: "NotEqual", filters: []sqlstore.Filter{{Field: "enabled", Operator: sqlstore.OperatorNotEqual, Value: "true"}}, expected: []string{"b", "e"}},
+		{name: "Time", filters: []sqlstore.Filter{{Field: "createdAt", Operator: sqlstore.OperatorGreaterThanOrEqual, Value: "2025-01-01T03:00:00Z"}}, expected: []string{"d", "e"}},
+		{name: "In", filters: []sqlstore.Filter{{Field: "id", Operator: sqlstore.OperatorIn, Value: "a,e"}}, expected: []string{"a", "e"}},
+		{name: "ContainsIgnoresCase", filters: []sqlstore.Filter{{Field: "name", Operator: sqlstore.OperatorContains, Value: "CHECKOUT"}}, expected: []string{"a", "b"}},
+		{name: "ContainsLiteral", filters: []sqlstore.Filter{{Field: "name", Operator: sqlstore.OperatorContains, Value: "0%"}}, expected: []string{"c"}},
+		{name: "Composed", filters: []sqlstore.Filter{
+			{Field: "name", Operator: sqlstore.OperatorContains, Value: "errors"},
+			{Field: "priority", Operator: sqlstore.OperatorLessThan, Value: "3"},
+		}, expected: []string{"b", "d"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ids, nextCursor := listIDs(t, store, &sqlstore.ListQuery{Filters: tc.filters})
+			assert.Equal(t, tc.expected, ids)
+			assert.Empty(t, nextCursor)
+		})
+	}
+}
+
+func TestListQueryBuilderPaginate(t *testing.T) {
+	store := newTestListStore(t)
+
+	testCases := []struct {
+		name     string
+		sort     []sqlstore.Sort
+		expected [][]string
+	}{
+		{name: "Key", expected: [][]string{{"a", "b"}, {"c", "d"}, {"e"}}},
+		{name: "Time", sort: []sqlstore.Sort{{Field: "createdAt", Descending: true}}, expected: [][]string{{"e", "d"}, {"c", "b"}, {"a"}}},
+		// The items of equal priority are ordered by the key.
+		{name: "Ties", sort: []sqlstore.Sort{{Field: "priority", Descending: true}}, expected: [][]string{{"e", "b"}, {"c", "d"}, {"a"}}},
+		{name: "MixedDirections", sort: []sqlstore.Sort{{Field: "priority"}, {Field: "createdAt", Descending: true}}, expected: [][]string{{"a", "d"}, {"c", "b"}, {"e"}}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			list := &sqlstore.ListQuery{Sort: tc.sort, Limit: 2}
+			for i, expected := range tc.expected {
+				ids, nextCursor := listIDs(t, store, list)
+				assert.Equal(t, expected, ids)
+				assert.Equal(t, i == len(tc.expected)-1, nextCursor == "")
+				list.Cursor = nextCursor
+			}
+		})
+	}
+}
+
+func TestListQueryBuilderInvalid(t *testing.T) {
+	store := newTestListStore(t)
+
+	testCases := []struct {
+		name string
+		list *sqlstore.ListQuery
+	}{
+		{name: "UnknownFilterField", list: &sqlstore.ListQuery{Filters: []sqlstore.Filter{{Field: "name; DROP TABLE list_items", Operator: sqlstore.OperatorEqual, Value: "a"}}}},
+		{name: "UnknownSortField", list: &sqlstore.ListQuery{Sort: []sqlstore.Sort{{Field: "password"}}}},
+		{name: "UnknownOperator", list: &sqlstore.ListQuery{Filters: []sqlstore.Filter{{Field: "name", Operator: "regex", Value: "a"}}}},
+		{name: "UnsupportedOperator", list: &sqlstore.ListQuery{Filters: []sqlstore.Filter{{Field: "priority", Operator: sqlstore.OperatorContains, Value: "1"}}}},
+		{name: "InvalidValue", list: &sqlstore.ListQuery{Filters: []sqlstore.Filter{{Field: "priority", Operator: sqlstore.OperatorEqual, Value: "high"}}}},
+		{name: "InvalidCursor", list: &sqlstore.ListQuery{Cursor: "not-a-cursor", Limit: 2}},
+		{name: "CursorOfAnotherSort", list: &sqlstore.ListQuery{Sort: []sqlstore.Sort{{Field: "priority"}}, Cursor: "WyJhIl0", Limit: 2}},
+		{name: "InvalidLimit", list: &sqlstore.ListQuery{Limit: sqlstore.MaxListLimit + 1}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := listQueryBuilder.Apply(store.BunDB().NewSelect().Model(&[]listItem{}), tc.list)
+			require.Error(t, err)
+			assert.True(t, errors.Asc(err, sqlstore.ErrCodeListQueryInvalid))
+		})
+	}
+}
+
+func TestNewListQueryFromRequest(t *testing.T) {
+	list, err := sqlstore.NewListQueryFromRequest(httptest.NewRequest("GET", "/?filter=name:contains:a:b&filter=priority:gt:1&sort=-priority,name&limit=10&cursor=abc", nil))
+	require.NoError(t, err)
+	assert.Equal(t, &sqlstore.ListQuery{
+		Filters: []sqlstore.Filter{{Field: "name", Operator: sqlstore.OperatorContains, Value: "a:b"}, {Field: "priority", Operator: sqlstore.OperatorGreaterThan, Value: "1"}},
+		Sort:    []sqlstore.Sort{{Field: "priority", Descending: true}, {Field: "name"}},
+		Limit:   10,
+		Cursor:  "abc",
+	}, list)
+
+	_, err = sqlstore.NewListQueryFromRequest(httptest.NewRequest("GET", "/?filter=name", nil))
+	assert.True(t, errors.Asc(err, sqlstore.ErrCodeListQueryInvalid))
+
+	_, err = sqlstore.NewListQueryFromRequest(httptest.NewRequest("GET", "/?limit=0", nil))
+	assert.True(t, errors.Asc(err, sqlstore.ErrCodeListQueryInvalid))
+}
@@ -0,0 +1,69 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/factory"
+	"github.com/uptrace/bun"
+)
+
+// StorableJobRun is the last run of a singleton job of the scheduler.
+type StorableJobRun struct {
+	bun.BaseModel `bun:"table:job_run"`
+
+	Name      string    `bun:"name,pk,type:text"`
+	LastRunAt time.Time `bun:"last_run_at,notnull"`
+}
+
+type locker struct {
+	sqlstore SQLStore
+}
+
+// NewLocker returns a locker holding the advisory locks of the dialect of the store and recording the last runs of
+// the jobs in the store, which makes the singleton jobs of the scheduler run once per interval across the replicas.
+func NewLocker(sqlstore SQLStore) factory.Locker {
+	return &locker{sqlstore: sqlstore}
+}
+
+func (locker *locker) TryLock(ctx context.Context, key string) (func(context.Context) error, bool, error) {
+	return locker.sqlstore.Dialect().TryAdvisoryLock(ctx, locker.sqlstore.BunDB(), key)
+}
+
+func (locker *locker) LastRun(ctx context.Context, key string) (time.Time, error) {
+	storable := new(StorableJobRun)
+	err := locker.
+		sqlstore.
+		BunDB().
+		NewSelect().
+		Model(storable).
+		Where("name = ?", key).
+		Scan(ctx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return time.Time{}, nil
+		}
+
+		return time.Time{}, errors.Wrapf(err, errors.TypeInternal, errors.CodeInternal, "failed to read the last run of %s", key)
+	}
+
+	return storable.LastRunAt, nil
+}
+
+func (locker *locker) RecordRun(ctx context.Context, key string, at time.Time) error {
+	_, err := locker.
+		sqlstore.
+		BunDB().
+		NewInsert().
+		Model(&StorableJobRun{Name: key, LastRunAt: at}).
+		On("CONFLICT (name) DO UPDATE").
+		Set("last_run_at = EXCLUDED.last_run_at").
+		Exec(ctx)
+	if err != nil {
+		return errors.Wrapf(err, errors.TypeInternal, errors.CodeInternal, "failed to record the run of %s", key)
+	}
+
+	return nil
+}
@@ -0,0 +1,35 @@
+package sqlstore_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/sqlstore"
+	"github.com/SigNoz/signoz/pkg/sqlstore/sqlstoretest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLockerRecordsRuns(t *testing.T) {
+	ctx := context.Background()
+	store := sqlstoretest.NewSQLite(t)
+	_, err := store.BunDB().NewCreateTable().Model(new(sqlstore.StorableJobRun)).Exec(ctx)
+	require.NoError(t, err)
+
+	locker := sqlstore.NewLocker(store)
+
+	lastRun, err := locker.LastRun(ctx, "job:purge")
+	require.NoError(t, err)
+	assert.True(t, lastRun.IsZero())
+
+	first := time.Now().Add(-time.Hour).Truncate(time.Second)
+	require.NoError(t, locker.RecordRun(ctx, "job:purge", first))
+
+	second := first.Add(30 * time.Minute)
+	require.NoError(t, locker.RecordRun(ctx, "job:purge", second))
+
+	lastRun, err = locker.LastRun(ctx, "job:purge")
+	require.NoError(t, err)
+	assert.True(t, second.Equal(lastRun))
+}
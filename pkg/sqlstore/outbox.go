@@ -0,0 +1,241 @@
+package sqlstore
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/factory"
+	"github.com/SigNoz/signoz/pkg/types"
+	"github.com/SigNoz/signoz/pkg/valuer"
+	"github.com/uptrace/bun"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+const (
+	outboxPurgeInterval time.Duration = time.Hour
+)
+
+// OutboxEvent is an integration event written to the outbox in the transaction of the change it describes, so that
+// it is published if and only if the change is committed.
+type OutboxEvent struct {
+	bun.BaseModel `bun:"table:outbox_event"`
+
+	types.Identifiable
+	// OrgID is the organization of the change described by the event.
+	OrgID valuer.UUID `bun:"org_id,type:text,notnull" json:"orgId"`
+	// Topic is the kind of the event, for example rule.created.
+	Topic string `bun:"topic,type:text,notnull" json:"topic"`
+	// Payload is the json encoded payload of the event.
+	Payload   string    `bun:"payload,type:text,notnull" json:"payload"`
+	CreatedAt time.Time `bun:"created_at,notnull" json:"createdAt"`
+	// Attempts is the number of failed attempts to publish the event.
+	Attempts      int       `bun:"attempts,notnull" json:"attempts"`
+	LastError     string    `bun:"last_error,type:text,nullzero" json:"lastError"`
+	NextAttemptAt time.Time `bun:"next_attempt_at,notnull" json:"nextAttemptAt"`
+	// SentAt is the time at which the event was published.
+	SentAt *time.Time `bun:"sent_at" json:"sentAt"`
+	// FailedAt is the time at which the event was given up on after too many failed attempts. Such events are kept
+	// for the failed retention to be inspected.
+	FailedAt *time.Time `bun:"failed_at" json:"failedAt"`
+}
+
+// OutboxSink is where the relay publishes the events of the outbox. The events are published at least once, the
+// sink must hence be idempotent on the id of the events.
+type OutboxSink interface {
+	Publish(context.Context, *OutboxEvent) error
+}
+
+func NewOutboxEvent(orgID valuer.UUID, topic string, payload any) (*OutboxEvent, error) {
+	if topic == "" {
+		return nil, errors.New(errors.TypeInvalidInput, errors.CodeInvalidInput, "topic of the outbox event must not be empty")
+	}
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, errors.Wrapf(err, errors.TypeInvalidInput, errors.CodeInvalidInput, "failed to encode the payload of the outbox event")
+	}
+
+	now := time.Now()
+	return &OutboxEvent{
+		Identifiable: types.Identifiable{
+			ID: valuer.GenerateUUID(),
+		},
+		OrgID:         orgID,
+		Topic:         topic,
+		Payload:       string(data),
+		CreatedAt:     now,
+		NextAttemptAt: now,
+	}, nil
+}
+
+// PublishOutboxEvent writes the event to the outbox with the transaction of the context, if any. Called within
+// RunInTxCtx, the event is committed or rolled back along with the change it describes.
+func PublishOutboxEvent(ctx context.Context, store SQLStore, orgID valuer.UUID, topic string, payload any) error {
+	event, err := NewOutboxEvent(orgID, topic, payload)
+	if err != nil {
+		return err
+	}
+
+	_, err = store.BunDBCtx(ctx).NewInsert().Model(event).Exec(ctx)
+	if err != nil {
+		return errors.Wrapf(err, errors.TypeInternal, errors.CodeInternal, "failed to write the outbox event")
+	}
+
+	return nil
+}
+
+// OutboxRelay publishes the pending events of the outbox to the sink, oldest first. An event failing to be published
+// is retried with an exponential backoff without holding back the other events, and is given up on once it failed
+// too many times.
+type OutboxRelay struct {
+	settings factory.ScopedProviderSettings
+	store    SQLStore
+	sink     OutboxSink
+	config   OutboxConfig
+	events   metric.Int64Counter
+}
+
+func NewOutboxRelay(providerSettings factory.ProviderSettings, store SQLStore, sink OutboxSink, config OutboxConfig) (*OutboxRelay, error) {
+	settings := factory.NewScopedProviderSettings(providerSettings, "github.com/SigNoz/signoz/pkg/sqlstore")
+
+	events, err := settings.Meter().Int64Counter("signoz.sqlstore.outbox.events", metric.WithDescription("Number of attempts to publish the events of the outbox, by result."))
+	if err != nil {
+		return nil, err
+	}
+
+	return &OutboxRelay{
+		settings: settings,
+		store:    store,
+		sink:     sink,
+		config:   config,
+		events:   events,
+	}, nil
+}
+
+// NewOutboxJob returns the job relaying the events of the outbox. It runs on a single replica at a time so that the
+// events are not published concurrently by the replicas.
+func NewOutboxJob(relay *OutboxRelay, config OutboxConfig) factory.Job {
+	return factory.NewJob(
+		factory.MustNewName("outboxrelay"),
+		config.Interval,
+		relay.Relay,
+		factory.WithSingleton(),
+	)
+}
+
+// Relay publishes a batch of the pending events and deletes the events published for longer than the retention, and
+// the events given up on for longer than the failed retention.
+func (relay *OutboxRelay) Relay(ctx context.Context) error {
+	now := time.Now()
+
+	events := make([]*OutboxEvent, 0)
+	err := relay.store.
+		BunDB().
+		NewSelect().
+		Model(&events).
+		Where("sent_at IS NULL").
+		Where("failed_at IS NULL").
+		Where("next_attempt_at <= ?", now).
+		Order("created_at ASC").
+		Limit(relay.config.BatchSize).
+		Scan(ctx)
+	if err != nil {
+		return errors.Wrapf(err, errors.TypeInternal, errors.CodeInternal, "failed to list the pending outbox events")
+	}
+
+	for _, event := range events {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		relay.publish(ctx, event)
+
+		_, err := relay.store.
+			BunDB().
+			NewUpdate().
+			Model(event).
+			Column("attempts", "last_error", "next_attempt_at", "sent_at", "failed_at").
+			WherePK().
+			Exec(ctx)
+		if err != nil {
+			// The event is published again by the next run, which the sink tolerates.
+			return errors.Wrapf(err, errors.TypeInternal, errors.CodeInternal, "failed to update the outbox event %s", event.ID.StringValue())
+		}
+	}
+
+	_, err = relay.store.
+		BunDB().
+		NewDelete().
+		Model(new(OutboxEvent)).
+		WhereOr("sent_at < ?", now.Add(-relay.config.Retention)).
+		WhereOr("failed_at < ?", now.Add(-relay.config.FailedRetention)).
+		Exec(ctx)
+	if err != nil {
+		return errors.Wrapf(err, errors.TypeInternal, errors.CodeInternal, "failed to delete the published and the failed outbox events")
+	}
+
+	return nil
+}
+
+// NewOutboxPurgeJob returns the job deleting the events written to the outbox while its relay is disabled, which are
+// never published, once they are older than the retention.
+func NewOutboxPurgeJob(store SQLStore, config OutboxConfig) factory.Job {
+	return factory.NewJob(
+		factory.MustNewName("outboxpurge"),
+		outboxPurgeInterval,
+		func(ctx context.Context) error {
+			_, err := store.
+				BunDB().
+				NewDelete().
+				Model(new(OutboxEvent)).
+				Where("created_at < ?", time.Now().Add(-config.Retention)).
+				Exec(ctx)
+			if err != nil {
+				return errors.Wrapf(err, errors.TypeInternal, errors.CodeInternal, "failed to delete the outbox events")
+			}
+
+			return nil
+		},
+		factory.WithSingleton(),
+	)
+}
+
+// publish publishes the event and records the outcome on the event.
+func (relay *OutboxRelay) publish(ctx context.Context, event *OutboxEvent) {
+	err := relay.sink.Publish(ctx, event)
+	now := time.Now()
+	if err == nil {
+		event.SentAt = &now
+		event.LastError = ""
+		relay.events.Add(ctx, 1, metric.WithAttributes(attribute.String("result", "sent")))
+		return
+	}
+
+	event.Attempts++
+	event.LastError = err.Error()
+
+	if event.Attempts >= relay.config.MaxAttempts {
+		event.FailedAt = &now
+		relay.events.Add(ctx, 1, metric.WithAttributes(attribute.String("result", "failed")))
+		relay.settings.Logger().ErrorContext(ctx, "giving up on the outbox event", "id", event.ID.StringValue(), "topic", event.Topic, "attempts", event.Attempts, "error", err)
+		return
+	}
+
+	event.NextAttemptAt = now.Add(relay.backoff(event.Attempts))
+	relay.events.Add(ctx, 1, metric.WithAttributes(attribute.String("result", "retried")))
+	relay.settings.Logger().WarnContext(ctx, "failed to publish the outbox event, retrying", "id", event.ID.StringValue(), "topic", event.Topic, "attempts", event.Attempts, "next_attempt_at", event.NextAttemptAt, "error", err)
+}
+
+// backoff returns the time waited before the next attempt to publish an event which failed the given number of
+// times.
+func (relay *OutboxRelay) backoff(attempts int) time.Duration {
+	backoff := relay.config.InitialBackoff
+	for i := 1; i < attempts && backoff < relay.config.MaxBackoff; i++ {
+		backoff *= 2
+	}
+
+	return min(backoff, relay.config.MaxBackoff)
+}
@@ -0,0 +1,237 @@
+package sqlstore_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/factory/factorytest"
+	"github.com/SigNoz/signoz/pkg/sqlstore"
+	"github.com/SigNoz/signoz/pkg/sqlstore/sqlstoretest"
+	"github.com/SigNoz/signoz/pkg/valuer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// failingSink fails to publish the events of the topics for as many times as given.
+type failingSink struct {
+	failures  map[string]int
+	published []string
+}
+
+func (sink *failingSink) Publish(_ context.Context, event *sqlstore.OutboxEvent) error {
+	if sink.failures[event.Topic] > 0 {
+		sink.failures[event.Topic]--
+		return errors.New(errors.TypeInternal, errors.CodeInternal, "sink is unavailable")
+	}
+
+	sink.published = append(sink.published, event.Topic)
+	return nil
+}
+
+func newOutboxStore(t *testing.T) sqlstore.SQLStore {
+	store := sqlstoretest.NewSQLite(t)
+	_, err := store.BunDB().NewCreateTable().Model(new(sqlstore.OutboxEvent)).Exec(context.Background())
+	require.NoError(t, err)
+
+	return store
+}
+
+func listOutboxEvents(t *testing.T, store sqlstore.SQLStore) map[string]*sqlstore.OutboxEvent {
+	events := make([]*sqlstore.OutboxEvent, 0)
+	require.NoError(t, store.BunDB().NewSelect().Model(&events).Scan(context.Background()))
+
+	byTopic := make(map[string]*sqlstore.OutboxEvent, len(events))
+	for _, event := range events {
+		byTopic[event.Topic] = event
+	}
+
+	return byTopic
+}
+
+func TestPublishOutboxEvent(t *testing.T) {
+	ctx := context.Background()
+	store := newOutboxStore(t)
+	orgID := valuer.GenerateUUID()
+
+	require.NoError(t, store.RunInTxCtx(ctx, nil, func(ctx context.Context) error {
+		return sqlstore.PublishOutboxEvent(ctx, store, orgID, "rule.created", map[string]string{"id": "rule"})
+	}))
+
+	err := store.RunInTxCtx(ctx, nil, func(ctx context.Context) error {
+		require.NoError(t, sqlstore.PublishOutboxEvent(ctx, store, orgID, "rule.deleted", map[string]string{"id": "rule"}))
+		return errors.New(errors.TypeInternal, errors.CodeInternal, "change failed")
+	})
+	require.Error(t, err)
+
+	// The event of the rolled back change is rolled back along with it.
+	events := listOutboxEvents(t, store)
+	require.Len(t, events, 1)
+	assert.JSONEq(t, `{"id": "rule"}`, events["rule.created"].Payload)
+	assert.Equal(t, orgID, events["rule.created"].OrgID)
+
+	assert.Error(t, sqlstore.PublishOutboxEvent(ctx, store, orgID, "", nil))
+}
+
+func TestOutboxRelay(t *testing.T) {
+	ctx := context.Background()
+	store := newOutboxStore(t)
+	orgID := valuer.GenerateUUID()
+	for _, topic := range []string{"rule.created", "rule.updated", "rule.deleted"} {
+		require.NoError(t, sqlstore.PublishOutboxEvent(ctx, store, orgID, topic, nil))
+	}
+
+	sink := &failingSink{failures: map[string]int{"rule.updated": 1, "rule.deleted": 2}}
+	relay, err := sqlstore.NewOutboxRelay(factorytest.NewSettings(), store, sink, sqlstore.OutboxConfig{
+		BatchSize:       10,
+		MaxAttempts:     2,
+		InitialBackoff:  time.Hour,
+		MaxBackoff:      time.Hour,
+		Retention:       time.Hour,
+		FailedRetention: time.Hour,
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, relay.Relay(ctx))
+	assert.Equal(t, []string{"rule.created"}, sink.published)
+
+	events := listOutboxEvents(t, store)
+	assert.NotNil(t, events["rule.created"].SentAt)
+	assert.Equal(t, 1, events["rule.updated"].Attempts)
+	assert.Contains(t, events["rule.updated"].LastError, "sink is unavailable")
+	assert.True(t, events["rule.updated"].NextAttemptAt.After(time.Now()))
+
+	// The events failing to be published wait for their backoff.
+	require.NoError(t, relay.Relay(ctx))
+	assert.Equal(t, []string{"rule.created"}, sink.published)
+
+	_, err = store.BunDB().NewUpdate().Model(new(sqlstore.OutboxEvent)).Set("next_attempt_at = ?", time.Now().Add(-time.Minute)).Where("sent_at IS NULL").Exec(ctx)
+	require.NoError(t, err)
+
+	require.NoError(t, relay.Relay(ctx))
+	assert.Equal(t, []string{"rule.created", "rule.updated"}, sink.published)
+
+	// The event failing as many times as the max attempts is given up on.
+	events = listOutboxEvents(t, store)
+	assert.NotNil(t, events["rule.updated"].SentAt)
+	assert.Nil(t, events["rule.deleted"].SentAt)
+	assert.NotNil(t, events["rule.deleted"].FailedAt)
+	assert.Equal(t, 2, events["rule.deleted"].Attempts)
+
+	_, err = store.BunDB().NewUpdate().Model(new(sqlstore.OutboxEvent)).Set("next_attempt_at = ?", time.Now().Add(-time.Minute)).Where("1 = 1").Exec(ctx)
+	require.NoError(t, err)
+
+	require.NoError(t, relay.Relay(ctx))
+	assert.Equal(t, []string{"rule.created", "rule.updated"}, sink.published)
+}
+
+func TestOutboxRelayDeletesPublishedAndFailedEvents(t *testing.T) {
+	ctx := context.Background()
+	store := newOutboxStore(t)
+	orgID := valuer.GenerateUUID()
+	require.NoError(t, sqlstore.PublishOutboxEvent(ctx, store, orgID, "rule.created", nil))
+	require.NoError(t, sqlstore.PublishOutboxEvent(ctx, store, orgID, "rule.deleted", nil))
+
+	relay, err := sqlstore.NewOutboxRelay(factorytest.NewSettings(), store, &failingSink{failures: map[string]int{"rule.deleted": 1}}, sqlstore.OutboxConfig{
+		BatchSize:       10,
+		MaxAttempts:     1,
+		InitialBackoff:  time.Second,
+		MaxBackoff:      time.Second,
+		Retention:       time.Hour,
+		FailedRetention: 2 * time.Hour,
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, relay.Relay(ctx))
+	events := listOutboxEvents(t, store)
+	require.Len(t, events, 2)
+	require.NotNil(t, events["rule.deleted"].FailedAt)
+
+	_, err = store.BunDB().NewUpdate().Model(new(sqlstore.OutboxEvent)).Set("sent_at = ?", time.Now().Add(-2*time.Hour)).Where("sent_at IS NOT NULL").Exec(ctx)
+	require.NoError(t, err)
+
+	// The failed event is kept for the failed retention, which is longer than the retention.
+	_, err = store.BunDB().NewUpdate().Model(new(sqlstore.OutboxEvent)).Set("failed_at = ?", time.Now().Add(-90*time.Minute)).Where("failed_at IS NOT NULL").Exec(ctx)
+	require.NoError(t, err)
+
+	require.NoError(t, relay.Relay(ctx))
+	events = listOutboxEvents(t, store)
+	require.Len(t, events, 1)
+	assert.Contains(t, events, "rule.deleted")
+
+	_, err = store.BunDB().NewUpdate().Model(new(sqlstore.OutboxEvent)).Set("failed_at = ?", time.Now().Add(-3*time.Hour)).Where("failed_at IS NOT NULL").Exec(ctx)
+	require.NoError(t, err)
+
+	require.NoError(t, relay.Relay(ctx))
+	assert.Empty(t, listOutboxEvents(t, store))
+}
+
+func TestOutboxPurgeJob(t *testing.T) {
+	ctx := context.Background()
+	store := newOutboxStore(t)
+	orgID := valuer.GenerateUUID()
+	require.NoError(t, sqlstore.PublishOutboxEvent(ctx, store, orgID, "rule.created", nil))
+	require.NoError(t, sqlstore.PublishOutboxEvent(ctx, store, orgID, "rule.deleted", nil))
+
+	_, err := store.BunDB().NewUpdate().Model(new(sqlstore.OutboxEvent)).Set("created_at = ?", time.Now().Add(-2*time.Hour)).Where("topic = ?", "rule.created").Exec(ctx)
+	require.NoError(t, err)
+
+	// The events written while the relay is disabled are deleted once they are older than the retention.
+	require.NoError(t, sqlstore.NewOutboxPurgeJob(store, sqlstore.OutboxConfig{Retention: time.Hour}).Run(ctx))
+	events := listOutboxEvents(t, store)
+	require.Len(t, events, 1)
+	assert.Contains(t, events, "rule.deleted")
+}
+
+func TestWebhookOutboxSink(t *testing.T) {
+	orgID := valuer.GenerateUUID()
+	event, err := sqlstore.NewOutboxEvent(orgID, "rule.created", map[string]string{"id": "rule"})
+	require.NoError(t, err)
+
+	status := http.StatusAccepted
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, event.ID.StringValue(), req.Header.Get(sqlstore.OutboxEventIDHeader))
+		assert.Equal(t, orgID.StringValue(), req.Header.Get(sqlstore.OutboxOrgIDHeader))
+
+		body := map[string]any{}
+		assert.NoError(t, json.NewDecoder(req.Body).Decode(&body))
+		assert.Equal(t, "rule.created", body["topic"])
+		assert.Equal(t, orgID.StringValue(), body["orgId"])
+		assert.Equal(t, map[string]any{"id": "rule"}, body["payload"])
+
+		rw.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	sink := sqlstore.NewWebhookOutboxSink(server.Client(), sqlstore.OutboxWebhookConfig{URL: server.URL})
+	assert.NoError(t, sink.Publish(context.Background(), event))
+
+	status = http.StatusServiceUnavailable
+	assert.Error(t, sink.Publish(context.Background(), event))
+}
+
+func TestWebhookOutboxSinkOrgURLs(t *testing.T) {
+	orgID := valuer.GenerateUUID()
+	event, err := sqlstore.NewOutboxEvent(orgID, "rule.created", nil)
+	require.NoError(t, err)
+
+	paths := []string{}
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		paths = append(paths, req.URL.Path)
+		rw.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	sink := sqlstore.NewWebhookOutboxSink(server.Client(), sqlstore.OutboxWebhookConfig{URL: server.URL + "/default", OrgURLs: map[string]string{orgID.StringValue(): server.URL + "/org"}})
+	require.NoError(t, sink.Publish(context.Background(), event))
+
+	other, err := sqlstore.NewOutboxEvent(valuer.GenerateUUID(), "rule.created", nil)
+	require.NoError(t, err)
+	require.NoError(t, sink.Publish(context.Background(), other))
+
+	assert.Equal(t, []string{"/org", "/default"}, paths)
+}
@@ -0,0 +1,79 @@
+package sqlstore
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+)
+
+const (
+	// OutboxEventIDHeader is the header holding the id of the events posted to the webhook, on which the receivers
+	// deduplicate the events published more than once.
+	OutboxEventIDHeader string = "X-SigNoz-Outbox-Event-Id"
+	// OutboxOrgIDHeader is the header holding the id of the organization of the events posted to the webhook.
+	OutboxOrgIDHeader string = "X-SigNoz-Org-Id"
+)
+
+type webhookOutboxSink struct {
+	client  *http.Client
+	url     string
+	orgURLs map[string]string
+}
+
+// NewWebhookOutboxSink returns the sink posting the events of the outbox to the url of their organization in the
+// config, or to the url of the config. Any response other than a 2xx fails the publication.
+func NewWebhookOutboxSink(client *http.Client, config OutboxWebhookConfig) OutboxSink {
+	return &webhookOutboxSink{client: client, url: config.URL, orgURLs: config.OrgURLs}
+}
+
+type postableOutboxEvent struct {
+	ID        string          `json:"id"`
+	OrgID     string          `json:"orgId"`
+	Topic     string          `json:"topic"`
+	Payload   json.RawMessage `json:"payload"`
+	CreatedAt time.Time       `json:"createdAt"`
+}
+
+func (sink *webhookOutboxSink) Publish(ctx context.Context, event *OutboxEvent) error {
+	body, err := json.Marshal(postableOutboxEvent{
+		ID:        event.ID.StringValue(),
+		OrgID:     event.OrgID.StringValue(),
+		Topic:     event.Topic,
+		Payload:   json.RawMessage(event.Payload),
+		CreatedAt: event.CreatedAt,
+	})
+	if err != nil {
+		return errors.Wrapf(err, errors.TypeInternal, errors.CodeInternal, "failed to encode the outbox event")
+	}
+
+	url, ok := sink.orgURLs[event.OrgID.StringValue()]
+	if !ok {
+		url = sink.url
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrapf(err, errors.TypeInternal, errors.CodeInternal, "failed to create the request of the outbox event")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(OutboxEventIDHeader, event.ID.StringValue())
+	req.Header.Set(OutboxOrgIDHeader, event.OrgID.StringValue())
+
+	res, err := sink.client.Do(req)
+	if err != nil {
+		return errors.Wrapf(err, errors.TypeInternal, errors.CodeInternal, "failed to post the outbox event")
+	}
+	defer res.Body.Close()
+	_, _ = io.Copy(io.Discard, res.Body)
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return errors.Newf(errors.TypeInternal, errors.CodeInternal, "webhook responded to the outbox event with status %d", res.StatusCode)
+	}
+
+	return nil
+}
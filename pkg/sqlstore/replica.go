@@ -0,0 +1,128 @@
+package sqlstore
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/factory"
+	"github.com/uptrace/bun"
+)
+
+const (
+	replicaHealthCheckInterval = 10 * time.Second
+	replicaHealthCheckTimeout  = 5 * time.Second
+)
+
+type primaryKey struct{}
+
+// NewContextWithPrimary returns a new context which forces reads made through ReadDB to go to the primary.
+// Use this for read-your-writes consistency within a request.
+func NewContextWithPrimary(ctx context.Context) context.Context {
+	return context.WithValue(ctx, primaryKey{}, true)
+}
+
+func primaryFromContext(ctx context.Context) bool {
+	primary, ok := ctx.Value(primaryKey{}).(bool)
+	return ok && primary
+}
+
+type replica struct {
+	db            *bun.DB
+	healthy       atomic.Bool
+	checking      atomic.Bool
+	lastCheckedAt atomic.Int64
+}
+
+// ReplicaSet routes read queries across a set of read replicas in a round-robin fashion.
+// Replicas are health checked lazily and the primary is used when none of them are healthy.
+type ReplicaSet struct {
+	settings factory.ScopedProviderSettings
+	primary  *BunDB
+	replicas []*replica
+	next     atomic.Uint64
+}
+
+func NewReplicaSet(ctx context.Context, settings factory.ScopedProviderSettings, primary *BunDB, replicas ...*bun.DB) *ReplicaSet {
+	set := &ReplicaSet{
+		settings: settings,
+		primary:  primary,
+		replicas: make([]*replica, len(replicas)),
+	}
+
+	for i, db := range replicas {
+		set.replicas[i] = &replica{db: db}
+		if !set.check(ctx, set.replicas[i]) {
+			settings.Logger().WarnContext(ctx, "replica is unreachable, reads will be routed elsewhere until it recovers", "replica", i)
+		}
+	}
+
+	return set
+}
+
+// ReadDBCtx returns an instance of bun.IDB for read queries. The primary is returned if a transaction or a
+// primary read is present in the context, or if none of the replicas are healthy.
+func (set *ReplicaSet) ReadDBCtx(ctx context.Context) bun.IDB {
+	if _, ok := txFromContext(ctx); ok || primaryFromContext(ctx) || len(set.replicas) == 0 {
+		return set.primary.BunDBCtx(ctx)
+	}
+
+	start := set.next.Add(1)
+	for i := 0; i < len(set.replicas); i++ {
+		replica := set.replicas[(start+uint64(i))%uint64(len(set.replicas))]
+		set.checkIfStale(replica)
+
+		if replica.healthy.Load() {
+			return replica.db
+		}
+	}
+
+	return set.primary.DB
+}
+
+// Close closes all the replicas in the set. It does not close the primary.
+func (set *ReplicaSet) Close() error {
+	var errs []error
+	for _, replica := range set.replicas {
+		if err := replica.db.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (set *ReplicaSet) checkIfStale(replica *replica) {
+	if time.Since(time.Unix(0, replica.lastCheckedAt.Load())) < replicaHealthCheckInterval {
+		return
+	}
+
+	if !replica.checking.CompareAndSwap(false, true) {
+		return
+	}
+
+	go func() {
+		defer replica.checking.Store(false)
+		_ = set.check(context.Background(), replica)
+	}()
+}
+
+func (set *ReplicaSet) check(ctx context.Context, replica *replica) bool {
+	ctx, cancel := context.WithTimeout(ctx, replicaHealthCheckTimeout)
+	defer cancel()
+
+	err := replica.db.PingContext(ctx)
+	checkedBefore := replica.lastCheckedAt.Swap(time.Now().UnixNano()) != 0
+
+	wasHealthy := replica.healthy.Swap(err == nil)
+	if err != nil && wasHealthy && checkedBefore {
+		set.settings.Logger().WarnContext(ctx, "replica has become unhealthy, reads will be routed elsewhere", "error", err)
+	}
+
+	if err == nil && !wasHealthy && checkedBefore {
+		set.settings.Logger().InfoContext(ctx, "replica has become healthy, reads will be routed to it")
+	}
+
+	return err == nil
+}
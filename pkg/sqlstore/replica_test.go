@@ -0,0 +1,139 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/SigNoz/signoz/pkg/factory"
+	"github.com/SigNoz/signoz/pkg/factory/factorytest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/dialect/sqlitedialect"
+)
+
+func newTestDB(t *testing.T, pingErr error) (*bun.DB, sqlmock.Sqlmock) {
+	t.Helper()
+
+	db, mock, err := sqlmock.New(sqlmock.MonitorPingsOption(true))
+	require.NoError(t, err)
+	mock.ExpectPing().WillReturnError(pingErr)
+
+	return bun.NewDB(db, sqlitedialect.New()), mock
+}
+
+func newTestPrimary(t *testing.T, settings factory.ScopedProviderSettings) *BunDB {
+	t.Helper()
+
+	db, _, err := sqlmock.New()
+	require.NoError(t, err)
+
+	return NewBunDB(settings, db, sqlitedialect.New(), nil)
+}
+
+func TestReplicaSetReadDBCtx(t *testing.T) {
+	settings := factory.NewScopedProviderSettings(factorytest.NewSettings(), "github.com/SigNoz/signoz/pkg/sqlstore")
+	ctx := context.Background()
+
+	testCases := []struct {
+		name     string
+		pingErrs []error
+		ctx      context.Context
+		expected func(primary *BunDB, replicas []*bun.DB) []bun.IDB
+	}{
+		{
+			name:     "NoReplicas",
+			pingErrs: []error{},
+			ctx:      ctx,
+			expected: func(primary *BunDB, _ []*bun.DB) []bun.IDB {
+				return []bun.IDB{primary.DB, primary.DB}
+			},
+		},
+		{
+			name:     "RoundRobinAcrossHealthyReplicas",
+			pingErrs: []error{nil, nil},
+			ctx:      ctx,
+			expected: func(_ *BunDB, replicas []*bun.DB) []bun.IDB {
+				return []bun.IDB{replicas[1], replicas[0], replicas[1]}
+			},
+		},
+		{
+			name:     "SkipUnhealthyReplicas",
+			pingErrs: []error{errors.New("connection refused"), nil},
+			ctx:      ctx,
+			expected: func(_ *BunDB, replicas []*bun.DB) []bun.IDB {
+				return []bun.IDB{replicas[1], replicas[1]}
+			},
+		},
+		{
+			name:     "FallbackToPrimaryWhenAllReplicasAreUnhealthy",
+			pingErrs: []error{errors.New("connection refused"), errors.New("connection refused")},
+			ctx:      ctx,
+			expected: func(primary *BunDB, _ []*bun.DB) []bun.IDB {
+				return []bun.IDB{primary.DB, primary.DB}
+			},
+		},
+		{
+			name:     "ForcePrimary",
+			pingErrs: []error{nil, nil},
+			ctx:      NewContextWithPrimary(ctx),
+			expected: func(primary *BunDB, _ []*bun.DB) []bun.IDB {
+				return []bun.IDB{primary.DB, primary.DB}
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			primary := newTestPrimary(t, settings)
+			replicas := make([]*bun.DB, len(tc.pingErrs))
+			for i, pingErr := range tc.pingErrs {
+				replicas[i], _ = newTestDB(t, pingErr)
+			}
+
+			set := NewReplicaSet(ctx, settings, primary, replicas...)
+			for _, expected := range tc.expected(primary, replicas) {
+				assert.Same(t, expected, set.ReadDBCtx(tc.ctx))
+			}
+		})
+	}
+}
+
+func TestReplicaSetReadDBCtxWithTx(t *testing.T) {
+	settings := factory.NewScopedProviderSettings(factorytest.NewSettings(), "github.com/SigNoz/signoz/pkg/sqlstore")
+	ctx := context.Background()
+
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	mock.ExpectBegin()
+	mock.ExpectCommit()
+
+	primary := NewBunDB(settings, db, sqlitedialect.New(), nil)
+	replica, _ := newTestDB(t, nil)
+	set := NewReplicaSet(ctx, settings, primary, replica)
+
+	err = primary.RunInTxCtx(ctx, &sql.TxOptions{}, func(ctx context.Context) error {
+		_, ok := set.ReadDBCtx(ctx).(bun.Tx)
+		assert.True(t, ok)
+		return nil
+	})
+	require.NoError(t, err)
+}
+
+func TestReplicaSetClose(t *testing.T) {
+	settings := factory.NewScopedProviderSettings(factorytest.NewSettings(), "github.com/SigNoz/signoz/pkg/sqlstore")
+
+	replica1, mock1 := newTestDB(t, nil)
+	replica2, mock2 := newTestDB(t, nil)
+	mock1.ExpectClose()
+	mock2.ExpectClose()
+
+	set := NewReplicaSet(context.Background(), settings, newTestPrimary(t, settings), replica1, replica2)
+	require.NoError(t, set.Close())
+
+	assert.NoError(t, mock1.ExpectationsWereMet())
+	assert.NoError(t, mock2.ExpectationsWereMet())
+}
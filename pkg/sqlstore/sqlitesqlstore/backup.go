@@ -0,0 +1,192 @@
+package sqlitesqlstore
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/sqlstore"
+	"github.com/uptrace/bun"
+)
+
+const (
+	// backupSchema is the name under which the backup is attached to the connection restoring it.
+	backupSchema string = "backup"
+)
+
+func (dialect *dialect) BackupTables(ctx context.Context, bun bun.IDB) ([]string, error) {
+	var tables []string
+	if err := bun.NewRaw("SELECT name FROM sqlite_master WHERE type = 'table' AND name NOT LIKE 'sqlite_%'").Scan(ctx, &tables); err != nil {
+		return nil, err
+	}
+
+	references := make(map[string][]string, len(tables))
+	for _, table := range tables {
+		var referenced []string
+		if err := bun.NewRaw("SELECT DISTINCT \"table\" FROM pragma_foreign_key_list(?)", table).Scan(ctx, &referenced); err != nil {
+			return nil, err
+		}
+		references[table] = referenced
+	}
+
+	return sqlstore.OrderByReferences(tables, references), nil
+}
+
+// Backup writes a snapshot of the whole database, the tables are not needed. The write ahead log is checkpointed
+// first so that the snapshot does not depend on it, then the snapshot is written by VACUUM INTO which reads the
+// database in a single read transaction.
+func (dialect *dialect) Backup(ctx context.Context, bun *bun.DB, tables []string, w io.Writer) error {
+	dir, err := os.MkdirTemp("", "signoz-backup-*")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir) //nolint:errcheck
+
+	conn, err := bun.DB.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close() //nolint:errcheck
+
+	if _, err := conn.ExecContext(ctx, "PRAGMA wal_checkpoint(TRUNCATE)"); err != nil {
+		return err
+	}
+
+	path := filepath.Join(dir, "backup.db")
+	if _, err := conn.ExecContext(ctx, "VACUUM INTO ?", path); err != nil {
+		return err
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close() //nolint:errcheck
+
+	_, err = io.Copy(w, file)
+	return err
+}
+
+// Restore attaches the snapshot to a connection and copies the rows of its tables. The foreign keys are only checked
+// once all the tables are copied.
+func (dialect *dialect) Restore(ctx context.Context, bun *bun.DB, tables []string, r io.Reader, dryRun bool) error {
+	dir, err := os.MkdirTemp("", "signoz-restore-*")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir) //nolint:errcheck
+
+	path := filepath.Join(dir, "backup.db")
+	if err := writeFile(path, r); err != nil {
+		return err
+	}
+
+	conn, err := bun.DB.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close() //nolint:errcheck
+
+	if _, err := conn.ExecContext(ctx, "ATTACH DATABASE ? AS "+backupSchema, "file:"+path+"?mode=ro"); err != nil {
+		return errors.Wrapf(err, errors.TypeInvalidInput, sqlstore.ErrCodeBackupInvalid, "cannot open the backup")
+	}
+	defer conn.ExecContext(context.WithoutCancel(ctx), "DETACH DATABASE "+backupSchema) //nolint:errcheck
+
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	if _, err := tx.ExecContext(ctx, "PRAGMA defer_foreign_keys = ON"); err != nil {
+		return err
+	}
+
+	for i := len(tables) - 1; i >= 0; i-- {
+		if _, err := tx.ExecContext(ctx, fmt.Sprintf("DELETE FROM main.%s", quote(tables[i]))); err != nil {
+			return err
+		}
+	}
+
+	for _, table := range tables {
+		columns, err := tableColumns(ctx, tx, table)
+		if err != nil {
+			return err
+		}
+
+		if _, err := tx.ExecContext(ctx, fmt.Sprintf("INSERT INTO main.%s (%s) SELECT %s FROM %s.%s", quote(table), columns, columns, backupSchema, quote(table))); err != nil {
+			return errors.Wrapf(err, errors.TypeInvalidInput, sqlstore.ErrCodeBackupInvalid, "cannot restore the table %q", table)
+		}
+	}
+
+	violations, err := tx.QueryContext(ctx, "PRAGMA main.foreign_key_check")
+	if err != nil {
+		return err
+	}
+	defer violations.Close() //nolint:errcheck
+
+	if violations.Next() {
+		var table string
+		var rowID sql.NullInt64
+		var parent string
+		var fkID int
+		if err := violations.Scan(&table, &rowID, &parent, &fkID); err != nil {
+			return err
+		}
+
+		return errors.Newf(errors.TypeInvalidInput, sqlstore.ErrCodeBackupInvalid, "row %d of the table %q references a missing row of the table %q", rowID.Int64, table, parent)
+	}
+
+	if err := violations.Err(); err != nil {
+		return err
+	}
+
+	if dryRun {
+		return tx.Rollback()
+	}
+
+	return tx.Commit()
+}
+
+// tableColumns returns the quoted columns of the table of the main database.
+func tableColumns(ctx context.Context, tx *sql.Tx, table string) (string, error) {
+	rows, err := tx.QueryContext(ctx, "SELECT name FROM pragma_table_info(?, 'main')", table)
+	if err != nil {
+		return "", err
+	}
+	defer rows.Close() //nolint:errcheck
+
+	var columns []string
+	for rows.Next() {
+		var column string
+		if err := rows.Scan(&column); err != nil {
+			return "", err
+		}
+		columns = append(columns, quote(column))
+	}
+
+	return strings.Join(columns, ", "), rows.Err()
+}
+
+func quote(identifier string) string {
+	return `"` + strings.ReplaceAll(identifier, `"`, `""`) + `"`
+}
+
+func writeFile(path string, r io.Reader) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(file, r); err != nil {
+		_ = file.Close()
+		return err
+	}
+
+	return file.Close()
+}
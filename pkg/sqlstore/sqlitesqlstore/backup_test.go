@@ -0,0 +1,128 @@
+package sqlitesqlstore
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/factory/factorytest"
+	"github.com/SigNoz/signoz/pkg/sqlstore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestBackupStore returns a store migrated to the version, the migrations seed a default organization.
+func newTestBackupStore(t *testing.T, journalMode string, version string) sqlstore.SQLStore {
+	ctx := context.Background()
+	store, err := New(ctx, factorytest.NewSettings(), sqlstore.Config{
+		Provider:   "sqlite",
+		Connection: sqlstore.ConnectionConfig{MaxOpenConns: 1},
+		Sqlite: sqlstore.SqliteConfig{
+			Path:        filepath.Join(t.TempDir(), "signoz.db"),
+			JournalMode: journalMode,
+			Synchronous: "full",
+			BusyTimeout: time.Second,
+		},
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = store.SQLDB().Close() })
+
+	for _, statement := range []string{
+		"CREATE TABLE migration (id INTEGER PRIMARY KEY AUTOINCREMENT, name TEXT, group_id INTEGER, migrated_at TIMESTAMP)",
+		"CREATE TABLE organizations (id TEXT PRIMARY KEY, name TEXT)",
+		`CREATE TABLE users (id TEXT PRIMARY KEY, org_id TEXT NOT NULL REFERENCES organizations (id), email TEXT)`,
+		"INSERT INTO migration (name, group_id, migrated_at) VALUES ('001_add_organization', 1, CURRENT_TIMESTAMP)",
+		"INSERT INTO organizations (id, name) VALUES ('default', 'default')",
+	} {
+		_, err := store.SQLDB().ExecContext(ctx, statement)
+		require.NoError(t, err)
+	}
+
+	if version != "001_add_organization" {
+		_, err := store.SQLDB().ExecContext(ctx, "INSERT INTO migration (name, group_id, migrated_at) VALUES (?, 2, CURRENT_TIMESTAMP)", version)
+		require.NoError(t, err)
+	}
+
+	return store
+}
+
+func newTestBackup(t *testing.T) (*bytes.Buffer, *sqlstore.BackupHeader) {
+	ctx := context.Background()
+	store := newTestBackupStore(t, "wal", "001_add_organization")
+
+	for _, statement := range []string{
+		"INSERT INTO organizations (id, name) VALUES ('org1', 'acme')",
+		"INSERT INTO users (id, org_id, email) VALUES ('user1', 'org1', 'admin@acme.com')",
+		"DELETE FROM organizations WHERE id = 'default'",
+	} {
+		_, err := store.SQLDB().ExecContext(ctx, statement)
+		require.NoError(t, err)
+	}
+
+	backup := new(bytes.Buffer)
+	header, err := sqlstore.Backup(ctx, store, backup)
+	require.NoError(t, err)
+
+	return backup, header
+}
+
+func TestBackupTables(t *testing.T) {
+	store := newTestBackupStore(t, "delete", "001_add_organization")
+
+	tables, err := store.Dialect().BackupTables(context.Background(), store.BunDB())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"migration", "organizations", "users"}, tables)
+}
+
+func TestBackupRestore(t *testing.T) {
+	ctx := context.Background()
+	backup, header := newTestBackup(t)
+	assert.Equal(t, "sqlite", header.Dialect)
+	assert.Equal(t, "001_add_organization", header.MigrationVersion)
+	assert.Equal(t, []string{"organizations", "users"}, header.Tables)
+
+	store := newTestBackupStore(t, "delete", "001_add_organization")
+	_, err := sqlstore.Restore(ctx, store, backup, sqlstore.RestoreOptions{})
+	require.NoError(t, err)
+
+	var orgs []string
+	require.NoError(t, store.BunDB().NewRaw("SELECT name FROM organizations ORDER BY id").Scan(ctx, &orgs))
+	assert.Equal(t, []string{"acme"}, orgs)
+
+	var email string
+	require.NoError(t, store.BunDB().NewRaw("SELECT email FROM users WHERE org_id = 'org1'").Scan(ctx, &email))
+	assert.Equal(t, "admin@acme.com", email)
+}
+
+func TestBackupRestoreDryRun(t *testing.T) {
+	ctx := context.Background()
+	backup, _ := newTestBackup(t)
+
+	store := newTestBackupStore(t, "delete", "001_add_organization")
+	_, err := sqlstore.Restore(ctx, store, backup, sqlstore.RestoreOptions{DryRun: true})
+	require.NoError(t, err)
+
+	var orgs []string
+	require.NoError(t, store.BunDB().NewRaw("SELECT name FROM organizations ORDER BY id").Scan(ctx, &orgs))
+	assert.Equal(t, []string{"default"}, orgs)
+}
+
+func TestBackupRestoreVersionMismatch(t *testing.T) {
+	backup, _ := newTestBackup(t)
+
+	store := newTestBackupStore(t, "delete", "002_add_users")
+	_, err := sqlstore.Restore(context.Background(), store, backup, sqlstore.RestoreOptions{DryRun: true})
+	require.Error(t, err)
+	assert.True(t, errors.Asc(err, sqlstore.ErrCodeBackupVersionMismatch))
+}
+
+func TestBackupRestoreInvalid(t *testing.T) {
+	store := newTestBackupStore(t, "delete", "001_add_organization")
+
+	_, err := sqlstore.Restore(context.Background(), store, bytes.NewBufferString("not a backup\n"), sqlstore.RestoreOptions{})
+	require.Error(t, err)
+	assert.True(t, errors.Asc(err, sqlstore.ErrCodeBackupInvalid))
+}
@@ -6,6 +6,7 @@ import (
 	"reflect"
 	"slices"
 	"strings"
+	"sync"
 
 	"github.com/SigNoz/signoz/pkg/errors"
 	"github.com/uptrace/bun"
@@ -37,7 +38,10 @@ const (
 	OrgField string = "org_id"
 )
 
-type dialect struct{}
+type dialect struct {
+	// advisoryLocks are the advisory locks keyed by key. The database is local to the process, so are its locks.
+	advisoryLocks sync.Map
+}
 
 func (dialect *dialect) GetColumnType(ctx context.Context, bun bun.IDB, table string, column string) (string, error) {
 	var columnType string
@@ -503,3 +507,16 @@ func (dialect *dialect) ToggleForeignKeyConstraint(ctx context.Context, bun *bun
 	_, err := bun.ExecContext(ctx, "PRAGMA foreign_keys = OFF")
 	return err
 }
+
+func (dialect *dialect) TryAdvisoryLock(ctx context.Context, bun *bun.DB, key string) (func(context.Context) error, bool, error) {
+	value, _ := dialect.advisoryLocks.LoadOrStore(key, new(sync.Mutex))
+	mu := value.(*sync.Mutex)
+	if !mu.TryLock() {
+		return nil, false, nil
+	}
+
+	return func(context.Context) error {
+		mu.Unlock()
+		return nil
+	}, true, nil
+}
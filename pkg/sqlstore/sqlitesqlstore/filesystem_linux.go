@@ -0,0 +1,30 @@
+package sqlitesqlstore
+
+import (
+	"syscall"
+)
+
+// Magic numbers of the network filesystems as defined in linux/magic.h and statfs(2).
+var networkFilesystems = map[int64]string{
+	0x6969:     "nfs",
+	0x517b:     "smb",
+	0xfe534d42: "smb2",
+	0xff534d42: "cifs",
+	0x65735546: "fuse",
+	0x564c:     "ncp",
+	0x73757245: "coda",
+	0x5346414f: "afs",
+	0x6b414653: "afs",
+	0x01021997: "9p",
+}
+
+// isNetworkFilesystem reports whether the given path is on a network filesystem along with the name of the filesystem.
+func isNetworkFilesystem(path string) (bool, string) {
+	var stat syscall.Statfs_t
+	if err := syscall.Statfs(path, &stat); err != nil {
+		return false, ""
+	}
+
+	fsType, ok := networkFilesystems[int64(stat.Type)] //nolint:unconvert
+	return ok, fsType
+}
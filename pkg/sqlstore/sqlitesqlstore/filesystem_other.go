@@ -0,0 +1,9 @@
+//go:build !linux
+
+package sqlitesqlstore
+
+// isNetworkFilesystem reports whether the given path is on a network filesystem along with the name of the filesystem.
+// The filesystem cannot be detected on this platform.
+func isNetworkFilesystem(string) (bool, string) {
+	return false, ""
+}
@@ -3,6 +3,10 @@ package sqlitesqlstore
 import (
 	"context"
 	"database/sql"
+	"net/url"
+	"path/filepath"
+	"strconv"
+	"strings"
 
 	"github.com/SigNoz/signoz/pkg/errors"
 	"github.com/SigNoz/signoz/pkg/factory"
@@ -19,6 +23,7 @@ type provider struct {
 	bundb    *sqlstore.BunDB
 	sqlxdb   *sqlx.DB
 	dialect  *dialect
+	vacuumer *vacuumer
 }
 
 func NewFactory(hookFactories ...factory.ProviderFactory[sqlstore.SQLStoreHook, sqlstore.Config]) factory.ProviderFactory[sqlstore.SQLStore, sqlstore.Config] {
@@ -39,22 +44,67 @@ func NewFactory(hookFactories ...factory.ProviderFactory[sqlstore.SQLStoreHook,
 func New(ctx context.Context, providerSettings factory.ProviderSettings, config sqlstore.Config, hooks ...sqlstore.SQLStoreHook) (sqlstore.SQLStore, error) {
 	settings := factory.NewScopedProviderSettings(providerSettings, "github.com/SigNoz/signoz/pkg/sqlitesqlstore")
 
-	sqldb, err := sql.Open("sqlite3", "file:"+config.Sqlite.Path+"?_foreign_keys=true")
+	if strings.EqualFold(config.Sqlite.JournalMode, "wal") {
+		if isNetworkFS, fsType := isNetworkFilesystem(filepath.Dir(config.Sqlite.Path)); isNetworkFS {
+			settings.Logger().WarnContext(ctx, "sqlite wal journal mode is not supported on network filesystems, the database can get corrupted", "path", config.Sqlite.Path, "filesystem", fsType)
+		}
+	}
+
+	sqldb, err := sql.Open("sqlite3", "file:"+config.Sqlite.Path+"?"+dsnParams(config.Sqlite).Encode())
 	if err != nil {
 		return nil, err
 	}
-	settings.Logger().InfoContext(ctx, "connected to sqlite", "path", config.Sqlite.Path)
+	settings.Logger().InfoContext(ctx, "connected to sqlite", "path", config.Sqlite.Path, "journal_mode", config.Sqlite.JournalMode, "synchronous", config.Sqlite.Synchronous, "busy_timeout", config.Sqlite.BusyTimeout.String())
 	sqldb.SetMaxOpenConns(config.Connection.MaxOpenConns)
 
+	vacuumer, err := newVacuumer(settings, sqldb, config.Sqlite.Vacuum)
+	if err != nil {
+		return nil, err
+	}
+
 	return &provider{
 		settings: settings,
 		sqldb:    sqldb,
 		bundb:    sqlstore.NewBunDB(settings, sqldb, sqlitedialect.New(), hooks),
 		sqlxdb:   sqlx.NewDb(sqldb, "sqlite3"),
 		dialect:  new(dialect),
+		vacuumer: vacuumer,
 	}, nil
 }
 
+// dsnParams returns the parameters of the dsn. The driver applies them via PRAGMA every time a connection is opened.
+func dsnParams(config sqlstore.SqliteConfig) url.Values {
+	params := url.Values{}
+	params.Set("_foreign_keys", "true")
+
+	if config.JournalMode != "" {
+		params.Set("_journal_mode", strings.ToUpper(config.JournalMode))
+	}
+
+	if config.Synchronous != "" {
+		params.Set("_synchronous", strings.ToUpper(config.Synchronous))
+	}
+
+	if config.BusyTimeout > 0 {
+		params.Set("_busy_timeout", strconv.FormatInt(config.BusyTimeout.Milliseconds(), 10))
+	}
+
+	return params
+}
+
+// Start runs the periodic vacuum of the database until the provider is stopped.
+func (provider *provider) Start(ctx context.Context) error {
+	return provider.vacuumer.Start(ctx)
+}
+
+func (provider *provider) Stop(ctx context.Context) error {
+	return provider.vacuumer.Stop(ctx)
+}
+
+func (provider *provider) Healthy(ctx context.Context) error {
+	return provider.sqldb.PingContext(ctx)
+}
+
 func (provider *provider) BunDB() *bun.DB {
 	return provider.bundb.DB
 }
@@ -75,22 +125,47 @@ func (provider *provider) BunDBCtx(ctx context.Context) bun.IDB {
 	return provider.bundb.BunDBCtx(ctx)
 }
 
+func (provider *provider) ReadDB(ctx context.Context) bun.IDB {
+	return provider.bundb.BunDBCtx(ctx)
+}
+
 func (provider *provider) RunInTxCtx(ctx context.Context, opts *sql.TxOptions, cb func(ctx context.Context) error) error {
 	return provider.bundb.RunInTxCtx(ctx, opts, cb)
 }
 
 func (provider *provider) WrapNotFoundErrf(err error, code errors.Code, format string, args ...any) error {
-	if err == sql.ErrNoRows {
-		return errors.Wrapf(err, errors.TypeNotFound, code, format, args...)
+	if normalizedErr := normalizeErr(err); sqlstore.IsNotFoundError(normalizedErr) {
+		return errors.Wrapf(normalizedErr, errors.TypeNotFound, code, format, args...)
 	}
 
 	return err
 }
 
 func (provider *provider) WrapAlreadyExistsErrf(err error, code errors.Code, format string, args ...any) error {
-	if sqlite3Err, ok := err.(sqlite3.Error); ok {
-		if sqlite3Err.ExtendedCode == sqlite3.ErrConstraintUnique {
-			return errors.Wrapf(err, errors.TypeAlreadyExists, code, format, args...)
+	if normalizedErr := normalizeErr(err); sqlstore.IsConstraintUniqueError(normalizedErr) {
+		return errors.Wrapf(normalizedErr, errors.TypeAlreadyExists, code, format, args...)
+	}
+
+	return err
+}
+
+func (provider *provider) WrapErrf(err error, code errors.Code, format string, args ...any) error {
+	return sqlstore.WrapErrf(normalizeErr(err), code, format, args...)
+}
+
+// normalizeErr maps sqlite specific errors to the errors of sqlstore.
+func normalizeErr(err error) error {
+	if errors.Is(err, sql.ErrNoRows) {
+		return sqlstore.NewNotFoundErr(err)
+	}
+
+	var sqlite3Err sqlite3.Error
+	if errors.As(err, &sqlite3Err) {
+		switch sqlite3Err.ExtendedCode {
+		case sqlite3.ErrConstraintUnique, sqlite3.ErrConstraintPrimaryKey:
+			return sqlstore.NewConstraintUniqueErr(err)
+		case sqlite3.ErrConstraintForeignKey:
+			return sqlstore.NewConstraintForeignKeyErr(err)
 		}
 	}
 
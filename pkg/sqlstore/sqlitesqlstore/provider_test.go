@@ -0,0 +1,110 @@
+package sqlitesqlstore
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/factory/factorytest"
+	"github.com/SigNoz/signoz/pkg/sqlstore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewWithPragmas(t *testing.T) {
+	ctx := context.Background()
+	store, err := New(ctx, factorytest.NewSettings(), sqlstore.Config{
+		Provider:   "sqlite",
+		Connection: sqlstore.ConnectionConfig{MaxOpenConns: 1},
+		Sqlite: sqlstore.SqliteConfig{
+			Path:        filepath.Join(t.TempDir(), "signoz.db"),
+			JournalMode: "wal",
+			Synchronous: "normal",
+			BusyTimeout: 5 * time.Second,
+		},
+	})
+	require.NoError(t, err)
+
+	var journalMode string
+	require.NoError(t, store.SQLDB().QueryRowContext(ctx, "PRAGMA journal_mode").Scan(&journalMode))
+	assert.Equal(t, "wal", journalMode)
+
+	// normal is 1
+	var synchronous int
+	require.NoError(t, store.SQLDB().QueryRowContext(ctx, "PRAGMA synchronous").Scan(&synchronous))
+	assert.Equal(t, 1, synchronous)
+
+	var busyTimeout int
+	require.NoError(t, store.SQLDB().QueryRowContext(ctx, "PRAGMA busy_timeout").Scan(&busyTimeout))
+	assert.Equal(t, 5000, busyTimeout)
+
+	var foreignKeys bool
+	require.NoError(t, store.SQLDB().QueryRowContext(ctx, "PRAGMA foreign_keys").Scan(&foreignKeys))
+	assert.True(t, foreignKeys)
+}
+
+func TestWrapErrf(t *testing.T) {
+	ctx := context.Background()
+	store, err := New(ctx, factorytest.NewSettings(), sqlstore.Config{
+		Provider:   "sqlite",
+		Connection: sqlstore.ConnectionConfig{MaxOpenConns: 1},
+		Sqlite: sqlstore.SqliteConfig{
+			Path:        filepath.Join(t.TempDir(), "signoz.db"),
+			JournalMode: "delete",
+			Synchronous: "full",
+			BusyTimeout: time.Second,
+		},
+	})
+	require.NoError(t, err)
+
+	_, err = store.SQLDB().ExecContext(ctx, `CREATE TABLE parent (id TEXT PRIMARY KEY, name TEXT UNIQUE)`)
+	require.NoError(t, err)
+	_, err = store.SQLDB().ExecContext(ctx, `CREATE TABLE child (id TEXT PRIMARY KEY, parent_id TEXT REFERENCES parent(id))`)
+	require.NoError(t, err)
+	_, err = store.SQLDB().ExecContext(ctx, `INSERT INTO parent (id, name) VALUES ('1', 'one')`)
+	require.NoError(t, err)
+
+	code := errors.MustNewCode("test")
+
+	t.Run("Unique", func(t *testing.T) {
+		_, err := store.SQLDB().ExecContext(ctx, `INSERT INTO parent (id, name) VALUES ('2', 'one')`)
+		require.Error(t, err)
+
+		wrapped := store.WrapErrf(err, code, "parent already exists")
+		assert.True(t, sqlstore.IsConstraintError(wrapped))
+		assert.True(t, sqlstore.IsConstraintUniqueError(wrapped))
+		assert.True(t, errors.Ast(wrapped, errors.TypeAlreadyExists))
+		assert.True(t, errors.Asc(wrapped, code))
+		assert.True(t, sqlstore.IsConstraintUniqueError(store.WrapAlreadyExistsErrf(err, code, "parent already exists")))
+	})
+
+	t.Run("PrimaryKey", func(t *testing.T) {
+		_, err := store.SQLDB().ExecContext(ctx, `INSERT INTO parent (id, name) VALUES ('1', 'two')`)
+		require.Error(t, err)
+
+		assert.True(t, sqlstore.IsConstraintUniqueError(store.WrapErrf(err, code, "parent already exists")))
+	})
+
+	t.Run("ForeignKey", func(t *testing.T) {
+		_, err := store.SQLDB().ExecContext(ctx, `INSERT INTO child (id, parent_id) VALUES ('1', '3')`)
+		require.Error(t, err)
+
+		wrapped := store.WrapErrf(err, code, "parent does not exist")
+		assert.True(t, sqlstore.IsConstraintError(wrapped))
+		assert.True(t, sqlstore.IsConstraintForeignKeyError(wrapped))
+		assert.True(t, errors.Ast(wrapped, errors.TypeInvalidInput))
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		var name string
+		err := store.SQLDB().QueryRowContext(ctx, `SELECT name FROM parent WHERE id = '3'`).Scan(&name)
+		require.Error(t, err)
+
+		wrapped := store.WrapErrf(err, code, "parent not found")
+		assert.False(t, sqlstore.IsConstraintError(wrapped))
+		assert.True(t, sqlstore.IsNotFoundError(wrapped))
+		assert.True(t, errors.Ast(wrapped, errors.TypeNotFound))
+	})
+}
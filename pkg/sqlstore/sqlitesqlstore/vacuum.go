@@ -0,0 +1,165 @@
+package sqlitesqlstore
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/factory"
+	"github.com/SigNoz/signoz/pkg/sqlstore"
+	"go.opentelemetry.io/otel/metric"
+)
+
+const (
+	// vacuumIdleCheckInterval is the interval at which a vacuum which is due checks whether the database is idle.
+	vacuumIdleCheckInterval = time.Minute
+	// autoVacuumIncremental is the value of PRAGMA auto_vacuum for incremental vacuums.
+	autoVacuumIncremental = 2
+)
+
+// vacuumer periodically vacuums the database so that the space of the deleted rows is returned to the
+// filesystem. Sqlite only reuses the free pages of a database, the file never shrinks otherwise.
+type vacuumer struct {
+	settings  factory.ScopedProviderSettings
+	sqldb     *sql.DB
+	config    sqlstore.SqliteVacuumConfig
+	reclaimed metric.Int64Counter
+	stopC     chan struct{}
+}
+
+func newVacuumer(settings factory.ScopedProviderSettings, sqldb *sql.DB, config sqlstore.SqliteVacuumConfig) (*vacuumer, error) {
+	reclaimed, err := settings.Meter().Int64Counter("signoz.sqlstore.sqlite.vacuum.reclaimed", metric.WithDescription("Number of bytes reclaimed by the vacuums of the sqlite database."), metric.WithUnit("By"))
+	if err != nil {
+		return nil, err
+	}
+
+	return &vacuumer{
+		settings:  settings,
+		sqldb:     sqldb,
+		config:    config,
+		reclaimed: reclaimed,
+		stopC:     make(chan struct{}),
+	}, nil
+}
+
+func (vacuumer *vacuumer) Start(ctx context.Context) error {
+	if !vacuumer.config.Enabled {
+		<-vacuumer.stopC
+		return nil
+	}
+
+	ticker := time.NewTicker(min(vacuumer.config.Interval, vacuumIdleCheckInterval))
+	defer ticker.Stop()
+
+	next := time.Now().Add(vacuumer.config.Interval)
+	for {
+		select {
+		case <-vacuumer.stopC:
+			return nil
+		case now := <-ticker.C:
+			if now.Before(next) {
+				continue
+			}
+
+			// A vacuum locks the whole database, it waits until no connection is in use.
+			if !vacuumer.idle() {
+				vacuumer.settings.Logger().DebugContext(ctx, "sqlite database is not idle, postponing the vacuum")
+				continue
+			}
+
+			if _, err := vacuumer.vacuum(ctx); err != nil {
+				vacuumer.settings.Logger().ErrorContext(ctx, "failed to vacuum the sqlite database", "error", err)
+			}
+			next = now.Add(vacuumer.config.Interval)
+		}
+	}
+}
+
+func (vacuumer *vacuumer) Stop(ctx context.Context) error {
+	close(vacuumer.stopC)
+	return nil
+}
+
+func (vacuumer *vacuumer) idle() bool {
+	return vacuumer.sqldb.Stats().InUse == 0
+}
+
+// vacuum vacuums the database if its free pages reach the configured threshold and returns the number of bytes
+// reclaimed. It is only called by the loop of Start, hence the vacuums never overlap.
+func (vacuumer *vacuumer) vacuum(ctx context.Context) (int64, error) {
+	// The pragmas apply to the connection which runs them, so the whole vacuum runs on a single connection.
+	conn, err := vacuumer.sqldb.Conn(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Close()
+
+	var pageSize, pageCount, freelistCount int64
+	if err := conn.QueryRowContext(ctx, "PRAGMA page_size").Scan(&pageSize); err != nil {
+		return 0, err
+	}
+
+	if err := conn.QueryRowContext(ctx, "PRAGMA page_count").Scan(&pageCount); err != nil {
+		return 0, err
+	}
+
+	if err := conn.QueryRowContext(ctx, "PRAGMA freelist_count").Scan(&freelistCount); err != nil {
+		return 0, err
+	}
+
+	if freelistCount*pageSize < vacuumer.config.MinReclaimableBytes {
+		vacuumer.settings.Logger().DebugContext(ctx, "skipping the vacuum of the sqlite database, not enough free pages", "reclaimable_bytes", freelistCount*pageSize, "min_reclaimable_bytes", vacuumer.config.MinReclaimableBytes)
+		return 0, nil
+	}
+
+	start := time.Now()
+	if err := vacuumer.run(ctx, conn); err != nil {
+		return 0, err
+	}
+
+	var vacuumedPageCount int64
+	if err := conn.QueryRowContext(ctx, "PRAGMA page_count").Scan(&vacuumedPageCount); err != nil {
+		return 0, err
+	}
+
+	reclaimed := max(pageCount-vacuumedPageCount, 0) * pageSize
+	vacuumer.reclaimed.Add(ctx, reclaimed)
+	vacuumer.settings.Logger().InfoContext(ctx, "vacuumed the sqlite database", "mode", vacuumer.config.Mode, "reclaimed_bytes", reclaimed, "duration", time.Since(start).String())
+
+	return reclaimed, nil
+}
+
+func (vacuumer *vacuumer) run(ctx context.Context, conn *sql.Conn) error {
+	if !strings.EqualFold(vacuumer.config.Mode, "incremental") {
+		_, err := conn.ExecContext(ctx, "VACUUM")
+		return err
+	}
+
+	var autoVacuum int
+	if err := conn.QueryRowContext(ctx, "PRAGMA auto_vacuum").Scan(&autoVacuum); err != nil {
+		return err
+	}
+
+	// The auto vacuum mode of an existing database only changes once it is rebuilt by a full vacuum.
+	if autoVacuum != autoVacuumIncremental {
+		if _, err := conn.ExecContext(ctx, "PRAGMA auto_vacuum = INCREMENTAL"); err != nil {
+			return err
+		}
+
+		_, err := conn.ExecContext(ctx, "VACUUM")
+		return err
+	}
+
+	// Every step of the pragma releases a single page, so its rows are read until the end.
+	rows, err := conn.QueryContext(ctx, "PRAGMA incremental_vacuum")
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+	}
+
+	return rows.Err()
+}
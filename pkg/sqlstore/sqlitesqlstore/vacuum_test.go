@@ -0,0 +1,99 @@
+package sqlitesqlstore
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/factory/factorytest"
+	"github.com/SigNoz/signoz/pkg/sqlstore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestVacuumer(t *testing.T, mode string, minReclaimableBytes int64) *vacuumer {
+	ctx := context.Background()
+	store, err := New(ctx, factorytest.NewSettings(), sqlstore.Config{
+		Provider:   "sqlite",
+		Connection: sqlstore.ConnectionConfig{MaxOpenConns: 1},
+		Sqlite: sqlstore.SqliteConfig{
+			Path:        filepath.Join(t.TempDir(), "signoz.db"),
+			JournalMode: "delete",
+			Synchronous: "full",
+			BusyTimeout: time.Second,
+			Vacuum: sqlstore.SqliteVacuumConfig{
+				Enabled:             true,
+				Mode:                mode,
+				Interval:            time.Hour,
+				MinReclaimableBytes: minReclaimableBytes,
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	// Leave about 1MB of free pages behind.
+	_, err = store.SQLDB().ExecContext(ctx, "CREATE TABLE dashboard (id INTEGER PRIMARY KEY, data TEXT)")
+	require.NoError(t, err)
+	for i := 0; i < 256; i++ {
+		_, err = store.SQLDB().ExecContext(ctx, "INSERT INTO dashboard (data) VALUES (?)", strings.Repeat("x", 4096))
+		require.NoError(t, err)
+	}
+	_, err = store.SQLDB().ExecContext(ctx, "DELETE FROM dashboard")
+	require.NoError(t, err)
+
+	return store.(*provider).vacuumer
+}
+
+func freelistCount(t *testing.T, vacuumer *vacuumer) int64 {
+	var count int64
+	require.NoError(t, vacuumer.sqldb.QueryRowContext(context.Background(), "PRAGMA freelist_count").Scan(&count))
+	return count
+}
+
+func TestVacuum(t *testing.T) {
+	for _, mode := range []string{"full", "incremental"} {
+		t.Run(mode, func(t *testing.T) {
+			vacuumer := newTestVacuumer(t, mode, 0)
+			require.NotZero(t, freelistCount(t, vacuumer))
+
+			reclaimed, err := vacuumer.vacuum(context.Background())
+			require.NoError(t, err)
+			assert.Greater(t, reclaimed, int64(1024*1024))
+			assert.Zero(t, freelistCount(t, vacuumer))
+		})
+	}
+}
+
+func TestVacuumIncrementalAfterConversion(t *testing.T) {
+	vacuumer := newTestVacuumer(t, "incremental", 0)
+	_, err := vacuumer.vacuum(context.Background())
+	require.NoError(t, err)
+
+	var autoVacuum int
+	require.NoError(t, vacuumer.sqldb.QueryRowContext(context.Background(), "PRAGMA auto_vacuum").Scan(&autoVacuum))
+	assert.Equal(t, autoVacuumIncremental, autoVacuum)
+
+	// The free pages are now released by incremental vacuums.
+	_, err = vacuumer.sqldb.ExecContext(context.Background(), "INSERT INTO dashboard (data) VALUES (?)", strings.Repeat("x", 64*4096))
+	require.NoError(t, err)
+	_, err = vacuumer.sqldb.ExecContext(context.Background(), "DELETE FROM dashboard")
+	require.NoError(t, err)
+	require.NotZero(t, freelistCount(t, vacuumer))
+
+	reclaimed, err := vacuumer.vacuum(context.Background())
+	require.NoError(t, err)
+	assert.Greater(t, reclaimed, int64(0))
+	assert.Zero(t, freelistCount(t, vacuumer))
+}
+
+func TestVacuumBelowThreshold(t *testing.T) {
+	vacuumer := newTestVacuumer(t, "full", 1024*1024*1024)
+	count := freelistCount(t, vacuumer)
+
+	reclaimed, err := vacuumer.vacuum(context.Background())
+	require.NoError(t, err)
+	assert.Zero(t, reclaimed)
+	assert.Equal(t, count, freelistCount(t, vacuumer))
+}
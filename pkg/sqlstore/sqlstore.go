@@ -3,6 +3,7 @@ package sqlstore
 import (
 	"context"
 	"database/sql"
+	"io"
 
 	"github.com/SigNoz/signoz/pkg/errors"
 	"github.com/jmoiron/sqlx"
@@ -32,11 +33,20 @@ type SQLStore interface {
 	// If a transaction is present in the context, it will be used. Otherwise, the default will be used.
 	BunDBCtx(ctx context.Context) bun.IDB
 
+	// ReadDB returns an instance of bun.IDB to be used for read only queries. If read replicas are configured,
+	// a healthy replica will be used. The primary will be used if a transaction is present in the context, if the
+	// context was created with NewContextWithPrimary or if none of the replicas are healthy.
+	ReadDB(ctx context.Context) bun.IDB
+
 	// WrapNotFoundErrf wraps the given error with the given message and returns it.
 	WrapNotFoundErrf(err error, code errors.Code, format string, args ...any) error
 
 	// WrapAlreadyExistsErrf wraps the given error with the given message and returns it.
 	WrapAlreadyExistsErrf(err error, code errors.Code, format string, args ...any) error
+
+	// WrapErrf normalizes the driver specific error into a unique constraint, a foreign key constraint or a not found
+	// error and wraps it with the given message. Errors which cannot be normalized are returned as is.
+	WrapErrf(err error, code errors.Code, format string, args ...any) error
 }
 
 type SQLStoreHook interface {
@@ -89,4 +99,22 @@ type SQLDialect interface {
 	// Toggles foreign key constraint for the given database. This makes sense only for sqlite. This cannot take a transaction as an argument and needs to take the db
 	// as an argument.
 	ToggleForeignKeyConstraint(ctx context.Context, bun *bun.DB, enable bool) error
+
+	// Tries to acquire the advisory lock of the given key without waiting. The lock is held across all the processes
+	// sharing the database until unlock is called. This cannot take a transaction as an argument as the lock must
+	// outlive it.
+	TryAdvisoryLock(ctx context.Context, bun *bun.DB, key string) (unlock func(context.Context) error, acquired bool, err error)
+
+	// Returns the tables of the database ordered so that the tables referenced by the foreign keys of a table come
+	// before it.
+	BackupTables(ctx context.Context, bun bun.IDB) ([]string, error)
+
+	// Writes a consistent backup of the data of the given tables to the writer. This cannot take a transaction as an
+	// argument as the backup reads a snapshot of its own.
+	Backup(ctx context.Context, bun *bun.DB, tables []string, w io.Writer) error
+
+	// Replaces the data of the given tables by the data of a backup written by Backup in a single transaction, which
+	// is rolled back when dryRun is true. This cannot take a transaction as an argument as the restore needs a
+	// connection of its own.
+	Restore(ctx context.Context, bun *bun.DB, tables []string, r io.Reader, dryRun bool) error
 }
@@ -0,0 +1,88 @@
+package sqlstoretest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/sqlstore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/uptrace/bun"
+)
+
+type conformanceModel struct {
+	bun.BaseModel `bun:"table:conformance"`
+
+	ID   string `bun:"id,pk"`
+	Name string `bun:"name"`
+}
+
+// RunConformance runs the tests every implementation of sqlstore.SQLStore running the queries must pass against the
+// stores returned by newStore, which must return an empty database on every call.
+func RunConformance(t *testing.T, newStore func(t *testing.T) sqlstore.SQLStore) {
+	ctx := context.Background()
+
+	t.Run("Seed", func(t *testing.T) {
+		store := newStore(t)
+		require.NoError(t, Seed(ctx, store, &conformanceModel{ID: "1", Name: "one"}, &conformanceModel{ID: "2", Name: "two"}))
+
+		var models []*conformanceModel
+		require.NoError(t, store.ReadDB(ctx).NewSelect().Model(&models).Order("id").Scan(ctx))
+		require.Len(t, models, 2)
+		assert.Equal(t, "one", models[0].Name)
+	})
+
+	t.Run("RunInTxCtxCommits", func(t *testing.T) {
+		store := newStore(t)
+		require.NoError(t, Seed(ctx, store, &conformanceModel{ID: "1", Name: "one"}))
+
+		err := store.RunInTxCtx(ctx, nil, func(ctx context.Context) error {
+			_, err := store.BunDBCtx(ctx).NewInsert().Model(&conformanceModel{ID: "2", Name: "two"}).Exec(ctx)
+			return err
+		})
+		require.NoError(t, err)
+
+		count, err := store.BunDB().NewSelect().Model(new(conformanceModel)).Count(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 2, count)
+	})
+
+	t.Run("RunInTxCtxRollsBack", func(t *testing.T) {
+		store := newStore(t)
+		require.NoError(t, Seed(ctx, store, &conformanceModel{ID: "1", Name: "one"}))
+
+		err := store.RunInTxCtx(ctx, nil, func(ctx context.Context) error {
+			if _, err := store.BunDBCtx(ctx).NewInsert().Model(&conformanceModel{ID: "2", Name: "two"}).Exec(ctx); err != nil {
+				return err
+			}
+
+			return errors.New(errors.TypeInternal, errors.CodeInternal, "rollback")
+		})
+		require.Error(t, err)
+
+		count, err := store.BunDB().NewSelect().Model(new(conformanceModel)).Count(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, count)
+	})
+
+	t.Run("WrapErrfNotFound", func(t *testing.T) {
+		store := newStore(t)
+		require.NoError(t, Seed(ctx, store, &conformanceModel{ID: "1", Name: "one"}))
+
+		err := store.BunDB().NewSelect().Model(new(conformanceModel)).Where("id = ?", "missing").Scan(ctx)
+		err = store.WrapErrf(err, errors.CodeNotFound, "model not found")
+		assert.True(t, sqlstore.IsNotFoundError(err), "expected a not found error, got %v", err)
+		assert.True(t, errors.Ast(err, errors.TypeNotFound), "expected a not found error, got %v", err)
+	})
+
+	t.Run("WrapErrfConstraintUnique", func(t *testing.T) {
+		store := newStore(t)
+		require.NoError(t, Seed(ctx, store, &conformanceModel{ID: "1", Name: "one"}))
+
+		_, err := store.BunDB().NewInsert().Model(&conformanceModel{ID: "1", Name: "one"}).Exec(ctx)
+		err = store.WrapErrf(err, errors.CodeAlreadyExists, "model already exists")
+		assert.True(t, sqlstore.IsConstraintUniqueError(err), "expected a unique constraint error, got %v", err)
+		assert.True(t, errors.Ast(err, errors.TypeAlreadyExists), "expected an already exists error, got %v", err)
+	})
+}
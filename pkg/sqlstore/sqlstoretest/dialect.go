@@ -2,6 +2,7 @@ package sqlstoretest
 
 import (
 	"context"
+	"io"
 
 	"github.com/uptrace/bun"
 )
@@ -68,3 +69,19 @@ func (dialect *dialect) TableExists(ctx context.Context, bun bun.IDB, table inte
 func (dialect *dialect) ToggleForeignKeyConstraint(ctx context.Context, bun *bun.DB, enable bool) error {
 	return nil
 }
+
+func (dialect *dialect) TryAdvisoryLock(ctx context.Context, bun *bun.DB, key string) (func(context.Context) error, bool, error) {
+	return func(context.Context) error { return nil }, true, nil
+}
+
+func (dialect *dialect) BackupTables(ctx context.Context, bun bun.IDB) ([]string, error) {
+	return []string{}, nil
+}
+
+func (dialect *dialect) Backup(ctx context.Context, bun *bun.DB, tables []string, w io.Writer) error {
+	return nil
+}
+
+func (dialect *dialect) Restore(ctx context.Context, bun *bun.DB, tables []string, r io.Reader, dryRun bool) error {
+	return nil
+}
@@ -4,12 +4,14 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"testing"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/SigNoz/signoz/pkg/errors"
 	"github.com/SigNoz/signoz/pkg/sqlstore"
 	"github.com/jmoiron/sqlx"
 	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/dialect/pgdialect"
 	"github.com/uptrace/bun/dialect/sqlitedialect"
 )
 
@@ -35,6 +37,9 @@ func New(config sqlstore.Config, matcher sqlmock.QueryMatcher) *Provider {
 	if config.Provider == "sqlite" {
 		bunDB = bun.NewDB(db, sqlitedialect.New())
 		sqlxDB = sqlx.NewDb(db, "sqlite3")
+	} else if config.Provider == "postgres" {
+		bunDB = bun.NewDB(db, pgdialect.New())
+		sqlxDB = sqlx.NewDb(db, "postgres")
 	} else {
 		panic(fmt.Errorf("provider %q is not supported by mockSQLStore", config.Provider))
 	}
@@ -64,6 +69,15 @@ func (provider *Provider) Mock() sqlmock.Sqlmock {
 	return provider.mock
 }
 
+// AssertExpectations fails the test when the expectations set on the mock were not met.
+func (provider *Provider) AssertExpectations(t testing.TB) {
+	t.Helper()
+
+	if err := provider.mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
 func (provider *Provider) Dialect() sqlstore.SQLDialect {
 	return provider.dialect
 }
@@ -72,6 +86,10 @@ func (provider *Provider) BunDBCtx(ctx context.Context) bun.IDB {
 	return provider.bunDB
 }
 
+func (provider *Provider) ReadDB(ctx context.Context) bun.IDB {
+	return provider.bunDB
+}
+
 func (provider *Provider) RunInTxCtx(ctx context.Context, opts *sql.TxOptions, cb func(ctx context.Context) error) error {
 	return cb(ctx)
 }
@@ -83,3 +101,7 @@ func (provider *Provider) WrapNotFoundErrf(err error, code errors.Code, format s
 func (provider *Provider) WrapAlreadyExistsErrf(err error, code errors.Code, format string, args ...any) error {
 	return fmt.Errorf(format, args...)
 }
+
+func (provider *Provider) WrapErrf(err error, code errors.Code, format string, args ...any) error {
+	return fmt.Errorf(format, args...)
+}
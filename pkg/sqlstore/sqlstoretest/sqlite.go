@@ -0,0 +1,54 @@
+package sqlstoretest
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/factory/factorytest"
+	"github.com/SigNoz/signoz/pkg/sqlstore"
+	"github.com/SigNoz/signoz/pkg/sqlstore/sqlitesqlstore"
+)
+
+// NewSQLite returns a sqlite sqlstore backed by a database in a temporary directory of the test, for the tests which
+// need the queries to run rather than to match the expectations of a mock. The database is removed with the directory
+// once the test is over.
+func NewSQLite(t testing.TB) sqlstore.SQLStore {
+	t.Helper()
+
+	store, err := sqlitesqlstore.New(context.Background(), factorytest.NewSettings(), sqlstore.Config{
+		Provider:   "sqlite",
+		Connection: sqlstore.ConnectionConfig{MaxOpenConns: 1},
+		Sqlite: sqlstore.SqliteConfig{
+			Path:        filepath.Join(t.TempDir(), "signoz.db"),
+			JournalMode: "delete",
+			Synchronous: "full",
+			BusyTimeout: time.Second,
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to create the sqlite sqlstore: %v", err)
+	}
+
+	t.Cleanup(func() {
+		_ = store.SQLDB().Close()
+	})
+
+	return store
+}
+
+// Seed creates the tables of the models, which must inherit bun.BaseModel, when they do not exist and inserts them.
+func Seed(ctx context.Context, store sqlstore.SQLStore, models ...any) error {
+	for _, model := range models {
+		if _, err := store.BunDB().NewCreateTable().Model(model).IfNotExists().Exec(ctx); err != nil {
+			return err
+		}
+
+		if _, err := store.BunDB().NewInsert().Model(model).Exec(ctx); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
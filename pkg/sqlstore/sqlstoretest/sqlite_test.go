@@ -0,0 +1,13 @@
+package sqlstoretest
+
+import (
+	"testing"
+
+	"github.com/SigNoz/signoz/pkg/sqlstore"
+)
+
+func TestSQLiteConformance(t *testing.T) {
+	RunConformance(t, func(t *testing.T) sqlstore.SQLStore {
+		return NewSQLite(t)
+	})
+}
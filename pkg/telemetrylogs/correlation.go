@@ -0,0 +1,81 @@
+package telemetrylogs
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/querybuilder"
+	"github.com/SigNoz/signoz/pkg/telemetrystore"
+)
+
+const (
+	// DefaultCorrelatedLogsLimit is the number of logs returned by LogsByTraceID when no limit is given.
+	DefaultCorrelatedLogsLimit int = 1000
+)
+
+// CorrelatedLog is a log emitted within a span of a trace.
+type CorrelatedLog struct {
+	Timestamp    time.Time `json:"timestamp"`
+	ID           string    `json:"id"`
+	TraceID      string    `json:"traceId"`
+	SpanID       string    `json:"spanId"`
+	SeverityText string    `json:"severityText"`
+	Body         string    `json:"body"`
+}
+
+// LogsByTraceID returns the logs of the trace emitted within the time window, oldest first. The window is usually the
+// window of the trace, widened by the caller to account for the logs emitted around its spans.
+func LogsByTraceID(ctx context.Context, telemetryStore telemetrystore.TelemetryStore, traceID string, start time.Time, end time.Time, limit int) ([]*CorrelatedLog, error) {
+	if traceID == "" {
+		return nil, errors.New(errors.TypeInvalidInput, errors.CodeInvalidInput, "trace id must not be empty")
+	}
+
+	if !start.Before(end) {
+		return nil, errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "start (%s) must be before end (%s)", start, end)
+	}
+
+	if limit <= 0 {
+		limit = DefaultCorrelatedLogsLimit
+	}
+
+	startNs, endNs := uint64(start.UnixNano()), uint64(end.UnixNano())
+	query := fmt.Sprintf(
+		"SELECT timestamp, id, trace_id, span_id, severity_text, body FROM %s.%s WHERE trace_id = ? AND timestamp >= ? AND timestamp <= ? AND ts_bucket_start >= ? AND ts_bucket_start <= ? ORDER BY timestamp ASC LIMIT ?",
+		DBName, LogsV2TableName,
+	)
+
+	rows, err := telemetryStore.ClickhouseDB().Query(
+		ctx,
+		query,
+		traceID,
+		startNs,
+		endNs,
+		startNs/querybuilder.NsToSeconds-querybuilder.BucketAdjustment,
+		endNs/querybuilder.NsToSeconds,
+		limit,
+	)
+	if err != nil {
+		return nil, errors.Wrapf(err, errors.TypeInternal, errors.CodeInternal, "failed to fetch the logs of trace %s", traceID)
+	}
+	defer rows.Close()
+
+	logs := make([]*CorrelatedLog, 0)
+	for rows.Next() {
+		var timestamp uint64
+		log := new(CorrelatedLog)
+		if err := rows.Scan(&timestamp, &log.ID, &log.TraceID, &log.SpanID, &log.SeverityText, &log.Body); err != nil {
+			return nil, errors.Wrapf(err, errors.TypeInternal, errors.CodeInternal, "failed to scan the logs of trace %s", traceID)
+		}
+
+		log.Timestamp = time.Unix(0, int64(timestamp)).UTC()
+		logs = append(logs, log)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrapf(err, errors.TypeInternal, errors.CodeInternal, "failed to fetch the logs of trace %s", traceID)
+	}
+
+	return logs, nil
+}
@@ -0,0 +1,54 @@
+package telemetrylogs
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/SigNoz/signoz/pkg/telemetrystore"
+	"github.com/SigNoz/signoz/pkg/telemetrystore/telemetrystoretest"
+	cmock "github.com/srikanthccv/ClickHouse-go-mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogsByTraceID(t *testing.T) {
+	telemetryStore := telemetrystoretest.New(telemetrystore.Config{Provider: "clickhouse"}, sqlmock.QueryMatcherEqual)
+
+	start := time.Unix(1700000000, 0)
+	end := start.Add(time.Hour)
+
+	cols := []cmock.ColumnType{
+		{Name: "timestamp", Type: "UInt64"},
+		{Name: "id", Type: "String"},
+		{Name: "trace_id", Type: "String"},
+		{Name: "span_id", Type: "String"},
+		{Name: "severity_text", Type: "String"},
+		{Name: "body", Type: "String"},
+	}
+	timestamp, id, traceID, spanID, severityText, body := uint64(start.Add(time.Minute).UnixNano()), "log-1", "4bf92f3577b34da6a3ce929d0e0e4736", "00f067aa0ba902b7", "ERROR", "failed to charge card"
+
+	telemetryStore.Mock().
+		ExpectQuery("SELECT timestamp, id, trace_id, span_id, severity_text, body FROM signoz_logs.distributed_logs_v2 WHERE trace_id = ? AND timestamp >= ? AND timestamp <= ? AND ts_bucket_start >= ? AND ts_bucket_start <= ? ORDER BY timestamp ASC LIMIT ?").
+		WithArgs(traceID, uint64(start.UnixNano()), uint64(end.UnixNano()), uint64(1700000000-1800), uint64(1700003600), DefaultCorrelatedLogsLimit).
+		WillReturnRows(cmock.NewRows(cols, [][]any{{&timestamp, &id, &traceID, &spanID, &severityText, &body}}))
+
+	logs, err := LogsByTraceID(context.Background(), telemetryStore, traceID, start, end, 0)
+	require.NoError(t, err)
+	assert.Equal(t, []*CorrelatedLog{{
+		Timestamp:    start.Add(time.Minute).UTC(),
+		ID:           "log-1",
+		TraceID:      traceID,
+		SpanID:       spanID,
+		SeverityText: "ERROR",
+		Body:         "failed to charge card",
+	}}, logs)
+	require.NoError(t, telemetryStore.Mock().ExpectationsWereMet())
+
+	_, err = LogsByTraceID(context.Background(), telemetryStore, "", start, end, 0)
+	assert.Error(t, err)
+
+	_, err = LogsByTraceID(context.Background(), telemetryStore, traceID, end, start, 0)
+	assert.Error(t, err)
+}
@@ -0,0 +1,20 @@
+package telemetrylogs
+
+import (
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/telemetrystore"
+)
+
+// bucket is the duration of the time buckets of the logs and of their resources.
+const bucket = 30 * time.Minute
+
+// DeletionTables are the tables from which the logs of the resources matching the label matchers of a deletion are
+// deleted. The matchers match the attributes of the resources, the logs are deleted by time bucket.
+var DeletionTables = telemetrystore.DeletionTables{
+	Series: telemetrystore.DeletionTable{Name: DBName + "." + LogsResourceV2TableName, TimeColumn: "seen_at_ts_bucket_start", TimeUnit: time.Second, Bucket: bucket},
+	Local: []telemetrystore.DeletionTable{
+		{Name: DBName + "." + LogsV2LocalTableName, FingerprintColumn: "resource_fingerprint", TimeColumn: "ts_bucket_start", TimeUnit: time.Second, Bucket: bucket},
+		{Name: DBName + "." + LogsResourceV2LocalTableName, TimeColumn: "seen_at_ts_bucket_start", TimeUnit: time.Second, Bucket: bucket},
+	},
+}
@@ -163,9 +163,14 @@ func (b *logQueryStatementBuilder) buildListQuery(
 		return nil, err
 	}
 
+	order, err := addCursorCondition(sb, query)
+	if err != nil {
+		return nil, err
+	}
+
 	// Add order by
-	for _, orderBy := range query.Order {
-		sb.OrderBy(fmt.Sprintf("`%s` %s", orderBy.Key.Name, orderBy.Direction))
+	for _, orderBy := range order {
+		sb.OrderBy(fmt.Sprintf("`%s` %s", orderBy.Key.Name, orderBy.Direction.StringValue()))
 	}
 
 	// Add limit and offset
@@ -448,6 +453,30 @@ func (b *logQueryStatementBuilder) addFilterCondition(
 	return warnings, nil
 }
 
+// addCursorCondition selects the logs strictly after the cursor in the order of the list, or before it for the cursor
+// of a previous page, and returns the order in which they are selected. The millisecond cursors are applied by the
+// querier to the time range instead.
+func addCursorCondition(sb *sqlbuilder.SelectBuilder, query qbtypes.QueryBuilderQuery[qbtypes.LogAggregation]) ([]qbtypes.OrderBy, error) {
+	cursor, err := qbtypes.NewLogsCursor(strings.TrimSpace(query.Cursor), query.Order)
+	if err != nil {
+		return nil, err
+	}
+
+	if cursor == nil {
+		return query.Order, nil
+	}
+
+	order := cursor.Order(query.Order)
+	op := ">"
+	if order[0].Direction == qbtypes.OrderDirectionDesc {
+		op = "<"
+	}
+
+	sb.Where(fmt.Sprintf("(timestamp, id) %s (%s, %s)", op, sb.Var(cursor.Timestamp), sb.Var(cursor.ID)))
+
+	return order, nil
+}
+
 func aggOrderBy(k qbtypes.OrderBy, q qbtypes.QueryBuilderQuery[qbtypes.LogAggregation]) (int, bool) {
 	for i, agg := range q.Aggregations {
 		if k.Key.Name == agg.Alias ||
@@ -2,6 +2,7 @@ package telemetrylogs
 
 import (
 	"context"
+	"encoding/base64"
 	"testing"
 	"time"
 
@@ -111,3 +112,106 @@ func TestStatementBuilder(t *testing.T) {
 		})
 	}
 }
+
+func TestStatementBuilderListCursor(t *testing.T) {
+	order := []qbtypes.OrderBy{
+		{Key: qbtypes.OrderByKey{TelemetryFieldKey: telemetrytypes.TelemetryFieldKey{Name: "timestamp"}}, Direction: qbtypes.OrderDirectionDesc},
+		{Key: qbtypes.OrderByKey{TelemetryFieldKey: telemetrytypes.TelemetryFieldKey{Name: "id"}}, Direction: qbtypes.OrderDirectionDesc},
+	}
+
+	next := qbtypes.Cursor{Timestamp: 1747950000000000000, ID: "2xWbRZtlRdYGbMHCvlSbBRBnvpA"}
+	previous := qbtypes.Cursor{Timestamp: 1747950000000000000, ID: "2xWbRZtlRdYGbMHCvlSbBRBnvpA", Previous: true}
+	// The millisecond cursor is applied by the querier to the time range.
+	millisecond := base64.StdEncoding.EncodeToString([]byte("1747950000000"))
+
+	cases := []struct {
+		name          string
+		order         []qbtypes.OrderBy
+		cursor        string
+		expectedWhere string
+		expectedOrder string
+		expectedArgs  []any
+		expectedErr   bool
+	}{
+		{
+			name:          "Next",
+			order:         order,
+			cursor:        next.String(),
+			expectedWhere: "(timestamp, id) < (?, ?)",
+			expectedOrder: "ORDER BY `timestamp` desc, `id` desc LIMIT ?",
+			expectedArgs:  []any{next.Timestamp, next.ID},
+		},
+		{
+			name:          "Previous",
+			order:         order,
+			cursor:        previous.String(),
+			expectedWhere: "(timestamp, id) > (?, ?)",
+			expectedOrder: "ORDER BY `timestamp` asc, `id` asc LIMIT ?",
+			expectedArgs:  []any{previous.Timestamp, previous.ID},
+		},
+		{
+			name:          "OrderWithoutID",
+			order:         order[:1],
+			cursor:        millisecond,
+			expectedOrder: "ORDER BY `timestamp` desc LIMIT ?",
+		},
+		{
+			name:          "Millisecond",
+			order:         order,
+			cursor:        millisecond,
+			expectedOrder: "ORDER BY `timestamp` desc, `id` desc LIMIT ?",
+		},
+		{
+			name:        "Invalid",
+			order:       order,
+			cursor:      "invalid",
+			expectedErr: true,
+		},
+	}
+
+	fm := NewFieldMapper()
+	cb := NewConditionBuilder(fm)
+	mockMetadataStore := telemetrytypestest.NewMockMetadataStore()
+	mockMetadataStore.KeysMap = buildCompleteFieldKeyMap()
+
+	resourceFilterStmtBuilder, err := resourceFilterStmtBuilder()
+	require.NoError(t, err)
+
+	statementBuilder := NewLogQueryStatementBuilder(
+		instrumentationtest.New().ToProviderSettings(),
+		mockMetadataStore,
+		fm,
+		cb,
+		resourceFilterStmtBuilder,
+		querybuilder.NewAggExprRewriter(nil, fm, cb, "", nil),
+		DefaultFullTextColumn,
+		BodyJSONStringSearchPrefix,
+		GetBodyJSONKey,
+	)
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			q, err := statementBuilder.Build(context.Background(), 1747947419000, 1747983448000, qbtypes.RequestTypeRaw, qbtypes.QueryBuilderQuery[qbtypes.LogAggregation]{
+				Signal: telemetrytypes.SignalLogs,
+				Filter: &qbtypes.Filter{Expression: "service.name = 'cartservice'"},
+				Order:  c.order,
+				Limit:  100,
+				Cursor: c.cursor,
+			})
+			if c.expectedErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Contains(t, q.Query, c.expectedOrder)
+			if c.expectedWhere == "" {
+				require.NotContains(t, q.Query, "(timestamp, id)")
+				return
+			}
+
+			require.Contains(t, q.Query, c.expectedWhere)
+			require.Subset(t, q.Args, c.expectedArgs)
+		})
+	}
+}
@@ -4,6 +4,8 @@ const (
 	DBName                        = "signoz_logs"
 	LogsV2TableName               = "distributed_logs_v2"
 	LogsV2LocalTableName          = "logs_v2"
+	LogsResourceV2TableName       = "distributed_logs_v2_resource"
+	LogsResourceV2LocalTableName  = "logs_v2_resource"
 	TagAttributesV2TableName      = "distributed_tag_attributes_v2"
 	TagAttributesV2LocalTableName = "tag_attributes_v2"
 )
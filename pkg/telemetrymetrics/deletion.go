@@ -0,0 +1,23 @@
+package telemetrymetrics
+
+import (
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/telemetrystore"
+)
+
+// DeletionTables are the tables from which the series of the metrics matching the label matchers of a deletion are
+// deleted. The rows of the time series tables hold the labels of the series, they are deleted along with the samples.
+var DeletionTables = telemetrystore.DeletionTables{
+	Series: telemetrystore.DeletionTable{Name: DBName + "." + TimeseriesV4TableName, Bucket: time.Hour},
+	Local: []telemetrystore.DeletionTable{
+		{Name: DBName + "." + SamplesV4LocalTableName},
+		{Name: DBName + "." + SamplesV4Agg5mLocalTableName, Bucket: 5 * time.Minute},
+		{Name: DBName + "." + SamplesV4Agg30mLocalTableName, Bucket: 30 * time.Minute},
+		{Name: DBName + "." + ExpHistogramLocalTableName},
+		{Name: DBName + "." + TimeseriesV4LocalTableName, Bucket: time.Hour},
+		{Name: DBName + "." + TimeseriesV46hrsLocalTableName, Bucket: 6 * time.Hour},
+		{Name: DBName + "." + TimeseriesV41dayLocalTableName, Bucket: 24 * time.Hour},
+		{Name: DBName + "." + TimeseriesV41weekLocalTableName, Bucket: 7 * 24 * time.Hour},
+	},
+}
@@ -0,0 +1,306 @@
+package telemetrystore
+
+import (
+	"context"
+
+	"github.com/ClickHouse/clickhouse-go/v2"
+	"github.com/ClickHouse/clickhouse-go/v2/lib/driver"
+	"github.com/ClickHouse/clickhouse-go/v2/lib/proto"
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/valuer"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+const (
+	batchResultAccepted string = "accepted"
+	batchResultRejected string = "rejected"
+	// batchReasonFailed is the reason of the rows left out by an insert which failed, for example on a dropped
+	// connection.
+	batchReasonFailed string = "failed"
+)
+
+var (
+	ErrCodeBatchRowInvalid = errors.MustNewCode("batch_row_invalid")
+)
+
+var (
+	// RejectionReasonInvalid is the reason of the rows whose values cannot be converted to the columns of the insert.
+	RejectionReasonInvalid = RejectionReason{valuer.NewString("invalid")}
+	// RejectionReasonRejected is the reason of the rows rejected by clickhouse.
+	RejectionReasonRejected = RejectionReason{valuer.NewString("rejected")}
+)
+
+// rowErrorCodes are the codes of the clickhouse exceptions caused by the values of some rows of a batch, which are
+// isolated by sending the halves of the batch separately. The other exceptions, such as timeouts or too many parts,
+// fail the batch as a whole.
+var rowErrorCodes = map[int32]struct{}{
+	6:   {}, // CANNOT_PARSE_TEXT
+	27:  {}, // CANNOT_PARSE_INPUT_ASSERTION_FAILED
+	38:  {}, // CANNOT_PARSE_DATE
+	41:  {}, // CANNOT_PARSE_DATETIME
+	53:  {}, // TYPE_MISMATCH
+	69:  {}, // ARGUMENT_OUT_OF_BOUND
+	70:  {}, // CANNOT_CONVERT_TYPE
+	72:  {}, // CANNOT_PARSE_NUMBER
+	117: {}, // INCORRECT_DATA
+	131: {}, // TOO_LARGE_STRING_SIZE
+	190: {}, // SIZES_OF_ARRAYS_DONT_MATCH
+	321: {}, // VALUE_IS_OUT_OF_RANGE_OF_DATA_TYPE
+	349: {}, // CANNOT_INSERT_NULL_IN_ORDINARY_COLUMN
+	395: {}, // FUNCTION_THROW_IF_VALUE_IS_NON_ZERO
+	469: {}, // VIOLATED_CONSTRAINT
+}
+
+// BatchInserter inserts batches of rows into a telemetry store.
+type BatchInserter interface {
+	// Insert inserts the rows with the insert query, every row holding the values of the columns of the query.
+	// With partial writes, the rows which cannot be inserted are reported in the rejections of the result and the
+	// other rows are committed. Otherwise a single row which cannot be inserted fails the whole insert. When an
+	// error is returned, the rows which are neither accepted nor rejected by the result were not inserted.
+	Insert(ctx context.Context, query string, rows Rows) (*InsertResult, error)
+}
+
+// Rows are the rows of an insert. The rows are appended to the batches one at a time, so that the callers do not
+// have to hold the values of all the rows at once.
+type Rows interface {
+	// Len returns the number of rows.
+	Len() int
+
+	// AppendTo appends the values of the row at the index to the batch.
+	AppendTo(batch driver.Batch, index int) error
+}
+
+// AnyRows are rows holding the values of the columns of the insert.
+type AnyRows [][]any
+
+func (rows AnyRows) Len() int {
+	return len(rows)
+}
+
+func (rows AnyRows) AppendTo(batch driver.Batch, index int) error {
+	return batch.Append(rows[index]...)
+}
+
+type RejectionReason struct{ valuer.String }
+
+// Rejection is a row which could not be inserted.
+type Rejection struct {
+	// Index is the index of the row in the rows of the insert.
+	Index int `json:"index"`
+	// Reason is the reason of the rejection.
+	Reason RejectionReason `json:"reason"`
+	// Column is the column whose value is invalid, when it is known.
+	Column string `json:"column,omitempty"`
+	// Message is the message of the error which rejected the row.
+	Message string `json:"message"`
+}
+
+// InsertResult is the result of an insert.
+type InsertResult struct {
+	// Accepted is the number of rows committed.
+	Accepted int
+	// Rejections are the rows which could not be inserted, in no particular order.
+	Rejections []Rejection
+}
+
+func (result *InsertResult) reject(index int, reason RejectionReason, cause error) {
+	rejection := Rejection{Index: index, Reason: reason, Message: cause.Error()}
+
+	var blockErr *proto.BlockError
+	if errors.As(cause, &blockErr) {
+		rejection.Column = blockErr.ColumnName
+		if blockErr.Err != nil {
+			rejection.Message = blockErr.Err.Error()
+		}
+	}
+
+	var exception *clickhouse.Exception
+	if errors.As(cause, &exception) {
+		rejection.Message = exception.Message
+	}
+
+	result.Rejections = append(result.Rejections, rejection)
+}
+
+type batchInserter struct {
+	conn   clickhouse.Conn
+	config BatchConfig
+	rows   metric.Int64Counter
+	attrs  attribute.KeyValue
+}
+
+// NewBatchInserter returns an inserter writing the batches to the connection with the given config.
+func NewBatchInserter(meter metric.Meter, name string, conn clickhouse.Conn, config BatchConfig) (BatchInserter, error) {
+	rows, err := meter.Int64Counter("signoz.telemetrystore.batch.rows", metric.WithDescription("Number of rows of the batch inserts, by result and reason."))
+	if err != nil {
+		return nil, err
+	}
+
+	return &batchInserter{
+		conn:   conn,
+		config: config,
+		rows:   rows,
+		attrs:  attribute.String("telemetrystore.name", name),
+	}, nil
+}
+
+func (inserter *batchInserter) Insert(ctx context.Context, query string, rows Rows) (*InsertResult, error) {
+	result := &InsertResult{}
+
+	var err error
+	if inserter.config.Partial {
+		err = inserter.insertPartial(ctx, query, rows, result)
+	} else {
+		err = inserter.insert(ctx, query, rows, result)
+	}
+
+	inserter.record(ctx, rows.Len(), result, err)
+	return result, err
+}
+
+// insert inserts all the rows in a single batch.
+func (inserter *batchInserter) insert(ctx context.Context, query string, rows Rows, result *InsertResult) error {
+	batch, err := inserter.conn.PrepareBatch(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer batch.Abort() //nolint:errcheck
+
+	for index := 0; index < rows.Len(); index++ {
+		if err := rows.AppendTo(batch, index); err != nil {
+			result.reject(index, RejectionReasonInvalid, err)
+			return errors.Wrapf(err, errors.TypeInvalidInput, ErrCodeBatchRowInvalid, "row %d of the batch is invalid", index)
+		}
+	}
+
+	if err := batch.Send(); err != nil {
+		return err
+	}
+
+	result.Accepted = rows.Len()
+	return nil
+}
+
+// insertPartial inserts the rows in sub batches, rejecting the rows which cannot be inserted.
+func (inserter *batchInserter) insertPartial(ctx context.Context, query string, rows Rows, result *InsertResult) error {
+	indexes := make([]int, 0, min(inserter.config.SubBatchSize, rows.Len()))
+	for start := 0; start < rows.Len(); start += inserter.config.SubBatchSize {
+		end := min(start+inserter.config.SubBatchSize, rows.Len())
+
+		indexes = indexes[:0]
+		for index := start; index < end; index++ {
+			indexes = append(indexes, index)
+		}
+
+		if err := inserter.send(ctx, query, rows, indexes, result); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// send sends the rows of the indexes in a batch. When clickhouse rejects the values of some rows of the batch, its
+// halves are sent separately until the rejected rows are isolated. Errors of other kinds, such as a dropped
+// connection or a timeout, stop the insert.
+func (inserter *batchInserter) send(ctx context.Context, query string, rows Rows, indexes []int, result *InsertResult) error {
+	batch, appended, err := inserter.prepare(ctx, query, rows, indexes, result)
+	if err != nil {
+		return err
+	}
+
+	if len(appended) == 0 {
+		_ = batch.Abort()
+		return nil
+	}
+
+	err = batch.Send()
+	if err == nil {
+		result.Accepted += len(appended)
+		return nil
+	}
+
+	if !isRowError(err) {
+		return err
+	}
+
+	if len(appended) == 1 {
+		result.reject(appended[0], RejectionReasonRejected, err)
+		return nil
+	}
+
+	half := len(appended) / 2
+	if err := inserter.send(ctx, query, rows, appended[:half], result); err != nil {
+		return err
+	}
+
+	return inserter.send(ctx, query, rows, appended[half:], result)
+}
+
+// prepare prepares a batch holding the rows of the indexes and returns the indexes of the rows appended to it. The
+// rows which cannot be appended are rejected. As a batch cannot be used anymore after a failed append, the batch is
+// prepared again with the rows appended so far.
+func (inserter *batchInserter) prepare(ctx context.Context, query string, rows Rows, indexes []int, result *InsertResult) (driver.Batch, []int, error) {
+	batch, err := inserter.conn.PrepareBatch(ctx, query)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	appended := make([]int, 0, len(indexes))
+	for _, index := range indexes {
+		err := rows.AppendTo(batch, index)
+		if err == nil {
+			appended = append(appended, index)
+			continue
+		}
+
+		result.reject(index, RejectionReasonInvalid, err)
+		_ = batch.Abort()
+		batch, err = inserter.conn.PrepareBatch(ctx, query)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		for _, previous := range appended {
+			if err := rows.AppendTo(batch, previous); err != nil {
+				_ = batch.Abort()
+				return nil, nil, err
+			}
+		}
+	}
+
+	return batch, appended, nil
+}
+
+// isRowError returns true if the error is an exception caused by the values of some rows of the batch.
+func isRowError(err error) bool {
+	var exception *clickhouse.Exception
+	if !errors.As(err, &exception) {
+		return false
+	}
+
+	_, ok := rowErrorCodes[exception.Code]
+	return ok
+}
+
+func (inserter *batchInserter) record(ctx context.Context, total int, result *InsertResult, err error) {
+	if result.Accepted > 0 {
+		inserter.rows.Add(ctx, int64(result.Accepted), metric.WithAttributes(inserter.attrs, attribute.String("result", batchResultAccepted)))
+	}
+
+	reasons := make(map[string]int64)
+	for _, rejection := range result.Rejections {
+		reasons[rejection.Reason.StringValue()]++
+	}
+
+	if err != nil {
+		if failed := total - result.Accepted - len(result.Rejections); failed > 0 {
+			reasons[batchReasonFailed] += int64(failed)
+		}
+	}
+
+	for reason, count := range reasons {
+		inserter.rows.Add(ctx, count, metric.WithAttributes(inserter.attrs, attribute.String("result", batchResultRejected), attribute.String("reason", reason)))
+	}
+}
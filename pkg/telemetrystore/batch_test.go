@@ -0,0 +1,150 @@
+package telemetrystore
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/ClickHouse/clickhouse-go/v2"
+	"github.com/ClickHouse/clickhouse-go/v2/lib/driver"
+	"github.com/ClickHouse/clickhouse-go/v2/lib/proto"
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/metric/noop"
+)
+
+// batchConn is a connection whose batches reject the rows holding a string on append and the batches holding a
+// negative value on send.
+type batchConn struct {
+	clickhouse.Conn
+	committed []int
+	sends     int
+}
+
+func (conn *batchConn) PrepareBatch(ctx context.Context, query string, opts ...driver.PrepareBatchOption) (driver.Batch, error) {
+	return &batch{conn: conn}, nil
+}
+
+type batch struct {
+	driver.Batch
+	conn   *batchConn
+	values []int
+	err    error
+}
+
+func (b *batch) Append(v ...any) error {
+	if b.err != nil {
+		return b.err
+	}
+
+	value, ok := v[0].(int)
+	if !ok {
+		b.err = &proto.BlockError{Op: "AppendRow", ColumnName: "value", Err: fmt.Errorf("converting %T to Int64 is unsupported", v[0])}
+		return b.err
+	}
+
+	b.values = append(b.values, value)
+	return nil
+}
+
+func (b *batch) Send() error {
+	b.conn.sends++
+	for _, value := range b.values {
+		if value < 0 {
+			return &clickhouse.Exception{Code: 53, Message: "value must not be negative"}
+		}
+	}
+
+	b.conn.committed = append(b.conn.committed, b.values...)
+	return nil
+}
+
+func (b *batch) Abort() error {
+	return nil
+}
+
+func TestBatchInserterInsert(t *testing.T) {
+	rows := AnyRows{{1}, {"two"}, {3}, {-4}, {5}, {6}, {-7}, {8}}
+
+	t.Run("AllOrNothing", func(t *testing.T) {
+		conn := &batchConn{}
+		inserter, err := NewBatchInserter(noop.NewMeterProvider().Meter(""), "test", conn, BatchConfig{})
+		require.NoError(t, err)
+
+		result, err := inserter.Insert(context.Background(), "INSERT INTO test", rows)
+		assert.True(t, errors.Asc(err, ErrCodeBatchRowInvalid))
+		assert.Equal(t, 0, result.Accepted)
+		assert.Empty(t, conn.committed)
+		require.Len(t, result.Rejections, 1)
+		assert.Equal(t, 1, result.Rejections[0].Index)
+	})
+
+	t.Run("Partial", func(t *testing.T) {
+		conn := &batchConn{}
+		inserter, err := NewBatchInserter(noop.NewMeterProvider().Meter(""), "test", conn, BatchConfig{Partial: true, SubBatchSize: 4})
+		require.NoError(t, err)
+
+		result, err := inserter.Insert(context.Background(), "INSERT INTO test", rows)
+		require.NoError(t, err)
+		assert.Equal(t, 5, result.Accepted)
+		assert.ElementsMatch(t, []int{1, 3, 5, 6, 8}, conn.committed)
+
+		rejections := make(map[int]Rejection)
+		for _, rejection := range result.Rejections {
+			rejections[rejection.Index] = rejection
+		}
+		require.Len(t, rejections, 3)
+
+		assert.Equal(t, RejectionReasonInvalid, rejections[1].Reason)
+		assert.Equal(t, "value", rejections[1].Column)
+		assert.Equal(t, "converting string to Int64 is unsupported", rejections[1].Message)
+
+		assert.Equal(t, RejectionReasonRejected, rejections[3].Reason)
+		assert.Equal(t, "value must not be negative", rejections[3].Message)
+		assert.Equal(t, RejectionReasonRejected, rejections[6].Reason)
+	})
+
+	for _, sendErr := range []error{fmt.Errorf("connection reset by peer"), &clickhouse.Exception{Code: 159, Message: "timeout exceeded"}} {
+		t.Run("PartialStopsOnOtherErrors", func(t *testing.T) {
+			conn := &failingConn{err: sendErr}
+			inserter, err := NewBatchInserter(noop.NewMeterProvider().Meter(""), "test", conn, BatchConfig{Partial: true, SubBatchSize: 4})
+			require.NoError(t, err)
+
+			result, err := inserter.Insert(context.Background(), "INSERT INTO test", AnyRows{{1}, {2}})
+			assert.ErrorIs(t, err, sendErr)
+			assert.Equal(t, 0, result.Accepted)
+			assert.Empty(t, result.Rejections)
+			// The batch is not bisected.
+			assert.Equal(t, 1, conn.sends)
+		})
+	}
+}
+
+type failingConn struct {
+	clickhouse.Conn
+	err   error
+	sends int
+}
+
+func (conn *failingConn) PrepareBatch(ctx context.Context, query string, opts ...driver.PrepareBatchOption) (driver.Batch, error) {
+	return &failingBatch{conn: conn}, nil
+}
+
+type failingBatch struct {
+	driver.Batch
+	conn *failingConn
+}
+
+func (b *failingBatch) Append(v ...any) error {
+	return nil
+}
+
+func (b *failingBatch) Send() error {
+	b.conn.sends++
+	return b.conn.err
+}
+
+func (b *failingBatch) Abort() error {
+	return nil
+}
@@ -0,0 +1,304 @@
+package telemetrystore
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+const (
+	CardinalityActionDrop   string = "drop"
+	CardinalityActionReject string = "reject"
+)
+
+var (
+	ErrCodeCardinalityLimited = errors.MustNewCode("cardinality_limited")
+)
+
+// CardinalityGuard enforces the limits on the number of series of the metrics of the tenants of a telemetry store.
+//
+// The series are counted in memory, hence each replica counts the series it ingested itself and the counts are reset
+// when it restarts. A metric ingested through several replicas can reach up to the limit on each of them.
+type CardinalityGuard interface {
+	// NewAdmission returns the admission of the series of a write of the tenant.
+	NewAdmission(tenantID string) CardinalityAdmission
+}
+
+// CardinalityAdmission admits the series of a single write. The new series it admitted count against the limits of
+// their metric within the write, and for the later writes only once they are committed.
+type CardinalityAdmission interface {
+	// Admit reports whether the series of the metric, identified by the fingerprint of its labels, is admitted. The
+	// known series are always admitted, the new ones are not once the metric reached its limit or once the tenant
+	// reached its limit of metrics for a new metric. A series which is not admitted is dropped, the error is set when
+	// the series is rejected rather than silently dropped and is expected to be returned to the client.
+	Admit(ctx context.Context, metricName string, fingerprint uint64) (bool, error)
+
+	// Commit counts the new series admitted, it is called once they are written. The new series of a write which
+	// failed are not counted.
+	Commit()
+}
+
+type noopCardinalityGuard struct{}
+
+func (noopCardinalityGuard) NewAdmission(string) CardinalityAdmission {
+	return noopCardinalityAdmission{}
+}
+
+type noopCardinalityAdmission struct{}
+
+func (noopCardinalityAdmission) Admit(context.Context, string, uint64) (bool, error) {
+	return true, nil
+}
+
+func (noopCardinalityAdmission) Commit() {}
+
+// metricSeries holds the series of a metric seen in the current and the previous window. A series of the previous
+// window is moved to the current one when it is seen again, so that the series of both windows are distinct.
+type metricSeries struct {
+	start    time.Time
+	current  map[uint64]struct{}
+	previous map[uint64]struct{}
+	// limited is whether the metric was limited in the current window, it is logged once per window.
+	limited bool
+}
+
+type metricSeriesKey struct {
+	tenantID   string
+	metricName string
+}
+
+// windowCardinalityGuard counts the series of a metric seen in the last two windows, so that the series which are no
+// longer written stop counting against the limit of their metric after at most two windows. The metrics without
+// series in both windows are evicted once per window.
+type windowCardinalityGuard struct {
+	logger    *slog.Logger
+	config    CardinalityConfig
+	now       func() time.Time
+	rejected  metric.Int64Counter
+	attrs     attribute.KeyValue
+	allowlist map[string]struct{}
+	mtx       sync.Mutex
+	metrics   map[metricSeriesKey]*metricSeries
+	// tenants is the number of metrics tracked per tenant.
+	tenants map[string]int
+	// limitedTenants are the tenants which reached their limit of metrics since the last eviction, they are logged
+	// once per window.
+	limitedTenants map[string]struct{}
+	evicted        time.Time
+}
+
+// NewCardinalityGuard returns a guard for the given config. The returned guard admits everything when the limits are
+// not enabled.
+func NewCardinalityGuard(logger *slog.Logger, meter metric.Meter, name string, config CardinalityConfig) (CardinalityGuard, error) {
+	if !config.Enabled {
+		return noopCardinalityGuard{}, nil
+	}
+
+	return newWindowCardinalityGuard(logger, meter, name, config, time.Now)
+}
+
+func newWindowCardinalityGuard(logger *slog.Logger, meter metric.Meter, name string, config CardinalityConfig, now func() time.Time) (*windowCardinalityGuard, error) {
+	rejected, err := meter.Int64Counter("signoz.telemetrystore.cardinality.series.rejected", metric.WithDescription("Number of new series rejected or dropped by the cardinality limits, by metric."))
+	if err != nil {
+		return nil, err
+	}
+
+	allowlist := make(map[string]struct{}, len(config.Allowlist))
+	for _, metricName := range config.Allowlist {
+		allowlist[metricName] = struct{}{}
+	}
+
+	return &windowCardinalityGuard{
+		logger:         logger,
+		config:         config,
+		now:            now,
+		rejected:       rejected,
+		attrs:          attribute.String("telemetrystore.name", name),
+		allowlist:      allowlist,
+		metrics:        make(map[metricSeriesKey]*metricSeries),
+		tenants:        make(map[string]int),
+		limitedTenants: make(map[string]struct{}),
+		evicted:        now(),
+	}, nil
+}
+
+func (guard *windowCardinalityGuard) NewAdmission(tenantID string) CardinalityAdmission {
+	return &windowCardinalityAdmission{guard: guard, tenantID: tenantID, pending: map[string]map[uint64]struct{}{}}
+}
+
+type windowCardinalityAdmission struct {
+	guard    *windowCardinalityGuard
+	tenantID string
+	// pending are the new series admitted keyed by their metric, they are counted once committed.
+	pending map[string]map[uint64]struct{}
+	// newMetrics is the number of metrics of pending which are not tracked yet.
+	newMetrics int
+}
+
+func (admission *windowCardinalityAdmission) Admit(ctx context.Context, metricName string, fingerprint uint64) (bool, error) {
+	guard := admission.guard
+	if _, ok := guard.allowlist[metricName]; ok {
+		return true, nil
+	}
+
+	limit, ok := guard.config.Metrics[metricName]
+	if !ok {
+		limit = guard.config.MaxSeriesPerMetric
+	}
+
+	if limit == 0 {
+		return true, nil
+	}
+
+	pending := admission.pending[metricName]
+	if _, ok := pending[fingerprint]; ok {
+		return true, nil
+	}
+
+	guard.mtx.Lock()
+	defer guard.mtx.Unlock()
+
+	now := guard.now()
+	guard.evict(now)
+
+	count := len(pending)
+	s, tracked := guard.metrics[metricSeriesKey{tenantID: admission.tenantID, metricName: metricName}]
+	if tracked {
+		guard.roll(s, now)
+		if _, ok := s.current[fingerprint]; ok {
+			return true, nil
+		}
+
+		if _, ok := s.previous[fingerprint]; ok {
+			delete(s.previous, fingerprint)
+			s.current[fingerprint] = struct{}{}
+			return true, nil
+		}
+
+		count += len(s.current) + len(s.previous)
+	}
+
+	newMetric := !tracked && pending == nil
+	if newMetric && guard.config.MaxMetricsPerTenant > 0 && guard.tenants[admission.tenantID]+admission.newMetrics >= guard.config.MaxMetricsPerTenant {
+		if _, ok := guard.limitedTenants[admission.tenantID]; !ok {
+			guard.limitedTenants[admission.tenantID] = struct{}{}
+			guard.logger.WarnContext(ctx, "tenant reached its limit of metrics, the series of its new metrics are not ingested", "tenant_id", admission.tenantID, "metric_name", metricName, "limit", guard.config.MaxMetricsPerTenant, "action", guard.config.Action)
+		}
+
+		return admission.limited(ctx, metricName, errors.Newf(errors.TypeInvalidInput, ErrCodeCardinalityLimited, "tenant %q reached its limit of %d metrics, metric %q is not ingested", admission.tenantID, guard.config.MaxMetricsPerTenant, metricName))
+	}
+
+	if count >= limit {
+		if tracked && !s.limited {
+			s.limited = true
+			guard.logger.WarnContext(ctx, "metric reached its cardinality limit, its new series are not ingested", "tenant_id", admission.tenantID, "metric_name", metricName, "limit", limit, "action", guard.config.Action)
+		}
+
+		return admission.limited(ctx, metricName, errors.Newf(errors.TypeInvalidInput, ErrCodeCardinalityLimited, "metric %q reached its limit of %d series for tenant %q", metricName, limit, admission.tenantID))
+	}
+
+	if pending == nil {
+		pending = map[uint64]struct{}{}
+		admission.pending[metricName] = pending
+		if newMetric {
+			admission.newMetrics++
+		}
+	}
+
+	pending[fingerprint] = struct{}{}
+	return true, nil
+}
+
+func (admission *windowCardinalityAdmission) Commit() {
+	if len(admission.pending) == 0 {
+		return
+	}
+
+	guard := admission.guard
+	guard.mtx.Lock()
+	defer guard.mtx.Unlock()
+
+	now := guard.now()
+	for metricName, fingerprints := range admission.pending {
+		// The concurrent writes of the metric may commit a few series past its limit, they are admitted against the
+		// same counts.
+		s := guard.series(metricSeriesKey{tenantID: admission.tenantID, metricName: metricName}, now)
+		for fingerprint := range fingerprints {
+			delete(s.previous, fingerprint)
+			s.current[fingerprint] = struct{}{}
+		}
+	}
+
+	admission.pending = map[string]map[uint64]struct{}{}
+	admission.newMetrics = 0
+}
+
+// limited records the series which is not admitted and returns the error of the action.
+func (admission *windowCardinalityAdmission) limited(ctx context.Context, metricName string, err error) (bool, error) {
+	admission.guard.rejected.Add(ctx, 1, metric.WithAttributes(admission.guard.attrs, attribute.String("metric.name", metricName)))
+	if admission.guard.config.Action == CardinalityActionDrop {
+		return false, nil
+	}
+
+	return false, err
+}
+
+// series returns the series of the metric, tracking it if it is not yet. It should be called with the lock held.
+func (guard *windowCardinalityGuard) series(key metricSeriesKey, now time.Time) *metricSeries {
+	s, ok := guard.metrics[key]
+	if !ok {
+		s = &metricSeries{start: now.Truncate(guard.config.Window), current: map[uint64]struct{}{}, previous: map[uint64]struct{}{}}
+		guard.metrics[key] = s
+		guard.tenants[key.tenantID]++
+		return s
+	}
+
+	guard.roll(s, now)
+	return s
+}
+
+// roll rolls the windows of the series over if now is past the end of the current one. It should be called with the
+// lock held.
+func (guard *windowCardinalityGuard) roll(s *metricSeries, now time.Time) {
+	elapsed := now.Sub(s.start)
+	switch {
+	case elapsed < guard.config.Window:
+	case elapsed < 2*guard.config.Window:
+		s.previous, s.current = s.current, map[uint64]struct{}{}
+		s.start = s.start.Add(guard.config.Window)
+		s.limited = false
+	default:
+		// No series in the previous window.
+		s.previous, s.current = map[uint64]struct{}{}, map[uint64]struct{}{}
+		s.start = now.Truncate(guard.config.Window)
+		s.limited = false
+	}
+}
+
+// evict stops tracking the metrics without series in both windows, at most once per window. It should be called with
+// the lock held.
+func (guard *windowCardinalityGuard) evict(now time.Time) {
+	if now.Sub(guard.evicted) < guard.config.Window {
+		return
+	}
+
+	guard.evicted = now
+	for key, s := range guard.metrics {
+		if now.Sub(s.start) < 2*guard.config.Window {
+			continue
+		}
+
+		delete(guard.metrics, key)
+		guard.tenants[key.tenantID]--
+		if guard.tenants[key.tenantID] == 0 {
+			delete(guard.tenants, key.tenantID)
+		}
+	}
+
+	clear(guard.limitedTenants)
+}
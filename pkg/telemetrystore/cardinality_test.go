@@ -0,0 +1,184 @@
+package telemetrystore
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/metric/noop"
+)
+
+func TestCardinalityGuardDisabled(t *testing.T) {
+	guard, err := NewCardinalityGuard(slog.New(slog.NewTextHandler(io.Discard, nil)), noop.NewMeterProvider().Meter(""), "default", CardinalityConfig{Enabled: false, MaxSeriesPerMetric: 1})
+	require.NoError(t, err)
+
+	admission := guard.NewAdmission("tenant")
+	for fingerprint := range uint64(10) {
+		admitted, err := admission.Admit(context.Background(), "http_requests_total", fingerprint)
+		assert.NoError(t, err)
+		assert.True(t, admitted)
+	}
+}
+
+func TestWindowCardinalityGuardAdmit(t *testing.T) {
+	now := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	config := CardinalityConfig{
+		Enabled:            true,
+		Window:             time.Hour,
+		MaxSeriesPerMetric: 2,
+		Metrics:            map[string]int{"http_requests_total": 3, "unlimited": 0},
+		Allowlist:          []string{"request_duration_by_id"},
+		Action:             CardinalityActionReject,
+	}
+
+	guard, err := newWindowCardinalityGuard(slog.New(slog.NewTextHandler(io.Discard, nil)), noop.NewMeterProvider().Meter(""), "default", config, func() time.Time { return now })
+	require.NoError(t, err)
+
+	admit := func(tenantID string, metricName string, fingerprint uint64) (bool, error) {
+		admission := guard.NewAdmission(tenantID)
+		admitted, err := admission.Admit(context.Background(), metricName, fingerprint)
+		admission.Commit()
+		return admitted, err
+	}
+
+	// The new series are admitted up to the limit, the known series are always admitted.
+	for _, fingerprint := range []uint64{1, 2, 1, 2} {
+		admitted, err := admit("tenant", "up", fingerprint)
+		require.NoError(t, err)
+		assert.True(t, admitted)
+	}
+
+	admitted, err := admit("tenant", "up", 3)
+	assert.False(t, admitted)
+	assert.True(t, errors.Asc(err, ErrCodeCardinalityLimited))
+
+	// The series of the other tenants and metrics are counted independently.
+	admitted, err = admit("other", "up", 3)
+	assert.NoError(t, err)
+	assert.True(t, admitted)
+
+	// The limits of specific metrics override the default limit, the allowlisted metrics are never limited.
+	for fingerprint := range uint64(3) {
+		admitted, err := admit("tenant", "http_requests_total", fingerprint)
+		require.NoError(t, err)
+		assert.True(t, admitted)
+	}
+
+	admitted, _ = admit("tenant", "http_requests_total", 3)
+	assert.False(t, admitted)
+
+	for fingerprint := range uint64(10) {
+		admitted, _ := admit("tenant", "request_duration_by_id", fingerprint)
+		assert.True(t, admitted)
+
+		admitted, _ = admit("tenant", "unlimited", fingerprint)
+		assert.True(t, admitted)
+	}
+
+	// The series of the previous window still count, series 1 is seen again and series 2 is not.
+	now = now.Add(time.Hour)
+	admitted, _ = admit("tenant", "up", 1)
+	assert.True(t, admitted)
+	admitted, _ = admit("tenant", "up", 3)
+	assert.False(t, admitted)
+
+	// Series 2 was not written in the last window, it no longer counts.
+	now = now.Add(time.Hour)
+	admitted, err = admit("tenant", "up", 3)
+	assert.NoError(t, err)
+	assert.True(t, admitted)
+}
+
+func TestWindowCardinalityGuardDrop(t *testing.T) {
+	config := CardinalityConfig{Enabled: true, Window: time.Hour, MaxSeriesPerMetric: 1, Action: CardinalityActionDrop}
+
+	guard, err := newWindowCardinalityGuard(slog.New(slog.NewTextHandler(io.Discard, nil)), noop.NewMeterProvider().Meter(""), "default", config, time.Now)
+	require.NoError(t, err)
+
+	admission := guard.NewAdmission("tenant")
+	admitted, err := admission.Admit(context.Background(), "up", 1)
+	require.NoError(t, err)
+	assert.True(t, admitted)
+
+	admitted, err = admission.Admit(context.Background(), "up", 2)
+	assert.NoError(t, err)
+	assert.False(t, admitted)
+}
+
+func TestWindowCardinalityGuardCommit(t *testing.T) {
+	config := CardinalityConfig{Enabled: true, Window: time.Hour, MaxSeriesPerMetric: 2, Action: CardinalityActionReject}
+
+	guard, err := newWindowCardinalityGuard(slog.New(slog.NewTextHandler(io.Discard, nil)), noop.NewMeterProvider().Meter(""), "default", config, time.Now)
+	require.NoError(t, err)
+
+	// The new series admitted by a write count against the limit within the write.
+	admission := guard.NewAdmission("tenant")
+	for _, fingerprint := range []uint64{1, 2, 1} {
+		admitted, err := admission.Admit(context.Background(), "up", fingerprint)
+		require.NoError(t, err)
+		assert.True(t, admitted)
+	}
+
+	admitted, err := admission.Admit(context.Background(), "up", 3)
+	assert.False(t, admitted)
+	assert.True(t, errors.Asc(err, ErrCodeCardinalityLimited))
+
+	// The series of a write which failed are not committed and do not count.
+	admission = guard.NewAdmission("tenant")
+	for _, fingerprint := range []uint64{3, 4} {
+		admitted, err := admission.Admit(context.Background(), "up", fingerprint)
+		require.NoError(t, err)
+		assert.True(t, admitted)
+	}
+	admission.Commit()
+
+	admitted, err = guard.NewAdmission("tenant").Admit(context.Background(), "up", 1)
+	assert.False(t, admitted)
+	assert.True(t, errors.Asc(err, ErrCodeCardinalityLimited))
+}
+
+func TestWindowCardinalityGuardMaxMetricsPerTenant(t *testing.T) {
+	now := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	config := CardinalityConfig{Enabled: true, Window: time.Hour, MaxSeriesPerMetric: 10, MaxMetricsPerTenant: 2, Action: CardinalityActionReject}
+
+	guard, err := newWindowCardinalityGuard(slog.New(slog.NewTextHandler(io.Discard, nil)), noop.NewMeterProvider().Meter(""), "default", config, func() time.Time { return now })
+	require.NoError(t, err)
+
+	admission := guard.NewAdmission("tenant")
+	for _, metricName := range []string{"up", "http_requests_total"} {
+		admitted, err := admission.Admit(context.Background(), metricName, 1)
+		require.NoError(t, err)
+		assert.True(t, admitted)
+	}
+
+	admitted, err := admission.Admit(context.Background(), "process_cpu_seconds_total", 1)
+	assert.False(t, admitted)
+	assert.True(t, errors.Asc(err, ErrCodeCardinalityLimited))
+	admission.Commit()
+
+	// The new series of the tracked metrics are still admitted, the metrics of the other tenants are counted
+	// independently.
+	admitted, err = guard.NewAdmission("tenant").Admit(context.Background(), "up", 2)
+	assert.NoError(t, err)
+	assert.True(t, admitted)
+
+	admitted, err = guard.NewAdmission("other").Admit(context.Background(), "process_cpu_seconds_total", 1)
+	assert.NoError(t, err)
+	assert.True(t, admitted)
+
+	// The metrics without series in both windows are evicted, making room for the new metrics.
+	now = now.Add(2 * time.Hour)
+	admission = guard.NewAdmission("tenant")
+	admitted, err = admission.Admit(context.Background(), "process_cpu_seconds_total", 1)
+	assert.NoError(t, err)
+	assert.True(t, admitted)
+	admission.Commit()
+
+	assert.Len(t, guard.metrics, 1)
+	assert.Equal(t, map[string]int{"tenant": 1}, guard.tenants)
+}
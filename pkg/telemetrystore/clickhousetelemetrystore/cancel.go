@@ -0,0 +1,59 @@
+package clickhousetelemetrystore
+
+import (
+	"context"
+	"time"
+
+	"github.com/ClickHouse/clickhouse-go/v2"
+	"github.com/ClickHouse/clickhouse-go/v2/lib/driver"
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/telemetrystore"
+)
+
+const (
+	// killTimeout is the maximum time spent killing a canceled read on the server.
+	killTimeout = 5 * time.Second
+)
+
+// killOnCancel gives the read a query id of its own and kills it on the server if ctx is canceled before stop is
+// called, for example as the user navigated away. The driver only closes the connection of a canceled read, which
+// leaves the query running on the server until it notices. The reads exceeding their deadline are not killed as
+// they are bounded by max_execution_time already.
+func (s *shard) killOnCancel(ctx context.Context, event *telemetrystore.QueryEvent) (context.Context, func()) {
+	// The driver does not send the reads whose context is done already.
+	if ctx.Err() != nil {
+		return ctx, func() {}
+	}
+
+	queryID := telemetrystore.NewQueryID(ctx)
+	event.QueryID = queryID
+
+	stop := context.AfterFunc(ctx, func() {
+		if !errors.Is(ctx.Err(), context.Canceled) {
+			return
+		}
+
+		s.cancellations.Add(ctx, 1, s.attributes)
+
+		// The kill must not run with the query id of the read it kills.
+		killCtx, cancel := context.WithTimeout(clickhouse.Context(context.WithoutCancel(ctx), clickhouse.WithQueryID("")), killTimeout)
+		defer cancel()
+
+		if err := s.clickHouseConn.Exec(killCtx, "KILL QUERY WHERE query_id = ? ASYNC", queryID); err != nil {
+			s.logger.WarnContext(killCtx, "failed to kill canceled query", "shard", s.name, "query_id", queryID, "error", err)
+		}
+	})
+
+	return clickhouse.Context(ctx, clickhouse.WithQueryID(queryID)), func() { stop() }
+}
+
+// killOnCancelRows stops killing the read on cancellation, and releases its timeout, once its rows are closed.
+type killOnCancelRows struct {
+	driver.Rows
+	stop func()
+}
+
+func (rows *killOnCancelRows) Close() error {
+	defer rows.stop()
+	return rows.Rows.Close()
+}
@@ -0,0 +1,109 @@
+package clickhousetelemetrystore
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ClickHouse/clickhouse-go/v2"
+	"github.com/ClickHouse/clickhouse-go/v2/lib/driver"
+	"github.com/SigNoz/signoz/pkg/telemetrystore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metricsdk "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+// longConn runs every read until its context is done, like a long query the client gave up on. The queries it
+// kills are sent to killed.
+type longConn struct {
+	clickhouse.Conn
+	killed chan []any
+}
+
+func (conn *longConn) Select(ctx context.Context, _ interface{}, _ string, _ ...interface{}) error {
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func (conn *longConn) Stats() driver.Stats {
+	return driver.Stats{MaxOpenConns: 10}
+}
+
+func (conn *longConn) Exec(ctx context.Context, query string, args ...interface{}) error {
+	if query == "KILL QUERY WHERE query_id = ? ASYNC" {
+		conn.killed <- args
+	}
+
+	return nil
+}
+
+// queryIDHook records the query ids of the reads.
+type queryIDHook struct {
+	mtx      sync.Mutex
+	queryIDs []string
+}
+
+func (hook *queryIDHook) BeforeQuery(ctx context.Context, _ *telemetrystore.QueryEvent) context.Context {
+	return ctx
+}
+
+func (hook *queryIDHook) AfterQuery(_ context.Context, event *telemetrystore.QueryEvent) {
+	hook.mtx.Lock()
+	defer hook.mtx.Unlock()
+	hook.queryIDs = append(hook.queryIDs, event.QueryID)
+}
+
+func TestShardKillsCanceledRead(t *testing.T) {
+	reader := metricsdk.NewManualReader()
+	cancellations, err := metricsdk.NewMeterProvider(metricsdk.WithReader(reader)).Meter("test").Int64Counter("cancellations")
+	require.NoError(t, err)
+
+	conn := &longConn{killed: make(chan []any, 1)}
+	hook := &queryIDHook{}
+	shard := newTestShard(conn, 0)
+	shard.hooks = []telemetrystore.TelemetryStoreHook{hook}
+	shard.cancellations = cancellations
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(10*time.Millisecond, cancel)
+
+	rows := []int{}
+	assert.ErrorIs(t, shard.Select(ctx, &rows, "SELECT sleep(3600)"), context.Canceled)
+
+	select {
+	case args := <-conn.killed:
+		require.Len(t, hook.queryIDs, 1)
+		assert.NotEmpty(t, hook.queryIDs[0])
+		assert.Equal(t, []any{hook.queryIDs[0]}, args)
+	case <-time.After(time.Second):
+		t.Fatal("the canceled read was not killed")
+	}
+
+	resourceMetrics := metricdata.ResourceMetrics{}
+	require.NoError(t, reader.Collect(context.Background(), &resourceMetrics))
+	require.Len(t, resourceMetrics.ScopeMetrics, 1)
+	require.Len(t, resourceMetrics.ScopeMetrics[0].Metrics, 1)
+	sum := resourceMetrics.ScopeMetrics[0].Metrics[0].Data.(metricdata.Sum[int64])
+	require.Len(t, sum.DataPoints, 1)
+	assert.Equal(t, int64(1), sum.DataPoints[0].Value)
+}
+
+func TestShardDoesNotKillCompletedRead(t *testing.T) {
+	conn := &longConn{killed: make(chan []any, 1)}
+	shard := newTestShard(conn, 0)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	// Reads exceeding their deadline are bounded by max_execution_time on the server.
+	rows := []int{}
+	assert.ErrorIs(t, shard.Select(ctx, &rows, "SELECT sleep(3600)"), context.DeadlineExceeded)
+
+	select {
+	case <-conn.killed:
+		t.Fatal("the read exceeding its deadline was killed")
+	case <-time.After(50 * time.Millisecond):
+	}
+}
@@ -0,0 +1,34 @@
+package clickhousetelemetrystore
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/factory/factorytest"
+	"github.com/SigNoz/signoz/pkg/telemetrystore"
+	"github.com/SigNoz/signoz/pkg/telemetrystore/telemetrystoretest"
+	"github.com/stretchr/testify/require"
+)
+
+// TestConformance runs the conformance tests of the telemetry stores against the clickhouse of the
+// SIGNOZ_TELEMETRYSTORE_CLICKHOUSE_DSN, so that the memory telemetry store is held to the same tests.
+func TestConformance(t *testing.T) {
+	dsn := os.Getenv("SIGNOZ_TELEMETRYSTORE_CLICKHOUSE_DSN")
+	if dsn == "" {
+		t.Skip("SIGNOZ_TELEMETRYSTORE_CLICKHOUSE_DSN is not set")
+	}
+
+	telemetrystoretest.RunConformance(t, func(t *testing.T) telemetrystore.TelemetryStore {
+		store, err := New(context.Background(), factorytest.NewSettings(), telemetrystore.Config{
+			Provider:   "clickhouse",
+			Name:       "test",
+			Connection: telemetrystore.ConnectionConfig{MaxOpenConns: 10, MaxIdleConns: 5, DialTimeout: 5 * time.Second},
+			Clickhouse: telemetrystore.ClickhouseConfig{DSN: dsn},
+		}, nil, nil)
+		require.NoError(t, err)
+
+		return store
+	})
+}
@@ -0,0 +1,77 @@
+package clickhousetelemetrystore
+
+import (
+	"context"
+
+	"github.com/ClickHouse/clickhouse-go/v2"
+	"github.com/ClickHouse/clickhouse-go/v2/lib/driver"
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/telemetrystore"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+const (
+	insertModeSync  string = "sync"
+	insertModeAsync string = "async"
+)
+
+// withInsertMode returns the context of a batch insert along with its mode. The settings of the asynchronous inserts
+// are set on the batch itself, after the hooks so that they are merged into the settings of the hooks.
+func (s *shard) withInsertMode(ctx context.Context) (context.Context, string) {
+	if !s.asyncInsert.Enabled {
+		return ctx, insertModeSync
+	}
+
+	waitForAsyncInsert := 0
+	if s.asyncInsert.Wait {
+		waitForAsyncInsert = 1
+	}
+
+	return telemetrystore.NewContextWithSettings(ctx, clickhouse.Settings{
+		"async_insert":          1,
+		"wait_for_async_insert": waitForAsyncInsert,
+	}), insertModeAsync
+}
+
+// insertBatch records the sends of a batch by insert mode and surfaces the errors of the asynchronous inserts.
+type insertBatch struct {
+	driver.Batch
+	ctx     context.Context
+	mode    string
+	inserts metric.Int64Counter
+	attrs   metric.MeasurementOption
+	// release gives back the slot of the connection held by the batch until it is sent or aborted.
+	release func()
+}
+
+func (s *shard) newInsertBatch(ctx context.Context, batch driver.Batch, mode string, release func()) driver.Batch {
+	return &insertBatch{
+		Batch:   batch,
+		ctx:     ctx,
+		mode:    mode,
+		inserts: s.inserts,
+		attrs:   s.attributes,
+		release: release,
+	}
+}
+
+func (b *insertBatch) Abort() error {
+	defer b.release()
+	return b.Batch.Abort()
+}
+
+func (b *insertBatch) Send() error {
+	defer b.release()
+
+	rows := b.Batch.Rows()
+	err := b.Batch.Send()
+
+	b.inserts.Add(b.ctx, 1, b.attrs, metric.WithAttributes(attribute.String("mode", b.mode), attribute.Bool("error", err != nil)))
+
+	if err != nil && b.mode == insertModeAsync {
+		return errors.Wrapf(err, errors.TypeInternal, telemetrystore.ErrCodeAsyncInsertFailed, "async insert of %d rows failed", rows)
+	}
+
+	return err
+}
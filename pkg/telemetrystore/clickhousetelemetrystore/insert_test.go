@@ -0,0 +1,123 @@
+package clickhousetelemetrystore
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ClickHouse/clickhouse-go/v2"
+	"github.com/ClickHouse/clickhouse-go/v2/lib/driver"
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/telemetrystore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// insertConn is a connection whose batches fail on send with the given error.
+type insertConn struct {
+	clickhouse.Conn
+	ctx context.Context
+	err error
+}
+
+func (conn *insertConn) Stats() driver.Stats {
+	return driver.Stats{MaxOpenConns: 1}
+}
+
+func (conn *insertConn) PrepareBatch(ctx context.Context, query string, opts ...driver.PrepareBatchOption) (driver.Batch, error) {
+	conn.ctx = ctx
+	return &insertConnBatch{err: conn.err}, nil
+}
+
+type insertConnBatch struct {
+	driver.Batch
+	err error
+}
+
+func (b *insertConnBatch) Rows() int {
+	return 2
+}
+
+func (b *insertConnBatch) Send() error {
+	return b.err
+}
+
+func TestShardPrepareBatchInsertMode(t *testing.T) {
+	testCases := []struct {
+		name        string
+		asyncInsert telemetrystore.AsyncInsertConfig
+		async       bool
+		wait        bool
+	}{
+		{name: "Sync", asyncInsert: telemetrystore.AsyncInsertConfig{Enabled: false, Wait: true}, async: false},
+		{name: "AsyncWait", asyncInsert: telemetrystore.AsyncInsertConfig{Enabled: true, Wait: true}, async: true, wait: true},
+		{name: "AsyncNoWait", asyncInsert: telemetrystore.AsyncInsertConfig{Enabled: true, Wait: false}, async: true, wait: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			conn := &insertConn{}
+			shard := newTestShard(conn, 0)
+			shard.asyncInsert = tc.asyncInsert
+
+			batch, err := shard.PrepareBatch(context.Background(), "INSERT INTO logs")
+			require.NoError(t, err)
+			require.NoError(t, batch.Send())
+
+			settings := telemetrystore.SettingsFromContext(conn.ctx)
+			if !tc.async {
+				assert.NotContains(t, settings, "async_insert")
+				return
+			}
+
+			assert.Equal(t, 1, settings["async_insert"])
+			wait := 0
+			if tc.wait {
+				wait = 1
+			}
+			assert.Equal(t, wait, settings["wait_for_async_insert"])
+		})
+	}
+}
+
+// commentHook sets a setting of its own on every query.
+type commentHook struct{}
+
+func (commentHook) BeforeQuery(ctx context.Context, _ *telemetrystore.QueryEvent) context.Context {
+	return telemetrystore.NewContextWithSettings(ctx, clickhouse.Settings{"log_comment": "test"})
+}
+
+func (commentHook) AfterQuery(context.Context, *telemetrystore.QueryEvent) {}
+
+func TestShardPrepareBatchAsyncInsertWithHooks(t *testing.T) {
+	conn := &insertConn{}
+	shard := newTestShard(conn, 0)
+	shard.hooks = []telemetrystore.TelemetryStoreHook{commentHook{}}
+	shard.asyncInsert = telemetrystore.AsyncInsertConfig{Enabled: true, Wait: true}
+
+	_, err := shard.PrepareBatch(context.Background(), "INSERT INTO logs")
+	require.NoError(t, err)
+
+	// The settings of the asynchronous inserts are merged into the settings of the hooks.
+	assert.Equal(t, clickhouse.Settings{"log_comment": "test", "async_insert": 1, "wait_for_async_insert": 1}, telemetrystore.SettingsFromContext(conn.ctx))
+}
+
+func TestShardAsyncInsertError(t *testing.T) {
+	exception := &clickhouse.Exception{Code: 53, Message: "type mismatch"}
+
+	shard := newTestShard(&insertConn{err: exception}, 0)
+	shard.asyncInsert = telemetrystore.AsyncInsertConfig{Enabled: true, Wait: true}
+
+	batch, err := shard.PrepareBatch(context.Background(), "INSERT INTO logs")
+	require.NoError(t, err)
+
+	err = batch.Send()
+	assert.True(t, errors.Asc(err, telemetrystore.ErrCodeAsyncInsertFailed))
+
+	var target *clickhouse.Exception
+	assert.True(t, errors.As(err, &target))
+
+	shard.asyncInsert.Enabled = false
+	batch, err = shard.PrepareBatch(context.Background(), "INSERT INTO logs")
+	require.NoError(t, err)
+	assert.Equal(t, exception, batch.Send())
+}
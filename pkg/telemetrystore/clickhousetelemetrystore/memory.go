@@ -0,0 +1,102 @@
+package clickhousetelemetrystore
+
+import (
+	"context"
+
+	"github.com/ClickHouse/clickhouse-go/v2/lib/driver"
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/telemetrystore"
+)
+
+// wrapQueryMemory returns an error explaining the memory limit of the read if err is clickhouse killing it for
+// exceeding its memory, and err otherwise. The reads killed are logged so that the memory limits can be tuned.
+func (s *shard) wrapQueryMemory(ctx context.Context, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	limit, _ := s.queryMemory.LimitFromContext(ctx)
+	err = telemetrystore.WrapQueryMemoryLimitExceeded(err, limit)
+	if errors.Asc(err, telemetrystore.ErrCodeQueryMemoryLimitExceeded) {
+		tag, _ := telemetrystore.QueryTagFromContext(ctx)
+		s.logger.WarnContext(ctx, "read killed for exceeding its memory, the memory limits of the tenant may need tuning", "shard", s.name, "tenant_id", tag.TenantID, "max_memory_usage", limit.MaxMemoryUsage, "error", err)
+	}
+
+	return err
+}
+
+// queryMemoryRow explains the memory limit of the read in the errors of the row.
+type queryMemoryRow struct {
+	driver.Row
+	ctx   context.Context
+	shard *shard
+	// err is the explained error of the row, which is explained, and logged, once.
+	err     error
+	wrapped bool
+}
+
+func (row *queryMemoryRow) Err() error {
+	if !row.wrapped {
+		row.err = row.shard.wrapQueryMemory(row.ctx, row.Row.Err())
+		row.wrapped = true
+	}
+
+	return row.err
+}
+
+func (row *queryMemoryRow) Scan(dest ...any) error {
+	if err := row.Err(); err != nil {
+		return err
+	}
+
+	return row.shard.wrapQueryMemory(row.ctx, row.Row.Scan(dest...))
+}
+
+func (row *queryMemoryRow) ScanStruct(dest any) error {
+	if err := row.Err(); err != nil {
+		return err
+	}
+
+	return row.shard.wrapQueryMemory(row.ctx, row.Row.ScanStruct(dest))
+}
+
+// queryMemoryRows explains the memory limit of the read in the errors of the rows, as clickhouse can kill the read
+// while its rows are streamed, after the query returned.
+type queryMemoryRows struct {
+	driver.Rows
+	ctx   context.Context
+	shard *shard
+	// err is the explained error of the rows once they are read, which is explained, and logged, once.
+	err  error
+	done bool
+}
+
+func (rows *queryMemoryRows) Next() bool {
+	if rows.done {
+		return false
+	}
+
+	if rows.Rows.Next() {
+		return true
+	}
+
+	rows.err = rows.shard.wrapQueryMemory(rows.ctx, rows.Rows.Err())
+	rows.done = true
+	return false
+}
+
+func (rows *queryMemoryRows) Err() error {
+	if rows.done {
+		return rows.err
+	}
+
+	return rows.shard.wrapQueryMemory(rows.ctx, rows.Rows.Err())
+}
+
+func (rows *queryMemoryRows) Scan(dest ...any) error {
+	return rows.shard.wrapQueryMemory(rows.ctx, rows.Rows.Scan(dest...))
+}
+
+func (rows *queryMemoryRows) ScanStruct(dest any) error {
+	return rows.shard.wrapQueryMemory(rows.ctx, rows.Rows.ScanStruct(dest))
+}
@@ -0,0 +1,117 @@
+package clickhousetelemetrystore
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/ClickHouse/clickhouse-go/v2"
+	"github.com/ClickHouse/clickhouse-go/v2/lib/driver"
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/telemetrystore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryConn kills every read for exceeding its memory.
+type memoryConn struct {
+	clickhouse.Conn
+}
+
+func (conn *memoryConn) Select(context.Context, interface{}, string, ...interface{}) error {
+	return &clickhouse.Exception{Code: 241, Name: "MEMORY_LIMIT_EXCEEDED", Message: "Memory limit (for query) exceeded"}
+}
+
+func (conn *memoryConn) Stats() driver.Stats {
+	return driver.Stats{MaxOpenConns: 10}
+}
+
+func (conn *memoryConn) QueryRow(ctx context.Context, query string, args ...interface{}) driver.Row {
+	return &memoryRow{err: conn.Select(ctx, nil, query, args...)}
+}
+
+func (conn *memoryConn) Query(ctx context.Context, query string, args ...interface{}) (driver.Rows, error) {
+	// The read is killed once its first row was streamed.
+	return &memoryRows{err: conn.Select(ctx, nil, query, args...)}, nil
+}
+
+type memoryRows struct {
+	driver.Rows
+	next int
+	err  error
+}
+
+func (rows *memoryRows) Next() bool {
+	rows.next++
+	return rows.next == 1
+}
+
+func (rows *memoryRows) Scan(...any) error { return nil }
+
+func (rows *memoryRows) Err() error {
+	if rows.next > 1 {
+		return rows.err
+	}
+
+	return nil
+}
+
+func (rows *memoryRows) Close() error { return nil }
+
+type memoryRow struct {
+	driver.Row
+	err error
+}
+
+func (row *memoryRow) Err() error { return row.err }
+
+func (row *memoryRow) Scan(...any) error { return row.err }
+
+func TestShardQueryMemoryLimitExceeded(t *testing.T) {
+	shard := newTestShard(&memoryConn{}, 0)
+	shard.queryMemory = telemetrystore.QueryMemoryConfig{
+		Enabled: true,
+		Default: telemetrystore.QueryMemoryLimit{MaxMemoryUsage: 1 << 30},
+	}
+
+	ctx := telemetrystore.NewContextWithQueryTag(context.Background(), telemetrystore.QueryTag{TenantID: "tenant"})
+
+	rows := []int{}
+	err := shard.Select(ctx, &rows, "SELECT uniq(trace_id) FROM signoz_traces.distributed_signoz_index_v3")
+	assert.True(t, errors.Asc(err, telemetrystore.ErrCodeQueryMemoryLimitExceeded))
+
+	var count uint64
+	err = shard.QueryRow(ctx, "SELECT count() FROM signoz_logs.distributed_logs_v2").Scan(&count)
+	assert.True(t, errors.Asc(err, telemetrystore.ErrCodeQueryMemoryLimitExceeded))
+}
+
+func TestShardQueryRowsMemoryLimitExceeded(t *testing.T) {
+	buffer := &bytes.Buffer{}
+	shard := newTestShard(&memoryConn{}, 0)
+	shard.logger = slog.New(slog.NewTextHandler(buffer, nil))
+	shard.queryMemory = telemetrystore.QueryMemoryConfig{
+		Enabled: true,
+		Default: telemetrystore.QueryMemoryLimit{MaxMemoryUsage: 1 << 30},
+	}
+
+	ctx := telemetrystore.NewContextWithQueryTag(context.Background(), telemetrystore.QueryTag{TenantID: "tenant"})
+
+	rows, err := shard.Query(ctx, "SELECT trace_id FROM signoz_traces.distributed_signoz_index_v3")
+	require.NoError(t, err)
+	defer rows.Close()
+
+	count := 0
+	for rows.Next() {
+		count++
+	}
+
+	assert.Equal(t, 1, count)
+	assert.True(t, errors.Asc(rows.Err(), telemetrystore.ErrCodeQueryMemoryLimitExceeded))
+	assert.True(t, errors.Asc(rows.Err(), telemetrystore.ErrCodeQueryMemoryLimitExceeded))
+
+	// The read killed is logged once, with the tenant whose limit may need tuning.
+	assert.Equal(t, 1, strings.Count(buffer.String(), "read killed for exceeding its memory"))
+	assert.Contains(t, buffer.String(), "tenant_id=tenant")
+}
@@ -0,0 +1,184 @@
+package clickhousetelemetrystore
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ClickHouse/clickhouse-go/v2"
+	"github.com/ClickHouse/clickhouse-go/v2/lib/driver"
+	"github.com/SigNoz/signoz/pkg/telemetrystore"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// waits tracks the operations which had to wait for a connection. clickhouse-go does not expose the time spent
+// acquiring a connection, so the shard holds a slot per connection of the pool: an operation takes a slot before the
+// driver acquires a connection and gives it back once the driver released the connection. An operation waits for a
+// slot exactly when the driver would wait for a connection.
+type waits struct {
+	// slots has a capacity of the maximum number of open connections, the waits are not tracked when it is nil.
+	slots chan struct{}
+	// timeout bounds the wait for a slot like the driver bounds the wait for a connection.
+	timeout  time.Duration
+	count    atomic.Int64
+	duration atomic.Int64
+}
+
+func newWaits(maxOpenConns int, timeout time.Duration) *waits {
+	return &waits{slots: make(chan struct{}, maxOpenConns), timeout: timeout}
+}
+
+// acquire takes a slot, waiting for one if every connection is in use. The returned function gives the slot back, it
+// can be called more than once and is a no-op if no slot was taken.
+func (w *waits) acquire(ctx context.Context) (func(), error) {
+	if w.slots == nil {
+		return func() {}, nil
+	}
+
+	select {
+	case w.slots <- struct{}{}:
+		return w.release(), nil
+	default:
+	}
+
+	start := time.Now()
+	w.count.Add(1)
+	defer func() {
+		w.duration.Add(int64(time.Since(start)))
+	}()
+
+	timer := time.NewTimer(w.timeout)
+	defer timer.Stop()
+
+	select {
+	case w.slots <- struct{}{}:
+		return w.release(), nil
+	case <-ctx.Done():
+		return func() {}, ctx.Err()
+	case <-timer.C:
+		return func() {}, clickhouse.ErrAcquireConnTimeout
+	}
+}
+
+func (w *waits) release() func() {
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			<-w.slots
+		})
+	}
+}
+
+// errorRow is the row of a read which failed before reaching the driver.
+type errorRow struct {
+	err error
+}
+
+func (row *errorRow) Err() error {
+	return row.err
+}
+
+func (row *errorRow) Scan(...any) error {
+	return row.err
+}
+
+func (row *errorRow) ScanStruct(any) error {
+	return row.err
+}
+
+// releaseRow gives back the slot of the connection of the row once it is scanned.
+type releaseRow struct {
+	driver.Row
+	release func()
+}
+
+func (row *releaseRow) Scan(dest ...any) error {
+	defer row.release()
+	return row.Row.Scan(dest...)
+}
+
+func (row *releaseRow) ScanStruct(dest any) error {
+	defer row.release()
+	return row.Row.ScanStruct(dest)
+}
+
+func (s *shard) poolStats() telemetrystore.PoolStats {
+	// clickhouse-go reports the connections in use as open.
+	stats := s.clickHouseConn.Stats()
+	return telemetrystore.PoolStats{
+		MaxOpenConns: stats.MaxOpenConns,
+		OpenConns:    stats.Open + stats.Idle,
+		InUseConns:   stats.Open,
+		IdleConns:    stats.Idle,
+		WaitCount:    s.waits.count.Load(),
+		WaitDuration: time.Duration(s.waits.duration.Load()),
+	}
+}
+
+// PoolStats returns the statistics of the connection pools of all the shards added together.
+func (p *provider) PoolStats() telemetrystore.PoolStats {
+	var stats telemetrystore.PoolStats
+	for _, shard := range p.shards {
+		shardStats := shard.poolStats()
+		stats.MaxOpenConns += shardStats.MaxOpenConns
+		stats.OpenConns += shardStats.OpenConns
+		stats.InUseConns += shardStats.InUseConns
+		stats.IdleConns += shardStats.IdleConns
+		stats.WaitCount += shardStats.WaitCount
+		stats.WaitDuration += shardStats.WaitDuration
+	}
+
+	return stats
+}
+
+func (p *provider) registerMetrics(name string) error {
+	meter := p.settings.Meter()
+
+	maxOpenConns, err := meter.Int64ObservableGauge("signoz.telemetrystore.connections.max", metric.WithDescription("Maximum number of open connections."))
+	if err != nil {
+		return err
+	}
+
+	openConns, err := meter.Int64ObservableGauge("signoz.telemetrystore.connections.open", metric.WithDescription("Number of open connections, both in use and idle."))
+	if err != nil {
+		return err
+	}
+
+	inUseConns, err := meter.Int64ObservableGauge("signoz.telemetrystore.connections.in_use", metric.WithDescription("Number of connections in use."))
+	if err != nil {
+		return err
+	}
+
+	idleConns, err := meter.Int64ObservableGauge("signoz.telemetrystore.connections.idle", metric.WithDescription("Number of idle connections."))
+	if err != nil {
+		return err
+	}
+
+	waitCount, err := meter.Int64ObservableCounter("signoz.telemetrystore.connections.wait.count", metric.WithDescription("Number of operations which had to wait for a connection."))
+	if err != nil {
+		return err
+	}
+
+	waitDuration, err := meter.Float64ObservableCounter("signoz.telemetrystore.connections.wait.duration", metric.WithDescription("Total time spent by the operations which had to wait for a connection."), metric.WithUnit("s"))
+	if err != nil {
+		return err
+	}
+
+	_, err = meter.RegisterCallback(func(_ context.Context, observer metric.Observer) error {
+		for _, shard := range p.shards {
+			attributes := metric.WithAttributes(attribute.String("telemetrystore.name", name), attribute.String("telemetrystore.shard", shard.name))
+			stats := shard.poolStats()
+			observer.ObserveInt64(maxOpenConns, int64(stats.MaxOpenConns), attributes)
+			observer.ObserveInt64(openConns, int64(stats.OpenConns), attributes)
+			observer.ObserveInt64(inUseConns, int64(stats.InUseConns), attributes)
+			observer.ObserveInt64(idleConns, int64(stats.IdleConns), attributes)
+			observer.ObserveInt64(waitCount, stats.WaitCount, attributes)
+			observer.ObserveFloat64(waitDuration, stats.WaitDuration.Seconds(), attributes)
+		}
+		return nil
+	}, maxOpenConns, openConns, inUseConns, idleConns, waitCount, waitDuration)
+
+	return err
+}
@@ -0,0 +1,57 @@
+package clickhousetelemetrystore
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ClickHouse/clickhouse-go/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWaitsAcquire(t *testing.T) {
+	waits := newWaits(1, time.Second)
+
+	release, err := waits.acquire(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), waits.count.Load())
+
+	acquiredC := make(chan func(), 1)
+	go func() {
+		release, err := waits.acquire(context.Background())
+		assert.NoError(t, err)
+		acquiredC <- release
+	}()
+
+	time.Sleep(50 * time.Millisecond)
+	release()
+	// Giving the slot back twice does not give back the slot taken by the other operation.
+	release()
+
+	second := <-acquiredC
+	assert.Equal(t, int64(1), waits.count.Load())
+	assert.GreaterOrEqual(t, time.Duration(waits.duration.Load()), 50*time.Millisecond)
+	assert.Len(t, waits.slots, 1)
+
+	second()
+	assert.Len(t, waits.slots, 0)
+}
+
+func TestWaitsAcquireTimeout(t *testing.T) {
+	waits := newWaits(1, 10*time.Millisecond)
+
+	_, err := waits.acquire(context.Background())
+	require.NoError(t, err)
+
+	release, err := waits.acquire(context.Background())
+	assert.ErrorIs(t, err, clickhouse.ErrAcquireConnTimeout)
+	// The release of a failed acquire is a no-op.
+	release()
+	assert.Len(t, waits.slots, 1)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = waits.acquire(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+}
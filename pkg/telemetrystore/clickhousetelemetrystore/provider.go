@@ -2,20 +2,35 @@ package clickhousetelemetrystore
 
 import (
 	"context"
+	"sync"
+	"time"
 
 	"github.com/ClickHouse/clickhouse-go/v2"
 	"github.com/ClickHouse/clickhouse-go/v2/lib/driver"
+	"github.com/SigNoz/signoz/pkg/errors"
 	"github.com/SigNoz/signoz/pkg/factory"
+	"github.com/SigNoz/signoz/pkg/secretstore"
 	"github.com/SigNoz/signoz/pkg/telemetrystore"
+	"github.com/SigNoz/signoz/pkg/types/authtypes"
+	"go.opentelemetry.io/otel/metric"
 )
 
 type provider struct {
-	settings       factory.ScopedProviderSettings
-	clickHouseConn clickhouse.Conn
-	hooks          []telemetrystore.TelemetryStoreHook
+	settings  factory.ScopedProviderSettings
+	shards    map[string]*shard
+	router    telemetrystore.Router
+	limiter   telemetrystore.IngestionLimiter
+	guard     telemetrystore.CardinalityGuard
+	retention telemetrystore.Retention
+	inserter  telemetrystore.BatchInserter
+	schema    telemetrystore.Schema
+	estimator telemetrystore.Estimator
+	deleter   telemetrystore.Deleter
 }
 
-func NewFactory(hookFactories ...factory.ProviderFactory[telemetrystore.TelemetryStoreHook, telemetrystore.Config]) factory.ProviderFactory[telemetrystore.TelemetryStore, telemetrystore.Config] {
+// NewFactory returns the factory of the clickhouse telemetry store. The router maps the tenants to their shards, the
+// tenants of the routing config are routed when it is nil.
+func NewFactory(secretResolver *secretstore.Resolver, router telemetrystore.Router, hookFactories ...factory.ProviderFactory[telemetrystore.TelemetryStoreHook, telemetrystore.Config]) factory.ProviderFactory[telemetrystore.TelemetryStore, telemetrystore.Config] {
 	return factory.NewProviderFactory(factory.MustNewName("clickhouse"), func(ctx context.Context, providerSettings factory.ProviderSettings, config telemetrystore.Config) (telemetrystore.TelemetryStore, error) {
 		// we want to fail fast so we have hook registration errors before creating the telemetry store
 		hooks := make([]telemetrystore.TelemetryStoreHook, len(hookFactories))
@@ -26,125 +41,285 @@ func NewFactory(hookFactories ...factory.ProviderFactory[telemetrystore.Telemetr
 			}
 			hooks[i] = hook
 		}
-		return New(ctx, providerSettings, config, hooks...)
+		return New(ctx, providerSettings, config, secretResolver, router, hooks...)
 	})
 }
 
-func New(ctx context.Context, providerSettings factory.ProviderSettings, config telemetrystore.Config, hooks ...telemetrystore.TelemetryStoreHook) (telemetrystore.TelemetryStore, error) {
+func New(ctx context.Context, providerSettings factory.ProviderSettings, config telemetrystore.Config, secretResolver *secretstore.Resolver, router telemetrystore.Router, hooks ...telemetrystore.TelemetryStoreHook) (_ telemetrystore.TelemetryStore, err error) {
 	settings := factory.NewScopedProviderSettings(providerSettings, "github.com/SigNoz/signoz/pkg/telemetrystore/clickhousetelemetrystore")
 
-	options, err := clickhouse.ParseDSN(config.Clickhouse.DSN)
+	reconnects, err := settings.Meter().Int64Counter("signoz.telemetrystore.reconnects", metric.WithDescription("Number of reads retried on another connection after their connection was dropped."))
 	if err != nil {
 		return nil, err
 	}
-	options.MaxIdleConns = config.Connection.MaxIdleConns
-	options.MaxOpenConns = config.Connection.MaxOpenConns
-	options.DialTimeout = config.Connection.DialTimeout
 
-	chConn, err := clickhouse.Open(options)
+	cancellations, err := settings.Meter().Int64Counter("signoz.telemetrystore.canceled_queries", metric.WithDescription("Number of reads canceled by the client before they completed, which are killed on the server."))
 	if err != nil {
 		return nil, err
 	}
 
-	return &provider{
-		settings:       settings,
-		clickHouseConn: chConn,
-		hooks:          hooks,
-	}, nil
+	inserts, err := settings.Meter().Int64Counter("signoz.telemetrystore.inserts", metric.WithDescription("Number of batches sent, by insert mode."))
+	if err != nil {
+		return nil, err
+	}
+
+	password, err := resolvePassword(ctx, secretResolver, config.Clickhouse.Password)
+	if err != nil {
+		return nil, err
+	}
+
+	tlsReloader, err := newTLSReloader(settings.Logger(), config.Clickhouse.TLS)
+	if err != nil {
+		return nil, err
+	}
+
+	defaultShard, err := newShard(settings.Logger(), telemetrystore.DefaultShardName, config.Clickhouse.DSN, password, tlsReloader, config, hooks, reconnects, cancellations, inserts)
+	if err != nil {
+		return nil, err
+	}
+
+	shards := map[string]*shard{telemetrystore.DefaultShardName: defaultShard}
+	// The shards opened so far are closed when a later step fails.
+	defer func() {
+		if err == nil {
+			return
+		}
+
+		for _, shard := range shards {
+			_ = shard.Close()
+		}
+	}()
+
+	for _, shardConfig := range config.Shards {
+		password, err := resolvePassword(ctx, secretResolver, shardConfig.Password)
+		if err != nil {
+			return nil, errors.Wrapf(err, errors.TypeInvalidInput, errors.CodeInvalidInput, "failed to resolve the password of shard %q", shardConfig.Name)
+		}
+
+		shard, err := newShard(settings.Logger(), shardConfig.Name, shardConfig.DSN, password, tlsReloader, config, hooks, reconnects, cancellations, inserts)
+		if err != nil {
+			return nil, errors.Wrapf(err, errors.TypeInvalidInput, errors.CodeInvalidInput, "failed to connect to shard %q", shardConfig.Name)
+		}
+
+		shards[shardConfig.Name] = shard
+	}
+
+	// The telemetrystore is not critical, a shard which is still unreachable once the retries are exhausted does not
+	// prevent signoz from booting. Its connections are opened on demand once it is reachable, until then it is
+	// reported as unhealthy. The shards are retried together so that the max duration bounds the wait for all of
+	// them, and they are closed when the boot is canceled while they are retried.
+	retryCtx, cancel := context.WithTimeout(ctx, config.Connection.ConnectRetry.MaxDuration)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	for name, shard := range shards {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := factory.RetryConnect(retryCtx, settings.Logger(), config.Connection.ConnectRetry, "clickhouse shard "+name, shard.clickHouseConn.Ping); err != nil {
+				settings.Logger().ErrorContext(ctx, "clickhouse shard is unreachable, starting in degraded mode", "shard", name, "error", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	limiter, err := telemetrystore.NewIngestionLimiter(settings.Meter(), config.Name, config.Ingestion)
+	if err != nil {
+		return nil, err
+	}
+
+	guard, err := telemetrystore.NewCardinalityGuard(settings.Logger(), settings.Meter(), config.Name, config.Cardinality)
+	if err != nil {
+		return nil, err
+	}
+
+	if router == nil {
+		router = telemetrystore.NewRouter(config.Routing)
+	}
+
+	provider := &provider{
+		settings: settings,
+		shards:   shards,
+		router:   router,
+		limiter:  limiter,
+		guard:    guard,
+	}
+
+	provider.retention = telemetrystore.NewRetention(config.Retention, config.Routing, provider.Shards())
+	provider.schema = telemetrystore.NewSchema(config.Schema, defaultShard)
+	provider.estimator = telemetrystore.NewEstimator(config.QueryBudget, provider)
+	provider.deleter = telemetrystore.NewDeleter(config.Deletion, config.Routing, provider)
+
+	provider.inserter, err = telemetrystore.NewBatchInserter(settings.Meter(), config.Name, provider, config.Batch)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := provider.registerMetrics(config.Name); err != nil {
+		return nil, err
+	}
+
+	if config.Connection.WarmupConns > 0 {
+		provider.warmup(ctx, config.Connection.WarmupConns, config.Connection.DialTimeout)
+	}
+
+	return provider, nil
+}
+
+// resolvePassword returns nil when the password is not set, the password of the DSN is used then.
+func resolvePassword(ctx context.Context, secretResolver *secretstore.Resolver, password string) (*secretstore.Value, error) {
+	if password == "" {
+		return nil, nil
+	}
+
+	return secretResolver.Resolve(ctx, password)
+}
+
+// warmup opens the connections of every shard concurrently. Failures are only logged since the connections are
+// opened on demand anyway.
+func (p *provider) warmup(ctx context.Context, n int, timeout time.Duration) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	for _, s := range p.shards {
+		wg.Add(1)
+		go func(s *shard) {
+			defer wg.Done()
+			if err := s.warmup(ctx, n); err != nil {
+				p.settings.Logger().WarnContext(ctx, "failed to warm up telemetrystore connections", "shard", s.name, "connections", n, "error", err)
+				return
+			}
+
+			p.settings.Logger().InfoContext(ctx, "warmed up telemetrystore connections", "shard", s.name, "connections", n)
+		}(s)
+	}
+	wg.Wait()
 }
 
 func (p *provider) ClickhouseDB() clickhouse.Conn {
 	return p
 }
 
-func (p *provider) Close() error {
-	return p.clickHouseConn.Close()
+func (p *provider) Shards() map[string]clickhouse.Conn {
+	shards := make(map[string]clickhouse.Conn, len(p.shards))
+	for name, shard := range p.shards {
+		shards[name] = shard
+	}
+
+	return shards
 }
 
-func (p *provider) Ping(ctx context.Context) error {
-	return p.clickHouseConn.Ping(ctx)
+func (p *provider) IngestionLimiter() telemetrystore.IngestionLimiter {
+	return p.limiter
 }
 
-func (p *provider) Stats() driver.Stats {
-	return p.clickHouseConn.Stats()
+func (p *provider) CardinalityGuard() telemetrystore.CardinalityGuard {
+	return p.guard
 }
 
-func (p *provider) Query(ctx context.Context, query string, args ...interface{}) (driver.Rows, error) {
-	event := telemetrystore.NewQueryEvent(query, args)
+func (p *provider) Retention() telemetrystore.Retention {
+	return p.retention
+}
 
-	ctx = telemetrystore.WrapBeforeQuery(p.hooks, ctx, event)
-	rows, err := p.clickHouseConn.Query(ctx, query, args...)
+func (p *provider) BatchInserter() telemetrystore.BatchInserter {
+	return p.inserter
+}
 
-	event.Err = err
-	telemetrystore.WrapAfterQuery(p.hooks, ctx, event)
+func (p *provider) Schema() telemetrystore.Schema {
+	return p.schema
+}
 
-	return rows, err
+func (p *provider) Estimator() telemetrystore.Estimator {
+	return p.estimator
 }
 
-func (p *provider) QueryRow(ctx context.Context, query string, args ...interface{}) driver.Row {
-	event := telemetrystore.NewQueryEvent(query, args)
+func (p *provider) Deleter() telemetrystore.Deleter {
+	return p.deleter
+}
 
-	ctx = telemetrystore.WrapBeforeQuery(p.hooks, ctx, event)
-	row := p.clickHouseConn.QueryRow(ctx, query, args...)
+// shard returns the shard of the tenant of the context. The tenant is the one set on the context or, failing
+// that, the organization of the authenticated user. Operations without a tenant go to the default shard.
+func (p *provider) shard(ctx context.Context) *shard {
+	tenantID, ok := telemetrystore.TenantIDFromContext(ctx)
+	if !ok {
+		if claims, err := authtypes.ClaimsFromContext(ctx); err == nil {
+			tenantID = claims.OrgID
+		}
+	}
 
-	event.Err = row.Err()
-	telemetrystore.WrapAfterQuery(p.hooks, ctx, event)
+	if tenantID == "" {
+		return p.shards[telemetrystore.DefaultShardName]
+	}
 
-	return row
-}
+	if shard, ok := p.shards[p.router.Route(tenantID)]; ok {
+		return shard
+	}
 
-func (p *provider) Select(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
-	event := telemetrystore.NewQueryEvent(query, args)
+	return p.shards[telemetrystore.DefaultShardName]
+}
 
-	ctx = telemetrystore.WrapBeforeQuery(p.hooks, ctx, event)
-	err := p.clickHouseConn.Select(ctx, dest, query, args...)
+func (p *provider) Close() error {
+	errs := make([]error, 0, len(p.shards))
+	for _, shard := range p.shards {
+		if err := shard.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
 
-	event.Err = err
-	telemetrystore.WrapAfterQuery(p.hooks, ctx, event)
+	return errors.Join(errs...)
+}
 
-	return err
+func (p *provider) Ping(ctx context.Context) error {
+	return p.shard(ctx).Ping(ctx)
 }
 
-func (p *provider) Exec(ctx context.Context, query string, args ...interface{}) error {
-	event := telemetrystore.NewQueryEvent(query, args)
+func (p *provider) Healthy(ctx context.Context) error {
+	for _, shard := range p.shards {
+		if err := shard.Ping(ctx); err != nil {
+			return err
+		}
+	}
 
-	ctx = telemetrystore.WrapBeforeQuery(p.hooks, ctx, event)
-	err := p.clickHouseConn.Exec(ctx, query, args...)
+	return nil
+}
 
-	event.Err = err
-	telemetrystore.WrapAfterQuery(p.hooks, ctx, event)
+func (p *provider) Stats() driver.Stats {
+	return p.shards[telemetrystore.DefaultShardName].Stats()
+}
 
-	return err
+func (p *provider) Query(ctx context.Context, query string, args ...interface{}) (driver.Rows, error) {
+	return p.shard(ctx).Query(ctx, query, args...)
 }
 
-func (p *provider) AsyncInsert(ctx context.Context, query string, wait bool, args ...interface{}) error {
-	event := telemetrystore.NewQueryEvent(query, args)
+func (p *provider) QueryRow(ctx context.Context, query string, args ...interface{}) driver.Row {
+	return p.shard(ctx).QueryRow(ctx, query, args...)
+}
 
-	ctx = telemetrystore.WrapBeforeQuery(p.hooks, ctx, event)
-	err := p.clickHouseConn.AsyncInsert(ctx, query, wait, args...)
+func (p *provider) Select(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+	return p.shard(ctx).Select(ctx, dest, query, args...)
+}
 
-	event.Err = err
-	telemetrystore.WrapAfterQuery(p.hooks, ctx, event)
+func (p *provider) Exec(ctx context.Context, query string, args ...interface{}) error {
+	return p.shard(ctx).Exec(ctx, query, args...)
+}
 
-	return err
+func (p *provider) AsyncInsert(ctx context.Context, query string, wait bool, args ...interface{}) error {
+	return p.shard(ctx).AsyncInsert(ctx, query, wait, args...)
 }
 
 func (p *provider) PrepareBatch(ctx context.Context, query string, opts ...driver.PrepareBatchOption) (driver.Batch, error) {
-	event := telemetrystore.NewQueryEvent(query, nil)
-
-	ctx = telemetrystore.WrapBeforeQuery(p.hooks, ctx, event)
-	batch, err := p.clickHouseConn.PrepareBatch(ctx, query, opts...)
-
-	event.Err = err
-	telemetrystore.WrapAfterQuery(p.hooks, ctx, event)
-
-	return batch, err
+	return p.shard(ctx).PrepareBatch(ctx, query, opts...)
 }
 
 func (p *provider) ServerVersion() (*driver.ServerVersion, error) {
-	return p.clickHouseConn.ServerVersion()
+	return p.shards[telemetrystore.DefaultShardName].ServerVersion()
 }
 
 func (p *provider) Contributors() []string {
-	return p.clickHouseConn.Contributors()
+	return p.shards[telemetrystore.DefaultShardName].Contributors()
 }
@@ -0,0 +1,113 @@
+package clickhousetelemetrystore
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/factory"
+	"github.com/SigNoz/signoz/pkg/factory/factorytest"
+	"github.com/SigNoz/signoz/pkg/telemetrystore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPoolStats(t *testing.T) {
+	store, err := New(context.Background(), factorytest.NewSettings(), telemetrystore.Config{
+		Provider: "clickhouse",
+		Name:     "test",
+		Connection: telemetrystore.ConnectionConfig{
+			MaxOpenConns: 10,
+			MaxIdleConns: 5,
+			DialTimeout:  time.Second,
+		},
+		Clickhouse: telemetrystore.ClickhouseConfig{DSN: "tcp://localhost:9000"},
+	}, nil, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, telemetrystore.PoolStats{MaxOpenConns: 10}, store.PoolStats())
+}
+
+func TestShardRouting(t *testing.T) {
+	store, err := New(context.Background(), factorytest.NewSettings(), telemetrystore.Config{
+		Provider:   "clickhouse",
+		Name:       "test",
+		Connection: telemetrystore.ConnectionConfig{MaxOpenConns: 10, MaxIdleConns: 5, DialTimeout: time.Second},
+		Clickhouse: telemetrystore.ClickhouseConfig{DSN: "tcp://localhost:9000"},
+		Shards:     []telemetrystore.ShardConfig{{Name: "eu", DSN: "tcp://localhost:9001"}},
+		Routing:    telemetrystore.RoutingConfig{Tenants: map[string]string{"tenant-eu": "eu"}},
+	}, nil, nil)
+	require.NoError(t, err)
+
+	p := store.(*provider)
+	assert.Len(t, store.Shards(), 2)
+	assert.Equal(t, "eu", p.shard(telemetrystore.NewContextWithTenantID(context.Background(), "tenant-eu")).name)
+	assert.Equal(t, telemetrystore.DefaultShardName, p.shard(telemetrystore.NewContextWithTenantID(context.Background(), "tenant-us")).name)
+	assert.Equal(t, telemetrystore.DefaultShardName, p.shard(context.Background()).name)
+	assert.Equal(t, telemetrystore.PoolStats{MaxOpenConns: 20}, store.PoolStats())
+}
+
+func TestShardRoutingWithRouter(t *testing.T) {
+	router := telemetrystore.RouterFunc(func(tenantID string) string {
+		if strings.HasPrefix(tenantID, "eu-") {
+			return "eu"
+		}
+
+		return telemetrystore.DefaultShardName
+	})
+
+	store, err := New(context.Background(), factorytest.NewSettings(), telemetrystore.Config{
+		Provider:   "clickhouse",
+		Name:       "test",
+		Connection: telemetrystore.ConnectionConfig{MaxOpenConns: 10, MaxIdleConns: 5, DialTimeout: time.Second},
+		Clickhouse: telemetrystore.ClickhouseConfig{DSN: "tcp://localhost:9000"},
+		Shards:     []telemetrystore.ShardConfig{{Name: "eu", DSN: "tcp://localhost:9001"}},
+		Routing:    telemetrystore.RoutingConfig{Tenants: map[string]string{"tenant-eu": "eu"}},
+	}, nil, router)
+	require.NoError(t, err)
+
+	p := store.(*provider)
+	assert.Equal(t, "eu", p.shard(telemetrystore.NewContextWithTenantID(context.Background(), "eu-1")).name)
+	// The routing config is not used when a router is given.
+	assert.Equal(t, telemetrystore.DefaultShardName, p.shard(telemetrystore.NewContextWithTenantID(context.Background(), "tenant-eu")).name)
+}
+
+func TestNewInvalidShard(t *testing.T) {
+	_, err := New(context.Background(), factorytest.NewSettings(), telemetrystore.Config{
+		Provider:   "clickhouse",
+		Name:       "test",
+		Connection: telemetrystore.ConnectionConfig{MaxOpenConns: 10, MaxIdleConns: 5, DialTimeout: time.Second},
+		Clickhouse: telemetrystore.ClickhouseConfig{DSN: "tcp://localhost:9000"},
+		Shards:     []telemetrystore.ShardConfig{{Name: "eu", DSN: "://localhost:9001"}},
+	}, nil, nil)
+	assert.True(t, errors.Ast(err, errors.TypeInvalidInput))
+}
+
+func TestNewRetriesShardsWithinMaxDuration(t *testing.T) {
+	config := telemetrystore.Config{
+		Provider: "clickhouse",
+		Name:     "test",
+		Connection: telemetrystore.ConnectionConfig{
+			MaxOpenConns: 10,
+			MaxIdleConns: 5,
+			DialTimeout:  50 * time.Millisecond,
+			ConnectRetry: factory.ConnectRetryConfig{MaxDuration: 300 * time.Millisecond, InitialBackoff: 10 * time.Millisecond, MaxBackoff: 50 * time.Millisecond},
+		},
+		Clickhouse: telemetrystore.ClickhouseConfig{DSN: "tcp://127.0.0.1:1"},
+		Shards:     []telemetrystore.ShardConfig{{Name: "eu", DSN: "tcp://127.0.0.1:2"}, {Name: "us", DSN: "tcp://127.0.0.1:3"}},
+	}
+
+	// The unreachable shards are retried together, the max duration bounds the wait for all of them.
+	start := time.Now()
+	_, err := New(context.Background(), factorytest.NewSettings(), config, nil, nil)
+	require.NoError(t, err)
+	assert.Less(t, time.Since(start), 2*config.Connection.ConnectRetry.MaxDuration)
+
+	// The boot is canceled while the shards are retried.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = New(ctx, factorytest.NewSettings(), config, nil, nil)
+	assert.ErrorIs(t, err, context.Canceled)
+}
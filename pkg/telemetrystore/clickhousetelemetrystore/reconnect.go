@@ -0,0 +1,80 @@
+package clickhousetelemetrystore
+
+import (
+	"context"
+	sqldriver "database/sql/driver"
+	"io"
+	"net"
+	"reflect"
+	"sync"
+	"syscall"
+
+	"github.com/ClickHouse/clickhouse-go/v2/lib/driver"
+	"github.com/SigNoz/signoz/pkg/errors"
+)
+
+// isConnBrokenError returns whether the error means that the connection was dropped. The driver closes such
+// connections instead of returning them to the pool, so the operation can be retried on another connection.
+func isConnBrokenError(err error) bool {
+	return errors.Is(err, io.EOF) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.EPIPE) ||
+		errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, net.ErrClosed) ||
+		errors.Is(err, sqldriver.ErrBadConn)
+}
+
+// retry runs the idempotent read, running it again on another connection while it fails because its connection
+// was dropped. The read is not retried once the context is done.
+func (s *shard) retry(ctx context.Context, read func() error) error {
+	err := read()
+	for attempt := 0; attempt < s.reconnectRetries && isConnBrokenError(err) && ctx.Err() == nil; attempt++ {
+		s.reconnects.Add(ctx, 1, s.attributes)
+		err = read()
+	}
+
+	return err
+}
+
+// resetSlice returns a function truncating the slice pointed to by dest to its current length, so that a retried
+// Select does not append the rows read by the failed attempt again.
+func resetSlice(dest interface{}) func() {
+	value := reflect.ValueOf(dest)
+	if value.Kind() != reflect.Pointer || value.Elem().Kind() != reflect.Slice {
+		return func() {}
+	}
+
+	length := value.Elem().Len()
+	return func() {
+		value.Elem().SetLen(length)
+	}
+}
+
+// warmup opens n connections by holding n of them at once, after which they are returned to the idle pool. It
+// returns the errors of the connections which could not be opened.
+func (s *shard) warmup(ctx context.Context, n int) error {
+	var acquired sync.WaitGroup
+	acquired.Add(n)
+
+	var wg sync.WaitGroup
+	errs := make([]error, n)
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			var rows driver.Rows
+			rows, errs[i] = s.clickHouseConn.Query(ctx, "SELECT 1")
+			acquired.Done()
+
+			// The connection is held until every connection has been acquired so that they are all distinct.
+			acquired.Wait()
+			if rows != nil {
+				_ = rows.Close()
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	return errors.Join(errs...)
+}
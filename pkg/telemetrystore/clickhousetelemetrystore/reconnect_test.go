@@ -0,0 +1,128 @@
+package clickhousetelemetrystore
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"sync/atomic"
+	"syscall"
+	"testing"
+
+	"github.com/ClickHouse/clickhouse-go/v2"
+	"github.com/ClickHouse/clickhouse-go/v2/lib/driver"
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/noop"
+)
+
+// droppingConn drops the connection of the first drops selects after having read a row.
+type droppingConn struct {
+	clickhouse.Conn
+	drops   int
+	selects int
+	open    atomic.Int64
+	maxOpen atomic.Int64
+}
+
+func (conn *droppingConn) Select(_ context.Context, dest interface{}, _ string, _ ...interface{}) error {
+	conn.selects++
+	rows := dest.(*[]int)
+	*rows = append(*rows, 1)
+	if conn.selects <= conn.drops {
+		return syscall.ECONNRESET
+	}
+
+	*rows = append(*rows, 2)
+	return nil
+}
+
+func (conn *droppingConn) Stats() driver.Stats {
+	return driver.Stats{MaxOpenConns: 10}
+}
+
+func (conn *droppingConn) Exec(context.Context, string, ...interface{}) error {
+	conn.selects++
+	return syscall.ECONNRESET
+}
+
+func (conn *droppingConn) Query(context.Context, string, ...interface{}) (driver.Rows, error) {
+	open := conn.open.Add(1)
+	for {
+		maxOpen := conn.maxOpen.Load()
+		if open <= maxOpen || conn.maxOpen.CompareAndSwap(maxOpen, open) {
+			break
+		}
+	}
+
+	return &heldRows{conn: conn}, nil
+}
+
+type heldRows struct {
+	driver.Rows
+	conn *droppingConn
+}
+
+func (rows *heldRows) Close() error {
+	rows.conn.open.Add(-1)
+	return nil
+}
+
+func newTestShard(conn clickhouse.Conn, reconnectRetries int) *shard {
+	reconnects, _ := noop.NewMeterProvider().Meter("test").Int64Counter("reconnects")
+	cancellations, _ := noop.NewMeterProvider().Meter("test").Int64Counter("cancellations")
+	inserts, _ := noop.NewMeterProvider().Meter("test").Int64Counter("inserts")
+	return &shard{name: "test", logger: slog.New(slog.NewTextHandler(io.Discard, nil)), clickHouseConn: conn, waits: &waits{}, reconnectRetries: reconnectRetries, reconnects: reconnects, cancellations: cancellations, inserts: inserts, attributes: metric.WithAttributes()}
+}
+
+func TestShardSelectReconnects(t *testing.T) {
+	conn := &droppingConn{drops: 1}
+	shard := newTestShard(conn, 1)
+
+	rows := []int{0}
+	require.NoError(t, shard.Select(context.Background(), &rows, "SELECT 1"))
+	// The row read before the connection was dropped is not duplicated.
+	assert.Equal(t, []int{0, 1, 2}, rows)
+	assert.Equal(t, 2, conn.selects)
+}
+
+func TestShardSelectReconnectRetriesExhausted(t *testing.T) {
+	conn := &droppingConn{drops: 3}
+	shard := newTestShard(conn, 2)
+
+	rows := []int{}
+	err := shard.Select(context.Background(), &rows, "SELECT 1")
+	assert.True(t, errors.Is(err, syscall.ECONNRESET))
+	assert.Equal(t, 3, conn.selects)
+}
+
+func TestShardSelectNoReconnectOnCanceledContext(t *testing.T) {
+	conn := &droppingConn{drops: 1}
+	shard := newTestShard(conn, 1)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	rows := []int{}
+	assert.Error(t, shard.Select(ctx, &rows, "SELECT 1"))
+	assert.Equal(t, 1, conn.selects)
+}
+
+func TestShardExecDoesNotReconnect(t *testing.T) {
+	conn := &droppingConn{drops: 1}
+	shard := newTestShard(conn, 1)
+
+	assert.Error(t, shard.Exec(context.Background(), "INSERT INTO t VALUES (1)"))
+	assert.Equal(t, 1, conn.selects)
+}
+
+func TestShardWarmup(t *testing.T) {
+	conn := &droppingConn{}
+	shard := newTestShard(conn, 0)
+
+	require.NoError(t, shard.warmup(context.Background(), 4))
+	// All the connections are held at once and released afterwards.
+	assert.Equal(t, int64(4), conn.maxOpen.Load())
+	assert.Equal(t, int64(0), conn.open.Load())
+}
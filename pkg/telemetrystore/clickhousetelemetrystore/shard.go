@@ -0,0 +1,268 @@
+package clickhousetelemetrystore
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/ClickHouse/clickhouse-go/v2"
+	"github.com/ClickHouse/clickhouse-go/v2/lib/driver"
+	"github.com/SigNoz/signoz/pkg/secretstore"
+	"github.com/SigNoz/signoz/pkg/telemetrystore"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// shard is a connection to the clickhouse cluster of a shard which runs the hooks around every operation.
+type shard struct {
+	name           string
+	logger         *slog.Logger
+	clickHouseConn clickhouse.Conn
+	hooks          []telemetrystore.TelemetryStoreHook
+	waits          *waits
+	// reconnectRetries is the number of times a read failing on a dropped connection is retried.
+	reconnectRetries int
+	reconnects       metric.Int64Counter
+	// cancellations counts the reads canceled by the client before they completed.
+	cancellations metric.Int64Counter
+	// queryTimeouts are the timeouts of the reads by kind.
+	queryTimeouts telemetrystore.QueryTimeoutConfig
+	// queryMemory is the memory limits of the reads by tenant, explained in the errors of the reads killed by them.
+	queryMemory telemetrystore.QueryMemoryConfig
+	// asyncInsert is the asynchronous inserts configuration of the batches.
+	asyncInsert telemetrystore.AsyncInsertConfig
+	// inserts counts the sends of the batches by insert mode.
+	inserts    metric.Int64Counter
+	attributes metric.MeasurementOption
+}
+
+func newShard(logger *slog.Logger, name string, dsn string, password *secretstore.Value, tlsReloader *tlsReloader, config telemetrystore.Config, hooks []telemetrystore.TelemetryStoreHook, reconnects metric.Int64Counter, cancellations metric.Int64Counter, inserts metric.Int64Counter) (*shard, error) {
+	options, err := clickhouse.ParseDSN(dsn)
+	if err != nil {
+		return nil, err
+	}
+	options.MaxIdleConns = config.Connection.MaxIdleConns
+	options.MaxOpenConns = config.Connection.MaxOpenConns
+	options.DialTimeout = config.Connection.DialTimeout
+
+	if tlsReloader != nil {
+		options.TLS = tlsReloader.Get()
+	}
+
+	// The password and the certificates are read on every dial so that the connections opened after a rotation use
+	// the new ones.
+	if password != nil || tlsReloader != nil {
+		options.DialStrategy = func(ctx context.Context, connID int, options *clickhouse.Options, dial clickhouse.Dial) (clickhouse.DialResult, error) {
+			dialOptions := *options
+			if password != nil {
+				dialOptions.Auth.Password = password.Get(ctx)
+			}
+			if tlsReloader != nil {
+				dialOptions.TLS = tlsReloader.Get()
+			}
+			return clickhouse.DefaultDialStrategy(ctx, connID, &dialOptions, dial)
+		}
+	}
+
+	chConn, err := clickhouse.Open(options)
+	if err != nil {
+		return nil, err
+	}
+
+	return &shard{
+		name:             name,
+		logger:           logger,
+		clickHouseConn:   chConn,
+		hooks:            hooks,
+		waits:            newWaits(chConn.Stats().MaxOpenConns, options.DialTimeout),
+		reconnectRetries: config.Connection.ReconnectRetries,
+		reconnects:       reconnects,
+		cancellations:    cancellations,
+		queryTimeouts:    config.QueryTimeout,
+		queryMemory:      config.QueryMemory,
+		asyncInsert:      config.AsyncInsert,
+		inserts:          inserts,
+		attributes:       metric.WithAttributes(attribute.String("telemetrystore.name", config.Name), attribute.String("telemetrystore.shard", name)),
+	}, nil
+}
+
+func (s *shard) Close() error {
+	return s.clickHouseConn.Close()
+}
+
+func (s *shard) Ping(ctx context.Context) error {
+	return s.retry(ctx, func() error {
+		return s.clickHouseConn.Ping(ctx)
+	})
+}
+
+func (s *shard) Stats() driver.Stats {
+	return s.clickHouseConn.Stats()
+}
+
+func (s *shard) Query(ctx context.Context, query string, args ...interface{}) (driver.Rows, error) {
+	event := telemetrystore.NewQueryEvent(query, args)
+
+	ctx, timeout, cancel := s.withQueryTimeout(ctx)
+	ctx = telemetrystore.WrapBeforeQuery(s.hooks, ctx, event)
+	release, err := s.waits.acquire(ctx)
+	var rows driver.Rows
+	if err == nil {
+		err = s.retry(ctx, func() error {
+			readCtx, stop := s.killOnCancel(ctx, event)
+			var err error
+			rows, err = s.clickHouseConn.Query(readCtx, query, args...)
+			if err != nil {
+				stop()
+				return err
+			}
+
+			// The timeout keeps bounding the read, and the connection is held, until its rows are closed.
+			rows = &queryMemoryRows{Rows: &killOnCancelRows{Rows: rows, stop: func() { stop(); cancel(); release() }}, ctx: ctx, shard: s}
+			return nil
+		})
+	}
+
+	if err != nil {
+		cancel()
+		release()
+	}
+
+	err = s.wrapQueryMemory(ctx, timeout.wrap(ctx, err))
+	event.Err = err
+	telemetrystore.WrapAfterQuery(s.hooks, ctx, event)
+
+	return rows, err
+}
+
+func (s *shard) QueryRow(ctx context.Context, query string, args ...interface{}) driver.Row {
+	event := telemetrystore.NewQueryEvent(query, args)
+
+	// The row is read on scan, hence its timeout is canceled once the row is scanned rather than here.
+	ctx, timeout, cancel := s.withQueryTimeout(ctx)
+	ctx = telemetrystore.WrapBeforeQuery(s.hooks, ctx, event)
+	var row driver.Row
+	release, err := s.waits.acquire(ctx)
+	if err != nil {
+		row = &errorRow{err: err}
+	} else {
+		_ = s.retry(ctx, func() error {
+			readCtx, stop := s.killOnCancel(ctx, event)
+			defer stop()
+
+			row = s.clickHouseConn.QueryRow(readCtx, query, args...)
+			return row.Err()
+		})
+
+		// The driver holds the connection of the row until it is scanned.
+		if row.Err() != nil {
+			release()
+		} else {
+			row = &releaseRow{Row: row, release: release}
+		}
+	}
+
+	if row.Err() != nil {
+		cancel()
+	}
+
+	if timeout != nil {
+		row = &timeoutRow{Row: row, ctx: ctx, timeout: timeout, cancel: cancel}
+	}
+	row = &queryMemoryRow{Row: row, ctx: ctx, shard: s}
+
+	event.Err = row.Err()
+	telemetrystore.WrapAfterQuery(s.hooks, ctx, event)
+
+	return row
+}
+
+func (s *shard) Select(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+	event := telemetrystore.NewQueryEvent(query, args)
+
+	ctx, timeout, cancel := s.withQueryTimeout(ctx)
+	defer cancel()
+
+	ctx = telemetrystore.WrapBeforeQuery(s.hooks, ctx, event)
+	release, err := s.waits.acquire(ctx)
+	if err == nil {
+		reset := resetSlice(dest)
+		err = s.retry(ctx, func() error {
+			readCtx, stop := s.killOnCancel(ctx, event)
+			defer stop()
+
+			reset()
+			return s.clickHouseConn.Select(readCtx, dest, query, args...)
+		})
+		release()
+	}
+
+	err = s.wrapQueryMemory(ctx, timeout.wrap(ctx, err))
+	event.Err = err
+	telemetrystore.WrapAfterQuery(s.hooks, ctx, event)
+
+	return err
+}
+
+func (s *shard) Exec(ctx context.Context, query string, args ...interface{}) error {
+	event := telemetrystore.NewQueryEvent(query, args)
+
+	ctx = telemetrystore.WrapBeforeQuery(s.hooks, ctx, event)
+	release, err := s.waits.acquire(ctx)
+	if err == nil {
+		err = s.clickHouseConn.Exec(ctx, query, args...)
+		release()
+	}
+
+	event.Err = err
+	telemetrystore.WrapAfterQuery(s.hooks, ctx, event)
+
+	return err
+}
+
+func (s *shard) AsyncInsert(ctx context.Context, query string, wait bool, args ...interface{}) error {
+	event := telemetrystore.NewQueryEvent(query, args)
+
+	ctx = telemetrystore.WrapBeforeQuery(s.hooks, ctx, event)
+	release, err := s.waits.acquire(ctx)
+	if err == nil {
+		err = s.clickHouseConn.AsyncInsert(ctx, query, wait, args...)
+		release()
+	}
+
+	event.Err = err
+	telemetrystore.WrapAfterQuery(s.hooks, ctx, event)
+
+	return err
+}
+
+func (s *shard) PrepareBatch(ctx context.Context, query string, opts ...driver.PrepareBatchOption) (driver.Batch, error) {
+	event := telemetrystore.NewQueryEvent(query, nil)
+
+	ctx = telemetrystore.WrapBeforeQuery(s.hooks, ctx, event)
+	ctx, mode := s.withInsertMode(ctx)
+	release, err := s.waits.acquire(ctx)
+	var batch driver.Batch
+	if err == nil {
+		batch, err = s.clickHouseConn.PrepareBatch(ctx, query, opts...)
+		if err != nil {
+			release()
+		}
+	}
+
+	event.Err = err
+	telemetrystore.WrapAfterQuery(s.hooks, ctx, event)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return s.newInsertBatch(ctx, batch, mode, release), nil
+}
+
+func (s *shard) ServerVersion() (*driver.ServerVersion, error) {
+	return s.clickHouseConn.ServerVersion()
+}
+
+func (s *shard) Contributors() []string {
+	return s.clickHouseConn.Contributors()
+}
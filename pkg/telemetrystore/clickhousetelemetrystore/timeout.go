@@ -0,0 +1,90 @@
+package clickhousetelemetrystore
+
+import (
+	"context"
+	"time"
+
+	"github.com/ClickHouse/clickhouse-go/v2/lib/driver"
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/query-service/common"
+)
+
+const (
+	// defaultQueryKind is the kind of the reads whose log comment has no source.
+	defaultQueryKind string = "default"
+)
+
+var (
+	ErrCodeQueryTimeout = errors.MustNewCode("query_timeout")
+)
+
+// queryKind returns the kind of the read, which is the source of its log comment, for example dashboards or alerts.
+func queryKind(ctx context.Context) string {
+	kvs, ok := ctx.Value(common.LogCommentKey).(map[string]string)
+	if !ok || kvs["source"] == "" {
+		return defaultQueryKind
+	}
+
+	return kvs["source"]
+}
+
+// queryTimeout is the timeout bounding a read.
+type queryTimeout struct {
+	kind    string
+	timeout time.Duration
+	// cause is the cause of the context of the read once the timeout fired.
+	cause error
+}
+
+// withQueryTimeout bounds the read by the timeout of its kind. The driver derives max_execution_time from the
+// deadline so that the read is also bounded on the server.
+func (s *shard) withQueryTimeout(ctx context.Context) (context.Context, *queryTimeout, context.CancelFunc) {
+	kind := queryKind(ctx)
+	timeout, ok := s.queryTimeouts.Kinds[kind]
+	if !ok {
+		timeout = s.queryTimeouts.Default
+	}
+
+	if timeout <= 0 {
+		return ctx, nil, func() {}
+	}
+
+	qt := &queryTimeout{kind: kind, timeout: timeout}
+	qt.cause = errors.Newf(errors.TypeTimeout, ErrCodeQueryTimeout, "%s query exceeded its timeout of %s", kind, timeout)
+
+	ctx, cancel := context.WithTimeoutCause(ctx, timeout, qt.cause)
+	return ctx, qt, cancel
+}
+
+// wrap returns an error of type TypeTimeout naming the kind of the read if err was caused by its timeout, rather
+// than by the deadline of the caller, and err otherwise.
+func (qt *queryTimeout) wrap(ctx context.Context, err error) error {
+	if err == nil || qt == nil || context.Cause(ctx) != qt.cause {
+		return err
+	}
+
+	return errors.Wrapf(err, errors.TypeTimeout, ErrCodeQueryTimeout, "%s query exceeded its timeout of %s", qt.kind, qt.timeout)
+}
+
+// timeoutRow names the kind of the read in the errors caused by its timeout, which is canceled once the row is
+// scanned.
+type timeoutRow struct {
+	driver.Row
+	ctx     context.Context
+	timeout *queryTimeout
+	cancel  context.CancelFunc
+}
+
+func (row *timeoutRow) Err() error {
+	return row.timeout.wrap(row.ctx, row.Row.Err())
+}
+
+func (row *timeoutRow) Scan(dest ...any) error {
+	defer row.cancel()
+	return row.timeout.wrap(row.ctx, row.Row.Scan(dest...))
+}
+
+func (row *timeoutRow) ScanStruct(dest any) error {
+	defer row.cancel()
+	return row.timeout.wrap(row.ctx, row.Row.ScanStruct(dest))
+}
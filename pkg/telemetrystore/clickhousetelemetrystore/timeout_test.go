@@ -0,0 +1,81 @@
+package clickhousetelemetrystore
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ClickHouse/clickhouse-go/v2"
+	"github.com/ClickHouse/clickhouse-go/v2/lib/driver"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/query-service/common"
+	"github.com/SigNoz/signoz/pkg/telemetrystore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShardQueryTimeout(t *testing.T) {
+	testCases := []struct {
+		name    string
+		source  string
+		timeout bool
+	}{
+		{name: "KindTimeout", source: "dashboards", timeout: true},
+		{name: "DefaultTimeout", source: "", timeout: true},
+		{name: "DisabledKind", source: "alerts", timeout: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			shard := newTestShard(&longConn{killed: make(chan []any, 1)}, 0)
+			shard.queryTimeouts = telemetrystore.QueryTimeoutConfig{
+				Default: 10 * time.Millisecond,
+				Kinds:   map[string]time.Duration{"dashboards": 10 * time.Millisecond, "alerts": 0},
+			}
+
+			ctx := context.WithValue(context.Background(), common.LogCommentKey, map[string]string{"source": tc.source})
+			ctx, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
+			defer cancel()
+
+			rows := []int{}
+			err := shard.Select(ctx, &rows, "SELECT sleep(3600)")
+			assert.ErrorIs(t, err, context.DeadlineExceeded)
+			assert.Equal(t, tc.timeout, errors.Asc(err, ErrCodeQueryTimeout))
+		})
+	}
+}
+
+// rowConn returns rows recording the context of their read.
+type rowConn struct {
+	clickhouse.Conn
+	ctx context.Context
+}
+
+func (conn *rowConn) QueryRow(ctx context.Context, _ string, _ ...interface{}) driver.Row {
+	conn.ctx = ctx
+	return &errorRow{}
+}
+
+func (conn *rowConn) Stats() driver.Stats {
+	return driver.Stats{MaxOpenConns: 10}
+}
+
+func TestShardQueryRowCancelsTimeoutOnScan(t *testing.T) {
+	conn := &rowConn{}
+	shard := newTestShard(conn, 0)
+	shard.queryTimeouts = telemetrystore.QueryTimeoutConfig{Default: time.Hour}
+
+	row := shard.QueryRow(context.Background(), "SELECT 1")
+	require.NotNil(t, conn.ctx)
+	assert.NoError(t, conn.ctx.Err())
+
+	var value int
+	assert.NoError(t, row.Scan(&value))
+	assert.ErrorIs(t, conn.ctx.Err(), context.Canceled)
+}
+
+func TestQueryKind(t *testing.T) {
+	assert.Equal(t, "default", queryKind(context.Background()))
+	assert.Equal(t, "alerts", queryKind(context.WithValue(context.Background(), common.LogCommentKey, map[string]string{"source": "alerts"})))
+}
@@ -0,0 +1,135 @@
+package clickhousetelemetrystore
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/telemetrystore"
+)
+
+// tlsReloader holds the TLS configuration of the connections to clickhouse. The certificates are loaded again when
+// their files change, so that the connections opened after a rotation use the new certificates without a restart.
+type tlsReloader struct {
+	logger *slog.Logger
+	config telemetrystore.TLSConfig
+
+	mu        sync.Mutex
+	modTimes  map[string]time.Time
+	tlsConfig *tls.Config
+}
+
+// newTLSReloader loads the certificates of the config. It returns nil when TLS is not enabled, the TLS settings of
+// the DSNs are used then.
+func newTLSReloader(logger *slog.Logger, config telemetrystore.TLSConfig) (*tlsReloader, error) {
+	if !config.Enabled {
+		return nil, nil
+	}
+
+	reloader := &tlsReloader{logger: logger, config: config}
+	modTimes, err := reloader.stat()
+	if err != nil {
+		return nil, err
+	}
+
+	tlsConfig, err := loadTLSConfig(config)
+	if err != nil {
+		return nil, err
+	}
+
+	reloader.modTimes = modTimes
+	reloader.tlsConfig = tlsConfig
+	return reloader, nil
+}
+
+// Get returns the TLS configuration, loading the certificates again if one of their files was modified. The
+// previous configuration is kept if they cannot be loaded, for instance while the files are being rotated.
+func (reloader *tlsReloader) Get() *tls.Config {
+	reloader.mu.Lock()
+	defer reloader.mu.Unlock()
+
+	modTimes, err := reloader.stat()
+	if err != nil {
+		reloader.logger.Warn("failed to check the tls files of clickhouse, using the loaded certificates", "error", err)
+		return reloader.tlsConfig
+	}
+
+	changed := false
+	for path, modTime := range modTimes {
+		if !modTime.Equal(reloader.modTimes[path]) {
+			changed = true
+			break
+		}
+	}
+
+	if !changed {
+		return reloader.tlsConfig
+	}
+
+	tlsConfig, err := loadTLSConfig(reloader.config)
+	if err != nil {
+		reloader.logger.Warn("failed to reload the tls files of clickhouse, using the loaded certificates", "error", err)
+		return reloader.tlsConfig
+	}
+
+	reloader.logger.Info("reloaded the tls files of clickhouse")
+	reloader.modTimes = modTimes
+	reloader.tlsConfig = tlsConfig
+	return tlsConfig
+}
+
+// stat returns the modification times of the files of the config.
+func (reloader *tlsReloader) stat() (map[string]time.Time, error) {
+	modTimes := make(map[string]time.Time, 3)
+	for _, path := range []string{reloader.config.CAFile, reloader.config.CertFile, reloader.config.KeyFile} {
+		if path == "" {
+			continue
+		}
+
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, errors.Wrapf(err, errors.TypeInvalidInput, errors.CodeInvalidInput, "cannot read the tls file %q", path)
+		}
+
+		modTimes[path] = info.ModTime()
+	}
+
+	return modTimes, nil
+}
+
+func loadTLSConfig(config telemetrystore.TLSConfig) (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		ServerName: config.ServerName,
+		MinVersion: tls.VersionTLS12,
+	}
+
+	if config.CAFile != "" {
+		ca, err := os.ReadFile(config.CAFile)
+		if err != nil {
+			return nil, errors.Wrapf(err, errors.TypeInvalidInput, errors.CodeInvalidInput, "cannot read the ca bundle %q", config.CAFile)
+		}
+
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(ca) {
+			return nil, errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "the ca bundle %q contains no pem encoded certificate", config.CAFile)
+		}
+
+		tlsConfig.RootCAs = pool
+	}
+
+	if config.CertFile != "" {
+		certificate, err := tls.LoadX509KeyPair(config.CertFile, config.KeyFile)
+		if err != nil {
+			// The cause is part of the message as it does not name the files.
+			return nil, errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "cannot load the client certificate %q with the key %q, check that they are pem encoded and that the key is the key of the certificate: %v", config.CertFile, config.KeyFile, err)
+		}
+
+		tlsConfig.Certificates = []tls.Certificate{certificate}
+	}
+
+	return tlsConfig, nil
+}
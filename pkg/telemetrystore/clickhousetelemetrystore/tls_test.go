@@ -0,0 +1,97 @@
+package clickhousetelemetrystore
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io"
+	"log/slog"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/telemetrystore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeCertificate writes a self signed certificate and its key as pem files and returns their paths.
+func writeCertificate(t *testing.T, dir string, name string) (string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	certFile := filepath.Join(dir, name+".pem")
+	keyFile := filepath.Join(dir, name+"-key.pem")
+	require.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600))
+
+	return certFile, keyFile
+}
+
+func TestTLSReloader(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	dir := t.TempDir()
+	caFile, _ := writeCertificate(t, dir, "ca")
+	certFile, keyFile := writeCertificate(t, dir, "client")
+
+	reloader, err := newTLSReloader(logger, telemetrystore.TLSConfig{})
+	require.NoError(t, err)
+	assert.Nil(t, reloader)
+
+	config := telemetrystore.TLSConfig{Enabled: true, CAFile: caFile, CertFile: certFile, KeyFile: keyFile, ServerName: "clickhouse.internal"}
+	reloader, err = newTLSReloader(logger, config)
+	require.NoError(t, err)
+
+	tlsConfig := reloader.Get()
+	assert.Equal(t, "clickhouse.internal", tlsConfig.ServerName)
+	assert.NotNil(t, tlsConfig.RootCAs)
+	require.Len(t, tlsConfig.Certificates, 1)
+	assert.Same(t, tlsConfig, reloader.Get())
+
+	// The rotated certificate is loaded on the next dial.
+	writeCertificate(t, dir, "client")
+	later := time.Now().Add(time.Minute)
+	require.NoError(t, os.Chtimes(certFile, later, later))
+	require.NoError(t, os.Chtimes(keyFile, later, later))
+
+	rotated := reloader.Get()
+	require.Len(t, rotated.Certificates, 1)
+	assert.NotEqual(t, tlsConfig.Certificates[0].Certificate, rotated.Certificates[0].Certificate)
+
+	// A broken rotation keeps the loaded certificate.
+	require.NoError(t, os.WriteFile(certFile, []byte("garbage"), 0o600))
+	evenLater := later.Add(time.Minute)
+	require.NoError(t, os.Chtimes(certFile, evenLater, evenLater))
+	assert.Same(t, rotated, reloader.Get())
+}
+
+func TestTLSReloaderMismatchedKey(t *testing.T) {
+	dir := t.TempDir()
+	certFile, _ := writeCertificate(t, dir, "client")
+	_, otherKeyFile := writeCertificate(t, dir, "other")
+
+	_, err := newTLSReloader(slog.New(slog.NewTextHandler(io.Discard, nil)), telemetrystore.TLSConfig{Enabled: true, CertFile: certFile, KeyFile: otherKeyFile})
+	require.Error(t, err)
+	assert.True(t, errors.Ast(err, errors.TypeInvalidInput))
+	assert.Contains(t, err.Error(), "client.pem")
+}
@@ -3,6 +3,7 @@ package telemetrystore
 import (
 	"time"
 
+	"github.com/SigNoz/signoz/pkg/errors"
 	"github.com/SigNoz/signoz/pkg/factory"
 )
 
@@ -10,11 +11,54 @@ type Config struct {
 	// Provider is the provider to use
 	Provider string `mapstructure:"provider"`
 
+	// Name is the name of the telemetry store. It is used to label the metrics of the telemetry store.
+	Name string `mapstructure:"name"`
+
 	// Connection is the connection configuration
 	Connection ConnectionConfig `mapstructure:",squash"`
 
 	// Clickhouse is the clickhouse configuration
 	Clickhouse ClickhouseConfig `mapstructure:"clickhouse"`
+
+	// Ingestion is the ingestion limits configuration
+	Ingestion IngestionConfig `mapstructure:"ingestion"`
+
+	// Cardinality is the configuration of the limits on the number of series of the metrics
+	Cardinality CardinalityConfig `mapstructure:"cardinality"`
+
+	// Shards are the clickhouse clusters holding the data of the tenants routed to them, in addition to the
+	// default shard connected to clickhouse::dsn.
+	Shards []ShardConfig `mapstructure:"shards"`
+
+	// Routing is the routing of the tenants to the shards
+	Routing RoutingConfig `mapstructure:"routing"`
+
+	// Retention is the retention of the data of the tenants
+	Retention RetentionConfig `mapstructure:"retention"`
+
+	// SlowQuery is the slow query logging configuration
+	SlowQuery SlowQueryConfig `mapstructure:"slow_query"`
+
+	// Batch is the batch inserts configuration
+	Batch BatchConfig `mapstructure:"batch"`
+
+	// AsyncInsert is the asynchronous inserts configuration
+	AsyncInsert AsyncInsertConfig `mapstructure:"async_insert"`
+
+	// QueryTimeout is the timeout of the reads by kind of query
+	QueryTimeout QueryTimeoutConfig `mapstructure:"query_timeout"`
+
+	// Schema is the schema introspection configuration
+	Schema SchemaConfig `mapstructure:"schema"`
+
+	// QueryBudget is the budget of the data read by the queries of the tenants
+	QueryBudget QueryBudgetConfig `mapstructure:"query_budget"`
+
+	// QueryMemory is the memory limit of the queries of the tenants
+	QueryMemory QueryMemoryConfig `mapstructure:"query_memory"`
+
+	// Deletion is the configuration of the deletions of series by label matchers
+	Deletion DeletionConfig `mapstructure:"deletion"`
 }
 
 type ConnectionConfig struct {
@@ -26,6 +70,17 @@ type ConnectionConfig struct {
 
 	// DialTimeout is the timeout for dialing a new connection.
 	DialTimeout time.Duration `mapstructure:"dial_timeout"`
+
+	// WarmupConns is the number of connections of every shard opened at startup so that the first operations do
+	// not pay for dialing. 0 disables the warmup.
+	WarmupConns int `mapstructure:"warmup_conns"`
+
+	// ReconnectRetries is the number of times a read failing because its connection was dropped is retried on
+	// another connection. Writes are never retried. 0 disables the retries.
+	ReconnectRetries int `mapstructure:"reconnect_retries"`
+
+	// ConnectRetry is the retry policy of the initial connection to every shard.
+	ConnectRetry factory.ConnectRetryConfig `mapstructure:"connect_retry"`
 }
 
 type QuerySettings struct {
@@ -40,8 +95,231 @@ type ClickhouseConfig struct {
 	// DSN is the database source name.
 	DSN string `mapstructure:"dsn"`
 
+	// Password is the password of the user of the DSN. It takes precedence over the password of the DSN and may
+	// reference a secret of the secret store.
+	Password string `mapstructure:"password"`
+
 	// QuerySettings is the query settings for clickhouse.
 	QuerySettings QuerySettings `mapstructure:"settings"`
+
+	// TLS is the TLS configuration of the connections to every shard.
+	TLS TLSConfig `mapstructure:"tls"`
+}
+
+type TLSConfig struct {
+	// Enabled enables TLS with the files below, replacing the TLS settings of the DSNs.
+	Enabled bool `mapstructure:"enabled"`
+
+	// CAFile is the path of the bundle of the certificate authorities verifying the servers. The certificate
+	// authorities of the system are used when it is empty.
+	CAFile string `mapstructure:"ca_file"`
+
+	// CertFile is the path of the client certificate presented to the servers for mutual TLS.
+	CertFile string `mapstructure:"cert_file"`
+
+	// KeyFile is the path of the key of the client certificate.
+	KeyFile string `mapstructure:"key_file"`
+
+	// ServerName overrides the name against which the certificates of the servers are verified, which is the host
+	// of the DSN by default.
+	ServerName string `mapstructure:"server_name"`
+}
+
+type IngestionConfig struct {
+	// Enabled enables the ingestion limits.
+	Enabled bool `mapstructure:"enabled"`
+
+	// Window is the duration of the sliding window over which the limits are enforced.
+	Window time.Duration `mapstructure:"window"`
+
+	// Default is the limit applied to the tenants which are not in tenants.
+	Default IngestionLimit `mapstructure:"default"`
+
+	// Tenants are the limits of specific tenants keyed by the tenant id.
+	Tenants map[string]IngestionLimit `mapstructure:"tenants"`
+}
+
+type IngestionLimit struct {
+	// BytesPerSecond is the maximum number of bytes ingested per second. 0 means unlimited.
+	BytesPerSecond int64 `mapstructure:"bytes_per_second"`
+
+	// SamplesPerSecond is the maximum number of samples ingested per second. 0 means unlimited.
+	SamplesPerSecond int64 `mapstructure:"samples_per_second"`
+}
+
+type CardinalityConfig struct {
+	// Enabled enables the limits on the number of series of the metrics. The series are counted by each replica in
+	// memory, hence the limits apply per replica and the counts are reset when a replica restarts.
+	Enabled bool `mapstructure:"enabled"`
+
+	// Window is the duration after which the series which are no longer written stop counting against the limit of
+	// their metric. A series is counted while it was written in the current or the previous window.
+	Window time.Duration `mapstructure:"window"`
+
+	// MaxSeriesPerMetric is the maximum number of series of a metric of a tenant which is not in metrics. 0 means
+	// unlimited.
+	MaxSeriesPerMetric int `mapstructure:"max_series_per_metric"`
+
+	// MaxMetricsPerTenant is the maximum number of limited metrics of a tenant tracked at once. The series of the new
+	// metrics of a tenant at this limit are not ingested. 0 means unlimited.
+	MaxMetricsPerTenant int `mapstructure:"max_metrics_per_tenant"`
+
+	// Metrics are the limits of specific metrics keyed by the metric name. 0 means unlimited.
+	Metrics map[string]int `mapstructure:"metrics"`
+
+	// Allowlist are the names of the metrics whose cardinality is intentionally high, they are never limited.
+	Allowlist []string `mapstructure:"allowlist"`
+
+	// Action is what happens to the new series of the metrics which reached their limit, either drop or reject.
+	// Both write the other series of the request, reject also fails the request with a 400.
+	Action string `mapstructure:"action"`
+}
+
+type ShardConfig struct {
+	// Name is the name of the shard. It is used by the routing and to label the metrics of the shard.
+	Name string `mapstructure:"name"`
+
+	// DSN is the database source name of the clickhouse cluster of the shard.
+	DSN string `mapstructure:"dsn"`
+
+	// Password is the password of the user of the DSN. It takes precedence over the password of the DSN and may
+	// reference a secret of the secret store.
+	Password string `mapstructure:"password"`
+}
+
+type RoutingConfig struct {
+	// Tenants maps the tenant ids to the name of the shard holding their data. The tenants which are not in
+	// tenants are routed to the default shard.
+	Tenants map[string]string `mapstructure:"tenants"`
+}
+
+type RetentionConfig struct {
+	// Enabled enables the retention managed by the config. The TTL of the tables of every shard is then set at
+	// startup, replacing the retention set from the UI which is refused for the managed signals.
+	Enabled bool `mapstructure:"enabled"`
+
+	// Cluster is the name of the clickhouse cluster of the shards on which the TTL of the tables is modified.
+	Cluster string `mapstructure:"cluster"`
+
+	// Interval is the interval at which the TTL of the tables is set again, such as after it was modified outside of
+	// the config. The TTL is also set at startup.
+	Interval time.Duration `mapstructure:"interval"`
+
+	// Default is the retention of the tenants which are not in tenants.
+	Default RetentionPolicy `mapstructure:"default"`
+
+	// Tenants are the retentions of specific tenants keyed by the tenant id. They apply per shard, see Retention.
+	Tenants map[string]RetentionPolicy `mapstructure:"tenants"`
+}
+
+type RetentionPolicy struct {
+	// Traces is the time for which the traces are kept. 0 leaves the retention of the traces unmanaged.
+	Traces time.Duration `mapstructure:"traces"`
+
+	// Logs is the time for which the logs are kept. 0 leaves the retention of the logs unmanaged.
+	Logs time.Duration `mapstructure:"logs"`
+
+	// Metrics is the time for which the metrics are kept. 0 leaves the retention of the metrics unmanaged.
+	Metrics time.Duration `mapstructure:"metrics"`
+}
+
+type SlowQueryConfig struct {
+	// Threshold is the duration above which a query is logged as slow. 0 disables the slow query logging.
+	Threshold time.Duration `mapstructure:"threshold"`
+}
+
+type BatchConfig struct {
+	// Partial enables the partial writes of the batch inserts. The rows which cannot be inserted are rejected and
+	// the other rows are committed, instead of failing the whole batch.
+	Partial bool `mapstructure:"partial"`
+
+	// SubBatchSize is the maximum number of rows sent at once by a partial write.
+	SubBatchSize int `mapstructure:"sub_batch_size"`
+}
+
+type AsyncInsertConfig struct {
+	// Enabled enables the asynchronous inserts of the batches. Clickhouse buffers the inserted rows and flushes them
+	// together, which creates fewer parts than many small synchronous inserts.
+	Enabled bool `mapstructure:"enabled"`
+
+	// Wait makes the inserts wait for their rows to be flushed, so that the errors of the flush are returned. Without
+	// waiting, an insert returns once its rows are buffered and the errors of the flush are only logged by clickhouse.
+	Wait bool `mapstructure:"wait"`
+}
+
+type QueryTimeoutConfig struct {
+	// Default is the timeout of the reads whose kind is not in kinds. 0 leaves them bounded by the deadline of
+	// their caller only.
+	Default time.Duration `mapstructure:"default"`
+
+	// Kinds are the timeouts of the reads keyed by their kind, which is the source of their log comment, for
+	// example dashboards or alerts. The reads without a source are of kind default. 0 disables the timeout of a kind.
+	Kinds map[string]time.Duration `mapstructure:"kinds"`
+}
+
+type SchemaConfig struct {
+	// RefreshInterval is the time for which the introspected tables of a signal are cached before being fetched
+	// again.
+	RefreshInterval time.Duration `mapstructure:"refresh_interval"`
+}
+
+type QueryBudgetConfig struct {
+	// Enabled enables the estimation of the queries before they are run, using EXPLAIN ESTIMATE.
+	Enabled bool `mapstructure:"enabled"`
+
+	// Action is what happens to the queries estimated above the budget of their tenant, either warn or reject.
+	Action string `mapstructure:"action"`
+
+	// Default is the budget of the tenants which are not in tenants.
+	Default QueryBudget `mapstructure:"default"`
+
+	// Tenants are the budgets of specific tenants keyed by the tenant id.
+	Tenants map[string]QueryBudget `mapstructure:"tenants"`
+}
+
+type QueryBudget struct {
+	// Rows is the maximum number of rows read by a request. 0 means unlimited.
+	Rows int64 `mapstructure:"rows"`
+
+	// Bytes is the maximum number of uncompressed bytes read by a request. 0 means unlimited.
+	Bytes int64 `mapstructure:"bytes"`
+}
+
+type QueryMemoryConfig struct {
+	// Enabled enables the memory limits, which are passed to clickhouse in the settings of every query.
+	Enabled bool `mapstructure:"enabled"`
+
+	// Default is the limit of the tenants which are not in tenants.
+	Default QueryMemoryLimit `mapstructure:"default"`
+
+	// Tenants are the limits of specific tenants keyed by the tenant id.
+	Tenants map[string]QueryMemoryLimit `mapstructure:"tenants"`
+}
+
+type QueryMemoryLimit struct {
+	// MaxMemoryUsage is the maximum number of bytes of memory used by a query on a server, above which the query
+	// is killed. 0 leaves the setting of the server.
+	MaxMemoryUsage int64 `mapstructure:"max_memory_usage"`
+
+	// MaxBytesBeforeExternalGroupBy is the number of bytes of memory used by the group by of a query above which
+	// it spills to disk. 0 leaves the setting of the server.
+	MaxBytesBeforeExternalGroupBy int64 `mapstructure:"max_bytes_before_external_group_by"`
+}
+
+type DeletionConfig struct {
+	// Cluster is the name of the clickhouse cluster of the shards on which the rows are deleted.
+	Cluster string `mapstructure:"cluster"`
+
+	// MaxSeries is the maximum number of series deleted at once. The deletions matching more series fail.
+	MaxSeries int `mapstructure:"max_series"`
+
+	// TenantLabel is the label of the series holding the id of their tenant. When set, the deletions only match the
+	// series of the tenant requesting them.
+	TenantLabel string `mapstructure:"tenant_label"`
+
+	// SingleTenant is true when the telemetry store holds the data of a single tenant, such as the deployments with
+	// a single organization. The deletions are then not scoped to the tenant.
+	SingleTenant bool `mapstructure:"single_tenant"`
 }
 
 func NewConfigFactory() factory.ConfigFactory {
@@ -51,18 +329,315 @@ func NewConfigFactory() factory.ConfigFactory {
 func newConfig() factory.Config {
 	return Config{
 		Provider: "clickhouse",
+		Name:     "default",
 		Connection: ConnectionConfig{
 			MaxOpenConns: 100,
 			MaxIdleConns: 50,
 			DialTimeout:  5 * time.Second,
+			// The connections are opened on demand by default.
+			WarmupConns:      0,
+			ReconnectRetries: 1,
+			ConnectRetry: factory.ConnectRetryConfig{
+				MaxDuration:    0,
+				InitialBackoff: time.Second,
+				MaxBackoff:     30 * time.Second,
+			},
 		},
 		Clickhouse: ClickhouseConfig{
 			DSN: "tcp://localhost:9000",
+			TLS: TLSConfig{
+				Enabled: false,
+			},
+		},
+		Ingestion: IngestionConfig{
+			Enabled: false,
+			Window:  time.Minute,
+			Default: IngestionLimit{
+				BytesPerSecond:   0,
+				SamplesPerSecond: 0,
+			},
+			Tenants: map[string]IngestionLimit{},
+		},
+		Cardinality: CardinalityConfig{
+			Enabled:             false,
+			Window:              time.Hour,
+			MaxSeriesPerMetric:  100000,
+			MaxMetricsPerTenant: 10000,
+			Metrics:             map[string]int{},
+			Allowlist:           []string{},
+			Action:              CardinalityActionReject,
+		},
+		Shards: []ShardConfig{},
+		Routing: RoutingConfig{
+			Tenants: map[string]string{},
+		},
+		Retention: RetentionConfig{
+			Enabled:  false,
+			Cluster:  "cluster",
+			Interval: time.Hour,
+			Default: RetentionPolicy{
+				Traces:  0,
+				Logs:    0,
+				Metrics: 0,
+			},
+			Tenants: map[string]RetentionPolicy{},
+		},
+		SlowQuery: SlowQueryConfig{
+			Threshold: 10 * time.Second,
+		},
+		Batch: BatchConfig{
+			Partial:      false,
+			SubBatchSize: 1000,
+		},
+		AsyncInsert: AsyncInsertConfig{
+			Enabled: false,
+			Wait:    true,
+		},
+		QueryTimeout: QueryTimeoutConfig{
+			Default: 0,
+			Kinds:   map[string]time.Duration{},
+		},
+		Schema: SchemaConfig{
+			RefreshInterval: 5 * time.Minute,
+		},
+		QueryBudget: QueryBudgetConfig{
+			Enabled: false,
+			Action:  QueryBudgetActionWarn,
+			Default: QueryBudget{
+				Rows:  0,
+				Bytes: 0,
+			},
+			Tenants: map[string]QueryBudget{},
+		},
+		QueryMemory: QueryMemoryConfig{
+			Enabled: false,
+			Default: QueryMemoryLimit{
+				MaxMemoryUsage:                0,
+				MaxBytesBeforeExternalGroupBy: 0,
+			},
+			Tenants: map[string]QueryMemoryLimit{},
+		},
+		Deletion: DeletionConfig{
+			Cluster:      "cluster",
+			MaxSeries:    100000,
+			TenantLabel:  "",
+			SingleTenant: false,
 		},
 	}
 
 }
 
 func (c Config) Validate() error {
+	if c.Connection.WarmupConns < 0 || c.Connection.WarmupConns > c.Connection.MaxIdleConns {
+		return errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "warmup_conns must be between 0 and max_idle_conns (%d), got %d", c.Connection.MaxIdleConns, c.Connection.WarmupConns)
+	}
+
+	if c.Connection.ReconnectRetries < 0 {
+		return errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "reconnect_retries must not be negative, got %d", c.Connection.ReconnectRetries)
+	}
+
+	if err := c.Connection.ConnectRetry.Validate(); err != nil {
+		return errors.Wrapf(err, errors.TypeInvalidInput, errors.CodeInvalidInput, "invalid connect_retry")
+	}
+
+	if c.Clickhouse.TLS.Enabled && (c.Clickhouse.TLS.CertFile == "") != (c.Clickhouse.TLS.KeyFile == "") {
+		return errors.New(errors.TypeInvalidInput, errors.CodeInvalidInput, "clickhouse::tls::cert_file and clickhouse::tls::key_file must be set together")
+	}
+
+	if c.SlowQuery.Threshold < 0 {
+		return errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "slow_query::threshold must not be negative, got %v", c.SlowQuery.Threshold)
+	}
+
+	if c.QueryTimeout.Default < 0 {
+		return errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "query_timeout::default must not be negative, got %v", c.QueryTimeout.Default)
+	}
+
+	for kind, timeout := range c.QueryTimeout.Kinds {
+		if timeout < 0 {
+			return errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "query_timeout::kinds must not be negative, got %v for %q", timeout, kind)
+		}
+	}
+
+	if c.Schema.RefreshInterval <= 0 {
+		return errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "schema::refresh_interval must be positive, got %v", c.Schema.RefreshInterval)
+	}
+
+	if c.Batch.Partial && c.Batch.SubBatchSize <= 0 {
+		return errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "batch::sub_batch_size must be positive when batch::partial is true, got %d", c.Batch.SubBatchSize)
+	}
+
+	shards := map[string]struct{}{DefaultShardName: {}}
+	for _, shard := range c.Shards {
+		if _, ok := shards[shard.Name]; ok || shard.Name == "" {
+			return errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "shards::name must be unique and not empty, got %q", shard.Name)
+		}
+
+		if shard.DSN == "" {
+			return errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "shards::dsn must not be empty for shard %q", shard.Name)
+		}
+
+		shards[shard.Name] = struct{}{}
+	}
+
+	for tenant, shard := range c.Routing.Tenants {
+		if _, ok := shards[shard]; !ok {
+			return errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "routing::tenants routes tenant %q to unknown shard %q", tenant, shard)
+		}
+	}
+
+	if c.Retention.Enabled {
+		if c.Retention.Cluster == "" {
+			return errors.New(errors.TypeInvalidInput, errors.CodeInvalidInput, "retention::cluster must not be empty")
+		}
+
+		if c.Retention.Interval <= 0 {
+			return errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "retention::interval must be positive, got %v", c.Retention.Interval)
+		}
+
+		if err := c.Retention.Default.validate(); err != nil {
+			return errors.Wrapf(err, errors.TypeInvalidInput, errors.CodeInvalidInput, "invalid retention::default")
+		}
+
+		for tenant, policy := range c.Retention.Tenants {
+			if err := policy.validate(); err != nil {
+				return errors.Wrapf(err, errors.TypeInvalidInput, errors.CodeInvalidInput, "invalid retention::tenants for %q", tenant)
+			}
+		}
+	}
+
+	if c.QueryBudget.Enabled {
+		if c.QueryBudget.Action != QueryBudgetActionWarn && c.QueryBudget.Action != QueryBudgetActionReject {
+			return errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "query_budget::action must be one of warn or reject, got %q", c.QueryBudget.Action)
+		}
+
+		if err := c.QueryBudget.Default.validate(); err != nil {
+			return errors.Wrapf(err, errors.TypeInvalidInput, errors.CodeInvalidInput, "invalid query_budget::default")
+		}
+
+		for tenant, budget := range c.QueryBudget.Tenants {
+			if err := budget.validate(); err != nil {
+				return errors.Wrapf(err, errors.TypeInvalidInput, errors.CodeInvalidInput, "invalid query_budget::tenants for %q", tenant)
+			}
+		}
+	}
+
+	if c.QueryMemory.Enabled {
+		if err := c.QueryMemory.Default.validate(); err != nil {
+			return errors.Wrapf(err, errors.TypeInvalidInput, errors.CodeInvalidInput, "invalid query_memory::default")
+		}
+
+		for tenant, limit := range c.QueryMemory.Tenants {
+			if err := limit.validate(); err != nil {
+				return errors.Wrapf(err, errors.TypeInvalidInput, errors.CodeInvalidInput, "invalid query_memory::tenants for %q", tenant)
+			}
+		}
+	}
+
+	if c.Deletion.Cluster == "" {
+		return errors.New(errors.TypeInvalidInput, errors.CodeInvalidInput, "deletion::cluster must not be empty")
+	}
+
+	if c.Deletion.MaxSeries <= 0 {
+		return errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "deletion::max_series must be positive, got %d", c.Deletion.MaxSeries)
+	}
+
+	if c.Cardinality.Enabled {
+		if c.Cardinality.Window <= 0 {
+			return errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "cardinality::window must be positive, got %v", c.Cardinality.Window)
+		}
+
+		if c.Cardinality.MaxSeriesPerMetric < 0 {
+			return errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "cardinality::max_series_per_metric must not be negative, got %d", c.Cardinality.MaxSeriesPerMetric)
+		}
+
+		if c.Cardinality.MaxMetricsPerTenant < 0 {
+			return errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "cardinality::max_metrics_per_tenant must not be negative, got %d", c.Cardinality.MaxMetricsPerTenant)
+		}
+
+		for metricName, limit := range c.Cardinality.Metrics {
+			if limit < 0 {
+				return errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "cardinality::metrics must not be negative, got %d for %q", limit, metricName)
+			}
+		}
+
+		if c.Cardinality.Action != CardinalityActionDrop && c.Cardinality.Action != CardinalityActionReject {
+			return errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "cardinality::action must be one of drop or reject, got %q", c.Cardinality.Action)
+		}
+	}
+
+	if !c.Ingestion.Enabled {
+		return nil
+	}
+
+	if c.Ingestion.Window <= 0 {
+		return errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "ingestion::window must be positive, got %v", c.Ingestion.Window)
+	}
+
+	if err := c.Ingestion.Default.validate(); err != nil {
+		return errors.Wrapf(err, errors.TypeInvalidInput, errors.CodeInvalidInput, "invalid ingestion::default")
+	}
+
+	for tenant, limit := range c.Ingestion.Tenants {
+		if err := limit.validate(); err != nil {
+			return errors.Wrapf(err, errors.TypeInvalidInput, errors.CodeInvalidInput, "invalid ingestion::tenants for %q", tenant)
+		}
+	}
+
+	return nil
+}
+
+func (l IngestionLimit) validate() error {
+	if l.BytesPerSecond < 0 {
+		return errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "bytes_per_second must not be negative, got %v", l.BytesPerSecond)
+	}
+
+	if l.SamplesPerSecond < 0 {
+		return errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "samples_per_second must not be negative, got %v", l.SamplesPerSecond)
+	}
+
+	return nil
+}
+
+func (b QueryBudget) validate() error {
+	if b.Rows < 0 {
+		return errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "rows must not be negative, got %v", b.Rows)
+	}
+
+	if b.Bytes < 0 {
+		return errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "bytes must not be negative, got %v", b.Bytes)
+	}
+
+	return nil
+}
+
+func (l QueryMemoryLimit) validate() error {
+	if l.MaxMemoryUsage < 0 {
+		return errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "max_memory_usage must not be negative, got %v", l.MaxMemoryUsage)
+	}
+
+	if l.MaxBytesBeforeExternalGroupBy < 0 {
+		return errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "max_bytes_before_external_group_by must not be negative, got %v", l.MaxBytesBeforeExternalGroupBy)
+	}
+
+	// The group by spilling to disk only once the query is killed would never spill.
+	if l.MaxMemoryUsage > 0 && l.MaxBytesBeforeExternalGroupBy >= l.MaxMemoryUsage {
+		return errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "max_bytes_before_external_group_by must be less than max_memory_usage (%d), got %v", l.MaxMemoryUsage, l.MaxBytesBeforeExternalGroupBy)
+	}
+
+	return nil
+}
+
+func (p RetentionPolicy) validate() error {
+	retentions := []struct {
+		signal    string
+		retention time.Duration
+	}{{"traces", p.Traces}, {"logs", p.Logs}, {"metrics", p.Metrics}}
+
+	for _, r := range retentions {
+		if r.retention < 0 || r.retention%time.Second != 0 {
+			return errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "%s must be a non negative number of seconds, got %v", r.signal, r.retention)
+		}
+	}
+
 	return nil
 }
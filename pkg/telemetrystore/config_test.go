@@ -89,3 +89,169 @@ func TestNewWithEnvProviderWithQuerySettings(t *testing.T) {
 
 	assert.Equal(t, expected.Clickhouse.QuerySettings, actual.Clickhouse.QuerySettings)
 }
+
+func TestNewWithEnvProviderWithIngestion(t *testing.T) {
+	t.Setenv("SIGNOZ_TELEMETRYSTORE_INGESTION_ENABLED", "true")
+	t.Setenv("SIGNOZ_TELEMETRYSTORE_INGESTION_WINDOW", "30s")
+	t.Setenv("SIGNOZ_TELEMETRYSTORE_INGESTION_DEFAULT_BYTES__PER__SECOND", "1048576")
+	t.Setenv("SIGNOZ_TELEMETRYSTORE_INGESTION_DEFAULT_SAMPLES__PER__SECOND", "10000")
+
+	conf, err := config.New(
+		context.Background(),
+		config.ResolverConfig{
+			Uris: []string{"env:"},
+			ProviderFactories: []config.ProviderFactory{
+				envprovider.NewFactory(),
+			},
+		},
+		[]factory.ConfigFactory{
+			NewConfigFactory(),
+		},
+	)
+	require.NoError(t, err)
+
+	actual := Config{}
+	err = conf.Unmarshal("telemetrystore", &actual)
+	require.NoError(t, err)
+
+	assert.NoError(t, actual.Validate())
+	assert.True(t, actual.Ingestion.Enabled)
+	assert.Equal(t, 30*time.Second, actual.Ingestion.Window)
+	assert.Equal(t, IngestionLimit{BytesPerSecond: 1048576, SamplesPerSecond: 10000}, actual.Ingestion.Default)
+}
+
+func TestValidateIngestion(t *testing.T) {
+	c := NewConfigFactory().New().(Config)
+	c.Ingestion.Enabled = true
+	assert.NoError(t, c.Validate())
+
+	c.Ingestion.Window = 0
+	assert.Error(t, c.Validate())
+
+	c.Ingestion.Window = time.Minute
+	c.Ingestion.Tenants = map[string]IngestionLimit{"tenant": {BytesPerSecond: -1}}
+	assert.Error(t, c.Validate())
+}
+
+func TestValidateShards(t *testing.T) {
+	c := NewConfigFactory().New().(Config)
+	c.Shards = []ShardConfig{{Name: "eu", DSN: "tcp://clickhouse-eu:9000"}}
+	c.Routing.Tenants = map[string]string{"tenant-eu": "eu", "tenant-us": DefaultShardName}
+	assert.NoError(t, c.Validate())
+
+	c.Routing.Tenants = map[string]string{"tenant": "unknown"}
+	assert.Error(t, c.Validate())
+
+	c.Routing.Tenants = map[string]string{}
+	c.Shards = []ShardConfig{{Name: DefaultShardName, DSN: "tcp://clickhouse-eu:9000"}}
+	assert.Error(t, c.Validate())
+
+	c.Shards = []ShardConfig{{Name: "eu", DSN: ""}}
+	assert.Error(t, c.Validate())
+}
+
+func TestValidateConnection(t *testing.T) {
+	c := NewConfigFactory().New().(Config)
+	c.Connection.WarmupConns = c.Connection.MaxIdleConns
+	assert.NoError(t, c.Validate())
+
+	c.Connection.WarmupConns = c.Connection.MaxIdleConns + 1
+	assert.Error(t, c.Validate())
+
+	c.Connection.WarmupConns = 0
+	c.Connection.ReconnectRetries = -1
+	assert.Error(t, c.Validate())
+}
+
+func TestValidateRetention(t *testing.T) {
+	c := NewConfigFactory().New().(Config)
+	c.Retention.Enabled = true
+	c.Retention.Default = RetentionPolicy{Traces: 7 * 24 * time.Hour}
+	c.Retention.Tenants = map[string]RetentionPolicy{"tenant": {Logs: 90 * 24 * time.Hour}}
+	assert.NoError(t, c.Validate())
+
+	c.Retention.Tenants = map[string]RetentionPolicy{"tenant": {Logs: -time.Hour}}
+	assert.Error(t, c.Validate())
+
+	c.Retention.Tenants = map[string]RetentionPolicy{"tenant": {Metrics: 1500 * time.Millisecond}}
+	assert.Error(t, c.Validate())
+
+	c.Retention.Tenants = map[string]RetentionPolicy{}
+	c.Retention.Cluster = ""
+	assert.Error(t, c.Validate())
+}
+
+func TestValidateQueryBudget(t *testing.T) {
+	c := NewConfigFactory().New().(Config)
+	c.QueryBudget.Enabled = true
+	c.QueryBudget.Default = QueryBudget{Rows: 1000000000}
+	c.QueryBudget.Tenants = map[string]QueryBudget{"tenant": {Bytes: 1 << 40}}
+	assert.NoError(t, c.Validate())
+
+	c.QueryBudget.Tenants = map[string]QueryBudget{"tenant": {Rows: -1}}
+	assert.Error(t, c.Validate())
+
+	c.QueryBudget.Tenants = map[string]QueryBudget{}
+	c.QueryBudget.Action = "drop"
+	assert.Error(t, c.Validate())
+}
+
+func TestValidateQueryMemory(t *testing.T) {
+	c := NewConfigFactory().New().(Config)
+	c.QueryMemory.Enabled = true
+	c.QueryMemory.Default = QueryMemoryLimit{MaxMemoryUsage: 10 << 30, MaxBytesBeforeExternalGroupBy: 5 << 30}
+	c.QueryMemory.Tenants = map[string]QueryMemoryLimit{"tenant": {MaxBytesBeforeExternalGroupBy: 1 << 30}}
+	assert.NoError(t, c.Validate())
+
+	c.QueryMemory.Tenants = map[string]QueryMemoryLimit{"tenant": {MaxMemoryUsage: -1}}
+	assert.Error(t, c.Validate())
+
+	c.QueryMemory.Tenants = map[string]QueryMemoryLimit{}
+	c.QueryMemory.Default = QueryMemoryLimit{MaxMemoryUsage: 1 << 30, MaxBytesBeforeExternalGroupBy: 1 << 30}
+	assert.Error(t, c.Validate())
+}
+
+func TestValidateTLS(t *testing.T) {
+	c := NewConfigFactory().New().(Config)
+	c.Clickhouse.TLS = TLSConfig{Enabled: true, CAFile: "/etc/clickhouse/ca.pem"}
+	assert.NoError(t, c.Validate())
+
+	c.Clickhouse.TLS.CertFile = "/etc/clickhouse/client.pem"
+	assert.Error(t, c.Validate())
+
+	c.Clickhouse.TLS.KeyFile = "/etc/clickhouse/client-key.pem"
+	assert.NoError(t, c.Validate())
+}
+
+func TestValidateDeletion(t *testing.T) {
+	c := NewConfigFactory().New().(Config)
+	assert.NoError(t, c.Validate())
+
+	c.Deletion.MaxSeries = 0
+	assert.Error(t, c.Validate())
+
+	c.Deletion = DeletionConfig{MaxSeries: 10}
+	assert.Error(t, c.Validate())
+}
+
+func TestValidateCardinality(t *testing.T) {
+	c := NewConfigFactory().New().(Config)
+	c.Cardinality.Enabled = true
+	c.Cardinality.Metrics = map[string]int{"http_requests_total": 0}
+	assert.NoError(t, c.Validate())
+
+	c.Cardinality.Metrics = map[string]int{"http_requests_total": -1}
+	assert.Error(t, c.Validate())
+
+	c.Cardinality.Metrics = map[string]int{}
+	c.Cardinality.Action = "warn"
+	assert.Error(t, c.Validate())
+
+	c.Cardinality.Action = CardinalityActionDrop
+	c.Cardinality.MaxMetricsPerTenant = -1
+	assert.Error(t, c.Validate())
+
+	c.Cardinality.MaxMetricsPerTenant = 0
+	c.Cardinality.Window = 0
+	assert.Error(t, c.Validate())
+}
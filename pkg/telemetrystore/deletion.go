@@ -0,0 +1,315 @@
+package telemetrystore
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/ClickHouse/clickhouse-go/v2"
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/uptrace/bun"
+)
+
+const (
+	DeletionStatusRunning   string = "running"
+	DeletionStatusSucceeded string = "succeeded"
+	DeletionStatusFailed    string = "failed"
+
+	LabelMatchEqual     string = "="
+	LabelMatchNotEqual  string = "!="
+	LabelMatchRegexp    string = "=~"
+	LabelMatchNotRegexp string = "!~"
+)
+
+var (
+	ErrCodeDeletionFailed   = errors.MustNewCode("deletion_failed")
+	ErrCodeDeletionNotFound = errors.MustNewCode("deletion_not_found")
+	ErrCodeDeletionUnscoped = errors.MustNewCode("deletion_unscoped")
+)
+
+// LabelMatcher matches the value of a label of the series.
+type LabelMatcher struct {
+	Name  string `json:"name"`
+	Op    string `json:"op"`
+	Value string `json:"value"`
+}
+
+// DeletionTables are the tables of the series deleted by label matchers.
+type DeletionTables struct {
+	// Series is the table from which the fingerprints of the matching series are selected. Its labels column holds
+	// the labels of the series as a JSON object.
+	Series DeletionTable
+	// Local are the local tables from which the rows of the matching series are deleted on every node of the
+	// cluster.
+	Local []DeletionTable
+}
+
+// DeletionTable is a table with a column of the fingerprints of the series and a column of the time of the rows.
+type DeletionTable struct {
+	// Name is the name of the table qualified by its database.
+	Name string
+	// FingerprintColumn is the column of the fingerprints of the series, fingerprint when empty.
+	FingerprintColumn string
+	// TimeColumn is the column of the time of the rows, unix_milli when empty.
+	TimeColumn string
+	// TimeUnit is the unit of the time column, milliseconds when 0.
+	TimeUnit time.Duration
+	// Bucket is the duration of the time buckets of the rows of the table, 0 when its rows are not bucketed. The
+	// rows of the buckets overlapping the deleted time range are matched.
+	Bucket time.Duration
+}
+
+// DeletionJob is a deletion of the series matching label matchers, run asynchronously.
+type DeletionJob struct {
+	bun.BaseModel `bun:"table:telemetry_deletion"`
+
+	ID       string         `bun:"id,pk,type:text" json:"id"`
+	TenantID string         `bun:"org_id,type:text,notnull" json:"-"`
+	Signal   string         `bun:"signal,type:text,notnull" json:"signal"`
+	Status   string         `bun:"status,type:text,notnull" json:"status"`
+	Matchers []LabelMatcher `bun:"matchers,type:text,notnull" json:"matchers"`
+	Start    time.Time      `bun:"start_time,notnull" json:"start"`
+	End      time.Time      `bun:"end_time,notnull" json:"end"`
+	// Series is the number of series matching the matchers.
+	Series uint64 `bun:"series,notnull" json:"series"`
+	// AffectedRows is the number of rows deleted, counted before they are deleted.
+	AffectedRows uint64     `bun:"affected_rows,notnull" json:"affectedRows"`
+	Error        string     `bun:"error,type:text" json:"error,omitempty"`
+	CreatedAt    time.Time  `bun:"created_at,notnull" json:"createdAt"`
+	FinishedAt   *time.Time `bun:"finished_at,nullzero" json:"finishedAt,omitempty"`
+}
+
+// Deleter deletes the series of the tenants of a telemetry store, for instance to comply with data deletion
+// requests.
+type Deleter interface {
+	// Validate checks that the series of the tenant matching all the matchers between start and end can be deleted.
+	// The deletions which cannot be scoped to the series of the tenant are refused.
+	Validate(tenantID string, matchers []LabelMatcher, start time.Time, end time.Time) error
+
+	// DeleteByMatchers deletes the rows of the series of the tenant in the tables matching all the matchers between
+	// start and end, on the shard of the tenant, with lightweight deletes. It returns the number of series and of
+	// rows deleted.
+	DeleteByMatchers(ctx context.Context, tenantID string, tables DeletionTables, matchers []LabelMatcher, start time.Time, end time.Time) (uint64, uint64, error)
+}
+
+type deleter struct {
+	config  DeletionConfig
+	routing RoutingConfig
+	conn    clickhouse.Conn
+}
+
+// NewDeleter returns the deleter of the series read through the given connection, which routes the operations to
+// the shard of the tenant of their context.
+func NewDeleter(config DeletionConfig, routing RoutingConfig, conn clickhouse.Conn) Deleter {
+	return &deleter{
+		config:  config,
+		routing: routing,
+		conn:    conn,
+	}
+}
+
+func (deleter *deleter) Validate(tenantID string, matchers []LabelMatcher, start time.Time, end time.Time) error {
+	if err := validateLabelMatchers(matchers); err != nil {
+		return err
+	}
+
+	if !start.Before(end) {
+		return errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "start %v must be before end %v", start, end)
+	}
+
+	// The rows do not record their tenant, the series of the tenant are told apart by the tenant label or by a
+	// shard holding the data of the tenant only.
+	if deleter.config.TenantLabel == "" && !deleter.config.SingleTenant && !deleter.dedicated(tenantID) {
+		return errors.New(errors.TypeForbidden, ErrCodeDeletionUnscoped, "the shard of the tenant holds the series of other tenants, the deletions must be scoped with deletion::tenant_label")
+	}
+
+	return nil
+}
+
+func (deleter *deleter) DeleteByMatchers(ctx context.Context, tenantID string, tables DeletionTables, matchers []LabelMatcher, start time.Time, end time.Time) (uint64, uint64, error) {
+	if err := deleter.Validate(tenantID, matchers, start, end); err != nil {
+		return 0, 0, err
+	}
+
+	ctx = NewContextWithTenantID(ctx, tenantID)
+	fingerprints, err := deleter.fingerprints(ctx, tenantID, tables.Series, matchers, start, end)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if len(fingerprints) == 0 {
+		return 0, 0, nil
+	}
+
+	var affectedRows uint64
+	for _, table := range tables.Local {
+		condition := fmt.Sprintf("has(?, toString(%s)) AND %s >= ? AND %s < ?", table.fingerprintColumn(), table.timeColumn(), table.timeColumn())
+		args := []any{fingerprints, table.start(start), table.time(end)}
+		database, name, _ := strings.Cut(table.Name, ".")
+
+		var count uint64
+		if err := deleter.conn.QueryRow(ctx, fmt.Sprintf("SELECT count() FROM cluster('%s', %s, %s) WHERE %s", deleter.config.Cluster, database, name, condition), args...).Scan(&count); err != nil {
+			return uint64(len(fingerprints)), affectedRows, errors.Wrapf(err, errors.TypeInternal, ErrCodeDeletionFailed, "failed to count the rows of %s", table.Name)
+		}
+
+		if count == 0 {
+			continue
+		}
+
+		if err := deleter.conn.Exec(ctx, fmt.Sprintf("DELETE FROM %s ON CLUSTER %s WHERE %s", table.Name, deleter.config.Cluster, condition), args...); err != nil {
+			return uint64(len(fingerprints)), affectedRows, errors.Wrapf(err, errors.TypeInternal, ErrCodeDeletionFailed, "failed to delete the rows of %s", table.Name)
+		}
+
+		affectedRows += count
+	}
+
+	return uint64(len(fingerprints)), affectedRows, nil
+}
+
+// dedicated returns true if the tenant is routed to a shard to which no other tenant is routed. The default shard
+// holds the data of every tenant which is not routed.
+func (deleter *deleter) dedicated(tenantID string) bool {
+	shard, ok := deleter.routing.Tenants[tenantID]
+	if !ok || shard == DefaultShardName {
+		return false
+	}
+
+	for other, otherShard := range deleter.routing.Tenants {
+		if other != tenantID && otherShard == shard {
+			return false
+		}
+	}
+
+	return true
+}
+
+// fingerprints returns the fingerprints of the series of the tenant matching all the matchers between start and end.
+func (deleter *deleter) fingerprints(ctx context.Context, tenantID string, table DeletionTable, matchers []LabelMatcher, start time.Time, end time.Time) ([]string, error) {
+	conditions := []string{table.timeColumn() + " >= ?", table.timeColumn() + " < ?"}
+	args := []any{table.start(start), table.time(end)}
+	if deleter.config.TenantLabel != "" {
+		conditions = append(conditions, "JSONExtractString(labels, ?) = ?")
+		args = append(args, deleter.config.TenantLabel, tenantID)
+	}
+
+	for _, matcher := range matchers {
+		value := matcher.Value
+		switch matcher.Op {
+		case LabelMatchEqual:
+			conditions = append(conditions, "JSONExtractString(labels, ?) = ?")
+		case LabelMatchNotEqual:
+			conditions = append(conditions, "JSONExtractString(labels, ?) != ?")
+		case LabelMatchRegexp:
+			conditions = append(conditions, "match(JSONExtractString(labels, ?), ?)")
+			value = anchored(value)
+		case LabelMatchNotRegexp:
+			conditions = append(conditions, "NOT match(JSONExtractString(labels, ?), ?)")
+			value = anchored(value)
+		}
+		args = append(args, matcher.Name, value)
+	}
+
+	// One more fingerprint than the maximum is selected to detect the deletions over the maximum. The fingerprints
+	// are selected as strings as they are numbers in some tables and strings in others.
+	query := fmt.Sprintf("SELECT DISTINCT toString(%s) FROM %s WHERE %s LIMIT %d", table.fingerprintColumn(), table.Name, strings.Join(conditions, " AND "), deleter.config.MaxSeries+1)
+	rows, err := deleter.conn.Query(ctx, query, args...)
+	if err != nil {
+		return nil, errors.Wrapf(err, errors.TypeInternal, ErrCodeDeletionFailed, "failed to select the matching series")
+	}
+	defer rows.Close()
+
+	fingerprints := make([]string, 0)
+	for rows.Next() {
+		var fingerprint string
+		if err := rows.Scan(&fingerprint); err != nil {
+			return nil, errors.Wrapf(err, errors.TypeInternal, ErrCodeDeletionFailed, "failed to scan the matching series")
+		}
+
+		fingerprints = append(fingerprints, fingerprint)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrapf(err, errors.TypeInternal, ErrCodeDeletionFailed, "failed to select the matching series")
+	}
+
+	if len(fingerprints) > deleter.config.MaxSeries {
+		return nil, errors.Newf(errors.TypeInvalidInput, ErrCodeDeletionFailed, "more than %d series match, narrow down the matchers or the time range", deleter.config.MaxSeries)
+	}
+
+	return fingerprints, nil
+}
+
+func (table DeletionTable) fingerprintColumn() string {
+	if table.FingerprintColumn == "" {
+		return "fingerprint"
+	}
+
+	return table.FingerprintColumn
+}
+
+func (table DeletionTable) timeColumn() string {
+	if table.TimeColumn == "" {
+		return "unix_milli"
+	}
+
+	return table.TimeColumn
+}
+
+// time returns the time in the unit of the time column of the table.
+func (table DeletionTable) time(t time.Time) int64 {
+	unit := table.TimeUnit
+	if unit <= 0 {
+		unit = time.Millisecond
+	}
+
+	return t.UnixNano() / unit.Nanoseconds()
+}
+
+// start returns the start, in the unit of the time column of the table, of the rows of the table matching the time
+// range starting at start.
+func (table DeletionTable) start(start time.Time) int64 {
+	if table.Bucket <= 0 {
+		return table.time(start)
+	}
+
+	bucket := table.time(time.Unix(0, 0).Add(table.Bucket))
+	return table.time(start) - table.time(start)%bucket
+}
+
+// anchored anchors the regular expression of a matcher so that it matches the whole value of the label.
+func anchored(regex string) string {
+	return "^(?:" + regex + ")$"
+}
+
+// validateLabelMatchers checks that the matchers are valid and that at least one of them selects the series by
+// their value, so that a deletion never matches every series.
+func validateLabelMatchers(matchers []LabelMatcher) error {
+	var selective bool
+	for _, matcher := range matchers {
+		if matcher.Name == "" {
+			return errors.New(errors.TypeInvalidInput, errors.CodeInvalidInput, "the name of a matcher must not be empty")
+		}
+
+		switch matcher.Op {
+		case LabelMatchEqual:
+			selective = selective || matcher.Value != ""
+		case LabelMatchNotEqual:
+		case LabelMatchRegexp, LabelMatchNotRegexp:
+			regex, err := regexp.Compile(anchored(matcher.Value))
+			if err != nil {
+				return errors.Wrapf(err, errors.TypeInvalidInput, errors.CodeInvalidInput, "invalid regular expression of the matcher of %q", matcher.Name)
+			}
+			selective = selective || (matcher.Op == LabelMatchRegexp && !regex.MatchString(""))
+		default:
+			return errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "the op of the matcher of %q must be one of %s, %s, %s or %s, got %q", matcher.Name, LabelMatchEqual, LabelMatchNotEqual, LabelMatchRegexp, LabelMatchNotRegexp, matcher.Op)
+		}
+	}
+
+	if !selective {
+		return errors.New(errors.TypeInvalidInput, errors.CodeInvalidInput, "at least one matcher must select the series by a non empty value")
+	}
+
+	return nil
+}
@@ -0,0 +1,156 @@
+package telemetrystore
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	cmock "github.com/srikanthccv/ClickHouse-go-mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeleterDeleteByMatchers(t *testing.T) {
+	conn := newTestShards(t, DefaultShardName)[DefaultShardName]
+	mock := conn.(cmock.ClickConnMockCommon)
+	deleter := NewDeleter(DeletionConfig{Cluster: "cluster", MaxSeries: 10, TenantLabel: "tenant"}, RoutingConfig{}, conn)
+
+	tables := DeletionTables{
+		Series: DeletionTable{Name: "signoz_metrics.time_series_v4", Bucket: time.Hour},
+		Local: []DeletionTable{
+			{Name: "signoz_metrics.samples_v4"},
+			{Name: "signoz_metrics.time_series_v4_1day", Bucket: 24 * time.Hour},
+			{Name: "signoz_metrics.samples_v4_agg_5m", Bucket: 5 * time.Minute},
+		},
+	}
+	start, end := time.UnixMilli(1_700_003_600_000), time.UnixMilli(1_700_007_200_000)
+	hour, day := int64(1_700_002_800_000), int64(1_699_920_000_000)
+
+	// The series are scoped to the tenant by the tenant label.
+	fingerprint1, fingerprint2 := "1", "2"
+	mock.ExpectQuery("SELECT DISTINCT toString(fingerprint) FROM signoz_metrics.time_series_v4 WHERE unix_milli >= ? AND unix_milli < ? AND JSONExtractString(labels, ?) = ? AND JSONExtractString(labels, ?) = ? AND match(JSONExtractString(labels, ?), ?) LIMIT 11").
+		WithArgs(hour, end.UnixMilli(), "tenant", "tenant-1", "service", "checkout", "pod", "^(?:checkout-.*)$").
+		WillReturnRows(cmock.NewRows([]cmock.ColumnType{{Name: "fingerprint", Type: "String"}}, [][]any{{&fingerprint1}, {&fingerprint2}}))
+
+	fingerprints := []string{"1", "2"}
+	countCols := []cmock.ColumnType{{Name: "count()", Type: "UInt64"}}
+	samples, series, empty := uint64(100), uint64(2), uint64(0)
+	mock.ExpectQueryRow("SELECT count() FROM cluster('cluster', signoz_metrics, samples_v4) WHERE has(?, toString(fingerprint)) AND unix_milli >= ? AND unix_milli < ?").
+		WillReturnRow(cmock.NewRow(countCols, []any{&samples}))
+	mock.ExpectExec("DELETE FROM signoz_metrics.samples_v4 ON CLUSTER cluster WHERE has(?, toString(fingerprint)) AND unix_milli >= ? AND unix_milli < ?").
+		WithArgs(fingerprints, start.UnixMilli(), end.UnixMilli())
+
+	// The rows of the buckets overlapping the time range are deleted.
+	mock.ExpectQueryRow("SELECT count() FROM cluster('cluster', signoz_metrics, time_series_v4_1day) WHERE has(?, toString(fingerprint)) AND unix_milli >= ? AND unix_milli < ?").
+		WillReturnRow(cmock.NewRow(countCols, []any{&series}))
+	mock.ExpectExec("DELETE FROM signoz_metrics.time_series_v4_1day ON CLUSTER cluster WHERE has(?, toString(fingerprint)) AND unix_milli >= ? AND unix_milli < ?").
+		WithArgs(fingerprints, day, end.UnixMilli())
+
+	// The tables without matching rows are not deleted from.
+	mock.ExpectQueryRow("SELECT count() FROM cluster('cluster', signoz_metrics, samples_v4_agg_5m) WHERE has(?, toString(fingerprint)) AND unix_milli >= ? AND unix_milli < ?").
+		WillReturnRow(cmock.NewRow(countCols, []any{&empty}))
+
+	matchers := []LabelMatcher{{Name: "service", Op: LabelMatchEqual, Value: "checkout"}, {Name: "pod", Op: LabelMatchRegexp, Value: "checkout-.*"}}
+	deletedSeries, affectedRows, err := deleter.DeleteByMatchers(context.Background(), "tenant-1", tables, matchers, start, end)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), deletedSeries)
+	assert.Equal(t, uint64(102), affectedRows)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleterDeleteByMatchersInSeconds(t *testing.T) {
+	conn := newTestShards(t, DefaultShardName)[DefaultShardName]
+	mock := conn.(cmock.ClickConnMockCommon)
+	deleter := NewDeleter(DeletionConfig{Cluster: "cluster", MaxSeries: 10, SingleTenant: true}, RoutingConfig{}, conn)
+
+	tables := DeletionTables{
+		Series: DeletionTable{Name: "signoz_logs.distributed_logs_v2_resource", TimeColumn: "seen_at_ts_bucket_start", TimeUnit: time.Second, Bucket: 30 * time.Minute},
+		Local: []DeletionTable{
+			{Name: "signoz_logs.logs_v2", FingerprintColumn: "resource_fingerprint", TimeColumn: "ts_bucket_start", TimeUnit: time.Second, Bucket: 30 * time.Minute},
+		},
+	}
+	start, end := time.Unix(1_700_003_700, 0), time.Unix(1_700_007_200, 0)
+	bucket := int64(1_700_002_800)
+
+	fingerprint := "service.name=checkout;hash=42"
+	mock.ExpectQuery("SELECT DISTINCT toString(fingerprint) FROM signoz_logs.distributed_logs_v2_resource WHERE seen_at_ts_bucket_start >= ? AND seen_at_ts_bucket_start < ? AND JSONExtractString(labels, ?) = ? LIMIT 11").
+		WithArgs(bucket, end.Unix(), "service.name", "checkout").
+		WillReturnRows(cmock.NewRows([]cmock.ColumnType{{Name: "fingerprint", Type: "String"}}, [][]any{{&fingerprint}}))
+
+	logs := uint64(10)
+	mock.ExpectQueryRow("SELECT count() FROM cluster('cluster', signoz_logs, logs_v2) WHERE has(?, toString(resource_fingerprint)) AND ts_bucket_start >= ? AND ts_bucket_start < ?").
+		WillReturnRow(cmock.NewRow([]cmock.ColumnType{{Name: "count()", Type: "UInt64"}}, []any{&logs}))
+	mock.ExpectExec("DELETE FROM signoz_logs.logs_v2 ON CLUSTER cluster WHERE has(?, toString(resource_fingerprint)) AND ts_bucket_start >= ? AND ts_bucket_start < ?").
+		WithArgs([]string{fingerprint}, bucket, end.Unix())
+
+	_, affectedRows, err := deleter.DeleteByMatchers(context.Background(), "tenant-1", tables, []LabelMatcher{{Name: "service.name", Op: LabelMatchEqual, Value: "checkout"}}, start, end)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(10), affectedRows)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleterValidate(t *testing.T) {
+	start, end := time.UnixMilli(1_700_003_600_000), time.UnixMilli(1_700_007_200_000)
+	matchers := []LabelMatcher{{Name: "service", Op: LabelMatchEqual, Value: "checkout"}}
+	routing := RoutingConfig{Tenants: map[string]string{"dedicated": "shard-1", "shared-1": "shard-2", "shared-2": "shard-2", "default": DefaultShardName}}
+
+	testCases := []struct {
+		name     string
+		config   DeletionConfig
+		tenantID string
+		pass     bool
+	}{
+		{name: "TenantLabel", config: DeletionConfig{TenantLabel: "tenant"}, tenantID: "shared-1", pass: true},
+		{name: "SingleTenant", config: DeletionConfig{SingleTenant: true}, tenantID: "unrouted", pass: true},
+		{name: "DedicatedShard", tenantID: "dedicated", pass: true},
+		{name: "SharedShard", tenantID: "shared-1", pass: false},
+		{name: "DefaultShard", tenantID: "default", pass: false},
+		{name: "Unrouted", tenantID: "unrouted", pass: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := NewDeleter(tc.config, routing, nil).Validate(tc.tenantID, matchers, start, end)
+			if tc.pass {
+				assert.NoError(t, err)
+				return
+			}
+
+			assert.True(t, errors.Asc(err, ErrCodeDeletionUnscoped))
+		})
+	}
+
+	err := NewDeleter(DeletionConfig{SingleTenant: true}, routing, nil).Validate("tenant", matchers, end, start)
+	assert.True(t, errors.Ast(err, errors.TypeInvalidInput))
+}
+
+func TestValidateLabelMatchers(t *testing.T) {
+	testCases := []struct {
+		name     string
+		matchers []LabelMatcher
+		pass     bool
+	}{
+		{name: "Equal", matchers: []LabelMatcher{{Name: "service", Op: LabelMatchEqual, Value: "checkout"}}, pass: true},
+		{name: "Regexp", matchers: []LabelMatcher{{Name: "service", Op: LabelMatchRegexp, Value: "check.+"}}, pass: true},
+		{name: "NoMatchers", matchers: nil, pass: false},
+		{name: "EqualEmpty", matchers: []LabelMatcher{{Name: "service", Op: LabelMatchEqual}}, pass: false},
+		{name: "RegexpMatchingEmpty", matchers: []LabelMatcher{{Name: "service", Op: LabelMatchRegexp, Value: ".*"}}, pass: false},
+		{name: "OnlyNegative", matchers: []LabelMatcher{{Name: "service", Op: LabelMatchNotEqual, Value: "checkout"}}, pass: false},
+		{name: "InvalidRegexp", matchers: []LabelMatcher{{Name: "service", Op: LabelMatchRegexp, Value: "("}}, pass: false},
+		{name: "InvalidOp", matchers: []LabelMatcher{{Name: "service", Op: "==", Value: "checkout"}}, pass: false},
+		{name: "EmptyName", matchers: []LabelMatcher{{Op: LabelMatchEqual, Value: "checkout"}}, pass: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateLabelMatchers(tc.matchers)
+			if tc.pass {
+				assert.NoError(t, err)
+				return
+			}
+
+			assert.True(t, errors.Ast(err, errors.TypeInvalidInput))
+		})
+	}
+}
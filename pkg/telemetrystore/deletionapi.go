@@ -0,0 +1,161 @@
+package telemetrystore
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/http/render"
+	"github.com/SigNoz/signoz/pkg/sqlstore"
+	"github.com/SigNoz/signoz/pkg/types/authtypes"
+	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+	"github.com/gorilla/mux"
+)
+
+// PostableDeletion is a request to delete the series of the signal matching all the matchers between start and end,
+// in milliseconds. The signal is metrics when it is empty. The series of the logs and of the traces are their
+// resources, the matchers match the attributes of the resources.
+type PostableDeletion struct {
+	Signal   telemetrytypes.Signal `json:"signal"`
+	Matchers []LabelMatcher        `json:"matchers"`
+	Start    int64                 `json:"start"`
+	End      int64                 `json:"end"`
+}
+
+// DeletionAPI runs the deletions of the series in the background. The deletion jobs are stored in the sqlstore,
+// hence they are shared between the replicas. A job interrupted by a restart stays running, the lightweight deletes
+// being idempotent the deletion can be requested again.
+type DeletionAPI struct {
+	logger         *slog.Logger
+	telemetryStore TelemetryStore
+	sqlstore       sqlstore.SQLStore
+	tables         map[telemetrytypes.Signal]DeletionTables
+}
+
+// NewDeletionAPI returns the API deleting the series of the tables of each signal with the deleter of the telemetry
+// store.
+func NewDeletionAPI(logger *slog.Logger, telemetryStore TelemetryStore, sqlstore sqlstore.SQLStore, tables map[telemetrytypes.Signal]DeletionTables) *DeletionAPI {
+	return &DeletionAPI{
+		logger:         logger,
+		telemetryStore: telemetryStore,
+		sqlstore:       sqlstore,
+		tables:         tables,
+	}
+}
+
+func (api *DeletionAPI) Delete(rw http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	claims, err := authtypes.ClaimsFromContext(ctx)
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	req := new(PostableDeletion)
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		render.Error(rw, errors.Wrapf(err, errors.TypeInvalidInput, errors.CodeInvalidInput, "failed to decode the deletion"))
+		return
+	}
+
+	if req.Signal.IsZero() {
+		req.Signal = telemetrytypes.SignalMetrics
+	}
+
+	tables, ok := api.tables[req.Signal]
+	if !ok {
+		render.Error(rw, errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "the series of the signal %q cannot be deleted", req.Signal.StringValue()))
+		return
+	}
+
+	start, end := time.UnixMilli(req.Start), time.UnixMilli(req.End)
+	if err := api.telemetryStore.Deleter().Validate(claims.OrgID, req.Matchers, start, end); err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	job := &DeletionJob{
+		ID:        valuer.GenerateUUID().StringValue(),
+		TenantID:  claims.OrgID,
+		Signal:    req.Signal.StringValue(),
+		Status:    DeletionStatusRunning,
+		Matchers:  req.Matchers,
+		Start:     start,
+		End:       end,
+		CreatedAt: time.Now(),
+	}
+
+	if _, err := api.sqlstore.BunDB().NewInsert().Model(job).Exec(ctx); err != nil {
+		render.Error(rw, errors.Wrapf(err, errors.TypeInternal, errors.CodeInternal, "failed to create the deletion"))
+		return
+	}
+
+	created := *job
+
+	// The deletion outlives the request which started it.
+	go api.run(context.WithoutCancel(ctx), job, tables)
+
+	render.Success(rw, http.StatusAccepted, &created)
+}
+
+func (api *DeletionAPI) Get(rw http.ResponseWriter, r *http.Request) {
+	claims, err := authtypes.ClaimsFromContext(r.Context())
+	if err != nil {
+		render.Error(rw, err)
+		return
+	}
+
+	id := mux.Vars(r)["id"]
+	job := new(DeletionJob)
+	err = api.
+		sqlstore.
+		BunDB().
+		NewSelect().
+		Model(job).
+		Where("id = ?", id).
+		Where("org_id = ?", claims.OrgID).
+		Scan(r.Context())
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			render.Error(rw, errors.Newf(errors.TypeNotFound, ErrCodeDeletionNotFound, "deletion %q does not exist", id))
+			return
+		}
+
+		render.Error(rw, errors.Wrapf(err, errors.TypeInternal, errors.CodeInternal, "failed to get the deletion %q", id))
+		return
+	}
+
+	render.Success(rw, http.StatusOK, job)
+}
+
+func (api *DeletionAPI) run(ctx context.Context, job *DeletionJob, tables DeletionTables) {
+	series, affectedRows, err := api.telemetryStore.Deleter().DeleteByMatchers(ctx, job.TenantID, tables, job.Matchers, job.Start, job.End)
+
+	finishedAt := time.Now()
+	job.Series = series
+	job.AffectedRows = affectedRows
+	job.Status = DeletionStatusSucceeded
+	job.FinishedAt = &finishedAt
+	if err != nil {
+		job.Status = DeletionStatusFailed
+		job.Error = err.Error()
+	}
+
+	_, err = api.
+		sqlstore.
+		BunDB().
+		NewUpdate().
+		Model(job).
+		Column("series", "affected_rows", "status", "error", "finished_at").
+		WherePK().
+		Exec(ctx)
+	if err != nil {
+		api.logger.ErrorContext(ctx, "failed to store the result of the deletion", "id", job.ID, "status", job.Status, "error", err)
+	}
+}
@@ -0,0 +1,73 @@
+package telemetrystore_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/SigNoz/signoz/pkg/sqlstore/sqlstoretest"
+	"github.com/SigNoz/signoz/pkg/telemetrystore"
+	"github.com/SigNoz/signoz/pkg/telemetrystore/telemetrystoretest"
+	"github.com/SigNoz/signoz/pkg/types/authtypes"
+	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeletionAPI(t *testing.T) {
+	ctx := context.Background()
+	store := sqlstoretest.NewSQLite(t)
+	_, err := store.BunDB().NewCreateTable().Model(new(telemetrystore.DeletionJob)).Exec(ctx)
+	require.NoError(t, err)
+
+	telemetryStore := telemetrystoretest.New(telemetrystore.Config{Provider: "clickhouse", Deletion: telemetrystore.DeletionConfig{Cluster: "cluster", MaxSeries: 10, SingleTenant: true}}, sqlmock.QueryMatcherRegexp)
+	api := telemetrystore.NewDeletionAPI(slog.New(slog.NewTextHandler(io.Discard, nil)), telemetryStore, store, map[telemetrytypes.Signal]telemetrystore.DeletionTables{
+		telemetrytypes.SignalMetrics: {Series: telemetrystore.DeletionTable{Name: "signoz_metrics.time_series_v4"}},
+	})
+
+	router := mux.NewRouter()
+	router.HandleFunc("/deletions", api.Delete).Methods(http.MethodPost)
+	router.HandleFunc("/deletions/{id}", api.Get).Methods(http.MethodGet)
+
+	do := func(orgID string, method string, path string, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req = req.WithContext(authtypes.NewContextWithClaims(req.Context(), authtypes.Claims{OrgID: orgID}))
+		rw := httptest.NewRecorder()
+		router.ServeHTTP(rw, req)
+		return rw
+	}
+
+	rw := do("org-1", http.MethodPost, "/deletions", `{"signal":"exceptions","matchers":[{"name":"service","op":"=","value":"checkout"}],"start":1,"end":2}`)
+	assert.Equal(t, http.StatusBadRequest, rw.Code)
+
+	rw = do("org-1", http.MethodPost, "/deletions", `{"matchers":[{"name":"service","op":"=","value":"checkout"}],"start":1,"end":2}`)
+	require.Equal(t, http.StatusAccepted, rw.Code, rw.Body.String())
+
+	var created struct {
+		Data telemetrystore.DeletionJob `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(rw.Body.Bytes(), &created))
+	assert.Equal(t, telemetrytypes.SignalMetrics.StringValue(), created.Data.Signal)
+
+	// The job is stored, its result is stored once the deletion has run.
+	assert.Eventually(t, func() bool {
+		job := new(telemetrystore.DeletionJob)
+		require.NoError(t, store.BunDB().NewSelect().Model(job).Where("id = ?", created.Data.ID).Scan(ctx))
+		return job.Status != telemetrystore.DeletionStatusRunning
+	}, 5*time.Second, 10*time.Millisecond)
+
+	rw = do("org-1", http.MethodGet, "/deletions/"+created.Data.ID, "")
+	assert.Equal(t, http.StatusOK, rw.Code)
+
+	// The jobs of an organization are not visible to the other organizations.
+	rw = do("org-2", http.MethodGet, "/deletions/"+created.Data.ID, "")
+	assert.Equal(t, http.StatusNotFound, rw.Code)
+}
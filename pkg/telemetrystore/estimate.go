@@ -0,0 +1,192 @@
+package telemetrystore
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/ClickHouse/clickhouse-go/v2"
+	"github.com/SigNoz/signoz/pkg/errors"
+)
+
+const (
+	QueryBudgetActionWarn   string = "warn"
+	QueryBudgetActionReject string = "reject"
+)
+
+var (
+	ErrCodeQueryBudgetExceeded = errors.MustNewCode("query_budget_exceeded")
+)
+
+// Estimate is the estimate of the data read by a query, computed by clickhouse from the indexes of the tables
+// without running the query.
+type Estimate struct {
+	// Rows is the number of rows read.
+	Rows uint64
+	// Bytes is the number of uncompressed bytes read. It is extrapolated from the average size of the rows of the
+	// tables, hence it is an upper bound of the bytes of the columns actually read.
+	Bytes uint64
+	// Parts is the number of parts read.
+	Parts uint64
+	// Marks is the number of marks read.
+	Marks uint64
+}
+
+// Add returns the sum of the estimates.
+func (estimate Estimate) Add(other Estimate) Estimate {
+	return Estimate{
+		Rows:  estimate.Rows + other.Rows,
+		Bytes: estimate.Bytes + other.Bytes,
+		Parts: estimate.Parts + other.Parts,
+		Marks: estimate.Marks + other.Marks,
+	}
+}
+
+// Estimator estimates the data read by the queries of a telemetry store and checks it against the query budgets of
+// the tenants.
+type Estimator interface {
+	// Enabled returns whether the queries are estimated before being run.
+	Enabled() bool
+
+	// Estimate returns the estimate of the data read by the query, which is run on the shard of the tenant of the
+	// context.
+	Estimate(ctx context.Context, query string, args ...any) (Estimate, error)
+
+	// Check checks the estimate against the budget of the tenant. It returns an error of type TypeInvalidInput when
+	// the estimate exceeds the budget and the queries above it are rejected, or a warning when they are only
+	// warned about.
+	Check(tenantID string, estimate Estimate) (string, error)
+}
+
+type noopEstimator struct{}
+
+func (noopEstimator) Enabled() bool {
+	return false
+}
+
+func (noopEstimator) Estimate(context.Context, string, ...any) (Estimate, error) {
+	return Estimate{}, nil
+}
+
+func (noopEstimator) Check(string, Estimate) (string, error) {
+	return "", nil
+}
+
+type explainEstimator struct {
+	config QueryBudgetConfig
+	conn   clickhouse.Conn
+}
+
+// NewEstimator returns the estimator of the queries run on the given connection. The returned estimator estimates
+// nothing when the query budgets are not enabled.
+func NewEstimator(config QueryBudgetConfig, conn clickhouse.Conn) Estimator {
+	if !config.Enabled {
+		return noopEstimator{}
+	}
+
+	return &explainEstimator{config: config, conn: conn}
+}
+
+func (estimator *explainEstimator) Enabled() bool {
+	return true
+}
+
+func (estimator *explainEstimator) Estimate(ctx context.Context, query string, args ...any) (Estimate, error) {
+	rows, err := estimator.conn.Query(ctx, "EXPLAIN ESTIMATE "+query, args...)
+	if err != nil {
+		return Estimate{}, errors.Wrapf(err, errors.TypeInternal, errors.CodeInternal, "failed to estimate the query")
+	}
+	defer rows.Close()
+
+	// The estimate is reported for every table read by the query.
+	tables := make(map[string]Estimate)
+	for rows.Next() {
+		var database, table string
+		var estimate Estimate
+		if err := rows.Scan(&database, &table, &estimate.Parts, &estimate.Rows, &estimate.Marks); err != nil {
+			return Estimate{}, errors.Wrapf(err, errors.TypeInternal, errors.CodeInternal, "failed to scan the estimate of the query")
+		}
+
+		name := database + "." + table
+		tables[name] = tables[name].Add(estimate)
+	}
+
+	if err := rows.Err(); err != nil {
+		return Estimate{}, errors.Wrapf(err, errors.TypeInternal, errors.CodeInternal, "failed to estimate the query")
+	}
+
+	if len(tables) == 0 {
+		return Estimate{}, nil
+	}
+
+	rowSizes, err := estimator.rowSizes(ctx, tables)
+	if err != nil {
+		return Estimate{}, err
+	}
+
+	var total Estimate
+	for name, estimate := range tables {
+		estimate.Bytes = uint64(float64(estimate.Rows) * rowSizes[name])
+		total = total.Add(estimate)
+	}
+
+	return total, nil
+}
+
+// rowSizes returns the average uncompressed size of the rows of the given tables keyed by their qualified name.
+func (estimator *explainEstimator) rowSizes(ctx context.Context, tables map[string]Estimate) (map[string]float64, error) {
+	conditions := make([]string, 0, len(tables))
+	args := make([]any, 0, 2*len(tables))
+	for name := range tables {
+		database, table, _ := strings.Cut(name, ".")
+		conditions = append(conditions, "(database = ? AND table = ?)")
+		args = append(args, database, table)
+	}
+
+	query := fmt.Sprintf(
+		"SELECT database, table, sum(data_uncompressed_bytes) / greatest(sum(rows), 1) FROM system.parts WHERE active AND (%s) GROUP BY database, table",
+		strings.Join(conditions, " OR "),
+	)
+
+	rows, err := estimator.conn.Query(ctx, query, args...)
+	if err != nil {
+		return nil, errors.Wrapf(err, errors.TypeInternal, errors.CodeInternal, "failed to fetch the size of the rows of the estimated tables")
+	}
+	defer rows.Close()
+
+	rowSizes := make(map[string]float64, len(tables))
+	for rows.Next() {
+		var database, table string
+		var rowSize float64
+		if err := rows.Scan(&database, &table, &rowSize); err != nil {
+			return nil, errors.Wrapf(err, errors.TypeInternal, errors.CodeInternal, "failed to scan the size of the rows of the estimated tables")
+		}
+
+		rowSizes[database+"."+table] = rowSize
+	}
+
+	return rowSizes, rows.Err()
+}
+
+func (estimator *explainEstimator) Check(tenantID string, estimate Estimate) (string, error) {
+	budget, ok := estimator.config.Tenants[tenantID]
+	if !ok {
+		budget = estimator.config.Default
+	}
+
+	var exceeded string
+	switch {
+	case budget.Rows > 0 && estimate.Rows > uint64(budget.Rows):
+		exceeded = fmt.Sprintf("the query is estimated to read %d rows which exceeds the budget of %d rows", estimate.Rows, budget.Rows)
+	case budget.Bytes > 0 && estimate.Bytes > uint64(budget.Bytes):
+		exceeded = fmt.Sprintf("the query is estimated to read %d bytes which exceeds the budget of %d bytes", estimate.Bytes, budget.Bytes)
+	default:
+		return "", nil
+	}
+
+	if estimator.config.Action == QueryBudgetActionReject {
+		return "", errors.New(errors.TypeInvalidInput, ErrCodeQueryBudgetExceeded, exceeded+", narrow down its time range or its filters")
+	}
+
+	return exceeded, nil
+}
@@ -0,0 +1,70 @@
+package telemetrystore
+
+import (
+	"context"
+	"testing"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	cmock "github.com/srikanthccv/ClickHouse-go-mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEstimatorEstimate(t *testing.T) {
+	conn := newTestShards(t, DefaultShardName)[DefaultShardName]
+	mock := conn.(cmock.ClickConnMockCommon)
+	estimator := NewEstimator(QueryBudgetConfig{Enabled: true, Action: QueryBudgetActionWarn}, conn)
+
+	explainCols := []cmock.ColumnType{{Name: "database", Type: "String"}, {Name: "table", Type: "String"}, {Name: "parts", Type: "UInt64"}, {Name: "rows", Type: "UInt64"}, {Name: "marks", Type: "UInt64"}}
+	explainRow := func(database, table string, parts, rows, marks uint64) []any {
+		return []any{&database, &table, &parts, &rows, &marks}
+	}
+	mock.ExpectQuery("EXPLAIN ESTIMATE SELECT count() FROM signoz_logs.logs_v2 WHERE ts > ?").WithArgs(10).WillReturnRows(cmock.NewRows(explainCols, [][]any{
+		explainRow("signoz_logs", "logs_v2", 2, 1000, 4),
+		explainRow("signoz_logs", "logs_v2", 1, 500, 2),
+	}))
+
+	sizeCols := []cmock.ColumnType{{Name: "database", Type: "String"}, {Name: "table", Type: "String"}, {Name: "size", Type: "Float64"}}
+	database, table, size := "signoz_logs", "logs_v2", 100.0
+	mock.ExpectQuery("SELECT database, table, sum(data_uncompressed_bytes) / greatest(sum(rows), 1) FROM system.parts WHERE active AND ((database = ? AND table = ?)) GROUP BY database, table").
+		WithArgs("signoz_logs", "logs_v2").
+		WillReturnRows(cmock.NewRows(sizeCols, [][]any{{&database, &table, &size}}))
+
+	estimate, err := estimator.Estimate(context.Background(), "SELECT count() FROM signoz_logs.logs_v2 WHERE ts > ?", 10)
+	require.NoError(t, err)
+	assert.Equal(t, Estimate{Rows: 1500, Bytes: 150000, Parts: 3, Marks: 6}, estimate)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestEstimatorCheck(t *testing.T) {
+	config := QueryBudgetConfig{
+		Enabled: true,
+		Action:  QueryBudgetActionWarn,
+		Default: QueryBudget{Rows: 1000},
+		Tenants: map[string]QueryBudget{"tenant-large": {Rows: 0, Bytes: 1 << 20}},
+	}
+
+	estimator := NewEstimator(config, nil)
+
+	warning, err := estimator.Check("tenant", Estimate{Rows: 1000})
+	require.NoError(t, err)
+	assert.Empty(t, warning)
+
+	warning, err = estimator.Check("tenant", Estimate{Rows: 1001})
+	require.NoError(t, err)
+	assert.Contains(t, warning, "1001 rows")
+
+	// The budget of a tenant replaces the default budget.
+	warning, err = estimator.Check("tenant-large", Estimate{Rows: 1 << 30, Bytes: 1 << 20})
+	require.NoError(t, err)
+	assert.Empty(t, warning)
+
+	config.Action = QueryBudgetActionReject
+	estimator = NewEstimator(config, nil)
+
+	_, err = estimator.Check("tenant-large", Estimate{Bytes: 1<<20 + 1})
+	assert.True(t, errors.Ast(err, errors.TypeInvalidInput))
+	assert.True(t, errors.Asc(err, ErrCodeQueryBudgetExceeded))
+
+	assert.False(t, NewEstimator(QueryBudgetConfig{}, nil).Enabled())
+}
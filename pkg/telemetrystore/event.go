@@ -7,6 +7,8 @@ import (
 type QueryEvent struct {
 	Query     string
 	QueryArgs []any
+	// QueryID is the id of the query on the server. It is only set for the reads, once they have been sent.
+	QueryID   string
 	StartTime time.Time
 	Err       error
 }
@@ -0,0 +1,36 @@
+package telemetrystore
+
+import (
+	"context"
+	"maps"
+
+	"github.com/ClickHouse/clickhouse-go/v2"
+	"github.com/SigNoz/signoz/pkg/errors"
+)
+
+var (
+	ErrCodeAsyncInsertFailed = errors.MustNewCode("async_insert_failed")
+)
+
+type settingsKey struct{}
+
+// NewContextWithSettings returns a context setting the clickhouse settings of the query. The settings are merged
+// into the settings set before with NewContextWithSettings, whereas clickhouse replaces them, so that the hooks and
+// the shards can each set their own settings on the same query.
+func NewContextWithSettings(ctx context.Context, settings clickhouse.Settings) context.Context {
+	merged := SettingsFromContext(ctx)
+	maps.Copy(merged, settings)
+
+	ctx = context.WithValue(ctx, settingsKey{}, merged)
+	return clickhouse.Context(ctx, clickhouse.WithSettings(maps.Clone(merged)))
+}
+
+// SettingsFromContext returns a copy of the clickhouse settings set with NewContextWithSettings.
+func SettingsFromContext(ctx context.Context) clickhouse.Settings {
+	settings, ok := ctx.Value(settingsKey{}).(clickhouse.Settings)
+	if !ok {
+		return clickhouse.Settings{}
+	}
+
+	return maps.Clone(settings)
+}
@@ -0,0 +1,147 @@
+package telemetrystore
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+const (
+	dropReasonBytesLimit   string = "bytes_limit"
+	dropReasonSamplesLimit string = "samples_limit"
+)
+
+var (
+	ErrCodeIngestionLimited = errors.MustNewCode("ingestion_limited")
+)
+
+// IngestionLimiter enforces the ingestion limits of the tenants of a telemetry store. It is applied by every path
+// writing telemetry on behalf of a tenant: the samples of the prometheus remote write receiver and the spans of the
+// OTLP receiver, including the spans of signoz itself written through the loopback of the instrumentation, which are
+// accounted to the empty tenant. The writes of signoz to its own tables, such as the metadata of the metrics or the
+// deletions, are not ingestion and are not limited.
+type IngestionLimiter interface {
+	// Allow reserves the given number of samples, or spans, and bytes for the tenant. It returns an error of type
+	// TypeTooManyRequests when the reservation would exceed the limits of the tenant, in which case nothing
+	// is reserved and the caller is expected to back off and retry.
+	Allow(ctx context.Context, tenantID string, samples int64, bytes int64) error
+}
+
+type noopIngestionLimiter struct{}
+
+func (noopIngestionLimiter) Allow(context.Context, string, int64, int64) error {
+	return nil
+}
+
+// window holds the usage of a tenant in the current and the previous window.
+type window struct {
+	start           time.Time
+	samples         int64
+	bytes           int64
+	previousSamples int64
+	previousBytes   int64
+}
+
+// slidingWindowIngestionLimiter approximates a sliding window by weighting the usage of the previous window by
+// the fraction of it which still overlaps with the sliding window.
+type slidingWindowIngestionLimiter struct {
+	config  IngestionConfig
+	now     func() time.Time
+	dropped metric.Int64Counter
+	attrs   attribute.KeyValue
+	mtx     sync.Mutex
+	windows map[string]*window
+}
+
+// NewIngestionLimiter returns a limiter for the given config. The returned limiter allows everything when the
+// limits are not enabled.
+func NewIngestionLimiter(meter metric.Meter, name string, config IngestionConfig) (IngestionLimiter, error) {
+	if !config.Enabled {
+		return noopIngestionLimiter{}, nil
+	}
+
+	return newSlidingWindowIngestionLimiter(meter, name, config, time.Now)
+}
+
+func newSlidingWindowIngestionLimiter(meter metric.Meter, name string, config IngestionConfig, now func() time.Time) (*slidingWindowIngestionLimiter, error) {
+	dropped, err := meter.Int64Counter("signoz.telemetrystore.ingestion.records.dropped", metric.WithDescription("Number of records dropped by the ingestion limits, by reason."))
+	if err != nil {
+		return nil, err
+	}
+
+	return &slidingWindowIngestionLimiter{
+		config:  config,
+		now:     now,
+		dropped: dropped,
+		attrs:   attribute.String("telemetrystore.name", name),
+		windows: make(map[string]*window),
+	}, nil
+}
+
+func (limiter *slidingWindowIngestionLimiter) Allow(ctx context.Context, tenantID string, samples int64, bytes int64) error {
+	limit, ok := limiter.config.Tenants[tenantID]
+	if !ok {
+		limit = limiter.config.Default
+	}
+
+	limiter.mtx.Lock()
+	defer limiter.mtx.Unlock()
+
+	now := limiter.now()
+	w := limiter.window(tenantID, now)
+
+	// The weight of the previous window is the fraction of it still covered by the sliding window.
+	weight := 1 - float64(now.Sub(w.start))/float64(limiter.config.Window)
+	seconds := limiter.config.Window.Seconds()
+
+	if limit.BytesPerSecond > 0 {
+		used := float64(w.previousBytes)*weight + float64(w.bytes)
+		if used+float64(bytes) > float64(limit.BytesPerSecond)*seconds {
+			limiter.dropped.Add(ctx, samples, metric.WithAttributes(limiter.attrs, attribute.String("reason", dropReasonBytesLimit)))
+			return errors.Newf(errors.TypeTooManyRequests, ErrCodeIngestionLimited, "ingestion limit of %d bytes per second exceeded for tenant %q", limit.BytesPerSecond, tenantID)
+		}
+	}
+
+	if limit.SamplesPerSecond > 0 {
+		used := float64(w.previousSamples)*weight + float64(w.samples)
+		if used+float64(samples) > float64(limit.SamplesPerSecond)*seconds {
+			limiter.dropped.Add(ctx, samples, metric.WithAttributes(limiter.attrs, attribute.String("reason", dropReasonSamplesLimit)))
+			return errors.Newf(errors.TypeTooManyRequests, ErrCodeIngestionLimited, "ingestion limit of %d samples per second exceeded for tenant %q", limit.SamplesPerSecond, tenantID)
+		}
+	}
+
+	w.samples += samples
+	w.bytes += bytes
+	return nil
+}
+
+// window returns the window of the tenant, rolling it over if now is past its end. It should be called with the
+// lock held.
+func (limiter *slidingWindowIngestionLimiter) window(tenantID string, now time.Time) *window {
+	w, ok := limiter.windows[tenantID]
+	if !ok {
+		w = &window{start: now.Truncate(limiter.config.Window)}
+		limiter.windows[tenantID] = w
+		return w
+	}
+
+	elapsed := now.Sub(w.start)
+	switch {
+	case elapsed < limiter.config.Window:
+	case elapsed < 2*limiter.config.Window:
+		w.previousSamples, w.previousBytes = w.samples, w.bytes
+		w.samples, w.bytes = 0, 0
+		w.start = w.start.Add(limiter.config.Window)
+	default:
+		// No usage in the previous window.
+		w.previousSamples, w.previousBytes = 0, 0
+		w.samples, w.bytes = 0, 0
+		w.start = now.Truncate(limiter.config.Window)
+	}
+
+	return w
+}
@@ -0,0 +1,55 @@
+package telemetrystore
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/metric/noop"
+)
+
+func TestIngestionLimiterDisabled(t *testing.T) {
+	limiter, err := NewIngestionLimiter(noop.NewMeterProvider().Meter(""), "default", IngestionConfig{Enabled: false, Default: IngestionLimit{SamplesPerSecond: 1}})
+	require.NoError(t, err)
+
+	assert.NoError(t, limiter.Allow(context.Background(), "tenant", 1000, 1000))
+}
+
+func TestSlidingWindowIngestionLimiterAllow(t *testing.T) {
+	now := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	config := IngestionConfig{
+		Enabled: true,
+		Window:  10 * time.Second,
+		Default: IngestionLimit{SamplesPerSecond: 10, BytesPerSecond: 1000},
+		Tenants: map[string]IngestionLimit{"large": {SamplesPerSecond: 100}},
+	}
+
+	limiter, err := newSlidingWindowIngestionLimiter(noop.NewMeterProvider().Meter(""), "default", config, func() time.Time { return now })
+	require.NoError(t, err)
+
+	// 100 samples are allowed in a window of 10s at 10 samples per second.
+	assert.NoError(t, limiter.Allow(context.Background(), "tenant", 100, 0))
+
+	err = limiter.Allow(context.Background(), "tenant", 1, 0)
+	assert.True(t, errors.Ast(err, errors.TypeTooManyRequests))
+
+	// The bytes limit is enforced independently.
+	err = limiter.Allow(context.Background(), "other", 1, 10001)
+	assert.True(t, errors.Ast(err, errors.TypeTooManyRequests))
+
+	// Tenants can override the default limits.
+	assert.NoError(t, limiter.Allow(context.Background(), "large", 1000, 1000000))
+
+	// Halfway into the next window, half of the previous window is still counted.
+	now = now.Add(15 * time.Second)
+	assert.NoError(t, limiter.Allow(context.Background(), "tenant", 50, 0))
+	err = limiter.Allow(context.Background(), "tenant", 1, 0)
+	assert.True(t, errors.Ast(err, errors.TypeTooManyRequests))
+
+	// Once the sliding window no longer overlaps the usage, the limit is available again.
+	now = now.Add(time.Minute)
+	assert.NoError(t, limiter.Allow(context.Background(), "tenant", 100, 0))
+}
@@ -0,0 +1,55 @@
+package telemetrystore
+
+import (
+	"context"
+
+	"github.com/ClickHouse/clickhouse-go/v2"
+	"github.com/SigNoz/signoz/pkg/errors"
+)
+
+const (
+	// memoryLimitExceededExceptionCode is the code of the exception of clickhouse killing the queries using more
+	// memory than allowed, MEMORY_LIMIT_EXCEEDED.
+	memoryLimitExceededExceptionCode int32 = 241
+)
+
+var (
+	ErrCodeQueryMemoryLimitExceeded = errors.MustNewCode("query_memory_limit_exceeded")
+)
+
+// Limit returns the memory limit of the queries of the tenant, which is the default limit when the tenant has no
+// limit of its own.
+func (config QueryMemoryConfig) Limit(tenantID string) QueryMemoryLimit {
+	if limit, ok := config.Tenants[tenantID]; ok {
+		return limit
+	}
+
+	return config.Default
+}
+
+// LimitFromContext returns the memory limit of the queries of the tenant of the query tag of the context. It
+// returns false when the limits are not enabled.
+func (config QueryMemoryConfig) LimitFromContext(ctx context.Context) (QueryMemoryLimit, bool) {
+	if !config.Enabled {
+		return QueryMemoryLimit{}, false
+	}
+
+	tag, _ := QueryTagFromContext(ctx)
+	return config.Limit(tag.TenantID), true
+}
+
+// WrapQueryMemoryLimitExceeded returns an error explaining that the query was killed for using too much memory if
+// err is the exception of clickhouse killing it, and err otherwise. The limit is the one set on the query, if any,
+// as the query can also be killed by the limits of the server.
+func WrapQueryMemoryLimitExceeded(err error, limit QueryMemoryLimit) error {
+	var exception *clickhouse.Exception
+	if !errors.As(err, &exception) || exception.Code != memoryLimitExceededExceptionCode {
+		return err
+	}
+
+	if limit.MaxMemoryUsage > 0 {
+		return errors.Wrapf(err, errors.TypeInvalidInput, ErrCodeQueryMemoryLimitExceeded, "the query exceeded the memory limit of %d bytes, narrow down its time range, its filters or its group by", limit.MaxMemoryUsage)
+	}
+
+	return errors.Wrapf(err, errors.TypeInvalidInput, ErrCodeQueryMemoryLimitExceeded, "the query exceeded the memory available to it, narrow down its time range, its filters or its group by")
+}
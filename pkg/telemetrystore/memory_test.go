@@ -0,0 +1,48 @@
+package telemetrystore
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ClickHouse/clickhouse-go/v2"
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQueryMemoryLimitFromContext(t *testing.T) {
+	config := QueryMemoryConfig{
+		Default: QueryMemoryLimit{MaxMemoryUsage: 10 << 30},
+		Tenants: map[string]QueryMemoryLimit{"tenant": {MaxMemoryUsage: 1 << 30, MaxBytesBeforeExternalGroupBy: 512 << 20}},
+	}
+
+	ctx := NewContextWithQueryTag(context.Background(), QueryTag{TenantID: "tenant"})
+
+	_, ok := config.LimitFromContext(ctx)
+	assert.False(t, ok)
+
+	config.Enabled = true
+	limit, ok := config.LimitFromContext(ctx)
+	assert.True(t, ok)
+	assert.Equal(t, QueryMemoryLimit{MaxMemoryUsage: 1 << 30, MaxBytesBeforeExternalGroupBy: 512 << 20}, limit)
+
+	limit, ok = config.LimitFromContext(context.Background())
+	assert.True(t, ok)
+	assert.Equal(t, QueryMemoryLimit{MaxMemoryUsage: 10 << 30}, limit)
+}
+
+func TestWrapQueryMemoryLimitExceeded(t *testing.T) {
+	exception := &clickhouse.Exception{Code: 241, Name: "MEMORY_LIMIT_EXCEEDED", Message: "Memory limit (for query) exceeded"}
+
+	err := WrapQueryMemoryLimitExceeded(exception, QueryMemoryLimit{MaxMemoryUsage: 1 << 30})
+	assert.True(t, errors.Asc(err, ErrCodeQueryMemoryLimitExceeded))
+	assert.True(t, errors.Ast(err, errors.TypeInvalidInput))
+	_, _, message, _, _, _ := errors.Unwrapb(err)
+	assert.Contains(t, message, "1073741824 bytes")
+
+	err = WrapQueryMemoryLimitExceeded(exception, QueryMemoryLimit{})
+	assert.True(t, errors.Asc(err, ErrCodeQueryMemoryLimitExceeded))
+
+	other := &clickhouse.Exception{Code: 159, Name: "TIMEOUT_EXCEEDED"}
+	assert.Equal(t, error(other), WrapQueryMemoryLimitExceeded(other, QueryMemoryLimit{MaxMemoryUsage: 1 << 30}))
+	assert.Nil(t, WrapQueryMemoryLimitExceeded(nil, QueryMemoryLimit{}))
+}
@@ -0,0 +1,22 @@
+package telemetrystore
+
+import (
+	"context"
+
+	"github.com/ClickHouse/clickhouse-go/v2"
+)
+
+type progressKey struct{}
+
+// NewContextWithProgress returns a context reporting the progress of the queries to fn. Unlike
+// clickhouse.WithProgress, the progress of the queries can also be observed by the hooks.
+func NewContextWithProgress(ctx context.Context, fn func(*clickhouse.Progress)) context.Context {
+	ctx = context.WithValue(ctx, progressKey{}, fn)
+	return clickhouse.Context(ctx, clickhouse.WithProgress(fn))
+}
+
+// ProgressFromContext returns the function set with NewContextWithProgress.
+func ProgressFromContext(ctx context.Context) (func(*clickhouse.Progress), bool) {
+	fn, ok := ctx.Value(progressKey{}).(func(*clickhouse.Progress))
+	return fn, ok && fn != nil
+}
@@ -0,0 +1,230 @@
+package telemetrystore
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ClickHouse/clickhouse-go/v2"
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
+)
+
+var (
+	ErrCodeRetentionFailed = errors.MustNewCode("retention_failed")
+)
+
+// Retention manages the retention of the data of a telemetry store per shard, not per tenant. The tenants of a
+// shard share its tables and a TTL applies to a whole table, so the retention of a shard is the longest retention
+// of the tenants routed to it: no tenant loses its data before the end of its own retention, but the data of a
+// tenant may be kept longer than its own retention. Tenants needing a shorter retention are routed to their own
+// shard.
+//
+// The retention of a signal managed by the config replaces the retention set from the UI, which is refused for
+// that signal.
+type Retention interface {
+	// Policy returns the retention effectively applied to the data of the tenant, which is the retention of its
+	// shard. A signal with a zero retention is not managed by the config and keeps the retention set from the UI.
+	Policy(tenantID string) RetentionPolicy
+
+	// Managed returns whether the retention of the signal is managed by the config on any shard.
+	Managed(signal telemetrytypes.Signal) bool
+
+	// Apply sets the TTL of the tables of every shard to the retention of the shard. Only the parts written
+	// afterwards are affected so that nothing is rebuilt, the existing parts expire as they are merged.
+	Apply(ctx context.Context, tables []RetentionTable) error
+}
+
+// RetentionTable is a local table whose rows are deleted once the retention of their signal is over.
+type RetentionTable struct {
+	// Signal is the signal of the data of the table.
+	Signal telemetrytypes.Signal
+	// Name is the name of the table qualified by its database.
+	Name string
+	// TimeExpression is the DateTime expression from which the retention of the rows is counted.
+	TimeExpression string
+}
+
+// Get returns the retention of the signal.
+func (p RetentionPolicy) Get(signal telemetrytypes.Signal) time.Duration {
+	switch signal {
+	case telemetrytypes.SignalTraces:
+		return p.Traces
+	case telemetrytypes.SignalLogs:
+		return p.Logs
+	case telemetrytypes.SignalMetrics:
+		return p.Metrics
+	default:
+		return 0
+	}
+}
+
+// longest returns the longest retention of both policies for every signal. A signal unmanaged by either policy
+// stays unmanaged since its retention cannot be known.
+func (p RetentionPolicy) longest(other RetentionPolicy) RetentionPolicy {
+	longest := func(a, b time.Duration) time.Duration {
+		if a == 0 || b == 0 {
+			return 0
+		}
+
+		return max(a, b)
+	}
+
+	return RetentionPolicy{
+		Traces:  longest(p.Traces, other.Traces),
+		Logs:    longest(p.Logs, other.Logs),
+		Metrics: longest(p.Metrics, other.Metrics),
+	}
+}
+
+type noopRetention struct{}
+
+func (noopRetention) Policy(string) RetentionPolicy {
+	return RetentionPolicy{}
+}
+
+func (noopRetention) Managed(telemetrytypes.Signal) bool {
+	return false
+}
+
+func (noopRetention) Apply(context.Context, []RetentionTable) error {
+	return nil
+}
+
+type retention struct {
+	config  RetentionConfig
+	routing RoutingConfig
+	shards  map[string]clickhouse.Conn
+}
+
+// NewRetention returns the retention of the given config applied to the given shards. The returned retention
+// manages nothing when the retention is not enabled.
+func NewRetention(config RetentionConfig, routing RoutingConfig, shards map[string]clickhouse.Conn) Retention {
+	if !config.Enabled {
+		return noopRetention{}
+	}
+
+	return &retention{
+		config:  config,
+		routing: routing,
+		shards:  shards,
+	}
+}
+
+func (r *retention) Policy(tenantID string) RetentionPolicy {
+	return r.shardPolicy(NewRouter(r.routing).Route(tenantID))
+}
+
+func (r *retention) Managed(signal telemetrytypes.Signal) bool {
+	for name := range r.shards {
+		if r.shardPolicy(name).Get(signal) != 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// shardPolicy returns the longest retention of the tenants routed to the shard. The default shard also holds
+// every tenant which is not routed, so the default retention always applies to it.
+func (r *retention) shardPolicy(shard string) RetentionPolicy {
+	tenantPolicy := func(tenantID string) RetentionPolicy {
+		if policy, ok := r.config.Tenants[tenantID]; ok {
+			return policy
+		}
+
+		return r.config.Default
+	}
+
+	policies := make([]RetentionPolicy, 0)
+	if shard == DefaultShardName {
+		policies = append(policies, r.config.Default)
+		for tenantID := range r.config.Tenants {
+			if _, ok := r.routing.Tenants[tenantID]; !ok {
+				policies = append(policies, tenantPolicy(tenantID))
+			}
+		}
+	}
+
+	for tenantID, tenantShard := range r.routing.Tenants {
+		if tenantShard == shard {
+			policies = append(policies, tenantPolicy(tenantID))
+		}
+	}
+
+	if len(policies) == 0 {
+		return r.config.Default
+	}
+
+	policy := policies[0]
+	for _, other := range policies[1:] {
+		policy = policy.longest(other)
+	}
+
+	return policy
+}
+
+func (r *retention) Apply(ctx context.Context, tables []RetentionTable) error {
+	names := make([]string, 0, len(r.shards))
+	for name := range r.shards {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	errs := make([]error, 0)
+	for _, name := range names {
+		policy := r.shardPolicy(name)
+		for _, table := range tables {
+			retention := policy.Get(table.Signal)
+			if retention == 0 {
+				continue
+			}
+
+			if err := r.apply(ctx, r.shards[name], table, retention); err != nil {
+				errs = append(errs, errors.Wrapf(err, errors.TypeInternal, ErrCodeRetentionFailed, "failed to apply the retention of %s to shard %q", table.Name, name))
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (r *retention) apply(ctx context.Context, conn clickhouse.Conn, table RetentionTable, retention time.Duration) error {
+	database, name, _ := strings.Cut(table.Name, ".")
+
+	var engine string
+	if err := conn.QueryRow(ctx, "SELECT engine_full FROM system.tables WHERE database = ? AND name = ?", database, name).Scan(&engine); err != nil {
+		return err
+	}
+
+	seconds := int64(retention / time.Second)
+	ttl := ttlOf(engine)
+	// Clickhouse normalizes the intervals of the TTL to toIntervalSecond.
+	if strings.Contains(ttl, fmt.Sprintf("toIntervalSecond(%d) DELETE", seconds)) {
+		return nil
+	}
+
+	// The TTL is replaced as a whole, which would drop the moves to a cold storage volume set from the UI.
+	if strings.Contains(ttl, "TO VOLUME") {
+		return errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "table %s moves its data to a cold storage volume, set its retention from the UI instead", table.Name)
+	}
+
+	// The TTL is only materialized in the new parts so that changing it does not rewrite the table.
+	return conn.Exec(ctx, fmt.Sprintf(
+		"ALTER TABLE %s ON CLUSTER %s MODIFY TTL %s + INTERVAL %d SECOND DELETE SETTINGS materialize_ttl_after_modify = 0",
+		table.Name, r.config.Cluster, table.TimeExpression, seconds,
+	))
+}
+
+// ttlOf returns the TTL clause of the full engine of a table or an empty string if the table has no TTL.
+func ttlOf(engine string) string {
+	_, ttl, ok := strings.Cut(engine, " TTL ")
+	if !ok {
+		return ""
+	}
+
+	ttl, _, _ = strings.Cut(ttl, " SETTINGS ")
+	return ttl
+}
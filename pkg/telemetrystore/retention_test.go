@@ -0,0 +1,112 @@
+package telemetrystore
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
+	cmock "github.com/srikanthccv/ClickHouse-go-mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	day = 24 * time.Hour
+)
+
+func newTestRetention(t *testing.T) (*retention, map[string]cmock.ClickConnMockCommon) {
+	shards := newTestShards(t, DefaultShardName, "eu")
+	config := RetentionConfig{
+		Enabled: true,
+		Cluster: "cluster",
+		Default: RetentionPolicy{Traces: 7 * day, Logs: 7 * day, Metrics: 30 * day},
+		Tenants: map[string]RetentionPolicy{
+			"tenant-eu-long": {Traces: 90 * day, Logs: 90 * day, Metrics: 90 * day},
+			"tenant-eu-logs": {Traces: 7 * day, Logs: 0, Metrics: 30 * day},
+			"tenant-us-long": {Traces: 30 * day, Logs: 7 * day, Metrics: 30 * day},
+		},
+	}
+	routing := RoutingConfig{Tenants: map[string]string{"tenant-eu-long": "eu", "tenant-eu-logs": "eu"}}
+
+	mocks := make(map[string]cmock.ClickConnMockCommon, len(shards))
+	for name, shard := range shards {
+		mocks[name] = shard.(cmock.ClickConnMockCommon)
+	}
+
+	return NewRetention(config, routing, shards).(*retention), mocks
+}
+
+func TestRetentionPolicy(t *testing.T) {
+	retention, _ := newTestRetention(t)
+
+	// The tenants of a shard keep their data for the longest retention of the shard, an unmanaged signal stays
+	// unmanaged.
+	assert.Equal(t, RetentionPolicy{Traces: 90 * day, Logs: 0, Metrics: 90 * day}, retention.Policy("tenant-eu-long"))
+	assert.Equal(t, RetentionPolicy{Traces: 90 * day, Logs: 0, Metrics: 90 * day}, retention.Policy("tenant-eu-logs"))
+
+	// The tenants which are not routed share the default shard.
+	assert.Equal(t, RetentionPolicy{Traces: 30 * day, Logs: 7 * day, Metrics: 30 * day}, retention.Policy("tenant-us-long"))
+	assert.Equal(t, RetentionPolicy{Traces: 30 * day, Logs: 7 * day, Metrics: 30 * day}, retention.Policy("tenant-us"))
+}
+
+func TestRetentionManaged(t *testing.T) {
+	retention, _ := newTestRetention(t)
+
+	// The logs are unmanaged on the eu shard only.
+	assert.True(t, retention.Managed(telemetrytypes.SignalLogs))
+	assert.True(t, retention.Managed(telemetrytypes.SignalTraces))
+
+	retention.config.Default.Logs = 0
+	retention.config.Tenants["tenant-us-long"] = RetentionPolicy{Traces: 30 * day}
+	assert.False(t, retention.Managed(telemetrytypes.SignalLogs))
+}
+
+func TestRetentionDisabled(t *testing.T) {
+	retention := NewRetention(RetentionConfig{Enabled: false, Default: RetentionPolicy{Traces: day}}, RoutingConfig{}, newTestShards(t, DefaultShardName))
+
+	assert.Equal(t, RetentionPolicy{}, retention.Policy("tenant"))
+	assert.False(t, retention.Managed(telemetrytypes.SignalTraces))
+	assert.NoError(t, retention.Apply(context.Background(), []RetentionTable{{Signal: telemetrytypes.SignalTraces, Name: "signoz_traces.signoz_index_v3"}}))
+}
+
+func TestRetentionApply(t *testing.T) {
+	retention, mocks := newTestRetention(t)
+	tables := []RetentionTable{
+		{Signal: telemetrytypes.SignalTraces, Name: "signoz_traces.signoz_index_v3", TimeExpression: "toDateTime(timestamp)"},
+		{Signal: telemetrytypes.SignalLogs, Name: "signoz_logs.logs_v2", TimeExpression: "toDateTime(timestamp / 1000000000)"},
+	}
+	cols := []cmock.ColumnType{{Name: "engine_full", Type: "String"}}
+	engine := func(ttl string) []any {
+		engineFull := "MergeTree PARTITION BY toDate(timestamp) ORDER BY timestamp " + ttl + "SETTINGS index_granularity = 8192"
+		return []any{&engineFull}
+	}
+
+	// The TTL of the traces of the default shard is modified while its logs already have the right TTL.
+	mocks[DefaultShardName].ExpectQueryRow("SELECT engine_full FROM system.tables WHERE database = ? AND name = ?").WillReturnRow(cmock.NewRow(cols, engine("TTL toDateTime(timestamp) + toIntervalSecond(604800) DELETE ")))
+	mocks[DefaultShardName].ExpectExec("ALTER TABLE signoz_traces.signoz_index_v3 ON CLUSTER cluster MODIFY TTL toDateTime(timestamp) + INTERVAL 2592000 SECOND DELETE SETTINGS materialize_ttl_after_modify = 0")
+	mocks[DefaultShardName].ExpectQueryRow("SELECT engine_full FROM system.tables WHERE database = ? AND name = ?").WillReturnRow(cmock.NewRow(cols, engine("TTL toDateTime(timestamp / 1000000000) + toIntervalSecond(604800) DELETE ")))
+
+	// The logs of the eu shard are unmanaged and its traces have no TTL yet.
+	mocks["eu"].ExpectQueryRow("SELECT engine_full FROM system.tables WHERE database = ? AND name = ?").WillReturnRow(cmock.NewRow(cols, engine("")))
+	mocks["eu"].ExpectExec("ALTER TABLE signoz_traces.signoz_index_v3 ON CLUSTER cluster MODIFY TTL toDateTime(timestamp) + INTERVAL 7776000 SECOND DELETE SETTINGS materialize_ttl_after_modify = 0")
+
+	require.NoError(t, retention.Apply(context.Background(), tables))
+	for _, mock := range mocks {
+		assert.NoError(t, mock.ExpectationsWereMet())
+	}
+}
+
+func TestRetentionApplyKeepsColdStorage(t *testing.T) {
+	shards := newTestShards(t, DefaultShardName)
+	retention := NewRetention(RetentionConfig{Enabled: true, Cluster: "cluster", Default: RetentionPolicy{Traces: 7 * day}}, RoutingConfig{}, shards)
+
+	engineFull := "MergeTree ORDER BY timestamp TTL toDateTime(timestamp) + toIntervalSecond(86400) DELETE, toDateTime(timestamp) + toIntervalSecond(3600) TO VOLUME 's3' SETTINGS index_granularity = 8192"
+	mock := shards[DefaultShardName].(cmock.ClickConnMockCommon)
+	mock.ExpectQueryRow("SELECT engine_full FROM system.tables WHERE database = ? AND name = ?").WillReturnRow(cmock.NewRow([]cmock.ColumnType{{Name: "engine_full", Type: "String"}}, []any{&engineFull}))
+
+	// The TTL is not replaced so that the move to the cold storage volume is not dropped.
+	err := retention.Apply(context.Background(), []RetentionTable{{Signal: telemetrytypes.SignalTraces, Name: "signoz_traces.signoz_index_v3", TimeExpression: "toDateTime(timestamp)"}})
+	assert.ErrorContains(t, err, "cold storage")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
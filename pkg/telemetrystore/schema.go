@@ -0,0 +1,233 @@
+package telemetrystore
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ClickHouse/clickhouse-go/v2"
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
+)
+
+const (
+	ColumnKindString   string = "string"
+	ColumnKindNumber   string = "number"
+	ColumnKindBool     string = "bool"
+	ColumnKindDateTime string = "datetime"
+	ColumnKindArray    string = "array"
+	ColumnKindMap      string = "map"
+	ColumnKindTuple    string = "tuple"
+	ColumnKindJSON     string = "json"
+	ColumnKindUnknown  string = "unknown"
+)
+
+var (
+	ErrCodeSchemaFailed = errors.MustNewCode("schema_failed")
+
+	// schemaDatabases are the databases holding the tables of every signal.
+	schemaDatabases = map[telemetrytypes.Signal]string{
+		telemetrytypes.SignalTraces:  "signoz_traces",
+		telemetrytypes.SignalLogs:    "signoz_logs",
+		telemetrytypes.SignalMetrics: "signoz_metrics",
+	}
+)
+
+// Schema introspects the tables of the signals of a telemetry store.
+type Schema interface {
+	// Tables returns the tables of the signal with their columns. The tables are cached and fetched again once
+	// they are older than the refresh interval.
+	Tables(ctx context.Context, signal telemetrytypes.Signal) ([]SchemaTable, error)
+}
+
+// SchemaTable is a table of a signal.
+type SchemaTable struct {
+	// Name is the name of the table qualified by its database.
+	Name    string         `json:"name"`
+	Columns []SchemaColumn `json:"columns"`
+}
+
+// SchemaColumn is a column of a table.
+type SchemaColumn struct {
+	Name string `json:"name"`
+	// Type is the clickhouse type of the column.
+	Type string `json:"type"`
+	// DataType is the normalized type of the column.
+	DataType ColumnType `json:"dataType"`
+}
+
+// ColumnType is a clickhouse type normalized to a kind. The LowCardinality and Nullable wrappers are flags of
+// the type they wrap, and the element of an array and the key and value of a map are types of their own.
+type ColumnType struct {
+	Kind           string      `json:"kind"`
+	Nullable       bool        `json:"nullable,omitempty"`
+	LowCardinality bool        `json:"lowCardinality,omitempty"`
+	Element        *ColumnType `json:"element,omitempty"`
+	Key            *ColumnType `json:"key,omitempty"`
+	Value          *ColumnType `json:"value,omitempty"`
+}
+
+type schemaEntry struct {
+	tables      []SchemaTable
+	refreshedAt time.Time
+}
+
+type schema struct {
+	config  SchemaConfig
+	conn    clickhouse.Conn
+	now     func() time.Time
+	mtx     sync.Mutex
+	entries map[telemetrytypes.Signal]schemaEntry
+}
+
+// NewSchema returns the schema of the tables of the given connection. The tables of every shard are the same,
+// hence the connection of any shard can be introspected.
+func NewSchema(config SchemaConfig, conn clickhouse.Conn) Schema {
+	return &schema{
+		config:  config,
+		conn:    conn,
+		now:     time.Now,
+		entries: make(map[telemetrytypes.Signal]schemaEntry),
+	}
+}
+
+func (s *schema) Tables(ctx context.Context, signal telemetrytypes.Signal) ([]SchemaTable, error) {
+	database, ok := schemaDatabases[signal]
+	if !ok {
+		return nil, errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "invalid signal %q, must be one of traces, logs or metrics", signal.StringValue())
+	}
+
+	// The lock is held while fetching so that concurrent lookups of an expired signal fetch it once.
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	entry, ok := s.entries[signal]
+	if ok && s.now().Sub(entry.refreshedAt) < s.config.RefreshInterval {
+		return entry.tables, nil
+	}
+
+	tables, err := s.fetch(ctx, database)
+	if err != nil {
+		// The stale tables are better than nothing as the schema rarely changes.
+		if ok {
+			return entry.tables, nil
+		}
+
+		return nil, errors.Wrapf(err, errors.TypeInternal, ErrCodeSchemaFailed, "failed to introspect the tables of %s", signal.StringValue())
+	}
+
+	s.entries[signal] = schemaEntry{tables: tables, refreshedAt: s.now()}
+	return tables, nil
+}
+
+func (s *schema) fetch(ctx context.Context, database string) ([]SchemaTable, error) {
+	rows, err := s.conn.Query(ctx, "SELECT table, name, type FROM system.columns WHERE database = ? AND NOT startsWith(table, '.inner') ORDER BY table, position", database)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tables := make([]SchemaTable, 0)
+	for rows.Next() {
+		var table, name, typ string
+		if err := rows.Scan(&table, &name, &typ); err != nil {
+			return nil, err
+		}
+
+		qualified := database + "." + table
+		if len(tables) == 0 || tables[len(tables)-1].Name != qualified {
+			tables = append(tables, SchemaTable{Name: qualified, Columns: make([]SchemaColumn, 0)})
+		}
+
+		last := &tables[len(tables)-1]
+		last.Columns = append(last.Columns, SchemaColumn{Name: name, Type: typ, DataType: ParseColumnType(typ)})
+	}
+
+	return tables, rows.Err()
+}
+
+// ParseColumnType normalizes the clickhouse type. The types which cannot be normalized are of kind unknown.
+func ParseColumnType(typ string) ColumnType {
+	name, args := splitColumnType(strings.TrimSpace(typ))
+
+	switch name {
+	case "LowCardinality":
+		columnType := ParseColumnType(args)
+		columnType.LowCardinality = true
+		return columnType
+	case "Nullable":
+		columnType := ParseColumnType(args)
+		columnType.Nullable = true
+		return columnType
+	case "Array":
+		element := ParseColumnType(args)
+		return ColumnType{Kind: ColumnKindArray, Element: &element}
+	case "Map":
+		kv := splitColumnTypeArgs(args)
+		if len(kv) != 2 {
+			return ColumnType{Kind: ColumnKindUnknown}
+		}
+
+		key, value := ParseColumnType(kv[0]), ParseColumnType(kv[1])
+		return ColumnType{Kind: ColumnKindMap, Key: &key, Value: &value}
+	case "SimpleAggregateFunction":
+		// The values of a simple aggregate function are of the type of its arguments.
+		fn := splitColumnTypeArgs(args)
+		if len(fn) != 2 {
+			return ColumnType{Kind: ColumnKindUnknown}
+		}
+
+		return ParseColumnType(fn[1])
+	case "Tuple", "Nested":
+		return ColumnType{Kind: ColumnKindTuple}
+	case "JSON", "Object":
+		return ColumnType{Kind: ColumnKindJSON}
+	case "String", "FixedString", "UUID", "IPv4", "IPv6", "Enum8", "Enum16":
+		return ColumnType{Kind: ColumnKindString}
+	case "Bool":
+		return ColumnType{Kind: ColumnKindBool}
+	case "Date", "Date32", "DateTime", "DateTime64":
+		return ColumnType{Kind: ColumnKindDateTime}
+	}
+
+	for _, prefix := range []string{"Int", "UInt", "Float", "Decimal"} {
+		if strings.HasPrefix(name, prefix) {
+			return ColumnType{Kind: ColumnKindNumber}
+		}
+	}
+
+	return ColumnType{Kind: ColumnKindUnknown}
+}
+
+// splitColumnType splits the type into its name and the arguments between its parentheses.
+func splitColumnType(typ string) (string, string) {
+	open := strings.IndexByte(typ, '(')
+	if open < 0 || !strings.HasSuffix(typ, ")") {
+		return typ, ""
+	}
+
+	return typ[:open], typ[open+1 : len(typ)-1]
+}
+
+// splitColumnTypeArgs splits the arguments of a type on the commas which are neither nested nor quoted.
+func splitColumnTypeArgs(args string) []string {
+	parts := make([]string, 0)
+	depth, quoted, start := 0, false, 0
+	for i := 0; i < len(args); i++ {
+		switch c := args[i]; {
+		case c == '\'' && (i == 0 || args[i-1] != '\\'):
+			quoted = !quoted
+		case quoted:
+		case c == '(':
+			depth++
+		case c == ')':
+			depth--
+		case c == ',' && depth == 0:
+			parts = append(parts, strings.TrimSpace(args[start:i]))
+			start = i + 1
+		}
+	}
+
+	return append(parts, strings.TrimSpace(args[start:]))
+}
@@ -0,0 +1,92 @@
+package telemetrystore
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
+	cmock "github.com/srikanthccv/ClickHouse-go-mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseColumnType(t *testing.T) {
+	testCases := []struct {
+		typ      string
+		expected ColumnType
+	}{
+		{typ: "String", expected: ColumnType{Kind: ColumnKindString}},
+		{typ: "LowCardinality(String)", expected: ColumnType{Kind: ColumnKindString, LowCardinality: true}},
+		{typ: "LowCardinality(Nullable(String))", expected: ColumnType{Kind: ColumnKindString, LowCardinality: true, Nullable: true}},
+		{typ: "UInt64", expected: ColumnType{Kind: ColumnKindNumber}},
+		{typ: "Decimal(18, 4)", expected: ColumnType{Kind: ColumnKindNumber}},
+		{typ: "DateTime64(9)", expected: ColumnType{Kind: ColumnKindDateTime}},
+		{typ: "Bool", expected: ColumnType{Kind: ColumnKindBool}},
+		{typ: "Enum8('a' = 1, 'b,c' = 2)", expected: ColumnType{Kind: ColumnKindString}},
+		{typ: "Array(Float64)", expected: ColumnType{Kind: ColumnKindArray, Element: &ColumnType{Kind: ColumnKindNumber}}},
+		{
+			typ: "Map(LowCardinality(String), Array(Nullable(Int64)))",
+			expected: ColumnType{
+				Kind:  ColumnKindMap,
+				Key:   &ColumnType{Kind: ColumnKindString, LowCardinality: true},
+				Value: &ColumnType{Kind: ColumnKindArray, Element: &ColumnType{Kind: ColumnKindNumber, Nullable: true}},
+			},
+		},
+		{typ: "SimpleAggregateFunction(max, UInt64)", expected: ColumnType{Kind: ColumnKindNumber}},
+		{typ: "Tuple(a String, b UInt8)", expected: ColumnType{Kind: ColumnKindTuple}},
+		{typ: "AggregateFunction(uniq, String)", expected: ColumnType{Kind: ColumnKindUnknown}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.typ, func(t *testing.T) {
+			assert.Equal(t, tc.expected, ParseColumnType(tc.typ))
+		})
+	}
+}
+
+func TestSchemaTables(t *testing.T) {
+	conn := newTestShards(t, DefaultShardName)[DefaultShardName]
+	mock := conn.(cmock.ClickConnMockCommon)
+	s := NewSchema(SchemaConfig{RefreshInterval: time.Minute}, conn).(*schema)
+	now := time.Now()
+	s.now = func() time.Time { return now }
+
+	cols := []cmock.ColumnType{{Name: "table", Type: "String"}, {Name: "name", Type: "String"}, {Name: "type", Type: "String"}}
+	row := func(table, name, typ string) []any { return []any{&table, &name, &typ} }
+	query := "SELECT table, name, type FROM system.columns WHERE database = ? AND NOT startsWith(table, '.inner') ORDER BY table, position"
+	mock.ExpectQuery(query).WithArgs("signoz_logs").WillReturnRows(cmock.NewRows(cols, [][]any{
+		row("logs_v2", "timestamp", "UInt64"),
+		row("logs_v2", "attributes_string", "Map(LowCardinality(String), String)"),
+		row("tag_attributes_v2", "tag_key", "LowCardinality(String)"),
+	}))
+
+	expected := []SchemaTable{
+		{Name: "signoz_logs.logs_v2", Columns: []SchemaColumn{
+			{Name: "timestamp", Type: "UInt64", DataType: ColumnType{Kind: ColumnKindNumber}},
+			{Name: "attributes_string", Type: "Map(LowCardinality(String), String)", DataType: ColumnType{Kind: ColumnKindMap, Key: &ColumnType{Kind: ColumnKindString, LowCardinality: true}, Value: &ColumnType{Kind: ColumnKindString}}},
+		}},
+		{Name: "signoz_logs.tag_attributes_v2", Columns: []SchemaColumn{
+			{Name: "tag_key", Type: "LowCardinality(String)", DataType: ColumnType{Kind: ColumnKindString, LowCardinality: true}},
+		}},
+	}
+
+	tables, err := s.Tables(context.Background(), telemetrytypes.SignalLogs)
+	require.NoError(t, err)
+	assert.Equal(t, expected, tables)
+
+	// The tables are served from the cache until the refresh interval is over.
+	tables, err = s.Tables(context.Background(), telemetrytypes.SignalLogs)
+	require.NoError(t, err)
+	assert.Equal(t, expected, tables)
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	// The stale tables are served when they cannot be fetched again.
+	now = now.Add(2 * time.Minute)
+	tables, err = s.Tables(context.Background(), telemetrytypes.SignalLogs)
+	require.NoError(t, err)
+	assert.Equal(t, expected, tables)
+
+	_, err = s.Tables(context.Background(), telemetrytypes.SignalUnspecified)
+	assert.Error(t, err)
+}
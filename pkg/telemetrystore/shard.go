@@ -0,0 +1,114 @@
+package telemetrystore
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/ClickHouse/clickhouse-go/v2"
+	"github.com/SigNoz/signoz/pkg/errors"
+)
+
+const (
+	// DefaultShardName is the name of the shard connected to clickhouse::dsn.
+	DefaultShardName string = "default"
+)
+
+var (
+	ErrCodeShardsUnavailable = errors.MustNewCode("shards_unavailable")
+)
+
+type tenantIDKey struct{}
+
+// NewContextWithTenantID returns a context routing the operations of the telemetry store to the shard of the tenant.
+func NewContextWithTenantID(ctx context.Context, tenantID string) context.Context {
+	return context.WithValue(ctx, tenantIDKey{}, tenantID)
+}
+
+// TenantIDFromContext returns the tenant set with NewContextWithTenantID.
+func TenantIDFromContext(ctx context.Context) (string, bool) {
+	tenantID, ok := ctx.Value(tenantIDKey{}).(string)
+	return tenantID, ok && tenantID != ""
+}
+
+// Router maps the tenant of an operation to the name of the shard holding its data.
+type Router interface {
+	Route(tenantID string) string
+}
+
+// RouterFunc is an adapter to use an ordinary function as a Router.
+type RouterFunc func(tenantID string) string
+
+func (f RouterFunc) Route(tenantID string) string {
+	return f(tenantID)
+}
+
+// NewRouter returns a router mapping the tenants of the config to their shard and every other tenant to the
+// default shard.
+func NewRouter(config RoutingConfig) Router {
+	return RouterFunc(func(tenantID string) string {
+		if shard, ok := config.Tenants[tenantID]; ok {
+			return shard
+		}
+
+		return DefaultShardName
+	})
+}
+
+// FanOutResult is the merged result of an operation run on every shard.
+type FanOutResult[T any] struct {
+	// Value is the merge of the results of the shards which succeeded.
+	Value T
+	// Partial is set when some of the shards failed, in which case Value is missing their data.
+	Partial bool
+	// Errors are the errors of the shards which failed keyed by the shard name.
+	Errors map[string]error
+}
+
+// FanOut runs the operation concurrently on every shard and merges the results of the shards which succeeded.
+// Failed shards only make the result partial, an error is returned when all of them failed.
+func FanOut[T any](ctx context.Context, shards map[string]clickhouse.Conn, operation func(context.Context, clickhouse.Conn) (T, error), merge func([]T) T) (*FanOutResult[T], error) {
+	names := make([]string, 0, len(shards))
+	for name := range shards {
+		names = append(names, name)
+	}
+	// The results are merged in the order of the names so that merges which are not commutative are stable.
+	sort.Strings(names)
+
+	values := make([]T, len(names))
+	errs := make([]error, len(names))
+
+	var wg sync.WaitGroup
+	for i, name := range names {
+		wg.Add(1)
+		go func(i int, conn clickhouse.Conn) {
+			defer wg.Done()
+			values[i], errs[i] = operation(ctx, conn)
+		}(i, shards[name])
+	}
+	wg.Wait()
+
+	result := &FanOutResult[T]{Errors: make(map[string]error)}
+	succeeded := make([]T, 0, len(names))
+	for i, name := range names {
+		if errs[i] != nil {
+			result.Errors[name] = errs[i]
+			continue
+		}
+
+		succeeded = append(succeeded, values[i])
+	}
+
+	if len(succeeded) == 0 && len(result.Errors) > 0 {
+		joined := make([]error, 0, len(names))
+		for _, name := range names {
+			joined = append(joined, result.Errors[name])
+		}
+
+		return nil, errors.Wrapf(errors.Join(joined...), errors.TypeInternal, ErrCodeShardsUnavailable, "all %d shards failed", len(names))
+	}
+
+	result.Value = merge(succeeded)
+	result.Partial = len(result.Errors) > 0
+	return result, nil
+}
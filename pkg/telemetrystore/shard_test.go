@@ -0,0 +1,78 @@
+package telemetrystore
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ClickHouse/clickhouse-go/v2"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/SigNoz/signoz/pkg/errors"
+	cmock "github.com/srikanthccv/ClickHouse-go-mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestShards(t *testing.T, names ...string) map[string]clickhouse.Conn {
+	shards := make(map[string]clickhouse.Conn, len(names))
+	for _, name := range names {
+		conn, err := cmock.NewClickHouseWithQueryMatcher(&clickhouse.Options{}, sqlmock.QueryMatcherEqual)
+		require.NoError(t, err)
+		shards[name] = conn
+	}
+
+	return shards
+}
+
+func TestNewRouter(t *testing.T) {
+	router := NewRouter(RoutingConfig{Tenants: map[string]string{"tenant-eu": "eu"}})
+
+	assert.Equal(t, "eu", router.Route("tenant-eu"))
+	assert.Equal(t, DefaultShardName, router.Route("tenant-us"))
+}
+
+func TestTenantIDFromContext(t *testing.T) {
+	_, ok := TenantIDFromContext(context.Background())
+	assert.False(t, ok)
+
+	tenantID, ok := TenantIDFromContext(NewContextWithTenantID(context.Background(), "tenant"))
+	assert.True(t, ok)
+	assert.Equal(t, "tenant", tenantID)
+}
+
+func TestFanOut(t *testing.T) {
+	shards := newTestShards(t, "a", "b", "c")
+	counts := map[clickhouse.Conn]int{shards["a"]: 1, shards["b"]: 2, shards["c"]: 4}
+	sum := func(values []int) int {
+		total := 0
+		for _, value := range values {
+			total += value
+		}
+		return total
+	}
+
+	result, err := FanOut(context.Background(), shards, func(_ context.Context, conn clickhouse.Conn) (int, error) {
+		return counts[conn], nil
+	}, sum)
+	require.NoError(t, err)
+	assert.Equal(t, 7, result.Value)
+	assert.False(t, result.Partial)
+	assert.Empty(t, result.Errors)
+
+	// A failed shard degrades the result instead of failing it.
+	cause := errors.New(errors.TypeInternal, errors.CodeInternal, "connection refused")
+	result, err = FanOut(context.Background(), shards, func(_ context.Context, conn clickhouse.Conn) (int, error) {
+		if conn == shards["b"] {
+			return 0, cause
+		}
+		return counts[conn], nil
+	}, sum)
+	require.NoError(t, err)
+	assert.Equal(t, 5, result.Value)
+	assert.True(t, result.Partial)
+	assert.Equal(t, map[string]error{"b": cause}, result.Errors)
+
+	_, err = FanOut(context.Background(), shards, func(_ context.Context, conn clickhouse.Conn) (int, error) {
+		return 0, cause
+	}, sum)
+	assert.True(t, errors.Asc(err, ErrCodeShardsUnavailable))
+}
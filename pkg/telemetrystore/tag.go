@@ -0,0 +1,80 @@
+package telemetrystore
+
+import (
+	"context"
+	"strings"
+
+	"github.com/SigNoz/signoz/pkg/types/authtypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+)
+
+const (
+	QueryKindAPI       string = "api"
+	QueryKindAlert     string = "alert"
+	QueryKindDashboard string = "dashboard"
+	QueryKindExplorer  string = "explorer"
+)
+
+// QueryTag attributes the queries to the tenant, the user and the feature issuing them. It is written in the
+// query_id and the log_comment of the queries, which makes it available in system.query_log.
+type QueryTag struct {
+	TenantID string
+	UserID   string
+	Kind     string
+}
+
+type queryTagKey struct{}
+
+// NewQueryTagFromClaims returns the tag of the queries issued on behalf of the holder of the claims.
+func NewQueryTagFromClaims(claims authtypes.Claims, kind string) QueryTag {
+	return QueryTag{TenantID: claims.OrgID, UserID: claims.UserID, Kind: kind}
+}
+
+// NewContextWithQueryTag returns a context tagging the queries of the telemetry store with the given tag.
+func NewContextWithQueryTag(ctx context.Context, tag QueryTag) context.Context {
+	return context.WithValue(ctx, queryTagKey{}, tag)
+}
+
+// QueryTagFromContext returns the tag set with NewContextWithQueryTag.
+func QueryTagFromContext(ctx context.Context) (QueryTag, bool) {
+	tag, ok := ctx.Value(queryTagKey{}).(QueryTag)
+	return tag, ok
+}
+
+// KVs returns the non empty fields of the tag, keyed as in the log_comment of the queries.
+func (tag QueryTag) KVs() map[string]string {
+	kvs := make(map[string]string, 3)
+	if tag.TenantID != "" {
+		kvs["tenantID"] = tag.TenantID
+	}
+
+	if tag.UserID != "" {
+		kvs["userID"] = tag.UserID
+	}
+
+	if tag.Kind != "" {
+		kvs["queryKind"] = tag.Kind
+	}
+
+	return kvs
+}
+
+// NewQueryID returns a new query id. It is prefixed with the kind and the tenant of the tag of the context, so that
+// the queries of a tenant can be found in system.query_log by their id alone.
+func NewQueryID(ctx context.Context) string {
+	id := valuer.GenerateUUID().StringValue()
+
+	tag, ok := QueryTagFromContext(ctx)
+	if !ok {
+		return id
+	}
+
+	parts := make([]string, 0, 3)
+	for _, part := range []string{tag.Kind, tag.TenantID} {
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+
+	return strings.Join(append(parts, id), ":")
+}
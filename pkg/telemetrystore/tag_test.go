@@ -0,0 +1,28 @@
+package telemetrystore
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/SigNoz/signoz/pkg/types/authtypes"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewQueryID(t *testing.T) {
+	ctx := context.Background()
+	assert.NotContains(t, NewQueryID(ctx), ":")
+
+	ctx = NewContextWithQueryTag(ctx, NewQueryTagFromClaims(authtypes.Claims{OrgID: "org", UserID: "user"}, QueryKindDashboard))
+	queryID := NewQueryID(ctx)
+	assert.True(t, strings.HasPrefix(queryID, "dashboard:org:"))
+	assert.NotEqual(t, queryID, NewQueryID(ctx))
+
+	ctx = NewContextWithQueryTag(context.Background(), QueryTag{TenantID: "org"})
+	assert.True(t, strings.HasPrefix(NewQueryID(ctx), "org:"))
+}
+
+func TestQueryTagKVs(t *testing.T) {
+	assert.Equal(t, map[string]string{"tenantID": "org", "userID": "user", "queryKind": "api"}, QueryTag{TenantID: "org", UserID: "user", Kind: QueryKindAPI}.KVs())
+	assert.Equal(t, map[string]string{"queryKind": "alert"}, QueryTag{Kind: QueryKindAlert}.KVs())
+}
@@ -2,12 +2,57 @@ package telemetrystore
 
 import (
 	"context"
+	"time"
 
 	"github.com/ClickHouse/clickhouse-go/v2"
 )
 
 type TelemetryStore interface {
+	// ClickhouseDB returns a connection which routes every operation to the shard of the tenant of its context.
 	ClickhouseDB() clickhouse.Conn
+
+	// Shards returns a connection to every shard keyed by the shard name.
+	Shards() map[string]clickhouse.Conn
+
+	// PoolStats returns the statistics of the connection pool.
+	PoolStats() PoolStats
+
+	// IngestionLimiter returns the limiter enforcing the ingestion limits of the tenants.
+	IngestionLimiter() IngestionLimiter
+
+	// CardinalityGuard returns the guard enforcing the limits on the number of series of the metrics.
+	CardinalityGuard() CardinalityGuard
+
+	// Retention returns the retention of the data of the tenants.
+	Retention() Retention
+
+	// BatchInserter returns the inserter of the batches of rows, routing them as ClickhouseDB does.
+	BatchInserter() BatchInserter
+
+	// Schema returns the introspected schema of the tables of the signals.
+	Schema() Schema
+
+	// Estimator returns the estimator of the queries, routing them as ClickhouseDB does.
+	Estimator() Estimator
+
+	// Deleter returns the deleter of the series, routing the deletions to the shard of their tenant.
+	Deleter() Deleter
+}
+
+// PoolStats are the statistics of the connection pool of a telemetry store.
+type PoolStats struct {
+	// MaxOpenConns is the maximum number of open connections.
+	MaxOpenConns int
+	// OpenConns is the number of open connections, both in use and idle.
+	OpenConns int
+	// InUseConns is the number of connections in use.
+	InUseConns int
+	// IdleConns is the number of idle connections.
+	IdleConns int
+	// WaitCount is the number of operations which had to wait for a connection because all of them were in use.
+	WaitCount int64
+	// WaitDuration is the total time spent by the operations which had to wait for a connection.
+	WaitDuration time.Duration
 }
 
 type TelemetryStoreHook interface {
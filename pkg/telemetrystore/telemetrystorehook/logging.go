@@ -38,6 +38,9 @@ func (hook *logging) AfterQuery(ctx context.Context, event *telemetrystore.Query
 		"db.query.args", event.QueryArgs,
 		"db.duration", time.Since(event.StartTime).String(),
 	}
+	if event.QueryID != "" {
+		args = append(args, "db.query.id", event.QueryID)
+	}
 	if event.Err != nil && !errors.Is(event.Err, sql.ErrNoRows) && !errors.Is(event.Err, context.Canceled) {
 		level = slog.LevelError
 		args = append(args, "db.query.error", event.Err)
@@ -3,15 +3,20 @@ package telemetrystorehook
 import (
 	"context"
 	"encoding/json"
+	"maps"
+	"time"
 
 	"github.com/ClickHouse/clickhouse-go/v2"
+	"github.com/SigNoz/signoz/pkg/errors"
 	"github.com/SigNoz/signoz/pkg/factory"
 	"github.com/SigNoz/signoz/pkg/query-service/common"
 	"github.com/SigNoz/signoz/pkg/telemetrystore"
 )
 
 type provider struct {
-	settings telemetrystore.QuerySettings
+	providerSettings factory.ScopedProviderSettings
+	settings         telemetrystore.QuerySettings
+	memory           telemetrystore.QueryMemoryConfig
 }
 
 func NewSettingsFactory() factory.ProviderFactory[telemetrystore.TelemetryStoreHook, telemetrystore.Config] {
@@ -20,7 +25,9 @@ func NewSettingsFactory() factory.ProviderFactory[telemetrystore.TelemetryStoreH
 
 func NewSettings(ctx context.Context, providerSettings factory.ProviderSettings, config telemetrystore.Config) (telemetrystore.TelemetryStoreHook, error) {
 	return &provider{
-		settings: config.Clickhouse.QuerySettings,
+		providerSettings: factory.NewScopedProviderSettings(providerSettings, "github.com/SigNoz/signoz/pkg/telemetrystore/telemetrystorehook"),
+		settings:         config.Clickhouse.QuerySettings,
+		memory:           config.QueryMemory,
 	}, nil
 }
 
@@ -69,22 +76,69 @@ func (h *provider) BeforeQuery(ctx context.Context, _ *telemetrystore.QueryEvent
 		settings["result_overflow_mode"] = ctx.Value("result_overflow_mode")
 	}
 
-	ctx = clickhouse.Context(ctx, clickhouse.WithSettings(settings))
+	if limit, ok := h.memory.LimitFromContext(ctx); ok {
+		if limit.MaxMemoryUsage != 0 {
+			settings["max_memory_usage"] = limit.MaxMemoryUsage
+		}
+
+		if limit.MaxBytesBeforeExternalGroupBy != 0 {
+			settings["max_bytes_before_external_group_by"] = limit.MaxBytesBeforeExternalGroupBy
+		}
+	}
+
+	ctx = telemetrystore.NewContextWithSettings(ctx, settings)
+	if _, ok := telemetrystore.QueryTagFromContext(ctx); ok {
+		ctx = clickhouse.Context(ctx, clickhouse.WithQueryID(telemetrystore.NewQueryID(ctx)))
+	}
+
 	return ctx
 }
 
 func (h *provider) AfterQuery(ctx context.Context, event *telemetrystore.QueryEvent) {
+	if event.Err == nil || !errors.Asc(event.Err, telemetrystore.ErrCodeQueryMemoryLimitExceeded) {
+		return
+	}
+
+	// The queries killed for their memory are logged with their tag and their limits to tune the limits.
+	limit, _ := h.memory.LimitFromContext(ctx)
+	tag, _ := telemetrystore.QueryTagFromContext(ctx)
+	text := normalizeQuery(event.Query)
+
+	var message string
+	var exception *clickhouse.Exception
+	if errors.As(event.Err, &exception) {
+		message = exception.Message
+	}
+
+	h.providerSettings.Logger().WarnContext(
+		ctx,
+		"::TELEMETRYSTORE-QUERY-MEMORY-LIMIT-EXCEEDED::",
+		"db.query.text", text,
+		"db.query.kind", queryKind(text),
+		"db.query.id", event.QueryID,
+		"db.duration", time.Since(event.StartTime).String(),
+		"tenant_id", tag.TenantID,
+		"user_id", tag.UserID,
+		"kind", tag.Kind,
+		"max_memory_usage", limit.MaxMemoryUsage,
+		"max_bytes_before_external_group_by", limit.MaxBytesBeforeExternalGroupBy,
+		"exception", message,
+	)
 }
 
 func (h *provider) getLogComment(ctx context.Context) string {
 	// Get the key-value pairs from context for log comment
-	kv := ctx.Value(common.LogCommentKey)
-	if kv == nil {
-		return ""
+	logCommentKVs, _ := ctx.Value(common.LogCommentKey).(map[string]string)
+
+	// The tag is merged into a copy as the key-value pairs of the context are shared by all its queries.
+	if tag, ok := telemetrystore.QueryTagFromContext(ctx); ok {
+		kvs := make(map[string]string, len(logCommentKVs)+3)
+		maps.Copy(kvs, logCommentKVs)
+		maps.Copy(kvs, tag.KVs())
+		logCommentKVs = kvs
 	}
 
-	logCommentKVs, ok := kv.(map[string]string)
-	if !ok {
+	if len(logCommentKVs) == 0 {
 		return ""
 	}
 
@@ -0,0 +1,66 @@
+package telemetrystorehook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"github.com/ClickHouse/clickhouse-go/v2"
+	"github.com/SigNoz/signoz/pkg/factory/factorytest"
+	"github.com/SigNoz/signoz/pkg/query-service/common"
+	"github.com/SigNoz/signoz/pkg/telemetrystore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetLogComment(t *testing.T) {
+	h := &provider{}
+
+	assert.Empty(t, h.getLogComment(context.Background()))
+
+	kvs := map[string]string{"source": "dashboards"}
+	ctx := context.WithValue(context.Background(), common.LogCommentKey, kvs)
+	ctx = telemetrystore.NewContextWithQueryTag(ctx, telemetrystore.QueryTag{TenantID: "org", UserID: "user", Kind: telemetrystore.QueryKindDashboard})
+
+	logComment := map[string]string{}
+	require.NoError(t, json.Unmarshal([]byte(h.getLogComment(ctx)), &logComment))
+	assert.Equal(t, map[string]string{"source": "dashboards", "tenantID": "org", "userID": "user", "queryKind": "dashboard"}, logComment)
+
+	// The key-value pairs of the context are left untouched.
+	assert.Equal(t, map[string]string{"source": "dashboards"}, kvs)
+}
+
+func TestSettingsLogsQueryMemoryLimitExceeded(t *testing.T) {
+	buf := new(bytes.Buffer)
+	providerSettings := factorytest.NewSettings()
+	providerSettings.Logger = slog.New(slog.NewJSONHandler(buf, nil))
+
+	hook, err := NewSettings(context.Background(), providerSettings, telemetrystore.Config{QueryMemory: telemetrystore.QueryMemoryConfig{
+		Enabled: true,
+		Tenants: map[string]telemetrystore.QueryMemoryLimit{"org": {MaxMemoryUsage: 1 << 30, MaxBytesBeforeExternalGroupBy: 512 << 20}},
+	}})
+	require.NoError(t, err)
+
+	ctx := telemetrystore.NewContextWithQueryTag(context.Background(), telemetrystore.QueryTag{TenantID: "org", UserID: "user", Kind: telemetrystore.QueryKindExplorer})
+
+	event := telemetrystore.NewQueryEvent("SELECT uniq(trace_id) FROM t WHERE service = 'checkout'", nil)
+	hook.AfterQuery(hook.BeforeQuery(ctx, event), event)
+	assert.Empty(t, buf.String())
+
+	limit := telemetrystore.QueryMemoryLimit{MaxMemoryUsage: 1 << 30, MaxBytesBeforeExternalGroupBy: 512 << 20}
+	event.Err = telemetrystore.WrapQueryMemoryLimitExceeded(&clickhouse.Exception{Code: 241, Message: "Memory limit (for query) exceeded"}, limit)
+	hook.AfterQuery(hook.BeforeQuery(ctx, event), event)
+
+	record := map[string]any{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &record))
+	assert.Equal(t, "::TELEMETRYSTORE-QUERY-MEMORY-LIMIT-EXCEEDED::", record["msg"])
+	assert.Equal(t, "SELECT uniq(trace_id) FROM t WHERE service = ?", record["db.query.text"])
+	assert.Equal(t, "org", record["tenant_id"])
+	assert.Equal(t, "user", record["user_id"])
+	assert.Equal(t, "explorer", record["kind"])
+	assert.Equal(t, float64(1<<30), record["max_memory_usage"])
+	assert.Equal(t, float64(512<<20), record["max_bytes_before_external_group_by"])
+	assert.Equal(t, "Memory limit (for query) exceeded", record["exception"])
+}
@@ -0,0 +1,225 @@
+package telemetrystorehook
+
+import (
+	"context"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/ClickHouse/clickhouse-go/v2"
+	"github.com/SigNoz/signoz/pkg/factory"
+	"github.com/SigNoz/signoz/pkg/telemetrystore"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var (
+	// slowQueryKinds are the kinds of the slow queries counted, the others are counted as other.
+	slowQueryKinds = []string{"select", "insert", "alter", "create", "drop", "truncate", "optimize", "show", "describe", "system"}
+)
+
+type slowQueryStatsKey struct{}
+
+// slowQueryStats are the statistics of a query collected from its progress.
+type slowQueryStats struct {
+	rows atomic.Uint64
+}
+
+// observe returns a progress function collecting the statistics and reporting the progress to next, if any.
+func (stats *slowQueryStats) observe(next func(*clickhouse.Progress)) func(*clickhouse.Progress) {
+	return func(p *clickhouse.Progress) {
+		stats.rows.Add(p.Rows)
+		if next != nil {
+			next(p)
+		}
+	}
+}
+
+// slowQuery logs the queries taking longer than the threshold with their literals redacted.
+type slowQuery struct {
+	settings  factory.ScopedProviderSettings
+	threshold time.Duration
+	name      string
+	queries   metric.Int64Counter
+}
+
+func NewSlowQueryFactory() factory.ProviderFactory[telemetrystore.TelemetryStoreHook, telemetrystore.Config] {
+	return factory.NewProviderFactory(factory.MustNewName("slowquery"), NewSlowQuery)
+}
+
+func NewSlowQuery(ctx context.Context, providerSettings factory.ProviderSettings, config telemetrystore.Config) (telemetrystore.TelemetryStoreHook, error) {
+	settings := factory.NewScopedProviderSettings(providerSettings, "github.com/SigNoz/signoz/pkg/telemetrystore/telemetrystorehook")
+
+	queries, err := settings.Meter().Int64Counter("signoz.telemetrystore.slow_queries", metric.WithDescription("Number of queries taking longer than the slow query threshold, by kind."))
+	if err != nil {
+		return nil, err
+	}
+
+	return &slowQuery{
+		settings:  settings,
+		threshold: config.SlowQuery.Threshold,
+		name:      config.Name,
+		queries:   queries,
+	}, nil
+}
+
+func (hook *slowQuery) BeforeQuery(ctx context.Context, event *telemetrystore.QueryEvent) context.Context {
+	if hook.threshold <= 0 {
+		return ctx
+	}
+
+	// The progress set with clickhouse.WithProgress is replaced, the progress of the callers is only kept when
+	// it is set with telemetrystore.NewContextWithProgress.
+	stats := new(slowQueryStats)
+	progress, _ := telemetrystore.ProgressFromContext(ctx)
+	ctx = clickhouse.Context(ctx, clickhouse.WithProgress(stats.observe(progress)))
+
+	return context.WithValue(ctx, slowQueryStatsKey{}, stats)
+}
+
+func (hook *slowQuery) AfterQuery(ctx context.Context, event *telemetrystore.QueryEvent) {
+	if hook.threshold <= 0 {
+		return
+	}
+
+	duration := time.Since(event.StartTime)
+	if duration < hook.threshold {
+		return
+	}
+
+	// The rows of a query are read as they are streamed, only those read until now are known.
+	var rowsRead uint64
+	if stats, ok := ctx.Value(slowQueryStatsKey{}).(*slowQueryStats); ok {
+		rowsRead = stats.rows.Load()
+	}
+
+	text := normalizeQuery(event.Query)
+	kind := queryKind(text)
+
+	hook.queries.Add(ctx, 1, metric.WithAttributes(attribute.String("telemetrystore.name", hook.name), attribute.String("kind", kind)))
+
+	trace.SpanFromContext(ctx).AddEvent("slow_query", trace.WithAttributes(
+		attribute.String("db.query.text", text),
+		attribute.String("db.query.kind", kind),
+		attribute.Int64("db.duration_ms", duration.Milliseconds()),
+		attribute.Int64("db.rows_read", int64(rowsRead)),
+	))
+
+	hook.settings.Logger().WarnContext(
+		ctx,
+		"::TELEMETRYSTORE-SLOW-QUERY::",
+		"db.query.text", text,
+		"db.query.kind", kind,
+		"db.duration", duration.String(),
+		"db.rows_read", rowsRead,
+		"threshold", hook.threshold.String(),
+	)
+}
+
+// normalizeQuery redacts the string and number literals of the query and collapses its whitespaces. The comments
+// are dropped, they can hold literals as well.
+func normalizeQuery(query string) string {
+	var b strings.Builder
+	b.Grow(len(query))
+
+	space := false
+	write := func(s string) {
+		if space && b.Len() > 0 {
+			b.WriteByte(' ')
+		}
+		space = false
+		b.WriteString(s)
+	}
+
+	for i := 0; i < len(query); {
+		c := query[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			space = true
+			i++
+		case c == '-' && i+1 < len(query) && query[i+1] == '-':
+			for i < len(query) && query[i] != '\n' {
+				i++
+			}
+			space = true
+		case c == '/' && i+1 < len(query) && query[i+1] == '*':
+			end := strings.Index(query[i+2:], "*/")
+			if end < 0 {
+				i = len(query)
+			} else {
+				i += end + 4
+			}
+			space = true
+		case c == '\'':
+			// The quotes of a string literal are escaped with a backslash or doubled.
+			i++
+			for i < len(query) {
+				if query[i] == '\\' {
+					i += 2
+					continue
+				}
+				if query[i] == '\'' {
+					if i+1 < len(query) && query[i+1] == '\'' {
+						i += 2
+						continue
+					}
+					break
+				}
+				i++
+			}
+			i++
+			write("?")
+		case c == '"' || c == '`':
+			// Quoted identifiers are kept.
+			stop := len(query)
+			if end := strings.IndexByte(query[i+1:], c); end >= 0 {
+				stop = i + end + 2
+			}
+			write(query[i:stop])
+			i = stop
+		case isDigit(c) && (i == 0 || !isIdentifierByte(query[i-1])):
+			for i < len(query) && (isIdentifierByte(query[i]) || query[i] == '.') {
+				i++
+			}
+			write("?")
+		default:
+			start := i
+			for i < len(query) && isIdentifierByte(query[i]) {
+				i++
+			}
+			if i == start {
+				i++
+			}
+			write(query[start:i])
+		}
+	}
+
+	return b.String()
+}
+
+// queryKind returns the kind of the statement of the normalized query.
+func queryKind(query string) string {
+	keyword, _, _ := strings.Cut(query, " ")
+	keyword = strings.ToLower(strings.TrimLeft(keyword, "("))
+	if keyword == "with" {
+		// The common table expressions are only used by the selects.
+		return "select"
+	}
+
+	for _, kind := range slowQueryKinds {
+		if keyword == kind {
+			return kind
+		}
+	}
+
+	return "other"
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isIdentifierByte(c byte) bool {
+	return isDigit(c) || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || c == '_'
+}
@@ -0,0 +1,103 @@
+package telemetrystorehook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/ClickHouse/clickhouse-go/v2"
+	"github.com/SigNoz/signoz/pkg/factory/factorytest"
+	"github.com/SigNoz/signoz/pkg/telemetrystore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeQuery(t *testing.T) {
+	testCases := []struct {
+		name     string
+		query    string
+		expected string
+	}{
+		{
+			name:     "Strings",
+			query:    "SELECT * FROM logs WHERE body = 'john@example.com' AND name = 'it''s \\'quoted\\''",
+			expected: "SELECT * FROM logs WHERE body = ? AND name = ?",
+		},
+		{
+			name:     "Numbers",
+			query:    "SELECT count() FROM signoz_traces.distributed_signoz_index_v3 WHERE ts_bucket_start >= 1700000000 AND duration > 1.5e3 AND id IN (1, 0x1F)",
+			expected: "SELECT count() FROM signoz_traces.distributed_signoz_index_v3 WHERE ts_bucket_start >= ? AND duration > ? AND id IN (?, ?)",
+		},
+		{
+			name:     "Identifiers",
+			query:    "SELECT `user.email`, \"1st\" AS __result_0 FROM t",
+			expected: "SELECT `user.email`, \"1st\" AS __result_0 FROM t",
+		},
+		{
+			name:     "CommentsAndWhitespace",
+			query:    "\n  SELECT a -- user 'john'\n FROM /* id 42 */ t\n\tLIMIT 10\n",
+			expected: "SELECT a FROM t LIMIT ?",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, normalizeQuery(tc.query))
+		})
+	}
+}
+
+func TestQueryKind(t *testing.T) {
+	assert.Equal(t, "select", queryKind("SELECT ?"))
+	assert.Equal(t, "select", queryKind("WITH a AS (SELECT ?) SELECT * FROM a"))
+	assert.Equal(t, "insert", queryKind("insert INTO t VALUES"))
+	assert.Equal(t, "other", queryKind("RENAME TABLE a TO b"))
+}
+
+func TestSlowQuery(t *testing.T) {
+	buf := new(bytes.Buffer)
+	providerSettings := factorytest.NewSettings()
+	providerSettings.Logger = slog.New(slog.NewJSONHandler(buf, nil))
+
+	hook, err := NewSlowQuery(context.Background(), providerSettings, telemetrystore.Config{SlowQuery: telemetrystore.SlowQueryConfig{Threshold: time.Second}})
+	require.NoError(t, err)
+
+	rows := uint64(0)
+	ctx := telemetrystore.NewContextWithProgress(context.Background(), func(p *clickhouse.Progress) {
+		rows += p.Rows
+	})
+
+	t.Run("Fast", func(t *testing.T) {
+		buf.Reset()
+		event := telemetrystore.NewQueryEvent("SELECT 1", nil)
+		hook.AfterQuery(hook.BeforeQuery(ctx, event), event)
+		assert.Empty(t, buf.String())
+	})
+
+	t.Run("Slow", func(t *testing.T) {
+		buf.Reset()
+		event := telemetrystore.NewQueryEvent("SELECT * FROM logs WHERE body = 'john@example.com'", nil)
+		queryCtx := hook.BeforeQuery(ctx, event)
+
+		stats, ok := queryCtx.Value(slowQueryStatsKey{}).(*slowQueryStats)
+		require.True(t, ok)
+
+		// The progress is collected and still reported to the caller.
+		progress, _ := telemetrystore.ProgressFromContext(queryCtx)
+		stats.observe(progress)(&clickhouse.Progress{Rows: 10})
+		assert.Equal(t, uint64(10), rows)
+
+		event.StartTime = time.Now().Add(-2 * time.Second)
+		hook.AfterQuery(queryCtx, event)
+
+		actual := map[string]any{}
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &actual))
+		assert.Equal(t, "SELECT * FROM logs WHERE body = ?", actual["db.query.text"])
+		assert.Equal(t, "select", actual["db.query.kind"])
+		assert.Equal(t, float64(10), actual["db.rows_read"])
+		assert.NotContains(t, buf.String(), "john@example.com")
+	})
+}
@@ -0,0 +1,116 @@
+package telemetrystoretest
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/SigNoz/signoz/pkg/telemetrystore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const conformanceTable string = "signoz_conformance"
+
+type conformanceRow struct {
+	ID    string `ch:"id"`
+	Value int64  `ch:"value"`
+}
+
+// Seed inserts the rows in the columns of the table in one batch.
+func Seed(ctx context.Context, store telemetrystore.TelemetryStore, table string, columns []string, rows ...[]any) error {
+	batch, err := store.ClickhouseDB().PrepareBatch(ctx, "INSERT INTO "+table+" ("+strings.Join(columns, ", ")+")")
+	if err != nil {
+		return err
+	}
+
+	for _, row := range rows {
+		if err := batch.Append(row...); err != nil {
+			_ = batch.Abort()
+			return err
+		}
+	}
+
+	return batch.Send()
+}
+
+// RunConformance runs the tests every implementation of telemetrystore.TelemetryStore running the queries must pass
+// against the stores returned by newStore. The tests create, and drop once they are over, a table of their own.
+func RunConformance(t *testing.T, newStore func(t *testing.T) telemetrystore.TelemetryStore) {
+	ctx := context.Background()
+
+	setup := func(t *testing.T) telemetrystore.TelemetryStore {
+		store := newStore(t)
+		require.NoError(t, store.ClickhouseDB().Exec(ctx, "DROP TABLE IF EXISTS "+conformanceTable))
+		require.NoError(t, store.ClickhouseDB().Exec(ctx, "CREATE TABLE "+conformanceTable+" (id String, value Int64) ENGINE = MergeTree ORDER BY id"))
+		t.Cleanup(func() {
+			_ = store.ClickhouseDB().Exec(ctx, "DROP TABLE IF EXISTS "+conformanceTable)
+		})
+
+		require.NoError(t, Seed(ctx, store, conformanceTable, []string{"id", "value"}, []any{"a", int64(3)}, []any{"b", int64(1)}, []any{"c", int64(2)}))
+		return store
+	}
+
+	t.Run("Count", func(t *testing.T) {
+		store := setup(t)
+
+		var count uint64
+		require.NoError(t, store.ClickhouseDB().QueryRow(ctx, "SELECT count() FROM "+conformanceTable).Scan(&count))
+		assert.Equal(t, uint64(3), count)
+	})
+
+	t.Run("Where", func(t *testing.T) {
+		store := setup(t)
+
+		var value int64
+		require.NoError(t, store.ClickhouseDB().QueryRow(ctx, "SELECT value FROM "+conformanceTable+" WHERE id = ?", "b").Scan(&value))
+		assert.Equal(t, int64(1), value)
+	})
+
+	t.Run("OrderLimit", func(t *testing.T) {
+		store := setup(t)
+
+		rows, err := store.ClickhouseDB().Query(ctx, "SELECT id FROM "+conformanceTable+" ORDER BY value DESC LIMIT 2")
+		require.NoError(t, err)
+		defer rows.Close()
+
+		ids := []string{}
+		for rows.Next() {
+			var id string
+			require.NoError(t, rows.Scan(&id))
+			ids = append(ids, id)
+		}
+		require.NoError(t, rows.Err())
+		assert.Equal(t, []string{"a", "c"}, ids)
+	})
+
+	t.Run("Select", func(t *testing.T) {
+		store := setup(t)
+
+		var rows []conformanceRow
+		require.NoError(t, store.ClickhouseDB().Select(ctx, &rows, "SELECT id, value FROM "+conformanceTable+" ORDER BY id"))
+		assert.Equal(t, []conformanceRow{{ID: "a", Value: 3}, {ID: "b", Value: 1}, {ID: "c", Value: 2}}, rows)
+	})
+
+	t.Run("AppendStruct", func(t *testing.T) {
+		store := setup(t)
+
+		batch, err := store.ClickhouseDB().PrepareBatch(ctx, "INSERT INTO "+conformanceTable+" (id, value)")
+		require.NoError(t, err)
+		require.NoError(t, batch.AppendStruct(&conformanceRow{ID: "d", Value: 4}))
+		require.NoError(t, batch.Send())
+
+		var value int64
+		require.NoError(t, store.ClickhouseDB().QueryRow(ctx, "SELECT value FROM "+conformanceTable+" WHERE id = ?", "d").Scan(&value))
+		assert.Equal(t, int64(4), value)
+	})
+
+	t.Run("Truncate", func(t *testing.T) {
+		store := setup(t)
+		require.NoError(t, store.ClickhouseDB().Exec(ctx, "TRUNCATE TABLE "+conformanceTable))
+
+		var count uint64
+		require.NoError(t, store.ClickhouseDB().QueryRow(ctx, "SELECT count() FROM "+conformanceTable).Scan(&count))
+		assert.Equal(t, uint64(0), count)
+	})
+}
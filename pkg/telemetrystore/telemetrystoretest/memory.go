@@ -0,0 +1,837 @@
+package telemetrystoretest
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ClickHouse/clickhouse-go/v2"
+	"github.com/ClickHouse/clickhouse-go/v2/lib/column"
+	"github.com/ClickHouse/clickhouse-go/v2/lib/driver"
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/telemetrystore"
+	"go.opentelemetry.io/otel/metric/noop"
+)
+
+var (
+	ErrCodeMemoryQueryUnsupported = errors.MustNewCode("memory_query_unsupported")
+)
+
+var (
+	createRe   = regexp.MustCompile(`(?is)^\s*CREATE\s+TABLE\s+(?:IF\s+NOT\s+EXISTS\s+)?([\w.]+)\s*\((.*)\).*$`)
+	dropRe     = regexp.MustCompile(`(?is)^\s*DROP\s+TABLE\s+(?:IF\s+EXISTS\s+)?([\w.]+)\s*;?\s*$`)
+	truncateRe = regexp.MustCompile(`(?is)^\s*TRUNCATE\s+TABLE\s+(?:IF\s+EXISTS\s+)?([\w.]+)\s*;?\s*$`)
+	insertRe   = regexp.MustCompile(`(?is)^\s*INSERT\s+INTO\s+([\w.]+)\s*(?:\(([^)]*)\))?\s*(?:VALUES\s*\(([^)]*)\))?\s*;?\s*$`)
+	selectRe   = regexp.MustCompile(`(?is)^\s*SELECT\s+(.+?)\s+FROM\s+([\w.]+)(?:\s+WHERE\s+(.+?))?(?:\s+ORDER\s+BY\s+(\w+)(?:\s+(ASC|DESC))?)?(?:\s+LIMIT\s+(\d+))?\s*;?\s*$`)
+	equalsRe   = regexp.MustCompile(`(?is)^\s*(\w+)\s*=\s*\?\s*$`)
+	andRe      = regexp.MustCompile(`(?i)\s+AND\s+`)
+)
+
+var _ telemetrystore.TelemetryStore = (*Memory)(nil)
+
+// Memory is a telemetry store keeping the rows in memory, for the tests of the modules reading and writing telemetry.
+// It answers the statements below, and returns an error of type TypeUnsupported for every other statement:
+//
+//	CREATE TABLE [IF NOT EXISTS] t (c1 Type, c2 Type) ...
+//	DROP TABLE [IF EXISTS] t
+//	TRUNCATE TABLE [IF EXISTS] t
+//	INSERT INTO t (c1, c2) [VALUES (?, ?)]
+//	SELECT c1, c2 | * | count() FROM t [WHERE c1 = ? [AND c2 = ?]] [ORDER BY c1 [ASC|DESC]] [LIMIT n]
+type Memory struct {
+	conn      *memoryConn
+	limiter   telemetrystore.IngestionLimiter
+	guard     telemetrystore.CardinalityGuard
+	retention telemetrystore.Retention
+	inserter  telemetrystore.BatchInserter
+	schema    telemetrystore.Schema
+	estimator telemetrystore.Estimator
+	deleter   telemetrystore.Deleter
+}
+
+// NewMemory creates a new telemetry store keeping the rows in memory
+func NewMemory(config telemetrystore.Config) *Memory {
+	limiter, err := telemetrystore.NewIngestionLimiter(noop.NewMeterProvider().Meter(""), config.Name, config.Ingestion)
+	if err != nil {
+		panic(err)
+	}
+
+	guard, err := telemetrystore.NewCardinalityGuard(slog.New(slog.NewTextHandler(io.Discard, nil)), noop.NewMeterProvider().Meter(""), config.Name, config.Cardinality)
+	if err != nil {
+		panic(err)
+	}
+
+	memory := &Memory{
+		conn:    &memoryConn{tables: map[string]*memoryTable{}},
+		limiter: limiter,
+		guard:   guard,
+	}
+	memory.retention = telemetrystore.NewRetention(config.Retention, config.Routing, memory.Shards())
+	memory.schema = telemetrystore.NewSchema(config.Schema, memory.ClickhouseDB())
+	memory.estimator = telemetrystore.NewEstimator(config.QueryBudget, memory.ClickhouseDB())
+	memory.deleter = telemetrystore.NewDeleter(config.Deletion, config.Routing, memory.ClickhouseDB())
+
+	memory.inserter, err = telemetrystore.NewBatchInserter(noop.NewMeterProvider().Meter(""), config.Name, memory.ClickhouseDB(), config.Batch)
+	if err != nil {
+		panic(err)
+	}
+
+	return memory
+}
+
+// ClickhouseDB returns the connection to the tables kept in memory
+func (m *Memory) ClickhouseDB() clickhouse.Conn {
+	return m.conn
+}
+
+// Shards returns the connection to the tables kept in memory as the default shard
+func (m *Memory) Shards() map[string]clickhouse.Conn {
+	return map[string]clickhouse.Conn{telemetrystore.DefaultShardName: m.conn}
+}
+
+// PoolStats returns empty statistics, there is no connection pool
+func (m *Memory) PoolStats() telemetrystore.PoolStats {
+	return telemetrystore.PoolStats{}
+}
+
+// IngestionLimiter returns the limiter built from the ingestion config
+func (m *Memory) IngestionLimiter() telemetrystore.IngestionLimiter {
+	return m.limiter
+}
+
+// CardinalityGuard returns the guard built from the cardinality config
+func (m *Memory) CardinalityGuard() telemetrystore.CardinalityGuard {
+	return m.guard
+}
+
+// Retention returns the retention built from the retention config applied to the tables kept in memory
+func (m *Memory) Retention() telemetrystore.Retention {
+	return m.retention
+}
+
+// BatchInserter returns the inserter built from the batch config writing to the tables kept in memory
+func (m *Memory) BatchInserter() telemetrystore.BatchInserter {
+	return m.inserter
+}
+
+// Schema returns the schema introspecting the tables kept in memory
+func (m *Memory) Schema() telemetrystore.Schema {
+	return m.schema
+}
+
+// Estimator returns the estimator built from the query budget config estimating on the tables kept in memory
+func (m *Memory) Estimator() telemetrystore.Estimator {
+	return m.estimator
+}
+
+// Deleter returns the deleter built from the deletion config deleting on the tables kept in memory
+func (m *Memory) Deleter() telemetrystore.Deleter {
+	return m.deleter
+}
+
+// Rows returns a copy of the rows of the table keyed by the column names, in the order they were inserted in.
+func (m *Memory) Rows(table string) []map[string]any {
+	m.conn.mtx.Lock()
+	defer m.conn.mtx.Unlock()
+
+	t, ok := m.conn.tables[table]
+	if !ok {
+		return nil
+	}
+
+	rows := make([]map[string]any, len(t.rows))
+	for i, row := range t.rows {
+		rows[i] = make(map[string]any, len(t.columns))
+		for j, column := range t.columns {
+			rows[i][column] = row[j]
+		}
+	}
+
+	return rows
+}
+
+// Statements returns the statements run on the store, in the order they were run in.
+func (m *Memory) Statements() []string {
+	m.conn.mtx.Lock()
+	defer m.conn.mtx.Unlock()
+
+	return append([]string(nil), m.conn.statements...)
+}
+
+type memoryTable struct {
+	columns []string
+	rows    [][]any
+}
+
+func (t *memoryTable) index(column string) (int, bool) {
+	for i, c := range t.columns {
+		if c == column {
+			return i, true
+		}
+	}
+
+	return 0, false
+}
+
+type memoryConn struct {
+	mtx        sync.Mutex
+	tables     map[string]*memoryTable
+	statements []string
+}
+
+func (conn *memoryConn) Contributors() []string {
+	return nil
+}
+
+func (conn *memoryConn) ServerVersion() (*driver.ServerVersion, error) {
+	return &driver.ServerVersion{Name: "memory"}, nil
+}
+
+func (conn *memoryConn) Select(ctx context.Context, dest any, query string, args ...any) error {
+	value := reflect.ValueOf(dest)
+	if value.Kind() != reflect.Pointer || value.Elem().Kind() != reflect.Slice {
+		return errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "dest must be a pointer to a slice, got %T", dest)
+	}
+
+	rows, err := conn.Query(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	slice := value.Elem()
+	for rows.Next() {
+		elem := reflect.New(slice.Type().Elem())
+		if err := rows.ScanStruct(elem.Interface()); err != nil {
+			return err
+		}
+
+		slice.Set(reflect.Append(slice, elem.Elem()))
+	}
+
+	return rows.Err()
+}
+
+func (conn *memoryConn) Query(ctx context.Context, query string, args ...any) (driver.Rows, error) {
+	conn.mtx.Lock()
+	defer conn.mtx.Unlock()
+
+	conn.statements = append(conn.statements, query)
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	matches := selectRe.FindStringSubmatch(query)
+	if matches == nil {
+		return nil, errors.Newf(errors.TypeUnsupported, ErrCodeMemoryQueryUnsupported, "query %q is not supported by the memory telemetry store", query)
+	}
+
+	table, ok := conn.tables[matches[2]]
+	if !ok {
+		return nil, errors.Newf(errors.TypeNotFound, errors.CodeNotFound, "table %s doesn't exist", matches[2])
+	}
+
+	rows, err := filter(table, matches[3], args)
+	if err != nil {
+		return nil, err
+	}
+
+	if matches[4] != "" {
+		i, ok := table.index(matches[4])
+		if !ok {
+			return nil, errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "column %s doesn't exist in table %s", matches[4], matches[2])
+		}
+
+		desc := strings.EqualFold(matches[5], "DESC")
+		sort.SliceStable(rows, func(a, b int) bool {
+			if desc {
+				return less(rows[b][i], rows[a][i])
+			}
+
+			return less(rows[a][i], rows[b][i])
+		})
+	}
+
+	if matches[6] != "" {
+		limit, _ := strconv.Atoi(matches[6])
+		if limit < len(rows) {
+			rows = rows[:limit]
+		}
+	}
+
+	projection := strings.TrimSpace(matches[1])
+	if strings.EqualFold(projection, "count()") || strings.EqualFold(projection, "count(*)") {
+		return &memoryRows{columns: []string{"count()"}, rows: [][]any{{uint64(len(rows))}}}, nil
+	}
+
+	if projection == "*" {
+		return &memoryRows{columns: append([]string(nil), table.columns...), rows: rows}, nil
+	}
+
+	columns := splitColumns(projection)
+	indexes := make([]int, len(columns))
+	for i, column := range columns {
+		if indexes[i], ok = table.index(column); !ok {
+			return nil, errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "column %s doesn't exist in table %s", column, matches[2])
+		}
+	}
+
+	projected := make([][]any, len(rows))
+	for i, row := range rows {
+		projected[i] = make([]any, len(indexes))
+		for j, index := range indexes {
+			projected[i][j] = row[index]
+		}
+	}
+
+	return &memoryRows{columns: columns, rows: projected}, nil
+}
+
+func (conn *memoryConn) QueryRow(ctx context.Context, query string, args ...any) driver.Row {
+	rows, err := conn.Query(ctx, query, args...)
+	if err != nil {
+		return &memoryRow{err: err}
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		return &memoryRow{err: errors.New(errors.TypeNotFound, errors.CodeNotFound, "no rows in result set")}
+	}
+
+	return &memoryRow{rows: rows.(*memoryRows)}
+}
+
+func (conn *memoryConn) PrepareBatch(ctx context.Context, query string, opts ...driver.PrepareBatchOption) (driver.Batch, error) {
+	conn.mtx.Lock()
+	defer conn.mtx.Unlock()
+
+	conn.statements = append(conn.statements, query)
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	matches := insertRe.FindStringSubmatch(query)
+	if matches == nil || matches[3] != "" {
+		return nil, errors.Newf(errors.TypeUnsupported, ErrCodeMemoryQueryUnsupported, "batch %q is not supported by the memory telemetry store", query)
+	}
+
+	table, ok := conn.tables[matches[1]]
+	if !ok {
+		return nil, errors.Newf(errors.TypeNotFound, errors.CodeNotFound, "table %s doesn't exist", matches[1])
+	}
+
+	columns := table.columns
+	if matches[2] != "" {
+		columns = splitColumns(matches[2])
+	}
+
+	for _, column := range columns {
+		if _, ok := table.index(column); !ok {
+			return nil, errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "column %s doesn't exist in table %s", column, matches[1])
+		}
+	}
+
+	return &memoryBatch{conn: conn, table: matches[1], columns: columns}, nil
+}
+
+func (conn *memoryConn) Exec(ctx context.Context, query string, args ...any) error {
+	conn.mtx.Lock()
+	defer conn.mtx.Unlock()
+
+	conn.statements = append(conn.statements, query)
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	if matches := createRe.FindStringSubmatch(query); matches != nil {
+		if _, ok := conn.tables[matches[1]]; ok {
+			return nil
+		}
+
+		columns := []string{}
+		for _, definition := range strings.Split(matches[2], ",") {
+			if fields := strings.Fields(definition); len(fields) > 0 {
+				columns = append(columns, strings.Trim(fields[0], "`\""))
+			}
+		}
+
+		conn.tables[matches[1]] = &memoryTable{columns: columns}
+		return nil
+	}
+
+	if matches := dropRe.FindStringSubmatch(query); matches != nil {
+		delete(conn.tables, matches[1])
+		return nil
+	}
+
+	if matches := truncateRe.FindStringSubmatch(query); matches != nil {
+		if table, ok := conn.tables[matches[1]]; ok {
+			table.rows = nil
+		}
+
+		return nil
+	}
+
+	if matches := insertRe.FindStringSubmatch(query); matches != nil && matches[3] != "" {
+		table, ok := conn.tables[matches[1]]
+		if !ok {
+			return errors.Newf(errors.TypeNotFound, errors.CodeNotFound, "table %s doesn't exist", matches[1])
+		}
+
+		columns := table.columns
+		if matches[2] != "" {
+			columns = splitColumns(matches[2])
+		}
+
+		return table.insert(columns, args)
+	}
+
+	return errors.Newf(errors.TypeUnsupported, ErrCodeMemoryQueryUnsupported, "statement %q is not supported by the memory telemetry store", query)
+}
+
+func (conn *memoryConn) AsyncInsert(ctx context.Context, query string, wait bool, args ...any) error {
+	return conn.Exec(ctx, query, args...)
+}
+
+func (conn *memoryConn) Ping(ctx context.Context) error {
+	return ctx.Err()
+}
+
+func (conn *memoryConn) Stats() driver.Stats {
+	return driver.Stats{}
+}
+
+func (conn *memoryConn) Close() error {
+	return nil
+}
+
+func (t *memoryTable) insert(columns []string, values []any) error {
+	if len(values) != len(columns) {
+		return errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "expected %d values, got %d", len(columns), len(values))
+	}
+
+	row := make([]any, len(t.columns))
+	for i, column := range columns {
+		index, ok := t.index(column)
+		if !ok {
+			return errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "column %s doesn't exist", column)
+		}
+
+		row[index] = values[i]
+	}
+
+	t.rows = append(t.rows, row)
+	return nil
+}
+
+type memoryBatch struct {
+	conn    *memoryConn
+	table   string
+	columns []string
+	rows    [][]any
+	sent    bool
+}
+
+func (batch *memoryBatch) Abort() error {
+	batch.rows = nil
+	batch.sent = true
+	return nil
+}
+
+func (batch *memoryBatch) Append(v ...any) error {
+	if batch.sent {
+		return errors.New(errors.TypeInvalidInput, errors.CodeInvalidInput, "batch has already been sent")
+	}
+
+	if len(v) != len(batch.columns) {
+		return errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "expected %d values, got %d", len(batch.columns), len(v))
+	}
+
+	batch.rows = append(batch.rows, v)
+	return nil
+}
+
+func (batch *memoryBatch) AppendStruct(v any) error {
+	fields, err := structFields(v)
+	if err != nil {
+		return err
+	}
+
+	values := make([]any, len(batch.columns))
+	for i, column := range batch.columns {
+		field, ok := fields[column]
+		if !ok {
+			return errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "%T has no field for column %s", v, column)
+		}
+
+		values[i] = field.Interface()
+	}
+
+	return batch.Append(values...)
+}
+
+func (batch *memoryBatch) Column(int) driver.BatchColumn {
+	return &memoryBatchColumn{}
+}
+
+func (batch *memoryBatch) Flush() error {
+	return nil
+}
+
+func (batch *memoryBatch) Send() error {
+	if batch.sent {
+		return errors.New(errors.TypeInvalidInput, errors.CodeInvalidInput, "batch has already been sent")
+	}
+
+	batch.conn.mtx.Lock()
+	defer batch.conn.mtx.Unlock()
+
+	batch.sent = true
+	table, ok := batch.conn.tables[batch.table]
+	if !ok {
+		return errors.Newf(errors.TypeNotFound, errors.CodeNotFound, "table %s doesn't exist", batch.table)
+	}
+
+	for _, row := range batch.rows {
+		if err := table.insert(batch.columns, row); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (batch *memoryBatch) IsSent() bool {
+	return batch.sent
+}
+
+func (batch *memoryBatch) Rows() int {
+	return len(batch.rows)
+}
+
+func (batch *memoryBatch) Columns() []column.Interface {
+	return nil
+}
+
+// memoryBatchColumn rejects the columnar appends, the rows are appended with Append and AppendStruct.
+type memoryBatchColumn struct{}
+
+func (*memoryBatchColumn) Append(any) error {
+	return errors.New(errors.TypeUnsupported, ErrCodeMemoryQueryUnsupported, "columnar appends are not supported by the memory telemetry store")
+}
+
+func (*memoryBatchColumn) AppendRow(any) error {
+	return errors.New(errors.TypeUnsupported, ErrCodeMemoryQueryUnsupported, "columnar appends are not supported by the memory telemetry store")
+}
+
+type memoryRows struct {
+	columns []string
+	rows    [][]any
+	next    int
+}
+
+func (rows *memoryRows) Next() bool {
+	if rows.next >= len(rows.rows) {
+		return false
+	}
+
+	rows.next++
+	return true
+}
+
+func (rows *memoryRows) current() ([]any, error) {
+	if rows.next == 0 || rows.next > len(rows.rows) {
+		return nil, errors.New(errors.TypeInvalidInput, errors.CodeInvalidInput, "scan called without calling next")
+	}
+
+	return rows.rows[rows.next-1], nil
+}
+
+func (rows *memoryRows) Scan(dest ...any) error {
+	row, err := rows.current()
+	if err != nil {
+		return err
+	}
+
+	if len(dest) != len(row) {
+		return errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "expected %d destinations, got %d", len(row), len(dest))
+	}
+
+	for i := range dest {
+		if err := assign(dest[i], row[i]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (rows *memoryRows) ScanStruct(dest any) error {
+	row, err := rows.current()
+	if err != nil {
+		return err
+	}
+
+	fields, err := structFields(dest)
+	if err != nil {
+		return err
+	}
+
+	for i, column := range rows.columns {
+		if field, ok := fields[column]; ok {
+			if err := assign(field.Addr().Interface(), row[i]); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (rows *memoryRows) ColumnTypes() []driver.ColumnType {
+	types := make([]driver.ColumnType, len(rows.columns))
+	for i, name := range rows.columns {
+		var scanType reflect.Type
+		if len(rows.rows) > 0 && rows.rows[0][i] != nil {
+			scanType = reflect.TypeOf(rows.rows[0][i])
+		}
+
+		types[i] = &memoryColumnType{name: name, scanType: scanType}
+	}
+
+	return types
+}
+
+func (rows *memoryRows) Totals(dest ...any) error {
+	return nil
+}
+
+func (rows *memoryRows) Columns() []string {
+	return rows.columns
+}
+
+func (rows *memoryRows) Close() error {
+	return nil
+}
+
+func (rows *memoryRows) Err() error {
+	return nil
+}
+
+type memoryRow struct {
+	rows *memoryRows
+	err  error
+}
+
+func (row *memoryRow) Err() error {
+	return row.err
+}
+
+func (row *memoryRow) Scan(dest ...any) error {
+	if row.err != nil {
+		return row.err
+	}
+
+	return row.rows.Scan(dest...)
+}
+
+func (row *memoryRow) ScanStruct(dest any) error {
+	if row.err != nil {
+		return row.err
+	}
+
+	return row.rows.ScanStruct(dest)
+}
+
+type memoryColumnType struct {
+	name     string
+	scanType reflect.Type
+}
+
+func (columnType *memoryColumnType) Name() string {
+	return columnType.name
+}
+
+func (columnType *memoryColumnType) Nullable() bool {
+	return false
+}
+
+func (columnType *memoryColumnType) ScanType() reflect.Type {
+	return columnType.scanType
+}
+
+func (columnType *memoryColumnType) DatabaseTypeName() string {
+	return ""
+}
+
+// filter returns the rows of the table matching the conditions of the where clause, which can only compare columns
+// to arguments.
+func filter(table *memoryTable, where string, args []any) ([][]any, error) {
+	type condition struct {
+		index int
+		value any
+	}
+
+	conditions := []condition{}
+	if strings.TrimSpace(where) != "" {
+		for _, clause := range andRe.Split(where, -1) {
+			matches := equalsRe.FindStringSubmatch(clause)
+			if matches == nil {
+				return nil, errors.Newf(errors.TypeUnsupported, ErrCodeMemoryQueryUnsupported, "condition %q is not supported by the memory telemetry store", clause)
+			}
+
+			index, ok := table.index(matches[1])
+			if !ok {
+				return nil, errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "column %s doesn't exist", matches[1])
+			}
+
+			if len(conditions) >= len(args) {
+				return nil, errors.New(errors.TypeInvalidInput, errors.CodeInvalidInput, "missing arguments for the conditions")
+			}
+
+			conditions = append(conditions, condition{index: index, value: args[len(conditions)]})
+		}
+	}
+
+	rows := [][]any{}
+	for _, row := range table.rows {
+		matched := true
+		for _, condition := range conditions {
+			if !equal(row[condition.index], condition.value) {
+				matched = false
+				break
+			}
+		}
+
+		if matched {
+			rows = append(rows, row)
+		}
+	}
+
+	return rows, nil
+}
+
+func splitColumns(columns string) []string {
+	result := []string{}
+	for _, column := range strings.Split(columns, ",") {
+		if column = strings.Trim(strings.TrimSpace(column), "`\""); column != "" {
+			result = append(result, column)
+		}
+	}
+
+	return result
+}
+
+// structFields returns the addressable fields of the struct pointed to by v keyed by their ch tag, or their name.
+func structFields(v any) (map[string]reflect.Value, error) {
+	value := reflect.ValueOf(v)
+	if value.Kind() != reflect.Pointer || value.Elem().Kind() != reflect.Struct {
+		return nil, errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "expected a pointer to a struct, got %T", v)
+	}
+
+	value = value.Elem()
+	fields := map[string]reflect.Value{}
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Type().Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name := field.Name
+		if tag, ok := field.Tag.Lookup("ch"); ok {
+			if tag == "-" {
+				continue
+			}
+
+			name = tag
+		}
+
+		fields[name] = value.Field(i)
+	}
+
+	return fields, nil
+}
+
+// assign sets the value pointed to by dest to the value, converting it when the types differ as the driver does.
+func assign(dest any, value any) error {
+	target := reflect.ValueOf(dest)
+	if target.Kind() != reflect.Pointer || target.IsNil() {
+		return errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "destination must be a non nil pointer, got %T", dest)
+	}
+
+	target = target.Elem()
+	if value == nil {
+		target.Set(reflect.Zero(target.Type()))
+		return nil
+	}
+
+	source := reflect.ValueOf(value)
+	switch {
+	case source.Type().AssignableTo(target.Type()):
+		target.Set(source)
+	case source.Type().ConvertibleTo(target.Type()) && source.Kind() != reflect.String && target.Kind() != reflect.String:
+		target.Set(source.Convert(target.Type()))
+	default:
+		return errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "cannot scan %T into %T", value, dest)
+	}
+
+	return nil
+}
+
+func equal(a any, b any) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	if x, ok := number(a); ok {
+		y, ok := number(b)
+		return ok && x == y
+	}
+
+	if x, ok := a.(time.Time); ok {
+		y, ok := b.(time.Time)
+		return ok && x.Equal(y)
+	}
+
+	return fmt.Sprint(a) == fmt.Sprint(b)
+}
+
+func less(a any, b any) bool {
+	if x, ok := number(a); ok {
+		if y, ok := number(b); ok {
+			return x < y
+		}
+	}
+
+	if x, ok := a.(time.Time); ok {
+		if y, ok := b.(time.Time); ok {
+			return x.Before(y)
+		}
+	}
+
+	return fmt.Sprint(a) < fmt.Sprint(b)
+}
+
+func number(v any) (float64, bool) {
+	value := reflect.ValueOf(v)
+	switch value.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(value.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(value.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return value.Float(), true
+	default:
+		return 0, false
+	}
+}
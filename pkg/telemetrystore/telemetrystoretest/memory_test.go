@@ -0,0 +1,31 @@
+package telemetrystoretest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/telemetrystore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryConformance(t *testing.T) {
+	RunConformance(t, func(t *testing.T) telemetrystore.TelemetryStore {
+		return NewMemory(telemetrystore.Config{Provider: "clickhouse"})
+	})
+}
+
+func TestMemoryRowsAndStatements(t *testing.T) {
+	ctx := context.Background()
+	memory := NewMemory(telemetrystore.Config{Provider: "clickhouse"})
+
+	require.NoError(t, memory.ClickhouseDB().Exec(ctx, "CREATE TABLE logs (body String, severity String) ENGINE = Memory"))
+	require.NoError(t, Seed(ctx, memory, "logs", []string{"body", "severity"}, []any{"started", "INFO"}))
+
+	assert.Equal(t, []map[string]any{{"body": "started", "severity": "INFO"}}, memory.Rows("logs"))
+	assert.Len(t, memory.Statements(), 2)
+
+	_, err := memory.ClickhouseDB().Query(ctx, "SELECT body FROM logs GROUP BY body")
+	assert.True(t, errors.Ast(err, errors.TypeUnsupported), err)
+}
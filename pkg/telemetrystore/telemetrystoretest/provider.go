@@ -1,10 +1,15 @@
 package telemetrystoretest
 
 import (
+	"io"
+	"log/slog"
+	"testing"
+
 	"github.com/ClickHouse/clickhouse-go/v2"
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/SigNoz/signoz/pkg/telemetrystore"
 	cmock "github.com/srikanthccv/ClickHouse-go-mock"
+	"go.opentelemetry.io/otel/metric/noop"
 )
 
 var _ telemetrystore.TelemetryStore = (*Provider)(nil)
@@ -12,18 +17,48 @@ var _ telemetrystore.TelemetryStore = (*Provider)(nil)
 // Provider represents a mock telemetry store provider for testing
 type Provider struct {
 	clickhouseDB cmock.ClickConnMockCommon
+	limiter      telemetrystore.IngestionLimiter
+	guard        telemetrystore.CardinalityGuard
+	retention    telemetrystore.Retention
+	inserter     telemetrystore.BatchInserter
+	schema       telemetrystore.Schema
+	estimator    telemetrystore.Estimator
+	deleter      telemetrystore.Deleter
 }
 
 // New creates a new mock telemetry store provider
-func New(_ telemetrystore.Config, matcher sqlmock.QueryMatcher) *Provider {
+func New(config telemetrystore.Config, matcher sqlmock.QueryMatcher) *Provider {
 	clickhouseDB, err := cmock.NewClickHouseWithQueryMatcher(&clickhouse.Options{}, matcher)
 	if err != nil {
 		panic(err)
 	}
 
-	return &Provider{
+	limiter, err := telemetrystore.NewIngestionLimiter(noop.NewMeterProvider().Meter(""), config.Name, config.Ingestion)
+	if err != nil {
+		panic(err)
+	}
+
+	guard, err := telemetrystore.NewCardinalityGuard(slog.New(slog.NewTextHandler(io.Discard, nil)), noop.NewMeterProvider().Meter(""), config.Name, config.Cardinality)
+	if err != nil {
+		panic(err)
+	}
+
+	provider := &Provider{
 		clickhouseDB: clickhouseDB,
+		limiter:      limiter,
+		guard:        guard,
 	}
+	provider.retention = telemetrystore.NewRetention(config.Retention, config.Routing, provider.Shards())
+	provider.schema = telemetrystore.NewSchema(config.Schema, provider.ClickhouseDB())
+	provider.estimator = telemetrystore.NewEstimator(config.QueryBudget, provider.ClickhouseDB())
+	provider.deleter = telemetrystore.NewDeleter(config.Deletion, config.Routing, provider.ClickhouseDB())
+
+	provider.inserter, err = telemetrystore.NewBatchInserter(noop.NewMeterProvider().Meter(""), config.Name, provider.ClickhouseDB(), config.Batch)
+	if err != nil {
+		panic(err)
+	}
+
+	return provider
 }
 
 // ClickhouseDB returns the mock Clickhouse connection
@@ -31,7 +66,67 @@ func (p *Provider) ClickhouseDB() clickhouse.Conn {
 	return p.clickhouseDB.(clickhouse.Conn)
 }
 
+// Shards returns the mock Clickhouse connection as the default shard
+func (p *Provider) Shards() map[string]clickhouse.Conn {
+	return map[string]clickhouse.Conn{telemetrystore.DefaultShardName: p.ClickhouseDB()}
+}
+
+// PoolStats returns the statistics of the mock connection pool
+func (p *Provider) PoolStats() telemetrystore.PoolStats {
+	stats := p.clickhouseDB.(clickhouse.Conn).Stats()
+	return telemetrystore.PoolStats{
+		MaxOpenConns: stats.MaxOpenConns,
+		OpenConns:    stats.Open + stats.Idle,
+		InUseConns:   stats.Open,
+		IdleConns:    stats.Idle,
+	}
+}
+
+// IngestionLimiter returns the limiter built from the ingestion config
+func (p *Provider) IngestionLimiter() telemetrystore.IngestionLimiter {
+	return p.limiter
+}
+
+// CardinalityGuard returns the guard built from the cardinality config
+func (p *Provider) CardinalityGuard() telemetrystore.CardinalityGuard {
+	return p.guard
+}
+
+// Retention returns the retention built from the retention config applied to the mock connection
+func (p *Provider) Retention() telemetrystore.Retention {
+	return p.retention
+}
+
+// BatchInserter returns the inserter built from the batch config writing to the mock connection
+func (p *Provider) BatchInserter() telemetrystore.BatchInserter {
+	return p.inserter
+}
+
+// Schema returns the schema introspecting the mock connection
+func (p *Provider) Schema() telemetrystore.Schema {
+	return p.schema
+}
+
+// Estimator returns the estimator built from the query budget config estimating on the mock connection
+func (p *Provider) Estimator() telemetrystore.Estimator {
+	return p.estimator
+}
+
+// Deleter returns the deleter built from the deletion config deleting on the mock connection
+func (p *Provider) Deleter() telemetrystore.Deleter {
+	return p.deleter
+}
+
 // Mock returns the underlying Clickhouse mock instance for setting expectations
 func (p *Provider) Mock() cmock.ClickConnMockCommon {
 	return p.clickhouseDB
 }
+
+// AssertExpectations fails the test when the expectations set on the mock were not met
+func (p *Provider) AssertExpectations(t testing.TB) {
+	t.Helper()
+
+	if err := p.clickhouseDB.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
@@ -14,3 +14,12 @@ func TestNew(t *testing.T) {
 	assert.NotNil(t, provider.Mock())
 	assert.NotNil(t, provider.ClickhouseDB())
 }
+
+func TestAssertExpectations(t *testing.T) {
+	provider := New(telemetrystore.Config{Provider: "clickhouse"}, sqlmock.QueryMatcherRegexp)
+	provider.Mock().ExpectExec("TRUNCATE TABLE logs")
+
+	mockT := new(testing.T)
+	provider.AssertExpectations(mockT)
+	assert.True(t, mockT.Failed())
+}
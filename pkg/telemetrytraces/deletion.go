@@ -0,0 +1,20 @@
+package telemetrytraces
+
+import (
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/telemetrystore"
+)
+
+// bucket is the duration of the time buckets of the spans and of their resources.
+const bucket = 30 * time.Minute
+
+// DeletionTables are the tables from which the spans of the resources matching the label matchers of a deletion are
+// deleted. The matchers match the attributes of the resources, the spans are deleted by time bucket.
+var DeletionTables = telemetrystore.DeletionTables{
+	Series: telemetrystore.DeletionTable{Name: DBName + "." + ResourceV3TableName, TimeColumn: "seen_at_ts_bucket_start", TimeUnit: time.Second, Bucket: bucket},
+	Local: []telemetrystore.DeletionTable{
+		{Name: DBName + "." + SpanIndexV3LocalTableName, FingerprintColumn: "resource_fingerprint", TimeColumn: "ts_bucket_start", TimeUnit: time.Second, Bucket: bucket},
+		{Name: DBName + "." + ResourceV3LocalTableName, TimeColumn: "seen_at_ts_bucket_start", TimeUnit: time.Second, Bucket: bucket},
+	},
+}
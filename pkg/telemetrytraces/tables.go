@@ -4,7 +4,11 @@ const (
 	DBName                        = "signoz_traces"
 	SpanIndexV3TableName          = "distributed_signoz_index_v3"
 	SpanIndexV3LocalTableName     = "signoz_index_v3"
+	ResourceV3TableName           = "distributed_traces_v3_resource"
+	ResourceV3LocalTableName      = "traces_v3_resource"
 	TagAttributesV2TableName      = "distributed_tag_attributes_v2"
 	TagAttributesV2LocalTableName = "tag_attributes_v2"
 	TopLevelOperationsTableName   = "distributed_top_level_operations"
+	ErrorIndexV2TableName         = "distributed_signoz_error_index_v2"
+	SpanAttributesKeysTableName   = "distributed_span_attributes_keys"
 )
@@ -0,0 +1,118 @@
+package alertmanagertypestest
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/types/alertmanagertypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+)
+
+var _ alertmanagertypes.DeadLetterStore = (*DeadLetterStore)(nil)
+
+type DeadLetterStore struct {
+	deadLetters map[valuer.UUID]*alertmanagertypes.DeadLetter
+	mtx         sync.RWMutex
+}
+
+func NewDeadLetterStore() *DeadLetterStore {
+	return &DeadLetterStore{
+		deadLetters: make(map[valuer.UUID]*alertmanagertypes.DeadLetter),
+	}
+}
+
+func (s *DeadLetterStore) Create(ctx context.Context, deadLetter *alertmanagertypes.DeadLetter) error {
+	s.mtx.Lock()
+	s.deadLetters[deadLetter.ID] = deadLetter
+	s.mtx.Unlock()
+	return nil
+}
+
+func (s *DeadLetterStore) Get(ctx context.Context, orgID string, id valuer.UUID) (*alertmanagertypes.DeadLetter, error) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	deadLetter, ok := s.deadLetters[id]
+	if !ok || deadLetter.OrgID != orgID {
+		return nil, errors.Newf(errors.TypeNotFound, alertmanagertypes.ErrCodeAlertmanagerDeadLetterNotFound, "cannot find dead letter with id %s", id.StringValue())
+	}
+
+	return deadLetter, nil
+}
+
+func (s *DeadLetterStore) GetByGroupKey(ctx context.Context, orgID string, receiver string, groupKey string) (*alertmanagertypes.DeadLetter, error) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	for _, deadLetter := range s.deadLetters {
+		if deadLetter.OrgID == orgID && deadLetter.Receiver == receiver && deadLetter.GroupKey == groupKey {
+			return deadLetter, nil
+		}
+	}
+
+	return nil, errors.Newf(errors.TypeNotFound, alertmanagertypes.ErrCodeAlertmanagerDeadLetterNotFound, "cannot find dead letter for receiver %s and group key %s", receiver, groupKey)
+}
+
+func (s *DeadLetterStore) List(ctx context.Context, orgID string) ([]*alertmanagertypes.DeadLetter, error) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	deadLetters := make([]*alertmanagertypes.DeadLetter, 0)
+	for _, deadLetter := range s.deadLetters {
+		if deadLetter.OrgID == orgID {
+			deadLetters = append(deadLetters, deadLetter)
+		}
+	}
+
+	sort.Slice(deadLetters, func(i, j int) bool {
+		return deadLetters[i].CreatedAt.After(deadLetters[j].CreatedAt)
+	})
+
+	return deadLetters, nil
+}
+
+func (s *DeadLetterStore) ListAttemptedBefore(ctx context.Context, before time.Time) ([]*alertmanagertypes.DeadLetter, error) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	deadLetters := make([]*alertmanagertypes.DeadLetter, 0)
+	for _, deadLetter := range s.deadLetters {
+		if deadLetter.UpdatedAt.Before(before) {
+			deadLetters = append(deadLetters, deadLetter)
+		}
+	}
+
+	sort.Slice(deadLetters, func(i, j int) bool {
+		return deadLetters[i].UpdatedAt.Before(deadLetters[j].UpdatedAt)
+	})
+
+	return deadLetters, nil
+}
+
+func (s *DeadLetterStore) Update(ctx context.Context, deadLetter *alertmanagertypes.DeadLetter) error {
+	s.mtx.Lock()
+	s.deadLetters[deadLetter.ID] = deadLetter
+	s.mtx.Unlock()
+	return nil
+}
+
+func (s *DeadLetterStore) Delete(ctx context.Context, orgID string, id valuer.UUID) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if deadLetter, ok := s.deadLetters[id]; ok && deadLetter.OrgID == orgID {
+		delete(s.deadLetters, id)
+	}
+
+	return nil
+}
+
+func (s *DeadLetterStore) DeleteBefore(ctx context.Context, before time.Time) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	for id, deadLetter := range s.deadLetters {
+		if deadLetter.UpdatedAt.Before(before) {
+			delete(s.deadLetters, id)
+		}
+	}
+
+	return nil
+}
@@ -1,6 +1,7 @@
 package alertmanagertypes
 
 import (
+	"context"
 	"encoding/json"
 	"reflect"
 	"regexp"
@@ -18,6 +19,10 @@ var (
 	ErrCodeAlertmanagerChannelNameMismatch = errors.MustNewCode("alertmanager_channel_name_mismatch")
 )
 
+const (
+	minSigningSecretLength int = 16
+)
+
 var (
 	// Regular expression to match anything before "_configs"
 	receiverTypeRegex = regexp.MustCompile(`^(.+)_configs`)
@@ -37,6 +42,43 @@ type Channel struct {
 	Type  string `json:"type" bun:"type"`
 	Data  string `json:"data" bun:"data"`
 	OrgID string `json:"org_id" bun:"org_id"`
+
+	// SigningSecret is the secret signing the webhook notifications of the channel, empty when they are not signed.
+	SigningSecret string `json:"-" bun:"signing_secret,nullzero"`
+
+	// Plugin is the name of the receiver plugin notifying the channel in addition to its integrations, empty when
+	// no plugin notifies it.
+	Plugin string `json:"plugin,omitempty" bun:"plugin,nullzero"`
+}
+
+type PostableChannelSigningSecret struct {
+	// Secret is the secret signing the webhook notifications of the channel. An empty secret stops the signing.
+	Secret string `json:"secret"`
+}
+
+// SigningSecretStore returns the secrets signing the webhook notifications of the channels.
+type SigningSecretStore interface {
+	// GetSigningSecret returns the signing secret of the channel of the organization with the given name, empty
+	// when the channel has none or does not exist.
+	GetSigningSecret(context.Context, string, string) (string, error)
+}
+
+type PostableChannelPlugin struct {
+	// Plugin is the name of the receiver plugin notifying the channel. An empty name stops the plugin.
+	Plugin string `json:"plugin"`
+}
+
+// PluginStore returns the receiver plugins notifying the channels.
+type PluginStore interface {
+	// GetPlugin returns the name of the plugin of the channel of the organization with the given name, empty when
+	// the channel has none or does not exist.
+	GetPlugin(context.Context, string, string) (string, error)
+}
+
+// ChannelStore returns the settings of the channels read when notifying them.
+type ChannelStore interface {
+	SigningSecretStore
+	PluginStore
 }
 
 // NewChannelFromReceiver creates a new Channel from a Receiver.
@@ -178,6 +220,24 @@ func (c *Channel) Update(receiver Receiver) error {
 	return nil
 }
 
+// SetSigningSecret sets the secret signing the webhook notifications of the channel.
+func (c *Channel) SetSigningSecret(secret string) error {
+	if secret != "" && len(secret) < minSigningSecretLength {
+		return errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "signing secret must be at least %d characters long", minSigningSecretLength)
+	}
+
+	c.SigningSecret = secret
+	c.UpdatedAt = time.Now()
+
+	return nil
+}
+
+// SetPlugin sets the name of the receiver plugin notifying the channel.
+func (c *Channel) SetPlugin(plugin string) {
+	c.Plugin = plugin
+	c.UpdatedAt = time.Now()
+}
+
 // This is needed by the legacy alertmanager to convert the MSTeamsV2Configs to MSTeamsConfigs
 func (c *Channel) MSTeamsV2ToMSTeams() error {
 	if c.Type != "msteamsv2" {
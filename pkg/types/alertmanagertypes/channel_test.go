@@ -228,3 +228,32 @@ func TestNewConfigFromChannels(t *testing.T) {
 		})
 	}
 }
+
+func TestChannelSetSigningSecret(t *testing.T) {
+	channel := &Channel{Name: "webhook", OrgID: "1"}
+
+	assert.Error(t, channel.SetSigningSecret("short"))
+	assert.Empty(t, channel.SigningSecret)
+
+	assert.NoError(t, channel.SetSigningSecret("0123456789abcdef"))
+	assert.Equal(t, "0123456789abcdef", channel.SigningSecret)
+
+	// The secret is never rendered with the channel.
+	data, err := json.Marshal(channel)
+	assert.NoError(t, err)
+	assert.NotContains(t, string(data), "0123456789abcdef")
+
+	assert.NoError(t, channel.SetSigningSecret(""))
+	assert.Empty(t, channel.SigningSecret)
+}
+
+func TestChannelSetPlugin(t *testing.T) {
+	channel := &Channel{Name: "oncall"}
+
+	channel.SetPlugin("incidenttool")
+	assert.Equal(t, "incidenttool", channel.Plugin)
+	assert.False(t, channel.UpdatedAt.IsZero())
+
+	channel.SetPlugin("")
+	assert.Empty(t, channel.Plugin)
+}
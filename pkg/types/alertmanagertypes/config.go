@@ -261,6 +261,8 @@ func (c *Config) DeleteReceiver(name string) error {
 		return errors.New(errors.TypeInvalidInput, ErrCodeAlertmanagerConfigInvalid, "delete receiver requires the receiver name")
 	}
 
+	c.deleteRuleIDRoutes("", name)
+
 	routes := c.alertmanagerConfig.Route.Routes
 	for i, r := range routes {
 		if r.Receiver == name {
@@ -289,6 +291,10 @@ func (c *Config) CreateRuleIDMatcher(ruleID string, receiverNames []string) erro
 	}
 
 	for _, route := range c.alertmanagerConfig.Route.Routes {
+		if _, ok := ruleIDFromRoute(route); ok {
+			continue
+		}
+
 		if slices.Contains(receiverNames, route.Receiver) {
 			if err := addRuleIDToRoute(route, ruleID); err != nil {
 				return err
@@ -313,6 +319,8 @@ func (c *Config) UpdateRuleIDMatcher(ruleID string, receiverNames []string) erro
 }
 
 func (c *Config) DeleteRuleIDMatcher(ruleID string) error {
+	c.deleteRuleIDRoutes(ruleID, "")
+
 	for i := range c.alertmanagerConfig.Route.Routes {
 		if err := removeRuleIDFromRoute(c.alertmanagerConfig.Route.Routes[i], ruleID); err != nil {
 			return err
@@ -387,9 +395,15 @@ type ConfigStore interface {
 	// ListAllChannels returns the list of channels for all organizations.
 	ListAllChannels(context.Context) ([]*Channel, error)
 
+	// ChannelStore returns the signing secrets and the plugins of the channels.
+	ChannelStore
+
 	// GetMatchers gets a list of matchers per organization.
 	// Matchers is an array of ruleId to receiver names.
 	GetMatchers(context.Context, string) (map[string][]string, error)
+
+	// GetNotificationSettings returns the notification settings of the rules of the organization keyed by rule id.
+	GetNotificationSettings(context.Context, string) (map[string]*NotificationSettings, error)
 }
 
 // MarshalSecretValue if set to true will expose Secret type
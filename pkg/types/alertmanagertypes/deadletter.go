@@ -0,0 +1,93 @@
+package alertmanagertypes
+
+import (
+	"context"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/types"
+	"github.com/SigNoz/signoz/pkg/valuer"
+	"github.com/prometheus/common/model"
+	"github.com/uptrace/bun"
+)
+
+var (
+	ErrCodeAlertmanagerDeadLetterNotFound = errors.MustNewCode("alertmanager_dead_letter_not_found")
+)
+
+type GettableDeadLetters = []*DeadLetter
+
+// DeadLetter is a notification which could not be delivered to a receiver after all the retries were exhausted.
+// It holds everything required to send the notification again through the notification pipeline.
+type DeadLetter struct {
+	bun.BaseModel `bun:"table:alertmanager_dead_letter"`
+
+	types.Identifiable
+	types.TimeAuditable
+	OrgID          string         `json:"orgId" bun:"org_id,type:text,notnull"`
+	Receiver       string         `json:"receiver" bun:"receiver,type:text,notnull"`
+	GroupKey       string         `json:"groupKey" bun:"group_key,type:text,notnull"`
+	GroupLabels    model.LabelSet `json:"groupLabels" bun:"group_labels,type:text"`
+	RouteID        string         `json:"routeId" bun:"route_id,type:text"`
+	RepeatInterval time.Duration  `json:"repeatInterval" bun:"repeat_interval"`
+	// MuteTimeIntervals and ActiveTimeIntervals are the names of the time intervals of the route.
+	MuteTimeIntervals   []string `json:"muteTimeIntervals" bun:"mute_time_intervals,type:text"`
+	ActiveTimeIntervals []string `json:"activeTimeIntervals" bun:"active_time_intervals,type:text"`
+	Alerts              []*Alert `json:"alerts" bun:"alerts,type:text,notnull"`
+	LastError           string   `json:"lastError" bun:"last_error,type:text"`
+	// Attempts is the number of times the delivery of the notification failed, including the re-dispatches.
+	Attempts int `json:"attempts" bun:"attempts"`
+}
+
+func NewDeadLetter(orgID string, receiver string, groupKey string, alerts []*Alert, cause error) *DeadLetter {
+	return &DeadLetter{
+		Identifiable: types.Identifiable{
+			ID: valuer.GenerateUUID(),
+		},
+		TimeAuditable: types.TimeAuditable{
+			CreatedAt: time.Now(),
+			UpdatedAt: time.Now(),
+		},
+		OrgID:     orgID,
+		Receiver:  receiver,
+		GroupKey:  groupKey,
+		Alerts:    alerts,
+		LastError: cause.Error(),
+		Attempts:  1,
+	}
+}
+
+// Failed records another failed attempt to deliver the notification.
+func (deadLetter *DeadLetter) Failed(alerts []*Alert, cause error) {
+	deadLetter.Alerts = alerts
+	deadLetter.LastError = cause.Error()
+	deadLetter.Attempts++
+	deadLetter.UpdatedAt = time.Now()
+}
+
+type DeadLetterStore interface {
+	// Create creates a new dead letter.
+	Create(context.Context, *DeadLetter) error
+
+	// Get returns the dead letter for the given orgID and id.
+	Get(context.Context, string, valuer.UUID) (*DeadLetter, error)
+
+	// GetByGroupKey returns the dead letter for the given orgID, receiver and group key.
+	GetByGroupKey(context.Context, string, string, string) (*DeadLetter, error)
+
+	// List returns the dead letters for the given orgID, most recent first.
+	List(context.Context, string) ([]*DeadLetter, error)
+
+	// ListAttemptedBefore returns the dead letters of all the organizations which were last attempted before the
+	// given time, least recently attempted first.
+	ListAttemptedBefore(context.Context, time.Time) ([]*DeadLetter, error)
+
+	// Update updates a dead letter.
+	Update(context.Context, *DeadLetter) error
+
+	// Delete deletes the dead letter for the given orgID and id.
+	Delete(context.Context, string, valuer.UUID) error
+
+	// DeleteBefore deletes the dead letters of all the organizations which were last attempted before the given time.
+	DeleteBefore(context.Context, time.Time) error
+}
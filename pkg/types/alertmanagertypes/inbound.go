@@ -0,0 +1,129 @@
+package alertmanagertypes
+
+import (
+	"bytes"
+	"encoding/json"
+	"slices"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/go-openapi/strfmt"
+	"github.com/prometheus/alertmanager/api/v2/models"
+	"github.com/prometheus/alertmanager/template"
+	"github.com/prometheus/common/model"
+)
+
+const (
+	// InboundRuleID is the rule id of the inbound alerts. They are routed to the channels matching it, as the alerts
+	// of a rule are routed to the channels matching the id of the rule.
+	InboundRuleID string = "inbound"
+
+	// InboundRuleIDLabelName is the label keeping the rule id set by the sender of an inbound alert.
+	InboundRuleIDLabelName string = "inboundRuleId"
+)
+
+var (
+	ErrCodeAlertmanagerInboundAlertsInvalid = errors.MustNewCode("alertmanager_inbound_alerts_invalid")
+)
+
+// InboundChannels are the channels notified of the inbound alerts of an organization.
+type InboundChannels struct {
+	// Channels are the names of the channels.
+	Channels []string `json:"channels"`
+}
+
+// NewInboundChannelsFromJSON returns the inbound channels of the body, which must all be channels of the
+// organization.
+func NewInboundChannelsFromJSON(body []byte, channels []*Channel) (*InboundChannels, error) {
+	inboundChannels := new(InboundChannels)
+	if err := json.Unmarshal(body, inboundChannels); err != nil {
+		return nil, errors.Wrapf(err, errors.TypeInvalidInput, errors.CodeInvalidInput, "body must be the names of the channels")
+	}
+
+	for _, name := range inboundChannels.Channels {
+		if !slices.ContainsFunc(channels, func(channel *Channel) bool { return channel.Name == name }) {
+			return nil, errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "channel %q does not exist", name)
+		}
+	}
+
+	return inboundChannels, nil
+}
+
+// inboundWebhookMessage is the part of the payload of the webhook notifications of an alertmanager holding the alerts.
+type inboundWebhookMessage struct {
+	Alerts template.Alerts `json:"alerts"`
+}
+
+// NewPostableAlertsFromInbound returns the alerts sent by an external system, either as the payload of the webhook
+// notifications of an alertmanager or as the array of alerts of the v2 api of an alertmanager. The alerts are
+// deduplicated by fingerprint, the last alert of a fingerprint being kept as the most recent one.
+//
+// The alerts are given the rule id of the inbound alerts. A rule id set by the sender is kept under another label,
+// so that the sender cannot route its alerts to the channels of the rules.
+func NewPostableAlertsFromInbound(body []byte) (PostableAlerts, error) {
+	body = bytes.TrimSpace(body)
+	if len(body) == 0 {
+		return nil, errors.New(errors.TypeInvalidInput, ErrCodeAlertmanagerInboundAlertsInvalid, "body must not be empty")
+	}
+
+	var postableAlerts PostableAlerts
+	var fingerprints []string
+	if body[0] == '[' {
+		if err := json.Unmarshal(body, &postableAlerts); err != nil {
+			return nil, errors.Wrapf(err, errors.TypeInvalidInput, ErrCodeAlertmanagerInboundAlertsInvalid, "body must be an array of alerts")
+		}
+
+		fingerprints = make([]string, len(postableAlerts))
+	} else {
+		var message inboundWebhookMessage
+		if err := json.Unmarshal(body, &message); err != nil {
+			return nil, errors.Wrapf(err, errors.TypeInvalidInput, ErrCodeAlertmanagerInboundAlertsInvalid, "body must be a webhook message")
+		}
+
+		for _, alert := range message.Alerts {
+			postableAlerts = append(postableAlerts, &PostableAlert{
+				Annotations: models.LabelSet(alert.Annotations),
+				StartsAt:    strfmt.DateTime(alert.StartsAt),
+				EndsAt:      strfmt.DateTime(alert.EndsAt),
+				Alert: models.Alert{
+					GeneratorURL: strfmt.URI(alert.GeneratorURL),
+					Labels:       models.LabelSet(alert.Labels),
+				},
+			})
+			fingerprints = append(fingerprints, alert.Fingerprint)
+		}
+	}
+
+	indexes := make(map[string]int, len(postableAlerts))
+	deduplicated := make(PostableAlerts, 0, len(postableAlerts))
+	for i, postableAlert := range postableAlerts {
+		if postableAlert == nil || len(postableAlert.Labels) == 0 {
+			return nil, errors.Newf(errors.TypeInvalidInput, ErrCodeAlertmanagerInboundAlertsInvalid, "alert %d must have labels", i)
+		}
+
+		fingerprint := fingerprints[i]
+		if fingerprint == "" {
+			labels := make(model.LabelSet, len(postableAlert.Labels))
+			for name, value := range postableAlert.Labels {
+				labels[model.LabelName(name)] = model.LabelValue(value)
+			}
+			fingerprint = labels.Fingerprint().String()
+		}
+
+		if index, ok := indexes[fingerprint]; ok {
+			deduplicated[index] = postableAlert
+			continue
+		}
+
+		indexes[fingerprint] = len(deduplicated)
+		deduplicated = append(deduplicated, postableAlert)
+	}
+
+	for _, postableAlert := range deduplicated {
+		if ruleID, ok := postableAlert.Labels[RuleIDMatcherName]; ok {
+			postableAlert.Labels[InboundRuleIDLabelName] = ruleID
+		}
+		postableAlert.Labels[RuleIDMatcherName] = InboundRuleID
+	}
+
+	return deduplicated, nil
+}
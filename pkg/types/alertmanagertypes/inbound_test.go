@@ -0,0 +1,81 @@
+package alertmanagertypes
+
+import (
+	"testing"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewPostableAlertsFromInbound(t *testing.T) {
+	t.Run("WebhookMessage", func(t *testing.T) {
+		alerts, err := NewPostableAlertsFromInbound([]byte(`{
+			"version": "4",
+			"status": "firing",
+			"receiver": "signoz",
+			"alerts": [
+				{"status": "firing", "labels": {"alertname": "HighLatency", "service": "checkout"}, "annotations": {"summary": "p99 over 1s"}, "startsAt": "2025-01-01T10:00:00Z", "endsAt": "0001-01-01T00:00:00Z", "generatorURL": "http://prometheus/graph", "fingerprint": "a"},
+				{"status": "resolved", "labels": {"alertname": "HighErrorRate", "service": "checkout"}, "startsAt": "2025-01-01T09:00:00Z", "endsAt": "2025-01-01T09:30:00Z", "fingerprint": "b"},
+				{"status": "resolved", "labels": {"alertname": "HighLatency", "service": "checkout"}, "startsAt": "2025-01-01T10:00:00Z", "endsAt": "2025-01-01T10:05:00Z", "fingerprint": "a"}
+			]
+		}`))
+		require.NoError(t, err)
+		require.Len(t, alerts, 2)
+
+		assert.Equal(t, "HighLatency", alerts[0].Labels["alertname"])
+		assert.Equal(t, time.Date(2025, 1, 1, 10, 5, 0, 0, time.UTC), time.Time(alerts[0].EndsAt))
+		assert.Equal(t, "HighErrorRate", alerts[1].Labels["alertname"])
+	})
+
+	t.Run("PostableAlerts", func(t *testing.T) {
+		alerts, err := NewPostableAlertsFromInbound([]byte(`[
+			{"labels": {"alertname": "DiskFull", "instance": "db-1"}, "annotations": {"summary": "disk is full"}},
+			{"labels": {"alertname": "DiskFull", "instance": "db-2"}},
+			{"labels": {"instance": "db-1", "alertname": "DiskFull"}, "annotations": {"summary": "disk is still full"}}
+		]`))
+		require.NoError(t, err)
+		require.Len(t, alerts, 2)
+
+		assert.Equal(t, "disk is still full", alerts[0].Annotations["summary"])
+		assert.Equal(t, "db-2", alerts[1].Labels["instance"])
+	})
+
+	t.Run("RuleID", func(t *testing.T) {
+		alerts, err := NewPostableAlertsFromInbound([]byte(`[
+			{"labels": {"alertname": "DiskFull", "ruleId": "0196f794-ff30-7bee-a2dc-fb1e2ef5e39e"}},
+			{"labels": {"alertname": "DiskFull", "instance": "db-2"}}
+		]`))
+		require.NoError(t, err)
+		require.Len(t, alerts, 2)
+
+		// The rule id set by the sender is kept under another label, the alerts are routed as inbound alerts.
+		assert.Equal(t, InboundRuleID, alerts[0].Labels[RuleIDMatcherName])
+		assert.Equal(t, "0196f794-ff30-7bee-a2dc-fb1e2ef5e39e", alerts[0].Labels[InboundRuleIDLabelName])
+		assert.Equal(t, InboundRuleID, alerts[1].Labels[RuleIDMatcherName])
+		assert.NotContains(t, alerts[1].Labels, InboundRuleIDLabelName)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		for _, body := range []string{``, `not json`, `[{"annotations": {"summary": "no labels"}}]`, `{"alerts": [{"status": "firing"}]}`} {
+			_, err := NewPostableAlertsFromInbound([]byte(body))
+			require.Error(t, err, body)
+			assert.True(t, errors.Asc(err, ErrCodeAlertmanagerInboundAlertsInvalid), body)
+		}
+	})
+}
+
+func TestNewInboundChannelsFromJSON(t *testing.T) {
+	channels := []*Channel{{Name: "slack"}, {Name: "pagerduty"}}
+
+	inboundChannels, err := NewInboundChannelsFromJSON([]byte(`{"channels": ["slack"]}`), channels)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"slack"}, inboundChannels.Channels)
+
+	_, err = NewInboundChannelsFromJSON([]byte(`{"channels": ["email"]}`), channels)
+	assert.True(t, errors.Ast(err, errors.TypeInvalidInput))
+
+	_, err = NewInboundChannelsFromJSON([]byte(`not json`), channels)
+	assert.True(t, errors.Ast(err, errors.TypeInvalidInput))
+}
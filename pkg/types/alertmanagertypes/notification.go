@@ -0,0 +1,204 @@
+package alertmanagertypes
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/prometheus/alertmanager/config"
+	"github.com/prometheus/alertmanager/pkg/labels"
+	"github.com/prometheus/common/model"
+)
+
+// Timings are the timings of the notifications of a route. The unset timings are inherited.
+type Timings struct {
+	GroupWait      *model.Duration `json:"groupWait,omitempty"`
+	GroupInterval  *model.Duration `json:"groupInterval,omitempty"`
+	RepeatInterval *model.Duration `json:"repeatInterval,omitempty"`
+}
+
+// NotificationSettings overrides the timings of the notifications of the alerts of a rule, for all its receivers and
+// for each of them. The timings of a receiver take precedence over the timings of the rule, which take precedence
+// over the timings of the route of the alertmanager configuration.
+type NotificationSettings struct {
+	Timings
+	// Receivers are the timings of the receivers keyed by receiver name.
+	Receivers map[string]Timings `json:"receivers,omitempty"`
+}
+
+func (timings Timings) IsZero() bool {
+	return timings.GroupWait == nil && timings.GroupInterval == nil && timings.RepeatInterval == nil
+}
+
+// merge returns the timings with the set timings of override taking precedence.
+func (timings Timings) merge(override Timings) Timings {
+	if override.GroupWait != nil {
+		timings.GroupWait = override.GroupWait
+	}
+
+	if override.GroupInterval != nil {
+		timings.GroupInterval = override.GroupInterval
+	}
+
+	if override.RepeatInterval != nil {
+		timings.RepeatInterval = override.RepeatInterval
+	}
+
+	return timings
+}
+
+func (timings Timings) validate() error {
+	for name, duration := range map[string]*model.Duration{"groupWait": timings.GroupWait, "groupInterval": timings.GroupInterval, "repeatInterval": timings.RepeatInterval} {
+		if duration != nil && time.Duration(*duration) <= 0 {
+			return errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "%s must be positive, got %s", name, duration.String())
+		}
+	}
+
+	return nil
+}
+
+// Validate validates the settings of a rule notifying the given receivers.
+func (settings *NotificationSettings) Validate(receiverNames []string) error {
+	if err := settings.Timings.validate(); err != nil {
+		return err
+	}
+
+	for name, timings := range settings.Receivers {
+		if !slices.Contains(receiverNames, name) {
+			return errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "notification settings of channel %q which is not a channel of the rule", name)
+		}
+
+		if err := timings.validate(); err != nil {
+			return errors.Wrapf(err, errors.TypeInvalidInput, errors.CodeInvalidInput, "invalid notification settings of channel %q", name)
+		}
+	}
+
+	return nil
+}
+
+// TimingsOf returns the timings of the given receiver.
+func (settings *NotificationSettings) TimingsOf(receiverName string) Timings {
+	return settings.Timings.merge(settings.Receivers[receiverName])
+}
+
+// CreateRuleIDRoutes moves the alerts of the rule to a route of their own for each of the given receivers with
+// timings, the other receivers keep notifying them through their shared route. The notification log keys its entries
+// by route, hence every (rule, receiver) pair is throttled independently, and its throttling state is persisted in
+// the sqlstore with the rest of the notification log so that it survives restarts.
+func (c *Config) CreateRuleIDRoutes(ruleID string, receiverNames []string, settings *NotificationSettings) error {
+	if settings == nil {
+		return nil
+	}
+
+	if c.alertmanagerConfig.Route == nil {
+		return errors.New(errors.TypeInvalidInput, ErrCodeAlertmanagerConfigInvalid, "route is nil")
+	}
+
+	for _, receiverName := range receiverNames {
+		timings := settings.TimingsOf(receiverName)
+		if timings.IsZero() {
+			continue
+		}
+
+		idx := slices.IndexFunc(c.alertmanagerConfig.Route.Routes, func(route *config.Route) bool {
+			_, ok := ruleIDFromRoute(route)
+			return !ok && route.Receiver == receiverName
+		})
+		if idx == -1 {
+			continue
+		}
+
+		if err := removeRuleIDFromRoute(c.alertmanagerConfig.Route.Routes[idx], ruleID); err != nil {
+			return err
+		}
+
+		route, err := newRuleIDRoute(ruleID, receiverName, timings)
+		if err != nil {
+			return err
+		}
+
+		c.alertmanagerConfig.Route.Routes = append(c.alertmanagerConfig.Route.Routes, route)
+	}
+
+	c.sortRuleIDRoutes()
+
+	c.storeableConfig.Config = string(newRawFromConfig(c.alertmanagerConfig))
+	c.storeableConfig.Hash = fmt.Sprintf("%x", newConfigHash(c.storeableConfig.Config))
+	c.storeableConfig.UpdatedAt = time.Now()
+
+	return nil
+}
+
+// deleteRuleIDRoutes deletes the routes of the given rule, or of the given receiver if ruleID is empty.
+func (c *Config) deleteRuleIDRoutes(ruleID string, receiverName string) {
+	c.alertmanagerConfig.Route.Routes = slices.DeleteFunc(c.alertmanagerConfig.Route.Routes, func(route *config.Route) bool {
+		routeRuleID, ok := ruleIDFromRoute(route)
+		if !ok {
+			return false
+		}
+
+		if ruleID != "" {
+			return routeRuleID == ruleID
+		}
+
+		return route.Receiver == receiverName
+	})
+}
+
+// sortRuleIDRoutes keeps the routes of the rules after the shared routes of the receivers and sorted by rule and
+// receiver, so that the same rules always produce the same configuration.
+func (c *Config) sortRuleIDRoutes() {
+	slices.SortStableFunc(c.alertmanagerConfig.Route.Routes, func(a, b *config.Route) int {
+		aRuleID, aOk := ruleIDFromRoute(a)
+		bRuleID, bOk := ruleIDFromRoute(b)
+		switch {
+		case !aOk && !bOk:
+			return 0
+		case !aOk:
+			return -1
+		case !bOk:
+			return 1
+		}
+
+		if cmp := strings.Compare(aRuleID, bRuleID); cmp != 0 {
+			return cmp
+		}
+
+		return strings.Compare(a.Receiver, b.Receiver)
+	})
+}
+
+func newRuleIDRoute(ruleID string, receiverName string, timings Timings) (*config.Route, error) {
+	matcher, err := labels.NewMatcher(labels.MatchEqual, RuleIDMatcherName, ruleID)
+	if err != nil {
+		return nil, err
+	}
+
+	route := &config.Route{
+		Receiver:       receiverName,
+		Continue:       true,
+		Matchers:       config.Matchers{matcher},
+		GroupWait:      timings.GroupWait,
+		GroupInterval:  timings.GroupInterval,
+		RepeatInterval: timings.RepeatInterval,
+	}
+	if err := route.UnmarshalYAML(func(i interface{}) error { return nil }); err != nil {
+		return nil, err
+	}
+
+	return route, nil
+}
+
+// ruleIDFromRoute returns the rule of the route if it is the route of a single rule. The shared routes of the
+// receivers match the rules with a regular expression instead.
+func ruleIDFromRoute(route *config.Route) (string, bool) {
+	for _, matcher := range route.Matchers {
+		if matcher.Name == RuleIDMatcherName && matcher.Type == labels.MatchEqual {
+			return matcher.Value, true
+		}
+	}
+
+	return "", false
+}
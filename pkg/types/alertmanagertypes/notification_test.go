@@ -0,0 +1,75 @@
+package alertmanagertypes
+
+import (
+	"encoding/json"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/prometheus/alertmanager/config"
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateRuleIDRoutes(t *testing.T) {
+	cfg, err := NewDefaultConfig(
+		GlobalConfig{SMTPSmarthost: config.HostPort{Host: "localhost", Port: "25"}, SMTPFrom: "test@example.com"},
+		RouteConfig{GroupInterval: 1 * time.Minute, GroupWait: 1 * time.Minute, RepeatInterval: 1 * time.Minute},
+		"1",
+	)
+	require.NoError(t, err)
+
+	require.NoError(t, cfg.CreateReceiver(config.Receiver{Name: "slack-receiver", SlackConfigs: []*config.SlackConfig{{Channel: "#alerts", APIURL: &config.SecretURL{URL: &url.URL{Scheme: "https", Host: "slack.com", Path: "/api/test"}}}}}))
+	require.NoError(t, cfg.CreateReceiver(config.Receiver{Name: "email-receiver", EmailConfigs: []*config.EmailConfig{{To: "test@example.com"}}}))
+
+	receivers := []string{"slack-receiver", "email-receiver"}
+	require.NoError(t, cfg.CreateRuleIDMatcher("test-rule-1", receivers))
+	require.NoError(t, cfg.CreateRuleIDMatcher("test-rule-2", receivers))
+
+	fiveMinutes, oneHour := model.Duration(5*time.Minute), model.Duration(time.Hour)
+	settings := &NotificationSettings{
+		Timings:   Timings{GroupWait: &fiveMinutes},
+		Receivers: map[string]Timings{"email-receiver": {RepeatInterval: &oneHour}},
+	}
+	require.NoError(t, settings.Validate(receivers))
+	require.NoError(t, cfg.CreateRuleIDRoutes("test-rule-1", receivers, settings))
+	// The routes of the other rules are left untouched when the matchers are created again.
+	require.NoError(t, cfg.UpdateRuleIDMatcher("test-rule-2", receivers))
+
+	assert.Equal(t, []map[string]any{
+		{"receiver": "slack-receiver", "continue": true, "matchers": []any{"ruleId=~\"-1|test-rule-2\""}},
+		{"receiver": "email-receiver", "continue": true, "matchers": []any{"ruleId=~\"-1|test-rule-2\""}},
+		{"receiver": "email-receiver", "continue": true, "matchers": []any{"ruleId=\"test-rule-1\""}, "group_wait": "5m", "repeat_interval": "1h"},
+		{"receiver": "slack-receiver", "continue": true, "matchers": []any{"ruleId=\"test-rule-1\""}, "group_wait": "5m"},
+	}, routesOf(t, cfg))
+	assert.ElementsMatch(t, receivers, cfg.ReceiverNamesFromRuleID("test-rule-1"))
+
+	// The configuration survives the round trip through the store.
+	stored, err := NewConfigFromStoreableConfig(cfg.StoreableConfig())
+	require.NoError(t, err)
+	assert.Equal(t, routesOf(t, cfg), routesOf(t, stored))
+
+	require.NoError(t, cfg.DeleteReceiver("slack-receiver"))
+	require.NoError(t, cfg.DeleteRuleIDMatcher("test-rule-1"))
+	assert.Equal(t, []map[string]any{
+		{"receiver": "email-receiver", "continue": true, "matchers": []any{"ruleId=~\"-1|test-rule-2\""}},
+	}, routesOf(t, cfg))
+}
+
+func TestNotificationSettingsValidate(t *testing.T) {
+	zero, oneHour := model.Duration(0), model.Duration(time.Hour)
+
+	assert.Error(t, (&NotificationSettings{Timings: Timings{GroupWait: &zero}}).Validate(nil))
+	assert.Error(t, (&NotificationSettings{Receivers: map[string]Timings{"other": {RepeatInterval: &oneHour}}}).Validate([]string{"slack"}))
+	assert.NoError(t, (&NotificationSettings{Receivers: map[string]Timings{"slack": {RepeatInterval: &oneHour}}}).Validate([]string{"slack"}))
+}
+
+func routesOf(t *testing.T, cfg *Config) []map[string]any {
+	routes, err := json.Marshal(cfg.alertmanagerConfig.Route.Routes)
+	require.NoError(t, err)
+
+	var actual []map[string]any
+	require.NoError(t, json.Unmarshal(routes, &actual))
+	return actual
+}
@@ -19,6 +19,9 @@ import (
 type (
 	// Receiver is the type for the receiver configuration.
 	Receiver = config.Receiver
+
+	// ReceiverIntegrationsFunc builds the integrations of a receiver.
+	ReceiverIntegrationsFunc = func(Receiver, *template.Template, *slog.Logger) ([]notify.Integration, error)
 )
 
 // Creates a new receiver from a string. The input is initialized with the default values from the upstream alertmanager.
@@ -53,7 +56,7 @@ func NewReceiverIntegrations(nc Receiver, tmpl *template.Template, logger *slog.
 	return receiver.BuildReceiverIntegrations(nc, tmpl, logger)
 }
 
-func TestReceiver(ctx context.Context, receiver Receiver, config *Config, tmpl *template.Template, logger *slog.Logger, alert *Alert) error {
+func TestReceiver(ctx context.Context, receiver Receiver, receiverIntegrationsFunc ReceiverIntegrationsFunc, config *Config, tmpl *template.Template, logger *slog.Logger, alert *Alert) error {
 	ctx = notify.WithGroupKey(ctx, fmt.Sprintf("%s-%s-%d", receiver.Name, alert.Labels.Fingerprint(), time.Now().Unix()))
 	ctx = notify.WithGroupLabels(ctx, alert.Labels)
 	ctx = notify.WithReceiverName(ctx, receiver.Name)
@@ -75,7 +78,7 @@ func TestReceiver(ctx context.Context, receiver Receiver, config *Config, tmpl *
 		return err
 	}
 
-	integrations, err := NewReceiverIntegrations(receiver, tmpl, logger)
+	integrations, err := receiverIntegrationsFunc(receiver, tmpl, logger)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,160 @@
+package alertmanagertypes
+
+import (
+	"encoding/json"
+	"sort"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/go-openapi/strfmt"
+	v2 "github.com/prometheus/alertmanager/api/v2"
+	"github.com/prometheus/alertmanager/api/v2/models"
+	"github.com/prometheus/alertmanager/pkg/labels"
+	"github.com/prometheus/alertmanager/silence/silencepb"
+)
+
+var (
+	ErrCodeAlertmanagerSilenceNotFound = errors.MustNewCode("alertmanager_silence_not_found")
+	ErrCodeAlertmanagerSilenceInvalid  = errors.MustNewCode("alertmanager_silence_invalid")
+)
+
+type (
+	// An alias for the PostableSilence type from the alertmanager package.
+	PostableSilence = models.PostableSilence
+
+	// An alias for the GettableSilence type from the alertmanager package.
+	GettableSilence = models.GettableSilence
+
+	// A slice of GettableSilence.
+	GettableSilences = models.GettableSilences
+
+	// An alias for the Silence type from the silencepb package.
+	Silence = silencepb.Silence
+)
+
+// NewPostableSilence returns a silence muting the alerts matched by all the matchers between startsAt and endsAt.
+// Matchers can be regular expressions and negated.
+func NewPostableSilence(matchers labels.Matchers, startsAt time.Time, endsAt time.Time, createdBy string, comment string) *PostableSilence {
+	postableMatchers := make(models.Matchers, 0, len(matchers))
+	for _, matcher := range matchers {
+		isEqual := matcher.Type == labels.MatchEqual || matcher.Type == labels.MatchRegexp
+		isRegex := matcher.Type == labels.MatchRegexp || matcher.Type == labels.MatchNotRegexp
+		postableMatchers = append(postableMatchers, &models.Matcher{
+			Name:    &matcher.Name,
+			Value:   &matcher.Value,
+			IsEqual: &isEqual,
+			IsRegex: &isRegex,
+		})
+	}
+
+	startsAtDateTime, endsAtDateTime := strfmt.DateTime(startsAt), strfmt.DateTime(endsAt)
+	return &PostableSilence{
+		Silence: models.Silence{
+			Matchers:  postableMatchers,
+			StartsAt:  &startsAtDateTime,
+			EndsAt:    &endsAtDateTime,
+			CreatedBy: &createdBy,
+			Comment:   &comment,
+		},
+	}
+}
+
+// NewPostableSilenceFromJSON parses a silence from its JSON representation.
+func NewPostableSilenceFromJSON(data []byte) (*PostableSilence, error) {
+	postableSilence := new(PostableSilence)
+	if err := json.Unmarshal(data, postableSilence); err != nil {
+		return nil, errors.Wrapf(err, errors.TypeInvalidInput, ErrCodeAlertmanagerSilenceInvalid, "cannot parse silence")
+	}
+
+	return postableSilence, nil
+}
+
+// NewSilenceFromPostableSilence validates the postable silence and converts it to a silence. A silence must end
+// after it starts, must not have ended already and must have at least one matcher not matching the empty string
+// so that it cannot mute every alert.
+func NewSilenceFromPostableSilence(postableSilence *PostableSilence, now time.Time) (*Silence, error) {
+	if postableSilence == nil {
+		return nil, errors.New(errors.TypeInvalidInput, ErrCodeAlertmanagerSilenceInvalid, "silence is required")
+	}
+
+	if err := postableSilence.Validate(strfmt.Default); err != nil {
+		return nil, errors.Wrapf(err, errors.TypeInvalidInput, ErrCodeAlertmanagerSilenceInvalid, "invalid silence")
+	}
+
+	silence, err := v2.PostableSilenceToProto(postableSilence)
+	if err != nil {
+		return nil, errors.Wrapf(err, errors.TypeInvalidInput, ErrCodeAlertmanagerSilenceInvalid, "invalid silence")
+	}
+
+	if !silence.StartsAt.Before(silence.EndsAt) {
+		return nil, errors.New(errors.TypeInvalidInput, ErrCodeAlertmanagerSilenceInvalid, "silence must end after it starts")
+	}
+
+	if silence.EndsAt.Before(now) {
+		return nil, errors.New(errors.TypeInvalidInput, ErrCodeAlertmanagerSilenceInvalid, "silence cannot end in the past")
+	}
+
+	matchesEmpty := true
+	for _, matcher := range postableSilence.Matchers {
+		// Matchers are equality matchers unless negated.
+		isEqual := matcher.IsEqual == nil || *matcher.IsEqual
+
+		matcherType := labels.MatchEqual
+		switch {
+		case !isEqual && *matcher.IsRegex:
+			matcherType = labels.MatchNotRegexp
+		case !isEqual:
+			matcherType = labels.MatchNotEqual
+		case *matcher.IsRegex:
+			matcherType = labels.MatchRegexp
+		}
+
+		labelsMatcher, err := labels.NewMatcher(matcherType, *matcher.Name, *matcher.Value)
+		if err != nil {
+			return nil, errors.Wrapf(err, errors.TypeInvalidInput, ErrCodeAlertmanagerSilenceInvalid, "invalid matcher %s", *matcher.Name)
+		}
+
+		if !labelsMatcher.Matches("") {
+			matchesEmpty = false
+		}
+	}
+
+	if matchesEmpty {
+		return nil, errors.New(errors.TypeInvalidInput, ErrCodeAlertmanagerSilenceInvalid, "at least one matcher must not match the empty string")
+	}
+
+	return silence, nil
+}
+
+// NewGettableSilencesFromSilences converts the silences and sorts them with the active silences first, ending
+// soonest first, then the pending silences, starting soonest first, and the expired silences, most recent first.
+func NewGettableSilencesFromSilences(silences []*Silence) (GettableSilences, error) {
+	gettableSilences := make(GettableSilences, 0, len(silences))
+	for _, silence := range silences {
+		gettableSilence, err := v2.GettableSilenceFromProto(silence)
+		if err != nil {
+			return nil, errors.Wrapf(err, errors.TypeInternal, errors.CodeInternal, "cannot convert silence %s", silence.Id)
+		}
+
+		gettableSilences = append(gettableSilences, &gettableSilence)
+	}
+
+	states := map[string]int{models.SilenceStatusStateActive: 0, models.SilenceStatusStatePending: 1, models.SilenceStatusStateExpired: 2}
+	sort.SliceStable(gettableSilences, func(i, j int) bool {
+		a, b := gettableSilences[i], gettableSilences[j]
+		if states[*a.Status.State] != states[*b.Status.State] {
+			return states[*a.Status.State] < states[*b.Status.State]
+		}
+
+		switch *a.Status.State {
+		case models.SilenceStatusStateActive:
+			return time.Time(*a.EndsAt).Before(time.Time(*b.EndsAt))
+		case models.SilenceStatusStatePending:
+			return time.Time(*a.StartsAt).Before(time.Time(*b.StartsAt))
+		default:
+			return time.Time(*a.EndsAt).After(time.Time(*b.EndsAt))
+		}
+	})
+
+	return gettableSilences, nil
+}
@@ -0,0 +1,79 @@
+package alertmanagertypes
+
+import (
+	"testing"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/prometheus/alertmanager/pkg/labels"
+	"github.com/prometheus/alertmanager/silence/silencepb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSilenceFromPostableSilence(t *testing.T) {
+	now := time.Now()
+
+	testCases := []struct {
+		name     string
+		matchers labels.Matchers
+		startsAt time.Time
+		endsAt   time.Time
+		expected []silencepb.Matcher_Type
+		fail     bool
+	}{
+		{
+			name:     "AllMatcherTypes",
+			matchers: labels.Matchers{&labels.Matcher{Type: labels.MatchEqual, Name: "a", Value: "1"}, &labels.Matcher{Type: labels.MatchNotEqual, Name: "b", Value: "2"}, &labels.Matcher{Type: labels.MatchRegexp, Name: "c", Value: "3|4"}, &labels.Matcher{Type: labels.MatchNotRegexp, Name: "d", Value: "5.*"}},
+			startsAt: now,
+			endsAt:   now.Add(time.Hour),
+			expected: []silencepb.Matcher_Type{silencepb.Matcher_EQUAL, silencepb.Matcher_NOT_EQUAL, silencepb.Matcher_REGEXP, silencepb.Matcher_NOT_REGEXP},
+		},
+		{
+			name:     "EndsBeforeStart",
+			matchers: labels.Matchers{&labels.Matcher{Type: labels.MatchEqual, Name: "a", Value: "1"}},
+			startsAt: now,
+			endsAt:   now.Add(-time.Minute),
+			fail:     true,
+		},
+		{
+			name:     "EndsInThePast",
+			matchers: labels.Matchers{&labels.Matcher{Type: labels.MatchEqual, Name: "a", Value: "1"}},
+			startsAt: now.Add(-2 * time.Hour),
+			endsAt:   now.Add(-time.Hour),
+			fail:     true,
+		},
+		{
+			name:     "MatchesEverything",
+			matchers: labels.Matchers{&labels.Matcher{Type: labels.MatchRegexp, Name: "a", Value: ".*"}},
+			startsAt: now,
+			endsAt:   now.Add(time.Hour),
+			fail:     true,
+		},
+		{
+			name:     "NoMatchers",
+			matchers: labels.Matchers{},
+			startsAt: now,
+			endsAt:   now.Add(time.Hour),
+			fail:     true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			silence, err := NewSilenceFromPostableSilence(NewPostableSilence(testCase.matchers, testCase.startsAt, testCase.endsAt, "creator", "comment"), now)
+			if testCase.fail {
+				require.Error(t, err)
+				assert.True(t, errors.Ast(err, errors.TypeInvalidInput))
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, "creator", silence.CreatedBy)
+			require.Len(t, silence.Matchers, len(testCase.expected))
+			for i, matcher := range silence.Matchers {
+				assert.Equal(t, testCase.expected[i], matcher.Type)
+			}
+		})
+	}
+}
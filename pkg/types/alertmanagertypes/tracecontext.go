@@ -0,0 +1,72 @@
+package alertmanagertypes
+
+import (
+	"context"
+	"sync"
+
+	"github.com/prometheus/alertmanager/types"
+	"github.com/prometheus/common/model"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TraceContexts are the spans which evaluated the alerts of an alertmanager keyed by the fingerprint of the alerts.
+// They are kept out of band rather than in the labels or the annotations of the alerts so that they neither change
+// the alerts nor show in their notifications. An alert keeps the span of its latest evaluation until it is
+// garbage collected, TraceContexts being the callback of the store of the alerts.
+type TraceContexts struct {
+	mtx   sync.RWMutex
+	spans map[model.Fingerprint]trace.SpanContext
+}
+
+func NewTraceContexts() *TraceContexts {
+	return &TraceContexts{
+		spans: make(map[model.Fingerprint]trace.SpanContext),
+	}
+}
+
+// Put records the span of the context as the span which evaluated the alerts. Nothing is recorded when the context
+// has no valid span.
+func (tc *TraceContexts) Put(ctx context.Context, alerts ...*Alert) {
+	spanContext := trace.SpanContextFromContext(ctx)
+	if !spanContext.IsValid() {
+		return
+	}
+
+	tc.mtx.Lock()
+	defer tc.mtx.Unlock()
+
+	for _, alert := range alerts {
+		tc.spans[alert.Fingerprint()] = spanContext
+	}
+}
+
+// NewContext returns a context continuing the span which evaluated the alerts. The alerts of a notification can
+// come from several evaluations, in which case the span of the first alert with a recorded span is continued.
+func (tc *TraceContexts) NewContext(ctx context.Context, alerts ...*Alert) context.Context {
+	tc.mtx.RLock()
+	defer tc.mtx.RUnlock()
+
+	for _, alert := range alerts {
+		if spanContext, ok := tc.spans[alert.Fingerprint()]; ok {
+			return trace.ContextWithRemoteSpanContext(ctx, spanContext)
+		}
+	}
+
+	return ctx
+}
+
+// PreStore implements the AlertStoreCallback interface of the store of the alerts.
+func (tc *TraceContexts) PreStore(_ *types.Alert, _ bool) error {
+	return nil
+}
+
+// PostStore implements the AlertStoreCallback interface of the store of the alerts.
+func (tc *TraceContexts) PostStore(_ *types.Alert, _ bool) {}
+
+// PostDelete forgets the span of the alert once it is garbage collected.
+func (tc *TraceContexts) PostDelete(alert *types.Alert) {
+	tc.mtx.Lock()
+	defer tc.mtx.Unlock()
+
+	delete(tc.spans, alert.Fingerprint())
+}
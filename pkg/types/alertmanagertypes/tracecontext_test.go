@@ -0,0 +1,44 @@
+package alertmanagertypes
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestTraceContexts(t *testing.T) {
+	spanContext := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{0x01},
+		SpanID:     trace.SpanID{0x02},
+		TraceFlags: trace.FlagsSampled,
+	})
+
+	evaluated := &Alert{Alert: model.Alert{Labels: model.LabelSet{"alertname": "evaluated"}}}
+	other := &Alert{Alert: model.Alert{Labels: model.LabelSet{"alertname": "other"}}}
+
+	traceContexts := NewTraceContexts()
+	traceContexts.Put(trace.ContextWithSpanContext(context.Background(), spanContext), evaluated)
+	// The alerts are left untouched.
+	assert.Empty(t, evaluated.Annotations)
+
+	// The first alert has no span, the span of the second one is continued.
+	continued := trace.SpanContextFromContext(traceContexts.NewContext(context.Background(), other, evaluated))
+	assert.Equal(t, spanContext.TraceID(), continued.TraceID())
+	assert.Equal(t, spanContext.SpanID(), continued.SpanID())
+	assert.True(t, continued.IsRemote())
+
+	// The span is forgotten once the alert is garbage collected.
+	traceContexts.PostDelete(evaluated)
+	assert.False(t, trace.SpanContextFromContext(traceContexts.NewContext(context.Background(), evaluated)).IsValid())
+}
+
+func TestTraceContextsWithoutSpan(t *testing.T) {
+	alert := &Alert{Alert: model.Alert{Labels: model.LabelSet{"alertname": "evaluated"}}}
+
+	traceContexts := NewTraceContexts()
+	traceContexts.Put(context.Background(), alert)
+	assert.False(t, trace.SpanContextFromContext(traceContexts.NewContext(context.Background(), alert)).IsValid())
+}
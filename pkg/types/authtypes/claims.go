@@ -17,6 +17,10 @@ type Claims struct {
 	Email  string     `json:"email"`
 	Role   types.Role `json:"role"`
 	OrgID  string     `json:"orgId"`
+	// TokenType is either access or refresh. It is empty for the tokens issued before refresh tokens were rotated.
+	TokenType string `json:"tokenType,omitempty"`
+	// FamilyID is shared by all the refresh tokens issued by rotation from the same login.
+	FamilyID string `json:"familyId,omitempty"`
 }
 
 func (c *Claims) Validate() error {
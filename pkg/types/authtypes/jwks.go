@@ -0,0 +1,237 @@
+package authtypes
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/types"
+	"github.com/SigNoz/signoz/pkg/valuer"
+	"github.com/go-jose/go-jose/v4"
+)
+
+const (
+	// jwksDefaultMaxAge is how long the keys of an issuer are cached when the response has no max-age.
+	jwksDefaultMaxAge = 15 * time.Minute
+	// jwksMinRefreshInterval is the minimum time between two fetches of the keys of an issuer, so that tokens
+	// signed with unknown keys cannot be used to flood the issuer.
+	jwksMinRefreshInterval = 30 * time.Second
+	// jwksFetchTimeout is the maximum time spent fetching the keys of an issuer.
+	jwksFetchTimeout = 10 * time.Second
+)
+
+var (
+	ErrCodeJWKSFetchFailed = errors.MustNewCode("jwks_fetch_failed")
+)
+
+// TrustedIssuer is an issuer whose tokens are accepted for the users of an organization. The tokens are verified
+// with the signing keys published at the jwks url of the issuer and must be minted for the audience. The roles of the
+// users are mapped from the role claim of the tokens, the organization and the roles claimed by the tokens are never
+// trusted as is.
+type TrustedIssuer struct {
+	// Issuer is the iss claim of the tokens of the issuer.
+	Issuer string
+	// JWKSURL is the url of the json web key set of the issuer.
+	JWKSURL string
+	// Audience is the aud claim the tokens of the issuer must hold.
+	Audience string
+	// OrgID is the organization the tokens of the issuer are accepted for.
+	OrgID valuer.UUID
+	// RoleClaim is the claim holding the roles of the user at the issuer, either a string or a list of strings.
+	RoleClaim string
+	// Roles maps the roles of the users at the issuer to their roles in the organization. The most privileged role
+	// of a user is granted.
+	Roles map[string]types.Role
+	// DefaultRole is the role of the users without any mapped role. The tokens of these users are rejected when it
+	// is empty.
+	DefaultRole types.Role
+}
+
+// role returns the role granted to the user holding the roles at the issuer.
+func (issuer TrustedIssuer) role(roles []string) (types.Role, error) {
+	granted := issuer.DefaultRole
+	for _, role := range roles {
+		mapped, ok := issuer.Roles[role]
+		if !ok {
+			continue
+		}
+
+		if granted == "" || rolePrivilege(mapped) > rolePrivilege(granted) {
+			granted = mapped
+		}
+	}
+
+	if granted == "" {
+		return "", errors.Newf(errors.TypeUnauthenticated, errors.CodeUnauthenticated, "no role of issuer %q is mapped", issuer.Issuer)
+	}
+
+	return granted, nil
+}
+
+func rolePrivilege(role types.Role) int {
+	switch role {
+	case types.RoleAdmin:
+		return 3
+	case types.RoleEditor:
+		return 2
+	case types.RoleViewer:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// jwks caches the signing keys of an issuer keyed by their id. The keys are fetched again once the max-age of
+// the response expires, or when a token is signed with a key which is not known yet as the issuer rotated its keys.
+type jwks struct {
+	url       string
+	client    *http.Client
+	mtx       sync.Mutex
+	keys      map[string]any
+	fetchedAt time.Time
+	expiresAt time.Time
+	// fetching is closed once the fetch in progress completes, it is nil when no fetch is in progress.
+	fetching chan struct{}
+}
+
+func newJWKS(client *http.Client, url string) *jwks {
+	return &jwks{
+		url:    url,
+		client: client,
+		keys:   map[string]any{},
+	}
+}
+
+// Key returns the signing key of the given id. The keys are fetched without holding the lock, the callers needing
+// the keys while they are fetched wait for the fetch in progress.
+func (set *jwks) Key(ctx context.Context, kid string) (any, error) {
+	for {
+		set.mtx.Lock()
+		now := time.Now()
+		key, ok := set.keys[kid]
+		if ok && now.Before(set.expiresAt) {
+			set.mtx.Unlock()
+			return key, nil
+		}
+
+		if fetching := set.fetching; fetching != nil {
+			set.mtx.Unlock()
+			select {
+			case <-fetching:
+				continue
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+		}
+
+		if !set.fetchedAt.IsZero() && now.Sub(set.fetchedAt) < jwksMinRefreshInterval {
+			set.mtx.Unlock()
+			if ok {
+				return key, nil
+			}
+
+			return nil, errors.Newf(errors.TypeUnauthenticated, errors.CodeUnauthenticated, "signing key %q is not found", kid)
+		}
+
+		fetching := make(chan struct{})
+		set.fetching = fetching
+		set.fetchedAt = now
+		set.mtx.Unlock()
+
+		// The keys are shared by the callers, the fetch is not canceled with the request which started it.
+		keys, age, err := set.fetch(context.WithoutCancel(ctx))
+
+		set.mtx.Lock()
+		if err == nil {
+			set.keys = keys
+			set.expiresAt = now.Add(age)
+		}
+		set.fetching = nil
+		close(fetching)
+		key, ok = set.keys[kid]
+		set.mtx.Unlock()
+
+		if !ok {
+			if err != nil {
+				return nil, err
+			}
+
+			return nil, errors.Newf(errors.TypeUnauthenticated, errors.CodeUnauthenticated, "signing key %q is not found", kid)
+		}
+
+		// Keep using the cached key rather than rejecting every token while the issuer is unreachable.
+		return key, nil
+	}
+}
+
+// fetch fetches the signing keys and returns them with how long they can be cached.
+func (set *jwks) fetch(ctx context.Context) (map[string]any, time.Duration, error) {
+	ctx, cancel := context.WithTimeout(ctx, jwksFetchTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, set.url, nil)
+	if err != nil {
+		return nil, 0, errors.Wrapf(err, errors.TypeInternal, ErrCodeJWKSFetchFailed, "failed to create the request of %q", set.url)
+	}
+
+	res, err := set.client.Do(req)
+	if err != nil {
+		return nil, 0, errors.Wrapf(err, errors.TypeUnauthenticated, ErrCodeJWKSFetchFailed, "failed to fetch %q", set.url)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, 0, errors.Newf(errors.TypeUnauthenticated, ErrCodeJWKSFetchFailed, "failed to fetch %q, got status %d", set.url, res.StatusCode)
+	}
+
+	var keySet jose.JSONWebKeySet
+	if err := json.NewDecoder(res.Body).Decode(&keySet); err != nil {
+		return nil, 0, errors.Wrapf(err, errors.TypeUnauthenticated, ErrCodeJWKSFetchFailed, "failed to decode %q", set.url)
+	}
+
+	keys := make(map[string]any, len(keySet.Keys))
+	for _, key := range keySet.Keys {
+		if key.Use != "" && key.Use != "sig" {
+			continue
+		}
+
+		if !key.Valid() || !key.IsPublic() {
+			continue
+		}
+
+		keys[key.KeyID] = key.Key
+	}
+
+	return keys, maxAge(res.Header), nil
+}
+
+// maxAge returns how long the response can be cached according to its Cache-Control header.
+func maxAge(header http.Header) time.Duration {
+	value := header.Get("Cache-Control")
+	if value == "" {
+		return jwksDefaultMaxAge
+	}
+
+	for _, directive := range strings.Split(value, ",") {
+		directive = strings.ToLower(strings.TrimSpace(directive))
+		if directive == "no-store" || directive == "no-cache" {
+			return 0
+		}
+
+		if seconds, ok := strings.CutPrefix(directive, "max-age="); ok {
+			age, err := strconv.Atoi(seconds)
+			if err != nil || age < 0 {
+				return jwksDefaultMaxAge
+			}
+
+			return time.Duration(age) * time.Second
+		}
+	}
+
+	return jwksDefaultMaxAge
+}
@@ -0,0 +1,257 @@
+package authtypes
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/types"
+	"github.com/SigNoz/signoz/pkg/valuer"
+	"github.com/go-jose/go-jose/v4"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type jwksServer struct {
+	*httptest.Server
+	mtx          sync.Mutex
+	keys         map[string]*rsa.PrivateKey
+	cacheControl string
+	fetches      int
+}
+
+func newJWKSServer(t *testing.T, kids ...string) *jwksServer {
+	server := &jwksServer{keys: map[string]*rsa.PrivateKey{}}
+	for _, kid := range kids {
+		server.rotate(t, kid)
+	}
+
+	server.Server = httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		server.mtx.Lock()
+		defer server.mtx.Unlock()
+
+		server.fetches++
+		keySet := jose.JSONWebKeySet{}
+		for kid, key := range server.keys {
+			keySet.Keys = append(keySet.Keys, jose.JSONWebKey{Key: &key.PublicKey, KeyID: kid, Algorithm: "RS256", Use: "sig"})
+		}
+
+		if server.cacheControl != "" {
+			rw.Header().Set("Cache-Control", server.cacheControl)
+		}
+		_ = json.NewEncoder(rw).Encode(keySet)
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+// rotate replaces the keys of the server with a new key of the given id.
+func (server *jwksServer) rotate(t *testing.T, kid string) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	server.mtx.Lock()
+	defer server.mtx.Unlock()
+	server.keys = map[string]*rsa.PrivateKey{kid: key}
+}
+
+func (server *jwksServer) sign(t *testing.T, kid string, claims jwt.Claims) string {
+	server.mtx.Lock()
+	defer server.mtx.Unlock()
+
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = kid
+	signed, err := token.SignedString(server.keys[kid])
+	require.NoError(t, err)
+	return signed
+}
+
+func (server *jwksServer) Fetches() int {
+	server.mtx.Lock()
+	defer server.mtx.Unlock()
+	return server.fetches
+}
+
+func newIssuerClaims(issuer string, audience string, roles ...string) jwt.MapClaims {
+	return jwt.MapClaims{
+		"iss":   issuer,
+		"aud":   audience,
+		"sub":   "userId",
+		"email": "email@example.com",
+		"roles": roles,
+		"exp":   time.Now().Add(time.Minute).Unix(),
+		"iat":   time.Now().Unix(),
+	}
+}
+
+func newTrustedIssuer(url string, orgID valuer.UUID) TrustedIssuer {
+	return TrustedIssuer{
+		Issuer:   "https://idp.example.com",
+		JWKSURL:  url,
+		Audience: "signoz",
+		OrgID:    orgID,
+		Roles:    map[string]types.Role{"admins": types.RoleAdmin, "editors": types.RoleEditor},
+	}
+}
+
+func TestJwtTrustedIssuer(t *testing.T) {
+	server := newJWKSServer(t, "key1")
+	orgID := valuer.GenerateUUID()
+
+	jwtService := NewJWT("secret", time.Minute, time.Hour, WithTrustedIssuers(server.Client(), newTrustedIssuer(server.URL, orgID)))
+
+	claims, err := jwtService.Claims(server.sign(t, "key1", newIssuerClaims("https://idp.example.com", "signoz", "editors", "admins", "other")))
+	require.NoError(t, err)
+	assert.Equal(t, orgID.StringValue(), claims.OrgID)
+	assert.Equal(t, "https://idp.example.com", claims.Issuer)
+	assert.Equal(t, "userId", claims.UserID)
+	// The most privileged mapped role is granted.
+	assert.Equal(t, types.RoleAdmin, claims.Role)
+
+	// The tokens issued by signoz are still accepted.
+	token, _, err := jwtService.AccessToken("orgId", "userId", "email@example.com", types.RoleAdmin)
+	require.NoError(t, err)
+	_, err = jwtService.Claims(token)
+	assert.NoError(t, err)
+}
+
+func TestJwtTrustedIssuerIgnoresClaimedOrgAndRole(t *testing.T) {
+	server := newJWKSServer(t, "key1")
+	orgID := valuer.GenerateUUID()
+
+	issuer := newTrustedIssuer(server.URL, orgID)
+	issuer.DefaultRole = types.RoleViewer
+	jwtService := NewJWT("secret", time.Minute, time.Hour, WithTrustedIssuers(server.Client(), issuer))
+
+	tokenClaims := newIssuerClaims("https://idp.example.com", "signoz")
+	tokenClaims["orgId"] = valuer.GenerateUUID().StringValue()
+	tokenClaims["role"] = "ADMIN"
+
+	claims, err := jwtService.Claims(server.sign(t, "key1", tokenClaims))
+	require.NoError(t, err)
+	assert.Equal(t, orgID.StringValue(), claims.OrgID)
+	assert.Equal(t, types.RoleViewer, claims.Role)
+}
+
+func TestJwtTrustedIssuerRejected(t *testing.T) {
+	server := newJWKSServer(t, "key1")
+	orgID := valuer.GenerateUUID()
+
+	jwtService := NewJWT("secret", time.Minute, time.Hour, WithTrustedIssuers(server.Client(), newTrustedIssuer(server.URL, orgID)))
+
+	t.Run("UnknownIssuer", func(t *testing.T) {
+		_, err := jwtService.Claims(server.sign(t, "key1", newIssuerClaims("https://other.example.com", "signoz", "admins")))
+		assert.ErrorContains(t, err, "is not trusted")
+	})
+
+	t.Run("OtherAudience", func(t *testing.T) {
+		_, err := jwtService.Claims(server.sign(t, "key1", newIssuerClaims("https://idp.example.com", "other", "admins")))
+		assert.ErrorContains(t, err, "audience")
+	})
+
+	t.Run("MissingAudience", func(t *testing.T) {
+		tokenClaims := newIssuerClaims("https://idp.example.com", "", "admins")
+		delete(tokenClaims, "aud")
+		_, err := jwtService.Claims(server.sign(t, "key1", tokenClaims))
+		assert.ErrorContains(t, err, "aud claim is required")
+	})
+
+	t.Run("NoMappedRole", func(t *testing.T) {
+		_, err := jwtService.Claims(server.sign(t, "key1", newIssuerClaims("https://idp.example.com", "signoz", "other")))
+		assert.ErrorContains(t, err, "no role of issuer")
+	})
+
+	t.Run("SignedWithSecret", func(t *testing.T) {
+		token := jwt.NewWithClaims(jwt.SigningMethodHS256, newIssuerClaims("https://idp.example.com", "signoz", "admins"))
+		signed, err := token.SignedString([]byte(jwtService.JwtSecret))
+		require.NoError(t, err)
+
+		_, err = jwtService.Claims(signed)
+		assert.ErrorContains(t, err, "unrecognized signing algorithm")
+	})
+
+	t.Run("UnknownKey", func(t *testing.T) {
+		other := newJWKSServer(t, "key2")
+
+		_, err := jwtService.Claims(other.sign(t, "key2", newIssuerClaims("https://idp.example.com", "signoz", "admins")))
+		assert.ErrorContains(t, err, "is not found")
+	})
+}
+
+func TestJwtTrustedIssuerKeyRotation(t *testing.T) {
+	server := newJWKSServer(t, "key1")
+	server.cacheControl = "max-age=3600"
+	orgID := valuer.GenerateUUID()
+
+	jwtService := NewJWT("secret", time.Minute, time.Hour, WithTrustedIssuers(server.Client(), newTrustedIssuer(server.URL, orgID)))
+
+	_, err := jwtService.Claims(server.sign(t, "key1", newIssuerClaims("https://idp.example.com", "signoz", "admins")))
+	require.NoError(t, err)
+
+	// The keys are cached for the max-age of the response.
+	_, err = jwtService.Claims(server.sign(t, "key1", newIssuerClaims("https://idp.example.com", "signoz", "admins")))
+	require.NoError(t, err)
+	assert.Equal(t, 1, server.Fetches())
+
+	// A token signed with an unknown key fetches the keys again, once the minimum refresh interval elapsed.
+	server.rotate(t, "key2")
+	_, err = jwtService.Claims(server.sign(t, "key2", newIssuerClaims("https://idp.example.com", "signoz", "admins")))
+	assert.Error(t, err)
+	assert.Equal(t, 1, server.Fetches())
+
+	jwtService.trustedIssuers["https://idp.example.com"].keys.fetchedAt = time.Now().Add(-jwksMinRefreshInterval)
+	_, err = jwtService.Claims(server.sign(t, "key2", newIssuerClaims("https://idp.example.com", "signoz", "admins")))
+	require.NoError(t, err)
+	assert.Equal(t, 2, server.Fetches())
+}
+
+func TestJWKSKeyConcurrentFetch(t *testing.T) {
+	server := newJWKSServer(t, "key1")
+	keys := newJWKS(server.Client(), server.URL)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := keys.Key(context.Background(), "key1")
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	// The callers waiting for the fetch in progress do not fetch the keys again.
+	assert.Equal(t, 1, server.Fetches())
+}
+
+func TestJWKSMaxAge(t *testing.T) {
+	testCases := []struct {
+		name         string
+		cacheControl string
+		expected     time.Duration
+	}{
+		{name: "Missing", cacheControl: "", expected: jwksDefaultMaxAge},
+		{name: "MaxAge", cacheControl: "public, max-age=300", expected: 300 * time.Second},
+		{name: "NoStore", cacheControl: "no-store", expected: 0},
+		{name: "InvalidMaxAge", cacheControl: "max-age=abc", expected: jwksDefaultMaxAge},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			header := http.Header{}
+			if tc.cacheControl != "" {
+				header.Set("Cache-Control", tc.cacheControl)
+			}
+
+			assert.Equal(t, tc.expected, maxAge(header))
+		})
+	}
+}
@@ -2,28 +2,69 @@ package authtypes
 
 import (
 	"context"
+	"net/http"
 	"strings"
 	"time"
 
 	"github.com/SigNoz/signoz/pkg/errors"
 	"github.com/SigNoz/signoz/pkg/types"
+	"github.com/SigNoz/signoz/pkg/valuer"
 	"github.com/golang-jwt/jwt/v5"
 )
 
 type jwtClaimsKey struct{}
 
+// defaultRoleClaim is the claim holding the roles of the users at a trusted issuer when none is configured.
+const defaultRoleClaim = "roles"
+
 type JWT struct {
 	JwtSecret  string
 	JwtExpiry  time.Duration
 	JwtRefresh time.Duration
+
+	// trustedIssuers are the issuers whose tokens are accepted in addition to the tokens signed with JwtSecret,
+	// keyed by their iss claim.
+	trustedIssuers map[string]*trustedIssuer
+}
+
+type trustedIssuer struct {
+	TrustedIssuer
+	keys *jwks
 }
 
-func NewJWT(jwtSecret string, jwtExpiry time.Duration, jwtRefresh time.Duration) *JWT {
-	return &JWT{
-		JwtSecret:  jwtSecret,
-		JwtExpiry:  jwtExpiry,
-		JwtRefresh: jwtRefresh,
+// JWTOption configures the tokens accepted by a JWT.
+type JWTOption func(*JWT)
+
+// WithTrustedIssuers accepts the tokens of the issuers. The signing keys of every issuer are fetched from its jwks
+// url with the given client and the tokens of an issuer are only accepted for its organization.
+func WithTrustedIssuers(client *http.Client, issuers ...TrustedIssuer) JWTOption {
+	return func(j *JWT) {
+		for _, issuer := range issuers {
+			if issuer.RoleClaim == "" {
+				issuer.RoleClaim = defaultRoleClaim
+			}
+
+			j.trustedIssuers[issuer.Issuer] = &trustedIssuer{
+				TrustedIssuer: issuer,
+				keys:          newJWKS(client, issuer.JWKSURL),
+			}
+		}
+	}
+}
+
+func NewJWT(jwtSecret string, jwtExpiry time.Duration, jwtRefresh time.Duration, opts ...JWTOption) *JWT {
+	j := &JWT{
+		JwtSecret:      jwtSecret,
+		JwtExpiry:      jwtExpiry,
+		JwtRefresh:     jwtRefresh,
+		trustedIssuers: map[string]*trustedIssuer{},
+	}
+
+	for _, opt := range opts {
+		opt(j)
 	}
+
+	return j
 }
 
 func (j *JWT) ContextFromRequest(ctx context.Context, values ...string) (context.Context, error) {
@@ -46,17 +87,41 @@ func (j *JWT) ContextFromRequest(ctx context.Context, values ...string) (context
 		bearerToken = value
 	}
 
-	claims, err := j.Claims(bearerToken)
+	claims, err := j.claims(ctx, bearerToken)
 	if err != nil {
 		return ctx, err
 	}
 
+	if claims.TokenType == TokenTypeRefresh {
+		return ctx, errors.New(errors.TypeUnauthenticated, errors.CodeUnauthenticated, "refresh tokens cannot be used for authorization")
+	}
+
 	return NewContextWithClaims(ctx, claims), nil
 }
 
 func (j *JWT) Claims(jwtStr string) (Claims, error) {
+	return j.claims(context.Background(), jwtStr)
+}
+
+// claims returns the claims of the token. The tokens without an issuer are signed by us with JwtSecret, the tokens
+// of a trusted issuer are verified with the signing keys of the issuer.
+func (j *JWT) claims(ctx context.Context, jwtStr string) (Claims, error) {
+	unverified := jwt.RegisteredClaims{}
+	if _, _, err := jwt.NewParser().ParseUnverified(jwtStr, &unverified); err != nil {
+		return Claims{}, errors.Wrapf(err, errors.TypeUnauthenticated, errors.CodeUnauthenticated, "failed to parse jwt token")
+	}
+
+	if unverified.Issuer != "" {
+		issuer, ok := j.trustedIssuers[unverified.Issuer]
+		if !ok {
+			return Claims{}, errors.Newf(errors.TypeUnauthenticated, errors.CodeUnauthenticated, "issuer %q is not trusted", unverified.Issuer)
+		}
+
+		return issuer.claims(ctx, jwtStr)
+	}
+
 	claims := Claims{}
-	_, err := jwt.ParseWithClaims(jwtStr, &claims, func(token *jwt.Token) (interface{}, error) {
+	_, err := jwt.ParseWithClaims(jwtStr, &claims, func(token *jwt.Token) (any, error) {
 		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
 			return nil, errors.Newf(errors.TypeUnauthenticated, errors.CodeUnauthenticated, "unrecognized signing algorithm: %s", token.Method.Alg())
 		}
@@ -69,6 +134,73 @@ func (j *JWT) Claims(jwtStr string) (Claims, error) {
 	return claims, nil
 }
 
+// claims verifies the token of the issuer and returns its claims. The organization is the organization of the
+// issuer and the role is mapped from the roles of the user at the issuer.
+func (issuer *trustedIssuer) claims(ctx context.Context, jwtStr string) (Claims, error) {
+	mapClaims := jwt.MapClaims{}
+	_, err := jwt.ParseWithClaims(jwtStr, mapClaims, func(token *jwt.Token) (any, error) {
+		switch token.Method.(type) {
+		case *jwt.SigningMethodRSA, *jwt.SigningMethodRSAPSS, *jwt.SigningMethodECDSA, *jwt.SigningMethodEd25519:
+		default:
+			return nil, errors.Newf(errors.TypeUnauthenticated, errors.CodeUnauthenticated, "unrecognized signing algorithm of issuer %q: %s", issuer.Issuer, token.Method.Alg())
+		}
+
+		kid, _ := token.Header["kid"].(string)
+		if kid == "" {
+			return nil, errors.Newf(errors.TypeUnauthenticated, errors.CodeUnauthenticated, "kid is required for the tokens of issuer %q", issuer.Issuer)
+		}
+
+		return issuer.keys.Key(ctx, kid)
+	}, jwt.WithIssuer(issuer.Issuer), jwt.WithAudience(issuer.Audience), jwt.WithExpirationRequired())
+	if err != nil {
+		return Claims{}, errors.Wrapf(err, errors.TypeUnauthenticated, errors.CodeUnauthenticated, "failed to parse jwt token")
+	}
+
+	role, err := issuer.role(stringsOf(mapClaims[issuer.RoleClaim]))
+	if err != nil {
+		return Claims{}, err
+	}
+
+	subject, _ := mapClaims.GetSubject()
+	audience, _ := mapClaims.GetAudience()
+	expiresAt, _ := mapClaims.GetExpirationTime()
+	issuedAt, _ := mapClaims.GetIssuedAt()
+	email, _ := mapClaims["email"].(string)
+
+	return Claims{
+		UserID:    subject,
+		Email:     email,
+		Role:      role,
+		OrgID:     issuer.OrgID.StringValue(),
+		TokenType: TokenTypeAccess,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    issuer.Issuer,
+			Subject:   subject,
+			Audience:  audience,
+			ExpiresAt: expiresAt,
+			IssuedAt:  issuedAt,
+		},
+	}, nil
+}
+
+// stringsOf returns the strings of a claim holding either a string or a list of strings.
+func stringsOf(claim any) []string {
+	switch value := claim.(type) {
+	case string:
+		return []string{value}
+	case []any:
+		values := make([]string, 0, len(value))
+		for _, v := range value {
+			if s, ok := v.(string); ok {
+				values = append(values, s)
+			}
+		}
+		return values
+	default:
+		return nil
+	}
+}
+
 // NewContextWithClaims attaches individual claims to the context.
 func NewContextWithClaims(ctx context.Context, claims Claims) context.Context {
 	ctx = context.WithValue(ctx, jwtClaimsKey{}, claims)
@@ -84,10 +216,11 @@ func (j *JWT) signToken(claims Claims) (string, error) {
 // AccessToken creates an access token with the provided claims
 func (j *JWT) AccessToken(orgId, userId, email string, role types.Role) (string, Claims, error) {
 	claims := Claims{
-		UserID: userId,
-		Role:   role,
-		Email:  email,
-		OrgID:  orgId,
+		UserID:    userId,
+		Role:      role,
+		Email:     email,
+		OrgID:     orgId,
+		TokenType: TokenTypeAccess,
 		RegisteredClaims: jwt.RegisteredClaims{
 			ExpiresAt: jwt.NewNumericDate(time.Now().Add(j.JwtExpiry)),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
@@ -102,14 +235,17 @@ func (j *JWT) AccessToken(orgId, userId, email string, role types.Role) (string,
 	return token, claims, nil
 }
 
-// RefreshToken creates a refresh token with the provided claims
-func (j *JWT) RefreshToken(orgId, userId, email string, role types.Role) (string, Claims, error) {
+// RefreshToken creates a refresh token of the given token family with the provided claims.
+func (j *JWT) RefreshToken(familyID, orgId, userId, email string, role types.Role) (string, Claims, error) {
 	claims := Claims{
-		UserID: userId,
-		Role:   role,
-		Email:  email,
-		OrgID:  orgId,
+		UserID:    userId,
+		Role:      role,
+		Email:     email,
+		OrgID:     orgId,
+		TokenType: TokenTypeRefresh,
+		FamilyID:  familyID,
 		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        valuer.GenerateUUID().StringValue(),
 			ExpiresAt: jwt.NewNumericDate(time.Now().Add(j.JwtRefresh)),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 		},
@@ -18,14 +18,6 @@ func TestJwtAccessToken(t *testing.T) {
 	assert.NotEmpty(t, token)
 }
 
-func TestJwtRefreshToken(t *testing.T) {
-	jwtService := NewJWT("secret", time.Minute, time.Hour)
-	token, _, err := jwtService.RefreshToken("orgId", "userId", "email@example.com", types.RoleAdmin)
-
-	assert.NoError(t, err)
-	assert.NotEmpty(t, token)
-}
-
 func TestJwtClaims(t *testing.T) {
 	jwtService := NewJWT("secret", time.Minute, time.Hour)
 
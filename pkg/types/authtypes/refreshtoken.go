@@ -0,0 +1,183 @@
+package authtypes
+
+import (
+	"context"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/types"
+	"github.com/SigNoz/signoz/pkg/valuer"
+	"github.com/uptrace/bun"
+)
+
+const (
+	TokenTypeAccess  string = "access"
+	TokenTypeRefresh string = "refresh"
+)
+
+var (
+	ErrCodeRefreshTokenNotFound = errors.MustNewCode("refresh_token_not_found")
+	ErrCodeRefreshTokenReused   = errors.MustNewCode("refresh_token_reused")
+)
+
+// TokenPair is an access token along with the refresh token used to obtain the next pair.
+type TokenPair struct {
+	AccessToken   string
+	AccessClaims  Claims
+	RefreshToken  string
+	RefreshClaims Claims
+}
+
+// StorableRefreshToken tracks an issued refresh token. A refresh token can be rotated exactly once, presenting
+// it again after it has been rotated means that it has been stolen and revokes its whole family.
+type StorableRefreshToken struct {
+	bun.BaseModel `bun:"table:refresh_token"`
+
+	types.TimeAuditable
+	// ID is the jti claim of the refresh token.
+	ID string `bun:"id,pk,type:text"`
+	// FamilyID is shared by all the refresh tokens issued by rotation from the same login.
+	FamilyID  string     `bun:"family_id,type:text,notnull"`
+	UserID    string     `bun:"user_id,type:text,notnull"`
+	OrgID     string     `bun:"org_id,type:text,notnull"`
+	ExpiresAt time.Time  `bun:"expires_at,notnull"`
+	RotatedAt *time.Time `bun:"rotated_at"`
+	RevokedAt *time.Time `bun:"revoked_at"`
+}
+
+func NewStorableRefreshToken(claims Claims) *StorableRefreshToken {
+	return &StorableRefreshToken{
+		TimeAuditable: types.TimeAuditable{
+			CreatedAt: time.Now(),
+			UpdatedAt: time.Now(),
+		},
+		ID:        claims.ID,
+		FamilyID:  claims.FamilyID,
+		UserID:    claims.UserID,
+		OrgID:     claims.OrgID,
+		ExpiresAt: claims.ExpiresAt.Time,
+	}
+}
+
+type RefreshTokenStore interface {
+	// Create tracks a newly issued refresh token.
+	Create(context.Context, *StorableRefreshToken) error
+
+	// Get returns the refresh token with the given id.
+	Get(context.Context, string) (*StorableRefreshToken, error)
+
+	// Rotate marks the refresh token with the given id as rotated. It returns an error with code
+	// ErrCodeRefreshTokenReused if the token has already been rotated or revoked.
+	Rotate(context.Context, string) error
+
+	// RevokeFamily revokes all the refresh tokens of the given family.
+	RevokeFamily(context.Context, string) error
+
+	// DeleteExpired deletes the refresh tokens which expired before the given time along with the revoked ones.
+	DeleteExpired(context.Context, time.Time) error
+}
+
+// TokenIssuer issues the token pairs of the users and rotates their refresh tokens. The issued refresh tokens are
+// tracked in its store to detect the reuse of the rotated ones.
+type TokenIssuer struct {
+	jwt   *JWT
+	store RefreshTokenStore
+}
+
+func NewTokenIssuer(jwt *JWT, store RefreshTokenStore) *TokenIssuer {
+	return &TokenIssuer{
+		jwt:   jwt,
+		store: store,
+	}
+}
+
+// NewTokenPair creates an access token and a refresh token starting a new token family.
+func (issuer *TokenIssuer) NewTokenPair(ctx context.Context, orgId, userId, email string, role types.Role) (TokenPair, error) {
+	return issuer.newTokenPair(ctx, valuer.GenerateUUID().StringValue(), orgId, userId, email, role)
+}
+
+// Refresh validates the refresh token, rotates it and issues a new pair in the same token family. Presenting a
+// refresh token which has already been rotated revokes the whole family, as either the legitimate client or an
+// attacker holds a stolen token.
+func (issuer *TokenIssuer) Refresh(ctx context.Context, refreshToken string) (TokenPair, error) {
+	claims, err := issuer.refreshClaims(refreshToken)
+	if err != nil {
+		return TokenPair{}, err
+	}
+
+	if claims.ID == "" {
+		return TokenPair{}, errors.New(errors.TypeUnauthenticated, errors.CodeUnauthenticated, "token is not a refresh token")
+	}
+
+	storable, err := issuer.store.Get(ctx, claims.ID)
+	if err != nil {
+		if errors.Ast(err, errors.TypeNotFound) {
+			return TokenPair{}, errors.New(errors.TypeUnauthenticated, errors.CodeUnauthenticated, "refresh token is not recognized")
+		}
+		return TokenPair{}, err
+	}
+
+	if storable.FamilyID != claims.FamilyID {
+		return TokenPair{}, errors.New(errors.TypeUnauthenticated, errors.CodeUnauthenticated, "refresh token is not recognized")
+	}
+
+	if err := issuer.store.Rotate(ctx, claims.ID); err != nil {
+		if !errors.Asc(err, ErrCodeRefreshTokenReused) {
+			return TokenPair{}, err
+		}
+
+		if err := issuer.store.RevokeFamily(ctx, claims.FamilyID); err != nil {
+			return TokenPair{}, err
+		}
+
+		return TokenPair{}, errors.New(errors.TypeUnauthenticated, ErrCodeRefreshTokenReused, "refresh token has already been used, please log in again")
+	}
+
+	return issuer.newTokenPair(ctx, claims.FamilyID, claims.OrgID, claims.UserID, claims.Email, claims.Role)
+}
+
+// RevokeFamily revokes the token family of the given refresh token, for example on logout.
+func (issuer *TokenIssuer) RevokeFamily(ctx context.Context, refreshToken string) error {
+	claims, err := issuer.refreshClaims(refreshToken)
+	if err != nil {
+		return err
+	}
+
+	return issuer.store.RevokeFamily(ctx, claims.FamilyID)
+}
+
+func (issuer *TokenIssuer) refreshClaims(refreshToken string) (Claims, error) {
+	claims, err := issuer.jwt.Claims(refreshToken)
+	if err != nil {
+		return Claims{}, err
+	}
+
+	if claims.TokenType != TokenTypeRefresh || claims.FamilyID == "" {
+		return Claims{}, errors.New(errors.TypeUnauthenticated, errors.CodeUnauthenticated, "token is not a refresh token")
+	}
+
+	return claims, nil
+}
+
+func (issuer *TokenIssuer) newTokenPair(ctx context.Context, familyID, orgId, userId, email string, role types.Role) (TokenPair, error) {
+	accessToken, accessClaims, err := issuer.jwt.AccessToken(orgId, userId, email, role)
+	if err != nil {
+		return TokenPair{}, err
+	}
+
+	refreshToken, refreshClaims, err := issuer.jwt.RefreshToken(familyID, orgId, userId, email, role)
+	if err != nil {
+		return TokenPair{}, err
+	}
+
+	if err := issuer.store.Create(ctx, NewStorableRefreshToken(refreshClaims)); err != nil {
+		return TokenPair{}, err
+	}
+
+	return TokenPair{
+		AccessToken:   accessToken,
+		AccessClaims:  accessClaims,
+		RefreshToken:  refreshToken,
+		RefreshClaims: refreshClaims,
+	}, nil
+}
@@ -0,0 +1,105 @@
+package authtypes
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type refreshTokenStore struct {
+	tokens map[string]*StorableRefreshToken
+}
+
+func (store *refreshTokenStore) Create(_ context.Context, token *StorableRefreshToken) error {
+	store.tokens[token.ID] = token
+	return nil
+}
+
+func (store *refreshTokenStore) Get(_ context.Context, id string) (*StorableRefreshToken, error) {
+	token, ok := store.tokens[id]
+	if !ok {
+		return nil, errors.New(errors.TypeNotFound, ErrCodeRefreshTokenNotFound, "not found")
+	}
+	return token, nil
+}
+
+func (store *refreshTokenStore) Rotate(_ context.Context, id string) error {
+	token := store.tokens[id]
+	if token.RotatedAt != nil || token.RevokedAt != nil {
+		return errors.New(errors.TypeAlreadyExists, ErrCodeRefreshTokenReused, "reused")
+	}
+	now := time.Now()
+	token.RotatedAt = &now
+	return nil
+}
+
+func (store *refreshTokenStore) RevokeFamily(_ context.Context, familyID string) error {
+	now := time.Now()
+	for _, token := range store.tokens {
+		if token.FamilyID == familyID {
+			token.RevokedAt = &now
+		}
+	}
+	return nil
+}
+
+func (store *refreshTokenStore) DeleteExpired(_ context.Context, before time.Time) error {
+	for id, token := range store.tokens {
+		if token.ExpiresAt.Before(before) || token.RevokedAt != nil {
+			delete(store.tokens, id)
+		}
+	}
+	return nil
+}
+
+func newTestTokenIssuer() (*JWT, *TokenIssuer) {
+	jwtService := NewJWT("secret", time.Minute, time.Hour)
+	return jwtService, NewTokenIssuer(jwtService, &refreshTokenStore{tokens: map[string]*StorableRefreshToken{}})
+}
+
+func TestTokenIssuerNewTokenPair(t *testing.T) {
+	jwtService, issuer := newTestTokenIssuer()
+	pair, err := issuer.NewTokenPair(context.Background(), "orgId", "userId", "email@example.com", types.RoleAdmin)
+	require.NoError(t, err)
+
+	assert.NotEmpty(t, pair.AccessToken)
+	assert.Equal(t, TokenTypeAccess, pair.AccessClaims.TokenType)
+	assert.Equal(t, TokenTypeRefresh, pair.RefreshClaims.TokenType)
+	assert.NotEmpty(t, pair.RefreshClaims.ID)
+	assert.NotEmpty(t, pair.RefreshClaims.FamilyID)
+
+	// Refresh tokens cannot be used to authorize requests.
+	_, err = jwtService.ContextFromRequest(context.Background(), "Bearer "+pair.RefreshToken)
+	assert.True(t, errors.Ast(err, errors.TypeUnauthenticated))
+
+	_, err = jwtService.ContextFromRequest(context.Background(), "Bearer "+pair.AccessToken)
+	assert.NoError(t, err)
+}
+
+func TestTokenIssuerRefresh(t *testing.T) {
+	_, issuer := newTestTokenIssuer()
+	pair, err := issuer.NewTokenPair(context.Background(), "orgId", "userId", "email@example.com", types.RoleAdmin)
+	require.NoError(t, err)
+
+	refreshed, err := issuer.Refresh(context.Background(), pair.RefreshToken)
+	require.NoError(t, err)
+	assert.Equal(t, pair.RefreshClaims.FamilyID, refreshed.RefreshClaims.FamilyID)
+	assert.NotEqual(t, pair.RefreshClaims.ID, refreshed.RefreshClaims.ID)
+	assert.Equal(t, "userId", refreshed.AccessClaims.UserID)
+
+	// Access tokens cannot be used to refresh.
+	_, err = issuer.Refresh(context.Background(), refreshed.AccessToken)
+	assert.True(t, errors.Ast(err, errors.TypeUnauthenticated))
+
+	// Replaying the rotated token revokes the whole family, including the latest refresh token.
+	_, err = issuer.Refresh(context.Background(), pair.RefreshToken)
+	assert.True(t, errors.Asc(err, ErrCodeRefreshTokenReused))
+
+	_, err = issuer.Refresh(context.Background(), refreshed.RefreshToken)
+	assert.True(t, errors.Asc(err, ErrCodeRefreshTokenReused))
+}
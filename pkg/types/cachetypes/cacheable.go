@@ -3,6 +3,7 @@ package cachetypes
 import (
 	"encoding"
 	"reflect"
+	"time"
 
 	"github.com/SigNoz/signoz/pkg/errors"
 )
@@ -31,3 +32,11 @@ func ValidatePointer(dest any, caller string) error {
 	}
 	return nil
 }
+
+// Item is a cacheable entity along with the ttl with which it should be cached.
+type Item struct {
+	// Data is the cacheable entity.
+	Data Cacheable
+	// TTL is the time to live of the entity in cache.
+	TTL time.Duration
+}
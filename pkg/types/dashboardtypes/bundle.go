@@ -0,0 +1,190 @@
+package dashboardtypes
+
+import (
+	"encoding/json"
+	"slices"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/valuer"
+)
+
+const (
+	// BundleVersionV1 is the version of the bundles written by this release. Bundles of a newer version are
+	// rejected, bundles of an older version are upgraded on import.
+	BundleVersionV1 int = 1
+	BundleVersion   int = BundleVersionV1
+)
+
+var (
+	ErrCodeBundleVersionUnsupported = errors.MustNewCode("dashboard_bundle_version_unsupported")
+)
+
+var (
+	// ConflictStrategySkip leaves the existing dashboard untouched.
+	ConflictStrategySkip = ConflictStrategy{valuer.NewString("skip")}
+	// ConflictStrategyOverwrite replaces the data of the existing dashboard.
+	ConflictStrategyOverwrite = ConflictStrategy{valuer.NewString("overwrite")}
+	// ConflictStrategyRename imports the dashboard as a new dashboard next to the existing one.
+	ConflictStrategyRename = ConflictStrategy{valuer.NewString("rename")}
+)
+
+var (
+	ImportStatusCreated     = ImportStatus{valuer.NewString("created")}
+	ImportStatusSkipped     = ImportStatus{valuer.NewString("skipped")}
+	ImportStatusOverwritten = ImportStatus{valuer.NewString("overwritten")}
+	ImportStatusRenamed     = ImportStatus{valuer.NewString("renamed")}
+	ImportStatusFailed      = ImportStatus{valuer.NewString("failed")}
+)
+
+// datasourceKeys are the keys of the dashboard data holding a reference to a datasource.
+var datasourceKeys = []string{"dataSource", "datasource"}
+
+type ConflictStrategy struct{ valuer.String }
+
+type ImportStatus struct{ valuer.String }
+
+// Bundle is the versioned serialization of a set of dashboards, used to move them between instances.
+type Bundle struct {
+	Version    int               `json:"version"`
+	ExportedAt time.Time         `json:"exportedAt"`
+	Dashboards []BundleDashboard `json:"dashboards"`
+}
+
+// BundleDashboard is a dashboard of a bundle, its data holds the variables and the panels.
+type BundleDashboard struct {
+	ID   string                `json:"id"`
+	Data StorableDashboardData `json:"data"`
+}
+
+type PostableExport struct {
+	// IDs are the ids of the dashboards to export, all the dashboards of the organization are exported when empty.
+	IDs []valuer.UUID `json:"ids"`
+}
+
+type PostableImport struct {
+	Bundle   Bundle           `json:"bundle"`
+	Strategy ConflictStrategy `json:"strategy"`
+	// Datasources remaps the datasources referenced by the dashboards, from the name in the bundle to the name in
+	// this instance.
+	Datasources map[string]string `json:"datasources"`
+}
+
+// ImportResult is the outcome of the import of a single dashboard of a bundle. A failed dashboard can be imported
+// again on its own, the other dashboards of the bundle are not affected by its failure.
+type ImportResult struct {
+	SourceID string       `json:"sourceId"`
+	ID       string       `json:"id,omitempty"`
+	Title    string       `json:"title,omitempty"`
+	Status   ImportStatus `json:"status"`
+	Error    string       `json:"error,omitempty"`
+}
+
+type GettableImport struct {
+	Results []*ImportResult `json:"results"`
+}
+
+func NewBundle(dashboards []*Dashboard) *Bundle {
+	bundleDashboards := make([]BundleDashboard, len(dashboards))
+	for idx, dashboard := range dashboards {
+		bundleDashboards[idx] = BundleDashboard{ID: dashboard.ID, Data: dashboard.Data}
+	}
+
+	return &Bundle{
+		Version:    BundleVersion,
+		ExportedAt: time.Now(),
+		Dashboards: bundleDashboards,
+	}
+}
+
+func (bundle *Bundle) Validate() error {
+	if bundle.Version <= 0 {
+		return errors.New(errors.TypeInvalidInput, errors.CodeInvalidInput, "version is missing in the bundle")
+	}
+
+	if bundle.Version > BundleVersion {
+		return errors.Newf(errors.TypeInvalidInput, ErrCodeBundleVersionUnsupported, "bundle version %d is not supported, the latest supported version is %d", bundle.Version, BundleVersion)
+	}
+
+	if len(bundle.Dashboards) == 0 {
+		return errors.New(errors.TypeInvalidInput, errors.CodeInvalidInput, "bundle has no dashboards")
+	}
+
+	seen := make(map[string]struct{}, len(bundle.Dashboards))
+	for idx, dashboard := range bundle.Dashboards {
+		if _, err := valuer.NewUUID(dashboard.ID); err != nil {
+			return errors.Wrapf(err, errors.TypeInvalidInput, errors.CodeInvalidInput, "id of dashboard %d is not a valid uuid", idx)
+		}
+
+		if _, ok := seen[dashboard.ID]; ok {
+			return errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "dashboard %s appears more than once in the bundle", dashboard.ID)
+		}
+		seen[dashboard.ID] = struct{}{}
+
+		if dashboard.Data == nil {
+			return errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "data of dashboard %s is missing", dashboard.ID)
+		}
+	}
+
+	return nil
+}
+
+func (postable *PostableImport) UnmarshalJSON(data []byte) error {
+	type Alias PostableImport
+
+	var temp Alias
+	if err := json.Unmarshal(data, &temp); err != nil {
+		return err
+	}
+
+	if temp.Strategy.IsZero() {
+		temp.Strategy = ConflictStrategySkip
+	}
+
+	if !slices.Contains([]ConflictStrategy{ConflictStrategySkip, ConflictStrategyOverwrite, ConflictStrategyRename}, temp.Strategy) {
+		return errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "strategy must be one of skip, overwrite or rename, got %q", temp.Strategy.StringValue())
+	}
+
+	if err := temp.Bundle.Validate(); err != nil {
+		return err
+	}
+
+	*postable = PostableImport(temp)
+	return nil
+}
+
+// RemapDatasources replaces the datasources referenced anywhere in the data, in the panels as well as in the
+// variables, according to the given mapping.
+func (storableDashboardData StorableDashboardData) RemapDatasources(datasources map[string]string) {
+	if len(datasources) == 0 {
+		return
+	}
+
+	remapDatasources(map[string]interface{}(storableDashboardData), datasources)
+}
+
+// Title returns the title of the dashboard, empty when it has none.
+func (storableDashboardData StorableDashboardData) Title() string {
+	title, _ := storableDashboardData["title"].(string)
+	return title
+}
+
+func remapDatasources(value interface{}, datasources map[string]string) {
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		for key, child := range typed {
+			if name, ok := child.(string); ok && slices.Contains(datasourceKeys, key) {
+				if remapped, ok := datasources[name]; ok {
+					typed[key] = remapped
+				}
+				continue
+			}
+
+			remapDatasources(child, datasources)
+		}
+	case []interface{}:
+		for _, child := range typed {
+			remapDatasources(child, datasources)
+		}
+	}
+}
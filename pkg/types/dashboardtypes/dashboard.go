@@ -18,9 +18,14 @@ type StorableDashboard struct {
 	types.Identifiable
 	types.TimeAuditable
 	types.UserAuditable
-	Data   StorableDashboardData `bun:"data,type:text,notnull"`
-	Locked bool                  `bun:"locked,notnull,default:false"`
-	OrgID  valuer.UUID           `bun:"org_id,notnull"`
+	Data       StorableDashboardData `bun:"data,type:text,notnull"`
+	Title      string                `bun:"title,type:text,nullzero"`
+	Locked     bool                  `bun:"locked,notnull,default:false"`
+	OrgID      valuer.UUID           `bun:"org_id,notnull"`
+	DeletedAt  *time.Time            `bun:"deleted_at"`
+	DeletedBy  string                `bun:"deleted_by,type:text,nullzero"`
+	RestoredAt *time.Time            `bun:"restored_at"`
+	RestoredBy string                `bun:"restored_by,type:text,nullzero"`
 }
 
 type Dashboard struct {
@@ -31,6 +36,12 @@ type Dashboard struct {
 	Data   StorableDashboardData `json:"data"`
 	Locked bool                  `json:"locked"`
 	OrgID  valuer.UUID           `json:"org_id"`
+	// DeletedAt and DeletedBy are set once the dashboard is deleted, until it is restored or purged.
+	DeletedAt *time.Time `json:"deletedAt,omitempty"`
+	DeletedBy string     `json:"deletedBy,omitempty"`
+	// RestoredAt and RestoredBy are set once the dashboard is restored after its deletion.
+	RestoredAt *time.Time `json:"restoredAt,omitempty"`
+	RestoredBy string     `json:"restoredBy,omitempty"`
 }
 
 type LockUnlockDashboard struct {
@@ -49,6 +60,12 @@ type (
 	ListableDashboard []*GettableDashboard
 )
 
+// GettableDashboardsPage is a page of the dashboards of an organization.
+type GettableDashboardsPage struct {
+	Dashboards []*GettableDashboard `json:"dashboards"`
+	NextCursor string               `json:"nextCursor,omitempty"`
+}
+
 func NewStorableDashboardFromDashboard(dashboard *Dashboard) (*StorableDashboard, error) {
 	dashboardID, err := valuer.NewUUID(dashboard.ID)
 	if err != nil {
@@ -67,9 +84,14 @@ func NewStorableDashboardFromDashboard(dashboard *Dashboard) (*StorableDashboard
 			CreatedBy: dashboard.CreatedBy,
 			UpdatedBy: dashboard.UpdatedBy,
 		},
-		OrgID:  dashboard.OrgID,
-		Data:   dashboard.Data,
-		Locked: dashboard.Locked,
+		OrgID:      dashboard.OrgID,
+		Data:       dashboard.Data,
+		Title:      dashboard.Data.Title(),
+		Locked:     dashboard.Locked,
+		DeletedAt:  dashboard.DeletedAt,
+		DeletedBy:  dashboard.DeletedBy,
+		RestoredAt: dashboard.RestoredAt,
+		RestoredBy: dashboard.RestoredBy,
 	}, nil
 }
 
@@ -103,9 +125,13 @@ func NewDashboardFromStorableDashboard(storableDashboard *StorableDashboard) (*D
 			CreatedBy: storableDashboard.CreatedBy,
 			UpdatedBy: storableDashboard.UpdatedBy,
 		},
-		OrgID:  storableDashboard.OrgID,
-		Data:   storableDashboard.Data,
-		Locked: storableDashboard.Locked,
+		OrgID:      storableDashboard.OrgID,
+		Data:       storableDashboard.Data,
+		Locked:     storableDashboard.Locked,
+		DeletedAt:  storableDashboard.DeletedAt,
+		DeletedBy:  storableDashboard.DeletedBy,
+		RestoredAt: storableDashboard.RestoredAt,
+		RestoredBy: storableDashboard.RestoredBy,
 	}, nil
 }
 
@@ -142,6 +168,10 @@ func NewGettableDashboardFromDashboard(dashboard *Dashboard) (*GettableDashboard
 		OrgID:         dashboard.OrgID,
 		Data:          dashboard.Data,
 		Locked:        dashboard.Locked,
+		DeletedAt:     dashboard.DeletedAt,
+		DeletedBy:     dashboard.DeletedBy,
+		RestoredAt:    dashboard.RestoredAt,
+		RestoredBy:    dashboard.RestoredBy,
 	}, nil
 }
 
@@ -316,9 +346,36 @@ type Store interface {
 
 	Get(context.Context, valuer.UUID, valuer.UUID) (*StorableDashboard, error)
 
+	// GetByTitle gets the oldest dashboard of the org with the given title.
+	GetByTitle(context.Context, valuer.UUID, string) (*StorableDashboard, error)
+
 	List(context.Context, valuer.UUID) ([]*StorableDashboard, error)
 
+	ListPage(context.Context, valuer.UUID, *types.Pagination) ([]*StorableDashboard, error)
+
 	Update(context.Context, valuer.UUID, *StorableDashboard) error
 
-	Delete(context.Context, valuer.UUID, valuer.UUID) error
+	// Delete soft deletes the dashboard, it is excluded from Get and List until it is restored.
+	Delete(context.Context, valuer.UUID, valuer.UUID, string) error
+
+	ListDeleted(context.Context, valuer.UUID) ([]*StorableDashboard, error)
+
+	Restore(context.Context, valuer.UUID, valuer.UUID, string) error
+
+	// Purge hard deletes the dashboards of all the orgs deleted before the given time and returns their number.
+	Purge(context.Context, time.Time) (int64, error)
+
+	CreatePublicLink(context.Context, *StorablePublicLink) error
+
+	ListPublicLinks(context.Context, valuer.UUID, valuer.UUID) ([]*StorablePublicLink, error)
+
+	// GetPublicLinkByTokenHash gets the link of any org by the hash of its token, whether it is revoked or not.
+	GetPublicLinkByTokenHash(context.Context, string) (*StorablePublicLink, error)
+
+	// RevokePublicLink revokes the link of the dashboard and returns it, it fails if the link is already revoked.
+	RevokePublicLink(context.Context, valuer.UUID, valuer.UUID, valuer.UUID, string) (*StorablePublicLink, error)
+
+	CreatePublicLinkAuditEvent(context.Context, *StorablePublicLinkAuditEvent) error
+
+	ListPublicLinkAuditEvents(context.Context, valuer.UUID, valuer.UUID) ([]*StorablePublicLinkAuditEvent, error)
 }
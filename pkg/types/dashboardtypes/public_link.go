@@ -0,0 +1,211 @@
+package dashboardtypes
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/types"
+	"github.com/SigNoz/signoz/pkg/valuer"
+	"github.com/uptrace/bun"
+)
+
+const (
+	// PublicLinkPasswordHeader is the header holding the password of the public links protected by one.
+	PublicLinkPasswordHeader string = "X-SigNoz-Public-Link-Password"
+
+	publicLinkTokenPrefixLength int = 8
+)
+
+var (
+	ErrCodePublicLinkInvalid          = errors.MustNewCode("dashboard_public_link_invalid")
+	ErrCodePublicLinkPasswordRequired = errors.MustNewCode("dashboard_public_link_password_required")
+	ErrCodePublicLinkPasswordAttempts = errors.MustNewCode("dashboard_public_link_password_attempts")
+)
+
+var (
+	PublicLinkAuditEventTypeCreated = PublicLinkAuditEventType{valuer.NewString("created")}
+	PublicLinkAuditEventTypeRevoked = PublicLinkAuditEventType{valuer.NewString("revoked")}
+	PublicLinkAuditEventTypeGranted = PublicLinkAuditEventType{valuer.NewString("granted")}
+	PublicLinkAuditEventTypeDenied  = PublicLinkAuditEventType{valuer.NewString("denied")}
+)
+
+type PublicLinkAuditEventType struct {
+	valuer.String
+}
+
+// StorablePublicLink is a link granting a read only access to a dashboard without an account. Only the hash of its
+// token is stored, along with the hash of its password if it has one.
+type StorablePublicLink struct {
+	bun.BaseModel `bun:"table:dashboard_public_link"`
+
+	types.Identifiable
+	types.TimeAuditable
+	types.UserAuditable
+	OrgID        valuer.UUID `bun:"org_id,type:text,notnull"`
+	DashboardID  valuer.UUID `bun:"dashboard_id,type:text,notnull"`
+	TokenHash    string      `bun:"token_hash,type:text,notnull"`
+	TokenPrefix  string      `bun:"token_prefix,type:text,notnull"`
+	PasswordHash string      `bun:"password_hash,type:text,nullzero"`
+	ExpiresAt    *time.Time  `bun:"expires_at"`
+	RevokedAt    *time.Time  `bun:"revoked_at"`
+	RevokedBy    string      `bun:"revoked_by,type:text,nullzero"`
+}
+
+// StorablePublicLinkAuditEvent records the creation and the revocation of a public link, and every access through it.
+type StorablePublicLinkAuditEvent struct {
+	bun.BaseModel `bun:"table:dashboard_public_link_audit"`
+
+	types.Identifiable
+	OrgID       valuer.UUID              `bun:"org_id,type:text,notnull" json:"orgId"`
+	DashboardID valuer.UUID              `bun:"dashboard_id,type:text,notnull" json:"dashboardId"`
+	LinkID      valuer.UUID              `bun:"link_id,type:text,notnull" json:"linkId"`
+	Type        PublicLinkAuditEventType `bun:"type,type:text,notnull" json:"type"`
+	// Actor is the user who created or revoked the link, or the address of the client which accessed it or was locked
+	// out of it.
+	Actor     string    `bun:"actor,type:text,notnull" json:"actor"`
+	CreatedAt time.Time `bun:"created_at,notnull" json:"createdAt"`
+}
+
+type GettablePublicLinkAuditEvent = StorablePublicLinkAuditEvent
+
+type PostablePublicLink struct {
+	// ExpiresAt is the time after which the link is invalid. The link never expires when it is not set.
+	ExpiresAt *time.Time `json:"expiresAt"`
+	// Password is the password required to access the dashboard through the link, if any.
+	Password string `json:"password"`
+}
+
+type GettablePublicLink struct {
+	types.Identifiable
+	types.TimeAuditable
+	types.UserAuditable
+	DashboardID valuer.UUID `json:"dashboardId"`
+	// Token is the plaintext token of the link, it is only returned on the creation of the link.
+	Token       string     `json:"token,omitempty"`
+	TokenPrefix string     `json:"tokenPrefix"`
+	HasPassword bool       `json:"hasPassword"`
+	ExpiresAt   *time.Time `json:"expiresAt,omitempty"`
+	RevokedAt   *time.Time `json:"revokedAt,omitempty"`
+	RevokedBy   string     `json:"revokedBy,omitempty"`
+}
+
+// GettablePublicDashboard is the read only view of a dashboard accessed through a public link, without the details of
+// its authors.
+type GettablePublicDashboard struct {
+	ID   string                `json:"id"`
+	Data StorableDashboardData `json:"data"`
+}
+
+// NewStorablePublicLink returns the link to the dashboard along with its plaintext token, which is not stored.
+func NewStorablePublicLink(orgID valuer.UUID, dashboardID valuer.UUID, createdBy string, postablePublicLink PostablePublicLink) (*StorablePublicLink, string, error) {
+	now := time.Now()
+	if postablePublicLink.ExpiresAt != nil && !postablePublicLink.ExpiresAt.After(now) {
+		return nil, "", errors.New(errors.TypeInvalidInput, errors.CodeInvalidInput, "expiresAt must be in the future")
+	}
+
+	var passwordHash string
+	if postablePublicLink.Password != "" {
+		hash, err := types.HashPassword(postablePublicLink.Password)
+		if err != nil {
+			return nil, "", errors.WrapInternalf(err, errors.CodeInternal, "failed to hash the password of the public link")
+		}
+
+		passwordHash = hash
+	}
+
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return nil, "", errors.WrapInternalf(err, errors.CodeInternal, "failed to generate the token of the public link")
+	}
+	// The token is part of the url of the link.
+	token := base64.RawURLEncoding.EncodeToString(buf)
+
+	return &StorablePublicLink{
+		Identifiable: types.Identifiable{
+			ID: valuer.GenerateUUID(),
+		},
+		TimeAuditable: types.TimeAuditable{
+			CreatedAt: now,
+			UpdatedAt: now,
+		},
+		UserAuditable: types.UserAuditable{
+			CreatedBy: createdBy,
+			UpdatedBy: createdBy,
+		},
+		OrgID:        orgID,
+		DashboardID:  dashboardID,
+		TokenHash:    HashPublicLinkToken(token),
+		TokenPrefix:  token[:publicLinkTokenPrefixLength],
+		PasswordHash: passwordHash,
+		ExpiresAt:    postablePublicLink.ExpiresAt,
+	}, token, nil
+}
+
+func NewGettablePublicLinkFromStorablePublicLink(storablePublicLink *StorablePublicLink, token string) *GettablePublicLink {
+	return &GettablePublicLink{
+		Identifiable:  storablePublicLink.Identifiable,
+		TimeAuditable: storablePublicLink.TimeAuditable,
+		UserAuditable: storablePublicLink.UserAuditable,
+		DashboardID:   storablePublicLink.DashboardID,
+		Token:         token,
+		TokenPrefix:   storablePublicLink.TokenPrefix,
+		HasPassword:   storablePublicLink.PasswordHash != "",
+		ExpiresAt:     storablePublicLink.ExpiresAt,
+		RevokedAt:     storablePublicLink.RevokedAt,
+		RevokedBy:     storablePublicLink.RevokedBy,
+	}
+}
+
+func NewGettablePublicDashboardFromDashboard(dashboard *Dashboard) *GettablePublicDashboard {
+	return &GettablePublicDashboard{ID: dashboard.ID, Data: dashboard.Data}
+}
+
+func NewPublicLinkAuditEvent(typ PublicLinkAuditEventType, actor string, storablePublicLink *StorablePublicLink) *StorablePublicLinkAuditEvent {
+	return &StorablePublicLinkAuditEvent{
+		Identifiable: types.Identifiable{
+			ID: valuer.GenerateUUID(),
+		},
+		OrgID:       storablePublicLink.OrgID,
+		DashboardID: storablePublicLink.DashboardID,
+		LinkID:      storablePublicLink.ID,
+		Type:        typ,
+		Actor:       actor,
+		CreatedAt:   time.Now(),
+	}
+}
+
+// HashPublicLinkToken returns the hash of the token under which a public link is stored.
+func HashPublicLinkToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// Authorize returns an error if the link does not grant the access to its dashboard with the password at the time.
+// The revoked and expired links are reported as invalid, like the unknown ones.
+func (storablePublicLink *StorablePublicLink) Authorize(password string, now time.Time) error {
+	if storablePublicLink.RevokedAt != nil {
+		return errors.New(errors.TypeNotFound, ErrCodePublicLinkInvalid, "public link is revoked")
+	}
+
+	if storablePublicLink.ExpiresAt != nil && !now.Before(*storablePublicLink.ExpiresAt) {
+		return errors.New(errors.TypeNotFound, ErrCodePublicLinkInvalid, "public link is expired")
+	}
+
+	if storablePublicLink.PasswordHash == "" {
+		return nil
+	}
+
+	if password == "" {
+		return errors.Newf(errors.TypeUnauthenticated, ErrCodePublicLinkPasswordRequired, "public link requires a password in the %s header", PublicLinkPasswordHeader)
+	}
+
+	if !types.ComparePassword(storablePublicLink.PasswordHash, password) {
+		return errors.New(errors.TypeUnauthenticated, ErrCodePublicLinkPasswordRequired, "password of the public link is invalid")
+	}
+
+	return nil
+}
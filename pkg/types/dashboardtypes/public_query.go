@@ -0,0 +1,393 @@
+package dashboardtypes
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	qbtypes "github.com/SigNoz/signoz/pkg/types/querybuildertypes/querybuildertypesv5"
+)
+
+var (
+	ErrCodePublicQueryInvalid = errors.MustNewCode("dashboard_public_query_invalid")
+)
+
+var (
+	// publicRequestTypes are the types of the requests of the panels which can be served through a public link, by
+	// the type of the panels.
+	publicRequestTypes = map[string]qbtypes.RequestType{
+		"graph": qbtypes.RequestTypeTimeSeries,
+		"bar":   qbtypes.RequestTypeTimeSeries,
+		"value": qbtypes.RequestTypeScalar,
+		"table": qbtypes.RequestTypeScalar,
+		"pie":   qbtypes.RequestTypeScalar,
+		"list":  qbtypes.RequestTypeRaw,
+	}
+
+	// filterOperators are the operators of the filter expressions by the operators of the filter items of the panels.
+	filterOperators = map[string]string{
+		"=":         "=",
+		"!=":        "!=",
+		">":         ">",
+		">=":        ">=",
+		"<":         "<",
+		"<=":        "<=",
+		"in":        "IN",
+		"nin":       "NOT IN",
+		"like":      "LIKE",
+		"nlike":     "NOT LIKE",
+		"ilike":     "ILIKE",
+		"notilike":  "NOT ILIKE",
+		"contains":  "CONTAINS",
+		"ncontains": "NOT CONTAINS",
+		"regex":     "REGEXP",
+		"nregex":    "NOT REGEXP",
+		"exists":    "EXISTS",
+		"nexists":   "NOT EXISTS",
+	}
+)
+
+// PostablePublicQuery is the time range of the queries of a widget run through a public link. The queries are the
+// ones of the widget as they are stored, hence the caller only chooses the time range and the step.
+type PostablePublicQuery struct {
+	// Start is the start time of the queries in epoch milliseconds.
+	Start uint64 `json:"start"`
+	// End is the end time of the queries in epoch milliseconds.
+	End uint64 `json:"end"`
+	// Step is the step of the queries, the step of the queries of the widget is used when it is not set.
+	Step qbtypes.Step `json:"step"`
+}
+
+// UnmarshalJSON rejects any field other than the time range and the step, so that a caller sending a query of its
+// own is told that it is not run.
+func (postable *PostablePublicQuery) UnmarshalJSON(data []byte) error {
+	type shadow PostablePublicQuery
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+
+	var temp shadow
+	if err := decoder.Decode(&temp); err != nil {
+		return errors.Wrapf(err, errors.TypeInvalidInput, ErrCodePublicQueryInvalid, "only start, end and step can be set on the queries run through a public link")
+	}
+
+	if temp.End == 0 || temp.Start >= temp.End {
+		return errors.New(errors.TypeInvalidInput, ErrCodePublicQueryInvalid, "start must be before end")
+	}
+
+	if temp.Step.Duration < 0 {
+		return errors.New(errors.TypeInvalidInput, ErrCodePublicQueryInvalid, "step must not be negative")
+	}
+
+	*postable = PostablePublicQuery(temp)
+	return nil
+}
+
+// NewPublicQueryRangeRequest returns the request running the queries of the widget as they are stored over the time
+// range, so that a public link only reads the data shown by the panels of its dashboard.
+func (dashboard *Dashboard) NewPublicQueryRangeRequest(widgetID string, postable PostablePublicQuery) (*qbtypes.QueryRangeRequest, error) {
+	widget, ok := dashboard.Data.widget(widgetID)
+	if !ok {
+		return nil, errors.Newf(errors.TypeNotFound, ErrCodePublicQueryInvalid, "widget %s doesn't exist in the dashboard", widgetID)
+	}
+
+	panelType, _ := widget["panelTypes"].(string)
+	requestType, ok := publicRequestTypes[panelType]
+	if !ok {
+		return nil, errors.Newf(errors.TypeInvalidInput, ErrCodePublicQueryInvalid, "panels of type %q cannot be served through a public link", panelType)
+	}
+
+	query, ok := widget["query"].(map[string]interface{})
+	if !ok {
+		return nil, errors.Newf(errors.TypeInvalidInput, ErrCodePublicQueryInvalid, "widget %s has no query", widgetID)
+	}
+
+	var specs []map[string]interface{}
+	queryType, _ := query["queryType"].(string)
+	switch queryType {
+	case "builder":
+		builder, _ := query["builder"].(map[string]interface{})
+		for _, item := range objects(builder["queryData"]) {
+			spec, err := newBuilderSpec(item, postable.Step)
+			if err != nil {
+				return nil, err
+			}
+
+			specs = append(specs, spec)
+		}
+
+		for _, item := range objects(builder["queryFormulas"]) {
+			specs = append(specs, map[string]interface{}{
+				"type": qbtypes.QueryTypeFormula.StringValue(),
+				"spec": map[string]interface{}{"name": item["queryName"], "expression": item["expression"]},
+			})
+		}
+	case "promql":
+		for _, item := range objects(query["promql"]) {
+			if text, _ := item["query"].(string); strings.TrimSpace(text) == "" {
+				continue
+			}
+
+			spec := map[string]interface{}{"name": item["name"], "query": item["query"], "disabled": item["disabled"] == true}
+			if postable.Step.Duration > 0 {
+				spec["step"] = postable.Step
+			}
+
+			specs = append(specs, map[string]interface{}{"type": qbtypes.QueryTypePromQL.StringValue(), "spec": spec})
+		}
+	case "clickhouse_sql":
+		for _, item := range objects(query["clickhouse_sql"]) {
+			if text, _ := item["query"].(string); strings.TrimSpace(text) == "" {
+				continue
+			}
+
+			specs = append(specs, map[string]interface{}{
+				"type": qbtypes.QueryTypeClickHouseSQL.StringValue(),
+				"spec": map[string]interface{}{"name": item["name"], "query": item["query"], "disabled": item["disabled"] == true},
+			})
+		}
+	default:
+		return nil, errors.Newf(errors.TypeInvalidInput, ErrCodePublicQueryInvalid, "queries of type %q cannot be served through a public link", queryType)
+	}
+
+	if len(specs) == 0 {
+		return nil, errors.Newf(errors.TypeInvalidInput, ErrCodePublicQueryInvalid, "widget %s has no query", widgetID)
+	}
+
+	envelopes := make([]qbtypes.QueryEnvelope, len(specs))
+	for i, spec := range specs {
+		data, err := json.Marshal(spec)
+		if err != nil {
+			return nil, errors.Wrapf(err, errors.TypeInvalidInput, ErrCodePublicQueryInvalid, "query of widget %s is invalid", widgetID)
+		}
+
+		if err := json.Unmarshal(data, &envelopes[i]); err != nil {
+			return nil, errors.Wrapf(err, errors.TypeInvalidInput, ErrCodePublicQueryInvalid, "query of widget %s is invalid", widgetID)
+		}
+	}
+
+	return &qbtypes.QueryRangeRequest{
+		SchemaVersion:  "v1",
+		Start:          postable.Start,
+		End:            postable.End,
+		RequestType:    requestType,
+		CompositeQuery: qbtypes.CompositeQuery{Queries: envelopes},
+	}, nil
+}
+
+func (data StorableDashboardData) widget(widgetID string) (map[string]interface{}, bool) {
+	for _, widget := range objects(data["widgets"]) {
+		if id, _ := widget["id"].(string); id != "" && id == widgetID {
+			return widget, true
+		}
+	}
+
+	return nil, false
+}
+
+// newBuilderSpec returns the envelope of the builder query of a panel. The queries saved with the filter items and
+// the aggregate operators of the previous versions of the query builder are translated to expressions.
+func newBuilderSpec(query map[string]interface{}, step qbtypes.Step) (map[string]interface{}, error) {
+	name, _ := query["queryName"].(string)
+	signal, _ := query["dataSource"].(string)
+
+	spec := map[string]interface{}{
+		"name":     name,
+		"signal":   signal,
+		"disabled": query["disabled"] == true,
+	}
+
+	if step.Duration > 0 {
+		spec["stepInterval"] = step
+	} else if stepInterval, ok := query["stepInterval"].(float64); ok && stepInterval > 0 {
+		spec["stepInterval"] = stepInterval
+	}
+
+	aggregations, err := newAggregations(query, signal)
+	if err != nil {
+		return nil, err
+	}
+	if len(aggregations) > 0 {
+		spec["aggregations"] = aggregations
+	}
+
+	expression, err := newFilterExpression(query)
+	if err != nil {
+		return nil, err
+	}
+	if expression != "" {
+		spec["filter"] = map[string]interface{}{"expression": expression}
+	}
+
+	groupBy := []map[string]interface{}{}
+	for _, item := range objects(query["groupBy"]) {
+		if key := fieldName(item); key != "" {
+			groupBy = append(groupBy, map[string]interface{}{"name": key})
+		}
+	}
+	if len(groupBy) > 0 {
+		spec["groupBy"] = groupBy
+	}
+
+	order := []map[string]interface{}{}
+	for _, item := range objects(query["orderBy"]) {
+		column, _ := item["columnName"].(string)
+		direction, _ := item["order"].(string)
+		if column != "" {
+			order = append(order, map[string]interface{}{"key": map[string]interface{}{"name": column}, "direction": strings.ToLower(direction)})
+		}
+	}
+	if len(order) > 0 {
+		spec["order"] = order
+	}
+
+	if limit, ok := query["limit"].(float64); ok && limit > 0 {
+		spec["limit"] = int(limit)
+	} else if pageSize, ok := query["pageSize"].(float64); ok && pageSize > 0 {
+		spec["limit"] = int(pageSize)
+	}
+
+	if having, ok := query["having"].(map[string]interface{}); ok {
+		if expression, _ := having["expression"].(string); expression != "" {
+			spec["having"] = map[string]interface{}{"expression": expression}
+		}
+	}
+
+	return map[string]interface{}{"type": qbtypes.QueryTypeBuilder.StringValue(), "spec": spec}, nil
+}
+
+func newAggregations(query map[string]interface{}, signal string) ([]map[string]interface{}, error) {
+	aggregations := []map[string]interface{}{}
+	for _, item := range objects(query["aggregations"]) {
+		if signal == "metrics" {
+			aggregations = append(aggregations, map[string]interface{}{
+				"metricName":       item["metricName"],
+				"temporality":      stringOf(item["temporality"]),
+				"timeAggregation":  stringOf(item["timeAggregation"]),
+				"spaceAggregation": stringOf(item["spaceAggregation"]),
+			})
+			continue
+		}
+
+		aggregations = append(aggregations, map[string]interface{}{"expression": item["expression"], "alias": stringOf(item["alias"])})
+	}
+
+	if len(aggregations) > 0 {
+		return aggregations, nil
+	}
+
+	attribute, _ := query["aggregateAttribute"].(map[string]interface{})
+	key, _ := attribute["key"].(string)
+	operator, _ := query["aggregateOperator"].(string)
+
+	if signal == "metrics" {
+		if key == "" {
+			return nil, errors.Newf(errors.TypeInvalidInput, ErrCodePublicQueryInvalid, "query %v has no metric", query["queryName"])
+		}
+
+		return []map[string]interface{}{{
+			"metricName":       key,
+			"timeAggregation":  stringOf(query["timeAggregation"]),
+			"spaceAggregation": stringOf(query["spaceAggregation"]),
+		}}, nil
+	}
+
+	// The lists of the logs and the traces have no aggregation.
+	if operator == "" || operator == "noop" {
+		return nil, nil
+	}
+
+	return []map[string]interface{}{{"expression": operator + "(" + key + ")"}}, nil
+}
+
+func newFilterExpression(query map[string]interface{}) (string, error) {
+	if filter, ok := query["filter"].(map[string]interface{}); ok {
+		if expression, _ := filter["expression"].(string); strings.TrimSpace(expression) != "" {
+			return expression, nil
+		}
+	}
+
+	filters, _ := query["filters"].(map[string]interface{})
+	conjunction := "AND"
+	if op, _ := filters["op"].(string); strings.EqualFold(op, "OR") {
+		conjunction = "OR"
+	}
+
+	clauses := []string{}
+	for _, item := range objects(filters["items"]) {
+		key, _ := item["key"].(map[string]interface{})
+		name := fieldName(key)
+		op, _ := item["op"].(string)
+		operator, ok := filterOperators[strings.ToLower(op)]
+		if name == "" || !ok {
+			return "", errors.Newf(errors.TypeInvalidInput, ErrCodePublicQueryInvalid, "filter %q %q of query %v cannot be served through a public link", name, op, query["queryName"])
+		}
+
+		switch operator {
+		case "EXISTS", "NOT EXISTS":
+			clauses = append(clauses, name+" "+operator)
+		case "IN", "NOT IN":
+			values, ok := item["value"].([]interface{})
+			if !ok {
+				values = []interface{}{item["value"]}
+			}
+
+			literals := make([]string, len(values))
+			for i, value := range values {
+				literals[i] = literal(value)
+			}
+
+			clauses = append(clauses, name+" "+operator+" ("+strings.Join(literals, ", ")+")")
+		default:
+			clauses = append(clauses, name+" "+operator+" "+literal(item["value"]))
+		}
+	}
+
+	return strings.Join(clauses, " "+conjunction+" "), nil
+}
+
+// fieldName returns the name of the field of the key of a panel, saved as name by the current query builder and as
+// key by the previous versions.
+func fieldName(key map[string]interface{}) string {
+	if name, _ := key["name"].(string); name != "" {
+		return name
+	}
+
+	name, _ := key["key"].(string)
+	return name
+}
+
+func literal(value interface{}) string {
+	switch value := value.(type) {
+	case string:
+		return "'" + strings.ReplaceAll(value, "'", `\'`) + "'"
+	case nil:
+		return "''"
+	default:
+		return fmt.Sprintf("%v", value)
+	}
+}
+
+func stringOf(value interface{}) string {
+	s, _ := value.(string)
+	return s
+}
+
+// objects returns the objects of the value when it is a list.
+func objects(value interface{}) []map[string]interface{} {
+	items, ok := value.([]interface{})
+	if !ok {
+		return nil
+	}
+
+	result := make([]map[string]interface{}, 0, len(items))
+	for _, item := range items {
+		if object, ok := item.(map[string]interface{}); ok {
+			result = append(result, object)
+		}
+	}
+
+	return result
+}
@@ -28,6 +28,7 @@ type SSOType string
 const (
 	SAML       SSOType = "SAML"
 	GoogleAuth SSOType = "GOOGLE_AUTH"
+	OIDC       SSOType = "OIDC"
 )
 
 // GettableOrgDomain identify org owned web domains for auth and other purposes
@@ -39,6 +40,11 @@ type GettableOrgDomain struct {
 
 	SamlConfig       *ssotypes.SamlConfig        `json:"samlConfig"`
 	GoogleAuthConfig *ssotypes.GoogleOAuthConfig `json:"googleAuthConfig"`
+	OIDCConfig       *ssotypes.OIDCConfig        `json:"oidcConfig,omitempty"`
+
+	// ClaimsMapping maps the claims of the id tokens of the google and the OIDC providers to the users, the default
+	// mapping is used when it is nil.
+	ClaimsMapping *ClaimsMapping `json:"claimsMapping,omitempty"`
 
 	Org *Organization
 }
@@ -58,7 +64,7 @@ func (od *GettableOrgDomain) Valid(err error) error {
 		return fmt.Errorf("both id and orgId are required")
 	}
 
-	return nil
+	return od.validSSOConfig()
 }
 
 // ValidNew cheks if the org domain is valid for insertion in db
@@ -72,6 +78,40 @@ func (od *GettableOrgDomain) ValidNew() error {
 		return fmt.Errorf("name is required")
 	}
 
+	return od.validSSOConfig()
+}
+
+func (od *GettableOrgDomain) validSSOConfig() error {
+	if err := od.validSamlConfig(); err != nil {
+		return err
+	}
+
+	if od.SsoType == OIDC && (od.OIDCConfig == nil || od.OIDCConfig.IssuerURL == "" || od.OIDCConfig.ClientID == "") {
+		return fmt.Errorf("oidcConfig with issuerUrl and clientId is required for OIDC")
+	}
+
+	if od.ClaimsMapping != nil {
+		return od.ClaimsMapping.Validate()
+	}
+
+	return nil
+}
+
+func (od *GettableOrgDomain) validSamlConfig() error {
+	if od.SamlConfig == nil {
+		return nil
+	}
+
+	if od.SamlConfig.RoleAttribute == "" && len(od.SamlConfig.RoleMapping) > 0 {
+		return fmt.Errorf("roleAttribute is required with roleMapping")
+	}
+
+	for value, role := range od.SamlConfig.RoleMapping {
+		if _, err := NewRole(role); err != nil {
+			return fmt.Errorf("invalid role %q for %q in roleMapping", role, value)
+		}
+	}
+
 	return nil
 }
 
@@ -109,6 +149,41 @@ func (od *GettableOrgDomain) GetSAMLCert() string {
 	return ""
 }
 
+// GetSAMLRole returns the role mapped from the values of the role attribute of an assertion, the most privileged
+// one when several values are mapped. It returns an empty role when none of the values are mapped.
+func (od *GettableOrgDomain) GetSAMLRole(values saml2.Values) Role {
+	if od.SamlConfig == nil || od.SamlConfig.RoleAttribute == "" {
+		return ""
+	}
+
+	mapped := map[Role]struct{}{}
+	for _, value := range values.GetAll(od.SamlConfig.RoleAttribute) {
+		role, err := NewRole(od.SamlConfig.RoleMapping[value])
+		if err != nil {
+			continue
+		}
+
+		mapped[role] = struct{}{}
+	}
+
+	for _, role := range []Role{RoleAdmin, RoleEditor, RoleViewer} {
+		if _, ok := mapped[role]; ok {
+			return role
+		}
+	}
+
+	return ""
+}
+
+// GetClaimsMapping returns the mapping of the claims of the id tokens to the users of the domain.
+func (od *GettableOrgDomain) GetClaimsMapping() ClaimsMapping {
+	if od.ClaimsMapping == nil {
+		return NewClaimsMapping()
+	}
+
+	return *od.ClaimsMapping
+}
+
 // PrepareGoogleOAuthProvider creates GoogleProvider that is used in
 // requesting OAuth and also used in processing response from google
 func (od *GettableOrgDomain) PrepareGoogleOAuthProvider(siteUrl *url.URL) (ssotypes.OAuthCallbackProvider, error) {
@@ -119,6 +194,16 @@ func (od *GettableOrgDomain) PrepareGoogleOAuthProvider(siteUrl *url.URL) (ssoty
 	return od.GoogleAuthConfig.GetProvider(od.Name, siteUrl)
 }
 
+// PrepareOIDCProvider creates the provider requesting the login of the users from the OIDC provider of the domain
+// and processing its response.
+func (od *GettableOrgDomain) PrepareOIDCProvider(siteUrl *url.URL) (ssotypes.OAuthCallbackProvider, error) {
+	if od.OIDCConfig == nil {
+		return nil, fmt.Errorf("OIDC is not setup correctly for this domain")
+	}
+
+	return od.OIDCConfig.GetProvider(siteUrl)
+}
+
 // PrepareSamlRequest creates a request accordingly gosaml2
 func (od *GettableOrgDomain) PrepareSamlRequest(siteUrl *url.URL) (*saml2.SAMLServiceProvider, error) {
 
@@ -182,6 +267,14 @@ func (od *GettableOrgDomain) BuildSsoUrl(siteUrl *url.URL) (ssoUrl string, err e
 		}
 		return googleProvider.BuildAuthURL(relayState)
 
+	case OIDC:
+
+		oidcProvider, err := od.PrepareOIDCProvider(siteUrl)
+		if err != nil {
+			return "", err
+		}
+		return oidcProvider.BuildAuthURL(relayState)
+
 	default:
 		return "", fmt.Errorf("unsupported SSO config for the domain")
 	}
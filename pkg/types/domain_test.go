@@ -0,0 +1,79 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/SigNoz/signoz/pkg/types/ssotypes"
+	"github.com/google/uuid"
+	saml2 "github.com/russellhaering/gosaml2"
+	saml2types "github.com/russellhaering/gosaml2/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func newSamlValues(name string, values ...string) saml2.Values {
+	attribute := saml2types.Attribute{Name: name}
+	for _, value := range values {
+		attribute.Values = append(attribute.Values, saml2types.AttributeValue{Value: value})
+	}
+
+	return saml2.Values{name: attribute}
+}
+
+func TestGetSAMLRole(t *testing.T) {
+	domain := &GettableOrgDomain{
+		SamlConfig: &ssotypes.SamlConfig{
+			RoleAttribute: "groups",
+			RoleMapping: map[string]string{
+				"admins":  "ADMIN",
+				"editors": "EDITOR",
+				"viewers": "VIEWER",
+			},
+		},
+	}
+
+	testCases := []struct {
+		name     string
+		values   saml2.Values
+		expected Role
+	}{
+		{name: "Mapped", values: newSamlValues("groups", "editors"), expected: RoleEditor},
+		{name: "MostPrivileged", values: newSamlValues("groups", "viewers", "admins", "editors"), expected: RoleAdmin},
+		{name: "NotMapped", values: newSamlValues("groups", "others"), expected: ""},
+		{name: "MissingAttribute", values: newSamlValues("roles", "admins"), expected: ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, domain.GetSAMLRole(tc.values))
+		})
+	}
+
+	assert.Equal(t, Role(""), (&GettableOrgDomain{SamlConfig: &ssotypes.SamlConfig{}}).GetSAMLRole(newSamlValues("groups", "admins")))
+}
+
+func TestValidSamlConfig(t *testing.T) {
+	newDomain := func(config *ssotypes.SamlConfig) *GettableOrgDomain {
+		return &GettableOrgDomain{StorableOrgDomain: StorableOrgDomain{ID: uuid.New(), OrgID: "orgId", Name: "example.com"}, SamlConfig: config}
+	}
+
+	assert.NoError(t, newDomain(nil).ValidNew())
+	assert.NoError(t, newDomain(&ssotypes.SamlConfig{RoleAttribute: "groups", RoleMapping: map[string]string{"admins": "ADMIN"}}).ValidNew())
+	assert.Error(t, newDomain(&ssotypes.SamlConfig{RoleMapping: map[string]string{"admins": "ADMIN"}}).ValidNew())
+	assert.Error(t, newDomain(&ssotypes.SamlConfig{RoleAttribute: "groups", RoleMapping: map[string]string{"admins": "OWNER"}}).Valid(nil))
+}
+
+func TestValidOIDCConfig(t *testing.T) {
+	newDomain := func(config *ssotypes.OIDCConfig, mapping *ClaimsMapping) *GettableOrgDomain {
+		return &GettableOrgDomain{StorableOrgDomain: StorableOrgDomain{ID: uuid.New(), OrgID: "orgId", Name: "example.com"}, SsoType: OIDC, OIDCConfig: config, ClaimsMapping: mapping}
+	}
+
+	config := &ssotypes.OIDCConfig{IssuerURL: "https://example.okta.com", ClientID: "clientId", ClientSecret: "clientSecret"}
+	assert.NoError(t, newDomain(config, nil).ValidNew())
+	assert.NoError(t, newDomain(config, &ClaimsMapping{EmailClaim: "email", EmailVerifiedClaim: "email_verified", GroupsClaim: "groups", RoleMapping: []RoleMappingRule{{Group: "admins", Role: "ADMIN"}}}).ValidNew())
+	assert.Error(t, newDomain(nil, nil).ValidNew())
+	assert.Error(t, newDomain(&ssotypes.OIDCConfig{ClientID: "clientId"}, nil).ValidNew())
+	assert.Error(t, newDomain(config, &ClaimsMapping{EmailClaim: "email"}).Valid(nil))
+
+	// The default mapping is used when the domain has none.
+	assert.Equal(t, NewClaimsMapping(), newDomain(config, nil).GetClaimsMapping())
+}
@@ -3,12 +3,27 @@ package emailtypes
 import (
 	"bytes"
 	"context"
+	"html"
 	"html/template"
+	"strings"
 
 	"github.com/SigNoz/signoz/pkg/errors"
 	"github.com/SigNoz/signoz/pkg/valuer"
 )
 
+var (
+	ErrCodeEmailTemplateNotFound     = errors.MustNewCode("email_template_not_found")
+	ErrCodeEmailTemplateRenderFailed = errors.MustNewCode("email_template_render_failed")
+)
+
+const (
+	// LayoutTemplateName is the name of the optional base layout shared by all the templates. Templates can render
+	// the layout with {{template "layout" .}} after defining the blocks used by it.
+	LayoutTemplateName = "layout"
+	// SubjectTemplateName is the name of the block which templates can define to render the subject of the email.
+	SubjectTemplateName = "subject"
+)
+
 var (
 	// Templates is a list of all the templates that are supported by the emailing service.
 	// This list should be updated whenever a new template is added.
@@ -30,16 +45,48 @@ func NewTemplateName(name string) (TemplateName, error) {
 	}
 }
 
-func NewContent(template *template.Template, data map[string]any) ([]byte, error) {
+// Branding contains the overrides which can be passed to templates to customize the look of emails per tenant.
+type Branding struct {
+	// LogoURL is the url of the logo shown in the header of the email.
+	LogoURL string
+	// PrimaryColor is the css color used for the header and buttons of the email.
+	PrimaryColor string
+}
+
+func NewContent(template *template.Template, data any) ([]byte, error) {
 	buf := bytes.NewBuffer(nil)
 	err := template.Execute(buf, data)
 	if err != nil {
-		return nil, errors.WrapInternalf(err, errors.CodeInternal, "failed to execute template")
+		return nil, errors.Wrapf(err, errors.TypeInternal, ErrCodeEmailTemplateRenderFailed, "failed to execute template %q", template.Name())
+	}
+
+	if len(bytes.TrimSpace(buf.Bytes())) == 0 {
+		return nil, errors.Newf(errors.TypeInternal, ErrCodeEmailTemplateRenderFailed, "template %q rendered an empty body", template.Name())
 	}
 
 	return buf.Bytes(), nil
 }
 
+// NewSubject renders the subject block defined by the template.
+func NewSubject(template *template.Template, data any) (string, error) {
+	subjectTemplate := template.Lookup(SubjectTemplateName)
+	if subjectTemplate == nil {
+		return "", errors.Newf(errors.TypeNotFound, ErrCodeEmailTemplateNotFound, "template %q does not define a %q block", template.Name(), SubjectTemplateName)
+	}
+
+	buf := bytes.NewBuffer(nil)
+	if err := subjectTemplate.Execute(buf, data); err != nil {
+		return "", errors.Wrapf(err, errors.TypeInternal, ErrCodeEmailTemplateRenderFailed, "failed to execute subject of template %q", template.Name())
+	}
+
+	subject := strings.TrimSpace(html.UnescapeString(buf.String()))
+	if subject == "" {
+		return "", errors.Newf(errors.TypeInternal, ErrCodeEmailTemplateRenderFailed, "template %q rendered an empty subject", template.Name())
+	}
+
+	return subject, nil
+}
+
 type TemplateStore interface {
 	Get(context.Context, TemplateName) (*template.Template, error)
 }
@@ -2,7 +2,11 @@ package types
 
 import (
 	"crypto/rand"
+	"crypto/sha256"
 	"encoding/base64"
+	"encoding/hex"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/SigNoz/signoz/pkg/errors"
@@ -12,25 +16,106 @@ import (
 
 var NEVER_EXPIRES = time.Unix(0, 0)
 
+const (
+	// apiKeyTokenPrefixLength is the number of characters of a token kept in plaintext to tell the keys apart.
+	apiKeyTokenPrefixLength int = 8
+)
+
+const (
+	APIKeyScopeActionRead  string = "read"
+	APIKeyScopeActionWrite string = "write"
+)
+
+const (
+	APIKeyScopeResourceDashboards string = "dashboards"
+	APIKeyScopeResourceAlerts     string = "alerts"
+	APIKeyScopeResourceChannels   string = "channels"
+	APIKeyScopeResourceViews      string = "views"
+	APIKeyScopeResourcePipelines  string = "pipelines"
+	APIKeyScopeResourceTelemetry  string = "telemetry"
+)
+
+var (
+	ErrCodeAPIKeyExpired        = errors.MustNewCode("api_key_expired")
+	ErrCodeAPIKeyRevoked        = errors.MustNewCode("api_key_revoked")
+	ErrCodeAPIKeyScopeForbidden = errors.MustNewCode("api_key_scope_forbidden")
+)
+
+var (
+	APIKeyScopeActions   = []string{APIKeyScopeActionRead, APIKeyScopeActionWrite}
+	APIKeyScopeResources = []string{
+		APIKeyScopeResourceDashboards,
+		APIKeyScopeResourceAlerts,
+		APIKeyScopeResourceChannels,
+		APIKeyScopeResourceViews,
+		APIKeyScopeResourcePipelines,
+		APIKeyScopeResourceTelemetry,
+	}
+)
+
+// APIKeyScopes are the scopes of an api key, such as read:dashboards or write:alerts. An api key without scopes is
+// restricted by its role only.
+type APIKeyScopes []string
+
+// NewAPIKeyScopes validates and deduplicates the given scopes.
+func NewAPIKeyScopes(scopes []string) (APIKeyScopes, error) {
+	result := make(APIKeyScopes, 0, len(scopes))
+	for _, scope := range scopes {
+		action, resource, ok := strings.Cut(scope, ":")
+		if !ok || !slices.Contains(APIKeyScopeActions, action) || !slices.Contains(APIKeyScopeResources, resource) {
+			return nil, errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "invalid scope %q, scopes must be one of %s:<%s>", scope, strings.Join(APIKeyScopeActions, "|"), strings.Join(APIKeyScopeResources, "|"))
+		}
+
+		if !slices.Contains(result, scope) {
+			result = append(result, scope)
+		}
+	}
+
+	return result, nil
+}
+
+// Allows returns true if the scopes grant the action on the resource. The write scope of a resource grants its read.
+func (scopes APIKeyScopes) Allows(action string, resource string) bool {
+	if len(scopes) == 0 {
+		return true
+	}
+
+	if slices.Contains(scopes, APIKeyScopeActionWrite+":"+resource) {
+		return true
+	}
+
+	return action == APIKeyScopeActionRead && slices.Contains(scopes, APIKeyScopeActionRead+":"+resource)
+}
+
+// HashAPIKeyToken returns the hash of the token under which an api key is stored.
+func HashAPIKeyToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
 type PostableAPIKey struct {
-	Name          string `json:"name"`
-	Role          Role   `json:"role"`
-	ExpiresInDays int64  `json:"expiresInDays"`
+	Name          string   `json:"name"`
+	Role          Role     `json:"role"`
+	ExpiresInDays int64    `json:"expiresInDays"`
+	Scopes        []string `json:"scopes"`
 }
 
 type GettableAPIKey struct {
 	Identifiable
 	TimeAuditable
 	UserAuditable
-	Token         string `json:"token"`
-	Role          Role   `json:"role"`
-	Name          string `json:"name"`
-	ExpiresAt     int64  `json:"expiresAt"`
-	LastUsed      int64  `json:"lastUsed"`
-	Revoked       bool   `json:"revoked"`
-	UserID        string `json:"userId"`
-	CreatedByUser *User  `json:"createdByUser"`
-	UpdatedByUser *User  `json:"updatedByUser"`
+	// Token is the plaintext token, it is only returned on the creation of the api key.
+	Token         string       `json:"token,omitempty"`
+	TokenPrefix   string       `json:"tokenPrefix"`
+	Role          Role         `json:"role"`
+	Name          string       `json:"name"`
+	Scopes        APIKeyScopes `json:"scopes"`
+	ExpiresAt     int64        `json:"expiresAt"`
+	LastUsed      int64        `json:"lastUsed"`
+	Revoked       bool         `json:"revoked"`
+	UserID        string       `json:"userId"`
+	CreatedByUser *User        `json:"createdByUser"`
+	UpdatedByUser *User        `json:"updatedByUser"`
 }
 
 type OrgUserAPIKey struct {
@@ -56,29 +141,39 @@ type StorableAPIKey struct {
 	Identifiable
 	TimeAuditable
 	UserAuditable
-	Token     string      `json:"token" bun:"token,type:text,notnull,unique"`
-	Role      Role        `json:"role" bun:"role,type:text,notnull,default:'ADMIN'"`
-	Name      string      `json:"name" bun:"name,type:text,notnull"`
-	ExpiresAt time.Time   `json:"-" bun:"expires_at,notnull,nullzero,type:timestamptz"`
-	LastUsed  time.Time   `json:"-" bun:"last_used,notnull,nullzero,type:timestamptz"`
-	Revoked   bool        `json:"revoked" bun:"revoked,notnull,default:false"`
-	UserID    valuer.UUID `json:"userId" bun:"user_id,type:text,notnull"`
+	// Token is the hash of the token, the plaintext token is never stored.
+	Token       string       `json:"-" bun:"token,type:text,notnull,unique"`
+	TokenPrefix string       `json:"tokenPrefix" bun:"token_prefix,type:text"`
+	Role        Role         `json:"role" bun:"role,type:text,notnull,default:'ADMIN'"`
+	Name        string       `json:"name" bun:"name,type:text,notnull"`
+	Scopes      APIKeyScopes `json:"scopes" bun:"scopes,type:text,nullzero"`
+	ExpiresAt   time.Time    `json:"-" bun:"expires_at,notnull,nullzero,type:timestamptz"`
+	LastUsed    time.Time    `json:"-" bun:"last_used,notnull,nullzero,type:timestamptz"`
+	Revoked     bool         `json:"revoked" bun:"revoked,notnull,default:false"`
+	UserID      valuer.UUID  `json:"userId" bun:"user_id,type:text,notnull"`
 }
 
-func NewStorableAPIKey(name string, userID valuer.UUID, role Role, expiresAt int64) (*StorableAPIKey, error) {
+// NewStorableAPIKey returns the api key along with its plaintext token, which is not stored and must be handed to
+// the caller right away.
+func NewStorableAPIKey(name string, userID valuer.UUID, role Role, expiresAt int64, scopes []string) (*StorableAPIKey, string, error) {
 	// validate
 
 	// we allow the APIKey if expiresAt is not set, which means it never expires
 	if expiresAt < 0 {
-		return nil, errors.New(errors.TypeInvalidInput, errors.CodeInvalidInput, "expiresAt must be greater than 0")
+		return nil, "", errors.New(errors.TypeInvalidInput, errors.CodeInvalidInput, "expiresAt must be greater than 0")
 	}
 
 	if name == "" {
-		return nil, errors.New(errors.TypeInvalidInput, errors.CodeInvalidInput, "name cannot be empty")
+		return nil, "", errors.New(errors.TypeInvalidInput, errors.CodeInvalidInput, "name cannot be empty")
 	}
 
 	if role == "" {
-		return nil, errors.New(errors.TypeInvalidInput, errors.CodeInvalidInput, "role cannot be empty")
+		return nil, "", errors.New(errors.TypeInvalidInput, errors.CodeInvalidInput, "role cannot be empty")
+	}
+
+	apiKeyScopes, err := NewAPIKeyScopes(scopes)
+	if err != nil {
+		return nil, "", err
 	}
 
 	now := time.Now()
@@ -93,9 +188,9 @@ func NewStorableAPIKey(name string, userID valuer.UUID, role Role, expiresAt int
 
 	// Generate a 32-byte random token.
 	token := make([]byte, 32)
-	_, err := rand.Read(token)
+	_, err = rand.Read(token)
 	if err != nil {
-		return nil, errors.New(errors.TypeInternal, errors.CodeInternal, "failed to generate token")
+		return nil, "", errors.New(errors.TypeInternal, errors.CodeInternal, "failed to generate token")
 	}
 	// Encode the token in base64.
 	encodedToken := base64.StdEncoding.EncodeToString(token)
@@ -112,14 +207,21 @@ func NewStorableAPIKey(name string, userID valuer.UUID, role Role, expiresAt int
 			CreatedBy: userID.String(),
 			UpdatedBy: userID.String(),
 		},
-		Token:     encodedToken,
-		Name:      name,
-		Role:      role,
-		UserID:    userID,
-		ExpiresAt: expiresAtTime,
-		LastUsed:  now,
-		Revoked:   false,
-	}, nil
+		Token:       HashAPIKeyToken(encodedToken),
+		TokenPrefix: encodedToken[:apiKeyTokenPrefixLength],
+		Name:        name,
+		Role:        role,
+		Scopes:      apiKeyScopes,
+		UserID:      userID,
+		ExpiresAt:   expiresAtTime,
+		LastUsed:    now,
+		Revoked:     false,
+	}, encodedToken, nil
+}
+
+// Expired returns true if the api key has an expiry which is before now.
+func (apiKey *StorableAPIKey) Expired(now time.Time) bool {
+	return !apiKey.ExpiresAt.Equal(NEVER_EXPIRES) && apiKey.ExpiresAt.Before(now)
 }
 
 func NewGettableAPIKeyFromStorableAPIKey(storableAPIKey *StorableAPIKeyUser) *GettableAPIKey {
@@ -131,9 +233,10 @@ func NewGettableAPIKeyFromStorableAPIKey(storableAPIKey *StorableAPIKeyUser) *Ge
 		Identifiable:  storableAPIKey.Identifiable,
 		TimeAuditable: storableAPIKey.TimeAuditable,
 		UserAuditable: storableAPIKey.UserAuditable,
-		Token:         storableAPIKey.Token,
+		TokenPrefix:   storableAPIKey.TokenPrefix,
 		Role:          storableAPIKey.Role,
 		Name:          storableAPIKey.Name,
+		Scopes:        storableAPIKey.Scopes,
 		ExpiresAt:     storableAPIKey.ExpiresAt.Unix(),
 		LastUsed:      lastUsed,
 		Revoked:       storableAPIKey.Revoked,
@@ -0,0 +1,51 @@
+package types
+
+import (
+	"testing"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/valuer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewAPIKeyScopes(t *testing.T) {
+	scopes, err := NewAPIKeyScopes([]string{"read:dashboards", "write:alerts", "read:dashboards"})
+	require.NoError(t, err)
+	assert.Equal(t, APIKeyScopes{"read:dashboards", "write:alerts"}, scopes)
+
+	for _, scope := range []string{"dashboards", "delete:dashboards", "read:users", "read:"} {
+		_, err := NewAPIKeyScopes([]string{scope})
+		assert.Error(t, err, scope)
+	}
+}
+
+func TestAPIKeyScopesAllows(t *testing.T) {
+	scopes := APIKeyScopes{"read:dashboards", "write:alerts"}
+
+	assert.True(t, scopes.Allows(APIKeyScopeActionRead, APIKeyScopeResourceDashboards))
+	assert.False(t, scopes.Allows(APIKeyScopeActionWrite, APIKeyScopeResourceDashboards))
+	assert.True(t, scopes.Allows(APIKeyScopeActionRead, APIKeyScopeResourceAlerts))
+	assert.True(t, scopes.Allows(APIKeyScopeActionWrite, APIKeyScopeResourceAlerts))
+	assert.False(t, scopes.Allows(APIKeyScopeActionRead, APIKeyScopeResourceChannels))
+	assert.True(t, APIKeyScopes{}.Allows(APIKeyScopeActionWrite, APIKeyScopeResourceChannels))
+}
+
+func TestNewStorableAPIKey(t *testing.T) {
+	apiKey, token, err := NewStorableAPIKey("ci", valuer.GenerateUUID(), RoleViewer, 1, []string{"read:telemetry"})
+	require.NoError(t, err)
+
+	assert.Equal(t, HashAPIKeyToken(token), apiKey.Token)
+	assert.NotEqual(t, token, apiKey.Token)
+	assert.Equal(t, token[:apiKeyTokenPrefixLength], apiKey.TokenPrefix)
+	assert.Equal(t, APIKeyScopes{"read:telemetry"}, apiKey.Scopes)
+	assert.False(t, apiKey.Expired(time.Now()))
+	assert.True(t, apiKey.Expired(time.Now().AddDate(0, 0, 2)))
+
+	apiKey, _, err = NewStorableAPIKey("ci", valuer.GenerateUUID(), RoleViewer, 0, nil)
+	require.NoError(t, err)
+	assert.False(t, apiKey.Expired(time.Now().AddDate(100, 0, 0)))
+
+	_, _, err = NewStorableAPIKey("ci", valuer.GenerateUUID(), RoleViewer, 0, []string{"admin"})
+	assert.Error(t, err)
+}
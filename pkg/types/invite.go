@@ -86,6 +86,27 @@ type PostableBulkInviteRequest struct {
 	Invites []PostableInvite `json:"invites"`
 }
 
+type InviteStatus struct {
+	valuer.String
+}
+
+var (
+	InviteStatusInvited        = InviteStatus{valuer.NewString("invited")}
+	InviteStatusInvalid        = InviteStatus{valuer.NewString("invalid")}
+	InviteStatusDuplicate      = InviteStatus{valuer.NewString("duplicate")}
+	InviteStatusAlreadyMember  = InviteStatus{valuer.NewString("already_member")}
+	InviteStatusAlreadyInvited = InviteStatus{valuer.NewString("already_invited")}
+)
+
+// GettableBulkInviteStatus is the outcome of the invite of one email of a bulk invite. ID is only set when the
+// email was invited and Error explains why it was not otherwise. The token of the invite is only sent by email.
+type GettableBulkInviteStatus struct {
+	Email  string       `json:"email"`
+	Status InviteStatus `json:"status"`
+	Error  string       `json:"error,omitempty"`
+	ID     string       `json:"id,omitempty"`
+}
+
 type GettableCreateInviteResponse struct {
 	InviteToken string `json:"token"`
 }
@@ -0,0 +1,151 @@
+package licensetypes
+
+import (
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/types"
+	"github.com/SigNoz/signoz/pkg/valuer"
+	"github.com/uptrace/bun"
+)
+
+const (
+	// AuditActorSystem is the actor of the audit events which are not triggered by a user.
+	AuditActorSystem string = "system"
+
+	DefaultAuditEventsLimit int = 20
+	MaxAuditEventsLimit     int = 100
+)
+
+var (
+	AuditEventTypeApplied   = AuditEventType{valuer.NewString("applied")}
+	AuditEventTypeRefreshed = AuditEventType{valuer.NewString("refreshed")}
+	AuditEventTypeExpired   = AuditEventType{valuer.NewString("expired")}
+	// AuditEventTypeOverridden is the type of the audit events of the overrides of the feature flags.
+	AuditEventTypeOverridden = AuditEventType{valuer.NewString("overridden")}
+)
+
+type AuditEventType struct {
+	valuer.String
+}
+
+type StorableAuditEvent struct {
+	bun.BaseModel `bun:"table:license_audit"`
+
+	types.Identifiable
+	Type            AuditEventType `bun:"type,type:text,notnull" json:"type"`
+	Actor           string         `bun:"actor,type:text,notnull" json:"actor"`
+	OldLicenseID    string         `bun:"old_license_id,type:text" json:"oldLicenseId"`
+	NewLicenseID    string         `bun:"new_license_id,type:text" json:"newLicenseId"`
+	AddedFeatures   []string       `bun:"added_features,type:text" json:"addedFeatures"`
+	RemovedFeatures []string       `bun:"removed_features,type:text" json:"removedFeatures"`
+	CreatedAt       time.Time      `bun:"created_at,notnull" json:"createdAt"`
+	OrgID           valuer.UUID    `bun:"org_id,type:text,notnull" json:"orgId"`
+}
+
+type GettableAuditEvent = StorableAuditEvent
+
+// NewAuditEvent creates an audit event for the transition of the license from previous to current. Any of previous or current can be nil,
+// in which case the corresponding license ID is left empty and all of the features of the other are part of the delta.
+func NewAuditEvent(typ AuditEventType, actor string, organizationID valuer.UUID, previous *License, current *License) *StorableAuditEvent {
+	event := &StorableAuditEvent{
+		Identifiable: types.Identifiable{
+			ID: valuer.GenerateUUID(),
+		},
+		Type:            typ,
+		Actor:           actor,
+		AddedFeatures:   []string{},
+		RemovedFeatures: []string{},
+		CreatedAt:       time.Now(),
+		OrgID:           organizationID,
+	}
+
+	var previousFeatures, currentFeatures []*Feature
+	if previous != nil {
+		event.OldLicenseID = previous.ID.StringValue()
+		previousFeatures = previous.Features
+	}
+
+	if current != nil {
+		event.NewLicenseID = current.ID.StringValue()
+		currentFeatures = current.Features
+	}
+
+	event.AddedFeatures, event.RemovedFeatures = featureDelta(previousFeatures, currentFeatures)
+	return event
+}
+
+// NewOverrideAuditEvent creates an audit event for the override of the feature flags of org, changing them from
+// previous to current.
+func NewOverrideAuditEvent(actor string, organizationID valuer.UUID, previous []*Feature, current []*Feature) *StorableAuditEvent {
+	added, removed := featureDelta(previous, current)
+
+	return &StorableAuditEvent{
+		Identifiable: types.Identifiable{
+			ID: valuer.GenerateUUID(),
+		},
+		Type:            AuditEventTypeOverridden,
+		Actor:           actor,
+		AddedFeatures:   added,
+		RemovedFeatures: removed,
+		CreatedAt:       time.Now(),
+		OrgID:           organizationID,
+	}
+}
+
+// Changed reports whether current differs from previous in any way which is worth an audit event: the license itself, its plan,
+// its status, its validity or its active features. The timestamps of the validation are not considered.
+func Changed(previous *License, current *License) bool {
+	if previous == nil || current == nil {
+		return previous != current
+	}
+
+	if previous.ID != current.ID ||
+		previous.PlanName != current.PlanName ||
+		previous.Status != current.Status ||
+		previous.ValidFrom != current.ValidFrom ||
+		previous.ValidUntil != current.ValidUntil {
+		return true
+	}
+
+	added, removed := featureDelta(previous.Features, current.Features)
+	return len(added) > 0 || len(removed) > 0
+}
+
+// featureDelta returns the names of the features which are active in current but not in previous and vice versa.
+func featureDelta(previous []*Feature, current []*Feature) ([]string, []string) {
+	previousActive := activeFeatures(previous)
+	currentActive := activeFeatures(current)
+
+	added := []string{}
+	for _, feature := range current {
+		if _, ok := currentActive[feature.Name.StringValue()]; !ok {
+			continue
+		}
+		if _, ok := previousActive[feature.Name.StringValue()]; !ok {
+			added = append(added, feature.Name.StringValue())
+		}
+	}
+
+	removed := []string{}
+	for _, feature := range previous {
+		if _, ok := previousActive[feature.Name.StringValue()]; !ok {
+			continue
+		}
+		if _, ok := currentActive[feature.Name.StringValue()]; !ok {
+			removed = append(removed, feature.Name.StringValue())
+		}
+	}
+
+	return added, removed
+}
+
+func activeFeatures(features []*Feature) map[string]struct{} {
+	active := make(map[string]struct{}, len(features))
+	for _, feature := range features {
+		if feature.Active {
+			active[feature.Name.StringValue()] = struct{}{}
+		}
+	}
+
+	return active
+}
@@ -0,0 +1,97 @@
+package licensetypes
+
+import (
+	"testing"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/valuer"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewAuditEvent(t *testing.T) {
+	organizationID := valuer.GenerateUUID()
+
+	previous := &License{
+		ID: valuer.GenerateUUID(),
+		Features: []*Feature{
+			{Name: SSO, Active: true},
+			{Name: Onboarding, Active: true},
+			{Name: ChatSupport, Active: false},
+		},
+	}
+
+	current := &License{
+		ID: valuer.GenerateUUID(),
+		Features: []*Feature{
+			{Name: SSO, Active: true},
+			{Name: Onboarding, Active: false},
+			{Name: ChatSupport, Active: true},
+		},
+	}
+
+	t.Run("Transition", func(t *testing.T) {
+		event := NewAuditEvent(AuditEventTypeApplied, "admin@signoz.io", organizationID, previous, current)
+
+		assert.Equal(t, AuditEventTypeApplied, event.Type)
+		assert.Equal(t, "admin@signoz.io", event.Actor)
+		assert.Equal(t, previous.ID.StringValue(), event.OldLicenseID)
+		assert.Equal(t, current.ID.StringValue(), event.NewLicenseID)
+		assert.Equal(t, []string{ChatSupport.StringValue()}, event.AddedFeatures)
+		assert.Equal(t, []string{Onboarding.StringValue()}, event.RemovedFeatures)
+		assert.Equal(t, organizationID, event.OrgID)
+		assert.False(t, event.CreatedAt.IsZero())
+	})
+
+	t.Run("NoPreviousLicense", func(t *testing.T) {
+		event := NewAuditEvent(AuditEventTypeApplied, AuditActorSystem, organizationID, nil, current)
+
+		assert.Empty(t, event.OldLicenseID)
+		assert.ElementsMatch(t, []string{SSO.StringValue(), ChatSupport.StringValue()}, event.AddedFeatures)
+		assert.Empty(t, event.RemovedFeatures)
+	})
+}
+
+func TestChanged(t *testing.T) {
+	license := &License{
+		ID:         valuer.GenerateUUID(),
+		PlanName:   PlanNameEnterprise,
+		ValidUntil: -1,
+		Features: []*Feature{
+			{Name: SSO, Active: true},
+			{Name: Onboarding, Active: false},
+		},
+	}
+
+	t.Run("Unchanged", func(t *testing.T) {
+		current := *license
+		current.Features = []*Feature{
+			{Name: SSO, Active: true},
+			{Name: Onboarding, Active: false},
+		}
+		current.LastValidatedAt = license.LastValidatedAt.Add(time.Hour)
+
+		assert.False(t, Changed(license, &current))
+	})
+
+	t.Run("ValidUntil", func(t *testing.T) {
+		current := *license
+		current.ValidUntil = 1
+
+		assert.True(t, Changed(license, &current))
+	})
+
+	t.Run("Features", func(t *testing.T) {
+		current := *license
+		current.Features = []*Feature{
+			{Name: SSO, Active: true},
+			{Name: Onboarding, Active: true},
+		}
+
+		assert.True(t, Changed(license, &current))
+	})
+
+	t.Run("Nil", func(t *testing.T) {
+		assert.True(t, Changed(nil, license))
+		assert.False(t, Changed(nil, nil))
+	})
+}
@@ -179,11 +179,11 @@ func NewLicense(data []byte, organizationID valuer.UUID) (*License, error) {
 
 	switch planName {
 	case PlanNameEnterprise:
-		features = append(features, EnterprisePlan...)
+		features = append(features, CloneFeatures(EnterprisePlan)...)
 	case PlanNameBasic:
-		features = append(features, BasicPlan...)
+		features = append(features, CloneFeatures(BasicPlan)...)
 	default:
-		features = append(features, BasicPlan...)
+		features = append(features, CloneFeatures(BasicPlan)...)
 	}
 
 	if len(featuresFromZeus) > 0 {
@@ -270,11 +270,11 @@ func NewLicenseFromStorableLicense(storableLicense *StorableLicense) (*License,
 
 	switch planName {
 	case PlanNameEnterprise:
-		features = append(features, EnterprisePlan...)
+		features = append(features, CloneFeatures(EnterprisePlan)...)
 	case PlanNameBasic:
-		features = append(features, BasicPlan...)
+		features = append(features, CloneFeatures(BasicPlan)...)
 	default:
-		features = append(features, BasicPlan...)
+		features = append(features, CloneFeatures(BasicPlan)...)
 	}
 
 	if len(featuresFromZeus) > 0 {
@@ -387,4 +387,7 @@ type Store interface {
 	Get(context.Context, valuer.UUID, valuer.UUID) (*StorableLicense, error)
 	GetAll(context.Context, valuer.UUID) ([]*StorableLicense, error)
 	Update(context.Context, valuer.UUID, *StorableLicense) error
+	CreateAuditEvent(context.Context, *StorableAuditEvent) error
+	// ListAuditEvents lists the audit events of the organization, most recent first.
+	ListAuditEvents(context.Context, valuer.UUID, int, int) ([]*StorableAuditEvent, error)
 }
@@ -172,3 +172,11 @@ func TestNewLicenseV3(t *testing.T) {
 
 	}
 }
+
+func TestCloneFeatures(t *testing.T) {
+	cloned := CloneFeatures(BasicPlan)
+	cloned[0].Active = !cloned[0].Active
+
+	assert.NotEqual(t, BasicPlan[0].Active, cloned[0].Active)
+	assert.Equal(t, BasicPlan[0].Name, cloned[0].Name)
+}
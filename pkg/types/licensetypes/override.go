@@ -0,0 +1,81 @@
+package licensetypes
+
+import (
+	"encoding/json"
+	"slices"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/valuer"
+	"github.com/uptrace/bun"
+)
+
+// FeatureOverride forces a feature flag on or off regardless of the license.
+type FeatureOverride struct {
+	Name   string `json:"name"`
+	Active bool   `json:"active"`
+}
+
+type PostableFeatureOverride = FeatureOverride
+
+type GettableFeatureOverride = FeatureOverride
+
+// StorableFeatureOverride is an override of a feature flag of an organization set through the API.
+type StorableFeatureOverride struct {
+	bun.BaseModel `bun:"table:feature_override"`
+
+	OrgID     valuer.UUID `bun:"org_id,pk,type:text"`
+	Name      string      `bun:"name,pk,type:text"`
+	Active    bool        `bun:"active,notnull"`
+	UpdatedAt time.Time   `bun:"updated_at,notnull"`
+}
+
+func (override *FeatureOverride) UnmarshalJSON(data []byte) error {
+	type Alias FeatureOverride
+
+	var temp Alias
+	if err := json.Unmarshal(data, &temp); err != nil {
+		return err
+	}
+
+	if temp.Name == "" {
+		return errors.New(errors.TypeInvalidInput, errors.CodeInvalidInput, "name of the overridden feature is missing")
+	}
+
+	*override = FeatureOverride(temp)
+	return nil
+}
+
+// ApplyFeatureOverrides returns a copy of the features with the overrides applied. The overridden features which are
+// not part of the features are added to them.
+func ApplyFeatureOverrides(features []*Feature, overrides map[string]bool) []*Feature {
+	if len(overrides) == 0 {
+		return CloneFeatures(features)
+	}
+
+	overridden := make([]*Feature, 0, len(features)+len(overrides))
+	applied := make(map[string]struct{}, len(overrides))
+	for _, feature := range features {
+		feature := *feature
+		if active, ok := overrides[feature.Name.StringValue()]; ok {
+			feature.Active = active
+			applied[feature.Name.StringValue()] = struct{}{}
+		}
+
+		overridden = append(overridden, &feature)
+	}
+
+	names := make([]string, 0, len(overrides))
+	for name := range overrides {
+		if _, ok := applied[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+
+	for _, name := range names {
+		overridden = append(overridden, &Feature{Name: valuer.NewString(name), Active: overrides[name]})
+	}
+
+	return overridden
+}
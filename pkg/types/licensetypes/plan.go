@@ -29,6 +29,22 @@ type Feature struct {
 	Route      string        `json:"route"`
 }
 
+// CloneFeatures returns a deep copy of the features, so that the callers mutating them do not change the features
+// they were copied from, such as the features of the plans.
+func CloneFeatures(features []*Feature) []*Feature {
+	cloned := make([]*Feature, len(features))
+	for i, feature := range features {
+		if feature == nil {
+			continue
+		}
+
+		clone := *feature
+		cloned[i] = &clone
+	}
+
+	return cloned
+}
+
 var BasicPlan = []*Feature{
 	{
 		Name:       SSO,
@@ -0,0 +1,200 @@
+package types
+
+import (
+	"path"
+	"strings"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+)
+
+var (
+	ErrCodeOIDCUserDenied = errors.MustNewCode("oidc_user_denied")
+)
+
+// ClaimsMapping maps the claims of the id tokens of the OIDC provider of an auth domain to the users of signoz, as the
+// providers put the same information in different claims. A claim is looked up by its name, then as a path of nested
+// claims separated by dots such as realm_access.roles.
+type ClaimsMapping struct {
+	// EmailClaim is the claim holding the email of the user.
+	EmailClaim string `json:"emailClaim"`
+
+	// EmailVerifiedClaim is the claim which is true when the provider verified the email of the user. The users whose
+	// email is not verified are denied.
+	EmailVerifiedClaim string `json:"emailVerifiedClaim"`
+
+	// NameClaim is the claim holding the display name of the user.
+	NameClaim string `json:"nameClaim"`
+
+	// GroupsClaim is the claim holding the groups or roles of the user, for example groups for Okta, roles for Azure
+	// AD and realm_access.roles for Keycloak. The role of the user is not mapped when it is empty.
+	GroupsClaim string `json:"groupsClaim,omitempty"`
+
+	// RoleMapping are the rules mapping the groups of the user to the roles of signoz. The most privileged role of the
+	// rules matching one of the groups is given to the user.
+	RoleMapping []RoleMappingRule `json:"roleMapping,omitempty"`
+
+	// DefaultRole is the role of the users none of whose groups are mapped. Such users are denied when it is empty.
+	DefaultRole string `json:"defaultRole,omitempty"`
+}
+
+type RoleMappingRule struct {
+	// Group is the group matched by the rule, either a name or a pattern such as signoz-*.
+	Group string `json:"group"`
+
+	// Role is the role of signoz given to the members of the group.
+	Role string `json:"role"`
+}
+
+// OIDCUser is the user mapped from the claims of an id token.
+type OIDCUser struct {
+	Email  string
+	Name   string
+	Groups []string
+	// Role is the role mapped from the groups, it is empty when the groups are not mapped.
+	Role Role
+}
+
+func NewClaimsMapping() ClaimsMapping {
+	return ClaimsMapping{
+		EmailClaim:         "email",
+		EmailVerifiedClaim: "email_verified",
+		NameClaim:          "name",
+		GroupsClaim:        "",
+		RoleMapping:        []RoleMappingRule{},
+		DefaultRole:        RoleViewer.String(),
+	}
+}
+
+func (mapping ClaimsMapping) Validate() error {
+	if mapping.EmailClaim == "" {
+		return errors.New(errors.TypeInvalidInput, errors.CodeInvalidInput, "claimsMapping.emailClaim must not be empty")
+	}
+
+	if mapping.EmailVerifiedClaim == "" {
+		return errors.New(errors.TypeInvalidInput, errors.CodeInvalidInput, "claimsMapping.emailVerifiedClaim must not be empty")
+	}
+
+	if mapping.GroupsClaim == "" && len(mapping.RoleMapping) > 0 {
+		return errors.New(errors.TypeInvalidInput, errors.CodeInvalidInput, "claimsMapping.groupsClaim is required with claimsMapping.roleMapping")
+	}
+
+	for i, rule := range mapping.RoleMapping {
+		if _, err := path.Match(rule.Group, ""); rule.Group == "" || err != nil {
+			return errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "claimsMapping.roleMapping[%d].group %q is not a valid group or pattern", i, rule.Group)
+		}
+
+		if _, err := NewRole(rule.Role); err != nil {
+			return errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "claimsMapping.roleMapping[%d].role %q is not a valid role", i, rule.Role)
+		}
+	}
+
+	if mapping.DefaultRole != "" {
+		if _, err := NewRole(mapping.DefaultRole); err != nil {
+			return errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "claimsMapping.defaultRole %q is not a valid role", mapping.DefaultRole)
+		}
+	}
+
+	return nil
+}
+
+// Map maps the claims of an id token to a user of the auth domain. The user is denied when its email is not verified
+// or does not belong to the domain, and when the groups are mapped but none of them matches a rule and there is no
+// default role.
+func (mapping ClaimsMapping) Map(claims map[string]any, domain string) (*OIDCUser, error) {
+	email, _ := lookupClaim(claims, mapping.EmailClaim).(string)
+	if email == "" {
+		return nil, errors.Newf(errors.TypeUnauthenticated, errors.CodeUnauthenticated, "the id token is missing the email claim %q", mapping.EmailClaim)
+	}
+
+	if !isClaimTrue(lookupClaim(claims, mapping.EmailVerifiedClaim)) {
+		return nil, errors.Newf(errors.TypeForbidden, ErrCodeOIDCUserDenied, "the email %s is not verified by the provider", email)
+	}
+
+	if !strings.HasSuffix(strings.ToLower(email), "@"+strings.ToLower(domain)) {
+		return nil, errors.Newf(errors.TypeForbidden, ErrCodeOIDCUserDenied, "the email %s does not belong to the domain %s", email, domain)
+	}
+
+	user := &OIDCUser{Email: email}
+	if mapping.NameClaim != "" {
+		user.Name, _ = lookupClaim(claims, mapping.NameClaim).(string)
+	}
+
+	if mapping.GroupsClaim == "" {
+		return user, nil
+	}
+
+	switch groups := lookupClaim(claims, mapping.GroupsClaim).(type) {
+	case string:
+		user.Groups = []string{groups}
+	case []any:
+		for _, group := range groups {
+			if group, ok := group.(string); ok {
+				user.Groups = append(user.Groups, group)
+			}
+		}
+	}
+
+	user.Role = mapping.role(user.Groups)
+	if user.Role != "" {
+		return user, nil
+	}
+
+	if mapping.DefaultRole == "" {
+		return nil, errors.Newf(errors.TypeForbidden, ErrCodeOIDCUserDenied, "none of the groups of %s are mapped to a role", email)
+	}
+
+	user.Role, _ = NewRole(mapping.DefaultRole)
+	return user, nil
+}
+
+// role returns the most privileged role of the rules matching one of the groups, or an empty role.
+func (mapping ClaimsMapping) role(groups []string) Role {
+	mapped := map[Role]struct{}{}
+	for _, rule := range mapping.RoleMapping {
+		for _, group := range groups {
+			if ok, _ := path.Match(rule.Group, group); ok {
+				role, _ := NewRole(rule.Role)
+				mapped[role] = struct{}{}
+			}
+		}
+	}
+
+	for _, role := range []Role{RoleAdmin, RoleEditor, RoleViewer} {
+		if _, ok := mapped[role]; ok {
+			return role
+		}
+	}
+
+	return ""
+}
+
+// lookupClaim returns the claim of the name, or the nested claim of the path of the name when there is no such claim.
+func lookupClaim(claims map[string]any, name string) any {
+	if value, ok := claims[name]; ok {
+		return value
+	}
+
+	var value any = claims
+	for _, part := range strings.Split(name, ".") {
+		nested, ok := value.(map[string]any)
+		if !ok {
+			return nil
+		}
+
+		value = nested[part]
+	}
+
+	return value
+}
+
+// isClaimTrue returns whether the claim is true, some providers sending the booleans as strings.
+func isClaimTrue(value any) bool {
+	switch value := value.(type) {
+	case bool:
+		return value
+	case string:
+		return strings.EqualFold(value, "true")
+	default:
+		return false
+	}
+}
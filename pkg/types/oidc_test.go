@@ -0,0 +1,164 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClaimsMappingMap(t *testing.T) {
+	testCases := []struct {
+		name     string
+		mapping  ClaimsMapping
+		claims   map[string]any
+		expected *OIDCUser
+	}{
+		{
+			name:     "Default",
+			mapping:  NewClaimsMapping(),
+			claims:   map[string]any{"email": "john@acme.com", "email_verified": true, "name": "John Doe", "groups": []any{"admins"}},
+			expected: &OIDCUser{Email: "john@acme.com", Name: "John Doe"},
+		},
+		{
+			name: "Okta",
+			mapping: ClaimsMapping{
+				EmailClaim:         "email",
+				EmailVerifiedClaim: "email_verified",
+				NameClaim:          "name",
+				GroupsClaim:        "groups",
+				RoleMapping:        []RoleMappingRule{{Group: "signoz-admins", Role: "ADMIN"}, {Group: "signoz-*", Role: "EDITOR"}},
+				DefaultRole:        "VIEWER",
+			},
+			claims:   map[string]any{"email": "john@acme.com", "email_verified": true, "name": "John Doe", "groups": []any{"signoz-editors", "signoz-admins"}},
+			expected: &OIDCUser{Email: "john@acme.com", Name: "John Doe", Groups: []string{"signoz-editors", "signoz-admins"}, Role: RoleAdmin},
+		},
+		{
+			name: "AzureAD",
+			mapping: ClaimsMapping{
+				EmailClaim:         "preferred_username",
+				EmailVerifiedClaim: "email_verified",
+				NameClaim:          "name",
+				GroupsClaim:        "roles",
+				RoleMapping:        []RoleMappingRule{{Group: "SigNoz.Editor", Role: "EDITOR"}},
+				DefaultRole:        "VIEWER",
+			},
+			claims:   map[string]any{"preferred_username": "john@acme.com", "email_verified": "true", "roles": "SigNoz.Editor"},
+			expected: &OIDCUser{Email: "john@acme.com", Groups: []string{"SigNoz.Editor"}, Role: RoleEditor},
+		},
+		{
+			name: "Keycloak",
+			mapping: ClaimsMapping{
+				EmailClaim:         "email",
+				EmailVerifiedClaim: "email_verified",
+				NameClaim:          "preferred_username",
+				GroupsClaim:        "realm_access.roles",
+				RoleMapping:        []RoleMappingRule{{Group: "admin", Role: "ADMIN"}},
+				DefaultRole:        "VIEWER",
+			},
+			claims:   map[string]any{"email": "john@acme.com", "email_verified": true, "preferred_username": "john", "realm_access": map[string]any{"roles": []any{"offline_access", "admin"}}},
+			expected: &OIDCUser{Email: "john@acme.com", Name: "john", Groups: []string{"offline_access", "admin"}, Role: RoleAdmin},
+		},
+		{
+			name: "Unmapped",
+			mapping: ClaimsMapping{
+				EmailClaim:         "email",
+				EmailVerifiedClaim: "email_verified",
+				GroupsClaim:        "groups",
+				RoleMapping:        []RoleMappingRule{{Group: "admins", Role: "ADMIN"}},
+				DefaultRole:        "EDITOR",
+			},
+			claims:   map[string]any{"email": "john@acme.com", "email_verified": true, "groups": []any{"developers"}},
+			expected: &OIDCUser{Email: "john@acme.com", Groups: []string{"developers"}, Role: RoleEditor},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			user, err := tc.mapping.Map(tc.claims, "acme.com")
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, user)
+		})
+	}
+}
+
+func TestClaimsMappingMapDenied(t *testing.T) {
+	mapping := ClaimsMapping{
+		EmailClaim:         "email",
+		EmailVerifiedClaim: "email_verified",
+		GroupsClaim:        "groups",
+		RoleMapping:        []RoleMappingRule{{Group: "admins", Role: "ADMIN"}},
+	}
+
+	testCases := []struct {
+		name    string
+		mapping ClaimsMapping
+		claims  map[string]any
+	}{
+		{name: "GroupsNotMapped", mapping: mapping, claims: map[string]any{"email": "john@acme.com", "email_verified": true, "groups": []any{"developers"}}},
+		{name: "NoGroups", mapping: mapping, claims: map[string]any{"email": "john@acme.com", "email_verified": true}},
+		{name: "EmailNotVerified", mapping: NewClaimsMapping(), claims: map[string]any{"email": "john@acme.com", "email_verified": false}},
+		{name: "EmailVerifiedMissing", mapping: NewClaimsMapping(), claims: map[string]any{"email": "john@acme.com"}},
+		{name: "EmailOfOtherDomain", mapping: NewClaimsMapping(), claims: map[string]any{"email": "john@other.com", "email_verified": true}},
+		{name: "EmailOfLookalikeDomain", mapping: NewClaimsMapping(), claims: map[string]any{"email": "john@evil-acme.com", "email_verified": true}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := tc.mapping.Map(tc.claims, "acme.com")
+			require.Error(t, err)
+			assert.True(t, errors.Asc(err, ErrCodeOIDCUserDenied))
+		})
+	}
+}
+
+func TestClaimsMappingMapMissingEmail(t *testing.T) {
+	_, err := NewClaimsMapping().Map(map[string]any{"name": "John Doe"}, "acme.com")
+	require.Error(t, err)
+	assert.True(t, errors.Ast(err, errors.TypeUnauthenticated))
+}
+
+func TestClaimsMappingValidate(t *testing.T) {
+	testCases := []struct {
+		name    string
+		mapping func(*ClaimsMapping)
+		pass    bool
+	}{
+		{name: "Default", mapping: func(*ClaimsMapping) {}, pass: true},
+		{name: "NoDefaultRole", mapping: func(m *ClaimsMapping) { m.DefaultRole = "" }, pass: true},
+		{name: "EmptyEmailClaim", mapping: func(m *ClaimsMapping) { m.EmailClaim = "" }, pass: false},
+		{name: "EmptyEmailVerifiedClaim", mapping: func(m *ClaimsMapping) { m.EmailVerifiedClaim = "" }, pass: false},
+		{name: "InvalidDefaultRole", mapping: func(m *ClaimsMapping) { m.DefaultRole = "OWNER" }, pass: false},
+		{name: "RulesWithoutGroupsClaim", mapping: func(m *ClaimsMapping) {
+			m.RoleMapping = []RoleMappingRule{{Group: "admins", Role: "ADMIN"}}
+		}, pass: false},
+		{name: "InvalidRuleRole", mapping: func(m *ClaimsMapping) {
+			m.GroupsClaim = "groups"
+			m.RoleMapping = []RoleMappingRule{{Group: "admins", Role: "admin"}}
+		}, pass: false},
+		{name: "InvalidRulePattern", mapping: func(m *ClaimsMapping) {
+			m.GroupsClaim = "groups"
+			m.RoleMapping = []RoleMappingRule{{Group: "admins[", Role: "ADMIN"}}
+		}, pass: false},
+		{name: "EmptyRuleGroup", mapping: func(m *ClaimsMapping) {
+			m.GroupsClaim = "groups"
+			m.RoleMapping = []RoleMappingRule{{Group: "", Role: "ADMIN"}}
+		}, pass: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mapping := NewClaimsMapping()
+			tc.mapping(&mapping)
+
+			err := mapping.Validate()
+			if tc.pass {
+				assert.NoError(t, err)
+				return
+			}
+
+			assert.Error(t, err)
+		})
+	}
+}
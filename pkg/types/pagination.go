@@ -0,0 +1,112 @@
+package types
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/valuer"
+	"github.com/uptrace/bun"
+)
+
+const (
+	// DefaultPaginationLimit is the limit of a page when only the cursor is given.
+	DefaultPaginationLimit = 100
+	// MaxPaginationLimit is the maximum limit of a page.
+	MaxPaginationLimit = 1000
+)
+
+var (
+	ErrCodePaginationInvalid = errors.MustNewCode("pagination_invalid")
+)
+
+// Pagination selects a page of a list ordered by creation time and id. The cursor points at the last item of the
+// previous page, so the items created meanwhile never shift the following pages.
+type Pagination struct {
+	Limit  int
+	Cursor *Cursor
+}
+
+// Cursor is the position of an item in a list ordered by creation time and id.
+type Cursor struct {
+	CreatedAt time.Time   `json:"c"`
+	ID        valuer.UUID `json:"i"`
+}
+
+// NewPaginationFromRequest returns the pagination of the limit and cursor query parameters of the request. It
+// returns nil when neither is given, in which case the whole list is returned.
+func NewPaginationFromRequest(req *http.Request) (*Pagination, error) {
+	query := req.URL.Query()
+	if !query.Has("limit") && !query.Has("cursor") {
+		return nil, nil
+	}
+
+	pagination := &Pagination{Limit: DefaultPaginationLimit}
+	if query.Has("limit") {
+		limit, err := strconv.Atoi(query.Get("limit"))
+		if err != nil || limit <= 0 || limit > MaxPaginationLimit {
+			return nil, errors.Newf(errors.TypeInvalidInput, ErrCodePaginationInvalid, "limit must be an integer between 1 and %d", MaxPaginationLimit)
+		}
+
+		pagination.Limit = limit
+	}
+
+	if query.Get("cursor") != "" {
+		cursor, err := NewCursorFromString(query.Get("cursor"))
+		if err != nil {
+			return nil, err
+		}
+
+		pagination.Cursor = cursor
+	}
+
+	return pagination, nil
+}
+
+func NewCursorFromString(input string) (*Cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(input)
+	if err != nil {
+		return nil, errors.New(errors.TypeInvalidInput, ErrCodePaginationInvalid, "cursor is invalid")
+	}
+
+	cursor := new(Cursor)
+	if err := json.Unmarshal(data, cursor); err != nil {
+		return nil, errors.New(errors.TypeInvalidInput, ErrCodePaginationInvalid, "cursor is invalid")
+	}
+
+	return cursor, nil
+}
+
+func (cursor Cursor) String() string {
+	data, err := json.Marshal(cursor)
+	if err != nil {
+		return ""
+	}
+
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// Apply orders the query by creation time and id and selects the page after the cursor. It selects one more item
+// than the limit to find out whether there is a next page, see Paginate.
+func (pagination *Pagination) Apply(query *bun.SelectQuery) *bun.SelectQuery {
+	query = query.OrderExpr("?TableAlias.created_at ASC, ?TableAlias.id ASC")
+	if pagination.Cursor != nil {
+		query = query.Where("(?TableAlias.created_at > ? OR (?TableAlias.created_at = ? AND ?TableAlias.id > ?))", pagination.Cursor.CreatedAt, pagination.Cursor.CreatedAt, pagination.Cursor.ID)
+	}
+
+	return query.Limit(pagination.Limit + 1)
+}
+
+// Paginate trims the items selected by a query to which the pagination was applied and returns the cursor of the
+// next page, which is empty on the last page.
+func Paginate[T any](pagination *Pagination, items []T, cursor func(T) Cursor) ([]T, string) {
+	if len(items) <= pagination.Limit {
+		return items, ""
+	}
+
+	items = items[:pagination.Limit]
+	return items, cursor(items[len(items)-1]).String()
+}
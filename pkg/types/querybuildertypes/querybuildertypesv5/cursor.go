@@ -0,0 +1,98 @@
+package querybuildertypesv5
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strconv"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+)
+
+var (
+	ErrCodeCursorInvalid = errors.MustNewCode("cursor_invalid")
+)
+
+// Cursor is the position of a log in the list of logs ordered by timestamp and id. The page of a cursor holds the
+// logs strictly after it in its direction, hence the logs ingested meanwhile never shift the pages and the cursors
+// stay valid.
+type Cursor struct {
+	// Timestamp is the timestamp of the log in epoch nanoseconds.
+	Timestamp uint64 `json:"t"`
+	// ID is the id of the log, it orders the logs of the same timestamp.
+	ID string `json:"i"`
+	// Previous selects the page before the log in the order of the list rather than the page after it.
+	Previous bool `json:"p,omitempty"`
+}
+
+func NewCursorFromString(input string) (*Cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(input)
+	if err != nil {
+		return nil, errors.New(errors.TypeInvalidInput, ErrCodeCursorInvalid, "cursor is invalid")
+	}
+
+	cursor := new(Cursor)
+	if err := json.Unmarshal(data, cursor); err != nil {
+		return nil, errors.New(errors.TypeInvalidInput, ErrCodeCursorInvalid, "cursor is invalid")
+	}
+
+	return cursor, nil
+}
+
+// NewLogsCursor returns the cursor of a page of the logs in the given order. The cursor is nil for the logs which are
+// not ordered by timestamp and id, and for the cursors holding the epoch milliseconds of a log, as returned for such
+// orders and before the cursors on timestamp and id. The page of these is narrowed to the logs after their millisecond.
+func NewLogsCursor(input string, order []OrderBy) (*Cursor, error) {
+	if input == "" || !IsCursorOrder(order) || isMillisecondCursor(input) {
+		return nil, nil
+	}
+
+	return NewCursorFromString(input)
+}
+
+func isMillisecondCursor(input string) bool {
+	data, err := base64.StdEncoding.DecodeString(input)
+	if err != nil {
+		return false
+	}
+
+	_, err = strconv.ParseInt(string(data), 10, 64)
+	return err == nil
+}
+
+func (cursor Cursor) String() string {
+	data, err := json.Marshal(cursor)
+	if err != nil {
+		return ""
+	}
+
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// IsCursorOrder returns whether the list is ordered by timestamp and id in the same direction, the only order the
+// cursors of the logs page through.
+func IsCursorOrder(order []OrderBy) bool {
+	return len(order) == 2 &&
+		order[0].Key.Name == "timestamp" &&
+		order[1].Key.Name == "id" &&
+		order[0].Direction == order[1].Direction
+}
+
+// Order returns the order in which the logs of the page of the cursor are selected, the order of the list reversed
+// for a previous page so that the logs nearest to the cursor are selected first.
+func (cursor Cursor) Order(order []OrderBy) []OrderBy {
+	if !cursor.Previous {
+		return order
+	}
+
+	reversed := make([]OrderBy, len(order))
+	for i, orderBy := range order {
+		reversed[i] = orderBy
+		if orderBy.Direction == OrderDirectionDesc {
+			reversed[i].Direction = OrderDirectionAsc
+		} else {
+			reversed[i].Direction = OrderDirectionDesc
+		}
+	}
+
+	return reversed
+}
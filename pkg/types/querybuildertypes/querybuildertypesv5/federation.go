@@ -0,0 +1,105 @@
+package querybuildertypesv5
+
+import (
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/types/telemetrytypes"
+)
+
+// FederatedQueryRequest is a request querying several signals over the same time range and constrained by the same
+// filter. The builder queries of every signal are grouped by the shared dimensions and their results are correlated
+// by the values of these dimensions.
+type FederatedQueryRequest struct {
+	// Start is the start time of the queries in epoch milliseconds.
+	Start uint64 `json:"start"`
+	// End is the end time of the queries in epoch milliseconds.
+	End uint64 `json:"end"`
+	// Filter is the filter shared by the queries, it is combined with the filter of every query.
+	Filter *Filter `json:"filter,omitempty"`
+	// GroupBy are the shared dimensions, every query is grouped by them and the results are keyed by their values.
+	GroupBy []GroupByKey `json:"groupBy"`
+	// Queries are the builder queries of the signals. They must not be grouped by anything but the shared dimensions.
+	Queries []QueryEnvelope `json:"queries"`
+	// NoCache is a flag to disable caching for the request.
+	NoCache bool `json:"noCache,omitempty"`
+}
+
+func (req *FederatedQueryRequest) Validate() error {
+	if req.Start >= req.End {
+		return errors.NewInvalidInputf(errors.CodeInvalidInput, "start must be before end")
+	}
+
+	if len(req.GroupBy) == 0 {
+		return errors.NewInvalidInputf(errors.CodeInvalidInput, "at least one shared dimension is required to correlate the results")
+	}
+
+	if len(req.Queries) == 0 {
+		return errors.NewInvalidInputf(errors.CodeInvalidInput, "at least one query is required")
+	}
+
+	names := make(map[string]struct{}, len(req.Queries))
+	for _, query := range req.Queries {
+		if query.Type != QueryTypeBuilder {
+			return errors.NewInvalidInputf(errors.CodeInvalidInput, "only the builder queries can be federated, got %q", query.Type.StringValue())
+		}
+
+		var name string
+		var groupBy []GroupByKey
+		switch spec := query.Spec.(type) {
+		case QueryBuilderQuery[TraceAggregation]:
+			name, groupBy = spec.Name, spec.GroupBy
+		case QueryBuilderQuery[LogAggregation]:
+			name, groupBy = spec.Name, spec.GroupBy
+		case QueryBuilderQuery[MetricAggregation]:
+			name, groupBy = spec.Name, spec.GroupBy
+		default:
+			return errors.NewInvalidInputf(errors.CodeInvalidInput, "unsupported builder spec type %T", query.Spec)
+		}
+
+		if name == "" {
+			return errors.NewInvalidInputf(errors.CodeInvalidInput, "the name of every query is required")
+		}
+
+		if _, ok := names[name]; ok {
+			return errors.NewInvalidInputf(errors.CodeInvalidInput, "duplicate query name %q", name)
+		}
+		names[name] = struct{}{}
+
+		if len(groupBy) > 0 {
+			return errors.NewInvalidInputf(errors.CodeInvalidInput, "query %q must not have its own group by, it is grouped by the shared dimensions", name)
+		}
+	}
+
+	return nil
+}
+
+// FederatedQueryResponse is the result of a federated query. The signals are queried independently, a signal failing
+// to be queried is reported in its status and its queries are absent from the rows.
+type FederatedQueryResponse struct {
+	// Dimensions are the names of the shared dimensions.
+	Dimensions []string `json:"dimensions"`
+	// Rows are the results of the queries correlated by the values of the shared dimensions.
+	Rows []*FederatedRow `json:"rows"`
+	// Signals are the statuses of the queries of every signal.
+	Signals []*FederatedSignalStatus `json:"signals"`
+	Meta    ExecStats                `json:"meta"`
+}
+
+// FederatedRow is the results of the queries for a set of values of the shared dimensions.
+type FederatedRow struct {
+	// Key are the values of the shared dimensions by name.
+	Key map[string]any `json:"key"`
+	// Values are the values of the aggregations of every query by query name, in the order of the aggregations. A
+	// query without results for the key is absent.
+	Values map[string][]any `json:"values"`
+}
+
+// FederatedSignalStatus is the status of the queries of a signal.
+type FederatedSignalStatus struct {
+	Signal telemetrytypes.Signal `json:"signal"`
+	// Queries are the names of the queries of the signal.
+	Queries []string `json:"queries"`
+	// Error is the error of the signal, it is empty when its queries succeeded.
+	Error    string    `json:"error,omitempty"`
+	Warnings []string  `json:"warnings,omitempty"`
+	Meta     ExecStats `json:"meta"`
+}
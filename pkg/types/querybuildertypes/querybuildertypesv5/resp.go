@@ -14,11 +14,37 @@ type QueryRangeResponse struct {
 	Type RequestType `json:"type"`
 	Data any         `json:"data"`
 	Meta ExecStats   `json:"meta"`
+	// Estimate is the estimate of the data read by the queries, nil when the queries are not estimated. It is
+	// returned in the headers of the response.
+	Estimate *QueryEstimate `json:"-"`
+}
+
+// QueryEstimate is the estimate of the data read by the queries of a request, computed before running them.
+type QueryEstimate struct {
+	Rows  uint64
+	Bytes uint64
 }
 
 type TimeSeriesData struct {
 	QueryName    string               `json:"queryName"`
 	Aggregations []*AggregationBucket `json:"aggregations"`
+	// Exemplars are the exemplars of the histogram buckets selected by a promql query.
+	Exemplars []*ExemplarSeries `json:"exemplars,omitempty"`
+}
+
+// ExemplarSeries are the exemplars of a series, linking some of its samples to the traces they were recorded in.
+type ExemplarSeries struct {
+	Labels    []*Label    `json:"labels"`
+	Exemplars []*Exemplar `json:"exemplars"`
+}
+
+type Exemplar struct {
+	Timestamp int64   `json:"timestamp"`
+	Value     float64 `json:"value"`
+	TraceID   string  `json:"traceId,omitempty"`
+	SpanID    string  `json:"spanId,omitempty"`
+	// Labels are the labels of the exemplar other than its trace and span ids.
+	Labels map[string]string `json:"labels,omitempty"`
 }
 
 type AggregationBucket struct {
@@ -134,8 +160,11 @@ type ScalarData struct {
 }
 
 type RawData struct {
-	QueryName  string    `json:"queryName"`
-	NextCursor string    `json:"nextCursor"`
+	QueryName  string `json:"queryName"`
+	NextCursor string `json:"nextCursor"`
+	// PrevCursor is the cursor of the page before the rows, it is only set for the logs. It is set even when there is
+	// no such page yet so that the logs ingested later can be paged through.
+	PrevCursor string    `json:"prevCursor,omitempty"`
 	Rows       []*RawRow `json:"rows"`
 }
 
@@ -0,0 +1,186 @@
+package rbactypes
+
+import (
+	"regexp"
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+	"github.com/SigNoz/signoz/pkg/types"
+	"github.com/SigNoz/signoz/pkg/types/authtypes"
+	"github.com/SigNoz/signoz/pkg/valuer"
+	"github.com/uptrace/bun"
+)
+
+const (
+	// Wildcard matches every resource or every action.
+	Wildcard string = "*"
+)
+
+const (
+	ActionRead  string = "read"
+	ActionWrite string = "write"
+)
+
+var (
+	ErrCodeRoleAlreadyExists = errors.MustNewCode("rbac_role_already_exists")
+	ErrCodeRoleNotFound      = errors.MustNewCode("rbac_role_not_found")
+	ErrCodeBindingNotFound   = errors.MustNewCode("rbac_binding_not_found")
+	ErrCodeBindingInvalid    = errors.MustNewCode("rbac_binding_invalid")
+	ErrCodePermissionDenied  = errors.MustNewCode("rbac_permission_denied")
+)
+
+var (
+	EffectAllow = Effect{valuer.NewString("allow")}
+	EffectDeny  = Effect{valuer.NewString("deny")}
+)
+
+var (
+	nameRegex = regexp.MustCompile(`^[a-z0-9][a-z0-9_.-]{0,63}$`)
+)
+
+// BuiltinPermissions are the permissions granted by the built-in roles, which every user holds in addition to the
+// roles bound to them.
+var BuiltinPermissions = map[types.Role][]Permission{
+	types.RoleAdmin:  {{Resource: Wildcard, Action: Wildcard, Effect: EffectAllow}},
+	types.RoleEditor: {{Resource: Wildcard, Action: ActionRead, Effect: EffectAllow}, {Resource: Wildcard, Action: ActionWrite, Effect: EffectAllow}},
+	types.RoleViewer: {{Resource: Wildcard, Action: ActionRead, Effect: EffectAllow}},
+}
+
+type Effect struct{ valuer.String }
+
+// Permission allows or denies an action on a resource. The resource and the action may be the wildcard.
+type Permission struct {
+	Resource string `json:"resource"`
+	Action   string `json:"action"`
+	Effect   Effect `json:"effect"`
+}
+
+func (permission Permission) Validate() error {
+	if permission.Resource != Wildcard && !nameRegex.MatchString(permission.Resource) {
+		return errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "resource must be %s or match %s, got %q", Wildcard, nameRegex.String(), permission.Resource)
+	}
+
+	if permission.Action != Wildcard && !nameRegex.MatchString(permission.Action) {
+		return errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "action must be %s or match %s, got %q", Wildcard, nameRegex.String(), permission.Action)
+	}
+
+	if permission.Effect != EffectAllow && permission.Effect != EffectDeny {
+		return errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "effect must be one of %s or %s, got %q", EffectAllow.StringValue(), EffectDeny.StringValue(), permission.Effect.StringValue())
+	}
+
+	return nil
+}
+
+func (permission Permission) matches(resource string, action string) bool {
+	return (permission.Resource == Wildcard || permission.Resource == resource) && (permission.Action == Wildcard || permission.Action == action)
+}
+
+// Role is a bundle of permissions of an organization.
+type Role struct {
+	bun.BaseModel `bun:"table:rbac_role"`
+
+	types.Identifiable
+	types.TimeAuditable
+	OrgID       valuer.UUID  `json:"orgId" bun:"org_id,type:text,notnull"`
+	Name        string       `json:"name" bun:"name,type:text,notnull"`
+	Description string       `json:"description" bun:"description,type:text"`
+	Permissions []Permission `json:"permissions" bun:"permissions,type:text,notnull"`
+}
+
+type PostableRole struct {
+	Name        string       `json:"name"`
+	Description string       `json:"description"`
+	Permissions []Permission `json:"permissions"`
+}
+
+func NewRole(orgID valuer.UUID, name string, description string, permissions []Permission) (*Role, error) {
+	if !nameRegex.MatchString(name) {
+		return nil, errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "name must match %s, got %q", nameRegex.String(), name)
+	}
+
+	if len(permissions) == 0 {
+		return nil, errors.New(errors.TypeInvalidInput, errors.CodeInvalidInput, "permissions must not be empty")
+	}
+
+	for _, permission := range permissions {
+		if err := permission.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	now := time.Now()
+	return &Role{
+		Identifiable:  types.Identifiable{ID: valuer.GenerateUUID()},
+		TimeAuditable: types.TimeAuditable{CreatedAt: now, UpdatedAt: now},
+		OrgID:         orgID,
+		Name:          name,
+		Description:   description,
+		Permissions:   permissions,
+	}, nil
+}
+
+// Binding binds a role to a subject of an organization.
+type Binding struct {
+	bun.BaseModel `bun:"table:rbac_binding"`
+
+	types.Identifiable
+	types.TimeAuditable
+	OrgID   valuer.UUID `json:"orgId" bun:"org_id,type:text,notnull"`
+	RoleID  valuer.UUID `json:"roleId" bun:"role_id,type:text,notnull"`
+	Subject string      `json:"subject" bun:"subject,type:text,notnull"`
+}
+
+type PostableBinding struct {
+	RoleID  valuer.UUID `json:"roleId"`
+	Subject string      `json:"subject"`
+}
+
+func NewBinding(orgID valuer.UUID, roleID valuer.UUID, subject string) (*Binding, error) {
+	if subject == "" {
+		return nil, errors.New(errors.TypeInvalidInput, errors.CodeInvalidInput, "subject must not be empty")
+	}
+
+	now := time.Now()
+	return &Binding{
+		Identifiable:  types.Identifiable{ID: valuer.GenerateUUID()},
+		TimeAuditable: types.TimeAuditable{CreatedAt: now, UpdatedAt: now},
+		OrgID:         orgID,
+		RoleID:        roleID,
+		Subject:       subject,
+	}, nil
+}
+
+// Subject is the user whose access is authorized, along with their built-in role.
+type Subject struct {
+	OrgID valuer.UUID
+	ID    string
+	Role  types.Role
+}
+
+func NewSubjectFromClaims(claims authtypes.Claims) (Subject, error) {
+	orgID, err := valuer.NewUUID(claims.OrgID)
+	if err != nil {
+		return Subject{}, errors.Wrapf(err, errors.TypeUnauthenticated, errors.CodeUnauthenticated, "orgId is not a valid uuid")
+	}
+
+	return Subject{OrgID: orgID, ID: claims.UserID, Role: claims.Role}, nil
+}
+
+// Evaluate evaluates the permissions for the action on the resource with the deny-overrides order: a matching deny
+// wins over any matching allow, and the action is denied when no permission matches.
+func Evaluate(permissions []Permission, resource string, action string) bool {
+	allowed := false
+	for _, permission := range permissions {
+		if !permission.matches(resource, action) {
+			continue
+		}
+
+		if permission.Effect == EffectDeny {
+			return false
+		}
+
+		allowed = true
+	}
+
+	return allowed
+}
@@ -0,0 +1,50 @@
+package rbactypes
+
+import (
+	"testing"
+
+	"github.com/SigNoz/signoz/pkg/valuer"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvaluate(t *testing.T) {
+	permissions := []Permission{
+		{Resource: Wildcard, Action: ActionRead, Effect: EffectAllow},
+		{Resource: "alerts", Action: Wildcard, Effect: EffectAllow},
+		{Resource: "billing", Action: Wildcard, Effect: EffectDeny},
+	}
+
+	testCases := []struct {
+		name     string
+		resource string
+		action   string
+		expected bool
+	}{
+		{name: "WildcardResource", resource: "dashboards", action: ActionRead, expected: true},
+		{name: "WildcardAction", resource: "alerts", action: ActionWrite, expected: true},
+		{name: "NoMatch", resource: "dashboards", action: ActionWrite, expected: false},
+		{name: "DenyOverridesAllow", resource: "billing", action: ActionRead, expected: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, Evaluate(permissions, tc.resource, tc.action))
+		})
+	}
+
+	assert.False(t, Evaluate(nil, "dashboards", ActionRead))
+}
+
+func TestNewRole(t *testing.T) {
+	_, err := NewRole(valuer.GenerateUUID(), "oncall", "", []Permission{{Resource: "alerts", Action: ActionWrite, Effect: EffectAllow}})
+	assert.NoError(t, err)
+
+	_, err = NewRole(valuer.GenerateUUID(), "On Call", "", []Permission{{Resource: "alerts", Action: ActionWrite, Effect: EffectAllow}})
+	assert.Error(t, err)
+
+	_, err = NewRole(valuer.GenerateUUID(), "oncall", "", nil)
+	assert.Error(t, err)
+
+	_, err = NewRole(valuer.GenerateUUID(), "oncall", "", []Permission{{Resource: "alerts", Action: ActionWrite}})
+	assert.Error(t, err)
+}
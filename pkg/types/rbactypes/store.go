@@ -0,0 +1,20 @@
+package rbactypes
+
+import (
+	"context"
+
+	"github.com/SigNoz/signoz/pkg/valuer"
+)
+
+type Store interface {
+	CreateRole(context.Context, *Role) error
+	GetRole(context.Context, valuer.UUID, valuer.UUID) (*Role, error)
+	ListRoles(context.Context, valuer.UUID) ([]*Role, error)
+	// DeleteRole deletes the role along with its bindings.
+	DeleteRole(context.Context, valuer.UUID, valuer.UUID) error
+	CreateBinding(context.Context, *Binding) error
+	ListBindings(context.Context, valuer.UUID) ([]*Binding, error)
+	DeleteBinding(context.Context, valuer.UUID, valuer.UUID) error
+	// ListRolesBySubject lists the roles bound to the subject.
+	ListRolesBySubject(context.Context, valuer.UUID, string) ([]*Role, error)
+}
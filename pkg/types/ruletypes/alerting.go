@@ -116,6 +116,8 @@ type RuleCondition struct {
 	SelectedQuery     string             `json:"selectedQueryName,omitempty"`
 	RequireMinPoints  bool               `yaml:"requireMinPoints,omitempty" json:"requireMinPoints,omitempty"`
 	RequiredNumPoints int                `yaml:"requiredNumPoints,omitempty" json:"requiredNumPoints,omitempty"`
+	// Windows replaces the op, the target and the match type of the condition with conditions over several windows.
+	Windows *WindowCondition `yaml:"windows,omitempty" json:"windows,omitempty"`
 }
 
 func (rc *RuleCondition) GetSelectedQueryName() string {
@@ -165,7 +167,7 @@ func (rc *RuleCondition) IsValid() bool {
 		return false
 	}
 
-	if rc.QueryType() == v3.QueryTypeBuilder {
+	if rc.QueryType() == v3.QueryTypeBuilder && rc.Windows == nil {
 		if rc.Target == nil {
 			return false
 		}
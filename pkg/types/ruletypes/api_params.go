@@ -9,6 +9,7 @@ import (
 
 	"github.com/SigNoz/signoz/pkg/query-service/model"
 	v3 "github.com/SigNoz/signoz/pkg/query-service/model/v3"
+	"github.com/SigNoz/signoz/pkg/types/alertmanagertypes"
 	"github.com/pkg/errors"
 	"go.uber.org/multierr"
 
@@ -62,6 +63,10 @@ type PostableRule struct {
 
 	PreferredChannels []string `json:"preferredChannels,omitempty"`
 
+	// NotificationSettings overrides the timings of the notifications of the rule, for all its channels and for each
+	// of them.
+	NotificationSettings *alertmanagertypes.NotificationSettings `json:"notificationSettings,omitempty"`
+
 	Version string `json:"version,omitempty"`
 
 	// legacy
@@ -215,7 +220,15 @@ func (r *PostableRule) Validate() error {
 		errs = append(errs, errors.Errorf("all queries are disabled in rule condition"))
 	}
 
-	if r.RuleType == RuleTypeThreshold {
+	if r.RuleCondition.Windows != nil {
+		if r.RuleType == RuleTypeAnomaly {
+			errs = append(errs, errors.Errorf("rule condition windows are not supported by anomaly rules"))
+		}
+
+		if err := r.RuleCondition.Windows.Validate(); err != nil {
+			errs = append(errs, errors.Wrap(err, "invalid rule condition windows"))
+		}
+	} else if r.RuleType == RuleTypeThreshold {
 		if r.RuleCondition.Target == nil {
 			errs = append(errs, errors.Errorf("rule condition missing the threshold"))
 		}
@@ -290,6 +303,8 @@ func testTemplateParsing(rl *PostableRule) (errs []error) {
 // GettableRules has info for all stored rules.
 type GettableRules struct {
 	Rules []*GettableRule `json:"rules"`
+	// NextCursor is the cursor of the next page when the rules are paginated, it is empty on the last page.
+	NextCursor string `json:"nextCursor,omitempty"`
 }
 
 // GettableRule has info for an alerting rules.
@@ -0,0 +1,33 @@
+package ruletypes
+
+import (
+	"context"
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+// StorableIdempotencyKey is the idempotency key of a rule creation. The rule id is empty while the rule is being
+// created.
+type StorableIdempotencyKey struct {
+	bun.BaseModel `bun:"table:rule_idempotency_key"`
+
+	OrgID     string    `bun:"org_id,pk,type:text"`
+	Key       string    `bun:"idempotency_key,pk,type:text"`
+	BodyHash  string    `bun:"body_hash,type:text,notnull"`
+	RuleID    string    `bun:"rule_id,type:text,notnull"`
+	CreatedAt time.Time `bun:"created_at,notnull"`
+}
+
+type IdempotencyKeyStore interface {
+	// Claim records the key unless the same key of the organization was recorded after expiredBefore, in which
+	// case the recorded key is returned with claimed false. The keys of the organization recorded before
+	// expiredBefore are deleted.
+	Claim(ctx context.Context, key *StorableIdempotencyKey, expiredBefore time.Time) (recorded *StorableIdempotencyKey, claimed bool, err error)
+
+	// SetRuleID sets the id of the rule created for the key.
+	SetRuleID(ctx context.Context, orgID string, key string, ruleID string) error
+
+	// Delete deletes the key.
+	Delete(ctx context.Context, orgID string, key string) error
+}
@@ -18,6 +18,10 @@ type Sample struct {
 	Metric labels.Labels
 
 	IsMissing bool
+
+	// Threshold is the threshold the sample was matched against when it is not the target of the rule, such as the
+	// threshold of a window.
+	Threshold *float64
 }
 
 func (s Sample) String() string {
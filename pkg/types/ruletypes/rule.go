@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"strings"
+	"time"
 
 	"github.com/SigNoz/signoz/pkg/types"
 	"github.com/SigNoz/signoz/pkg/valuer"
@@ -20,6 +21,28 @@ type Rule struct {
 	OrgID   string `bun:"org_id,type:text"`
 }
 
+const (
+	// TopicRuleCreated is the topic of the outbox events published on the creation of a rule.
+	TopicRuleCreated string = "rule.created"
+)
+
+// RuleCreatedEvent is the payload of the outbox events published on the creation of a rule.
+type RuleCreatedEvent struct {
+	ID        string          `json:"id"`
+	CreatedBy string          `json:"createdBy"`
+	CreatedAt time.Time       `json:"createdAt"`
+	Rule      json.RawMessage `json:"rule"`
+}
+
+func NewRuleCreatedEvent(rule *Rule) *RuleCreatedEvent {
+	return &RuleCreatedEvent{
+		ID:        rule.ID.StringValue(),
+		CreatedBy: rule.CreatedBy,
+		CreatedAt: rule.CreatedAt,
+		Rule:      json.RawMessage(rule.Data),
+	}
+}
+
 func NewStatsFromRules(rules []*Rule) map[string]any {
 	stats := make(map[string]any)
 	for _, rule := range rules {
@@ -52,5 +75,6 @@ type RuleStore interface {
 	EditRule(context.Context, *Rule, func(context.Context) error) error
 	DeleteRule(context.Context, valuer.UUID, func(context.Context) error) error
 	GetStoredRules(context.Context, string) ([]*Rule, error)
+	GetStoredRulesPage(context.Context, string, *types.Pagination) ([]*Rule, error)
 	GetStoredRule(context.Context, valuer.UUID) (*Rule, error)
 }
@@ -0,0 +1,82 @@
+package ruletypes
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	// MaxRuleValidationSamples is the maximum number of samples returned by the validation of a rule.
+	MaxRuleValidationSamples int = 10
+)
+
+// RuleValidation is the result of the validation of a rule before it is saved. The rule is linted, the metrics and
+// labels its queries reference are looked up and the rule is evaluated once, without sending any notification.
+type RuleValidation struct {
+	// Valid is true when the rule has no errors, it may still have warnings.
+	Valid bool `json:"valid"`
+	// Errors are the issues preventing the rule from working, such as a query which cannot be parsed.
+	Errors []RuleValidationIssue `json:"errors"`
+	// Warnings are the issues which may prevent the rule from firing, such as a label no series has.
+	Warnings []RuleValidationIssue `json:"warnings"`
+	// EvaluatedAt is the time the rule was evaluated at, it is zero when the rule was not evaluated.
+	EvaluatedAt time.Time `json:"evaluatedAt,omitempty"`
+	// Firing is the number of series which would fire when the rule is evaluated.
+	Firing int `json:"firing"`
+	// Samples are the first series which would fire, at most MaxRuleValidationSamples.
+	Samples []RuleValidationSample `json:"samples"`
+}
+
+// RuleValidationIssue is an error or a warning of the validation of a rule.
+type RuleValidationIssue struct {
+	// Query is the name of the query of the issue, it is empty when the issue is about the whole rule.
+	Query string `json:"query,omitempty"`
+	// Message describes the issue.
+	Message string `json:"message"`
+}
+
+// RuleValidationSample is a series which would fire when the rule is evaluated.
+type RuleValidationSample struct {
+	Labels map[string]string `json:"labels"`
+	// Value is the value compared against the target of the rule.
+	Value float64 `json:"value"`
+	// Timestamp is the timestamp of the value in milliseconds.
+	Timestamp int64 `json:"timestamp"`
+	// Missing is true when the series fires because the data is absent.
+	Missing bool `json:"missing,omitempty"`
+}
+
+func NewRuleValidation() *RuleValidation {
+	return &RuleValidation{
+		Valid:    true,
+		Errors:   []RuleValidationIssue{},
+		Warnings: []RuleValidationIssue{},
+		Samples:  []RuleValidationSample{},
+	}
+}
+
+// AddError adds an error about the query, or about the whole rule when the query is empty.
+func (validation *RuleValidation) AddError(query string, format string, args ...any) {
+	validation.Valid = false
+	validation.Errors = append(validation.Errors, RuleValidationIssue{Query: query, Message: fmt.Sprintf(format, args...)})
+}
+
+// AddWarning adds a warning about the query, or about the whole rule when the query is empty.
+func (validation *RuleValidation) AddWarning(query string, format string, args ...any) {
+	validation.Warnings = append(validation.Warnings, RuleValidationIssue{Query: query, Message: fmt.Sprintf(format, args...)})
+}
+
+// AddSample counts a firing series and keeps it as a sample while there are less than MaxRuleValidationSamples.
+func (validation *RuleValidation) AddSample(sample Sample) {
+	validation.Firing++
+	if len(validation.Samples) >= MaxRuleValidationSamples {
+		return
+	}
+
+	validation.Samples = append(validation.Samples, RuleValidationSample{
+		Labels:    sample.Metric.Map(),
+		Value:     sample.V,
+		Timestamp: sample.T,
+		Missing:   sample.IsMissing,
+	})
+}
@@ -0,0 +1,101 @@
+package ruletypes
+
+import (
+	"time"
+
+	"github.com/SigNoz/signoz/pkg/errors"
+)
+
+type WindowsOp string
+
+const (
+	// WindowsOpAnd fires when all the conditions fire.
+	WindowsOpAnd WindowsOp = "and"
+	// WindowsOpOr fires when any of the conditions fires.
+	WindowsOpOr WindowsOp = "or"
+)
+
+// WindowCondition is a condition of a rule evaluated over windows of different durations, such as the multi-window
+// multi-burn-rate conditions of the SLOs. A condition either combines other conditions with an op, or compares the
+// points of a window with its own target. The windows of a series end at its last point, hence the query of the rule
+// is run once over the longest window and every window is evaluated over the points it covers.
+//
+// For example, the fast and slow burns of an SLO fire on (1h and 5m above 14.4) or (6h and 30m above 6).
+type WindowCondition struct {
+	// Op combines the conditions, one of and or or.
+	Op         WindowsOp         `yaml:"op,omitempty" json:"op,omitempty"`
+	Conditions []WindowCondition `yaml:"conditions,omitempty" json:"conditions,omitempty"`
+
+	// Window is the duration of the window of the condition.
+	Window    Duration  `yaml:"window,omitempty" json:"window,omitempty"`
+	CompareOp CompareOp `yaml:"compareOp,omitempty" json:"compareOp,omitempty"`
+	// MatchType is how the points of the window are matched against the target, it defaults to on average.
+	MatchType MatchType `yaml:"matchType,omitempty" json:"matchType,omitempty"`
+	Target    *float64  `yaml:"target,omitempty" json:"target,omitempty"`
+}
+
+// IsWindow returns whether the condition compares the points of a window, as opposed to combining other conditions.
+func (condition WindowCondition) IsWindow() bool {
+	return len(condition.Conditions) == 0
+}
+
+// MaxWindow returns the longest window of the condition.
+func (condition WindowCondition) MaxWindow() time.Duration {
+	if condition.IsWindow() {
+		return time.Duration(condition.Window)
+	}
+
+	var maxWindow time.Duration
+	for _, child := range condition.Conditions {
+		maxWindow = max(maxWindow, child.MaxWindow())
+	}
+
+	return maxWindow
+}
+
+// GetMatchType returns the match type of the window, on average when it is not set.
+func (condition WindowCondition) GetMatchType() MatchType {
+	if condition.MatchType == "" || condition.MatchType == MatchTypeNone {
+		return OnAverage
+	}
+
+	return condition.MatchType
+}
+
+func (condition WindowCondition) Validate() error {
+	if !condition.IsWindow() {
+		if condition.Op != WindowsOpAnd && condition.Op != WindowsOpOr {
+			return errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "windows op must be one of %s or %s, got %q", WindowsOpAnd, WindowsOpOr, condition.Op)
+		}
+
+		if condition.Window != 0 || condition.Target != nil {
+			return errors.New(errors.TypeInvalidInput, errors.CodeInvalidInput, "windows combining conditions must not have a window nor a target")
+		}
+
+		for _, child := range condition.Conditions {
+			if err := child.Validate(); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	if condition.Op != "" {
+		return errors.New(errors.TypeInvalidInput, errors.CodeInvalidInput, "windows op must not be set without conditions")
+	}
+
+	if condition.Window <= 0 {
+		return errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "window must be positive, got %s", time.Duration(condition.Window))
+	}
+
+	if condition.Target == nil {
+		return errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "window %s is missing the threshold", time.Duration(condition.Window))
+	}
+
+	if condition.CompareOp == "" || condition.CompareOp == CompareOpNone {
+		return errors.Newf(errors.TypeInvalidInput, errors.CodeInvalidInput, "window %s is missing the compare op", time.Duration(condition.Window))
+	}
+
+	return nil
+}
@@ -78,12 +78,18 @@ func (g *GoogleOAuthProvider) createIdentity(ctx context.Context, token *oauth2.
 		return identity, fmt.Errorf("oidc: unexpected hd claim %v", claims.HostedDomain)
 	}
 
+	var rawClaims map[string]any
+	if err := idToken.Claims(&rawClaims); err != nil {
+		return identity, fmt.Errorf("oidc: failed to decode claims: %v", err)
+	}
+
 	identity = &SSOIdentity{
 		UserID:        idToken.Subject,
 		Username:      claims.Username,
 		Email:         claims.Email,
 		EmailVerified: claims.EmailVerified,
 		ConnectorData: []byte(token.RefreshToken),
+		Claims:        rawClaims,
 	}
 
 	return identity, nil
@@ -0,0 +1,107 @@
+package ssotypes
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+	"golang.org/x/oauth2"
+)
+
+// OIDCConfig is the config of a generic OpenID Connect provider, such as Okta, Azure AD or Keycloak.
+type OIDCConfig struct {
+	// IssuerURL is the issuer of the provider, the endpoints of the provider are discovered from it.
+	IssuerURL    string `json:"issuerUrl"`
+	ClientID     string `json:"clientId"`
+	ClientSecret string `json:"clientSecret"`
+
+	// Scopes are the scopes requested along with openid, email and profile, such as groups for Okta.
+	Scopes []string `json:"scopes,omitempty"`
+}
+
+func (c *OIDCConfig) GetProvider(siteUrl *url.URL) (OAuthCallbackProvider, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	provider, err := oidc.NewProvider(ctx, c.IssuerURL)
+	if err != nil {
+		cancel()
+		return nil, fmt.Errorf("failed to get provider: %v", err)
+	}
+
+	// this is the url the provider will call after login completion
+	redirectURL := fmt.Sprintf("%s://%s/%s",
+		siteUrl.Scheme,
+		siteUrl.Host,
+		"api/v1/complete/oidc")
+
+	return &OIDCProvider{
+		OAuth2Config: &oauth2.Config{
+			ClientID:     c.ClientID,
+			ClientSecret: c.ClientSecret,
+			Endpoint:     provider.Endpoint(),
+			Scopes:       append([]string{oidc.ScopeOpenID, "email", "profile"}, c.Scopes...),
+			RedirectURL:  redirectURL,
+		},
+		Verifier: provider.Verifier(
+			&oidc.Config{ClientID: c.ClientID},
+		),
+		Cancel: cancel,
+	}, nil
+}
+
+// OIDCProvider is the callback provider of a generic OpenID Connect provider. The claims of the id token are returned
+// as they are, to be mapped to the user by the claims mapping of the auth domain.
+type OIDCProvider struct {
+	OAuth2Config *oauth2.Config
+	Verifier     *oidc.IDTokenVerifier
+	Cancel       context.CancelFunc
+}
+
+func (o *OIDCProvider) BuildAuthURL(state string) (string, error) {
+	return o.OAuth2Config.AuthCodeURL(state), nil
+}
+
+func (o *OIDCProvider) HandleCallback(r *http.Request) (identity *SSOIdentity, err error) {
+	q := r.URL.Query()
+	if errType := q.Get("error"); errType != "" {
+		return identity, &oauth2Error{errType, q.Get("error_description")}
+	}
+
+	token, err := o.OAuth2Config.Exchange(r.Context(), q.Get("code"))
+	if err != nil {
+		return identity, fmt.Errorf("oidc: failed to get token: %v", err)
+	}
+
+	rawIDToken, ok := token.Extra("id_token").(string)
+	if !ok {
+		return identity, errors.New("oidc: no id_token in token response")
+	}
+
+	idToken, err := o.Verifier.Verify(r.Context(), rawIDToken)
+	if err != nil {
+		return identity, fmt.Errorf("oidc: failed to verify ID Token: %v", err)
+	}
+
+	var rawClaims map[string]any
+	if err := idToken.Claims(&rawClaims); err != nil {
+		return identity, fmt.Errorf("oidc: failed to decode claims: %v", err)
+	}
+
+	email, _ := rawClaims["email"].(string)
+	emailVerified, _ := rawClaims["email_verified"].(bool)
+	username, _ := rawClaims["name"].(string)
+	preferredUsername, _ := rawClaims["preferred_username"].(string)
+
+	return &SSOIdentity{
+		UserID:            idToken.Subject,
+		Username:          username,
+		PreferredUsername: preferredUsername,
+		Email:             email,
+		EmailVerified:     emailVerified,
+		ConnectorData:     []byte(token.RefreshToken),
+		Claims:            rawClaims,
+	}, nil
+}
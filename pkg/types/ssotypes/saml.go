@@ -1,18 +1,65 @@
 package ssotypes
 
 import (
+	"context"
 	"crypto/x509"
 	"encoding/base64"
 	"encoding/pem"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/SigNoz/signoz/pkg/errors"
 	"github.com/SigNoz/signoz/pkg/query-service/constants"
+	"github.com/jonboulle/clockwork"
 	saml2 "github.com/russellhaering/gosaml2"
+	saml2types "github.com/russellhaering/gosaml2/types"
 	dsig "github.com/russellhaering/goxmldsig"
+	"github.com/uptrace/bun"
 )
 
+const (
+	// SamlClockSkew is the tolerated difference between the clocks of the identity provider and signoz when
+	// checking the validity window of the assertions.
+	SamlClockSkew = 3 * time.Minute
+)
+
+var (
+	ErrCodeSamlAssertionInvalid  = errors.MustNewCode("saml_assertion_invalid")
+	ErrCodeSamlAssertionReplayed = errors.MustNewCode("saml_assertion_replayed")
+)
+
+// StorableSamlAssertion is an assertion which was consumed. It is stored until the assertion expires so that the
+// assertion cannot be replayed on any replica.
+type StorableSamlAssertion struct {
+	bun.BaseModel `bun:"table:saml_assertion"`
+
+	// ID is the id of the assertion given by the identity provider.
+	ID        string    `bun:"id,pk,type:text"`
+	OrgID     string    `bun:"org_id,type:text,notnull"`
+	ExpiresAt time.Time `bun:"expires_at,notnull"`
+	CreatedAt time.Time `bun:"created_at,notnull"`
+}
+
+type SamlAssertionStore interface {
+	// Consume stores the assertion. It returns an error with code ErrCodeSamlAssertionReplayed if the assertion
+	// was already consumed and has not expired yet. Two concurrent consumptions of an assertion cannot both succeed.
+	Consume(context.Context, *StorableSamlAssertion) error
+}
+
+// skewedClock lags behind the real clock by SamlClockSkew. gosaml2 rejects the subject confirmations which expired
+// by its clock without any tolerance, the lag accepts them for SamlClockSkew longer, for example when the clock of
+// the identity provider is behind. The lag does not reject the assertions of identity providers whose clock is
+// ahead, as gosaml2 only reports the validity window of the conditions as a warning. That window is checked by
+// ValidateAssertion, which tolerates SamlClockSkew in both directions.
+type skewedClock struct {
+	clockwork.Clock
+}
+
+func (clock skewedClock) Now() time.Time {
+	return clock.Clock.Now().Add(-SamlClockSkew)
+}
+
 func LoadCertificateStore(certString string) (dsig.X509CertificateStore, error) {
 	certStore := &dsig.MemoryX509CertificateStore{
 		Roots: []*x509.Certificate{},
@@ -101,7 +148,41 @@ func PrepareRequest(issuer, acsUrl, audience, entity, idp, certString string) (*
 
 		IDPCertificateStore: certStore,
 		SPKeyStore:          randomKeyStore,
+		Clock:               dsig.NewFakeClock(skewedClock{Clock: clockwork.NewRealClock()}),
 	}
 
 	return sp, nil
 }
+
+// ValidateAssertion checks that the assertion has an id and is valid at the given time, tolerating SamlClockSkew
+// in both directions. It returns the time after which the assertion is rejected.
+func ValidateAssertion(assertion *saml2types.Assertion, now time.Time) (time.Time, error) {
+	if assertion.ID == "" {
+		return time.Time{}, errors.New(errors.TypeUnauthenticated, ErrCodeSamlAssertionInvalid, "saml assertion has no id")
+	}
+
+	if assertion.Conditions == nil {
+		return time.Time{}, errors.New(errors.TypeUnauthenticated, ErrCodeSamlAssertionInvalid, "saml assertion has no conditions")
+	}
+
+	notBefore, err := time.Parse(time.RFC3339, assertion.Conditions.NotBefore)
+	if err != nil {
+		return time.Time{}, errors.Wrapf(err, errors.TypeUnauthenticated, ErrCodeSamlAssertionInvalid, "saml assertion has an invalid NotBefore %q", assertion.Conditions.NotBefore)
+	}
+
+	notOnOrAfter, err := time.Parse(time.RFC3339, assertion.Conditions.NotOnOrAfter)
+	if err != nil {
+		return time.Time{}, errors.Wrapf(err, errors.TypeUnauthenticated, ErrCodeSamlAssertionInvalid, "saml assertion has an invalid NotOnOrAfter %q", assertion.Conditions.NotOnOrAfter)
+	}
+
+	if now.Add(SamlClockSkew).Before(notBefore) {
+		return time.Time{}, errors.Newf(errors.TypeUnauthenticated, ErrCodeSamlAssertionInvalid, "saml assertion is not valid before %s", notBefore.Format(time.RFC3339))
+	}
+
+	expiresAt := notOnOrAfter.Add(SamlClockSkew)
+	if !now.Before(expiresAt) {
+		return time.Time{}, errors.Newf(errors.TypeUnauthenticated, ErrCodeSamlAssertionInvalid, "saml assertion expired at %s", notOnOrAfter.Format(time.RFC3339))
+	}
+
+	return expiresAt, nil
+}
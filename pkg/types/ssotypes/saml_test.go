@@ -0,0 +1,62 @@
+package ssotypes
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+	saml2types "github.com/russellhaering/gosaml2/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newAssertion(id string, notBefore time.Time, notOnOrAfter time.Time) *saml2types.Assertion {
+	return &saml2types.Assertion{
+		ID: id,
+		Conditions: &saml2types.Conditions{
+			NotBefore:    notBefore.Format(time.RFC3339),
+			NotOnOrAfter: notOnOrAfter.Format(time.RFC3339),
+		},
+	}
+}
+
+func TestValidateAssertion(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+
+	testCases := []struct {
+		name      string
+		assertion *saml2types.Assertion
+		pass      bool
+	}{
+		{name: "Valid", assertion: newAssertion("id", now.Add(-time.Minute), now.Add(time.Minute)), pass: true},
+		{name: "NotBeforeWithinSkew", assertion: newAssertion("id", now.Add(time.Minute), now.Add(5*time.Minute)), pass: true},
+		{name: "NotOnOrAfterWithinSkew", assertion: newAssertion("id", now.Add(-5*time.Minute), now.Add(-time.Minute)), pass: true},
+		{name: "NotYetValid", assertion: newAssertion("id", now.Add(SamlClockSkew+time.Minute), now.Add(10*time.Minute)), pass: false},
+		{name: "Expired", assertion: newAssertion("id", now.Add(-10*time.Minute), now.Add(-SamlClockSkew)), pass: false},
+		{name: "MissingID", assertion: newAssertion("", now.Add(-time.Minute), now.Add(time.Minute)), pass: false},
+		{name: "MissingConditions", assertion: &saml2types.Assertion{ID: "id"}, pass: false},
+		{name: "InvalidNotBefore", assertion: &saml2types.Assertion{ID: "id", Conditions: &saml2types.Conditions{NotBefore: "abc", NotOnOrAfter: now.Format(time.RFC3339)}}, pass: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			expiresAt, err := ValidateAssertion(tc.assertion, now)
+			if !tc.pass {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			notOnOrAfter, err := time.Parse(time.RFC3339, tc.assertion.Conditions.NotOnOrAfter)
+			require.NoError(t, err)
+			assert.Equal(t, notOnOrAfter.Add(SamlClockSkew), expiresAt)
+		})
+	}
+}
+
+func TestSkewedClock(t *testing.T) {
+	now := time.Now()
+	clock := skewedClock{Clock: clockwork.NewFakeClockAt(now)}
+
+	assert.Equal(t, now.Add(-SamlClockSkew), clock.Now())
+}
@@ -18,6 +18,8 @@ type SSOIdentity struct {
 	Email             string
 	EmailVerified     bool
 	ConnectorData     []byte
+	// Claims are the claims of the id token, mapped to the user by the claims mapping of the auth provider.
+	Claims map[string]any
 }
 
 // OAuthCallbackProvider is an interface implemented by connectors which use an OAuth
@@ -40,6 +42,12 @@ type SamlConfig struct {
 	SamlEntity string `json:"samlEntity"`
 	SamlIdp    string `json:"samlIdp"`
 	SamlCert   string `json:"samlCert"`
+
+	// RoleAttribute is the name of the attribute of the assertions whose values are mapped to the role of the
+	// user. The role of the user is not managed by the identity provider when it is empty.
+	RoleAttribute string `json:"roleAttribute,omitempty"`
+	// RoleMapping maps the values of the role attribute to the roles of signoz.
+	RoleMapping map[string]string `json:"roleMapping,omitempty"`
 }
 
 // GoogleOauthConfig contains a generic config to support oauth
@@ -40,6 +40,7 @@ type UserStore interface {
 	GetUsersByEmail(ctx context.Context, email string) ([]*GettableUser, error)
 	GetUsersByRoleInOrg(ctx context.Context, orgID string, role Role) ([]*GettableUser, error)
 	ListUsers(ctx context.Context, orgID string) ([]*GettableUser, error)
+	ListUsersPage(ctx context.Context, orgID string, pagination *Pagination) ([]*GettableUser, error)
 	UpdateUser(ctx context.Context, orgID string, id string, user *User) (*User, error)
 	DeleteUser(ctx context.Context, orgID string, id string) error
 
@@ -81,6 +82,12 @@ type GettableUser struct {
 	Organization string `json:"organization"`
 }
 
+// GettableUsersPage is a page of the users of an organization.
+type GettableUsersPage struct {
+	Users      []*GettableUser `json:"users"`
+	NextCursor string          `json:"nextCursor,omitempty"`
+}
+
 type User struct {
 	bun.BaseModel `bun:"table:users"`
 
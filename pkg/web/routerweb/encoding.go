@@ -0,0 +1,206 @@
+package routerweb
+
+import (
+	"compress/gzip"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+const (
+	encodingBrotli string = "br"
+	encodingGzip   string = "gzip"
+)
+
+// precompressedEncoding is an encoding served from a file built next to the asset with the given extension.
+type precompressedEncoding struct {
+	encoding  string
+	extension string
+}
+
+// precompressedEncodings are in the order of preference of the server.
+var precompressedEncodings = []precompressedEncoding{
+	{encoding: encodingBrotli, extension: ".br"},
+	{encoding: encodingGzip, extension: ".gz"},
+}
+
+// incompressibleExtensions are the asset types which are already compressed and gain nothing from being compressed again.
+var incompressibleExtensions = map[string]struct{}{
+	".br":    {},
+	".gz":    {},
+	".zip":   {},
+	".png":   {},
+	".jpg":   {},
+	".jpeg":  {},
+	".gif":   {},
+	".webp":  {},
+	".avif":  {},
+	".woff":  {},
+	".woff2": {},
+	".mp4":   {},
+	".webm":  {},
+}
+
+// acceptedEncodings parses the Accept-Encoding header into the quality values of the encodings. An encoding
+// which is explicitly refused has a quality of 0.
+func acceptedEncodings(header string) map[string]float64 {
+	encodings := make(map[string]float64)
+	for _, part := range strings.Split(header, ",") {
+		name, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+
+		quality := 1.0
+		if key, value, ok := strings.Cut(strings.TrimSpace(params), "="); ok && strings.TrimSpace(key) == "q" {
+			if q, err := strconv.ParseFloat(strings.TrimSpace(value), 64); err == nil {
+				quality = q
+			}
+		}
+
+		encodings[name] = quality
+	}
+
+	return encodings
+}
+
+// accepts returns whether the encoding is acceptable to the client along with its quality.
+func accepts(encodings map[string]float64, encoding string) (float64, bool) {
+	if q, ok := encodings[encoding]; ok {
+		return q, q > 0
+	}
+
+	if q, ok := encodings["*"]; ok {
+		return q, q > 0
+	}
+
+	return 0, false
+}
+
+// serveFile serves the file at path, preferring a pre-compressed variant acceptable to the client and falling
+// back to compressing the file on the fly.
+func serveFile(rw http.ResponseWriter, req *http.Request, path string) {
+	rw.Header().Add("Vary", "Accept-Encoding")
+
+	ext := strings.ToLower(filepath.Ext(path))
+	if _, ok := incompressibleExtensions[ext]; ok {
+		http.ServeFile(rw, req, path)
+		return
+	}
+
+	encodings := acceptedEncodings(req.Header.Get("Accept-Encoding"))
+
+	var best *precompressedEncoding
+	var bestQuality float64
+	for i, precompressed := range precompressedEncodings {
+		quality, ok := accepts(encodings, precompressed.encoding)
+		if !ok || quality <= bestQuality {
+			continue
+		}
+
+		if fi, err := os.Stat(path + precompressed.extension); err == nil && !fi.IsDir() {
+			best, bestQuality = &precompressedEncodings[i], quality
+		}
+	}
+
+	if best != nil {
+		servePrecompressedFile(rw, req, path, best)
+		return
+	}
+
+	if _, ok := accepts(encodings, encodingGzip); ok {
+		gzipWriter := newGzipResponseWriter(rw)
+		defer gzipWriter.Close() //nolint:errcheck
+
+		// Ranges cannot be served over content compressed on the fly.
+		req.Header.Del("Range")
+		http.ServeFile(gzipWriter, req, path)
+		return
+	}
+
+	http.ServeFile(rw, req, path)
+}
+
+func servePrecompressedFile(rw http.ResponseWriter, req *http.Request, path string, precompressed *precompressedEncoding) {
+	file, err := os.Open(path + precompressed.extension)
+	if err != nil {
+		http.ServeFile(rw, req, path)
+		return
+	}
+	defer file.Close() //nolint:errcheck
+
+	fi, err := file.Stat()
+	if err != nil {
+		http.ServeFile(rw, req, path)
+		return
+	}
+
+	// The content type is the one of the asset, not of the compressed file.
+	if contentType := mime.TypeByExtension(filepath.Ext(path)); contentType != "" {
+		rw.Header().Set("Content-Type", contentType)
+	}
+	rw.Header().Set("Content-Encoding", precompressed.encoding)
+
+	http.ServeContent(rw, req, filepath.Base(path), fi.ModTime(), file)
+}
+
+// gzipResponseWriter compresses the body of 200 responses only, so that responses such as 304 or 416 are left
+// untouched.
+type gzipResponseWriter struct {
+	http.ResponseWriter
+	writer      *gzip.Writer
+	compress    bool
+	wroteHeader bool
+}
+
+func newGzipResponseWriter(rw http.ResponseWriter) *gzipResponseWriter {
+	return &gzipResponseWriter{ResponseWriter: rw}
+}
+
+func (writer *gzipResponseWriter) WriteHeader(statusCode int) {
+	if writer.wroteHeader {
+		return
+	}
+	writer.wroteHeader = true
+
+	if statusCode == http.StatusOK {
+		writer.compress = true
+		writer.Header().Del("Content-Length")
+		writer.Header().Set("Content-Encoding", encodingGzip)
+	}
+
+	writer.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (writer *gzipResponseWriter) Write(b []byte) (int, error) {
+	if !writer.wroteHeader {
+		writer.WriteHeader(http.StatusOK)
+	}
+
+	if !writer.compress {
+		return writer.ResponseWriter.Write(b)
+	}
+
+	if writer.writer == nil {
+		writer.writer = gzip.NewWriter(writer.ResponseWriter)
+	}
+
+	return writer.writer.Write(b)
+}
+
+func (writer *gzipResponseWriter) Close() error {
+	if !writer.compress {
+		return nil
+	}
+
+	// An empty body still has to be a valid gzip stream.
+	if writer.writer == nil {
+		writer.writer = gzip.NewWriter(writer.ResponseWriter)
+	}
+
+	return writer.writer.Close()
+}
@@ -0,0 +1,128 @@
+package routerweb
+
+import (
+	"compress/gzip"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/SigNoz/signoz/pkg/factory/factorytest"
+	"github.com/SigNoz/signoz/pkg/web"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAcceptedEncodings(t *testing.T) {
+	encodings := acceptedEncodings("gzip;q=0.8, br, identity;q=0, *;q=0.1")
+
+	q, ok := accepts(encodings, encodingBrotli)
+	assert.True(t, ok)
+	assert.Equal(t, 1.0, q)
+
+	q, ok = accepts(encodings, encodingGzip)
+	assert.True(t, ok)
+	assert.Equal(t, 0.8, q)
+
+	_, ok = accepts(encodings, "identity")
+	assert.False(t, ok)
+
+	_, ok = accepts(encodings, "zstd")
+	assert.True(t, ok)
+
+	_, ok = accepts(acceptedEncodings(""), encodingGzip)
+	assert.False(t, ok)
+}
+
+func TestServeHttpEncoding(t *testing.T) {
+	t.Parallel()
+
+	web, err := New(context.Background(), factorytest.NewSettings(), web.Config{Prefix: "/", Directory: filepath.Join("testdata")})
+	require.NoError(t, err)
+
+	read := func(t *testing.T, name string) []byte {
+		b, err := os.ReadFile(filepath.Join("testdata", name))
+		require.NoError(t, err)
+		return b
+	}
+
+	testCases := []struct {
+		name             string
+		path             string
+		acceptEncoding   string
+		expectedEncoding string
+		expectedBody     []byte
+		gunzip           bool
+	}{
+		{
+			name:             "PrefersBrotli",
+			path:             "/assets/main.js",
+			acceptEncoding:   "gzip, deflate, br",
+			expectedEncoding: "br",
+			expectedBody:     read(t, "assets/main.js.br"),
+		},
+		{
+			name:             "HonorsQuality",
+			path:             "/assets/main.js",
+			acceptEncoding:   "br;q=0.5, gzip",
+			expectedEncoding: "gzip",
+			expectedBody:     read(t, "assets/main.js.gz"),
+		},
+		{
+			name:             "Identity",
+			path:             "/assets/main.js",
+			acceptEncoding:   "",
+			expectedEncoding: "",
+			expectedBody:     read(t, "assets/main.js"),
+		},
+		{
+			name:             "GzipOnTheFly",
+			path:             "/does-not-exist",
+			acceptEncoding:   "gzip",
+			expectedEncoding: "gzip",
+			expectedBody:     read(t, indexFileName),
+			gunzip:           true,
+		},
+		{
+			name:             "SkipsIncompressible",
+			path:             "/assets/logo.png",
+			acceptEncoding:   "gzip, br",
+			expectedEncoding: "",
+			expectedBody:     read(t, "assets/logo.png"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tc.path, nil)
+			if tc.acceptEncoding != "" {
+				req.Header.Set("Accept-Encoding", tc.acceptEncoding)
+			}
+			rec := httptest.NewRecorder()
+
+			web.ServeHTTP(rec, req)
+
+			res := rec.Result()
+			assert.Equal(t, http.StatusOK, res.StatusCode)
+			assert.Equal(t, tc.expectedEncoding, res.Header.Get("Content-Encoding"))
+			assert.Equal(t, "Accept-Encoding", res.Header.Get("Vary"))
+
+			body := io.Reader(res.Body)
+			if tc.gunzip {
+				body, err = gzip.NewReader(res.Body)
+				require.NoError(t, err)
+			}
+
+			actual, err := io.ReadAll(body)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedBody, actual)
+
+			if tc.path == "/assets/main.js" {
+				assert.Contains(t, res.Header.Get("Content-Type"), "javascript")
+			}
+		})
+	}
+}
@@ -74,7 +74,7 @@ func (provider *provider) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
 	fi, err := os.Stat(path)
 	if os.IsNotExist(err) || fi.IsDir() {
 		// file does not exist or path is a directory, serve index.html
-		http.ServeFile(rw, req, filepath.Join(provider.config.Directory, indexFileName))
+		serveFile(rw, req, filepath.Join(provider.config.Directory, indexFileName))
 		return
 	}
 
@@ -86,6 +86,6 @@ func (provider *provider) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
 		return
 	}
 
-	// otherwise, use http.FileServer to serve the static file
-	http.FileServer(http.Dir(provider.config.Directory)).ServeHTTP(rw, req)
+	// otherwise, serve the static file, negotiating its encoding with the client
+	serveFile(rw, req, path)
 }
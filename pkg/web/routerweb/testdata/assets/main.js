@@ -0,0 +1 @@
+console.log("hello from the bundle");